@@ -155,6 +155,38 @@ func TestGenerateAStargateAppWithEmptyModule(t *testing.T) {
 		envtest.ExecShouldError(),
 	))
 
+	env.Must(env.Exec("create a module with custom stores and a capability",
+		step.NewSteps(step.New(
+			step.Exec(
+				"starport",
+				"s",
+				"module",
+				"stores",
+				"--transient-store",
+				"--memory-store",
+				"--capability",
+				"--require-registration",
+			),
+			step.Workdir(path),
+		)),
+	))
+
+	env.Must(env.Exec("should prevent creating an IBC module with a capability",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "module", "capibc", "--ibc", "--capability", "--require-registration"),
+			step.Workdir(path),
+		)),
+		envtest.ExecShouldError(),
+	))
+
+	env.Must(env.Exec("should prevent creating an IBC module with AutoCLI",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "module", "autocliibc", "--ibc", "--autocli", "--require-registration"),
+			step.Workdir(path),
+		)),
+		envtest.ExecShouldError(),
+	))
+
 	env.Must(env.Exec("create a module with dependencies",
 		step.NewSteps(step.New(
 			step.Exec(
@@ -170,6 +202,20 @@ func TestGenerateAStargateAppWithEmptyModule(t *testing.T) {
 		)),
 	))
 
+	env.Must(env.Exec("create a list in a module",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "list", "post", "title", "--module", "example"),
+			step.Workdir(path),
+		)),
+	))
+
+	env.Must(env.Exec("create a module depending on the keeper of a module of the app",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "module", "with_keeper_dep", "--dep", "example", "--require-registration"),
+			step.Workdir(path),
+		)),
+	))
+
 	env.Must(env.Exec("should prevent creating a module with invalid dependencies",
 		step.NewSteps(step.New(
 			step.Exec(
@@ -116,6 +116,21 @@ func TestGenerateAnAppWithStargateWithListAndVerify(t *testing.T) {
 		)),
 	))
 
+	env.Must(env.Exec("create a streamed list",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "list", "streamed", "title", "--stream"),
+			step.Workdir(path),
+		)),
+	))
+
+	env.Must(env.Exec("should prevent creating a streamed list with no interaction message",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "list", "nostream", "title", "--stream", "--no-message"),
+			step.Workdir(path),
+		)),
+		envtest.ExecShouldError(),
+	))
+
 	env.EnsureAppIsSteady(path)
 }
 
@@ -69,6 +69,28 @@ func TestGenerateAnAppWithMessage(t *testing.T) {
 		)),
 	))
 
+	env.Must(env.Exec("create a message with a sequence",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "message", "create-note", "title", "--sequence", "note", "--response", "hash"),
+			step.Workdir(path),
+		)),
+	))
+
+	env.Must(env.Exec("create a message with an existing sequence",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "message", "copy-note", "noteId:uint", "--sequence", "note", "--response", "id:uint"),
+			step.Workdir(path),
+		)),
+	))
+
+	env.Must(env.Exec("should prevent creating a message with a sequence and a non uint id",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "message", "move-note", "--sequence", "note", "--response", "id:string"),
+			step.Workdir(path),
+		)),
+		envtest.ExecShouldError(),
+	))
+
 	env.Must(env.Exec("create a custom field type",
 		step.NewSteps(step.New(
 			step.Exec("starport",
@@ -99,6 +121,35 @@ func TestGenerateAnAppWithMessage(t *testing.T) {
 		)),
 	))
 
+	env.Must(env.Exec("create a message with CLI flags",
+		step.NewSteps(step.New(
+			step.Exec(
+				"starport",
+				"s",
+				"message",
+				"do-flags",
+				"text",
+				"vote:int",
+				"amount:coin",
+				"customField:CustomType",
+				"--cli-flags",
+				"--cli-default",
+				"vote=10",
+				"--cli-default",
+				"amount=10token",
+			),
+			step.Workdir(path),
+		)),
+	))
+
+	env.Must(env.Exec("should prevent creating a message with an invalid CLI flag default value",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "message", "do-bad-flags", "vote:int", "--cli-flags", "--cli-default", "vote=ten"),
+			step.Workdir(path),
+		)),
+		envtest.ExecShouldError(),
+	))
+
 	env.Must(env.Exec("create a module",
 		step.NewSteps(step.New(
 			step.Exec("starport", "s", "module", "foo", "--require-registration"),
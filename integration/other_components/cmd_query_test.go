@@ -71,6 +71,31 @@ func TestGenerateAnAppWithQuery(t *testing.T) {
 		)),
 	))
 
+	env.Must(env.Exec("create a query with CLI flags",
+		step.NewSteps(step.New(
+			step.Exec(
+				"starport",
+				"s",
+				"query",
+				"baz",
+				"text",
+				"vote:int",
+				"--cli-flags",
+				"--cli-default",
+				"vote=10",
+			),
+			step.Workdir(path),
+		)),
+	))
+
+	env.Must(env.Exec("should prevent creating a query with a CLI flag of the SDK",
+		step.NewSteps(step.New(
+			step.Exec("starport", "s", "query", "qux", "height:int", "--cli-flags"),
+			step.Workdir(path),
+		)),
+		envtest.ExecShouldError(),
+	))
+
 	env.Must(env.Exec("create a custom field type",
 		step.NewSteps(step.New(
 			step.Exec("starport",
@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/goccy/go-yaml"
 	"github.com/imdario/mergo"
 
@@ -21,6 +25,13 @@ var (
 	ConfigFileNames = []string{"config.yml", "config.yaml"}
 )
 
+// defaultSnapshotsKeepRecent is the default number of recent snapshots that the nodes keep.
+const defaultSnapshotsKeepRecent = 2
+
+// validatorPortsStep is the difference between the ports of the servers of the consecutive
+// validators' nodes.
+const validatorPortsStep = 10
+
 var (
 	// ErrCouldntLocateConfig returned when config.yml cannot be found in the source code.
 	ErrCouldntLocateConfig = errors.New(
@@ -38,6 +49,15 @@ var DefaultConf = Config{
 		GRPC:    "0.0.0.0:9090",
 		GRPCWeb: "0.0.0.0:9091",
 		API:     "0.0.0.0:1317",
+		OpenAPI: "0.0.0.0:4501",
+		GRPCUI:  "0.0.0.0:4502",
+		GraphQL: "0.0.0.0:4503",
+
+		Proxy:     "0.0.0.0:443",
+		ProxyHTTP: "0.0.0.0:80",
+
+		APIRecorder:  "0.0.0.0:1318",
+		GRPCRecorder: "0.0.0.0:9092",
 	},
 	Build: Build{
 		Proto: Proto{
@@ -48,22 +68,41 @@ var DefaultConf = Config{
 			},
 		},
 	},
+	Init: Init{
+		Snapshots: Snapshots{
+			KeepRecent: defaultSnapshotsKeepRecent,
+		},
+	},
 	Faucet: Faucet{
 		Host: "0.0.0.0:4500",
 	},
+	Recorder: Recorder{
+		SampleRate:  1,
+		MaxBodySize: defaultRecorderMaxBodySize,
+	},
 }
 
 // Config is the user given configuration to do additional setup
 // during serve.
 type Config struct {
-	Accounts  []Account              `yaml:"accounts"`
-	Validator Validator              `yaml:"validator"`
-	Faucet    Faucet                 `yaml:"faucet"`
-	Client    Client                 `yaml:"client"`
-	Build     Build                  `yaml:"build"`
-	Init      Init                   `yaml:"init"`
-	Genesis   map[string]interface{} `yaml:"genesis"`
-	Host      Host                   `yaml:"host"`
+	// Version is the version of the config schema, older configs are migrated to the latest
+	// version when parsed.
+	Version int `yaml:"version"`
+
+	Accounts     []Account              `yaml:"accounts"`
+	TestAccounts TestAccounts           `yaml:"test_accounts"`
+	Validators   []Validator            `yaml:"validators"`
+	Denoms       []Denom                `yaml:"denoms"`
+	Tokenomics   Tokenomics             `yaml:"tokenomics"`
+	Faucet       Faucet                 `yaml:"faucet"`
+	Client       Client                 `yaml:"client"`
+	Build        Build                  `yaml:"build"`
+	Init         Init                   `yaml:"init"`
+	Genesis      map[string]interface{} `yaml:"genesis"`
+	Seed         []SeedTx               `yaml:"seed"`
+	Recorder     Recorder               `yaml:"recorder"`
+	Proxy        Proxy                  `yaml:"proxy"`
+	Host         Host                   `yaml:"host"`
 }
 
 // AccountByName finds account by name.
@@ -88,10 +127,90 @@ type Account struct {
 	RPCAddress string `yaml:"rpc_address,omitempty"`
 }
 
-// Validator holds info related to validator settings.
+// Validator holds info related to validator settings. each validator runs its own node, the first
+// one is the node that the chain services like the faucet connect to.
 type Validator struct {
-	Name   string `yaml:"name"`
-	Staked string `yaml:"staked"`
+	// Name is the name of the validator's account, it must be one of the accounts.
+	Name string `yaml:"name"`
+
+	// Bonded is the amount of coins bonded by the validator in its gentx.
+	Bonded string `yaml:"bonded"`
+
+	// Home overwrites the home directory of the validator's node.
+	Home string `yaml:"home"`
+
+	// App overwrites the node's config/app.toml configs on top of init.app.
+	App map[string]interface{} `yaml:"app"`
+
+	// Client overwrites the node's config/client.toml configs on top of init.client.
+	Client map[string]interface{} `yaml:"client"`
+
+	// Config overwrites the node's config/config.toml configs on top of init.config.
+	Config map[string]interface{} `yaml:"config"`
+
+	// Host overwrites the addresses of the node's servers, it is only used by the validators other
+	// than the first one since the servers of the first one are configured by host.
+	Host Host `yaml:"host"`
+
+	// Gentx sets the description and the commission of the validator in its gentx.
+	Gentx Gentx `yaml:"gentx"`
+}
+
+// Gentx holds the description and the commission of a validator that are embedded in its gentx,
+// the defaults of the chain's binary are used for the values that are not set.
+type Gentx struct {
+	Moniker                 string `yaml:"moniker"`
+	Identity                string `yaml:"identity"`
+	Website                 string `yaml:"website"`
+	SecurityContact         string `yaml:"security_contact"`
+	Details                 string `yaml:"details"`
+	CommissionRate          string `yaml:"commission_rate"`
+	CommissionMaxRate       string `yaml:"commission_max_rate"`
+	CommissionMaxChangeRate string `yaml:"commission_max_change_rate"`
+	MinSelfDelegation       string `yaml:"min_self_delegation"`
+}
+
+// ValidatorHost returns the addresses of the servers of the validator's node at index i. the first
+// validator uses host, the ports of host are shifted by validatorPortsStep for each of the other
+// validators unless they are overwritten by the validator's host.
+func (c Config) ValidatorHost(i int) Host {
+	if i == 0 {
+		return c.Host
+	}
+
+	var (
+		host = c.Validators[i].Host
+		step = i * validatorPortsStep
+	)
+	for _, address := range []struct {
+		value *string
+		base  string
+	}{
+		{&host.RPC, c.Host.RPC},
+		{&host.P2P, c.Host.P2P},
+		{&host.Prof, c.Host.Prof},
+		{&host.GRPC, c.Host.GRPC},
+		{&host.GRPCWeb, c.Host.GRPCWeb},
+		{&host.API, c.Host.API},
+	} {
+		if *address.value == "" {
+			*address.value = shiftPort(address.base, step)
+		}
+	}
+	return host
+}
+
+// shiftPort adds n to the port of the address, the address is returned as is if it has no port.
+func shiftPort(address string, n int) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return address
+	}
+	return net.JoinHostPort(host, strconv.Itoa(p+n))
 }
 
 // Build holds build configs.
@@ -99,7 +218,12 @@ type Build struct {
 	Main    string   `yaml:"main"`
 	Binary  string   `yaml:"binary"`
 	LDFlags []string `yaml:"ldflags"`
-	Proto   Proto    `yaml:"proto"`
+
+	// Tags are the build tags of the binary, e.g. to build it without the modules that are not
+	// supported on a platform.
+	Tags []string `yaml:"tags"`
+
+	Proto Proto `yaml:"proto"`
 }
 
 // Proto holds proto build configs.
@@ -110,6 +234,43 @@ type Proto struct {
 	// ThirdPartyPath is the relative path of where the third party proto files are
 	// located that used by the app.
 	ThirdPartyPaths []string `yaml:"third_party_paths"`
+
+	// Buf configures usage of buf to manage proto files.
+	Buf Buf `yaml:"buf"`
+
+	// Check configures checks of proto files made before code generation.
+	Check ProtoCheck `yaml:"check"`
+}
+
+// Severities of proto checks.
+const (
+	// ProtoCheckOff disables a check.
+	ProtoCheckOff = "off"
+
+	// ProtoCheckWarn reports found issues without interrupting the build.
+	ProtoCheckWarn = "warn"
+
+	// ProtoCheckError reports found issues and fails the build.
+	ProtoCheckError = "error"
+)
+
+// ProtoCheck configures checks of proto files made by buf before code generation.
+// Each check has a severity that is one of off, warn and error, checks are off by default.
+type ProtoCheck struct {
+	// Lint configures the severity of lint issues.
+	Lint string `yaml:"lint"`
+
+	// Breaking configures the severity of breaking changes against the latest git tag.
+	Breaking string `yaml:"breaking"`
+}
+
+// Buf configures usage of buf to manage proto files.
+type Buf struct {
+	// Enabled makes Go code generated with buf instead of protoc.
+	Enabled bool `yaml:"enabled"`
+
+	// Template is the path of the buf generation template relative to the proto dir.
+	Template string `yaml:"template"`
 }
 
 // Client configures code generation for clients.
@@ -117,11 +278,32 @@ type Client struct {
 	// Vuex configures code generation for Vuex.
 	Vuex Vuex `yaml:"vuex"`
 
+	// Vue configures generation of Vue 3 composables.
+	Vue Vue `yaml:"vue"`
+
+	// React configures generation of React Query hooks.
+	React React `yaml:"react"`
+
 	// Dart configures client code generation for Dart.
 	Dart Dart `yaml:"dart"`
 
+	// Rust configures client code generation for Rust.
+	Rust Rust `yaml:"rust"`
+
+	// Go configures generation of a standalone Go client module.
+	Go Go `yaml:"go"`
+
+	// WasmSigner configures generation of a WebAssembly helper to sign txs in browsers.
+	WasmSigner WasmSigner `yaml:"wasm_signer"`
+
+	// Keplr configures generation of the chain info to suggest the chain to Keplr wallet.
+	Keplr Keplr `yaml:"keplr"`
+
 	// OpenAPI configures OpenAPI spec generation for API.
 	OpenAPI OpenAPI `yaml:"openapi"`
+
+	// BundleReport enables reporting sizes of the generated TypeScript clients of each module.
+	BundleReport bool `yaml:"bundle_report"`
 }
 
 // Vuex configures code generation for Vuex.
@@ -130,15 +312,67 @@ type Vuex struct {
 	Path string `yaml:"path"`
 }
 
+// Vue configures generation of Vue 3 composables.
+type Vue struct {
+	// Path configures out location for generated composables.
+	Path string `yaml:"path"`
+}
+
+// React configures generation of React Query hooks.
+type React struct {
+	// Path configures out location for generated hooks.
+	Path string `yaml:"path"`
+}
+
 // Dart configures client code generation for Dart.
 type Dart struct {
 	// Path configures out location for generated Dart code.
 	Path string `yaml:"path"`
 }
 
+// Rust configures client code generation for Rust.
+type Rust struct {
+	// Path configures out location for the generated Cargo workspace.
+	Path string `yaml:"path"`
+}
+
+// Go configures generation of a standalone Go client module.
+type Go struct {
+	// Path configures out location for the generated Go module.
+	Path string `yaml:"path"`
+}
+
+// WasmSigner configures generation of a WebAssembly helper to sign txs in browsers.
+type WasmSigner struct {
+	// Path configures out location for the helper and its TypeScript loader.
+	Path string `yaml:"path"`
+}
+
+// Keplr configures generation of the chain info to suggest the chain to Keplr wallet.
+type Keplr struct {
+	// Path configures out location for the chain info in JSON format.
+	Path string `yaml:"path"`
+
+	// ChainName is the name of the chain shown by Keplr, default is the chain id.
+	ChainName string `yaml:"chain_name"`
+
+	// CoinType is the BIP44 coin type of the chain's keys, default is 118.
+	CoinType int `yaml:"coin_type"`
+}
+
 // OpenAPI configures OpenAPI spec generation for API.
 type OpenAPI struct {
+	// Path configures out location for the Swagger 2.0 spec in YAML format.
 	Path string `yaml:"path"`
+
+	// V3Path configures out location for the OpenAPI 3.0 spec in JSON format.
+	V3Path string `yaml:"v3_path"`
+}
+
+// Enabled returns true when the specs are generated, both specs are generated when either of their
+// paths is set.
+func (o OpenAPI) Enabled() bool {
+	return o.Path != "" || o.V3Path != ""
 }
 
 // Faucet configuration.
@@ -153,9 +387,46 @@ type Faucet struct {
 	// to single user.
 	CoinsMax []string `yaml:"coins_max"`
 
+	// FeeCoin is sent on top of the requested coins when they don't include its denom,
+	// so the users can pay the fees of their txs.
+	FeeCoin string `yaml:"fee_coin"`
+
 	// LimitRefreshTime sets the timeframe at the end of which the limit will be refreshed
 	RateLimitWindow string `yaml:"rate_limit_window"`
 
+	// RateLimitWindows holds of chain denoms and the timeframes at the end of which their limits
+	// will be refreshed, instead of RateLimitWindow.
+	RateLimitWindows map[string]string `yaml:"rate_limit_windows"`
+
+	// IPCoinsMax holds of chain denoms and their max amounts that can be transferred
+	// to the users requesting from a single IP.
+	IPCoinsMax []string `yaml:"ip_coins_max"`
+
+	// IPRateLimitWindow sets the timeframe at the end of which the limit of IPs will be refreshed.
+	IPRateLimitWindow string `yaml:"ip_rate_limit_window"`
+
+	// IPRateLimitWindows holds of chain denoms and the timeframes at the end of which their limits of
+	// IPs will be refreshed, instead of IPRateLimitWindow.
+	IPRateLimitWindows map[string]string `yaml:"ip_rate_limit_windows"`
+
+	// TrustForwardedFor determines the IPs of users from the X-Forwarded-For header.
+	TrustForwardedFor bool `yaml:"trust_forwarded_for"`
+
+	// AdminToken enables the admin endpoints of the faucet server.
+	AdminToken string `yaml:"admin_token"`
+
+	// Captcha requires users to solve a captcha to request tokens.
+	Captcha FaucetCaptcha `yaml:"captcha"`
+
+	// SignedChallenge requires users to sign a challenge with the keys of their accounts to request tokens.
+	SignedChallenge bool `yaml:"signed_challenge"`
+
+	// UI customizes the web UI of the faucet.
+	UI FaucetUI `yaml:"ui"`
+
+	// BatchInterval queues the transfers and sends the queued ones within a single tx every interval.
+	BatchInterval string `yaml:"batch_interval"`
+
 	// Host is the host of the faucet server
 	Host string `yaml:"host"`
 
@@ -163,6 +434,42 @@ type Faucet struct {
 	Port int `yaml:"port"`
 }
 
+// Captcha providers of the faucet.
+const (
+	// FaucetCaptchaHCaptcha verifies the captchas with hCaptcha.
+	FaucetCaptchaHCaptcha = "hcaptcha"
+
+	// FaucetCaptchaTurnstile verifies the captchas with Cloudflare Turnstile.
+	FaucetCaptchaTurnstile = "turnstile"
+)
+
+// FaucetCaptcha configures the captcha of the faucet.
+type FaucetCaptcha struct {
+	// Provider verifies the captchas, it can be hcaptcha or turnstile.
+	Provider string `yaml:"provider"`
+
+	// Secret is the secret key of the faucet's site registered to the provider.
+	Secret string `yaml:"secret"`
+
+	// SiteKey is the site key of the faucet's site registered to the provider, used by the web UI.
+	SiteKey string `yaml:"site_key"`
+}
+
+// FaucetUI customizes the web UI of the faucet.
+type FaucetUI struct {
+	// Title of the page.
+	Title string `yaml:"title"`
+
+	// Description shown below the title.
+	Description string `yaml:"description"`
+
+	// Logo is the URL of the logo shown above the title.
+	Logo string `yaml:"logo"`
+
+	// Color is the primary CSS color of the page.
+	Color string `yaml:"color"`
+}
+
 // Init overwrites sdk configurations with given values.
 type Init struct {
 	// App overwrites appd's config/app.toml configs.
@@ -179,6 +486,33 @@ type Init struct {
 
 	// KeyringBackend is the default keyring backend to use for blockchain initialization
 	KeyringBackend string `yaml:"keyring-backend"`
+
+	// Snapshots enables the snapshots of the state that the nodes serve to the nodes joining the
+	// chain with state sync.
+	Snapshots Snapshots `yaml:"snapshots"`
+}
+
+// Snapshots configures the snapshots of the state of the nodes, they're written to app.toml.
+type Snapshots struct {
+	// Interval is the number of blocks between the snapshots, the snapshots are disabled when it's 0.
+	Interval uint64 `yaml:"interval"`
+
+	// KeepRecent is the number of recent snapshots to keep and serve.
+	KeepRecent uint32 `yaml:"keep-recent"`
+}
+
+// AppConfig returns the changes of app.toml that enable the snapshots, it's nil when the snapshots
+// are disabled.
+func (s Snapshots) AppConfig() map[string]interface{} {
+	if s.Interval == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"state-sync": map[string]interface{}{
+			"snapshot-interval":    int64(s.Interval),
+			"snapshot-keep-recent": int64(s.KeepRecent),
+		},
+	}
 }
 
 // Host keeps configuration related to started servers.
@@ -189,28 +523,106 @@ type Host struct {
 	GRPC    string `yaml:"grpc"`
 	GRPCWeb string `yaml:"grpc-web"`
 	API     string `yaml:"api"`
+
+	// OpenAPI is the host of the server that serves the OpenAPI 3.0 spec and its console during serve.
+	OpenAPI string `yaml:"openapi"`
+
+	// GRPCUI is the host of the web UI that explores and invokes gRPC services of the node, it's enabled
+	// by serving with --grpc-ui.
+	GRPCUI string `yaml:"grpc-ui"`
+
+	// GraphQL is the host of the GraphQL API of the modules of the chain, it's enabled by serving
+	// with --graphql.
+	GraphQL string `yaml:"graphql"`
+
+	// Proxy and ProxyHTTP are the hosts of the TLS reverse proxy and of the server that redirects
+	// to it and solves the ACME challenges, they're enabled by serving with --public.
+	Proxy     string `yaml:"proxy"`
+	ProxyHTTP string `yaml:"proxy-http"`
+
+	// APIRecorder and GRPCRecorder are the hosts of the proxies of the API and the gRPC server
+	// that record the requests, they're enabled by serving with --record-requests.
+	APIRecorder  string `yaml:"api-recorder"`
+	GRPCRecorder string `yaml:"grpc-recorder"`
 }
 
 // Parse parses config.yml into UserConfig.
-func Parse(r io.Reader) (Config, error) {
+func Parse(r io.Reader, options ...ParseOption) (Config, error) {
+	o := newParseOptions(options...)
+
+	raw, err := decodeRaw(r)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if raw, err = resolveRaw(raw, o); err != nil {
+		return Config{}, err
+	}
+
+	conf, err := fromRaw(raw)
+	if err != nil {
+		return conf, err
+	}
+	return conf, validate(conf)
+}
+
+// decodeRaw decodes the config into a raw map and migrates it to the latest version.
+func decodeRaw(r io.Reader) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+	if _, err := migrate(raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// resolveRaw applies the selected profile to the raw config and resolves its references.
+func resolveRaw(raw map[string]interface{}, o parseOptions) (map[string]interface{}, error) {
+	raw, err := applyProfile(raw, o.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := interpolate(raw, o.secretResolvers(), ""); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// fromRaw converts the raw config into a config with the defaults, the coins given in the declared
+// denoms are converted to their base denoms.
+func fromRaw(raw map[string]interface{}) (Config, error) {
+	data, err := yaml.Marshal(raw)
+	if err != nil {
+		return Config{}, err
+	}
+
 	var conf Config
-	if err := yaml.NewDecoder(r).Decode(&conf); err != nil {
+	if err := yaml.Unmarshal(data, &conf); err != nil {
 		return conf, err
 	}
 	if err := mergo.Merge(&conf, DefaultConf); err != nil {
 		return Config{}, err
 	}
-	return conf, validate(conf)
+	if err := convertDenomCoins(&conf); err != nil {
+		return Config{}, err
+	}
+	return conf, nil
 }
 
 // ParseFile parses config.yml from the path.
-func ParseFile(path string) (Config, error) {
+func ParseFile(path string, options ...ParseOption) (Config, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return Config{}, nil
 	}
 	defer file.Close()
-	return Parse(file)
+	return Parse(file, append([]ParseOption{withDir(filepath.Dir(path))}, options...)...)
 }
 
 // validate validates user config.
@@ -218,12 +630,132 @@ func validate(conf Config) error {
 	if len(conf.Accounts) == 0 {
 		return &ValidationError{"at least 1 account is needed"}
 	}
-	if conf.Validator.Name == "" {
-		return &ValidationError{"validator is required"}
+	if len(conf.Validators) == 0 {
+		return &ValidationError{"at least 1 validator is needed"}
+	}
+	names := make(map[string]bool)
+	for i, validator := range conf.Validators {
+		if validator.Name == "" {
+			return &ValidationError{"validator is required"}
+		}
+		if names[validator.Name] {
+			return &ValidationError{fmt.Sprintf("validator %q is defined more than once", validator.Name)}
+		}
+		names[validator.Name] = true
+
+		if i == 0 && validator.Host != (Host{}) {
+			return &ValidationError{"the servers of the first validator are configured by host"}
+		}
+	}
+	for _, check := range []struct{ name, severity string }{
+		{"lint", conf.Build.Proto.Check.Lint},
+		{"breaking", conf.Build.Proto.Check.Breaking},
+	} {
+		switch check.severity {
+		case "", ProtoCheckOff, ProtoCheckWarn, ProtoCheckError:
+		default:
+			return &ValidationError{fmt.Sprintf(
+				"invalid severity %q for proto %s check, must be one of: off, warn, error",
+				check.severity,
+				check.name,
+			)}
+		}
+	}
+	if err := validateFaucetCoins(conf.Faucet); err != nil {
+		return err
+	}
+	if err := validateDenoms(conf); err != nil {
+		return err
+	}
+	if err := validateTokenomics(conf); err != nil {
+		return err
+	}
+	if err := validateTestAccounts(conf); err != nil {
+		return err
+	}
+	if err := validateSeed(conf); err != nil {
+		return err
+	}
+	if err := validateRecorder(conf); err != nil {
+		return err
+	}
+	if err := validateProxy(conf); err != nil {
+		return err
+	}
+	switch conf.Faucet.Captcha.Provider {
+	case "":
+	case FaucetCaptchaHCaptcha, FaucetCaptchaTurnstile:
+		if conf.Faucet.Captcha.Secret == "" {
+			return &ValidationError{"faucet captcha secret is required"}
+		}
+	default:
+		return &ValidationError{fmt.Sprintf(
+			"invalid faucet captcha provider %q, must be one of: hcaptcha, turnstile",
+			conf.Faucet.Captcha.Provider,
+		)}
 	}
 	return nil
 }
 
+// validateFaucetCoins validates the coins of the faucet, the max amounts and the rate limit windows
+// can only be set for the denoms distributed by the faucet.
+func validateFaucetCoins(faucet Faucet) error {
+	denoms := make(map[string]bool)
+	for _, coin := range faucet.Coins {
+		parsed, err := sdk.ParseCoinNormalized(coin)
+		if err != nil {
+			return &ValidationError{fmt.Sprintf("invalid faucet coin %q: %s", coin, err)}
+		}
+		if denoms[parsed.Denom] {
+			return &ValidationError{fmt.Sprintf("faucet coin %q is defined more than once", parsed.Denom)}
+		}
+		denoms[parsed.Denom] = true
+	}
+
+	for _, maxes := range []struct {
+		key   string
+		coins []string
+	}{
+		{"coins_max", faucet.CoinsMax},
+		{"ip_coins_max", faucet.IPCoinsMax},
+	} {
+		for _, coin := range maxes.coins {
+			parsed, err := sdk.ParseCoinNormalized(coin)
+			if err != nil {
+				return &ValidationError{fmt.Sprintf("invalid faucet %s %q: %s", maxes.key, coin, err)}
+			}
+			if !denoms[parsed.Denom] {
+				return &ValidationError{fmt.Sprintf("faucet %s %q is not one of the faucet coins", maxes.key, parsed.Denom)}
+			}
+		}
+	}
+
+	for _, windows := range []struct {
+		key     string
+		windows map[string]string
+	}{
+		{"rate_limit_windows", faucet.RateLimitWindows},
+		{"ip_rate_limit_windows", faucet.IPRateLimitWindows},
+	} {
+		for denom, window := range windows.windows {
+			if !denoms[denom] {
+				return &ValidationError{fmt.Sprintf("faucet %s %q is not one of the faucet coins", windows.key, denom)}
+			}
+			if _, err := time.ParseDuration(window); err != nil {
+				return &ValidationError{fmt.Sprintf("invalid faucet %s of %q: %s", windows.key, denom, err)}
+			}
+		}
+	}
+
+	if faucet.FeeCoin != "" {
+		if _, err := sdk.ParseCoinNormalized(faucet.FeeCoin); err != nil {
+			return &ValidationError{fmt.Sprintf("invalid faucet fee_coin %q: %s", faucet.FeeCoin, err)}
+		}
+	}
+
+	return nil
+}
+
 // ValidationError is returned when a configuration is invalid.
 type ValidationError struct {
 	Message string
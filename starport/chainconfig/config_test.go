@@ -1,6 +1,7 @@
 package chainconfig
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -14,9 +15,9 @@ accounts:
     coins: ["1000token", "100000000stake"]
   - name: you
     coins: ["5000token"]
-validator:
-  name: user1
-  staked: "100000000stake"
+validators:
+  - name: user1
+    bonded: "100000000stake"
 `
 
 	conf, err := Parse(strings.NewReader(confyml))
@@ -32,10 +33,10 @@ validator:
 			Coins: []string{"5000token"},
 		},
 	}, conf.Accounts)
-	require.Equal(t, Validator{
+	require.Equal(t, []Validator{{
 		Name:   "user1",
-		Staked: "100000000stake",
-	}, conf.Validator)
+		Bonded: "100000000stake",
+	}}, conf.Validators)
 }
 
 func TestCoinTypeParse(t *testing.T) {
@@ -69,10 +70,10 @@ validator:
 			CoinType: "123456",
 		},
 	}, conf.Accounts)
-	require.Equal(t, Validator{
+	require.Equal(t, []Validator{{
 		Name:   "user1",
-		Staked: "100000000stake",
-	}, conf.Validator)
+		Bonded: "100000000stake",
+	}}, conf.Validators)
 }
 
 func TestParseInvalid(t *testing.T) {
@@ -85,7 +86,82 @@ accounts:
 `
 
 	_, err := Parse(strings.NewReader(confyml))
-	require.Equal(t, &ValidationError{"validator is required"}, err)
+	require.Equal(t, &ValidationError{"at least 1 validator is needed"}, err)
+}
+
+func TestParseInvalidProtoCheckSeverity(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+build:
+  proto:
+    check:
+      lint: warn
+      breaking: fatal
+`
+
+	_, err := Parse(strings.NewReader(confyml))
+	require.Equal(t, &ValidationError{`invalid severity "fatal" for proto breaking check, must be one of: off, warn, error`}, err)
+}
+
+func TestParseInvalidFaucetCaptcha(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  name: me
+  captcha:
+    provider: %s
+`
+
+	_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, "recaptcha")))
+	require.Equal(t, &ValidationError{`invalid faucet captcha provider "recaptcha", must be one of: hcaptcha, turnstile`}, err)
+
+	_, err = Parse(strings.NewReader(fmt.Sprintf(confyml, "turnstile")))
+	require.Equal(t, &ValidationError{"faucet captcha secret is required"}, err)
+}
+
+func TestParseInvalidFaucetCoins(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  name: me
+  coins: ["5token"]
+%s
+`
+
+	for _, tt := range []struct {
+		faucet string
+		err    string
+	}{
+		{`  coins_max: ["100stake"]`, `faucet coins_max "stake" is not one of the faucet coins`},
+		{`  ip_coins_max: ["100foo"]`, `faucet ip_coins_max "foo" is not one of the faucet coins`},
+		{`  fee_coin: "-1stake"`, `invalid faucet fee_coin "-1stake"`},
+		{`  rate_limit_windows: {stake: 1h}`, `faucet rate_limit_windows "stake" is not one of the faucet coins`},
+		{`  ip_rate_limit_windows: {token: 1day}`, `invalid faucet ip_rate_limit_windows of "token"`},
+	} {
+		_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, tt.faucet)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tt.err)
+	}
+
+	_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, `  coins_max: ["100token"]
+  rate_limit_windows: {token: 24h}
+  fee_coin: "1stake"`)))
+	require.NoError(t, err)
 }
 
 func TestFaucetHost(t *testing.T) {
@@ -139,3 +215,457 @@ faucet:
 	require.NoError(t, err)
 	require.Equal(t, ":4700", FaucetHost(conf))
 }
+
+func TestParseProfile(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+host:
+  rpc: "0.0.0.0:26657"
+  api: "0.0.0.0:1317"
+faucet:
+  name: me
+  coins: ["5token"]
+profiles:
+  ci:
+    accounts:
+      - name: ci
+        coins: ["1token", "100000000stake"]
+    validator:
+      name: ci
+    host:
+      rpc: "0.0.0.0:36657"
+    faucet:
+      name: ~
+  empty:
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, "me", conf.Validators[0].Name)
+	require.Equal(t, "0.0.0.0:26657", conf.Host.RPC)
+
+	conf, err = Parse(strings.NewReader(confyml), WithProfile(DefaultProfile))
+	require.NoError(t, err)
+	require.Equal(t, "me", conf.Validators[0].Name)
+
+	conf, err = Parse(strings.NewReader(confyml), WithProfile("ci"))
+	require.NoError(t, err)
+	// lists are replaced.
+	require.Equal(t, []Account{{Name: "ci", Coins: []string{"1token", "100000000stake"}}}, conf.Accounts)
+	// maps are merged.
+	require.Equal(t, []Validator{{Name: "ci", Bonded: "100000000stake"}}, conf.Validators)
+	require.Equal(t, "0.0.0.0:36657", conf.Host.RPC)
+	require.Equal(t, "0.0.0.0:1317", conf.Host.API)
+	require.Nil(t, conf.Faucet.Name)
+	require.Equal(t, []string{"5token"}, conf.Faucet.Coins)
+
+	conf, err = Parse(strings.NewReader(confyml), WithProfile("empty"))
+	require.NoError(t, err)
+	require.Equal(t, "me", conf.Validators[0].Name)
+
+	_, err = Parse(strings.NewReader(confyml), WithProfile("staging"))
+	require.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestValidatorHost(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+  - name: bob
+    coins: ["1000token", "100000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+  - name: bob
+    bonded: "100000000stake"
+    host:
+      rpc: ":36657"
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, conf.Host, conf.ValidatorHost(0))
+	require.Equal(t, Host{
+		RPC:     ":36657",
+		P2P:     "0.0.0.0:26666",
+		Prof:    "0.0.0.0:6070",
+		GRPC:    "0.0.0.0:9100",
+		GRPCWeb: "0.0.0.0:9101",
+		API:     "0.0.0.0:1327",
+	}, conf.ValidatorHost(1))
+}
+
+func TestParseInvalidValidators(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+%s
+`
+
+	for _, tt := range []struct {
+		validator string
+		err       string
+	}{
+		{
+			validator: "  - name: alice\n    bonded: \"1stake\"",
+			err:       `validator "alice" is defined more than once`,
+		},
+		{
+			validator: "    host:\n      rpc: \":36657\"",
+			err:       "the servers of the first validator are configured by host",
+		},
+	} {
+		_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, tt.validator)))
+		require.Equal(t, &ValidationError{tt.err}, err)
+	}
+}
+
+func TestParseInvalidDenoms(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000utoken", "100000000ustake"]
+validators:
+  - name: me
+    bonded: "100000000ustake"
+denoms:
+  - base: ustake
+    display: stake
+    exponent: 6
+%s
+`
+
+	for _, tt := range []struct {
+		conf string
+		err  string
+	}{
+		{`  - base: ufoo`, `denom "utoken" of accounts[0].coins is not declared in denoms`},
+		{`  - base: ustake`, `denom "ustake" is declared more than once`},
+		{`  - base: utoken
+    display: token`, `the exponent of denoms[1] is required for its display denom "token"`},
+		{`  - base: utoken
+    aliases: ["stake"]`, `denom "stake" is declared more than once`},
+		{`  - base: utoken
+faucet:
+  coins: ["5stake"]`, `denom "stake" of faucet.coins is not declared in denoms, declared denoms: ustake, utoken`},
+		{`  - base: utoken
+genesis:
+  app_state:
+    staking:
+      params:
+        bond_denom: stake`, `denom "stake" of genesis.app_state.staking.params.bond_denom is not declared`},
+	} {
+		_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, tt.conf)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tt.err)
+	}
+
+	_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, `  - base: utoken
+    display: token
+    exponent: 6
+    symbol: TOKEN
+faucet:
+  coins: ["5utoken"]`)))
+	require.NoError(t, err)
+}
+
+func TestParseDenomCoins(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["12.5stake", "1000utoken", "3star"]
+validators:
+  - name: me
+    bonded: "%s"
+denoms:
+  - base: ustake
+    display: stake
+    exponent: 6
+    aliases: ["star"]
+  - base: utoken
+`
+
+	conf, err := Parse(strings.NewReader(fmt.Sprintf(confyml, "0.000001stake")))
+	require.NoError(t, err)
+	require.Equal(t, []string{"12500000ustake", "1000utoken", "3000000ustake"}, conf.Accounts[0].Coins)
+	require.Equal(t, "1ustake", conf.Validators[0].Bonded)
+
+	_, err = Parse(strings.NewReader(fmt.Sprintf(confyml, "0.0000001stake")))
+	require.EqualError(t, err, `config is not valid: invalid coin "0.0000001stake" of validators[0]: the amount of "0.0000001stake" has more than the 6 decimals of stake`)
+
+	_, err = Parse(strings.NewReader(fmt.Sprintf(confyml, "1.5ustake")))
+	require.EqualError(t, err, `config is not valid: invalid coin "1.5ustake" of validators[0]: the amount of "1.5ustake" can't have decimals, ustake is a base denom`)
+}
+
+func TestDenomsGenesis(t *testing.T) {
+	conf := Config{
+		Validators: []Validator{{Name: "alice", Bonded: "100000000ustake"}},
+		Denoms: []Denom{
+			{Base: "ustake", Display: "stake", Exponent: 6, Symbol: "STAKE"},
+			{Base: "token"},
+		},
+		Genesis: map[string]interface{}{
+			"app_state": map[string]interface{}{
+				"mint": map[string]interface{}{
+					"params": map[string]interface{}{"mint_denom": "token"},
+				},
+			},
+		},
+	}
+
+	genesis := conf.DenomsGenesis()
+	require.Equal(t, "ustake", genesisValue(genesis, genesisBondDenom))
+	require.Nil(t, genesisValue(genesis, genesisMintDenom))
+
+	metadata := genesisValue(genesis, "app_state.bank.denom_metadata").([]interface{})
+	require.Len(t, metadata, 2)
+	require.Equal(t, map[string]interface{}{
+		"description": "",
+		"denom_units": []interface{}{
+			map[string]interface{}{"denom": "ustake", "exponent": 0, "aliases": []interface{}{}},
+			map[string]interface{}{"denom": "stake", "exponent": uint32(6), "aliases": []interface{}{}},
+		},
+		"base":    "ustake",
+		"display": "stake",
+		"name":    "stake",
+		"symbol":  "STAKE",
+	}, metadata[0])
+	require.Len(t, metadata[1].(map[string]interface{})["denom_units"], 1)
+
+	require.Nil(t, Config{}.DenomsGenesis())
+}
+
+func TestParseInvalidTokenomics(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+  - name: bob
+    coins: ["50000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+%s
+`
+
+	for _, tt := range []struct {
+		conf string
+		err  string
+	}{
+		{`tokenomics:
+  preset: deflationary`, `invalid tokenomics preset "deflationary", must be one of: fixed, inflationary, capped`},
+		{`tokenomics:
+  preset: fixed
+  supply: 100000000stake`, `the coins of the accounts add up to 150000000stake, but the tokenomics supply is 100000000stake`},
+		{`tokenomics:
+  preset: fixed
+  supply: 1000token`, `tokenomics supply "1000token" is not in the mint denom "stake"`},
+		{`tokenomics:
+  preset: capped`, `tokenomics max_supply is required for the capped preset`},
+		{`tokenomics:
+  preset: inflationary
+  max_supply: 200000000stake`, `tokenomics max_supply can only be set for the capped preset`},
+		{`tokenomics:
+  preset: capped
+  max_supply: 100000000stake`, `the coins of the accounts add up to 150000000stake, which exceeds the tokenomics max supply 100000000stake`},
+		{`tokenomics:
+  preset: fixed
+faucet:
+  name: bob
+  coins: ["60000000stake"]`, `faucet account "bob" holds 50000000stake, which is less than the 60000000stake of faucet.coins`},
+		{`tokenomics:
+  preset: inflationary
+genesis:
+  app_state:
+    mint:
+      params:
+        inflation_max: "0.5"`, `genesis.app_state.mint.params.inflation_max is set by the tokenomics preset, remove it from the genesis`},
+		{`tokenomics:
+  preset: fixed
+  denom: token
+genesis:
+  app_state:
+    mint:
+      params:
+        mint_denom: stake`, `tokenomics denom "token" is not the mint denom "stake" of the genesis`},
+	} {
+		_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, tt.conf)))
+		require.Equal(t, &ValidationError{tt.err}, err)
+	}
+
+	_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, `tokenomics:
+  preset: capped
+  supply: 150000000stake
+  max_supply: 200000000stake
+faucet:
+  name: bob
+  coins: ["5stake", "5token"]`)))
+	require.NoError(t, err)
+}
+
+func TestTokenomicsGenesis(t *testing.T) {
+	conf := Config{
+		Validators: []Validator{{Name: "alice", Bonded: "100000000ustake"}},
+		Tokenomics: Tokenomics{Preset: TokenomicsInflationary},
+	}
+
+	genesis := conf.TokenomicsGenesis()
+	require.Equal(t, "ustake", genesisValue(genesis, genesisMintDenom))
+	require.Equal(t, "0.130000000000000000", genesisValue(genesis, "app_state.mint.minter.inflation"))
+	require.Equal(t, "0.200000000000000000", genesisValue(genesis, "app_state.mint.params.inflation_max"))
+	require.Equal(t, "0.070000000000000000", genesisValue(genesis, "app_state.mint.params.inflation_min"))
+	require.Equal(t, "0.020000000000000000", genesisValue(genesis, "app_state.distribution.params.community_tax"))
+
+	conf.Tokenomics = Tokenomics{Preset: TokenomicsFixed, Denom: "token"}
+	genesis = conf.TokenomicsGenesis()
+	require.Equal(t, "token", genesisValue(genesis, genesisMintDenom))
+	require.Equal(t, "0.000000000000000000", genesisValue(genesis, "app_state.mint.params.inflation_max"))
+
+	require.Nil(t, Config{}.TokenomicsGenesis())
+}
+
+func TestParseInvalidSeed(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+  - name: bob
+    address: cosmos1l344zjky9a6fjhamufh4lg3hxn2d3cevae2039
+validators:
+  - name: alice
+    bonded: "100000000stake"
+seed:
+%s
+`
+
+	for _, tt := range []struct {
+		seed string
+		err  string
+	}{
+		{`  - module: bank
+    msg: send`, "the from account of seed[0] is required"},
+		{`  - from: alice
+    msg: send`, "the module of seed[0] is required"},
+		{`  - from: carol
+    module: bank
+    msg: send`, `seed[0] is signed by "carol", which is not one of the accounts`},
+		{`  - from: bob
+    module: bank
+    msg: send`, `seed[0] is signed by "bob", which has no key since its address is set`},
+	} {
+		_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, tt.seed)))
+		require.Equal(t, &ValidationError{tt.err}, err)
+	}
+
+	conf, err := Parse(strings.NewReader(fmt.Sprintf(confyml, `  - from: alice
+    module: bank
+    msg: send
+    args: ["alice", '{{ address "bob" }}', "10stake"]`)))
+	require.NoError(t, err)
+	require.Equal(t, []SeedTx{{
+		From:   "alice",
+		Module: "bank",
+		Msg:    "send",
+		Args:   []string{"alice", `{{ address "bob" }}`, "10stake"},
+	}}, conf.Seed)
+}
+
+func TestParseRecorder(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+%s
+`
+
+	conf, err := Parse(strings.NewReader(fmt.Sprintf(confyml, "")))
+	require.NoError(t, err)
+	require.Equal(t, Recorder{SampleRate: 1, MaxBodySize: defaultRecorderMaxBodySize}, conf.Recorder)
+
+	conf, err = Parse(strings.NewReader(fmt.Sprintf(confyml, `recorder:
+  sample_rate: 0.5
+  redact: ["mnemonic"]`)))
+	require.NoError(t, err)
+	require.Equal(t, Recorder{
+		SampleRate:  0.5,
+		Redact:      []string{"mnemonic"},
+		MaxBodySize: defaultRecorderMaxBodySize,
+	}, conf.Recorder)
+
+	_, err = Parse(strings.NewReader(fmt.Sprintf(confyml, `recorder:
+  sample_rate: 2`)))
+	require.Equal(t, &ValidationError{"recorder sample_rate 2 must be greater than 0 and at most 1"}, err)
+}
+
+func TestParseProxy(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+%s
+`
+
+	conf, err := Parse(strings.NewReader(fmt.Sprintf(confyml, `proxy:
+  domain: devnet.example.com
+  tls: acme
+  email: alice@example.com`)))
+	require.NoError(t, err)
+	require.Equal(t, Proxy{
+		Domain: "devnet.example.com",
+		TLS:    ProxyTLSACME,
+		Email:  "alice@example.com",
+	}, conf.Proxy)
+	require.Equal(t, "rpc.devnet.example.com", conf.Proxy.Hostname("rpc"))
+	require.Equal(t, "0.0.0.0:443", conf.Host.Proxy)
+
+	_, err = Parse(strings.NewReader(fmt.Sprintf(confyml, `proxy:
+  tls: letsencrypt`)))
+	require.Equal(t, &ValidationError{`proxy tls "letsencrypt" must be self-signed or acme`}, err)
+
+	_, err = Parse(strings.NewReader(fmt.Sprintf(confyml, `proxy:
+  domain: https://devnet.example.com`)))
+	require.Equal(t, &ValidationError{`proxy domain "https://devnet.example.com" must be a domain name without scheme or port`}, err)
+}
+
+func TestOpenAPIEnabled(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+%s`
+
+	for _, tt := range []struct {
+		name    string
+		client  string
+		enabled bool
+	}{
+		{"no paths", "", false},
+		{"swagger path", "client:\n  openapi:\n    path: docs/static/openapi.yml", true},
+		{"v3 path only", "client:\n  openapi:\n    v3_path: docs/static/openapi.json", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := Parse(strings.NewReader(fmt.Sprintf(confyml, tt.client)))
+			require.NoError(t, err)
+			require.Equal(t, tt.enabled, conf.Client.OpenAPI.Enabled())
+		})
+	}
+}
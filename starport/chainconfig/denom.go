@@ -0,0 +1,292 @@
+package chainconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+)
+
+// Denom is a denom of the chain, its metadata is written in the denom metadata of the bank module
+// in the genesis.
+type Denom struct {
+	// Base is the denom of the coins on chain, e.g. ustake.
+	Base string `yaml:"base"`
+
+	// Display is the denom shown to the users, e.g. stake. it's the base denom when it's empty.
+	Display string `yaml:"display"`
+
+	// Exponent is the power of 10 of the base denom in one display denom, e.g. 6.
+	Exponent uint32 `yaml:"exponent"`
+
+	// Aliases are other denoms of the display denom.
+	Aliases []string `yaml:"aliases"`
+
+	// Symbol is the ticker of the denom, e.g. STAKE.
+	Symbol string `yaml:"symbol"`
+
+	// Description describes the denom.
+	Description string `yaml:"description"`
+}
+
+// validateDenoms validates the declared denoms, and when some are declared, that the coins of the
+// accounts, the validators, the faucet and the staking and mint params of the genesis are in
+// the declared denoms. the coins that can't be parsed are reported by the other validations.
+func validateDenoms(conf Config) error {
+	if len(conf.Denoms) == 0 {
+		return nil
+	}
+
+	declared := make(map[string]bool)
+	for i, denom := range conf.Denoms {
+		if err := sdk.ValidateDenom(denom.Base); err != nil {
+			return &ValidationError{fmt.Sprintf("invalid base denom %q of denoms[%d]: %s", denom.Base, i, err)}
+		}
+		if declared[denom.Base] {
+			return &ValidationError{fmt.Sprintf("denom %q is declared more than once", denom.Base)}
+		}
+		declared[denom.Base] = true
+
+		if denom.Display != "" && denom.Display != denom.Base {
+			if err := sdk.ValidateDenom(denom.Display); err != nil {
+				return &ValidationError{fmt.Sprintf("invalid display denom %q of denoms[%d]: %s", denom.Display, i, err)}
+			}
+			if denom.Exponent == 0 {
+				return &ValidationError{fmt.Sprintf("the exponent of denoms[%d] is required for its display denom %q", i, denom.Display)}
+			}
+		}
+		if denom.Exponent > sdk.Precision {
+			return &ValidationError{fmt.Sprintf("the exponent of denoms[%d] can't be more than %d", i, sdk.Precision)}
+		}
+		for _, alias := range denom.Aliases {
+			if err := sdk.ValidateDenom(alias); err != nil {
+				return &ValidationError{fmt.Sprintf("invalid alias %q of denoms[%d]: %s", alias, i, err)}
+			}
+		}
+	}
+
+	// the amounts of the config can be given in the display denoms and the aliases too, so all of the
+	// denoms of the units must be unique.
+	units := make(map[string]bool)
+	for _, denom := range conf.CoinDenoms() {
+		for _, unit := range denom.Units {
+			for _, name := range append([]string{unit.Denom}, unit.Aliases...) {
+				if units[name] {
+					return &ValidationError{fmt.Sprintf("denom %q is declared more than once", name)}
+				}
+				units[name] = true
+			}
+		}
+	}
+
+	check := func(key string, coins ...string) error {
+		for _, coin := range coins {
+			parsed, err := sdk.ParseCoinNormalized(coin)
+			if err != nil {
+				continue
+			}
+			if err := checkDeclaredDenom(declared, key, parsed.Denom); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, account := range conf.Accounts {
+		if err := check(fmt.Sprintf("accounts[%d].coins", i), account.Coins...); err != nil {
+			return err
+		}
+	}
+	for i, validator := range conf.Validators {
+		if err := check(fmt.Sprintf("validators[%d].bonded", i), validator.Bonded); err != nil {
+			return err
+		}
+	}
+	for _, faucetCoins := range []struct {
+		key   string
+		coins []string
+	}{
+		{"faucet.coins", conf.Faucet.Coins},
+		{"faucet.coins_max", conf.Faucet.CoinsMax},
+		{"faucet.ip_coins_max", conf.Faucet.IPCoinsMax},
+		{"faucet.fee_coin", []string{conf.Faucet.FeeCoin}},
+	} {
+		if err := check(faucetCoins.key, faucetCoins.coins...); err != nil {
+			return err
+		}
+	}
+
+	for _, param := range []string{genesisBondDenom, genesisMintDenom} {
+		if denom, ok := genesisValue(conf.Genesis, param).(string); ok {
+			if err := checkDeclaredDenom(declared, "genesis."+param, denom); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkDeclaredDenom(declared map[string]bool, key, denom string) error {
+	if declared[denom] {
+		return nil
+	}
+	denoms := make([]string, 0, len(declared))
+	for d := range declared {
+		denoms = append(denoms, d)
+	}
+	sort.Strings(denoms)
+	return &ValidationError{fmt.Sprintf(
+		"denom %q of %s is not declared in denoms, declared denoms: %s",
+		denom,
+		key,
+		strings.Join(denoms, ", "),
+	)}
+}
+
+const (
+	genesisBondDenom = "app_state.staking.params.bond_denom"
+	genesisMintDenom = "app_state.mint.params.mint_denom"
+)
+
+// genesisValue returns the value of the genesis at the dot separated key.
+func genesisValue(genesis map[string]interface{}, key string) interface{} {
+	var value interface{} = genesis
+	for _, name := range strings.Split(key, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[name]
+	}
+	return value
+}
+
+// DenomsGenesis returns the changes of the genesis for the declared denoms: their metadata in the
+// bank module, and the denom of the first validator's bonded coins as the bond and mint denoms
+// when they are not set in the genesis of the config. it returns nil when no denoms are declared.
+func (c Config) DenomsGenesis() map[string]interface{} {
+	if len(c.Denoms) == 0 {
+		return nil
+	}
+
+	metadata := make([]interface{}, 0, len(c.Denoms))
+	for _, denom := range c.Denoms {
+		display := denom.Display
+		if display == "" {
+			display = denom.Base
+		}
+		aliases := make([]interface{}, 0, len(denom.Aliases))
+		for _, alias := range denom.Aliases {
+			aliases = append(aliases, alias)
+		}
+		var units []interface{}
+		if display == denom.Base {
+			units = append(units, map[string]interface{}{"denom": denom.Base, "exponent": 0, "aliases": aliases})
+		} else {
+			units = append(units,
+				map[string]interface{}{"denom": denom.Base, "exponent": 0, "aliases": []interface{}{}},
+				map[string]interface{}{"denom": display, "exponent": denom.Exponent, "aliases": aliases},
+			)
+		}
+		metadata = append(metadata, map[string]interface{}{
+			"description": denom.Description,
+			"denom_units": units,
+			"base":        denom.Base,
+			"display":     display,
+			"name":        display,
+			"symbol":      denom.Symbol,
+		})
+	}
+
+	appState := map[string]interface{}{
+		"bank": map[string]interface{}{"denom_metadata": metadata},
+	}
+
+	if len(c.Validators) > 0 {
+		if bonded, err := sdk.ParseCoinNormalized(c.Validators[0].Bonded); err == nil {
+			if genesisValue(c.Genesis, genesisBondDenom) == nil {
+				appState["staking"] = map[string]interface{}{
+					"params": map[string]interface{}{"bond_denom": bonded.Denom},
+				}
+			}
+			if genesisValue(c.Genesis, genesisMintDenom) == nil {
+				appState["mint"] = map[string]interface{}{
+					"params": map[string]interface{}{"mint_denom": bonded.Denom},
+				}
+			}
+		}
+	}
+
+	return map[string]interface{}{"app_state": appState}
+}
+
+// CoinDenoms returns the units of the declared denoms that the amounts of the config can be given
+// in: the base denom, and the display denom with its aliases.
+func (c Config) CoinDenoms() cosmosutil.Denoms {
+	denoms := make(cosmosutil.Denoms, 0, len(c.Denoms))
+	for _, denom := range c.Denoms {
+		base := cosmosutil.DenomUnit{Denom: denom.Base}
+		if denom.Display == "" || denom.Display == denom.Base {
+			base.Aliases = denom.Aliases
+			denoms = append(denoms, cosmosutil.Denom{Base: denom.Base, Units: []cosmosutil.DenomUnit{base}})
+			continue
+		}
+		denoms = append(denoms, cosmosutil.Denom{
+			Base: denom.Base,
+			Units: []cosmosutil.DenomUnit{
+				base,
+				{Denom: denom.Display, Exponent: denom.Exponent, Aliases: denom.Aliases},
+			},
+		})
+	}
+	return denoms
+}
+
+// convertDenomCoins converts the coins of the accounts and the bonded coins of the validators that
+// are given in the declared denoms to their base denoms, e.g. 12.5mars to 12500000umars when the
+// exponent of mars is 6. the coins in other denoms are kept as they are, the validations report them.
+func convertDenomCoins(conf *Config) error {
+	if len(conf.Denoms) == 0 {
+		return nil
+	}
+
+	denoms := conf.CoinDenoms()
+	convert := func(key, coin string) (string, error) {
+		decCoin, err := sdk.ParseDecCoin(coin)
+		if err != nil {
+			return coin, nil
+		}
+		if _, _, ok := denoms.Lookup(decCoin.Denom); !ok {
+			return coin, nil
+		}
+		converted, err := denoms.ParseCoin(coin)
+		if err != nil {
+			return "", &ValidationError{fmt.Sprintf("invalid coin %q of %s: %s", coin, key, err)}
+		}
+		return converted.String(), nil
+	}
+
+	for i, account := range conf.Accounts {
+		coins := make([]string, 0, len(account.Coins))
+		for _, coin := range account.Coins {
+			converted, err := convert(fmt.Sprintf("accounts[%d]", i), coin)
+			if err != nil {
+				return err
+			}
+			coins = append(coins, converted)
+		}
+		conf.Accounts[i].Coins = coins
+	}
+	for i, validator := range conf.Validators {
+		bonded, err := convert(fmt.Sprintf("validators[%d]", i), validator.Bonded)
+		if err != nil {
+			return err
+		}
+		conf.Validators[i].Bonded = bonded
+	}
+	return nil
+}
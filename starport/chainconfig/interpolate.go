@@ -0,0 +1,252 @@
+package chainconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Schemes of the built-in secret resolvers.
+const (
+	// SecretSchemeEnv resolves ${env:NAME} to the value of the NAME environment variable.
+	SecretSchemeEnv = "env"
+
+	// SecretSchemeFile resolves ${file:path} to the content of the file, the path is relative to
+	// the directory of the config file and cannot be outside of it.
+	SecretSchemeFile = "file"
+
+	// SecretSchemeCommand resolves ${cmd:command} to the output of the shell command, only when
+	// enabled with WithCommandSecrets.
+	SecretSchemeCommand = "cmd"
+)
+
+// ErrCommandSecretsDisabled is returned for the ${cmd:command} references of a config parsed
+// without WithCommandSecrets.
+var ErrCommandSecretsDisabled = errors.New("command references are not enabled, they run shell commands")
+
+// reference matches the ${...} references in the config values, references escaped as $${...}
+// are matched too to keep them as is.
+var reference = regexp.MustCompile(`\$?\$\{[^}]*\}`)
+
+// envName matches the names of the environment variables.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SecretResolver resolves the reference of a secret to its value, e.g. a secret manager item.
+type SecretResolver func(ref string) (string, error)
+
+// WithSecretResolver registers a resolver for ${scheme:ref} references of the config, so secrets
+// like mnemonics and API keys can be kept out of the config. built-in resolvers can be
+// replaced with the same schemes.
+func WithSecretResolver(scheme string, resolver SecretResolver) ParseOption {
+	return func(o *parseOptions) {
+		if o.resolvers == nil {
+			o.resolvers = make(map[string]SecretResolver)
+		}
+		o.resolvers[scheme] = resolver
+	}
+}
+
+// withDir sets the directory that the relative paths of the file secrets are relative to.
+func withDir(dir string) ParseOption {
+	return func(o *parseOptions) {
+		o.dir = dir
+	}
+}
+
+// WithCommandSecrets enables the ${cmd:command} references of the config, they run their commands
+// with the shell each time the config is parsed. only enable them for trusted configs, a config of a
+// cloned repository could run any command otherwise.
+func WithCommandSecrets() ParseOption {
+	return func(o *parseOptions) {
+		o.commands = true
+	}
+}
+
+// secretResolvers returns the built-in resolvers together with the registered ones.
+func (o parseOptions) secretResolvers() map[string]SecretResolver {
+	resolvers := map[string]SecretResolver{
+		SecretSchemeEnv:     resolveEnv,
+		SecretSchemeFile:    fileResolver(o.dir),
+		SecretSchemeCommand: resolveCommandDisabled,
+	}
+	if o.commands {
+		resolvers[SecretSchemeCommand] = resolveCommand
+	}
+	for scheme, resolver := range o.resolvers {
+		resolvers[scheme] = resolver
+	}
+	return resolvers
+}
+
+func resolveEnv(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+// fileResolver returns a resolver that reads the files under dir, the paths that are absolute or
+// lead outside of dir, including through symlinks, are rejected.
+func fileResolver(dir string) SecretResolver {
+	return func(path string) (string, error) {
+		if filepath.IsAbs(path) {
+			return "", errors.New("the path must be relative to the directory of the config")
+		}
+		root, err := filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		if root, err = filepath.EvalSymlinks(root); err != nil {
+			return "", err
+		}
+		path, err = filepath.EvalSymlinks(filepath.Join(root, path))
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", errors.New("the path must be inside the directory of the config")
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+}
+
+func resolveCommandDisabled(string) (string, error) {
+	return "", ErrCommandSecretsDisabled
+}
+
+func resolveCommand(command string) (string, error) {
+	var stderr strings.Builder
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if stderr.Len() > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// interpolate replaces the references in the string values of the raw config:
+//   - ${NAME} with the value of the environment variable, ${NAME:-default} falls back to default
+//     when the variable is not set.
+//   - ${scheme:ref} with the value resolved by the secret resolver of the scheme.
+//   - $${...} with ${...} as is.
+func interpolate(value interface{}, resolvers map[string]SecretResolver, key string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			resolved, err := interpolate(item, resolvers, joinKey(key, k))
+			if err != nil {
+				return nil, err
+			}
+			v[k] = resolved
+		}
+		return v, nil
+
+	case []interface{}:
+		for i, item := range v {
+			resolved, err := interpolate(item, resolvers, fmt.Sprintf("%s[%d]", key, i))
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+		return v, nil
+
+	case string:
+		return interpolateString(v, resolvers, key)
+
+	default:
+		return v, nil
+	}
+}
+
+func interpolateString(s string, resolvers map[string]SecretResolver, key string) (interface{}, error) {
+	var (
+		err     error
+		matches int
+	)
+
+	resolved := reference.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		matches++
+		if err != nil {
+			return ""
+		}
+
+		var value string
+		if value, err = resolveReference(ref[2:len(ref)-1], resolvers); err != nil {
+			err = &ValidationError{fmt.Sprintf("cannot resolve %s of %s: %s", ref, key, err)}
+		}
+		return value
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// a value that is a single reference takes the type of the resolved number or bool, e.g. a port
+	// number. other values are kept as strings as is.
+	if matches == 1 && reference.FindString(s) == s {
+		var typed interface{}
+		if err := yaml.Unmarshal([]byte(resolved), &typed); err == nil {
+			switch typed.(type) {
+			case int, int64, uint64, float64, bool:
+				return typed, nil
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolveReference resolves the content of a ${...} reference.
+func resolveReference(ref string, resolvers map[string]SecretResolver) (string, error) {
+	if i := strings.Index(ref, ":"); i > 0 {
+		if resolver, ok := resolvers[ref[:i]]; ok {
+			return resolver(ref[i+1:])
+		}
+	}
+
+	name, fallback, hasFallback := ref, "", false
+	if i := strings.Index(ref, ":-"); i >= 0 {
+		name, fallback, hasFallback = ref[:i], ref[i+2:], true
+	}
+
+	if !envName.MatchString(name) {
+		return "", errors.New("unknown secret resolver or invalid environment variable name")
+	}
+
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		if hasFallback {
+			return fallback, nil
+		}
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+func joinKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
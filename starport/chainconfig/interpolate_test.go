@@ -0,0 +1,129 @@
+package chainconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterpolation(t *testing.T) {
+	t.Setenv("TEST_VALIDATOR", "alice")
+	t.Setenv("TEST_FAUCET_PORT", "4600")
+	t.Setenv("TEST_MNEMONIC", "ozone unfold device pave")
+	t.Setenv("TEST_TRUST_PROXY", "true")
+
+	confyml := `
+accounts:
+  - name: ${TEST_VALIDATOR}
+    coins: ["1000token", "100000000stake"]
+    mnemonic: ${env:TEST_MNEMONIC}
+  - name: bob
+    coins: ["1${TEST_DENOM:-token}"]
+    address: $${TEST_VALIDATOR}
+validator:
+  name: ${TEST_VALIDATOR}
+  staked: "100000000stake"
+faucet:
+  name: bob
+  port: ${TEST_FAUCET_PORT}
+  trust_forwarded_for: ${TEST_TRUST_PROXY}
+  admin_token: ${cmd:echo secret}
+`
+
+	conf, err := Parse(strings.NewReader(confyml), WithCommandSecrets())
+	require.NoError(t, err)
+	require.Equal(t, "alice", conf.Accounts[0].Name)
+	require.Equal(t, "ozone unfold device pave", conf.Accounts[0].Mnemonic)
+	require.Equal(t, []string{"1token"}, conf.Accounts[1].Coins)
+	require.Equal(t, "${TEST_VALIDATOR}", conf.Accounts[1].Address)
+	require.Equal(t, "alice", conf.Validators[0].Name)
+	require.Equal(t, ":4600", FaucetHost(conf))
+	require.True(t, conf.Faucet.TrustForwardedFor)
+	require.Equal(t, "secret", conf.Faucet.AdminToken)
+}
+
+func TestParseInterpolationErrors(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+    mnemonic: %s
+validator:
+  name: me
+  staked: "100000000stake"
+`
+
+	for _, ref := range []string{"${TEST_NOT_SET}", "${env:TEST_NOT_SET}", "${vault:mnemonic}", "${cmd:exit 1}"} {
+		_, err := Parse(strings.NewReader(strings.Replace(confyml, "%s", ref, 1)), WithCommandSecrets())
+		require.Error(t, err, ref)
+		require.Contains(t, err.Error(), "accounts[0].mnemonic", ref)
+	}
+
+	// the commands are not run unless enabled.
+	_, err := Parse(strings.NewReader(strings.Replace(confyml, "%s", "${cmd:echo secret}", 1)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), ErrCommandSecretsDisabled.Error())
+}
+
+func TestParseSecretResolvers(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mnemonic"), []byte("ozone unfold\n"), 0600))
+
+	path := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+    mnemonic: ${file:mnemonic}
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  name: me
+  captcha:
+    provider: hcaptcha
+    secret: ${vault:captcha}
+`), 0600))
+
+	vault := func(ref string) (string, error) {
+		if ref == "captcha" {
+			return "captcha-secret", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	conf, err := ParseFile(path, WithSecretResolver("vault", vault))
+	require.NoError(t, err)
+	require.Equal(t, "ozone unfold", conf.Accounts[0].Mnemonic)
+	require.Equal(t, "captcha-secret", conf.Faucet.Captcha.Secret)
+}
+
+func TestParseFileSecretOutsideDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret"), []byte("ozone unfold"), 0600))
+
+	dir := filepath.Join(root, "app")
+	require.NoError(t, os.Mkdir(dir, 0755))
+	require.NoError(t, os.Symlink(filepath.Join(root, "secret"), filepath.Join(dir, "link")))
+
+	path := filepath.Join(dir, "config.yml")
+	for _, ref := range []string{"../secret", "link", filepath.Join(root, "secret")} {
+		require.NoError(t, os.WriteFile(path, []byte(`
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+    mnemonic: ${file:`+ref+`}
+validator:
+  name: me
+  staked: "100000000stake"
+`), 0600))
+
+		_, err := ParseFile(path)
+		require.Error(t, err, ref)
+		require.Contains(t, err.Error(), "accounts[0].mnemonic", ref)
+	}
+}
@@ -0,0 +1,208 @@
+package chainconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+)
+
+// LatestVersion is the version of the config schema supported by this version of Starport.
+// configs without a version are at version 0.
+const LatestVersion = 2
+
+// keyVersion is the key of the config that defines the version of its schema.
+const keyVersion = "version"
+
+// ErrUnsupportedVersion is returned when the config is created for a newer version of Starport.
+var ErrUnsupportedVersion = errors.New("config version is not supported")
+
+// migration upgrades a raw config from its version to the next one.
+type migration func(raw map[string]interface{}) error
+
+// migrations upgrade the raw configs, the migration at index i upgrades a config from version i
+// to i+1. a new migration must be added each time the schema changes in a breaking way.
+var migrations = []migration{
+	migrateV0,
+	migrateV1,
+}
+
+// migrate upgrades the raw config to the latest version, it returns the version of the config
+// before the migration.
+func migrate(raw map[string]interface{}) (version int, err error) {
+	if version, err = configVersion(raw); err != nil {
+		return 0, err
+	}
+	if version > LatestVersion {
+		return 0, fmt.Errorf(
+			"%w: %d is newer than %d, please upgrade Starport",
+			ErrUnsupportedVersion,
+			version,
+			LatestVersion,
+		)
+	}
+
+	for _, m := range migrations[version:] {
+		if err := m(raw); err != nil {
+			return 0, err
+		}
+	}
+	raw[keyVersion] = LatestVersion
+
+	return version, nil
+}
+
+// configVersion returns the version of the raw config.
+func configVersion(raw map[string]interface{}) (int, error) {
+	var version int
+	switch v := raw[keyVersion].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		version = v
+	case uint64:
+		version = int(v)
+	case int64:
+		version = int(v)
+	default:
+		return 0, &ValidationError{fmt.Sprintf("version must be a number, got %v", v)}
+	}
+	if version < 0 {
+		return 0, &ValidationError{fmt.Sprintf("version cannot be negative, got %d", version)}
+	}
+	return version, nil
+}
+
+// withProfiles calls fn for the raw config and the values of each of its profiles, since the
+// profiles are written in the same schema with the config.
+func withProfiles(raw map[string]interface{}, fn func(values map[string]interface{})) {
+	fn(raw)
+
+	profiles, _ := raw[keyProfiles].(map[string]interface{})
+	for _, profile := range profiles {
+		if values, ok := profile.(map[string]interface{}); ok {
+			fn(values)
+		}
+	}
+}
+
+// migrateV0 upgrades the configs created before the schema is versioned:
+//   - faucet.port is replaced by faucet.host.
+//   - init.keyring-backend is moved to init.client.keyring-backend.
+func migrateV0(raw map[string]interface{}) error {
+	withProfiles(raw, func(values map[string]interface{}) {
+		if faucet, ok := values["faucet"].(map[string]interface{}); ok {
+			if port, ok := faucet["port"]; ok {
+				// the port always had priority over the host.
+				if p := fmt.Sprint(port); port != nil && p != "0" {
+					faucet["host"] = ":" + p
+				}
+				delete(faucet, "port")
+			}
+		}
+
+		if init, ok := values["init"].(map[string]interface{}); ok {
+			if backend, ok := init["keyring-backend"]; ok {
+				if backend != nil && backend != "" {
+					client, _ := init["client"].(map[string]interface{})
+					if client == nil {
+						client = make(map[string]interface{})
+						init["client"] = client
+					}
+					client["keyring-backend"] = backend
+				}
+				delete(init, "keyring-backend")
+			}
+		}
+	})
+	return nil
+}
+
+// migrateV1 replaces the single validator with the list of validators, the staked amount of the
+// validator becomes its bonded amount. since the validator of a profile was merged into the
+// validator of the config, it's merged before being converted to a list.
+func migrateV1(raw map[string]interface{}) error {
+	base, _ := raw["validator"].(map[string]interface{})
+
+	withProfiles(raw, func(values map[string]interface{}) {
+		validator, ok := values["validator"]
+		if !ok {
+			return
+		}
+		delete(values, "validator")
+
+		v, _ := validator.(map[string]interface{})
+		if v == nil {
+			return
+		}
+
+		converted := make(map[string]interface{})
+		for key, value := range base {
+			converted[key] = value
+		}
+		for key, value := range v {
+			converted[key] = value
+		}
+		if staked, ok := converted["staked"]; ok {
+			converted["bonded"] = staked
+			delete(converted, "staked")
+		}
+		values["validators"] = []interface{}{converted}
+	})
+	return nil
+}
+
+// MigrateFile upgrades the config file at path to the latest version and writes it back when it
+// is not at the latest version already. it returns the version of the config before the
+// migration. the values of the config are kept as is, so environment variables and secrets
+// are not resolved but comments of the file are not kept.
+func MigrateFile(path string) (version int, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return 0, err
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+
+	if version, err = migrate(raw); err != nil {
+		return 0, err
+	}
+	if version == LatestVersion {
+		return version, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	if data, err = marshalRaw(raw); err != nil {
+		return 0, err
+	}
+	return version, os.WriteFile(path, data, info.Mode())
+}
+
+// marshalRaw marshals the raw config with its keys sorted and the version at the top.
+func marshalRaw(raw map[string]interface{}) ([]byte, error) {
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		if key != keyVersion {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	values := yaml.MapSlice{{Key: keyVersion, Value: raw[keyVersion]}}
+	for _, key := range keys {
+		values = append(values, yaml.MapItem{Key: key, Value: raw[key]})
+	}
+	return yaml.Marshal(values)
+}
@@ -0,0 +1,155 @@
+package chainconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	require.Len(t, migrations, LatestVersion)
+}
+
+func TestParseMigrate(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  host: "0.0.0.0:4600"
+  port: 4700
+init:
+  keyring-backend: os
+  client:
+    node: "tcp://localhost:26657"
+profiles:
+  ci:
+    faucet:
+      port: 4800
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, LatestVersion, conf.Version)
+	require.Equal(t, ":4700", conf.Faucet.Host)
+	require.Zero(t, conf.Faucet.Port)
+	require.Empty(t, conf.Init.KeyringBackend)
+	require.Equal(t, map[string]interface{}{
+		"keyring-backend": "os",
+		"node":            "tcp://localhost:26657",
+	}, conf.Init.Client)
+
+	conf, err = Parse(strings.NewReader(confyml), WithProfile("ci"))
+	require.NoError(t, err)
+	require.Equal(t, ":4800", conf.Faucet.Host)
+}
+
+func TestParseMigrateV1(t *testing.T) {
+	confyml := `
+version: 1
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+  - name: ci
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  host: "0.0.0.0:4600"
+profiles:
+  ci:
+    validator:
+      name: ci
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, LatestVersion, conf.Version)
+	require.Equal(t, []Validator{{Name: "me", Bonded: "100000000stake"}}, conf.Validators)
+	require.Equal(t, "0.0.0.0:4600", conf.Faucet.Host)
+
+	conf, err = Parse(strings.NewReader(confyml), WithProfile("ci"))
+	require.NoError(t, err)
+	require.Equal(t, []Validator{{Name: "ci", Bonded: "100000000stake"}}, conf.Validators)
+}
+
+func TestParseLatestVersion(t *testing.T) {
+	confyml := `
+version: 2
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validators:
+  - name: me
+    bonded: "100000000stake"
+faucet:
+  host: "0.0.0.0:4600"
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, 2, conf.Version)
+	require.Equal(t, "0.0.0.0:4600", conf.Faucet.Host)
+}
+
+func TestParseUnsupportedVersion(t *testing.T) {
+	confyml := `
+version: 100
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+`
+
+	_, err := Parse(strings.NewReader(confyml))
+	require.True(t, errors.Is(err, ErrUnsupportedVersion))
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, "100", "latest", 1)))
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+}
+
+func TestMigrateFile(t *testing.T) {
+	confyml := `accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+    mnemonic: ${TEST_MNEMONIC}
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  port: 4700
+`
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(confyml), 0644))
+
+	version, err := MigrateFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(data), fmt.Sprintf("version: %d\n", LatestVersion)))
+	require.Contains(t, string(data), "${TEST_MNEMONIC}")
+	require.NotContains(t, string(data), "port:")
+	require.Contains(t, string(data), "bonded: 100000000stake")
+
+	version, err = MigrateFile(path)
+	require.NoError(t, err)
+	require.Equal(t, LatestVersion, version)
+
+	migrated, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, migrated)
+}
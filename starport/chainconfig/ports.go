@@ -0,0 +1,69 @@
+package chainconfig
+
+import (
+	"os"
+
+	"github.com/goccy/go-yaml"
+)
+
+// ShiftPortsFile adds n to the ports of the servers and the faucet in the config file at path and
+// writes it back, so chains that use the default hosts can run on the same computer. the config is
+// migrated to the latest version and comments of the file are not kept.
+func ShiftPortsFile(path string, n int) error {
+	conf, err := ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+	if _, err := migrate(raw); err != nil {
+		return err
+	}
+
+	raw["host"] = map[string]interface{}{
+		"rpc":      shiftPort(conf.Host.RPC, n),
+		"p2p":      shiftPort(conf.Host.P2P, n),
+		"prof":     shiftPort(conf.Host.Prof, n),
+		"grpc":     shiftPort(conf.Host.GRPC, n),
+		"grpc-web": shiftPort(conf.Host.GRPCWeb, n),
+		"api":      shiftPort(conf.Host.API, n),
+		"openapi":  shiftPort(conf.Host.OpenAPI, n),
+		"grpc-ui":  shiftPort(conf.Host.GRPCUI, n),
+		"graphql":  shiftPort(conf.Host.GraphQL, n),
+
+		"proxy":      shiftPort(conf.Host.Proxy, n),
+		"proxy-http": shiftPort(conf.Host.ProxyHTTP, n),
+
+		"api-recorder":  shiftPort(conf.Host.APIRecorder, n),
+		"grpc-recorder": shiftPort(conf.Host.GRPCRecorder, n),
+	}
+
+	faucet, _ := raw["faucet"].(map[string]interface{})
+	if faucet == nil {
+		faucet = make(map[string]interface{})
+	}
+	faucet["host"] = shiftPort(FaucetHost(conf), n)
+	delete(faucet, "port")
+	raw["faucet"] = faucet
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if data, err = marshalRaw(raw); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, info.Mode())
+}
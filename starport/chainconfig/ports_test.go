@@ -0,0 +1,52 @@
+package chainconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftPortsFile(t *testing.T) {
+	confyml := `version: 2
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+host:
+  rpc: "0.0.0.0:36657"
+faucet:
+  name: alice
+  port: 4600
+`
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(confyml), 0644))
+
+	require.NoError(t, ShiftPortsFile(path, 1000))
+
+	conf, err := ParseFile(path)
+	require.NoError(t, err)
+	require.Equal(t, Host{
+		RPC:     "0.0.0.0:37657",
+		P2P:     "0.0.0.0:27656",
+		Prof:    "0.0.0.0:7060",
+		GRPC:    "0.0.0.0:10090",
+		GRPCWeb: "0.0.0.0:10091",
+		API:     "0.0.0.0:2317",
+		OpenAPI: "0.0.0.0:5501",
+		GRPCUI:  "0.0.0.0:5502",
+		GraphQL: "0.0.0.0:5503",
+
+		Proxy:     "0.0.0.0:1443",
+		ProxyHTTP: "0.0.0.0:1080",
+
+		APIRecorder:  "0.0.0.0:2318",
+		GRPCRecorder: "0.0.0.0:10092",
+	}, conf.Host)
+	require.Equal(t, ":5600", FaucetHost(conf))
+	require.Equal(t, "alice", *conf.Faucet.Name)
+	require.Len(t, conf.Accounts, 1)
+}
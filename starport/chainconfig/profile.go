@@ -0,0 +1,93 @@
+package chainconfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// DefaultProfile is the name of the profile that uses the config as is, without merging any
+	// of the profiles defined in the config.
+	DefaultProfile = "default"
+
+	// keyProfiles is the key of the config that defines the profiles.
+	keyProfiles = "profiles"
+)
+
+// ErrProfileNotFound is returned when the selected profile is not defined in the config.
+var ErrProfileNotFound = errors.New("config profile not found")
+
+// ParseOption configures parsing the config.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	profile   string
+	resolvers map[string]SecretResolver
+
+	// commands enables the ${cmd:command} references.
+	commands bool
+
+	// dir is the directory of the config file.
+	dir string
+}
+
+func newParseOptions(options ...ParseOption) parseOptions {
+	var o parseOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+	return o
+}
+
+// WithProfile selects a profile defined under the profiles key of the config. the profile is deep
+// merged into the config, so it only needs to define the values that differ from the config:
+// maps are merged key by key while the other values, including lists, are replaced.
+// when no profile or the default profile is selected, the config is used as is.
+func WithProfile(name string) ParseOption {
+	return func(o *parseOptions) {
+		o.profile = name
+	}
+}
+
+// applyProfile removes the profiles from the raw config and deep merges the selected one into it.
+func applyProfile(raw map[string]interface{}, name string) (map[string]interface{}, error) {
+	profiles, _ := raw[keyProfiles].(map[string]interface{})
+	delete(raw, keyProfiles)
+
+	if name == "" || name == DefaultProfile {
+		return raw, nil
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	// a profile without any values is the same with the default profile.
+	if profile == nil {
+		return raw, nil
+	}
+
+	values, ok := profile.(map[string]interface{})
+	if !ok {
+		return nil, &ValidationError{fmt.Sprintf("profile %q must be a map of config values", name)}
+	}
+
+	return deepMerge(raw, values), nil
+}
+
+// deepMerge merges src into dst by replacing the values of dst with the ones in src, except the maps
+// that exist in both, they are merged recursively.
+func deepMerge(dst, src map[string]interface{}) map[string]interface{} {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+
+		if srcIsMap && dstIsMap {
+			dst[key] = deepMerge(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+	return dst
+}
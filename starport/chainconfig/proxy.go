@@ -0,0 +1,51 @@
+package chainconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TLS modes of the proxy.
+const (
+	// ProxyTLSSelfSigned serves a self-signed certificate generated for the hostnames.
+	ProxyTLSSelfSigned = "self-signed"
+
+	// ProxyTLSACME serves certificates obtained from an ACME CA, like Let's Encrypt, the hostnames
+	// must resolve to the machine and host.proxy and host.proxy-http must be reachable on the ports
+	// 443 and 80 to solve the challenges.
+	ProxyTLSACME = "acme"
+)
+
+// Proxy configures the reverse proxy that exposes the servers of the chain over TLS at host.proxy,
+// the requests are routed by their hostnames, e.g. rpc.<domain> for the Tendermint RPC. it's
+// enabled by serving with --public.
+type Proxy struct {
+	// Domain is the parent domain of the hostnames of the servers, e.g. devnet.example.com.
+	Domain string `yaml:"domain"`
+
+	// TLS is the source of the certificates, self-signed (default) or acme.
+	TLS string `yaml:"tls"`
+
+	// Email is the contact of the ACME account, optional.
+	Email string `yaml:"email"`
+
+	// ACMEDirectory is the directory URL of the ACME CA, default is Let's Encrypt.
+	ACMEDirectory string `yaml:"acme_directory"`
+}
+
+// Hostname returns the hostname of the server with the name, e.g. rpc.devnet.example.com.
+func (p Proxy) Hostname(name string) string {
+	return name + "." + p.Domain
+}
+
+func validateProxy(conf Config) error {
+	switch conf.Proxy.TLS {
+	case "", ProxyTLSSelfSigned, ProxyTLSACME:
+	default:
+		return &ValidationError{fmt.Sprintf("proxy tls %q must be %s or %s", conf.Proxy.TLS, ProxyTLSSelfSigned, ProxyTLSACME)}
+	}
+	if strings.ContainsAny(conf.Proxy.Domain, ":/ ") {
+		return &ValidationError{fmt.Sprintf("proxy domain %q must be a domain name without scheme or port", conf.Proxy.Domain)}
+	}
+	return nil
+}
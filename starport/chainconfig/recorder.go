@@ -0,0 +1,38 @@
+package chainconfig
+
+import (
+	"fmt"
+)
+
+// defaultRecorderMaxBodySize is the default max size of the recorded bodies of a request and its
+// response.
+const defaultRecorderMaxBodySize = 64 * 1024
+
+// Recorder configures the recorder of the requests sent to the API and the gRPC server of the
+// chain through the proxies at host.api-recorder and host.grpc-recorder, it's enabled by serving
+// with --record-requests.
+type Recorder struct {
+	// File is the path of the file the requests are recorded in as JSON lines, default is
+	// requests.jsonl in the home of the chain.
+	File string `yaml:"file"`
+
+	// SampleRate is the ratio of the requests that are recorded, greater than 0 and at most 1.
+	SampleRate float64 `yaml:"sample_rate"`
+
+	// Redact are the names of the headers, the query params and the JSON fields whose values
+	// are not recorded, on top of the Authorization, Cookie and Set-Cookie headers.
+	Redact []string `yaml:"redact"`
+
+	// MaxBodySize is the max size in bytes of the recorded bodies, larger bodies are truncated.
+	MaxBodySize int `yaml:"max_body_size"`
+}
+
+func validateRecorder(conf Config) error {
+	if conf.Recorder.SampleRate <= 0 || conf.Recorder.SampleRate > 1 {
+		return &ValidationError{fmt.Sprintf("recorder sample_rate %v must be greater than 0 and at most 1", conf.Recorder.SampleRate)}
+	}
+	if conf.Recorder.MaxBodySize < 0 {
+		return &ValidationError{fmt.Sprintf("recorder max_body_size %d can't be negative", conf.Recorder.MaxBodySize)}
+	}
+	return nil
+}
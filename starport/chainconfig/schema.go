@@ -0,0 +1,126 @@
+package chainconfig
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// SchemaURL is the URL of the JSON Schema draft that the config schema is written in.
+const SchemaURL = "http://json-schema.org/draft-07/schema#"
+
+// schemaReference is the pattern of the values that are a single reference to an environment
+// variable or a secret, they are accepted for the values that are not strings.
+const schemaReference = `^\$\{[^}]*\}$`
+
+// schemaEnums are the allowed values of the config keys.
+var schemaEnums = map[string][]string{
+	"build.proto.check.lint":     {ProtoCheckOff, ProtoCheckWarn, ProtoCheckError},
+	"build.proto.check.breaking": {ProtoCheckOff, ProtoCheckWarn, ProtoCheckError},
+	"faucet.captcha.provider":    {FaucetCaptchaHCaptcha, FaucetCaptchaTurnstile},
+	"tokenomics.preset":          {TokenomicsFixed, TokenomicsInflationary, TokenomicsCapped},
+}
+
+// schemaRequired are the required keys of the config by their parent keys.
+var schemaRequired = map[string][]string{
+	"":           {"accounts", "validators"},
+	"accounts":   {"name"},
+	"validators": {"name", "bonded"},
+}
+
+// JSONSchema returns the JSON Schema of the config, editors use it to autocomplete and validate
+// config files.
+func JSONSchema() ([]byte, error) {
+	schema := typeSchema(configType, "")
+	schema["$schema"] = SchemaURL
+	schema["title"] = "Starport config"
+
+	// profiles are written in the same schema with the config, but only with the values that
+	// differ from it.
+	profile := typeSchema(configType, "")
+	removeRequired(profile)
+	schema["properties"].(map[string]interface{})[keyProfiles] = map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": profile,
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// typeSchema returns the schema of the type for the values of the key.
+func typeSchema(t reflect.Type, key string) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := yamlKey(field)
+			properties[name] = typeSchema(field.Type, joinKey(key, name))
+		}
+
+		schema := map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		if required, ok := schemaRequired[key]; ok {
+			schema["required"] = required
+		}
+		return schema
+
+	case reflect.Slice:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": typeSchema(t.Elem(), key),
+		}
+
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+
+	case reflect.String:
+		schema := map[string]interface{}{"type": "string"}
+		if enum, ok := schemaEnums[key]; ok {
+			schema["enum"] = enum
+		}
+		return schema
+
+	case reflect.Bool:
+		return scalarSchema("boolean")
+
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return scalarSchema("integer")
+
+	case reflect.Float32, reflect.Float64:
+		return scalarSchema("number")
+
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// scalarSchema returns the schema of a non string type that accepts the references too.
+func scalarSchema(typ string) map[string]interface{} {
+	return map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"type": typ},
+			map[string]interface{}{"type": "string", "pattern": schemaReference},
+		},
+	}
+}
+
+// removeRequired removes the required keys from the schema and its sub schemas.
+func removeRequired(schema map[string]interface{}) {
+	delete(schema, "required")
+
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, property := range properties {
+			removeRequired(property.(map[string]interface{}))
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		removeRequired(items)
+	}
+}
@@ -0,0 +1,78 @@
+package chainconfig
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+)
+
+// SeedTx is a tx sent to the chain after it's initialized by serve, so the chain has the same data
+// after every reset.
+type SeedTx struct {
+	// From is the name of the account that signs the tx.
+	From string `yaml:"from"`
+
+	// Module is the module of the tx command of the chain's binary, e.g. bank.
+	Module string `yaml:"module"`
+
+	// Msg is the tx command of the module, e.g. send.
+	Msg string `yaml:"msg"`
+
+	// Args are the args and the flags of the tx command. the addresses of the accounts can be
+	// used with {{ address "name" }}, e.g. ["alice", "{{ address \"bob\" }}", "100token"].
+	Args []string `yaml:"args"`
+}
+
+// validateSeed validates the txs of the seed, they must be signed by the accounts whose keys
+// are created by the config or by the test accounts.
+func validateSeed(conf Config) error {
+	if err := validateSeedTxs(conf.Seed); err != nil {
+		return err
+	}
+	for i, tx := range conf.Seed {
+		if conf.TestAccounts.Has(tx.From) {
+			continue
+		}
+		account, ok := conf.AccountByName(tx.From)
+		if !ok {
+			return &ValidationError{fmt.Sprintf("seed[%d] is signed by %q, which is not one of the accounts", i, tx.From)}
+		}
+		if account.Address != "" {
+			return &ValidationError{fmt.Sprintf("seed[%d] is signed by %q, which has no key since its address is set", i, tx.From)}
+		}
+	}
+	return nil
+}
+
+func validateSeedTxs(txs []SeedTx) error {
+	for i, tx := range txs {
+		switch {
+		case tx.From == "":
+			return &ValidationError{fmt.Sprintf("the from account of seed[%d] is required", i)}
+		case tx.Module == "":
+			return &ValidationError{fmt.Sprintf("the module of seed[%d] is required", i)}
+		case tx.Msg == "":
+			return &ValidationError{fmt.Sprintf("the msg of seed[%d] is required", i)}
+		}
+	}
+	return nil
+}
+
+// ParseSeedFile parses the seed file at path, it has a list of txs in the format of the seed of
+// the config.
+func ParseSeedFile(path string) ([]SeedTx, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []SeedTx
+	if err := yaml.UnmarshalWithOptions(data, &txs, yaml.Strict()); err != nil {
+		return nil, err
+	}
+	if err := validateSeedTxs(txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
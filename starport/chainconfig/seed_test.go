@@ -0,0 +1,40 @@
+package chainconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+- from: alice
+  module: blog
+  msg: create-post
+  args: ["hello", "world"]
+`), 0644))
+
+	txs, err := ParseSeedFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []SeedTx{{From: "alice", Module: "blog", Msg: "create-post", Args: []string{"hello", "world"}}}, txs)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+- from: alice
+  module: blog
+`), 0644))
+
+	_, err = ParseSeedFile(path)
+	require.Equal(t, &ValidationError{"the msg of seed[0] is required"}, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+- from: alice
+  modul: blog
+`), 0644))
+
+	_, err = ParseSeedFile(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package chainconfig
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+)
+
+const (
+	// DefaultTestAccountsMnemonic is the mnemonic the test accounts are derived from by default, it's
+	// well known so the keys of the test accounts must never hold real funds.
+	DefaultTestAccountsMnemonic = "test test test test test test test test test test test junk"
+
+	// DefaultTestAccountsPath is the path of the file the test accounts are exported to by default.
+	DefaultTestAccountsPath = "test_accounts.json"
+
+	// defaultTestAccountsCoinType is the BIP44 coin type of the test accounts by default.
+	defaultTestAccountsCoinType = 118
+)
+
+// TestAccounts derives a set of funded accounts from a mnemonic at genesis, so the accounts are the
+// same after every reset of the chain. the accounts are named test-0, test-1 and so on, and derived
+// with their indexes in the HD path.
+type TestAccounts struct {
+	// Count is the number of the test accounts.
+	Count int `yaml:"count"`
+
+	// Mnemonic is the mnemonic the accounts are derived from, default is DefaultTestAccountsMnemonic.
+	Mnemonic string `yaml:"mnemonic"`
+
+	// Coins are the initial coins of each account.
+	Coins []string `yaml:"coins"`
+
+	// CoinType is the BIP44 coin type of the HD path of the keys, default is 118.
+	CoinType int `yaml:"coin_type"`
+
+	// Path is the path of the JSON file the names, the addresses and the keys of the accounts are
+	// exported to, relative to the app.
+	Path string `yaml:"path"`
+}
+
+// Name returns the name of the test account at the index.
+func (t TestAccounts) Name(index int) string {
+	return fmt.Sprintf("test-%d", index)
+}
+
+// Has checks if the name is one of the names of the test accounts.
+func (t TestAccounts) Has(name string) bool {
+	for i := 0; i < t.Count; i++ {
+		if t.Name(i) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MnemonicOrDefault returns the mnemonic of the test accounts, or the default one when it's not set.
+func (t TestAccounts) MnemonicOrDefault() string {
+	if t.Mnemonic != "" {
+		return t.Mnemonic
+	}
+	return DefaultTestAccountsMnemonic
+}
+
+// CoinTypeOrDefault returns the coin type of the test accounts, or 118 when it's not set.
+func (t TestAccounts) CoinTypeOrDefault() uint32 {
+	if t.CoinType != 0 {
+		return uint32(t.CoinType)
+	}
+	return defaultTestAccountsCoinType
+}
+
+// PathOrDefault returns the export path of the test accounts, or the default one when it's not set.
+func (t TestAccounts) PathOrDefault() string {
+	if t.Path != "" {
+		return t.Path
+	}
+	return DefaultTestAccountsPath
+}
+
+// validateTestAccounts validates the test accounts, they need coins to be funded and their names
+// can't be the names of the accounts.
+func validateTestAccounts(conf Config) error {
+	t := conf.TestAccounts
+	switch {
+	case t.Count < 0:
+		return &ValidationError{"the count of test_accounts can't be negative"}
+	case t.Count == 0:
+		return nil
+	case len(t.Coins) == 0:
+		return &ValidationError{"the coins of test_accounts are required"}
+	case !bip39.IsMnemonicValid(t.MnemonicOrDefault()):
+		return &ValidationError{"the mnemonic of test_accounts is not valid"}
+	case t.CoinType < 0:
+		return &ValidationError{"the coin type of test_accounts can't be negative"}
+	}
+	for _, coin := range t.Coins {
+		if _, err := sdk.ParseCoinNormalized(coin); err != nil {
+			return &ValidationError{fmt.Sprintf("invalid coin %q of test_accounts: %s", coin, err)}
+		}
+	}
+	for _, account := range conf.Accounts {
+		if t.Has(account.Name) {
+			return &ValidationError{fmt.Sprintf("account %q has the name of a test account", account.Name)}
+		}
+	}
+	return nil
+}
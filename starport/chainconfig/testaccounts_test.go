@@ -0,0 +1,74 @@
+package chainconfig
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTestAccounts(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+test_accounts:
+%s
+`
+
+	for _, tt := range []struct {
+		testAccounts string
+		err          string
+	}{
+		{`  count: -1`, "the count of test_accounts can't be negative"},
+		{`  count: 2`, "the coins of test_accounts are required"},
+		{`  count: 2
+  coins: ["10"]`, `invalid coin "10" of test_accounts: invalid decimal coin expression: 10`},
+		{`  count: 2
+  coins: ["10token"]
+  mnemonic: "test test test"`, "the mnemonic of test_accounts is not valid"},
+	} {
+		_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, tt.testAccounts)))
+		require.Equal(t, &ValidationError{tt.err}, err)
+	}
+
+	conf, err := Parse(strings.NewReader(fmt.Sprintf(confyml, `  count: 2
+  coins: ["10token"]`)))
+	require.NoError(t, err)
+	require.Equal(t, TestAccounts{Count: 2, Coins: []string{"10token"}}, conf.TestAccounts)
+	require.Equal(t, DefaultTestAccountsMnemonic, conf.TestAccounts.MnemonicOrDefault())
+	require.Equal(t, uint32(118), conf.TestAccounts.CoinTypeOrDefault())
+	require.Equal(t, DefaultTestAccountsPath, conf.TestAccounts.PathOrDefault())
+	require.True(t, conf.TestAccounts.Has("test-1"))
+	require.False(t, conf.TestAccounts.Has("test-2"))
+}
+
+func TestParseTestAccountsConflicts(t *testing.T) {
+	confyml := `
+accounts:
+  - name: %s
+    coins: ["100000000stake"]
+validators:
+  - name: %[1]s
+    bonded: "100000000stake"
+test_accounts:
+  count: 2
+  coins: ["10token"]
+seed:
+  - from: test-1
+    module: bank
+    msg: send
+`
+
+	_, err := Parse(strings.NewReader(fmt.Sprintf(confyml, "test-0")))
+	require.Equal(t, &ValidationError{`account "test-0" has the name of a test account`}, err)
+
+	// the test accounts sign the seed txs.
+	conf, err := Parse(strings.NewReader(fmt.Sprintf(confyml, "alice")))
+	require.NoError(t, err)
+	require.Equal(t, "test-1", conf.Seed[0].From)
+}
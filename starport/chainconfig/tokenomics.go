@@ -0,0 +1,329 @@
+package chainconfig
+
+import (
+	"fmt"
+	"os"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// TokenomicsFixed mints no coins, the supply is the coins of the accounts in the genesis.
+	TokenomicsFixed = "fixed"
+
+	// TokenomicsInflationary mints coins with an inflation between 7% and 20% that targets 67% of
+	// the supply bonded, like the Cosmos Hub.
+	TokenomicsInflationary = "inflationary"
+
+	// TokenomicsCapped mints coins with an inflation that starts at 10% and declines to 0%, the
+	// coins of the genesis can't exceed the max supply.
+	TokenomicsCapped = "capped"
+)
+
+// Tokenomics configures the mint and distribution params of the genesis with a preset.
+type Tokenomics struct {
+	// Preset is the model of the supply, one of: fixed, inflationary, capped.
+	Preset string `yaml:"preset"`
+
+	// Denom is the denom minted by the chain, default is the mint denom of the genesis or the denom
+	// of the first validator's bonded coins.
+	Denom string `yaml:"denom,omitempty"`
+
+	// Supply is the supply of the genesis, e.g. 1000000stake. when it's set, the coins of the
+	// accounts in the mint denom must add up to it.
+	Supply string `yaml:"supply,omitempty"`
+
+	// MaxSupply is the max supply of the capped preset, e.g. 2000000stake.
+	MaxSupply string `yaml:"max_supply,omitempty"`
+}
+
+// tokenomicsPreset are the params of the mint and distribution modules for a preset.
+type tokenomicsPreset struct {
+	inflation           string
+	inflationRateChange string
+	inflationMax        string
+	inflationMin        string
+	goalBonded          string
+	communityTax        string
+	baseProposerReward  string
+	bonusProposerReward string
+}
+
+// the mint module of the SDK has no hard cap, the capped preset lowers the inflation down to 0%
+// as the bonded ratio grows and the max supply is only checked for the genesis.
+var tokenomicsPresets = map[string]tokenomicsPreset{
+	TokenomicsFixed: {
+		inflation:           "0.000000000000000000",
+		inflationRateChange: "0.000000000000000000",
+		inflationMax:        "0.000000000000000000",
+		inflationMin:        "0.000000000000000000",
+		goalBonded:          "0.670000000000000000",
+		communityTax:        "0.000000000000000000",
+		baseProposerReward:  "0.010000000000000000",
+		bonusProposerReward: "0.040000000000000000",
+	},
+	TokenomicsInflationary: {
+		inflation:           "0.130000000000000000",
+		inflationRateChange: "0.130000000000000000",
+		inflationMax:        "0.200000000000000000",
+		inflationMin:        "0.070000000000000000",
+		goalBonded:          "0.670000000000000000",
+		communityTax:        "0.020000000000000000",
+		baseProposerReward:  "0.010000000000000000",
+		bonusProposerReward: "0.040000000000000000",
+	},
+	TokenomicsCapped: {
+		inflation:           "0.100000000000000000",
+		inflationRateChange: "0.100000000000000000",
+		inflationMax:        "0.100000000000000000",
+		inflationMin:        "0.000000000000000000",
+		goalBonded:          "0.670000000000000000",
+		communityTax:        "0.020000000000000000",
+		baseProposerReward:  "0.010000000000000000",
+		bonusProposerReward: "0.040000000000000000",
+	},
+}
+
+// genesis keys set by the tokenomics presets.
+var tokenomicsGenesisKeys = []string{
+	"app_state.mint.minter.inflation",
+	"app_state.mint.params.inflation_rate_change",
+	"app_state.mint.params.inflation_max",
+	"app_state.mint.params.inflation_min",
+	"app_state.mint.params.goal_bonded",
+	"app_state.distribution.params.community_tax",
+	"app_state.distribution.params.base_proposer_reward",
+	"app_state.distribution.params.bonus_proposer_reward",
+}
+
+// MintDenom returns the denom minted by the chain: the denom of the tokenomics, the mint denom of
+// the genesis or the denom of the first validator's bonded coins. it's empty when none is set.
+func (c Config) MintDenom() string {
+	if c.Tokenomics.Denom != "" {
+		return c.Tokenomics.Denom
+	}
+	if denom, ok := genesisValue(c.Genesis, genesisMintDenom).(string); ok {
+		return denom
+	}
+	if len(c.Validators) > 0 {
+		if bonded, err := sdk.ParseCoinNormalized(c.Validators[0].Bonded); err == nil {
+			return bonded.Denom
+		}
+	}
+	return ""
+}
+
+// AccountsSupply returns the sum of the accounts' coins in denom, the coins that can't be parsed
+// are skipped.
+func (c Config) AccountsSupply(denom string) sdk.Int {
+	supply := sdk.ZeroInt()
+	for _, account := range c.Accounts {
+		supply = supply.Add(coinsAmountOf(account.Coins, denom))
+	}
+	return supply
+}
+
+func coinsAmountOf(coins []string, denom string) sdk.Int {
+	amount := sdk.ZeroInt()
+	for _, coin := range coins {
+		if parsed, err := sdk.ParseCoinNormalized(coin); err == nil && parsed.Denom == denom {
+			amount = amount.Add(parsed.Amount)
+		}
+	}
+	return amount
+}
+
+// validateTokenomics validates the tokenomics, and that the coins of the accounts and the faucet
+// fit the supply of the preset: the accounts add up to the supply, the supply doesn't exceed the max
+// supply and the faucet account holds the coins distributed by the faucet, since a faucet doesn't mint.
+func validateTokenomics(conf Config) error {
+	t := conf.Tokenomics
+	if t == (Tokenomics{}) {
+		return nil
+	}
+
+	if _, ok := tokenomicsPresets[t.Preset]; !ok {
+		return &ValidationError{fmt.Sprintf(
+			"invalid tokenomics preset %q, must be one of: %s, %s, %s",
+			t.Preset,
+			TokenomicsFixed,
+			TokenomicsInflationary,
+			TokenomicsCapped,
+		)}
+	}
+
+	denom := conf.MintDenom()
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return &ValidationError{fmt.Sprintf("invalid tokenomics denom %q: %s", denom, err)}
+	}
+	if genesisDenom, ok := genesisValue(conf.Genesis, genesisMintDenom).(string); ok && genesisDenom != denom {
+		return &ValidationError{fmt.Sprintf(
+			"tokenomics denom %q is not the mint denom %q of the genesis",
+			denom,
+			genesisDenom,
+		)}
+	}
+
+	parse := func(key, coin string) (sdk.Coin, error) {
+		parsed, err := sdk.ParseCoinNormalized(coin)
+		if err != nil {
+			return sdk.Coin{}, &ValidationError{fmt.Sprintf("invalid tokenomics %s %q: %s", key, coin, err)}
+		}
+		if parsed.Denom != denom {
+			return sdk.Coin{}, &ValidationError{fmt.Sprintf(
+				"tokenomics %s %q is not in the mint denom %q",
+				key,
+				coin,
+				denom,
+			)}
+		}
+		return parsed, nil
+	}
+
+	accountsSupply := conf.AccountsSupply(denom)
+
+	if t.Supply != "" {
+		supply, err := parse("supply", t.Supply)
+		if err != nil {
+			return err
+		}
+		if !supply.Amount.Equal(accountsSupply) {
+			return &ValidationError{fmt.Sprintf(
+				"the coins of the accounts add up to %s%s, but the tokenomics supply is %s",
+				accountsSupply,
+				denom,
+				supply,
+			)}
+		}
+	}
+
+	switch {
+	case t.Preset == TokenomicsCapped && t.MaxSupply == "":
+		return &ValidationError{"tokenomics max_supply is required for the capped preset"}
+	case t.Preset != TokenomicsCapped && t.MaxSupply != "":
+		return &ValidationError{"tokenomics max_supply can only be set for the capped preset"}
+	case t.MaxSupply != "":
+		maxSupply, err := parse("max_supply", t.MaxSupply)
+		if err != nil {
+			return err
+		}
+		if accountsSupply.GT(maxSupply.Amount) {
+			return &ValidationError{fmt.Sprintf(
+				"the coins of the accounts add up to %s%s, which exceeds the tokenomics max supply %s",
+				accountsSupply,
+				denom,
+				maxSupply,
+			)}
+		}
+	}
+
+	if conf.Faucet.Name != nil {
+		if faucetAmount := coinsAmountOf(conf.Faucet.Coins, denom); faucetAmount.IsPositive() {
+			account, _ := conf.AccountByName(*conf.Faucet.Name)
+			if balance := coinsAmountOf(account.Coins, denom); balance.LT(faucetAmount) {
+				return &ValidationError{fmt.Sprintf(
+					"faucet account %q holds %s%s, which is less than the %s%s of faucet.coins",
+					*conf.Faucet.Name,
+					balance,
+					denom,
+					faucetAmount,
+					denom,
+				)}
+			}
+		}
+	}
+
+	for _, key := range tokenomicsGenesisKeys {
+		if genesisValue(conf.Genesis, key) != nil {
+			return &ValidationError{fmt.Sprintf(
+				"genesis.%s is set by the tokenomics preset, remove it from the genesis",
+				key,
+			)}
+		}
+	}
+
+	return nil
+}
+
+// TokenomicsGenesis returns the changes of the genesis for the tokenomics preset: the inflation
+// and the params of the mint module and the params of the distribution module. it returns nil when
+// no preset is set.
+func (c Config) TokenomicsGenesis() map[string]interface{} {
+	preset, ok := tokenomicsPresets[c.Tokenomics.Preset]
+	if !ok {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"app_state": map[string]interface{}{
+			"mint": map[string]interface{}{
+				"minter": map[string]interface{}{
+					"inflation": preset.inflation,
+				},
+				"params": map[string]interface{}{
+					"mint_denom":            c.MintDenom(),
+					"inflation_rate_change": preset.inflationRateChange,
+					"inflation_max":         preset.inflationMax,
+					"inflation_min":         preset.inflationMin,
+					"goal_bonded":           preset.goalBonded,
+				},
+			},
+			"distribution": map[string]interface{}{
+				"params": map[string]interface{}{
+					"community_tax":         preset.communityTax,
+					"base_proposer_reward":  preset.baseProposerReward,
+					"bonus_proposer_reward": preset.bonusProposerReward,
+				},
+			},
+		},
+	}
+}
+
+// SetTokenomicsFile sets the tokenomics of the config file at path and writes it back. when the
+// supply is not set, it's set to the coins of the accounts in the mint denom. the config with the
+// tokenomics is validated before it's written, it's migrated to the latest version and comments
+// of the file are not kept.
+func SetTokenomicsFile(path string, tokenomics Tokenomics) error {
+	conf, err := ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	if tokenomics.Supply == "" {
+		denom := conf.MintDenom()
+		tokenomics.Supply = conf.AccountsSupply(denom).String() + denom
+	}
+	conf.Tokenomics = tokenomics
+	if err := validateTokenomics(conf); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+	if _, err := migrate(raw); err != nil {
+		return err
+	}
+
+	raw["tokenomics"] = tokenomics
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if data, err = marshalRaw(raw); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, info.Mode())
+}
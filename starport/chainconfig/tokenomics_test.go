@@ -0,0 +1,42 @@
+package chainconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTokenomicsFile(t *testing.T) {
+	confyml := `version: 2
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+  - name: bob
+    coins: ["50000000stake"]
+validators:
+  - name: alice
+    bonded: "100000000stake"
+`
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(confyml), 0644))
+
+	require.NoError(t, SetTokenomicsFile(path, Tokenomics{Preset: TokenomicsCapped, MaxSupply: "200000000stake"}))
+
+	conf, err := ParseFile(path)
+	require.NoError(t, err)
+	require.Equal(t, Tokenomics{
+		Preset:    TokenomicsCapped,
+		Supply:    "150000000stake",
+		MaxSupply: "200000000stake",
+	}, conf.Tokenomics)
+	require.Len(t, conf.Accounts, 2)
+
+	err = SetTokenomicsFile(path, Tokenomics{Preset: TokenomicsFixed, Supply: "100stake"})
+	require.Equal(t, &ValidationError{"the coins of the accounts add up to 150000000stake, but the tokenomics supply is 100stake"}, err)
+
+	conf, err = ParseFile(path)
+	require.NoError(t, err)
+	require.Equal(t, TokenomicsCapped, conf.Tokenomics.Preset)
+}
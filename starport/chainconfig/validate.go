@@ -0,0 +1,277 @@
+package chainconfig
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// configType is the type of the config that the keys of the raw configs are matched with.
+var configType = reflect.TypeOf(Config{})
+
+// ValidationErrors is returned when a configuration has one or more issues.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return fmt.Sprintf("config is not valid: %s", strings.Join(messages, "; "))
+}
+
+// Validate parses the config and validates it fully. unlike Parse, it reports the unknown keys of
+// the config and its profiles, the invalid coins, the duplicate accounts and the conflicting
+// ports as well. all of the found issues are returned within ValidationErrors, other errors are
+// returned when the config cannot be parsed.
+func Validate(r io.Reader, options ...ParseOption) error {
+	o := newParseOptions(options...)
+
+	raw, err := decodeRaw(r)
+	if err != nil {
+		return err
+	}
+
+	var errs ValidationErrors
+
+	for _, name := range sortedKeys(raw) {
+		if name == keyProfiles {
+			continue
+		}
+		errs = append(errs, unknownKeys(raw[name], fieldType(configType, name), name)...)
+	}
+	profiles, _ := raw[keyProfiles].(map[string]interface{})
+	for _, name := range sortedKeys(profiles) {
+		errs = append(errs, unknownKeys(profiles[name], configType, joinKey(keyProfiles, name))...)
+	}
+
+	if raw, err = resolveRaw(raw, o); err != nil {
+		return err
+	}
+
+	conf, err := fromRaw(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := validate(conf); err != nil {
+		if validationErr, ok := err.(*ValidationError); ok {
+			errs = append(errs, validationErr)
+		} else {
+			return err
+		}
+	}
+	errs = append(errs, validateAccounts(conf)...)
+	errs = append(errs, validatePorts(conf)...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateFile parses the config file at path and validates it fully.
+func ValidateFile(path string, options ...ParseOption) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return Validate(file, append([]ParseOption{withDir(filepath.Dir(path))}, options...)...)
+}
+
+// validateAccounts validates the coins of the accounts and the validators, and that the accounts
+// used by the validators and the faucet are defined once.
+func validateAccounts(conf Config) (errs ValidationErrors) {
+	names := make(map[string]bool)
+	for i, account := range conf.Accounts {
+		if names[account.Name] {
+			errs = append(errs, &ValidationError{fmt.Sprintf("account %q is defined more than once", account.Name)})
+		}
+		names[account.Name] = true
+
+		for _, coin := range account.Coins {
+			if _, err := sdk.ParseCoinNormalized(coin); err != nil {
+				errs = append(errs, &ValidationError{fmt.Sprintf("invalid coin %q of accounts[%d]: %s", coin, i, err)})
+			}
+		}
+	}
+
+	for i, validator := range conf.Validators {
+		if validator.Name != "" && !names[validator.Name] {
+			errs = append(errs, &ValidationError{fmt.Sprintf("validator account %q is not one of the accounts", validator.Name)})
+		}
+		if _, err := sdk.ParseCoinNormalized(validator.Bonded); err != nil {
+			errs = append(errs, &ValidationError{fmt.Sprintf("invalid bonded coin %q of validators[%d]: %s", validator.Bonded, i, err)})
+		}
+		gentx := validator.Gentx
+		if err := ValidateCommission(gentx.CommissionRate, gentx.CommissionMaxRate, gentx.CommissionMaxChangeRate); err != nil {
+			errs = append(errs, &ValidationError{fmt.Sprintf("invalid commission of validators[%d]: %s", i, err)})
+		}
+		if gentx.MinSelfDelegation != "" {
+			if n, ok := sdk.NewIntFromString(gentx.MinSelfDelegation); !ok || !n.IsPositive() {
+				errs = append(errs, &ValidationError{fmt.Sprintf("invalid min self delegation %q of validators[%d]", gentx.MinSelfDelegation, i)})
+			}
+		}
+	}
+	if conf.Faucet.Name != nil && !names[*conf.Faucet.Name] {
+		errs = append(errs, &ValidationError{fmt.Sprintf("faucet account %q is not one of the accounts", *conf.Faucet.Name)})
+	}
+
+	return errs
+}
+
+// ValidateCommission validates the commission rates of a validator, the rates must be between 0
+// and 1, the rate and the max change rate can't exceed the max rate. the rates that are empty use
+// the defaults of the chain's binary and are not checked.
+func ValidateCommission(rate, maxRate, maxChangeRate string) error {
+	parse := func(name, value string) (sdk.Dec, error) {
+		if value == "" {
+			return sdk.Dec{}, nil
+		}
+		d, err := sdk.NewDecFromStr(value)
+		if err != nil {
+			return d, fmt.Errorf("invalid commission %s %q", name, value)
+		}
+		if d.IsNegative() || d.GT(sdk.OneDec()) {
+			return d, fmt.Errorf("commission %s %q must be between 0 and 1", name, value)
+		}
+		return d, nil
+	}
+
+	r, err := parse("rate", rate)
+	if err != nil {
+		return err
+	}
+	max, err := parse("max rate", maxRate)
+	if err != nil {
+		return err
+	}
+	change, err := parse("max change rate", maxChangeRate)
+	if err != nil {
+		return err
+	}
+
+	if maxRate == "" {
+		return nil
+	}
+	if rate != "" && r.GT(max) {
+		return fmt.Errorf("commission rate %s can't exceed the max rate %s", rate, maxRate)
+	}
+	if maxChangeRate != "" && change.GT(max) {
+		return fmt.Errorf("commission max change rate %s can't exceed the max rate %s", maxChangeRate, maxRate)
+	}
+	return nil
+}
+
+// validatePorts validates the addresses of the servers of the validators' nodes and the chain
+// services, and that they don't listen on the same port.
+func validatePorts(conf Config) (errs ValidationErrors) {
+	type address struct{ key, address string }
+
+	var hosts []address
+	for i := range conf.Validators {
+		key := "host"
+		if i > 0 {
+			key = fmt.Sprintf("validators[%d].host", i)
+		}
+
+		host := conf.ValidatorHost(i)
+		hosts = append(hosts,
+			address{key + ".rpc", host.RPC},
+			address{key + ".p2p", host.P2P},
+			address{key + ".prof", host.Prof},
+			address{key + ".grpc", host.GRPC},
+			address{key + ".grpc-web", host.GRPCWeb},
+			address{key + ".api", host.API},
+		)
+	}
+	hosts = append(hosts,
+		address{"host.openapi", conf.Host.OpenAPI},
+		address{"host.grpc-ui", conf.Host.GRPCUI},
+		address{"host.graphql", conf.Host.GraphQL},
+		address{"host.proxy", conf.Host.Proxy},
+		address{"host.proxy-http", conf.Host.ProxyHTTP},
+		address{"host.api-recorder", conf.Host.APIRecorder},
+		address{"host.grpc-recorder", conf.Host.GRPCRecorder},
+		address{"faucet.host", FaucetHost(conf)},
+	)
+
+	ports := make(map[string]string)
+	for _, host := range hosts {
+		_, port, err := net.SplitHostPort(host.address)
+		if err != nil {
+			errs = append(errs, &ValidationError{fmt.Sprintf("invalid address %q of %s: %s", host.address, host.key, err)})
+			continue
+		}
+		if key, ok := ports[port]; ok {
+			errs = append(errs, &ValidationError{fmt.Sprintf("%s and %s use the same port %s", key, host.key, port)})
+			continue
+		}
+		ports[port] = host.key
+	}
+
+	return errs
+}
+
+// unknownKeys returns the keys of the raw value that are not defined by the type.
+func unknownKeys(value interface{}, t reflect.Type, key string) (errs ValidationErrors) {
+	if t == nil {
+		return ValidationErrors{{fmt.Sprintf("unknown key %s", key)}}
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		values, _ := value.(map[string]interface{})
+		for _, name := range sortedKeys(values) {
+			errs = append(errs, unknownKeys(values[name], fieldType(t, name), joinKey(key, name))...)
+		}
+
+	case reflect.Slice:
+		items, _ := value.([]interface{})
+		for i, item := range items {
+			errs = append(errs, unknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", key, i))...)
+		}
+	}
+
+	return errs
+}
+
+// fieldType returns the type of the struct field with the yaml key, nil is returned if there is
+// no such field.
+func fieldType(t reflect.Type, key string) reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); yamlKey(field) == key {
+			return field.Type
+		}
+	}
+	return nil
+}
+
+// yamlKey returns the yaml key of the struct field.
+func yamlKey(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,109 @@
+package chainconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	confyml := `
+version: 1
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+  - name: bob
+    coins: ["5000token"]
+validator:
+  name: alice
+  staked: "100000000stake"
+faucet:
+  name: bob
+  coins: ["5token"]
+genesis:
+  chain_id: "mars"
+profiles:
+  ci:
+    host:
+      rpc: ":36657"
+`
+
+	require.NoError(t, Validate(strings.NewReader(confyml)))
+	require.NoError(t, Validate(strings.NewReader(confyml), WithProfile("ci")))
+}
+
+func TestValidateIssues(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+    passphrase: "secret"
+  - name: alice
+    coins: ["5000-token"]
+validator:
+  name: carol
+  staked: "100000000stake"
+faucet:
+  name: bob
+  host: ":26657"
+build:
+  protos: "proto"
+profiles:
+  ci:
+    hosts:
+      rpc: ":36657"
+`
+
+	err := Validate(strings.NewReader(confyml))
+
+	var errs ValidationErrors
+	require.True(t, errors.As(err, &errs))
+
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Message)
+	}
+	require.Equal(t, []string{
+		"unknown key accounts[0].passphrase",
+		"unknown key build.protos",
+		"unknown key profiles.ci.hosts",
+		`account "alice" is defined more than once`,
+		`invalid coin "5000-token" of accounts[1]: invalid decimal coin expression: 5000-token`,
+		`validator account "carol" is not one of the accounts`,
+		`faucet account "bob" is not one of the accounts`,
+		"host.rpc and faucet.host use the same port 26657",
+	}, messages)
+}
+
+func TestValidateCommission(t *testing.T) {
+	require.NoError(t, ValidateCommission("", "", ""))
+	require.NoError(t, ValidateCommission("0.1", "0.2", "0.01"))
+	require.NoError(t, ValidateCommission("0.5", "", ""))
+	require.EqualError(t, ValidateCommission("ten", "", ""), `invalid commission rate "ten"`)
+	require.EqualError(t, ValidateCommission("", "1.5", ""), `commission max rate "1.5" must be between 0 and 1`)
+	require.EqualError(t, ValidateCommission("0.3", "0.2", ""), "commission rate 0.3 can't exceed the max rate 0.2")
+	require.EqualError(t, ValidateCommission("", "0.2", "0.3"), "commission max change rate 0.3 can't exceed the max rate 0.2")
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		Schema     string   `json:"$schema"`
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Properties           map[string]json.RawMessage `json:"properties"`
+			AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	require.Equal(t, SchemaURL, schema.Schema)
+	require.Equal(t, []string{"accounts", "validators"}, schema.Required)
+	require.Contains(t, schema.Properties["faucet"].Properties, "host")
+	require.Contains(t, schema.Properties["host"].Properties, "grpc-web")
+	require.Contains(t, string(schema.Properties[keyProfiles].AdditionalProperties), `"validators"`)
+}
@@ -1,8 +1,11 @@
 package starportcmd
 
 import (
-	"os"
+	"fmt"
+	"io"
 
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -17,6 +20,9 @@ const (
 	flagNonInteractive = "non-interactive"
 	flagKeyringBackend = "keyring-backend"
 	flagFrom           = "from"
+	flagHDPath         = "hd-path"
+	flagCoinType       = "coin-type"
+	flagKeyType        = "key-type"
 )
 
 func NewAccount() *cobra.Command {
@@ -30,6 +36,7 @@ Starport uses accounts to interact with the Starport Network blockchain, use an
 	}
 
 	c.AddCommand(NewAccountCreate())
+	c.AddCommand(NewAccountCreateMultisig())
 	c.AddCommand(NewAccountDelete())
 	c.AddCommand(NewAccountShow())
 	c.AddCommand(NewAccountList())
@@ -39,12 +46,95 @@ Starport uses accounts to interact with the Starport Network blockchain, use an
 	return c
 }
 
+var accountHeader = []string{"name", "address", "public key"}
+
+// accountOutput is an account as printed in the JSON and YAML formats.
+type accountOutput struct {
+	Name      string          `json:"name"`
+	Address   string          `json:"address"`
+	PublicKey string          `json:"public_key"`
+	Multisig  *multisigOutput `json:"multisig,omitempty"`
+}
+
+// multisigOutput is the threshold and the members of a multisig account.
+type multisigOutput struct {
+	Threshold int             `json:"threshold"`
+	Members   []accountOutput `json:"members"`
+}
+
+func newAccountOutput(cmd *cobra.Command, acc cosmosaccount.Account) accountOutput {
+	return accountOutput{
+		Name:      acc.Name,
+		Address:   acc.Address(getAddressPrefix(cmd)),
+		PublicKey: acc.PubKey(),
+	}
+}
+
 func printAccounts(cmd *cobra.Command, accounts ...cosmosaccount.Account) error {
-	var accEntries [][]string
+	outputs := make([]accountOutput, 0, len(accounts))
 	for _, acc := range accounts {
-		accEntries = append(accEntries, []string{acc.Name, acc.Address(getAddressPrefix(cmd)), acc.PubKey()})
+		outputs = append(outputs, newAccountOutput(cmd, acc))
 	}
-	return entrywriter.MustWrite(os.Stdout, []string{"name", "address", "public key"}, accEntries...)
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		return writeAccounts(out, outputs)
+	})
+}
+
+func writeAccounts(out io.Writer, accounts []accountOutput) error {
+	var entries [][]string
+	for _, acc := range accounts {
+		entries = append(entries, []string{acc.Name, acc.Address, acc.PublicKey})
+	}
+	return entrywriter.MustWrite(out, accountHeader, entries...)
+}
+
+// printAccount prints an account, the members of a multisig account are printed with it.
+func printAccount(cmd *cobra.Command, registry cosmosaccount.Registry, acc cosmosaccount.Account) error {
+	output := newAccountOutput(cmd, acc)
+	if acc.IsMultisig() {
+		multisig, err := newMultisigOutput(cmd, registry, acc)
+		if err != nil {
+			return err
+		}
+		output.Multisig = &multisig
+	}
+
+	return printOutput(cmd, output, func(out io.Writer) error {
+		if err := writeAccounts(out, []accountOutput{output}); err != nil {
+			return err
+		}
+		if output.Multisig == nil {
+			return nil
+		}
+		fmt.Fprintf(out, "\nMembers, %d of them need to sign a transaction:\n\n", output.Multisig.Threshold)
+		return writeAccounts(out, output.Multisig.Members)
+	})
+}
+
+// newMultisigOutput returns the members of a multisig account, members that are in the registry
+// are returned with their names.
+func newMultisigOutput(cmd *cobra.Command, registry cosmosaccount.Registry, acc cosmosaccount.Account) (multisigOutput, error) {
+	threshold, pubKeys, err := acc.Multisig()
+	if err != nil {
+		return multisigOutput{}, err
+	}
+
+	members := make([]accountOutput, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		name := "-"
+		if info, err := registry.Keyring.KeyByAddress(sdktypes.AccAddress(pubKey.Address())); err == nil {
+			name = info.GetName()
+		}
+
+		address, err := sdktypes.Bech32ifyAddressBytes(getAddressPrefix(cmd), pubKey.Address())
+		if err != nil {
+			return multisigOutput{}, err
+		}
+
+		members = append(members, accountOutput{Name: name, Address: address, PublicKey: pubKey.String()})
+	}
+
+	return multisigOutput{Threshold: threshold, Members: members}, nil
 }
 
 func flagSetKeyringBackend() *flag.FlagSet {
@@ -74,6 +164,32 @@ func getFrom(cmd *cobra.Command) string {
 	return prefix
 }
 
+func flagSetAccountKey() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagHDPath, "", "HD path to derive the private key from the mnemonic, e.g. m/44'/118'/0'/0/0")
+	fs.Uint32(flagCoinType, sdktypes.CoinType, "Coin type of the default HD path, it's ignored when --hd-path is set (default 60 for eth_secp256k1 keys)")
+	fs.String(flagKeyType, string(hd.Secp256k1Type), "Type of the private key: secp256k1 or eth_secp256k1 for Ethermint based chains")
+	return fs
+}
+
+func getAccountOptions(cmd *cobra.Command) []cosmosaccount.AccountOption {
+	var (
+		hdPath, _   = cmd.Flags().GetString(flagHDPath)
+		coinType, _ = cmd.Flags().GetUint32(flagCoinType)
+		keyType, _  = cmd.Flags().GetString(flagKeyType)
+		options     = []cosmosaccount.AccountOption{cosmosaccount.WithKeyType(hd.PubKeyType(keyType))}
+	)
+
+	switch {
+	case hdPath != "":
+		options = append(options, cosmosaccount.WithHDPath(hdPath))
+	case cmd.Flags().Changed(flagCoinType):
+		options = append(options, cosmosaccount.WithCoinType(coinType))
+	}
+
+	return options
+}
+
 func flagSetAccountImportExport() *flag.FlagSet {
 	fs := flag.NewFlagSet("", flag.ContinueOnError)
 	fs.Bool(flagNonInteractive, false, "Do not enter into interactive mode")
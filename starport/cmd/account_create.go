@@ -17,6 +17,7 @@ func NewAccountCreate() *cobra.Command {
 	}
 
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountKey())
 
 	return c
 }
@@ -31,7 +32,7 @@ func accountCreateHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	_, mnemonic, err := ca.Create(name)
+	_, mnemonic, err := ca.Create(name, getAccountOptions(cmd)...)
 	if err != nil {
 		return err
 	}
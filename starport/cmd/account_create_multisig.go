@@ -0,0 +1,58 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+)
+
+const flagThreshold = "threshold"
+
+func NewAccountCreateMultisig() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "create-multisig [name] [member]...",
+		Short: "Create a new multisig account from existing accounts",
+		Long: `Create a new multisig account by combining the public keys of existing accounts.
+
+A transaction of the multisig account is valid once it's signed by at least threshold of its members.`,
+		Example:           "starport account create-multisig team alice bob carol --threshold 2",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeMultisigMembers,
+		RunE:              accountCreateMultisigHandler,
+	}
+
+	c.Flags().Int(flagThreshold, 0, "Number of signatures required to sign a transaction (default: number of members)")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountCreateMultisigHandler(cmd *cobra.Command, args []string) error {
+	var (
+		name         = args[0]
+		members      = args[1:]
+		threshold, _ = cmd.Flags().GetInt(flagThreshold)
+	)
+
+	if threshold == 0 {
+		threshold = len(members)
+	}
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	acc, err := ca.CreateMultisig(name, threshold, members...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Multisig account %q created with %d-of-%d threshold:\n\n", name, threshold, len(members))
+	return printAccounts(cmd, acc)
+}
@@ -10,10 +10,11 @@ import (
 
 func NewAccountDelete() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "delete [name]",
-		Short: "Delete an account by name",
-		Args:  cobra.ExactArgs(1),
-		RunE:  accountDeleteHandler,
+		Use:               "delete [name]",
+		Short:             "Delete an account by name",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccountNameArg,
+		RunE:              accountDeleteHandler,
 	}
 
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
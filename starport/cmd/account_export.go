@@ -4,36 +4,58 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
 )
 
+const flagFormat = "format"
+
+const (
+	exportFormatArmor    = "armor"
+	exportFormatHex      = "hex"
+	exportFormatKeystore = "keystore"
+)
+
 func NewAccountExport() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "export [name]",
 		Short: "Export an account as a private key",
-		Args:  cobra.ExactArgs(1),
-		RunE:  accountExportHandler,
+		Long: `Export an account as a private key.
+
+The private key is exported in one of these formats:
+  armor     armored private key, encrypted with the passphrase
+  hex       unencrypted private key in hex
+  keystore  JSON keystore, encrypted with the passphrase
+
+Multisig accounts don't have private keys, they're exported as armored public keys.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccountNameArg,
+		RunE:              accountExportHandler,
 	}
 
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetAccountImportExport())
 	c.Flags().String(flagPath, "", "path to export private key. default: ./key_[name]")
+	c.Flags().String(flagFormat, exportFormatArmor, "format of the private key: armor, hex or keystore")
 
 	return c
 }
 
 func accountExportHandler(cmd *cobra.Command, args []string) error {
 	var (
-		name = args[0]
-		path = flagGetPath(cmd)
+		name      = args[0]
+		path      = flagGetPath(cmd)
+		format, _ = cmd.Flags().GetString(flagFormat)
 	)
 
-	passphrase, err := getPassphrase(cmd)
-	if err != nil {
-		return err
+	format = strings.ToLower(format)
+	switch format {
+	case exportFormatArmor, exportFormatHex, exportFormatKeystore:
+	default:
+		return fmt.Errorf("unknown format %q, must be one of: armor, hex, keystore", format)
 	}
 
 	ca, err := cosmosaccount.New(
@@ -43,11 +65,48 @@ func accountExportHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	armored, err := ca.Export(name, passphrase)
+	acc, err := ca.GetByName(name)
 	if err != nil {
 		return err
 	}
 
+	var exported []byte
+
+	switch {
+	case acc.IsMultisig():
+		armored, err := ca.ExportPubKey(name)
+		if err != nil {
+			return err
+		}
+		exported = []byte(armored)
+
+	case format == exportFormatHex:
+		key, err := ca.ExportHex(name, "")
+		if err != nil {
+			return err
+		}
+		exported = []byte(key)
+
+	default:
+		passphrase, err := getPassphrase(cmd)
+		if err != nil {
+			return err
+		}
+
+		if format == exportFormatKeystore {
+			if exported, err = ca.ExportKeystore(name, passphrase); err != nil {
+				return err
+			}
+			break
+		}
+
+		armored, err := ca.Export(name, passphrase)
+		if err != nil {
+			return err
+		}
+		exported = []byte(armored)
+	}
+
 	if path == "" {
 		path = fmt.Sprintf("./key_%s", name)
 	}
@@ -56,7 +115,12 @@ func accountExportHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := os.WriteFile(path, []byte(armored), 0644); err != nil {
+	// the exported keys can only be read by the user, the mode of an existing file is set again
+	// since it's kept when the file is written.
+	if err := os.WriteFile(path, exported, 0600); err != nil {
+		return err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
 		return err
 	}
 
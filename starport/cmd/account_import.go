@@ -18,13 +18,18 @@ func NewAccountImport() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "import [name]",
 		Short: "Import an account by using a mnemonic or a private key",
-		Args:  cobra.ExactArgs(1),
-		RunE:  accountImportHandler,
+		Long: `Import an account by using a mnemonic or a private key.
+
+The private key can be an armored private key, a private key in hex or an encrypted JSON keystore.
+Armored private keys and keystores are decrypted with the passphrase.`,
+		Args: cobra.ExactArgs(1),
+		RunE: accountImportHandler,
 	}
 
-	c.Flags().String(flagSecret, "", "Your mnemonic or path to your private key (use interactive mode instead to securely pass your mnemonic)")
+	c.Flags().String(flagSecret, "", "Your mnemonic, private key in hex or path to your private key (use interactive mode instead to securely pass your mnemonic)")
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetAccountImportExport())
+	c.Flags().AddFlagSet(flagSetAccountKey())
 
 	return c
 }
@@ -36,8 +41,12 @@ func accountImportHandler(cmd *cobra.Command, args []string) error {
 	)
 
 	if secret == "" {
-		if err := cliquiz.Ask(
-			cliquiz.NewQuestion("Your mnemonic or path to your private key", &secret, cliquiz.Required())); err != nil {
+		err := cliquiz.Ask(
+			cliquiz.NewQuestion("Your mnemonic or path to your private key", &secret, cliquiz.Required()))
+		if errors.Is(err, cliquiz.ErrInputRequired) {
+			return fmt.Errorf("%w, use --%s to give the mnemonic or the private key", err, flagSecret)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -47,7 +56,7 @@ func accountImportHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if !bip39.IsMnemonicValid(secret) {
+	if !bip39.IsMnemonicValid(secret) && !cosmosaccount.IsHexPrivKey(secret) {
 		privKey, err := os.ReadFile(secret)
 		if os.IsNotExist(err) {
 			return errors.New("mnemonic is not valid or private key not found at path")
@@ -65,7 +74,7 @@ func accountImportHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if _, err := ca.Import(name, secret, passphrase); err != nil {
+	if _, err := ca.Import(name, secret, passphrase, getAccountOptions(cmd)...); err != nil {
 		return err
 	}
 
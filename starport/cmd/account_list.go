@@ -15,6 +15,7 @@ func NewAccountList() *cobra.Command {
 
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
 
 	return c
 }
@@ -8,14 +8,16 @@ import (
 
 func NewAccountShow() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "show [name]",
-		Short: "Show detailed information about a particular account",
-		Args:  cobra.ExactArgs(1),
-		RunE:  accountShowHandler,
+		Use:               "show [name]",
+		Short:             "Show detailed information about a particular account",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccountNameArg,
+		RunE:              accountShowHandler,
 	}
 
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
 
 	return c
 }
@@ -35,5 +37,5 @@ func accountShowHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return printAccounts(cmd, acc)
+	return printAccount(cmd, ca, acc)
 }
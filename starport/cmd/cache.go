@@ -0,0 +1,284 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/cliquiz"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+	"github.com/tendermint/starport/starport/services/cachemanager"
+)
+
+const (
+	flagOlderThan = "older-than"
+	flagMaxAge    = "max-age"
+	flagMaxSize   = "max-size"
+	flagCaches    = "caches"
+)
+
+var cacheHeader = []string{"Cache", "Entries", "Size", "Oldest entry", "Path"}
+
+// cacheOutput is the usage of a cache as printed in the JSON and YAML formats.
+type cacheOutput struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Path        string     `json:"path"`
+	Entries     int        `json:"entries"`
+	Size        int64      `json:"size"`
+	Oldest      *time.Time `json:"oldest,omitempty"`
+}
+
+// NewCache returns a command that groups the sub commands to manage the caches of Starport.
+func NewCache() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "cache [command]",
+		Short: "Show and clean the caches of Starport",
+		Long: `Show and clean the caches Starport keeps in its data dir.
+
+The entries of the codegen and sources caches are recreated when they are needed again, they are
+removed automatically once a day when they are not used for 30 days. The automatic GC is
+configured with "starport cache policy".`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(
+		NewCacheList(),
+		NewCacheClean(),
+		NewCachePolicy(),
+	)
+
+	return c
+}
+
+// NewCacheList returns a command that shows the disk usage of the caches.
+func NewCacheList() *cobra.Command {
+	c := &cobra.Command{
+		Use:       "list [cache]...",
+		Short:     "Show the disk usage of the caches",
+		Args:      cobra.OnlyValidArgs,
+		ValidArgs: cacheNames(),
+		RunE:      cacheListHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func cacheListHandler(cmd *cobra.Command, args []string) error {
+	m, err := cachemanager.Default()
+	if err != nil {
+		return err
+	}
+	usages, err := m.Usage(args...)
+	if err != nil {
+		return err
+	}
+
+	outputs := make([]cacheOutput, 0, len(usages))
+	for _, u := range usages {
+		output := cacheOutput{
+			Name:        u.Name,
+			Description: u.Description,
+			Path:        u.Path,
+			Entries:     len(u.Entries),
+			Size:        u.Size,
+		}
+		if len(u.Entries) > 0 {
+			output.Oldest = &u.Entries[0].ModTime
+		}
+		outputs = append(outputs, output)
+	}
+
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		var (
+			entries [][]string
+			total   int64
+		)
+		for _, o := range outputs {
+			oldest := "-"
+			if o.Oldest != nil {
+				oldest = o.Oldest.Format(time.RFC3339)
+			}
+			entries = append(entries, []string{
+				o.Name,
+				fmt.Sprint(o.Entries),
+				cachemanager.FormatSize(o.Size),
+				oldest,
+				o.Path,
+			})
+			total += o.Size
+		}
+		if err := entrywriter.MustWrite(out, cacheHeader, entries...); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(out, "\nTotal: %s\n", cachemanager.FormatSize(total))
+		return err
+	})
+}
+
+// NewCacheClean returns a command that removes the entries of the caches.
+func NewCacheClean() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "clean [cache]...",
+		Short: "Remove the entries of the caches",
+		Long: `Remove the entries of the caches, all the caches are cleaned when no caches are given.
+
+Use --older-than to only remove the entries that are not modified for a duration, e.g. 30d or 12h.`,
+		Args:      cobra.OnlyValidArgs,
+		ValidArgs: cacheNames(),
+		RunE:      cacheCleanHandler,
+	}
+
+	c.Flags().String(flagOlderThan, "", "Only remove the entries that are not modified for the duration, e.g. 30d or 12h")
+
+	return c
+}
+
+func cacheCleanHandler(cmd *cobra.Command, args []string) error {
+	olderThanFlag, _ := cmd.Flags().GetString(flagOlderThan)
+	olderThan, err := cachemanager.ParseAge(olderThanFlag)
+	if err != nil {
+		return err
+	}
+
+	m, err := cachemanager.Default()
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		for _, c := range m.Caches() {
+			names = append(names, c.Name)
+		}
+	}
+	question := fmt.Sprintf("Remove the entries of %s", strings.Join(names, ", "))
+	if olderThan != 0 {
+		question += fmt.Sprintf(" not modified for %s", olderThanFlag)
+	}
+	ok, err := cliquiz.Confirm(question)
+	if errors.Is(err, cliquiz.ErrInputRequired) {
+		return fmt.Errorf("%w, use --%s to clean the caches", err, flagYes)
+	}
+	if err != nil || !ok {
+		return err
+	}
+
+	removed, err := m.Clean(olderThan, args...)
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	for _, e := range removed {
+		size += e.Size
+	}
+	fmt.Printf("%s Removed %d entries (%s)\n", clispinner.OK, len(removed), cachemanager.FormatSize(size))
+	return nil
+}
+
+// NewCachePolicy returns a command that shows and sets the GC policy of the caches.
+func NewCachePolicy() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "policy",
+		Short: "Show or set the automatic GC policy of the caches",
+		Long: `Show the automatic GC policy of the caches, or set it with the flags.
+
+The GC runs after the commands once the interval is elapsed since its last run. It removes the
+entries of the caches that are older than the max age, and then the oldest entries until the
+total size of the caches is below the max size. Set an empty interval to disable the automatic GC.`,
+		Args: cobra.NoArgs,
+		RunE: cachePolicyHandler,
+	}
+
+	c.Flags().String(flagMaxAge, "", "Age after which the entries are removed, e.g. 30d")
+	c.Flags().String(flagMaxSize, "", "Total size of the caches above which the oldest entries are removed, e.g. 2GB")
+	c.Flags().String(flagInterval, "", "Time between two runs of the automatic GC, e.g. 24h")
+	c.Flags().StringSlice(flagCaches, nil, "Caches collected by the GC")
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func cachePolicyHandler(cmd *cobra.Command, args []string) error {
+	m, err := cachemanager.Default()
+	if err != nil {
+		return err
+	}
+	p, err := m.Policy()
+	if err != nil {
+		return err
+	}
+
+	var (
+		flags = cmd.Flags()
+		set   = false
+	)
+	for name, value := range map[string]*string{
+		flagMaxAge:   &p.MaxAge,
+		flagMaxSize:  &p.MaxSize,
+		flagInterval: &p.Interval,
+	} {
+		if flags.Changed(name) {
+			*value, _ = flags.GetString(name)
+			set = true
+		}
+	}
+	if flags.Changed(flagCaches) {
+		p.Caches, _ = flags.GetStringSlice(flagCaches)
+		set = true
+	}
+
+	if set {
+		if err := m.SetPolicy(p); err != nil {
+			return err
+		}
+		fmt.Printf("%s Cache policy updated\n", clispinner.OK)
+		return nil
+	}
+
+	return printOutput(cmd, p, func(out io.Writer) error {
+		_, err := fmt.Fprintf(out, "Max age: %s\nMax size: %s\nInterval: %s\nCaches: %s\n",
+			orNone(p.MaxAge),
+			orNone(p.MaxSize),
+			orNone(p.Interval),
+			orNone(strings.Join(p.Caches, ", ")),
+		)
+		return err
+	})
+}
+
+// AutoGC runs the automatic GC of the caches after a command, the GC never fails the commands so
+// its errors are ignored.
+func AutoGC(cmd *cobra.Command) {
+	if cmd == nil || isCompletionRequest() {
+		return
+	}
+	m, err := cachemanager.Default()
+	if err != nil {
+		return
+	}
+	_, _ = m.AutoGC()
+}
+
+func cacheNames() []string {
+	var names []string
+	for _, c := range cachemanager.DefaultCaches("") {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -18,7 +18,19 @@ func NewChain() *cobra.Command {
 		NewChainBuild(),
 		NewChainInit(),
 		NewChainFaucet(),
+		NewChainDeploy(),
+		NewChainBench(),
 		NewChainSimulate(),
+		NewChainProtoCheck(),
+		NewChainConfig(),
+		NewChainGentx(),
+		NewChainGov(),
+		NewChainSeed(),
+		NewChainStateSync(),
+		NewChainRequests(),
+		NewChainDeps(),
+		NewChainMigrateSDK(),
+		NewChainImport(),
 	)
 
 	return c
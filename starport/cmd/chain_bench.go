@@ -0,0 +1,211 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosbench"
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+const (
+	flagTPS       = "tps"
+	flagDuration  = "duration"
+	flagMsg       = "msg"
+	flagMsgFields = "msg-fields"
+	flagSigners   = "signers"
+	flagGas       = "gas"
+	flagFees      = "fees"
+)
+
+var benchHeader = []string{"metric", "value"}
+
+type benchOutput struct {
+	Signers        int            `json:"signers"`
+	TargetTPS      int            `json:"target_tps"`
+	Duration       string         `json:"duration"`
+	Sent           int            `json:"sent"`
+	Rejected       int            `json:"rejected"`
+	Committed      int            `json:"committed"`
+	Failed         int            `json:"failed"`
+	Pending        int            `json:"pending"`
+	SendRate       float64        `json:"send_rate"`
+	Throughput     float64        `json:"throughput"`
+	Latency        latencyOutput  `json:"latency"`
+	Gas            gasOutput      `json:"gas"`
+	Blocks         int            `json:"blocks"`
+	MaxTxsPerBlock int            `json:"max_txs_per_block"`
+	Errors         map[string]int `json:"errors,omitempty"`
+}
+
+type latencyOutput struct {
+	Min  string `json:"min"`
+	Mean string `json:"mean"`
+	P50  string `json:"p50"`
+	P90  string `json:"p90"`
+	P99  string `json:"p99"`
+	Max  string `json:"max"`
+}
+
+type gasOutput struct {
+	Wanted uint64 `json:"wanted"`
+	Min    uint64 `json:"min"`
+	Mean   uint64 `json:"mean"`
+	Max    uint64 `json:"max"`
+	Total  uint64 `json:"total"`
+}
+
+// NewChainBench returns a command to load test a served chain.
+func NewChainBench() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "bench",
+		Short: "Load test a served chain and report the latency, the throughput and the gas of its txs",
+		Long: `Load test a chain served with "starport chain serve" and report the latency, the throughput
+and the gas of its txs.
+
+Concurrent signers broadcast txs at the rate of --tps for --duration, then the txs are tracked until
+they're included in blocks. The signers are the test accounts of the config, funded at genesis. With
+--from or without test accounts, the signers are new accounts kept in memory, they're funded by a
+single tx from the account of --from, the faucet account or the first account of the config by
+default.
+
+With --msg bank-send, the signers send a coin to themselves. Any msg of the modules of the chain is
+sent with its name, the msg is resolved with the gRPC reflection of the node, its fields are set
+with --msg-fields in JSON and its creator is the signer:
+
+  starport chain bench --tps 200 --duration 2m
+  starport chain bench --msg create-post --msg-fields '{"title":"bench","body":"load"}'`,
+		Args: cobra.NoArgs,
+		RunE: chainBenchHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	c.Flags().Int(flagTPS, 100, "Number of txs broadcasted per second")
+	c.Flags().Duration(flagDuration, time.Minute, "Time the txs are broadcasted for")
+	c.Flags().String(flagMsg, chain.BenchMsgBankSend, "Msg of the txs, bank-send or a msg of the modules of the chain")
+	c.Flags().String(flagMsgFields, "", "Fields of the msg in JSON")
+	c.Flags().Int(flagSigners, 10, "Number of concurrent signers")
+	c.Flags().String(flagFrom, "", "Account that funds new signers instead of signing with the test accounts")
+	c.Flags().Uint64(flagGas, 0, "Gas limit of the txs (default simulated)")
+	c.Flags().String(flagFees, "", "Fees of each tx")
+
+	return c
+}
+
+func chainBenchHandler(cmd *cobra.Command, args []string) error {
+	var (
+		tps, _       = cmd.Flags().GetInt(flagTPS)
+		duration, _  = cmd.Flags().GetDuration(flagDuration)
+		msg, _       = cmd.Flags().GetString(flagMsg)
+		msgFields, _ = cmd.Flags().GetString(flagMsgFields)
+		signers, _   = cmd.Flags().GetInt(flagSigners)
+		from, _      = cmd.Flags().GetString(flagFrom)
+		gas, _       = cmd.Flags().GetUint64(flagGas)
+		fees, _      = cmd.Flags().GetString(flagFees)
+	)
+
+	c, err := newChainWithHomeFlags(cmd, chain.LogLevel(logLevel(cmd)))
+	if err != nil {
+		return err
+	}
+
+	report, err := c.Bench(cmd.Context(), msg,
+		chain.BenchTPS(tps),
+		chain.BenchDuration(duration),
+		chain.BenchMsgFields([]byte(msgFields)),
+		chain.BenchSigners(signers),
+		chain.BenchFrom(from),
+		chain.BenchGas(gas),
+		chain.BenchFees(fees),
+	)
+	if err != nil {
+		return err
+	}
+
+	output := newBenchOutput(report)
+	return printOutput(cmd, output, func(out io.Writer) error {
+		if err := entrywriter.MustWrite(out, benchHeader,
+			[]string{"signers", fmt.Sprint(output.Signers)},
+			[]string{"target tps", fmt.Sprint(output.TargetTPS)},
+			[]string{"duration", output.Duration},
+			[]string{"sent", fmt.Sprint(output.Sent)},
+			[]string{"rejected", fmt.Sprint(output.Rejected)},
+			[]string{"committed", fmt.Sprint(output.Committed)},
+			[]string{"failed", fmt.Sprint(output.Failed)},
+			[]string{"pending", fmt.Sprint(output.Pending)},
+			[]string{"send rate", fmt.Sprintf("%.2f tx/s", output.SendRate)},
+			[]string{"throughput", fmt.Sprintf("%.2f tx/s", output.Throughput)},
+			[]string{"latency min/mean/max", fmt.Sprintf("%s / %s / %s",
+				output.Latency.Min, output.Latency.Mean, output.Latency.Max)},
+			[]string{"latency p50/p90/p99", fmt.Sprintf("%s / %s / %s",
+				output.Latency.P50, output.Latency.P90, output.Latency.P99)},
+			[]string{"gas wanted", fmt.Sprint(output.Gas.Wanted)},
+			[]string{"gas used min/mean/max", fmt.Sprintf("%d / %d / %d",
+				output.Gas.Min, output.Gas.Mean, output.Gas.Max)},
+			[]string{"gas used total", fmt.Sprint(output.Gas.Total)},
+			[]string{"blocks", fmt.Sprint(output.Blocks)},
+			[]string{"max txs per block", fmt.Sprint(output.MaxTxsPerBlock)},
+		); err != nil {
+			return err
+		}
+		if len(output.Errors) == 0 {
+			return nil
+		}
+
+		reasons := make([]string, 0, len(output.Errors))
+		for reason := range output.Errors {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		var entries [][]string
+		for _, reason := range reasons {
+			entries = append(entries, []string{reason, fmt.Sprint(output.Errors[reason])})
+		}
+		fmt.Fprintln(out)
+		return entrywriter.MustWrite(out, []string{"error", "txs"}, entries...)
+	})
+}
+
+func newBenchOutput(report cosmosbench.Report) benchOutput {
+	round := func(d time.Duration) string {
+		return d.Round(time.Millisecond).String()
+	}
+	return benchOutput{
+		Signers:    report.Signers,
+		TargetTPS:  report.TargetTPS,
+		Duration:   round(report.Duration),
+		Sent:       report.Sent,
+		Rejected:   report.Rejected,
+		Committed:  report.Committed,
+		Failed:     report.Failed,
+		Pending:    report.Pending,
+		SendRate:   report.SendRate,
+		Throughput: report.Throughput,
+		Latency: latencyOutput{
+			Min:  round(report.Latency.Min),
+			Mean: round(report.Latency.Mean),
+			P50:  round(report.Latency.P50),
+			P90:  round(report.Latency.P90),
+			P99:  round(report.Latency.P99),
+			Max:  round(report.Latency.Max),
+		},
+		Gas: gasOutput{
+			Wanted: report.Gas.Wanted,
+			Min:    report.Gas.Min,
+			Mean:   report.Gas.Mean,
+			Max:    report.Gas.Max,
+			Total:  report.Gas.Total,
+		},
+		Blocks:         report.Blocks,
+		MaxTxsPerBlock: report.MaxTxsPerBlock,
+		Errors:         report.Errors,
+	}
+}
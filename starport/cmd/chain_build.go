@@ -3,10 +3,12 @@ package starportcmd
 import (
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/services/chain"
 )
 
@@ -38,7 +40,9 @@ Sample usages:
 	}
 
 	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetWorkspaceChain())
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
 	c.Flags().AddFlagSet(flagSetProto3rdParty("Available only without the --release flag"))
 	c.Flags().Bool(flagRelease, false, "build for a release")
 	c.Flags().StringSliceP(flagReleaseTargets, "t", []string{}, "release targets. Available only with --release flag")
@@ -50,6 +54,15 @@ Sample usages:
 }
 
 func chainBuildHandler(cmd *cobra.Command, args []string) error {
+	var (
+		wg sync.WaitGroup
+		ev = events.NewBus()
+	)
+	wg.Add(1)
+	go printLogEvents(&wg, ev)
+	defer wg.Wait()
+	defer ev.Shutdown()
+
 	var (
 		isRelease, _      = cmd.Flags().GetBool(flagRelease)
 		releaseTargets, _ = cmd.Flags().GetStringSlice(flagReleaseTargets)
@@ -59,6 +72,7 @@ func chainBuildHandler(cmd *cobra.Command, args []string) error {
 
 	chainOption := []chain.Option{
 		chain.LogLevel(logLevel(cmd)),
+		chain.CollectEvents(ev),
 		chain.KeyringBackend(chaincmd.KeyringBackendTest),
 	}
 
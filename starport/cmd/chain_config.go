@@ -0,0 +1,37 @@
+package starportcmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+// NewChainConfig creates a new config command that holds some other sub commands
+// related to the config file of a blockchain.
+func NewChainConfig() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the config file of a blockchain",
+	}
+
+	c.AddCommand(
+		NewChainConfigMigrate(),
+		NewChainConfigValidate(),
+		NewChainConfigSchema(),
+	)
+
+	return c
+}
+
+// chainConfigPath returns the path of the config file provided by --config, or the one
+// located in the app's path.
+func chainConfigPath(cmd *cobra.Command) (string, error) {
+	config, err := cmd.Flags().GetString(flagConfig)
+	if err != nil {
+		return "", err
+	}
+	if config != "" {
+		return config, nil
+	}
+	return chainconfig.LocateDefault(flagGetPath(cmd))
+}
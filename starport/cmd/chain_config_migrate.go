@@ -0,0 +1,49 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+// NewChainConfigMigrate returns a new command to migrate the config file of a blockchain
+// to the latest version.
+func NewChainConfigMigrate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the config file to the latest version",
+		Long: `Migrate the config file of the blockchain to the latest version of the config schema.
+
+Older config files are already migrated when they are loaded, migrate writes the migrated
+config back to the file. The values of the config are kept as is but comments are not.`,
+		Args: cobra.NoArgs,
+		RunE: chainConfigMigrateHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().StringP(flagConfig, "c", "", "Starport config file (default: ./config.yml)")
+
+	return c
+}
+
+func chainConfigMigrateHandler(cmd *cobra.Command, args []string) error {
+	path, err := chainConfigPath(cmd)
+	if err != nil {
+		return err
+	}
+
+	version, err := chainconfig.MigrateFile(path)
+	if err != nil {
+		return err
+	}
+
+	if version == chainconfig.LatestVersion {
+		fmt.Printf("✅ %s is already at the latest version %d.\n", path, version)
+		return nil
+	}
+
+	fmt.Printf("✅ Migrated %s from version %d to %d.\n", path, version, chainconfig.LatestVersion)
+	return nil
+}
@@ -0,0 +1,38 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+// NewChainConfigSchema returns a new command to print the JSON Schema of the config file.
+func NewChainConfigSchema() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the config file",
+		Long: `Print the JSON Schema of the config file for the autocompletion and validation in editors.
+
+For example, save it and refer to it at the top of config.yml for the YAML language server:
+
+  starport chain config schema > config.schema.json
+
+  # yaml-language-server: $schema=config.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: chainConfigSchemaHandler,
+	}
+
+	return c
+}
+
+func chainConfigSchemaHandler(cmd *cobra.Command, args []string) error {
+	schema, err := chainconfig.JSONSchema()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(schema))
+	return nil
+}
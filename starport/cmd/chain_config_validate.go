@@ -0,0 +1,85 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+// configValidationOutput is the result of the validation of a config file as printed in the JSON
+// and YAML formats.
+type configValidationOutput struct {
+	Path   string   `json:"path"`
+	Valid  bool     `json:"valid"`
+	Issues []string `json:"issues"`
+}
+
+// NewChainConfigValidate returns a new command to validate the config file of a blockchain.
+func NewChainConfigValidate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the config file",
+		Long: `Validate the config file of the blockchain.
+
+Unlike the validation made when the config is loaded, all of the issues are reported, including
+the unknown keys of the config and its profiles, the invalid coins, the duplicate accounts and
+the servers that use the same port.`,
+		Args: cobra.NoArgs,
+		RunE: chainConfigValidateHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().StringP(flagConfig, "c", "", "Starport config file (default: ./config.yml)")
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func chainConfigValidateHandler(cmd *cobra.Command, args []string) error {
+	path, err := chainConfigPath(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = chainconfig.ValidateFile(path, configParseOptions(cmd)...)
+
+	var validationErrs chainconfig.ValidationErrors
+	if err != nil && !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	output := configValidationOutput{
+		Path:   path,
+		Valid:  len(validationErrs) == 0,
+		Issues: make([]string, 0, len(validationErrs)),
+	}
+	for _, validationErr := range validationErrs {
+		output.Issues = append(output.Issues, validationErr.Message)
+	}
+
+	if err := printOutput(cmd, output, func(out io.Writer) error {
+		if output.Valid {
+			fmt.Fprintf(out, "✅ %s is valid.\n", path)
+			return nil
+		}
+
+		fmt.Fprintf(out, "❌ Found %d issue(s) in %s:\n\n", len(output.Issues), path)
+		for _, issue := range output.Issues {
+			fmt.Fprintf(out, "  %s\n", issue)
+		}
+		fmt.Fprintln(out)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if !output.Valid {
+		return errors.New("config is not valid")
+	}
+	return nil
+}
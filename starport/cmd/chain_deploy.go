@@ -0,0 +1,133 @@
+package starportcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/internal/version"
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+const (
+	flagSentries    = "sentries"
+	flagFaucet      = "faucet"
+	flagImage       = "image"
+	flagFaucetImage = "faucet-image"
+	flagHelm        = "helm"
+)
+
+// NewChainDeploy returns a command that groups the sub commands to deploy a chain.
+func NewChainDeploy() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "deploy [command]",
+		Short: "Deploy the nodes of a chain to an orchestrator",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewChainDeployK8s())
+
+	return c
+}
+
+// NewChainDeployK8s returns a command to write the Kubernetes manifests that deploy a chain.
+func NewChainDeployK8s() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "k8s",
+		Short: "Write the Kubernetes manifests and a Helm chart that deploy the nodes of a chain",
+		Long: `Write the Kubernetes manifests that deploy the validators of the config to a cluster, a
+StatefulSet for each node with its config in a ConfigMap, its keys in a Secret and its home in a
+volume, the genesis in a ConfigMap and a Service that serves the RPC, the API and the gRPC of the
+chain. The genesis is created with the accounts and the validators of the config in a temporary home,
+the home of the chain is not changed.
+
+With --sentries, the validators only connect to the sentries and the sentries serve the clients.
+With --faucet, the faucet of the config is served by Starport with "starport chain faucet serve".
+With --helm, a Helm chart of the chain is written next to the manifests, the image, the storage of
+the nodes and the types of the Services are its values.
+
+A Dockerfile that builds the image of the chain from its source is written next to the manifests:
+
+  starport chain deploy k8s --output deploy --sentries 2 --faucet --helm
+  docker build -f deploy/Dockerfile -t mars:latest .
+  kubectl apply -f deploy`,
+		Args: cobra.NoArgs,
+		RunE: chainDeployK8sHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().StringP(flagOutput, "o", "deploy", "Output directory of the manifests")
+	c.Flags().Int(flagSentries, 0, "Number of sentries deployed in front of the validators")
+	c.Flags().Bool(flagFaucet, false, "Deploy the faucet of the config")
+	c.Flags().String(flagImage, "", "Image of the chain (default \"<name>:latest\")")
+	c.Flags().String(flagFaucetImage, defaultFaucetImage(), "Image of Starport that serves the faucet")
+	c.Flags().Bool(flagHelm, false, "Write a Helm chart of the chain")
+
+	return c
+}
+
+func chainDeployK8sHandler(cmd *cobra.Command, args []string) error {
+	var (
+		output, _      = cmd.Flags().GetString(flagOutput)
+		sentries, _    = cmd.Flags().GetInt(flagSentries)
+		faucet, _      = cmd.Flags().GetBool(flagFaucet)
+		image, _       = cmd.Flags().GetString(flagImage)
+		faucetImage, _ = cmd.Flags().GetString(flagFaucetImage)
+		helm, _        = cmd.Flags().GetBool(flagHelm)
+	)
+
+	// the genesis of the deployment is created in a temporary home to keep the state of the chain.
+	home, err := os.MkdirTemp("", "")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(home)
+
+	c, err := newChainWithHomeFlags(cmd,
+		chain.LogLevel(logLevel(cmd)),
+		chain.KeyringBackend(chaincmd.KeyringBackendTest),
+		chain.HomePath(filepath.Join(home, "home")),
+	)
+	if err != nil {
+		return err
+	}
+
+	options := []chain.DeployOption{
+		chain.DeploySentries(sentries),
+		chain.DeployImage(image),
+	}
+	if faucet {
+		options = append(options, chain.DeployFaucet(faucetImage))
+	}
+	if helm {
+		options = append(options, chain.DeployHelm())
+	}
+
+	deployment, err := c.DeployK8s(cmd.Context(), output, options...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🗃  Kubernetes manifests written to: %s\n", infoColor(output))
+	fmt.Printf("🐳 Build the image of the chain with: %s\n",
+		infoColor(fmt.Sprintf("docker build -f %s -t %s .", filepath.Join(output, "Dockerfile"), deployment.Image)))
+	fmt.Printf("🚀 Deploy the chain with: %s\n", infoColor("kubectl apply -f "+output))
+	if helm {
+		fmt.Printf("⛵ Or install its Helm chart with: %s\n",
+			infoColor(fmt.Sprintf("helm install %s %s", deployment.Name, filepath.Join(output, deployment.Name))))
+	}
+	return nil
+}
+
+// defaultFaucetImage returns the image of the running version of Starport.
+func defaultFaucetImage() string {
+	if version.IsDevelopment() {
+		return "starport/cli:latest"
+	}
+	return "starport/cli:" + strings.TrimPrefix(version.Version, "v")
+}
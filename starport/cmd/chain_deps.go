@@ -0,0 +1,147 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosdeps"
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+)
+
+const flagSDK = "sdk"
+
+// NewChainDeps returns a command that groups the sub commands to check and upgrade the
+// dependencies of a chain.
+func NewChainDeps() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "deps [command]",
+		Short: "Check and upgrade the Cosmos SDK, ibc-go, Tendermint and Starport versions of a chain",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(
+		NewChainDepsCheck(),
+		NewChainDepsUpgrade(),
+	)
+
+	return c
+}
+
+// NewChainDepsCheck returns a command to check the versions of the dependencies of a chain.
+func NewChainDepsCheck() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "check",
+		Short: "Check that the versions of the dependencies in go.mod are compatible",
+		Long: `Check that the versions of ibc-go, Tendermint and Starport in go.mod are compatible with
+the version of the Cosmos SDK, and that the modules the SDK needs are replaced.
+
+The replaced modules are checked with the versions they're replaced with.`,
+		Args: cobra.NoArgs,
+		RunE: chainDepsCheckHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+// NewChainDepsUpgrade returns a command to upgrade the dependencies of a chain to a version of
+// the SDK.
+func NewChainDepsUpgrade() *cobra.Command {
+	latest := cosmosdeps.Matrix[len(cosmosdeps.Matrix)-1]
+
+	c := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the Cosmos SDK with the dependencies that are compatible with it",
+		Long: `Upgrade the Cosmos SDK in go.mod and the versions of ibc-go, Tendermint and Starport that
+are not compatible with it, add the replaces the SDK needs and run go mod tidy.
+
+When the major version of ibc-go changes, the imports of ibc-go in the Go files of the chain are
+rewritten. Only the upgrades that don't need other changes of the code scaffolded by Starport are
+supported, run "starport chain migrate-sdk" for the others.`,
+		Example: "  starport chain deps upgrade --sdk v0.45.x",
+		Args:    cobra.NoArgs,
+		RunE:    chainDepsUpgradeHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().String(flagSDK, latest.SDK+".x", "Version of the Cosmos SDK, a line like v0.45.x or a version like v0.45.4")
+
+	return c
+}
+
+func chainDepsCheckHandler(cmd *cobra.Command, args []string) error {
+	report, err := cosmosdeps.CheckAt(flagGetPath(cmd))
+	if err != nil {
+		return err
+	}
+
+	if err := printOutput(cmd, report, func(out io.Writer) error {
+		if len(report.Issues) == 0 {
+			fmt.Fprintf(out, "%s The dependencies are compatible with the Cosmos SDK %s.\n", clispinner.OK, report.SDK)
+			return nil
+		}
+
+		fmt.Fprintf(out, "Found %d issue(s) with the Cosmos SDK %s:\n\n", len(report.Issues), report.SDK)
+		for _, issue := range report.Issues {
+			icon := "⚠️ "
+			if issue.Severity == cosmosdeps.SeverityError {
+				icon = "❌"
+			}
+			fmt.Fprintf(out, "  %s %s\n", icon, issue)
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, `💡 Run "starport chain deps upgrade" to upgrade the dependencies that are not compatible.`)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if report.HasErrors() {
+		return errors.New("the dependencies are not compatible")
+	}
+	return nil
+}
+
+func chainDepsUpgradeHandler(cmd *cobra.Command, args []string) error {
+	var (
+		path      = flagGetPath(cmd)
+		target, _ = cmd.Flags().GetString(flagSDK)
+	)
+
+	s := clispinner.New().SetText("Upgrading the dependencies...")
+	defer s.Stop()
+
+	upgrade, err := cosmosdeps.UpgradeAt(path, target)
+	if err != nil {
+		return err
+	}
+	if len(upgrade.Changes) == 0 {
+		s.Stop()
+		fmt.Printf("%s The dependencies are up to date with the Cosmos SDK %s.\n", clispinner.OK, upgrade.Compatibility.SDK)
+		return nil
+	}
+
+	s.SetText("Tidying go.mod...")
+	if err := gocmd.ModTidy(cmd.Context(), path); err != nil {
+		return fmt.Errorf("go.mod is upgraded but go mod tidy failed: %w", err)
+	}
+
+	s.Stop()
+
+	fmt.Printf("%s Upgraded the dependencies to the Cosmos SDK %s:\n\n", clispinner.OK, upgrade.Compatibility.SDK)
+	for _, change := range upgrade.Changes {
+		fmt.Printf("  %s\n", change)
+	}
+	for old, new := range upgrade.Imports {
+		fmt.Printf("  imports: %s → %s\n", old, new)
+	}
+	fmt.Println()
+
+	return nil
+}
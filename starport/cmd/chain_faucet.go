@@ -21,8 +21,11 @@ func NewChainFaucet() *cobra.Command {
 
 	flagSetPath(c)
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
 	c.Flags().BoolP("verbose", "v", false, "Verbose output")
 
+	c.AddCommand(NewChainFaucetServe())
+
 	return c
 }
 
@@ -46,6 +49,7 @@ func chainFaucetHandler(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer faucet.Close()
 
 	// parse provided coins
 	parsedCoins, err := sdk.ParseCoinsNormalized(coins)
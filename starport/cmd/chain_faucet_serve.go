@@ -0,0 +1,85 @@
+package starportcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+const (
+	flagNode = "node"
+	flagAPI  = "api"
+)
+
+// NewChainFaucetServe creates a new command to serve the faucet of a chain without its source.
+func NewChainFaucetServe() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the faucet of a chain deployed without its source",
+		Long: `Serve the faucet of a chain deployed without its source, like the chains deployed with
+"starport chain deploy k8s". The faucet is configured by the faucet of the config file and sends
+the coins with the binary of the chain from the faucet account in the test keyring of the home.
+
+  starport chain faucet serve -c config.yml --binary marsd --chain-id mars --home ~/.mars`,
+		Args: cobra.NoArgs,
+		RunE: chainFaucetServeHandler,
+	}
+
+	c.Flags().StringP(flagConfig, "c", "", "Starport config file of the chain")
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().String(flagBinary, "", "Binary of the chain")
+	c.Flags().String(flagChainID, "", "ID of the chain")
+	c.Flags().String(flagNode, "http://localhost:26657", "RPC of the node the transfers are broadcast to")
+	c.Flags().String(flagAPI, "http://localhost:1317", "API of the node used by the OpenAPI console of the faucet")
+
+	return c
+}
+
+func chainFaucetServeHandler(cmd *cobra.Command, args []string) error {
+	var (
+		configPath, _ = cmd.Flags().GetString(flagConfig)
+		binary, _     = cmd.Flags().GetString(flagBinary)
+		chainID, _    = cmd.Flags().GetString(flagChainID)
+		node, _       = cmd.Flags().GetString(flagNode)
+		api, _        = cmd.Flags().GetString(flagAPI)
+	)
+	switch {
+	case configPath == "":
+		return fmt.Errorf("the config file is required, use --%s", flagConfig)
+	case binary == "":
+		return fmt.Errorf("the binary of the chain is required, use --%s", flagBinary)
+	case chainID == "":
+		return fmt.Errorf("the ID of the chain is required, use --%s", flagChainID)
+	}
+
+	if _, err := os.Stat(configPath); err != nil {
+		return err
+	}
+	conf, err := chainconfig.ParseFile(configPath, configParseOptions(cmd)...)
+	if err != nil {
+		return err
+	}
+
+	options := []chaincmd.Option{
+		chaincmd.WithChainID(chainID),
+		chaincmd.WithNodeAddress(node),
+		chaincmd.WithKeyringBackend(chaincmd.KeyringBackendTest),
+	}
+	if home := getHome(cmd); home != "" {
+		options = append(options, chaincmd.WithHome(home))
+	}
+	commands, err := chaincmdrunner.New(cmd.Context(), chaincmd.New(binary, options...))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🌍 Token faucet: http://%s\n", chainconfig.FaucetHost(conf))
+	return chain.ServeFaucet(cmd.Context(), commands, conf, chainID, api)
+}
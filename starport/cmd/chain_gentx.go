@@ -0,0 +1,269 @@
+package starportcmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+	"github.com/tendermint/starport/starport/services/gentxcollector"
+	"github.com/tendermint/starport/starport/services/network/networkchain"
+)
+
+const (
+	flagAddress        = "address"
+	flagPeer           = "peer"
+	flagValidatorCoins = "validator-coins"
+	flagGenesisTime    = "genesis-time"
+	flagReinit         = "reinit"
+)
+
+// NewChainGentx returns a command that groups the sub commands to launch a chain without SPN by
+// collecting the gentxs of its validators.
+func NewChainGentx() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "gentx [command]",
+		Short: "Launch a chain without SPN by collecting the gentxs of its validators",
+		Long: `Launch a chain without SPN by collecting the gentxs of its validators.
+
+The coordinator serves the base genesis of the chain and collects the gentxs of the validators
+with "collect". The validators generate their gentxs for the base genesis and submit them with
+"submit". The gentxs are validated like the requests of the validators on SPN.
+
+Once the gentxs are collected, the coordinator assembles the final genesis with "finalize", the
+validators fetch it and the peers of the other validators from the collector to start their nodes.`,
+	}
+
+	c.AddCommand(
+		NewChainGentxCollect(),
+		NewChainGentxSubmit(),
+		NewChainGentxFinalize(),
+	)
+
+	return c
+}
+
+// NewChainGentxCollect returns a command that collects the gentxs of the validators.
+func NewChainGentxCollect() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "collect [dir]",
+		Short: "Serve the base genesis of the chain and collect the gentxs of the validators",
+		Long: `Serve the base genesis of the chain and collect the gentxs of the validators.
+
+The chain is initialized in dir on the first run and its genesis is saved as the base genesis, the
+next runs keep the base genesis and the submitted gentxs unless --reinit is given.
+
+The collector serves:
+
+  POST /gentxs         submit a gentx with the address of the validator's node
+  GET  /gentxs         list the validators that submitted their gentxs
+  GET  /genesis        the base genesis to generate the gentxs for
+  GET  /genesis/final  the final genesis once it's assembled with "finalize"
+  GET  /peers          the addresses of the nodes of the validators`,
+		Args: cobra.ExactArgs(1),
+		RunE: chainGentxCollectHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().String(flagAddress, ":4600", "Address to serve the collector on")
+	c.Flags().Bool(flagReinit, false, "Initialize the chain and save its base genesis again")
+
+	return c
+}
+
+func chainGentxCollectHandler(cmd *cobra.Command, args []string) error {
+	var (
+		address, _ = cmd.Flags().GetString(flagAddress)
+		reinit, _  = cmd.Flags().GetBool(flagReinit)
+	)
+
+	collector, err := gentxcollector.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(collector.BaseGenesisPath())
+	if reinit || os.IsNotExist(err) {
+		if err := initGentxCollector(cmd, collector); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("%s Base genesis: %s\n", clispinner.Bullet, collector.BaseGenesisPath())
+	fmt.Printf("🌍 Collecting the gentxs on %s\n", address)
+
+	return xhttp.Serve(cmd.Context(), &http.Server{
+		Addr:    address,
+		Handler: collector,
+	})
+}
+
+// initGentxCollector initializes the chain of the collector and saves its base genesis.
+func initGentxCollector(cmd *cobra.Command, collector *gentxcollector.Collector) error {
+	return withGentxCollectorChain(cmd, collector, func(ctx context.Context, c *networkchain.Chain) error {
+		return collector.Init(ctx, c)
+	})
+}
+
+// NewChainGentxSubmit returns a command that submits a gentx to a collector.
+func NewChainGentxSubmit() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "submit [collector-url] [gentx]",
+		Short: "Submit a gentx to the collector of the launch",
+		Long: `Submit a gentx to the collector of the launch.
+
+The gentx is generated for the base genesis served by the collector at /genesis. The address of
+the validator's node is given with --peer in the id@host:port format, the node ID is printed by
+the "tendermint show-node-id" command of the chain.`,
+		Args: cobra.ExactArgs(2),
+		RunE: chainGentxSubmitHandler,
+	}
+
+	c.Flags().String(flagPeer, "", "Address of the validator's node in the id@host:port format")
+
+	return c
+}
+
+func chainGentxSubmitHandler(cmd *cobra.Command, args []string) error {
+	peer, _ := cmd.Flags().GetString(flagPeer)
+	if peer == "" {
+		return fmt.Errorf("--%s is required", flagPeer)
+	}
+
+	gentx, err := os.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	v, err := gentxcollector.NewClient(args[0]).Submit(cmd.Context(), gentxcollector.Submission{
+		Gentx: gentx,
+		Peer:  peer,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Gentx of %s with a self-delegation of %s submitted\n", clispinner.OK, v.Address, v.SelfDelegation)
+	return nil
+}
+
+// NewChainGentxFinalize returns a command that assembles the final genesis from the gentxs.
+func NewChainGentxFinalize() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "finalize [dir]",
+		Short: "Assemble the final genesis from the collected gentxs",
+		Long: `Assemble the final genesis from the collected gentxs.
+
+An account is added to the genesis for each validator with --validator-coins, or with its
+self-delegation when --validator-coins is not given. The final genesis is served by the collector
+at /genesis/final.`,
+		Args: cobra.ExactArgs(1),
+		RunE: chainGentxFinalizeHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().String(flagValidatorCoins, "", "Coins of the account of each validator")
+	c.Flags().Int64(flagGenesisTime, 0, "Genesis time of the chain as a unix timestamp (default now)")
+
+	return c
+}
+
+func chainGentxFinalizeHandler(cmd *cobra.Command, args []string) error {
+	var (
+		validatorCoins, _ = cmd.Flags().GetString(flagValidatorCoins)
+		genesisTime, _    = cmd.Flags().GetInt64(flagGenesisTime)
+	)
+
+	if genesisTime == 0 {
+		genesisTime = time.Now().Unix()
+	}
+
+	coins, err := sdk.ParseCoinsNormalized(validatorCoins)
+	if err != nil {
+		return err
+	}
+
+	collector, err := gentxcollector.New(args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(collector.BaseGenesisPath()); err != nil {
+		return fmt.Errorf("the chain is not initialized in %s, run \"collect\" first", args[0])
+	}
+
+	var reportPath string
+	err = withGentxCollectorChain(cmd, collector, func(ctx context.Context, c *networkchain.Chain) error {
+		if err := collector.Finalize(ctx, c, coins); err != nil {
+			return err
+		}
+		path, err := c.PrepareReportPath()
+		reportPath = path
+		return err
+	}, networkchain.WithLaunchTime(genesisTime))
+	if err != nil {
+		return err
+	}
+
+	report, err := networkchain.LoadPrepareReport(reportPath)
+	if err != nil {
+		return err
+	}
+	if _, err := report.Diff.WriteTo(os.Stdout); err != nil {
+		return err
+	}
+	fmt.Printf("\n%s Final genesis assembled at %s, sha256 %s\n", clispinner.OK, collector.FinalGenesisPath(), report.GenesisHash)
+
+	return nil
+}
+
+// withGentxCollectorChain runs fn with the chain of the collector at --path.
+func withGentxCollectorChain(
+	cmd *cobra.Command,
+	collector *gentxcollector.Collector,
+	fn func(ctx context.Context, c *networkchain.Chain) error,
+	options ...networkchain.Option,
+) error {
+	appPath, err := filepath.Abs(flagGetPath(cmd))
+	if err != nil {
+		return err
+	}
+
+	var (
+		s  = clispinner.New()
+		ev = events.NewBus()
+		wg sync.WaitGroup
+	)
+	wg.Add(1)
+	go printEvents(&wg, ev, s)
+	defer func() {
+		s.Stop()
+		ev.Shutdown()
+		wg.Wait()
+	}()
+
+	// the accounts of the registry are not used, the validators submit gentxs signed by their own
+	// keys.
+	options = append(
+		options,
+		networkchain.WithHome(collector.Home()),
+		networkchain.WithKeyringBackend(chaincmd.KeyringBackendTest),
+		networkchain.CollectEvents(ev),
+	)
+
+	c, err := networkchain.New(cmd.Context(), cosmosaccount.Registry{}, networkchain.SourceLocal(appPath), options...)
+	if err != nil {
+		return err
+	}
+	return fn(cmd.Context(), c)
+}
@@ -0,0 +1,146 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+const (
+	flagWait = "wait"
+
+	// govLongVotingPeriod is the voting period from which waiting for the end of the voting
+	// period suggests to shorten it.
+	govLongVotingPeriod = 5 * time.Minute
+)
+
+// NewChainGov returns a command that groups the sub commands to submit, vote and follow the
+// governance proposals of the served chain.
+func NewChainGov() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "gov [command]",
+		Short: "Submit, vote and follow governance proposals on the served chain",
+		Long: `Submit, vote and follow governance proposals on the chain served by "starport chain serve".
+
+The proposals are submitted from the account of the first validator and voted yes by the accounts
+of all the validators defined in the config by default. With --wait, the commands wait for the end
+of the voting period and show the result of the proposal. The voting period is set by the genesis
+of the chain, shorten it in the config to try proposals quickly:
+
+  genesis:
+    app_state:
+      gov:
+        voting_params:
+          voting_period: "30s"`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(
+		NewChainGovSubmit(),
+		NewChainGovVote(),
+		NewChainGovStatus(),
+	)
+
+	return c
+}
+
+// govOutput is the output of a proposal.
+type govOutput struct {
+	ID            uint64                  `json:"id"`
+	Title         string                  `json:"title"`
+	Type          string                  `json:"type"`
+	Status        string                  `json:"status"`
+	VotingEndTime time.Time               `json:"voting_end_time"`
+	Tally         chaincmdrunner.GovTally `json:"tally"`
+}
+
+func flagSetGovChain(c *cobra.Command) {
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().BoolP("verbose", "v", false, "Verbose output")
+}
+
+func newGovChain(cmd *cobra.Command) (*chain.Chain, chaincmdrunner.Runner, error) {
+	c, err := newChainWithHomeFlags(cmd,
+		chain.LogLevel(logLevel(cmd)),
+		chain.KeyringBackend(chaincmd.KeyringBackendTest),
+	)
+	if err != nil {
+		return nil, chaincmdrunner.Runner{}, err
+	}
+
+	commands, err := c.Commands(cmd.Context())
+	if err != nil {
+		return nil, chaincmdrunner.Runner{}, err
+	}
+	return c, commands, nil
+}
+
+func parseProposalID(arg string) (uint64, error) {
+	id, err := strconv.ParseUint(arg, 10, 64)
+	return id, errors.Wrapf(err, "invalid proposal id %q", arg)
+}
+
+// waitProposal waits for the end of the voting period of the proposal and prints its result.
+func waitProposal(cmd *cobra.Command, c *chain.Chain, commands chaincmdrunner.Runner, id uint64) error {
+	params, err := commands.GovParams(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if params.VotingPeriod > govLongVotingPeriod {
+		fmt.Printf(
+			"💡 The voting period is %s, set genesis.app_state.gov.voting_params.voting_period in the config to shorten it.\n",
+			params.VotingPeriod,
+		)
+	}
+
+	s := clispinner.New().SetText(fmt.Sprintf("Waiting for the end of the voting period of proposal %d...", id))
+	defer s.Stop()
+
+	proposal, err := c.GovWaitProposal(cmd.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	s.Stop()
+
+	return printGovProposal(cmd, proposal)
+}
+
+func printGovProposal(cmd *cobra.Command, p chaincmdrunner.GovProposal) error {
+	output := govOutput{
+		ID:            p.ID,
+		Title:         p.Title,
+		Type:          p.Type,
+		Status:        p.Status,
+		VotingEndTime: p.VotingEndTime,
+		Tally:         p.Tally,
+	}
+
+	return printOutput(cmd, output, func(out io.Writer) error {
+		fmt.Fprintf(out, "Proposal %d %q is %s\n", output.ID, output.Title, output.Status)
+		fmt.Fprintf(out, "Type: %s\n", output.Type)
+		if !output.VotingEndTime.IsZero() {
+			fmt.Fprintf(out, "Voting end time: %s\n", output.VotingEndTime.Local().Format(time.RFC3339))
+		}
+		fmt.Fprintf(
+			out,
+			"Tally: yes %s, no %s, no with veto %s, abstain %s\n",
+			output.Tally.Yes,
+			output.Tally.No,
+			output.Tally.NoWithVeto,
+			output.Tally.Abstain,
+		)
+		return nil
+	})
+}
@@ -0,0 +1,45 @@
+package starportcmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewChainGovStatus returns a command to show the status of a proposal.
+func NewChainGovStatus() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "status [proposal-id]",
+		Short: "Show the status and the tally of a proposal",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainGovStatusHandler,
+	}
+
+	flagSetGovChain(c)
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	c.Flags().Bool(flagWait, false, "Wait for the end of the voting period and show the result")
+
+	return c
+}
+
+func chainGovStatusHandler(cmd *cobra.Command, args []string) error {
+	wait, _ := cmd.Flags().GetBool(flagWait)
+
+	id, err := parseProposalID(args[0])
+	if err != nil {
+		return err
+	}
+
+	c, commands, err := newGovChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if wait {
+		return waitProposal(cmd, c, commands, id)
+	}
+
+	proposal, err := commands.GovProposal(cmd.Context(), id)
+	if err != nil {
+		return err
+	}
+	return printGovProposal(cmd, proposal)
+}
@@ -0,0 +1,213 @@
+package starportcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+)
+
+const (
+	flagTitle         = "title"
+	flagProposalDesc  = "description"
+	flagDeposit       = "deposit"
+	flagNoVote        = "no-vote"
+	flagUpgradeHeight = "upgrade-height"
+	flagUpgradeInfo   = "upgrade-info"
+)
+
+// NewChainGovSubmit returns a command that groups the sub commands to submit proposals.
+func NewChainGovSubmit() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "submit [command]",
+		Short: "Submit a proposal and vote it with the validators",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(
+		NewChainGovSubmitText(),
+		NewChainGovSubmitParamChange(),
+		NewChainGovSubmitSoftwareUpgrade(),
+	)
+
+	return c
+}
+
+// NewChainGovSubmitText returns a command to submit a text proposal.
+func NewChainGovSubmitText() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "text",
+		Short: "Submit a text proposal",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chainGovSubmitHandler(cmd, "Text proposal", func(commands chaincmdrunner.Runner, from, title, description, deposit string) (uint64, error) {
+				return commands.GovSubmitTextProposal(cmd.Context(), from, title, description, deposit)
+			})
+		},
+	}
+
+	flagSetGovSubmit(c)
+
+	return c
+}
+
+// NewChainGovSubmitParamChange returns a command to submit a proposal to change a param.
+func NewChainGovSubmitParamChange() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "param-change [subspace] [key] [value]",
+		Short: "Submit a proposal to change a param of a module",
+		Long: `Submit a proposal to change a param of a module.
+
+The value is the JSON of the param, values that are not valid JSON are changed as strings.`,
+		Example: `  starport chain gov submit param-change staking MaxValidators 50
+  starport chain gov submit param-change mint InflationMax 0.15`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			change := chaincmdrunner.GovParamChange{
+				Subspace: args[0],
+				Key:      args[1],
+				Value:    json.RawMessage(args[2]),
+			}
+			if !json.Valid(change.Value) {
+				value, err := json.Marshal(args[2])
+				if err != nil {
+					return err
+				}
+				change.Value = value
+			}
+
+			return chainGovSubmitHandler(cmd, fmt.Sprintf("Change %s %s", change.Subspace, change.Key), func(commands chaincmdrunner.Runner, from, title, description, deposit string) (uint64, error) {
+				return commands.GovSubmitParamChangeProposal(
+					cmd.Context(),
+					from,
+					title,
+					description,
+					deposit,
+					[]chaincmdrunner.GovParamChange{change},
+				)
+			})
+		},
+	}
+
+	flagSetGovSubmit(c)
+
+	return c
+}
+
+// NewChainGovSubmitSoftwareUpgrade returns a command to submit a software upgrade proposal.
+func NewChainGovSubmitSoftwareUpgrade() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "software-upgrade [name]",
+		Short: "Submit a proposal to upgrade the chain at a height",
+		Long: `Submit a proposal to upgrade the chain at a height.
+
+The name is the name of the upgrade handler of the new version of the chain. The height must be
+after the end of the voting period.`,
+		Example: "  starport chain gov submit software-upgrade v2 --upgrade-height 100 --wait",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				height, _ = cmd.Flags().GetInt64(flagUpgradeHeight)
+				info, _   = cmd.Flags().GetString(flagUpgradeInfo)
+			)
+			if height <= 0 {
+				return errors.New("--upgrade-height is required")
+			}
+
+			return chainGovSubmitHandler(cmd, fmt.Sprintf("Upgrade to %s", args[0]), func(commands chaincmdrunner.Runner, from, title, description, deposit string) (uint64, error) {
+				return commands.GovSubmitSoftwareUpgradeProposal(cmd.Context(), from, args[0], height, info, title, description, deposit)
+			})
+		},
+	}
+
+	flagSetGovSubmit(c)
+	c.Flags().Int64(flagUpgradeHeight, 0, "Height of the upgrade")
+	c.Flags().String(flagUpgradeInfo, "", "Info of the upgrade, e.g. the binaries of the new version")
+
+	return c
+}
+
+func flagSetGovSubmit(c *cobra.Command) {
+	flagSetGovChain(c)
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	c.Flags().String(flagTitle, "", "Title of the proposal (default: a title of the proposed change)")
+	c.Flags().String(flagProposalDesc, "", "Description of the proposal (default: the title)")
+	c.Flags().String(flagDeposit, "", "Deposit of the proposal (default: the min deposit of the chain)")
+	c.Flags().String(flagFrom, "", "Account that submits the proposal (default: the account of the first validator)")
+	c.Flags().Bool(flagNoVote, false, "Don't vote yes with the accounts of the validators")
+	c.Flags().Bool(flagWait, false, "Wait for the end of the voting period and show the result")
+}
+
+func chainGovSubmitHandler(
+	cmd *cobra.Command,
+	defaultTitle string,
+	submit func(commands chaincmdrunner.Runner, from, title, description, deposit string) (uint64, error),
+) error {
+	var (
+		title, _       = cmd.Flags().GetString(flagTitle)
+		description, _ = cmd.Flags().GetString(flagProposalDesc)
+		deposit, _     = cmd.Flags().GetString(flagDeposit)
+		from, _        = cmd.Flags().GetString(flagFrom)
+		noVote, _      = cmd.Flags().GetBool(flagNoVote)
+		wait, _        = cmd.Flags().GetBool(flagWait)
+	)
+
+	c, commands, err := newGovChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if from == "" {
+		conf, err := c.Config()
+		if err != nil {
+			return err
+		}
+		if len(conf.Validators) == 0 {
+			return errors.New("no validators in the config, set the account with --from")
+		}
+		from = conf.Validators[0].Name
+	}
+
+	if deposit == "" {
+		params, err := commands.GovParams(cmd.Context())
+		if err != nil {
+			return err
+		}
+		deposit = params.MinDeposit.String()
+	}
+
+	if title == "" {
+		title = defaultTitle
+	}
+	if description == "" {
+		description = title
+	}
+
+	id, err := submit(commands, from, title, description, deposit)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🗳  Submitted proposal %d from %q.\n", id, from)
+
+	if !noVote {
+		voters, err := c.GovVoteWithValidators(cmd.Context(), id, "yes")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Voted yes with %s.\n", strings.Join(voters, ", "))
+	}
+
+	if wait {
+		return waitProposal(cmd, c, commands, id)
+	}
+
+	proposal, err := commands.GovProposal(cmd.Context(), id)
+	if err != nil {
+		return err
+	}
+	return printGovProposal(cmd, proposal)
+}
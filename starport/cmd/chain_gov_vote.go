@@ -0,0 +1,67 @@
+package starportcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// NewChainGovVote returns a command to vote on a proposal.
+func NewChainGovVote() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "vote [proposal-id] [yes|no|no_with_veto|abstain]",
+		Short: "Vote on a proposal with the validators",
+		Long: `Vote on a proposal with the accounts of all the validators defined in the config, or with
+the account set by --from.`,
+		Args: cobra.ExactArgs(2),
+		RunE: chainGovVoteHandler,
+	}
+
+	flagSetGovChain(c)
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	c.Flags().String(flagFrom, "", "Account that votes (default: the accounts of the validators)")
+	c.Flags().Bool(flagWait, false, "Wait for the end of the voting period and show the result")
+
+	return c
+}
+
+func chainGovVoteHandler(cmd *cobra.Command, args []string) error {
+	var (
+		option  = args[1]
+		from, _ = cmd.Flags().GetString(flagFrom)
+		wait, _ = cmd.Flags().GetBool(flagWait)
+		voters  []string
+	)
+
+	switch option {
+	case "yes", "no", "no_with_veto", "abstain":
+	default:
+		return fmt.Errorf("invalid vote option %q, must be one of: yes, no, no_with_veto, abstain", option)
+	}
+
+	id, err := parseProposalID(args[0])
+	if err != nil {
+		return err
+	}
+
+	c, commands, err := newGovChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if from != "" {
+		if err := commands.GovVote(cmd.Context(), from, id, option); err != nil {
+			return err
+		}
+		voters = []string{from}
+	} else if voters, err = c.GovVoteWithValidators(cmd.Context(), id, option); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Voted %s with %s.\n", option, strings.Join(voters, ", "))
+
+	if wait {
+		return waitProposal(cmd, c, commands, id)
+	}
+	return nil
+}
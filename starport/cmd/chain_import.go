@@ -0,0 +1,114 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/goanalysis"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+const (
+	flagMain        = "main"
+	flagBinary      = "binary"
+	flagDefaultHome = "default-home"
+)
+
+// NewChainImport returns a command to import a chain that is not scaffolded by Starport.
+func NewChainImport() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "import",
+		Short: "Import a chain that is not scaffolded by Starport",
+		Long: `Import a chain that is not scaffolded by Starport, e.g. a gaiad-like repository, so it can be
+built, served and published to Starport Network like the scaffolded chains.
+
+The layout of the chain is detected from its source code and written to starport.yml at the root
+of the chain:
+
+  main     the main package of the app's binary, e.g. cmd/gaiad
+  binary   the name of the app's binary, e.g. gaiad
+  home     the default home of the node, from the DefaultNodeHome var of the app
+  watch    the paths the app is built from, watched to rebuild it during serve
+
+The detected values can be overridden with the flags, and starport.yml can be edited afterwards.
+The build settings of config.yml take precedence over starport.yml.`,
+		Args: cobra.NoArgs,
+		RunE: chainImportHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().String(flagMain, "", "Path of the main package of the app's binary, e.g. cmd/gaiad")
+	c.Flags().String(flagBinary, "", "Name of the app's binary (default: the name of the main package)")
+	c.Flags().String(flagDefaultHome, "", "Default home of the node, e.g. $HOME/.gaia")
+	c.Flags().Bool(flagForce, false, "Overwrite the existing starport.yml")
+
+	return c
+}
+
+func chainImportHandler(cmd *cobra.Command, _ []string) error {
+	var (
+		appPath   = flagGetPath(cmd)
+		main, _   = cmd.Flags().GetString(flagMain)
+		binary, _ = cmd.Flags().GetString(flagBinary)
+		home, _   = cmd.Flags().GetString(flagDefaultHome)
+		force, _  = cmd.Flags().GetBool(flagForce)
+	)
+
+	absPath, err := filepath.Abs(appPath)
+	if err != nil {
+		return err
+	}
+	app, err := chain.NewAppAt(absPath)
+	if err != nil {
+		return err
+	}
+	if _, found, err := chain.ReadAdapter(app.Path); err != nil {
+		return err
+	} else if found && !force {
+		return fmt.Errorf("%s already exists, use --%s to overwrite it", chain.AdapterFile, flagForce)
+	}
+
+	var options []chain.DetectOption
+	if main != "" {
+		options = append(options, chain.DetectWithMain(main))
+	}
+
+	s := clispinner.New().SetText("Detecting the layout of the chain...")
+	d, err := chain.DetectAdapter(app.Path, options...)
+	s.Stop()
+	if errors.Is(err, goanalysis.ErrMultipleMainPackagesFound) {
+		return fmt.Errorf("%w, use --%s to give the main package of the app's binary", err, flagMain)
+	}
+	if err != nil {
+		return err
+	}
+
+	if binary != "" {
+		d.Binary = binary
+		d.Name = strings.TrimSuffix(binary, "d")
+	}
+	if home != "" {
+		d.Home = home
+	}
+
+	if err := chain.WriteAdapter(app.Path, d.Adapter); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Chain imported, its layout is written to %s\n\n", clispinner.OK, chain.AdapterFile)
+	fmt.Printf("%s App constructor: %s\n", clispinner.Bullet, d.AppConstructor)
+	fmt.Printf("%s Main package: %s\n", clispinner.Bullet, d.Main)
+	fmt.Printf("%s Binary: %s\n", clispinner.Bullet, d.Binary)
+	if d.Home != "" {
+		fmt.Printf("%s Home: %s\n", clispinner.Bullet, d.Home)
+	} else {
+		fmt.Printf("%s Home: not detected, $HOME/.%s is used\n", clispinner.Bullet, d.Name)
+	}
+	fmt.Printf("%s Watched paths: %s\n", clispinner.Bullet, strings.Join(d.Watch, ", "))
+	return nil
+}
@@ -9,16 +9,28 @@ import (
 	"github.com/tendermint/starport/starport/services/chain"
 )
 
+const flagPeers = "peers"
+
 func NewChainInit() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize your chain",
-		Args:  cobra.NoArgs,
-		RunE:  chainInitHandler,
+		Long: `Initialize the home of your chain with a local genesis that has the accounts and validators of
+your config.
+
+To join a network that is already running, give its genesis with --genesis and its peers with --peers
+instead, the node is initialized with the genesis of the network and no local genesis is created:
+
+  starport chain init --genesis https://example.com/genesis.json --peers 3b7a...@1.2.3.4:26656`,
+		Args: cobra.NoArgs,
+		RunE: chainInitHandler,
 	}
 
 	flagSetPath(c)
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().String(flagGenesis, "", "URL or path of the genesis of a running network to join")
+	c.Flags().StringSlice(flagPeers, nil, "Persistent peers of the network to join in the id@host:port format")
 
 	return c
 }
@@ -38,8 +50,22 @@ func chainInitHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Init(cmd.Context(), true); err != nil {
-		return err
+	var (
+		genesis, _ = cmd.Flags().GetString(flagGenesis)
+		peers, _   = cmd.Flags().GetStringSlice(flagPeers)
+	)
+
+	switch {
+	case genesis != "":
+		if err := c.InitFromGenesis(cmd.Context(), genesis, peers); err != nil {
+			return err
+		}
+	case len(peers) > 0:
+		return fmt.Errorf("--%s requires --%s", flagPeers, flagGenesis)
+	default:
+		if err := c.Init(cmd.Context(), true); err != nil {
+			return err
+		}
 	}
 
 	home, err := c.Home()
@@ -0,0 +1,103 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosmigrate"
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+)
+
+const (
+	flagTo     = "to"
+	flagDryRun = "dry-run"
+)
+
+// NewChainMigrateSDK returns a command to migrate the code of a chain to a version of the SDK.
+func NewChainMigrateSDK() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "migrate-sdk",
+		Short: "Migrate the code of a chain to a version of the Cosmos SDK",
+		Long: `Migrate the code scaffolded by Starport to a version of the Cosmos SDK and upgrade the
+dependencies of go.mod with the versions that are compatible with it.
+
+The mechanical changes, like the import paths of the IBC module that moved to ibc-go, the renamed
+APIs and the wiring of app.go, are applied to the Go files of the chain. The changes that can't
+be automated are reported as manual steps with their positions in the code.`,
+		Example: "  starport chain migrate-sdk --to v0.44.x --dry-run",
+		Args:    cobra.NoArgs,
+		RunE:    chainMigrateSDKHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	c.Flags().String(flagTo, "", "Version of the Cosmos SDK, a line like v0.44.x or a version like v0.44.5")
+	c.Flags().Bool(flagDryRun, false, "Report the changes without writing them")
+
+	return c
+}
+
+func chainMigrateSDKHandler(cmd *cobra.Command, args []string) error {
+	var (
+		path      = flagGetPath(cmd)
+		target, _ = cmd.Flags().GetString(flagTo)
+		dryRun, _ = cmd.Flags().GetBool(flagDryRun)
+		options   []cosmosmigrate.Option
+	)
+	if target == "" {
+		return fmt.Errorf("--%s is required", flagTo)
+	}
+	if dryRun {
+		options = append(options, cosmosmigrate.DryRun())
+	}
+
+	s := clispinner.New().SetText("Migrating the code...")
+	defer s.Stop()
+
+	report, err := cosmosmigrate.Migrate(path, target, options...)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && len(report.Dependencies) > 0 {
+		s.SetText("Tidying go.mod...")
+		if err := gocmd.ModTidy(cmd.Context(), path); err != nil {
+			return fmt.Errorf("the chain is migrated but go mod tidy failed: %w", err)
+		}
+	}
+
+	s.Stop()
+
+	return printOutput(cmd, report, func(out io.Writer) error {
+		if len(report.Changes) == 0 && len(report.Dependencies) == 0 {
+			fmt.Fprintf(out, "%s The chain is up to date with the Cosmos SDK %s.\n", clispinner.OK, report.To)
+			return nil
+		}
+
+		if dryRun {
+			fmt.Fprintf(out, "Migrating the chain from the Cosmos SDK %s to %s would make these changes:\n\n", report.From, report.To)
+		} else {
+			fmt.Fprintf(out, "%s Migrated the chain from the Cosmos SDK %s to %s:\n\n", clispinner.OK, report.From, report.To)
+		}
+		for _, change := range report.Dependencies {
+			fmt.Fprintf(out, "  go.mod: %s\n", change)
+		}
+		for _, change := range report.Changes {
+			fmt.Fprintf(out, "  %s\n", change)
+		}
+		fmt.Fprintln(out)
+
+		if len(report.Steps) == 0 {
+			return nil
+		}
+		fmt.Fprintf(out, "⚠️  %d manual step(s) are left to finish the migration:\n\n", len(report.Steps))
+		for _, step := range report.Steps {
+			fmt.Fprintf(out, "  %s\n", step)
+		}
+		fmt.Fprintln(out)
+		return nil
+	})
+}
@@ -0,0 +1,69 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosbuf"
+)
+
+const flagAgainst = "against"
+
+// NewChainProtoCheck returns a new command to check the proto files of a blockchain for breaking changes.
+func NewChainProtoCheck() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "proto-check",
+		Short: "Check proto files for breaking changes against the last release",
+		Long: `Check proto files of the blockchain for breaking changes by using buf.
+
+By default, proto files are compared with their versions at the latest git tag of the
+blockchain's repository. Use --against to compare with a specific tag.
+
+Requires buf to be installed: https://docs.buf.build/installation`,
+		Args: cobra.NoArgs,
+		RunE: chainProtoCheckHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().String(flagAgainst, "", "git tag to compare proto files with (default: latest tag)")
+
+	return c
+}
+
+func chainProtoCheckHandler(cmd *cobra.Command, args []string) error {
+	s := clispinner.New().SetText("Checking proto files...")
+	defer s.Stop()
+
+	against, _ := cmd.Flags().GetString(flagAgainst)
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	tag, err := c.CheckProtoBreaking(cmd.Context(), against)
+
+	var annotations cosmosbuf.Annotations
+	if errors.As(err, &annotations) {
+		s.Stop()
+
+		fmt.Printf("❌ Found %d breaking change(s) against %s:\n\n", len(annotations), tag)
+		for _, annotation := range annotations {
+			fmt.Printf("  %s\n", annotation)
+		}
+		fmt.Println()
+
+		return errors.New("proto files have breaking changes")
+	}
+	if err != nil {
+		return err
+	}
+
+	s.Stop()
+	fmt.Printf("✅ No breaking changes found against %s.\n", tag)
+
+	return nil
+}
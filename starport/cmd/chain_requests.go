@@ -0,0 +1,104 @@
+package starportcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/httprecorder"
+)
+
+const (
+	flagFollow = "follow"
+	flagLines  = "lines"
+	flagKind   = "kind"
+)
+
+// NewChainRequests returns a command that groups the sub commands related to the requests
+// recorded while serving the chain.
+func NewChainRequests() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "requests [command]",
+		Short: "View the requests sent to the API and the gRPC server of the served chain",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewChainRequestsTail())
+
+	return c
+}
+
+// NewChainRequestsTail returns a command to print the recorded requests.
+func NewChainRequestsTail() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "tail",
+		Short: "Print the last requests recorded by the proxies of serve",
+		Long: `Print the last requests recorded by the proxies of "starport chain serve --record-requests".
+
+The requests sent to the API and the gRPC server through the proxies at host.api-recorder and
+host.grpc-recorder are recorded with their responses, the bodies of the gRPC requests are printed
+in base64 with --output json.`,
+		Example: "  starport chain requests tail -f --kind grpc",
+		Args:    cobra.NoArgs,
+		RunE:    chainRequestsTailHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	c.Flags().BoolP(flagFollow, "f", false, "Print the requests as they're recorded")
+	c.Flags().IntP(flagLines, "n", 10, "Number of the last requests to print, 0 prints all of them")
+	c.Flags().String(flagKind, "", "Print only the requests of a kind: api or grpc")
+
+	return c
+}
+
+func chainRequestsTailHandler(cmd *cobra.Command, args []string) error {
+	var (
+		follow, _ = cmd.Flags().GetBool(flagFollow)
+		lines, _  = cmd.Flags().GetInt(flagLines)
+		kind, _   = cmd.Flags().GetString(flagKind)
+	)
+
+	options := []httprecorder.TailOption{httprecorder.TailLast(lines)}
+	if follow {
+		options = append(options, httprecorder.TailFollow())
+	}
+	switch k := httprecorder.Kind(kind); k {
+	case "":
+	case httprecorder.KindAPI, httprecorder.KindGRPC:
+		options = append(options, httprecorder.TailKind(k))
+	default:
+		return fmt.Errorf("invalid kind %q, must be one of: api, grpc", kind)
+	}
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	path, err := c.RequestsPath()
+	if err != nil {
+		return err
+	}
+
+	err = httprecorder.Tail(cmd.Context(), path, func(rec httprecorder.Record) error {
+		return printOutput(cmd, rec, func(out io.Writer) error {
+			_, err := fmt.Fprintln(out, rec)
+			return err
+		})
+	}, options...)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("no requests are recorded, serve the chain with --record-requests to record them: %w", err)
+	case errors.Is(err, context.Canceled):
+		// the requests are followed until the command is interrupted.
+		return nil
+	}
+	return err
+}
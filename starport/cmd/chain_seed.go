@@ -0,0 +1,83 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+// NewChainSeed returns a command that groups the sub commands related to the seed txs of a chain.
+func NewChainSeed() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "seed [command]",
+		Short: "Send the txs of a seed to the served chain",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewChainSeedRun())
+
+	return c
+}
+
+// NewChainSeedRun returns a command to send the txs of a seed file to the served chain.
+func NewChainSeedRun() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "run [file]",
+		Short: "Send the txs of a seed file or of the config to the served chain",
+		Long: `Send the txs of a seed file to the chain served by "starport chain serve" in order, each
+tx is added to a block before the next one is sent.
+
+The seed file has a list of txs in the format of the seed section of the config, the txs of the
+config are sent when no file is given. "starport chain serve" sends the txs of the config after
+every reset of the chain.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: chainSeedRunHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().BoolP("verbose", "v", false, "Verbose output")
+
+	return c
+}
+
+func chainSeedRunHandler(cmd *cobra.Command, args []string) error {
+	c, err := newChainWithHomeFlags(cmd,
+		chain.LogLevel(logLevel(cmd)),
+		chain.KeyringBackend(chaincmd.KeyringBackendTest),
+	)
+	if err != nil {
+		return err
+	}
+
+	var txs []chainconfig.SeedTx
+	if len(args) > 0 {
+		if txs, err = chainconfig.ParseSeedFile(args[0]); err != nil {
+			return err
+		}
+	} else {
+		conf, err := c.Config()
+		if err != nil {
+			return err
+		}
+		txs = conf.Seed
+	}
+
+	s := clispinner.New().SetText(fmt.Sprintf("Sending %d seed txs...", len(txs)))
+	defer s.Stop()
+
+	if err := c.Seed(cmd.Context(), txs); err != nil {
+		return err
+	}
+
+	s.Stop()
+
+	fmt.Printf("🌱 Seeded the chain with %d txs.\n", len(txs))
+	return nil
+}
@@ -1,15 +1,28 @@
 package starportcmd
 
 import (
+	"fmt"
+	"net/url"
+	"sync"
+
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/xssh"
 	"github.com/tendermint/starport/starport/services/chain"
 )
 
 const (
-	flagForceReset = "force-reset"
-	flagResetOnce  = "reset-once"
-	flagConfig     = "config"
+	flagForceReset  = "force-reset"
+	flagResetOnce   = "reset-once"
+	flagConfig      = "config"
+	flagGRPCUI      = "grpc-ui"
+	flagGraphQL     = "graphql"
+	flagPublic      = "public"
+	flagRecord      = "record-requests"
+	flagIndex       = "index"
+	flagTarget      = "target"
+	flagTargetBuild = "target-build"
 )
 
 // NewChainServe creates a new serve command to serve a blockchain.
@@ -19,23 +32,43 @@ func NewChainServe() *cobra.Command {
 		Short: "Start a blockchain node in development",
 		Long:  "Start a blockchain node with automatic reloading",
 		Args:  cobra.NoArgs,
-		RunE:  chainServeHandler,
+		RunE:  recordOperation(chainServeHandler),
 	}
 
 	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetWorkspaceChain())
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
 	c.Flags().AddFlagSet(flagSetProto3rdParty(""))
 	c.Flags().BoolP("verbose", "v", false, "Verbose output")
 	c.Flags().BoolP(flagForceReset, "f", false, "Force reset of the app state on start and every source change")
 	c.Flags().BoolP(flagResetOnce, "r", false, "Reset of the app state on first start")
 	c.Flags().StringP(flagConfig, "c", "", "Starport config file (default: ./config.yml)")
+	c.Flags().Bool(flagGRPCUI, false, "Start a web UI to explore and invoke the gRPC services of the node")
+	c.Flags().Bool(flagGraphQL, false, "Start a GraphQL API for the queries of the modules of the chain")
+	c.Flags().Bool(flagPublic, false, "Expose the RPC, the API and the faucet over TLS at the hostnames under proxy.domain of the config")
+	c.Flags().Bool(flagRecord, false, "Start proxies of the API and the gRPC server that record the requests")
+	c.Flags().String(flagIndex, "", "Index the blocks, txs and events in the PostgreSQL database with the URL (e.g. postgres://localhost/chain)")
+	c.Flags().String(flagTarget, "", "Serve the chain on the machine over SSH instead of locally (e.g. ssh://user@host/path)")
+	c.Flags().String(flagTargetBuild, chain.TargetBuildRemote, fmt.Sprintf("Build the chain served on a target with Starport on the target (%s) or cross-compile it locally (%s)", chain.TargetBuildRemote, chain.TargetBuildLocal))
 
 	return c
 }
 
 func chainServeHandler(cmd *cobra.Command, args []string) error {
+	var (
+		wg  sync.WaitGroup
+		bus = events.NewBus()
+		ev  = recordEvents(bus)
+	)
+	wg.Add(1)
+	go printLogEvents(&wg, bus)
+	defer wg.Wait()
+	defer ev.Shutdown()
+
 	chainOption := []chain.Option{
 		chain.LogLevel(logLevel(cmd)),
+		chain.CollectEvents(ev),
 	}
 
 	if flagGetProto3rdParty(cmd) {
@@ -73,6 +106,62 @@ func chainServeHandler(cmd *cobra.Command, args []string) error {
 	if resetOnce {
 		serveOptions = append(serveOptions, chain.ServeResetOnce())
 	}
+	grpcUI, err := cmd.Flags().GetBool(flagGRPCUI)
+	if err != nil {
+		return err
+	}
+	if grpcUI {
+		serveOptions = append(serveOptions, chain.ServeGRPCUI())
+	}
+	graphQL, err := cmd.Flags().GetBool(flagGraphQL)
+	if err != nil {
+		return err
+	}
+	if graphQL {
+		serveOptions = append(serveOptions, chain.ServeGraphQL())
+	}
+	public, err := cmd.Flags().GetBool(flagPublic)
+	if err != nil {
+		return err
+	}
+	if public {
+		serveOptions = append(serveOptions, chain.ServePublic())
+	}
+	record, err := cmd.Flags().GetBool(flagRecord)
+	if err != nil {
+		return err
+	}
+	if record {
+		serveOptions = append(serveOptions, chain.ServeRecordRequests())
+	}
+	index, err := cmd.Flags().GetString(flagIndex)
+	if err != nil {
+		return err
+	}
+	if index != "" {
+		if u, err := url.Parse(index); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			return fmt.Errorf("--%s must be the URL of a PostgreSQL database, e.g. postgres://localhost/chain", flagIndex)
+		}
+		serveOptions = append(serveOptions, chain.ServeIndex(index))
+	}
+	target, err := cmd.Flags().GetString(flagTarget)
+	if err != nil {
+		return err
+	}
+	targetBuild, err := cmd.Flags().GetString(flagTargetBuild)
+	if err != nil {
+		return err
+	}
+	if targetBuild != chain.TargetBuildRemote && targetBuild != chain.TargetBuildLocal {
+		return fmt.Errorf("--%s must be %s or %s", flagTargetBuild, chain.TargetBuildRemote, chain.TargetBuildLocal)
+	}
+	if target != "" {
+		t, err := xssh.ParseTarget(target)
+		if err != nil {
+			return err
+		}
+		serveOptions = append(serveOptions, chain.ServeTarget(t, targetBuild))
+	}
 
 	return c.Serve(cmd.Context(), serveOptions...)
 }
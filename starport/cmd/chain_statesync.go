@@ -0,0 +1,102 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/network/networkchain"
+)
+
+const (
+	flagRPC         = "rpc"
+	flagTrustPeriod = "trust-period"
+)
+
+// NewChainStateSync returns a command that groups the sub commands related to the state sync of a chain.
+func NewChainStateSync() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "statesync [command]",
+		Short: "Sync the state of a node from the snapshots of its peers",
+		Long: `Sync the state of a node from the snapshots of its peers instead of replaying the blocks of
+the chain.
+
+The nodes serve snapshots of their state once the snapshots are enabled in app.toml, the nodes
+started by "starport chain serve" enable them with init.snapshots in the config:
+
+  init:
+    snapshots:
+      interval: 1000
+      keep-recent: 2`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewChainStateSyncBootstrap())
+
+	return c
+}
+
+// NewChainStateSyncBootstrap returns a command to enable state sync in the config of a node.
+func NewChainStateSyncBootstrap() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Enable state sync in the config of a node with a trusted block of the chain",
+		Long: `Enable state sync in the config.toml of the node in the home of the chain, the node syncs
+its state from the snapshots of its peers at its first start.
+
+The light client of state sync verifies the snapshots with the RPC servers given with --rpc, the
+block to trust is fetched from the first server. A single server is used twice since Tendermint
+requires two servers. The node must start with an empty data dir.
+
+  starport chain statesync bootstrap --rpc http://node1:26657 --rpc http://node2:26657`,
+		Args: cobra.NoArgs,
+		RunE: chainStateSyncBootstrapHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetConfigProfile())
+	c.Flags().StringArray(flagRPC, nil, "RPC server to verify the snapshots with")
+	c.Flags().String(flagTrustPeriod, "", "Period that the trusted block is trusted for (default 168h0m0s)")
+
+	return c
+}
+
+func chainStateSyncBootstrapHandler(cmd *cobra.Command, _ []string) error {
+	var (
+		rpcServers, _  = cmd.Flags().GetStringArray(flagRPC)
+		trustPeriod, _ = cmd.Flags().GetString(flagTrustPeriod)
+	)
+	if len(rpcServers) == 0 {
+		return errors.New("at least one RPC server is required, use --" + flagRPC)
+	}
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+	configPath, err := c.ConfigTOMLPath()
+	if err != nil {
+		return err
+	}
+
+	s := clispinner.New().SetText(fmt.Sprintf("Fetching the trusted block from %s...", rpcServers[0]))
+	defer s.Stop()
+
+	block, err := networkchain.ApplyStateSync(cmd.Context(), configPath, networkchain.StateSync{
+		RPCServers:  rpcServers,
+		TrustPeriod: trustPeriod,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Stop()
+
+	fmt.Printf("%s State sync enabled in %s\n", clispinner.OK, configPath)
+	fmt.Printf("%s Trust height: %d\n", clispinner.Bullet, block.Height)
+	fmt.Printf("%s Trust hash: %s\n", clispinner.Bullet, block.Hash)
+	return nil
+}
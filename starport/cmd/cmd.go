@@ -3,6 +3,7 @@ package starportcmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -13,14 +14,19 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+	"golang.org/x/term"
 
+	"github.com/tendermint/starport/starport/chainconfig"
 	"github.com/tendermint/starport/starport/internal/version"
+	"github.com/tendermint/starport/starport/pkg/cliformat"
+	"github.com/tendermint/starport/starport/pkg/cliquiz"
 	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
 	"github.com/tendermint/starport/starport/pkg/cosmosver"
 	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/pkg/gitpod"
 	"github.com/tendermint/starport/starport/pkg/goenv"
+	"github.com/tendermint/starport/starport/pkg/workspace"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/services/chain"
 	"github.com/tendermint/starport/starport/services/scaffolder"
@@ -29,8 +35,11 @@ import (
 const (
 	flagPath          = "path"
 	flagHome          = "home"
+	flagProfile       = "profile"
+	flagConfigCmds    = "allow-config-commands"
 	flagProto3rdParty = "proto-all-modules"
 	flagYes           = "yes"
+	flagNoInput       = "no-input"
 
 	checkVersionTimeout = time.Millisecond * 600
 )
@@ -41,7 +50,10 @@ var infoColor = color.New(color.FgYellow).SprintFunc()
 func New(ctx context.Context) *cobra.Command {
 	cobra.EnableCommandSorting = false
 
-	checkNewVersion(ctx)
+	// the shell waits for the completions, so the new version is not checked while completing.
+	if !isCompletionRequest() {
+		checkNewVersion(ctx)
+	}
 
 	c := &cobra.Command{
 		Use:   "starport",
@@ -56,21 +68,34 @@ starport scaffold chain github.com/cosmonaut/mars`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			configureInput(cmd)
 			return goenv.ConfigurePath()
 		},
 	}
 
+	c.PersistentFlags().Bool(flagYes, false, "Answers interactive yes/no questions with yes")
+	c.PersistentFlags().Bool(flagNoInput, false, "Never prompt and fail when an answer is required (default when the input is not a terminal)")
+
 	c.AddCommand(NewScaffold())
 	c.AddCommand(NewChain())
 	c.AddCommand(NewGenerate())
 	c.AddCommand(NewNetwork())
 	c.AddCommand(NewAccount())
 	c.AddCommand(NewRelayer())
+	c.AddCommand(NewWorkspace())
 	c.AddCommand(NewTools())
 	c.AddCommand(NewDocs())
 	c.AddCommand(NewVersion())
+	c.AddCommand(NewUpgrade())
+	c.AddCommand(NewTelemetry())
+	c.AddCommand(NewCache())
+	c.AddCommand(NewLogs())
+	c.AddCommand(NewPlugin())
 	c.AddCommand(deprecated()...)
 
+	addPlugins(ctx, c)
+	registerFlagCompletions(c)
+
 	return c
 }
 
@@ -96,6 +121,16 @@ func printEvents(wg *sync.WaitGroup, bus events.Bus, s *clispinner.Spinner) {
 	}
 }
 
+// printLogEvents prints the descriptions of events as log lines, it is used where
+// the output is not managed by a spinner, e.g. while serving a chain.
+func printLogEvents(wg *sync.WaitGroup, bus events.Bus) {
+	defer wg.Done()
+
+	for event := range bus {
+		fmt.Println(event.Text())
+	}
+}
+
 func flagSetPath(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringP(flagPath, "p", ".", "path of the app")
 }
@@ -105,6 +140,30 @@ func flagGetPath(cmd *cobra.Command) (path string) {
 	return
 }
 
+func flagSetOutputFormat() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	format := cliformat.Table
+	fs.VarP(&format, flagOutput, "o", "Output format: table, json or yaml")
+	return fs
+}
+
+func getOutputFormat(cmd *cobra.Command) cliformat.Format {
+	f := cmd.Flags().Lookup(flagOutput)
+	if f == nil {
+		return cliformat.Table
+	}
+	if format, ok := f.Value.(*cliformat.Format); ok {
+		return *format
+	}
+	return cliformat.Table
+}
+
+// printOutput prints v in the format given with --output, table prints v in the table format.
+// the commands without the flag print v in the table format.
+func printOutput(cmd *cobra.Command, v interface{}, table func(out io.Writer) error) error {
+	return cliformat.Write(os.Stdout, getOutputFormat(cmd), v, table)
+}
+
 func flagSetHome() *flag.FlagSet {
 	fs := flag.NewFlagSet("", flag.ContinueOnError)
 	fs.String(flagHome, "", "Home directory used for blockchains")
@@ -122,15 +181,18 @@ func getHome(cmd *cobra.Command) (home string) {
 	return
 }
 
-func flagSetYes() *flag.FlagSet {
-	fs := flag.NewFlagSet("", flag.ContinueOnError)
-	fs.Bool(flagYes, false, "Answers interactive yes/no questions with yes")
-	return fs
-}
-
-func getYes(cmd *cobra.Command) (ok bool) {
-	ok, _ = cmd.Flags().GetBool(flagYes)
-	return
+// configureInput disables the prompts with --no-input or when the input is not a terminal, so the
+// commands run unattended fail instead of waiting for answers.
+func configureInput(cmd *cobra.Command) {
+	var (
+		yes, _     = cmd.Flags().GetBool(flagYes)
+		noInput, _ = cmd.Flags().GetBool(flagNoInput)
+	)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		noInput = true
+	}
+	cliquiz.DisableInput(noInput)
+	cliquiz.AssumeYes(yes)
 }
 
 func flagSetProto3rdParty(additionalInfo string) *flag.FlagSet {
@@ -150,13 +212,64 @@ func flagGetProto3rdParty(cmd *cobra.Command) bool {
 	return isEnabled
 }
 
+func flagSetConfigProfile() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagProfile, "", "Profile of the config file to use (default: the config as is)")
+	fs.Bool(flagConfigCmds, false, "Run the shell commands of the ${cmd:...} references of the config file, only for trusted configs")
+	return fs
+}
+
+func getConfigProfile(cmd *cobra.Command) (profile string) {
+	profile, _ = cmd.Flags().GetString(flagProfile)
+	return
+}
+
+func getAllowConfigCommands(cmd *cobra.Command) (allow bool) {
+	allow, _ = cmd.Flags().GetBool(flagConfigCmds)
+	return
+}
+
+// configParseOptions returns the options to parse the config file with the profile flags.
+func configParseOptions(cmd *cobra.Command) []chainconfig.ParseOption {
+	options := []chainconfig.ParseOption{chainconfig.WithProfile(getConfigProfile(cmd))}
+	if getAllowConfigCommands(cmd) {
+		options = append(options, chainconfig.WithCommandSecrets())
+	}
+	return options
+}
+
 func newChainWithHomeFlags(cmd *cobra.Command, chainOption ...chain.Option) (*chain.Chain, error) {
 	// Check if custom home is provided
 	if home := getHome(cmd); home != "" {
 		chainOption = append(chainOption, chain.HomePath(home))
 	}
 
+	// Check if a config profile is selected
+	if profile := getConfigProfile(cmd); profile != "" {
+		chainOption = append(chainOption, chain.ConfigProfile(profile))
+	}
+	if getAllowConfigCommands(cmd) {
+		chainOption = append(chainOption, chain.AllowConfigCommands())
+	}
+
 	appPath := flagGetPath(cmd)
+
+	// Check if a chain of the workspace is selected, its config is overridden by the given one
+	if name := getWorkspaceChain(cmd); name != "" {
+		w, err := workspace.Open(appPath)
+		if err != nil {
+			return nil, err
+		}
+		wc, err := w.Chain(name)
+		if err != nil {
+			return nil, err
+		}
+		appPath = w.ChainPath(wc)
+		if config := w.ChainConfigPath(wc); config != "" {
+			chainOption = append([]chain.Option{chain.ConfigFile(config)}, chainOption...)
+		}
+	}
+
 	absPath, err := filepath.Abs(appPath)
 	if err != nil {
 		return nil, err
@@ -168,6 +281,7 @@ func newChainWithHomeFlags(cmd *cobra.Command, chainOption ...chain.Option) (*ch
 var (
 	modifyPrefix = color.New(color.FgMagenta).SprintFunc()("modify ")
 	createPrefix = color.New(color.FgGreen).SprintFunc()("create ")
+	deletePrefix = color.New(color.FgRed).SprintFunc()("remove ")
 	removePrefix = func(s string) string {
 		return strings.TrimPrefix(strings.TrimPrefix(s, modifyPrefix), createPrefix)
 	}
@@ -251,10 +365,11 @@ func checkNewVersion(ctx context.Context) {
 		return
 	}
 
-	fmt.Printf(`·
+	// the notice is printed to stderr to keep the outputs of the commands parsable.
+	fmt.Fprintf(os.Stderr, `·
 · 🛸 Starport %s is available!
 ·
-· To upgrade your Starport version, see the upgrade doc: https://docs.starport.network/guide/install.html#upgrading-your-starport-installation
+· To upgrade your Starport version, run "starport upgrade" or see the upgrade doc: https://docs.starport.network/guide/install.html#upgrading-your-starport-installation
 ·
 ··
 
@@ -0,0 +1,264 @@
+package starportcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/gomodulepath"
+	"github.com/tendermint/starport/starport/pkg/protoanalysis"
+	"github.com/tendermint/starport/starport/services/network"
+	"github.com/tendermint/starport/starport/templates/field/datatype"
+)
+
+// completionTimeout is the time given to the completion functions querying SPN, the shell waits
+// for the completions so they are skipped when SPN doesn't answer in time.
+const completionTimeout = time.Second * 3
+
+// isCompletionRequest returns true when starport is run by the shell to complete a command line.
+func isCompletionRequest() bool {
+	return len(os.Args) > 1 &&
+		(os.Args[1] == cobra.ShellCompRequestCmd || os.Args[1] == cobra.ShellCompNoDescRequestCmd)
+}
+
+// registerFlagCompletions registers the completion functions of the flags shared by the commands
+// of c and its sub commands, the flags are added from flag sets so they are looked up by name.
+func registerFlagCompletions(c *cobra.Command) {
+	completions := map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		flagFrom:     completeAccountNames,
+		flagCampaign: completeCampaignIDs,
+		flagModule:   completeModuleNames,
+		flagDep:      completeModuleNames,
+	}
+
+	for name, fn := range completions {
+		// the persistent flags are only registered on the command defining them.
+		if c.LocalFlags().Lookup(name) != nil {
+			_ = c.RegisterFlagCompletionFunc(name, fn)
+		}
+	}
+
+	for _, sub := range c.Commands() {
+		registerFlagCompletions(sub)
+	}
+}
+
+// completeLaunchIDs completes the first argument with the launch IDs of the chains published on
+// SPN, the chain ID of each launch is shown as its description.
+func completeLaunchIDs(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	n, ctx, cancel, err := completionNetwork(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer cancel()
+
+	launches, err := n.ChainLaunches(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]string, 0, len(launches))
+	for _, launch := range launches {
+		completions = append(completions, fmt.Sprintf("%d\t%s", launch.ID, launch.ChainID))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCampaignIDs completes with the IDs of the campaigns of SPN, the name of each campaign is
+// shown as its description.
+func completeCampaignIDs(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	n, ctx, cancel, err := completionNetwork(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer cancel()
+
+	campaigns, err := n.Campaigns(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]string, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		completions = append(completions, fmt.Sprintf("%d\t%s", campaign.ID, campaign.Name))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCampaignIDArg completes the first argument with the IDs of the campaigns of SPN.
+func completeCampaignIDArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeCampaignIDs(cmd, args, toComplete)
+}
+
+// completionNetwork returns a network to query SPN from the completion functions. the events are
+// not printed and the keyring is not accessed because the output of the completion functions is
+// read by the shell.
+func completionNetwork(cmd *cobra.Command) (network.Network, context.Context, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+
+	client, err := initNetworkCosmosClient(ctx, cmd)
+	if err != nil {
+		cancel()
+		return network.Network{}, nil, nil, err
+	}
+
+	n, err := network.New(network.NewCosmosClient(*client), cosmosaccount.Account{})
+	if err != nil {
+		cancel()
+		return network.Network{}, nil, nil, err
+	}
+	return n, ctx, cancel, nil
+}
+
+// completeAccountNames completes with the names of the accounts of the keyring.
+func completeAccountNames(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	accounts, err := ca.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, 0, len(accounts))
+	for _, acc := range accounts {
+		names = append(names, acc.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeMultisigMembers completes the arguments following the name of the multisig account with
+// the names of the accounts of the keyring.
+func completeMultisigMembers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeAccountNames(cmd, args, toComplete)
+}
+
+// completeAccountNameArg completes the first argument with the names of the accounts of the keyring.
+func completeAccountNameArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeAccountNames(cmd, args, toComplete)
+}
+
+// completeModuleNames completes with the names of the modules scaffolded in the app at --path.
+func completeModuleNames(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	modules, err := appModuleNames(flagGetPath(cmd))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return modules, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFields completes the fields given after the name of the scaffolded component, the types
+// of the fields are completed with the builtin types and the types scaffolded in the module.
+func completeFields(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	i := strings.Index(toComplete, datatype.Separator)
+	if len(args) == 0 || i == -1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	name := toComplete[:i+1]
+
+	types := make([]string, 0, len(datatype.SupportedTypes))
+	for t := range datatype.SupportedTypes {
+		if t != datatype.Custom {
+			types = append(types, string(t))
+		}
+	}
+
+	// the scaffolded types are not completed when they can't be found, the builtin types are still
+	// valid types of the fields.
+	if scaffolded, err := appTypeNames(cmd.Context(), flagGetPath(cmd), flagGetModule(cmd)); err == nil {
+		types = append(types, scaffolded...)
+	}
+	sort.Strings(types)
+
+	completions := make([]string, 0, len(types))
+	for _, t := range types {
+		completions = append(completions, name+t)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// appModuleNames returns the names of the modules scaffolded in the app at path.
+func appModuleNames(path string) ([]string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	_, appPath, err := gomodulepath.Find(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(appPath, "x"))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// appTypeNames returns the names of the proto messages of the module of the app at path that can
+// be used as the types of fields, the main module of the app is used when module is empty.
+func appTypeNames(ctx context.Context, path, module string) ([]string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	modpath, appPath, err := gomodulepath.Find(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if module == "" {
+		module = modpath.Package
+	}
+
+	pkgs, err := protoanalysis.Parse(ctx, protoanalysis.NewCache(), filepath.Join(appPath, "proto", module))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, pkg := range pkgs {
+		for _, message := range pkg.Messages {
+			// the messages of the transactions, queries and genesis can't be used as field types.
+			if strings.HasPrefix(message.Name, "Msg") ||
+				strings.HasPrefix(message.Name, "Query") ||
+				message.Name == "GenesisState" {
+				continue
+			}
+			names = append(names, message.Name)
+		}
+	}
+	return names, nil
+}
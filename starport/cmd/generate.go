@@ -1,6 +1,15 @@
 package starportcmd
 
-import "github.com/spf13/cobra"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+	"github.com/tendermint/starport/starport/services/chain"
+)
 
 // NewGenerate returns a command that groups code generation related sub commands.
 func NewGenerate() *cobra.Command {
@@ -11,16 +20,73 @@ func NewGenerate() *cobra.Command {
 
 Such as compiling protocol buffer files into Go or implement particular functionality, for example, generating an OpenAPI spec.
 
-Produced source code can be regenerated by running a command again and is not meant to be edited by hand.`,
+Produced source code can be regenerated by running a command again and is not meant to be edited by hand.
+
+Use --check to verify that the generated code of the source code matches the proto files: the code is
+regenerated in a temporary directory, the command fails and lists the generated files that drifted.`,
 		Aliases: []string{"g"},
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.NoArgs,
+		RunE:    generateCheckHandler,
 	}
 
 	flagSetPath(c)
+	c.Flags().Bool(flagCheck, false, "Check that the generated Go code, clients and OpenAPI specs match the proto files")
+	c.Flags().AddFlagSet(flagSetProto3rdParty("Used with --check"))
 	c.AddCommand(NewGenerateGo())
+	c.AddCommand(NewGenerateGoClient())
 	c.AddCommand(NewGenerateVuex())
+	c.AddCommand(NewGenerateVue())
+	c.AddCommand(NewGenerateReact())
 	c.AddCommand(NewGenerateDart())
+	c.AddCommand(NewGenerateRust())
+	c.AddCommand(NewGenerateWasmSigner())
 	c.AddCommand(NewGenerateOpenAPI())
 
 	return c
 }
+
+func generateCheckHandler(cmd *cobra.Command, args []string) error {
+	if check, _ := cmd.Flags().GetBool(flagCheck); !check {
+		return cmd.Help()
+	}
+
+	s := clispinner.New().SetText("Checking generated code...")
+	defer s.Stop()
+
+	var chainOption []chain.Option
+	if flagGetProto3rdParty(cmd) {
+		chainOption = append(chainOption, chain.EnableThirdPartyModuleCodegen())
+	}
+
+	c, err := newChainWithHomeFlags(cmd, chainOption...)
+	if err != nil {
+		return err
+	}
+
+	changes, err := c.CheckGenerated(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	s.Stop()
+
+	if len(changes) == 0 {
+		fmt.Println("✅ Generated code matches the proto files.")
+		return nil
+	}
+
+	fmt.Printf("❌ Found %d generated file(s) that don't match the proto files:\n\n", len(changes))
+	for _, change := range changes {
+		switch change.Kind {
+		case dirchange.Modified:
+			fmt.Printf("  %s%s\n", modifyPrefix, change.Path)
+		case dirchange.Added:
+			fmt.Printf("  %s%s\n", createPrefix, change.Path)
+		case dirchange.Removed:
+			fmt.Printf("  %s%s\n", deletePrefix, change.Path)
+		}
+	}
+	fmt.Println()
+
+	return errors.New("generated code is out of date, run `starport chain build` to regenerate it")
+}
@@ -0,0 +1,39 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+func NewGenerateReact() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "react",
+		Short: "Generate React Query hooks for your chain's frontend",
+		RunE:  generateReactHandler,
+	}
+	c.Flags().AddFlagSet(flagSetProto3rdParty(""))
+	return c
+}
+
+func generateReactHandler(cmd *cobra.Command, args []string) error {
+	s := clispinner.New().SetText("Generating...")
+	defer s.Stop()
+
+	c, err := newChainWithHomeFlags(cmd, chain.EnableThirdPartyModuleCodegen())
+	if err != nil {
+		return err
+	}
+
+	if err := c.Generate(cmd.Context(), chain.GenerateReact()); err != nil {
+		return err
+	}
+
+	s.Stop()
+	fmt.Println("⛏️  Generated React hooks.")
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+func NewGenerateRust() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "rust",
+		Short: "Generate Rust client crates",
+		RunE:  generateRustHandler,
+	}
+	return c
+}
+
+func generateRustHandler(cmd *cobra.Command, args []string) error {
+	s := clispinner.New().SetText("Generating...")
+	defer s.Stop()
+
+	c, err := newChainWithHomeFlags(cmd, chain.EnableThirdPartyModuleCodegen())
+	if err != nil {
+		return err
+	}
+
+	if err := c.Generate(cmd.Context(), chain.GenerateRust()); err != nil {
+		return err
+	}
+
+	s.Stop()
+	fmt.Println("⛏️  Generated Rust client.")
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+func NewGenerateVue() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "vue",
+		Short: "Generate Vue 3 composables for your chain's frontend",
+		RunE:  generateVueHandler,
+	}
+	c.Flags().AddFlagSet(flagSetProto3rdParty(""))
+	return c
+}
+
+func generateVueHandler(cmd *cobra.Command, args []string) error {
+	s := clispinner.New().SetText("Generating...")
+	defer s.Stop()
+
+	c, err := newChainWithHomeFlags(cmd, chain.EnableThirdPartyModuleCodegen())
+	if err != nil {
+		return err
+	}
+
+	if err := c.Generate(cmd.Context(), chain.GenerateVue()); err != nil {
+		return err
+	}
+
+	s.Stop()
+	fmt.Println("⛏️  Generated Vue composables.")
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+func NewGenerateWasmSigner() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "wasm-signer",
+		Short: "Generate a WebAssembly helper to sign txs in browsers",
+		RunE:  generateWasmSignerHandler,
+	}
+	return c
+}
+
+func generateWasmSignerHandler(cmd *cobra.Command, args []string) error {
+	s := clispinner.New().SetText("Generating...")
+	defer s.Stop()
+
+	c, err := newChainWithHomeFlags(cmd, chain.EnableThirdPartyModuleCodegen())
+	if err != nil {
+		return err
+	}
+
+	if err := c.Generate(cmd.Context(), chain.GenerateWasmSigner()); err != nil {
+		return err
+	}
+
+	s.Stop()
+	fmt.Println("⛏️  Generated WebAssembly signer.")
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/oplog"
+)
+
+var (
+	logsOperationHeader = []string{"Operation", "Runs", "Last run", "Status"}
+	logsRunHeader       = []string{"Run ID", "Start", "Duration", "Events", "Status"}
+)
+
+// operationLog is the log of the run of the current command when its events are recorded.
+var operationLog *oplog.Log
+
+// NewLogs returns a command that shows the logs of the past runs of the long operations.
+func NewLogs() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "logs [operation] [run-id]",
+		Short: "Show the logs of the past runs of the long operations",
+		Long: `Show the logs of the past runs of the long operations.
+
+The events of the long operations, like publishing a chain to SPN, preparing a chain for launch or
+serving a chain, are written with their timestamps to a log per run in the data dir of Starport.
+The logs of the last 20 runs of each operation are kept.
+
+Without arguments, the operations that have runs are listed. With an operation, e.g.
+network-chain-publish, its runs are listed. With a run ID, or "latest", the events of the run and
+its error are shown.`,
+		Args:              cobra.MaximumNArgs(2),
+		ValidArgsFunction: completeOperations,
+		RunE:              logsHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func logsHandler(cmd *cobra.Command, args []string) error {
+	m, err := oplog.Default()
+	if err != nil {
+		return err
+	}
+
+	switch len(args) {
+	case 0:
+		return printOperations(cmd, m)
+	case 1:
+		return printRuns(cmd, m, args[0])
+	}
+
+	id := args[1]
+	if id == "latest" {
+		id = ""
+	}
+	r, err := m.Run(args[0], id)
+	if err != nil {
+		return err
+	}
+	return printOutput(cmd, r, func(out io.Writer) error {
+		for _, e := range r.Entries {
+			var line string
+			switch e.Kind {
+			case oplog.EntryStart:
+				line = fmt.Sprintf("run %s started %s", e.RunID, strings.Join(e.Args, " "))
+			case oplog.EntryEnd:
+				line = fmt.Sprintf("run %s %s", e.RunID, runStatus(r))
+			default:
+				line = events.New(eventStatus(e), e.Description).Text()
+			}
+			if _, err := fmt.Fprintf(out, "%s  %s\n", e.Time.Local().Format(time.RFC3339), line); err != nil {
+				return err
+			}
+		}
+		if r.Error != "" {
+			_, err := fmt.Fprintf(out, "\nError: %s\n", r.Error)
+			return err
+		}
+		return nil
+	})
+}
+
+func printOperations(cmd *cobra.Command, m oplog.Manager) error {
+	operations, err := m.Operations()
+	if err != nil {
+		return err
+	}
+
+	type operationOutput struct {
+		Operation string     `json:"operation"`
+		Runs      int        `json:"runs"`
+		LastRun   *oplog.Run `json:"last_run,omitempty"`
+	}
+	outputs := make([]operationOutput, 0, len(operations))
+	for _, operation := range operations {
+		runs, err := m.Runs(operation)
+		if err != nil {
+			return err
+		}
+		output := operationOutput{Operation: operation, Runs: len(runs)}
+		if len(runs) > 0 {
+			last := runs[0]
+			last.Entries = nil
+			output.LastRun = &last
+		}
+		outputs = append(outputs, output)
+	}
+
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		var entries [][]string
+		for _, o := range outputs {
+			lastRun, status := "-", "-"
+			if o.LastRun != nil {
+				lastRun, status = o.LastRun.Start.Local().Format(time.RFC3339), runStatus(*o.LastRun)
+			}
+			entries = append(entries, []string{o.Operation, fmt.Sprint(o.Runs), lastRun, status})
+		}
+		return entrywriter.MustWrite(out, logsOperationHeader, entries...)
+	})
+}
+
+func printRuns(cmd *cobra.Command, m oplog.Manager, operation string) error {
+	runs, err := m.Runs(operation)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return fmt.Errorf("no runs of %s", operation)
+	}
+
+	type runOutput struct {
+		ID       string    `json:"id"`
+		Start    time.Time `json:"start"`
+		Duration string    `json:"duration,omitempty"`
+		Events   int       `json:"events"`
+		Status   string    `json:"status"`
+		Error    string    `json:"error,omitempty"`
+	}
+	outputs := make([]runOutput, 0, len(runs))
+	for _, r := range runs {
+		output := runOutput{
+			ID:     r.ID,
+			Start:  r.Start,
+			Events: countEvents(r),
+			Status: runStatus(r),
+			Error:  r.Error,
+		}
+		if r.Finished() {
+			output.Duration = r.End.Sub(r.Start).Round(time.Second).String()
+		}
+		outputs = append(outputs, output)
+	}
+
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		var entries [][]string
+		for _, o := range outputs {
+			entries = append(entries, []string{
+				o.ID,
+				o.Start.Local().Format(time.RFC3339),
+				orNone(o.Duration),
+				fmt.Sprint(o.Events),
+				o.Status,
+			})
+		}
+		return entrywriter.MustWrite(out, logsRunHeader, entries...)
+	})
+}
+
+// recordOperation records the events of the handler in a new run of the operation log of the
+// command, the handler's buses are recorded with recordEvents. the command never fails because of
+// its log.
+func recordOperation(handler func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		m, err := oplog.Default()
+		if err != nil {
+			return handler(cmd, args)
+		}
+		log, err := m.Start(operationName(cmd), args)
+		if err != nil {
+			return handler(cmd, args)
+		}
+
+		operationLog = log
+		err = handler(cmd, args)
+		operationLog = nil
+
+		_ = log.Close(err)
+		return err
+	}
+}
+
+// recordEvents returns a bus recording the events sent to bus in the operation log of the command,
+// bus is returned when the command is not recorded.
+func recordEvents(bus events.Bus) events.Bus {
+	if operationLog == nil {
+		return bus
+	}
+	return operationLog.Record(bus)
+}
+
+// operationName returns the name of the operation of the command, e.g. network-chain-publish.
+func operationName(cmd *cobra.Command) string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	return strings.ReplaceAll(path, " ", "-")
+}
+
+func runStatus(r oplog.Run) string {
+	switch {
+	case !r.Finished():
+		return "unfinished"
+	case r.Error != "":
+		return "failed"
+	default:
+		return "succeeded"
+	}
+}
+
+func eventStatus(e oplog.Entry) events.Status {
+	if e.Ongoing {
+		return events.StatusOngoing
+	}
+	return events.StatusDone
+}
+
+func countEvents(r oplog.Run) (n int) {
+	for _, e := range r.Entries {
+		if e.Kind == oplog.EntryEvent {
+			n++
+		}
+	}
+	return n
+}
+
+func completeOperations(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	m, err := oplog.Default()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	operations, err := m.Operations()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return operations, cobra.ShellCompDirectiveNoFileComp
+}
@@ -1,14 +1,17 @@
 package starportcmd
 
 import (
+	"context"
 	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
 	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
 	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/pkg/gitpod"
 	"github.com/tendermint/starport/starport/services/network"
@@ -31,6 +34,8 @@ const (
 	flagSPNNodeAddress   = "spn-node-address"
 	flagSPNFaucetAddress = "spn-faucet-address"
 
+	flagDenomUnit = "denom-unit"
+
 	spnNodeAddressAlpha   = "https://rpc.alpha.starport.network:443"
 	spnFaucetAddressAlpha = "https://faucet.alpha.starport.network"
 
@@ -61,7 +66,10 @@ func NewNetwork() *cobra.Command {
 	// add sub commands.
 	c.AddCommand(
 		NewNetworkChain(),
+		NewNetworkCampaign(),
 		NewNetworkRequest(),
+		NewNetworkAccount(),
+		NewNetworkReward(),
 	)
 
 	return c
@@ -82,15 +90,18 @@ type NetworkBuilder struct {
 func newNetworkBuilder(cmd *cobra.Command) (NetworkBuilder, error) {
 	var err error
 
-	n := NetworkBuilder{
-		Spinner: clispinner.New(),
-		ev:      events.NewBus(),
-		wg:      &sync.WaitGroup{},
-		cmd:     cmd,
-	}
+	var (
+		bus = events.NewBus()
+		n   = NetworkBuilder{
+			Spinner: clispinner.New(),
+			ev:      recordEvents(bus),
+			wg:      &sync.WaitGroup{},
+			cmd:     cmd,
+		}
+	)
 
 	n.wg.Add(1)
-	go printEvents(n.wg, n.ev, n.Spinner)
+	go printEvents(n.wg, bus, n.Spinner)
 
 	if n.cc, err = getNetworkCosmosClient(cmd); err != nil {
 		n.Cleanup()
@@ -120,7 +131,7 @@ func (n NetworkBuilder) Network(options ...network.Option) (network.Network, err
 		return network.Network{}, errors.Wrap(err, "make sure that this account exists, use 'starport account -h' to manage accounts")
 	}
 
-	return network.New(*cosmos, account, options...)
+	return network.New(network.NewCosmosClient(*cosmos), account, options...)
 }
 
 func (n NetworkBuilder) Cleanup() {
@@ -129,10 +140,45 @@ func (n NetworkBuilder) Cleanup() {
 	n.wg.Wait()
 }
 
+func flagSetDenomUnits() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringArray(flagDenomUnit, nil, "Unit of a denom that the amounts can be given in with decimals, in the display=base:exponent format, e.g. mars=umars:6")
+	return fs
+}
+
+// getDenomUnits returns the denoms of the units given with --denom-unit.
+func getDenomUnits(cmd *cobra.Command) (cosmosutil.Denoms, error) {
+	units, _ := cmd.Flags().GetStringArray(flagDenomUnit)
+	denoms := make(cosmosutil.Denoms, 0, len(units))
+	for _, unit := range units {
+		denom, err := cosmosutil.ParseDenomUnit(unit)
+		if err != nil {
+			return nil, err
+		}
+		denoms = append(denoms, denom)
+	}
+	return denoms, nil
+}
+
 func getNetworkCosmosClient(cmd *cobra.Command) (cosmosclient.Client, error) {
+	client, err := initNetworkCosmosClient(cmd.Context(), cmd)
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	if err := client.AccountRegistry.EnsureDefaultAccount(); err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	return *client, nil
+}
+
+// initNetworkCosmosClient initializes the cosmos client of SPN without ensuring the default account,
+// so it can be used to query SPN without accessing the keyring.
+func initNetworkCosmosClient(ctx context.Context, cmd *cobra.Command) (*cosmosclient.Client, error) {
 	// check preconfigured networks
 	if nightly && local {
-		return cosmosclient.Client{}, errors.New("local and nightly networks can't both be specified in the same command, specify local or nightly")
+		return nil, errors.New("local and nightly networks can't both be specified in the same command, specify local or nightly")
 	}
 	if local {
 		spnNodeAddress = spnNodeAddressLocal
@@ -148,6 +194,7 @@ func getNetworkCosmosClient(cmd *cobra.Command) (cosmosclient.Client, error) {
 		cosmosclient.WithAddressPrefix(networktypes.SPN),
 		cosmosclient.WithUseFaucet(spnFaucetAddress, networktypes.SPNDenom, 5),
 		cosmosclient.WithKeyringServiceName(cosmosaccount.KeyringServiceName),
+		cosmosclient.WithAutoFees(),
 	}
 
 	keyringBackend := getKeyringBackend(cmd)
@@ -163,16 +210,12 @@ func getNetworkCosmosClient(cmd *cobra.Command) (cosmosclient.Client, error) {
 	// init cosmos client only once on start in order to spnclient to
 	// reuse unlocked keyring in the following steps.
 	if cosmos == nil {
-		client, err := cosmosclient.New(cmd.Context(), cosmosOptions...)
+		client, err := cosmosclient.New(ctx, cosmosOptions...)
 		if err != nil {
-			return cosmosclient.Client{}, err
+			return nil, err
 		}
 		cosmos = &client
 	}
 
-	if err := cosmos.AccountRegistry.EnsureDefaultAccount(); err != nil {
-		return cosmosclient.Client{}, err
-	}
-
-	return *cosmos, nil
+	return cosmos, nil
 }
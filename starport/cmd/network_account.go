@@ -0,0 +1,24 @@
+package starportcmd
+
+import "github.com/spf13/cobra"
+
+// NewNetworkAccount creates a new account command that holds some other
+// sub commands related to the SPN accounts.
+func NewNetworkAccount() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "account",
+		Short: "Manage SPN accounts",
+		Long: `Manage the balances of your SPN accounts.
+
+The accounts are shared with the account command, use 'starport account import' to recover
+an account from its mnemonic.`,
+	}
+
+	c.AddCommand(
+		NewNetworkAccountBalance(),
+		NewNetworkAccountFund(),
+		NewNetworkAccountTransfer(),
+	)
+
+	return c
+}
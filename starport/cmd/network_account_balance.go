@@ -0,0 +1,71 @@
+package starportcmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+)
+
+var balanceHeader = []string{"Amount", "Denom"}
+
+// balanceOutput is a balance as printed in the JSON and YAML formats.
+type balanceOutput struct {
+	Amount string `json:"amount"`
+	Denom  string `json:"denom"`
+}
+
+// NewNetworkAccountBalance creates a new account balance command to show
+// the balances of an SPN account.
+func NewNetworkAccountBalance() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance [address]",
+		Short: "Show the balances of an SPN account",
+		Long: `Show the balances of an SPN address, the balances of the account given with --from
+are shown when no address is given.`,
+		RunE: networkAccountBalanceHandler,
+		Args: cobra.MaximumNArgs(1),
+	}
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	return c
+}
+
+func networkAccountBalanceHandler(cmd *cobra.Command, args []string) error {
+	var address string
+	if len(args) > 0 {
+		address = args[0]
+	}
+
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	balances, err := n.Balance(cmd.Context(), address)
+	if err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	outputs := make([]balanceOutput, 0, len(balances))
+	for _, coin := range balances {
+		outputs = append(outputs, balanceOutput{Amount: coin.Amount.String(), Denom: coin.Denom})
+	}
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		entries := make([][]string, 0, len(outputs))
+		for _, b := range outputs {
+			entries = append(entries, []string{b.Amount, b.Denom})
+		}
+		return entrywriter.MustWrite(out, balanceHeader, entries...)
+	})
+}
@@ -0,0 +1,57 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+)
+
+// NewNetworkAccountFund creates a new account fund command to request
+// coins from the SPN faucet.
+func NewNetworkAccountFund() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "fund [coins]",
+		Short: "Request coins from the SPN faucet",
+		Long: `Request coins from the SPN faucet to the account given with --from, the faucet sends
+its default coins when no coins are given.`,
+		RunE: networkAccountFundHandler,
+		Args: cobra.MaximumNArgs(1),
+	}
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	return c
+}
+
+func networkAccountFundHandler(cmd *cobra.Command, args []string) error {
+	var coins sdk.Coins
+	if len(args) > 0 {
+		var err error
+		if coins, err = sdk.ParseCoinsNormalized(args[0]); err != nil {
+			return errors.Wrap(err, "error parsing coins")
+		}
+	}
+
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	if err := n.Fund(cmd.Context(), coins); err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	fmt.Printf("%s %s is funded from the faucet\n", clispinner.OK, n.AccountAddress())
+	return nil
+}
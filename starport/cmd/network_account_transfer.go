@@ -0,0 +1,60 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+)
+
+// NewNetworkAccountTransfer creates a new account transfer command to send
+// coins to another SPN account.
+func NewNetworkAccountTransfer() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "transfer [to-address] [amount]",
+		Short: "Send coins to an SPN account",
+		Long: `Send coins from the account given with --from to an SPN address:
+
+  starport network account transfer spn1... 1000uspn`,
+		RunE: networkAccountTransferHandler,
+		Args: cobra.ExactArgs(2),
+	}
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	return c
+}
+
+func networkAccountTransferHandler(cmd *cobra.Command, args []string) error {
+	toAddress := args[0]
+
+	amount, err := sdk.ParseCoinsNormalized(args[1])
+	if err != nil {
+		return errors.Wrap(err, "error parsing amount")
+	}
+	if amount.Empty() {
+		return errors.New("amount must be positive")
+	}
+
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	if err := n.Transfer(cmd.Context(), toAddress, amount); err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	fmt.Printf("%s %s sent to %s\n", clispinner.OK, amount, toAddress)
+	return nil
+}
@@ -0,0 +1,97 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/network"
+)
+
+const (
+	flagTotalSupply = "total-supply"
+	flagAllocation  = "allocation"
+)
+
+// NewNetworkCampaignCreate returns a new command to create a new campaign.
+func NewNetworkCampaignCreate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Create a new campaign",
+		Long: `Create a new campaign with its total supply and the special allocations of its shares.
+
+The allocations are given in the address=shares format and the flag can be repeated:
+
+  starport network campaign create mars --total-supply 1000000mars --allocation spn1...=1000mars
+
+With --denom-unit, the total supply and the shares can be given in the units of the denoms with
+decimals, they are converted to their base denoms:
+
+  starport network campaign create mars --denom-unit mars=umars:6 --total-supply 1000000mars --allocation spn1...=12.5mars`,
+		Args: cobra.ExactArgs(1),
+		RunE: networkCampaignCreateHandler,
+	}
+
+	c.Flags().String(flagTotalSupply, "", "Total supply of the campaign, e.g. 1000000foo,500bar")
+	c.Flags().StringArray(flagAllocation, nil, "Special allocation of the campaign's shares in the address=shares format")
+	c.Flags().AddFlagSet(flagSetDenomUnits())
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func networkCampaignCreateHandler(cmd *cobra.Command, args []string) error {
+	var (
+		name              = args[0]
+		totalSupplyStr, _ = cmd.Flags().GetString(flagTotalSupply)
+		allocationStrs, _ = cmd.Flags().GetStringArray(flagAllocation)
+	)
+
+	denoms, err := getDenomUnits(cmd)
+	if err != nil {
+		return err
+	}
+
+	var options []network.CampaignOption
+
+	if totalSupplyStr != "" {
+		totalSupply, err := denoms.ParseCoins(totalSupplyStr)
+		if err != nil {
+			return errors.Wrap(err, "error parsing total supply")
+		}
+		options = append(options, network.WithTotalSupply(totalSupply))
+	}
+
+	for _, allocationStr := range allocationStrs {
+		allocation, err := network.ParseAllocation(allocationStr, denoms)
+		if err != nil {
+			return err
+		}
+		options = append(options, network.WithAllocations(allocation))
+	}
+
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	campaignID, err := n.CreateCampaign(cmd.Context(), name, options...)
+	if err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	fmt.Printf("%s Campaign ID: %d \n", clispinner.Bullet, campaignID)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/network"
+)
+
+// NewNetworkCampaignUpdateAllocations returns a new command to update the special allocations of a campaign.
+func NewNetworkCampaignUpdateAllocations() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "update-allocations [campaign-id]",
+		Short: "Update the special allocations of the shares of a campaign",
+		Long: `Update the special allocations of the shares of a campaign coordinated by your account.
+
+The allocations are given in the address=shares format and the flag can be repeated, the shares
+are added to the shares already allocated to the addresses:
+
+  starport network campaign update-allocations 3 --allocation spn1...=1000mars
+
+With --denom-unit, the shares can be given in the units of the denoms with decimals, e.g.
+--denom-unit mars=umars:6 --allocation spn1...=12.5mars for 12500000umars.
+
+The allocations are checked against the total shares of the campaign before they are sent.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCampaignIDArg,
+		RunE:              networkCampaignUpdateAllocationsHandler,
+	}
+
+	c.Flags().StringArray(flagAllocation, nil, "Special allocation of the campaign's shares in the address=shares format")
+	c.Flags().AddFlagSet(flagSetDenomUnits())
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func networkCampaignUpdateAllocationsHandler(cmd *cobra.Command, args []string) error {
+	campaignID, err := network.ParseCampaignID(args[0])
+	if err != nil {
+		return err
+	}
+
+	allocationStrs, _ := cmd.Flags().GetStringArray(flagAllocation)
+	if len(allocationStrs) == 0 {
+		return errors.Errorf("at least one --%s is required", flagAllocation)
+	}
+
+	denoms, err := getDenomUnits(cmd)
+	if err != nil {
+		return err
+	}
+
+	allocations := make([]network.Allocation, 0, len(allocationStrs))
+	for _, allocationStr := range allocationStrs {
+		allocation, err := network.ParseAllocation(allocationStr, denoms)
+		if err != nil {
+			return err
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	if err := n.UpdateSpecialAllocations(cmd.Context(), campaignID, allocations...); err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	fmt.Printf("%s Allocations of campaign %d updated\n", clispinner.OK, campaignID)
+
+	return nil
+}
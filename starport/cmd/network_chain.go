@@ -18,7 +18,9 @@ func NewNetworkChain() *cobra.Command {
 		NewNetworkChainInit(),
 		NewNetworkChainJoin(),
 		NewNetworkChainPrepare(),
+		NewNetworkChainInstallService(),
 		NewNetworkChainShow(),
+		NewNetworkChainAudit(),
 		NewNetworkChainLaunch(),
 	)
 
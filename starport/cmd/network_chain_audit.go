@@ -0,0 +1,95 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/network/networkchain"
+)
+
+const flagMinValidators = "min-validators"
+
+// NewNetworkChainAudit creates a new chain audit command to run the pre-launch checks of a
+// mainnet on the genesis of a chain.
+func NewNetworkChainAudit() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "audit [launch-id]",
+		Short: "Run the pre-launch checks of a mainnet on the genesis of a chain",
+		Long: `Run the pre-launch checks of a mainnet on the genesis built from the approved requests
+of a chain:
+
+- the validator count and the share of the stake of the largest validator
+- the validators sharing consensus keys
+- the sum of the balances of the accounts against the supply
+- the bond denom, the unbonding period, the max validators and the voting period
+
+Each check passes, warns about a risk for a mainnet or fails, and the command fails when a check
+fails so the audit can gate the launch of the chain.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              networkChainAuditHandler,
+	}
+
+	c.Flags().Int(flagMinValidators, 4, "Number of validators below which the validator count is a warning")
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func networkChainAuditHandler(cmd *cobra.Command, args []string) error {
+	minValidators, _ := cmd.Flags().GetInt(flagMinValidators)
+
+	nb, launchID, err := networkChainLaunch(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	genesis, err := networkChainGenesis(cmd.Context(), nb, n, launchID)
+	if err != nil {
+		return err
+	}
+
+	report, err := networkchain.Audit(genesis, networkchain.AuditMinValidators(minValidators))
+	if err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	if err := printOutput(cmd, report, func(out io.Writer) error {
+		for _, check := range report.Checks {
+			icon := clispinner.OK
+			switch check.Status {
+			case networkchain.AuditWarn:
+				icon = "⚠️ "
+			case networkchain.AuditFail:
+				icon = "❌"
+			}
+			fmt.Fprintf(out, "%s %s: %s\n", icon, check.Name, check.Message)
+		}
+		fmt.Fprintf(out, "\n%d passed, %d warning(s), %d failed\n",
+			report.Count(networkchain.AuditPass),
+			report.Count(networkchain.AuditWarn),
+			report.Count(networkchain.AuditFail),
+		)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if failed := report.Count(networkchain.AuditFail); failed > 0 {
+		return fmt.Errorf("the chain failed %d check(s) of the audit", failed)
+	}
+	return nil
+}
@@ -1,11 +1,13 @@
 package starportcmd
 
 import (
+	"errors"
 	"fmt"
 
-	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
+	"github.com/tendermint/starport/starport/chainconfig"
 	"github.com/tendermint/starport/starport/pkg/cliquiz"
 	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
@@ -16,14 +18,17 @@ import (
 )
 
 const (
-	flagValidatorAccount         = "validator-account"
-	flagValidatorWebsite         = "validator-website"
-	flagValidatorDetails         = "validator-details"
-	flagValidatorSecurityContact = "validator-security-contact"
-	flagValidatorMoniker         = "validator-moniker"
-	flagValidatorIdentity        = "validator-identity"
-	flagValidatorSelfDelegation  = "validator-self-delegation"
-	flagValidatorGasPrice        = "validator-gas-price"
+	flagValidatorAccount                 = "validator-account"
+	flagValidatorWebsite                 = "validator-website"
+	flagValidatorDetails                 = "validator-details"
+	flagValidatorSecurityContact         = "validator-security-contact"
+	flagValidatorMoniker                 = "validator-moniker"
+	flagValidatorIdentity                = "validator-identity"
+	flagValidatorSelfDelegation          = "validator-self-delegation"
+	flagValidatorGasPrice                = "validator-gas-price"
+	flagValidatorCommissionRate          = "validator-commission-rate"
+	flagValidatorCommissionMaxRate       = "validator-commission-max-rate"
+	flagValidatorCommissionMaxChangeRate = "validator-commission-max-change-rate"
 )
 
 // NewNetworkChainInit returns a new command to initialize a chain from a published chain ID
@@ -31,27 +36,33 @@ func NewNetworkChainInit() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "init [launch-id]",
 		Short: "Initialize a chain from a published chain ID",
-		Args:  cobra.ExactArgs(1),
-		RunE:  networkChainInitHandler,
+		Long: `Initialize a chain from a published chain ID.
+
+The chain is built from its source unless a prebuilt binary is downloaded with --binary-url, the
+binary is served as is or in a tar.gz archive. The sha256 checksum of the file served at the URL
+is given with --binary-checksum, the binary is installed in the Go bin dir once verified.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              networkChainInitHandler,
 	}
 
 	c.Flags().String(flagValidatorAccount, cosmosaccount.DefaultAccount, "Account for the chain validator")
-	c.Flags().String(flagValidatorWebsite, "", "Associate a website with the validator")
-	c.Flags().String(flagValidatorDetails, "", "Details about the validator")
-	c.Flags().String(flagValidatorSecurityContact, "", "Validator security contact email")
-	c.Flags().String(flagValidatorMoniker, "", "Custom validator moniker")
-	c.Flags().String(flagValidatorIdentity, "", "Validator identity signature (ex. UPort or Keybase)")
-	c.Flags().String(flagValidatorSelfDelegation, "", "Validator minimum self delegation")
-	c.Flags().String(flagValidatorGasPrice, "", "Validator gas price")
+	c.Flags().AddFlagSet(flagSetValidatorGentx())
+	c.Flags().AddFlagSet(flagSetSkipGenesisVerification())
+	c.Flags().AddFlagSet(flagSetBinaryDownload())
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetHome())
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
-	c.Flags().AddFlagSet(flagSetYes())
 
 	return c
 }
 
 func networkChainInitHandler(cmd *cobra.Command, args []string) error {
+	binaryOptions, err := binaryDownloadOptions(cmd)
+	if err != nil {
+		return err
+	}
+
 	nb, err := newNetworkBuilder(cmd)
 	if err != nil {
 		return err
@@ -77,15 +88,18 @@ func networkChainInitHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if !getYes(cmd) && exist {
-		prompt := promptui.Prompt{
-			Label: fmt.Sprintf("The chain has already been initialized under: %s. Would you like to overwrite the home directory",
-				chainHome,
-			),
-			IsConfirm: true,
-		}
+	if exist {
 		nb.Spinner.Stop()
-		if _, err := prompt.Run(); err != nil {
+		ok, err := cliquiz.Confirm(fmt.Sprintf("The chain has already been initialized under: %s. Would you like to overwrite the home directory",
+			chainHome,
+		))
+		if errors.Is(err, cliquiz.ErrInputRequired) {
+			return fmt.Errorf("%w, use --%s to overwrite the home directory", err, flagYes)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
 			fmt.Println("said no")
 			return nil
 		}
@@ -102,7 +116,8 @@ func networkChainInitHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch))
+	chainOptions := append(genesisVerificationOptions(cmd), binaryOptions...)
+	c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch), chainOptions...)
 	if err != nil {
 		return err
 	}
@@ -136,53 +151,91 @@ func networkChainInitHandler(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// askValidatorInfo prompts to the user questions to query validator information
-func askValidatorInfo(cmd *cobra.Command, stakeDenom string) (chain.Validator, error) {
+// flagSetValidatorGentx returns the flags of the description and the commission of the validator
+// that are embedded in its gentx.
+func flagSetValidatorGentx() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagValidatorWebsite, "", "Associate a website with the validator")
+	fs.String(flagValidatorDetails, "", "Details about the validator")
+	fs.String(flagValidatorSecurityContact, "", "Validator security contact email")
+	fs.String(flagValidatorMoniker, "", "Custom validator moniker")
+	fs.String(flagValidatorIdentity, "", "Validator identity signature (ex. UPort or Keybase)")
+	fs.String(flagValidatorSelfDelegation, "", "Validator minimum self delegation")
+	fs.String(flagValidatorGasPrice, "", "Validator gas price")
+	fs.String(flagValidatorCommissionRate, "", "Validator commission rate, e.g. 0.10")
+	fs.String(flagValidatorCommissionMaxRate, "", "Validator commission max rate, e.g. 0.20")
+	fs.String(flagValidatorCommissionMaxChangeRate, "", "Validator commission max daily change rate, e.g. 0.01")
+	return fs
+}
+
+// getValidatorGentx returns the validator of the gentx with the description and the commission
+// given with the flags, the values that are not given are empty.
+func getValidatorGentx(cmd *cobra.Command, stakeDenom string) (chain.Validator, error) {
 	var (
-		account, _         = cmd.Flags().GetString(flagValidatorAccount)
-		website, _         = cmd.Flags().GetString(flagValidatorWebsite)
-		details, _         = cmd.Flags().GetString(flagValidatorDetails)
-		securityContact, _ = cmd.Flags().GetString(flagValidatorSecurityContact)
-		moniker, _         = cmd.Flags().GetString(flagValidatorMoniker)
-		identity, _        = cmd.Flags().GetString(flagValidatorIdentity)
-		selfDelegation, _  = cmd.Flags().GetString(flagValidatorSelfDelegation)
-		gasPrice, _        = cmd.Flags().GetString(flagValidatorGasPrice)
+		website, _                 = cmd.Flags().GetString(flagValidatorWebsite)
+		details, _                 = cmd.Flags().GetString(flagValidatorDetails)
+		securityContact, _         = cmd.Flags().GetString(flagValidatorSecurityContact)
+		moniker, _                 = cmd.Flags().GetString(flagValidatorMoniker)
+		identity, _                = cmd.Flags().GetString(flagValidatorIdentity)
+		selfDelegation, _          = cmd.Flags().GetString(flagValidatorSelfDelegation)
+		gasPrice, _                = cmd.Flags().GetString(flagValidatorGasPrice)
+		commissionRate, _          = cmd.Flags().GetString(flagValidatorCommissionRate)
+		commissionMaxRate, _       = cmd.Flags().GetString(flagValidatorCommissionMaxRate)
+		commissionMaxChangeRate, _ = cmd.Flags().GetString(flagValidatorCommissionMaxChangeRate)
 	)
 	if gasPrice == "" {
 		gasPrice = "0" + stakeDenom
 	}
 	v := chain.Validator{
-		Name:              account,
-		Website:           website,
-		Details:           details,
-		Moniker:           moniker,
-		Identity:          identity,
-		SecurityContact:   securityContact,
-		MinSelfDelegation: selfDelegation,
-		GasPrices:         gasPrice,
+		Website:                 website,
+		Details:                 details,
+		Moniker:                 moniker,
+		Identity:                identity,
+		SecurityContact:         securityContact,
+		MinSelfDelegation:       selfDelegation,
+		GasPrices:               gasPrice,
+		CommissionRate:          commissionRate,
+		CommissionMaxRate:       commissionMaxRate,
+		CommissionMaxChangeRate: commissionMaxChangeRate,
+	}
+	return v, chainconfig.ValidateCommission(v.CommissionRate, v.CommissionMaxRate, v.CommissionMaxChangeRate)
+}
+
+// askValidatorInfo prompts to the user questions to query validator information, the commission
+// rates given with the flags are not asked.
+func askValidatorInfo(cmd *cobra.Command, stakeDenom string) (chain.Validator, error) {
+	v, err := getValidatorGentx(cmd, stakeDenom)
+	if err != nil {
+		return v, err
 	}
+	v.Name, _ = cmd.Flags().GetString(flagValidatorAccount)
 
-	questions := append([]cliquiz.Question{},
+	questions := []cliquiz.Question{
 		cliquiz.NewQuestion("Staking amount",
 			&v.StakingAmount,
 			cliquiz.DefaultAnswer("95000000stake"),
 			cliquiz.Required(),
 		),
-		cliquiz.NewQuestion("Commission rate",
-			&v.CommissionRate,
-			cliquiz.DefaultAnswer("0.10"),
-			cliquiz.Required(),
-		),
-		cliquiz.NewQuestion("Commission max rate",
-			&v.CommissionMaxRate,
-			cliquiz.DefaultAnswer("0.20"),
-			cliquiz.Required(),
-		),
-		cliquiz.NewQuestion("Commission max change rate",
-			&v.CommissionMaxChangeRate,
-			cliquiz.DefaultAnswer("0.01"),
+	}
+	for _, q := range []struct {
+		question, defaultAnswer string
+		answer                  *string
+	}{
+		{"Commission rate", "0.10", &v.CommissionRate},
+		{"Commission max rate", "0.20", &v.CommissionMaxRate},
+		{"Commission max change rate", "0.01", &v.CommissionMaxChangeRate},
+	} {
+		if *q.answer != "" {
+			continue
+		}
+		questions = append(questions, cliquiz.NewQuestion(q.question,
+			q.answer,
+			cliquiz.DefaultAnswer(q.defaultAnswer),
 			cliquiz.Required(),
-		),
-	)
-	return v, cliquiz.Ask(questions...)
+		))
+	}
+	if err := cliquiz.Ask(questions...); err != nil {
+		return v, err
+	}
+	return v, chainconfig.ValidateCommission(v.CommissionRate, v.CommissionMaxRate, v.CommissionMaxChangeRate)
 }
@@ -0,0 +1,147 @@
+package starportcmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/services/network"
+	"github.com/tendermint/starport/starport/services/network/networkchain"
+)
+
+const (
+	flagUser       = "user"
+	flagCosmovisor = "cosmovisor"
+
+	cosmovisorBinary = "cosmovisor"
+)
+
+// NewNetworkChainInstallService returns a new command to install the service of the node of a launched chain.
+func NewNetworkChainInstallService() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "install-service [launch-id]",
+		Short: "Install the service that supervises the node of a prepared chain",
+		Long: `Install the service that supervises the node of a chain prepared by "starport network chain
+prepare", the node is started at boot and restarted when it fails. The service runs the binary
+the chain is prepared with in the home of the chain, it's written in one of these formats:
+  systemd  unit in /etc/systemd/system, the default on Linux
+  launchd  agent in ~/Library/LaunchAgents, the default on macOS
+
+The systemd units run as the current user unless --user is given. Writing to /etc/systemd/system
+requires privileges, use --out to write the unit to another path and move it with sudo.
+
+With --cosmovisor, the node is run by cosmovisor to be upgraded automatically. The binary of the
+chain is copied to the cosmovisor dir of the home, cosmovisor is looked up in $PATH.
+
+  starport network chain install-service 3 --cosmovisor --out mars.service`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              networkChainInstallServiceHandler,
+	}
+	c.Flags().String(flagFormat, string(networkchain.DefaultServiceFormat()), "Format of the service: systemd or launchd")
+	c.Flags().String(flagOut, "", "Path of the file to write the service to, - to print it")
+	c.Flags().String(flagUser, "", "User that runs the node")
+	c.Flags().Bool(flagCosmovisor, false, "Run the node with cosmovisor")
+	c.Flags().AddFlagSet(flagSetHome())
+	return c
+}
+
+func networkChainInstallServiceHandler(cmd *cobra.Command, args []string) error {
+	var (
+		f, _              = cmd.Flags().GetString(flagFormat)
+		out, _            = cmd.Flags().GetString(flagOut)
+		serviceUser, _    = cmd.Flags().GetString(flagUser)
+		withCosmovisor, _ = cmd.Flags().GetBool(flagCosmovisor)
+	)
+
+	launchID, err := network.ParseLaunchID(args[0])
+	if err != nil {
+		return err
+	}
+	format, err := networkchain.ParseServiceFormat(f)
+	if err != nil {
+		return err
+	}
+
+	home := getHome(cmd)
+	if home == "" {
+		home = networkchain.ChainHome(launchID)
+	}
+	if home, err = filepath.Abs(home); err != nil {
+		return err
+	}
+	service, err := networkchain.NewNodeService(home)
+	if err != nil {
+		return err
+	}
+
+	// launchd agents run as the user that loads them.
+	if serviceUser == "" && format == networkchain.ServiceFormatSystemd {
+		u, err := user.Current()
+		if err != nil {
+			return err
+		}
+		serviceUser = u.Username
+	}
+	service.User = serviceUser
+
+	if withCosmovisor {
+		path, err := exec.LookPath(cosmovisorBinary)
+		if err != nil {
+			return fmt.Errorf("%s is not found in $PATH, install it with: go install github.com/cosmos/cosmos-sdk/cosmovisor/cmd/cosmovisor@latest", cosmovisorBinary)
+		}
+		if service.Cosmovisor, err = filepath.Abs(path); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := service.Write(&buf, format); err != nil {
+		return err
+	}
+	if out == "-" {
+		_, err := buf.WriteTo(os.Stdout)
+		return err
+	}
+
+	if withCosmovisor {
+		if err := service.SetupCosmovisor(); err != nil {
+			return err
+		}
+		fmt.Printf("%s Binary copied to %s\n", clispinner.Bullet, service.CosmovisorBinaryPath())
+	}
+
+	userPath := out != ""
+	if !userPath {
+		if out, err = service.DefaultPath(format); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("%w, use --%s to write the service to another path", err, flagOut)
+		}
+		return err
+	}
+	fmt.Printf("%s Service of %s written to %s\n", clispinner.OK, service.ChainID, out)
+
+	name := service.Name(format)
+	switch {
+	case format == networkchain.ServiceFormatLaunchd:
+		fmt.Printf("%s Start the node with: launchctl load -w %s\n", clispinner.Bullet, out)
+	case userPath:
+		fmt.Printf("%s Move the unit to /etc/systemd/system/%s.service and start the node with: sudo systemctl daemon-reload && sudo systemctl enable --now %s\n", clispinner.Bullet, name, name)
+	default:
+		fmt.Printf("%s Start the node with: sudo systemctl daemon-reload && sudo systemctl enable --now %s\n", clispinner.Bullet, name)
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 	"github.com/rdegges/go-ipify"
 	"github.com/spf13/cobra"
@@ -18,7 +17,9 @@ import (
 )
 
 const (
-	flagGentx = "gentx"
+	flagGentx       = "gentx"
+	flagNodeHome    = "node-home"
+	flagPeerAddress = "peer-address"
 )
 
 // NewNetworkChainJoin creates a new chain join command to join
@@ -27,16 +28,47 @@ func NewNetworkChainJoin() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "join [launch-id] [amount]",
 		Short: "Request to join a network as a validator",
-		Args:  cobra.ExactArgs(2),
-		RunE:  networkChainJoinHandler,
+		Long: `Request to join a network as a validator.
+
+The gentx of the validator is generated by "starport network chain init" by default. With
+--node-home, the keys and the moniker of an existing node are used to generate the gentx
+instead, and the public address of the node is detected from its config or from the public
+IP of the machine. The description and the commission of the validator embedded in this gentx
+are set with the --validator flags, the defaults of the chain's binary are used otherwise.
+
+The account of the validator is requested as a delayed vesting account with --vesting-coins and
+--vesting-end-time.
+
+With --denom-unit, the amount and the vesting coins can be given in the units of the denoms with
+decimals, e.g. --denom-unit stake=ustake:6 with 12.5stake for 12500000ustake.
+
+With --node-home, the chain is built from its source unless a prebuilt binary is downloaded with
+--binary-url and verified with the sha256 checksum given with --binary-checksum. The genesis of
+the chain is verified against the published genesis hash unless --skip-genesis-verification is set.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              networkChainJoinHandler,
 	}
 	c.Flags().String(flagGentx, "", "Path to a gentx json file")
+	c.Flags().String(flagNodeHome, "", "Home of an existing node to generate the gentx from")
+	c.Flags().String(flagPeerAddress, "", "Public address of the node that its peers connect to")
+	c.Flags().AddFlagSet(flagSetBinaryDownload())
+	c.Flags().AddFlagSet(flagSetSkipGenesisVerification())
+	c.Flags().AddFlagSet(flagSetValidatorGentx())
+	c.Flags().String(flagVestingCoins, "", "Coins of the amount that are locked until --vesting-end-time, e.g. 800stake")
+	c.Flags().String(flagVestingEndTime, "", "Time at which the vesting coins are unlocked in the RFC3339 format")
+	c.Flags().AddFlagSet(flagSetDenomUnits())
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	return c
 }
 
 func networkChainJoinHandler(cmd *cobra.Command, args []string) error {
+	binaryOptions, err := binaryDownloadOptions(cmd)
+	if err != nil {
+		return err
+	}
+
 	nb, err := newNetworkBuilder(cmd)
 	if err != nil {
 		return err
@@ -50,18 +82,48 @@ func networkChainJoinHandler(cmd *cobra.Command, args []string) error {
 	}
 
 	// parse the amount.
-	amount, err := sdk.ParseCoinNormalized(args[1])
+	denoms, err := getDenomUnits(cmd)
+	if err != nil {
+		return err
+	}
+	amount, err := denoms.ParseCoin(args[1])
 	if err != nil {
 		return errors.Wrap(err, "error parsing amount")
 	}
 
-	gentxPath, _ := cmd.Flags().GetString(flagGentx)
+	var (
+		gentxPath, _    = cmd.Flags().GetString(flagGentx)
+		nodeHomePath, _ = cmd.Flags().GetString(flagNodeHome)
+		publicAddr, _   = cmd.Flags().GetString(flagPeerAddress)
+	)
+
+	var joinOptions []network.JoinOption
+
+	if gentxPath != "" {
+		joinOptions = append(joinOptions, network.WithCustomGentxPath(gentxPath))
+	}
+
+	// the public address of an existing node is detected when it's not on Gitpod.
+	if publicAddr == "" && (nodeHomePath == "" || gitpod.IsOnGitpod()) {
+		publicAddr, err = askPublicAddress(cmd.Context(), nb.Spinner)
+		if errors.Is(err, cliquiz.ErrInputRequired) {
+			return fmt.Errorf("%w, use --%s to give the address of the node", err, flagPeerAddress)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if publicAddr != "" {
+		joinOptions = append(joinOptions, network.WithPublicAddress(publicAddr))
+	}
 
-	// get the peer public address for the validator.
-	publicAddr, err := askPublicAddress(cmd.Context(), nb.Spinner)
+	vesting, err := getVestingSchedule(cmd, denoms)
 	if err != nil {
 		return err
 	}
+	if vesting != nil {
+		joinOptions = append(joinOptions, network.WithAccountVesting(*vesting))
+	}
 
 	n, err := nb.Network()
 	if err != nil {
@@ -73,13 +135,34 @@ func networkChainJoinHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch))
+	chainOptions := append(genesisVerificationOptions(cmd), binaryOptions...)
+	c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch), chainOptions...)
 	if err != nil {
 		return err
 	}
 
+	// generate the gentx with the keys of the existing node.
+	if nodeHomePath != "" {
+		nodeHome, err := networkchain.ReadNodeHome(nodeHomePath)
+		if err != nil {
+			return err
+		}
+
+		v, err := getValidatorGentx(cmd, amount.Denom)
+		if err != nil {
+			return err
+		}
+		v.Name = getFrom(cmd)
+		v.StakingAmount = amount.String()
+		gentxPath, err := c.InitFromNodeHome(cmd.Context(), nodeHome, v, getFrom(cmd))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s Gentx generated: %s\n", clispinner.Bullet, gentxPath)
+	}
+
 	// create the message to add the validator.
-	return n.Join(cmd.Context(), c, launchID, amount, publicAddr, gentxPath)
+	return n.Join(cmd.Context(), c, launchID, amount, joinOptions...)
 }
 
 // askPublicAddress prepare questions to interactively ask for a publicAddress
@@ -14,10 +14,11 @@ const (
 // the network as a coordinator.
 func NewNetworkChainLaunch() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "launch [launch-id]",
-		Short: "Launch a network as a coordinator",
-		Args:  cobra.ExactArgs(1),
-		RunE:  networkChainLaunchHandler,
+		Use:               "launch [launch-id]",
+		Short:             "Launch a network as a coordinator",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              recordOperation(networkChainLaunchHandler),
 	}
 
 	c.Flags().Duration(flagRemainingTime, 0, "Duration of time in seconds before the chain is effectively launched")
@@ -3,7 +3,6 @@ package starportcmd
 import (
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/spf13/cobra"
 
@@ -22,6 +21,31 @@ type LaunchSummary struct {
 	CampaignID string
 }
 
+// chainLaunchOutput is a chain launch as printed in the JSON and YAML formats.
+type chainLaunchOutput struct {
+	LaunchID    uint64 `json:"launch_id"`
+	ChainID     string `json:"chain_id"`
+	SourceURL   string `json:"source_url"`
+	SourceHash  string `json:"source_hash"`
+	GenesisURL  string `json:"genesis_url"`
+	GenesisHash string `json:"genesis_hash"`
+	LaunchTime  int64  `json:"launch_time"`
+	CampaignID  uint64 `json:"campaign_id"`
+}
+
+func newChainLaunchOutput(c networktypes.ChainLaunch) chainLaunchOutput {
+	return chainLaunchOutput{
+		LaunchID:    c.ID,
+		ChainID:     c.ChainID,
+		SourceURL:   c.SourceURL,
+		SourceHash:  c.SourceHash,
+		GenesisURL:  c.GenesisURL,
+		GenesisHash: c.GenesisHash,
+		LaunchTime:  c.LaunchTime,
+		CampaignID:  c.CampaignID,
+	}
+}
+
 // NewNetworkChainList returns a new command to list all published chains on Starport Network
 func NewNetworkChainList() *cobra.Command {
 	c := &cobra.Command{
@@ -33,6 +57,7 @@ func NewNetworkChainList() *cobra.Command {
 	c.Flags().String(flagFrom, cosmosaccount.DefaultAccount, "Account name to use for sending transactions to SPN")
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
 
 	return c
 }
@@ -55,7 +80,14 @@ func networkChainListHandler(cmd *cobra.Command, args []string) error {
 	}
 
 	nb.Cleanup()
-	return renderLaunchSummaries(chainLaunches, os.Stdout)
+
+	outputs := make([]chainLaunchOutput, 0, len(chainLaunches))
+	for _, c := range chainLaunches {
+		outputs = append(outputs, newChainLaunchOutput(c))
+	}
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		return renderLaunchSummaries(chainLaunches, out)
+	})
 }
 
 // renderLaunchSummaries writes into the provided out, the list of summarized launches
@@ -1,21 +1,65 @@
 package starportcmd
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
+	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/services/network"
 	"github.com/tendermint/starport/starport/services/network/networkchain"
 )
 
+const (
+	flagSeed                 = "seed"
+	flagAddrBook             = "addrbook"
+	flagStateSyncRPC         = "state-sync-rpc"
+	flagStateSyncTrustPeriod = "state-sync-trust-period"
+	flagSnapshotInterval     = "snapshot-interval"
+	flagSnapshotKeepRecent   = "snapshot-keep-recent"
+
+	flagSkipGenesisVerification = "skip-genesis-verification"
+	flagBinaryURL               = "binary-url"
+	flagBinaryChecksum          = "binary-checksum"
+)
+
 // NewNetworkChainPrepare returns a new command to prepare the chain for launch
 func NewNetworkChainPrepare() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "prepare [launch-id]",
 		Short: "Prepare the chain for launch",
-		Args:  cobra.ExactArgs(1),
-		RunE:  networkChainPrepareHandler,
+		Long: `Prepare the chain for launch by building its finalized genesis from the approved requests.
+
+The genesis information is queried at the latest SPN block height, and the difference between
+the base genesis and the finalized genesis is shown. A report with the SPN block height and the
+hashes of the genesis files is written to the config dir of the chain to reproduce the genesis.
+
+The peers of the genesis validators are added to config.toml as persistent peers, the validators
+given with --seed are added as seeds instead. With --addrbook, an addrbook.json is generated from
+the peers of the validators too.
+
+A node joining the chain after its launch can state sync from the snapshots of the other nodes
+with --state-sync-rpc, the block to trust is fetched from the first RPC server. The validators
+providing the snapshots enable them with --snapshot-interval.
+
+The genesis fetched from the genesis URL of the chain must match the genesis hash published for
+the chain, the result of the verification is written to the report. Use
+--skip-genesis-verification to prepare the chain with a genesis that doesn't match.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              recordOperation(networkChainPrepareHandler),
 	}
 
+	c.Flags().StringArray(flagSeed, nil, "Address of a genesis validator to use as a seed node")
+	c.Flags().Bool(flagAddrBook, false, "Generate the address book from the peers of the genesis validators")
+	c.Flags().StringArray(flagStateSyncRPC, nil, "RPC server to state sync the node from")
+	c.Flags().String(flagStateSyncTrustPeriod, "", "Period that the trusted block of state sync is trusted for (default 168h0m0s)")
+	c.Flags().Uint64(flagSnapshotInterval, 0, "Number of blocks between the snapshots of the state provided to the other nodes")
+	c.Flags().Uint32(flagSnapshotKeepRecent, 2, "Number of recent snapshots to keep and provide")
+	c.Flags().AddFlagSet(flagSetSkipGenesisVerification())
+	c.Flags().AddFlagSet(flagSetBinaryDownload())
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetHome())
@@ -24,6 +68,37 @@ func NewNetworkChainPrepare() *cobra.Command {
 }
 
 func networkChainPrepareHandler(cmd *cobra.Command, args []string) error {
+	var (
+		seeds, _                = cmd.Flags().GetStringArray(flagSeed)
+		addrBook, _             = cmd.Flags().GetBool(flagAddrBook)
+		stateSyncRPCs, _        = cmd.Flags().GetStringArray(flagStateSyncRPC)
+		stateSyncTrustPeriod, _ = cmd.Flags().GetString(flagStateSyncTrustPeriod)
+		snapshotInterval, _     = cmd.Flags().GetUint64(flagSnapshotInterval)
+		snapshotKeepRecent, _   = cmd.Flags().GetUint32(flagSnapshotKeepRecent)
+	)
+
+	prepareOptions := []networkchain.PrepareOption{networkchain.WithSeeds(seeds...)}
+	if addrBook {
+		prepareOptions = append(prepareOptions, networkchain.WithAddrBook())
+	}
+	if len(stateSyncRPCs) > 0 {
+		prepareOptions = append(prepareOptions, networkchain.WithStateSync(networkchain.StateSync{
+			RPCServers:  stateSyncRPCs,
+			TrustPeriod: stateSyncTrustPeriod,
+		}))
+	}
+	if snapshotInterval > 0 {
+		prepareOptions = append(prepareOptions, networkchain.WithSnapshots(networkchain.Snapshots{
+			Interval:   snapshotInterval,
+			KeepRecent: snapshotKeepRecent,
+		}))
+	}
+
+	binaryOptions, err := binaryDownloadOptions(cmd)
+	if err != nil {
+		return err
+	}
+
 	nb, err := newNetworkBuilder(cmd)
 	if err != nil {
 		return err
@@ -47,7 +122,8 @@ func networkChainPrepareHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch))
+	chainOptions := append(genesisVerificationOptions(cmd), binaryOptions...)
+	c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch), chainOptions...)
 	if err != nil {
 		return err
 	}
@@ -58,5 +134,71 @@ func networkChainPrepareHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return c.Prepare(cmd.Context(), genesisInformation)
+	if err := c.Prepare(cmd.Context(), genesisInformation, prepareOptions...); err != nil {
+		return err
+	}
+
+	reportPath, err := c.PrepareReportPath()
+	if err != nil {
+		return err
+	}
+	report, err := networkchain.LoadPrepareReport(reportPath)
+	if err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	if _, err := report.Diff.WriteTo(os.Stdout); err != nil {
+		return err
+	}
+	fmt.Printf("\n%s Genesis prepared at SPN height %d, sha256 %s\n", clispinner.OK, report.SPNHeight, report.GenesisHash)
+	if v := report.GenesisVerification; v != nil {
+		fmt.Printf("%s Genesis from %s: %s\n", clispinner.Bullet, v.URL, v.Status)
+	}
+	fmt.Printf("%s Report written to %s\n", clispinner.Bullet, reportPath)
+
+	return nil
+}
+
+func flagSetSkipGenesisVerification() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Bool(flagSkipGenesisVerification, false, "Use the genesis fetched from the genesis URL even if it doesn't match the published genesis hash")
+	return fs
+}
+
+func genesisVerificationOptions(cmd *cobra.Command) []networkchain.Option {
+	if skip, _ := cmd.Flags().GetBool(flagSkipGenesisVerification); skip {
+		return []networkchain.Option{networkchain.WithoutGenesisVerification()}
+	}
+	return nil
+}
+
+func flagSetBinaryDownload() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagBinaryURL, "", "URL of a prebuilt binary of the chain to download instead of building the chain")
+	fs.String(flagBinaryChecksum, "", "sha256 checksum of the file served at --binary-url")
+	return fs
+}
+
+// binaryDownloadOptions returns the options to download the prebuilt binary of the chain, the
+// checksum is required to verify the binary.
+func binaryDownloadOptions(cmd *cobra.Command) ([]networkchain.Option, error) {
+	var (
+		url, _      = cmd.Flags().GetString(flagBinaryURL)
+		checksum, _ = cmd.Flags().GetString(flagBinaryChecksum)
+	)
+	switch {
+	case url == "" && checksum == "":
+		return nil, nil
+	case url == "":
+		return nil, fmt.Errorf("--%s is only used with --%s", flagBinaryChecksum, flagBinaryURL)
+	case checksum == "":
+		return nil, fmt.Errorf("--%s is required to verify the binary downloaded from --%s", flagBinaryChecksum, flagBinaryURL)
+	}
+	checksum, err := networkchain.ParseBinaryChecksum(checksum)
+	if err != nil {
+		return nil, err
+	}
+	return []networkchain.Option{networkchain.WithBinaryDownload(url, checksum)}, nil
 }
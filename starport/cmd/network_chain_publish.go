@@ -1,11 +1,15 @@
 package starportcmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/starport/starport/pkg/cliquiz"
 	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/services/network"
 	"github.com/tendermint/starport/starport/services/network/networkchain"
@@ -15,10 +19,19 @@ const (
 	flagTag      = "tag"
 	flagBranch   = "branch"
 	flagHash     = "hash"
+	flagRef      = "ref"
 	flagGenesis  = "genesis"
 	flagCampaign = "campaign"
 	flagNoCheck  = "no-check"
 	flagChainID  = "chain-id"
+
+	flagLaunchDir      = "launch-dir"
+	flagReadmeTemplate = "readme-template"
+	flagHardwareCPU    = "hardware-cpu"
+	flagHardwareMemory = "hardware-memory"
+	flagHardwareDisk   = "hardware-disk"
+	flagGentxDeadline  = "gentx-deadline"
+	flagLaunchTime     = "launch-time"
 )
 
 // NewNetworkChainPublish returns a new command to publish a new chain to start a new network.
@@ -26,21 +39,50 @@ func NewNetworkChainPublish() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "publish [source-url]",
 		Short: "Publish a new chain to start a new network",
-		Args:  cobra.ExactArgs(1),
-		RunE:  networkChainPublishHandler,
+		Long: `Publish a new chain to start a new network.
+
+The chain is published from a ref of its repository with --ref, the ref can be a tag, a branch or
+a commit and it's resolved to the hash of its commit. When the chain isn't at the root of its
+repository, its path in the repository is given with --path:
+
+  starport network chain publish https://github.com/foo/bar --ref v0.3.0 --path chains/foo
+
+When a publish fails after some of its transactions succeeded, e.g. after the campaign of the
+chain is created, publishing the same chain again resumes the publish from its checkpoint instead of
+sending the transactions again.
+
+The chains already published by the coordinator with the same chain ID or the same source are shown
+before publishing, and the chain is only published again once confirmed or with --force.
+
+Once the chain is published, the launch files are written to the directory of --launch-dir so the
+instructions for the validators are ready to share: a README.md with the launch ID, the join
+commands, the hardware requirements and the timeline of the launch, and a machine readable
+launch.json with the same information. The README is rendered from a Go text/template given with
+--readme-template, the template is executed with the content of launch.json.`,
+		Args: cobra.ExactArgs(1),
+		RunE: recordOperation(networkChainPublishHandler),
 	}
 
 	c.Flags().String(flagBranch, "", "Git branch to use for the repo")
 	c.Flags().String(flagTag, "", "Git tag to use for the repo")
 	c.Flags().String(flagHash, "", "Git hash to use for the repo")
+	c.Flags().String(flagRef, "", "Git ref to use for the repo, a tag, a branch or a commit")
+	c.Flags().String(flagPath, "", "Path of the chain in the repo")
 	c.Flags().String(flagGenesis, "", "URL to a custom Genesis")
 	c.Flags().String(flagChainID, "", "Chain ID to use for this network")
 	c.Flags().Uint64(flagCampaign, 0, "Campaign ID to use for this network")
 	c.Flags().Bool(flagNoCheck, false, "Skip verifying chain's integrity")
+	c.Flags().Bool(flagForce, false, "Publish the chain even if it's already published with the same chain ID or source")
+	c.Flags().String(flagLaunchDir, "launch", "Directory to write the launch files to")
+	c.Flags().String(flagReadmeTemplate, "", "Path to a Go text/template to render the README of the launch files with")
+	c.Flags().Int(flagHardwareCPU, network.DefaultHardware.CPU, "Minimum CPU cores of the nodes of the validators")
+	c.Flags().String(flagHardwareMemory, network.DefaultHardware.Memory, "Minimum memory of the nodes of the validators")
+	c.Flags().String(flagHardwareDisk, network.DefaultHardware.Disk, "Minimum disk of the nodes of the validators")
+	c.Flags().String(flagGentxDeadline, "", "Time until which the requests to join are accepted in the RFC3339 format")
+	c.Flags().String(flagLaunchTime, "", "Time the chain is planned to be launched at in the RFC3339 format")
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetHome())
-	c.Flags().AddFlagSet(flagSetYes())
 
 	return c
 }
@@ -51,12 +93,23 @@ func networkChainPublishHandler(cmd *cobra.Command, args []string) error {
 		tag, _        = cmd.Flags().GetString(flagTag)
 		branch, _     = cmd.Flags().GetString(flagBranch)
 		hash, _       = cmd.Flags().GetString(flagHash)
+		ref, _        = cmd.Flags().GetString(flagRef)
+		sourcePath, _ = cmd.Flags().GetString(flagPath)
 		genesisURL, _ = cmd.Flags().GetString(flagGenesis)
 		chainID, _    = cmd.Flags().GetString(flagChainID)
 		campaign, _   = cmd.Flags().GetUint64(flagCampaign)
 		noCheck, _    = cmd.Flags().GetBool(flagNoCheck)
+		force, _      = cmd.Flags().GetBool(flagForce)
+		launchDir, _  = cmd.Flags().GetString(flagLaunchDir)
 	)
 
+	// the flags of the launch files are parsed first, so a wrong flag doesn't fail the publish
+	// once the chain is published.
+	info, launchFilesOptions, err := getLaunchFiles(cmd)
+	if err != nil {
+		return err
+	}
+
 	nb, err := newNetworkBuilder(cmd)
 	if err != nil {
 		return err
@@ -67,6 +120,8 @@ func networkChainPublishHandler(cmd *cobra.Command, args []string) error {
 	var sourceOption networkchain.SourceOption
 
 	switch {
+	case ref != "":
+		sourceOption = networkchain.SourceRemoteRef(source, ref)
 	case tag != "":
 		sourceOption = networkchain.SourceRemoteTag(source, tag)
 	case branch != "":
@@ -79,6 +134,10 @@ func networkChainPublishHandler(cmd *cobra.Command, args []string) error {
 
 	var initOptions []networkchain.Option
 
+	if sourcePath != "" {
+		initOptions = append(initOptions, networkchain.WithSourcePath(sourcePath))
+	}
+
 	// use custom genesis from url if given.
 	if genesisURL != "" {
 		initOptions = append(initOptions, networkchain.WithGenesisFromURL(genesisURL))
@@ -125,7 +184,32 @@ func networkChainPublishHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if force {
+		publishOptions = append(publishOptions, network.WithForce())
+	}
+
 	launchID, campaignID, err := n.Publish(cmd.Context(), c, publishOptions...)
+	var duplicateErr *network.DuplicateChainError
+	if errors.As(err, &duplicateErr) {
+		nb.Spinner.Stop()
+		fmt.Printf("%s The chain %s is already published:\n", clispinner.Bullet, duplicateErr.ChainID)
+		for _, d := range duplicateErr.Duplicates {
+			fmt.Printf("  launch %d: %s %s@%s\n", d.ID, d.ChainID, d.SourceURL, d.SourceHash)
+		}
+
+		ok, cerr := cliquiz.Confirm("Publish the chain again")
+		if errors.Is(cerr, cliquiz.ErrInputRequired) {
+			return fmt.Errorf("%w, use --%s to publish the chain again", err, flagForce)
+		}
+		if cerr != nil || !ok {
+			return cerr
+		}
+
+		// no transactions are sent when the chain is detected as a duplicate.
+		nb.Spinner.Start()
+		publishOptions = append(publishOptions, network.WithForce())
+		launchID, campaignID, err = n.Publish(cmd.Context(), c, publishOptions...)
+	}
 	if err != nil {
 		return err
 	}
@@ -136,5 +220,73 @@ func networkChainPublishHandler(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s Launch ID: %d \n", clispinner.Bullet, launchID)
 	fmt.Printf("%s Campaign ID: %d \n", clispinner.Bullet, campaignID)
 
+	if chainID == "" {
+		if chainID, err = c.ID(); err != nil {
+			return err
+		}
+	}
+	info.LaunchID = launchID
+	info.CampaignID = campaignID
+	info.ChainID = chainID
+	info.SourceURL = c.SourceURL()
+	info.SourceHash = c.SourceHash()
+	info.GenesisURL = genesisURL
+	info.Join = network.JoinSteps(launchID)
+	info.Timeline.PublishedAt = time.Now().UTC()
+
+	if err := network.WriteLaunchFiles(launchDir, info, launchFilesOptions...); err != nil {
+		return fmt.Errorf("the chain is published but its launch files can't be written: %w", err)
+	}
+	fmt.Printf("%s Launch instructions: %s \n", clispinner.Bullet, filepath.Join(launchDir, network.LaunchReadmeFile))
+
 	return nil
 }
+
+// getLaunchFiles returns the launch information given with the flags and the options of the
+// launch files.
+func getLaunchFiles(cmd *cobra.Command) (network.LaunchInfo, []network.LaunchFilesOption, error) {
+	var (
+		readmeTemplate, _ = cmd.Flags().GetString(flagReadmeTemplate)
+		cpu, _            = cmd.Flags().GetInt(flagHardwareCPU)
+		memory, _         = cmd.Flags().GetString(flagHardwareMemory)
+		disk, _           = cmd.Flags().GetString(flagHardwareDisk)
+		info              = network.LaunchInfo{Hardware: network.Hardware{CPU: cpu, Memory: memory, Disk: disk}}
+		options           []network.LaunchFilesOption
+	)
+
+	var err error
+	if info.Timeline.GentxDeadline, err = getTimeFlag(cmd, flagGentxDeadline); err != nil {
+		return info, nil, err
+	}
+	if info.Timeline.LaunchTime, err = getTimeFlag(cmd, flagLaunchTime); err != nil {
+		return info, nil, err
+	}
+
+	if readmeTemplate != "" {
+		data, err := os.ReadFile(readmeTemplate)
+		if err != nil {
+			return info, nil, err
+		}
+		t, err := network.ParseLaunchReadmeTemplate(string(data))
+		if err != nil {
+			return info, nil, err
+		}
+		options = append(options, network.WithLaunchReadmeTemplate(t))
+	}
+
+	return info, options, nil
+}
+
+// getTimeFlag returns the time of a flag in the RFC3339 format, it's nil when the flag is not set.
+func getTimeFlag(cmd *cobra.Command, flag string) (*time.Time, error) {
+	value, _ := cmd.Flags().GetString(flag)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing --%s: %w", flag, err)
+	}
+	t = t.UTC()
+	return &t, nil
+}
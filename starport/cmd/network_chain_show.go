@@ -2,8 +2,10 @@ package starportcmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/cosmosutil"
 	"github.com/tendermint/starport/starport/pkg/entrywriter"
 	"github.com/tendermint/starport/starport/pkg/yaml"
@@ -19,12 +22,49 @@ import (
 	"github.com/tendermint/starport/starport/services/network/networktypes"
 )
 
+const (
+	flagOut = "out"
+
+	validatorsFormatCSV = "csv"
+)
+
 var (
 	chainGenesisValSummaryHeader = []string{"Genesis Validator", "Self Delegation", "Peer"}
 	chainGenesisAccSummaryHeader = []string{"Genesis Account", "Coins"}
 	chainVestingAccSummaryHeader = []string{"Vesting Account", "Total Balance", "Vesting", "EndTime"}
 )
 
+// chainInfoOutput is the info of a chain as printed in the JSON and YAML formats.
+type chainInfoOutput struct {
+	Chain   chainLaunchOutput `json:"chain"`
+	Genesis interface{}       `json:"genesis,omitempty"`
+}
+
+// chainAccountsOutput is the accounts of a chain as printed in the JSON and YAML formats.
+type chainAccountsOutput struct {
+	GenesisAccounts []genesisAccountOutput `json:"genesis_accounts"`
+	VestingAccounts []vestingAccountOutput `json:"vesting_accounts"`
+}
+
+type genesisAccountOutput struct {
+	Address string `json:"address"`
+	Coins   string `json:"coins"`
+}
+
+type vestingAccountOutput struct {
+	Address      string `json:"address"`
+	TotalBalance string `json:"total_balance"`
+	Vesting      string `json:"vesting"`
+	EndTime      int64  `json:"end_time"`
+}
+
+// genesisValidatorOutput is a validator of a chain as printed in the JSON and YAML formats.
+type genesisValidatorOutput struct {
+	Address        string `json:"address"`
+	SelfDelegation string `json:"self_delegation"`
+	Peer           string `json:"peer"`
+}
+
 // NewNetworkChainShow creates a new chain show
 // command to show a chain details on SPN.
 func NewNetworkChainShow() *cobra.Command {
@@ -38,9 +78,11 @@ func NewNetworkChainShow() *cobra.Command {
 		newNetworkChainShowAccounts(),
 		newNetworkChainShowValidators(),
 		newNetworkChainShowPeers(),
+		newNetworkChainShowInventory(),
 	)
 	c.PersistentFlags().AddFlagSet(flagNetworkFrom())
 	c.PersistentFlags().AddFlagSet(flagSetKeyringBackend())
+	c.PersistentFlags().AddFlagSet(flagSetOutputFormat())
 	return c
 }
 
@@ -59,9 +101,10 @@ func networkChainLaunch(cmd *cobra.Command, args []string) (NetworkBuilder, uint
 
 func newNetworkChainShowInfo() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "info [launch-id]",
-		Short: "Show info details of the chain",
-		Args:  cobra.ExactArgs(1),
+		Use:               "info [launch-id]",
+		Short:             "Show info details of the chain",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			nb, launchID, err := networkChainLaunch(cmd, args)
 			if err != nil {
@@ -85,20 +128,29 @@ func newNetworkChainShowInfo() *cobra.Command {
 					return err
 				}
 			}
-			chainInfo := struct {
-				Chain   networktypes.ChainLaunch `json:"Chain"`
-				Genesis []byte                   `json:"Genesis"`
-			}{
-				Chain:   chainLaunch,
-				Genesis: genesis,
-			}
-			info, err := yaml.Marshal(cmd.Context(), chainInfo, "$.Genesis")
-			if err != nil {
-				return err
+			output := chainInfoOutput{Chain: newChainLaunchOutput(chainLaunch)}
+			if genesis != nil {
+				if output.Genesis, err = decodeJSON(genesis); err != nil {
+					return err
+				}
 			}
+
 			nb.Spinner.Stop()
-			fmt.Print(info)
-			return nil
+			return printOutput(cmd, output, func(out io.Writer) error {
+				chainInfo := struct {
+					Chain   networktypes.ChainLaunch `json:"Chain"`
+					Genesis []byte                   `json:"Genesis"`
+				}{
+					Chain:   chainLaunch,
+					Genesis: genesis,
+				}
+				info, err := yaml.Marshal(cmd.Context(), chainInfo, "$.Genesis")
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprint(out, info)
+				return err
+			})
 		},
 	}
 	return c
@@ -108,7 +160,12 @@ func newNetworkChainShowGenesis() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "genesis [launch-id]",
 		Short: "Show the chain genesis file",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the genesis of the chain, it's built from the approved requests when the chain is not
+launched yet. With --out, the genesis is written to a file instead, to provision the nodes:
+
+  starport network chain show genesis 3 --out genesis.json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			nb, launchID, err := networkChainLaunch(cmd, args)
 			if err != nil {
@@ -120,66 +177,93 @@ func newNetworkChainShowGenesis() *cobra.Command {
 				return err
 			}
 
-			chainLaunch, err := n.ChainLaunch(cmd.Context(), launchID)
-			if err != nil {
-				return err
-			}
-
-			c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch))
+			genesisFile, err := networkChainGenesis(cmd.Context(), nb, n, launchID)
 			if err != nil {
 				return err
 			}
-			genesisPath, err := c.GenesisPath()
-			if err != nil {
-				return err
-			}
-
-			// check if the genesis already exists
-			if _, err = os.Stat(genesisPath); os.IsNotExist(err) {
-				// fetch the information to construct genesis
-				genesisInformation, err := n.GenesisInformation(cmd.Context(), launchID)
-				if err != nil {
-					return err
-				}
-
-				// create the chain into a temp dir
-				home := filepath.Join(os.TempDir(), "spn/temp", chainLaunch.ChainID)
-				c.SetHome(home)
-				defer os.RemoveAll(home)
 
-				err = c.Prepare(cmd.Context(), genesisInformation)
-				if err != nil {
+			if out, _ := cmd.Flags().GetString(flagOut); out != "" {
+				if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
 					return err
 				}
-
-				// get the new genesis path
-				genesisPath, err = c.GenesisPath()
-				if err != nil {
+				if err := os.WriteFile(out, genesisFile, 0644); err != nil {
 					return err
 				}
+				nb.Spinner.Stop()
+				fmt.Printf("%s Genesis written to %s\n", clispinner.OK, out)
+				return nil
 			}
-			genesisFile, err := os.ReadFile(genesisPath)
+
+			genesis, err := decodeJSON(genesisFile)
 			if err != nil {
 				return err
 			}
 
-			var prettyJSON bytes.Buffer
-			if err := json.Indent(&prettyJSON, genesisFile, "", "    "); err != nil {
-				return err
-			}
 			nb.Spinner.Stop()
-			fmt.Printf("Genesis: \n%s", prettyJSON.String())
-			return nil
+			return printOutput(cmd, genesis, func(out io.Writer) error {
+				var prettyJSON bytes.Buffer
+				if err := json.Indent(&prettyJSON, genesisFile, "", "    "); err != nil {
+					return err
+				}
+				_, err := fmt.Fprintf(out, "Genesis: \n%s", prettyJSON.String())
+				return err
+			})
 		},
 	}
+	c.Flags().String(flagOut, "", "Path of the file to write the genesis to")
 	return c
 }
 
+// networkChainGenesis returns the genesis of the chain of the launch, the genesis is built in a
+// temporary home from the approved requests when the chain is not prepared yet.
+func networkChainGenesis(ctx context.Context, nb NetworkBuilder, n network.Network, launchID uint64) ([]byte, error) {
+	chainLaunch, err := n.ChainLaunch(ctx, launchID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := nb.Chain(networkchain.SourceLaunch(chainLaunch))
+	if err != nil {
+		return nil, err
+	}
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return nil, err
+	}
+
+	// check if the genesis already exists
+	if _, err = os.Stat(genesisPath); os.IsNotExist(err) {
+		// fetch the information to construct genesis
+		genesisInformation, err := n.GenesisInformation(ctx, launchID)
+		if err != nil {
+			return nil, err
+		}
+
+		// create the chain into a temp dir
+		home := filepath.Join(os.TempDir(), "spn/temp", chainLaunch.ChainID)
+		c.SetHome(home)
+		defer os.RemoveAll(home)
+
+		err = c.Prepare(ctx, genesisInformation)
+		if err != nil {
+			return nil, err
+		}
+
+		// get the new genesis path
+		genesisPath, err = c.GenesisPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return os.ReadFile(genesisPath)
+}
+
 func newNetworkChainShowAccounts() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "accounts [launch-id]",
-		Short: "Show all vesting and genesis accounts of the chain",
-		Args:  cobra.ExactArgs(1),
+		Use:               "accounts [launch-id]",
+		Short:             "Show all vesting and genesis accounts of the chain",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			nb, launchID, err := networkChainLaunch(cmd, args)
 			if err != nil {
@@ -191,56 +275,74 @@ func newNetworkChainShowAccounts() *cobra.Command {
 				return err
 			}
 
-			accountSummary := bytes.NewBufferString("")
-
 			// get all chain genesis accounts
 			genesisAccs, err := n.GenesisAccounts(cmd.Context(), launchID)
 			if err != nil {
 				return err
 			}
-			genesisAccEntries := make([][]string, 0)
-			for _, acc := range genesisAccs {
-				genesisAccEntries = append(genesisAccEntries, []string{
-					acc.Address,
-					acc.Coins,
-				})
-			}
-			if len(genesisAccEntries) > 0 {
-				if err = entrywriter.MustWrite(
-					accountSummary,
-					chainGenesisAccSummaryHeader,
-					genesisAccEntries...,
-				); err != nil {
-					return err
-				}
-			}
 
 			// get all chain vesting accounts
 			vestingAccs, err := n.VestingAccounts(cmd.Context(), launchID)
 			if err != nil {
 				return err
 			}
-			genesisVestingAccEntries := make([][]string, 0)
-			for _, acc := range vestingAccs {
-				genesisVestingAccEntries = append(genesisVestingAccEntries, []string{
-					acc.Address,
-					acc.TotalBalance,
-					acc.Vesting,
-					strconv.FormatInt(acc.EndTime, 10),
+
+			output := chainAccountsOutput{
+				GenesisAccounts: make([]genesisAccountOutput, 0, len(genesisAccs)),
+				VestingAccounts: make([]vestingAccountOutput, 0, len(vestingAccs)),
+			}
+			for _, acc := range genesisAccs {
+				output.GenesisAccounts = append(output.GenesisAccounts, genesisAccountOutput{
+					Address: acc.Address,
+					Coins:   acc.Coins,
 				})
 			}
-			if len(genesisVestingAccEntries) > 0 {
-				if err = entrywriter.MustWrite(
-					accountSummary,
-					chainVestingAccSummaryHeader,
-					genesisVestingAccEntries...,
-				); err != nil {
-					return err
-				}
+			for _, acc := range vestingAccs {
+				output.VestingAccounts = append(output.VestingAccounts, vestingAccountOutput{
+					Address:      acc.Address,
+					TotalBalance: acc.TotalBalance,
+					Vesting:      acc.Vesting,
+					EndTime:      acc.EndTime,
+				})
 			}
+
 			nb.Spinner.Stop()
-			fmt.Print(accountSummary.String())
-			return nil
+			return printOutput(cmd, output, func(out io.Writer) error {
+				genesisAccEntries := make([][]string, 0)
+				for _, acc := range output.GenesisAccounts {
+					genesisAccEntries = append(genesisAccEntries, []string{
+						acc.Address,
+						acc.Coins,
+					})
+				}
+				if len(genesisAccEntries) > 0 {
+					if err := entrywriter.MustWrite(
+						out,
+						chainGenesisAccSummaryHeader,
+						genesisAccEntries...,
+					); err != nil {
+						return err
+					}
+				}
+
+				genesisVestingAccEntries := make([][]string, 0)
+				for _, acc := range output.VestingAccounts {
+					genesisVestingAccEntries = append(genesisVestingAccEntries, []string{
+						acc.Address,
+						acc.TotalBalance,
+						acc.Vesting,
+						strconv.FormatInt(acc.EndTime, 10),
+					})
+				}
+				if len(genesisVestingAccEntries) > 0 {
+					return entrywriter.MustWrite(
+						out,
+						chainVestingAccSummaryHeader,
+						genesisVestingAccEntries...,
+					)
+				}
+				return nil
+			})
 		},
 	}
 	return c
@@ -250,7 +352,10 @@ func newNetworkChainShowValidators() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "validators [launch-id]",
 		Short: "Show all validators of the chain",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show all validators of the chain. With --format csv, the validators are printed in the CSV
+format with their address, their self-delegation and their peer.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			nb, launchID, err := networkChainLaunch(cmd, args)
 			if err != nil {
@@ -262,37 +367,56 @@ func newNetworkChainShowValidators() *cobra.Command {
 				return err
 			}
 
-			validatorSummary := bytes.NewBufferString("")
+			format, _ := cmd.Flags().GetString(flagFormat)
+			if format != "" && format != validatorsFormatCSV {
+				return fmt.Errorf("invalid format %q, must be: %s", format, validatorsFormatCSV)
+			}
+
 			validators, err := n.GenesisValidators(cmd.Context(), launchID)
 			if err != nil {
 				return err
 			}
-			validatorEntries := make([][]string, 0)
+
+			if format == validatorsFormatCSV {
+				nb.Spinner.Stop()
+				return network.WriteValidatorsCSV(os.Stdout, validators)
+			}
+
+			outputs := make([]genesisValidatorOutput, 0, len(validators))
 			for _, acc := range validators {
 				peer, err := network.PeerAddress(acc.Peer)
 				if err != nil {
 					return err
 				}
-				validatorEntries = append(validatorEntries, []string{
-					acc.Address,
-					acc.SelfDelegation.String(),
-					peer,
+				outputs = append(outputs, genesisValidatorOutput{
+					Address:        acc.Address,
+					SelfDelegation: acc.SelfDelegation.String(),
+					Peer:           peer,
 				})
 			}
-			if len(validatorEntries) > 0 {
-				if err = entrywriter.MustWrite(
-					validatorSummary,
-					chainGenesisValSummaryHeader,
-					validatorEntries...,
-				); err != nil {
-					return err
-				}
-			}
+
 			nb.Spinner.Stop()
-			fmt.Print(validatorSummary.String())
-			return nil
+			return printOutput(cmd, outputs, func(out io.Writer) error {
+				validatorEntries := make([][]string, 0)
+				for _, v := range outputs {
+					validatorEntries = append(validatorEntries, []string{
+						v.Address,
+						v.SelfDelegation,
+						v.Peer,
+					})
+				}
+				if len(validatorEntries) > 0 {
+					return entrywriter.MustWrite(
+						out,
+						chainGenesisValSummaryHeader,
+						validatorEntries...,
+					)
+				}
+				return nil
+			})
 		},
 	}
+	c.Flags().String(flagFormat, "", "Format of the validators: csv (default: the output format)")
 	return c
 }
 
@@ -300,7 +424,12 @@ func newNetworkChainShowPeers() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "peers [launch-id]",
 		Short: "Show peers list of the chain",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the peers of the validators of the chain. With --format, the peers are printed as they
+are used to provision the nodes:
+  comma        the comma separated list of the peers
+  config-toml  the persistent_peers line of the config.toml of the nodes`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			nb, launchID, err := networkChainLaunch(cmd, args)
 			if err != nil {
@@ -311,27 +440,105 @@ func newNetworkChainShowPeers() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			genVals, err := n.GenesisValidators(cmd.Context(), launchID)
+
+			var format network.PeersFormat
+			if f, _ := cmd.Flags().GetString(flagFormat); f != "" {
+				if format, err = network.ParsePeersFormat(f); err != nil {
+					return err
+				}
+			}
+
+			peers, err := n.Peers(cmd.Context(), launchID)
 			if err != nil {
 				return err
 			}
+			nb.Spinner.Stop()
 
-			peers := make([]string, 0)
-			for _, acc := range genVals {
-				peer, err := network.PeerAddress(acc.Peer)
-				if err != nil {
+			if format != "" {
+				fmt.Println(network.FormatPeers(peers, format))
+				return nil
+			}
+
+			return printOutput(cmd, peers, func(out io.Writer) error {
+				if len(peers) > 0 {
+					_, err := fmt.Fprintf(out, "Peers: %s\n", strings.Join(peers, ","))
 					return err
 				}
-				peers = append(peers, peer)
+				_, err := fmt.Fprint(out, "empty peer list")
+				return err
+			})
+		},
+	}
+	c.Flags().String(flagFormat, "", fmt.Sprintf("Format of the peers: %s or %s (default: the output format)", network.PeersFormatComma, network.PeersFormatConfigTOML))
+	return c
+}
+
+func newNetworkChainShowInventory() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "inventory [launch-id]",
+		Short: "Show the inventory of the validators of a launched chain",
+		Long: `Show the inventory of the approved validators of a launched chain with their address, moniker,
+node ID and peer address, so the monitoring and the firewall configs of the nodes can be templated
+from SPN. The inventory is printed in one of these formats:
+  ansible    YAML inventory of Ansible with the validators group
+  terraform  tfvars file of Terraform with the validators variable
+
+  starport network chain show inventory 3 --format terraform --out validators.tfvars`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nb, launchID, err := networkChainLaunch(cmd, args)
+			if err != nil {
+				return err
+			}
+			defer nb.Cleanup()
+
+			f, _ := cmd.Flags().GetString(flagFormat)
+			format, err := network.ParseInventoryFormat(f)
+			if err != nil {
+				return err
+			}
+
+			n, err := nb.Network()
+			if err != nil {
+				return err
+			}
+			inventory, err := n.Inventory(cmd.Context(), launchID)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			if err := inventory.Write(&buf, format); err != nil {
+				return err
 			}
 			nb.Spinner.Stop()
-			if len(peers) > 0 {
-				fmt.Printf("Peers: %s\n", strings.Join(peers, ","))
-			} else {
-				fmt.Print("empty peer list")
+
+			out, _ := cmd.Flags().GetString(flagOut)
+			if out == "" {
+				_, err := buf.WriteTo(os.Stdout)
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+				return err
 			}
+			if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+				return err
+			}
+			fmt.Printf("%s Inventory of %d validators written to %s\n", clispinner.OK, len(inventory.Validators), out)
 			return nil
 		},
 	}
+	c.Flags().String(flagFormat, string(network.InventoryFormatAnsible), "Format of the inventory: ansible or terraform")
+	c.Flags().String(flagOut, "", "Path of the file to write the inventory to")
 	return c
 }
+
+// decodeJSON decodes the JSON data to print it in the JSON and YAML formats, the numbers are kept
+// as they are.
+func decodeJSON(data []byte) (v interface{}, err error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	err = d.Decode(&v)
+	return v, err
+}
@@ -13,9 +13,11 @@ func NewNetworkRequest() *cobra.Command {
 	c.AddCommand(
 		NewNetworkRequestShow(),
 		NewNetworkRequestList(),
+		NewNetworkRequestStatus(),
 		NewNetworkRequestApprove(),
 		NewNetworkRequestReject(),
 		NewNetworkRequestVerify(),
+		NewNetworkRequestAddAccount(),
 	)
 
 	return c
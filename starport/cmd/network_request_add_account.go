@@ -0,0 +1,134 @@
+package starportcmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+	"github.com/tendermint/starport/starport/services/network"
+)
+
+const (
+	flagVestingCoins   = "vesting-coins"
+	flagVestingEndTime = "vesting-end-time"
+)
+
+// NewNetworkRequestAddAccount creates a new request add-account command to request a genesis
+// account in the genesis of a chain.
+func NewNetworkRequestAddAccount() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "add-account [launch-id] [coins]",
+		Short: "Request a genesis account or a vesting account",
+		Long: `Request a genesis account with the coins in the genesis of a chain, the account given with
+--from is requested when no address is given.
+
+With --vesting-coins and --vesting-end-time, a delayed vesting account is requested instead:
+the vesting coins of the balance are locked until the end time, which must be after the launch
+of the chain.
+
+  starport network request add-account 3 1000stake --vesting-coins 800stake --vesting-end-time 2030-01-01T00:00:00Z
+
+With --denom-unit, the coins can be given in the units of the denoms with decimals, they are
+converted to their base denoms:
+
+  starport network request add-account 3 12.5stake --denom-unit stake=ustake:6`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              networkRequestAddAccountHandler,
+	}
+
+	c.Flags().String(flagAddress, "", "SPN address of the requested account")
+	c.Flags().String(flagVestingCoins, "", "Coins of the balance that are locked until the end time, e.g. 800stake")
+	c.Flags().String(flagVestingEndTime, "", "Time at which the vesting coins are unlocked in the RFC3339 format")
+	c.Flags().AddFlagSet(flagSetDenomUnits())
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func networkRequestAddAccountHandler(cmd *cobra.Command, args []string) error {
+	launchID, err := network.ParseLaunchID(args[0])
+	if err != nil {
+		return err
+	}
+
+	denoms, err := getDenomUnits(cmd)
+	if err != nil {
+		return err
+	}
+
+	coins, err := denoms.ParseCoins(args[1])
+	if err != nil {
+		return errors.Wrap(err, "error parsing coins")
+	}
+
+	address, _ := cmd.Flags().GetString(flagAddress)
+
+	var options []network.AccountRequestOption
+	vesting, err := getVestingSchedule(cmd, denoms)
+	if err != nil {
+		return err
+	}
+	if vesting != nil {
+		options = append(options, network.WithVesting(*vesting))
+	}
+
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	if address == "" {
+		address = n.AccountAddress()
+	}
+
+	requestID, err := n.RequestAccount(cmd.Context(), launchID, address, coins, options...)
+	if err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	fmt.Printf("%s Request ID: %d\n", clispinner.Bullet, requestID)
+
+	return nil
+}
+
+// getVestingSchedule returns the vesting schedule given with the flags, it's nil when the account
+// is not a vesting account. the vesting coins can be given in the units of the denoms.
+func getVestingSchedule(cmd *cobra.Command, denoms cosmosutil.Denoms) (*network.VestingSchedule, error) {
+	var (
+		vestingCoinsStr, _ = cmd.Flags().GetString(flagVestingCoins)
+		vestingEndTime, _  = cmd.Flags().GetString(flagVestingEndTime)
+	)
+	if vestingCoinsStr == "" && vestingEndTime == "" {
+		return nil, nil
+	}
+	if vestingCoinsStr == "" || vestingEndTime == "" {
+		return nil, fmt.Errorf("--%s and --%s are required to request a vesting account", flagVestingCoins, flagVestingEndTime)
+	}
+
+	vestingCoins, err := denoms.ParseCoins(vestingCoinsStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing vesting coins")
+	}
+	endTime, err := time.Parse(time.RFC3339, vestingEndTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing the end time of the vesting")
+	}
+	return &network.VestingSchedule{
+		Vesting: vestingCoins,
+		EndTime: endTime,
+	}, nil
+}
@@ -1,7 +1,9 @@
 package starportcmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -12,7 +14,8 @@ import (
 )
 
 const (
-	flagNoVerification = "no-verification"
+	flagNoVerification  = "no-verification"
+	flagAllowDuplicates = "allow-duplicates"
 )
 
 // NewNetworkRequestApprove creates a new request approve
@@ -22,10 +25,17 @@ func NewNetworkRequestApprove() *cobra.Command {
 		Use:     "approve [launch-id] [number<,...>]",
 		Aliases: []string{"accept"},
 		Short:   "Approve requests",
-		RunE:    networkRequestApproveHandler,
-		Args:    cobra.ExactArgs(2),
+		Long: `Approve requests.
+
+The requests to add validators sharing a consensus key, a node ID or an operator address with an
+approved validator or with another pending request are not approved since the duplicates break
+the genesis of the launch, unless --allow-duplicates is used.`,
+		RunE:              networkRequestApproveHandler,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeLaunchIDs,
 	}
 	c.Flags().Bool(flagNoVerification, false, "approve the requests without verifying them")
+	c.Flags().Bool(flagAllowDuplicates, false, "approve the requests of validators sharing a consensus key, a node ID or an operator address")
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetHome())
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
@@ -63,6 +73,13 @@ func networkRequestApproveHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	allowDuplicates, _ := cmd.Flags().GetBool(flagAllowDuplicates)
+	if !allowDuplicates {
+		if err := checkValidatorDuplicates(cmd.Context(), n, launchID, ids); err != nil {
+			return err
+		}
+	}
+
 	// if requests must be verified, we simulate the chain in a temporary directory with the requests
 	if !noVerification {
 		if err := verifyRequest(cmd.Context(), nb, launchID, ids...); err != nil {
@@ -76,7 +93,7 @@ func networkRequestApproveHandler(cmd *cobra.Command, args []string) error {
 	for _, id := range ids {
 		reviewals = append(reviewals, network.ApproveRequest(id))
 	}
-	if err := n.SubmitRequest(launchID, reviewals...); err != nil {
+	if err := n.SubmitRequest(cmd.Context(), launchID, reviewals...); err != nil {
 		return err
 	}
 
@@ -84,3 +101,30 @@ func networkRequestApproveHandler(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s Request(s) %s approved\n", clispinner.OK, numbers.List(ids, "#"))
 	return nil
 }
+
+// checkValidatorDuplicates fails when the requests share a consensus key, a node ID or an operator
+// address with the approved validators or with other pending requests.
+func checkValidatorDuplicates(ctx context.Context, n network.Network, launchID uint64, ids []uint64) error {
+	duplicates, err := n.ValidatorDuplicates(ctx, launchID)
+	if err != nil {
+		return err
+	}
+
+	var found []string
+	for _, d := range duplicates {
+		for _, id := range ids {
+			if d.HasRequest(id) {
+				found = append(found, "  "+d.String())
+				break
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"the requests share validator fields that break the genesis of the launch:\n%s\nuse --%s to approve them anyway",
+		strings.Join(found, "\n"),
+		flagAllowDuplicates,
+	)
+}
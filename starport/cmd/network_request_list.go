@@ -3,29 +3,88 @@ package starportcmd
 import (
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/spf13/cobra"
 	launchtypes "github.com/tendermint/spn/x/launch/types"
 
 	"github.com/tendermint/starport/starport/pkg/entrywriter"
 	"github.com/tendermint/starport/starport/services/network"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
 )
 
 var requestSummaryHeader = []string{"ID", "Type", "Content"}
 
+// requestOutput is a request as printed in the JSON and YAML formats, the fields that don't
+// apply to the type of the request are omitted.
+type requestOutput struct {
+	ID             uint64                   `json:"id"`
+	LaunchID       uint64                   `json:"launch_id"`
+	Creator        string                   `json:"creator"`
+	CreatedAt      int64                    `json:"created_at"`
+	Type           networktypes.RequestType `json:"type"`
+	Address        string                   `json:"address"`
+	Coins          string                   `json:"coins,omitempty"`
+	Vesting        string                   `json:"vesting,omitempty"`
+	SelfDelegation string                   `json:"self_delegation,omitempty"`
+	Peer           string                   `json:"peer,omitempty"`
+
+	// Duplicates are the validator fields the request shares with other validators, they're
+	// only printed by the list command.
+	Duplicates []string `json:"duplicates,omitempty"`
+
+	// Gentx is only printed by the show command.
+	Gentx interface{} `json:"gentx,omitempty"`
+}
+
+func newRequestOutput(request launchtypes.Request) (requestOutput, error) {
+	record := networktypes.NewRequestRecord(request)
+	output := requestOutput{
+		ID:        request.RequestID,
+		LaunchID:  request.LaunchID,
+		Creator:   request.Creator,
+		CreatedAt: request.CreatedAt,
+		Type:      record.Type,
+		Address:   record.Address,
+	}
+
+	switch req := request.Content.Content.(type) {
+	case *launchtypes.RequestContent_GenesisAccount:
+		output.Coins = req.GenesisAccount.Coins.String()
+	case *launchtypes.RequestContent_GenesisValidator:
+		peer, err := network.PeerAddress(req.GenesisValidator.Peer)
+		if err != nil {
+			return output, err
+		}
+		output.SelfDelegation = req.GenesisValidator.SelfDelegation.String()
+		output.Peer = peer
+	case *launchtypes.RequestContent_VestingAccount:
+		if dv := req.VestingAccount.VestingOptions.GetDelayedVesting(); dv != nil {
+			output.Coins = dv.TotalBalance.String()
+			output.Vesting = dv.Vesting.String()
+		}
+	}
+	return output, nil
+}
+
 // NewNetworkRequestList creates a new request list command to list
 // requests for a chain
 func NewNetworkRequestList() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "list [launch-id]",
 		Short: "List all pending requests",
-		RunE:  networkRequestListHandler,
-		Args:  cobra.ExactArgs(1),
+		Long: `List all pending requests.
+
+The requests to add validators sharing a consensus key, a node ID or an operator address with an
+approved validator or with another pending request are reported, they can't be approved without
+--allow-duplicates.`,
+		RunE:              networkRequestListHandler,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
 	}
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
 	return c
 }
 
@@ -51,9 +110,40 @@ func networkRequestListHandler(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	validators, err := n.GenesisValidators(cmd.Context(), launchID)
+	if err != nil {
+		return err
+	}
+	duplicates := networktypes.FindValidatorDuplicates(validators, requests)
 
 	nb.Cleanup()
-	return renderRequestSummaries(requests, os.Stdout)
+
+	outputs := make([]requestOutput, 0, len(requests))
+	for _, request := range requests {
+		output, err := newRequestOutput(request)
+		if err != nil {
+			return err
+		}
+		for _, d := range duplicates {
+			if d.HasRequest(request.RequestID) {
+				output.Duplicates = append(output.Duplicates, d.String())
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		if err := renderRequestSummaries(requests, out); err != nil {
+			return err
+		}
+		if len(duplicates) == 0 {
+			return nil
+		}
+		fmt.Fprintf(out, "\n⚠️  Duplicate validator fields, the requests sharing them are not approved without --%s:\n", flagAllowDuplicates)
+		for _, d := range duplicates {
+			fmt.Fprintf(out, "  %s\n", d)
+		}
+		return nil
+	})
 }
 
 // renderRequestSummaries writes into the provided out, the list of summarized requests
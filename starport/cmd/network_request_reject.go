@@ -14,11 +14,12 @@ import (
 // command to reject requests for a chain.
 func NewNetworkRequestReject() *cobra.Command {
 	c := &cobra.Command{
-		Use:     "reject [launch-id] [number<,...>]",
-		Aliases: []string{"accept"},
-		Short:   "Reject requests",
-		RunE:    networkRequestRejectHandler,
-		Args:    cobra.ExactArgs(2),
+		Use:               "reject [launch-id] [number<,...>]",
+		Aliases:           []string{"accept"},
+		Short:             "Reject requests",
+		RunE:              networkRequestRejectHandler,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeLaunchIDs,
 	}
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetHome())
@@ -56,7 +57,7 @@ func networkRequestRejectHandler(cmd *cobra.Command, args []string) error {
 	for _, id := range ids {
 		reviewals = append(reviewals, network.RejectRequest(id))
 	}
-	if err := n.SubmitRequest(launchID, reviewals...); err != nil {
+	if err := n.SubmitRequest(cmd.Context(), launchID, reviewals...); err != nil {
 		return err
 	}
 
@@ -2,6 +2,7 @@ package starportcmd
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -15,14 +16,16 @@ import (
 // requests details for a chain
 func NewNetworkRequestShow() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "show [launch-id] [request-id]",
-		Short: "Show pending requests details",
-		RunE:  networkRequestShowHandler,
-		Args:  cobra.ExactArgs(2),
+		Use:               "show [launch-id] [request-id]",
+		Short:             "Show pending requests details",
+		RunE:              networkRequestShowHandler,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeLaunchIDs,
 	}
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagNetworkFrom())
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
 	return c
 }
 
@@ -56,17 +59,28 @@ func networkRequestShowHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// convert the request object to YAML to be more readable
-	// and convert the byte array fields to string.
-	requestYaml, err := yaml.Marshal(cmd.Context(), request,
-		"$.content.content.genesisValidator.genTx",
-		"$.content.content.genesisValidator.consPubKey",
-	)
+	output, err := newRequestOutput(request)
 	if err != nil {
 		return err
 	}
+	if validator := request.Content.GetGenesisValidator(); validator != nil {
+		if output.Gentx, err = decodeJSON(validator.GenTx); err != nil {
+			return errors.Wrap(err, "invalid gentx")
+		}
+	}
 
 	nb.Spinner.Stop()
-	fmt.Println(requestYaml)
-	return nil
+	return printOutput(cmd, output, func(out io.Writer) error {
+		// convert the request object to YAML to be more readable
+		// and convert the byte array fields to string.
+		requestYaml, err := yaml.Marshal(cmd.Context(), request,
+			"$.content.content.genesisValidator.genTx",
+			"$.content.content.genesisValidator.consPubKey",
+		)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, requestYaml)
+		return err
+	})
 }
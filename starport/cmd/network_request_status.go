@@ -0,0 +1,138 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+	"github.com/tendermint/starport/starport/services/network"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+const (
+	flagWatch    = "watch"
+	flagWebhook  = "webhook"
+	flagInterval = "interval"
+)
+
+var requestHistoryHeader = []string{"Status", "Time"}
+
+// requestRecordOutput is the record of a request as printed in the JSON and YAML formats.
+type requestRecordOutput struct {
+	LaunchID  uint64                      `json:"launch_id"`
+	RequestID uint64                      `json:"request_id"`
+	Type      networktypes.RequestType    `json:"type"`
+	Address   string                      `json:"address"`
+	Status    networktypes.RequestStatus  `json:"status"`
+	History   []requestStatusChangeOutput `json:"history"`
+}
+
+type requestStatusChangeOutput struct {
+	Status networktypes.RequestStatus `json:"status"`
+	Time   string                     `json:"time"`
+}
+
+// NewNetworkRequestStatus creates a new request status command to show
+// the status of a request and its history
+func NewNetworkRequestStatus() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "status [launch-id] [request-id]",
+		Short: "Show the status of a request and its history",
+		Long: `Show the status of a request and its history.
+
+SPN removes the requests once they are settled, so the statuses of the requests that are
+submitted or checked from this machine are recorded to be known after they are settled.
+
+With --watch, the command waits for the request to be approved or rejected, and the
+webhooks are notified with the record of the request as JSON.`,
+		RunE:              networkRequestStatusHandler,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeLaunchIDs,
+	}
+	c.Flags().Bool(flagWatch, false, "Wait for the request to be approved or rejected")
+	c.Flags().StringArray(flagWebhook, nil, "URL to notify once the watched request is settled")
+	c.Flags().Duration(flagInterval, time.Second*10, "Duration between the checks of the watched request")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+	return c
+}
+
+func networkRequestStatusHandler(cmd *cobra.Command, args []string) error {
+	var (
+		watch, _    = cmd.Flags().GetBool(flagWatch)
+		webhooks, _ = cmd.Flags().GetStringArray(flagWebhook)
+		interval, _ = cmd.Flags().GetDuration(flagInterval)
+	)
+
+	// initialize network common methods
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	// parse launch ID
+	launchID, err := network.ParseLaunchID(args[0])
+	if err != nil {
+		return err
+	}
+
+	// parse request ID
+	requestID, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "error parsing requestID")
+	}
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	var record networktypes.RequestRecord
+	if watch {
+		options := []network.TrackOption{network.WithTrackInterval(interval)}
+		for _, url := range webhooks {
+			options = append(options, network.WithWebhook(url))
+		}
+		record, err = n.TrackRequest(cmd.Context(), launchID, requestID, options...)
+	} else {
+		record, err = n.RequestStatus(cmd.Context(), launchID, requestID)
+	}
+	if err != nil {
+		return err
+	}
+
+	output := requestRecordOutput{
+		LaunchID:  record.LaunchID,
+		RequestID: record.RequestID,
+		Type:      record.Type,
+		Address:   record.Address,
+		Status:    record.Status,
+		History:   make([]requestStatusChangeOutput, 0, len(record.History)),
+	}
+	for _, change := range record.History {
+		output.History = append(output.History, requestStatusChangeOutput{
+			Status: change.Status,
+			Time:   change.Time.Format(time.RFC3339),
+		})
+	}
+
+	nb.Spinner.Stop()
+
+	return printOutput(cmd, output, func(out io.Writer) error {
+		fmt.Fprintf(out, "Request %d of launch %d is %s\n", output.RequestID, output.LaunchID, output.Status)
+		fmt.Fprintf(out, "Type: %s\nAddress: %s\n\n", output.Type, output.Address)
+
+		var entries [][]string
+		for _, change := range output.History {
+			entries = append(entries, []string{string(change.Status), change.Time})
+		}
+		return entrywriter.MustWrite(out, requestHistoryHeader, entries...)
+	})
+}
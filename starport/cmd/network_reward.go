@@ -0,0 +1,110 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/services/network"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// monitoringStatusOutput is the monitoring status of a launch as printed in the JSON and YAML formats.
+type monitoringStatusOutput struct {
+	LaunchID          uint64   `json:"launch_id"`
+	Stage             string   `json:"stage"`
+	ProviderClientID  string   `json:"provider_client_id,omitempty"`
+	VerifiedClientIDs []string `json:"verified_client_ids"`
+	ChannelIDs        []string `json:"channel_ids"`
+}
+
+// NewNetworkReward creates a new reward command that holds some other sub commands related to
+// the rewards of the incentivized testnets.
+func NewNetworkReward() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "reward",
+		Short: "Follow the rewards of the incentivized testnets",
+		Long: `Follow the rewards of the incentivized testnets.
+
+The rewards of a testnet are distributed from the monitoring data relayed from the launched chain
+to SPN. The reward pools and the claims are not available in the version of SPN used by this
+Starport, only the relay of the monitoring data can be followed for now.`,
+	}
+
+	c.AddCommand(NewNetworkRewardStatus())
+
+	return c
+}
+
+// NewNetworkRewardStatus creates a new reward status command to show the relay of the monitoring
+// data of a launch to SPN.
+func NewNetworkRewardStatus() *cobra.Command {
+	c := &cobra.Command{
+		Use:               "status [launch-id]",
+		Short:             "Show the relay of the monitoring data of a launch to SPN",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLaunchIDs,
+		RunE:              networkRewardStatusHandler,
+	}
+
+	c.Flags().AddFlagSet(flagNetworkFrom())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func networkRewardStatusHandler(cmd *cobra.Command, args []string) error {
+	launchID, err := network.ParseLaunchID(args[0])
+	if err != nil {
+		return err
+	}
+
+	nb, err := newNetworkBuilder(cmd)
+	if err != nil {
+		return err
+	}
+	defer nb.Cleanup()
+
+	n, err := nb.Network()
+	if err != nil {
+		return err
+	}
+
+	monitoringStatus, err := n.MonitoringStatus(cmd.Context(), launchID)
+	if err != nil {
+		return err
+	}
+
+	nb.Spinner.Stop()
+
+	output := monitoringStatusOutput{
+		LaunchID:          monitoringStatus.LaunchID,
+		Stage:             string(monitoringStatus.Stage()),
+		ProviderClientID:  monitoringStatus.ProviderClientID,
+		VerifiedClientIDs: monitoringStatus.VerifiedClientIDs,
+		ChannelIDs:        monitoringStatus.ChannelIDs,
+	}
+	return printOutput(cmd, output, func(out io.Writer) error {
+		var hint string
+		switch monitoringStatus.Stage() {
+		case networktypes.MonitoringNotConfigured:
+			hint = "the IBC client of the chain is not verified on SPN yet"
+		case networktypes.MonitoringClientVerified:
+			hint = "the monitoring channel is not established yet, the monitoring data is not relayed"
+		case networktypes.MonitoringRelaying:
+			hint = "the monitoring data is relayed to SPN"
+		}
+		_, err := fmt.Fprintf(out, "Launch: %d\nStage: %s (%s)\nProvider client: %s\nVerified clients: %s\nChannels: %s\n",
+			output.LaunchID,
+			output.Stage,
+			hint,
+			orNone(output.ProviderClientID),
+			orNone(strings.Join(output.VerifiedClientIDs, ", ")),
+			orNone(strings.Join(output.ChannelIDs, ", ")),
+		)
+		return err
+	})
+}
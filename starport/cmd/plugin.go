@@ -0,0 +1,314 @@
+package starportcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+	"github.com/tendermint/starport/starport/pkg/starportplugin"
+)
+
+// loadPluginTimeout is the duration that a plugin has to return its manifest.
+const loadPluginTimeout = time.Second * 10
+
+var pluginListHeader = []string{"name", "path", "commands", "hooks"}
+
+// pluginOutput is a plugin as printed in the JSON and YAML formats.
+type pluginOutput struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Commands []string `json:"commands"`
+	Hooks    []string `json:"hooks"`
+}
+
+// NewPlugin returns a command that groups sub commands related to plugins.
+func NewPlugin() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "plugin [command]",
+		Short: "Manage the plugins that extend Starport",
+		Long: `Manage the plugins that extend Starport.
+
+Plugins are binaries installed in $HOME/.starport/plugins. They are built with the
+starportplugin package to add commands to Starport and to hook into its commands.`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewPluginList())
+
+	return c
+}
+
+// NewPluginList returns a new command to list the installed plugins.
+func NewPluginList() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List the installed plugins",
+		Args:  cobra.NoArgs,
+		RunE:  pluginListHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func pluginListHandler(cmd *cobra.Command, args []string) error {
+	plugins, err := discoverPlugins(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	outputs := make([]pluginOutput, 0, len(plugins))
+	for _, p := range plugins {
+		output := pluginOutput{
+			Name:     p.manifest.Name,
+			Path:     p.path,
+			Commands: make([]string, 0, len(p.manifest.Commands)),
+			Hooks:    append([]string{}, p.manifest.Hooks...),
+		}
+		for _, command := range p.manifest.Commands {
+			output.Commands = append(output.Commands, strings.TrimSpace(command.Parent+" "+commandName(command.Use)))
+		}
+		outputs = append(outputs, output)
+	}
+
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		var entries [][]string
+		for _, p := range outputs {
+			entries = append(entries, []string{
+				p.Name,
+				p.Path,
+				strings.Join(p.Commands, ", "),
+				strings.Join(p.Hooks, ", "),
+			})
+		}
+		return entrywriter.MustWrite(out, pluginListHeader, entries...)
+	})
+}
+
+type loadedPlugin struct {
+	path     string
+	manifest starportplugin.Manifest
+}
+
+// discoverPlugins loads the manifests of the installed plugins, the plugins that cannot be loaded
+// are skipped with a warning. the manifests are cached so the plugins are only started when their
+// binaries change.
+func discoverPlugins(ctx context.Context) ([]loadedPlugin, error) {
+	dir, err := starportplugin.PluginsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := starportplugin.Discover(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	cachePath, err := starportplugin.ManifestCachePath()
+	if err != nil {
+		return nil, err
+	}
+	cache, err := starportplugin.OpenManifestCache(cachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []loadedPlugin
+	for _, path := range paths {
+		// the plugins are not interactive while their manifests are read.
+		loadCtx, cancel := context.WithTimeout(ctx, loadPluginTimeout)
+		manifest, err := cache.Manifest(loadCtx, path, starportplugin.Stdin(nil), starportplugin.Stdout(io.Discard))
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Cannot load plugin %s: %s\n", path, err)
+			continue
+		}
+		plugins = append(plugins, loadedPlugin{path, manifest})
+	}
+
+	if err := cache.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Cannot cache the manifests of the plugins: %s\n", err)
+	}
+	return plugins, nil
+}
+
+// addPlugins adds the commands of the installed plugins to the root command and hooks the
+// plugins into the commands of the root command.
+func addPlugins(ctx context.Context, root *cobra.Command) {
+	plugins, err := discoverPlugins(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Cannot load plugins: %s\n", err)
+		return
+	}
+
+	for _, p := range plugins {
+		for _, command := range p.manifest.Commands {
+			parent, args, err := root.Find(strings.Fields(command.Parent))
+			if err != nil || len(args) > 0 {
+				fmt.Fprintf(os.Stderr, "⚠️  Cannot add command %q of plugin %s: parent command %q not found\n",
+					command.Use, p.manifest.Name, command.Parent)
+				continue
+			}
+			parent.AddCommand(newPluginCommand(p.path, command))
+		}
+	}
+
+	for _, p := range plugins {
+		for _, hook := range p.manifest.Hooks {
+			hookPlugin(root, p.path, hook)
+		}
+	}
+}
+
+// newPluginCommand returns a command that is run by the plugin at path.
+func newPluginCommand(path string, command starportplugin.Command) *cobra.Command {
+	c := &cobra.Command{
+		Use:   command.Use,
+		Short: command.Short,
+		Long:  command.Long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := starportplugin.Start(cmd.Context(), path)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			flags := make(map[string]string)
+			cmd.LocalFlags().VisitAll(func(f *flag.Flag) {
+				flags[f.Name] = f.Value.String()
+			})
+
+			return client.Execute(starportplugin.ExecuteRequest{
+				Command: pluginCommandPath(cmd),
+				Args:    args,
+				Flags:   flags,
+				Chain:   pluginChain(cmd),
+			})
+		},
+	}
+
+	for _, f := range command.Flags {
+		if f.Bool {
+			c.Flags().BoolP(f.Name, f.Shorthand, f.Default == "true", f.Usage)
+		} else {
+			c.Flags().StringP(f.Name, f.Shorthand, f.Default, f.Usage)
+		}
+	}
+
+	return c
+}
+
+// hookPlugin makes the plugin at path hooked into the command at the hook path and its sub
+// commands.
+func hookPlugin(root *cobra.Command, path, hook string) {
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+
+		commandPath := pluginCommandPath(c)
+		if c.RunE == nil || (commandPath != hook && !strings.HasPrefix(commandPath, hook+" ")) {
+			return
+		}
+
+		run := c.RunE
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			req := starportplugin.HookRequest{
+				Event:   starportplugin.HookPre,
+				Command: pluginCommandPath(cmd),
+				Args:    args,
+				Flags:   make(map[string]string),
+				Chain:   pluginChain(cmd),
+			}
+			cmd.Flags().Visit(func(f *flag.Flag) {
+				req.Flags[f.Name] = f.Value.String()
+			})
+
+			if err := callPluginHook(cmd.Context(), path, req); err != nil {
+				return err
+			}
+
+			err := run(cmd, args)
+
+			req.Event = starportplugin.HookPost
+			if err != nil {
+				req.Error = err.Error()
+			}
+
+			// the post hooks are called even if the command is canceled.
+			if hookErr := callPluginHook(context.Background(), path, req); hookErr != nil && err == nil {
+				err = hookErr
+			}
+			return err
+		}
+	}
+	walk(root)
+}
+
+func callPluginHook(ctx context.Context, path string, req starportplugin.HookRequest) error {
+	client, err := starportplugin.Start(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Hook(req); err != nil {
+		return fmt.Errorf("%s hook of plugin %s: %w", req.Event, path, err)
+	}
+	return nil
+}
+
+// pluginCommandPath returns the path of the command under starport.
+func pluginCommandPath(cmd *cobra.Command) string {
+	return strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()), " ")
+}
+
+// pluginChain returns the chain in the path of the command, the config of the chain is parsed
+// with the --config and --profile flags when the command has them.
+func pluginChain(cmd *cobra.Command) starportplugin.Chain {
+	chain := starportplugin.Chain{AppPath: "."}
+	if cmd.Flags().Lookup(flagPath) != nil {
+		chain.AppPath = flagGetPath(cmd)
+	}
+
+	if cmd.Flags().Lookup(flagConfig) != nil {
+		chain.ConfigPath, _ = cmd.Flags().GetString(flagConfig)
+	}
+	if chain.ConfigPath == "" {
+		chain.ConfigPath, _ = chainconfig.LocateDefault(chain.AppPath)
+	}
+
+	if chain.ConfigPath != "" {
+		var options []chainconfig.ParseOption
+		if cmd.Flags().Lookup(flagProfile) != nil {
+			options = configParseOptions(cmd)
+		}
+		if conf, err := chainconfig.ParseFile(chain.ConfigPath, options...); err == nil {
+			chain.Config = &conf
+		}
+	}
+
+	return chain
+}
+
+// commandName returns the name of the command from its usage.
+func commandName(use string) string {
+	if i := strings.Index(use, " "); i >= 0 {
+		return use[:i]
+	}
+	return use
+}
@@ -17,6 +17,11 @@ func NewRelayer() *cobra.Command {
 
 	c.AddCommand(NewRelayerConfigure())
 	c.AddCommand(NewRelayerConnect())
+	c.AddCommand(NewRelayerStart())
+	c.AddCommand(NewRelayerClear())
+	c.AddCommand(NewRelayerDiagnose())
+	c.AddCommand(NewRelayerFees())
+	c.AddCommand(NewRelayerStatus())
 
 	return c
 }
@@ -0,0 +1,56 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/relayer"
+)
+
+// NewRelayerClear returns a new relayer clear command to relay the pending packets of a path once.
+func NewRelayerClear() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "clear [path]",
+		Short: "Relay the pending packets and acknowledgements of a path once, including the stuck ones",
+		Args:  cobra.ExactArgs(1),
+		RunE:  relayerClearHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func relayerClearHandler(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := ca.EnsureDefaultAccount(); err != nil {
+		return err
+	}
+
+	s := clispinner.New().SetText("Clearing packets...")
+	defer s.Stop()
+
+	if err := relayer.New(ca).Clear(cmd.Context(), args[0]); err != nil {
+		return err
+	}
+
+	s.Stop()
+
+	fmt.Printf("🧹 Cleared pending packets of %s\n", color.Green.Sprint(args[0]))
+
+	return nil
+}
@@ -2,6 +2,7 @@ package starportcmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/briandowns/spinner"
 	"github.com/gookit/color"
@@ -33,6 +34,11 @@ const (
 	flagSourceAddressPrefix = "source-prefix"
 	flagTargetAddressPrefix = "target-prefix"
 	flagOrdered             = "ordered"
+	flagReuse               = "reuse"
+	flagNew                 = "new"
+	flagIncentivized        = "incentivized"
+	flagSourcePayee         = "source-payee"
+	flagTargetPayee         = "target-payee"
 
 	relayerSource = "source"
 	relayerTarget = "target"
@@ -76,6 +82,11 @@ func NewRelayerConfigure() *cobra.Command {
 	c.Flags().String(flagSourceAccount, "", "Source Account")
 	c.Flags().String(flagTargetAccount, "", "Target Account")
 	c.Flags().Bool(flagOrdered, false, "Set the channel as ordered")
+	c.Flags().Bool(flagReuse, false, "Reuse an existing open channel between the chains without asking")
+	c.Flags().Bool(flagNew, false, "Create a new channel even if there is an existing open channel between the chains")
+	c.Flags().Bool(flagIncentivized, false, "Set the channel as an incentivized channel of the ICS-29 fee middleware")
+	c.Flags().String(flagSourcePayee, "", "Address on the source chain that receives the relayer fees (default is the source account)")
+	c.Flags().String(flagTargetPayee, "", "Address on the target chain that receives the relayer fees (default is the target account)")
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 
 	return c
@@ -295,6 +306,29 @@ func relayerConfigureHandler(cmd *cobra.Command, args []string) (err error) {
 	if err != nil {
 		return err
 	}
+	reuse, err := cmd.Flags().GetBool(flagReuse)
+	if err != nil {
+		return err
+	}
+	newChannel, err := cmd.Flags().GetBool(flagNew)
+	if err != nil {
+		return err
+	}
+	incentivized, err := cmd.Flags().GetBool(flagIncentivized)
+	if err != nil {
+		return err
+	}
+	sourcePayee, err := cmd.Flags().GetString(flagSourcePayee)
+	if err != nil {
+		return err
+	}
+	targetPayee, err := cmd.Flags().GetString(flagTargetPayee)
+	if err != nil {
+		return err
+	}
+	if reuse && newChannel {
+		return fmt.Errorf("--%s and --%s flags cannot be used together", flagReuse, flagNew)
+	}
 
 	var questions []cliquiz.Question
 
@@ -412,6 +446,54 @@ func relayerConfigureHandler(cmd *cobra.Command, args []string) (err error) {
 		}
 	}
 
+	// relay on an incentivized channel and get paid with the fees of its packets
+	if incentivized {
+		channelOptions = append(channelOptions,
+			relayer.Incentivized(),
+			relayer.SourcePayee(sourcePayee),
+			relayer.TargetPayee(targetPayee),
+		)
+	}
+
+	// reuse an existing channel between the chains instead of creating a new one
+	if !newChannel {
+		s.SetText("Looking for an existing channel...")
+
+		channel, err := sourceChain.FindChannel(cmd.Context(), targetChain, channelOptions...)
+		switch {
+		case err == nil:
+			s.Stop()
+
+			if !reuse {
+				reuse, err = cliquiz.Confirm(fmt.Sprintf("%s (%s) and %s (%s) channels are open between the chains. Would you like to reuse them",
+					channel.Src.ChannelID,
+					channel.Src.ChainID,
+					channel.Dst.ChannelID,
+					channel.Dst.ChainID,
+				))
+				if errors.Is(err, cliquiz.ErrInputRequired) {
+					return fmt.Errorf("%w, use --%s or --%s", err, flagReuse, flagNew)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if reuse {
+				channelOptions = append(channelOptions, relayer.ReuseChannel(channel))
+			}
+
+			s.SetText("Configuring...").Start()
+		case errors.Is(err, relayer.ErrChannelNotFound):
+		default:
+			// the channels cannot be listed on some chains, e.g. when their IBC queries are not
+			// implemented, so a new channel is created instead.
+			s.Stop()
+			fmt.Fprintf(os.Stderr, "⚠️  Cannot look for an existing channel, creating a new one: %s\n", err)
+			s.SetText("Configuring...").Start()
+		}
+	}
+
 	// create the connection configuration
 	id, err := sourceChain.Connect(cmd.Context(), targetChain, channelOptions...)
 	if err != nil {
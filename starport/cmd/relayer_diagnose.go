@@ -0,0 +1,119 @@
+package starportcmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/relayer"
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+// NewRelayerDiagnose returns a new relayer diagnose command to list the pending packets of all or
+// some relayer paths.
+func NewRelayerDiagnose() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "diagnose [<path>,...]",
+		Short:   "List packets and acknowledgements of linked paths that are not relayed yet",
+		Aliases: []string{"diag"},
+		RunE:    relayerDiagnoseHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func relayerDiagnoseHandler(cmd *cobra.Command, args []string) error {
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	s := clispinner.New()
+	defer s.Stop()
+
+	r := relayer.New(ca)
+
+	all, err := r.ListPaths(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// if no path ids provided, then we diagnose all linked paths otherwise, only the specified ones.
+	var paths []relayerconf.Path
+	for _, path := range all {
+		if path.Src.ChannelID == "" {
+			continue
+		}
+		if len(args) == 0 {
+			paths = append(paths, path)
+			continue
+		}
+		for _, id := range args {
+			if id == path.ID {
+				paths = append(paths, path)
+				break
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		s.Stop()
+
+		fmt.Println("No linked paths found to diagnose.")
+		return nil
+	}
+
+	for _, path := range paths {
+		s.SetText("Querying pending packets...").Start()
+
+		pending, err := r.PendingPackets(cmd.Context(), path.ID)
+		if err != nil {
+			return err
+		}
+
+		s.Stop()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.TabIndent)
+		fmt.Fprintf(w, "%s:\n", path.ID)
+		printPendingPackets(w, path.Src, path.Dst, pending.Src)
+		printPendingPackets(w, path.Dst, path.Src, pending.Dst)
+		fmt.Fprintln(w)
+		w.Flush()
+	}
+
+	return nil
+}
+
+// printPendingPackets prints the pending packets of end to be relayed to counterparty.
+func printPendingPackets(w *tabwriter.Writer, end, counterparty relayerconf.PathEnd, pending relayer.PendingPackets) {
+	fmt.Fprintf(w, "   \t%s\t>\t%s\t(channel: %s)\t(packets: %d)\t(acks: %d)\n",
+		end.ChainID,
+		counterparty.ChainID,
+		end.ChannelID,
+		len(pending.Packets),
+		len(pending.Acks),
+	)
+
+	for _, packet := range pending.Packets {
+		fmt.Fprintf(w, "   \t\t\tpacket\t(sequence: %d)\t%s\n", packet.Sequence, formatPacketAge(packet))
+	}
+	for _, ack := range pending.Acks {
+		fmt.Fprintf(w, "   \t\t\tack\t(sequence: %d)\t%s\n", ack.Sequence, formatPacketAge(ack))
+	}
+}
+
+func formatPacketAge(packet relayer.PendingPacket) string {
+	if packet.Time.IsZero() {
+		return "(age: unknown)"
+	}
+	return fmt.Sprintf("(age: %s)\t(height: %d)", packet.Age().Round(time.Second), packet.Height)
+}
@@ -0,0 +1,140 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/relayer"
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+// relayerPathFeesOutput is the fees earned on a path as printed in the JSON and YAML formats.
+type relayerPathFeesOutput struct {
+	ID  string                   `json:"id"`
+	Src relayerPathEndFeesOutput `json:"src"`
+	Dst relayerPathEndFeesOutput `json:"dst"`
+}
+
+type relayerPathEndFeesOutput struct {
+	ChainID   string `json:"chain_id"`
+	ChannelID string `json:"channel_id"`
+	Payee     string `json:"payee"`
+	Fees      string `json:"fees"`
+}
+
+func newRelayerPathEndFeesOutput(end relayerconf.PathEnd, fees relayer.Fees) relayerPathEndFeesOutput {
+	return relayerPathEndFeesOutput{
+		ChainID:   end.ChainID,
+		ChannelID: end.ChannelID,
+		Payee:     fees.Payee,
+		Fees:      fees.Coins.String(),
+	}
+}
+
+// NewRelayerFees returns a new relayer fees command to show the fees earned on all or some
+// incentivized relayer paths.
+func NewRelayerFees() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "fees [<path>,...]",
+		Short: "Show the fees earned by relaying packets of incentivized paths",
+		RunE:  relayerFeesHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func relayerFeesHandler(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	s := clispinner.New()
+	defer s.Stop()
+
+	r := relayer.New(ca)
+
+	all, err := r.ListPaths(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// if no path ids provided, then we show the fees of all linked incentivized paths otherwise,
+	// only the specified ones.
+	var paths []relayerconf.Path
+	for _, path := range all {
+		if !path.Incentivized || path.Src.ChannelID == "" {
+			continue
+		}
+		if len(args) == 0 {
+			paths = append(paths, path)
+			continue
+		}
+		for _, id := range args {
+			if id == path.ID {
+				paths = append(paths, path)
+				break
+			}
+		}
+	}
+
+	outputs := make([]relayerPathFeesOutput, 0, len(paths))
+	for _, path := range paths {
+		s.SetText("Querying earned fees...").Start()
+
+		fees, err := r.EarnedFees(cmd.Context(), path.ID)
+		if err != nil {
+			return err
+		}
+
+		outputs = append(outputs, relayerPathFeesOutput{
+			ID:  path.ID,
+			Src: newRelayerPathEndFeesOutput(path.Src, fees.Src),
+			Dst: newRelayerPathEndFeesOutput(path.Dst, fees.Dst),
+		})
+	}
+
+	s.Stop()
+
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		if len(outputs) == 0 {
+			fmt.Fprintln(out, "No linked incentivized paths found.")
+			return nil
+		}
+
+		for _, output := range outputs {
+			w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.TabIndent)
+			fmt.Fprintf(w, "%s:\n", output.ID)
+			printFees(w, output.Src)
+			printFees(w, output.Dst)
+			fmt.Fprintln(w)
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// printFees prints the fees earned on end.
+func printFees(w *tabwriter.Writer, end relayerPathEndFeesOutput) {
+	fees := end.Fees
+	if fees == "" {
+		fees = "none"
+	}
+	fmt.Fprintf(w, "   \t%s\t(channel: %s)\t(payee: %s)\t(fees: %s)\n", end.ChainID, end.ChannelID, end.Payee, fees)
+}
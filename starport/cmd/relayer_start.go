@@ -0,0 +1,189 @@
+package starportcmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/relayer"
+)
+
+const (
+	flagDaemon         = "daemon"
+	flagControlAddress = "control-address"
+	flagControlToken   = "control-token"
+)
+
+// NewRelayerStart returns a new relayer start command to relay the linked paths, optionally as a
+// long-running daemon that can be controlled through its API.
+func NewRelayerStart() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "start [<path>,...]",
+		Short: "Start relaying packets of linked paths",
+		Long: `Start relaying packets of linked paths.
+
+With --daemon, the relayer runs as a long-running service that relays all linked paths that are not
+paused. It serves a control API on a local socket to add paths, pause and resume paths and reload
+the configuration without restart. The configuration is also reloaded on SIGHUP.
+
+The control API can be served on a loopback TCP address instead, its requests are authorized with
+the bearer token of --control-token. A token is generated when it's not set.`,
+		RunE: relayerStartHandler,
+	}
+
+	c.Flags().Bool(flagDaemon, false, "Run as a long-running service with a control API")
+	c.Flags().String(flagControlAddress, relayer.DefaultControlAddress, "Address of the control API, either unix://<socket path> or <loopback host>:<port>")
+	c.Flags().String(flagControlToken, "", "Bearer token of the control API (default: generated for TCP addresses)")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func relayerStartHandler(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	daemon, err := cmd.Flags().GetBool(flagDaemon)
+	if err != nil {
+		return err
+	}
+	controlAddress, err := cmd.Flags().GetString(flagControlAddress)
+	if err != nil {
+		return err
+	}
+	controlToken, err := cmd.Flags().GetString(flagControlToken)
+	if err != nil {
+		return err
+	}
+	if daemon && len(args) > 0 {
+		return fmt.Errorf("paths cannot be specified with --%s, pause the paths that should not be relayed", flagDaemon)
+	}
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := ca.EnsureDefaultAccount(); err != nil {
+		return err
+	}
+
+	r := relayer.New(ca)
+
+	if daemon {
+		return startRelayerDaemon(cmd.Context(), r, controlAddress, controlToken)
+	}
+
+	all, err := r.ListPaths(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// if no path ids provided, then we relay all linked paths that are not paused otherwise,
+	// only the specified ones.
+	var use []string
+	for _, path := range all {
+		if path.Src.ChannelID == "" {
+			continue
+		}
+		if len(args) == 0 {
+			if !path.Paused {
+				use = append(use, path.ID)
+			}
+			continue
+		}
+		for _, id := range args {
+			if id == path.ID {
+				use = append(use, path.ID)
+				break
+			}
+		}
+	}
+
+	if len(use) == 0 {
+		fmt.Println("No linked paths found to relay.")
+		return nil
+	}
+
+	printSection("Listening and relaying packets between chains...")
+
+	return r.Start(cmd.Context(), use...)
+}
+
+// startRelayerDaemon runs the relayer daemon and serves its control API until ctx is canceled.
+func startRelayerDaemon(ctx context.Context, r relayer.Relayer, controlAddress, controlToken string) error {
+	var serveOptions []relayer.ServeOption
+	if controlToken == "" && !relayer.IsUnixControlAddress(controlAddress) {
+		var err error
+		if controlToken, err = newControlToken(relayer.DefaultControlTokenPath); err != nil {
+			return err
+		}
+		fmt.Printf("🔑 Control API token is written to %s\n", relayer.DefaultControlTokenPath)
+	}
+	if controlToken != "" {
+		serveOptions = append(serveOptions, relayer.ControlToken(controlToken))
+	}
+
+	d := r.NewDaemon()
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return d.Run(ctx)
+	})
+
+	g.Go(func() error {
+		return d.Serve(ctx, controlAddress, serveOptions...)
+	})
+
+	// reload the configuration on SIGHUP.
+	g.Go(func() error {
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		defer signal.Stop(reload)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-reload:
+				if err := d.Reload(); err != nil {
+					fmt.Printf("cannot reload the configuration: %s\n", err)
+				}
+			}
+		}
+	})
+
+	printSection(fmt.Sprintf("Relayer daemon is running, control API: %s", controlAddress))
+
+	return g.Wait()
+}
+
+// newControlToken generates a token for the control API and writes it to path, only the user can read it.
+func newControlToken(path string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
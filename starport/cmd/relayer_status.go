@@ -0,0 +1,186 @@
+package starportcmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/relayer"
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+// relayerPathStatusOutput is the status of a path as printed in the JSON and YAML formats.
+type relayerPathStatusOutput struct {
+	ID           string               `json:"id"`
+	Health       relayer.Health       `json:"health"`
+	LastSuccess  string               `json:"last_success,omitempty"`
+	LastError    string               `json:"last_error,omitempty"`
+	Failures     int                  `json:"failures"`
+	NextAttempt  string               `json:"next_attempt,omitempty"`
+	PendingError string               `json:"pending_error,omitempty"`
+	Src          relayerPathEndOutput `json:"src"`
+	Dst          relayerPathEndOutput `json:"dst"`
+}
+
+// relayerPathEndOutput is the status of an end of a path, the pending counts are omitted when
+// they cannot be queried.
+type relayerPathEndOutput struct {
+	ChainID        string `json:"chain_id"`
+	ChannelID      string `json:"channel_id"`
+	PacketHeight   int64  `json:"packet_height"`
+	AckHeight      int64  `json:"ack_height"`
+	PendingPackets *int   `json:"pending_packets,omitempty"`
+	PendingAcks    *int   `json:"pending_acks,omitempty"`
+}
+
+func newRelayerPathStatusOutput(status relayer.PathStatus) relayerPathStatusOutput {
+	output := relayerPathStatusOutput{
+		ID:        status.Path.ID,
+		Health:    status.Health,
+		LastError: status.Relay.LastError,
+		Failures:  status.Relay.Failures,
+		Src:       newRelayerPathEndOutput(status.Path.Src),
+		Dst:       newRelayerPathEndOutput(status.Path.Dst),
+	}
+	if !status.Relay.LastSuccess.IsZero() {
+		output.LastSuccess = status.Relay.LastSuccess.Format(time.RFC3339)
+	}
+	if status.Relay.Failures > 0 {
+		output.NextAttempt = status.Relay.NextAttempt.Format(time.RFC3339)
+	}
+	if status.PendingErr != nil {
+		output.PendingError = status.PendingErr.Error()
+	}
+	if status.Pending != nil {
+		output.Src.setPending(status.Pending.Src)
+		output.Dst.setPending(status.Pending.Dst)
+	}
+	return output
+}
+
+func newRelayerPathEndOutput(end relayerconf.PathEnd) relayerPathEndOutput {
+	return relayerPathEndOutput{
+		ChainID:      end.ChainID,
+		ChannelID:    end.ChannelID,
+		PacketHeight: end.PacketHeight,
+		AckHeight:    end.AckHeight,
+	}
+}
+
+func (o *relayerPathEndOutput) setPending(pending relayer.PendingPackets) {
+	packets, acks := len(pending.Packets), len(pending.Acks)
+	o.PendingPackets, o.PendingAcks = &packets, &acks
+}
+
+// NewRelayerStatus returns a new relayer status command to show the health, the last relayed heights
+// and the pending packet counts of all or some relayer paths.
+func NewRelayerStatus() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "status [<path>,...]",
+		Short: "Show the health, relayed heights and pending packets of paths",
+		RunE:  relayerStatusHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func relayerStatusHandler(cmd *cobra.Command, args []string) error {
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	s := clispinner.New()
+	defer s.Stop()
+
+	s.SetText("Querying status of paths...")
+
+	statuses, err := relayer.New(ca).Status(cmd.Context(), args...)
+	if err != nil {
+		return err
+	}
+
+	s.Stop()
+
+	outputs := make([]relayerPathStatusOutput, 0, len(statuses))
+	for _, status := range statuses {
+		outputs = append(outputs, newRelayerPathStatusOutput(status))
+	}
+
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		if len(statuses) == 0 {
+			fmt.Fprintln(out, "No paths found.")
+			return nil
+		}
+
+		for _, status := range statuses {
+			w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.TabIndent)
+			fmt.Fprintf(w, "%s:\t(health: %s)\t%s\n", status.Path.ID, status.Health, formatRelayAttempts(status.Relay))
+			if status.Relay.LastError != "" {
+				fmt.Fprintf(w, "   \terror: %s\n", status.Relay.LastError)
+			}
+			if status.PendingErr != nil {
+				fmt.Fprintf(w, "   \tcannot query pending packets: %s\n", status.PendingErr)
+			}
+
+			var src, dst relayer.PendingPackets
+			if status.Pending != nil {
+				src, dst = status.Pending.Src, status.Pending.Dst
+			}
+			printPathEndStatus(w, status.Path.Src, status.Path.Dst, src, status.Pending != nil)
+			printPathEndStatus(w, status.Path.Dst, status.Path.Src, dst, status.Pending != nil)
+			fmt.Fprintln(w)
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// formatRelayAttempts formats the times of the last and the next attempts to relay a path.
+func formatRelayAttempts(status relayerconf.PathStatus) string {
+	text := "(last relayed: never)"
+	if !status.LastSuccess.IsZero() {
+		text = fmt.Sprintf("(last relayed: %s ago)", time.Since(status.LastSuccess).Round(time.Second))
+	}
+	if status.Failures > 0 {
+		text += fmt.Sprintf("\t(failures: %d)\t(next attempt: %s)",
+			status.Failures,
+			status.NextAttempt.Format(time.RFC3339),
+		)
+	}
+	return text
+}
+
+// printPathEndStatus prints the relayed heights and the pending packet counts of end.
+func printPathEndStatus(
+	w *tabwriter.Writer,
+	end, counterparty relayerconf.PathEnd,
+	pending relayer.PendingPackets,
+	hasPending bool,
+) {
+	counts := "(packets: -)\t(acks: -)"
+	if hasPending {
+		counts = fmt.Sprintf("(packets: %d)\t(acks: %d)", len(pending.Packets), len(pending.Acks))
+	}
+
+	fmt.Fprintf(w, "   \t%s\t>\t%s\t(channel: %s)\t(packet height: %d)\t(ack height: %d)\t%s\n",
+		end.ChainID,
+		counterparty.ChainID,
+		end.ChannelID,
+		end.PacketHeight,
+		end.AckHeight,
+		counts,
+	)
+}
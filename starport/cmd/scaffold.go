@@ -2,6 +2,7 @@ package starportcmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -18,6 +19,8 @@ const (
 	flagNoSimulation = "no-simulation"
 	flagResponse     = "response"
 	flagDescription  = "desc"
+	flagCLIFlags     = "cli-flags"
+	flagCLIDefault   = "cli-default"
 )
 
 // NewScaffold returns a command that groups scaffolding related sub commands.
@@ -44,6 +47,7 @@ CRUD stands for "create, read, update, delete".`,
 	c.AddCommand(NewScaffoldBandchain())
 	c.AddCommand(NewScaffoldVue())
 	c.AddCommand(NewScaffoldFlutter())
+	c.AddCommand(NewScaffoldTokenomics())
 	// c.AddCommand(NewScaffoldWasm())
 
 	return c
@@ -53,6 +57,7 @@ func scaffoldType(
 	cmd *cobra.Command,
 	args []string,
 	kind scaffolder.AddTypeKind,
+	kindOptions ...scaffolder.AddTypeOption,
 ) error {
 	var (
 		typeName          = args[0]
@@ -64,7 +69,7 @@ func scaffoldType(
 		appPath           = flagGetPath(cmd)
 	)
 
-	var options []scaffolder.AddTypeOption
+	options := kindOptions
 
 	if len(fields) > 0 {
 		options = append(options, scaffolder.TypeWithFields(fields...))
@@ -137,3 +142,30 @@ func flagGetSigner(cmd *cobra.Command) string {
 	signer, _ := cmd.Flags().GetString(flagSigner)
 	return signer
 }
+
+func flagSetCLIFlags() *flag.FlagSet {
+	f := flag.NewFlagSet("", flag.ContinueOnError)
+	f.Bool(flagCLIFlags, false, "Read the fields in the CLI command from flags instead of positional args")
+	f.StringArray(flagCLIDefault, []string{}, "Default value of the flag of a field in the CLI command, e.g. --cli-default amount=10token (requires --cli-flags)")
+	return f
+}
+
+// flagGetCLIFlags returns true when the CLI command reads the fields from flags with the default
+// values of the flags by the names of the fields.
+func flagGetCLIFlags(cmd *cobra.Command) (enabled bool, defaults map[string]string, err error) {
+	enabled, _ = cmd.Flags().GetBool(flagCLIFlags)
+	values, _ := cmd.Flags().GetStringArray(flagCLIDefault)
+	if len(values) > 0 && !enabled {
+		return false, nil, fmt.Errorf("--%s requires --%s", flagCLIDefault, flagCLIFlags)
+	}
+
+	defaults = make(map[string]string)
+	for _, v := range values {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return false, nil, fmt.Errorf("default value %s is invalid, must be <field>=<value>", v)
+		}
+		defaults[kv[0]] = kv[1]
+	}
+	return enabled, defaults, nil
+}
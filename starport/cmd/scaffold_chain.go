@@ -2,9 +2,11 @@ package starportcmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/starport/starport/pkg/chaintemplate"
 	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/services/scaffolder"
@@ -12,6 +14,8 @@ import (
 
 const (
 	flagNoDefaultModule = "no-module"
+	flagTemplate        = "template"
+	flagVar             = "var"
 )
 
 // NewScaffoldChain creates new command to scaffold a Comos-SDK based blockchain.
@@ -19,14 +23,22 @@ func NewScaffoldChain() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "chain [github.com/org/repo]",
 		Short: "Fully-featured Cosmos SDK blockchain",
-		Long:  "Scaffold a new Cosmos SDK blockchain with a default directory structure",
-		Args:  cobra.ExactArgs(1),
-		RunE:  scaffoldChainHandler,
+		Long: `Scaffold a new Cosmos SDK blockchain with a default directory structure.
+
+The blockchain can be scaffolded from a chain template with --template instead of the built-in
+template. The template is a local directory or a git repository with a starport.template.yml
+manifest at its root that declares its variables, their values are given with --var:
+
+  starport scaffold chain github.com/org/mars --template https://github.com/org/template --var Denom=umars`,
+		Args: cobra.ExactArgs(1),
+		RunE: scaffoldChainHandler,
 	}
 
 	c.Flags().StringP(flagPath, "p", ".", "path to scaffold the chain")
 	c.Flags().String(flagAddressPrefix, "cosmos", "Address prefix")
 	c.Flags().Bool(flagNoDefaultModule, false, "Prevent scaffolding a default module in the app")
+	c.Flags().String(flagTemplate, "", "Local directory or git repository of a chain template to scaffold from")
+	c.Flags().StringArray(flagVar, nil, "Value of a template variable in the name=value format")
 
 	return c
 }
@@ -39,10 +51,28 @@ func scaffoldChainHandler(cmd *cobra.Command, args []string) error {
 		name               = args[0]
 		addressPrefix, _   = cmd.Flags().GetString(flagAddressPrefix)
 		noDefaultModule, _ = cmd.Flags().GetBool(flagNoDefaultModule)
+		template, _        = cmd.Flags().GetString(flagTemplate)
+		vars, _            = cmd.Flags().GetStringArray(flagVar)
 		appPath            = flagGetPath(cmd)
 	)
 
-	appdir, err := scaffolder.Init(placeholder.New(), appPath, name, addressPrefix, noDefaultModule)
+	var initOptions []scaffolder.InitOption
+	if template != "" {
+		values, err := parseTemplateVars(vars)
+		if err != nil {
+			return err
+		}
+
+		t, err := chaintemplate.Open(cmd.Context(), template)
+		if err != nil {
+			return err
+		}
+		defer t.Close()
+
+		initOptions = append(initOptions, scaffolder.FromTemplate(t, values))
+	}
+
+	appdir, err := scaffolder.Init(placeholder.New(), appPath, name, addressPrefix, noDefaultModule, initOptions...)
 	if err != nil {
 		return err
 	}
@@ -67,3 +97,16 @@ Documentation: https://docs.starport.network
 
 	return nil
 }
+
+// parseTemplateVars parses the values of the template variables in the name=value format.
+func parseTemplateVars(vars []string) (map[string]string, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid template variable %q, expected name=value", v)
+		}
+		values[parts[0]] = parts[1]
+	}
+	return values, nil
+}
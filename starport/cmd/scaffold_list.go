@@ -6,21 +6,29 @@ import (
 	"github.com/tendermint/starport/starport/services/scaffolder"
 )
 
+const flagStream = "stream"
+
 // NewScaffoldList returns a new command to scaffold a list.
 func NewScaffoldList() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "list NAME [field]...",
-		Short: "CRUD for data stored as an array",
-		Args:  cobra.MinimumNArgs(1),
-		RunE:  scaffoldListHandler,
+		Use:               "list NAME [field]...",
+		Short:             "CRUD for data stored as an array",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeFields,
+		RunE:              scaffoldListHandler,
 	}
 
 	flagSetPath(c)
 	c.Flags().AddFlagSet(flagSetScaffoldType())
+	c.Flags().Bool(flagStream, false, "Emit the create, update and delete events of the list to stream it over websocket")
 
 	return c
 }
 
 func scaffoldListHandler(cmd *cobra.Command, args []string) error {
-	return scaffoldType(cmd, args, scaffolder.ListType())
+	var options []scaffolder.AddTypeOption
+	if stream, _ := cmd.Flags().GetBool(flagStream); stream {
+		options = append(options, scaffolder.TypeWithStream())
+	}
+	return scaffoldType(cmd, args, scaffolder.ListType(), options...)
 }
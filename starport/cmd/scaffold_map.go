@@ -13,10 +13,11 @@ const (
 // NewScaffoldMap returns a new command to scaffold a map.
 func NewScaffoldMap() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "map NAME [field]...",
-		Short: "CRUD for data stored as key-value pairs",
-		Args:  cobra.MinimumNArgs(1),
-		RunE:  scaffoldMapHandler,
+		Use:               "map NAME [field]...",
+		Short:             "CRUD for data stored as key-value pairs",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeFields,
+		RunE:              scaffoldMapHandler,
 	}
 
 	flagSetPath(c)
@@ -10,15 +10,19 @@ import (
 	"github.com/tendermint/starport/starport/services/scaffolder"
 )
 
-const flagSigner = "signer"
+const (
+	flagSigner   = "signer"
+	flagSequence = "sequence"
+)
 
 // NewScaffoldMessage returns the command to scaffold messages
 func NewScaffoldMessage() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "message [name] [field1] [field2] ...",
-		Short: "Message to perform state transition on the blockchain",
-		Args:  cobra.MinimumNArgs(1),
-		RunE:  messageHandler,
+		Use:               "message [name] [field1] [field2] ...",
+		Short:             "Message to perform state transition on the blockchain",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeFields,
+		RunE:              messageHandler,
 	}
 
 	flagSetPath(c)
@@ -27,6 +31,8 @@ func NewScaffoldMessage() *cobra.Command {
 	c.Flags().Bool(flagNoSimulation, false, "Disable CRUD simulation scaffolding")
 	c.Flags().StringP(flagDescription, "d", "", "Description of the command")
 	c.Flags().String(flagSigner, "", "Label for the message signer (default: creator)")
+	c.Flags().String(flagSequence, "", "Sequence in the keeper that the id of the response is assigned from")
+	c.Flags().AddFlagSet(flagSetCLIFlags())
 
 	return c
 }
@@ -36,6 +42,7 @@ func messageHandler(cmd *cobra.Command, args []string) error {
 		module, _         = cmd.Flags().GetString(flagModule)
 		resFields, _      = cmd.Flags().GetStringSlice(flagResponse)
 		desc, _           = cmd.Flags().GetString(flagDescription)
+		sequence, _       = cmd.Flags().GetString(flagSequence)
 		signer            = flagGetSigner(cmd)
 		appPath           = flagGetPath(cmd)
 		withoutSimulation = flagGetNoSimulation(cmd)
@@ -61,6 +68,20 @@ func messageHandler(cmd *cobra.Command, args []string) error {
 		options = append(options, scaffolder.WithoutSimulation())
 	}
 
+	// Assign the response id from a sequence
+	if sequence != "" {
+		options = append(options, scaffolder.WithSequence(sequence))
+	}
+
+	// Read the fields from flags in the CLI command
+	cliFlags, cliDefaults, err := flagGetCLIFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if cliFlags {
+		options = append(options, scaffolder.WithCLIFlags(cliDefaults))
+	}
+
 	sc, err := newApp(appPath)
 	if err != nil {
 		return err
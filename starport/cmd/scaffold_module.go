@@ -22,6 +22,10 @@ const (
 	flagParams              = "params"
 	flagIBCOrdering         = "ordering"
 	flagRequireRegistration = "require-registration"
+	flagTransientStore      = "transient-store"
+	flagMemoryStore         = "memory-store"
+	flagCapability          = "capability"
+	flagAutoCLI             = "autocli"
 )
 
 // NewScaffoldModule returns the command to scaffold a Cosmos SDK module
@@ -40,6 +44,10 @@ func NewScaffoldModule() *cobra.Command {
 	c.Flags().String(flagIBCOrdering, "none", "channel ordering of the IBC module [none|ordered|unordered]")
 	c.Flags().Bool(flagRequireRegistration, false, "if true command will fail if module can't be registered")
 	c.Flags().StringSlice(flagParams, []string{}, "scaffold module params")
+	c.Flags().Bool(flagTransientStore, false, "scaffold a transient store reset at the end of each block")
+	c.Flags().Bool(flagMemoryStore, false, "mount the memory store of the module, kept in memory and not persisted")
+	c.Flags().Bool(flagCapability, false, "scaffold a scoped capability keeper to own object capabilities")
+	c.Flags().Bool(flagAutoCLI, false, "generate the CLI commands of the module from the methods of its services")
 
 	return c
 }
@@ -75,6 +83,38 @@ func scaffoldModuleHandler(cmd *cobra.Command, args []string) error {
 		scaffolder.WithParams(params),
 	}
 
+	transientStore, err := cmd.Flags().GetBool(flagTransientStore)
+	if err != nil {
+		return err
+	}
+	if transientStore {
+		options = append(options, scaffolder.WithTransientStore())
+	}
+
+	memoryStore, err := cmd.Flags().GetBool(flagMemoryStore)
+	if err != nil {
+		return err
+	}
+	if memoryStore {
+		options = append(options, scaffolder.WithMemoryStore())
+	}
+
+	capability, err := cmd.Flags().GetBool(flagCapability)
+	if err != nil {
+		return err
+	}
+	if capability {
+		options = append(options, scaffolder.WithCapability())
+	}
+
+	autoCLI, err := cmd.Flags().GetBool(flagAutoCLI)
+	if err != nil {
+		return err
+	}
+	if autoCLI {
+		options = append(options, scaffolder.WithAutoCLI())
+	}
+
 	// Check if the module must be an IBC module
 	if ibcModule {
 		options = append(options, scaffolder.WithIBCChannelOrdering(ibcOrdering), scaffolder.WithIBC())
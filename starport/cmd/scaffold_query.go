@@ -7,6 +7,7 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/services/scaffolder"
 )
 
 const (
@@ -16,10 +17,11 @@ const (
 // NewScaffoldQuery command creates a new type command to scaffold queries
 func NewScaffoldQuery() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "query [name] [request_field1] [request_field2] ...",
-		Short: "Query to get data from the blockchain",
-		Args:  cobra.MinimumNArgs(1),
-		RunE:  queryHandler,
+		Use:               "query [name] [request_field1] [request_field2] ...",
+		Short:             "Query to get data from the blockchain",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeFields,
+		RunE:              queryHandler,
 	}
 
 	flagSetPath(c)
@@ -27,6 +29,7 @@ func NewScaffoldQuery() *cobra.Command {
 	c.Flags().StringSliceP(flagResponse, "r", []string{}, "Response fields")
 	c.Flags().StringP(flagDescription, "d", "", "Description of the command")
 	c.Flags().Bool(flagPaginated, false, "Define if the request can be paginated")
+	c.Flags().AddFlagSet(flagSetCLIFlags())
 
 	return c
 }
@@ -64,12 +67,22 @@ func queryHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Read the request fields from flags in the CLI command
+	var options []scaffolder.QueryOption
+	cliFlags, cliDefaults, err := flagGetCLIFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if cliFlags {
+		options = append(options, scaffolder.QueryWithCLIFlags(cliDefaults))
+	}
+
 	sc, err := newApp(appPath)
 	if err != nil {
 		return err
 	}
 
-	sm, err := sc.AddQuery(cmd.Context(), placeholder.New(), module, args[0], desc, args[1:], resFields, paginated)
+	sm, err := sc.AddQuery(cmd.Context(), placeholder.New(), module, args[0], desc, args[1:], resFields, paginated, options...)
 	if err != nil {
 		return err
 	}
@@ -9,10 +9,11 @@ import (
 // NewScaffoldSingle returns a new command to scaffold a singleton.
 func NewScaffoldSingle() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "single NAME [field]...",
-		Short: "CRUD for data stored in a single location",
-		Args:  cobra.MinimumNArgs(1),
-		RunE:  scaffoldSingleHandler,
+		Use:               "single NAME [field]...",
+		Short:             "CRUD for data stored in a single location",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeFields,
+		RunE:              scaffoldSingleHandler,
 	}
 
 	flagSetPath(c)
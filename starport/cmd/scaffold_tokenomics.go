@@ -0,0 +1,74 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+const (
+	flagPreset    = "preset"
+	flagDenom     = "denom"
+	flagSupply    = "supply"
+	flagMaxSupply = "max-supply"
+)
+
+// NewScaffoldTokenomics returns a new command to configure the tokenomics of a blockchain.
+func NewScaffoldTokenomics() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "tokenomics",
+		Short: "Configure the mint and distribution params of the genesis with a preset",
+		Long: `Configure the tokenomics of the blockchain in its config file with a preset:
+
+  fixed         no coins are minted, the supply is the coins of the accounts
+  inflationary  coins are minted with an inflation between 7% and 20% that targets 67% bonded
+  capped        coins are minted with an inflation that declines from 10% to 0%, the genesis
+                supply can't exceed the max supply
+
+The params of the mint and distribution modules are set in the genesis when the chain is
+initialized. The config is validated so the coins of the accounts add up to the supply and the
+faucet account holds the coins distributed by the faucet. The values of the config are kept as
+is but comments are not.`,
+		Example: `  starport scaffold tokenomics --preset fixed
+  starport scaffold tokenomics --preset capped --max-supply 200000000stake`,
+		Args: cobra.NoArgs,
+		RunE: scaffoldTokenomicsHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().StringP(flagConfig, "c", "", "Starport config file (default: ./config.yml)")
+	c.Flags().String(flagPreset, "", "Preset of the tokenomics: fixed, inflationary or capped")
+	c.Flags().String(flagDenom, "", "Denom minted by the chain (default: the denom of the first validator's bonded coins)")
+	c.Flags().String(flagSupply, "", "Supply of the genesis, e.g. 1000000stake (default: the coins of the accounts)")
+	c.Flags().String(flagMaxSupply, "", "Max supply of the capped preset, e.g. 2000000stake")
+
+	return c
+}
+
+func scaffoldTokenomicsHandler(cmd *cobra.Command, args []string) error {
+	var (
+		preset, _    = cmd.Flags().GetString(flagPreset)
+		denom, _     = cmd.Flags().GetString(flagDenom)
+		supply, _    = cmd.Flags().GetString(flagSupply)
+		maxSupply, _ = cmd.Flags().GetString(flagMaxSupply)
+	)
+
+	path, err := chainConfigPath(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := chainconfig.SetTokenomicsFile(path, chainconfig.Tokenomics{
+		Preset:    preset,
+		Denom:     denom,
+		Supply:    supply,
+		MaxSupply: maxSupply,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🎉 Configured the %s tokenomics in %s.\n\n", preset, path)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	starportcmd "github.com/tendermint/starport/starport/cmd"
 	"github.com/tendermint/starport/starport/pkg/clictx"
@@ -14,7 +15,10 @@ import (
 func main() {
 	ctx := clictx.From(context.Background())
 
-	err := starportcmd.New(ctx).ExecuteContext(ctx)
+	start := time.Now()
+	cmd, err := starportcmd.New(ctx).ExecuteContextC(ctx)
+	starportcmd.RecordTelemetry(cmd, time.Since(start), err)
+	starportcmd.AutoGC(cmd)
 
 	if ctx.Err() == context.Canceled || err == context.Canceled {
 		fmt.Println("aborted")
@@ -0,0 +1,165 @@
+package starportcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/tendermint/starport/starport/internal/telemetry"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+)
+
+// telemetryFlushTimeout is the time given to send the spooled events after a command, the events
+// that are not sent in time are sent by the next commands.
+const telemetryFlushTimeout = time.Second
+
+// telemetryStatusOutput is the telemetry status as printed in the JSON and YAML formats.
+type telemetryStatusOutput struct {
+	Enabled   bool   `json:"enabled"`
+	ID        string `json:"id,omitempty"`
+	Spooled   int    `json:"spooled"`
+	SpoolPath string `json:"spool_path"`
+}
+
+// NewTelemetry returns a command that groups the sub commands to opt in and out of the telemetry.
+func NewTelemetry() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "telemetry [command]",
+		Short: "Opt in or out of the anonymized usage metrics",
+		Long: `Opt in or out of the anonymized usage metrics.
+
+The telemetry is disabled until you opt in with "starport telemetry on". Once enabled, the name of
+the command, the names of the flags that are set, the duration and the category of the error of
+each command are recorded. The arguments and the values of the flags are never recorded, and
+the URLs, addresses, hosts and paths are removed from the error messages.
+
+The metrics are spooled locally before they are sent, the spool can be inspected at the path
+printed by "starport telemetry status".`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(
+		NewTelemetryOn(),
+		NewTelemetryOff(),
+		NewTelemetryStatus(),
+	)
+
+	return c
+}
+
+// NewTelemetryOn returns a command that opts in to the telemetry.
+func NewTelemetryOn() *cobra.Command {
+	return &cobra.Command{
+		Use:   "on",
+		Short: "Opt in to the anonymized usage metrics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := telemetry.Default()
+			if err != nil {
+				return err
+			}
+			if _, err := t.Enable(); err != nil {
+				return err
+			}
+			fmt.Printf("%s Telemetry enabled, thank you for helping us improve Starport\n", clispinner.OK)
+			return nil
+		},
+	}
+}
+
+// NewTelemetryOff returns a command that opts out of the telemetry.
+func NewTelemetryOff() *cobra.Command {
+	return &cobra.Command{
+		Use:   "off",
+		Short: "Opt out of the anonymized usage metrics and remove the spooled metrics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := telemetry.Default()
+			if err != nil {
+				return err
+			}
+			if err := t.Disable(); err != nil {
+				return err
+			}
+			fmt.Printf("%s Telemetry disabled\n", clispinner.OK)
+			return nil
+		},
+	}
+}
+
+// NewTelemetryStatus returns a command that shows whether the telemetry is enabled.
+func NewTelemetryStatus() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the anonymized usage metrics are enabled",
+		Args:  cobra.NoArgs,
+		RunE:  telemetryStatusHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func telemetryStatusHandler(cmd *cobra.Command, args []string) error {
+	t, err := telemetry.Default()
+	if err != nil {
+		return err
+	}
+	c, err := t.Config()
+	if err != nil {
+		return err
+	}
+	events, err := t.Spooled()
+	if err != nil {
+		return err
+	}
+
+	status := telemetryStatusOutput{
+		Enabled:   c.Enabled,
+		ID:        c.ID,
+		Spooled:   len(events),
+		SpoolPath: t.SpoolPath(),
+	}
+	return printOutput(cmd, status, func(out io.Writer) error {
+		state := "disabled"
+		if status.Enabled {
+			state = "enabled"
+		}
+		_, err := fmt.Fprintf(out, "Telemetry: %s\nSpooled metrics: %d (%s)\n", state, status.Spooled, status.SpoolPath)
+		return err
+	})
+}
+
+// RecordTelemetry records the run of cmd when the telemetry is enabled and sends the spooled
+// events. the telemetry never fails the commands, so its errors are ignored.
+func RecordTelemetry(cmd *cobra.Command, duration time.Duration, err error) {
+	if cmd == nil || isCompletionRequest() {
+		return
+	}
+
+	t, terr := telemetry.Default()
+	if terr != nil {
+		return
+	}
+
+	var flags []string
+	cmd.Flags().Visit(func(f *flag.Flag) {
+		flags = append(flags, f.Name)
+	})
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+
+	if terr := t.Record(telemetry.NewEvent(command, flags, duration, err)); terr != nil {
+		return
+	}
+
+	// the context of the command can be canceled already, e.g. by an interrupt.
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryFlushTimeout)
+	defer cancel()
+	_ = t.Flush(ctx)
+}
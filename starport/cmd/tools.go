@@ -99,6 +99,9 @@ func NewToolsCompletions() *cobra.Command {
 				system. Since most Unix-like operating systems come with bash-completion by default, bash-completion 
 				is probably already installed and operational.
 
+Besides the commands and the flags, the launch IDs and the campaign IDs of SPN, the account names of
+the keyring, and the modules and the field types scaffolded in the app are completed.
+
 Bash:
 
   $ source <(starport  tools completions bash)
@@ -147,7 +150,7 @@ PowerShell:
 		Run: func(cmd *cobra.Command, args []string) {
 			switch args[0] {
 			case "bash":
-				cmd.Root().GenBashCompletion(os.Stdout)
+				cmd.Root().GenBashCompletionV2(os.Stdout, true)
 			case "zsh":
 				cmd.Root().GenZshCompletion(os.Stdout)
 			case "fish":
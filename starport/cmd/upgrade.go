@@ -0,0 +1,95 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/internal/selfupdate"
+	"github.com/tendermint/starport/starport/internal/version"
+	"github.com/tendermint/starport/starport/pkg/cliquiz"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+)
+
+const flagForce = "force"
+
+// NewUpgrade returns a command that replaces the running Starport with its latest release.
+func NewUpgrade() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade Starport to its latest release",
+		Long: `Upgrade Starport to its latest release.
+
+The archive of the release for your platform is downloaded, its checksum is verified with the
+checksums of the release, and the running binary is replaced with the binary of the archive.
+The signature of the checksums is verified too for the builds with a signing key.
+
+Upgrade to the latest build of the development branch with --channel nightly.`,
+		Args: cobra.NoArgs,
+		RunE: upgradeHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetChannel())
+	c.Flags().Bool(flagForce, false, "Replace the binary even when it's not older than the release")
+
+	return c
+}
+
+func upgradeHandler(cmd *cobra.Command, _ []string) error {
+	force, _ := cmd.Flags().GetBool(flagForce)
+
+	channel, err := getChannel(cmd)
+	if err != nil {
+		return err
+	}
+
+	s := clispinner.New().SetText("Checking the latest release...").Start()
+	defer s.Stop()
+
+	release, err := version.LatestRelease(cmd.Context(), channel)
+	if err != nil {
+		return err
+	}
+
+	isNewer, err := release.IsNewer()
+	if err != nil {
+		return err
+	}
+	if !isNewer && !force {
+		s.Stop()
+		if version.IsDevelopment() {
+			return fmt.Errorf("you are running a development build, use --%s to replace it with %s", flagForce, release.Tag)
+		}
+		fmt.Printf("%s Starport %s is the latest %s release\n", clispinner.OK, version.Version, channel)
+		return nil
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		return err
+	}
+
+	s.Stop()
+	ok, err := cliquiz.Confirm(fmt.Sprintf("Replace %s (%s) with Starport %s", path, version.Version, release.Tag))
+	if errors.Is(err, cliquiz.ErrInputRequired) {
+		return fmt.Errorf("%w, use --%s to upgrade", err, flagYes)
+	}
+	if err != nil || !ok {
+		return err
+	}
+
+	s.SetText(fmt.Sprintf("Upgrading to %s...", release.Tag)).Start()
+	if err := selfupdate.Update(cmd.Context(), release, path); err != nil {
+		return err
+	}
+	s.Stop()
+
+	fmt.Printf("%s Starport upgraded to %s\n", clispinner.OK, release.Tag)
+	return nil
+}
@@ -4,8 +4,15 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/tendermint/starport/starport/internal/version"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+)
+
+const (
+	flagCheck   = "check"
+	flagChannel = "channel"
 )
 
 // NewVersion creates a new version command to show starport's version.
@@ -13,9 +20,61 @@ func NewVersion() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "version",
 		Short: "Print the current build information",
-		Run: func(cmd *cobra.Command, _ []string) {
-			fmt.Println(version.Long(cmd.Context()))
-		},
+		RunE:  versionHandler,
 	}
+
+	c.Flags().Bool(flagCheck, false, "Check whether a newer version is released")
+	c.Flags().AddFlagSet(flagSetChannel())
+
 	return c
 }
+
+func versionHandler(cmd *cobra.Command, _ []string) error {
+	if check, _ := cmd.Flags().GetBool(flagCheck); !check {
+		fmt.Println(version.Long(cmd.Context()))
+		return nil
+	}
+
+	channel, err := getChannel(cmd)
+	if err != nil {
+		return err
+	}
+
+	s := clispinner.New().SetText("Checking the latest release...").Start()
+	release, err := version.LatestRelease(cmd.Context(), channel)
+	s.Stop()
+	if err != nil {
+		return err
+	}
+
+	isNewer, err := release.IsNewer()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case version.IsDevelopment():
+		fmt.Printf("%s You are running a development build, the latest %s release is %s\n", clispinner.Bullet, channel, release.Tag)
+	case isNewer:
+		fmt.Printf("%s Starport %s is available, you are running %s. Upgrade with \"starport upgrade --channel %s\"\n",
+			clispinner.Bullet,
+			release.Tag,
+			version.Version,
+			channel,
+		)
+	default:
+		fmt.Printf("%s Starport %s is the latest %s release\n", clispinner.OK, version.Version, channel)
+	}
+	return nil
+}
+
+func flagSetChannel() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagChannel, string(version.ChannelStable), fmt.Sprintf("Release channel (%s or %s)", version.ChannelStable, version.ChannelNightly))
+	return fs
+}
+
+func getChannel(cmd *cobra.Command) (version.Channel, error) {
+	channel, _ := cmd.Flags().GetString(flagChannel)
+	return version.ParseChannel(channel)
+}
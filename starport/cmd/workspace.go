@@ -0,0 +1,112 @@
+package starportcmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+	"github.com/tendermint/starport/starport/pkg/workspace"
+)
+
+const flagWorkspaceChain = "chain"
+
+var workspaceChainsHeader = []string{"name", "path", "config"}
+
+// workspaceChainOutput is a chain of a workspace as printed in the JSON and YAML formats.
+type workspaceChainOutput struct {
+	Name          string `json:"name"`
+	Path          string `json:"path"`
+	Config        string `json:"config,omitempty"`
+	AddressPrefix string `json:"address_prefix"`
+	GasPrice      string `json:"gas_price"`
+}
+
+// NewWorkspace returns a command that groups the commands of the multi-chain workspaces.
+func NewWorkspace() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "workspace",
+		Short: "Develop multiple chains in a workspace",
+		Long: `A workspace lists the chains of a repository in a starport.work.yml file at its root:
+
+chains:
+  - name: mars
+    path: mars
+  - name: venus
+    path: venus
+    config: config.yml
+relayer:
+  paths:
+    - source: mars
+      target: venus
+
+The chains of a workspace are built and served with "starport chain build --chain <name>" and
+"starport chain serve --chain <name>", and served together with the relayer connecting them with
+"starport workspace serve". The chains of a workspace must use different hosts in their configs.
+
+Set up and serve two chains connected with a transfer channel with "starport workspace dev-ibc".`,
+	}
+
+	c.AddCommand(NewWorkspaceList())
+	c.AddCommand(NewWorkspaceServe())
+	c.AddCommand(NewWorkspaceDevIBC())
+
+	return c
+}
+
+// NewWorkspaceList returns a command that lists the chains of the workspace.
+func NewWorkspaceList() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List the chains of the workspace",
+		Args:  cobra.NoArgs,
+		RunE:  workspaceListHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetOutputFormat())
+
+	return c
+}
+
+func workspaceListHandler(cmd *cobra.Command, args []string) error {
+	w, err := workspace.Open(flagGetPath(cmd))
+	if err != nil {
+		return err
+	}
+
+	outputs := make([]workspaceChainOutput, 0, len(w.Chains))
+	for _, c := range w.Chains {
+		outputs = append(outputs, workspaceChainOutput{
+			Name:          c.Name,
+			Path:          w.ChainPath(c),
+			Config:        w.ChainConfigPath(c),
+			AddressPrefix: c.AddressPrefix,
+			GasPrice:      c.GasPrice,
+		})
+	}
+
+	return printOutput(cmd, outputs, func(out io.Writer) error {
+		var entries [][]string
+		for _, c := range outputs {
+			config := c.Config
+			if config == "" {
+				config = "-"
+			}
+			entries = append(entries, []string{c.Name, c.Path, config})
+		}
+		return entrywriter.MustWrite(out, workspaceChainsHeader, entries...)
+	})
+}
+
+func flagSetWorkspaceChain() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagWorkspaceChain, "", "Name of the chain in the workspace found from the path")
+	return fs
+}
+
+func getWorkspaceChain(cmd *cobra.Command) (name string) {
+	name, _ = cmd.Flags().GetString(flagWorkspaceChain)
+	return
+}
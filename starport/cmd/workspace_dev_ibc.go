@@ -0,0 +1,128 @@
+package starportcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/gomodulepath"
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/pkg/workspace"
+	"github.com/tendermint/starport/starport/services/scaffolder"
+)
+
+// devIBCPortsStep is the difference between the ports of the servers of the chains scaffolded for
+// the IBC development environment.
+const devIBCPortsStep = 1000
+
+var devIBCDefaultChains = []string{"mars", "venus"}
+
+// NewWorkspaceDevIBC returns a command that sets up and serves two chains connected by the relayer.
+func NewWorkspaceDevIBC() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "dev-ibc [github.com/org/chain-a] [github.com/org/chain-b]",
+		Short: "Serve two local chains connected with a transfer channel for IBC development",
+		Long: `Serve two local chains connected by the relayer with a transfer channel.
+
+The chains are scaffolded in the path when they don't exist, the second one with the ports of its
+servers and faucet shifted so both chains can run on the same computer, and a workspace file
+listing them is created. The chains are named mars and venus when no name is given.
+
+The relayer uses the default account, it receives tokens from the faucets of both chains and the
+accounts of the chains' configs are funded in their genesis. Run the command again to serve the
+chains of the previous run.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 2 {
+				return errors.New("requires no chain or two chains")
+			}
+			return nil
+		},
+		RunE: workspaceDevIBCHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().BoolP("verbose", "v", false, "Verbose output")
+	c.Flags().BoolP(flagResetOnce, "r", false, "Reset of the app state of the chains on first start")
+
+	return c
+}
+
+func workspaceDevIBCHandler(cmd *cobra.Command, args []string) error {
+	modulePaths := args
+	if len(modulePaths) == 0 {
+		modulePaths = devIBCDefaultChains
+	}
+
+	dir, err := filepath.Abs(flagGetPath(cmd))
+	if err != nil {
+		return err
+	}
+
+	s := clispinner.New().Stop()
+	defer s.Stop()
+
+	var (
+		names []string
+		w     workspace.Workspace
+	)
+	for i, modulePath := range modulePaths {
+		pathInfo, err := gomodulepath.Parse(modulePath)
+		if err != nil {
+			return err
+		}
+		names = append(names, pathInfo.Root)
+		w.Chains = append(w.Chains, workspace.Chain{
+			Name: pathInfo.Root,
+			Path: pathInfo.Root,
+		})
+
+		if _, err := os.Stat(filepath.Join(dir, pathInfo.Root)); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+
+		s.SetText(fmt.Sprintf("Scaffolding %s...", pathInfo.Root)).Start()
+
+		appPath, err := scaffolder.Init(placeholder.New(), dir, modulePath, "cosmos", false)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			configPath, err := chainconfig.LocateDefault(appPath)
+			if err != nil {
+				return err
+			}
+			if err := chainconfig.ShiftPortsFile(configPath, i*devIBCPortsStep); err != nil {
+				return err
+			}
+		}
+
+		s.Stop()
+		fmt.Printf("%s Scaffolded %s\n", clispinner.OK, appPath)
+	}
+
+	switch _, err := os.Stat(filepath.Join(dir, workspace.File)); {
+	case err == nil:
+		if w, err = workspace.Parse(filepath.Join(dir, workspace.File)); err != nil {
+			return err
+		}
+	case os.IsNotExist(err):
+		w.Relayer.Paths = []workspace.RelayerPath{{Source: names[0], Target: names[1]}}
+		if w, err = workspace.Save(dir, w); err != nil {
+			return err
+		}
+		fmt.Printf("%s Workspace file created at %s\n", clispinner.OK, filepath.Join(dir, workspace.File))
+	default:
+		return err
+	}
+
+	return serveWorkspace(cmd, w, names...)
+}
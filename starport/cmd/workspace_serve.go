@@ -0,0 +1,270 @@
+package starportcmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/relayer"
+	"github.com/tendermint/starport/starport/pkg/workspace"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+const (
+	flagNoRelayer = "no-relayer"
+
+	// workspaceChainPollInterval is the interval between the checks of a served chain producing
+	// its first block.
+	workspaceChainPollInterval = time.Second
+)
+
+// NewWorkspaceServe returns a command that serves the chains of the workspace and the relayer
+// between them.
+func NewWorkspaceServe() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "serve [chain...]",
+		Short: "Serve the chains of the workspace and relay packets between them",
+		Long: `Serve the chains of the workspace, or only the given chains, with automatic reloading.
+
+Once the chains produce their first blocks, the relayer paths of the workspace between the served
+chains are connected with the default account and the relayer starts relaying packets.`,
+		RunE: workspaceServeHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().BoolP("verbose", "v", false, "Verbose output")
+	c.Flags().BoolP(flagResetOnce, "r", false, "Reset of the app state of the chains on first start")
+	c.Flags().Bool(flagNoRelayer, false, "Serve the chains without the relayer")
+
+	return c
+}
+
+// workspaceChain is a chain of the workspace that is served.
+type workspaceChain struct {
+	workspace.Chain
+	chain *chain.Chain
+}
+
+func workspaceServeHandler(cmd *cobra.Command, args []string) error {
+	w, err := workspace.Open(flagGetPath(cmd))
+	if err != nil {
+		return err
+	}
+
+	return serveWorkspace(cmd, w, args...)
+}
+
+// serveWorkspace serves the chains of the workspace with the names, or all of its chains, and the
+// relayer between them.
+func serveWorkspace(cmd *cobra.Command, w workspace.Workspace, names ...string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	var (
+		resetOnce, _ = cmd.Flags().GetBool(flagResetOnce)
+		noRelayer, _ = cmd.Flags().GetBool(flagNoRelayer)
+	)
+
+	if len(names) == 0 {
+		for _, c := range w.Chains {
+			names = append(names, c.Name)
+		}
+	}
+
+	var (
+		wg sync.WaitGroup
+		ev = events.NewBus()
+	)
+	wg.Add(1)
+	go printLogEvents(&wg, ev)
+	defer wg.Wait()
+	defer ev.Shutdown()
+
+	chains := make(map[string]workspaceChain, len(names))
+	for _, name := range names {
+		wc, err := w.Chain(name)
+		if err != nil {
+			return err
+		}
+
+		chainOption := []chain.Option{
+			chain.LogLevel(logLevel(cmd)),
+			chain.CollectEvents(ev),
+		}
+		if config := w.ChainConfigPath(wc); config != "" {
+			chainOption = append(chainOption, chain.ConfigFile(config))
+		}
+
+		c, err := chain.New(w.ChainPath(wc), chainOption...)
+		if err != nil {
+			return err
+		}
+		chains[name] = workspaceChain{wc, c}
+	}
+
+	var serveOptions []chain.ServeOption
+	if resetOnce {
+		serveOptions = append(serveOptions, chain.ServeResetOnce())
+	}
+
+	g, ctx := errgroup.WithContext(cmd.Context())
+	for _, c := range chains {
+		c := c
+		g.Go(func() error {
+			if err := c.chain.Serve(ctx, serveOptions...); err != nil {
+				return fmt.Errorf("%s: %w", c.Name, err)
+			}
+			return nil
+		})
+	}
+
+	// relay only between the served chains.
+	var paths []workspace.RelayerPath
+	for _, p := range w.Relayer.Paths {
+		_, okSource := chains[p.Source]
+		_, okTarget := chains[p.Target]
+		if okSource && okTarget {
+			paths = append(paths, p)
+		}
+	}
+
+	if !noRelayer && len(paths) > 0 {
+		g.Go(func() error {
+			return relayWorkspace(ctx, cmd, chains, paths)
+		})
+	}
+
+	return g.Wait()
+}
+
+// relayWorkspace connects the relayer paths between the chains once they are up and relays
+// packets between them.
+func relayWorkspace(
+	ctx context.Context,
+	cmd *cobra.Command,
+	chains map[string]workspaceChain,
+	paths []workspace.RelayerPath,
+) error {
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := ca.EnsureDefaultAccount(); err != nil {
+		return err
+	}
+
+	r := relayer.New(ca)
+	s := clispinner.New().Stop()
+	defer s.Stop()
+
+	relayerChains := make(map[string]*relayer.Chain)
+	initRelayerChain := func(name string) (*relayer.Chain, error) {
+		if c, ok := relayerChains[name]; ok {
+			return c, nil
+		}
+
+		wc := chains[name]
+		conf, err := wc.chain.Config()
+		if err != nil {
+			return nil, err
+		}
+		rpcAddress, err := wc.chain.RPCPublicAddress()
+		if err != nil {
+			return nil, err
+		}
+		if err := waitForChain(ctx, xurl.HTTP(rpcAddress)); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		var faucetAddress string
+		if conf.Faucet.Name != nil {
+			faucetAddress = xurl.HTTP(chainconfig.FaucetHost(conf))
+		}
+
+		c, err := initChain(
+			cmd,
+			r,
+			s,
+			name,
+			cosmosaccount.DefaultAccount,
+			xurl.HTTP(rpcAddress),
+			faucetAddress,
+			wc.GasPrice,
+			defautSourceGasLimit,
+			wc.AddressPrefix,
+		)
+		if err != nil {
+			return nil, err
+		}
+		relayerChains[name] = c
+		return c, nil
+	}
+
+	var ids []string
+	for _, p := range paths {
+		source, err := initRelayerChain(p.Source)
+		if err != nil {
+			return err
+		}
+		target, err := initRelayerChain(p.Target)
+		if err != nil {
+			return err
+		}
+
+		id, err := source.Connect(ctx, target)
+		if err != nil {
+			return fmt.Errorf("cannot connect %s to %s: %w", p.Source, p.Target, err)
+		}
+		ids = append(ids, id)
+	}
+
+	s.SetText("Creating links between chains...").Start()
+
+	if err := r.Link(ctx, ids...); err != nil {
+		return err
+	}
+
+	s.Stop()
+
+	printSection("Listening and relaying packets between chains...")
+
+	return r.Start(ctx, ids...)
+}
+
+// waitForChain waits until the chain with the RPC address produces its first block.
+func waitForChain(ctx context.Context, rpcAddress string) error {
+	client, err := rpchttp.New(rpcAddress, "/websocket")
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(workspaceChainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if status, err := client.Status(ctx); err == nil && status.SyncInfo.LatestBlockHeight > 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Package selfupdate replaces the running Starport binary with the binary of a release after
+// verifying its checksum and, when a signing key is set, the signature of the checksums.
+package selfupdate
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/tendermint/starport/starport/internal/version"
+)
+
+// SigningKey is the base64 encoded ed25519 public key the checksums of the releases are signed
+// with, it's set at build time for the releases. the signature is required once it's set.
+var SigningKey = ""
+
+const (
+	checksumsAsset = "checksums.txt"
+	signatureAsset = "checksums.txt.sig"
+	binaryName     = "starport"
+)
+
+var (
+	// ErrNoArchive is returned when the release doesn't have an archive for the platform.
+	ErrNoArchive = fmt.Errorf("the release doesn't have an archive for %s/%s", runtime.GOOS, runtime.GOARCH)
+
+	// ErrChecksumMismatch is returned when the checksum of the downloaded archive is not the one of the release.
+	ErrChecksumMismatch = errors.New("the checksum of the archive doesn't match the checksum of the release")
+
+	// ErrInvalidSignature is returned when the signature of the checksums is invalid.
+	ErrInvalidSignature = errors.New("invalid signature of the checksums of the release")
+)
+
+// ArchiveName returns the name of the archive of the release for the platform, the archives are
+// named <binary>_<version>_<os>_<arch>.tar.gz.
+func ArchiveName(r version.Release) (string, error) {
+	suffix := fmt.Sprintf("_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	for name := range r.Assets {
+		if strings.HasPrefix(name, binaryName+"_") && strings.HasSuffix(name, suffix) {
+			return name, nil
+		}
+	}
+	return "", ErrNoArchive
+}
+
+// Update downloads the binary of the release, verifies it and replaces the binary at path with it.
+func Update(ctx context.Context, r version.Release, path string) error {
+	archiveName, err := ArchiveName(r)
+	if err != nil {
+		return err
+	}
+
+	checksums, err := download(ctx, r, checksumsAsset)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(ctx, r, checksums); err != nil {
+		return err
+	}
+
+	archive, err := download(ctx, r, archiveName)
+	if err != nil {
+		return err
+	}
+	if err := VerifyChecksum(checksums, archiveName, archive); err != nil {
+		return err
+	}
+
+	binary, err := ExtractBinary(archive)
+	if err != nil {
+		return err
+	}
+	return replace(path, binary)
+}
+
+func verifySignature(ctx context.Context, r version.Release, checksums []byte) error {
+	if SigningKey == "" {
+		return nil
+	}
+	signature, err := download(ctx, r, signatureAsset)
+	if err != nil {
+		return err
+	}
+	return VerifySignature(SigningKey, checksums, signature)
+}
+
+// VerifySignature verifies the base64 encoded ed25519 signature of the checksums with the base64
+// encoded public key.
+func VerifySignature(key string, checksums, signature []byte) error {
+	pub, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid signing key %q", key)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), checksums, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyChecksum verifies the sha256 checksum of the archive with the checksums of the release,
+// each line of checksums is a checksum followed by the name of its file.
+func VerifyChecksum(checksums []byte, name string, archive []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		sum := sha256.Sum256(archive)
+		if hex.EncodeToString(sum[:]) != strings.ToLower(fields[0]) {
+			return ErrChecksumMismatch
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("no checksum for %s in the checksums of the release", name)
+}
+
+// ExtractBinary extracts the Starport binary from a tar.gz archive.
+func ExtractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	name := binaryName
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("the archive doesn't contain the %s binary", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag == tar.TypeReg && filepath.Base(h.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replace replaces the binary at path, the new binary is written next to it and renamed over it so
+// the binary is never left half written.
+func replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".new"
+	if err := os.WriteFile(tmp, binary, info.Mode().Perm()|0111); err != nil {
+		return err
+	}
+
+	// the running binary can't be overwritten on Windows but it can be renamed.
+	old := path + ".old"
+	if runtime.GOOS == "windows" {
+		if err := os.Rename(path, old); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	os.Remove(old)
+	return nil
+}
+
+func download(ctx context.Context, r version.Release, name string) ([]byte, error) {
+	url, ok := r.Assets[name]
+	if !ok {
+		return nil, fmt.Errorf("the release %s doesn't have %s", r.Tag, name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot download %s: %s", name, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
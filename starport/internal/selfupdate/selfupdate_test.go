@@ -0,0 +1,152 @@
+package selfupdate_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/internal/selfupdate"
+	"github.com/tendermint/starport/starport/internal/version"
+)
+
+func newArchive(t *testing.T, binary []byte) []byte {
+	name := "starport"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "readme.md", Mode: 0644, Size: 2, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("hi"))
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(binary)), Typeflag: tar.TypeReg}))
+	_, err = tw.Write(binary)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func newRelease(t *testing.T, files map[string][]byte) version.Release {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[filepath.Base(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	release := version.Release{Tag: "v0.20.0", Assets: make(map[string]string)}
+	for name := range files {
+		release.Assets[name] = server.URL + "/" + name
+	}
+	return release
+}
+
+func TestUpdate(t *testing.T) {
+	archiveName := fmt.Sprintf("starport_0.20.0_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	archive := newArchive(t, []byte("new binary"))
+	sum := sha256.Sum256(archive)
+	checksums := []byte(fmt.Sprintf("%x  %s\n", sum, archiveName))
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, checksums)))
+
+	selfupdate.SigningKey = base64.StdEncoding.EncodeToString(pub)
+	defer func() { selfupdate.SigningKey = "" }()
+
+	path := filepath.Join(t.TempDir(), "starport")
+	require.NoError(t, os.WriteFile(path, []byte("old binary"), 0755))
+
+	release := newRelease(t, map[string][]byte{
+		archiveName:           archive,
+		"checksums.txt":       checksums,
+		"checksums.txt.sig":   signature,
+		"starport_0.20.0.txt": []byte("other asset"),
+	})
+	require.NoError(t, selfupdate.Update(context.Background(), release, path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "new binary", string(data))
+}
+
+func TestUpdateInvalid(t *testing.T) {
+	archiveName := fmt.Sprintf("starport_0.20.0_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	archive := newArchive(t, []byte("new binary"))
+	sum := sha256.Sum256(archive)
+	checksums := []byte(fmt.Sprintf("%x  %s\n", sum, archiveName))
+
+	pub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		files      map[string][]byte
+		signingKey string
+		err        error
+	}{
+		{
+			name: "checksum mismatch",
+			files: map[string][]byte{
+				archiveName:     archive,
+				"checksums.txt": []byte(fmt.Sprintf("%x  %s\n", sha256.Sum256([]byte("other")), archiveName)),
+			},
+			err: selfupdate.ErrChecksumMismatch,
+		},
+		{
+			name: "invalid signature",
+			files: map[string][]byte{
+				archiveName:         archive,
+				"checksums.txt":     checksums,
+				"checksums.txt.sig": []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(otherPriv, checksums))),
+			},
+			signingKey: base64.StdEncoding.EncodeToString(pub),
+			err:        selfupdate.ErrInvalidSignature,
+		},
+		{
+			name: "no archive for the platform",
+			files: map[string][]byte{
+				"starport_0.20.0_plan9_mips.tar.gz": archive,
+				"checksums.txt":                     checksums,
+			},
+			err: selfupdate.ErrNoArchive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selfupdate.SigningKey = tt.signingKey
+			defer func() { selfupdate.SigningKey = "" }()
+
+			path := filepath.Join(t.TempDir(), "starport")
+			require.NoError(t, os.WriteFile(path, []byte("old binary"), 0755))
+
+			err := selfupdate.Update(context.Background(), newRelease(t, tt.files), path)
+			require.ErrorIs(t, err, tt.err)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, "old binary", string(data))
+		})
+	}
+}
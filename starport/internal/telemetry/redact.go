@@ -0,0 +1,107 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/cliquiz"
+	"github.com/tendermint/starport/starport/pkg/validation"
+)
+
+// maxErrorLength is the length the redacted error messages are truncated to.
+const maxErrorLength = 200
+
+// the error categories.
+const (
+	CategoryCanceled   = "canceled"
+	CategoryTimeout    = "timeout"
+	CategoryValidation = "validation"
+	CategoryInput      = "input"
+	CategoryNetwork    = "network"
+	CategoryFilesystem = "filesystem"
+	CategoryExec       = "exec"
+	CategoryOther      = "other"
+)
+
+// redactions are applied in order, the URLs and the peers are replaced before the hosts and the
+// paths they contain.
+var redactions = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`), "<url>"},
+	{regexp.MustCompile(`[0-9a-fA-F]{40}@\S+`), "<peer>"},
+	{regexp.MustCompile(`[\w.+-]+@[\w-]+(\.[\w-]+)+`), "<email>"},
+	{regexp.MustCompile(`\b0x[0-9a-fA-F]{40}\b`), "<address>"},
+	{regexp.MustCompile(`\b[a-z]{1,83}1[02-9ac-hj-np-z]{38,}\b`), "<address>"},
+	{regexp.MustCompile(`\b[0-9a-fA-F]{32,}\b`), "<hex>"},
+	{regexp.MustCompile(`\b(\d{1,3}\.){3}\d{1,3}(:\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`\b[\w-]+(\.[\w-]+)+/\S*`), "<url>"},
+	{regexp.MustCompile(`\b(localhost|[\w-]+(\.[\w-]+)+):\d+\b`), "<host>"},
+	{regexp.MustCompile(`(~|\.{1,2})?/[^\s"':,]+`), "<path>"},
+}
+
+// Redact removes the URLs, the addresses, the hosts and the paths from s.
+func Redact(s string) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" && home != "/" {
+		s = strings.ReplaceAll(s, home, "~")
+	}
+	for _, r := range redactions {
+		s = r.re.ReplaceAllString(s, r.replacement)
+	}
+	return s
+}
+
+// RedactError returns the redacted first line of the message of err.
+func RedactError(err error) string {
+	message := err.Error()
+	if i := strings.IndexByte(message, '\n'); i != -1 {
+		message = message[:i]
+	}
+	message = Redact(message)
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	return message
+}
+
+// Category returns the category of err, it's empty when err is nil.
+func Category(err error) string {
+	var (
+		validationErr validation.Error
+		netErr        net.Error
+		pathErr       *fs.PathError
+		exitErr       *exec.ExitError
+	)
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.Canceled):
+		return CategoryCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return CategoryTimeout
+	case errors.As(err, &validationErr):
+		return CategoryValidation
+	case errors.Is(err, cliquiz.ErrInputRequired):
+		return CategoryInput
+	// the path errors are checked first because they implement net.Error too.
+	case errors.As(err, &pathErr), errors.Is(err, fs.ErrNotExist):
+		return CategoryFilesystem
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return CategoryTimeout
+		}
+		return CategoryNetwork
+	case errors.As(err, &exitErr):
+		return CategoryExec
+	default:
+		return CategoryOther
+	}
+}
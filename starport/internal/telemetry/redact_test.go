@@ -0,0 +1,87 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/internal/telemetry"
+	"github.com/tendermint/starport/starport/pkg/cliquiz"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "url",
+			s:    `Get "https://rpc.example.com:26657/status?height=1": EOF`,
+			want: `Get "<url> EOF`,
+		},
+		{
+			name: "bech32 address",
+			s:    "account cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj not found",
+			want: "account <address> not found",
+		},
+		{
+			name: "hex address",
+			s:    "invalid address 0x52908400098527886E0F7030069857D2E4169EE7",
+			want: "invalid address <address>",
+		},
+		{
+			name: "peer",
+			s:    "cannot reach 8c2e28a1ba1a7d33d6bb4d3a95dfd67b2b7a6e51@192.168.0.1:26656",
+			want: "cannot reach <peer>",
+		},
+		{
+			name: "ip and host",
+			s:    "dial tcp 10.0.0.1:1317 and node.example.com:9090: connection refused",
+			want: "dial tcp <ip> and <host>: connection refused",
+		},
+		{
+			name: "repository",
+			s:    "cannot clone github.com/cosmonaut/mars",
+			want: "cannot clone <url>",
+		},
+		{
+			name: "path",
+			s:    "open /etc/mars/config.yml: permission denied",
+			want: "open <path>: permission denied",
+		},
+		{
+			name: "nothing to redact",
+			s:    "the module bank doesn't exist",
+			want: "the module bank doesn't exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, telemetry.Redact(tt.s))
+		})
+	}
+}
+
+func TestCategory(t *testing.T) {
+	_, pathErr := os.Open("/does/not/exist")
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{fmt.Errorf("serve: %w", context.Canceled), telemetry.CategoryCanceled},
+		{context.DeadlineExceeded, telemetry.CategoryTimeout},
+		{fmt.Errorf("%w: Mnemonic", cliquiz.ErrInputRequired), telemetry.CategoryInput},
+		{pathErr, telemetry.CategoryFilesystem},
+		{errors.New("unknown"), telemetry.CategoryOther},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, telemetry.Category(tt.err))
+	}
+}
@@ -0,0 +1,271 @@
+// Package telemetry records anonymized metrics of the usage of the commands once the users opt in
+// with "starport telemetry on". the metrics are spooled locally and sent when they can be, they
+// never contain the arguments or the values of the flags of the commands and the error messages
+// are redacted.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/internal/version"
+	"github.com/tendermint/starport/starport/pkg/gacli"
+)
+
+// GAID is the tracking ID of the Google Analytics property the metrics are sent to, it's set at
+// build time for the releases. the metrics are only spooled when it's empty.
+var GAID = ""
+
+const (
+	configFile = "telemetry.json"
+	spoolFile  = "telemetry.jsonl"
+
+	// maxSpooled is the number of events kept in the spool, the oldest events are dropped when the
+	// metrics can't be sent for a while.
+	maxSpooled = 1000
+)
+
+// Config is the telemetry choice of the user.
+type Config struct {
+	// Enabled is true once the user opted in.
+	Enabled bool `json:"enabled"`
+
+	// ID is a random ID identifying the installation instead of the user.
+	ID string `json:"id,omitempty"`
+}
+
+// Event is the metric recorded for a run of a command.
+type Event struct {
+	// Command is the path of the command without the binary name, e.g. "chain serve".
+	Command string `json:"command"`
+
+	// Flags are the names of the flags set for the command, their values are not recorded.
+	Flags []string `json:"flags,omitempty"`
+
+	// DurationMS is the duration of the command in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+
+	// ErrorCategory is the category of the error of the command, it's empty when the command succeeds.
+	ErrorCategory string `json:"error_category,omitempty"`
+
+	// Error is the redacted message of the error of the command.
+	Error string `json:"error,omitempty"`
+
+	Version string    `json:"version"`
+	OS      string    `json:"os"`
+	Arch    string    `json:"arch"`
+	Time    time.Time `json:"time"`
+}
+
+// NewEvent returns the event of a run of command.
+func NewEvent(command string, flags []string, duration time.Duration, err error) Event {
+	e := Event{
+		Command:       command,
+		Flags:         flags,
+		DurationMS:    duration.Milliseconds(),
+		ErrorCategory: Category(err),
+		Version:       version.Version,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		Time:          time.Now().UTC(),
+	}
+	if err != nil {
+		e.Error = RedactError(err)
+	}
+	return e
+}
+
+// Telemetry manages the telemetry choice of the user and the spool of the events.
+type Telemetry struct {
+	dir string
+}
+
+// New returns the telemetry keeping its config and spool in dir.
+func New(dir string) Telemetry {
+	return Telemetry{dir}
+}
+
+// Default returns the telemetry keeping its config and spool in the config dir of Starport.
+func Default() (Telemetry, error) {
+	dir, err := chainconfig.ConfigDirPath()
+	if err != nil {
+		return Telemetry{}, err
+	}
+	return New(dir), nil
+}
+
+// SpoolPath returns the path of the spool, the events are saved as JSON lines.
+func (t Telemetry) SpoolPath() string {
+	return filepath.Join(t.dir, spoolFile)
+}
+
+// Config returns the telemetry choice of the user, the telemetry is disabled until the user opts in.
+func (t Telemetry) Config() (Config, error) {
+	var c Config
+	data, err := os.ReadFile(filepath.Join(t.dir, configFile))
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+// Enable opts in to the telemetry, the anonymous ID of the installation is generated on the first opt in.
+func (t Telemetry) Enable() (Config, error) {
+	c, err := t.Config()
+	if err != nil {
+		return c, err
+	}
+	if c.ID == "" {
+		if c.ID, err = newID(); err != nil {
+			return c, err
+		}
+	}
+	c.Enabled = true
+	return c, t.saveConfig(c)
+}
+
+// Disable opts out of the telemetry and removes the spooled events.
+func (t Telemetry) Disable() error {
+	c, err := t.Config()
+	if err != nil {
+		return err
+	}
+	c.Enabled = false
+	if err := t.saveConfig(c); err != nil {
+		return err
+	}
+	if err := os.Remove(t.SpoolPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (t Telemetry) saveConfig(c Config) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.dir, configFile), data, 0644)
+}
+
+// Record spools the event when the telemetry is enabled.
+func (t Telemetry) Record(e Event) error {
+	c, err := t.Config()
+	if err != nil || !c.Enabled {
+		return err
+	}
+
+	events, err := t.Spooled()
+	if err != nil {
+		return err
+	}
+	events = append(events, e)
+	if len(events) > maxSpooled {
+		events = events[len(events)-maxSpooled:]
+	}
+	return t.spool(events)
+}
+
+// Spooled returns the events that are not sent yet.
+func (t Telemetry) Spooled() ([]Event, error) {
+	f, err := os.Open(t.SpoolPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		// the lines that can't be decoded are dropped rather than blocking the spool.
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			events = append(events, e)
+		}
+	}
+	return events, scanner.Err()
+}
+
+func (t Telemetry) spool(events []Event) error {
+	var buf bytes.Buffer
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.SpoolPath(), buf.Bytes(), 0644)
+}
+
+// Flush sends the spooled events when the telemetry is enabled, the events that can't be sent are
+// kept in the spool to be sent by the next runs.
+func (t Telemetry) Flush(ctx context.Context) error {
+	c, err := t.Config()
+	if err != nil || !c.Enabled || GAID == "" {
+		return err
+	}
+
+	events, err := t.Spooled()
+	if err != nil || len(events) == 0 {
+		return err
+	}
+
+	client := gacli.New(GAID)
+	for i, e := range events {
+		if err := client.Send(ctx, toMetric(c.ID, e)); err != nil {
+			if serr := t.spool(events[i:]); serr != nil {
+				return serr
+			}
+			return err
+		}
+	}
+	return t.spool(nil)
+}
+
+func toMetric(id string, e Event) gacli.Metric {
+	label := e.ErrorCategory
+	if label == "" {
+		label = "ok"
+	}
+	return gacli.Metric{
+		Category: "command",
+		Action:   e.Command,
+		Label:    label,
+		Value:    strconv.FormatInt(e.DurationMS, 10),
+		User:     id,
+		Version:  e.Version,
+	}
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,49 @@
+package telemetry_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/internal/telemetry"
+)
+
+func TestRecord(t *testing.T) {
+	tm := telemetry.New(t.TempDir())
+
+	// the events are not recorded until the user opts in.
+	require.NoError(t, tm.Record(telemetry.NewEvent("chain serve", nil, time.Second, nil)))
+	events, err := tm.Spooled()
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	c, err := tm.Enable()
+	require.NoError(t, err)
+	require.True(t, c.Enabled)
+	require.Len(t, c.ID, 32)
+
+	err = errors.New("cannot fetch https://github.com/cosmonaut/mars")
+	require.NoError(t, tm.Record(telemetry.NewEvent("chain serve", []string{"verbose"}, time.Second, err)))
+
+	events, err = tm.Spooled()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "chain serve", events[0].Command)
+	require.Equal(t, []string{"verbose"}, events[0].Flags)
+	require.Equal(t, int64(1000), events[0].DurationMS)
+	require.Equal(t, telemetry.CategoryOther, events[0].ErrorCategory)
+	require.Equal(t, "cannot fetch <url>", events[0].Error)
+
+	// the ID is kept when the user opts in again.
+	again, err := tm.Enable()
+	require.NoError(t, err)
+	require.Equal(t, c.ID, again.ID)
+
+	// the spool is removed when the user opts out.
+	require.NoError(t, tm.Disable())
+	events, err = tm.Spooled()
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
@@ -52,7 +52,7 @@ func generate(cmd *cobra.Command, outPath string) error {
 	}
 	defer f.Close()
 
-	if _, err := fmt.Fprintln(f, head); err != nil {
+	if _, err := fmt.Fprintf(f, "%s\n", head); err != nil {
 		return err
 	}
 
@@ -0,0 +1,55 @@
+// this tool signs the checksums of the Starport releases with the ed25519 key of the releases, the
+// signature is verified by `starport update` with the public key set to selfupdate.SigningKey.
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"log"
+	"os"
+)
+
+func main() {
+	keyEnv := flag.String("key-env", "STARPORT_SIGNING_KEY", "environment variable of the base64 encoded ed25519 private key or seed")
+	outPath := flag.String("out", "", "path of the signature (default: <checksums>.sig)")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("the path of the checksums is required")
+	}
+	path := flag.Arg(0)
+	if *outPath == "" {
+		*outPath = path + ".sig"
+	}
+
+	if err := sign(os.Getenv(*keyEnv), path, *outPath); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// sign writes the base64 encoded signature of the file at path to outPath.
+func sign(key, path, outPath string) error {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return errors.New("the signing key must be base64 encoded")
+	}
+
+	var priv ed25519.PrivateKey
+	switch len(decoded) {
+	case ed25519.SeedSize:
+		priv = ed25519.NewKeyFromSeed(decoded)
+	case ed25519.PrivateKeySize:
+		priv = ed25519.PrivateKey(decoded)
+	default:
+		return errors.New("the signing key must be an ed25519 private key or seed")
+	}
+
+	checksums, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, checksums))
+	return os.WriteFile(outPath, []byte(signature+"\n"), 0644)
+}
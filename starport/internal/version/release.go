@@ -0,0 +1,105 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/google/go-github/v37/github"
+)
+
+const (
+	// ChannelStable releases the tagged versions of Starport.
+	ChannelStable Channel = "stable"
+
+	// ChannelNightly releases the latest build of the development branch under the nightly tag.
+	ChannelNightly Channel = "nightly"
+)
+
+// Channel is a release channel of Starport.
+type Channel string
+
+// ParseChannel parses a release channel.
+func ParseChannel(s string) (Channel, error) {
+	switch c := Channel(s); c {
+	case ChannelStable, ChannelNightly:
+		return c, nil
+	default:
+		return "", fmt.Errorf("invalid channel %q, expected %s or %s", s, ChannelStable, ChannelNightly)
+	}
+}
+
+// Release is a release of Starport.
+type Release struct {
+	// Tag is the tag of the release, it's nightly for the nightly channel.
+	Tag string
+
+	// PublishedAt is the time the release is published at.
+	PublishedAt time.Time
+
+	// Assets are the files of the release by name.
+	Assets map[string]string
+}
+
+// LatestRelease fetches the latest release of the channel.
+func LatestRelease(ctx context.Context, channel Channel) (Release, error) {
+	repos := github.NewClient(nil).Repositories
+
+	var (
+		r   *github.RepositoryRelease
+		err error
+	)
+	if channel == ChannelNightly {
+		r, _, err = repos.GetReleaseByTag(ctx, "tendermint", "starport", string(ChannelNightly))
+	} else {
+		r, _, err = repos.GetLatestRelease(ctx, "tendermint", "starport")
+	}
+	if err != nil {
+		return Release{}, err
+	}
+
+	release := Release{
+		Tag:    r.GetTagName(),
+		Assets: make(map[string]string),
+	}
+	if r.PublishedAt != nil {
+		release.PublishedAt = r.PublishedAt.Time
+	}
+	for _, asset := range r.Assets {
+		release.Assets[asset.GetName()] = asset.GetBrowserDownloadURL()
+	}
+	return release, nil
+}
+
+// IsDevelopment returns true when the running Starport is not a released build.
+func IsDevelopment() bool {
+	return Version == versionDev
+}
+
+// IsNewer returns true when the release is newer than the running Starport. the nightly releases
+// have the same tag, so they are compared with the build date of the running Starport.
+func (r Release) IsNewer() (bool, error) {
+	if IsDevelopment() {
+		return false, nil
+	}
+
+	if r.Tag == string(ChannelNightly) || Version == string(ChannelNightly) {
+		date, err := time.Parse(time.RFC3339, Date)
+		if err != nil {
+			return false, fmt.Errorf("cannot parse the build date %q: %w", Date, err)
+		}
+		return r.PublishedAt.After(date), nil
+	}
+
+	current, err := semver.Parse(strings.TrimPrefix(Version, prefix))
+	if err != nil {
+		return false, err
+	}
+	latest, err := semver.Parse(strings.TrimPrefix(r.Tag, prefix))
+	if err != nil {
+		return false, err
+	}
+	return latest.GT(current), nil
+}
@@ -0,0 +1,263 @@
+// Package autocli generates the CLI commands of a module from the methods of its gRPC query and
+// msg services, the commands stay in sync with the proto services of the module.
+package autocli
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/iancoleman/strcase"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// ModuleOptions are the options of the CLI commands of a module.
+type ModuleOptions struct {
+	// Query are the options of the commands of the query service.
+	Query ServiceOptions
+
+	// Tx are the options of the commands of the msg service.
+	Tx ServiceOptions
+}
+
+// ServiceOptions are the options of the commands of the methods of a gRPC service.
+type ServiceOptions struct {
+	// NewClient returns the gRPC client of the service, e.g. types.NewQueryClient, a command is
+	// generated for each method of the client.
+	NewClient func(clientCtx client.Context) interface{}
+
+	// RPCs are the options of the commands of the methods, a method without options has a command
+	// named after it that reads the fields of the request from flags.
+	RPCs []RPCOptions
+}
+
+// RPCOptions are the options of the command of a method of a service.
+type RPCOptions struct {
+	// Method is the name of the method, e.g. CreatePost.
+	Method string
+
+	// Use is the one-line usage of the command, it's the name of the method in kebab case followed
+	// by the positional args by default.
+	Use string
+
+	// Short is the short description of the command.
+	Short string
+
+	// PositionalArgs are the names of the fields of the request that are read from positional args
+	// in order, the other fields are read from flags.
+	PositionalArgs []string
+
+	// FlagDefaults are the default values of the flags of the command by the names of the fields.
+	FlagDefaults map[string]string
+
+	// Signer is the name of the field of a message that is set to the address of the signer of
+	// the tx, the signer is the first field of the message by default.
+	Signer string
+
+	// Skip prevents generating the command of the method.
+	Skip bool
+}
+
+// QueryCommand returns the query command of the module with a sub command for each method of the
+// query service.
+func QueryCommand(moduleName string, opts ServiceOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        moduleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", moduleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	for _, m := range serviceMethods(opts) {
+		cmd.AddCommand(newQueryCommand(m, opts))
+	}
+	return cmd
+}
+
+// TxCommand returns the tx command of the module with a sub command for each method of the msg
+// service.
+func TxCommand(moduleName string, opts ServiceOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        moduleName,
+		Short:                      fmt.Sprintf("%s transactions subcommands", moduleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	for _, m := range serviceMethods(opts) {
+		cmd.AddCommand(newTxCommand(m, opts))
+	}
+	return cmd
+}
+
+// method is a method of a gRPC client with the options of its command.
+type method struct {
+	RPCOptions
+
+	// request is the type of the request of the method, a pointer to a struct.
+	request reflect.Type
+}
+
+var (
+	contextType    = reflect.TypeOf((*context.Context)(nil)).Elem()
+	callOptionType = reflect.TypeOf([]grpc.CallOption{})
+	errorType      = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// serviceMethods returns the methods of the client of the service that have a command.
+func serviceMethods(opts ServiceOptions) []method {
+	rpcs := make(map[string]RPCOptions)
+	for _, rpc := range opts.RPCs {
+		rpcs[rpc.Method] = rpc
+	}
+
+	var methods []method
+	t := reflect.TypeOf(opts.NewClient(client.Context{}))
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+
+		// the methods of the client have the func(ctx, *Request, ...grpc.CallOption) (*Response, error)
+		// signature, the first in is the receiver.
+		mt := m.Type
+		if mt.NumIn() != 4 || mt.NumOut() != 2 || !mt.IsVariadic() ||
+			mt.In(1) != contextType || mt.In(3) != callOptionType || mt.Out(1) != errorType {
+			continue
+		}
+		request := mt.In(2)
+		if request.Kind() != reflect.Ptr || request.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		rpc, ok := rpcs[m.Name]
+		if !ok {
+			rpc = RPCOptions{Method: m.Name}
+		}
+		if rpc.Skip {
+			continue
+		}
+		methods = append(methods, method{RPCOptions: rpc, request: request})
+	}
+	return methods
+}
+
+// use returns the one-line usage of the command of the method.
+func (m method) use() string {
+	if m.Use != "" {
+		return m.Use
+	}
+	use := strcase.ToKebab(m.Method)
+	for _, arg := range m.PositionalArgs {
+		use += fmt.Sprintf(" [%s]", strcase.ToKebab(arg))
+	}
+	return use
+}
+
+func newQueryCommand(m method, opts ServiceOptions) *cobra.Command {
+	req := reflect.New(m.request.Elem())
+	b := newBinder(req, m.PositionalArgs, "", false)
+
+	short := m.Short
+	if short == "" {
+		short = fmt.Sprintf("Query %s", strcase.ToKebab(m.Method))
+	}
+
+	cmd := &cobra.Command{
+		Use:   m.use(),
+		Short: short,
+		Args:  cobra.ExactArgs(len(m.PositionalArgs)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if b.err != nil {
+				return b.err
+			}
+			if err := b.bindArgs(args); err != nil {
+				return err
+			}
+			if err := b.bindPagination(cmd); err != nil {
+				return err
+			}
+
+			c := reflect.ValueOf(opts.NewClient(clientCtx))
+			out := c.MethodByName(m.Method).Call([]reflect.Value{reflect.ValueOf(cmd.Context()), req})
+			if err, _ := out[1].Interface().(error); err != nil {
+				return err
+			}
+			res, ok := out[0].Interface().(proto.Message)
+			if !ok {
+				return fmt.Errorf("the response of %s is not a proto message", m.Method)
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	if b.pagination >= 0 {
+		flags.AddPaginationFlagsToCmd(cmd, cmd.Name())
+	}
+	b.addFlags(cmd, m.FlagDefaults)
+	return cmd
+}
+
+func newTxCommand(m method, opts ServiceOptions) *cobra.Command {
+	req := reflect.New(m.request.Elem())
+	b := newBinder(req, m.PositionalArgs, m.Signer, true)
+
+	short := m.Short
+	if short == "" {
+		short = fmt.Sprintf("Broadcast message %s", strcase.ToKebab(m.Method))
+	}
+
+	cmd := &cobra.Command{
+		Use:   m.use(),
+		Short: short,
+		Args:  cobra.ExactArgs(len(m.PositionalArgs)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			if b.err != nil {
+				return b.err
+			}
+			if err := b.bindArgs(args); err != nil {
+				return err
+			}
+			b.bindSigner(clientCtx.GetFromAddress().String())
+
+			msg, ok := req.Interface().(sdk.Msg)
+			if !ok {
+				return fmt.Errorf("the request of %s is not a message", m.Method)
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	b.addFlags(cmd, m.FlagDefaults)
+	return cmd
+}
+
+// protoName returns the name of the field in the proto file from its tag, e.g. customField.
+func protoName(f reflect.StructField) string {
+	for _, part := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return ""
+}
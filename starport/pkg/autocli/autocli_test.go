@@ -0,0 +1,142 @@
+package autocli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/tendermint/starport/starport/pkg/autocli"
+)
+
+var errCalled = errors.New("called")
+
+type queryPostRequest struct {
+	Id         uint64             `protobuf:"varint,1,opt,name=id,proto3"`
+	Tags       []string           `protobuf:"bytes,2,rep,name=tags,proto3"`
+	Amount     sdk.Coin           `protobuf:"bytes,3,opt,name=amount,proto3"`
+	Verified   bool               `protobuf:"varint,4,opt,name=verified,proto3"`
+	Pagination *query.PageRequest `protobuf:"bytes,5,opt,name=pagination,proto3"`
+
+	XXX_unrecognized []byte
+}
+
+type queryPostResponse struct{}
+
+type createPostRequest struct {
+	Creator  string `protobuf:"bytes,1,opt,name=creator,proto3"`
+	Title    string `protobuf:"bytes,2,opt,name=title,proto3"`
+	MaxCount int32  `protobuf:"varint,3,opt,name=maxCount,proto3"`
+}
+
+type createPostResponse struct{}
+
+type blogClient struct {
+	req interface{}
+}
+
+func (c *blogClient) Post(ctx context.Context, req *queryPostRequest, opts ...grpc.CallOption) (*queryPostResponse, error) {
+	c.req = req
+	return nil, errCalled
+}
+
+func (c *blogClient) CreatePost(ctx context.Context, req *createPostRequest, opts ...grpc.CallOption) (*createPostResponse, error) {
+	return nil, errCalled
+}
+
+// Hidden isn't the method of a service.
+func (c *blogClient) Hidden(ctx context.Context) error { return nil }
+
+func TestQueryCommand(t *testing.T) {
+	c := &blogClient{}
+	cmd := autocli.QueryCommand("blog", autocli.ServiceOptions{
+		NewClient: func(client.Context) interface{} { return c },
+		RPCs: []autocli.RPCOptions{
+			{Method: "Post", Short: "shows a post", PositionalArgs: []string{"id"}},
+			{Method: "CreatePost", Skip: true},
+		},
+	})
+
+	commands := cmd.Commands()
+	require.Len(t, commands, 1)
+	post := commands[0]
+	require.Equal(t, "post [id]", post.Use)
+	require.Equal(t, "shows a post", post.Short)
+	require.Nil(t, post.Flags().Lookup("id"))
+	require.NotNil(t, post.Flags().Lookup("limit"))
+	require.Nil(t, post.Flags().Lookup("x-x-x-unrecognized"))
+
+	ctx := context.WithValue(context.Background(), client.ClientContextKey, &client.Context{})
+	cmd.SetArgs([]string{"post", "5", "--tags", "a,b", "--tags", "c", "--amount", "10token", "--verified", "--limit", "3"})
+	require.ErrorIs(t, cmd.ExecuteContext(ctx), errCalled)
+
+	req := c.req.(*queryPostRequest)
+	require.Equal(t, uint64(5), req.Id)
+	require.Equal(t, []string{"a", "b", "c"}, req.Tags)
+	require.Equal(t, sdk.NewInt64Coin("token", 10), req.Amount)
+	require.True(t, req.Verified)
+	require.Equal(t, uint64(3), req.Pagination.Limit)
+}
+
+func TestQueryCommandInvalidArg(t *testing.T) {
+	c := &blogClient{}
+	cmd := autocli.QueryCommand("blog", autocli.ServiceOptions{
+		NewClient: func(client.Context) interface{} { return c },
+		RPCs:      []autocli.RPCOptions{{Method: "Post", PositionalArgs: []string{"id"}}},
+	})
+
+	ctx := context.WithValue(context.Background(), client.ClientContextKey, &client.Context{})
+	cmd.SetArgs([]string{"post", "first"})
+	require.Error(t, cmd.ExecuteContext(ctx))
+	require.Nil(t, c.req)
+}
+
+func TestQueryCommandFlagDefaults(t *testing.T) {
+	c := &blogClient{}
+	cmd := autocli.QueryCommand("blog", autocli.ServiceOptions{
+		NewClient: func(client.Context) interface{} { return c },
+		RPCs: []autocli.RPCOptions{{
+			Method:         "Post",
+			PositionalArgs: []string{"id"},
+			FlagDefaults:   map[string]string{"verified": "true", "amount": "5token", "tags": "b,c"},
+		}},
+	})
+	post, _, err := cmd.Find([]string{"post"})
+	require.NoError(t, err)
+	require.Equal(t, "5token", post.Flags().Lookup("amount").DefValue)
+	require.Contains(t, post.Flags().FlagUsages(), "(default 5token)")
+
+	ctx := context.WithValue(context.Background(), client.ClientContextKey, &client.Context{})
+	cmd.SetArgs([]string{"post", "1", "--amount", "10token", "--tags", "a"})
+	require.ErrorIs(t, cmd.ExecuteContext(ctx), errCalled)
+
+	req := c.req.(*queryPostRequest)
+	require.True(t, req.Verified)
+	require.Equal(t, sdk.NewInt64Coin("token", 10), req.Amount)
+	require.Equal(t, []string{"a"}, req.Tags)
+}
+
+func TestTxCommand(t *testing.T) {
+	cmd := autocli.TxCommand("blog", autocli.ServiceOptions{
+		NewClient: func(client.Context) interface{} { return &blogClient{} },
+		RPCs: []autocli.RPCOptions{
+			{Method: "Post", Skip: true},
+			{Method: "CreatePost", PositionalArgs: []string{"title"}},
+		},
+	})
+
+	commands := cmd.Commands()
+	require.Len(t, commands, 1)
+	create := commands[0]
+	require.Equal(t, "create-post [title]", create.Use)
+	require.Equal(t, "Broadcast message create-post", create.Short)
+	require.Nil(t, create.Flags().Lookup("creator"))
+	require.Nil(t, create.Flags().Lookup("title"))
+	require.NotNil(t, create.Flags().Lookup("max-count"))
+	require.NotNil(t, create.Flags().Lookup("from"))
+}
@@ -0,0 +1,294 @@
+package autocli
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/iancoleman/strcase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	coinType        = reflect.TypeOf(sdk.Coin{})
+	coinsType       = reflect.TypeOf(sdk.Coins{})
+	pageRequestType = reflect.TypeOf(&query.PageRequest{})
+)
+
+// binder binds the positional args and the flags of a command to the fields of a request.
+type binder struct {
+	req reflect.Value
+
+	// fields are the indexes of the fields of the request that are declared in the proto file.
+	fields []int
+
+	// args are the indexes of the fields that are read from the positional args in order.
+	args []int
+
+	// signer is the index of the field that is set to the address of the signer, -1 for none.
+	signer int
+
+	// pagination is the index of the page request field, -1 for none.
+	pagination int
+
+	// err is the error of an invalid default value of a flag, returned when the command runs.
+	err error
+}
+
+// newBinder returns a binder of the request, positional are the names of the fields read from the
+// positional args and signer is the name of the field set to the address of the signer, a request
+// of a tx has a signer and the first field is the signer when no name is given.
+func newBinder(req reflect.Value, positional []string, signer string, tx bool) *binder {
+	b := &binder{req: req, signer: -1, pagination: -1}
+
+	t := req.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.HasPrefix(f.Name, "XXX_") || protoName(f) == "" {
+			continue
+		}
+		b.fields = append(b.fields, i)
+		if f.Type == pageRequestType {
+			b.pagination = i
+		}
+	}
+
+	for _, name := range positional {
+		if i := b.field(name); i >= 0 {
+			b.args = append(b.args, i)
+		}
+	}
+
+	if tx {
+		switch {
+		case signer != "":
+			b.signer = b.field(signer)
+		case len(b.fields) > 0:
+			b.signer = b.fields[0]
+		}
+	}
+	return b
+}
+
+// field returns the index of the field with the name, -1 when it's not found.
+func (b *binder) field(name string) int {
+	t := b.req.Elem().Type()
+	for _, i := range b.fields {
+		if strcase.ToLowerCamel(protoName(t.Field(i))) == strcase.ToLowerCamel(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// addFlags adds a flag for each field of the request that isn't read from the positional args,
+// the fields with a name that conflicts with the existing flags of the command are skipped.
+// defaults are the default values of the flags by the names of the fields.
+func (b *binder) addFlags(cmd *cobra.Command, defaults map[string]string) {
+	t := b.req.Elem().Type()
+	for _, i := range b.fields {
+		if i == b.signer || i == b.pagination || b.isArg(i) {
+			continue
+		}
+
+		f := t.Field(i)
+		name := strcase.ToKebab(protoName(f))
+		if cmd.Flags().Lookup(name) != nil {
+			continue
+		}
+
+		v := &value{v: b.req.Elem().Field(i)}
+		flag := cmd.Flags().VarPF(v, name, "", fmt.Sprintf("%s as %s", name, v.Type()))
+		if f.Type.Kind() == reflect.Bool {
+			flag.NoOptDefVal = "true"
+		}
+		if value, ok := lookupDefault(defaults, protoName(f)); ok {
+			if err := v.Set(value); err != nil {
+				b.err = fmt.Errorf("invalid default value of %s: %s", name, err.Error())
+				continue
+			}
+			v.changed = false
+			flag.DefValue = v.String()
+		}
+	}
+}
+
+func (b *binder) isArg(i int) bool {
+	for _, arg := range b.args {
+		if arg == i {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupDefault returns the default value of the field with the name in defaults.
+func lookupDefault(defaults map[string]string, name string) (string, bool) {
+	for field, value := range defaults {
+		if strcase.ToLowerCamel(field) == strcase.ToLowerCamel(name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// bindArgs sets the fields read from the positional args.
+func (b *binder) bindArgs(args []string) error {
+	t := b.req.Elem().Type()
+	for n, i := range b.args {
+		v, err := parseValue(t.Field(i).Type, args[n])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", strcase.ToKebab(protoName(t.Field(i))), err.Error())
+		}
+		b.req.Elem().Field(i).Set(v)
+	}
+	return nil
+}
+
+// bindPagination sets the page request field from the pagination flags.
+func (b *binder) bindPagination(cmd *cobra.Command) error {
+	if b.pagination < 0 {
+		return nil
+	}
+	pageReq, err := client.ReadPageRequest(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	b.req.Elem().Field(b.pagination).Set(reflect.ValueOf(pageReq))
+	return nil
+}
+
+// bindSigner sets the signer field to the address.
+func (b *binder) bindSigner(address string) {
+	if b.signer < 0 {
+		return
+	}
+	if f := b.req.Elem().Field(b.signer); f.Kind() == reflect.String {
+		f.SetString(address)
+	}
+}
+
+// value is the pflag.Value of a field of a request.
+type value struct {
+	v reflect.Value
+
+	// changed is true once the flag is given.
+	changed bool
+}
+
+func (v *value) String() string {
+	if !v.v.IsValid() || v.v.IsZero() {
+		return ""
+	}
+	return fmt.Sprint(v.v.Interface())
+}
+
+// Set sets the field from the value of the flag, the values of a repeated field are separated by
+// commas and the values of the flag given multiple times are appended to replace the default.
+func (v *value) Set(s string) error {
+	parsed, err := parseValue(v.v.Type(), s)
+	if err != nil {
+		return err
+	}
+	if v.changed && v.v.Kind() == reflect.Slice && v.v.Type() != coinsType && v.v.Type().Elem().Kind() != reflect.Uint8 {
+		parsed = reflect.AppendSlice(v.v, parsed)
+	}
+	v.v.Set(parsed)
+	v.changed = true
+	return nil
+}
+
+func (v *value) Type() string {
+	return typeName(v.v.Type())
+}
+
+// typeName returns the name of the type of a field in the usage of its flag.
+func typeName(t reflect.Type) string {
+	switch {
+	case t == coinType:
+		return "coin"
+	case t == coinsType:
+		return "coins"
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return typeName(t.Elem()) + "s"
+	case reflect.Struct, reflect.Ptr, reflect.Map:
+		return "json"
+	}
+	return t.Kind().String()
+}
+
+// parseValue parses s as a value of the type of a field.
+func parseValue(t reflect.Type, s string) (reflect.Value, error) {
+	switch t {
+	case coinType:
+		coin, err := sdk.ParseCoinNormalized(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(coin), nil
+	case coinsType:
+		coins, err := sdk.ParseCoinsNormalized(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(coins), nil
+	}
+
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(s))
+			break
+		}
+		for _, item := range strings.Split(s, ",") {
+			elem, err := parseValue(t.Elem(), strings.TrimSpace(item))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v = reflect.Append(v, elem)
+		}
+	default:
+		// messages and maps are given as JSON.
+		if err := json.Unmarshal([]byte(s), v.Addr().Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return v, nil
+}
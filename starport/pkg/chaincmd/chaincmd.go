@@ -46,10 +46,13 @@ const (
 	optionVestingAmount                    = "--vesting-amount"
 	optionVestingEndTime                   = "--vesting-end-time"
 	optionBroadcastMode                    = "--broadcast-mode"
+	optionFrom                             = "--from"
+	optionOutputDocument                   = "--output-document"
 
 	constTendermint = "tendermint"
 	constJSON       = "json"
 	constSync       = "sync"
+	constBlock      = "block"
 )
 
 type KeyringBackend string
@@ -534,6 +537,73 @@ func (c ChainCmd) BankSendCommand(fromAddress, toAddress, amount string) step.Op
 	return c.cliCommand(command)
 }
 
+// TxCommand returns the command to send a tx from fromAccount with the args of the tx command,
+// e.g. bank send alice cosmos1... 100stake. the tx is broadcast in block mode so the tx is added
+// to a block and has its events in the output.
+func (c ChainCmd) TxCommand(fromAccount string, args ...string) step.Option {
+	command := append([]string{commandTx}, args...)
+	command = append(command,
+		optionFrom,
+		fromAccount,
+		optionBroadcastMode,
+		constBlock,
+		optionYes,
+		optionOutput,
+		constJSON,
+	)
+
+	command = c.attachChainID(command)
+	command = c.attachKeyringBackend(command)
+	command = c.attachNode(command)
+
+	return c.cliCommand(command)
+}
+
+// SignTxCommand returns the command to sign the unsigned tx in unsignedTxFile with the key of
+// fromAddress and write the signed tx to signedTxFile.
+func (c ChainCmd) SignTxCommand(fromAddress, unsignedTxFile, signedTxFile string) step.Option {
+	// Check version
+	if !c.isStargate() {
+		panic("sign command with an output document is only supported for Stargate")
+	}
+
+	command := []string{
+		commandTx,
+		"sign",
+		unsignedTxFile,
+		optionFrom,
+		fromAddress,
+		optionOutputDocument,
+		signedTxFile,
+	}
+
+	command = c.attachChainID(command)
+	command = c.attachKeyringBackend(command)
+	command = c.attachNode(command)
+
+	return c.cliCommand(command)
+}
+
+// BroadcastTxCommand returns the command to broadcast the signed tx in signedTxFile.
+func (c ChainCmd) BroadcastTxCommand(signedTxFile string) step.Option {
+	// Check version
+	if !c.isStargate() {
+		panic("broadcast command is only supported for Stargate")
+	}
+
+	command := []string{
+		commandTx,
+		"broadcast",
+		signedTxFile,
+		optionBroadcastMode,
+		constSync,
+	}
+
+	command = c.attachNode(command)
+
+	return c.cliCommand(command)
+}
+
 // QueryTxCommand returns the command to query tx
 func (c ChainCmd) QueryTxCommand(txHash string) step.Option {
 	command := []string{
@@ -567,6 +637,23 @@ func (c ChainCmd) QueryTxEventsCommand(query string) step.Option {
 	return c.cliCommand(command)
 }
 
+// BankBalancesCommand returns the command to query the balances of an address.
+func (c ChainCmd) BankBalancesCommand(address string) step.Option {
+	command := []string{
+		commandQuery,
+	}
+
+	if c.sdkVersion.IsFamily(cosmosver.Stargate) {
+		command = append(command, "bank", "balances", address)
+	} else {
+		command = append(command, "account", address, "--trust-node")
+	}
+
+	command = append(command, optionOutput, constJSON)
+	command = c.attachNode(command)
+	return c.cliCommand(command)
+}
+
 // LaunchpadSetConfigCommand returns the command to set config value
 func (c ChainCmd) LaunchpadSetConfigCommand(name, value string) step.Option {
 	// Check version
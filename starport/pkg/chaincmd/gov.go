@@ -0,0 +1,92 @@
+package chaincmd
+
+import (
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
+)
+
+const (
+	commandGov = "gov"
+
+	optionProposal      = "--proposal"
+	optionTitle         = "--title"
+	optionDescription   = "--description"
+	optionDeposit       = "--deposit"
+	optionUpgradeHeight = "--upgrade-height"
+	optionUpgradeInfo   = "--upgrade-info"
+)
+
+// GovSubmitProposalCommand returns the command to submit the proposal in proposalFile from
+// fromAccount, the proposal file has the title, the description, the type and the deposit of
+// a text proposal.
+func (c ChainCmd) GovSubmitProposalCommand(fromAccount, proposalFile string) step.Option {
+	return c.govTxCommand(fromAccount, "submit-proposal", optionProposal, proposalFile)
+}
+
+// GovSubmitParamChangeProposalCommand returns the command to submit the param change proposal in
+// proposalFile from fromAccount.
+func (c ChainCmd) GovSubmitParamChangeProposalCommand(fromAccount, proposalFile string) step.Option {
+	return c.govTxCommand(fromAccount, "submit-proposal", "param-change", proposalFile)
+}
+
+// GovSubmitSoftwareUpgradeProposalCommand returns the command to submit a proposal from
+// fromAccount to upgrade the chain with the upgrade name at height.
+func (c ChainCmd) GovSubmitSoftwareUpgradeProposalCommand(
+	fromAccount,
+	name,
+	height,
+	info,
+	title,
+	description,
+	deposit string,
+) step.Option {
+	args := []string{
+		"submit-proposal",
+		"software-upgrade",
+		name,
+		optionUpgradeHeight,
+		height,
+		optionTitle,
+		title,
+		optionDescription,
+		description,
+		optionDeposit,
+		deposit,
+	}
+	if info != "" {
+		args = append(args, optionUpgradeInfo, info)
+	}
+	return c.govTxCommand(fromAccount, args...)
+}
+
+// GovVoteCommand returns the command to vote with the option on a proposal from fromAccount.
+func (c ChainCmd) GovVoteCommand(fromAccount, proposalID, option string) step.Option {
+	return c.govTxCommand(fromAccount, "vote", proposalID, option)
+}
+
+// GovProposalCommand returns the command to query a proposal.
+func (c ChainCmd) GovProposalCommand(proposalID string) step.Option {
+	return c.govQueryCommand("proposal", proposalID)
+}
+
+// GovTallyCommand returns the command to query the current tally of a proposal.
+func (c ChainCmd) GovTallyCommand(proposalID string) step.Option {
+	return c.govQueryCommand("tally", proposalID)
+}
+
+// GovParamsCommand returns the command to query the params of the gov module.
+func (c ChainCmd) GovParamsCommand() step.Option {
+	return c.govQueryCommand("params")
+}
+
+// govTxCommand returns the command to send a tx of the gov module from fromAccount.
+func (c ChainCmd) govTxCommand(fromAccount string, args ...string) step.Option {
+	return c.TxCommand(fromAccount, append([]string{commandGov}, args...)...)
+}
+
+func (c ChainCmd) govQueryCommand(args ...string) step.Option {
+	command := append([]string{commandQuery, commandGov}, args...)
+	command = append(command, optionOutput, constJSON)
+	command = c.attachNode(command)
+
+	return c.cliCommand(command)
+}
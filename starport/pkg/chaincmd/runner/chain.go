@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 
 	"github.com/tendermint/starport/starport/pkg/chaincmd"
@@ -201,6 +203,191 @@ func (r Runner) BankSend(ctx context.Context, fromAccount, toAccount, amount str
 	return txResult.TxHash, nil
 }
 
+const (
+	// multiSendGasBase is the gas limit of a multi send tx excluding its outputs.
+	multiSendGasBase = 100000
+
+	// multiSendGasPerOutput is the gas limit added to a multi send tx for each of its outputs.
+	multiSendGasPerOutput = 50000
+)
+
+// BankOutput is an output of a multi send tx.
+type BankOutput struct {
+	Address string
+	Coins   sdk.Coins
+}
+
+// BankMultiSend sends the coins of outputs from fromAccount within a single tx. it's only
+// supported by Stargate chains.
+func (r Runner) BankMultiSend(ctx context.Context, fromAccount string, outputs []BankOutput) (string, error) {
+	if !r.chainCmd.SDKVersion().IsFamily(cosmosver.Stargate) {
+		return "", errors.New("multi send is only supported by Stargate chains")
+	}
+	if len(outputs) == 0 {
+		return "", errors.New("multi send requires at least one output")
+	}
+
+	type coin struct {
+		Denom  string `json:"denom"`
+		Amount string `json:"amount"`
+	}
+
+	type inputOutput struct {
+		Address string `json:"address"`
+		Coins   []coin `json:"coins"`
+	}
+
+	toCoins := func(coins sdk.Coins) []coin {
+		c := make([]coin, 0, len(coins))
+		for _, cc := range coins {
+			c = append(c, coin{cc.Denom, cc.Amount.String()})
+		}
+		return c
+	}
+
+	var (
+		total     sdk.Coins
+		txOutputs []inputOutput
+		empty     = []json.RawMessage{}
+	)
+	for _, o := range outputs {
+		total = total.Add(o.Coins...)
+		txOutputs = append(txOutputs, inputOutput{o.Address, toCoins(o.Coins)})
+	}
+
+	unsignedTx := map[string]interface{}{
+		"body": map[string]interface{}{
+			"messages": []interface{}{
+				map[string]interface{}{
+					"@type":   "/cosmos.bank.v1beta1.MsgMultiSend",
+					"inputs":  []inputOutput{{fromAccount, toCoins(total)}},
+					"outputs": txOutputs,
+				},
+			},
+			"memo":                           "",
+			"timeout_height":                 "0",
+			"extension_options":              empty,
+			"non_critical_extension_options": empty,
+		},
+		"auth_info": map[string]interface{}{
+			"signer_infos": empty,
+			"fee": map[string]interface{}{
+				"amount":    []coin{},
+				"gas_limit": fmt.Sprint(multiSendGasBase + multiSendGasPerOutput*len(outputs)),
+				"payer":     "",
+				"granter":   "",
+			},
+		},
+		"signatures": []string{},
+	}
+
+	dir, err := os.MkdirTemp("", "multisend")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		unsignedTxFile = filepath.Join(dir, "unsigned.json")
+		signedTxFile   = filepath.Join(dir, "signed.json")
+	)
+
+	data, err := json.Marshal(unsignedTx)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(unsignedTxFile, data, 0644); err != nil {
+		return "", err
+	}
+
+	opt := []step.Option{
+		r.chainCmd.SignTxCommand(fromAccount, unsignedTxFile, signedTxFile),
+	}
+
+	if r.chainCmd.KeyringPassword() != "" {
+		input := &bytes.Buffer{}
+		fmt.Fprintln(input, r.chainCmd.KeyringPassword())
+		fmt.Fprintln(input, r.chainCmd.KeyringPassword())
+		fmt.Fprintln(input, r.chainCmd.KeyringPassword())
+		opt = append(opt, step.Write(input.Bytes()))
+	}
+
+	if err := r.run(ctx, runOptions{}, opt...); err != nil {
+		if strings.Contains(err.Error(), "key not found") {
+			return "", errors.New("account doesn't have any balances")
+		}
+		return "", err
+	}
+
+	b := newBuffer()
+	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.BroadcastTxCommand(signedTxFile)); err != nil {
+		return "", err
+	}
+
+	txResult, err := decodeTxResult(b)
+	if err != nil {
+		return "", err
+	}
+
+	if txResult.Code > 0 {
+		return "", fmt.Errorf("cannot send tokens (SDK code %d): %s", txResult.Code, txResult.RawLog)
+	}
+
+	return txResult.TxHash, nil
+}
+
+// BankBalances returns the balances of the address.
+func (r Runner) BankBalances(ctx context.Context, address string) (sdk.Coins, error) {
+	b := newBuffer()
+
+	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.BankBalancesCommand(address)); err != nil {
+		return nil, err
+	}
+
+	data, err := b.JSONEnsuredBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	type coin struct {
+		Denom  string `json:"denom"`
+		Amount string `json:"amount"`
+	}
+
+	var coins []coin
+
+	if r.chainCmd.SDKVersion().IsFamily(cosmosver.Stargate) {
+		out := struct {
+			Balances []coin `json:"balances"`
+		}{}
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+		coins = out.Balances
+	} else {
+		out := struct {
+			Value struct {
+				Coins []coin `json:"coins"`
+			} `json:"value"`
+		}{}
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+		coins = out.Value.Coins
+	}
+
+	var balances sdk.Coins
+	for _, c := range coins {
+		amount, ok := sdk.NewIntFromString(c.Amount)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q of %q denom", c.Amount, c.Denom)
+		}
+		balances = balances.Add(sdk.NewCoin(c.Denom, amount))
+	}
+
+	return balances, nil
+}
+
 // WaitTx waits until a tx is successfully added to a block and can be queried
 func (r Runner) WaitTx(ctx context.Context, txHash string, retryDelay time.Duration, maxRetry int) error {
 	retry := 0
@@ -354,3 +541,35 @@ func (r Runner) QueryTxEvents(
 
 	return events, nil
 }
+
+// Tx sends a tx from fromAccount with the args of the tx command, e.g. bank send alice
+// cosmos1... 100stake, and returns its hash once it's added to a block.
+func (r Runner) Tx(ctx context.Context, fromAccount string, args ...string) (string, error) {
+	return r.tx(ctx, newBuffer(), r.chainCmd.TxCommand(fromAccount, args...))
+}
+
+// tx runs the command of a tx, writes its result to b and returns its hash.
+func (r Runner) tx(ctx context.Context, b *buffer, command step.Option) (string, error) {
+	opt := []step.Option{command}
+
+	if r.chainCmd.KeyringPassword() != "" {
+		input := &bytes.Buffer{}
+		fmt.Fprintln(input, r.chainCmd.KeyringPassword())
+		fmt.Fprintln(input, r.chainCmd.KeyringPassword())
+		fmt.Fprintln(input, r.chainCmd.KeyringPassword())
+		opt = append(opt, step.Write(input.Bytes()))
+	}
+
+	if err := r.run(ctx, runOptions{stdout: b}, opt...); err != nil {
+		return "", err
+	}
+
+	txResult, err := decodeTxResult(b)
+	if err != nil {
+		return "", err
+	}
+	if txResult.Code > 0 {
+		return "", fmt.Errorf("tx failed (SDK code %d): %s", txResult.Code, txResult.RawLog)
+	}
+	return txResult.TxHash, nil
+}
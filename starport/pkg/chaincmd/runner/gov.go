@@ -0,0 +1,304 @@
+package chaincmdrunner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
+)
+
+// the statuses of the proposals.
+const (
+	GovProposalStatusDepositPeriod = "PROPOSAL_STATUS_DEPOSIT_PERIOD"
+	GovProposalStatusVotingPeriod  = "PROPOSAL_STATUS_VOTING_PERIOD"
+	GovProposalStatusPassed        = "PROPOSAL_STATUS_PASSED"
+	GovProposalStatusRejected      = "PROPOSAL_STATUS_REJECTED"
+	GovProposalStatusFailed        = "PROPOSAL_STATUS_FAILED"
+)
+
+// GovParamChange is a change of a param in a param change proposal, value is the JSON of the param.
+type GovParamChange struct {
+	Subspace string          `json:"subspace"`
+	Key      string          `json:"key"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// GovTally is the votes of a proposal.
+type GovTally struct {
+	Yes        string `json:"yes"`
+	Abstain    string `json:"abstain"`
+	No         string `json:"no"`
+	NoWithVeto string `json:"no_with_veto"`
+}
+
+// GovProposal is a proposal of the gov module.
+type GovProposal struct {
+	ID              uint64
+	Type            string
+	Title           string
+	Status          string
+	VotingStartTime time.Time
+	VotingEndTime   time.Time
+	Tally           GovTally
+}
+
+// GovParams are the params of the gov module.
+type GovParams struct {
+	MinDeposit       sdk.Coins
+	MaxDepositPeriod time.Duration
+	VotingPeriod     time.Duration
+}
+
+// GovSubmitTextProposal submits a text proposal from fromAccount and returns its id.
+func (r Runner) GovSubmitTextProposal(ctx context.Context, fromAccount, title, description, deposit string) (uint64, error) {
+	return r.govSubmitProposalFile(ctx, fromAccount, map[string]interface{}{
+		"title":       title,
+		"description": description,
+		"type":        "Text",
+		"deposit":     deposit,
+	}, r.chainCmd.GovSubmitProposalCommand)
+}
+
+// GovSubmitParamChangeProposal submits a proposal to change params from fromAccount and returns its id.
+func (r Runner) GovSubmitParamChangeProposal(
+	ctx context.Context,
+	fromAccount,
+	title,
+	description,
+	deposit string,
+	changes []GovParamChange,
+) (uint64, error) {
+	return r.govSubmitProposalFile(ctx, fromAccount, map[string]interface{}{
+		"title":       title,
+		"description": description,
+		"changes":     changes,
+		"deposit":     deposit,
+	}, r.chainCmd.GovSubmitParamChangeProposalCommand)
+}
+
+// GovSubmitSoftwareUpgradeProposal submits a proposal from fromAccount to upgrade the chain with
+// the upgrade name at height and returns its id.
+func (r Runner) GovSubmitSoftwareUpgradeProposal(
+	ctx context.Context,
+	fromAccount,
+	name string,
+	height int64,
+	info,
+	title,
+	description,
+	deposit string,
+) (uint64, error) {
+	b := newBuffer()
+	_, err := r.tx(ctx, b, r.chainCmd.GovSubmitSoftwareUpgradeProposalCommand(
+		fromAccount,
+		name,
+		strconv.FormatInt(height, 10),
+		info,
+		title,
+		description,
+		deposit,
+	))
+	if err != nil {
+		return 0, err
+	}
+	return decodeProposalID(b)
+}
+
+func (r Runner) govSubmitProposalFile(
+	ctx context.Context,
+	fromAccount string,
+	proposal map[string]interface{},
+	command func(fromAccount, proposalFile string) step.Option,
+) (uint64, error) {
+	dir, err := os.MkdirTemp("", "proposal")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	data, err := json.Marshal(proposal)
+	if err != nil {
+		return 0, err
+	}
+	proposalFile := filepath.Join(dir, "proposal.json")
+	if err := os.WriteFile(proposalFile, data, 0644); err != nil {
+		return 0, err
+	}
+
+	b := newBuffer()
+	if _, err := r.tx(ctx, b, command(fromAccount, proposalFile)); err != nil {
+		return 0, err
+	}
+	return decodeProposalID(b)
+}
+
+// GovVote votes with the option on a proposal from fromAccount, option is one of: yes, no,
+// no_with_veto, abstain.
+func (r Runner) GovVote(ctx context.Context, fromAccount string, proposalID uint64, option string) error {
+	_, err := r.tx(ctx, newBuffer(), r.chainCmd.GovVoteCommand(fromAccount, strconv.FormatUint(proposalID, 10), option))
+	return err
+}
+
+// decodeProposalID returns the id of the proposal submitted by the tx in b.
+func decodeProposalID(b *buffer) (uint64, error) {
+	data, err := b.JSONEnsuredBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	out := struct {
+		Logs []struct {
+			Events []struct {
+				Type  string `json:"type"`
+				Attrs []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"attributes"`
+			} `json:"events"`
+		} `json:"logs"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return 0, err
+	}
+
+	for _, log := range out.Logs {
+		for _, e := range log.Events {
+			if e.Type != "submit_proposal" {
+				continue
+			}
+			for _, attr := range e.Attrs {
+				if attr.Key == "proposal_id" {
+					return strconv.ParseUint(attr.Value, 10, 64)
+				}
+			}
+		}
+	}
+	return 0, errors.New("the tx has no submitted proposal")
+}
+
+// GovProposal returns a proposal, its tally is the current tally during the voting period and
+// the final tally afterwards.
+func (r Runner) GovProposal(ctx context.Context, proposalID uint64) (GovProposal, error) {
+	id := strconv.FormatUint(proposalID, 10)
+
+	b := newBuffer()
+	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.GovProposalCommand(id)); err != nil {
+		return GovProposal{}, err
+	}
+
+	data, err := b.JSONEnsuredBytes()
+	if err != nil {
+		return GovProposal{}, err
+	}
+
+	out := struct {
+		Content struct {
+			Type  string `json:"@type"`
+			Title string `json:"title"`
+		} `json:"content"`
+		Status           string    `json:"status"`
+		FinalTallyResult GovTally  `json:"final_tally_result"`
+		VotingStartTime  time.Time `json:"voting_start_time"`
+		VotingEndTime    time.Time `json:"voting_end_time"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return GovProposal{}, err
+	}
+
+	p := GovProposal{
+		ID:              proposalID,
+		Type:            out.Content.Type,
+		Title:           out.Content.Title,
+		Status:          out.Status,
+		VotingStartTime: out.VotingStartTime,
+		VotingEndTime:   out.VotingEndTime,
+		Tally:           out.FinalTallyResult,
+	}
+
+	if p.Status != GovProposalStatusVotingPeriod {
+		return p, nil
+	}
+
+	b = newBuffer()
+	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.GovTallyCommand(id)); err != nil {
+		return GovProposal{}, err
+	}
+	if data, err = b.JSONEnsuredBytes(); err != nil {
+		return GovProposal{}, err
+	}
+	if err := json.Unmarshal(data, &p.Tally); err != nil {
+		return GovProposal{}, err
+	}
+
+	return p, nil
+}
+
+// GovParams returns the params of the gov module.
+func (r Runner) GovParams(ctx context.Context) (GovParams, error) {
+	b := newBuffer()
+	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.GovParamsCommand()); err != nil {
+		return GovParams{}, err
+	}
+
+	data, err := b.JSONEnsuredBytes()
+	if err != nil {
+		return GovParams{}, err
+	}
+
+	out := struct {
+		VotingParams struct {
+			VotingPeriod json.RawMessage `json:"voting_period"`
+		} `json:"voting_params"`
+		DepositParams struct {
+			MinDeposit []struct {
+				Denom  string `json:"denom"`
+				Amount string `json:"amount"`
+			} `json:"min_deposit"`
+			MaxDepositPeriod json.RawMessage `json:"max_deposit_period"`
+		} `json:"deposit_params"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return GovParams{}, err
+	}
+
+	var params GovParams
+	for _, c := range out.DepositParams.MinDeposit {
+		amount, ok := sdk.NewIntFromString(c.Amount)
+		if !ok {
+			return GovParams{}, fmt.Errorf("invalid amount %q of %q denom", c.Amount, c.Denom)
+		}
+		params.MinDeposit = params.MinDeposit.Add(sdk.NewCoin(c.Denom, amount))
+	}
+	if params.VotingPeriod, err = decodeDuration(out.VotingParams.VotingPeriod); err != nil {
+		return GovParams{}, err
+	}
+	if params.MaxDepositPeriod, err = decodeDuration(out.DepositParams.MaxDepositPeriod); err != nil {
+		return GovParams{}, err
+	}
+
+	return params, nil
+}
+
+// decodeDuration decodes a duration encoded by Amino in nanoseconds or by proto, e.g. "172800s".
+func decodeDuration(data json.RawMessage) (time.Duration, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return 0, fmt.Errorf("invalid duration %s", data)
+		}
+		return time.Duration(n), nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	return time.ParseDuration(s)
+}
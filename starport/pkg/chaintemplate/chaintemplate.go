@@ -0,0 +1,253 @@
+// Package chaintemplate renders the chain templates kept in git repositories, so organizations
+// can scaffold their chains from a standard starting point instead of the built-in template.
+//
+// A template is a directory with a starport.template.yml manifest at its root that describes the
+// variables of the template. the files ending with .plush are rendered with the variables and
+// saved without the extension, the {{Variable}} placeholders in the paths of the files are
+// replaced by the values of the variables and the other files are copied as they are.
+package chaintemplate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/gobuffalo/plush"
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// ManifestFile is the file at the root of a template that describes it.
+	ManifestFile = "starport.template.yml"
+
+	// plushExt is the extension of the files rendered with the variables.
+	plushExt = ".plush"
+)
+
+// Manifest describes a template.
+type Manifest struct {
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	Variables   []Variable `yaml:"variables"`
+
+	// Exclude lists the glob patterns of the paths in the template that are not scaffolded.
+	Exclude []string `yaml:"exclude"`
+}
+
+// Variable is a variable substituted in the template.
+type Variable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+
+	// Default is the value of the variable when it's not given, the variable is required when it
+	// has no default.
+	Default string `yaml:"default"`
+}
+
+// Template is a chain template.
+type Template struct {
+	Manifest Manifest
+
+	path    string
+	cleanup func()
+}
+
+// Open opens the template at source, the source is a local directory or the URL of a git
+// repository that is cloned. the cloned templates are removed by Close.
+func Open(ctx context.Context, source string) (*Template, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return Load(source)
+	}
+
+	path, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+	cleanup := func() { os.RemoveAll(path) }
+
+	if _, err := git.PlainCloneContext(ctx, path, false, &git.CloneOptions{
+		URL:   source,
+		Depth: 1,
+	}); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("cannot clone the template %s: %w", source, err)
+	}
+
+	t, err := Load(path)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	t.cleanup = cleanup
+	return t, nil
+}
+
+// Load loads the template in the directory at path.
+func Load(path string) (*Template, error) {
+	data, err := os.ReadFile(filepath.Join(path, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the manifest of the template: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest of the template: %w", err)
+	}
+	for _, v := range m.Variables {
+		if v.Name == "" {
+			return nil, fmt.Errorf("invalid manifest of the template: a variable has no name")
+		}
+	}
+
+	return &Template{
+		Manifest: m,
+		path:     path,
+	}, nil
+}
+
+// Close removes the template when it's cloned.
+func (t *Template) Close() {
+	if t.cleanup != nil {
+		t.cleanup()
+	}
+}
+
+// Values returns the values of the variables of the template, the variables that are not given
+// get their defaults. builtins are the values set by Starport, they are available to the template
+// without being declared in its manifest.
+func (t *Template) Values(given, builtins map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(builtins)+len(t.Manifest.Variables))
+	for name, value := range builtins {
+		values[name] = value
+	}
+
+	declared := make(map[string]bool, len(t.Manifest.Variables))
+	var missing []string
+	for _, v := range t.Manifest.Variables {
+		declared[v.Name] = true
+		value, ok := given[v.Name]
+		switch {
+		case ok:
+		case v.Default != "":
+			value = v.Default
+		case builtins[v.Name] != "":
+			value = builtins[v.Name]
+		default:
+			missing = append(missing, v.Name)
+		}
+		values[v.Name] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing values of the template variables: %s", strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	for name := range given {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown template variables: %s", strings.Join(unknown, ", "))
+	}
+
+	return values, nil
+}
+
+// Render renders the template with the values of the variables to the directory at dst.
+func (t *Template) Render(dst string, values map[string]string) error {
+	ctx := plush.NewContext()
+	for name, value := range values {
+		ctx.Set(name, value)
+	}
+
+	return filepath.WalkDir(t.path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(t.path, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if rel == ".git" || rel == ManifestFile || t.isExcluded(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// the symlinks of the template are skipped, so they cannot copy files from outside of it.
+		if d.IsDir() || d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(rel, plushExt) {
+			rendered, err := plush.Render(string(content), ctx)
+			if err != nil {
+				return fmt.Errorf("cannot render %s: %w", rel, err)
+			}
+			content = []byte(rendered)
+			rel = strings.TrimSuffix(rel, plushExt)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		target, err := renderPath(dst, rel, values)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, content, info.Mode().Perm())
+	})
+}
+
+// isExcluded checks if the path of the template matches an excluded pattern.
+func (t *Template) isExcluded(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range t.Manifest.Exclude {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// renderPath returns the path in dst of the file of the template at rel, the placeholders of its
+// path are replaced with the values. the path must stay in dst.
+func renderPath(dst, rel string, values map[string]string) (string, error) {
+	target := filepath.Join(dst, replacePlaceholders(rel, values))
+	targetRel, err := filepath.Rel(dst, target)
+	if err != nil {
+		return "", err
+	}
+	if targetRel == "." || targetRel == ".." || strings.HasPrefix(targetRel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("the path %s of the template is outside of the destination", rel)
+	}
+	return target, nil
+}
+
+// replacePlaceholders replaces the {{Variable}} placeholders in the path.
+func replacePlaceholders(path string, values map[string]string) string {
+	for name, value := range values {
+		path = strings.ReplaceAll(path, "{{"+name+"}}", value)
+	}
+	return path
+}
@@ -0,0 +1,102 @@
+package chaintemplate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/chaintemplate"
+)
+
+const manifest = `name: company
+description: Company chain template
+variables:
+  - name: Denom
+    description: Staking denom of the chain
+  - name: Team
+    default: core
+exclude:
+  - docs/*
+`
+
+func writeTemplate(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		chaintemplate.ManifestFile:          manifest,
+		"config.yml.plush":                  "denom: <%= Denom %>\nteam: <%= Team %>\n",
+		"cmd/{{BinaryNamePrefix}}d/main.go": "package main\n",
+		"docs/internal.md":                  "internal",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestRender(t *testing.T) {
+	tpl, err := chaintemplate.Open(context.Background(), writeTemplate(t))
+	require.NoError(t, err)
+	defer tpl.Close()
+	require.Equal(t, "company", tpl.Manifest.Name)
+
+	values, err := tpl.Values(
+		map[string]string{"Denom": "umars"},
+		map[string]string{"BinaryNamePrefix": "mars"},
+	)
+	require.NoError(t, err)
+
+	dst := t.TempDir()
+	require.NoError(t, tpl.Render(dst, values))
+
+	config, err := os.ReadFile(filepath.Join(dst, "config.yml"))
+	require.NoError(t, err)
+	require.Equal(t, "denom: umars\nteam: core\n", string(config))
+
+	require.FileExists(t, filepath.Join(dst, "cmd/marsd/main.go"))
+	require.NoFileExists(t, filepath.Join(dst, "docs/internal.md"))
+	require.NoFileExists(t, filepath.Join(dst, chaintemplate.ManifestFile))
+}
+
+func TestRenderOutsideDestination(t *testing.T) {
+	dir := writeTemplate(t)
+	require.NoError(t, os.Symlink("/etc/hostname", filepath.Join(dir, "hostname")))
+
+	tpl, err := chaintemplate.Load(dir)
+	require.NoError(t, err)
+
+	values, err := tpl.Values(
+		map[string]string{"Denom": "umars"},
+		map[string]string{"BinaryNamePrefix": "mars"},
+	)
+	require.NoError(t, err)
+
+	// the symlinks are skipped.
+	dst := t.TempDir()
+	require.NoError(t, tpl.Render(dst, values))
+	require.NoFileExists(t, filepath.Join(dst, "hostname"))
+
+	// the placeholders cannot move the files out of the destination.
+	values["BinaryNamePrefix"] = "../../mars"
+	require.Error(t, tpl.Render(t.TempDir(), values))
+}
+
+func TestValues(t *testing.T) {
+	tpl, err := chaintemplate.Load(writeTemplate(t))
+	require.NoError(t, err)
+
+	_, err = tpl.Values(nil, nil)
+	require.EqualError(t, err, "missing values of the template variables: Denom")
+
+	_, err = tpl.Values(map[string]string{"Denom": "umars", "Foo": "bar"}, nil)
+	require.EqualError(t, err, "unknown template variables: Foo")
+}
+
+func TestLoadNoManifest(t *testing.T) {
+	_, err := chaintemplate.Load(t.TempDir())
+	require.Error(t, err)
+}
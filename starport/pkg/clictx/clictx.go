@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"syscall"
 )
 
 // From creates a new context from ctx that is canceled when an exit signal received.
@@ -12,7 +13,9 @@ func From(ctx context.Context) context.Context {
 		ctxend, cancel = context.WithCancel(ctx)
 		quit           = make(chan os.Signal, 1)
 	)
-	signal.Notify(quit, os.Interrupt)
+	// SIGTERM is sent by the service managers and the containers to stop a process, it's never
+	// sent on Windows.
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-quit
 		cancel()
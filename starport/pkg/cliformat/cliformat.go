@@ -0,0 +1,93 @@
+// Package cliformat writes the output of the commands in the table, JSON or YAML formats, so the
+// output of the commands can be parsed by scripts.
+package cliformat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// Table is the human readable format of the commands.
+	Table Format = "table"
+
+	// JSON writes the output as indented JSON.
+	JSON Format = "json"
+
+	// YAML writes the output as YAML.
+	YAML Format = "yaml"
+)
+
+// Formats is the list of the supported formats.
+var Formats = []Format{Table, JSON, YAML}
+
+// Format is an output format, it implements pflag.Value to be used as a flag.
+type Format string
+
+// Parse parses an output format.
+func Parse(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format %q, expected one of %s", s, formatList())
+}
+
+// String implements pflag.Value.
+func (f Format) String() string {
+	if f == "" {
+		return string(Table)
+	}
+	return string(f)
+}
+
+// Set implements pflag.Value.
+func (f *Format) Set(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// Type implements pflag.Value.
+func (Format) Type() string {
+	return "format"
+}
+
+// Write writes v to out in the format, table writes v in the table format. the field names of
+// the JSON and YAML outputs are the json tags of v and must be kept stable for the scripts.
+func Write(out io.Writer, format Format, v interface{}, table func(out io.Writer) error) error {
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s\n", data)
+		return err
+	case YAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	default:
+		return table(out)
+	}
+}
+
+func formatList() string {
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
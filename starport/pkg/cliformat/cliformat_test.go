@@ -0,0 +1,75 @@
+package cliformat_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cliformat"
+)
+
+type account struct {
+	Name      string   `json:"name"`
+	PublicKey string   `json:"public_key"`
+	Coins     []string `json:"coins"`
+}
+
+func TestWrite(t *testing.T) {
+	accounts := []account{{Name: "alice", PublicKey: "key", Coins: []string{"10token"}}}
+	table := func(out io.Writer) error {
+		_, err := fmt.Fprintln(out, "alice")
+		return err
+	}
+
+	tests := []struct {
+		format cliformat.Format
+		want   string
+	}{
+		{
+			format: cliformat.Table,
+			want:   "alice\n",
+		},
+		{
+			format: cliformat.JSON,
+			want: `[
+  {
+    "name": "alice",
+    "public_key": "key",
+    "coins": [
+      "10token"
+    ]
+  }
+]
+`,
+		},
+		{
+			format: cliformat.YAML,
+			want: `- name: alice
+  public_key: key
+  coins:
+  - 10token
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, cliformat.Write(&out, tt.format, accounts, table))
+			require.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestFormatSet(t *testing.T) {
+	var f cliformat.Format
+	require.Equal(t, "table", f.String())
+
+	require.NoError(t, f.Set("yaml"))
+	require.Equal(t, cliformat.YAML, f)
+
+	require.EqualError(t, f.Set("xml"), `invalid output format "xml", expected one of table, json, yaml`)
+	require.Equal(t, cliformat.YAML, f)
+}
@@ -0,0 +1,45 @@
+package cliquiz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// ErrInputRequired is returned when a question must be answered while the input is disabled.
+var ErrInputRequired = errors.New("an answer is required but the input is disabled")
+
+var (
+	inputDisabled bool
+	assumeYes     bool
+)
+
+// DisableInput disables the prompts so the questions can be answered unattended, e.g. in CI.
+func DisableInput(disabled bool) {
+	inputDisabled = disabled
+}
+
+// AssumeYes answers the yes/no questions with yes without prompting.
+func AssumeYes(yes bool) {
+	assumeYes = yes
+}
+
+// Confirm asks a yes/no question and returns true when it's answered with yes. ErrInputRequired is
+// returned when the input is disabled and the yes/no questions are not answered with yes.
+func Confirm(question string) (ok bool, err error) {
+	if assumeYes {
+		return true, nil
+	}
+	if inputDisabled {
+		return false, fmt.Errorf("%w: %s", ErrInputRequired, question)
+	}
+
+	err = survey.AskOne(&survey.Confirm{Message: question}, &ok)
+	if err == terminal.InterruptErr {
+		err = context.Canceled
+	}
+	return ok, err
+}
@@ -0,0 +1,50 @@
+package cliquiz_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cliquiz"
+)
+
+func TestAskInputDisabled(t *testing.T) {
+	cliquiz.DisableInput(true)
+	defer cliquiz.DisableInput(false)
+
+	var (
+		amount   string
+		gasLimit int64
+		website  string
+	)
+	err := cliquiz.Ask(
+		cliquiz.NewQuestion("Staking amount", &amount, cliquiz.DefaultAnswer("95000000stake"), cliquiz.Required()),
+		cliquiz.NewQuestion("Gas limit", &gasLimit, cliquiz.DefaultAnswer(300000), cliquiz.Required()),
+		cliquiz.NewQuestion("Website", &website),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "95000000stake", amount)
+	require.Equal(t, int64(300000), gasLimit)
+	require.Empty(t, website)
+
+	var secret string
+	err = cliquiz.Ask(cliquiz.NewQuestion("Mnemonic", &secret, cliquiz.Required()))
+	require.True(t, errors.Is(err, cliquiz.ErrInputRequired))
+	require.EqualError(t, err, "an answer is required but the input is disabled: Mnemonic")
+}
+
+func TestConfirmInputDisabled(t *testing.T) {
+	cliquiz.DisableInput(true)
+	defer cliquiz.DisableInput(false)
+
+	_, err := cliquiz.Confirm("Overwrite")
+	require.True(t, errors.Is(err, cliquiz.ErrInputRequired))
+
+	cliquiz.AssumeYes(true)
+	defer cliquiz.AssumeYes(false)
+
+	ok, err := cliquiz.Confirm("Overwrite")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
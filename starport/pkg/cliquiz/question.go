@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/spf13/pflag"
 )
@@ -117,8 +118,14 @@ func ask(q Question) error {
 	return nil
 }
 
-// Ask asks questions and collect answers.
+// Ask asks questions and collect answers. when the input is disabled, the questions are answered
+// with their default answers and ErrInputRequired is returned for the required questions without
+// a default answer.
 func Ask(question ...Question) (err error) {
+	if inputDisabled {
+		return answerWithDefaults(question...)
+	}
+
 	defer func() {
 		if err == terminal.InterruptErr {
 			err = context.Canceled
@@ -154,6 +161,22 @@ func Ask(question ...Question) (err error) {
 	return nil
 }
 
+// answerWithDefaults answers the questions with their default answers without prompting.
+func answerWithDefaults(question ...Question) error {
+	for _, q := range question {
+		if q.defaultAnswer == nil {
+			if q.required {
+				return fmt.Errorf("%w: %s", ErrInputRequired, q.question)
+			}
+			continue
+		}
+		if err := core.WriteAnswer(q.answer, "", fmt.Sprintf("%v", q.defaultAnswer)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Flag represents a cmd flag.
 type Flag struct {
 	Name       string
@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package cmdrunner
+
+import "os"
+
+// signal signals s to the process.
+func signal(p *os.Process, s os.Signal) {
+	p.Signal(s)
+}
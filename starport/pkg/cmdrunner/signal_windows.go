@@ -0,0 +1,11 @@
+package cmdrunner
+
+import "os"
+
+// signal signals s to the process. the processes can't be interrupted on Windows, only os.Kill can
+// be signaled, so the process is killed when s can't be signaled.
+func signal(p *os.Process, s os.Signal) {
+	if err := p.Signal(s); err != nil {
+		p.Kill()
+	}
+}
@@ -1,17 +1,27 @@
 package cosmosaccount
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	dkeyring "github.com/99designs/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/go-bip39"
+
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
 )
 
 const (
@@ -27,6 +37,9 @@ var KeyringHome = os.ExpandEnv("$HOME/.starport/accounts")
 
 var (
 	ErrAccountExists = errors.New("account already exists")
+
+	// ErrNotMultisig is returned when an account is expected to be a multisig account.
+	ErrNotMultisig = errors.New("account is not a multisig account")
 )
 
 const (
@@ -44,6 +57,10 @@ const (
 	// KeyringOS is the OS keyring backend. with this backend, your keys will be
 	// stored in your operating system's secured keyring.
 	KeyringOS KeyringBackend = "os"
+
+	// KeyringMemory is the in-memory keyring backend. With this backend, your keys will be
+	// lost when the registry is not used anymore, it's useful for testing.
+	KeyringMemory KeyringBackend = "memory"
 )
 
 // Registry for accounts.
@@ -90,7 +107,15 @@ func New(options ...Option) (Registry, error) {
 
 	var err error
 
-	r.Keyring, err = keyring.New(r.keyringServiceName, string(r.keyringBackend), r.homePath, os.Stdin)
+	r.Keyring, err = keyring.New(
+		r.keyringServiceName,
+		string(r.keyringBackend),
+		r.homePath,
+		os.Stdin,
+		func(o *keyring.Options) {
+			o.SupportedAlgos = keyring.SigningAlgoList{hd.Secp256k1, ethsecp256k1.Algo}
+		},
+	)
 	if err != nil {
 		return Registry{}, err
 	}
@@ -107,6 +132,39 @@ func NewStandalone(options ...Option) (Registry, error) {
 	)
 }
 
+// AccountOption configures the creation and import of an account.
+type AccountOption func(*accountOptions)
+
+type accountOptions struct {
+	hdPath   string
+	coinType *uint32
+	keyType  hd.PubKeyType
+}
+
+// WithHDPath sets the BIP44 HD path to derive the private key of the account from its mnemonic,
+// e.g. m/44'/118'/0'/0/0. it overwrites the coin type set by WithCoinType.
+func WithHDPath(path string) AccountOption {
+	return func(o *accountOptions) {
+		o.hdPath = path
+	}
+}
+
+// WithCoinType sets the coin type of the default HD path to derive the private key of the account
+// from its mnemonic. by default, the coin type of the SDK config is used, or 60 for eth_secp256k1 keys.
+func WithCoinType(coinType uint32) AccountOption {
+	return func(o *accountOptions) {
+		o.coinType = &coinType
+	}
+}
+
+// WithKeyType sets the type of the private key of the account, secp256k1 keys are used by default.
+// eth_secp256k1 keys are used by Ethermint based chains.
+func WithKeyType(keyType hd.PubKeyType) AccountOption {
+	return func(o *accountOptions) {
+		o.keyType = keyType
+	}
+}
+
 // Account represents an Cosmos SDK account.
 type Account struct {
 	// Name of the account.
@@ -125,11 +183,29 @@ func (a Account) Address(accPrefix string) string {
 	return toBench32(accPrefix, a.Info.GetPubKey().Address())
 }
 
-// PubKey returns a public key for account.
+// PubKey returns a public key for account. The threshold and the number of members are
+// returned for multisig accounts, see Multisig to get public keys of the members.
 func (a Account) PubKey() string {
+	if threshold, pubKeys, err := a.Multisig(); err == nil {
+		return fmt.Sprintf("multisig %d-of-%d", threshold, len(pubKeys))
+	}
 	return a.Info.GetPubKey().String()
 }
 
+// IsMultisig checks if the account is a multisig account.
+func (a Account) IsMultisig() bool {
+	return a.Info.GetType() == keyring.TypeMulti
+}
+
+// Multisig returns the threshold and the public keys of the members of a multisig account.
+func (a Account) Multisig() (threshold int, pubKeys []cryptotypes.PubKey, err error) {
+	pubKey, ok := a.Info.GetPubKey().(*multisig.LegacyAminoPubKey)
+	if !a.IsMultisig() || !ok {
+		return 0, nil, ErrNotMultisig
+	}
+	return int(pubKey.GetThreshold()), pubKey.GetPubKeys(), nil
+}
+
 func toBench32(prefix string, addr []byte) string {
 	bech32Addr, err := bech32.ConvertAndEncode(prefix, addr)
 	if err != nil {
@@ -152,7 +228,7 @@ func (r Registry) EnsureDefaultAccount() error {
 }
 
 // Create creates a new account with name.
-func (r Registry) Create(name string) (acc Account, mnemonic string, err error) {
+func (r Registry) Create(name string, options ...AccountOption) (acc Account, mnemonic string, err error) {
 	acc, err = r.GetByName(name)
 	if err == nil {
 		return Account{}, "", ErrAccountExists
@@ -171,11 +247,16 @@ func (r Registry) Create(name string) (acc Account, mnemonic string, err error)
 		return Account{}, "", err
 	}
 
-	algo, err := r.algo()
+	o := newAccountOptions(options...)
+	hdPath, err := o.hdPathOrDefault()
+	if err != nil {
+		return Account{}, "", err
+	}
+	algo, err := r.algo(o.keyType)
 	if err != nil {
 		return Account{}, "", err
 	}
-	info, err := r.Keyring.NewAccount(name, mnemonic, "", r.hdPath(), algo)
+	info, err := r.Keyring.NewAccount(name, mnemonic, "", hdPath, algo)
 	if err != nil {
 		return Account{}, "", err
 	}
@@ -188,9 +269,65 @@ func (r Registry) Create(name string) (acc Account, mnemonic string, err error)
 	return acc, mnemonic, nil
 }
 
+// CreateMultisig creates a new multisig account with name by combining the public keys of the member accounts,
+// a tx of the multisig account needs to be signed by at least threshold of the members. public keys are sorted
+// by their addresses, so the same members always create the same multisig account.
+func (r Registry) CreateMultisig(name string, threshold int, members ...string) (Account, error) {
+	_, err := r.GetByName(name)
+	if err == nil {
+		return Account{}, ErrAccountExists
+	}
+	var accErr *AccountDoesNotExistError
+	if !errors.As(err, &accErr) {
+		return Account{}, err
+	}
+
+	if threshold <= 0 {
+		return Account{}, errors.New("threshold must be a positive number")
+	}
+	if threshold > len(members) {
+		return Account{}, fmt.Errorf("threshold %d cannot be greater than the number of members %d", threshold, len(members))
+	}
+
+	var pubKeys []cryptotypes.PubKey
+	seen := make(map[string]bool)
+
+	for _, member := range members {
+		if seen[member] {
+			return Account{}, fmt.Errorf("duplicate member %q", member)
+		}
+		seen[member] = true
+
+		acc, err := r.GetByName(member)
+		if err != nil {
+			return Account{}, err
+		}
+		if acc.IsMultisig() {
+			return Account{}, fmt.Errorf("member %q cannot be a multisig account", member)
+		}
+		pubKeys = append(pubKeys, acc.Info.GetPubKey())
+	}
+
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i].Address(), pubKeys[j].Address()) < 0
+	})
+
+	info, err := r.Keyring.SaveMultisig(name, multisig.NewLegacyAminoPubKey(threshold, pubKeys))
+	if err != nil {
+		return Account{}, err
+	}
+
+	return Account{
+		Name: name,
+		Info: info,
+	}, nil
+}
+
 // Import imports an existing account with name and passphrase and secret where secret can be a
-// mnemonic or a private key.
-func (r Registry) Import(name, secret, passphrase string) (Account, error) {
+// mnemonic, an armored private key, a private key in hex or an encrypted JSON keystore.
+// passphrase is used to decrypt the armored private key and the keystore. options are only
+// used while importing a mnemonic.
+func (r Registry) Import(name, secret, passphrase string, options ...AccountOption) (Account, error) {
 	_, err := r.GetByName(name)
 	if err == nil {
 		return Account{}, ErrAccountExists
@@ -200,22 +337,79 @@ func (r Registry) Import(name, secret, passphrase string) (Account, error) {
 		return Account{}, err
 	}
 
-	if bip39.IsMnemonicValid(secret) {
-		algo, err := r.algo()
+	secret = strings.TrimSpace(secret)
+	o := newAccountOptions(options...)
+
+	switch {
+	case bip39.IsMnemonicValid(secret):
+		hdPath, err := o.hdPathOrDefault()
 		if err != nil {
 			return Account{}, err
 		}
-		_, err = r.Keyring.NewAccount(name, secret, passphrase, r.hdPath(), algo)
+		algo, err := r.algo(o.keyType)
 		if err != nil {
 			return Account{}, err
 		}
-	} else if err := r.Keyring.ImportPrivKey(name, secret, passphrase); err != nil {
-		return Account{}, err
+		if _, err := r.Keyring.NewAccount(name, secret, passphrase, hdPath, algo); err != nil {
+			return Account{}, err
+		}
+
+	case IsHexPrivKey(secret):
+		key, _ := hex.DecodeString(strings.TrimPrefix(secret, "0x"))
+		if err := r.importRawPrivKey(name, key, o.keyType); err != nil {
+			return Account{}, err
+		}
+
+	case isKeystore(secret):
+		key, err := decryptKeystore([]byte(secret), passphrase)
+		if err != nil {
+			return Account{}, err
+		}
+		if err := r.importRawPrivKey(name, key, o.keyType); err != nil {
+			return Account{}, err
+		}
+
+	default:
+		if err := r.Keyring.ImportPrivKey(name, secret, passphrase); err != nil {
+			return Account{}, err
+		}
 	}
 
 	return r.GetByName(name)
 }
 
+// importRawPrivKey imports a raw private key of the key type, the keyring only imports armored keys
+// so the key is armored with a temporary passphrase first.
+func (r Registry) importRawPrivKey(name string, key []byte, keyType hd.PubKeyType) error {
+	if len(key) != secp256k1.PrivKeySize {
+		return fmt.Errorf("private key must be %d bytes, got %d", secp256k1.PrivKeySize, len(key))
+	}
+
+	var privKey cryptotypes.PrivKey
+	switch keyType {
+	case hd.Secp256k1Type:
+		privKey = &secp256k1.PrivKey{Key: key}
+	case ethsecp256k1.Type:
+		privKey = &ethsecp256k1.PrivKey{Key: key}
+	default:
+		return fmt.Errorf("key type %q is not supported", keyType)
+	}
+
+	const armorPassphrase = "import"
+	armor := crypto.EncryptArmorPrivKey(privKey, armorPassphrase, string(keyType))
+	return r.Keyring.ImportPrivKey(name, armor, armorPassphrase)
+}
+
+// IsHexPrivKey checks if secret is a secp256k1 private key in hex, optionally prefixed with 0x.
+func IsHexPrivKey(secret string) bool {
+	key := strings.TrimPrefix(strings.TrimSpace(secret), "0x")
+	if len(key) != hex.EncodedLen(secp256k1.PrivKeySize) {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
 // Export exports an account as a private key.
 func (r Registry) Export(name, passphrase string) (key string, err error) {
 	if _, err = r.GetByName(name); err != nil {
@@ -226,6 +420,16 @@ func (r Registry) Export(name, passphrase string) (key string, err error) {
 
 }
 
+// ExportPubKey exports the public key of an account in armor format, multisig accounts can only be
+// exported this way since they don't have private keys.
+func (r Registry) ExportPubKey(name string) (key string, err error) {
+	if _, err = r.GetByName(name); err != nil {
+		return "", err
+	}
+
+	return r.Keyring.ExportPubKeyArmor(name)
+}
+
 // ExportHex exports an account as a private key in hex.
 func (r Registry) ExportHex(name, passphrase string) (hex string, err error) {
 	if _, err = r.GetByName(name); err != nil {
@@ -235,6 +439,29 @@ func (r Registry) ExportHex(name, passphrase string) (hex string, err error) {
 	return keyring.NewUnsafe(r.Keyring).UnsafeExportPrivKeyHex(name)
 }
 
+// ExportKeystore exports an account as a JSON keystore where the private key is encrypted with passphrase.
+func (r Registry) ExportKeystore(name, passphrase string) ([]byte, error) {
+	acc, err := r.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required to encrypt the keystore")
+	}
+
+	keyHex, err := keyring.NewUnsafe(r.Keyring).UnsafeExportPrivKeyHex(name)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryptKeystore(key, hex.EncodeToString(acc.Info.GetAddress()), passphrase)
+}
+
 // GetByName returns an account by its name.
 func (r Registry) GetByName(name string) (Account, error) {
 	info, err := r.Keyring.Key(name)
@@ -281,13 +508,38 @@ func (r Registry) DeleteByName(name string) error {
 	return err
 }
 
-func (r Registry) hdPath() string {
-	return hd.CreateHDPath(sdktypes.GetConfig().GetCoinType(), 0, 0).String()
+func newAccountOptions(options ...AccountOption) accountOptions {
+	o := accountOptions{
+		keyType: hd.Secp256k1Type,
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+	return o
+}
+
+func (o accountOptions) hdPathOrDefault() (string, error) {
+	if o.hdPath != "" {
+		if _, err := hd.NewParamsFromPath(o.hdPath); err != nil {
+			return "", fmt.Errorf("invalid hd path %q: %w", o.hdPath, err)
+		}
+		return o.hdPath, nil
+	}
+
+	coinType := sdktypes.GetConfig().GetCoinType()
+	switch {
+	case o.coinType != nil:
+		coinType = *o.coinType
+	case o.keyType == ethsecp256k1.Type:
+		coinType = ethsecp256k1.CoinType
+	}
+
+	return hd.CreateHDPath(coinType, 0, 0).String(), nil
 }
 
-func (r Registry) algo() (keyring.SignatureAlgo, error) {
+func (r Registry) algo(keyType hd.PubKeyType) (keyring.SignatureAlgo, error) {
 	algos, _ := r.Keyring.SupportedAlgorithms()
-	return keyring.NewSigningAlgoFromString(string(hd.Secp256k1Type), algos)
+	return keyring.NewSigningAlgoFromString(string(keyType), algos)
 }
 
 type AccountDoesNotExistError struct {
@@ -0,0 +1,198 @@
+package cosmosaccount_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
+)
+
+func TestCreateMultisig(t *testing.T) {
+	r, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob", "carol"} {
+		_, _, err := r.Create(name)
+		require.NoError(t, err)
+	}
+
+	acc, err := r.CreateMultisig("team", 2, "alice", "bob", "carol")
+	require.NoError(t, err)
+	require.True(t, acc.IsMultisig())
+
+	threshold, pubKeys, err := acc.Multisig()
+	require.NoError(t, err)
+	require.Equal(t, 2, threshold)
+	require.Len(t, pubKeys, 3)
+
+	// the order of the members doesn't change the account, so it cannot be created twice.
+	other, err := r.CreateMultisig("team2", 2, "carol", "alice", "bob")
+	require.Error(t, err)
+	require.Empty(t, other.Name)
+
+	got, err := r.GetByName("team")
+	require.NoError(t, err)
+	require.Equal(t, acc.Address("cosmos"), got.Address("cosmos"))
+
+	// multisig accounts can only be exported as public keys.
+	_, err = r.Export("team", "pass")
+	require.Error(t, err)
+	armored, err := r.ExportPubKey("team")
+	require.NoError(t, err)
+	require.Contains(t, armored, "BEGIN TENDERMINT PUBLIC KEY")
+
+	alice, err := r.GetByName("alice")
+	require.NoError(t, err)
+	require.False(t, alice.IsMultisig())
+	_, _, err = alice.Multisig()
+	require.Equal(t, cosmosaccount.ErrNotMultisig, err)
+}
+
+func TestCreateMultisigInvalid(t *testing.T) {
+	r, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob"} {
+		_, _, err := r.Create(name)
+		require.NoError(t, err)
+	}
+
+	_, err = r.CreateMultisig("team", 3, "alice", "bob")
+	require.Error(t, err)
+
+	_, err = r.CreateMultisig("team", 0, "alice", "bob")
+	require.Error(t, err)
+
+	_, err = r.CreateMultisig("team", 1, "alice", "alice")
+	require.Error(t, err)
+
+	var accErr *cosmosaccount.AccountDoesNotExistError
+	_, err = r.CreateMultisig("team", 1, "alice", "dave")
+	require.ErrorAs(t, err, &accErr)
+
+	_, err = r.CreateMultisig("alice", 1, "bob")
+	require.Equal(t, cosmosaccount.ErrAccountExists, err)
+
+	_, err = r.CreateMultisig("team", 1, "alice", "bob")
+	require.NoError(t, err)
+	_, err = r.CreateMultisig("teams", 1, "team")
+	require.Error(t, err)
+}
+
+func TestImportFormats(t *testing.T) {
+	r, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+
+	acc, _, err := r.Create("alice")
+	require.NoError(t, err)
+	address := acc.Address("cosmos")
+
+	armored, err := r.Export("alice", "pass")
+	require.NoError(t, err)
+	keyHex, err := r.ExportHex("alice", "")
+	require.NoError(t, err)
+	keystore, err := r.ExportKeystore("alice", "pass")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		secret     string
+		passphrase string
+	}{
+		{"armor", armored, "pass"},
+		{"hex", keyHex, ""},
+		{"hex with prefix", "0x" + keyHex, ""},
+		{"keystore", string(keystore), "pass"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the same key cannot be imported twice to a registry.
+			r, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+			require.NoError(t, err)
+
+			imported, err := r.Import("alice", tt.secret, tt.passphrase)
+			require.NoError(t, err)
+			require.Equal(t, address, imported.Address("cosmos"))
+		})
+	}
+
+	_, err = r.Import("bob", string(keystore), "wrong")
+	require.Equal(t, cosmosaccount.ErrInvalidPassphrase, err)
+}
+
+func TestImportMnemonicHDPath(t *testing.T) {
+	r, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+
+	alice, mnemonic, err := r.Create("alice")
+	require.NoError(t, err)
+
+	other, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+	same, err := other.Import("same", mnemonic, "", cosmosaccount.WithHDPath("m/44'/118'/0'/0/0"))
+	require.NoError(t, err)
+	require.Equal(t, alice.Address("cosmos"), same.Address("cosmos"))
+
+	index, err := r.Import("index", mnemonic, "", cosmosaccount.WithHDPath("m/44'/118'/0'/0/1"))
+	require.NoError(t, err)
+	require.NotEqual(t, alice.Address("cosmos"), index.Address("cosmos"))
+
+	coinType, err := r.Import("coin-type", mnemonic, "", cosmosaccount.WithCoinType(60))
+	require.NoError(t, err)
+	require.NotEqual(t, alice.Address("cosmos"), coinType.Address("cosmos"))
+
+	_, err = r.Import("invalid", mnemonic, "", cosmosaccount.WithHDPath("m/44'/118'"))
+	require.Error(t, err)
+	_, _, err = r.Create("invalid", cosmosaccount.WithHDPath("invalid"))
+	require.Error(t, err)
+}
+
+func TestIsHexPrivKey(t *testing.T) {
+	key := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	require.True(t, cosmosaccount.IsHexPrivKey(key))
+	require.True(t, cosmosaccount.IsHexPrivKey("0x"+key))
+	require.False(t, cosmosaccount.IsHexPrivKey(key[:10]))
+	require.False(t, cosmosaccount.IsHexPrivKey("zz"+key[2:]))
+}
+
+func TestEthSecp256k1Account(t *testing.T) {
+	r, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+
+	// the address is derived like an Ethereum address.
+	acc, err := r.Import(
+		"alice",
+		"4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+		"",
+		cosmosaccount.WithKeyType(ethsecp256k1.Type),
+	)
+	require.NoError(t, err)
+	require.Equal(t, ethsecp256k1.Type, acc.Info.GetAlgo())
+	require.Equal(t, "2c7536e3605d9c16a7a3d7b1898e529396a65c23", hex.EncodeToString(acc.Info.GetAddress()))
+
+	// keys are derived with the coin type 60 by default.
+	bob, mnemonic, err := r.Create("bob", cosmosaccount.WithKeyType(ethsecp256k1.Type))
+	require.NoError(t, err)
+
+	other, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+	imported, err := other.Import(
+		"bob",
+		mnemonic,
+		"",
+		cosmosaccount.WithKeyType(ethsecp256k1.Type),
+		cosmosaccount.WithHDPath("m/44'/60'/0'/0/0"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, bob.Address("evmos"), imported.Address("evmos"))
+
+	signature, pubKey, err := r.Keyring.Sign("bob", []byte("hello"))
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature([]byte("hello"), signature))
+
+	_, _, err = r.Create("carol", cosmosaccount.WithKeyType("unknown"))
+	require.Error(t, err)
+}
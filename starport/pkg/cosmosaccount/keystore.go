@@ -0,0 +1,177 @@
+package cosmosaccount
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// keystore parameters, these are the same with the defaults of the Ethereum keystores.
+const (
+	keystoreVersion = 3
+	keystoreCipher  = "aes-128-ctr"
+	keystoreKDF     = "scrypt"
+	keystoreScryptN = 1 << 18
+	keystoreScryptR = 8
+	keystoreScryptP = 1
+	keystoreKeyLen  = 32
+)
+
+// ErrInvalidPassphrase is returned when a keystore cannot be decrypted with the passphrase.
+var ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+// keystore is an encrypted JSON keystore in Web3 Secret Storage format (version 3).
+type keystore struct {
+	Version int            `json:"version"`
+	ID      string         `json:"id"`
+	Address string         `json:"address"`
+	Crypto  keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string               `json:"cipher"`
+	CipherText   string               `json:"ciphertext"`
+	CipherParams keystoreCipherParams `json:"cipherparams"`
+	KDF          string               `json:"kdf"`
+	KDFParams    keystoreKDFParams    `json:"kdfparams"`
+	MAC          string               `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+type keystoreKDFParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	Salt  string `json:"salt"`
+}
+
+// isKeystore checks if secret looks like a JSON keystore.
+func isKeystore(secret string) bool {
+	return strings.HasPrefix(strings.TrimSpace(secret), "{")
+}
+
+// encryptKeystore encrypts the private key with passphrase into a JSON keystore,
+// address is the hex encoded address of the key.
+func encryptKeystore(privKey []byte, address, passphrase string) ([]byte, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	id := make([]byte, 16)
+	for _, b := range [][]byte{salt, iv, id} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, keystoreScryptN, keystoreScryptR, keystoreScryptP, keystoreKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTR(derivedKey[:16], iv, privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// set the version and variant bits of a random UUID.
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	return json.MarshalIndent(keystore{
+		Version: keystoreVersion,
+		ID:      fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Address: address,
+		Crypto: keystoreCrypto{
+			Cipher:       keystoreCipher,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: keystoreCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          keystoreKDF,
+			KDFParams: keystoreKDFParams{
+				DKLen: keystoreKeyLen,
+				N:     keystoreScryptN,
+				R:     keystoreScryptR,
+				P:     keystoreScryptP,
+				Salt:  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keystoreMAC(derivedKey, cipherText)),
+		},
+	}, "", "  ")
+}
+
+// decryptKeystore decrypts the private key in a JSON keystore with passphrase.
+func decryptKeystore(content []byte, passphrase string) ([]byte, error) {
+	var ks keystore
+	if err := json.Unmarshal(content, &ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore: %w", err)
+	}
+
+	c := ks.Crypto
+	switch {
+	case ks.Version != keystoreVersion:
+		return nil, fmt.Errorf("keystore version %d is not supported", ks.Version)
+	case c.Cipher != keystoreCipher:
+		return nil, fmt.Errorf("keystore cipher %q is not supported", c.Cipher)
+	case c.KDF != keystoreKDF:
+		return nil, fmt.Errorf("keystore kdf %q is not supported", c.KDF)
+	}
+
+	var (
+		salt, errSalt       = hex.DecodeString(c.KDFParams.Salt)
+		iv, errIV           = hex.DecodeString(c.CipherParams.IV)
+		cipherText, errText = hex.DecodeString(c.CipherText)
+		mac, errMAC         = hex.DecodeString(c.MAC)
+	)
+	for _, err := range []error{errSalt, errIV, errText, errMAC} {
+		if err != nil {
+			return nil, fmt.Errorf("invalid keystore: %w", err)
+		}
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, c.KDFParams.N, c.KDFParams.R, c.KDFParams.P, c.KDFParams.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	if len(derivedKey) < 32 {
+		return nil, errors.New("invalid keystore: derived key is too short")
+	}
+
+	if !bytes.Equal(keystoreMAC(derivedKey, cipherText), mac) {
+		return nil, ErrInvalidPassphrase
+	}
+
+	return aesCTR(derivedKey[:16], iv, cipherText)
+}
+
+// keystoreMAC calculates the MAC of cipher text as Keccak-256(derivedKey[16:32] ++ cipherText).
+func keystoreMAC(derivedKey, cipherText []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(derivedKey[16:32])
+	h.Write(cipherText)
+	return h.Sum(nil)
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.New("invalid keystore: iv has an invalid length")
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
@@ -7,6 +7,9 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io/fs"
+	"sort"
+	"strings"
 
 	"golang.org/x/mod/modfile"
 )
@@ -19,7 +22,8 @@ const (
 // implementation tracks the implementation of an interface for a given struct
 type implementation map[string]bool
 
-// FindImplementation finds the name of all types that implement the provided interface
+// FindImplementation finds the name of all types that implement the provided interface,
+// the names are sorted so the code generated from them is the same on each run.
 func FindImplementation(modulePath string, interfaceList []string) (found []string, err error) {
 	// parse go packages/files under path
 	fset := token.NewFileSet()
@@ -76,10 +80,54 @@ func FindImplementation(modulePath string, interfaceList []string) (found []stri
 			found = append(found, name)
 		}
 	}
+	sort.Strings(found)
 
 	return found, nil
 }
 
+// FindConstructors finds the constructors of the types in the Go package at pkgPath, a constructor of a
+// type is the New<Type> func that returns the type or a pointer to it. the constructors are returned
+// by the names of their types.
+func FindConstructors(pkgPath string, types []string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, pkgPath, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]string, len(types))
+	for _, t := range types {
+		wanted["New"+t] = t
+	}
+
+	constructors := make(map[string]string)
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Recv != nil {
+					continue
+				}
+				typeName, ok := wanted[funcDecl.Name.Name]
+				if !ok || funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) != 1 {
+					continue
+				}
+
+				result := funcDecl.Type.Results.List[0].Type
+				if star, ok := result.(*ast.StarExpr); ok {
+					result = star.X
+				}
+				if ident, ok := result.(*ast.Ident); ok && ident.Name == typeName {
+					constructors[typeName] = funcDecl.Name.Name
+				}
+			}
+		}
+	}
+	return constructors, nil
+}
+
 // newImplementation returns a new object to parse implementation of an interface
 func newImplementation(interfaceList []string) implementation {
 	impl := make(implementation)
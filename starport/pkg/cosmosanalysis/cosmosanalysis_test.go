@@ -53,9 +53,7 @@ func TestFindImplementation(t *testing.T) {
 	// find in dir
 	found, err := cosmosanalysis.FindImplementation(tmpDir, expectedinterface)
 	require.NoError(t, err)
-	require.Len(t, found, 2)
-	require.Contains(t, found, "Foo")
-	require.Contains(t, found, "Foobar")
+	require.Equal(t, []string{"Foo", "Foobar"}, found)
 
 	// empty directory
 	emptyDir, err := os.MkdirTemp("", "cosmosanalysis_test")
@@ -69,3 +67,31 @@ func TestFindImplementation(t *testing.T) {
 	_, err = cosmosanalysis.FindImplementation(filepath.Join(tmpDir, "1.go"), expectedinterface)
 	require.Error(t, err)
 }
+
+func TestFindConstructors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tmpDir, "msgs.go"), []byte(`
+package foo
+
+type MsgFoo struct {}
+func NewMsgFoo() *MsgFoo { return &MsgFoo{} }
+
+type MsgBar struct {}
+func NewMsgBar() MsgFoo { return MsgFoo{} }
+
+type MsgFoobar struct {}
+func (MsgFoo) NewMsgFoobar() *MsgFoobar { return nil }
+`), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpDir, "msgs_test.go"), []byte(`
+package foo
+
+func NewMsgFoobar() *MsgFoobar { return nil }
+`), 0644)
+	require.NoError(t, err)
+
+	found, err := cosmosanalysis.FindConstructors(tmpDir, []string{"MsgFoo", "MsgBar", "MsgFoobar"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"MsgFoo": "NewMsgFoo"}, found)
+}
@@ -0,0 +1,190 @@
+// Package keeper provides a toolset for statically analysing the keepers of the modules of a
+// blockchain.
+package keeper
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const sdkTypesPath = "github.com/cosmos/cosmos-sdk/types"
+
+// Qualifier returns the name to refer to the package with the import path in a signature.
+type Qualifier func(path string) string
+
+// Method is a method of a keeper that can be declared by an expected keeper interface.
+type Method struct {
+	// Name of the method.
+	Name string
+
+	// signature is the declaration of the params and results of the method.
+	signature *ast.FuncType
+
+	// imports maps the names of the packages that the signature refers to, to their import paths.
+	imports map[string]string
+}
+
+// Imports returns the import paths of the packages that the signature of the method refers to.
+func (m Method) Imports() []string {
+	var paths []string
+	for _, path := range m.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Signature returns the method as declared in an interface, e.g. GetPost(ctx sdk.Context, id uint64) types.Post.
+// packages are named by qualifier.
+func (m Method) Signature(qualifier Qualifier) (string, error) {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), m.signature); err != nil {
+		return "", err
+	}
+
+	// parse the signature again to rename the packages without modifying the method.
+	expr, err := parser.ParseExpr(buf.String())
+	if err != nil {
+		return "", err
+	}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			ident.Name = qualifier(m.imports[ident.Name])
+		}
+		return false
+	})
+
+	buf.Reset()
+	if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+		return "", err
+	}
+	return m.Name + strings.TrimPrefix(buf.String(), "func"), nil
+}
+
+// Discover finds the methods of the keeper type with the name in the package at path that an
+// expected keeper interface can declare.
+// these are the exported methods with a value receiver and an sdk.Context as their first param,
+// the methods that refer to the types of the package of the keeper are skipped since they can't
+// be referred outside of the package without an import cycle.
+func Discover(path, typeName string) ([]Method, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, path, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []Method
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			imports := fileImports(f)
+			for _, decl := range f.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Recv == nil || !funcDecl.Name.IsExported() {
+					continue
+				}
+				recv, ok := funcDecl.Recv.List[0].Type.(*ast.Ident)
+				if !ok || recv.Name != typeName {
+					continue
+				}
+				if !hasContextParam(funcDecl.Type, imports) {
+					continue
+				}
+				used, ok := signatureImports(funcDecl.Type, imports)
+				if !ok {
+					continue
+				}
+				methods = append(methods, Method{
+					Name:      funcDecl.Name.Name,
+					signature: funcDecl.Type,
+					imports:   used,
+				})
+			}
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+	return methods, nil
+}
+
+// fileImports returns the import paths of the file by the names of the packages.
+func fileImports(f *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// hasContextParam checks if the first param of the function is an sdk.Context.
+func hasContextParam(funcType *ast.FuncType, imports map[string]string) bool {
+	if len(funcType.Params.List) == 0 {
+		return false
+	}
+	sel, ok := funcType.Params.List[0].Type.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Context" {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && imports[ident.Name] == sdkTypesPath
+}
+
+// signatureImports returns the imports of the packages that the function refers to, ok is false
+// when the function refers to the types of its own package or to unknown packages.
+func signatureImports(funcType *ast.FuncType, imports map[string]string) (used map[string]string, ok bool) {
+	used = make(map[string]string)
+	ok = true
+
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			// the names of the params and results aren't types.
+			ast.Inspect(n.Type, inspect)
+			return false
+		case *ast.SelectorExpr:
+			ident, isIdent := n.X.(*ast.Ident)
+			if !isIdent {
+				ok = false
+				return false
+			}
+			path, found := imports[ident.Name]
+			if !found {
+				ok = false
+				return false
+			}
+			used[ident.Name] = path
+			return false
+		case *ast.Ident:
+			// an identifier that isn't qualified by a package is either builtin or declared in
+			// the package of the function.
+			if types.Universe.Lookup(n.Name) == nil {
+				ok = false
+			}
+		}
+		return ok
+	}
+	ast.Inspect(funcType, inspect)
+	return used, ok
+}
@@ -0,0 +1,80 @@
+package keeper_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/keeper"
+)
+
+var (
+	keeperFile = []byte(`
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/foo/bar/x/bar/types"
+)
+
+type Keeper struct{}
+
+func (k Keeper) Logger(ctx sdk.Context) log.Logger { return nil }
+func (k Keeper) GetPost(ctx sdk.Context, id uint64) (val types.Post, found bool) { return }
+func (k Keeper) SetPosts(ctx sdk.Context, posts []*types.Post, count map[string]int) {}
+
+// not methods of an expected keeper.
+func (k Keeper) getPost(ctx sdk.Context, id uint64) types.Post { return types.Post{} }
+func (k *Keeper) SetHooks(ctx sdk.Context) {}
+func (k Keeper) Post(c context.Context, req *types.QueryGetPostRequest) {}
+func (k Keeper) Hooks(ctx sdk.Context) Hooks { return Hooks{} }
+func (o Other) GetOther(ctx sdk.Context) {}
+func GetPost(ctx sdk.Context) {}
+`)
+
+	keeperTestFile = []byte(`
+package keeper
+
+func (k Keeper) GetTest(ctx sdk.Context) {}
+`)
+)
+
+func TestDiscover(t *testing.T) {
+	path := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(path, "keeper.go"), keeperFile, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "keeper_test.go"), keeperTestFile, 0644))
+
+	methods, err := keeper.Discover(path, "Keeper")
+	require.NoError(t, err)
+
+	qualifier := func(path string) string {
+		switch path {
+		case "github.com/cosmos/cosmos-sdk/types":
+			return "sdk"
+		case "github.com/foo/bar/x/bar/types":
+			return "bartypes"
+		}
+		return filepath.Base(path)
+	}
+
+	var signatures []string
+	for _, m := range methods {
+		signature, err := m.Signature(qualifier)
+		require.NoError(t, err)
+		signatures = append(signatures, signature)
+	}
+	require.Equal(t, []string{
+		"GetPost(ctx sdk.Context, id uint64) (val bartypes.Post, found bool)",
+		"Logger(ctx sdk.Context) log.Logger",
+		"SetPosts(ctx sdk.Context, posts []*bartypes.Post, count map[string]int)",
+	}, signatures)
+
+	require.Equal(t, []string{
+		"github.com/cosmos/cosmos-sdk/types",
+		"github.com/foo/bar/x/bar/types",
+	}, methods[0].Imports())
+}
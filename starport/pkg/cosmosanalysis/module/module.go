@@ -42,6 +42,10 @@ type Msg struct {
 
 	// FilePath is the path of the .proto file where message is defined at.
 	FilePath string
+
+	// Constructor is the name of the func of the Go package of the module that creates the msg, it's
+	// empty when the package has none.
+	Constructor string
 }
 
 // HTTPQuery is an sdk Query.
@@ -137,6 +141,11 @@ func (d *moduleDiscoverer) discover(pkg protoanalysis.Package) (Module, error) {
 	}
 
 	// fill sdk Msgs.
+	constructors, err := cosmosanalysis.FindConstructors(pkgpath, msgs)
+	if err != nil {
+		return Module{}, err
+	}
+
 	for _, msg := range msgs {
 		pkgmsg, err := pkg.MessageByName(msg)
 		if err != nil {
@@ -147,9 +156,10 @@ func (d *moduleDiscoverer) discover(pkg protoanalysis.Package) (Module, error) {
 		}
 
 		m.Msgs = append(m.Msgs, Msg{
-			Name:     msg,
-			URI:      fmt.Sprintf("%s.%s", pkg.Name, msg),
-			FilePath: pkgmsg.Path,
+			Name:        msg,
+			URI:         fmt.Sprintf("%s.%s", pkg.Name, msg),
+			FilePath:    pkgmsg.Path,
+			Constructor: constructors[msg],
 		})
 	}
 
@@ -0,0 +1,471 @@
+// Package cosmosbench drives load against a chain with the txs of concurrent signers and reports the
+// latency, the throughput and the gas of the txs.
+package cosmosbench
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/pkg/errors"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+)
+
+const (
+	defaultTPS          = 100
+	defaultDuration     = time.Minute
+	defaultDrainTimeout = 30 * time.Second
+
+	// pollInterval is the interval of the queries of the new blocks.
+	pollInterval = 100 * time.Millisecond
+
+	// maxCommitFailures is the max. number of consecutive polls that fail to query a block.
+	maxCommitFailures = 50
+
+	// gasAdjustment adjusts the simulated gas of the txs when their gas limit is not set.
+	gasAdjustment = 1.5
+
+	// maxErrorLength is the max. length of the errors in the reports.
+	maxErrorLength = 80
+)
+
+// reSequenceMismatch matches the error returned by the node when a tx is signed with a wrong sequence.
+var reSequenceMismatch = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
+type options struct {
+	tps          int
+	duration     time.Duration
+	gas          uint64
+	fees         string
+	drainTimeout time.Duration
+}
+
+// Option configures a load test.
+type Option func(*options)
+
+// TPS sets the rate the txs are broadcasted at, in txs per second. it's 100 by default.
+func TPS(tps int) Option {
+	return func(o *options) {
+		o.tps = tps
+	}
+}
+
+// Duration sets the time the txs are broadcasted for. it's one minute by default.
+func Duration(duration time.Duration) Option {
+	return func(o *options) {
+		o.duration = duration
+	}
+}
+
+// Gas sets the gas limit of the txs, by default the gas of a tx is simulated once before the test.
+func Gas(gas uint64) Option {
+	return func(o *options) {
+		o.gas = gas
+	}
+}
+
+// Fees sets the fees of each tx.
+func Fees(fees string) Option {
+	return func(o *options) {
+		o.fees = fees
+	}
+}
+
+// DrainTimeout sets the time the broadcasted txs are waited to be included in blocks at the end of the
+// test. it's 30 seconds by default.
+func DrainTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.drainTimeout = timeout
+	}
+}
+
+// Signer is an account that signs the txs of a load test.
+type Signer struct {
+	// Name is the name of the account in the account registry of the client.
+	Name string
+
+	// Address is the address of the account.
+	Address string
+}
+
+type signer struct {
+	Signer
+	number, sequence uint64
+}
+
+// bench is a running load test.
+type bench struct {
+	client  cosmosclient.Client
+	msg     MsgFunc
+	options options
+	gas     uint64
+
+	mu      sync.Mutex
+	pending map[string]time.Time
+	report  Report
+
+	latencies  []time.Duration
+	gasUsed    []uint64
+	lastCommit time.Time
+}
+
+// Run broadcasts txs with the msgs returned by msg from the signers at the rate of the options until the
+// end of the test, each signer broadcasts its txs in order. the signers must have accounts on the chain
+// with enough coins to pay the fees. the txs are broadcasted without waiting for their inclusion, and
+// the blocks are watched to measure the latency of the txs.
+func Run(ctx context.Context, client cosmosclient.Client, signers []Signer, msg MsgFunc, opts ...Option) (Report, error) {
+	o := options{
+		tps:          defaultTPS,
+		duration:     defaultDuration,
+		drainTimeout: defaultDrainTimeout,
+	}
+	for _, apply := range opts {
+		apply(&o)
+	}
+	switch {
+	case len(signers) == 0:
+		return Report{}, errors.New("no signers")
+	case o.tps <= 0:
+		return Report{}, errors.New("the tps must be positive")
+	case o.duration <= 0:
+		return Report{}, errors.New("the duration must be positive")
+	}
+	if o.fees != "" {
+		if _, err := sdktypes.ParseCoinsNormalized(o.fees); err != nil {
+			return Report{}, errors.Wrap(err, "invalid fees")
+		}
+	}
+
+	// the addresses of the msgs of the SDK are encoded with the prefix of the config.
+	prefix, _, err := bech32.DecodeAndConvert(signers[0].Address)
+	if err != nil {
+		return Report{}, err
+	}
+	sdktypes.GetConfig().SetBech32PrefixForAccount(prefix, prefix+"pub")
+
+	b := &bench{
+		client:  client,
+		msg:     msg,
+		options: o,
+		gas:     o.gas,
+		pending: make(map[string]time.Time),
+		report: Report{
+			Signers:   len(signers),
+			TargetTPS: o.tps,
+			Errors:    make(map[string]int),
+		},
+	}
+
+	accounts := make([]*signer, len(signers))
+	for i, s := range signers {
+		address, err := sdktypes.AccAddressFromBech32(s.Address)
+		if err != nil {
+			return Report{}, err
+		}
+		number, sequence, err := client.Context.AccountRetriever.GetAccountNumberSequence(client.Context, address)
+		if err != nil {
+			return Report{}, errors.Wrapf(err, "the signer %s has no account on the chain", s.Name)
+		}
+		accounts[i] = &signer{Signer: s, number: number, sequence: sequence}
+	}
+
+	if b.gas == 0 {
+		if b.gas, err = b.simulate(accounts[0]); err != nil {
+			return Report{}, errors.Wrap(err, "cannot simulate the gas of the txs")
+		}
+	}
+
+	status, err := client.RPC.Status(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var (
+		done    = make(chan struct{})
+		tracked = make(chan error, 1)
+	)
+	go func() {
+		tracked <- b.track(ctx, status.SyncInfo.LatestBlockHeight+1, done)
+	}()
+
+	start := time.Now()
+	b.load(ctx, accounts)
+	b.report.Duration = time.Since(start)
+	close(done)
+
+	if err := <-tracked; err != nil {
+		return Report{}, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	r := b.report
+	r.Pending = len(b.pending)
+	r.SendRate = float64(r.Sent) / r.Duration.Seconds()
+	if !b.lastCommit.IsZero() {
+		r.Throughput = float64(r.Committed) / b.lastCommit.Sub(start).Seconds()
+	}
+	r.Latency = newLatency(b.latencies)
+	r.Gas = newGas(b.gas, b.gasUsed)
+	return r, ctx.Err()
+}
+
+// load broadcasts the txs at the rate of the options until the end of the test, the txs are dispatched
+// to the signers in turn.
+func (b *bench) load(ctx context.Context, signers []*signer) {
+	ctx, cancel := context.WithTimeout(ctx, b.options.duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	queues := make([]chan struct{}, len(signers))
+	for i, s := range signers {
+		queues[i] = make(chan struct{}, b.options.tps)
+		wg.Add(1)
+		go func(s *signer, queue chan struct{}) {
+			defer wg.Done()
+			for range queue {
+				if ctx.Err() != nil {
+					continue
+				}
+				b.broadcast(s)
+			}
+		}(s, queues[i])
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(b.options.tps))
+	defer ticker.Stop()
+
+loop:
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+		}
+		// the signer is waited when it's late to keep the order of its txs.
+		select {
+		case <-ctx.Done():
+			break loop
+		case queues[i%len(queues)] <- struct{}{}:
+		}
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+}
+
+// factory returns the tx factory of the next tx of the signer.
+func (b *bench) factory(s *signer) tx.Factory {
+	txf := b.client.Factory.
+		WithAccountNumber(s.number).
+		WithSequence(s.sequence).
+		WithGas(b.gas).
+		WithGasPrices("")
+	if b.options.fees != "" {
+		txf = txf.WithFees(b.options.fees)
+	}
+	return txf
+}
+
+func (b *bench) simulate(s *signer) (uint64, error) {
+	msg, err := b.msg(s.Address)
+	if err != nil {
+		return 0, err
+	}
+	_, gas, err := tx.CalculateGas(b.client.Context, b.factory(s), msg)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(gasAdjustment * float64(gas)), nil
+}
+
+// broadcast signs and broadcasts the next tx of the signer, the tx is pending until it's included in
+// a block.
+func (b *bench) broadcast(s *signer) {
+	txBytes, err := b.sign(s)
+	if err != nil {
+		b.reject(err.Error())
+		return
+	}
+	hash := fmt.Sprintf("%X", tmtypes.Tx(txBytes).Hash())
+
+	// the tx is pending before it's broadcasted, so it's tracked when it's included right away.
+	b.mu.Lock()
+	b.pending[hash] = time.Now()
+	b.report.Sent++
+	b.mu.Unlock()
+
+	resp, err := b.client.Context.WithBroadcastMode(flags.BroadcastSync).BroadcastTx(txBytes)
+	switch {
+	case err != nil:
+		b.drop(hash, err.Error())
+		return
+
+	case resp.Code != 0:
+		// the node tells the expected sequence, the next txs of the signer use it.
+		if match := reSequenceMismatch.FindStringSubmatch(resp.RawLog); match != nil {
+			s.sequence, _ = strconv.ParseUint(match[1], 10, 64)
+		}
+		b.drop(hash, errorReason(resp.Codespace, resp.Code, resp.RawLog))
+		return
+	}
+	s.sequence++
+}
+
+func (b *bench) sign(s *signer) ([]byte, error) {
+	msg, err := b.msg(s.Address)
+	if err != nil {
+		return nil, err
+	}
+	txf := b.factory(s)
+	builder, err := tx.BuildUnsignedTx(txf, msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Sign(txf, s.Name, builder, true); err != nil {
+		return nil, err
+	}
+	return b.client.Context.TxConfig.TxEncoder()(builder.GetTx())
+}
+
+// reject counts a tx that can't be broadcasted.
+func (b *bench) reject(reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.report.Rejected++
+	b.report.Errors[truncate(reason)]++
+}
+
+// drop counts a broadcasted tx rejected by the node.
+func (b *bench) drop(hash, reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.pending, hash)
+	b.report.Rejected++
+	b.report.Errors[truncate(reason)]++
+}
+
+// track watches the blocks from height until the load is done and the pending txs are included in
+// blocks, or until the drain timeout. the included pending txs are counted with their latencies.
+func (b *bench) track(ctx context.Context, height int64, done <-chan struct{}) error {
+	var (
+		drain    <-chan time.Time
+		failures int
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-drain:
+			return nil
+		case <-done:
+			done = nil
+			drain = time.After(b.options.drainTimeout)
+		case <-time.After(pollInterval):
+		}
+
+		status, err := b.client.RPC.Status(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for ; height <= status.SyncInfo.LatestBlockHeight; height++ {
+			// the results of the latest block may not be stored yet, the block is tracked again at the
+			// next poll and only the errors that last fail the test.
+			if err := b.commit(ctx, height); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if failures++; failures >= maxCommitFailures {
+					return err
+				}
+				break
+			}
+			failures = 0
+		}
+
+		b.mu.Lock()
+		drained := len(b.pending) == 0
+		b.mu.Unlock()
+		if done == nil && drained {
+			return nil
+		}
+	}
+}
+
+// commit counts the pending txs included in the block at height.
+func (b *bench) commit(ctx context.Context, height int64) error {
+	block, err := b.client.RPC.Block(ctx, &height)
+	if err != nil {
+		return err
+	}
+	results, err := b.client.RPC.BlockResults(ctx, &height)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var included int
+	for i, t := range block.Block.Txs {
+		hash := fmt.Sprintf("%X", t.Hash())
+		sent, ok := b.pending[hash]
+		if !ok {
+			continue
+		}
+		delete(b.pending, hash)
+		included++
+
+		result := results.TxsResults[i]
+		b.latencies = append(b.latencies, now.Sub(sent))
+		b.gasUsed = append(b.gasUsed, uint64(result.GasUsed))
+		b.lastCommit = now
+		if result.Code != 0 {
+			b.report.Failed++
+			b.report.Errors[truncate(errorReason(result.Codespace, result.Code, result.Log))]++
+			continue
+		}
+		b.report.Committed++
+	}
+	if included > 0 {
+		b.report.Blocks++
+		if included > b.report.MaxTxsPerBlock {
+			b.report.MaxTxsPerBlock = included
+		}
+	}
+	return nil
+}
+
+// errorReason returns the reason of the error of a tx, the description of the error at the end of its log
+// without the details of the tx.
+func errorReason(codespace string, code uint32, log string) string {
+	if i := strings.LastIndex(log, ": "); i >= 0 {
+		log = log[i+2:]
+	}
+	return fmt.Sprintf("%s (%s %d)", log, codespace, code)
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength-3] + "..."
+	}
+	return s
+}
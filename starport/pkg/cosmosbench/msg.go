@@ -0,0 +1,170 @@
+package cosmosbench
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/tendermint/starport/starport/pkg/grpcui"
+)
+
+// signerFields are the fields of the msgs set to the address of the signer, the first one found in a msg
+// is set. the msgs scaffolded by Starport are signed by their creators.
+var signerFields = []string{"creator", "signer", "sender", "from_address"}
+
+// MsgFunc returns the msg of a tx signed by the signer with the address.
+type MsgFunc func(signer string) (sdktypes.Msg, error)
+
+// BankSend returns the msgs that send the amount from the signers to themselves, so the signers keep
+// their balances.
+func BankSend(amount sdktypes.Coins) MsgFunc {
+	return func(signer string) (sdktypes.Msg, error) {
+		return &banktypes.MsgSend{
+			FromAddress: signer,
+			ToAddress:   signer,
+			Amount:      amount,
+		}, nil
+	}
+}
+
+// ReflectMsg returns the msgs of the method of a Msg service of the gRPC server, resolved with the gRPC
+// reflection of the server, so the msgs of the custom modules of a chain are sent without their types.
+// name is the name of the method in kebab case like create-post, or the full name of the method like
+// blog.blog.Msg/CreatePost when several services have the method. the fields of the msgs are set from
+// fields in JSON and their signer field is set to the address of the signer.
+func ReflectMsg(ctx context.Context, conn grpc.ClientConnInterface, name string, fields []byte) (MsgFunc, error) {
+	// the Msg services are not served by the nodes, they're resolved from the tx.proto files next to the
+	// files of the Query services of their modules.
+	client := grpcui.New(conn)
+	files, services, err := client.Files(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot resolve the services of the chain with gRPC reflection")
+	}
+	var (
+		txFiles []string
+		seen    = make(map[string]bool)
+	)
+	for _, service := range services {
+		d, err := files.FindDescriptorByName(protoreflect.FullName(service))
+		if err != nil {
+			continue
+		}
+		txFile := path.Join(path.Dir(d.ParentFile().Path()), "tx.proto")
+		if !seen[txFile] {
+			seen[txFile] = true
+			txFiles = append(txFiles, txFile)
+		}
+	}
+	if files, err = client.FilesByName(ctx, txFiles...); err != nil {
+		return nil, errors.Wrap(err, "cannot resolve the Msg services of the chain with gRPC reflection")
+	}
+
+	var methods []protoreflect.MethodDescriptor
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			sd := fd.Services().Get(i)
+			if sd.Name() != "Msg" {
+				continue
+			}
+			for j := 0; j < sd.Methods().Len(); j++ {
+				if md := sd.Methods().Get(j); matchMethod(md, name) {
+					methods = append(methods, md)
+				}
+			}
+		}
+		return true
+	})
+
+	switch len(methods) {
+	case 0:
+		return nil, fmt.Errorf("no msg %q in the Msg services of the chain", name)
+	case 1:
+	default:
+		var names []string
+		for _, md := range methods {
+			names = append(names, methodName(md))
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("several msgs match %q, use one of: %s", name, strings.Join(names, ", "))
+	}
+
+	md := methods[0].Input()
+	template := dynamicpb.NewMessage(md)
+	if len(fields) > 0 {
+		if err := protojson.Unmarshal(fields, template); err != nil {
+			return nil, errors.Wrapf(err, "invalid fields of %s", md.FullName())
+		}
+	}
+
+	var signerField protoreflect.FieldDescriptor
+	for _, field := range signerFields {
+		if fd := md.Fields().ByName(protoreflect.Name(field)); fd != nil && fd.Kind() == protoreflect.StringKind {
+			signerField = fd
+			break
+		}
+	}
+	if signerField == nil {
+		return nil, fmt.Errorf("%s has no signer field, one of: %s", md.FullName(), strings.Join(signerFields, ", "))
+	}
+
+	return func(signer string) (sdktypes.Msg, error) {
+		_, address, err := bech32.DecodeAndConvert(signer)
+		if err != nil {
+			return nil, err
+		}
+
+		msg := proto.Clone(template)
+		msg.ProtoReflect().Set(signerField, protoreflect.ValueOfString(signer))
+		value, err := proto.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &rawMsg{name: string(md.FullName()), value: value, signer: address}, nil
+	}, nil
+}
+
+func matchMethod(md protoreflect.MethodDescriptor, name string) bool {
+	if name == methodName(md) {
+		return true
+	}
+	return strings.EqualFold(strings.ReplaceAll(name, "-", ""), string(md.Name()))
+}
+
+func methodName(md protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("%s/%s", md.Parent().FullName(), md.Name())
+}
+
+// rawMsg is a msg encoded in protobuf, it's packed in txs with its name like the msgs of the registered
+// types are.
+type rawMsg struct {
+	name   string
+	value  []byte
+	signer sdktypes.AccAddress
+}
+
+func (m *rawMsg) Reset()         { *m = rawMsg{} }
+func (m *rawMsg) String() string { return m.name }
+func (*rawMsg) ProtoMessage()    {}
+
+// XXX_MessageName returns the name of the msg, the type URL of the msg in txs is derived from it.
+func (m *rawMsg) XXX_MessageName() string { return m.name }
+
+// Marshal returns the msg encoded in protobuf.
+func (m *rawMsg) Marshal() ([]byte, error) { return m.value, nil }
+
+// ValidateBasic does nothing, the msg is validated by the chain.
+func (*rawMsg) ValidateBasic() error { return nil }
+
+func (m *rawMsg) GetSigners() []sdktypes.AccAddress { return []sdktypes.AccAddress{m.signer} }
@@ -0,0 +1,79 @@
+package cosmosbench
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/server/grpc/gogoreflection"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	var (
+		listener = bufconn.Listen(1024 * 1024)
+		server   = grpc.NewServer()
+	)
+
+	// like the nodes, the server serves the Query service of the module but not its Msg service.
+	banktypes.RegisterQueryServer(server, &banktypes.UnimplementedQueryServer{})
+	gogoreflection.Register(server)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestReflectMsg(t *testing.T) {
+	var (
+		conn   = newTestConn(t)
+		ctx    = context.Background()
+		signer = "cosmos1ghx7gw556m0ekatplvtqcjhz5aeqq5n8fak6cs"
+	)
+
+	for _, name := range []string{"send", "Send", "cosmos.bank.v1beta1.Msg/Send"} {
+		msgFunc, err := ReflectMsg(ctx, conn, name, []byte(`{"to_address": "cosmos142ywvffu27pguhkdunjvargf0wxad0pkmas4u6", "amount": [{"denom": "token", "amount": "1"}]}`))
+		require.NoError(t, err, name)
+
+		msg, err := msgFunc(signer)
+		require.NoError(t, err)
+
+		_, address, err := bech32.DecodeAndConvert(signer)
+		require.NoError(t, err)
+		require.Equal(t, []sdktypes.AccAddress{address}, msg.GetSigners())
+
+		// the msg is packed in txs like the registered msgs.
+		any, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", any.TypeUrl)
+
+		var send banktypes.MsgSend
+		require.NoError(t, send.Unmarshal(any.Value))
+		require.Equal(t, banktypes.MsgSend{
+			FromAddress: signer,
+			ToAddress:   "cosmos142ywvffu27pguhkdunjvargf0wxad0pkmas4u6",
+			Amount:      sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 1)),
+		}, send)
+	}
+
+	_, err := ReflectMsg(ctx, conn, "create-post", nil)
+	require.Error(t, err)
+
+	_, err = ReflectMsg(ctx, conn, "send", []byte(`{"unknown": true}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package cosmosbench
+
+import (
+	"sort"
+	"time"
+)
+
+// Report is the report of a load test.
+type Report struct {
+	// Signers is the number of concurrent signers.
+	Signers int
+
+	// TargetTPS is the rate the txs are broadcasted at.
+	TargetTPS int
+
+	// Duration is the time the txs are broadcasted for.
+	Duration time.Duration
+
+	// Sent is the number of broadcasted txs.
+	Sent int
+
+	// Rejected is the number of txs rejected by the node before they're added to its mempool.
+	Rejected int
+
+	// Committed is the number of txs included in blocks that succeeded.
+	Committed int
+
+	// Failed is the number of txs included in blocks that failed.
+	Failed int
+
+	// Pending is the number of txs accepted by the node but not included in blocks in time.
+	Pending int
+
+	// SendRate is the rate the txs were broadcasted at, in txs per second.
+	SendRate float64
+
+	// Throughput is the rate of the committed txs from the first broadcast to the last commit, in txs
+	// per second.
+	Throughput float64
+
+	// Latency is the time from the broadcast of the included txs to their commit.
+	Latency LatencyStats
+
+	// Gas is the gas used by the included txs.
+	Gas GasStats
+
+	// Blocks is the number of blocks that include txs of the test.
+	Blocks int
+
+	// MaxTxsPerBlock is the max. number of txs of the test included in a block.
+	MaxTxsPerBlock int
+
+	// Errors are the numbers of the rejected and the failed txs by their errors.
+	Errors map[string]int
+}
+
+// LatencyStats are the statistics of the latencies of txs.
+type LatencyStats struct {
+	Min, Mean, P50, P90, P99, Max time.Duration
+}
+
+// GasStats are the statistics of the gas used by txs.
+type GasStats struct {
+	// Wanted is the gas limit of the txs.
+	Wanted uint64
+
+	Min, Mean, Max, Total uint64
+}
+
+// newLatency returns the statistics of the latencies.
+func newLatency(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+
+	return LatencyStats{
+		Min:  sorted[0],
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(sorted, 50),
+		P90:  percentile(sorted, 90),
+		P99:  percentile(sorted, 99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of the sorted latencies with the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// newGas returns the statistics of the used gas.
+func newGas(wanted uint64, used []uint64) GasStats {
+	gas := GasStats{Wanted: wanted}
+	if len(used) == 0 {
+		return gas
+	}
+
+	gas.Min = used[0]
+	for _, u := range used {
+		if u < gas.Min {
+			gas.Min = u
+		}
+		if u > gas.Max {
+			gas.Max = u
+		}
+		gas.Total += u
+	}
+	gas.Mean = gas.Total / uint64(len(used))
+	return gas
+}
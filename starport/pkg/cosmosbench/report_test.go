@@ -0,0 +1,47 @@
+package cosmosbench
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLatency(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	require.Equal(t, LatencyStats{
+		Min:  time.Millisecond,
+		Mean: 50500 * time.Microsecond,
+		P50:  50 * time.Millisecond,
+		P90:  90 * time.Millisecond,
+		P99:  99 * time.Millisecond,
+		Max:  100 * time.Millisecond,
+	}, newLatency(latencies))
+
+	require.Equal(t, LatencyStats{
+		Min:  time.Second,
+		Mean: time.Second,
+		P50:  time.Second,
+		P90:  time.Second,
+		P99:  time.Second,
+		Max:  time.Second,
+	}, newLatency([]time.Duration{time.Second}))
+
+	require.Equal(t, LatencyStats{}, newLatency(nil))
+}
+
+func TestNewGas(t *testing.T) {
+	require.Equal(t, GasStats{Wanted: 100000, Min: 40000, Mean: 50000, Max: 60000, Total: 150000},
+		newGas(100000, []uint64{50000, 60000, 40000}))
+	require.Equal(t, GasStats{Wanted: 100000}, newGas(100000, nil))
+}
+
+func TestErrorReason(t *testing.T) {
+	require.Equal(t, "insufficient funds (sdk 5)",
+		errorReason("sdk", 5, "0stake is smaller than 1stake: insufficient funds"))
+	require.Equal(t, "unknown (mars 1100)", errorReason("mars", 1100, "unknown"))
+}
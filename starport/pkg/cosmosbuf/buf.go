@@ -0,0 +1,159 @@
+// Package cosmosbuf provides high level access to the buf command to manage proto files.
+package cosmosbuf
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/cmdrunner"
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
+)
+
+const (
+	binaryName = "buf"
+
+	// ConfigFileName is the name of the buf module config.
+	ConfigFileName = "buf.yaml"
+
+	// GenTemplateFileName is the name of the default buf generation template.
+	GenTemplateFileName = "buf.gen.yaml"
+)
+
+// ErrNotInstalled is returned when buf cannot be found in the PATH.
+var ErrNotInstalled = errors.New("buf is not installed, see: https://docs.buf.build/installation")
+
+// Annotation is an issue found in a proto file by lint or breaking change checks.
+type Annotation struct {
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line"`
+	StartColumn int    `json:"start_column"`
+	EndLine     int    `json:"end_line"`
+	EndColumn   int    `json:"end_column"`
+	Type        string `json:"type"`
+	Message     string `json:"message"`
+}
+
+// String returns the annotation in file:line:column:message format.
+func (a Annotation) String() string {
+	return fmt.Sprintf("%s:%d:%d:%s", a.Path, a.StartLine, a.StartColumn, a.Message)
+}
+
+// Annotations is returned as an error when lint or breaking change checks fail.
+type Annotations []Annotation
+
+func (a Annotations) Error() string {
+	var lines []string
+	for _, annotation := range a {
+		lines = append(lines, annotation.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Buf runs buf commands.
+type Buf struct {
+	path string
+}
+
+// New creates a new Buf by locating the buf binary in the PATH.
+func New() (Buf, error) {
+	path, err := exec.LookPath(binaryName)
+	if err != nil {
+		return Buf{}, ErrNotInstalled
+	}
+	return Buf{path: path}, nil
+}
+
+// Generate generates code into outDir for the buf module in protoDir by using the template,
+// template can be a path or a JSON/YAML content.
+func (b Buf) Generate(ctx context.Context, protoDir, outDir, template string) error {
+	_, err := b.run(ctx, protoDir, "generate", "--template", template, "--output", outDir)
+	return err
+}
+
+// Update updates the dependencies of the buf module in protoDir and the buf.lock file.
+func (b Buf) Update(ctx context.Context, protoDir string) error {
+	_, err := b.run(ctx, protoDir, "mod", "update")
+	return err
+}
+
+// Lint lints the buf module in protoDir, found issues are returned as Annotations.
+func (b Buf) Lint(ctx context.Context, protoDir string) error {
+	return b.check(ctx, protoDir, "lint", "--error-format", "json")
+}
+
+// Breaking checks the buf module in protoDir for breaking changes against an input, e.g. a git reference
+// created by GitInput. found breaking changes are returned as Annotations.
+func (b Buf) Breaking(ctx context.Context, protoDir, against string) error {
+	return b.check(ctx, protoDir, "breaking", "--against", against, "--error-format", "json")
+}
+
+// GitInput returns a buf input that points to the subDir of the git repository at repoPath at tag.
+func GitInput(repoPath, tag, subDir string) string {
+	input := fmt.Sprintf("%s#tag=%s", repoPath, tag)
+	if subDir != "" {
+		input += ",subdir=" + subDir
+	}
+	return input
+}
+
+// check runs a buf command that reports issues in JSON lines and parses them.
+func (b Buf) check(ctx context.Context, protoDir string, args ...string) error {
+	out, err := b.run(ctx, protoDir, args...)
+	if err == nil {
+		return nil
+	}
+
+	annotations, perr := parseAnnotations(out)
+	if perr != nil || len(annotations) == 0 {
+		return err
+	}
+	return annotations
+}
+
+func (b Buf) run(ctx context.Context, dir string, args ...string) (stdout []byte, err error) {
+	var (
+		outb = &bytes.Buffer{}
+		errb = &bytes.Buffer{}
+	)
+
+	err = cmdrunner.
+		New(
+			cmdrunner.DefaultStdout(outb),
+			cmdrunner.DefaultStderr(errb),
+			cmdrunner.DefaultWorkdir(dir),
+		).
+		Run(ctx, step.New(step.Exec(b.path, args...)))
+	if err != nil {
+		return outb.Bytes(), errors.Wrap(err, strings.TrimSpace(errb.String()+outb.String()))
+	}
+
+	return outb.Bytes(), nil
+}
+
+// parseAnnotations parses annotations that are printed as JSON lines.
+func parseAnnotations(out []byte) (Annotations, error) {
+	var annotations Annotations
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var annotation Annotation
+		if err := json.Unmarshal(line, &annotation); err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, scanner.Err()
+}
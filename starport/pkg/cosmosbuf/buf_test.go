@@ -0,0 +1,49 @@
+package cosmosbuf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	out := []byte(`{"path":"blog/post.proto","start_line":8,"start_column":3,"end_line":8,"end_column":20,"type":"FIELD_SAME_TYPE","message":"Field \"1\" on message \"Post\" changed type."}
+
+{"path":"blog/tx.proto","start_line":1,"start_column":1,"end_line":1,"end_column":1,"type":"FILE_NO_DELETE","message":"Previously present file \"blog/tx.proto\" was deleted."}
+`)
+
+	annotations, err := parseAnnotations(out)
+	require.NoError(t, err)
+	require.Equal(t, Annotations{
+		{
+			Path:        "blog/post.proto",
+			StartLine:   8,
+			StartColumn: 3,
+			EndLine:     8,
+			EndColumn:   20,
+			Type:        "FIELD_SAME_TYPE",
+			Message:     `Field "1" on message "Post" changed type.`,
+		},
+		{
+			Path:        "blog/tx.proto",
+			StartLine:   1,
+			StartColumn: 1,
+			EndLine:     1,
+			EndColumn:   1,
+			Type:        "FILE_NO_DELETE",
+			Message:     `Previously present file "blog/tx.proto" was deleted.`,
+		},
+	}, annotations)
+	require.Equal(t,
+		"blog/post.proto:8:3:Field \"1\" on message \"Post\" changed type.\nblog/tx.proto:1:1:Previously present file \"blog/tx.proto\" was deleted.",
+		annotations.Error(),
+	)
+
+	_, err = parseAnnotations([]byte("Failure: something went wrong"))
+	require.Error(t, err)
+}
+
+func TestGitInput(t *testing.T) {
+	require.Equal(t, "/src/mars/.git#tag=v0.1.0,subdir=proto", GitInput("/src/mars/.git", "v0.1.0", "proto"))
+	require.Equal(t, ".git#tag=v1", GitInput(".git", "v1", ""))
+}
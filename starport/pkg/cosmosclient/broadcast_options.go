@@ -0,0 +1,63 @@
+package cosmosclient
+
+import (
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BroadcastOption configures the txs broadcasted by a client returned from WithBroadcastOptions.
+type BroadcastOption func(*broadcastOptions)
+
+type broadcastOptions struct {
+	memo          string
+	timeoutHeight uint64
+	gas           uint64
+	fees          string
+	feeGranter    sdktypes.AccAddress
+}
+
+// WithMemo sets the memo of the tx.
+func WithMemo(memo string) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.memo = memo
+	}
+}
+
+// WithTimeoutHeight sets the block height after which the tx is not included in a block anymore.
+func WithTimeoutHeight(height uint64) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.timeoutHeight = height
+	}
+}
+
+// WithGas sets the gas limit of the tx, the tx is not simulated to calculate its gas when it's set.
+func WithGas(gas uint64) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.gas = gas
+	}
+}
+
+// WithFees sets the fees of the tx, e.g. 200stake. the fees are not estimated even when the client
+// is created with WithAutoFees.
+func WithFees(fees string) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.fees = fees
+	}
+}
+
+// WithFeeGranter sets the account that pays the fees of the tx through a fee grant.
+func WithFeeGranter(granter sdktypes.AccAddress) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.feeGranter = granter
+	}
+}
+
+// WithBroadcastOptions returns a copy of the client that broadcasts txs with the options, so the
+// options are only used by the txs broadcasted with the returned client, e.g.
+//
+//	c.WithBroadcastOptions(cosmosclient.WithMemo("hello")).BroadcastTxAndWait(ctx, "alice", msg)
+func (c Client) WithBroadcastOptions(options ...BroadcastOption) Client {
+	for _, apply := range options {
+		apply(&c.broadcastOptions)
+	}
+	return c
+}
@@ -0,0 +1,38 @@
+package cosmosclient
+
+import (
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBroadcastOptions(t *testing.T) {
+	var (
+		c       Client
+		granter = sdktypes.AccAddress("granter")
+	)
+
+	withOptions := c.WithBroadcastOptions(
+		WithMemo("starport publish mars"),
+		WithTimeoutHeight(100),
+		WithGas(200000),
+		WithFees("10stake"),
+		WithFeeGranter(granter),
+	)
+	require.Equal(t, broadcastOptions{
+		memo:          "starport publish mars",
+		timeoutHeight: 100,
+		gas:           200000,
+		fees:          "10stake",
+		feeGranter:    granter,
+	}, withOptions.broadcastOptions)
+
+	// the options are not used by the original client.
+	require.Equal(t, broadcastOptions{}, c.broadcastOptions)
+
+	// the options of the copy are extended.
+	extended := withOptions.WithBroadcastOptions(WithMemo("starport launch 1"))
+	require.Equal(t, "starport launch 1", extended.broadcastOptions.memo)
+	require.Equal(t, "starport publish mars", withOptions.broadcastOptions.memo)
+}
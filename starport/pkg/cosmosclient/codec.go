@@ -0,0 +1,38 @@
+package cosmosclient
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+)
+
+// codecRegistration registers types to the codecs of the client.
+type codecRegistration func(registry codectypes.InterfaceRegistry, amino *codec.LegacyAmino)
+
+// WithInterfaceRegistrations registers the interfaces and the implementations of custom modules to the
+// interface registry of the client, so msgs of the modules can be broadcasted and values of the modules
+// packed into Any can be decoded. SDK types are always registered.
+func WithInterfaceRegistrations(register ...func(registry codectypes.InterfaceRegistry)) Option {
+	return func(c *Client) {
+		for _, r := range register {
+			r := r
+			c.codecRegistrations = append(c.codecRegistrations, func(registry codectypes.InterfaceRegistry, _ *codec.LegacyAmino) {
+				r(registry)
+			})
+		}
+	}
+}
+
+// WithModuleBasics registers the types of the modules to the codecs of the client, e.g. the modules
+// of a scaffolded chain can be registered by passing in the module basics of its app.
+func WithModuleBasics(modules ...module.AppModuleBasic) Option {
+	return func(c *Client) {
+		for _, m := range modules {
+			m := m
+			c.codecRegistrations = append(c.codecRegistrations, func(registry codectypes.InterfaceRegistry, amino *codec.LegacyAmino) {
+				m.RegisterInterfaces(registry)
+				m.RegisterLegacyAminoCodec(amino)
+			})
+		}
+	}
+}
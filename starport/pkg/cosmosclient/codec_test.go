@@ -0,0 +1,41 @@
+package cosmosclient
+
+import (
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecRegistrations(t *testing.T) {
+	var c Client
+
+	apply := []Option{
+		WithModuleBasics(bank.AppModuleBasic{}),
+		WithInterfaceRegistrations(govtypes.RegisterInterfaces),
+	}
+	for _, o := range apply {
+		o(&c)
+	}
+
+	ctx := newContext(nil, nil, "mars", t.TempDir(), c.codecRegistrations...)
+
+	for _, msg := range []sdktypes.Msg{&banktypes.MsgSend{}, &govtypes.MsgVote{}} {
+		resolved, err := ctx.InterfaceRegistry.Resolve(sdktypes.MsgTypeURL(msg))
+		require.NoError(t, err)
+		require.IsType(t, msg, resolved)
+	}
+
+	// msgs of the modules are registered to the amino codec with WithModuleBasics.
+	bz, err := ctx.LegacyAmino.MarshalJSON([]sdktypes.Msg{&banktypes.MsgSend{}})
+	require.NoError(t, err)
+	require.Contains(t, string(bz), "cosmos-sdk/MsgSend")
+
+	// msgs of the modules that are not registered cannot be resolved.
+	ctx = newContext(nil, nil, "mars", t.TempDir())
+	_, err = ctx.InterfaceRegistry.Resolve(sdktypes.MsgTypeURL(&banktypes.MsgSend{}))
+	require.Error(t, err)
+}
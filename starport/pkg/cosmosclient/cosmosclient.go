@@ -20,6 +20,7 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
@@ -80,6 +81,29 @@ type Client struct {
 	homePath           string
 	keyringServiceName string
 	keyringBackend     cosmosaccount.KeyringBackend
+
+	txInclusionTimeout time.Duration
+	useEIP712          bool
+
+	useAutoFees        bool
+	gasPrices          string
+	defaultGasPrices   sdktypes.DecCoins
+	feeDenom           string
+	gasAdjustment      float64
+	gasPriceMultiplier float64
+
+	// codecRegistrations register the types of custom modules to the codecs.
+	codecRegistrations []codecRegistration
+
+	// broadcastOptions are the options of the txs broadcasted by the client.
+	broadcastOptions broadcastOptions
+
+	// sequences is shared by the copies of the client, so concurrent txs of an account use
+	// different sequences.
+	sequences *sequenceManager
+
+	// wsStart starts the websocket connection to the node once to wait for txs.
+	wsStart *sync.Once
 }
 
 // Option configures your client.
@@ -109,6 +133,14 @@ func WithKeyringBackend(backend cosmosaccount.KeyringBackend) Option {
 	}
 }
 
+// WithAccountRegistry makes the client use an existing account registry to sign txs instead of
+// creating one from the keyring options.
+func WithAccountRegistry(registry cosmosaccount.Registry) Option {
+	return func(c *Client) {
+		c.AccountRegistry = registry
+	}
+}
+
 // WithNodeAddress sets the node address of your chain. When this option is not provided
 // `http://localhost:26657` is used as default.
 func WithNodeAddress(addr string) Option {
@@ -123,6 +155,14 @@ func WithAddressPrefix(prefix string) Option {
 	}
 }
 
+// WithEIP712 makes the client sign txs with EIP-712 like Web3 wallets do for Ethermint based chains.
+// accounts need to be eth_secp256k1 accounts to sign txs this way.
+func WithEIP712() Option {
+	return func(c *Client) {
+		c.useEIP712 = true
+	}
+}
+
 func WithUseFaucet(faucetAddress, denom string, minAmount uint64) Option {
 	return func(c *Client) {
 		c.useFaucet = true
@@ -139,13 +179,18 @@ func WithUseFaucet(faucetAddress, denom string, minAmount uint64) Option {
 // New creates a new client with given options.
 func New(ctx context.Context, options ...Option) (Client, error) {
 	c := Client{
-		nodeAddress:     defaultNodeAddress,
-		keyringBackend:  cosmosaccount.KeyringTest,
-		addressPrefix:   "cosmos",
-		faucetAddress:   defaultFaucetAddress,
-		faucetDenom:     defaultFaucetDenom,
-		faucetMinAmount: defaultFaucetMinAmount,
-		out:             io.Discard,
+		nodeAddress:        defaultNodeAddress,
+		keyringBackend:     cosmosaccount.KeyringTest,
+		addressPrefix:      "cosmos",
+		faucetAddress:      defaultFaucetAddress,
+		faucetDenom:        defaultFaucetDenom,
+		faucetMinAmount:    defaultFaucetMinAmount,
+		out:                io.Discard,
+		sequences:          newSequenceManager(),
+		wsStart:            &sync.Once{},
+		txInclusionTimeout: defaultTxInclusionTimeout,
+		gasAdjustment:      defaultGasAdjustment,
+		gasPriceMultiplier: defaultGasPriceMultiplier,
 	}
 
 	var err error
@@ -154,6 +199,12 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		apply(&c)
 	}
 
+	if c.gasPrices != "" {
+		if c.defaultGasPrices, err = sdktypes.ParseDecCoins(c.gasPrices); err != nil {
+			return Client{}, errors.Wrap(err, "invalid gas prices")
+		}
+	}
+
 	if c.RPC, err = rpchttp.New(c.nodeAddress, "/websocket"); err != nil {
 		return Client{}, err
 	}
@@ -173,17 +224,26 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		c.homePath = filepath.Join(home, "."+c.chainID)
 	}
 
-	c.AccountRegistry, err = cosmosaccount.New(
-		cosmosaccount.WithKeyringServiceName(c.keyringServiceName),
-		cosmosaccount.WithKeyringBackend(c.keyringBackend),
-		cosmosaccount.WithHome(c.homePath),
-	)
-	if err != nil {
-		return Client{}, err
+	if c.AccountRegistry.Keyring == nil {
+		c.AccountRegistry, err = cosmosaccount.New(
+			cosmosaccount.WithKeyringServiceName(c.keyringServiceName),
+			cosmosaccount.WithKeyringBackend(c.keyringBackend),
+			cosmosaccount.WithHome(c.homePath),
+		)
+		if err != nil {
+			return Client{}, err
+		}
 	}
 
-	c.Context = newContext(c.RPC, c.out, c.chainID, c.homePath).WithKeyring(c.AccountRegistry.Keyring)
-	c.Factory = newFactory(c.Context)
+	c.Context = newContext(c.RPC, c.out, c.chainID, c.homePath, c.codecRegistrations...).WithKeyring(c.AccountRegistry.Keyring)
+	c.Factory = newFactory(c.Context).WithGasAdjustment(c.gasAdjustment)
+	if !c.useAutoFees && c.gasPrices != "" {
+		gasPrices, err := c.singleDenomGasPrices(c.defaultGasPrices, "")
+		if err != nil {
+			return Client{}, err
+		}
+		c.Factory = c.Factory.WithGasPrices(gasPrices.String())
+	}
 
 	return c, nil
 }
@@ -240,6 +300,15 @@ func (r Response) Decode(message proto.Message) error {
 	}, message)
 }
 
+// NewResponse returns the response of a tx decoded with cdc, it's used to build the responses of
+// the fake clients in tests.
+func NewResponse(cdc codec.Codec, res *sdktypes.TxResponse) Response {
+	return Response{
+		codec:      cdc,
+		TxResponse: res,
+	}
+}
+
 // BroadcastTx creates and broadcasts a tx with given messages for account.
 func (c Client) BroadcastTx(accountName string, msgs ...sdktypes.Msg) (Response, error) {
 	_, broadcast, err := c.BroadcastTxWithProvision(accountName, msgs...)
@@ -254,6 +323,20 @@ var mconf sync.Mutex
 
 func (c Client) BroadcastTxWithProvision(accountName string, msgs ...sdktypes.Msg) (
 	gas uint64, broadcast func() (Response, error), err error) {
+	gas, broadcastAndWait, err := c.provision(accountName, msgs...)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return gas, func() (Response, error) {
+		return broadcastAndWait(context.Background(), c.Context.BroadcastMode == flags.BroadcastBlock)
+	}, nil
+}
+
+// provision simulates the tx to calculate its gas and returns a func to broadcast it. the tx is waited
+// to be included in a block by the broadcast func when wait is true.
+func (c Client) provision(accountName string, msgs ...sdktypes.Msg) (
+	gas uint64, broadcast func(ctx context.Context, wait bool) (Response, error), err error) {
 	if err := c.prepareBroadcast(context.Background(), accountName, msgs); err != nil {
 		return 0, nil, err
 	}
@@ -278,33 +361,113 @@ func (c Client) BroadcastTxWithProvision(accountName string, msgs ...sdktypes.Ms
 		return 0, nil, err
 	}
 
-	_, gas, err = tx.CalculateGas(ctx, txf, msgs...)
-	if err != nil {
-		return 0, nil, err
+	// the sequence in the factory is only used when it's explicitly set, otherwise the next sequence
+	// of the account is tracked by the client.
+	var sequence *accountSequence
+	if c.Factory.Sequence() == 0 && c.sequences != nil {
+		sequence = c.sequences.account(accountAddress)
 	}
-	// the simulated gas can vary from the actual gas needed for a real transaction
-	// we add an additional amount to endure sufficient gas is provided
-	gas += 10000
-	txf = txf.WithGas(gas)
 
-	// Return the provision function
-	return gas, func() (Response, error) {
-		txUnsigned, err := tx.BuildUnsignedTx(txf, msgs...)
+	opts := c.broadcastOptions
+	if opts.memo != "" {
+		txf = txf.WithMemo(opts.memo)
+	}
+	if opts.timeoutHeight != 0 {
+		txf = txf.WithTimeoutHeight(opts.timeoutHeight)
+	}
+	if opts.feeGranter != nil {
+		ctx = ctx.WithFeeGranterAddress(opts.feeGranter)
+	}
+
+	if opts.gas != 0 {
+		gas = opts.gas
+	} else {
+		err = useSequence(ctx, sequence, txf, func(number, seq uint64) (bool, error) {
+			txf = txf.WithAccountNumber(number).WithSequence(seq)
+			_, gas, err = tx.CalculateGas(ctx, txf, msgs...)
+			return false, err
+		})
 		if err != nil {
-			return Response{}, err
+			return 0, nil, err
 		}
+		// the simulated gas can vary from the actual gas needed for a real transaction
+		// we add an additional amount to endure sufficient gas is provided
+		gas += 10000
+	}
+	txf = txf.WithGas(gas)
 
-		txUnsigned.SetFeeGranter(ctx.GetFeeGranterAddress())
-		if err := tx.Sign(txf, accountName, txUnsigned, true); err != nil {
-			return Response{}, err
+	switch {
+	case opts.fees != "":
+		if _, err := sdktypes.ParseCoinsNormalized(opts.fees); err != nil {
+			return 0, nil, errors.Wrap(err, "invalid fees")
 		}
+		txf = txf.WithGasPrices("").WithFees(opts.fees)
 
-		txBytes, err := ctx.TxConfig.TxEncoder()(txUnsigned.GetTx())
+	// fees are calculated from the estimated gas prices and the gas of the tx.
+	case c.useAutoFees:
+		gasPrices, err := c.EstimateGasPrices(context.Background())
 		if err != nil {
-			return Response{}, err
+			return 0, nil, err
+		}
+		txf = txf.WithFees("").WithGasPrices(gasPrices.String())
+	}
+
+	// Return the provision function
+	return gas, func(waitCtx context.Context, wait bool) (Response, error) {
+		var (
+			resp *sdktypes.TxResponse
+			mode = ctx.BroadcastMode
+		)
+
+		// when the tx is waited, the sequence is only locked until the tx is accepted by the node, then the tx
+		// is waited to be included in a block, so other txs of the account can be broadcasted meanwhile.
+		if wait {
+			mode = flags.BroadcastSync
+		}
+
+		err := useSequence(ctx, sequence, txf, func(number, seq uint64) (bool, error) {
+			txf := txf.WithAccountNumber(number).WithSequence(seq)
+
+			txUnsigned, err := tx.BuildUnsignedTx(txf, msgs...)
+			if err != nil {
+				return false, err
+			}
+
+			txUnsigned.SetFeeGranter(ctx.GetFeeGranterAddress())
+			if c.useEIP712 {
+				err = c.signEIP712(ctx, txf, accountName, txUnsigned)
+			} else {
+				err = tx.Sign(txf, accountName, txUnsigned, true)
+			}
+			if err != nil {
+				return false, err
+			}
+
+			txBytes, err := ctx.TxConfig.TxEncoder()(txUnsigned.GetTx())
+			if err != nil {
+				return false, err
+			}
+
+			if resp, err = ctx.WithBroadcastMode(mode).BroadcastTx(txBytes); err != nil {
+				// it's unknown whether the tx is accepted or not, the sequence is locked while fn is called.
+				if sequence != nil {
+					sequence.synced = false
+				}
+				return false, err
+			}
+			if resp.Code == sdkerrors.ErrWrongSequence.ABCICode() && resp.Codespace == sdkerrors.ErrWrongSequence.Codespace() {
+				return false, sdkerrors.Wrap(sdkerrors.ErrWrongSequence, resp.RawLog)
+			}
+			// the sequence is only used when the tx passes CheckTx, or when it's included in a block
+			// and fails in DeliverTx.
+			return resp.Code == 0 || resp.Height > 0, nil
+		})
+		if err == nil && resp.Code == 0 && wait {
+			if resp, err = c.WaitForTx(waitCtx, resp.TxHash); err != nil && sequence != nil {
+				sequence.invalidate()
+			}
 		}
 
-		resp, err := ctx.BroadcastTx(txBytes)
 		return Response{
 			codec:      ctx.Codec,
 			TxResponse: resp,
@@ -312,6 +475,21 @@ func (c Client) BroadcastTxWithProvision(accountName string, msgs ...sdktypes.Ms
 	}, nil
 }
 
+// useSequence calls fn with the account number and the next sequence tracked by the client. when sequence
+// is nil, fn is called with the account number and the sequence of the tx factory.
+func useSequence(
+	ctx client.Context,
+	sequence *accountSequence,
+	txf tx.Factory,
+	fn func(number, seq uint64) (used bool, err error),
+) error {
+	if sequence == nil {
+		_, err := fn(txf.AccountNumber(), txf.Sequence())
+		return err
+	}
+	return sequence.use(ctx, fn)
+}
+
 // prepareBroadcast performs checks and operations before broadcasting messages
 func (c *Client) prepareBroadcast(ctx context.Context, accountName string, _ []sdktypes.Msg) error {
 	// TODO uncomment after https://github.com/tendermint/spn/issues/363
@@ -426,6 +604,7 @@ func newContext(
 	out io.Writer,
 	chainID,
 	home string,
+	registrations ...codecRegistration,
 ) client.Context {
 	var (
 		amino             = codec.NewLegacyAmino()
@@ -439,6 +618,14 @@ func newContext(
 	sdktypes.RegisterInterfaces(interfaceRegistry)
 	staking.RegisterInterfaces(interfaceRegistry)
 	cryptocodec.RegisterInterfaces(interfaceRegistry)
+	registerEthermintInterfaces(interfaceRegistry)
+
+	sdktypes.RegisterLegacyAminoCodec(amino)
+	cryptocodec.RegisterCrypto(amino)
+
+	for _, register := range registrations {
+		register(interfaceRegistry, amino)
+	}
 
 	return client.Context{}.
 		WithChainID(chainID).
@@ -0,0 +1,160 @@
+package cosmosclient
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/eip712"
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
+)
+
+// reEthermintChainID matches the chain ids of Ethermint based chains, e.g. evmos_9000-1.
+var reEthermintChainID = regexp.MustCompile(`^[a-z]+_([1-9][0-9]*)-[1-9][0-9]*$`)
+
+func init() {
+	proto.RegisterType((*ethAccount)(nil), "ethermint.types.v1.EthAccount")
+	proto.RegisterType((*extensionOptionsWeb3Tx)(nil), "ethermint.types.v1.ExtensionOptionsWeb3Tx")
+}
+
+// registerEthermintInterfaces registers the types of Ethermint based chains that are needed to
+// query accounts and broadcast txs.
+func registerEthermintInterfaces(registry codectypes.InterfaceRegistry) {
+	ethsecp256k1.RegisterInterfaces(registry)
+	registry.RegisterImplementations((*authtypes.AccountI)(nil), &ethAccount{})
+}
+
+// parseEIP155ChainID parses the EIP-155 chain id from the chain id of an Ethermint based chain.
+func parseEIP155ChainID(chainID string) (uint64, error) {
+	match := reEthermintChainID.FindStringSubmatch(chainID)
+	if match == nil {
+		return 0, fmt.Errorf("chain id %q is not in {name}_{eip155 id}-{version} format", chainID)
+	}
+	return strconv.ParseUint(match[1], 10, 64)
+}
+
+// ethAccount is the account type of Ethermint based chains.
+type ethAccount struct {
+	BaseAccount *authtypes.BaseAccount `protobuf:"bytes,1,opt,name=base_account,json=baseAccount,proto3" json:"base_account,omitempty"`
+	CodeHash    string                 `protobuf:"bytes,2,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
+}
+
+func (a *ethAccount) Reset()         { *a = ethAccount{} }
+func (a *ethAccount) String() string { return proto.CompactTextString(a) }
+func (*ethAccount) ProtoMessage()    {}
+
+func (a *ethAccount) base() *authtypes.BaseAccount {
+	if a.BaseAccount == nil {
+		a.BaseAccount = &authtypes.BaseAccount{}
+	}
+	return a.BaseAccount
+}
+
+func (a *ethAccount) GetAddress() sdktypes.AccAddress           { return a.base().GetAddress() }
+func (a *ethAccount) SetAddress(addr sdktypes.AccAddress) error { return a.base().SetAddress(addr) }
+func (a *ethAccount) GetPubKey() cryptotypes.PubKey             { return a.base().GetPubKey() }
+func (a *ethAccount) SetPubKey(pk cryptotypes.PubKey) error     { return a.base().SetPubKey(pk) }
+func (a *ethAccount) GetAccountNumber() uint64                  { return a.base().GetAccountNumber() }
+func (a *ethAccount) SetAccountNumber(n uint64) error           { return a.base().SetAccountNumber(n) }
+func (a *ethAccount) GetSequence() uint64                       { return a.base().GetSequence() }
+func (a *ethAccount) SetSequence(sequence uint64) error         { return a.base().SetSequence(sequence) }
+
+// UnpackInterfaces unpacks the public key of the account.
+func (a *ethAccount) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	return a.base().UnpackInterfaces(unpacker)
+}
+
+// signEIP712 signs the tx with EIP-712 as it's done by Web3 wallets for Ethermint based chains. the amino JSON
+// sign doc of the tx is wrapped into typed data and the signature is set to the tx with an extension option.
+func (c Client) signEIP712(ctx client.Context, txf tx.Factory, accountName string, builder client.TxBuilder) error {
+	extBuilder, ok := builder.(authtx.ExtensionOptionsTxBuilder)
+	if !ok {
+		return errors.New("tx builder doesn't support extension options")
+	}
+
+	account, err := c.Account(accountName)
+	if err != nil {
+		return err
+	}
+	if account.Info.GetAlgo() != ethsecp256k1.Type {
+		return fmt.Errorf("account %q must be an %s account to sign with EIP-712", accountName, ethsecp256k1.Type)
+	}
+
+	eip155ChainID, err := parseEIP155ChainID(txf.ChainID())
+	if err != nil {
+		return err
+	}
+
+	feePayer, err := sdktypes.Bech32ifyAddressBytes(c.addressPrefix, account.Info.GetAddress())
+	if err != nil {
+		return err
+	}
+
+	unsignedTx := builder.GetTx()
+	signDoc := legacytx.StdSignBytes(
+		txf.ChainID(),
+		txf.AccountNumber(),
+		txf.Sequence(),
+		txf.TimeoutHeight(),
+		legacytx.StdFee{Amount: unsignedTx.GetFee(), Gas: unsignedTx.GetGas()},
+		unsignedTx.GetMsgs(),
+		unsignedTx.GetMemo(),
+	)
+
+	typedData, err := eip712.WrapTxToTypedData(eip155ChainID, feePayer, signDoc)
+	if err != nil {
+		return err
+	}
+	signBytes, err := typedData.SignBytes()
+	if err != nil {
+		return err
+	}
+
+	// eth_secp256k1 keys sign the Keccak-256 hash of the sign bytes, which is the hash of the typed data.
+	signature, pubKey, err := ctx.Keyring.Sign(accountName, signBytes)
+	if err != nil {
+		return err
+	}
+
+	option, err := codectypes.NewAnyWithValue(&extensionOptionsWeb3Tx{
+		TypedDataChainID: eip155ChainID,
+		FeePayer:         feePayer,
+		FeePayerSig:      signature,
+	})
+	if err != nil {
+		return err
+	}
+	extBuilder.SetExtensionOptions(option)
+
+	return builder.SetSignatures(signing.SignatureV2{
+		PubKey: pubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode:  signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+			Signature: signature,
+		},
+		Sequence: txf.Sequence(),
+	})
+}
+
+// extensionOptionsWeb3Tx is the extension option of the txs signed with EIP-712 for Ethermint based chains.
+type extensionOptionsWeb3Tx struct {
+	TypedDataChainID uint64 `protobuf:"varint,1,opt,name=typed_data_chain_id,json=typedDataChainId,proto3" json:"typed_data_chain_id,omitempty"`
+	FeePayer         string `protobuf:"bytes,2,opt,name=fee_payer,json=feePayer,proto3" json:"fee_payer,omitempty"`
+	FeePayerSig      []byte `protobuf:"bytes,3,opt,name=fee_payer_sig,json=feePayerSig,proto3" json:"fee_payer_sig,omitempty"`
+}
+
+func (o *extensionOptionsWeb3Tx) Reset()         { *o = extensionOptionsWeb3Tx{} }
+func (o *extensionOptionsWeb3Tx) String() string { return proto.CompactTextString(o) }
+func (*extensionOptionsWeb3Tx) ProtoMessage()    {}
@@ -0,0 +1,116 @@
+package cosmosclient
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/eip712"
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
+)
+
+func TestParseEIP155ChainID(t *testing.T) {
+	id, err := parseEIP155ChainID("evmos_9000-1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(9000), id)
+
+	_, err = parseEIP155ChainID("mars")
+	require.Error(t, err)
+}
+
+func TestSignEIP712(t *testing.T) {
+	registry, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+
+	alice, _, err := registry.Create("alice", cosmosaccount.WithKeyType(ethsecp256k1.Type))
+	require.NoError(t, err)
+	_, _, err = registry.Create("bob")
+	require.NoError(t, err)
+
+	var (
+		ctx = newContext(nil, nil, "evmos_9000-1", t.TempDir()).WithKeyring(registry.Keyring)
+		c   = Client{
+			Context:         ctx,
+			Factory:         newFactory(ctx),
+			AccountRegistry: registry,
+			addressPrefix:   "evmos",
+			chainID:         "evmos_9000-1",
+			useEIP712:       true,
+		}
+		txf = c.Factory.WithAccountNumber(3).WithSequence(7)
+		msg = banktypes.NewMsgSend(
+			alice.Info.GetAddress(),
+			alice.Info.GetAddress(),
+			sdktypes.NewCoins(sdktypes.NewInt64Coin("aevmos", 10)),
+		)
+	)
+
+	builder, err := tx.BuildUnsignedTx(txf, msg)
+	require.NoError(t, err)
+	require.NoError(t, c.signEIP712(ctx, txf, "alice", builder))
+
+	// the signature is set to the tx and to the extension option.
+	signatures, err := builder.GetTx().GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, signatures, 1)
+	data := signatures[0].Data.(*signing.SingleSignatureData)
+	require.Equal(t, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, data.SignMode)
+
+	options := builder.GetTx().(ante.HasExtensionOptionsTx).GetExtensionOptions()
+	require.Len(t, options, 1)
+	require.Equal(t, "/ethermint.types.v1.ExtensionOptionsWeb3Tx", options[0].TypeUrl)
+
+	var option extensionOptionsWeb3Tx
+	require.NoError(t, proto.Unmarshal(options[0].Value, &option))
+	require.Equal(t, uint64(9000), option.TypedDataChainID)
+	require.Equal(t, alice.Address("evmos"), option.FeePayer)
+	require.Equal(t, data.Signature, option.FeePayerSig)
+
+	// the signature is made for the hash of the typed data.
+	signDoc := legacytx.StdSignBytes(
+		"evmos_9000-1", 3, 7, 0,
+		legacytx.StdFee{Amount: builder.GetTx().GetFee(), Gas: builder.GetTx().GetGas()},
+		[]sdktypes.Msg{msg},
+		"",
+	)
+	typedData, err := eip712.WrapTxToTypedData(9000, alice.Address("evmos"), signDoc)
+	require.NoError(t, err)
+	hash, err := typedData.Hash()
+	require.NoError(t, err)
+	pubKey := signatures[0].PubKey.(*ethsecp256k1.PubKey)
+	require.True(t, pubKey.VerifyHashSignature(hash, data.Signature))
+
+	// only eth_secp256k1 accounts can sign with EIP-712.
+	builder, err = tx.BuildUnsignedTx(txf, msg)
+	require.NoError(t, err)
+	require.Error(t, c.signEIP712(ctx, txf, "bob", builder))
+}
+
+func TestEthAccount(t *testing.T) {
+	ctx := newContext(nil, nil, "evmos_9000-1", t.TempDir())
+
+	account, err := codectypes.NewAnyWithValue(&ethAccount{
+		BaseAccount: authtypes.NewBaseAccount(sdktypes.AccAddress("address"), nil, 3, 7),
+		CodeHash:    "hash",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/ethermint.types.v1.EthAccount", account.TypeUrl)
+
+	var acc authtypes.AccountI
+	require.NoError(t, ctx.InterfaceRegistry.UnpackAny(&codectypes.Any{
+		TypeUrl: account.TypeUrl,
+		Value:   account.Value,
+	}, &acc))
+	require.Equal(t, uint64(3), acc.GetAccountNumber())
+	require.Equal(t, uint64(7), acc.GetSequence())
+}
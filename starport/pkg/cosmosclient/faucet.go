@@ -0,0 +1,79 @@
+package cosmosclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosfaucet"
+)
+
+// errFaucetNotSet is returned when the client is asked for tokens without a faucet.
+var errFaucetNotSet = errors.New("faucet is not set, use WithUseFaucet to set it")
+
+// Balances returns all the balances of the address.
+func (c Client) Balances(ctx context.Context, address string) (sdktypes.Coins, error) {
+	resp, err := banktypes.NewQueryClient(c.Context).AllBalances(ctx, &banktypes.QueryAllBalancesRequest{
+		Address: address,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Balances, nil
+}
+
+// FundFromFaucet requests coins from the faucet set by WithUseFaucet to the account and waits until
+// they are received. the faucet sends its default coins when coins is empty.
+func (c Client) FundFromFaucet(ctx context.Context, accountName string, coins sdktypes.Coins) error {
+	if !c.useFaucet {
+		return errFaucetNotSet
+	}
+
+	account, err := c.Account(accountName)
+	if err != nil {
+		return err
+	}
+	address := account.Address(c.addressPrefix)
+
+	// the received coins are detected from the balance of a requested denom.
+	denom := c.faucetDenom
+	if len(coins) > 0 {
+		denom = coins[0].Denom
+	}
+	balanceBefore, err := c.Balances(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	req := cosmosfaucet.TransferRequest{AccountAddress: address}
+	for _, coin := range coins {
+		req.Coins = append(req.Coins, coin.String())
+	}
+	faucetResp, err := cosmosfaucet.NewClient(c.faucetAddress).Transfer(ctx, req)
+	if err != nil {
+		return errors.Wrap(errCannotRetrieveFundsFromFaucet, err.Error())
+	}
+	if faucetResp.Error != "" {
+		return errors.Wrap(errCannotRetrieveFundsFromFaucet, faucetResp.Error)
+	}
+
+	// make sure funds are retrieved.
+	ctx, cancel := context.WithTimeout(ctx, FaucetTransferEnsureDuration)
+	defer cancel()
+
+	return backoff.Retry(func() error {
+		balance, err := c.Balances(ctx, address)
+		if err != nil {
+			return err
+		}
+		if !balance.AmountOf(denom).GT(balanceBefore.AmountOf(denom)) {
+			return fmt.Errorf("%q coins from the faucet are not received yet", denom)
+		}
+		return nil
+	}, backoff.WithContext(backoff.NewConstantBackOff(time.Second), ctx))
+}
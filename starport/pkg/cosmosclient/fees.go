@@ -0,0 +1,228 @@
+package cosmosclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	defaultGasPriceMultiplier = 1.0
+
+	// gRPC methods queried to estimate the gas prices. the node config service is available on
+	// Cosmos SDK v0.46+ and the fee market module is available on Ethermint based chains.
+	methodNodeConfig       = "/cosmos.base.node.v1beta1.Service/Config"
+	methodFeeMarketBaseFee = "/ethermint.feemarket.v1.Query/BaseFee"
+	methodEVMParams        = "/ethermint.evm.v1.Query/Params"
+)
+
+// WithAutoFees makes the client estimate the fees of txs from the gas prices of the node instead of
+// broadcasting them with the fees of the tx factory. the gas prices are queried from the node's min
+// gas prices and from the fee market module when the chain has one. when none of them are
+// available, gas prices set with WithGasPrices are used.
+func WithAutoFees() Option {
+	return func(c *Client) {
+		c.useAutoFees = true
+	}
+}
+
+// WithGasPrices sets the gas prices used to pay the fees of txs, e.g. 0.025uatom. when it's used
+// with WithAutoFees, the gas prices are only used if they cannot be queried from the node.
+func WithGasPrices(gasPrices string) Option {
+	return func(c *Client) {
+		c.gasPrices = gasPrices
+	}
+}
+
+// WithFeeDenom sets the denom that the fees of txs are paid in when the node accepts several
+// denoms, by default it's the denom of the base fee or of the gas prices set with WithGasPrices.
+func WithFeeDenom(denom string) Option {
+	return func(c *Client) {
+		c.feeDenom = denom
+	}
+}
+
+// WithGasAdjustment sets the multiplier applied to the simulated gas of txs. by default, it is 1.
+func WithGasAdjustment(adjustment float64) Option {
+	return func(c *Client) {
+		c.gasAdjustment = adjustment
+	}
+}
+
+// WithGasPriceMultiplier sets the multiplier applied to the gas prices estimated with WithAutoFees,
+// so txs keep paying enough fees when the prices of a fee market increase. by default, it is 1.
+func WithGasPriceMultiplier(multiplier float64) Option {
+	return func(c *Client) {
+		c.gasPriceMultiplier = multiplier
+	}
+}
+
+// EstimateGasPrices returns the gas prices that are used to pay the fees of txs with WithAutoFees,
+// they're in a single denom picked by singleDenomGasPrices.
+func (c Client) EstimateGasPrices(ctx context.Context) (sdktypes.DecCoins, error) {
+	prices, baseFeeDenom, err := queryGasPrices(ctx, c.Context)
+	if err != nil {
+		return nil, err
+	}
+	if prices.IsZero() {
+		prices = c.defaultGasPrices
+	}
+	if prices, err = c.singleDenomGasPrices(prices, baseFeeDenom); err != nil {
+		return nil, err
+	}
+
+	multiplier, err := sdktypes.NewDecFromStr(strconv.FormatFloat(c.gasPriceMultiplier, 'f', -1, 64))
+	if err != nil {
+		return nil, err
+	}
+	return prices.MulDec(multiplier), nil
+}
+
+// singleDenomGasPrices returns the price of the denom that the fees are paid in, the node accepts
+// the fees in any of the denoms of its gas prices so paying in all of them overpays. the denom is
+// the one set with WithFeeDenom, the preferred denom, the first denom of the gas prices set with
+// WithGasPrices or the first one of prices, in that order.
+func (c Client) singleDenomGasPrices(prices sdktypes.DecCoins, preferred string) (sdktypes.DecCoins, error) {
+	if prices.Empty() {
+		return prices, nil
+	}
+
+	if c.feeDenom != "" {
+		if err := sdktypes.ValidateDenom(c.feeDenom); err != nil {
+			return nil, err
+		}
+		amount := prices.AmountOf(c.feeDenom)
+		if !amount.IsPositive() {
+			return nil, fmt.Errorf("the fees cannot be paid in %s, the gas prices are %s", c.feeDenom, prices)
+		}
+		return sdktypes.DecCoins{sdktypes.NewDecCoinFromDec(c.feeDenom, amount)}, nil
+	}
+
+	var candidates []string
+	if preferred != "" {
+		candidates = append(candidates, preferred)
+	}
+	if !c.defaultGasPrices.Empty() {
+		candidates = append(candidates, c.defaultGasPrices[0].Denom)
+	}
+	for _, denom := range candidates {
+		if amount := prices.AmountOf(denom); amount.IsPositive() {
+			return sdktypes.DecCoins{sdktypes.NewDecCoinFromDec(denom, amount)}, nil
+		}
+	}
+	return prices[:1], nil
+}
+
+// queryGasPrices queries the min gas prices of the node and the base fee of the fee market module, the
+// highest price of each denom is returned with the denom of the base fee. the prices are zero when
+// neither of them are available.
+func queryGasPrices(ctx context.Context, clientCtx client.Context) (prices sdktypes.DecCoins, baseFeeDenom string, err error) {
+	var config nodeConfigResponse
+	if err := clientCtx.Invoke(ctx, methodNodeConfig, &nodeConfigRequest{}, &config); err == nil && config.MinimumGasPrice != "" {
+		if prices, err = sdktypes.ParseDecCoins(config.MinimumGasPrice); err != nil {
+			return nil, "", err
+		}
+	}
+
+	baseFee, ok, err := queryBaseFee(ctx, clientCtx)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		return prices, "", nil
+	}
+	return mergeBaseFee(prices, baseFee), baseFee.Denom, nil
+}
+
+// mergeBaseFee replaces the min gas price of the base fee's denom with the base fee when it's higher.
+func mergeBaseFee(prices sdktypes.DecCoins, baseFee sdktypes.DecCoin) sdktypes.DecCoins {
+	estimated := sdktypes.DecCoins{baseFee}
+	for _, price := range prices {
+		if price.Denom != baseFee.Denom {
+			estimated = append(estimated, price)
+		} else if price.Amount.GT(baseFee.Amount) {
+			estimated[0] = price
+		}
+	}
+	return estimated.Sort()
+}
+
+// queryBaseFee queries the base fee of the fee market module in the EVM denom of the chain. ok is false
+// when the chain doesn't have a fee market.
+func queryBaseFee(ctx context.Context, clientCtx client.Context) (baseFee sdktypes.DecCoin, ok bool, err error) {
+	var feeResp baseFeeResponse
+	if err := clientCtx.Invoke(ctx, methodFeeMarketBaseFee, &baseFeeRequest{}, &feeResp); err != nil || feeResp.BaseFee == "" {
+		return sdktypes.DecCoin{}, false, nil
+	}
+
+	var paramsResp evmParamsResponse
+	if err := clientCtx.Invoke(ctx, methodEVMParams, &evmParamsRequest{}, &paramsResp); err != nil {
+		return sdktypes.DecCoin{}, false, nil
+	}
+	if paramsResp.Params == nil || paramsResp.Params.EvmDenom == "" {
+		return sdktypes.DecCoin{}, false, nil
+	}
+
+	amount, err := sdktypes.NewDecFromStr(feeResp.BaseFee)
+	if err != nil {
+		return sdktypes.DecCoin{}, false, err
+	}
+	return sdktypes.NewDecCoinFromDec(paramsResp.Params.EvmDenom, amount), true, nil
+}
+
+// the messages of the node config service and the Ethermint queries, only the fields that are needed
+// to estimate the gas prices are defined.
+
+type nodeConfigRequest struct{}
+
+func (r *nodeConfigRequest) Reset()         { *r = nodeConfigRequest{} }
+func (r *nodeConfigRequest) String() string { return proto.CompactTextString(r) }
+func (*nodeConfigRequest) ProtoMessage()    {}
+
+type nodeConfigResponse struct {
+	MinimumGasPrice string `protobuf:"bytes,1,opt,name=minimum_gas_price,json=minimumGasPrice,proto3" json:"minimum_gas_price,omitempty"`
+}
+
+func (r *nodeConfigResponse) Reset()         { *r = nodeConfigResponse{} }
+func (r *nodeConfigResponse) String() string { return proto.CompactTextString(r) }
+func (*nodeConfigResponse) ProtoMessage()    {}
+
+type baseFeeRequest struct{}
+
+func (r *baseFeeRequest) Reset()         { *r = baseFeeRequest{} }
+func (r *baseFeeRequest) String() string { return proto.CompactTextString(r) }
+func (*baseFeeRequest) ProtoMessage()    {}
+
+type baseFeeResponse struct {
+	BaseFee string `protobuf:"bytes,1,opt,name=base_fee,json=baseFee,proto3" json:"base_fee,omitempty"`
+}
+
+func (r *baseFeeResponse) Reset()         { *r = baseFeeResponse{} }
+func (r *baseFeeResponse) String() string { return proto.CompactTextString(r) }
+func (*baseFeeResponse) ProtoMessage()    {}
+
+type evmParamsRequest struct{}
+
+func (r *evmParamsRequest) Reset()         { *r = evmParamsRequest{} }
+func (r *evmParamsRequest) String() string { return proto.CompactTextString(r) }
+func (*evmParamsRequest) ProtoMessage()    {}
+
+type evmParams struct {
+	EvmDenom string `protobuf:"bytes,1,opt,name=evm_denom,json=evmDenom,proto3" json:"evm_denom,omitempty"`
+}
+
+func (p *evmParams) Reset()         { *p = evmParams{} }
+func (p *evmParams) String() string { return proto.CompactTextString(p) }
+func (*evmParams) ProtoMessage()    {}
+
+type evmParamsResponse struct {
+	Params *evmParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (r *evmParamsResponse) Reset()         { *r = evmParamsResponse{} }
+func (r *evmParamsResponse) String() string { return proto.CompactTextString(r) }
+func (*evmParamsResponse) ProtoMessage()    {}
@@ -0,0 +1,100 @@
+package cosmosclient
+
+import (
+	"context"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func TestMergeBaseFee(t *testing.T) {
+	prices := sdktypes.NewDecCoins(
+		sdktypes.NewDecCoinFromDec("aphoton", sdktypes.NewDec(10)),
+		sdktypes.NewDecCoinFromDec("stake", sdktypes.NewDec(1)),
+	)
+
+	// the base fee is higher than the min gas price.
+	merged := mergeBaseFee(prices, sdktypes.NewDecCoinFromDec("aphoton", sdktypes.NewDec(20)))
+	require.Equal(t, "20.000000000000000000aphoton,1.000000000000000000stake", merged.String())
+
+	// the min gas price is higher than the base fee.
+	merged = mergeBaseFee(prices, sdktypes.NewDecCoinFromDec("aphoton", sdktypes.NewDec(5)))
+	require.Equal(t, prices.String(), merged.String())
+
+	// the node has no min gas prices.
+	merged = mergeBaseFee(nil, sdktypes.NewDecCoinFromDec("aphoton", sdktypes.NewDec(5)))
+	require.Equal(t, "5.000000000000000000aphoton", merged.String())
+}
+
+func TestEstimateGasPricesFallback(t *testing.T) {
+	// there is no node listening at the address, so the gas prices cannot be queried.
+	rpc, err := rpchttp.New("http://127.0.0.1:1", "/websocket")
+	require.NoError(t, err)
+
+	c := Client{
+		RPC:                rpc,
+		Context:            newContext(rpc, nil, "mars", t.TempDir()),
+		defaultGasPrices:   sdktypes.NewDecCoins(sdktypes.NewDecCoinFromDec("stake", sdktypes.NewDec(2))),
+		gasPriceMultiplier: 1.5,
+	}
+
+	prices, err := c.EstimateGasPrices(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "3.000000000000000000stake", prices.String())
+}
+
+func TestSingleDenomGasPrices(t *testing.T) {
+	prices := sdktypes.NewDecCoins(
+		sdktypes.NewDecCoinFromDec("aphoton", sdktypes.NewDec(10)),
+		sdktypes.NewDecCoinFromDec("stake", sdktypes.NewDec(1)),
+		sdktypes.NewDecCoinFromDec("token", sdktypes.NewDec(2)),
+	)
+	defaults := sdktypes.NewDecCoins(sdktypes.NewDecCoinFromDec("token", sdktypes.NewDec(3)))
+
+	tests := []struct {
+		name      string
+		c         Client
+		preferred string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "first denom",
+			want: "10.000000000000000000aphoton",
+		},
+		{
+			name:      "base fee denom",
+			preferred: "stake",
+			want:      "1.000000000000000000stake",
+		},
+		{
+			name: "denom of the default gas prices",
+			c:    Client{defaultGasPrices: defaults},
+			want: "2.000000000000000000token",
+		},
+		{
+			name:      "fee denom",
+			c:         Client{feeDenom: "token", defaultGasPrices: defaults},
+			preferred: "stake",
+			want:      "2.000000000000000000token",
+		},
+		{
+			name:    "fee denom without gas price",
+			c:       Client{feeDenom: "atom"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.singleDenomGasPrices(prices, tt.preferred)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package cosmosclient
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+)
+
+// multisigSignMode is the sign mode that is supported by multisig accounts.
+const multisigSignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+
+// MultisigTx is a tx of a multisig account that is assembled from the signatures of the members.
+// The unsigned tx can be shared with the members in JSON, so each member can sign it with its own client
+// and send back a partial signature.
+type MultisigTx struct {
+	client        Client
+	ctx           client.Context
+	signerData    authsigning.SignerData
+	builder       client.TxBuilder
+	threshold     int
+	pubKeys       []cryptotypes.PubKey
+	multisigKey   cryptotypes.PubKey
+	signatures    *signing.MultiSignatureData
+	signedMembers map[string]bool
+}
+
+// NewMultisigTx creates a new tx with messages for the multisig account.
+func (c Client) NewMultisigTx(multisigName string, msgs ...sdktypes.Msg) (*MultisigTx, error) {
+	t, err := c.newMultisigTx(multisigName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.builder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+	t.builder.SetGasLimit(c.Factory.Gas())
+	t.builder.SetFeeAmount(c.Factory.Fees())
+	t.builder.SetMemo(c.Factory.Memo())
+
+	return t, nil
+}
+
+// MultisigTxFromJSON decodes an unsigned tx of the multisig account from JSON.
+func (c Client) MultisigTxFromJSON(multisigName string, txJSON []byte) (*MultisigTx, error) {
+	t, err := c.newMultisigTx(multisigName)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := c.Context.TxConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid tx")
+	}
+
+	if t.builder, err = c.Context.TxConfig.WrapTxBuilder(decoded); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (c Client) newMultisigTx(multisigName string) (*MultisigTx, error) {
+	account, err := c.Account(multisigName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.IsMultisig() {
+		return nil, cosmosaccount.ErrNotMultisig
+	}
+
+	ctx := c.Context.
+		WithFromName(multisigName).
+		WithFromAddress(account.Info.GetAddress())
+
+	txf, err := prepareFactory(ctx, c.Factory)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newMultisigTxWithSigner(ctx, account, authsigning.SignerData{
+		ChainID:       c.chainID,
+		AccountNumber: txf.AccountNumber(),
+		Sequence:      txf.Sequence(),
+	})
+}
+
+func (c Client) newMultisigTxWithSigner(
+	ctx client.Context,
+	account cosmosaccount.Account,
+	signerData authsigning.SignerData,
+) (*MultisigTx, error) {
+	threshold, pubKeys, err := account.Multisig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultisigTx{
+		client:        c,
+		ctx:           ctx,
+		signerData:    signerData,
+		builder:       c.Context.TxConfig.NewTxBuilder(),
+		threshold:     threshold,
+		pubKeys:       pubKeys,
+		multisigKey:   account.Info.GetPubKey(),
+		signatures:    multisig.NewMultisig(len(pubKeys)),
+		signedMembers: make(map[string]bool),
+	}, nil
+}
+
+// JSON encodes the unsigned tx in JSON to be shared with the members.
+func (t *MultisigTx) JSON() ([]byte, error) {
+	return t.ctx.TxConfig.TxJSONEncoder()(t.builder.GetTx())
+}
+
+// Sign signs the tx with a member account that is in the account registry of the client.
+// the returned partial signature needs to be added to the tx with AddSignature.
+func (t *MultisigTx) Sign(memberName string) (signing.SignatureV2, error) {
+	member, err := t.client.Account(memberName)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	if !t.isMember(member.Info.GetPubKey()) {
+		return signing.SignatureV2{}, fmt.Errorf("account %q is not a member of the multisig account", memberName)
+	}
+
+	// addresses in the sign bytes depend on the global bech32 prefix.
+	mconf.Lock()
+	defer mconf.Unlock()
+	config := sdktypes.GetConfig()
+	config.SetBech32PrefixForAccount(t.client.addressPrefix, t.client.addressPrefix+"pub")
+
+	signBytes, err := t.ctx.TxConfig.SignModeHandler().GetSignBytes(multisigSignMode, t.signerData, t.builder.GetTx())
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+
+	signature, pubKey, err := t.ctx.Keyring.Sign(memberName, signBytes)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+
+	return signing.SignatureV2{
+		PubKey: pubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode:  multisigSignMode,
+			Signature: signature,
+		},
+		Sequence: t.signerData.Sequence,
+	}, nil
+}
+
+// AddSignature adds a partial signature of a member to the tx.
+func (t *MultisigTx) AddSignature(signature signing.SignatureV2) error {
+	if !t.isMember(signature.PubKey) {
+		return errors.New("signature is not made by a member of the multisig account")
+	}
+	if signature.Sequence != t.signerData.Sequence {
+		return fmt.Errorf("signature is made for sequence %d, expected %d", signature.Sequence, t.signerData.Sequence)
+	}
+
+	data, ok := signature.Data.(*signing.SingleSignatureData)
+	if !ok || data.SignMode != multisigSignMode {
+		return fmt.Errorf("signature must be signed in %s sign mode", multisigSignMode)
+	}
+
+	if err := multisig.AddSignatureV2(t.signatures, signature, t.pubKeys); err != nil {
+		return err
+	}
+	t.signedMembers[signature.PubKey.Address().String()] = true
+
+	return nil
+}
+
+// Signatures returns the number of the members that signed the tx and the threshold of the multisig account.
+func (t *MultisigTx) Signatures() (signed, threshold int) {
+	return len(t.signedMembers), t.threshold
+}
+
+// Broadcast broadcasts the tx once it's signed by at least threshold of the members.
+func (t *MultisigTx) Broadcast() (Response, error) {
+	if signed, threshold := t.Signatures(); signed < threshold {
+		return Response{}, fmt.Errorf("tx is signed by %d member(s), at least %d signatures are required", signed, threshold)
+	}
+
+	err := t.builder.SetSignatures(signing.SignatureV2{
+		PubKey:   t.multisigKey,
+		Data:     t.signatures,
+		Sequence: t.signerData.Sequence,
+	})
+	if err != nil {
+		return Response{}, err
+	}
+
+	txBytes, err := t.ctx.TxConfig.TxEncoder()(t.builder.GetTx())
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := t.ctx.BroadcastTx(txBytes)
+	return Response{
+		codec:      t.ctx.Codec,
+		TxResponse: resp,
+	}, handleBroadcastResult(resp, err)
+}
+
+func (t *MultisigTx) isMember(pubKey cryptotypes.PubKey) bool {
+	if pubKey == nil {
+		return false
+	}
+	for _, pk := range t.pubKeys {
+		if bytes.Equal(pk.Bytes(), pubKey.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+// SignatureJSON encodes a partial signature in JSON to be sent to the assembler of a multisig tx.
+func (c Client) SignatureJSON(signature signing.SignatureV2) ([]byte, error) {
+	return c.Context.TxConfig.MarshalSignatureJSON([]signing.SignatureV2{signature})
+}
+
+// SignatureFromJSON decodes a partial signature from JSON.
+func (c Client) SignatureFromJSON(data []byte) (signing.SignatureV2, error) {
+	signatures, err := c.Context.TxConfig.UnmarshalSignatureJSON(data)
+	if err != nil {
+		return signing.SignatureV2{}, errors.Wrap(err, "invalid signature")
+	}
+	if len(signatures) != 1 {
+		return signing.SignatureV2{}, fmt.Errorf("expected a single signature, got %d", len(signatures))
+	}
+	return signatures[0], nil
+}
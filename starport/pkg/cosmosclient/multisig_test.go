@@ -0,0 +1,108 @@
+package cosmosclient
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+)
+
+func TestMultisigTx(t *testing.T) {
+	registry, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob", "carol"} {
+		_, _, err := registry.Create(name)
+		require.NoError(t, err)
+	}
+	team, err := registry.CreateMultisig("team", 2, "alice", "bob", "carol")
+	require.NoError(t, err)
+
+	var (
+		ctx = newContext(nil, nil, "mars", t.TempDir()).WithKeyring(registry.Keyring)
+		c   = Client{
+			Context:         ctx,
+			Factory:         newFactory(ctx),
+			AccountRegistry: registry,
+			addressPrefix:   "cosmos",
+			chainID:         "mars",
+		}
+		signerData = authsigning.SignerData{ChainID: "mars", AccountNumber: 3, Sequence: 7}
+	)
+
+	banktypes.RegisterInterfaces(ctx.InterfaceRegistry)
+
+	tx, err := c.newMultisigTxWithSigner(ctx, team, signerData)
+	require.NoError(t, err)
+	require.NoError(t, tx.builder.SetMsgs(banktypes.NewMsgSend(
+		team.Info.GetAddress(),
+		team.Info.GetAddress(),
+		sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 10)),
+	)))
+
+	// members sign the tx shared in JSON.
+	txJSON, err := tx.JSON()
+	require.NoError(t, err)
+
+	sign := func(member string) signing.SignatureV2 {
+		decoded, err := ctx.TxConfig.TxJSONDecoder()(txJSON)
+		require.NoError(t, err)
+
+		memberTx, err := c.newMultisigTxWithSigner(ctx, team, signerData)
+		require.NoError(t, err)
+		memberTx.builder, err = ctx.TxConfig.WrapTxBuilder(decoded)
+		require.NoError(t, err)
+
+		signature, err := memberTx.Sign(member)
+		require.NoError(t, err)
+
+		// partial signatures are sent back in JSON.
+		signatureJSON, err := c.SignatureJSON(signature)
+		require.NoError(t, err)
+		signature, err = c.SignatureFromJSON(signatureJSON)
+		require.NoError(t, err)
+
+		return signature
+	}
+
+	require.NoError(t, tx.AddSignature(sign("alice")))
+	signed, threshold := tx.Signatures()
+	require.Equal(t, 1, signed)
+	require.Equal(t, 2, threshold)
+
+	_, err = tx.Broadcast()
+	require.EqualError(t, err, "tx is signed by 1 member(s), at least 2 signatures are required")
+
+	require.NoError(t, tx.AddSignature(sign("carol")))
+	signed, _ = tx.Signatures()
+	require.Equal(t, 2, signed)
+
+	// the assembled signature is valid for the multisig account.
+	signBytes := func(mode signing.SignMode) ([]byte, error) {
+		return ctx.TxConfig.SignModeHandler().GetSignBytes(mode, signerData, tx.builder.GetTx())
+	}
+	require.NoError(t, team.Info.GetPubKey().(*multisig.LegacyAminoPubKey).VerifyMultisignature(signBytes, tx.signatures))
+
+	// non-members cannot sign.
+	_, _, err = registry.Create("dave")
+	require.NoError(t, err)
+	_, err = tx.Sign("dave")
+	require.Error(t, err)
+
+	// signatures for another sequence are rejected.
+	signature := sign("bob")
+	signature.Sequence = 8
+	require.Error(t, tx.AddSignature(signature))
+
+	// only multisig accounts can have multisig txs.
+	alice, err := registry.GetByName("alice")
+	require.NoError(t, err)
+	_, err = c.newMultisigTxWithSigner(ctx, alice, signerData)
+	require.Equal(t, cosmosaccount.ErrNotMultisig, err)
+}
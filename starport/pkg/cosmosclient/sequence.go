@@ -0,0 +1,124 @@
+package cosmosclient
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/pkg/errors"
+)
+
+// maxSequenceRetries is the max. number of times a tx is retried with a re-synced sequence.
+const maxSequenceRetries = 5
+
+// reSequenceMismatch matches the error returned by the node when a tx is signed with a wrong sequence.
+var reSequenceMismatch = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
+// sequenceManager tracks the sequences of accounts, so txs of an account can be broadcasted
+// concurrently without having to query the sequence from the chain for each tx.
+type sequenceManager struct {
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+func newSequenceManager() *sequenceManager {
+	return &sequenceManager{
+		accounts: make(map[string]*accountSequence),
+	}
+}
+
+// account returns the sequence of the account with address.
+func (m *sequenceManager) account(address sdktypes.AccAddress) *accountSequence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.accounts[address.String()]
+	if !ok {
+		a = &accountSequence{address: address}
+		m.accounts[address.String()] = a
+	}
+	return a
+}
+
+// accountSequence is the sequence of an account, it's locked while a tx is signed and broadcasted
+// so each tx uses the next sequence in order.
+type accountSequence struct {
+	mu       sync.Mutex
+	address  sdktypes.AccAddress
+	synced   bool
+	number   uint64
+	sequence uint64
+}
+
+// use calls fn with the account number and the next sequence while the sequence is locked. the sequence is
+// incremented when fn succeeds and reports that the sequence is used, e.g. when the node accepts the tx
+// signed with it. when fn fails with a sequence mismatch, the sequence is re-synced and fn is called again.
+func (a *accountSequence) use(ctx client.Context, fn func(number, sequence uint64) (used bool, err error)) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var (
+		used bool
+		err  error
+	)
+
+	for i := 0; i < maxSequenceRetries; i++ {
+		if !a.synced {
+			if a.number, a.sequence, err = ctx.AccountRetriever.GetAccountNumberSequence(ctx, a.address); err != nil {
+				return err
+			}
+			a.synced = true
+		}
+
+		used, err = fn(a.number, a.sequence)
+
+		expected, ok := parseSequenceMismatch(err)
+		switch {
+		case err == nil:
+			if used {
+				a.sequence++
+			}
+			return nil
+
+		case ok:
+			// the node tells the expected sequence, otherwise it's queried again.
+			if expected > a.sequence {
+				a.sequence = expected
+			} else {
+				a.synced = false
+			}
+
+		default:
+			return err
+		}
+	}
+
+	return err
+}
+
+// invalidate makes sure that the sequence is queried from the chain before it's used again.
+// it's needed when it's unknown whether a tx with the sequence is accepted by the node or not.
+func (a *accountSequence) invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.synced = false
+}
+
+// parseSequenceMismatch returns the expected sequence when err is caused by a sequence mismatch.
+// expected is zero when err doesn't tell the expected sequence.
+func parseSequenceMismatch(err error) (expected uint64, ok bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	match := reSequenceMismatch.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, errors.Is(err, sdkerrors.ErrWrongSequence)
+	}
+
+	expected, _ = strconv.ParseUint(match[1], 10, 64)
+	return expected, true
+}
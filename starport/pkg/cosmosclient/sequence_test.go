@@ -0,0 +1,123 @@
+package cosmosclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// accountRetriever is a fake account retriever that returns the same sequence for each query.
+type accountRetriever struct {
+	client.AccountRetriever
+
+	mu       sync.Mutex
+	sequence uint64
+	queries  int
+}
+
+func (r *accountRetriever) GetAccountNumberSequence(client.Context, sdktypes.AccAddress) (uint64, uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries++
+	return 3, r.sequence, nil
+}
+
+func TestAccountSequenceConcurrent(t *testing.T) {
+	var (
+		retriever = &accountRetriever{sequence: 10}
+		ctx       = client.Context{}.WithAccountRetriever(retriever)
+		m         = newSequenceManager()
+		address   = sdktypes.AccAddress("address")
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		used      = make(map[uint64]bool)
+	)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := m.account(address).use(ctx, func(number, sequence uint64) (bool, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				require.Equal(t, uint64(3), number)
+				require.False(t, used[sequence], "sequence %d is used twice", sequence)
+				used[sequence] = true
+				return true, nil
+			})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, used, 50)
+	for seq := uint64(10); seq < 60; seq++ {
+		require.True(t, used[seq])
+	}
+	require.Equal(t, 1, retriever.queries)
+}
+
+func TestAccountSequenceMismatch(t *testing.T) {
+	var (
+		retriever = &accountRetriever{sequence: 1}
+		ctx       = client.Context{}.WithAccountRetriever(retriever)
+		a         = newSequenceManager().account(sdktypes.AccAddress("address"))
+		got       []uint64
+	)
+
+	// the expected sequence is used when the node tells it.
+	err := a.use(ctx, func(_, sequence uint64) (bool, error) {
+		got = append(got, sequence)
+		if sequence != 5 {
+			return false, fmt.Errorf("account sequence mismatch, expected 5, got %d: incorrect account sequence", sequence)
+		}
+		return true, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 5}, got)
+
+	// the sequence is queried again, otherwise.
+	retriever.sequence = 8
+	got = nil
+	err = a.use(ctx, func(_, sequence uint64) (bool, error) {
+		got = append(got, sequence)
+		if sequence != 8 {
+			return false, sdkerrors.ErrWrongSequence
+		}
+		return false, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{6, 8}, got)
+	require.Equal(t, 2, retriever.queries)
+
+	// the sequence is not incremented when it's not used, e.g. when the node rejects the tx.
+	got = nil
+	err = a.use(ctx, func(_, sequence uint64) (bool, error) {
+		got = append(got, sequence)
+		return false, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{8}, got)
+
+	// other errors are not retried and the sequence is not incremented.
+	errFailed := errors.New("failed")
+	got = nil
+	err = a.use(ctx, func(_, sequence uint64) (bool, error) {
+		got = append(got, sequence)
+		return true, errFailed
+	})
+	require.Equal(t, errFailed, err)
+	require.Equal(t, []uint64{8}, got)
+
+	// sequence is retried up to a limit.
+	err = a.use(ctx, func(_, sequence uint64) (bool, error) {
+		return false, sdkerrors.ErrWrongSequence
+	})
+	require.True(t, errors.Is(err, sdkerrors.ErrWrongSequence))
+}
@@ -0,0 +1,117 @@
+package cosmosclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/pkg/errors"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	defaultTxInclusionTimeout = time.Minute
+
+	// txPollInterval is the interval of querying a tx while it's waited to be included in a block.
+	txPollInterval = time.Second
+)
+
+// ErrTxNotIncluded is returned when a tx is not included in a block before the timeout.
+var ErrTxNotIncluded = errors.New("tx is not included in a block")
+
+// WithTxInclusionTimeout sets the duration to wait for a broadcasted tx to be included in a block.
+// by default, it is one minute.
+func WithTxInclusionTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.txInclusionTimeout = timeout
+	}
+}
+
+// BroadcastTxAndWait creates and broadcasts a tx with given messages for account without waiting for
+// the node to commit it, then waits for the tx to be included in a block. the response holds the
+// height of the block, the gas used and the events emitted by the tx.
+func (c Client) BroadcastTxAndWait(ctx context.Context, accountName string, msgs ...sdktypes.Msg) (Response, error) {
+	_, broadcast, err := c.provision(accountName, msgs...)
+	if err != nil {
+		return Response{}, err
+	}
+	return broadcast(ctx, true)
+}
+
+// WaitForTx waits until the tx with hash is included in a block and returns its response. the tx is
+// waited through the websocket connection of the node when it's available, it's polled otherwise.
+func (c Client) WaitForTx(ctx context.Context, hash string) (*sdktypes.TxResponse, error) {
+	timeout := c.txInclusionTimeout
+	if timeout == 0 {
+		timeout = defaultTxInclusionTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	txEvents := c.subscribeTx(ctx, hash)
+
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// the tx is queried at least once, since it might already be included before subscribing.
+		if resp, err := authtx.QueryTx(c.Context, hash); err == nil {
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ErrTxNotIncluded, "%s: %s", hash, ctx.Err())
+
+		case event := <-txEvents:
+			if data, ok := event.Data.(tmtypes.EventDataTx); ok {
+				return sdktypes.NewResponseResultTx(&ctypes.ResultTx{
+					Hash:     tmtypes.Tx(data.Tx).Hash(),
+					Height:   data.Height,
+					Index:    data.Index,
+					TxResult: data.Result,
+					Tx:       data.Tx,
+				}, nil, ""), nil
+			}
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscribeTx subscribes to the inclusion event of the tx with hash until ctx is canceled.
+// a nil channel is returned when the websocket connection of the node is not available.
+func (c Client) subscribeTx(ctx context.Context, hash string) <-chan ctypes.ResultEvent {
+	if c.RPC == nil || c.wsStart == nil {
+		return nil
+	}
+
+	c.wsStart.Do(func() {
+		// the connection is started once and shared by the copies of the client.
+		_ = c.RPC.Start()
+	})
+	if !c.RPC.IsRunning() {
+		return nil
+	}
+
+	var (
+		subscriber = "cosmosclient-" + hash
+		query      = fmt.Sprintf("%s='%s' AND %s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx, tmtypes.TxHashKey, hash)
+	)
+
+	events, err := c.RPC.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		return nil
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = c.RPC.Unsubscribe(context.Background(), subscriber, query)
+	}()
+
+	return events
+}
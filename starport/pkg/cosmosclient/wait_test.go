@@ -0,0 +1,33 @@
+package cosmosclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func TestWaitForTxTimeout(t *testing.T) {
+	// there is no node listening at the address.
+	rpc, err := rpchttp.New("http://127.0.0.1:1", "/websocket")
+	require.NoError(t, err)
+
+	c := Client{
+		RPC:                rpc,
+		Context:            newContext(rpc, nil, "mars", t.TempDir()),
+		txInclusionTimeout: time.Millisecond * 50,
+	}
+
+	_, err = c.WaitForTx(context.Background(), "ABCD")
+	require.True(t, errors.Is(err, ErrTxNotIncluded))
+
+	// the timeout of ctx is respected when it's shorter.
+	c.txInclusionTimeout = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	_, err = c.WaitForTx(ctx, "ABCD")
+	require.True(t, errors.Is(err, ErrTxNotIncluded))
+}
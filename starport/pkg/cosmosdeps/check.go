@@ -0,0 +1,214 @@
+package cosmosdeps
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+)
+
+// ErrNoSDK is returned when the go.mod doesn't require the SDK.
+var ErrNoSDK = errors.New("go.mod doesn't require the Cosmos SDK")
+
+// Severity is the severity of an issue.
+type Severity string
+
+const (
+	// SeverityError is the severity of the dependencies that are not compatible.
+	SeverityError Severity = "error"
+
+	// SeverityWarning is the severity of the dependencies that are not known to be compatible.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a dependency of a go.mod that is not compatible with the others.
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Module   string   `json:"module"`
+	Version  string   `json:"version,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	if i.Version == "" {
+		return fmt.Sprintf("%s: %s", i.Module, i.Message)
+	}
+	return fmt.Sprintf("%s@%s: %s", i.Module, i.Version, i.Message)
+}
+
+// Report is the result of the check of a go.mod.
+type Report struct {
+	// SDK is the version of the SDK of the go.mod.
+	SDK string `json:"sdk"`
+
+	// Compatibility is the compatibility of the SDK line, it's not set for the unknown lines.
+	Compatibility *Compatibility `json:"-"`
+
+	Issues []Issue `json:"issues"`
+}
+
+// HasErrors checks if some issues are errors.
+func (r Report) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Check checks that the versions of the dependencies of the go.mod are compatible with its SDK
+// version. the replaced modules are checked with the versions they're replaced with and the
+// modules that are replaced by local directories are not checked.
+func Check(f *modfile.File) (Report, error) {
+	versions := requiredVersions(f)
+
+	sdk, ok := versions[PathSDK]
+	if !ok {
+		return Report{}, ErrNoSDK
+	}
+
+	report := Report{SDK: sdk.Version, Issues: []Issue{}}
+	if sdk.Version == "" {
+		report.Issues = append(report.Issues, Issue{
+			Severity: SeverityWarning,
+			Module:   PathSDK,
+			Message:  fmt.Sprintf("is replaced by the local directory %s, the dependencies are not checked", sdk.Path),
+		})
+		return report, nil
+	}
+
+	c, ok := Lookup(sdk.Version)
+	if !ok {
+		report.Issues = append(report.Issues, Issue{
+			Severity: SeverityWarning,
+			Module:   PathSDK,
+			Version:  sdk.Version,
+			Message:  fmt.Sprintf("the SDK line is unknown, the known lines are: %s", strings.Join(Lines(), ", ")),
+		})
+		return report, nil
+	}
+	report.Compatibility = &c
+
+	check := func(r Requirement, severity Severity) {
+		v, ok := versions[r.Path]
+		if !ok || v.Version == "" || r.Compatible(v.Version) {
+			return
+		}
+		report.Issues = append(report.Issues, Issue{
+			Severity: severity,
+			Module:   r.Path,
+			Version:  v.Version,
+			Message:  fmt.Sprintf("is not compatible with the SDK %s, use a version >= %s and < %s", c.SDK, r.Min, r.Max),
+		})
+	}
+
+	for path, v := range versions {
+		if !isIBC(path) || v.Version == "" {
+			continue
+		}
+		if len(c.IBC) == 0 {
+			report.Issues = append(report.Issues, Issue{
+				Severity: SeverityError,
+				Module:   path,
+				Version:  v.Version,
+				Message:  fmt.Sprintf("is not compatible with the SDK %s, its IBC module is part of the SDK", c.SDK),
+			})
+			continue
+		}
+
+		r, ok := ibcRequirement(c, path)
+		if !ok {
+			var compatible []string
+			for _, r := range c.IBC {
+				compatible = append(compatible, r.String())
+			}
+			report.Issues = append(report.Issues, Issue{
+				Severity: SeverityError,
+				Module:   path,
+				Version:  v.Version,
+				Message:  fmt.Sprintf("is not compatible with the SDK %s, use one of: %s", c.SDK, strings.Join(compatible, "; ")),
+			})
+			continue
+		}
+		check(r, SeverityError)
+	}
+
+	check(c.Tendermint, SeverityError)
+
+	// the packages of Starport used by the chains only depend on the SDK, so the versions that
+	// are not compatible may still work.
+	check(c.Starport, SeverityWarning)
+
+	for _, r := range c.Replaces {
+		if _, ok := versions[r.Old]; !ok {
+			continue
+		}
+		if !hasReplace(f, r) {
+			report.Issues = append(report.Issues, Issue{
+				Severity: SeverityError,
+				Module:   r.Old,
+				Message:  fmt.Sprintf("must be replaced by %s %s for the SDK %s", r.New, r.NewVersion, c.SDK),
+			})
+		}
+	}
+
+	// the issues of ibc-go are found from a map.
+	sort.SliceStable(report.Issues, func(i, j int) bool { return report.Issues[i].Module < report.Issues[j].Module })
+
+	return report, nil
+}
+
+// CheckAt checks the go.mod of the app at path.
+func CheckAt(path string) (Report, error) {
+	f, err := gomodule.ParseAt(path)
+	if err != nil {
+		return Report{}, err
+	}
+	return Check(f)
+}
+
+// requiredVersions returns the versions of the required modules by their paths, the replaced
+// modules have the versions they're replaced with.
+func requiredVersions(f *modfile.File) map[string]module.Version {
+	versions := make(map[string]module.Version)
+	for _, r := range f.Require {
+		versions[r.Mod.Path] = r.Mod
+	}
+	for _, r := range f.Replace {
+		if _, ok := versions[r.Old.Path]; !ok {
+			continue
+		}
+		if r.Old.Version != "" && r.Old.Version != versions[r.Old.Path].Version {
+			continue
+		}
+		versions[r.Old.Path] = r.New
+	}
+	return versions
+}
+
+// ibcRequirement returns the requirement of the major of ibc-go at path.
+func ibcRequirement(c Compatibility, path string) (Requirement, bool) {
+	for _, r := range c.IBC {
+		if r.Path == path {
+			return r, true
+		}
+	}
+	return Requirement{}, false
+}
+
+// hasReplace checks if the module is replaced as the SDK needs.
+func hasReplace(f *modfile.File, r Replace) bool {
+	for _, replace := range f.Replace {
+		if replace.Old.Path == r.Old && replace.New.Path == r.New && semver.Compare(replace.New.Version, r.NewVersion) == 0 {
+			return true
+		}
+	}
+	return false
+}
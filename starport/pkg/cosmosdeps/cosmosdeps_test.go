@@ -0,0 +1,239 @@
+package cosmosdeps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+)
+
+const gomod = `module github.com/test/mars
+
+go 1.16
+
+require (
+	github.com/cosmos/cosmos-sdk v0.44.5
+	github.com/cosmos/ibc-go/v2 v2.0.2
+	github.com/gogo/protobuf v1.3.3
+	github.com/tendermint/starport v0.19.2
+	github.com/tendermint/tendermint v0.34.14
+	google.golang.org/grpc v1.43.0
+)
+
+replace (
+	github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.3-alpha.regen.1
+	google.golang.org/grpc => google.golang.org/grpc v1.33.2
+)
+`
+
+func parse(t *testing.T, data string) *modfile.File {
+	f, err := modfile.Parse("go.mod", []byte(data), nil)
+	require.NoError(t, err)
+	return f
+}
+
+func TestCheck(t *testing.T) {
+	report, err := Check(parse(t, gomod))
+	require.NoError(t, err)
+	require.Equal(t, "v0.44.5", report.SDK)
+	require.Equal(t, "v0.44", report.Compatibility.SDK)
+	require.Empty(t, report.Issues)
+
+	report, err = Check(parse(t, `module github.com/test/mars
+
+require (
+	github.com/cosmos/cosmos-sdk v0.45.1
+	github.com/cosmos/ibc-go/v2 v2.0.0
+	github.com/gogo/protobuf v1.3.3
+	github.com/tendermint/starport v0.17.0
+	github.com/tendermint/tendermint v0.35.0
+)
+`))
+	require.NoError(t, err)
+	require.True(t, report.HasErrors())
+	require.Equal(t, []Issue{
+		{
+			Severity: SeverityError,
+			Module:   "github.com/cosmos/ibc-go/v2",
+			Version:  "v2.0.0",
+			Message:  "is not compatible with the SDK v0.45, use a version >= v2.0.2 and < v3.0.0",
+		},
+		{
+			Severity: SeverityError,
+			Module:   PathGogoProto,
+			Message:  "must be replaced by github.com/regen-network/protobuf v1.3.3-alpha.regen.1 for the SDK v0.45",
+		},
+		{
+			Severity: SeverityWarning,
+			Module:   PathStarport,
+			Version:  "v0.17.0",
+			Message:  "is not compatible with the SDK v0.45, use a version >= v0.19.0 and < v0.20.0",
+		},
+		{
+			Severity: SeverityError,
+			Module:   PathTendermint,
+			Version:  "v0.35.0",
+			Message:  "is not compatible with the SDK v0.45, use a version >= v0.34.14 and < v0.35.0",
+		},
+	}, report.Issues)
+
+	report, err = Check(parse(t, `module github.com/test/mars
+
+require (
+	github.com/cosmos/cosmos-sdk v0.42.10
+	github.com/cosmos/ibc-go v1.2.0
+)
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Issue{{
+		Severity: SeverityError,
+		Module:   PathIBC,
+		Version:  "v1.2.0",
+		Message:  "is not compatible with the SDK v0.42, its IBC module is part of the SDK",
+	}}, report.Issues)
+
+	// the replaced versions are checked.
+	report, err = Check(parse(t, `module github.com/test/mars
+
+require github.com/cosmos/cosmos-sdk v0.44.5
+
+replace github.com/cosmos/cosmos-sdk => github.com/cosmos/cosmos-sdk v0.46.0
+`))
+	require.NoError(t, err)
+	require.Nil(t, report.Compatibility)
+	require.Equal(t, SeverityWarning, report.Issues[0].Severity)
+	require.Equal(t, "v0.46.0", report.Issues[0].Version)
+
+	_, err = Check(parse(t, "module github.com/test/mars\n"))
+	require.Equal(t, ErrNoSDK, err)
+}
+
+func TestParseTarget(t *testing.T) {
+	for _, tt := range []struct {
+		target, version string
+	}{
+		{"v0.45.x", "v0.45.4"},
+		{"v0.45", "v0.45.4"},
+		{"0.45.2", "v0.45.2"},
+		{"v0.44.x", "v0.44.5"},
+	} {
+		_, version, err := ParseTarget(tt.target)
+		require.NoError(t, err)
+		require.Equal(t, tt.version, version, tt.target)
+	}
+
+	_, _, err := ParseTarget("v0.46.x")
+	require.EqualError(t, err, "unknown SDK line v0.46, the known lines are: v0.42, v0.44, v0.45")
+
+	_, _, err = ParseTarget("latest")
+	require.EqualError(t, err, `invalid SDK version "latest"`)
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	f := parse(t, gomod)
+	upgrade, err := PlanUpgrade(f, "v0.45.x")
+	require.NoError(t, err)
+	// ibc-go v2.0.2 is compatible with both lines.
+	require.Equal(t, []Change{
+		{Module: PathSDK, From: "v0.44.5", To: "v0.45.4"},
+	}, upgrade.Changes)
+	require.Empty(t, upgrade.Imports)
+
+	report, err := Check(f)
+	require.NoError(t, err)
+	require.Equal(t, "v0.45.4", report.SDK)
+	require.Empty(t, report.Issues)
+
+	// the major of ibc-go changes and the missing replace is added.
+	f = parse(t, `module github.com/test/mars
+
+require (
+	github.com/cosmos/cosmos-sdk v0.44.3
+	github.com/cosmos/ibc-go v1.2.0
+	github.com/gogo/protobuf v1.3.3
+	github.com/tendermint/tendermint v0.34.13
+)
+
+replace github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.2-alpha.regen.4
+`)
+	upgrade, err = PlanUpgrade(f, "v0.45.2")
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Module: PathSDK, From: "v0.44.3", To: "v0.45.2"},
+		{Module: PathIBC, From: "v1.2.0", To: "github.com/cosmos/ibc-go/v2 v2.0.3"},
+		{Module: PathTendermint, From: "v0.34.13", To: "v0.34.19"},
+		{
+			Module: "replace " + PathGogoProto,
+			From:   "github.com/regen-network/protobuf v1.3.2-alpha.regen.4",
+			To:     "github.com/regen-network/protobuf v1.3.3-alpha.regen.1",
+		},
+	}, upgrade.Changes)
+	require.Equal(t, map[string]string{PathIBC: PathIBC + "/v2"}, upgrade.Imports)
+
+	report, err = Check(f)
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+
+	for _, tt := range []struct {
+		gomod, target, err string
+	}{
+		{gomod, "v0.42.x", "downgrading the SDK from v0.44.5 to v0.42.11 is not supported"},
+		{
+			"module github.com/test/mars\n\nrequire github.com/cosmos/cosmos-sdk v0.42.10\n",
+			"v0.45.x",
+			"upgrading the SDK from v0.42 to v0.45 needs changes of the app, migrate it with starport chain migrate-sdk",
+		},
+		{
+			"module github.com/test/mars\n\nrequire github.com/cosmos/cosmos-sdk v0.44.5\n\nreplace github.com/cosmos/cosmos-sdk => ../sdk\n",
+			"v0.45.x",
+			"the SDK is replaced by ../sdk, update the replace of go.mod to upgrade it",
+		},
+	} {
+		_, err := PlanUpgrade(parse(t, tt.gomod), tt.target)
+		require.EqualError(t, err, tt.err)
+	}
+}
+
+func TestUpgradeAt(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(`module github.com/test/mars
+
+require (
+	github.com/cosmos/cosmos-sdk v0.44.5
+	github.com/cosmos/ibc-go v1.2.0
+)
+`), 0644))
+
+	app := `package app
+
+import (
+	ibc "github.com/cosmos/ibc-go/modules/core"
+	ibcclient "github.com/cosmos/ibc-go"
+	other "github.com/cosmos/ibc-gopher"
+)
+`
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "app"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app/app.go"), []byte(app), 0644))
+
+	upgrade, err := UpgradeAt(dir, "v0.45.x")
+	require.NoError(t, err)
+	require.Len(t, upgrade.Changes, 2)
+
+	data, err := os.ReadFile(filepath.Join(dir, "app/app.go"))
+	require.NoError(t, err)
+	require.Equal(t, `package app
+
+import (
+	ibc "github.com/cosmos/ibc-go/v2/modules/core"
+	ibcclient "github.com/cosmos/ibc-go/v2"
+	other "github.com/cosmos/ibc-gopher"
+)
+`, string(data))
+
+	report, err := CheckAt(dir)
+	require.NoError(t, err)
+	require.Equal(t, "v0.45.4", report.SDK)
+	require.Empty(t, report.Issues)
+}
@@ -0,0 +1,140 @@
+// Package cosmosdeps knows the compatible versions of the dependencies of the Cosmos SDK chains,
+// it checks the go.mod of a chain and upgrades its dependencies together.
+package cosmosdeps
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// the paths of the modules in the matrix.
+const (
+	PathSDK        = "github.com/cosmos/cosmos-sdk"
+	PathIBC        = "github.com/cosmos/ibc-go"
+	PathTendermint = "github.com/tendermint/tendermint"
+	PathStarport   = "github.com/tendermint/starport"
+	PathGogoProto  = "github.com/gogo/protobuf"
+	PathGRPC       = "google.golang.org/grpc"
+)
+
+// Requirement is the range of the compatible versions of a module.
+type Requirement struct {
+	// Path is the path of the module.
+	Path string
+
+	// Min is the min compatible version.
+	Min string
+
+	// Max is the first version that is not compatible anymore.
+	Max string
+
+	// Version is the version that upgrades use.
+	Version string
+}
+
+// Compatible checks if the version is in the range of the requirement.
+func (r Requirement) Compatible(version string) bool {
+	return semver.Compare(version, r.Min) >= 0 && semver.Compare(version, r.Max) < 0
+}
+
+func (r Requirement) String() string {
+	return fmt.Sprintf("%s >= %s, < %s", r.Path, r.Min, r.Max)
+}
+
+// Replace is a replace directive that the chains of an SDK line need.
+type Replace struct {
+	Old        string
+	New        string
+	NewVersion string
+}
+
+// Compatibility is a combination of the versions of the dependencies that work together for a
+// line of the SDK.
+type Compatibility struct {
+	// SDK is the line of the SDK, e.g. v0.45.
+	SDK string
+
+	// Version is the version of the SDK that upgrades to the line use.
+	Version string
+
+	// IBC are the compatible majors of ibc-go, the first one is used by upgrades. it's empty for
+	// the lines with IBC in the SDK.
+	IBC []Requirement
+
+	Tendermint Requirement
+	Starport   Requirement
+
+	// Replaces are the replace directives the chains of the line need.
+	Replaces []Replace
+
+	// UpgradesFrom are the lines that can be upgraded to this one by only updating go.mod and
+	// the imports, the other upgrades need a migration of the code of the app.
+	UpgradesFrom []string
+}
+
+// replacesStargate are the replaces of the protobuf and the gRPC versions of the SDK.
+var replacesStargate = []Replace{
+	{Old: PathGogoProto, New: "github.com/regen-network/protobuf", NewVersion: "v1.3.3-alpha.regen.1"},
+	{Old: PathGRPC, New: PathGRPC, NewVersion: "v1.33.2"},
+}
+
+// Matrix are the known lines of the SDK, sorted by version.
+var Matrix = []Compatibility{
+	{
+		SDK:        "v0.42",
+		Version:    "v0.42.11",
+		Tendermint: Requirement{Path: PathTendermint, Min: "v0.34.8", Max: "v0.35.0", Version: "v0.34.14"},
+		Starport:   Requirement{Path: PathStarport, Min: "v0.15.0", Max: "v0.18.0", Version: "v0.17.3"},
+		Replaces:   replacesStargate,
+	},
+	{
+		SDK:     "v0.44",
+		Version: "v0.44.5",
+		IBC: []Requirement{
+			{Path: PathIBC + "/v2", Min: "v2.0.0", Max: "v3.0.0", Version: "v2.0.2"},
+			{Path: PathIBC, Min: "v1.1.0", Max: "v2.0.0", Version: "v1.2.5"},
+		},
+		Tendermint: Requirement{Path: PathTendermint, Min: "v0.34.13", Max: "v0.35.0", Version: "v0.34.14"},
+		Starport:   Requirement{Path: PathStarport, Min: "v0.18.0", Max: "v0.20.0", Version: "v0.19.2"},
+		Replaces:   replacesStargate,
+	},
+	{
+		SDK:     "v0.45",
+		Version: "v0.45.4",
+		IBC: []Requirement{
+			{Path: PathIBC + "/v2", Min: "v2.0.2", Max: "v3.0.0", Version: "v2.0.3"},
+			{Path: PathIBC + "/v3", Min: "v3.0.0", Max: "v4.0.0", Version: "v3.0.0"},
+		},
+		Tendermint:   Requirement{Path: PathTendermint, Min: "v0.34.14", Max: "v0.35.0", Version: "v0.34.19"},
+		Starport:     Requirement{Path: PathStarport, Min: "v0.19.0", Max: "v0.20.0", Version: "v0.19.2"},
+		Replaces:     replacesStargate,
+		UpgradesFrom: []string{"v0.44"},
+	},
+}
+
+// Lookup returns the compatibility of the SDK line of version.
+func Lookup(version string) (Compatibility, bool) {
+	line := semver.MajorMinor(version)
+	for _, c := range Matrix {
+		if c.SDK == line {
+			return c, true
+		}
+	}
+	return Compatibility{}, false
+}
+
+// Lines returns the known lines of the SDK.
+func Lines() []string {
+	lines := make([]string, len(Matrix))
+	for i, c := range Matrix {
+		lines[i] = c.SDK
+	}
+	return lines
+}
+
+// isIBC checks if the path is the path of a major of ibc-go.
+func isIBC(path string) bool {
+	return path == PathIBC || strings.HasPrefix(path, PathIBC+"/v")
+}
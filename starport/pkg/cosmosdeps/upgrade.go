@@ -0,0 +1,307 @@
+package cosmosdeps
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+)
+
+// Change is a change of the go.mod made by an upgrade.
+type Change struct {
+	// Module is the path of the module, or of the replaced module for the changes of the replaces.
+	Module string `json:"module"`
+
+	// From is the version before the upgrade, it's empty for the added modules.
+	From string `json:"from,omitempty"`
+
+	// To is the version after the upgrade.
+	To string `json:"to"`
+}
+
+func (c Change) String() string {
+	if c.From == "" {
+		return fmt.Sprintf("%s: %s", c.Module, c.To)
+	}
+	return fmt.Sprintf("%s: %s → %s", c.Module, c.From, c.To)
+}
+
+// Upgrade is an upgrade of the dependencies of a go.mod to a line of the SDK.
+type Upgrade struct {
+	// Compatibility is the compatibility of the line the go.mod is upgraded to.
+	Compatibility Compatibility `json:"-"`
+
+	// Changes are the changes of the go.mod.
+	Changes []Change `json:"changes"`
+
+	// Imports are the new import paths of the modules whose major changes, by their old paths.
+	Imports map[string]string `json:"imports,omitempty"`
+}
+
+// ParseTarget returns the compatibility and the SDK version of the target of an upgrade, the target
+// is a line like v0.45.x and v0.45 or a version of the line like v0.45.4.
+func ParseTarget(target string) (Compatibility, string, error) {
+	version := strings.TrimSuffix(target, ".x")
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return Compatibility{}, "", fmt.Errorf("invalid SDK version %q", target)
+	}
+
+	c, ok := Lookup(version)
+	if !ok {
+		return Compatibility{}, "", fmt.Errorf("unknown SDK line %s, the known lines are: %s", semver.MajorMinor(version), strings.Join(Lines(), ", "))
+	}
+
+	// the line is upgraded to the version of the matrix.
+	if version == c.SDK {
+		version = c.Version
+	}
+	return c, version, nil
+}
+
+type upgradeOptions struct {
+	migrated bool
+}
+
+// UpgradeOption configures an upgrade.
+type UpgradeOption func(*upgradeOptions)
+
+// UpgradeMigrated allows the upgrades between the lines of the SDK that need changes of the app,
+// once the code of the app is migrated to the target line.
+func UpgradeMigrated() UpgradeOption {
+	return func(o *upgradeOptions) {
+		o.migrated = true
+	}
+}
+
+// PlanUpgrade updates the requirements of the go.mod to the target version of the SDK with the
+// versions of ibc-go, Tendermint and Starport that are compatible with it, and adds the
+// replaces the SDK needs. the modules that are already compatible keep their versions.
+func PlanUpgrade(f *modfile.File, target string, options ...UpgradeOption) (Upgrade, error) {
+	var o upgradeOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	c, version, err := ParseTarget(target)
+	if err != nil {
+		return Upgrade{}, err
+	}
+	upgrade := Upgrade{Compatibility: c}
+
+	var current string
+	for _, r := range f.Require {
+		if r.Mod.Path == PathSDK {
+			current = r.Mod.Version
+		}
+	}
+	if current == "" {
+		return Upgrade{}, ErrNoSDK
+	}
+	for _, r := range f.Replace {
+		if r.Old.Path == PathSDK {
+			return Upgrade{}, fmt.Errorf("the SDK is replaced by %s, update the replace of go.mod to upgrade it", r.New)
+		}
+	}
+
+	line := semver.MajorMinor(current)
+	switch {
+	case semver.Compare(version, current) < 0:
+		return Upgrade{}, fmt.Errorf("downgrading the SDK from %s to %s is not supported", current, version)
+	case line != c.SDK && !contains(c.UpgradesFrom, line) && !o.migrated:
+		return Upgrade{}, fmt.Errorf(
+			"upgrading the SDK from %s to %s needs changes of the app, migrate it with starport chain migrate-sdk",
+			line,
+			c.SDK,
+		)
+	}
+
+	set := func(path, from, to string) error {
+		if from == to {
+			return nil
+		}
+		if err := f.AddRequire(path, to); err != nil {
+			return err
+		}
+		upgrade.Changes = append(upgrade.Changes, Change{Module: path, From: from, To: to})
+		return nil
+	}
+
+	if err := set(PathSDK, current, version); err != nil {
+		return Upgrade{}, err
+	}
+
+	// the requirements are collected first since the requires of f change.
+	required := make(map[string]string)
+	for _, r := range f.Require {
+		required[r.Mod.Path] = r.Mod.Version
+	}
+
+	for path, v := range required {
+		if !isIBC(path) {
+			continue
+		}
+		if len(c.IBC) == 0 {
+			return Upgrade{}, fmt.Errorf("%s is not compatible with the SDK %s, its IBC module is part of the SDK", path, c.SDK)
+		}
+
+		r, ok := ibcRequirement(c, path)
+		if ok {
+			if !r.Compatible(v) {
+				if err := set(path, v, r.Version); err != nil {
+					return Upgrade{}, err
+				}
+			}
+			continue
+		}
+
+		// the major of ibc-go is not compatible, the first compatible one is used instead.
+		r = c.IBC[0]
+		if err := f.DropRequire(path); err != nil {
+			return Upgrade{}, err
+		}
+		if err := f.AddRequire(r.Path, r.Version); err != nil {
+			return Upgrade{}, err
+		}
+		upgrade.Changes = append(upgrade.Changes, Change{Module: path, From: v, To: fmt.Sprintf("%s %s", r.Path, r.Version)})
+		if upgrade.Imports == nil {
+			upgrade.Imports = make(map[string]string)
+		}
+		upgrade.Imports[path] = r.Path
+	}
+
+	for _, r := range []Requirement{c.Tendermint, c.Starport} {
+		if v, ok := required[r.Path]; ok && !r.Compatible(v) {
+			if err := set(r.Path, v, r.Version); err != nil {
+				return Upgrade{}, err
+			}
+		}
+	}
+
+	for _, r := range c.Replaces {
+		if _, ok := required[r.Old]; !ok || hasReplace(f, r) {
+			continue
+		}
+
+		var from string
+		for _, replace := range f.Replace {
+			if replace.Old.Path == r.Old {
+				from = fmt.Sprintf("%s %s", replace.New.Path, replace.New.Version)
+				if err := f.DropReplace(replace.Old.Path, replace.Old.Version); err != nil {
+					return Upgrade{}, err
+				}
+			}
+		}
+		if err := f.AddReplace(r.Old, "", r.New, r.NewVersion); err != nil {
+			return Upgrade{}, err
+		}
+		upgrade.Changes = append(upgrade.Changes, Change{
+			Module: "replace " + r.Old,
+			From:   from,
+			To:     fmt.Sprintf("%s %s", r.New, r.NewVersion),
+		})
+	}
+
+	f.Cleanup()
+
+	return upgrade, nil
+}
+
+// UpgradeAt upgrades the dependencies of the go.mod of the app at path to the target version of
+// the SDK and rewrites the imports of the modules whose major changes in its Go files. go.sum is
+// not updated, run go mod tidy after the upgrade.
+func UpgradeAt(path, target string, options ...UpgradeOption) (Upgrade, error) {
+	f, err := gomodule.ParseAt(path)
+	if err != nil {
+		return Upgrade{}, err
+	}
+
+	upgrade, err := PlanUpgrade(f, target, options...)
+	if err != nil {
+		return Upgrade{}, err
+	}
+	if len(upgrade.Changes) == 0 {
+		return upgrade, nil
+	}
+
+	data, err := f.Format()
+	if err != nil {
+		return Upgrade{}, err
+	}
+
+	goModPath := filepath.Join(path, "go.mod")
+	info, err := os.Stat(goModPath)
+	if err != nil {
+		return Upgrade{}, err
+	}
+	if err := os.WriteFile(goModPath, data, info.Mode()); err != nil {
+		return Upgrade{}, err
+	}
+
+	return upgrade, RewriteImports(path, upgrade.Imports)
+}
+
+// RewriteImports replaces the import paths of the Go files in the directory at root, the imports
+// are the new paths of the modules by their old paths.
+func RewriteImports(root string, imports map[string]string) error {
+	if len(imports) == 0 {
+		return nil
+	}
+
+	rewrites := make(map[*regexp.Regexp]string)
+	for old, new := range imports {
+		rewrites[regexp.MustCompile(`"`+regexp.QuoteMeta(old)+`(["/])`)] = `"` + new + `$1`
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (name == "vendor" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rewritten := data
+		for re, replacement := range rewrites {
+			rewritten = re.ReplaceAll(rewritten, []byte(replacement))
+		}
+		if bytes.Equal(data, rewritten) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, rewritten, info.Mode())
+	})
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/pkg/errors"
+
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+)
+
+const (
+	// DefaultBatchInterval is the default interval to send the queued transfers.
+	DefaultBatchInterval = time.Second * 5
+
+	// maxBatchTransfers is the max number of transfers sent within a single tx.
+	maxBatchTransfers = 100
+)
+
+// ErrFaucetClosed is returned for the queued transfers that aren't sent before the faucet is closed.
+var ErrFaucetClosed = errors.New("faucet is closed")
+
+// BatchTransfers queues the transfers and sends the queued ones every interval within a single multi
+// send tx, so busy faucets don't send a tx per transfer and hit sequence errors. it's only supported
+// by Stargate chains.
+func BatchTransfers(interval time.Duration) Option {
+	return func(f *Faucet) {
+		if interval == 0 {
+			interval = DefaultBatchInterval
+		}
+		f.batchInterval = interval
+	}
+}
+
+// batchTransfer is a transfer queued to be sent within a batch.
+type batchTransfer struct {
+	ip      string
+	address string
+	coins   sdk.Coins
+
+	// time is the time that the claims of the transfer are recorded at.
+	time time.Time
+
+	// result receives the result of the transfer once its batch is sent.
+	result chan error
+}
+
+// batcher queues the transfers and sends them in batches.
+type batcher struct {
+	interval time.Duration
+
+	mu     sync.Mutex
+	queue  []batchTransfer
+	closed bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newBatcher creates a batcher and starts sending the queued transfers with send every interval.
+func newBatcher(interval time.Duration, send func(context.Context, []batchTransfer) error) *batcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &batcher{
+		interval: interval,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go b.run(ctx, send)
+
+	return b
+}
+
+// add queues the transfer, the result of the transfer is received from the returned chan.
+func (b *batcher) add(t batchTransfer) <-chan error {
+	t.result = make(chan error, 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		t.result <- ErrFaucetClosed
+		return t.result
+	}
+
+	b.queue = append(b.queue, t)
+
+	return t.result
+}
+
+// next removes the transfers of the next batch from the queue.
+func (b *batcher) next() []batchTransfer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(b.queue)
+	if n > maxBatchTransfers {
+		n = maxBatchTransfers
+	}
+
+	batch := b.queue[:n:n]
+	b.queue = b.queue[n:]
+
+	return batch
+}
+
+func (b *batcher) run(ctx context.Context, send func(context.Context, []batchTransfer) error) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// batches are sent one by one, so the sequence of the faucet account is increased by
+		// the previous batch before sending the next one.
+		for batch := b.next(); len(batch) > 0; batch = b.next() {
+			err := send(ctx, batch)
+			for _, t := range batch {
+				t.result <- err
+			}
+		}
+	}
+}
+
+// close stops sending the batches, the transfers still in the queue receive ErrFaucetClosed and
+// they're returned.
+func (b *batcher) close() []batchTransfer {
+	b.cancel()
+	<-b.done
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	unsent := b.queue
+	b.queue = nil
+
+	for _, t := range unsent {
+		t.result <- ErrFaucetClosed
+	}
+
+	return unsent
+}
+
+// queueTransfer queues the transfer of coins to toAccountAddress requested from ip and waits until
+// its batch is sent.
+func (f Faucet) queueTransfer(ctx context.Context, ip, toAccountAddress string, coins sdk.Coins) error {
+	now := time.Now()
+
+	// the claims are recorded before the transfer is sent, so the queued transfers count for the limits.
+	transferMutex.Lock()
+	err := f.checkLimits(ctx, ip, toAccountAddress, coins, now)
+	if err == nil {
+		err = f.addClaims(ip, toAccountAddress, coins, now)
+	}
+	transferMutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	result := f.batcher.add(batchTransfer{
+		ip:      ip,
+		address: toAccountAddress,
+		coins:   coins,
+		time:    now,
+	})
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendBatch sends the transfers within a single multi send tx and waits for it to be confirmed.
+func (f Faucet) sendBatch(ctx context.Context, transfers []batchTransfer) error {
+	txHash, err := f.broadcastBatch(ctx, transfers)
+	if err != nil {
+		f.metrics.failures.Add(float64(len(transfers)))
+
+		// the transfers aren't sent, so their claims are removed.
+		for _, t := range transfers {
+			if err := f.removeClaims(t.ip, t.address, t.coins, t.time); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+
+	for _, t := range transfers {
+		f.metrics.observeGrant(t.coins)
+	}
+
+	// the tx is broadcasted, keep the claims even if it's not confirmed in time.
+	if err := f.runner.WaitTx(ctx, txHash, time.Second, 30); err != nil {
+		f.metrics.failures.Add(float64(len(transfers)))
+		return err
+	}
+	return nil
+}
+
+func (f Faucet) broadcastBatch(ctx context.Context, transfers []batchTransfer) (txHash string, err error) {
+	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
+	if err != nil {
+		return "", err
+	}
+
+	outputs := make([]chaincmdrunner.BankOutput, 0, len(transfers))
+	for _, t := range transfers {
+		outputs = append(outputs, chaincmdrunner.BankOutput{
+			Address: t.address,
+			Coins:   t.coins,
+		})
+	}
+
+	return f.runner.BankMultiSend(ctx, fromAccount.Address, outputs)
+}
+
+// normalizeAddress validates the bech32 address of an account of the chain with the prefix and
+// returns its lowercase form, bech32 addresses are also valid in uppercase.
+func normalizeAddress(address, prefix string) (string, error) {
+	bz, err := sdk.GetFromBech32(address, prefix)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid address %s", address)
+	}
+	if err := sdk.VerifyAddressFormat(bz); err != nil {
+		return "", errors.Wrapf(err, "invalid address %s", address)
+	}
+	return bech32.ConvertAndEncode(prefix, bz)
+}
@@ -0,0 +1,132 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatcherNext(t *testing.T) {
+	b := &batcher{}
+	for i := 0; i < maxBatchTransfers+1; i++ {
+		b.add(batchTransfer{address: "cosmos1"})
+	}
+
+	// the queued transfers are sent in batches of max transfers.
+	require.Len(t, b.next(), maxBatchTransfers)
+	require.Len(t, b.next(), 1)
+	require.Empty(t, b.next())
+}
+
+func TestBatcher(t *testing.T) {
+	errSend := errors.New("send failed")
+
+	b := newBatcher(time.Millisecond*10, func(_ context.Context, transfers []batchTransfer) error {
+		if transfers[0].address == "cosmos-fail" {
+			return errSend
+		}
+		return nil
+	})
+
+	// the transfers of a batch receive the result of the batch.
+	require.NoError(t, <-b.add(batchTransfer{address: "cosmos1"}))
+	require.Equal(t, errSend, <-b.add(batchTransfer{address: "cosmos-fail"}))
+
+	b.close()
+
+	// the transfers aren't queued after the batcher is closed.
+	require.Equal(t, ErrFaucetClosed, <-b.add(batchTransfer{address: "cosmos1"}))
+}
+
+func TestBatcherClose(t *testing.T) {
+	b := newBatcher(time.Hour, func(context.Context, []batchTransfer) error {
+		return nil
+	})
+
+	result := b.add(batchTransfer{address: "cosmos1"})
+
+	unsent := b.close()
+	require.Len(t, unsent, 1)
+	require.Equal(t, "cosmos1", unsent[0].address)
+	require.Equal(t, ErrFaucetClosed, <-result)
+}
+
+func TestLimiterRemove(t *testing.T) {
+	var (
+		now    = time.Now()
+		window = time.Hour
+		key    = addressKey("cosmos1")
+		coins  = sdk.NewCoins(sdk.NewInt64Coin("token", 5))
+	)
+
+	l, err := newLimiter(t.TempDir())
+	require.NoError(t, err)
+	defer l.close()
+
+	require.NoError(t, l.add(key, coins, now.Add(-time.Minute), fixedWindow(window)))
+	require.NoError(t, l.add(key, coins, now, fixedWindow(window)))
+
+	// only the claims made at the time are removed.
+	require.NoError(t, l.remove(key, coins, now))
+	claims, err := l.claims(key)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), claims.Total("token", now.Add(-window)))
+
+	require.NoError(t, l.remove(key, coins, now.Add(-time.Minute)))
+	claims, err = l.claims(key)
+	require.NoError(t, err)
+	require.Empty(t, claims)
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	const address = "cosmos1xfxwg5f5e9u2a9cwfjm83euuss5t6q85wxmpa3"
+
+	for _, form := range []string{address, strings.ToUpper(address)} {
+		normalized, err := normalizeAddress(form, "cosmos")
+		require.NoError(t, err)
+		require.Equal(t, address, normalized)
+	}
+
+	for _, address := range []string{
+		"venus1xfxwg5f5e9u2a9cwfjm83euuss5t6q85wxmpa3",
+		"cosmos1xfxwg5f5e9u2a9cwfjm83euuss5t6q85wxmpa4",
+		"Cosmos1xfxwg5f5e9u2a9cwfjm83euuss5t6q85wxmpa3",
+		"",
+	} {
+		_, err := normalizeAddress(address, "cosmos")
+		require.Error(t, err, address)
+	}
+}
+
+func TestTransferLimitsUppercaseAddress(t *testing.T) {
+	const address = "cosmos1xfxwg5f5e9u2a9cwfjm83euuss5t6q85wxmpa3"
+
+	dir := t.TempDir()
+	l, err := newLimiter(dir)
+	require.NoError(t, err)
+	defer l.close()
+
+	b := newBatcher(time.Millisecond, func(context.Context, []batchTransfer) error { return nil })
+	defer b.close()
+
+	f := Faucet{
+		limiter:            l,
+		limiterDir:         dir,
+		coinsMax:           map[string]uint64{"token": 10},
+		limitRefreshWindow: time.Hour,
+		addressPrefix:      "cosmos",
+		batcher:            b,
+	}
+
+	require.NoError(t, f.transfer(context.Background(), "", address, sdk.NewCoins(sdk.NewInt64Coin("token", 10))))
+
+	// the uppercase form of the address is the same account, it has reached the limit.
+	err = f.transfer(context.Background(), "", strings.ToUpper(address), sdk.NewCoins(sdk.NewInt64Coin("token", 1)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "max. allowed amount")
+}
@@ -0,0 +1,255 @@
+package cosmosfaucet
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
+)
+
+// CaptchaProvider is a service that verifies the captchas solved by users.
+type CaptchaProvider string
+
+const (
+	// CaptchaHCaptcha verifies captchas with hCaptcha.
+	CaptchaHCaptcha CaptchaProvider = "hcaptcha"
+
+	// CaptchaTurnstile verifies captchas with Cloudflare Turnstile.
+	CaptchaTurnstile CaptchaProvider = "turnstile"
+)
+
+// captchaVerifyURLs are the endpoints of the providers that verify the captcha tokens.
+var captchaVerifyURLs = map[CaptchaProvider]string{
+	CaptchaHCaptcha:  "https://hcaptcha.com/siteverify",
+	CaptchaTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+const (
+	// DefaultChallengeTTL is the default duration that a challenge can be signed within.
+	DefaultChallengeTTL = time.Minute * 5
+
+	// maxPendingChallenges is the max number of challenges that are waiting to be signed.
+	maxPendingChallenges = 10000
+
+	// maxClientPendingChallenges is the max number of challenges that are waiting to be signed for
+	// an account or requested from an IP, so a single client cannot fill the pending challenges.
+	maxClientPendingChallenges = 5
+)
+
+var (
+	// ErrCaptchaVerification is returned when the captcha of a transfer request cannot be verified.
+	ErrCaptchaVerification = errors.New("captcha verification failed")
+
+	// ErrChallengeVerification is returned when the signed challenge of a transfer request cannot be verified.
+	ErrChallengeVerification = errors.New("challenge verification failed")
+
+	// ErrTooManyChallenges is returned when too many challenges are waiting to be signed.
+	ErrTooManyChallenges = errors.New("too many pending challenges, try again later")
+)
+
+// Captcha requires users to solve a captcha of the provider before requesting tokens. secret is the
+// secret key of the faucet's site registered to the provider.
+func Captcha(provider CaptchaProvider, secret string) Option {
+	return func(f *Faucet) {
+		f.captchaProvider = provider
+		f.captchaSecret = secret
+	}
+}
+
+// SignedChallenge requires users to sign a challenge received from the faucet with the keys of their
+// accounts before requesting tokens, so they prove that they own the accounts.
+func SignedChallenge(ttl time.Duration) Option {
+	return func(f *Faucet) {
+		if ttl == 0 {
+			ttl = DefaultChallengeTTL
+		}
+		f.challenges = newChallengeStore(ttl)
+	}
+}
+
+// verifyCaptcha verifies the captcha token solved by the user requesting from ip.
+func (f Faucet) verifyCaptcha(ctx context.Context, token, ip string) error {
+	if token == "" {
+		return errors.Wrap(ErrCaptchaVerification, "captcha token is required")
+	}
+
+	verifyURL := f.captchaVerifyURL
+	if verifyURL == "" {
+		var ok bool
+		if verifyURL, ok = captchaVerifyURLs[f.captchaProvider]; !ok {
+			return fmt.Errorf("unknown captcha provider %q", f.captchaProvider)
+		}
+	}
+
+	form := url.Values{
+		"secret":   {f.captchaSecret},
+		"response": {token},
+	}
+	if ip != "" {
+		form.Set("remoteip", ip)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var verification struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&verification); err != nil {
+		return err
+	}
+	if !verification.Success {
+		return errors.Wrap(ErrCaptchaVerification, strings.Join(verification.ErrorCodes, ", "))
+	}
+
+	return nil
+}
+
+// verifyChallenge verifies that the challenge of req is signed with the key of the requested account.
+func (f Faucet) verifyChallenge(req TransferRequest) error {
+	if req.Challenge == "" || req.PubKey == "" || req.Signature == "" {
+		return errors.Wrap(ErrChallengeVerification, "challenge, pub key and signature are required")
+	}
+
+	pubKeyBytes, err := base64.StdEncoding.DecodeString(req.PubKey)
+	if err != nil {
+		return errors.Wrap(ErrChallengeVerification, "invalid pub key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(req.Signature)
+	if err != nil {
+		return errors.Wrap(ErrChallengeVerification, "invalid signature")
+	}
+
+	var pubKey cryptotypes.PubKey
+	switch req.KeyType {
+	case "", string(hd.Secp256k1Type):
+		pubKey = &secp256k1.PubKey{Key: pubKeyBytes}
+	case ethsecp256k1.KeyType:
+		pubKey = &ethsecp256k1.PubKey{Key: pubKeyBytes}
+	default:
+		return errors.Wrapf(ErrChallengeVerification, "unsupported key type %q", req.KeyType)
+	}
+
+	// keys are verified to be compressed secp256k1 keys before deriving their addresses.
+	if len(pubKeyBytes) != secp256k1.PubKeySize {
+		return errors.Wrap(ErrChallengeVerification, "invalid pub key")
+	}
+
+	_, address, err := bech32.DecodeAndConvert(req.AccountAddress)
+	if err != nil {
+		return errors.Wrap(ErrChallengeVerification, "invalid address")
+	}
+	if !bytes.Equal(pubKey.Address(), address) {
+		return errors.Wrap(ErrChallengeVerification, "pub key doesn't belong to the account")
+	}
+
+	// the challenge is used once even if the signature is invalid.
+	if !f.challenges.use(req.Challenge, req.AccountAddress, time.Now()) {
+		return errors.Wrap(ErrChallengeVerification, "challenge is unknown or expired")
+	}
+	if !pubKey.VerifySignature([]byte(req.Challenge), signature) {
+		return errors.Wrap(ErrChallengeVerification, "invalid signature")
+	}
+
+	return nil
+}
+
+// challengeStore keeps the challenges given to accounts until they're used or expired.
+type challengeStore struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	challenges map[string]challenge
+}
+
+type challenge struct {
+	address   string
+	ip        string
+	expiresAt time.Time
+}
+
+func newChallengeStore(ttl time.Duration) *challengeStore {
+	return &challengeStore{
+		ttl:        ttl,
+		challenges: make(map[string]challenge),
+	}
+}
+
+// new creates a new challenge for the account address requested from ip.
+func (s *challengeStore) new(address, ip string, now time.Time) (string, time.Time, error) {
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", time.Time{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var addressPending, ipPending int
+	for c, ch := range s.challenges {
+		if now.After(ch.expiresAt) {
+			delete(s.challenges, c)
+			continue
+		}
+		if ch.address == address {
+			addressPending++
+		}
+		if ip != "" && ch.ip == ip {
+			ipPending++
+		}
+	}
+
+	if len(s.challenges) >= maxPendingChallenges ||
+		addressPending >= maxClientPendingChallenges ||
+		ipPending >= maxClientPendingChallenges {
+		return "", time.Time{}, ErrTooManyChallenges
+	}
+
+	c := hex.EncodeToString(nonce)
+	s.challenges[c] = challenge{
+		address:   address,
+		ip:        ip,
+		expiresAt: now.Add(s.ttl),
+	}
+
+	return c, s.challenges[c].expiresAt, nil
+}
+
+// use removes the challenge and returns true if it's given to the account address and not expired.
+func (s *challengeStore) use(c, address string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch, ok := s.challenges[c]
+	if !ok {
+		return false
+	}
+	delete(s.challenges, c)
+
+	return ch.address == address && !now.After(ch.expiresAt)
+}
@@ -0,0 +1,132 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
+)
+
+func TestVerifyCaptcha(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.Form.Get("secret"))
+		require.Equal(t, "10.0.0.1", r.Form.Get("remoteip"))
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     r.Form.Get("response") == "solved",
+			"error-codes": []string{"invalid-input-response"},
+		})
+	}))
+	defer server.Close()
+
+	f := Faucet{
+		captchaProvider:  CaptchaHCaptcha,
+		captchaSecret:    "secret",
+		captchaVerifyURL: server.URL,
+	}
+
+	require.NoError(t, f.verifyCaptcha(context.Background(), "solved", "10.0.0.1"))
+
+	err := f.verifyCaptcha(context.Background(), "unsolved", "10.0.0.1")
+	require.True(t, errors.Is(err, ErrCaptchaVerification))
+	require.Contains(t, err.Error(), "invalid-input-response")
+
+	err = f.verifyCaptcha(context.Background(), "", "10.0.0.1")
+	require.True(t, errors.Is(err, ErrCaptchaVerification))
+}
+
+func TestVerifyChallenge(t *testing.T) {
+	ethKey, err := ethsecp256k1.GenPrivKey()
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		keyType string
+		key     cryptotypes.PrivKey
+	}{
+		{"", secp256k1.GenPrivKey()},
+		{ethsecp256k1.KeyType, ethKey},
+	} {
+		t.Run(tt.key.Type(), func(t *testing.T) {
+			f := Faucet{challenges: newChallengeStore(time.Minute)}
+
+			address, err := bech32.ConvertAndEncode("cosmos", tt.key.PubKey().Address())
+			require.NoError(t, err)
+
+			signedRequest := func() TransferRequest {
+				challenge, _, err := f.challenges.new(address, "", time.Now())
+				require.NoError(t, err)
+
+				signature, err := tt.key.Sign([]byte(challenge))
+				require.NoError(t, err)
+
+				return TransferRequest{
+					AccountAddress: address,
+					Challenge:      challenge,
+					PubKey:         base64.StdEncoding.EncodeToString(tt.key.PubKey().Bytes()),
+					KeyType:        tt.keyType,
+					Signature:      base64.StdEncoding.EncodeToString(signature),
+				}
+			}
+
+			req := signedRequest()
+			require.NoError(t, f.verifyChallenge(req))
+
+			// challenges can be used once.
+			require.True(t, errors.Is(f.verifyChallenge(req), ErrChallengeVerification))
+
+			// challenges need to be signed by the key of the account.
+			req = signedRequest()
+			otherKey := secp256k1.GenPrivKey()
+			req.PubKey = base64.StdEncoding.EncodeToString(otherKey.PubKey().Bytes())
+			require.True(t, errors.Is(f.verifyChallenge(req), ErrChallengeVerification))
+
+			req = signedRequest()
+			signature, err := otherKey.Sign([]byte(req.Challenge))
+			require.NoError(t, err)
+			req.Signature = base64.StdEncoding.EncodeToString(signature)
+			require.True(t, errors.Is(f.verifyChallenge(req), ErrChallengeVerification))
+
+			// challenges expire.
+			req = signedRequest()
+			f.challenges.challenges[req.Challenge] = challenge{address: address, expiresAt: time.Now().Add(-time.Second)}
+			require.True(t, errors.Is(f.verifyChallenge(req), ErrChallengeVerification))
+		})
+	}
+}
+
+func TestChallengeStoreClientLimits(t *testing.T) {
+	var (
+		s   = newChallengeStore(time.Minute)
+		now = time.Now()
+	)
+
+	for i := 0; i < maxClientPendingChallenges; i++ {
+		_, _, err := s.new("cosmos1", "10.0.0.1", now)
+		require.NoError(t, err)
+	}
+
+	// the pending challenges are limited for an account and for an IP.
+	_, _, err := s.new("cosmos1", "10.0.0.2", now)
+	require.Equal(t, ErrTooManyChallenges, err)
+	_, _, err = s.new("cosmos2", "10.0.0.1", now)
+	require.Equal(t, ErrTooManyChallenges, err)
+
+	_, _, err = s.new("cosmos2", "10.0.0.2", now)
+	require.NoError(t, err)
+
+	// the expired challenges don't count.
+	_, _, err = s.new("cosmos1", "10.0.0.1", now.Add(time.Minute*2))
+	require.NoError(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 )
 
 // ErrTransferRequest is a error that occurs when a transfer request fails
@@ -55,6 +56,29 @@ func (c HTTPClient) Transfer(ctx context.Context, req TransferRequest) (Transfer
 	return res, err
 }
 
+// Challenge requests a challenge for the account address to be signed by the key of the account
+// when the faucet requires signed challenges.
+func (c HTTPClient) Challenge(ctx context.Context, accountAddress string) (ChallengeResponse, error) {
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr+"/challenge?address="+url.QueryEscape(accountAddress), nil)
+	if err != nil {
+		return ChallengeResponse{}, err
+	}
+
+	hres, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return ChallengeResponse{}, err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		return ChallengeResponse{}, errors.New(http.StatusText(hres.StatusCode))
+	}
+
+	var res ChallengeResponse
+	err = json.NewDecoder(hres.Body).Decode(&res)
+	return res, err
+}
+
 // FaucetInfo fetch the faucet info for clients to determine if this is a real faucet and
 // what is the chain id of the chain that faucet is operating for.
 func (c HTTPClient) FaucetInfo(ctx context.Context) (FaucetInfoResponse, error) {
@@ -6,8 +6,10 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/pkg/events"
 )
 
 const (
@@ -50,12 +52,79 @@ type Faucet struct {
 	// coins keeps a list of coins that can be distributed by the faucet.
 	coins sdk.Coins
 
+	// feeCoin is sent on top of the requested coins so the accounts can pay the fees of their txs.
+	feeCoin sdk.Coin
+
 	// coinsMax is a denom-max pair.
 	// it holds the maximum amounts of coins that can be sent to a single account.
 	coinsMax map[string]uint64
 
 	limitRefreshWindow time.Duration
 
+	// coinsWindow is a denom-window pair.
+	// it overrides the refresh window of the limits of accounts for the denoms.
+	coinsWindow map[string]time.Duration
+
+	// ipCoinsMax is a denom-max pair.
+	// it holds the maximum amounts of coins that can be sent to the accounts requested from a single IP.
+	ipCoinsMax map[string]uint64
+
+	ipLimitRefreshWindow time.Duration
+
+	// ipCoinsWindow is a denom-window pair.
+	// it overrides the refresh window of the limits of IPs for the denoms.
+	ipCoinsWindow map[string]time.Duration
+
+	// limiter keeps the claim history of accounts and IPs.
+	limiter *limiter
+
+	// limiterDir is the dir to persist the claim history, it's kept in memory when empty.
+	limiterDir string
+
+	// trustForwardedFor uses the X-Forwarded-For header to determine the IPs of requests.
+	trustForwardedFor bool
+
+	// captchaProvider verifies the captchas solved by users, captchas aren't required when it's empty.
+	captchaProvider CaptchaProvider
+
+	// captchaSecret is the secret key of the faucet registered to the captcha provider.
+	captchaSecret string
+
+	// captchaSiteKey is the site key of the faucet registered to the captcha provider, used by the web UI.
+	captchaSiteKey string
+
+	// captchaVerifyURL overwrites the verification endpoint of the captcha provider.
+	captchaVerifyURL string
+
+	// challenges keeps the challenges to be signed by users, signed challenges aren't required when it's nil.
+	challenges *challengeStore
+
+	// batchInterval is the interval to send the queued transfers, transfers aren't batched when it's zero.
+	batchInterval time.Duration
+
+	// batcher queues the transfers to send them in batches.
+	batcher *batcher
+
+	// addressPrefix is the prefix of the addresses of the chain, the addresses of the transfers are
+	// validated and normalized with it, so an invalid address cannot fail a batch and an account
+	// cannot get new limits with another form of its address.
+	addressPrefix string
+
+	// ev collects the events of the faucet.
+	ev events.Bus
+
+	// lowBalanceRequests is the number of requests that the balance needs to cover for each denom.
+	lowBalanceRequests uint64
+
+	// metrics are the Prometheus metrics of the faucet.
+	metrics *metrics
+
+	// adminToken authorizes requests to the admin endpoints, they're disabled when it's empty.
+	adminToken string
+
+	// branding customizes the web UI.
+	branding Branding
+
 	// openAPIData holds template data customizations for serving OpenAPI page & spec.
 	openAPIData openAPIData
 }
@@ -86,6 +155,14 @@ func Coin(amount, maxAmount uint64, denom string) Option {
 	}
 }
 
+// FeeCoin sends the amount of the fee denom of the chain on top of the requested coins when
+// they don't include the fee denom, so newly funded accounts can pay the fees of their txs.
+func FeeCoin(amount uint64, denom string) Option {
+	return func(f *Faucet) {
+		f.feeCoin = sdk.NewCoin(denom, sdk.NewIntFromUint64(amount))
+	}
+}
+
 // RefreshWindow adds the duration to refresh the transfer limit to the faucet
 func RefreshWindow(refreshWindow time.Duration) Option {
 	return func(f *Faucet) {
@@ -93,6 +170,62 @@ func RefreshWindow(refreshWindow time.Duration) Option {
 	}
 }
 
+// CoinRefreshWindow sets the duration to refresh the transfer limit of accounts for the denom, instead
+// of the refresh window of all the denoms.
+func CoinRefreshWindow(refreshWindow time.Duration, denom string) Option {
+	return func(f *Faucet) {
+		f.coinsWindow[denom] = refreshWindow
+	}
+}
+
+// IPCoinMax sets the maximum amount of the coin that can be sent to the accounts requested from
+// a single IP within the IP refresh window.
+func IPCoinMax(maxAmount uint64, denom string) Option {
+	return func(f *Faucet) {
+		f.ipCoinsMax[denom] = maxAmount
+	}
+}
+
+// IPRefreshWindow sets the duration to refresh the transfer limit of IPs. by default, it's the
+// same with the refresh window of accounts.
+func IPRefreshWindow(refreshWindow time.Duration) Option {
+	return func(f *Faucet) {
+		f.ipLimitRefreshWindow = refreshWindow
+	}
+}
+
+// IPCoinRefreshWindow sets the duration to refresh the transfer limit of IPs for the denom, instead of
+// the IP refresh window of all the denoms.
+func IPCoinRefreshWindow(refreshWindow time.Duration, denom string) Option {
+	return func(f *Faucet) {
+		f.ipCoinsWindow[denom] = refreshWindow
+	}
+}
+
+// ClaimsDir persists the claim history used to limit the transfers in dir, so the limits are kept
+// across restarts. the claim history is kept in memory when it isn't provided.
+func ClaimsDir(dir string) Option {
+	return func(f *Faucet) {
+		f.limiterDir = dir
+	}
+}
+
+// TrustForwardedFor determines the IPs of requests from the X-Forwarded-For header, it should only
+// be used when the faucet is served behind a trusted proxy.
+func TrustForwardedFor() Option {
+	return func(f *Faucet) {
+		f.trustForwardedFor = true
+	}
+}
+
+// AdminToken enables the admin endpoints to inspect and reset the limits, requests to them need to be
+// authorized with the token as bearer token.
+func AdminToken(token string) Option {
+	return func(f *Faucet) {
+		f.adminToken = token
+	}
+}
+
 // ChainID adds chain id to faucet. faucet will automatically fetch when it isn't provided.
 func ChainID(id string) Option {
 	return func(f *Faucet) {
@@ -113,7 +246,14 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		runner:      ccr,
 		accountName: DefaultAccountName,
 		coinsMax:    make(map[string]uint64),
+		coinsWindow: make(map[string]time.Duration),
+		ipCoinsMax:  make(map[string]uint64),
+		metrics:     newMetrics(),
+		branding:    Branding{Title: DefaultUITitle, Color: DefaultUIColor},
 		openAPIData: openAPIData{"Blockchain", "http://localhost:1317"},
+
+		ipCoinsWindow:      make(map[string]time.Duration),
+		lowBalanceRequests: DefaultLowBalanceRequests,
 	}
 
 	for _, apply := range options {
@@ -128,6 +268,10 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		RefreshWindow(DefaultRefreshWindow)(&f)
 	}
 
+	if f.ipLimitRefreshWindow == 0 {
+		IPRefreshWindow(f.limitRefreshWindow)(&f)
+	}
+
 	// import the account if mnemonic is provided.
 	if f.accountMnemonic != "" {
 		_, err := f.runner.AddAccount(ctx, f.accountName, f.accountMnemonic, f.coinType)
@@ -146,5 +290,34 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		f.openAPIData.ChainID = status.ChainID
 	}
 
+	var err error
+	if f.limiter, err = newLimiter(f.limiterDir); err != nil {
+		return Faucet{}, err
+	}
+
+	account, err := f.runner.ShowAccount(ctx, f.accountName)
+	if err != nil {
+		return Faucet{}, err
+	}
+	if f.addressPrefix, _, err = bech32.DecodeAndConvert(account.Address); err != nil {
+		return Faucet{}, err
+	}
+
+	if f.batchInterval != 0 {
+		f.batcher = newBatcher(f.batchInterval, f.sendBatch)
+	}
+
 	return f, nil
 }
+
+// Close stops sending the queued transfers and closes the claim history of the faucet.
+func (f Faucet) Close() error {
+	if f.batcher != nil {
+		for _, t := range f.batcher.close() {
+			if err := f.removeClaims(t.ip, t.address, t.coins, t.time); err != nil {
+				return err
+			}
+		}
+	}
+	return f.limiter.close()
+}
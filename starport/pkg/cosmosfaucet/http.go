@@ -20,7 +20,26 @@ func (f Faucet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	router.Handle("/info", cors.Default().Handler(http.HandlerFunc(f.faucetInfoHandler))).
 		Methods(http.MethodGet)
 
-	router.HandleFunc("/", openapiconsole.Handler("Faucet", "openapi.yml")).
+	router.HandleFunc("/healthz", f.healthHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/metrics", f.metricsHandler).
+		Methods(http.MethodGet)
+
+	if f.challenges != nil {
+		router.Handle("/challenge", cors.Default().Handler(http.HandlerFunc(f.challengeHandler))).
+			Methods(http.MethodGet)
+	}
+
+	if f.adminToken != "" {
+		router.HandleFunc("/admin/limits", f.adminLimitsHandler).
+			Methods(http.MethodGet, http.MethodDelete)
+	}
+
+	router.HandleFunc("/", f.uiHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/openapi", openapiconsole.Handler("Faucet", "openapi.yml")).
 		Methods(http.MethodGet)
 
 	router.HandleFunc("/openapi.yml", f.openAPISpecHandler).
@@ -0,0 +1,62 @@
+package cosmosfaucet
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// LimitsResponse is the payload of the admin limits endpoint.
+type LimitsResponse struct {
+	// Claims are the claims kept for the account or the IP.
+	Claims Claims `json:"claims"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// adminLimitsHandler returns the claims of an account or an IP on GET and resets them on DELETE.
+// the account or the IP is given with the address or ip query parameter.
+func (f Faucet) adminLimitsHandler(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(f.adminToken)) != 1 {
+		xhttp.ResponseJSON(w, http.StatusUnauthorized, LimitsResponse{Error: "unauthorized"})
+		return
+	}
+
+	var (
+		query = r.URL.Query()
+		key   string
+	)
+	switch {
+	case query.Get("address") != "":
+		address, err := normalizeAddress(query.Get("address"), f.addressPrefix)
+		if err != nil {
+			xhttp.ResponseJSON(w, http.StatusBadRequest, LimitsResponse{Error: err.Error()})
+			return
+		}
+		key = addressKey(address)
+	case query.Get("ip") != "":
+		key = ipKey(query.Get("ip"))
+	default:
+		xhttp.ResponseJSON(w, http.StatusBadRequest, LimitsResponse{Error: "address or ip is required"})
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := f.limiter.reset(key); err != nil {
+			xhttp.ResponseJSON(w, http.StatusInternalServerError, LimitsResponse{Error: err.Error()})
+			return
+		}
+		xhttp.ResponseJSON(w, http.StatusOK, LimitsResponse{Claims: Claims{}})
+		return
+	}
+
+	claims, err := f.limiter.claims(key)
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, LimitsResponse{Error: err.Error()})
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, LimitsResponse{Claims: claims})
+}
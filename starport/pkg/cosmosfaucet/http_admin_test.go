@@ -0,0 +1,71 @@
+package cosmosfaucet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminLimitsHandler(t *testing.T) {
+	l, err := newLimiter("")
+	require.NoError(t, err)
+
+	const address = "cosmos1xfxwg5f5e9u2a9cwfjm83euuss5t6q85wxmpa3"
+
+	f := Faucet{limiter: l, adminToken: "secret", addressPrefix: "cosmos"}
+	require.NoError(t, l.add(ipKey("10.0.0.1"), sdk.NewCoins(sdk.NewInt64Coin("token", 10)), time.Now(), fixedWindow(time.Hour)))
+	require.NoError(t, l.add(addressKey(address), sdk.NewCoins(sdk.NewInt64Coin("token", 5)), time.Now(), fixedWindow(time.Hour)))
+
+	do := func(method, query, token string) (int, LimitsResponse) {
+		req := httptest.NewRequest(method, "/admin/limits?"+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, req)
+
+		var resp LimitsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return w.Code, resp
+	}
+
+	code, _ := do(http.MethodGet, "ip=10.0.0.1", "wrong")
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = do(http.MethodGet, "", "secret")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, resp := do(http.MethodGet, "ip=10.0.0.1", "secret")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(10), resp.Claims.Total("token", time.Time{}))
+
+	code, _ = do(http.MethodDelete, "ip=10.0.0.1", "secret")
+	require.Equal(t, http.StatusOK, code)
+
+	_, resp = do(http.MethodGet, "ip=10.0.0.1", "secret")
+	require.Empty(t, resp.Claims)
+
+	// the address is normalized.
+	code, resp = do(http.MethodGet, "address="+strings.ToUpper(address), "secret")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(5), resp.Claims.Total("token", time.Time{}))
+
+	code, _ = do(http.MethodGet, "address=cosmos1", "secret")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestRequestIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "10.0.0.2, 10.0.0.3")
+
+	require.Equal(t, "10.0.0.1", Faucet{}.requestIP(req))
+	// the last hop is the one added by the trusted proxy.
+	require.Equal(t, "10.0.0.3", Faucet{trustForwardedFor: true}.requestIP(req))
+}
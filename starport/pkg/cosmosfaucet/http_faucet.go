@@ -3,9 +3,14 @@ package cosmosfaucet
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
 
 	"github.com/tendermint/starport/starport/pkg/xhttp"
 )
@@ -17,6 +22,23 @@ type TransferRequest struct {
 	// Coins that are requested.
 	// default ones used when this one isn't provided.
 	Coins []string `json:"coins"`
+
+	// CaptchaToken is the token of the captcha solved by the user.
+	// it's required when the faucet requires captchas.
+	CaptchaToken string `json:"captcha_token,omitempty"`
+
+	// Challenge is the challenge received from the faucet to be signed.
+	// it's required with PubKey and Signature when the faucet requires signed challenges.
+	Challenge string `json:"challenge,omitempty"`
+
+	// PubKey is the base64 encoded public key of the account.
+	PubKey string `json:"pub_key,omitempty"`
+
+	// KeyType is the type of PubKey, secp256k1 is assumed when it isn't provided.
+	KeyType string `json:"key_type,omitempty"`
+
+	// Signature is the base64 encoded signature of the challenge signed by the key of the account.
+	Signature string `json:"signature,omitempty"`
 }
 
 func NewTransferRequest(accountAddress string, coins []string) TransferRequest {
@@ -31,30 +53,62 @@ type TransferResponse struct {
 }
 
 func (f Faucet) faucetHandler(w http.ResponseWriter, r *http.Request) {
+	code, err := f.serveTransfer(r)
+	if err == context.Canceled {
+		return
+	}
+	f.metrics.observeRequest(code)
+
+	if err != nil {
+		responseError(w, code, err)
+		return
+	}
+	responseSuccess(w)
+
+	// check the balances after flushing the response, so it's not delayed.
+	if f.ev != nil {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		f.checkBalances(r.Context())
+	}
+}
+
+// serveTransfer performs the transfer request and returns the HTTP status code of the response.
+func (f Faucet) serveTransfer(r *http.Request) (code int, err error) {
 	var req TransferRequest
 
 	// decode request into req.
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		responseError(w, http.StatusBadRequest, err)
-		return
+		return http.StatusBadRequest, err
+	}
+
+	ip := f.requestIP(r)
+
+	// verify that the request is made by a human owning the account when it's required.
+	if f.captchaProvider != "" {
+		if err := f.verifyCaptcha(r.Context(), req.CaptchaToken, ip); err != nil {
+			return http.StatusForbidden, err
+		}
+	}
+	if f.challenges != nil {
+		if err := f.verifyChallenge(req); err != nil {
+			return http.StatusForbidden, err
+		}
 	}
 
 	// determine coins to transfer.
 	coins, err := f.coinsFromRequest(req)
 	if err != nil {
-		responseError(w, http.StatusBadRequest, err)
-		return
+		return http.StatusBadRequest, err
 	}
 
 	// try performing the transfer
-	if err := f.Transfer(r.Context(), req.AccountAddress, coins); err != nil {
-		if err == context.Canceled {
-			return
-		}
-		responseError(w, http.StatusInternalServerError, err)
-	} else {
-		responseSuccess(w)
+	if err := f.transfer(r.Context(), ip, req.AccountAddress, coins); err != nil {
+		return http.StatusInternalServerError, err
 	}
+
+	return http.StatusOK, nil
 }
 
 // FaucetInfoResponse is the faucet info payload.
@@ -74,24 +128,103 @@ func (f Faucet) faucetInfoHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ChallengeResponse is the challenge payload.
+type ChallengeResponse struct {
+	// Challenge needs to be signed by the key of the account to request tokens.
+	Challenge string `json:"challenge,omitempty"`
+
+	// ExpiresAt is the time after which the challenge cannot be used.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+func (f Faucet) challengeHandler(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("address")
+	if address == "" {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, ChallengeResponse{Error: "address is required"})
+		return
+	}
+
+	challenge, expiresAt, err := f.challenges.new(address, f.requestIP(r), time.Now())
+	if errors.Is(err, ErrTooManyChallenges) {
+		xhttp.ResponseJSON(w, http.StatusTooManyRequests, ChallengeResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, ChallengeResponse{Error: err.Error()})
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, ChallengeResponse{
+		Challenge: challenge,
+		ExpiresAt: expiresAt,
+	})
+}
+
 // coinsFromRequest determines tokens to transfer from transfer request.
+// the requested coins need to be distributed by the faucet and cannot exceed the amounts per request.
 func (f Faucet) coinsFromRequest(req TransferRequest) (sdk.Coins, error) {
 	if len(req.Coins) == 0 {
 		return f.coins, nil
 	}
 
-	var coins []sdk.Coin
+	var coins sdk.Coins
 	for _, c := range req.Coins {
 		coin, err := sdk.ParseCoinNormalized(c)
 		if err != nil {
 			return nil, err
 		}
-		coins = append(coins, coin)
+
+		if !coin.IsPositive() {
+			return nil, fmt.Errorf("%q denom is requested with a non-positive amount", coin.Denom)
+		}
+		if !coins.AmountOf(coin.Denom).IsZero() {
+			return nil, fmt.Errorf("%q denom is requested more than once", coin.Denom)
+		}
+
+		maxAmount, ok := f.coinAmount(coin.Denom)
+		if !ok {
+			return nil, fmt.Errorf("%q denom is not distributed by the faucet", coin.Denom)
+		}
+		if coin.Amount.GT(maxAmount) {
+			return nil, fmt.Errorf("%q denom can be requested up to %s per request", coin.Denom, maxAmount)
+		}
+
+		coins = coins.Add(coin)
 	}
 
 	return coins, nil
 }
 
+// requestIP returns the IP of the client that sent r.
+func (f Faucet) requestIP(r *http.Request) string {
+	if f.trustForwardedFor {
+		// the last IP is added by the trusted proxy, the ones before it are sent by the client and
+		// can be forged to get around the limits of IPs.
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			return strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// coinAmount returns the amount of the denom that is distributed per request.
+func (f Faucet) coinAmount(denom string) (sdk.Int, bool) {
+	for _, c := range f.coins {
+		if c.Denom == denom {
+			return c.Amount, true
+		}
+	}
+	return sdk.Int{}, false
+}
+
 func responseSuccess(w http.ResponseWriter) {
 	xhttp.ResponseJSON(w, http.StatusOK, TransferResponse{})
 }
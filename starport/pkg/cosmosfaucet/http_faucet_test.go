@@ -0,0 +1,44 @@
+package cosmosfaucet
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinsFromRequest(t *testing.T) {
+	f := Faucet{coins: sdk.Coins{sdk.NewInt64Coin("token", 100), sdk.NewInt64Coin("stake", 10)}}
+
+	coins, err := f.coinsFromRequest(TransferRequest{})
+	require.NoError(t, err)
+	require.Equal(t, f.coins, coins)
+
+	coins, err = f.coinsFromRequest(TransferRequest{Coins: []string{"50token", "10stake"}})
+	require.NoError(t, err)
+	require.Equal(t, "10stake,50token", coins.String())
+
+	for _, requested := range [][]string{
+		{"101token"},
+		{"1foo"},
+		{"1token", "2token"},
+		{"0token"},
+	} {
+		_, err := f.coinsFromRequest(TransferRequest{Coins: requested})
+		require.Error(t, err, requested)
+	}
+}
+
+func TestWithFeeCoin(t *testing.T) {
+	f := Faucet{feeCoin: sdk.NewInt64Coin("stake", 5)}
+
+	coins := f.withFeeCoin(sdk.Coins{sdk.NewInt64Coin("token", 100)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("token", 100), sdk.NewInt64Coin("stake", 5)}, coins)
+
+	// the fee coin isn't added when its denom is requested.
+	coins = f.withFeeCoin(sdk.Coins{sdk.NewInt64Coin("stake", 1)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("stake", 1)}, coins)
+
+	coins = Faucet{}.withFeeCoin(sdk.Coins{sdk.NewInt64Coin("token", 100)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("token", 100)}, coins)
+}
@@ -0,0 +1,96 @@
+package cosmosfaucet
+
+import (
+	"bytes"
+	_ "embed" // used for embedding the web UI.
+	"html/template"
+	"net/http"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+const (
+	fileNameUI = "ui/index.html.tmpl"
+
+	// DefaultUITitle is the default title of the web UI.
+	DefaultUITitle = "Faucet"
+
+	// DefaultUIColor is the default primary color of the web UI.
+	DefaultUIColor = "#4251fa"
+)
+
+//go:embed ui/index.html.tmpl
+var bytesUI []byte
+
+var tmplUI = template.Must(template.New(fileNameUI).Parse(string(bytesUI)))
+
+// Branding customizes the web UI of the faucet.
+type Branding struct {
+	// Title of the page.
+	Title string
+
+	// Description shown below the title.
+	Description string
+
+	// LogoURL is the URL of the logo shown above the title.
+	LogoURL string
+
+	// Color is the primary CSS color of the page, e.g. #4251fa.
+	Color string
+}
+
+// UIBranding customizes the web UI served at the root of the faucet, empty fields are kept as
+// their defaults.
+func UIBranding(branding Branding) Option {
+	return func(f *Faucet) {
+		if branding.Title != "" {
+			f.branding.Title = branding.Title
+		}
+		if branding.Color != "" {
+			f.branding.Color = branding.Color
+		}
+		f.branding.Description = branding.Description
+		f.branding.LogoURL = branding.LogoURL
+	}
+}
+
+// CaptchaSiteKey sets the site key of the faucet registered to the captcha provider to render the
+// captcha in the web UI.
+func CaptchaSiteKey(siteKey string) Option {
+	return func(f *Faucet) {
+		f.captchaSiteKey = siteKey
+	}
+}
+
+type uiData struct {
+	Branding
+
+	ChainID         string
+	Coins           sdk.Coins
+	CaptchaProvider CaptchaProvider
+	CaptchaSiteKey  string
+	SignedChallenge bool
+}
+
+// uiHandler serves the web UI that requests tokens from the faucet.
+// the page is rendered before it's written, so the errors of the template aren't sent as a
+// truncated page.
+func (f Faucet) uiHandler(w http.ResponseWriter, r *http.Request) {
+	var page bytes.Buffer
+	if err := tmplUI.Execute(&page, uiData{
+		Branding:        f.branding,
+		ChainID:         f.chainID,
+		Coins:           f.coins,
+		CaptchaProvider: f.captchaProvider,
+		CaptchaSiteKey:  f.captchaSiteKey,
+		SignedChallenge: f.challenges != nil,
+	}); err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	page.WriteTo(w)
+}
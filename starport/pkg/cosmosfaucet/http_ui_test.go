@@ -0,0 +1,52 @@
+package cosmosfaucet
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUIHandler(t *testing.T) {
+	f := Faucet{
+		chainID:         "mars",
+		coins:           sdk.Coins{sdk.NewInt64Coin("token", 10), sdk.NewInt64Coin("stake", 1)},
+		captchaProvider: CaptchaTurnstile,
+		captchaSiteKey:  "site-key",
+		branding:        Branding{Title: DefaultUITitle, Color: DefaultUIColor},
+	}
+	UIBranding(Branding{Title: "Mars Faucet", LogoURL: "https://mars.com/logo.png"})(&f)
+
+	w := httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	page := w.Body.String()
+	require.Contains(t, page, "<title>Mars Faucet</title>")
+	require.Contains(t, page, `<img src="https://mars.com/logo.png"`)
+	require.Contains(t, page, "--color: #4251fa;")
+	require.Contains(t, page, `value="10token"`)
+	require.Contains(t, page, `value="1stake"`)
+	require.Contains(t, page, `<div class="captcha cf-turnstile" data-sitekey="site-key">`)
+	require.Contains(t, page, "turnstile/v0/api.js")
+	require.NotContains(t, page, "hcaptcha.com")
+
+	// the OpenAPI console is moved from the root.
+	w = httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "swagger-ui")
+}
+
+func TestUIHandlerTemplateError(t *testing.T) {
+	defer func(tmpl *template.Template) { tmplUI = tmpl }(tmplUI)
+	tmplUI = template.Must(template.New(fileNameUI).Parse(`<title>{{ .Missing }}</title>`))
+
+	w := httptest.NewRecorder()
+	Faucet{}.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NotContains(t, w.Body.String(), "<title>")
+}
@@ -0,0 +1,174 @@
+package cosmosfaucet
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// limiterDBName is the name of the database that keeps the claim history of the faucet.
+	limiterDBName = "faucet"
+
+	// key prefixes of the claims of accounts and IPs.
+	addressKeyPrefix = "address/"
+	ipKeyPrefix      = "ip/"
+)
+
+// Claim is a transfer of a denom made by the faucet.
+type Claim struct {
+	// Time of the transfer.
+	Time time.Time `json:"time"`
+
+	// Amount of the denom transferred.
+	Amount uint64 `json:"amount"`
+}
+
+// Claims are the claims of an account or an IP by denoms.
+type Claims map[string][]Claim
+
+// Total returns the total amount of the denom claimed after since.
+func (c Claims) Total(denom string, since time.Time) (total uint64) {
+	for _, claim := range c[denom] {
+		if claim.Time.After(since) {
+			total += claim.Amount
+		}
+	}
+	return total
+}
+
+// limiter keeps the claim history of accounts and IPs to limit the amounts that they can claim.
+type limiter struct {
+	mu sync.Mutex
+	db dbm.DB
+}
+
+// newLimiter creates a limiter that persists the claim history in a database in dir. the claim
+// history is kept in memory when dir is empty.
+func newLimiter(dir string) (*limiter, error) {
+	if dir == "" {
+		return &limiter{db: dbm.NewMemDB()}, nil
+	}
+
+	db, err := dbm.NewDB(limiterDBName, dbm.GoLevelDBBackend, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &limiter{db: db}, nil
+}
+
+// claims returns the claims kept with key.
+func (l *limiter) claims(key string) (Claims, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.get(key)
+}
+
+// add adds the claims of coins to the claims kept with key. the claims out of the window of their
+// denoms are pruned.
+func (l *limiter) add(key string, coins sdk.Coins, now time.Time, window func(denom string) time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	claims, err := l.get(key)
+	if err != nil {
+		return err
+	}
+
+	for denom, denomClaims := range claims {
+		var kept []Claim
+		for _, claim := range denomClaims {
+			if now.Sub(claim.Time) < window(denom) {
+				kept = append(kept, claim)
+			}
+		}
+		if len(kept) == 0 {
+			delete(claims, denom)
+			continue
+		}
+		claims[denom] = kept
+	}
+
+	for _, coin := range coins {
+		claims[coin.Denom] = append(claims[coin.Denom], Claim{
+			Time:   now,
+			Amount: coin.Amount.Uint64(),
+		})
+	}
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	return l.db.Set([]byte(key), data)
+}
+
+// remove removes the claims of coins made at the time from the claims kept with key.
+func (l *limiter) remove(key string, coins sdk.Coins, at time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	claims, err := l.get(key)
+	if err != nil {
+		return err
+	}
+
+	for _, coin := range coins {
+		denomClaims := claims[coin.Denom]
+		for i, claim := range denomClaims {
+			if claim.Time.Equal(at) && claim.Amount == coin.Amount.Uint64() {
+				claims[coin.Denom] = append(denomClaims[:i:i], denomClaims[i+1:]...)
+				break
+			}
+		}
+		if len(claims[coin.Denom]) == 0 {
+			delete(claims, coin.Denom)
+		}
+	}
+
+	if len(claims) == 0 {
+		return l.db.Delete([]byte(key))
+	}
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	return l.db.Set([]byte(key), data)
+}
+
+// reset deletes the claims kept with key.
+func (l *limiter) reset(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.db.Delete([]byte(key))
+}
+
+func (l *limiter) close() error {
+	return l.db.Close()
+}
+
+func (l *limiter) get(key string) (Claims, error) {
+	claims := make(Claims)
+
+	data, err := l.db.Get([]byte(key))
+	if err != nil || data == nil {
+		return claims, err
+	}
+
+	err = json.Unmarshal(data, &claims)
+	return claims, err
+}
+
+func addressKey(address string) string {
+	return addressKeyPrefix + address
+}
+
+func ipKey(ip string) string {
+	return ipKeyPrefix + ip
+}
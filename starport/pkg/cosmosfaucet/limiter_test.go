@@ -0,0 +1,86 @@
+package cosmosfaucet
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		now    = time.Now()
+		window = time.Hour
+		key    = addressKey("cosmos1")
+	)
+
+	l, err := newLimiter(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, l.add(key, sdk.NewCoins(sdk.NewInt64Coin("token", 10)), now.Add(-window*2), fixedWindow(window*3)))
+	require.NoError(t, l.add(key, sdk.NewCoins(sdk.NewInt64Coin("token", 5), sdk.NewInt64Coin("stake", 1)), now, fixedWindow(window)))
+
+	claims, err := l.claims(key)
+	require.NoError(t, err)
+
+	// the claim out of the window is pruned.
+	require.Len(t, claims["token"], 1)
+	require.Equal(t, uint64(5), claims.Total("token", now.Add(-window)))
+	require.Equal(t, uint64(1), claims.Total("stake", now.Add(-window)))
+	require.Equal(t, uint64(0), claims.Total("token", now))
+
+	// the claims are persisted across restarts.
+	require.NoError(t, l.close())
+	l, err = newLimiter(dir)
+	require.NoError(t, err)
+	defer l.close()
+
+	claims, err = l.claims(key)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), claims.Total("token", now.Add(-window)))
+
+	// the claims of other keys are kept separately.
+	claims, err = l.claims(ipKey("127.0.0.1"))
+	require.NoError(t, err)
+	require.Empty(t, claims)
+
+	require.NoError(t, l.reset(key))
+	claims, err = l.claims(key)
+	require.NoError(t, err)
+	require.Empty(t, claims)
+}
+
+func TestLimiterDenomWindows(t *testing.T) {
+	var (
+		now    = time.Now()
+		key    = addressKey("cosmos1")
+		window = func(denom string) time.Duration {
+			if denom == "stake" {
+				return time.Minute
+			}
+			return time.Hour
+		}
+	)
+
+	l, err := newLimiter("")
+	require.NoError(t, err)
+	defer l.close()
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin("token", 10), sdk.NewInt64Coin("stake", 1))
+	require.NoError(t, l.add(key, coins, now.Add(-time.Minute*2), window))
+	require.NoError(t, l.add(key, sdk.NewCoins(sdk.NewInt64Coin("token", 1)), now, window))
+
+	// only the claims out of the window of their denom are pruned.
+	claims, err := l.claims(key)
+	require.NoError(t, err)
+	require.Len(t, claims["token"], 2)
+	require.Empty(t, claims["stake"])
+}
+
+func fixedWindow(window time.Duration) func(string) time.Duration {
+	return func(string) time.Duration {
+		return window
+	}
+}
@@ -0,0 +1,235 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// DefaultLowBalanceRequests is the default number of requests that the balance of the faucet
+// needs to cover for each denom before a low balance warning is sent.
+const DefaultLowBalanceRequests = 10
+
+const metricsNamespace = "faucet"
+
+// queryCacheTTL is the time that the results of the queries of the health and the metrics
+// endpoints are reused for.
+const queryCacheTTL = 5 * time.Second
+
+// CollectEvents collects events of the faucet, e.g. low balance warnings.
+func CollectEvents(ev events.Bus) Option {
+	return func(f *Faucet) {
+		f.ev = ev
+	}
+}
+
+// LowBalanceWarning sets the number of requests that the balance of the faucet needs to cover for
+// each denom, a warning is sent to the events when the balance of a denom covers less.
+func LowBalanceWarning(requests uint64) Option {
+	return func(f *Faucet) {
+		f.lowBalanceRequests = requests
+	}
+}
+
+// metrics are the Prometheus metrics of the faucet.
+type metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	grants   *prometheus.CounterVec
+	failures prometheus.Counter
+	balance  *prometheus.GaugeVec
+
+	mu sync.Mutex
+
+	// lowBalances are the denoms that a low balance warning is sent for, a new warning is only sent
+	// after the balance of the denom is increased.
+	lowBalances map[string]bool
+
+	// balancesQuery and statusQuery cache the queries of the chain's binary run by the metrics and
+	// the health endpoints, which are polled by the monitoring.
+	balancesQuery cachedQuery
+	statusQuery   cachedQuery
+}
+
+// cachedQuery runs a query at most once for a TTL.
+type cachedQuery struct {
+	mu  sync.Mutex
+	at  time.Time
+	err error
+}
+
+// run runs query unless its last run happened less than ttl ago, the error of the last run is
+// returned then. the errors of the runs that are canceled by ctx are not cached.
+func (q *cachedQuery) run(ctx context.Context, ttl time.Duration, query func(context.Context) error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.at.IsZero() && time.Since(q.at) < ttl {
+		return q.err
+	}
+
+	err := query(ctx)
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+	q.at, q.err = time.Now(), err
+	return err
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Number of the transfer requests by their HTTP status codes.",
+		}, []string{"code"}),
+		grants: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "granted_amount_total",
+			Help:      "Amounts of the coins transferred by denoms.",
+		}, []string{"denom"}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "transfer_failures_total",
+			Help:      "Number of the transfers that failed after passing the limits.",
+		}),
+		balance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "balance",
+			Help:      "Balance of the faucet account by denoms.",
+		}, []string{"denom"}),
+		lowBalances: make(map[string]bool),
+	}
+
+	m.registry.MustRegister(m.requests, m.grants, m.failures, m.balance)
+
+	return m
+}
+
+// observeRequest records a transfer request responded with the HTTP status code.
+func (m *metrics) observeRequest(code int) {
+	m.requests.WithLabelValues(strconv.Itoa(code)).Inc()
+}
+
+// observeGrant records the coins transferred.
+func (m *metrics) observeGrant(coins sdk.Coins) {
+	for _, c := range coins {
+		amount, _ := c.Amount.ToDec().Float64()
+		m.grants.WithLabelValues(c.Denom).Add(amount)
+	}
+}
+
+// balances queries the balances of the faucet account and updates the balance gauge.
+func (f Faucet) balances(ctx context.Context) (sdk.Coins, error) {
+	account, err := f.runner.ShowAccount(ctx, f.accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := f.runner.BankBalances(ctx, account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range f.coins {
+		amount, _ := balances.AmountOf(c.Denom).ToDec().Float64()
+		f.metrics.balance.WithLabelValues(c.Denom).Set(amount)
+	}
+
+	return balances, nil
+}
+
+// checkBalances sends warnings to the events when the balance of the faucet cannot cover the
+// configured number of requests for a denom.
+func (f Faucet) checkBalances(ctx context.Context) error {
+	balances, err := f.balances(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range f.metrics.lowBalanceWarnings(f.coins, balances, f.lowBalanceRequests) {
+		f.ev.Send(events.New(events.StatusDone, warning))
+	}
+
+	return nil
+}
+
+// lowBalanceWarnings returns warnings for the coins that balances cannot cover the number of requests
+// for. a warning is returned once until the balance of its denom is increased.
+func (m *metrics) lowBalanceWarnings(coins, balances sdk.Coins, requests uint64) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var warnings []string
+
+	for _, c := range coins {
+		threshold := c.Amount.MulRaw(int64(requests))
+		balance := balances.AmountOf(c.Denom)
+
+		if balance.GTE(threshold) {
+			delete(m.lowBalances, c.Denom)
+			continue
+		}
+		if m.lowBalances[c.Denom] {
+			continue
+		}
+		m.lowBalances[c.Denom] = true
+
+		warnings = append(warnings, fmt.Sprintf(
+			"⚠️  Faucet balance is low: %s%s left, enough for less than %d requests",
+			balance,
+			c.Denom,
+			requests,
+		))
+	}
+
+	return warnings
+}
+
+// HealthResponse is the health payload.
+type HealthResponse struct {
+	Status string `json:"status"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// healthHandler responds with OK when the node of the chain is reachable by the faucet.
+// the status of the node is cached for queryCacheTTL.
+func (f Faucet) healthHandler(w http.ResponseWriter, r *http.Request) {
+	err := f.metrics.statusQuery.run(r.Context(), queryCacheTTL, func(ctx context.Context) error {
+		_, err := f.runner.Status(ctx)
+		return err
+	})
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusServiceUnavailable, HealthResponse{
+			Status: "unavailable",
+			Error:  err.Error(),
+		})
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// metricsHandler serves the metrics in Prometheus format, the balance gauge is updated before when
+// it's older than queryCacheTTL.
+func (f Faucet) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	// the metrics are still served when the balances cannot be queried.
+	f.metrics.balancesQuery.run(r.Context(), queryCacheTTL, func(ctx context.Context) error {
+		_, err := f.balances(ctx)
+		return err
+	})
+
+	promhttp.HandlerFor(f.metrics.registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
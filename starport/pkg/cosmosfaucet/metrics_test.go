@@ -0,0 +1,95 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	m := newMetrics()
+
+	m.observeRequest(200)
+	m.observeRequest(200)
+	m.observeRequest(403)
+	m.observeGrant(sdk.NewCoins(sdk.NewInt64Coin("token", 10), sdk.NewInt64Coin("stake", 1)))
+	m.observeGrant(sdk.NewCoins(sdk.NewInt64Coin("token", 5)))
+	m.failures.Inc()
+
+	families, err := m.registry.Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			name := family.GetName()
+			for _, label := range metric.GetLabel() {
+				name += "/" + label.GetValue()
+			}
+			if metric.Counter != nil {
+				values[name] = metric.Counter.GetValue()
+			}
+		}
+	}
+
+	require.Equal(t, map[string]float64{
+		"faucet_requests_total/200":         2,
+		"faucet_requests_total/403":         1,
+		"faucet_granted_amount_total/token": 15,
+		"faucet_granted_amount_total/stake": 1,
+		"faucet_transfer_failures_total":    1,
+	}, values)
+}
+
+func TestLowBalanceWarnings(t *testing.T) {
+	var (
+		m     = newMetrics()
+		coins = sdk.Coins{sdk.NewInt64Coin("token", 10), sdk.NewInt64Coin("stake", 1)}
+	)
+
+	// the balance of token covers less than 10 requests.
+	warnings := m.lowBalanceWarnings(coins, sdk.NewCoins(sdk.NewInt64Coin("token", 99), sdk.NewInt64Coin("stake", 10)), 10)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "99token")
+
+	// the warning is sent once.
+	warnings = m.lowBalanceWarnings(coins, sdk.NewCoins(sdk.NewInt64Coin("token", 90), sdk.NewInt64Coin("stake", 10)), 10)
+	require.Empty(t, warnings)
+
+	// the warning is sent again after the balance is increased.
+	require.Empty(t, m.lowBalanceWarnings(coins, sdk.NewCoins(sdk.NewInt64Coin("token", 100), sdk.NewInt64Coin("stake", 10)), 10))
+	require.Len(t, m.lowBalanceWarnings(coins, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), 10), 1)
+}
+
+func TestCachedQuery(t *testing.T) {
+	var (
+		q    cachedQuery
+		runs int
+		fail = errors.New("unreachable")
+	)
+	query := func(err error) func(context.Context) error {
+		return func(context.Context) error {
+			runs++
+			return err
+		}
+	}
+
+	// the error of the run is reused until the ttl passes.
+	require.Equal(t, fail, q.run(context.Background(), time.Hour, query(fail)))
+	require.Equal(t, fail, q.run(context.Background(), time.Hour, query(nil)))
+	require.Equal(t, 1, runs)
+	require.NoError(t, q.run(context.Background(), 0, query(nil)))
+	require.Equal(t, 2, runs)
+
+	// the canceled runs are not cached.
+	q = cachedQuery{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(t, fail, q.run(ctx, time.Hour, query(fail)))
+	require.NoError(t, q.run(context.Background(), time.Hour, query(nil)))
+	require.Equal(t, 4, runs)
+}
@@ -3,7 +3,6 @@ package cosmosfaucet
 import (
 	"context"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
@@ -40,7 +39,7 @@ func (f Faucet) TotalTransferredAmount(ctx context.Context, toAccountAddress, de
 
 					amount := coins.AmountOf(denom).Uint64()
 
-					if amount > 0 && time.Since(event.Time) < f.limitRefreshWindow {
+					if amount > 0 && time.Since(event.Time) < f.refreshWindow(denom) {
 						totalAmount += amount
 					}
 				}
@@ -53,19 +52,89 @@ func (f Faucet) TotalTransferredAmount(ctx context.Context, toAccountAddress, de
 
 // Transfer transfer amount of tokens from the faucet account to toAccountAddress.
 func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins) error {
+	return f.transfer(ctx, "", toAccountAddress, coins)
+}
+
+// transfer transfers amount of tokens from the faucet account to toAccountAddress requested from ip.
+// the limits of IPs are only checked when ip is provided.
+func (f *Faucet) transfer(ctx context.Context, ip, toAccountAddress string, coins sdk.Coins) error {
+	toAccountAddress, err := normalizeAddress(toAccountAddress, f.addressPrefix)
+	if err != nil {
+		return err
+	}
+
+	coins = f.withFeeCoin(coins)
+
+	if f.batcher != nil {
+		return f.queueTransfer(ctx, ip, toAccountAddress, coins)
+	}
+
 	transferMutex.Lock()
 	defer transferMutex.Unlock()
 
-	var coinsStr []string
+	now := time.Now()
 
-	// check for each coin, the max transferred amount hasn't been reached
-	for _, c := range coins {
-		totalSent, err := f.TotalTransferredAmount(ctx, toAccountAddress, c.Denom)
-		if err != nil {
+	if err := f.checkLimits(ctx, ip, toAccountAddress, coins, now); err != nil {
+		return err
+	}
+
+	// perform transfer for all coins
+	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
+	if err != nil {
+		return err
+	}
+	txHash, err := f.runner.BankSend(ctx, fromAccount.Address, toAccountAddress, coins.String())
+	if err != nil {
+		f.metrics.failures.Inc()
+		return err
+	}
+	f.metrics.observeGrant(coins)
+
+	// the tx is broadcasted, keep the claims even if it's not confirmed in time.
+	if err := f.addClaims(ip, toAccountAddress, coins, now); err != nil {
+		return err
+	}
+
+	// wait for the send tx to be confirmed
+	if err := f.runner.WaitTx(ctx, txHash, time.Second, 30); err != nil {
+		f.metrics.failures.Inc()
+		return err
+	}
+	return nil
+}
+
+// checkLimits checks that transferring coins to toAccountAddress requested from ip doesn't exceed
+// the limits of the account and the IP.
+func (f Faucet) checkLimits(ctx context.Context, ip, toAccountAddress string, coins sdk.Coins, now time.Time) error {
+	accountClaims, err := f.limiter.claims(addressKey(toAccountAddress))
+	if err != nil {
+		return err
+	}
+
+	ipClaims := make(Claims)
+	if ip != "" {
+		if ipClaims, err = f.limiter.claims(ipKey(ip)); err != nil {
 			return err
 		}
+	}
+
+	// check for each coin, the max transferred amount hasn't been reached
+	for _, c := range coins {
+		totalSent := accountClaims.Total(c.Denom, now.Add(-f.refreshWindow(c.Denom)))
 
 		if f.coinsMax[c.Denom] != 0 {
+			// the claim history kept in memory is lost once the faucet is stopped, the transfers
+			// made on the chain are counted too so the limits of the accounts are kept.
+			if f.limiterDir == "" {
+				onChain, err := f.TotalTransferredAmount(ctx, toAccountAddress, c.Denom)
+				if err != nil {
+					return err
+				}
+				if onChain > totalSent {
+					totalSent = onChain
+				}
+			}
+
 			if totalSent >= f.coinsMax[c.Denom] {
 				return fmt.Errorf(
 					"account has reached to the max. allowed amount (%d) for %q denom",
@@ -83,19 +152,68 @@ func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sd
 			}
 		}
 
-		coinsStr = append(coinsStr, c.String())
+		if ipMax := f.ipCoinsMax[c.Denom]; ip != "" && ipMax != 0 {
+			ipTotalSent := ipClaims.Total(c.Denom, now.Add(-f.ipRefreshWindow(c.Denom)))
+			if ipTotalSent+c.Amount.Uint64() > ipMax {
+				return fmt.Errorf(
+					"IP has reached to the max. allowed amount (%d) for %q denom",
+					ipMax,
+					c.Denom,
+				)
+			}
+		}
 	}
 
-	// perform transfer for all coins
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
-	if err != nil {
+	return nil
+}
+
+// addClaims records the claims of coins transferred to toAccountAddress requested from ip.
+func (f Faucet) addClaims(ip, toAccountAddress string, coins sdk.Coins, now time.Time) error {
+	if err := f.limiter.add(addressKey(toAccountAddress), coins, now, f.refreshWindow); err != nil {
 		return err
 	}
-	txHash, err := f.runner.BankSend(ctx, fromAccount.Address, toAccountAddress, strings.Join(coinsStr, ","))
-	if err != nil {
+	if ip != "" {
+		return f.limiter.add(ipKey(ip), coins, now, f.ipRefreshWindow)
+	}
+	return nil
+}
+
+// removeClaims removes the claims recorded by addClaims for a transfer that isn't sent.
+func (f Faucet) removeClaims(ip, toAccountAddress string, coins sdk.Coins, now time.Time) error {
+	if err := f.limiter.remove(addressKey(toAccountAddress), coins, now); err != nil {
 		return err
 	}
+	if ip != "" {
+		return f.limiter.remove(ipKey(ip), coins, now)
+	}
+	return nil
+}
 
-	// wait for the send tx to be confirmed
-	return f.runner.WaitTx(ctx, txHash, time.Second, 30)
+// refreshWindow returns the duration to refresh the transfer limit of accounts for the denom.
+func (f Faucet) refreshWindow(denom string) time.Duration {
+	if window, ok := f.coinsWindow[denom]; ok {
+		return window
+	}
+	return f.limitRefreshWindow
+}
+
+// ipRefreshWindow returns the duration to refresh the transfer limit of IPs for the denom.
+func (f Faucet) ipRefreshWindow(denom string) time.Duration {
+	if window, ok := f.ipCoinsWindow[denom]; ok {
+		return window
+	}
+	return f.ipLimitRefreshWindow
+}
+
+// withFeeCoin adds the fee coin to coins when they don't include the fee denom.
+func (f Faucet) withFeeCoin(coins sdk.Coins) sdk.Coins {
+	if f.feeCoin.Denom == "" {
+		return coins
+	}
+	for _, c := range coins {
+		if c.Denom == f.feeCoin.Denom {
+			return coins
+		}
+	}
+	return append(append(sdk.Coins{}, coins...), f.feeCoin)
 }
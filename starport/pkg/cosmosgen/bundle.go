@@ -0,0 +1,156 @@
+package cosmosgen
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+)
+
+// reImport matches the module specifiers of import/export statements and dynamic imports in TS and JS files.
+var reImport = regexp.MustCompile(`(?m)(?:\bfrom\s*|\bimport\s*\(?\s*)['"]([^'"]+)['"]`)
+
+// BundleSize is the size of the JS code generated for a module's entrypoint.
+type BundleSize struct {
+	// Module is the name of the module.
+	Module string
+
+	// Path is the path of the entrypoint's dir.
+	Path string
+
+	// Size is the total size of the JS files in bytes.
+	Size int64
+
+	// GzipSize is the gzip compressed size of the JS files in bytes.
+	GzipSize int64
+}
+
+// BundleReport holds the sizes of the generated entrypoints.
+type BundleReport []BundleSize
+
+// String returns the report as a table sorted by paths of the entrypoints.
+func (r BundleReport) String() string {
+	sizes := make(BundleReport, len(r))
+	copy(sizes, r)
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i].Path < sizes[j].Path })
+
+	var (
+		b         strings.Builder
+		w         = tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		total     int64
+		totalGzip int64
+	)
+
+	fmt.Fprintln(w, "MODULE\tSIZE\tGZIP\tPATH")
+	for _, s := range sizes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Module, formatSize(s.Size), formatSize(s.GzipSize), s.Path)
+		total += s.Size
+		totalGzip += s.GzipSize
+	}
+	fmt.Fprintf(w, "total\t%s\t%s\n", formatSize(total), formatSize(totalGzip))
+	w.Flush()
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// bundleSize calculates the size of the JS files in the entrypoint at dir.
+func bundleSize(name, dir string) (BundleSize, error) {
+	var (
+		size int64
+		gzb  = &countWriter{}
+		gzw  = gzip.NewWriter(gzb)
+	)
+
+	err := walkFiles(dir, ".js", func(path string) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		n, err := io.Copy(gzw, f)
+		size += n
+		return err
+	})
+	if err != nil {
+		return BundleSize{}, err
+	}
+
+	if err := gzw.Close(); err != nil {
+		return BundleSize{}, err
+	}
+
+	return BundleSize{
+		Module:   name,
+		Path:     dir,
+		Size:     size,
+		GzipSize: gzb.n,
+	}, nil
+}
+
+// checkEntrypointImports makes sure that the TS files of the entrypoint at dir only import files inside
+// the entrypoint or external packages, so each entrypoint can be bundled independently of others.
+func checkEntrypointImports(dir string) error {
+	return walkFiles(dir, ".ts", func(path string) error {
+		if strings.HasSuffix(path, ".d.ts") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, match := range reImport.FindAllSubmatch(content, -1) {
+			specifier := string(match[1])
+			if !strings.HasPrefix(specifier, ".") {
+				continue
+			}
+
+			target := filepath.Join(filepath.Dir(path), filepath.FromSlash(specifier))
+			if rel, err := filepath.Rel(dir, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return errors.Errorf("%s imports %q which is outside of its module", path, specifier)
+			}
+		}
+
+		return nil
+	})
+}
+
+// walkFiles calls fn for each file with the ext in dir.
+func walkFiles(dir, ext string, fn func(path string) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ext {
+			return nil
+		}
+		return fn(path)
+	})
+}
+
+func formatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KiB", float64(size)/1024)
+}
+
+// countWriter counts the bytes written to it.
+type countWriter struct {
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,69 @@
+package cosmosgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEntrypointImports(t *testing.T) {
+	write := func(t *testing.T, path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	t.Run("imports inside the module", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, filepath.Join(dir, "index.ts"), `import { txClient } from './module'
+import { Post } from "./module/types/mars/post"
+// @ts-ignore
+import { SpVuexError } from '@starport/vuex'`)
+		write(t, filepath.Join(dir, "module", "types", "mars", "post.ts"), `export * from "../../types/gogoproto/gogo";`)
+
+		require.NoError(t, checkEntrypointImports(dir))
+	})
+
+	t.Run("imports another module", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, filepath.Join(dir, "index.ts"), `import { Coin } from '../bank/module/types/coin'`)
+
+		err := checkEntrypointImports(dir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "../bank/module/types/coin")
+	})
+
+	t.Run("declarations are skipped", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, filepath.Join(dir, "index.d.ts"), `import { Coin } from '../bank'`)
+
+		require.NoError(t, checkEntrypointImports(dir))
+	})
+}
+
+func TestBundleSize(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "module"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.js"), []byte(strings.Repeat("a", 2000)), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "module", "rest.js"), []byte(strings.Repeat("b", 100)), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.ts"), []byte(strings.Repeat("c", 100)), 0644))
+
+	size, err := bundleSize("mars", dir)
+	require.NoError(t, err)
+	require.Equal(t, "mars", size.Module)
+	require.Equal(t, int64(2100), size.Size)
+	require.Greater(t, size.GzipSize, int64(0))
+	require.Less(t, size.GzipSize, size.Size)
+
+	report := BundleReport{
+		{Module: "mars", Path: "vue/mars", Size: 2048, GzipSize: 512},
+		{Module: "bank", Path: "vue/bank", Size: 100, GzipSize: 80},
+	}
+	require.Equal(t, `MODULE  SIZE     GZIP   PATH
+bank    100 B    80 B   vue/bank
+mars    2.0 KiB  512 B  vue/mars
+total   2.1 KiB  592 B`, report.String())
+}
@@ -0,0 +1,288 @@
+package cosmosgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+	nodetimedata "github.com/tendermint/starport/starport/pkg/nodetime/data"
+	protocdata "github.com/tendermint/starport/starport/pkg/protoc/data"
+)
+
+var (
+	bundledToolsChecksum     string
+	bundledToolsChecksumOnce sync.Once
+)
+
+// cacheRecord is the record of the last code generation of an app.
+type cacheRecord struct {
+	// Key is calculated from all the inputs of the code generation.
+	Key string `json:"key"`
+
+	// Outputs keeps the checksums of generated files by their paths.
+	Outputs map[string]string `json:"outputs"`
+}
+
+// cachePath returns the path of the file where the cache record of the app is saved.
+func (g *generator) cachePath() string {
+	h := sha256.Sum256([]byte(g.appPath))
+	return filepath.Join(g.o.cacheDir, hex.EncodeToString(h[:])+".json")
+}
+
+// isCached checks if the outputs of the last code generation with key are still in place.
+func (g *generator) isCached(key string) (bool, error) {
+	content, err := os.ReadFile(g.cachePath())
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var record cacheRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		// a broken record is not an error, code is simply regenerated.
+		return false, nil
+	}
+
+	if record.Key != key || len(record.Outputs) == 0 {
+		return false, nil
+	}
+
+	for path, checksum := range record.Outputs {
+		current, err := fileChecksum(path)
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if current != checksum {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// saveCache saves the cache record for key with the checksums of the generated files.
+func (g *generator) saveCache(key string) error {
+	record := cacheRecord{
+		Key:     key,
+		Outputs: make(map[string]string),
+	}
+
+	for _, output := range g.outputs {
+		err := filepath.WalkDir(output, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			checksum, err := fileChecksum(path)
+			if err != nil {
+				return err
+			}
+			record.Outputs[path] = checksum
+			return nil
+		})
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	content, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(g.o.cacheDir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(g.cachePath(), content, 0644)
+}
+
+// addOutput registers paths of generated files or dirs to be tracked by the cache.
+func (g *generator) addOutput(paths ...string) {
+	g.outputsMu.Lock()
+	defer g.outputsMu.Unlock()
+
+	g.outputs = append(g.outputs, paths...)
+}
+
+// cacheKey calculates a key from the inputs of code generation. The key changes when proto files of the
+// app, versions of its dependencies, versions of the tools used for generation or the generation
+// options are changed.
+func (g *generator) cacheKey() (string, error) {
+	h := sha256.New()
+
+	// proto files of the app.
+	protoDirs := []string{g.protoDir}
+	protoDirs = append(protoDirs, g.o.includeDirs...)
+
+	for _, dir := range protoDirs {
+		if err := hashDir(h, filepath.Join(g.appPath, dir)); err != nil {
+			return "", err
+		}
+	}
+
+	// dependencies that 3rd party proto files are coming from, module versions are immutable.
+	var deps []string
+	for _, dep := range g.deps {
+		deps = append(deps, dep.Path+"@"+dep.Version)
+	}
+	sort.Strings(deps)
+
+	for _, dep := range deps {
+		fmt.Fprintln(h, dep)
+	}
+
+	// versions of the protoc plugins installed from the app's go.mod.
+	gm, err := gomodule.ParseAt(g.appPath)
+	if err != nil {
+		return "", err
+	}
+
+	for _, tool := range Tools {
+		for _, r := range gm.Require {
+			if r.Mod.Path == tool.Module {
+				fmt.Fprintln(h, r.Mod.String())
+			}
+		}
+	}
+
+	// tools bundled into Starport and the templates.
+	fmt.Fprintln(h, bundledToolsHash())
+
+	if err := fs.WalkDir(templates, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := templates.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(h, path)
+		h.Write(content)
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	// generation options.
+	fmt.Fprintln(h, g.optionsFingerprint())
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// optionsFingerprint returns a text describing what code is generated and where.
+func (g *generator) optionsFingerprint() string {
+	o := g.o
+
+	fingerprint := fmt.Sprintf("go:%s,%s client:%s,%s wasm:%s spec:%s,%s vuex:%s third:%t,%t,%t,%t,%t",
+		o.gomodPath, o.bufTemplate,
+		o.goClientAppPath, o.goClientOut,
+		o.wasmSignerOut,
+		o.specOut, o.specV3Out,
+		o.vuexStoreRootPath,
+		o.jsIncludeThirdParty, o.vueIncludeThirdParty, o.reactIncludeThirdParty, o.dartIncludeThirdParty, o.rustIncludeThirdParty,
+	)
+
+	outs := map[string]func(module.Module) string{
+		"js":    o.jsOut,
+		"vue":   o.vueOut,
+		"react": o.reactOut,
+		"dart":  o.dartOut,
+		"rust":  o.rustOut,
+	}
+
+	var names []string
+	for name := range outs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	modules := append([]module.Module{}, g.appModules...)
+	for _, m := range g.thirdModules {
+		modules = append(modules, m...)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Pkg.Name < modules[j].Pkg.Name })
+
+	for _, name := range names {
+		out := outs[name]
+		if out == nil {
+			continue
+		}
+		for _, m := range modules {
+			fingerprint += fmt.Sprintf(" %s:%s", name, out(m))
+		}
+	}
+
+	return fingerprint
+}
+
+// bundledToolsHash returns the checksum of the protoc and nodetime binaries bundled into Starport.
+func bundledToolsHash() string {
+	bundledToolsChecksumOnce.Do(func() {
+		h := sha256.New()
+		h.Write(protocdata.Binary())
+		h.Write(nodetimedata.Binary())
+		bundledToolsChecksum = hex.EncodeToString(h.Sum(nil))
+	})
+	return bundledToolsChecksum
+}
+
+// hashDir writes the relative paths and contents of the files in dir to h in a stable order.
+func hashDir(h hash.Hash, dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(h, filepath.ToSlash(rel))
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
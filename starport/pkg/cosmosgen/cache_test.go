@@ -0,0 +1,79 @@
+package cosmosgen
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	var (
+		appPath = t.TempDir()
+		outDir  = filepath.Join(appPath, "out")
+		outFile = filepath.Join(outDir, "a.ts")
+		g       = &generator{
+			appPath: appPath,
+			o:       &generateOptions{cacheDir: t.TempDir()},
+		}
+	)
+
+	require.NoError(t, os.MkdirAll(outDir, 0755))
+	require.NoError(t, os.WriteFile(outFile, []byte("a"), 0644))
+
+	// nothing is cached yet.
+	isCached, err := g.isCached("key")
+	require.NoError(t, err)
+	require.False(t, isCached)
+
+	g.addOutput(outDir)
+	require.NoError(t, g.saveCache("key"))
+
+	isCached, err = g.isCached("key")
+	require.NoError(t, err)
+	require.True(t, isCached)
+
+	// a different key means that inputs are changed.
+	isCached, err = g.isCached("other")
+	require.NoError(t, err)
+	require.False(t, isCached)
+
+	// generated files are modified.
+	require.NoError(t, os.WriteFile(outFile, []byte("b"), 0644))
+	isCached, err = g.isCached("key")
+	require.NoError(t, err)
+	require.False(t, isCached)
+
+	// generated files are removed.
+	require.NoError(t, os.RemoveAll(outDir))
+	isCached, err = g.isCached("key")
+	require.NoError(t, err)
+	require.False(t, isCached)
+}
+
+func TestHashDir(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.proto"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b", "c.proto"), []byte("c"), 0644))
+
+	checksum := func() string {
+		h := sha256.New()
+		require.NoError(t, hashDir(h, dir))
+		return string(h.Sum(nil))
+	}
+
+	first := checksum()
+	require.Equal(t, first, checksum())
+
+	// renaming a file changes the checksum even if the content is the same.
+	require.NoError(t, os.Rename(filepath.Join(dir, "a.proto"), filepath.Join(dir, "d.proto")))
+	require.NotEqual(t, first, checksum())
+
+	// missing dirs are ignored.
+	h := sha256.New()
+	require.NoError(t, hashDir(h, filepath.Join(dir, "missing")))
+}
@@ -2,6 +2,7 @@ package cosmosgen
 
 import (
 	"context"
+	"sync"
 
 	gomodmodule "golang.org/x/mod/module"
 
@@ -12,16 +13,36 @@ import (
 type generateOptions struct {
 	includeDirs []string
 	gomodPath   string
+	cacheDir    string
+	bufTemplate string
 
 	jsOut               func(module.Module) string
 	jsIncludeThirdParty bool
 	vuexStoreRootPath   string
 
-	specOut string
+	vueOut               func(module.Module) string
+	vueIncludeThirdParty bool
+
+	reactOut               func(module.Module) string
+	reactIncludeThirdParty bool
+
+	bundleReport func(BundleReport)
+
+	specOut   string
+	specV3Out string
+
+	goClientAppPath string
+	goClientOut     string
+
+	wasmSignerOut string
 
 	dartOut               func(module.Module) string
 	dartIncludeThirdParty bool
 	dartRootPath          string
+
+	rustOut               func(module.Module) string
+	rustIncludeThirdParty bool
+	rustRootPath          string
 }
 
 // TODO add WithInstall.
@@ -51,6 +72,33 @@ func WithVuexGeneration(includeThirdPartyModules bool, out func(module.Module) (
 	}
 }
 
+// WithVueGeneration adds generation of Vue 3 composables with cached queries and tx helpers for each module.
+// out hook is called for each module to retrieve the path of the underlying JS lib of a given module, composables
+// are placed in the parent dir of it.
+func WithVueGeneration(includeThirdPartyModules bool, out func(module.Module) (path string)) Option {
+	return func(o *generateOptions) {
+		o.vueOut = out
+		o.vueIncludeThirdParty = includeThirdPartyModules
+	}
+}
+
+// WithReactGeneration adds generation of React Query hooks for each module. includeThirdPartyModules and out
+// works the same as in WithVueGeneration.
+func WithReactGeneration(includeThirdPartyModules bool, out func(module.Module) (path string)) Option {
+	return func(o *generateOptions) {
+		o.reactOut = out
+		o.reactIncludeThirdParty = includeThirdPartyModules
+	}
+}
+
+// WithBundleSizeReport makes the sizes of the JS code generated for each module's entrypoint calculated
+// after JS code generation, report is called with the sizes.
+func WithBundleSizeReport(report func(BundleReport)) Option {
+	return func(o *generateOptions) {
+		o.bundleReport = report
+	}
+}
+
 func WithDartGeneration(includeThirdPartyModules bool, out func(module.Module) (path string), rootPath string) Option {
 	return func(o *generateOptions) {
 		o.dartOut = out
@@ -59,6 +107,17 @@ func WithDartGeneration(includeThirdPartyModules bool, out func(module.Module) (
 	}
 }
 
+// WithRustGeneration adds Rust code generation. out hook is called for each module to retrieve the path
+// of the crate generated for a given module. rootPath is the root of the Cargo workspace that includes
+// all the generated crates.
+func WithRustGeneration(includeThirdPartyModules bool, out func(module.Module) (path string), rootPath string) Option {
+	return func(o *generateOptions) {
+		o.rustOut = out
+		o.rustIncludeThirdParty = includeThirdPartyModules
+		o.rustRootPath = rootPath
+	}
+}
+
 // WithGoGeneration adds Go code generation.
 func WithGoGeneration(gomodPath string) Option {
 	return func(o *generateOptions) {
@@ -66,6 +125,35 @@ func WithGoGeneration(gomodPath string) Option {
 	}
 }
 
+// WithBuf makes Go code generated with buf by using the generation template at path relative to
+// the proto dir, instead of protoc. Proto dependencies are resolved by buf from the buf.yaml in
+// the proto dir, so the template may use remote plugins for hermetic code generation.
+func WithBuf(template string) Option {
+	return func(o *generateOptions) {
+		o.bufTemplate = template
+	}
+}
+
+// WithGoClientGeneration adds generation of a standalone Go module that exposes typed clients
+// for all modules used by the app -including the 3rd party ones-. appModulePath is the Go module
+// path of the app and out is the path of the client module relative to the app's source code.
+func WithGoClientGeneration(appModulePath, out string) Option {
+	return func(o *generateOptions) {
+		o.goClientAppPath = appModulePath
+		o.goClientOut = out
+	}
+}
+
+// WithWasmSignerGeneration adds generation of a WebAssembly helper that signs the txs of the app in
+// browsers, including the ones with the app's custom messages. the helper is built from the proto files of
+// all modules with msg services and saved to out together with a TypeScript loader. out is relative to the
+// app's path.
+func WithWasmSignerGeneration(out string) Option {
+	return func(o *generateOptions) {
+		o.wasmSignerOut = out
+	}
+}
+
 // WithOpenAPIGeneration adds OpenAPI spec generation.
 func WithOpenAPIGeneration(out string) Option {
 	return func(o *generateOptions) {
@@ -73,6 +161,24 @@ func WithOpenAPIGeneration(out string) Option {
 	}
 }
 
+// WithOpenAPIV3Generation adds OpenAPI 3.0 spec generation. The spec is saved in JSON format to out
+// and it merges the specs of app's modules with the 3rd party ones -including the SDK and IBC- under
+// a tag per module.
+func WithOpenAPIV3Generation(out string) Option {
+	return func(o *generateOptions) {
+		o.specV3Out = out
+	}
+}
+
+// WithCacheDir enables caching of code generation. The cache keeps a key calculated from proto files,
+// dependency and tool versions and generation options together with the checksums of generated files,
+// so code generation is skipped when none of them are changed. Cache records are saved in dir.
+func WithCacheDir(dir string) Option {
+	return func(o *generateOptions) {
+		o.cacheDir = dir
+	}
+}
+
 // IncludeDirs configures the third party proto dirs that used by app's proto.
 // relative to the projectPath.
 func IncludeDirs(dirs []string) Option {
@@ -91,6 +197,8 @@ type generator struct {
 	deps         []gomodmodule.Version
 	appModules   []module.Module
 	thirdModules map[string][]module.Module // app dependency-modules pair.
+	outputs      []string                   // paths of generated files and dirs.
+	outputsMu    sync.Mutex
 }
 
 // Generate generates code from protoDir of an SDK app residing at appPath with given options.
@@ -112,6 +220,29 @@ func Generate(ctx context.Context, appPath, protoDir string, options ...Option)
 		return err
 	}
 
+	if g.o.cacheDir == "" {
+		return g.generate()
+	}
+
+	key, err := g.cacheKey()
+	if err != nil {
+		return err
+	}
+
+	isCached, err := g.isCached(key)
+	if err != nil || isCached {
+		return err
+	}
+
+	if err := g.generate(); err != nil {
+		return err
+	}
+
+	return g.saveCache(key)
+}
+
+// generate generates code for enabled targets.
+func (g *generator) generate() error {
 	if g.o.gomodPath != "" {
 		if err := g.generateGo(); err != nil {
 			return err
@@ -121,7 +252,7 @@ func Generate(ctx context.Context, appPath, protoDir string, options ...Option)
 	// js generation requires Go types to be existent in the source code. because
 	// sdk.Msg implementations defined on the generated Go types.
 	// so it needs to run after Go code gen.
-	if g.o.jsOut != nil {
+	if g.o.jsOut != nil || g.o.vueOut != nil || g.o.reactOut != nil {
 		if err := g.generateJS(); err != nil {
 			return err
 		}
@@ -133,12 +264,30 @@ func Generate(ctx context.Context, appPath, protoDir string, options ...Option)
 		}
 	}
 
-	if g.o.specOut != "" {
+	if g.o.rustOut != nil {
+		if err := g.generateRust(); err != nil {
+			return err
+		}
+	}
+
+	// the client module depends on the Go types of the app, so it needs to run after Go code gen.
+	if g.o.goClientOut != "" {
+		if err := g.generateGoClient(); err != nil {
+			return err
+		}
+	}
+
+	if g.o.wasmSignerOut != "" {
+		if err := g.generateWasmSigner(); err != nil {
+			return err
+		}
+	}
+
+	if g.o.specOut != "" || g.o.specV3Out != "" {
 		if err := generateOpenAPISpec(g); err != nil {
 			return err
 		}
 	}
 
 	return nil
-
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/mattn/go-zglob"
 	"github.com/pkg/errors"
@@ -75,6 +76,8 @@ func (g *dartGenerator) generateModule(ctx context.Context, plugin, appPath stri
 		exportOut = filepath.Join(out, dartExportFileName)
 	)
 
+	g.g.addOutput(out)
+
 	includePaths, err := g.g.resolveInclude(appPath)
 	if err != nil {
 		return err
@@ -107,6 +110,9 @@ func (g *dartGenerator) generateModule(ctx context.Context, plugin, appPath stri
 		return err
 	}
 
+	// keep the exports in a stable order.
+	sort.Strings(generatedFiles)
+
 	var exportContent bytes.Buffer
 	for _, file := range generatedFiles {
 		path, err := filepath.Rel(out, file)
@@ -1,12 +1,14 @@
 package cosmosgen
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 
 	"github.com/otiai10/copy"
 	"github.com/pkg/errors"
 
+	"github.com/tendermint/starport/starport/pkg/cosmosbuf"
 	"github.com/tendermint/starport/starport/pkg/protoanalysis"
 	"github.com/tendermint/starport/starport/pkg/protoc"
 )
@@ -33,18 +35,31 @@ func (g *generator) generateGo() error {
 	}
 	defer os.RemoveAll(tmp)
 
-	// discover proto packages in the app.
 	pp := filepath.Join(g.appPath, g.protoDir)
-	pkgs, err := protoanalysis.Parse(g.ctx, nil, pp)
-	if err != nil {
-		return err
-	}
 
-	// code generate for each module.
-	for _, pkg := range pkgs {
-		if err := protoc.Generate(g.ctx, tmp, pkg.Path, includePaths, goOuts); err != nil {
+	if g.o.bufTemplate != "" {
+		// buf resolves the dependencies itself and generates code for all packages at once.
+		b, err := cosmosbuf.New()
+		if err != nil {
+			return err
+		}
+
+		if err := b.Generate(g.ctx, pp, tmp, filepath.Join(pp, g.o.bufTemplate)); err != nil {
+			return err
+		}
+	} else {
+		// discover proto packages in the app.
+		pkgs, err := protoanalysis.Parse(g.ctx, nil, pp)
+		if err != nil {
 			return err
 		}
+
+		// code generate for each module.
+		for _, pkg := range pkgs {
+			if err := protoc.Generate(g.ctx, tmp, pkg.Path, includePaths, goOuts); err != nil {
+				return err
+			}
+		}
 	}
 
 	// move generated code for the app under the relative locations in its source code.
@@ -52,6 +67,22 @@ func (g *generator) generateGo() error {
 
 	_, err = os.Stat(generatedPath)
 	if err == nil {
+		// track the generated files under their final locations.
+		err = filepath.WalkDir(generatedPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(generatedPath, path)
+			if err != nil {
+				return err
+			}
+			g.addOutput(filepath.Join(g.appPath, rel))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
 		err = copy.Copy(generatedPath, g.appPath)
 		if err != nil {
 			return errors.Wrap(err, "cannot copy path")
@@ -0,0 +1,183 @@
+package cosmosgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"golang.org/x/mod/modfile"
+
+	"github.com/tendermint/starport/starport/pkg/cmdrunner"
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+)
+
+const (
+	goClientQueryService = "Query"
+	goClientMsgService   = "Msg"
+)
+
+// goClientModule keeps the info needed to wire a module's client into the root client.
+type goClientModule struct {
+	Alias      string
+	ImportPath string
+	FieldName  string
+}
+
+// generateGoClient generates a standalone Go module that exposes typed clients for all the modules
+// used by the app, including the 3rd party ones.
+func (g *generator) generateGoClient() error {
+	var (
+		out        = filepath.Join(g.appPath, g.o.goClientOut)
+		modulePath = g.o.goClientAppPath + "/" + filepath.ToSlash(g.o.goClientOut)
+	)
+
+	g.addOutput(out)
+
+	// reset destination dir.
+	if err := os.RemoveAll(out); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(out, 0766); err != nil {
+		return err
+	}
+
+	modules := append([]module.Module{}, g.appModules...)
+	for _, m := range g.thirdModules {
+		modules = append(modules, m...)
+	}
+
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Pkg.Name < modules[j].Pkg.Name })
+
+	var clientModules []goClientModule
+
+	for _, m := range modules {
+		// skip modules without any service to use.
+		hasQuery, hasMsg := goClientServices(m)
+		if !hasQuery && !hasMsg {
+			continue
+		}
+
+		var (
+			pkgPath = strings.ReplaceAll(m.Pkg.Name, ".", "/")
+			pkgOut  = filepath.Join(out, pkgPath)
+		)
+
+		if err := os.MkdirAll(pkgOut, 0766); err != nil {
+			return err
+		}
+
+		if err := templateGoClientModule.Write(pkgOut, "", struct {
+			Package     string
+			TypesImport string
+			HasQuery    bool
+			HasMsg      bool
+			Module      module.Module
+		}{
+			Package:     filepath.Base(pkgPath),
+			TypesImport: m.Pkg.GoImportPath(),
+			HasQuery:    hasQuery,
+			HasMsg:      hasMsg,
+			Module:      m,
+		}); err != nil {
+			return err
+		}
+
+		clientModules = append(clientModules, goClientModule{
+			Alias:      strings.ReplaceAll(m.Pkg.Name, ".", ""),
+			ImportPath: modulePath + "/" + pkgPath,
+			FieldName:  strcase.ToCamel(strings.ReplaceAll(m.Pkg.Name, ".", "_")),
+		})
+	}
+
+	gm, err := gomodule.ParseAt(g.appPath)
+	if err != nil {
+		return err
+	}
+
+	appRelPath, err := filepath.Rel(out, g.appPath)
+	if err != nil {
+		return err
+	}
+
+	replaces, err := goClientReplaces(gm, g.appPath, out)
+	if err != nil {
+		return err
+	}
+
+	goVersion := "1.16"
+	if gm.Go != nil {
+		goVersion = gm.Go.Version
+	}
+
+	if err := templateGoClientRoot.Write(out, "", struct {
+		ModulePath    string
+		AppModulePath string
+		AppRelPath    string
+		GoVersion     string
+		Replaces      []string
+		Modules       []goClientModule
+	}{
+		ModulePath:    modulePath,
+		AppModulePath: g.o.goClientAppPath,
+		AppRelPath:    filepath.ToSlash(appRelPath),
+		GoVersion:     goVersion,
+		Replaces:      replaces,
+		Modules:       clientModules,
+	}); err != nil {
+		return err
+	}
+
+	// format the generated code and resolve the dependencies of the client module.
+	return cmdrunner.
+		New(cmdrunner.DefaultWorkdir(out)).
+		Run(g.ctx,
+			step.New(step.Exec("gofmt", "-w", ".")),
+			step.New(step.Exec("go", "mod", "tidy")),
+		)
+}
+
+// goClientServices checks if a module has query and msg services.
+func goClientServices(m module.Module) (hasQuery, hasMsg bool) {
+	for _, s := range m.Pkg.Services {
+		switch s.Name {
+		case goClientQueryService:
+			hasQuery = true
+		case goClientMsgService:
+			hasMsg = true
+		}
+	}
+	return
+}
+
+// goClientReplaces returns the replace directives of the app that also must be applied to the
+// client module, local paths are made relative to out.
+func goClientReplaces(f *modfile.File, appPath, out string) ([]string, error) {
+	var replaces []string
+
+	for _, r := range f.Replace {
+		old := r.Old.Path
+		if r.Old.Version != "" {
+			old += " " + r.Old.Version
+		}
+
+		replacement := r.New.Path
+		if r.New.Version != "" {
+			replacement += " " + r.New.Version
+		} else if !filepath.IsAbs(replacement) {
+			path, err := filepath.Rel(out, filepath.Join(appPath, replacement))
+			if err != nil {
+				return nil, err
+			}
+			replacement = filepath.ToSlash(path)
+		}
+
+		replaces = append(replaces, fmt.Sprintf("%s => %s", old, replacement))
+	}
+
+	return replaces, nil
+}
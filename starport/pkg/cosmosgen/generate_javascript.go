@@ -1,7 +1,11 @@
 package cosmosgen
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,6 +36,22 @@ var (
 
 const vuexRootMarker = "vuex-root"
 
+// jsModuleChecksumFile is the file where the checksum of inputs used to generate a module's JS code is saved.
+const jsModuleChecksumFile = ".checksum"
+
+// jsTarget is a JS code generation target, each target generates the JS lib of modules together with
+// the bindings rendered from templates.
+type jsTarget struct {
+	// out returns the path of the JS lib generated for a module.
+	out func(module.Module) string
+
+	// includeThirdParty enables code generation for the 3rd party modules.
+	includeThirdParty bool
+
+	// bindings renders the framework bindings of a module, placed in the parent dir of the JS lib.
+	bindings *templateWriter
+}
+
 type jsGenerator struct {
 	g *generator
 }
@@ -45,14 +65,62 @@ func newJSGenerator(g *generator) *jsGenerator {
 func (g *generator) generateJS() error {
 	jsg := newJSGenerator(g)
 
-	if err := jsg.generateModules(); err != nil {
-		return err
+	targets := jsg.targets()
+
+	for _, target := range targets {
+		if err := jsg.generateModules(target); err != nil {
+			return err
+		}
+	}
+
+	if g.o.vuexStoreRootPath != "" {
+		if err := jsg.generateVuexModuleLoader(); err != nil {
+			return err
+		}
+	}
+
+	if g.o.bundleReport == nil {
+		return nil
+	}
+
+	return jsg.reportBundleSizes(targets)
+}
+
+// targets returns the enabled JS code generation targets.
+func (g *jsGenerator) targets() []jsTarget {
+	var targets []jsTarget
+
+	if g.g.o.jsOut != nil {
+		target := jsTarget{
+			out:               g.g.o.jsOut,
+			includeThirdParty: g.g.o.jsIncludeThirdParty,
+		}
+		if g.g.o.vuexStoreRootPath != "" {
+			target.bindings = &templateVuexStore
+		}
+		targets = append(targets, target)
+	}
+
+	if g.g.o.vueOut != nil {
+		targets = append(targets, jsTarget{
+			out:               g.g.o.vueOut,
+			includeThirdParty: g.g.o.vueIncludeThirdParty,
+			bindings:          &templateVue,
+		})
 	}
 
-	return jsg.generateVuexModuleLoader()
+	if g.g.o.reactOut != nil {
+		targets = append(targets, jsTarget{
+			out:               g.g.o.reactOut,
+			includeThirdParty: g.g.o.reactIncludeThirdParty,
+			bindings:          &templateReact,
+		})
+	}
+
+	return targets
 }
 
-func (g *jsGenerator) generateModules() error {
+func (g *jsGenerator) generateModules(target jsTarget) error {
 	tsprotoPluginPath, cleanup, err := tsproto.BinaryPath()
 	if err != nil {
 		return err
@@ -61,32 +129,79 @@ func (g *jsGenerator) generateModules() error {
 
 	gg := &errgroup.Group{}
 
-	add := func(sourcePath string, modules []module.Module) {
+	g.forEachModule(target, func(sourcePath string, m module.Module) {
+		gg.Go(func() error { return g.generateModule(g.g.ctx, target, tsprotoPluginPath, sourcePath, m) })
+	})
+
+	return gg.Wait()
+}
+
+// forEachModule calls fn for each module that JS code is generated for by the target.
+func (g *jsGenerator) forEachModule(target jsTarget, fn func(sourcePath string, m module.Module)) {
+	for _, m := range g.g.appModules {
+		fn(g.g.appPath, m)
+	}
+
+	if !target.includeThirdParty {
+		return
+	}
+
+	for sourcePath, modules := range g.g.thirdModules {
 		for _, m := range modules {
-			m := m
-			gg.Go(func() error { return g.generateModule(g.g.ctx, tsprotoPluginPath, sourcePath, m) })
+			fn(sourcePath, m)
 		}
 	}
+}
 
-	add(g.g.appPath, g.g.appModules)
+// reportBundleSizes calculates the sizes of the entrypoints generated for each module by the targets.
+func (g *jsGenerator) reportBundleSizes(targets []jsTarget) error {
+	var (
+		report BundleReport
+		err    error
+	)
+
+	for _, target := range targets {
+		g.forEachModule(target, func(_ string, m module.Module) {
+			if err != nil {
+				return
+			}
 
-	if g.g.o.jsIncludeThirdParty {
-		for sourcePath, modules := range g.g.thirdModules {
-			add(sourcePath, modules)
+			var size BundleSize
+			size, err = bundleSize(m.Pkg.Name, filepath.Dir(target.out(m)))
+			report = append(report, size)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	return gg.Wait()
+	g.g.o.bundleReport(report)
+
+	return nil
 }
 
 // generateModule generates generates JS code for a module.
-func (g *jsGenerator) generateModule(ctx context.Context, tsprotoPluginPath, appPath string, m module.Module) error {
+// generation is skipped when neither the proto files of the module nor the templates are changed since
+// the last time, so only the changed modules are regenerated while serving.
+func (g *jsGenerator) generateModule(ctx context.Context, target jsTarget, tsprotoPluginPath, appPath string, m module.Module) error {
 	var (
-		out          = g.g.o.jsOut(m)
+		out          = target.out(m)
 		storeDirPath = filepath.Dir(out)
 		typesOut     = filepath.Join(out, "types")
+		checksumPath = filepath.Join(out, jsModuleChecksumFile)
 	)
 
+	g.g.addOutput(storeDirPath)
+
+	checksum, err := jsModuleChecksum(target, m)
+	if err != nil {
+		return err
+	}
+
+	if saved, err := os.ReadFile(checksumPath); err == nil && bytes.Equal(saved, checksum) {
+		return nil
+	}
+
 	includePaths, err := g.g.resolveInclude(appPath)
 	if err != nil {
 		return err
@@ -143,15 +258,67 @@ func (g *jsGenerator) generateModule(ctx context.Context, tsprotoPluginPath, app
 		return err
 	}
 
-	// generate Vuex if enabled.
-	if g.g.o.vuexStoreRootPath != "" {
-		err = templateVuexStore.Write(storeDirPath, pp, struct{ Module module.Module }{m})
+	// generate framework bindings if enabled.
+	if target.bindings != nil {
+		err = target.bindings.Write(storeDirPath, pp, struct{ Module module.Module }{m})
 		if err != nil {
 			return err
 		}
 	}
+	// each module is an independent entrypoint, so frontends only bundle the modules they import.
+	if err := checkEntrypointImports(storeDirPath); err != nil {
+		return err
+	}
+
 	// generate .js and .d.ts files for all ts files.
-	return tsc.Generate(g.g.ctx, tscConfig(storeDirPath+"/**/*.ts"))
+	if err := tsc.Generate(g.g.ctx, tscConfig(storeDirPath+"/**/*.ts")); err != nil {
+		return err
+	}
+
+	return os.WriteFile(checksumPath, checksum, 0644)
+}
+
+// jsModuleChecksum calculates a checksum from the proto files of a module and the templates
+// used to generate its JS code.
+func jsModuleChecksum(target jsTarget, m module.Module) ([]byte, error) {
+	h := sha256.New()
+
+	protoFiles, err := filepath.Glob(filepath.Join(m.Pkg.Path, "*.proto"))
+	if err != nil {
+		return nil, err
+	}
+
+	templateDirs := []string{templateJSClient.templateDir}
+	if target.bindings != nil {
+		templateDirs = append(templateDirs, target.bindings.templateDir)
+	}
+
+	for _, path := range protoFiles {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(content)
+	}
+
+	for _, dir := range templateDirs {
+		err := fs.WalkDir(templates, filepath.Join("templates", dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := templates.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			h.Write(content)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []byte(fmt.Sprintf("%x", h.Sum(nil))), nil
 }
 
 func (g *jsGenerator) generateVuexModuleLoader() error {
@@ -207,6 +374,7 @@ func (g *jsGenerator) generateVuexModuleLoader() error {
 	}
 
 	loaderPath := filepath.Join(g.g.o.vuexStoreRootPath, "index.ts")
+	g.g.addOutput(g.g.o.vuexStoreRootPath)
 
 	if err := templateVuexRoot.Write(g.g.o.vuexStoreRootPath, "", data); err != nil {
 		return err
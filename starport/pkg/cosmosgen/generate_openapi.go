@@ -1,14 +1,17 @@
 package cosmosgen
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"sort"
 
+	"github.com/goccy/go-yaml"
 	"github.com/iancoleman/strcase"
 
 	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
 	swaggercombine "github.com/tendermint/starport/starport/pkg/nodetime/programs/swagger-combine"
+	"github.com/tendermint/starport/starport/pkg/openapiv3"
 	"github.com/tendermint/starport/starport/pkg/protoc"
 )
 
@@ -17,8 +20,6 @@ var openAPIOut = []string{
 }
 
 func generateOpenAPISpec(g *generator) error {
-	out := filepath.Join(g.appPath, g.o.specOut)
-
 	var (
 		specDirs []string
 		conf     = swaggercombine.Config{
@@ -62,6 +63,12 @@ func generateOpenAPISpec(g *generator) error {
 		specDirs = append(specDirs, dir)
 
 		specPath := filepath.Join(dir, "apidocs.swagger.json")
+
+		// group operations of each module under a tag named after the module.
+		if err := tagSpec(specPath, m.Pkg.Name); err != nil {
+			return err
+		}
+
 		return conf.AddSpec(strcase.ToCamel(m.Pkg.Name), specPath)
 	}
 
@@ -92,12 +99,96 @@ func generateOpenAPISpec(g *generator) error {
 
 	sort.Slice(conf.APIs, func(a, b int) bool { return conf.APIs[a].ID < conf.APIs[b].ID })
 
-	// ensure out dir exists.
-	outDir := filepath.Dir(out)
-	if err := os.MkdirAll(outDir, 0766); err != nil {
+	// combine specs into one and save to out. when only the OpenAPI 3.0 spec is requested,
+	// the combined one is saved to a temporary file to be converted.
+	var out string
+
+	if g.o.specOut != "" {
+		out = filepath.Join(g.appPath, g.o.specOut)
+
+		if err := os.MkdirAll(filepath.Dir(out), 0766); err != nil {
+			return err
+		}
+	} else {
+		dir, err := os.MkdirTemp("", "gen-openapi-spec")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		out = filepath.Join(dir, "openapi.yml")
+	}
+
+	if err := swaggercombine.Combine(g.ctx, conf, out); err != nil {
+		return err
+	}
+
+	if g.o.specOut != "" {
+		g.addOutput(out)
+	}
+
+	if g.o.specV3Out == "" {
+		return nil
+	}
+
+	outV3 := filepath.Join(g.appPath, g.o.specV3Out)
+	g.addOutput(outV3)
+
+	return generateOpenAPIV3Spec(out, outV3)
+}
+
+// generateOpenAPIV3Spec converts the combined Swagger 2.0 spec at specPath to OpenAPI 3.0 and saves it to out.
+func generateOpenAPIV3Spec(specPath, out string) error {
+	spec, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+
+	spec, err = yaml.YAMLToJSON(spec)
+	if err != nil {
+		return err
+	}
+
+	specV3, err := openapiv3.Convert(spec)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(out), 0766); err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, specV3, 0644)
+}
+
+// tagSpec replaces tags of all operations in the spec at path with tag.
+func tagSpec(path, tag string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return err
+	}
+
+	paths, _ := spec["paths"].(map[string]interface{})
+	for _, item := range paths {
+		operations, _ := item.(map[string]interface{})
+		for _, op := range operations {
+			if op, ok := op.(map[string]interface{}); ok {
+				op["tags"] = []string{tag}
+			}
+		}
+	}
+
+	spec["tags"] = []map[string]string{{"name": tag}}
+
+	content, err = json.Marshal(spec)
+	if err != nil {
 		return err
 	}
 
-	// combine specs into one and save to out.
-	return swaggercombine.Combine(g.ctx, conf, out)
+	return os.WriteFile(path, content, 0644)
 }
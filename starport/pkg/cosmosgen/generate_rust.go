@@ -0,0 +1,231 @@
+package cosmosgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+	"github.com/tendermint/starport/starport/pkg/protoc"
+)
+
+var (
+	rustOut = []string{
+		"--prost_out=.",
+		"--tonic_out=no_include=true:.",
+	}
+
+	// rustPlugins are the protoc plugins needed to generate Rust code, they can be installed with cargo.
+	rustPlugins = []string{
+		"protoc-gen-prost",
+		"protoc-gen-tonic",
+	}
+)
+
+const (
+	rustSrcDirName      = "src"
+	rustLibFileName     = "lib.rs"
+	rustTonicFileSuffix = ".tonic.rs"
+)
+
+type rustGenerator struct {
+	g *generator
+}
+
+func newRustGenerator(g *generator) *rustGenerator {
+	return &rustGenerator{
+		g: g,
+	}
+}
+
+func (g *generator) generateRust() error {
+	rg := newRustGenerator(g)
+
+	if err := rg.generateModules(); err != nil {
+		return err
+	}
+
+	return rg.generateWorkspace()
+}
+
+// modules returns the list of modules that Rust crates are generated for, grouped by their source path.
+func (g *rustGenerator) modules() map[string][]module.Module {
+	modules := map[string][]module.Module{
+		g.g.appPath: g.g.appModules,
+	}
+
+	if g.g.o.rustIncludeThirdParty {
+		for sourcePath, m := range g.g.thirdModules {
+			modules[sourcePath] = append(modules[sourcePath], m...)
+		}
+	}
+
+	return modules
+}
+
+func (g *rustGenerator) generateModules() error {
+	for _, plugin := range rustPlugins {
+		if _, err := exec.LookPath(plugin); err != nil {
+			return fmt.Errorf("%s is required to generate Rust code, install it with: cargo install %s", plugin, plugin)
+		}
+	}
+
+	gg := &errgroup.Group{}
+
+	for sourcePath, modules := range g.modules() {
+		for _, m := range modules {
+			sourcePath, m := sourcePath, m
+			gg.Go(func() error { return g.generateModule(g.g.ctx, sourcePath, m) })
+		}
+	}
+
+	return gg.Wait()
+}
+
+// generateModule generates a Rust crate for a module.
+func (g *rustGenerator) generateModule(ctx context.Context, appPath string, m module.Module) error {
+	var (
+		out    = g.g.o.rustOut(m)
+		srcOut = filepath.Join(out, rustSrcDirName)
+	)
+
+	includePaths, err := g.g.resolveInclude(appPath)
+	if err != nil {
+		return err
+	}
+
+	// reset destination dir.
+	if err := os.RemoveAll(out); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(srcOut, 0766); err != nil {
+		return err
+	}
+
+	// generate prost types and tonic grpc clients.
+	if err := protoc.Generate(
+		ctx,
+		srcOut,
+		m.Pkg.Path,
+		includePaths,
+		rustOut,
+		protoc.GenerateDependencies(),
+	); err != nil {
+		return err
+	}
+
+	// generate the crate's entrypoint that exposes generated code under modules named after proto packages.
+	generatedFiles, err := filepath.Glob(filepath.Join(srcOut, "*.rs"))
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, file := range generatedFiles {
+		names = append(names, filepath.Base(file))
+	}
+
+	if err := os.WriteFile(filepath.Join(srcOut, rustLibFileName), rustLib(names), 0644); err != nil {
+		return errors.Wrap(err, "could not create the Rust lib file for module")
+	}
+
+	return templateRustCrate.Write(out, "", struct {
+		Name   string
+		Module module.Module
+	}{
+		Name:   rustCrateName(m),
+		Module: m,
+	})
+}
+
+// generateWorkspace generates a Cargo workspace that includes all generated crates.
+func (g *rustGenerator) generateWorkspace() error {
+	var members []string
+
+	for _, modules := range g.modules() {
+		for _, m := range modules {
+			member, err := filepath.Rel(g.g.o.rustRootPath, g.g.o.rustOut(m))
+			if err != nil {
+				return err
+			}
+			members = append(members, filepath.ToSlash(member))
+		}
+	}
+
+	g.g.addOutput(g.g.o.rustRootPath)
+
+	sort.Strings(members)
+
+	return templateRustWorkspace.Write(g.g.o.rustRootPath, "", struct{ Members []string }{members})
+}
+
+// rustCrateName returns the crate name for a module.
+func rustCrateName(m module.Module) string {
+	return strings.ReplaceAll(m.Pkg.Name, ".", "-")
+}
+
+// rustModule is a node of the Rust module tree made from proto package names.
+type rustModule struct {
+	files    []string
+	children map[string]*rustModule
+}
+
+// rustLib renders a lib.rs that includes generated files for each proto package
+// inside nested modules, e.g. cosmos.bank.v1beta1.rs is included in cosmos::bank::v1beta1.
+func rustLib(files []string) []byte {
+	root := &rustModule{children: make(map[string]*rustModule)}
+
+	for _, file := range files {
+		if file == rustLibFileName {
+			continue
+		}
+
+		pkg := strings.TrimSuffix(strings.TrimSuffix(file, rustTonicFileSuffix), ".rs")
+
+		node := root
+		for _, name := range strings.Split(pkg, ".") {
+			child, ok := node.children[name]
+			if !ok {
+				child = &rustModule{children: make(map[string]*rustModule)}
+				node.children[name] = child
+			}
+			node = child
+		}
+		node.files = append(node.files, file)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.\n\n")
+	root.write(&b, 0)
+
+	return b.Bytes()
+}
+
+func (m *rustModule) write(b *bytes.Buffer, depth int) {
+	indent := strings.Repeat("    ", depth)
+
+	sort.Strings(m.files)
+	for _, file := range m.files {
+		fmt.Fprintf(b, "%sinclude!(%q);\n", indent, file)
+	}
+
+	var names []string
+	for name := range m.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(b, "%spub mod %s {\n", indent, name)
+		m.children[name].write(b, depth+1)
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+}
@@ -0,0 +1,264 @@
+package cosmosgen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/mod/semver"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/tendermint/starport/starport/pkg/cmdrunner"
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/exec"
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+	"github.com/tendermint/starport/starport/pkg/protoc"
+	"github.com/tendermint/starport/starport/pkg/wasmsigner"
+)
+
+const (
+	wasmModulePath      = "signer"
+	wasmSignerDir       = "wasmsigner"
+	wasmDescriptorsFile = "descriptors.bin"
+	wasmFile            = "signer.wasm"
+	wasmExecFile        = "wasm_exec.js"
+	wasmGlobalName      = "starportSigner"
+
+	protobufModule = "google.golang.org/protobuf"
+
+	// protobufVersion is the min version of the protobuf runtime needed by the signer.
+	protobufVersion = "v1.27.1"
+)
+
+var (
+	// wasmTxProtoFiles are the proto files of the SDK needed to create and sign txs.
+	wasmTxProtoFiles = []string{
+		"cosmos/tx/v1beta1/tx.proto",
+		"cosmos/crypto/secp256k1/keys.proto",
+	}
+
+	// wasmExecPaths are the possible paths of wasm_exec.js in GOROOT, it was moved to lib with Go 1.24.
+	wasmExecPaths = []string{
+		"lib/wasm/wasm_exec.js",
+		"misc/wasm/wasm_exec.js",
+	}
+)
+
+// generateWasmSigner builds the WebAssembly signer from the proto files of the modules with msg
+// services and saves it to out with wasm_exec.js of Go and a TypeScript loader.
+// the Go types of the SDK can't be compiled to WebAssembly, so the signer creates the messages from
+// the descriptors of the proto files instead.
+func (g *generator) generateWasmSigner() error {
+	out := filepath.Join(g.appPath, g.o.wasmSignerOut)
+
+	g.addOutput(out)
+
+	// reset destination dir.
+	if err := os.RemoveAll(out); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(out, 0766); err != nil {
+		return err
+	}
+
+	src, err := os.MkdirTemp("", "starport-wasm-signer")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(src)
+
+	if err := g.writeWasmSource(src); err != nil {
+		return err
+	}
+
+	if err := gocmd.BuildPath(g.ctx, out, wasmFile, src, []string{gocmd.FlagMod + "=mod"},
+		exec.StepOption(step.Env(
+			cmdrunner.Env(gocmd.EnvGOOS, "js"),
+			cmdrunner.Env(gocmd.EnvGOARCH, "wasm"),
+		)),
+		exec.IncludeStdLogsToError(),
+	); err != nil {
+		return err
+	}
+
+	if err := copyWasmExec(g.ctx, filepath.Join(out, wasmExecFile)); err != nil {
+		return err
+	}
+
+	return templateWasmLoader.Write(out, "", struct {
+		WasmFile     string
+		WasmExecFile string
+		GlobalName   string
+	}{
+		WasmFile:     wasmFile,
+		WasmExecFile: wasmExecFile,
+		GlobalName:   wasmGlobalName,
+	})
+}
+
+// writeWasmSource writes the source code of the signer's Go module to dir.
+func (g *generator) writeWasmSource(dir string) error {
+	descriptors, err := g.wasmDescriptors()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, wasmDescriptorsFile), descriptors, 0644); err != nil {
+		return err
+	}
+
+	signerSource, err := wasmsigner.Source.ReadFile("signer.go")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, wasmSignerDir), 0766); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, wasmSignerDir, "signer.go"), signerSource, 0644); err != nil {
+		return err
+	}
+
+	gm, err := gomodule.ParseAt(g.appPath)
+	if err != nil {
+		return err
+	}
+
+	goVersion := "1.16"
+	if gm.Go != nil {
+		goVersion = gm.Go.Version
+	}
+
+	// the version used by the app is preferred, so the checksums in the app's go.sum can be used.
+	version := protobufVersion
+	for _, r := range gm.Require {
+		if r.Mod.Path == protobufModule && semver.Compare(r.Mod.Version, version) > 0 {
+			version = r.Mod.Version
+		}
+	}
+
+	sum, err := os.ReadFile(filepath.Join(g.appPath, "go.sum"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0644); err != nil {
+		return err
+	}
+
+	return templateWasmModule.Write(dir, "", struct {
+		ModulePath      string
+		GoVersion       string
+		ProtobufVersion string
+		DescriptorsFile string
+		GlobalName      string
+	}{
+		ModulePath:      wasmModulePath,
+		GoVersion:       goVersion,
+		ProtobufVersion: version,
+		DescriptorsFile: wasmDescriptorsFile,
+		GlobalName:      wasmGlobalName,
+	})
+}
+
+// wasmDescriptors returns the encoded descriptor set of the proto files of the modules with msg
+// services and of the SDK's tx files.
+func (g *generator) wasmDescriptors() ([]byte, error) {
+	var (
+		gg   errgroup.Group
+		mu   sync.Mutex
+		sets [][]byte
+	)
+
+	add := func(ctx context.Context, sourcePath string, files []string) {
+		gg.Go(func() error {
+			includePaths, err := g.resolveInclude(sourcePath)
+			if err != nil {
+				return err
+			}
+			set, err := protoc.DescriptorSet(ctx, files, includePaths)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			sets = append(sets, set)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	add(g.ctx, g.appPath, wasmTxProtoFiles)
+
+	modules := map[string][]module.Module{
+		g.appPath: g.appModules,
+	}
+	for sourcePath, m := range g.thirdModules {
+		modules[sourcePath] = append(modules[sourcePath], m...)
+	}
+
+	for sourcePath, modules := range modules {
+		for _, m := range modules {
+			if _, hasMsg := goClientServices(m); hasMsg {
+				add(g.ctx, sourcePath, m.Pkg.Files.Paths())
+			}
+		}
+	}
+
+	if err := gg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return mergeDescriptorSets(sets...)
+}
+
+// mergeDescriptorSets merges the encoded descriptor sets, the files with the same names are only
+// included once.
+func mergeDescriptorSets(sets ...[]byte) ([]byte, error) {
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	for _, content := range sets {
+		var set descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(content, &set); err != nil {
+			return nil, err
+		}
+		for _, f := range set.File {
+			if _, ok := files[f.GetName()]; !ok {
+				files[f.GetName()] = f
+			}
+		}
+	}
+
+	var merged descriptorpb.FileDescriptorSet
+	for _, f := range files {
+		merged.File = append(merged.File, f)
+	}
+	sort.Slice(merged.File, func(i, j int) bool { return merged.File[i].GetName() < merged.File[j].GetName() })
+
+	return proto.MarshalOptions{Deterministic: true}.Marshal(&merged)
+}
+
+// copyWasmExec copies wasm_exec.js of the installed Go to out, it must be loaded by the page before the
+// signer and match the version of Go that built the signer.
+func copyWasmExec(ctx context.Context, out string) error {
+	goroot, err := gocmd.Env(ctx, gocmd.EnvGOROOT)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range wasmExecPaths {
+		content, err := os.ReadFile(filepath.Join(goroot, path))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(out, content, 0644)
+	}
+
+	return fmt.Errorf("%s is not found in %s", wasmExecFile, goroot)
+}
@@ -8,33 +8,93 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/cmdrunner"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
+	"github.com/tendermint/starport/starport/pkg/gomodule"
 )
 
+// Tool is a protoc plugin that is installed from the Go module of the app.
+type Tool struct {
+	// Module is the path of the Go module that hosts the plugin.
+	Module string
+
+	// Version is the pinned version of the module, it's only used when the app does not require
+	// the module yet, otherwise the version in the app's go.mod is used.
+	Version string
+
+	// Packages are the import paths of the plugin's main packages.
+	Packages []string
+}
+
+// Tools are the protoc plugins needed by Cosmos ecosystem. Scaffolded apps track these tools in their
+// go.mod through a tools.go file, so the same versions are used by everyone generating code for the app.
+var Tools = []Tool{
+	{
+		// the gocosmos plugin.
+		Module:   "github.com/regen-network/cosmos-proto",
+		Version:  "v0.3.1",
+		Packages: []string{"github.com/regen-network/cosmos-proto/protoc-gen-gocosmos"},
+	},
+	{
+		// Go code generation plugin.
+		Module:   "github.com/golang/protobuf",
+		Version:  "v1.5.2",
+		Packages: []string{"github.com/golang/protobuf/protoc-gen-go"},
+	},
+	{
+		// grpc-gateway plugins.
+		Module:  "github.com/grpc-ecosystem/grpc-gateway",
+		Version: "v1.16.0",
+		Packages: []string{
+			"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway",
+			"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger",
+		},
+	},
+	{
+		Module:   "github.com/grpc-ecosystem/grpc-gateway/v2",
+		Version:  "v2.7.2",
+		Packages: []string{"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2"},
+	},
+}
+
 // InstallDependencies installs protoc dependencies needed by Cosmos ecosystem.
+// Tools are installed with the versions required by the app's go.mod, tools that are
+// not required yet are added to go.mod with their pinned versions.
 func InstallDependencies(ctx context.Context, appPath string) error {
+	gm, err := gomodule.ParseAt(appPath)
+	if err != nil {
+		return err
+	}
+
+	required := make(map[string]bool)
+	for _, r := range gm.Require {
+		required[r.Mod.Path] = true
+	}
+
+	var (
+		missing  []string
+		packages []string
+	)
+
+	for _, tool := range Tools {
+		if !required[tool.Module] {
+			missing = append(missing, tool.Module+"@"+tool.Version)
+		}
+		packages = append(packages, tool.Packages...)
+	}
+
+	var steps step.Steps
+
+	if len(missing) > 0 {
+		steps.Add(step.New(step.Exec("go", append([]string{"get"}, missing...)...)))
+	}
+
+	steps.Add(step.New(step.Exec("go", append([]string{"install"}, packages...)...)))
+
 	errb := &bytes.Buffer{}
-	err := cmdrunner.
+	err = cmdrunner.
 		New(
 			cmdrunner.DefaultStderr(errb),
 			cmdrunner.DefaultWorkdir(appPath),
 		).
-		Run(ctx,
-			step.New(
-				step.Exec(
-					"go",
-					"get",
-					// installs the gocosmos plugin.
-					"github.com/regen-network/cosmos-proto/protoc-gen-gocosmos",
-
-					// install Go code generation plugin.
-					"github.com/golang/protobuf/protoc-gen-go",
-
-					// install grpc-gateway plugins.
-					"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway",
-					"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger",
-					"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2",
-				),
-			),
-		)
+		Run(ctx, steps...)
 	return errors.Wrap(err, errb.String())
 }
@@ -0,0 +1,67 @@
+package cosmosgen
+
+import (
+	"strings"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+)
+
+// the names of the events emitted for the types that are streamed, e.g. EventPostCreated.
+const (
+	streamEventPrefix  = "Event"
+	streamEventCreated = "Created"
+	streamEventUpdated = "Updated"
+	streamEventDeleted = "Deleted"
+)
+
+// moduleStream is a type of a module that the events of its creation, update and deletion are
+// emitted for, the changes of the type are streamed over the websocket of Tendermint.
+type moduleStream struct {
+	// Name is the name of the type.
+	Name string
+
+	// Key is the attribute of the created and updated events that holds the type.
+	Key string
+
+	// Created, Updated and Deleted are the names of the events.
+	Created, Updated, Deleted string
+
+	// FilePath is the path of the .proto file where the events are defined at.
+	FilePath string
+}
+
+// moduleStreams returns the streamed types of the module, a type is streamed when the module has
+// its created, updated and deleted events defined in the same file.
+func moduleStreams(m module.Module) []moduleStream {
+	types := make(map[string]string)
+	for _, t := range m.Types {
+		types[t.Name] = t.FilePath
+	}
+
+	var streams []moduleStream
+	for _, t := range m.Types {
+		if !strings.HasPrefix(t.Name, streamEventPrefix) || !strings.HasSuffix(t.Name, streamEventCreated) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(t.Name, streamEventPrefix), streamEventCreated)
+		if name == "" {
+			continue
+		}
+
+		s := moduleStream{
+			Name:     name,
+			Key:      strcase.ToLowerCamel(name),
+			Created:  t.Name,
+			Updated:  streamEventPrefix + name + streamEventUpdated,
+			Deleted:  streamEventPrefix + name + streamEventDeleted,
+			FilePath: t.FilePath,
+		}
+		if types[s.Updated] != s.FilePath || types[s.Deleted] != s.FilePath {
+			continue
+		}
+		streams = append(streams, s)
+	}
+	return streams
+}
@@ -0,0 +1,42 @@
+package cosmosgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+)
+
+func TestModuleStreams(t *testing.T) {
+	m := module.Module{
+		Types: []module.Type{
+			{Name: "BlogPost", FilePath: "proto/blog/blog_post.proto"},
+			{Name: "EventBlogPostCreated", FilePath: "proto/blog/blog_post_events.proto"},
+			{Name: "EventBlogPostUpdated", FilePath: "proto/blog/blog_post_events.proto"},
+			{Name: "EventBlogPostDeleted", FilePath: "proto/blog/blog_post_events.proto"},
+
+			// the events of a type that isn't fully streamed.
+			{Name: "EventCommentCreated", FilePath: "proto/blog/comment_events.proto"},
+			{Name: "EventCommentUpdated", FilePath: "proto/blog/comment_events.proto"},
+
+			// the events of a type defined in different files.
+			{Name: "EventUserCreated", FilePath: "proto/blog/user_events.proto"},
+			{Name: "EventUserUpdated", FilePath: "proto/blog/user_events.proto"},
+			{Name: "EventUserDeleted", FilePath: "proto/blog/events.proto"},
+
+			{Name: "EventCreated", FilePath: "proto/blog/events.proto"},
+		},
+	}
+
+	require.Equal(t, []moduleStream{
+		{
+			Name:     "BlogPost",
+			Key:      "blogPost",
+			Created:  "EventBlogPostCreated",
+			Updated:  "EventBlogPostUpdated",
+			Deleted:  "EventBlogPostDeleted",
+			FilePath: "proto/blog/blog_post_events.proto",
+		},
+	}, moduleStreams(m))
+}
@@ -18,6 +18,17 @@ var (
 	templateJSClient  = newTemplateWriter("js")         // js wrapper client.
 	templateVuexRoot  = newTemplateWriter("vuex/root")  // vuex store loader.
 	templateVuexStore = newTemplateWriter("vuex/store") // vuex store.
+	templateVue       = newTemplateWriter("vue")        // vue composables.
+	templateReact     = newTemplateWriter("react")      // react query hooks.
+
+	templateRustCrate     = newTemplateWriter("rust/crate")     // rust crate manifest.
+	templateRustWorkspace = newTemplateWriter("rust/workspace") // rust workspace manifest.
+
+	templateGoClientRoot   = newTemplateWriter("goclient/root")   // go client module.
+	templateGoClientModule = newTemplateWriter("goclient/module") // go client for a module.
+
+	templateWasmModule = newTemplateWriter("wasm/module") // wasm signer module.
+	templateWasmLoader = newTemplateWriter("wasm/loader") // wasm signer loader.
 
 )
 
@@ -61,6 +72,7 @@ func (t templateWriter) Write(destDir, protoPath string, data interface{}) error
 			return i + 1
 		},
 		"replace": strings.ReplaceAll,
+		"streams": moduleStreams,
 	}
 
 	// render and write the template.
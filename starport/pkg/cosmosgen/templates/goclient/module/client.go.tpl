@@ -0,0 +1,48 @@
+// Package {{ .Package }} provides a typed client for the {{ .Module.Pkg.Name }} module.
+//
+// THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+package {{ .Package }}
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+
+	moduletypes "{{ .TypesImport }}"
+)
+
+// Client gives access to the services of the {{ .Module.Pkg.Name }} module.
+type Client struct {
+{{- if .HasQuery }}
+	// Query is the query service client.
+	Query moduletypes.QueryClient
+{{- end }}
+{{- if .HasMsg }}
+	// Msg is the msg service client.
+	Msg moduletypes.MsgClient
+{{- end }}
+}
+
+// New creates a new client for the module that uses conn to connect to the chain.
+func New(conn gogogrpc.ClientConn) Client {
+	return Client{
+{{- if .HasQuery }}
+		Query: moduletypes.NewQueryClient(conn),
+{{- end }}
+{{- if .HasMsg }}
+		Msg: moduletypes.NewMsgClient(conn),
+{{- end }}
+	}
+}
+
+// RegisterInterfaces registers the interface types of the module, so its messages can be encoded and decoded.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	moduletypes.RegisterInterfaces(registry)
+}
+{{ range .Module.Msgs }}
+// {{ .Name }} is the {{ .URI }} message.
+type {{ .Name }} = moduletypes.{{ .Name }}
+{{ if .Constructor }}
+// {{ .Constructor }} creates a {{ .Name }} message.
+var {{ .Constructor }} = moduletypes.{{ .Constructor }}
+{{ end -}}
+{{ end -}}
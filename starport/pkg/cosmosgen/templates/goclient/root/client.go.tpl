@@ -0,0 +1,35 @@
+// Package client provides typed clients for all modules used by the chain.
+//
+// THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+package client
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+{{ range .Modules }}
+	{{ .Alias }} "{{ .ImportPath }}"
+{{- end }}
+)
+
+// Client gives access to the clients of all modules.
+type Client struct {
+{{- range .Modules }}
+	{{ .FieldName }} {{ .Alias }}.Client
+{{- end }}
+}
+
+// New creates a new client that uses conn to connect to the chain.
+func New(conn gogogrpc.ClientConn) Client {
+	return Client{
+{{- range .Modules }}
+		{{ .FieldName }}: {{ .Alias }}.New(conn),
+{{- end }}
+	}
+}
+
+// RegisterInterfaces registers the interface types of all modules into registry.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+{{- range .Modules }}
+	{{ .Alias }}.RegisterInterfaces(registry)
+{{- end }}
+}
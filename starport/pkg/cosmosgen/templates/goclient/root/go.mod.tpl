@@ -0,0 +1,10 @@
+module {{ .ModulePath }}
+
+go {{ .GoVersion }}
+
+require {{ .AppModulePath }} v0.0.0
+
+replace {{ .AppModulePath }} => {{ .AppRelPath }}
+{{ range .Replaces }}
+replace {{ . }}
+{{- end }}
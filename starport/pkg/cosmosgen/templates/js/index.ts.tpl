@@ -5,6 +5,7 @@ import { SigningStargateClient } from "@cosmjs/stargate";
 import { Registry, OfflineSigner, EncodeObject, DirectSecp256k1HdWallet } from "@cosmjs/proto-signing";
 import { Api } from "./rest";
 {{ range .Module.Msgs }}import { {{ .Name }} } from "./types/{{ resolveFile .FilePath }}";
+{{ end }}{{ range streams .Module }}import { {{ .Created }}, {{ .Updated }}, {{ .Deleted }} } from "./types/{{ resolveFile .FilePath }}";
 {{ end }}
 
 const types = [
@@ -54,7 +55,70 @@ const queryClient = async ({ addr: addr }: QueryClientOptions = { addr: "http://
   return new Api({ baseUrl: addr });
 };
 
+interface StreamOptions {
+  addr: string
+}
+
+export interface StreamHandlers<Created, Updated, Deleted> {
+  onCreated?: (event: Created) => void
+  onUpdated?: (event: Updated) => void
+  onDeleted?: (event: Deleted) => void
+  onError?: (error: Error) => void
+}
+
+interface Subscription {
+  eventType: string
+  key: string
+  handle: (attributes: Record<string, any>) => void
+}
+
+// subscribe subscribes to the events of the txs over the websocket of the Tendermint RPC at addr, each
+// subscription is called with the attributes of its events. the returned func closes the subscriptions.
+const subscribe = (addr: string, subscriptions: Subscription[], onError?: (error: Error) => void): (() => void) => {
+  const ws = new WebSocket(addr.replace(/^http/, "ws") + "/websocket");
+
+  ws.onopen = () => {
+    subscriptions.forEach(({ eventType, key }, id) => {
+      const query = `tm.event='Tx' AND ${eventType}.${key} EXISTS`;
+      ws.send(JSON.stringify({ jsonrpc: "2.0", method: "subscribe", id, params: { query } }));
+    });
+  };
+  ws.onerror = () => onError && onError(new Error(`cannot subscribe to the events of ${addr}`));
+  ws.onmessage = (message: MessageEvent) => {
+    const { id, result, error } = JSON.parse(message.data);
+    if (error) {
+      onError && onError(new Error(error.data || error.message));
+      return;
+    }
+    const subscription = subscriptions[id];
+    if (!subscription || !result || !result.events) return;
+
+    // the events of the tx are grouped by attribute, e.g. {"mars.blog.EventPostCreated.post": ["{...}"]}.
+    const prefix = subscription.eventType + ".";
+    const events: Record<string, any>[] = [];
+    Object.keys(result.events).filter((key) => key.indexOf(prefix) === 0).forEach((key) => {
+      result.events[key].forEach((value: string, i: number) => {
+        events[i] = events[i] || {};
+        events[i][key.slice(prefix.length)] = JSON.parse(value);
+      });
+    });
+    events.forEach((attributes) => subscription.handle(attributes));
+  };
+
+  return () => ws.close();
+};
+{{ range streams .Module }}
+const subscribe{{ .Name }} = (handlers: StreamHandlers<{{ .Created }}, {{ .Updated }}, {{ .Deleted }}>, { addr: addr }: StreamOptions = { addr: "http://localhost:26657" }) => {
+  return subscribe(addr, [
+    { eventType: "{{ $.Module.Pkg.Name }}.{{ .Created }}", key: "{{ .Key }}", handle: (attributes) => handlers.onCreated && handlers.onCreated({{ .Created }}.fromJSON(attributes)) },
+    { eventType: "{{ $.Module.Pkg.Name }}.{{ .Updated }}", key: "{{ .Key }}", handle: (attributes) => handlers.onUpdated && handlers.onUpdated({{ .Updated }}.fromJSON(attributes)) },
+    { eventType: "{{ $.Module.Pkg.Name }}.{{ .Deleted }}", key: "id", handle: (attributes) => handlers.onDeleted && handlers.onDeleted({{ .Deleted }}.fromJSON(attributes)) },
+  ], handlers.onError);
+};
+{{ end }}
 export {
   txClient,
   queryClient,
+  {{ range streams .Module }}subscribe{{ .Name }},
+  {{ end }}
 };
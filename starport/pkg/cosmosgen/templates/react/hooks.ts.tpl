@@ -0,0 +1,115 @@
+// THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+
+// @ts-ignore
+import { useEffect, useRef } from 'react'
+// @ts-ignore
+import { useMutation, useQuery, useQueryClient } from 'react-query'
+import { OfflineSigner } from '@cosmjs/proto-signing'
+import { Api } from './module/rest'
+import { txClient, queryClient, StreamHandlers{{ range streams .Module }}, subscribe{{ .Name }}{{ end }} } from './module'
+{{ range .Module.Msgs }}import { {{ .Name }} } from './module/types/{{ resolveFile .FilePath }}'
+{{ end }}{{ range streams .Module }}import { {{ .Created }}, {{ .Updated }}, {{ .Deleted }} } from './module/types/{{ resolveFile .FilePath }}'
+{{ end }}
+type QueryMethod = (...args: any[]) => Promise<{ data: any }>
+
+type QueryData<F extends QueryMethod> = ReturnType<F> extends Promise<{ data: infer D }> ? D : never
+
+export interface ClientOptions {
+	apiURL?: string
+	rpcURL?: string
+}
+
+export interface QueryOptions extends ClientOptions {
+	// query configures the underlying react-query query, e.g. enabled, staleTime or refetchInterval.
+	query?: Record<string, any>
+}
+
+export interface TxVariables<T> {
+	value: T
+	fee?: { amount: { denom: string, amount: string }[], gas: string }
+	memo?: string
+}
+
+// queryKey is the root of the query keys of the module, it can be used to invalidate all module queries.
+export const queryKey = '{{ .Module.Pkg.Name }}'
+
+const defaultClientOptions = {
+	apiURL: 'http://localhost:1317',
+	rpcURL: 'http://localhost:26657'
+}
+
+const defaultFee = {
+	amount: [],
+	gas: '200000'
+}
+
+function useModuleQuery<F extends QueryMethod>(method: string, args: Parameters<F>, options: QueryOptions = {}) {
+	const { apiURL } = { ...defaultClientOptions, ...options }
+
+	return useQuery(
+		[queryKey, method, ...args],
+		async (): Promise<QueryData<F>> => {
+			const client = await queryClient({ addr: apiURL })
+			const { data } = await client[method](...args)
+			return data
+		},
+		options.query
+	)
+}
+{{ range .Module.HTTPQueries }}{{ $FullName := .FullName }}{{ range $i, $rule := .Rules }}{{ $n := "" }}{{ if (gt $i 0) }}{{ $n = inc $i }}{{ end }}
+export function use{{ $FullName }}{{ $n }}(args: Parameters<Api<unknown>['{{ camelCaseSta $FullName }}{{ $n }}']>, options?: QueryOptions) {
+	return useModuleQuery<Api<unknown>['{{ camelCaseSta $FullName }}{{ $n }}']>('{{ camelCaseSta $FullName }}{{ $n }}', args, options)
+}
+{{ end }}{{ end }}
+function useTxMutation<T>(signer: OfflineSigner, createMsg: (client: any, value: T) => any, options: ClientOptions = {}) {
+	const { rpcURL } = { ...defaultClientOptions, ...options }
+	const client = useQueryClient()
+
+	return useMutation(
+		async ({ value, fee = defaultFee, memo = '' }: TxVariables<T>) => {
+			const tx = await txClient(signer, { addr: rpcURL })
+			return tx.signAndBroadcast([createMsg(tx, value)], { fee, memo })
+		},
+		{
+			// the state of the module might be changed by the tx, so its queries are refetched.
+			onSuccess: () => client.invalidateQueries(queryKey)
+		}
+	)
+}
+{{ range .Module.Msgs }}
+export function useSend{{ .Name }}(signer: OfflineSigner, options?: ClientOptions) {
+	return useTxMutation<{{ .Name }}>(signer, (tx, value) => tx.{{ camelCase .Name }}(value), options)
+}
+{{ end }}
+{{ range streams .Module }}
+// use{{ .Name }}Stream subscribes to the events of {{ .Name }} while the component is mounted, the queries of the
+// module are refetched on each event so they show the live state of the chain without polling.
+export function use{{ .Name }}Stream(handlers: StreamHandlers<{{ .Created }}, {{ .Updated }}, {{ .Deleted }}> = {}, options: ClientOptions = {}) {
+	const { rpcURL } = { ...defaultClientOptions, ...options }
+	const client = useQueryClient()
+	const handlersRef = useRef(handlers)
+	handlersRef.current = handlers
+
+	useEffect(() => {
+		const refetch = () => client.invalidateQueries(queryKey)
+		return subscribe{{ .Name }}(
+			{
+				onCreated: (event) => {
+					refetch()
+					handlersRef.current.onCreated && handlersRef.current.onCreated(event)
+				},
+				onUpdated: (event) => {
+					refetch()
+					handlersRef.current.onUpdated && handlersRef.current.onUpdated(event)
+				},
+				onDeleted: (event) => {
+					refetch()
+					handlersRef.current.onDeleted && handlersRef.current.onDeleted(event)
+				},
+				onError: (error) => handlersRef.current.onError && handlersRef.current.onError(error)
+			},
+			{ addr: rpcURL }
+		)
+	}, [client, rpcURL])
+}
+{{ end }}
\ No newline at end of file
@@ -0,0 +1,21 @@
+{
+  "name": "{{ replace .Module.Pkg.Name "." "-" }}-react",
+  "version": "0.1.0",
+  "description": "Autogenerated React Query hooks for Cosmos module {{ .Module.Pkg.Name }}",
+  "author": "Starport Codegen <hello@tendermint.com>",
+  "homepage": "http://{{ .Module.Pkg.GoImportName }}",
+  "license": "Apache-2.0",
+  "licenses": [
+    {
+      "type": "Apache-2.0",
+      "url": "http://www.apache.org/licenses/LICENSE-2.0"
+    }
+  ],
+  "main": "hooks.js",
+  "module": "hooks.js",
+  "types": "hooks.d.ts",
+  "sideEffects": false,
+  "publishConfig": {
+    "access": "public"
+  }
+}
\ No newline at end of file
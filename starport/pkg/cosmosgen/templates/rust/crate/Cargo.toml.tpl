@@ -0,0 +1,14 @@
+# THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+
+[package]
+name = "{{ .Name }}"
+version = "0.1.0"
+description = "Autogenerated Rust client for the {{ .Module.Pkg.Name }} module"
+authors = ["Starport Codegen <hello@tendermint.com>"]
+license = "Apache-2.0"
+edition = "2018"
+
+[dependencies]
+prost = "0.9"
+prost-types = "0.9"
+tonic = "0.6"
@@ -0,0 +1,6 @@
+# THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+
+[workspace]
+members = [
+{{ range .Members }}  "{{ . }}",
+{{ end }}]
@@ -0,0 +1,156 @@
+// THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+
+// @ts-ignore
+import { computed, onUnmounted, reactive, ref, unref, watch } from 'vue'
+import { OfflineSigner } from '@cosmjs/proto-signing'
+import { Api } from './module/rest'
+import { txClient, queryClient, StreamHandlers{{ range streams .Module }}, subscribe{{ .Name }}{{ end }} } from './module'
+{{ range .Module.Msgs }}import { {{ .Name }} } from './module/types/{{ resolveFile .FilePath }}'
+{{ end }}{{ range streams .Module }}import { {{ .Created }}, {{ .Updated }}, {{ .Deleted }} } from './module/types/{{ resolveFile .FilePath }}'
+{{ end }}
+type MaybeRef<T> = T | { value: T }
+
+type QueryMethod = (...args: any[]) => Promise<{ data: any }>
+
+type QueryData<F extends QueryMethod> = ReturnType<F> extends Promise<{ data: infer D }> ? D : never
+
+export interface ClientOptions {
+	apiURL?: string
+	rpcURL?: string
+}
+
+export interface QueryOptions extends ClientOptions {
+	enabled?: MaybeRef<boolean>
+}
+
+export interface TxOptions {
+	fee?: { amount: { denom: string, amount: string }[], gas: string }
+	memo?: string
+}
+
+const defaultClientOptions = {
+	apiURL: 'http://localhost:1317',
+	rpcURL: 'http://localhost:26657'
+}
+
+const defaultFee = {
+	amount: [],
+	gas: '200000'
+}
+
+// cache keeps the results of queries by their method and arguments, so components using the same query share the result.
+const cache = reactive({} as Record<string, any>)
+
+// refetchers keeps the functions to refresh active queries after a tx is broadcasted.
+const refetchers = new Map<string, () => Promise<void>>()
+
+function useQuery<F extends QueryMethod>(method: string, args: MaybeRef<Parameters<F>>, options: QueryOptions = {}) {
+	const { apiURL } = { ...defaultClientOptions, ...options }
+	const key = computed(() => JSON.stringify(['{{ .Module.Pkg.Name }}', method, unref(args)]))
+	const isLoading = ref(false)
+	const error = ref(undefined as Error | undefined)
+
+	const refetch = async () => {
+		const currentKey = key.value
+		isLoading.value = true
+		error.value = undefined
+		try {
+			const client = await queryClient({ addr: apiURL })
+			const { data } = await client[method](...unref(args))
+			cache[currentKey] = data
+		} catch (e) {
+			error.value = e
+		} finally {
+			isLoading.value = false
+		}
+	}
+
+	watch(
+		[key, () => unref(options.enabled) ?? true],
+		([currentKey, enabled], [previousKey] = []) => {
+			if (previousKey) refetchers.delete(previousKey)
+			if (!enabled) return
+			refetchers.set(currentKey, refetch)
+			if (!(currentKey in cache)) refetch()
+		},
+		{ immediate: true }
+	)
+
+	return {
+		data: computed(() => cache[key.value] as QueryData<F> | undefined),
+		isLoading,
+		error,
+		refetch
+	}
+}
+
+// invalidate refreshes all the active queries of the module.
+export async function invalidate() {
+	await Promise.all([...refetchers.values()].map((refetch) => refetch()))
+}
+{{ range .Module.HTTPQueries }}{{ $FullName := .FullName }}{{ range $i, $rule := .Rules }}{{ $n := "" }}{{ if (gt $i 0) }}{{ $n = inc $i }}{{ end }}
+export function use{{ $FullName }}{{ $n }}(args: MaybeRef<Parameters<Api<unknown>['{{ camelCaseSta $FullName }}{{ $n }}']>>, options?: QueryOptions) {
+	return useQuery<Api<unknown>['{{ camelCaseSta $FullName }}{{ $n }}']>('{{ camelCaseSta $FullName }}{{ $n }}', args, options)
+}
+{{ end }}{{ end }}
+export function useTx(signer: MaybeRef<OfflineSigner>, options: ClientOptions = {}) {
+	const { rpcURL } = { ...defaultClientOptions, ...options }
+	const isSending = ref(false)
+	const error = ref(undefined as Error | undefined)
+
+	const send = async (createMsg: (client: any) => any, { fee = defaultFee, memo = '' }: TxOptions = {}) => {
+		isSending.value = true
+		error.value = undefined
+		try {
+			const client = await txClient(unref(signer), { addr: rpcURL })
+			const result = await client.signAndBroadcast([createMsg(client)], { fee, memo })
+			await invalidate()
+			return result
+		} catch (e) {
+			error.value = e
+			throw e
+		} finally {
+			isSending.value = false
+		}
+	}
+
+	return {
+		isSending,
+		error,
+		{{ range .Module.Msgs }}send{{ .Name }}: (value: {{ .Name }}, options?: TxOptions) => send((client) => client.{{ camelCase .Name }}(value), options),
+		{{ end }}
+	}
+}
+{{ range streams .Module }}
+// use{{ .Name }}Stream subscribes to the events of {{ .Name }} until the component is unmounted, the active queries
+// of the module are refreshed on each event so they show the live state of the chain without polling.
+export function use{{ .Name }}Stream(handlers: StreamHandlers<{{ .Created }}, {{ .Updated }}, {{ .Deleted }}> = {}, options: ClientOptions = {}) {
+	const { rpcURL } = { ...defaultClientOptions, ...options }
+	const error = ref(undefined as Error | undefined)
+
+	const unsubscribe = subscribe{{ .Name }}(
+		{
+			onCreated: (event) => {
+				invalidate()
+				handlers.onCreated && handlers.onCreated(event)
+			},
+			onUpdated: (event) => {
+				invalidate()
+				handlers.onUpdated && handlers.onUpdated(event)
+			},
+			onDeleted: (event) => {
+				invalidate()
+				handlers.onDeleted && handlers.onDeleted(event)
+			},
+			onError: (e) => {
+				error.value = e
+				handlers.onError && handlers.onError(e)
+			}
+		},
+		{ addr: rpcURL }
+	)
+	onUnmounted(unsubscribe)
+
+	return { error, unsubscribe }
+}
+{{ end }}
\ No newline at end of file
@@ -11,6 +11,14 @@
     }
   ],
   "main": "index.js",
+  "module": "index.js",
+  "types": "index.d.ts",
+  "sideEffects": false,
+  "exports": {
+    ".": "./index.js"{{ range .Modules }},
+    "./{{ .FullPath }}": "./{{ .FullPath }}/index.js"{{ end }},
+    "./*": "./*"
+  },
   "publishConfig": {
     "access": "public"
   }
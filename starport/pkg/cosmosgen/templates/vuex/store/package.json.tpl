@@ -12,6 +12,9 @@
     }
   ],
   "main": "index.js",
+  "module": "index.js",
+  "types": "index.d.ts",
+  "sideEffects": false,
   "publishConfig": {
     "access": "public"
   }
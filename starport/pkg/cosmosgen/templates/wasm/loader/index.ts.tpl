@@ -0,0 +1,93 @@
+// THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+
+import './{{ .WasmExecFile }}'
+
+export type SignMode = 'direct' | 'amino'
+
+export interface Coin {
+  denom: string
+  amount: string
+}
+
+export interface Fee {
+  amount: Coin[]
+  gas: string
+  payer?: string
+  granter?: string
+}
+
+// Msg is a message in the JSON format of proto with its type URL,
+// e.g. { '@type': '/cosmos.bank.v1beta1.MsgSend', fromAddress: '...' }.
+export interface Msg {
+  '@type': string
+  [field: string]: unknown
+}
+
+export interface SignDoc {
+  // mode is 'direct' by default.
+  mode?: SignMode
+  chainId: string
+  accountNumber: string
+  sequence: string
+  // pubKey is the compressed secp256k1 public key of the signer.
+  pubKey: Uint8Array
+  msgs: Msg[]
+  fee: Fee
+  memo?: string
+  // aminoTypes are the Amino names of the messages by their type URLs, only needed for the 'amino' mode.
+  aminoTypes?: Record<string, string>
+}
+
+interface Result {
+  result?: string
+  error?: string
+}
+
+interface WasmSigner {
+  signBytes(doc: string): Result
+  encodeTx(doc: string, signature: string): Result
+}
+
+declare const Go: any
+
+let signer: Promise<WasmSigner> | undefined
+
+// load loads the signer once, {{ .WasmFile }} is loaded from next to this file by default.
+export function load(url: string | URL = new URL('./{{ .WasmFile }}', import.meta.url)): Promise<WasmSigner> {
+  if (!signer) {
+    signer = (async () => {
+      const go = new Go()
+      const { instance } = await WebAssembly.instantiateStreaming(fetch(url.toString()), go.importObject)
+      go.run(instance)
+      return (globalThis as any).{{ .GlobalName }} as WasmSigner
+    })()
+  }
+  return signer
+}
+
+// signBytes returns the bytes to sign for the tx described by doc.
+export async function signBytes(doc: SignDoc): Promise<Uint8Array> {
+  const s = await load()
+  return decodeResult(s.signBytes(encodeDoc(doc)))
+}
+
+// encodeTx returns the encoded tx described by doc with the signature of its sign bytes, ready to broadcast.
+export async function encodeTx(doc: SignDoc, signature: Uint8Array): Promise<Uint8Array> {
+  const s = await load()
+  return decodeResult(s.encodeTx(encodeDoc(doc), toBase64(signature)))
+}
+
+function encodeDoc(doc: SignDoc): string {
+  return JSON.stringify({ ...doc, pubKey: toBase64(doc.pubKey) })
+}
+
+function decodeResult(r: Result): Uint8Array {
+  if (r.error) {
+    throw new Error(r.error)
+  }
+  return Uint8Array.from(atob(r.result ?? ''), (c) => c.charCodeAt(0))
+}
+
+function toBase64(bytes: Uint8Array): string {
+  return btoa(String.fromCharCode(...Array.from(bytes)))
+}
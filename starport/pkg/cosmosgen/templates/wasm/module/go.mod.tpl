@@ -0,0 +1,5 @@
+module {{ .ModulePath }}
+
+go {{ .GoVersion }}
+
+require google.golang.org/protobuf {{ .ProtobufVersion }}
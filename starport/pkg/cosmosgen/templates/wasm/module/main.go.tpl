@@ -0,0 +1,74 @@
+// Command signer is a WebAssembly helper that signs the txs of the chain in browsers, it's built
+// from the proto files of the chain so its custom messages can be signed too.
+//
+// THIS FILE IS GENERATED AUTOMATICALLY. DO NOT MODIFY.
+package main
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"syscall/js"
+
+	"{{ .ModulePath }}/wasmsigner"
+)
+
+//go:embed {{ .DescriptorsFile }}
+var descriptors []byte
+
+func main() {
+	s, err := wasmsigner.New(descriptors)
+	if err != nil {
+		panic(err)
+	}
+
+	js.Global().Set("{{ .GlobalName }}", js.ValueOf(map[string]interface{}{
+		"signBytes": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			return result(func() ([]byte, error) {
+				doc, err := decodeDoc(args)
+				if err != nil {
+					return nil, err
+				}
+				return s.SignBytes(doc)
+			})
+		}),
+		"encodeTx": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			return result(func() ([]byte, error) {
+				doc, err := decodeDoc(args)
+				if err != nil {
+					return nil, err
+				}
+				if len(args) < 2 {
+					return nil, errors.New("signature is missing")
+				}
+				signature, err := base64.StdEncoding.DecodeString(args[1].String())
+				if err != nil {
+					return nil, err
+				}
+				return s.EncodeTx(doc, signature)
+			})
+		}),
+	}))
+
+	// keep the functions available for the page.
+	select {}
+}
+
+// result returns the bytes returned by fn in base64 as {result: ...} or its error as {error: ...}.
+func result(fn func() ([]byte, error)) interface{} {
+	b, err := fn()
+	if err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+	return map[string]interface{}{"result": base64.StdEncoding.EncodeToString(b)}
+}
+
+// decodeDoc decodes the doc from the JSON in the first arg.
+func decodeDoc(args []js.Value) (doc wasmsigner.Doc, err error) {
+	if len(args) < 1 {
+		return doc, errors.New("doc is missing")
+	}
+	err = json.Unmarshal([]byte(args[0].String()), &doc)
+	return doc, err
+}
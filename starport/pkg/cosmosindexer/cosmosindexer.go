@@ -0,0 +1,234 @@
+// Package cosmosindexer indexes the blocks of a chain with their txs and events in a database, so
+// the data of the chain can be queried from the database while the chain runs.
+package cosmosindexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// defaultPollInterval is the default interval between the checks for new blocks.
+const defaultPollInterval = 500 * time.Millisecond
+
+// Block is an indexed block.
+type Block struct {
+	Height   int64
+	Hash     string
+	Time     time.Time
+	Proposer string
+	Txs      []Tx
+
+	// BeginBlockEvents and EndBlockEvents are the events emitted by the modules of the chain at
+	// the beginning and at the end of the block.
+	BeginBlockEvents []Event
+	EndBlockEvents   []Event
+}
+
+// Tx is an indexed tx of a block.
+type Tx struct {
+	Hash      string
+	Index     int
+	Code      uint32
+	Log       string
+	GasWanted int64
+	GasUsed   int64
+	Raw       []byte
+	Events    []Event
+}
+
+// Event is an event emitted by a module, e.g. the typed events of the scaffolded modules are named
+// after their proto message and have its fields as attributes.
+type Event struct {
+	Type       string
+	Attributes map[string]string
+}
+
+// Node is the Tendermint RPC of the node that the blocks are indexed from.
+type Node interface {
+	Status(ctx context.Context) (*ctypes.ResultStatus, error)
+	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+}
+
+// Sink stores the indexed blocks.
+type Sink interface {
+	// LastBlock returns the height and the hash of the last indexed block, the height is 0 when no
+	// block is indexed.
+	LastBlock(ctx context.Context) (height int64, hash string, err error)
+
+	// Index stores the block with its txs and events.
+	Index(ctx context.Context, block Block) error
+
+	// Reset removes all the indexed blocks.
+	Reset(ctx context.Context) error
+}
+
+// Indexer indexes the blocks of a node in a sink.
+type Indexer struct {
+	node         Node
+	sink         Sink
+	pollInterval time.Duration
+}
+
+// Option configures the indexer.
+type Option func(*Indexer)
+
+// WithPollInterval sets the interval between the checks for new blocks.
+func WithPollInterval(d time.Duration) Option {
+	return func(i *Indexer) {
+		i.pollInterval = d
+	}
+}
+
+// New creates an indexer of the blocks of the node in the sink.
+func New(node Node, sink Sink, options ...Option) Indexer {
+	i := Indexer{
+		node:         node,
+		sink:         sink,
+		pollInterval: defaultPollInterval,
+	}
+	for _, apply := range options {
+		apply(&i)
+	}
+	return i
+}
+
+// nodeError is an error of the node, the node is unreachable while it restarts so the blocks are
+// indexed again once it's back.
+type nodeError struct {
+	err error
+}
+
+func (e nodeError) Error() string { return e.err.Error() }
+func (e nodeError) Unwrap() error { return e.err }
+
+// Run indexes the blocks of the node until ctx is canceled or the sink fails, the blocks produced
+// while the node is unreachable are indexed once it's back.
+// the indexed blocks are reset when the chain of the node doesn't have the last indexed block
+// anymore, e.g. when the state of the chain is reset.
+func (i Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+
+	verified := false
+	for {
+		var nerr nodeError
+		switch err := i.sync(ctx, !verified); {
+		case err == nil:
+			verified = true
+		case errors.As(err, &nerr):
+			verified = false
+		default:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync indexes the blocks of the node after the last indexed block, verify checks that the last
+// indexed block is a block of the chain of the node first.
+func (i Indexer) sync(ctx context.Context, verify bool) error {
+	status, err := i.node.Status(ctx)
+	if err != nil {
+		return nodeError{err}
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+
+	height, hash, err := i.sink.LastBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	if height > 0 && (verify || height > latest) {
+		reset := height > latest
+		if !reset {
+			block, err := i.node.Block(ctx, &height)
+			if err != nil {
+				return nodeError{err}
+			}
+			reset = block.BlockID.Hash.String() != hash
+		}
+		if reset {
+			if err := i.sink.Reset(ctx); err != nil {
+				return err
+			}
+			height = 0
+		}
+	}
+
+	for height < latest {
+		height++
+
+		block, err := i.fetch(ctx, height)
+		if err != nil {
+			return nodeError{err}
+		}
+		if err := i.sink.Index(ctx, block); err != nil {
+			return fmt.Errorf("cannot index block %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+// fetch fetches the block at the height with the results of its txs.
+func (i Indexer) fetch(ctx context.Context, height int64) (Block, error) {
+	b, err := i.node.Block(ctx, &height)
+	if err != nil {
+		return Block{}, err
+	}
+	results, err := i.node.BlockResults(ctx, &height)
+	if err != nil {
+		return Block{}, err
+	}
+
+	block := Block{
+		Height:           height,
+		Hash:             b.BlockID.Hash.String(),
+		Time:             b.Block.Time,
+		Proposer:         b.Block.ProposerAddress.String(),
+		BeginBlockEvents: toEvents(results.BeginBlockEvents),
+		EndBlockEvents:   toEvents(results.EndBlockEvents),
+	}
+	for n, raw := range b.Block.Txs {
+		tx := Tx{
+			Hash:  fmt.Sprintf("%X", raw.Hash()),
+			Index: n,
+			Raw:   raw,
+		}
+		if n < len(results.TxsResults) {
+			result := results.TxsResults[n]
+			tx.Code = result.Code
+			tx.Log = result.Log
+			tx.GasWanted = result.GasWanted
+			tx.GasUsed = result.GasUsed
+			tx.Events = toEvents(result.Events)
+		}
+		block.Txs = append(block.Txs, tx)
+	}
+	return block, nil
+}
+
+func toEvents(events []abci.Event) []Event {
+	var list []Event
+	for _, e := range events {
+		event := Event{
+			Type:       e.Type,
+			Attributes: make(map[string]string),
+		}
+		for _, attr := range e.Attributes {
+			event.Attributes[string(attr.Key)] = string(attr.Value)
+		}
+		list = append(list, event)
+	}
+	return list
+}
@@ -0,0 +1,187 @@
+package cosmosindexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// node is a chain with a block per height, the hashes of its blocks change with its generation.
+type node struct {
+	height     int64
+	generation int
+	down       bool
+}
+
+func (n *node) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	if n.down {
+		return nil, errors.New("connection refused")
+	}
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: n.height}}, nil
+}
+
+func (n *node) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	if n.down || *height > n.height {
+		return nil, errors.New("no block")
+	}
+	return &ctypes.ResultBlock{
+		BlockID: tmtypes.BlockID{Hash: []byte(fmt.Sprintf("%d-%d", n.generation, *height))},
+		Block: &tmtypes.Block{
+			Header: tmtypes.Header{Height: *height, Time: time.Unix(*height, 0)},
+			Data:   tmtypes.Data{Txs: tmtypes.Txs{[]byte("tx")}},
+		},
+	}, nil
+}
+
+func (n *node) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	return &ctypes.ResultBlockResults{
+		TxsResults: []*abci.ResponseDeliverTx{{
+			GasUsed: 10,
+			Events: []abci.Event{{
+				Type:       "venus.blog.EventPostCreated",
+				Attributes: []abci.EventAttribute{{Key: []byte("id"), Value: []byte(`"1"`)}},
+			}},
+		}},
+		EndBlockEvents: []abci.Event{{Type: "end"}},
+	}, nil
+}
+
+type sink struct {
+	blocks []Block
+	resets int
+	err    error
+}
+
+func (s *sink) LastBlock(ctx context.Context) (int64, string, error) {
+	if len(s.blocks) == 0 {
+		return 0, "", nil
+	}
+	last := s.blocks[len(s.blocks)-1]
+	return last.Height, last.Hash, nil
+}
+
+func (s *sink) Index(ctx context.Context, block Block) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.blocks = append(s.blocks, block)
+	return nil
+}
+
+func (s *sink) Reset(ctx context.Context) error {
+	s.blocks = nil
+	s.resets++
+	return nil
+}
+
+func heights(blocks []Block) []int64 {
+	var list []int64
+	for _, b := range blocks {
+		list = append(list, b.Height)
+	}
+	return list
+}
+
+func TestIndexerSync(t *testing.T) {
+	var (
+		ctx = context.Background()
+		n   = &node{height: 2}
+		s   = &sink{}
+		i   = New(n, s)
+	)
+
+	require.NoError(t, i.sync(ctx, true))
+	require.Equal(t, []int64{1, 2}, heights(s.blocks))
+
+	block := s.blocks[0]
+	require.Equal(t, time.Unix(1, 0), block.Time)
+	require.Len(t, block.Txs, 1)
+	require.Equal(t, fmt.Sprintf("%X", tmtypes.Tx("tx").Hash()), block.Txs[0].Hash)
+	require.Equal(t, int64(10), block.Txs[0].GasUsed)
+	require.Equal(t, []Event{{
+		Type:       "venus.blog.EventPostCreated",
+		Attributes: map[string]string{"id": `"1"`},
+	}}, block.Txs[0].Events)
+	require.Equal(t, []Event{{Type: "end", Attributes: map[string]string{}}}, block.EndBlockEvents)
+
+	// the next blocks are indexed after the last indexed one.
+	n.height = 4
+	require.NoError(t, i.sync(ctx, false))
+	require.Equal(t, []int64{1, 2, 3, 4}, heights(s.blocks))
+	require.Zero(t, s.resets)
+}
+
+func TestIndexerSyncNodeDown(t *testing.T) {
+	var (
+		ctx = context.Background()
+		n   = &node{height: 1, down: true}
+		s   = &sink{}
+		i   = New(n, s)
+	)
+
+	var nerr nodeError
+	require.True(t, errors.As(i.sync(ctx, true), &nerr))
+	require.Empty(t, s.blocks)
+
+	n.down = false
+	require.NoError(t, i.sync(ctx, true))
+	require.Equal(t, []int64{1}, heights(s.blocks))
+}
+
+func TestIndexerSyncReset(t *testing.T) {
+	var (
+		ctx = context.Background()
+		n   = &node{height: 3}
+		s   = &sink{}
+		i   = New(n, s)
+	)
+	require.NoError(t, i.sync(ctx, true))
+
+	// the state of the chain is reset and the blocks are produced again from the first one.
+	n.generation++
+	require.NoError(t, i.sync(ctx, true))
+	require.Equal(t, 1, s.resets)
+	require.Equal(t, []int64{1, 2, 3}, heights(s.blocks))
+	require.Equal(t, tmtypes.BlockID{Hash: []byte("1-1")}.Hash.String(), s.blocks[0].Hash)
+
+	// the chain has fewer blocks than the indexed ones.
+	n.height = 1
+	require.NoError(t, i.sync(ctx, false))
+	require.Equal(t, 2, s.resets)
+	require.Equal(t, []int64{1}, heights(s.blocks))
+}
+
+func TestIndexerRun(t *testing.T) {
+	var (
+		n = &node{height: 2, down: true}
+		s = &sink{}
+		i = New(n, s, WithPollInterval(time.Millisecond))
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- i.Run(ctx) }()
+
+	// the node errors are retried until it's reachable.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	require.Empty(t, s.blocks)
+}
+
+func TestIndexerRunSinkError(t *testing.T) {
+	var (
+		n = &node{height: 1}
+		s = &sink{err: errors.New("database is down")}
+		i = New(n, s, WithPollInterval(time.Millisecond))
+	)
+
+	require.EqualError(t, i.Run(context.Background()), "cannot index block 1: database is down")
+}
@@ -0,0 +1,159 @@
+package cosmosindexer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	// registers the postgres driver.
+	_ "github.com/lib/pq"
+)
+
+// schema creates the tables of the indexed blocks, the txs and the events of a block are removed
+// with the block.
+// the events emitted at the beginning and at the end of a block have no tx index.
+const schema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	height   BIGINT PRIMARY KEY,
+	hash     TEXT NOT NULL,
+	time     TIMESTAMPTZ NOT NULL,
+	proposer TEXT NOT NULL,
+	num_txs  INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS txs (
+	height     BIGINT NOT NULL REFERENCES blocks (height) ON DELETE CASCADE,
+	tx_index   INTEGER NOT NULL,
+	hash       TEXT NOT NULL,
+	code       BIGINT NOT NULL,
+	log        TEXT NOT NULL,
+	gas_wanted BIGINT NOT NULL,
+	gas_used   BIGINT NOT NULL,
+	raw        BYTEA NOT NULL,
+	PRIMARY KEY (height, tx_index)
+);
+
+CREATE INDEX IF NOT EXISTS txs_hash ON txs (hash);
+
+CREATE TABLE IF NOT EXISTS events (
+	height      BIGINT NOT NULL REFERENCES blocks (height) ON DELETE CASCADE,
+	event_index INTEGER NOT NULL,
+	stage       TEXT NOT NULL,
+	tx_index    INTEGER,
+	type        TEXT NOT NULL,
+	attributes  JSONB NOT NULL,
+	PRIMARY KEY (height, event_index)
+);
+
+CREATE INDEX IF NOT EXISTS events_type ON events (type);
+`
+
+// Stages of the events of a block.
+const (
+	StageBeginBlock = "begin_block"
+	StageTx         = "tx"
+	StageEndBlock   = "end_block"
+)
+
+// Postgres is a sink that stores the indexed blocks in a PostgreSQL database.
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres connects to the PostgreSQL database with the URL and creates the tables of the
+// indexed blocks when they don't exist.
+func NewPostgres(ctx context.Context, url string) (Postgres, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return Postgres{}, err
+	}
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		db.Close()
+		return Postgres{}, err
+	}
+	return Postgres{db: db}, nil
+}
+
+// Close closes the connections to the database.
+func (p Postgres) Close() error {
+	return p.db.Close()
+}
+
+// LastBlock implements Sink.
+func (p Postgres) LastBlock(ctx context.Context) (height int64, hash string, err error) {
+	err = p.db.
+		QueryRowContext(ctx, "SELECT height, hash FROM blocks ORDER BY height DESC LIMIT 1").
+		Scan(&height, &hash)
+	if err == sql.ErrNoRows {
+		return 0, "", nil
+	}
+	return height, hash, err
+}
+
+// Index implements Sink, the block is stored with its txs and events in a single transaction.
+func (p Postgres) Index(ctx context.Context, block Block) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO blocks (height, hash, time, proposer, num_txs) VALUES ($1, $2, $3, $4, $5)",
+		block.Height, block.Hash, block.Time, block.Proposer, len(block.Txs),
+	); err != nil {
+		return err
+	}
+
+	for _, t := range block.Txs {
+		if _, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO txs (height, tx_index, hash, code, log, gas_wanted, gas_used, raw)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			block.Height, t.Index, t.Hash, int64(t.Code), t.Log, t.GasWanted, t.GasUsed, t.Raw,
+		); err != nil {
+			return err
+		}
+	}
+
+	var index int
+	insertEvents := func(stage string, txIndex interface{}, events []Event) error {
+		for _, e := range events {
+			attributes, err := json.Marshal(e.Attributes)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(
+				ctx,
+				`INSERT INTO events (height, event_index, stage, tx_index, type, attributes)
+				VALUES ($1, $2, $3, $4, $5, $6)`,
+				block.Height, index, stage, txIndex, e.Type, string(attributes),
+			); err != nil {
+				return err
+			}
+			index++
+		}
+		return nil
+	}
+
+	if err := insertEvents(StageBeginBlock, nil, block.BeginBlockEvents); err != nil {
+		return err
+	}
+	for _, t := range block.Txs {
+		if err := insertEvents(StageTx, t.Index, t.Events); err != nil {
+			return err
+		}
+	}
+	if err := insertEvents(StageEndBlock, nil, block.EndBlockEvents); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Reset implements Sink.
+func (p Postgres) Reset(ctx context.Context) error {
+	_, err := p.db.ExecContext(ctx, "TRUNCATE blocks, txs, events")
+	return err
+}
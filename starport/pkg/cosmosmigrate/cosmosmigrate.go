@@ -0,0 +1,329 @@
+// Package cosmosmigrate migrates the code of the chains scaffolded by Starport between the lines
+// of the Cosmos SDK. the mechanical changes are rewritten in the syntax trees of the Go files and
+// the changes that can't be automated are reported as manual steps.
+package cosmosmigrate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosdeps"
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+)
+
+// Change is a change of a Go file made by a migration.
+type Change struct {
+	File    string `json:"file"`
+	Message string `json:"message"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s", c.File, c.Message)
+}
+
+// Step is a change of the code that can't be automated.
+type Step struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("%s:%d: %s", s.File, s.Line, s.Message)
+}
+
+// Report is the result of a migration.
+type Report struct {
+	// From is the version of the SDK before the migration.
+	From string `json:"from"`
+
+	// To is the version of the SDK after the migration.
+	To string `json:"to"`
+
+	// Changes are the changes of the Go files.
+	Changes []Change `json:"changes"`
+
+	// Steps are the manual steps left to finish the migration.
+	Steps []Step `json:"steps"`
+
+	// Dependencies are the changes of go.mod.
+	Dependencies []cosmosdeps.Change `json:"dependencies"`
+}
+
+type migrateOptions struct {
+	dryRun bool
+}
+
+// Option configures a migration.
+type Option func(*migrateOptions)
+
+// DryRun reports the changes of the migration without writing them.
+func DryRun() Option {
+	return func(o *migrateOptions) {
+		o.dryRun = true
+	}
+}
+
+// Migrate migrates the code of the app at path to the target version of the SDK, a line like
+// v0.44.x or a version like v0.44.5, and upgrades the dependencies of its go.mod. go.sum is not
+// updated, run go mod tidy after the migration.
+func Migrate(path, target string, options ...Option) (Report, error) {
+	var o migrateOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	f, err := gomodule.ParseAt(path)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var current string
+	for _, r := range f.Require {
+		if r.Mod.Path == cosmosdeps.PathSDK {
+			current = r.Mod.Version
+		}
+	}
+
+	// go.mod is upgraded first, so the migration fails before any change when the upgrade is
+	// not supported.
+	upgrade, err := cosmosdeps.PlanUpgrade(f, target, cosmosdeps.UpgradeMigrated())
+	if err != nil {
+		return Report{}, err
+	}
+
+	migrations, err := chain(semver.MajorMinor(current), upgrade.Compatibility.SDK)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		From:         current,
+		Changes:      []Change{},
+		Steps:        []Step{},
+		Dependencies: upgrade.Changes,
+	}
+	for _, r := range f.Require {
+		if r.Mod.Path == cosmosdeps.PathSDK {
+			report.To = r.Mod.Version
+		}
+	}
+
+	for _, m := range migrations {
+		if !m.RequireIBC || hasIBC(f.Require) {
+			continue
+		}
+		r := upgrade.Compatibility.IBC[0]
+		if err := f.AddRequire(r.Path, r.Version); err != nil {
+			return Report{}, err
+		}
+		report.Dependencies = append(report.Dependencies, cosmosdeps.Change{Module: r.Path, To: r.Version})
+	}
+
+	files, err := parseDir(path)
+	if err != nil {
+		return Report{}, err
+	}
+	for _, m := range migrations {
+		for _, file := range files {
+			m.apply(file)
+		}
+	}
+
+	for _, file := range files {
+		for _, message := range file.changes {
+			report.Changes = append(report.Changes, Change{File: file.Path, Message: message})
+		}
+		report.Steps = append(report.Steps, file.steps...)
+	}
+
+	if o.dryRun || len(report.Dependencies) == 0 && len(report.Changes) == 0 {
+		return report, nil
+	}
+
+	for _, file := range files {
+		if len(file.changes) == 0 {
+			continue
+		}
+		if err := file.write(path); err != nil {
+			return Report{}, err
+		}
+	}
+
+	f.Cleanup()
+	data, err := f.Format()
+	if err != nil {
+		return Report{}, err
+	}
+	if err := os.WriteFile(filepath.Join(path, "go.mod"), data, 0644); err != nil {
+		return Report{}, err
+	}
+
+	return report, cosmosdeps.RewriteImports(path, upgrade.Imports)
+}
+
+// chain returns the migrations from a line of the SDK to another one.
+func chain(from, to string) ([]Migration, error) {
+	var migrations []Migration
+	for line := from; line != to; {
+		m, ok := lookup(line)
+		if !ok {
+			return nil, fmt.Errorf("no known migration of the code from the SDK %s to %s", from, to)
+		}
+		migrations = append(migrations, m)
+		line = m.To
+	}
+	return migrations, nil
+}
+
+func hasIBC(requires []*modfile.Require) bool {
+	for _, r := range requires {
+		if r.Mod.Path == cosmosdeps.PathIBC || strings.HasPrefix(r.Mod.Path, cosmosdeps.PathIBC+"/v") {
+			return true
+		}
+	}
+	return false
+}
+
+// File is a Go file of the app that is migrated.
+type File struct {
+	// Path is the path of the file relative to the app.
+	Path string
+
+	Fset *token.FileSet
+	AST  *ast.File
+
+	// Package are the files of the package of the file, including it.
+	Package []*ast.File
+
+	changes  []string
+	steps    []Step
+	appended bytes.Buffer
+}
+
+// Changed records a change of the file, the same change is only recorded once.
+func (f *File) Changed(message string) {
+	for _, m := range f.changes {
+		if m == message {
+			return
+		}
+	}
+	f.changes = append(f.changes, message)
+}
+
+// Manual records a manual step at the position of the node.
+func (f *File) Manual(n ast.Node, message string) {
+	f.steps = append(f.steps, Step{
+		File:    f.Path,
+		Line:    f.Fset.Position(n.Pos()).Line,
+		Message: message,
+	})
+}
+
+// Append appends the source to the end of the file. it's simpler than adding the declarations
+// with their comments to the syntax tree.
+func (f *File) Append(src string) {
+	f.appended.WriteString("\n")
+	f.appended.WriteString(src)
+}
+
+// ImportName returns the name the file imports the package at path with.
+func (f *File) ImportName(path string) (string, bool) {
+	for _, spec := range f.AST.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != path {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name, true
+		}
+		return p[strings.LastIndex(p, "/")+1:], true
+	}
+	return "", false
+}
+
+// isPackage checks if the expression is the name of the package at path imported by the file.
+func (f *File) isPackage(x ast.Expr, path string) bool {
+	id, ok := x.(*ast.Ident)
+	if !ok || id.Obj != nil {
+		return false
+	}
+	name, ok := f.ImportName(path)
+	return ok && id.Name == name
+}
+
+func (f *File) write(root string) error {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, f.Fset, f.AST); err != nil {
+		return fmt.Errorf("%s: %w", f.Path, err)
+	}
+	buf.Write(f.appended.Bytes())
+
+	path := filepath.Join(root, f.Path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), info.Mode())
+}
+
+// parseDir parses the Go files of the app at root, sorted by path.
+func parseDir(root string) ([]*File, error) {
+	var (
+		fset     = token.NewFileSet()
+		files    []*File
+		packages = make(map[string][]*ast.File)
+	)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (name == "vendor" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		parsed, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Dir(rel)
+		packages[dir] = append(packages[dir], parsed)
+		files = append(files, &File{Path: rel, Fset: fset, AST: parsed})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		file.Package = packages[filepath.Dir(file.Path)]
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return files, nil
+}
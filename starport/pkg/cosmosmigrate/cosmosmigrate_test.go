@@ -0,0 +1,219 @@
+package cosmosmigrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosdeps"
+)
+
+const gomod = `module github.com/test/mars
+
+go 1.16
+
+require (
+	github.com/cosmos/cosmos-sdk v0.42.10
+	github.com/gogo/protobuf v1.3.3
+	github.com/tendermint/tendermint v0.34.11
+)
+
+replace github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.3-alpha.regen.1
+`
+
+const app = `package app
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	upgradekeeper "github.com/cosmos/cosmos-sdk/x/upgrade/keeper"
+	ibctransfer "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer"
+	ibckeeper "github.com/cosmos/cosmos-sdk/x/ibc/core/keeper"
+)
+
+type App struct {
+	appCodec codec.Marshaler
+}
+
+func New(appCodec codec.Marshaler, homePath string) *App {
+	app := &App{appCodec: appCodec}
+
+	// the keeper of the upgrade module.
+	app.UpgradeKeeper = upgradekeeper.NewKeeper(skipUpgradeHeights, keys[upgradetypes.StoreKey], appCodec, homePath)
+
+	app.mm.RegisterServices(module.NewConfigurator(app.MsgServiceRouter(), app.GRPCQueryRouter()))
+
+	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.BankKeeper, ante.DefaultSigVerificationGasConsumer, signModeHandler))
+
+	return app
+}
+
+func (app *App) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+	return app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+}
+`
+
+const appMigrated = `package app
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	upgradekeeper "github.com/cosmos/cosmos-sdk/x/upgrade/keeper"
+	ibctransfer "github.com/cosmos/ibc-go/v2/modules/apps/transfer"
+	ibckeeper "github.com/cosmos/ibc-go/v2/modules/core/keeper"
+)
+
+type App struct {
+	appCodec codec.Codec
+}
+
+func New(appCodec codec.Codec, homePath string) *App {
+	app := &App{appCodec: appCodec}
+
+	// the keeper of the upgrade module.
+	app.UpgradeKeeper = upgradekeeper.NewKeeper(skipUpgradeHeights, keys[upgradetypes.StoreKey], appCodec, homePath, app.BaseApp)
+
+	app.mm.RegisterServices(module.NewConfigurator(app.appCodec, app.MsgServiceRouter(), app.GRPCQueryRouter()))
+
+	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.BankKeeper, ante.DefaultSigVerificationGasConsumer, signModeHandler))
+
+	return app
+}
+
+func (app *App) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+	return app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+}
+`
+
+const module = `package blog
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// AppModule implements the AppModule interface for the blog module.
+type AppModule struct{}
+
+// DefaultGenesis returns the default genesis state of the blog module.
+func (AppModule) DefaultGenesis(cdc codec.JSONMarshaler) json.RawMessage {
+	return cdc.MustMarshalJSON(types.DefaultGenesis())
+}
+`
+
+const moduleMigrated = `package blog
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// AppModule implements the AppModule interface for the blog module.
+type AppModule struct{}
+
+// DefaultGenesis returns the default genesis state of the blog module.
+func (AppModule) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	return cdc.MustMarshalJSON(types.DefaultGenesis())
+}
+
+// ConsensusVersion implements ConsensusVersion.
+func (AppModule) ConsensusVersion() uint64 { return 2 }
+`
+
+const keeper = `package keeper
+
+func (k Keeper) SetPost(ctx sdk.Context, post types.Post) {
+	store.Set(GetPostIDBytes(post.Id), k.cdc.MustMarshalBinaryBare(&post))
+}
+
+func (k Keeper) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet) (*sdk.Result, []byte, error) {
+	return nil, nil, nil
+}
+`
+
+func writeChain(t *testing.T) string {
+	dir := t.TempDir()
+	for path, data := range map[string]string{
+		"go.mod":                  gomod,
+		"app/app.go":              app,
+		"x/blog/module.go":        module,
+		"x/blog/keeper/keeper.go": keeper,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+	}
+	return dir
+}
+
+func read(t *testing.T, dir, path string) string {
+	data, err := os.ReadFile(filepath.Join(dir, path))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestMigrate(t *testing.T) {
+	dir := writeChain(t)
+
+	report, err := Migrate(dir, "v0.44.x")
+	require.NoError(t, err)
+	require.Equal(t, "v0.42.10", report.From)
+	require.Equal(t, "v0.44.5", report.To)
+	require.Equal(t, []Change{
+		{File: "app/app.go", Message: "rewrote the imports of github.com/cosmos/cosmos-sdk/x/ibc/applications to github.com/cosmos/ibc-go/v2/modules/apps"},
+		{File: "app/app.go", Message: "rewrote the imports of github.com/cosmos/cosmos-sdk/x/ibc/core to github.com/cosmos/ibc-go/v2/modules/core"},
+		{File: "app/app.go", Message: "renamed codec.Marshaler to codec.Codec"},
+		{File: "app/app.go", Message: "passed the codec of the app to module.NewConfigurator"},
+		{File: "app/app.go", Message: "passed the base app to the keeper of the upgrade module"},
+		{File: "x/blog/keeper/keeper.go", Message: "renamed MustMarshalBinaryBare to MustMarshal"},
+		{File: "x/blog/module.go", Message: "renamed codec.JSONMarshaler to codec.JSONCodec"},
+		{File: "x/blog/module.go", Message: "added the ConsensusVersion method of AppModule"},
+	}, report.Changes)
+
+	var steps []string
+	for _, step := range report.Steps {
+		steps = append(steps, step.File)
+	}
+	require.Equal(t, []string{"app/app.go", "app/app.go", "x/blog/keeper/keeper.go"}, steps)
+	require.Equal(t, 24, report.Steps[0].Line)
+
+	require.Equal(t, appMigrated, read(t, dir, "app/app.go"))
+	require.Equal(t, moduleMigrated, read(t, dir, "x/blog/module.go"))
+	require.Contains(t, read(t, dir, "x/blog/keeper/keeper.go"), "k.cdc.MustMarshal(&post)")
+
+	check, err := cosmosdeps.CheckAt(dir)
+	require.NoError(t, err)
+	require.Equal(t, "v0.44.5", check.SDK)
+	require.Empty(t, check.Issues)
+	require.Contains(t, read(t, dir, "go.mod"), "github.com/cosmos/ibc-go/v2 v2.0.2")
+
+	// the migrated code is not changed again.
+	report, err = Migrate(dir, "v0.44.x")
+	require.NoError(t, err)
+	require.Empty(t, report.Changes)
+	require.Empty(t, report.Dependencies)
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	dir := writeChain(t)
+
+	report, err := Migrate(dir, "v0.45.x", DryRun())
+	require.NoError(t, err)
+	require.Equal(t, "v0.45.4", report.To)
+	require.Len(t, report.Changes, 8)
+	require.Equal(t, app, read(t, dir, "app/app.go"))
+	require.Equal(t, gomod, read(t, dir, "go.mod"))
+}
+
+func TestMigrateErrors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/test/mars\n\nrequire github.com/cosmos/cosmos-sdk v0.40.1\n"), 0644))
+
+	_, err := Migrate(dir, "v0.44.x")
+	require.EqualError(t, err, "no known migration of the code from the SDK v0.40 to v0.44")
+
+	_, err = Migrate(writeChain(t), "v0.46.x")
+	require.EqualError(t, err, "unknown SDK line v0.46, the known lines are: v0.42, v0.44, v0.45")
+}
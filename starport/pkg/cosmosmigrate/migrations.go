@@ -0,0 +1,340 @@
+package cosmosmigrate
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+// the paths of the packages of the SDK the migrations change the uses of.
+const (
+	pathCodec         = "github.com/cosmos/cosmos-sdk/codec"
+	pathModule        = "github.com/cosmos/cosmos-sdk/types/module"
+	pathAnte          = "github.com/cosmos/cosmos-sdk/x/auth/ante"
+	pathUpgradeKeeper = "github.com/cosmos/cosmos-sdk/x/upgrade/keeper"
+)
+
+// Rename is a renamed identifier.
+type Rename struct {
+	// Package is the path of the package of the identifier, it's empty for the methods.
+	Package string
+
+	Old string
+	New string
+}
+
+// Rewrite changes a Go file, it records the changes and the manual steps in the file.
+type Rewrite func(f *File)
+
+// Migration is a migration of the code of the chains from a line of the SDK to the next one.
+type Migration struct {
+	// From is the line of the SDK the migration starts from.
+	From string
+
+	// To is the line of the SDK the migration ends at.
+	To string
+
+	// Imports are the new prefixes of the import paths by their old prefixes.
+	Imports map[string]string
+
+	// Renames are the renamed identifiers.
+	Renames []Rename
+
+	// Rewrites are the other changes of the code.
+	Rewrites []Rewrite
+
+	// RequireIBC is set when the IBC module moves out of the SDK, ibc-go is then required.
+	RequireIBC bool
+}
+
+// Migrations are the known migrations, sorted by line.
+var Migrations = []Migration{
+	{
+		From: "v0.42",
+		To:   "v0.44",
+		Imports: map[string]string{
+			"github.com/cosmos/cosmos-sdk/x/ibc/applications":  "github.com/cosmos/ibc-go/v2/modules/apps",
+			"github.com/cosmos/cosmos-sdk/x/ibc/core":          "github.com/cosmos/ibc-go/v2/modules/core",
+			"github.com/cosmos/cosmos-sdk/x/ibc/light-clients": "github.com/cosmos/ibc-go/v2/modules/light-clients",
+			"github.com/cosmos/cosmos-sdk/x/ibc/testing":       "github.com/cosmos/ibc-go/v2/testing",
+		},
+		Renames: []Rename{
+			{Package: pathCodec, Old: "Marshaler", New: "Codec"},
+			{Package: pathCodec, Old: "BinaryMarshaler", New: "BinaryCodec"},
+			{Package: pathCodec, Old: "JSONMarshaler", New: "JSONCodec"},
+			{Old: "MarshalBinaryBare", New: "Marshal"},
+			{Old: "MustMarshalBinaryBare", New: "MustMarshal"},
+			{Old: "UnmarshalBinaryBare", New: "Unmarshal"},
+			{Old: "MustUnmarshalBinaryBare", New: "MustUnmarshal"},
+			{Old: "MarshalBinaryLengthPrefixed", New: "MarshalLengthPrefixed"},
+			{Old: "MustMarshalBinaryLengthPrefixed", New: "MustMarshalLengthPrefixed"},
+			{Old: "UnmarshalBinaryLengthPrefixed", New: "UnmarshalLengthPrefixed"},
+			{Old: "MustUnmarshalBinaryLengthPrefixed", New: "MustUnmarshalLengthPrefixed"},
+		},
+		Rewrites: []Rewrite{
+			configuratorCodec,
+			upgradeKeeperBaseApp,
+			consensusVersion,
+			anteHandlerOptions,
+			upgradeHandlerVersionMap,
+			initChainerVersionMap,
+			ibcCallbacks,
+		},
+		RequireIBC: true,
+	},
+	{
+		// the code scaffolded for v0.44 works with v0.45.
+		From: "v0.44",
+		To:   "v0.45",
+	},
+}
+
+// lookup returns the migration from the line.
+func lookup(line string) (Migration, bool) {
+	for _, m := range Migrations {
+		if m.From == line {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
+
+// apply applies the migration to the file.
+func (m Migration) apply(f *File) {
+	for _, spec := range f.AST.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		for old, new := range m.Imports {
+			if path != old && !strings.HasPrefix(path, old+"/") {
+				continue
+			}
+			spec.Path.Value = strconv.Quote(new + strings.TrimPrefix(path, old))
+			f.Changed("rewrote the imports of " + old + " to " + new)
+		}
+	}
+	if len(m.Imports) > 0 {
+		ast.SortImports(f.Fset, f.AST)
+	}
+
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		for _, r := range m.Renames {
+			if sel.Sel.Name != r.Old {
+				continue
+			}
+			if r.Package == "" {
+				// the methods are renamed on the values, not on the packages.
+				if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil && isImportName(f, id.Name) {
+					continue
+				}
+				sel.Sel.Name = r.New
+				f.Changed("renamed " + r.Old + " to " + r.New)
+				continue
+			}
+			if f.isPackage(sel.X, r.Package) {
+				name, _ := f.ImportName(r.Package)
+				sel.Sel.Name = r.New
+				f.Changed("renamed " + name + "." + r.Old + " to " + name + "." + r.New)
+			}
+		}
+		return true
+	})
+
+	for _, rewrite := range m.Rewrites {
+		rewrite(f)
+	}
+}
+
+func isImportName(f *File, name string) bool {
+	for _, spec := range f.AST.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if n, _ := f.ImportName(path); n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// calls calls fn with the calls of the function of the package at path.
+func calls(f *File, path, name string, fn func(call *ast.CallExpr)) {
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == name && f.isPackage(sel.X, path) {
+			fn(call)
+		}
+		return true
+	})
+}
+
+// configuratorCodec passes the codec of the app to module.NewConfigurator.
+func configuratorCodec(f *File) {
+	calls(f, pathModule, "NewConfigurator", func(call *ast.CallExpr) {
+		if len(call.Args) != 2 {
+			return
+		}
+
+		// the app is the receiver of app.MsgServiceRouter().
+		if router, ok := call.Args[0].(*ast.CallExpr); ok {
+			if sel, ok := router.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "MsgServiceRouter" {
+				if app, ok := sel.X.(*ast.Ident); ok {
+					codec := &ast.SelectorExpr{X: ast.NewIdent(app.Name), Sel: ast.NewIdent("appCodec")}
+					call.Args = append([]ast.Expr{codec}, call.Args...)
+					f.Changed("passed the codec of the app to module.NewConfigurator")
+					return
+				}
+			}
+		}
+		f.Manual(call, "module.NewConfigurator takes the codec of the app as its first argument")
+	})
+}
+
+// upgradeKeeperBaseApp passes the base app to the keeper of the upgrade module.
+func upgradeKeeperBaseApp(f *File) {
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			call, ok := rhs.(*ast.CallExpr)
+			if !ok || len(call.Args) != 4 {
+				continue
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "NewKeeper" || !f.isPackage(sel.X, pathUpgradeKeeper) {
+				continue
+			}
+
+			// the keeper is assigned to a field of the app, e.g. app.UpgradeKeeper.
+			if field, ok := assign.Lhs[i].(*ast.SelectorExpr); ok {
+				if app, ok := field.X.(*ast.Ident); ok {
+					call.Args = append(call.Args, &ast.SelectorExpr{X: ast.NewIdent(app.Name), Sel: ast.NewIdent("BaseApp")})
+					f.Changed("passed the base app to the keeper of the upgrade module")
+					continue
+				}
+			}
+			f.Manual(call, "the keeper of the upgrade module takes the base app as its last argument")
+		}
+		return true
+	})
+}
+
+// consensusVersion adds the ConsensusVersion method to the AppModule types of the modules.
+func consensusVersion(f *File) {
+	if !declaresType(f.AST, "AppModule") {
+		return
+	}
+	for _, file := range f.Package {
+		if declaresMethod(file, "AppModule", "ConsensusVersion") {
+			return
+		}
+	}
+	f.Append(`// ConsensusVersion implements ConsensusVersion.
+func (AppModule) ConsensusVersion() uint64 { return 2 }
+`)
+	f.Changed("added the ConsensusVersion method of AppModule")
+}
+
+// anteHandlerOptions reports the ante handlers created with the keepers.
+func anteHandlerOptions(f *File) {
+	calls(f, pathAnte, "NewAnteHandler", func(call *ast.CallExpr) {
+		if len(call.Args) == 1 {
+			return
+		}
+		f.Manual(call, "ante.NewAnteHandler takes ante.HandlerOptions and returns an error, pass the keepers, the sign mode handler and the signature gas consumer in the options")
+	})
+}
+
+// upgradeHandlerVersionMap reports the upgrade handlers without version maps.
+func upgradeHandlerVersionMap(f *File) {
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "SetUpgradeHandler" {
+			return true
+		}
+		if handler, ok := call.Args[1].(*ast.FuncLit); ok && handler.Type.Params.NumFields() == 2 {
+			f.Manual(call, "the upgrade handlers take the version map of the modules and return the migrated one, run the migrations with the RunMigrations method of the module manager")
+		}
+		return true
+	})
+}
+
+// initChainerVersionMap reports the InitChainer methods that don't set the version map of the
+// modules.
+func initChainerVersionMap(f *File) {
+	for _, decl := range f.AST.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "InitChainer" || fn.Body == nil {
+			continue
+		}
+
+		var found bool
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == "SetModuleVersionMap" {
+				found = true
+			}
+			return !found
+		})
+		if !found {
+			f.Manual(fn, "set the version map of the modules in InitChainer with app.UpgradeKeeper.SetModuleVersionMap(ctx, app.mm.GetVersionMap())")
+		}
+	}
+}
+
+// ibcCallbacks reports the IBC callbacks of the modules with the signatures of the SDK.
+func ibcCallbacks(f *File) {
+	for _, decl := range f.AST.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "OnRecvPacket" || fn.Type.Results.NumFields() != 3 {
+			continue
+		}
+		f.Manual(fn, "OnRecvPacket returns an acknowledgement in ibc-go and the packet callbacks take the address of the relayer, update the callbacks of the IBC module")
+	}
+}
+
+// declaresType checks if the file declares the type.
+func declaresType(file *ast.File, name string) bool {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if t, ok := spec.(*ast.TypeSpec); ok && t.Name.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// declaresMethod checks if the file declares the method of the type.
+func declaresMethod(file *ast.File, typ, name string) bool {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != name || len(fn.Recv.List) == 0 {
+			continue
+		}
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		if id, ok := recv.(*ast.Ident); ok && id.Name == typ {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package cosmosutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type (
+	// DenomUnit is a unit of a denom, one coin of the unit is 10^Exponent coins of the base denom.
+	DenomUnit struct {
+		Denom    string
+		Exponent uint32
+		Aliases  []string
+	}
+
+	// Denom is the metadata of a denom, as in the denom metadata of the bank module.
+	Denom struct {
+		Base  string
+		Units []DenomUnit
+	}
+
+	// Denoms are the denoms that human amounts are converted from.
+	Denoms []Denom
+)
+
+// ParseDenomUnit parses a unit of a denom in the display=base:exponent format, e.g. mars=umars:6.
+func ParseDenomUnit(unit string) (Denom, error) {
+	parts := strings.SplitN(unit, "=", 2)
+	if len(parts) != 2 {
+		return Denom{}, fmt.Errorf("invalid denom unit %q, expected display=base:exponent", unit)
+	}
+	display := parts[0]
+	parts = strings.SplitN(parts[1], ":", 2)
+	if len(parts) != 2 {
+		return Denom{}, fmt.Errorf("invalid denom unit %q, expected display=base:exponent", unit)
+	}
+	base := parts[0]
+	exponent, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return Denom{}, fmt.Errorf("invalid exponent of denom unit %q: %w", unit, err)
+	}
+	if exponent > sdk.Precision {
+		return Denom{}, fmt.Errorf("the exponent of denom unit %q is more than %d", unit, sdk.Precision)
+	}
+	for _, denom := range []string{display, base} {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return Denom{}, fmt.Errorf("invalid denom unit %q: %w", unit, err)
+		}
+	}
+	return Denom{
+		Base:  base,
+		Units: []DenomUnit{{Denom: display, Exponent: uint32(exponent)}},
+	}, nil
+}
+
+// Lookup returns the base denom and the exponent of the unit of a denom or of one of its aliases,
+// ok is false when the denom is not a unit of the denoms.
+func (d Denoms) Lookup(denom string) (base string, exponent uint32, ok bool) {
+	for _, metadata := range d {
+		for _, unit := range metadata.Units {
+			if unit.Denom == denom {
+				return metadata.Base, unit.Exponent, true
+			}
+			for _, alias := range unit.Aliases {
+				if alias == denom {
+					return metadata.Base, unit.Exponent, true
+				}
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// ParseCoin parses a human amount of a coin, e.g. 12.5mars, and converts it to its base denom,
+// e.g. 12500000umars when the exponent of mars is 6. the denoms that are not units of the denoms
+// are base denoms. the amounts can't have more decimals than the exponent of their unit, so the
+// amounts of the base denoms are integers.
+func (d Denoms) ParseCoin(coin string) (sdk.Coin, error) {
+	decCoin, err := sdk.ParseDecCoin(coin)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	base, exponent, ok := d.Lookup(decCoin.Denom)
+	if !ok {
+		base = decCoin.Denom
+	}
+	if exponent > sdk.Precision {
+		return sdk.Coin{}, fmt.Errorf("the exponent %d of %s is more than %d", exponent, decCoin.Denom, sdk.Precision)
+	}
+	amount := decCoin.Amount.Mul(sdk.NewDecFromInt(sdk.NewIntWithDecimal(1, int(exponent))))
+	if !amount.TruncateDec().Equal(amount) {
+		if exponent == 0 {
+			return sdk.Coin{}, fmt.Errorf("the amount of %q can't have decimals, %s is a base denom", coin, decCoin.Denom)
+		}
+		return sdk.Coin{}, fmt.Errorf("the amount of %q has more than the %d decimals of %s", coin, exponent, decCoin.Denom)
+	}
+	return sdk.NewCoin(base, amount.TruncateInt()), nil
+}
+
+// ParseCoins parses human amounts of coins separated by commas, e.g. 12.5mars,100token, the
+// amounts of the units of a same base denom are added up. it returns nil for an empty string.
+func (d Denoms) ParseCoins(coins string) (sdk.Coins, error) {
+	coins = strings.TrimSpace(coins)
+	if coins == "" {
+		return nil, nil
+	}
+
+	parsed := sdk.NewCoins()
+	for _, coin := range strings.Split(coins, ",") {
+		c, err := d.ParseCoin(coin)
+		if err != nil {
+			return nil, err
+		}
+		parsed = parsed.Add(c)
+	}
+	return parsed, nil
+}
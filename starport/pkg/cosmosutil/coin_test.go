@@ -0,0 +1,93 @@
+package cosmosutil_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+)
+
+var testDenoms = cosmosutil.Denoms{
+	{
+		Base: "uatom",
+		Units: []cosmosutil.DenomUnit{
+			{Denom: "uatom"},
+			{Denom: "atom", Exponent: 6, Aliases: []string{"cosmos"}},
+		},
+	},
+}
+
+func TestParseCoins(t *testing.T) {
+	tests := []struct {
+		name    string
+		coins   string
+		want    sdk.Coins
+		wantErr string
+	}{
+		{
+			name:  "empty",
+			coins: "",
+		},
+		{
+			name:  "display denom",
+			coins: "12.5atom",
+			want:  sdk.NewCoins(sdk.NewInt64Coin("uatom", 12500000)),
+		},
+		{
+			name:  "alias",
+			coins: "1cosmos",
+			want:  sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000000)),
+		},
+		{
+			name:  "units of a same base denom are added up",
+			coins: "1atom,500uatom,10token",
+			want:  sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000500), sdk.NewInt64Coin("token", 10)),
+		},
+		{
+			name:    "too many decimals",
+			coins:   "0.0000001atom",
+			wantErr: `the amount of "0.0000001atom" has more than the 6 decimals of atom`,
+		},
+		{
+			name:    "decimals of a base denom",
+			coins:   "1.5token",
+			wantErr: `the amount of "1.5token" can't have decimals, token is a base denom`,
+		},
+		{
+			name:    "invalid coin",
+			coins:   "atom",
+			wantErr: "invalid decimal coin expression: atom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testDenoms.ParseCoins(tt.coins)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseDenomUnit(t *testing.T) {
+	denom, err := cosmosutil.ParseDenomUnit("mars=umars:6")
+	require.NoError(t, err)
+	require.Equal(t, cosmosutil.Denom{
+		Base:  "umars",
+		Units: []cosmosutil.DenomUnit{{Denom: "mars", Exponent: 6}},
+	}, denom)
+
+	coin, err := cosmosutil.Denoms{denom}.ParseCoin("2mars")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin("umars", 2000000), coin)
+
+	for _, unit := range []string{"mars", "mars=umars", "mars=umars:x", "mars=umars:19", "m=umars:6"} {
+		_, err := cosmosutil.ParseDenomUnit(unit)
+		require.Error(t, err, unit)
+	}
+}
@@ -0,0 +1,220 @@
+package cosmosutil
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultGenesisMaxSize is the default max size of a genesis fetched from a URL, once extracted.
+	DefaultGenesisMaxSize = 1 << 30
+
+	// DefaultGenesisFetchTimeout is the default timeout to fetch a genesis from a URL.
+	DefaultGenesisFetchTimeout = 10 * time.Minute
+
+	// genesisFileName is the name of the genesis in the tarballs.
+	genesisFileName = "genesis.json"
+
+	// progressStep is the number of bytes read between two reports of the progress.
+	progressStep = 1 << 20
+)
+
+var (
+	// ErrGenesisTooLarge is returned when a genesis fetched from a URL is larger than the max size.
+	ErrGenesisTooLarge = errors.New("the genesis is too large")
+
+	gzipMagic      = []byte{0x1f, 0x8b}
+	zstdMagic      = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	tarMagic       = []byte("ustar")
+	tarMagicOffset = 257
+)
+
+// genesisFetchOptions holds info about how to fetch a genesis from a URL.
+type genesisFetchOptions struct {
+	maxSize  int64
+	timeout  time.Duration
+	progress func(read, total int64)
+}
+
+// GenesisFetchOption configures the fetch of a genesis from a URL.
+type GenesisFetchOption func(*genesisFetchOptions)
+
+// WithGenesisMaxSize sets the max size in bytes of the fetched genesis, it's checked against both
+// the downloaded and extracted sizes. a size of 0 disables the check.
+func WithGenesisMaxSize(size int64) GenesisFetchOption {
+	return func(o *genesisFetchOptions) {
+		o.maxSize = size
+	}
+}
+
+// WithGenesisFetchTimeout sets the timeout to fetch the genesis, a timeout of 0 disables it.
+func WithGenesisFetchTimeout(timeout time.Duration) GenesisFetchOption {
+	return func(o *genesisFetchOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithGenesisFetchProgress calls progress with the number of bytes downloaded every MB and once the
+// download is completed. total is -1 when the server doesn't send the size of the genesis.
+func WithGenesisFetchProgress(progress func(read, total int64)) GenesisFetchOption {
+	return func(o *genesisFetchOptions) {
+		o.progress = progress
+	}
+}
+
+// GenesisAndHashFromURL fetches the genesis from the given url and returns its content along with the sha256 hash.
+// the genesis can be compressed with gzip or zstd and packaged in a tarball, the genesis.json of
+// the tarball is extracted then. the hash is the hash of the extracted genesis.
+func GenesisAndHashFromURL(ctx context.Context, url string, options ...GenesisFetchOption) (genesis []byte, hash string, err error) {
+	o := genesisFetchOptions{
+		maxSize: DefaultGenesisMaxSize,
+		timeout: DefaultGenesisFetchTimeout,
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("cannot fetch the genesis from %s: %s", url, resp.Status)
+	}
+	if o.maxSize > 0 && resp.ContentLength > o.maxSize {
+		return nil, "", errors.Wrapf(ErrGenesisTooLarge, "%d bytes, max %d bytes", resp.ContentLength, o.maxSize)
+	}
+
+	body := &progressReader{r: resp.Body, total: resp.ContentLength, progress: o.progress}
+	genesis, err = decodeGenesis(&limitedReader{r: body, n: o.maxSize}, o.maxSize)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "cannot read the genesis from %s", url)
+	}
+	body.report()
+
+	h := sha256.Sum256(genesis)
+	return genesis, hex.EncodeToString(h[:]), nil
+}
+
+// decodeGenesis decompresses the genesis when it's compressed with gzip or zstd, and extracts it.
+func decodeGenesis(r io.Reader, maxSize int64) ([]byte, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		return extractGenesis(gr, maxSize)
+
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return extractGenesis(zr, maxSize)
+	}
+
+	return extractGenesis(br, maxSize)
+}
+
+// extractGenesis returns the genesis.json of the tarball, or the genesis itself when it's not a tarball.
+func extractGenesis(r io.Reader, maxSize int64) ([]byte, error) {
+	br := bufio.NewReader(r)
+	header, _ := br.Peek(tarMagicOffset + len(tarMagic))
+	if len(header) < tarMagicOffset+len(tarMagic) || !bytes.Equal(header[tarMagicOffset:], tarMagic) {
+		return readAll(br, maxSize)
+	}
+
+	tr := tar.NewReader(br)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no %s in the tarball", genesisFileName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag == tar.TypeReg && path.Base(h.Name) == genesisFileName {
+			return readAll(tr, maxSize)
+		}
+	}
+}
+
+// readAll reads r until EOF, it fails when r is larger than maxSize.
+func readAll(r io.Reader, maxSize int64) ([]byte, error) {
+	return io.ReadAll(&limitedReader{r: r, n: maxSize})
+}
+
+// limitedReader fails with ErrGenesisTooLarge when more than n bytes are read, n is unlimited
+// when it's 0.
+type limitedReader struct {
+	r    io.Reader
+	n    int64
+	read int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.n > 0 && l.read > l.n {
+		return n, errors.Wrapf(ErrGenesisTooLarge, "max %d bytes", l.n)
+	}
+	return n, err
+}
+
+// progressReader reports the number of bytes read.
+type progressReader struct {
+	r        io.Reader
+	total    int64
+	read     int64
+	reported int64
+	progress func(read, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.read-p.reported >= progressStep {
+		p.report()
+	}
+	return n, err
+}
+
+func (p *progressReader) report() {
+	if p.progress == nil || (p.reported == p.read && p.read != 0) {
+		return
+	}
+	p.reported = p.read
+	p.progress(p.read, p.total)
+}
@@ -0,0 +1,124 @@
+package cosmosutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+var testGenesis = []byte(`{"chain_id":"earth-1","app_state":{}}`)
+
+func gzipData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func tarData(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for name, data := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func serve(t *testing.T, data []byte) string {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(s.Close)
+	return s.URL
+}
+
+func TestGenesisAndHashFromURL(t *testing.T) {
+	h := sha256.Sum256(testGenesis)
+	wantHash := hex.EncodeToString(h[:])
+
+	for name, data := range map[string][]byte{
+		"plain":   testGenesis,
+		"gzip":    gzipData(t, testGenesis),
+		"zstd":    zstdData(t, testGenesis),
+		"tarball": tarData(t, map[string][]byte{"config/genesis.json": testGenesis}),
+		"tar.gz":  gzipData(t, tarData(t, map[string][]byte{"genesis.json": testGenesis})),
+		"tar.zst": zstdData(t, tarData(t, map[string][]byte{"genesis.json": testGenesis})),
+	} {
+		t.Run(name, func(t *testing.T) {
+			genesis, hash, err := GenesisAndHashFromURL(context.Background(), serve(t, data))
+			require.NoError(t, err)
+			require.Equal(t, testGenesis, genesis)
+			require.Equal(t, wantHash, hash)
+		})
+	}
+}
+
+func TestGenesisAndHashFromURLInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	_, _, err := GenesisAndHashFromURL(ctx, serve(t, testGenesis), WithGenesisMaxSize(10))
+	require.ErrorIs(t, err, ErrGenesisTooLarge)
+
+	// the extracted size is checked too.
+	large := gzipData(t, bytes.Repeat([]byte(" "), 1<<20))
+	_, _, err = GenesisAndHashFromURL(ctx, serve(t, large), WithGenesisMaxSize(1<<10))
+	require.ErrorIs(t, err, ErrGenesisTooLarge)
+
+	_, _, err = GenesisAndHashFromURL(ctx, serve(t, tarData(t, map[string][]byte{"README": nil})))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no genesis.json in the tarball")
+
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+	_, _, err = GenesisAndHashFromURL(ctx, s.URL)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "404 Not Found")
+}
+
+func TestGenesisAndHashFromURLProgress(t *testing.T) {
+	data := bytes.Repeat([]byte(" "), 3*progressStep)
+	var reports []int64
+
+	_, _, err := GenesisAndHashFromURL(
+		context.Background(),
+		serve(t, data),
+		WithGenesisFetchProgress(func(read, total int64) {
+			require.EqualValues(t, len(data), total)
+			reports = append(reports, read)
+		}),
+	)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(reports), 3)
+	require.EqualValues(t, len(data), reports[len(reports)-1])
+}
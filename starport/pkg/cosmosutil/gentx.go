@@ -6,7 +6,16 @@ import (
 	"errors"
 	"os"
 
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
 )
 
 var GentxFilename = "gentx.json"
@@ -17,6 +26,7 @@ type (
 	// GentxInfo represents the basic info about gentx file
 	GentxInfo struct {
 		DelegatorAddress string
+		Moniker          string
 		PubKey           PubKey
 		SelfDelegation   sdk.Coin
 	}
@@ -24,6 +34,9 @@ type (
 	StargateGentx struct {
 		Body struct {
 			Messages []struct {
+				Description struct {
+					Moniker string `json:"moniker"`
+				} `json:"description"`
 				DelegatorAddress string `json:"delegator_address"`
 				ValidatorAddress string `json:"validator_address"`
 				PubKey           struct {
@@ -74,6 +87,7 @@ func ParseGentx(gentx []byte) (info GentxInfo, file []byte, err error) {
 	}
 
 	info.DelegatorAddress = stargateGentx.Body.Messages[0].DelegatorAddress
+	info.Moniker = stargateGentx.Body.Messages[0].Description.Moniker
 	info.PubKey = []byte(stargateGentx.Body.Messages[0].PubKey.Key)
 
 	amount, ok := sdk.NewIntFromString(stargateGentx.Body.Messages[0].Value.Amount)
@@ -88,3 +102,57 @@ func ParseGentx(gentx []byte) (info GentxInfo, file []byte, err error) {
 
 	return info, gentx, nil
 }
+
+// VerifyGentxSignature verifies that the gentx is signed for the chain by the delegator of its
+// validator, with the account number and the sequence of a genesis account.
+func VerifyGentxSignature(gentx []byte, chainID string) error {
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	stakingtypes.RegisterInterfaces(registry)
+	ethsecp256k1.RegisterInterfaces(registry)
+	txConfig := authtx.NewTxConfig(codec.NewProtoCodec(registry), authtx.DefaultSignModes)
+
+	decoded, err := txConfig.TxJSONDecoder()(gentx)
+	if err != nil {
+		return err
+	}
+	tx, ok := decoded.(authsigning.SigVerifiableTx)
+	if !ok {
+		return errors.New("the gentx cannot be verified")
+	}
+
+	msgs := tx.GetMsgs()
+	if len(msgs) != 1 {
+		return errors.New("add validator gentx must contain 1 message")
+	}
+	msg, ok := msgs[0].(*stakingtypes.MsgCreateValidator)
+	if !ok {
+		return errors.New("the message of the gentx must create a validator")
+	}
+	// the delegator address is decoded without its prefix that depends on the chain.
+	_, delegator, err := bech32.DecodeAndConvert(msg.DelegatorAddress)
+	if err != nil {
+		return err
+	}
+
+	pubKeys, err := tx.GetPubKeys()
+	if err != nil {
+		return err
+	}
+	sigs, err := tx.GetSignaturesV2()
+	if err != nil {
+		return err
+	}
+	if len(pubKeys) != 1 || len(sigs) != 1 {
+		return errors.New("the gentx must have a single signature")
+	}
+	if pubKeys[0] == nil || !bytes.Equal(pubKeys[0].Address(), delegator) {
+		return errors.New("the gentx is not signed by the delegator")
+	}
+
+	signerData := authsigning.SignerData{ChainID: chainID}
+	if err := authsigning.VerifySignature(pubKeys[0], signerData, sigs[0].Data, txConfig.SignModeHandler(), tx); err != nil {
+		return errors.New("the signature of the gentx is invalid for the chain " + chainID)
+	}
+	return nil
+}
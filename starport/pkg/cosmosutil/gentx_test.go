@@ -1,6 +1,8 @@
 package cosmosutil_test
 
 import (
+	"bytes"
+	"os"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -21,6 +23,7 @@ func TestParseGentx(t *testing.T) {
 			gentxPath: "testdata/gentx1.json",
 			wantInfo: cosmosutil.GentxInfo{
 				DelegatorAddress: "cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj",
+				Moniker:          "default",
 				PubKey:           []byte("aeQLCJOjXUyB7evOodI4mbrshIt3vhHGlycJDbUkaMs="),
 				SelfDelegation: sdk.Coin{
 					Denom:  "stake",
@@ -32,6 +35,7 @@ func TestParseGentx(t *testing.T) {
 			gentxPath: "testdata/gentx2.json",
 			wantInfo: cosmosutil.GentxInfo{
 				DelegatorAddress: "cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa",
+				Moniker:          "alice",
 				PubKey:           []byte("OL+EIoo7DwyaBFDbPbgAhwS5rvgIqoUa0x8qWqzfQVQ="),
 				SelfDelegation: sdk.Coin{
 					Denom:  "stake",
@@ -89,3 +93,49 @@ func TestPubKey_Equal(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyGentxSignature(t *testing.T) {
+	gentx, err := os.ReadFile("testdata/gentx_signed.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		gentx   []byte
+		chainID string
+		wantErr bool
+	}{
+		{
+			name:    "signed gentx",
+			gentx:   gentx,
+			chainID: "gxchain",
+		},
+		{
+			name:    "gentx of another chain",
+			gentx:   gentx,
+			chainID: "mars",
+			wantErr: true,
+		},
+		{
+			name:    "tampered gentx",
+			gentx:   bytes.Replace(gentx, []byte(`"amount":"50000000"`), []byte(`"amount":"60000000"`), 1),
+			chainID: "gxchain",
+			wantErr: true,
+		},
+		{
+			name:    "gentx signed by another account",
+			gentx:   bytes.Replace(gentx, []byte("cosmos12xeajwf397d05zwpyspp7hzkexy25tnd85m0hj"), []byte("cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"), 1),
+			chainID: "gxchain",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cosmosutil.VerifyGentxSignature(tt.gentx, tt.chainID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package dirchange
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ChangeKind is the kind of change of a file between two directories.
+type ChangeKind int
+
+const (
+	// Modified is a file with a different content in the two directories.
+	Modified ChangeKind = iota
+
+	// Added is a file that only exists in the target directory.
+	Added
+
+	// Removed is a file that only exists in the base directory.
+	Removed
+)
+
+// Change is a file that differs between two directories.
+type Change struct {
+	// Path of the file relative to the directories.
+	Path string
+
+	Kind ChangeKind
+}
+
+// Match checks if a file or a directory with the path relative to the compared directories is
+// compared, the content of a directory that doesn't match isn't compared.
+type Match func(path string, isDir bool) bool
+
+// Diff returns the files that differ between the base and target directories sorted by path.
+// all files are compared when match is nil.
+func Diff(base, target string, match Match) ([]Change, error) {
+	baseFiles, err := listFiles(base, match)
+	if err != nil {
+		return nil, err
+	}
+	targetFiles, err := listFiles(target, match)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for path := range baseFiles {
+		if _, ok := targetFiles[path]; !ok {
+			changes = append(changes, Change{Path: path, Kind: Removed})
+			continue
+		}
+
+		baseContent, err := os.ReadFile(filepath.Join(base, path))
+		if err != nil {
+			return nil, err
+		}
+		targetContent, err := os.ReadFile(filepath.Join(target, path))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(baseContent, targetContent) {
+			changes = append(changes, Change{Path: path, Kind: Modified})
+		}
+	}
+	for path := range targetFiles {
+		if _, ok := baseFiles[path]; !ok {
+			changes = append(changes, Change{Path: path, Kind: Added})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// listFiles returns the relative paths of the files in dir that match.
+func listFiles(dir string, match Match) (map[string]struct{}, error) {
+	files := make(map[string]struct{})
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if match != nil && !match(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files[rel] = struct{}{}
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,62 @@
+package dirchange_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for path, content := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base, target := t.TempDir(), t.TempDir()
+	writeFiles(t, base, map[string]string{
+		"a.go":          "a",
+		"x/b.go":        "b",
+		"x/c.go":        "c",
+		"x/skip/d.go":   "d",
+		"x/not-matched": "e",
+	})
+	writeFiles(t, target, map[string]string{
+		"a.go":          "a",
+		"x/b.go":        "changed",
+		"x/new.go":      "new",
+		"x/skip/d.go":   "changed",
+		"x/not-matched": "changed",
+	})
+
+	changes, err := dirchange.Diff(base, target, func(path string, isDir bool) bool {
+		if isDir {
+			return filepath.Base(path) != "skip"
+		}
+		return strings.HasSuffix(path, ".go")
+	})
+	require.NoError(t, err)
+	require.Equal(t, []dirchange.Change{
+		{Path: filepath.Join("x", "b.go"), Kind: dirchange.Modified},
+		{Path: filepath.Join("x", "c.go"), Kind: dirchange.Removed},
+		{Path: filepath.Join("x", "new.go"), Kind: dirchange.Added},
+	}, changes)
+}
+
+func TestDiffNoChange(t *testing.T) {
+	base, target := t.TempDir(), t.TempDir()
+	files := map[string]string{"a": "a", "x/b": "b"}
+	writeFiles(t, base, files)
+	writeFiles(t, target, files)
+
+	changes, err := dirchange.Diff(base, target, nil)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
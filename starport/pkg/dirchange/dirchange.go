@@ -68,6 +68,12 @@ func HasDirChecksumChanged(workdir string, paths []string, checksumSavePath stri
 	return true, nil
 }
 
+// Checksum computes the md5 checksum of the provided paths (directories or files)
+// paths are relative to workdir, if workdir is empty string paths are absolute
+func Checksum(workdir string, paths []string) ([]byte, error) {
+	return checksumFromPaths(workdir, paths)
+}
+
 // checksumFromPaths computes the md5 checksum from the provided paths
 // paths are relative to workdir, if workdir is empty string paths are absolute
 func checksumFromPaths(workdir string, paths []string) ([]byte, error) {
@@ -0,0 +1,198 @@
+package eip712
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
+)
+
+// domain of the txs signed for Ethermint based chains.
+const (
+	domainName              = "Cosmos Web3"
+	domainVersion           = "1.0.0"
+	domainVerifyingContract = "cosmos"
+	domainSalt              = "0"
+)
+
+const (
+	txType       = "Tx"
+	msgValueType = "MsgValue"
+)
+
+// txTypes are the types of a Cosmos SDK tx, the type of msg values are inferred from the msgs.
+var txTypes = Types{
+	domainType: {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "string"},
+		{Name: "salt", Type: "string"},
+	},
+	txType: {
+		{Name: "account_number", Type: "string"},
+		{Name: "chain_id", Type: "string"},
+		{Name: "fee", Type: "Fee"},
+		{Name: "memo", Type: "string"},
+		{Name: "msgs", Type: "Msg[]"},
+		{Name: "sequence", Type: "string"},
+	},
+	"Fee": {
+		{Name: "feePayer", Type: "string"},
+		{Name: "amount", Type: "Coin[]"},
+		{Name: "gas", Type: "string"},
+	},
+	"Coin": {
+		{Name: "denom", Type: "string"},
+		{Name: "amount", Type: "string"},
+	},
+	"Msg": {
+		{Name: "type", Type: "string"},
+		{Name: "value", Type: msgValueType},
+	},
+}
+
+// WrapTxToTypedData wraps the amino JSON sign doc of a tx into typed data as it's done by
+// Ethermint based chains to verify txs signed with EIP-712. eip155ChainID is the EIP-155 chain
+// id of the chain and feePayer is the address of the account that pays the fees.
+// all msgs of the tx need to be of the same type.
+func WrapTxToTypedData(eip155ChainID uint64, feePayer string, signDoc []byte) (TypedData, error) {
+	var message map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(signDoc))
+	decoder.UseNumber()
+	if err := decoder.Decode(&message); err != nil {
+		return TypedData{}, errors.Wrap(err, "invalid sign doc")
+	}
+
+	fee, ok := message["fee"].(map[string]interface{})
+	if !ok {
+		return TypedData{}, errors.New("invalid sign doc: fee is missing")
+	}
+	fee["feePayer"] = feePayer
+
+	msgs, ok := message["msgs"].([]interface{})
+	if !ok || len(msgs) == 0 {
+		return TypedData{}, errors.New("invalid sign doc: msgs are missing")
+	}
+
+	types := make(Types)
+	for name, fields := range txTypes {
+		types[name] = fields
+	}
+
+	var msgType string
+	for i, m := range msgs {
+		msg, ok := m.(map[string]interface{})
+		if !ok {
+			return TypedData{}, fmt.Errorf("invalid sign doc: msg %d is not an object", i)
+		}
+
+		t, _ := msg["type"].(string)
+		switch {
+		case i == 0:
+			msgType = t
+			value, _ := msg["value"].(map[string]interface{})
+			if err := inferTypes(types, msgValueType, "Type", value); err != nil {
+				return TypedData{}, err
+			}
+		case t != msgType:
+			return TypedData{}, fmt.Errorf("msgs must be of the same type, got %q and %q", msgType, t)
+		}
+	}
+
+	return TypedData{
+		Types:       types,
+		PrimaryType: txType,
+		Domain: map[string]interface{}{
+			"name":              domainName,
+			"version":           domainVersion,
+			"chainId":           eip155ChainID,
+			"verifyingContract": domainVerifyingContract,
+			"salt":              domainSalt,
+		},
+		Message: message,
+	}, nil
+}
+
+// inferTypes adds the struct type inferred from the JSON object to types, nested objects are added as
+// separate types that are named after prefix and the field path.
+func inferTypes(types Types, typeName, prefix string, object map[string]interface{}) error {
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := []Type{}
+
+	for _, name := range names {
+		fieldType, err := inferType(types, prefix+strcase.ToCamel(name), object[name])
+		if err != nil {
+			return errors.Wrapf(err, "field %q", name)
+		}
+		// fields without values cannot be typed, they're encoded as empty strings.
+		if fieldType == "" {
+			fieldType = "string"
+		}
+		fields = append(fields, Type{Name: name, Type: fieldType})
+	}
+
+	if existing, ok := types[typeName]; ok && !sameTypes(existing, fields) {
+		return fmt.Errorf("type %q is inferred differently", typeName)
+	}
+	types[typeName] = fields
+
+	return nil
+}
+
+func inferType(types Types, typeName string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return "string", nil
+	case bool:
+		return "bool", nil
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "", fmt.Errorf("%s is not an integer", v)
+		}
+		return "int64", nil
+	case map[string]interface{}:
+		return typeName, inferTypes(types, typeName, typeName, v)
+	case []interface{}:
+		itemType := ""
+		for _, item := range v {
+			t, err := inferType(types, typeName, item)
+			if err != nil {
+				return "", err
+			}
+			if itemType != "" && t != itemType {
+				return "", errors.New("array items must be of the same type")
+			}
+			itemType = t
+		}
+		if itemType == "" {
+			itemType = "string"
+		}
+		return itemType + "[]", nil
+	}
+	return "", fmt.Errorf("%v cannot be typed", value)
+}
+
+func sameTypes(a, b []Type) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
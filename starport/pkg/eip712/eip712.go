@@ -0,0 +1,338 @@
+// Package eip712 implements hashing of EIP-712 typed structured data, so data can be signed by
+// Ethereum keys and wallets in a human readable form.
+//
+// See https://eips.ethereum.org/EIPS/eip-712.
+package eip712
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// domainType is the name of the domain type.
+const domainType = "EIP712Domain"
+
+var (
+	reArray = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+	reInt   = regexp.MustCompile(`^(u?)int(\d*)$`)
+	reBytes = regexp.MustCompile(`^bytes(\d+)$`)
+)
+
+// Type is a field of a struct type.
+type Type struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types are the struct types of typed data by their names.
+type Types map[string][]Type
+
+// TypedData is EIP-712 typed structured data.
+type TypedData struct {
+	// Types are the struct types used by the domain and the message, it needs to include
+	// the EIP712Domain type.
+	Types Types `json:"types"`
+
+	// PrimaryType is the type of the message.
+	PrimaryType string `json:"primaryType"`
+
+	// Domain is the domain separator of the message.
+	Domain map[string]interface{} `json:"domain"`
+
+	// Message is the data to be signed.
+	Message map[string]interface{} `json:"message"`
+}
+
+// SignBytes returns the bytes to be signed, which are hashed with Keccak-256 while signing with an
+// Ethereum key: 0x19 0x01 || hashStruct(domain) || hashStruct(message).
+func (d TypedData) SignBytes() ([]byte, error) {
+	domainHash, err := d.HashStruct(domainType, d.Domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "domain")
+	}
+
+	messageHash, err := d.HashStruct(d.PrimaryType, d.Message)
+	if err != nil {
+		return nil, errors.Wrap(err, "message")
+	}
+
+	return bytes.Join([][]byte{{0x19, 0x01}, domainHash, messageHash}, nil), nil
+}
+
+// Hash returns the hash of the typed data that is signed.
+func (d TypedData) Hash() ([]byte, error) {
+	signBytes, err := d.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	return keccak256(signBytes), nil
+}
+
+// HashStruct hashes data of the struct type as keccak256(typeHash || encodeData(data)).
+func (d TypedData) HashStruct(typeName string, data map[string]interface{}) ([]byte, error) {
+	encoded, err := d.encodeData(typeName, data)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256(encoded), nil
+}
+
+// EncodeType encodes the struct type with the types it references, e.g.
+// Mail(Person from,Person to,string contents)Person(string name,address wallet).
+func (d TypedData) EncodeType(typeName string) (string, error) {
+	deps, err := d.dependencies(typeName, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// the struct type comes first and the types it references are sorted by their names.
+	sort.Strings(deps[1:])
+
+	var b strings.Builder
+	for _, dep := range deps {
+		b.WriteString(dep)
+		b.WriteString("(")
+		for i, field := range d.Types[dep] {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(field.Type)
+			b.WriteString(" ")
+			b.WriteString(field.Name)
+		}
+		b.WriteString(")")
+	}
+
+	return b.String(), nil
+}
+
+func (d TypedData) dependencies(typeName string, found []string) ([]string, error) {
+	typeName = baseType(typeName)
+
+	for _, name := range found {
+		if name == typeName {
+			return found, nil
+		}
+	}
+
+	fields, ok := d.Types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("type %q is not defined", typeName)
+	}
+
+	found = append(found, typeName)
+
+	var err error
+	for _, field := range fields {
+		if _, ok := d.Types[baseType(field.Type)]; !ok {
+			continue
+		}
+		if found, err = d.dependencies(field.Type, found); err != nil {
+			return nil, err
+		}
+	}
+
+	return found, nil
+}
+
+func (d TypedData) encodeData(typeName string, data map[string]interface{}) ([]byte, error) {
+	encodedType, err := d.EncodeType(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := [][]byte{keccak256([]byte(encodedType))}
+
+	for _, field := range d.Types[typeName] {
+		value, err := d.encodeValue(field.Type, data[field.Name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %q", field.Name)
+		}
+		encoded = append(encoded, value)
+	}
+
+	return bytes.Join(encoded, nil), nil
+}
+
+// encodeValue encodes value of the type in 32 bytes.
+func (d TypedData) encodeValue(typeName string, value interface{}) ([]byte, error) {
+	// arrays are encoded as the hash of their encoded items.
+	if match := reArray.FindStringSubmatch(typeName); match != nil {
+		items, ok := value.([]interface{})
+		if !ok && value != nil {
+			return nil, fmt.Errorf("%v is not an array", value)
+		}
+
+		var encoded [][]byte
+		for _, item := range items {
+			v, err := d.encodeValue(match[1], item)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, v)
+		}
+		return keccak256(bytes.Join(encoded, nil)), nil
+	}
+
+	// structs are encoded as their hashes.
+	if _, ok := d.Types[typeName]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok && value != nil {
+			return nil, fmt.Errorf("%v is not a struct", value)
+		}
+		return d.HashStruct(typeName, data)
+	}
+
+	switch {
+	case typeName == "string":
+		s, err := toString(value)
+		if err != nil {
+			return nil, err
+		}
+		return keccak256([]byte(s)), nil
+
+	case typeName == "bytes":
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return keccak256(b), nil
+
+	case typeName == "bool":
+		b, ok := value.(bool)
+		if !ok && value != nil {
+			return nil, fmt.Errorf("%v is not a bool", value)
+		}
+		if b {
+			return padLeft([]byte{1}), nil
+		}
+		return padLeft(nil), nil
+
+	case typeName == "address":
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != 20 {
+			return nil, fmt.Errorf("%v is not an address", value)
+		}
+		return padLeft(b), nil
+
+	case reBytes.MatchString(typeName):
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > 32 {
+			return nil, fmt.Errorf("%v is longer than 32 bytes", value)
+		}
+		encoded := make([]byte, 32)
+		copy(encoded, b)
+		return encoded, nil
+
+	case reInt.MatchString(typeName):
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		unsigned := reInt.FindStringSubmatch(typeName)[1] == "u"
+		if unsigned && n.Sign() < 0 {
+			return nil, fmt.Errorf("%v is negative", value)
+		}
+		return encodeInt(n), nil
+	}
+
+	return nil, fmt.Errorf("unknown type %q", typeName)
+}
+
+// baseType returns the item type of array types.
+func baseType(typeName string) string {
+	for {
+		match := reArray.FindStringSubmatch(typeName)
+		if match == nil {
+			return typeName
+		}
+		typeName = match[1]
+	}
+}
+
+func toString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("%v is not a string", value)
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return hex.DecodeString(strings.TrimPrefix(v, "0x"))
+	}
+	return nil, fmt.Errorf("%v is not bytes", value)
+}
+
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case nil:
+		return new(big.Int), nil
+	case *big.Int:
+		return v, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return toBigInt(v.String())
+	case string:
+		n, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a number", v)
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("%v is not a number", value)
+}
+
+// encodeInt encodes n as a 256 bits two's complement number.
+func encodeInt(n *big.Int) []byte {
+	if n.Sign() >= 0 {
+		return padLeft(n.Bytes())
+	}
+
+	n = new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 256), n)
+	return padLeft(n.Bytes())
+}
+
+func padLeft(b []byte) []byte {
+	padded := make([]byte, 32)
+	copy(padded[32-len(b):], b)
+	return padded
+}
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
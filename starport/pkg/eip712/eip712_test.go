@@ -0,0 +1,137 @@
+package eip712_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/eip712"
+)
+
+// mail is the example of the EIP-712 specification.
+const mail = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestHash(t *testing.T) {
+	var data eip712.TypedData
+	require.NoError(t, json.Unmarshal([]byte(mail), &data))
+
+	encodedType, err := data.EncodeType("Mail")
+	require.NoError(t, err)
+	require.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", encodedType)
+
+	domainHash, err := data.HashStruct("EIP712Domain", data.Domain)
+	require.NoError(t, err)
+	require.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domainHash))
+
+	messageHash, err := data.HashStruct("Mail", data.Message)
+	require.NoError(t, err)
+	require.Equal(t, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToString(messageHash))
+
+	hash, err := data.Hash()
+	require.NoError(t, err)
+	require.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+}
+
+func TestHashUndefinedType(t *testing.T) {
+	var data eip712.TypedData
+	require.NoError(t, json.Unmarshal([]byte(mail), &data))
+	data.PrimaryType = "Letter"
+
+	_, err := data.Hash()
+	require.Error(t, err)
+}
+
+func TestWrapTxToTypedData(t *testing.T) {
+	signDoc := `{
+		"account_number": "3",
+		"chain_id": "evmos_9000-1",
+		"fee": {"amount": [{"amount": "20", "denom": "aevmos"}], "gas": "200000"},
+		"memo": "",
+		"msgs": [
+			{
+				"type": "cosmos-sdk/MsgSend",
+				"value": {
+					"amount": [{"amount": "10", "denom": "aevmos"}],
+					"from_address": "evmos1from",
+					"to_address": "evmos1to"
+				}
+			},
+			{
+				"type": "cosmos-sdk/MsgSend",
+				"value": {
+					"amount": [{"amount": "5", "denom": "aevmos"}],
+					"from_address": "evmos1from",
+					"to_address": "evmos1other"
+				}
+			}
+		],
+		"sequence": "7"
+	}`
+
+	data, err := eip712.WrapTxToTypedData(9000, "evmos1from", []byte(signDoc))
+	require.NoError(t, err)
+	require.Equal(t, "Tx", data.PrimaryType)
+	require.Equal(t, []eip712.Type{
+		{Name: "amount", Type: "TypeAmount[]"},
+		{Name: "from_address", Type: "string"},
+		{Name: "to_address", Type: "string"},
+	}, data.Types["MsgValue"])
+	require.Equal(t, []eip712.Type{
+		{Name: "amount", Type: "string"},
+		{Name: "denom", Type: "string"},
+	}, data.Types["TypeAmount"])
+
+	encodedType, err := data.EncodeType("Tx")
+	require.NoError(t, err)
+	require.Equal(t,
+		"Tx(string account_number,string chain_id,Fee fee,string memo,Msg[] msgs,string sequence)"+
+			"Coin(string denom,string amount)"+
+			"Fee(string feePayer,Coin[] amount,string gas)"+
+			"Msg(string type,MsgValue value)"+
+			"MsgValue(TypeAmount[] amount,string from_address,string to_address)"+
+			"TypeAmount(string amount,string denom)",
+		encodedType,
+	)
+
+	_, err = data.Hash()
+	require.NoError(t, err)
+
+	// msgs of different types cannot be wrapped.
+	_, err = eip712.WrapTxToTypedData(9000, "evmos1from", []byte(`{
+		"fee": {"amount": [], "gas": "1"},
+		"msgs": [{"type": "a", "value": {}}, {"type": "b", "value": {}}]
+	}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package ethsecp256k1
+
+import (
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/go-bip39"
+)
+
+const (
+	// Type is the keyring algorithm type of the keys.
+	Type = hd.PubKeyType(KeyType)
+
+	// CoinType is the BIP44 coin type of Ethereum that is used by Ethermint based chains.
+	CoinType = 60
+)
+
+// Algo derives and generates the keys in a keyring.
+var Algo = algo{}
+
+type algo struct{}
+
+// Name returns the type of the algorithm.
+func (algo) Name() hd.PubKeyType {
+	return Type
+}
+
+// Derive derives a private key from a mnemonic with the BIP32 HD path.
+// the derivation is the same with the derivation of secp256k1 keys.
+func (algo) Derive() hd.DeriveFn {
+	return func(mnemonic, bip39Passphrase, path string) ([]byte, error) {
+		seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		master, ch := hd.ComputeMastersFromSeed(seed)
+		if len(path) == 0 {
+			return master[:], nil
+		}
+		return hd.DerivePrivateKeyForPath(master, ch, path)
+	}
+}
+
+// Generate generates a private key from the derived key.
+func (algo) Generate() hd.GenerateFn {
+	return func(bz []byte) cryptotypes.PrivKey {
+		key := make([]byte, PrivKeySize)
+		copy(key, bz)
+		return &PrivKey{Key: key}
+	}
+}
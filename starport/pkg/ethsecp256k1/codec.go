@@ -0,0 +1,24 @@
+package ethsecp256k1
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func init() {
+	proto.RegisterType((*PubKey)(nil), "ethermint.crypto.v1.ethsecp256k1.PubKey")
+	proto.RegisterType((*PrivKey)(nil), "ethermint.crypto.v1.ethsecp256k1.PrivKey")
+
+	// keyring stores keys encoded with the global amino codec.
+	legacy.Cdc.RegisterConcrete(&PubKey{}, PubKeyName, nil)
+	legacy.Cdc.RegisterConcrete(&PrivKey{}, PrivKeyName, nil)
+}
+
+// RegisterInterfaces registers the keys to the interface registry, so they can be packed into
+// and unpacked from Any values in txs and accounts.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*cryptotypes.PubKey)(nil), &PubKey{})
+	registry.RegisterImplementations((*cryptotypes.PrivKey)(nil), &PrivKey{})
+}
@@ -0,0 +1,236 @@
+// Package ethsecp256k1 provides the Ethereum flavored secp256k1 keys used by Ethermint based chains.
+// Addresses are derived from the keys like Ethereum addresses and messages are signed with their
+// Keccak-256 hash in [R || S || V] format.
+package ethsecp256k1
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// PrivKeySize is the size of a private key in bytes.
+	PrivKeySize = 32
+
+	// PubKeySize is the size of a compressed public key in bytes.
+	PubKeySize = 33
+
+	// SignatureSize is the size of a signature in [R || S || V] format in bytes.
+	SignatureSize = 65
+
+	// KeyType is the type of the keys.
+	KeyType = "eth_secp256k1"
+
+	// PrivKeyName is the amino name of the private key.
+	PrivKeyName = "ethermint/PrivKeyEthSecp256k1"
+
+	// PubKeyName is the amino name of the public key.
+	PubKeyName = "ethermint/PubKeyEthSecp256k1"
+)
+
+var (
+	_ cryptotypes.PrivKey  = &PrivKey{}
+	_ codec.AminoMarshaler = &PrivKey{}
+	_ cryptotypes.PubKey   = &PubKey{}
+	_ codec.AminoMarshaler = &PubKey{}
+)
+
+// secp256k1halfN is used to reject malleable signatures.
+var secp256k1halfN = new(big.Int).Rsh(btcec.S256().N, 1)
+
+// PrivKey is an Ethereum flavored secp256k1 private key.
+type PrivKey struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+// GenPrivKey generates a new random private key.
+func GenPrivKey() (*PrivKey, error) {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	return &PrivKey{Key: paddedBytes(key.D, PrivKeySize)}, nil
+}
+
+func (k *PrivKey) Reset()         { *k = PrivKey{} }
+func (k *PrivKey) String() string { return proto.CompactTextString(k) }
+func (*PrivKey) ProtoMessage()    {}
+
+// Bytes returns the raw private key.
+func (k *PrivKey) Bytes() []byte {
+	return k.Key
+}
+
+// PubKey returns the compressed public key of the private key.
+func (k *PrivKey) PubKey() cryptotypes.PubKey {
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), k.Key)
+	return &PubKey{Key: pub.SerializeCompressed()}
+}
+
+// Equals checks if the private keys are the same.
+func (k *PrivKey) Equals(other cryptotypes.LedgerPrivKey) bool {
+	return k.Type() == other.Type() && subtle.ConstantTimeCompare(k.Bytes(), other.Bytes()) == 1
+}
+
+// Type returns the type of the key.
+func (k *PrivKey) Type() string {
+	return KeyType
+}
+
+// Sign signs the Keccak-256 hash of msg and returns the signature in [R || S || V] format
+// where V is 0 or 1.
+func (k *PrivKey) Sign(msg []byte) ([]byte, error) {
+	return k.SignHash(Keccak256(msg))
+}
+
+// SignHash signs the hash and returns the signature in [R || S || V] format where V is 0 or 1.
+func (k *PrivKey) SignHash(hash []byte) ([]byte, error) {
+	if len(k.Key) != PrivKeySize {
+		return nil, fmt.Errorf("invalid private key size %d", len(k.Key))
+	}
+
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), k.Key)
+
+	// compact signatures are in [V || R || S] format where V is 27 + recovery id for uncompressed keys.
+	sig, err := btcec.SignCompact(btcec.S256(), priv, hash, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(sig[1:], sig[0]-27), nil
+}
+
+func (k PrivKey) MarshalAmino() ([]byte, error) {
+	return k.Key, nil
+}
+
+func (k *PrivKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PrivKeySize {
+		return fmt.Errorf("invalid private key size %d", len(bz))
+	}
+	k.Key = bz
+	return nil
+}
+
+func (k PrivKey) MarshalAminoJSON() ([]byte, error) {
+	return k.MarshalAmino()
+}
+
+func (k *PrivKey) UnmarshalAminoJSON(bz []byte) error {
+	return k.UnmarshalAmino(bz)
+}
+
+// PubKey is an Ethereum flavored secp256k1 public key in compressed format.
+type PubKey struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (k *PubKey) Reset()      { *k = PubKey{} }
+func (*PubKey) ProtoMessage() {}
+
+// String returns the public key in hex.
+func (k *PubKey) String() string {
+	return fmt.Sprintf("EthPubKeySecp256k1{%X}", k.Key)
+}
+
+// Address returns the Ethereum address of the public key, that is the last 20 bytes of the
+// Keccak-256 hash of the uncompressed public key.
+func (k *PubKey) Address() tmcrypto.Address {
+	pub, err := btcec.ParsePubKey(k.Key, btcec.S256())
+	if err != nil {
+		panic(err)
+	}
+	return tmcrypto.Address(Keccak256(pub.SerializeUncompressed()[1:])[12:])
+}
+
+// Bytes returns the compressed public key.
+func (k *PubKey) Bytes() []byte {
+	return k.Key
+}
+
+// Type returns the type of the key.
+func (k *PubKey) Type() string {
+	return KeyType
+}
+
+// Equals checks if the public keys are the same.
+func (k *PubKey) Equals(other cryptotypes.PubKey) bool {
+	return k.Type() == other.Type() && bytes.Equal(k.Bytes(), other.Bytes())
+}
+
+// VerifySignature verifies the signature of msg in [R || S || V] or [R || S] format. signatures
+// that are not in lower-S form are rejected.
+func (k *PubKey) VerifySignature(msg, sig []byte) bool {
+	return k.VerifyHashSignature(Keccak256(msg), sig)
+}
+
+// VerifyHashSignature verifies the signature of hash in [R || S || V] or [R || S] format.
+func (k *PubKey) VerifyHashSignature(hash, sig []byte) bool {
+	if len(sig) == SignatureSize {
+		sig = sig[:SignatureSize-1]
+	}
+	if len(sig) != SignatureSize-1 {
+		return false
+	}
+
+	pub, err := btcec.ParsePubKey(k.Key, btcec.S256())
+	if err != nil {
+		return false
+	}
+
+	signature := &btcec.Signature{
+		R: new(big.Int).SetBytes(sig[:32]),
+		S: new(big.Int).SetBytes(sig[32:]),
+	}
+	if signature.S.Cmp(secp256k1halfN) > 0 {
+		return false
+	}
+
+	return signature.Verify(hash, pub)
+}
+
+func (k PubKey) MarshalAmino() ([]byte, error) {
+	return k.Key, nil
+}
+
+func (k *PubKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PubKeySize {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, "invalid pubkey size")
+	}
+	k.Key = bz
+	return nil
+}
+
+func (k PubKey) MarshalAminoJSON() ([]byte, error) {
+	return k.MarshalAmino()
+}
+
+func (k *PubKey) UnmarshalAminoJSON(bz []byte) error {
+	return k.UnmarshalAmino(bz)
+}
+
+// Keccak256 returns the Keccak-256 hash of data.
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+func paddedBytes(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
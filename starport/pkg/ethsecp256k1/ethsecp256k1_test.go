@@ -0,0 +1,74 @@
+package ethsecp256k1_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/ethsecp256k1"
+)
+
+func TestAddress(t *testing.T) {
+	key, err := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+
+	privKey := &ethsecp256k1.PrivKey{Key: key}
+	require.Equal(t,
+		"2c7536e3605d9c16a7a3d7b1898e529396a65c23",
+		hex.EncodeToString(privKey.PubKey().Address()),
+	)
+}
+
+func TestSign(t *testing.T) {
+	privKey, err := ethsecp256k1.GenPrivKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey()
+
+	msg := []byte("hello")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, ethsecp256k1.SignatureSize)
+	require.Contains(t, []byte{0, 1}, sig[64])
+
+	require.True(t, pubKey.VerifySignature(msg, sig))
+	require.True(t, pubKey.VerifySignature(msg, sig[:64]))
+	require.False(t, pubKey.VerifySignature([]byte("other"), sig))
+
+	other, err := ethsecp256k1.GenPrivKey()
+	require.NoError(t, err)
+	require.False(t, other.PubKey().VerifySignature(msg, sig))
+}
+
+func TestCodec(t *testing.T) {
+	privKey, err := ethsecp256k1.GenPrivKey()
+	require.NoError(t, err)
+
+	// amino is used by the keyring.
+	bz, err := legacy.Cdc.Marshal(privKey)
+	require.NoError(t, err)
+	decodedPrivKey, err := legacy.PrivKeyFromBytes(bz)
+	require.NoError(t, err)
+	require.True(t, privKey.Equals(decodedPrivKey))
+
+	bz, err = legacy.Cdc.Marshal(privKey.PubKey())
+	require.NoError(t, err)
+	decodedPubKey, err := legacy.PubKeyFromBytes(bz)
+	require.NoError(t, err)
+	require.True(t, privKey.PubKey().Equals(decodedPubKey))
+
+	// protobuf is used by txs.
+	registry := codectypes.NewInterfaceRegistry()
+	ethsecp256k1.RegisterInterfaces(registry)
+
+	any, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+	require.Equal(t, "/ethermint.crypto.v1.ethsecp256k1.PubKey", any.TypeUrl)
+
+	var pubKey cryptotypes.PubKey
+	require.NoError(t, registry.UnpackAny(&codectypes.Any{TypeUrl: any.TypeUrl, Value: any.Value}, &pubKey))
+	require.True(t, privKey.PubKey().Equals(pubKey))
+}
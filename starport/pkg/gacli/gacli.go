@@ -2,8 +2,10 @@
 package gacli
 
 import (
+	"context"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
@@ -34,7 +36,7 @@ type Metric struct {
 }
 
 // Send sends metrics to GA.
-func (c *Client) Send(metric Metric) error {
+func (c *Client) Send(ctx context.Context, metric Metric) error {
 	v := url.Values{
 		"v":   {"1"},
 		"tid": {c.gaid},
@@ -54,7 +56,12 @@ func (c *Client) Send(metric Metric) error {
 		v.Set("an", metric.Version)
 		v.Set("av", metric.Version)
 	}
-	resp, err := http.PostForm(endpoint, v)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(v.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
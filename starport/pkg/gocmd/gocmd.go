@@ -1,6 +1,7 @@
 package gocmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -28,18 +29,29 @@ const (
 
 	// CommandModVerify represents go mod "verify" command.
 	CommandModVerify = "verify"
+
+	// CommandVersion represents go "version" command.
+	CommandVersion = "version"
+
+	// CommandEnv represents go "env" command.
+	CommandEnv = "env"
 )
 
 const (
 	FlagMod              = "-mod"
 	FlagModValueReadOnly = "readonly"
 	FlagLdflags          = "-ldflags"
+	FlagTags             = "-tags"
 	FlagOut              = "-o"
 )
 
 const (
-	EnvGOOS   = "GOOS"
-	EnvGOARCH = "GOARCH"
+	EnvGOOS       = "GOOS"
+	EnvGOARCH     = "GOARCH"
+	EnvGOCACHE    = "GOCACHE"
+	EnvGOMODCACHE = "GOMODCACHE"
+	EnvGOFLAGS    = "GOFLAGS"
+	EnvGOROOT     = "GOROOT"
 )
 
 // Name returns the name of Go binary to use.
@@ -51,6 +63,20 @@ func Name() string {
 	return "go"
 }
 
+// Version returns the version of Go, e.g. go version go1.16.5 linux/amd64.
+func Version(ctx context.Context) (string, error) {
+	var b bytes.Buffer
+	err := exec.Exec(ctx, []string{Name(), CommandVersion}, exec.StepOption(step.Stdout(&b)))
+	return strings.TrimSpace(b.String()), err
+}
+
+// Env returns the value of the Go env var with name, e.g. GOROOT.
+func Env(ctx context.Context, name string) (string, error) {
+	var b bytes.Buffer
+	err := exec.Exec(ctx, []string{Name(), CommandEnv, name}, exec.StepOption(step.Stdout(&b)))
+	return strings.TrimSpace(b.String()), err
+}
+
 // ModTidy runs go mod tidy on path with options.
 func ModTidy(ctx context.Context, path string, options ...exec.Option) error {
 	return exec.Exec(ctx, []string{Name(), CommandMod, CommandModTidy}, append(options, exec.StepOption(step.Workdir(path)))...)
@@ -63,7 +89,7 @@ func ModVerify(ctx context.Context, path string, options ...exec.Option) error {
 
 // BuildPath runs go install on cmd folder with options.
 func BuildPath(ctx context.Context, output, binary, path string, flags []string, options ...exec.Option) error {
-	binaryOutput, err := binaryPath(output, binary)
+	binaryOutput, err := BinaryPath(output, binary)
 	if err != nil {
 		return err
 	}
@@ -105,6 +131,20 @@ func Ldflags(flags ...string) string {
 	return strings.Join(flags, " ")
 }
 
+// Tags returns a combined build tags set from tags.
+func Tags(tags ...string) string {
+	return strings.Join(tags, ",")
+}
+
+// BinaryName returns the name of the binary built for goos, the binaries of Windows have the
+// .exe extension.
+func BinaryName(binary, goos string) string {
+	if goos == "windows" && !strings.HasSuffix(binary, ".exe") {
+		return binary + ".exe"
+	}
+	return binary
+}
+
 // BuildTarget builds a GOOS:GOARCH pair.
 func BuildTarget(goos, goarch string) string {
 	return fmt.Sprintf("%s:%s", goos, goarch)
@@ -125,8 +165,8 @@ func PackageLiteral(path, version string) string {
 	return fmt.Sprintf("%s@%s", path, version)
 }
 
-// binaryPath determines the path where binary will be located at.
-func binaryPath(output, binary string) (string, error) {
+// BinaryPath determines the path where binary will be located at.
+func BinaryPath(output, binary string) (string, error) {
 	if output != "" {
 		outputAbs, err := filepath.Abs(output)
 		if err != nil {
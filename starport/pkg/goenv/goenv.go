@@ -2,7 +2,6 @@
 package goenv
 
 import (
-	"fmt"
 	"go/build"
 	"os"
 	"path/filepath"
@@ -33,7 +32,7 @@ func Bin() string {
 
 // Path returns $PATH with correct go bin configuration set.
 func Path() string {
-	return os.ExpandEnv(fmt.Sprintf("$PATH:%s", Bin()))
+	return os.Getenv("PATH") + string(os.PathListSeparator) + Bin()
 }
 
 // ConfigurePath configures the env with correct $PATH that has go bin setup.
@@ -0,0 +1,434 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// errNull is returned while completing a value when a non-null field is null, the null propagates
+// to the closest nullable parent.
+var errNull = errors.New("null value of a non-null field")
+
+// Params are the params of a GraphQL request.
+type Params struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the response of a GraphQL request.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is an error of a GraphQL request, the path is the path of the field with the error in the
+// data of the response.
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+// Invoker invokes the methods of gRPC services with requests in JSON and returns their responses in
+// JSON, grpcui.Client is an invoker.
+type Invoker interface {
+	Invoke(ctx context.Context, service, method string, request []byte) ([]byte, error)
+}
+
+// request is the execution of an operation of a document.
+type request struct {
+	schema    *Schema
+	invoker   Invoker
+	doc       *document
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+// Execute executes the query of the params, the methods of the services are invoked with the invoker.
+// only queries are supported.
+func (s *Schema) Execute(ctx context.Context, invoker Invoker, params Params) Response {
+	doc, err := parse(params.Query)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	op, err := selectOperation(doc, params.OperationName)
+	if err != nil {
+		return errorResponse(err)
+	}
+	if op.kind != "query" {
+		return errorResponse(fmt.Errorf("only queries are supported, not %s", op.kind))
+	}
+
+	r := &request{
+		schema:  s,
+		invoker: invoker,
+		doc:     doc,
+	}
+	if r.variables, err = r.coerceVariables(op, params.Variables); err != nil {
+		return errorResponse(err)
+	}
+
+	var data interface{}
+	if object, err := r.executeSelections(ctx, s.query, op.selections, struct{}{}, nil); err == nil {
+		data = object
+	}
+	return Response{Data: data, Errors: r.errors}
+}
+
+func errorResponse(err error) Response {
+	e := &Error{Message: err.Error()}
+	var serr *SyntaxError
+	if errors.As(err, &serr) {
+		e.Locations = []Location{serr.Location}
+	}
+	return Response{Errors: []*Error{e}}
+}
+
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, errors.New("the operation name is required for a document with multiple operations")
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+func (r *request) coerceVariables(op *operation, given map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+	for _, v := range op.variables {
+		if value, ok := given[v.name]; ok {
+			variables[v.name] = value
+			continue
+		}
+		if v.hasDefault {
+			value, _ := r.value(v.defaultValue)
+			variables[v.name] = value
+			continue
+		}
+		if v.nonNull {
+			return nil, fmt.Errorf("variable $%s is required", v.name)
+		}
+	}
+	return variables, nil
+}
+
+// addError adds the error of the field at the path to the response.
+func (r *request) addError(f *field, path []interface{}, format string, args ...interface{}) {
+	r.errors = append(r.errors, &Error{
+		Message:   fmt.Sprintf(format, args...),
+		Locations: []Location{f.location},
+		Path:      append([]interface{}(nil), path...),
+	})
+}
+
+// fieldGroup are the fields of a selection set with the same response key.
+type fieldGroup struct {
+	key    string
+	fields []*field
+}
+
+// collectFields returns the fields of the selections on the type grouped by their response keys, the
+// fields of the fragments are included.
+func (r *request) collectFields(t *Type, selections []selection, visited map[string]bool, groups []*fieldGroup) []*fieldGroup {
+	for _, s := range selections {
+		switch s := s.(type) {
+		case *field:
+			if !r.included(s.directives) {
+				continue
+			}
+			key := s.responseKey()
+			found := false
+			for _, g := range groups {
+				if g.key == key {
+					g.fields = append(g.fields, s)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*field{s}})
+			}
+		case *fragmentSpread:
+			if !r.included(s.directives) || visited[s.name] {
+				continue
+			}
+			visited[s.name] = true
+			f, ok := r.doc.fragments[s.name]
+			if !ok || !r.included(f.directives) || (f.typeCondition != t.Name) {
+				continue
+			}
+			groups = r.collectFields(t, f.selections, visited, groups)
+		case *inlineFragment:
+			if !r.included(s.directives) || (s.typeCondition != "" && s.typeCondition != t.Name) {
+				continue
+			}
+			groups = r.collectFields(t, s.selections, visited, groups)
+		}
+	}
+	return groups
+}
+
+// included checks the @skip and @include directives.
+func (r *request) included(directives []*directive) bool {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			continue
+		}
+		var condition bool
+		for _, arg := range d.arguments {
+			if arg.name == "if" {
+				v, _ := r.value(arg.value)
+				condition, _ = v.(bool)
+			}
+		}
+		if (d.name == "skip") == condition {
+			return false
+		}
+	}
+	return true
+}
+
+// executeSelections executes the selections on the value of an object.
+func (r *request) executeSelections(ctx context.Context, t *Type, selections []selection, source interface{}, path []interface{}) (object, error) {
+	var (
+		groups = r.collectFields(t, selections, make(map[string]bool), nil)
+		o      = make(object, 0, len(groups))
+	)
+	for _, g := range groups {
+		value, err := r.executeField(ctx, t, g, source, append(path, g.key))
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, member{g.key, value})
+	}
+	return o, nil
+}
+
+func (r *request) executeField(ctx context.Context, t *Type, g *fieldGroup, source interface{}, path []interface{}) (interface{}, error) {
+	f := g.fields[0]
+	if f.name == "__typename" {
+		return t.Name, nil
+	}
+
+	def := t.Field(f.name)
+	if t == r.schema.query {
+		switch f.name {
+		case r.schema.schemaField.Name:
+			def = r.schema.schemaField
+		case r.schema.typeField.Name:
+			def = r.schema.typeField
+		}
+	}
+	if def == nil {
+		r.addError(f, path, "cannot query field %q on type %q", f.name, t.Name)
+		return nil, nil
+	}
+
+	var selections []selection
+	for _, f := range g.fields {
+		selections = append(selections, f.selections...)
+	}
+	switch named := namedType(def.Type); {
+	case named.Kind == KindObject && len(selections) == 0:
+		r.addError(f, path, "field %q of type %q must have a selection of subfields", f.name, def.Type)
+		return nil, nil
+	case named.Kind != KindObject && len(selections) > 0:
+		r.addError(f, path, "field %q of type %q must not have a selection of subfields", f.name, def.Type)
+		return nil, nil
+	}
+
+	args, err := r.coerceArguments(def, f.arguments)
+	if err != nil {
+		r.addError(f, path, "%s", err)
+		return nil, nullError(def.Type)
+	}
+
+	resolve := def.resolve
+	if resolve == nil {
+		resolve = resolveJSONField(def.Name)
+	}
+	value, err := resolve(ctx, r, source, args)
+	if err != nil {
+		r.addError(f, path, "%s", err)
+		return nil, nullError(def.Type)
+	}
+
+	return r.complete(ctx, def.Type, t.Name, f, selections, value, path)
+}
+
+// nullError returns errNull when the type is non-null.
+func nullError(t *Type) error {
+	if t.Kind == KindNonNull {
+		return errNull
+	}
+	return nil
+}
+
+// resolveJSONField resolves the value of a field from a JSON object.
+func resolveJSONField(name string) resolveFunc {
+	return func(ctx context.Context, r *request, source interface{}, args map[string]interface{}) (interface{}, error) {
+		if m, ok := source.(map[string]interface{}); ok {
+			return m[name], nil
+		}
+		return nil, nil
+	}
+}
+
+func (r *request) coerceArguments(def *Field, arguments []*argument) (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+	for _, arg := range arguments {
+		var known bool
+		for _, a := range def.Args {
+			known = known || a.Name == arg.name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown argument %q on field %q", arg.name, def.Name)
+		}
+
+		if v, ok := r.value(arg.value); ok {
+			args[arg.name] = v
+		}
+	}
+	for _, a := range def.Args {
+		if _, ok := args[a.Name]; !ok && a.Type.Kind == KindNonNull {
+			return nil, fmt.Errorf("argument %q of type %q is required", a.Name, a.Type)
+		}
+	}
+	return args, nil
+}
+
+// value returns the value of an argument as JSON values, ok is false for a variable without value.
+func (r *request) value(v interface{}) (value interface{}, ok bool) {
+	switch v := v.(type) {
+	case variable:
+		value, ok = r.variables[string(v)]
+		return value, ok
+	case enumValue:
+		return string(v), true
+	case listValue:
+		list := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			value, _ := r.value(item)
+			list = append(list, value)
+		}
+		return list, true
+	case objectValue:
+		m := make(map[string]interface{})
+		for _, field := range v {
+			if value, ok := r.value(field.value); ok {
+				m[field.name] = value
+			}
+		}
+		return m, true
+	}
+	return v, true
+}
+
+// complete completes the value of a field with its type, the value of a non-null field that is null
+// returns errNull and the other nulls are absorbed.
+func (r *request) complete(
+	ctx context.Context,
+	t *Type,
+	parent string,
+	f *field,
+	selections []selection,
+	value interface{},
+	path []interface{},
+) (interface{}, error) {
+	if t.Kind == KindNonNull {
+		v, err := r.completeValue(ctx, t.OfType, parent, f, selections, value, path)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			r.addError(f, path, "cannot return null for non-null field %s.%s", parent, f.name)
+			return nil, errNull
+		}
+		return v, nil
+	}
+
+	v, err := r.completeValue(ctx, t, parent, f, selections, value, path)
+	if err != nil {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func (r *request) completeValue(
+	ctx context.Context,
+	t *Type,
+	parent string,
+	f *field,
+	selections []selection,
+	value interface{},
+	path []interface{},
+) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t.Kind {
+	case KindList:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice {
+			r.addError(f, path, "expected a list for field %s.%s", parent, f.name)
+			return nil, nil
+		}
+		list := make([]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, err := r.complete(ctx, t.OfType, parent, f, selections, rv.Index(i).Interface(), append(path, i))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	case KindObject:
+		return r.executeSelections(ctx, t, selections, value, path)
+	}
+	return value, nil
+}
+
+// object is a JSON object whose members keep the order of the selections.
+type object []member
+
+type member struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
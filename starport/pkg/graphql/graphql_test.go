@@ -0,0 +1,429 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func scalarField(name string, number int32, t descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     t.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+}
+
+func typedField(name string, number int32, t descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := scalarField(name, number, t)
+	f.TypeName = proto.String(typeName)
+	return f
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+func method(name, input, output string) *descriptorpb.MethodDescriptorProto {
+	return &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String(input),
+		OutputType: proto.String(output),
+	}
+}
+
+// testFiles returns the files of the query services of the blog and news modules of mars.
+func testFiles(t *testing.T) *protoregistry.Files {
+	const (
+		str    = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		uint64 = descriptorpb.FieldDescriptorProto_TYPE_UINT64
+		int32  = descriptorpb.FieldDescriptorProto_TYPE_INT32
+		msg    = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		enum   = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+	)
+
+	watch := method("Watch", ".alice.mars.blog.QueryGetPostRequest", ".alice.mars.blog.QueryGetPostResponse")
+	watch.ServerStreaming = proto.Bool(true)
+
+	blog := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("blog/query.proto"),
+		Package: proto.String("alice.mars.blog"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("DRAFT"), Number: proto.Int32(0)},
+				{Name: proto.String("PUBLISHED"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Post"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					scalarField("id", 1, str),
+					scalarField("title", 2, str),
+					scalarField("count", 3, uint64),
+					repeated(scalarField("tags", 4, str)),
+					typedField("status", 5, enum, ".alice.mars.blog.Status"),
+					typedField("parent", 6, msg, ".alice.mars.blog.Post"),
+				},
+			},
+			{
+				Name:  proto.String("Filter"),
+				Field: []*descriptorpb.FieldDescriptorProto{repeated(scalarField("tags", 1, str))},
+			},
+			{
+				Name:  proto.String("QueryGetPostRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{scalarField("id", 1, str)},
+			},
+			{
+				Name:  proto.String("QueryGetPostResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{typedField("post", 1, msg, ".alice.mars.blog.Post")},
+			},
+			{
+				Name:  proto.String("QueryAllPostRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{typedField("filter", 1, msg, ".alice.mars.blog.Filter")},
+			},
+			{
+				Name:  proto.String("QueryAllPostResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{repeated(typedField("post", 1, msg, ".alice.mars.blog.Post"))},
+			},
+			{Name: proto.String("QueryParamsRequest")},
+			{Name: proto.String("QueryParamsResponse")},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Query"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("Params", ".alice.mars.blog.QueryParamsRequest", ".alice.mars.blog.QueryParamsResponse"),
+				method("Post", ".alice.mars.blog.QueryGetPostRequest", ".alice.mars.blog.QueryGetPostResponse"),
+				method("PostAll", ".alice.mars.blog.QueryAllPostRequest", ".alice.mars.blog.QueryAllPostResponse"),
+				watch,
+			},
+		}},
+	}
+
+	news := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("news/query.proto"),
+		Package: proto.String("alice.mars.news.v1beta1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("QueryParamsRequest")},
+			{
+				Name:  proto.String("QueryParamsResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{scalarField("maxLength", 1, int32)},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Query"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("Params", ".alice.mars.news.v1beta1.QueryParamsRequest", ".alice.mars.news.v1beta1.QueryParamsResponse"),
+			},
+		}},
+	}
+
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{blog, news},
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func testSchema(t *testing.T) *Schema {
+	s, err := NewSchema(testFiles(t), []string{"alice.mars.news.v1beta1.Query", "alice.mars.blog.Query"})
+	require.NoError(t, err)
+	return s
+}
+
+type invocation struct {
+	method  string
+	request string
+}
+
+// invoker returns the responses by the full names of the methods.
+type invoker struct {
+	responses   map[string]string
+	invocations []invocation
+}
+
+func (i *invoker) Invoke(ctx context.Context, service, method string, request []byte) ([]byte, error) {
+	name := service + "/" + method
+	i.invocations = append(i.invocations, invocation{name, string(request)})
+
+	res, ok := i.responses[name]
+	if !ok {
+		return nil, errors.New("rpc error: code = NotFound desc = not found")
+	}
+	return []byte(res), nil
+}
+
+func execute(t *testing.T, s *Schema, i Invoker, query string, variables map[string]interface{}) string {
+	res := s.Execute(context.Background(), i, Params{Query: query, Variables: variables})
+	out, err := json.Marshal(res)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestSchemaString(t *testing.T) {
+	require.Equal(t, `input BlogFilterInput {
+  tags: [String]
+}
+
+type BlogPost {
+  id: String
+  title: String
+  count: String
+  tags: [String]
+  status: BlogStatus
+  parent: BlogPost
+}
+
+"""The methods of alice.mars.blog.Query."""
+type BlogQuery {
+  """Invokes alice.mars.blog.Query/Params."""
+  params: JSON
+  """Invokes alice.mars.blog.Query/Post."""
+  post(id: String): BlogQueryGetPostResponse
+  """Invokes alice.mars.blog.Query/PostAll."""
+  postAll(filter: BlogFilterInput): BlogQueryAllPostResponse
+}
+
+type BlogQueryAllPostResponse {
+  post: [BlogPost]
+}
+
+type BlogQueryGetPostResponse {
+  post: BlogPost
+}
+
+enum BlogStatus {
+  DRAFT
+  PUBLISHED
+}
+
+"""A JSON value, e.g. an Any, a map or an empty message."""
+scalar JSON
+
+"""The methods of alice.mars.news.v1beta1.Query."""
+type NewsQuery {
+  """Invokes alice.mars.news.v1beta1.Query/Params."""
+  params: NewsQueryParamsResponse
+}
+
+type NewsQueryParamsResponse {
+  maxLength: Int
+}
+
+type Query {
+  """The methods of alice.mars.blog.Query."""
+  blog: BlogQuery
+  """The methods of alice.mars.news.v1beta1.Query."""
+  news: NewsQuery
+}
+`, testSchema(t).String())
+}
+
+func TestExecute(t *testing.T) {
+	i := &invoker{responses: map[string]string{
+		"alice.mars.blog.Query/Post":           `{"post": {"id": "1", "title": "hello", "count": "3", "tags": ["a", "b"], "status": "PUBLISHED", "parent": null}}`,
+		"alice.mars.blog.Query/PostAll":        `{"post": [{"id": "1", "title": "hello"}, {"id": "2", "title": "world"}]}`,
+		"alice.mars.news.v1beta1.Query/Params": `{"maxLength": 140}`,
+	}}
+
+	out := execute(t, testSchema(t), i, `
+		query Posts($id: String!, $tags: [String] = ["a"]) {
+			blog {
+				__typename
+				first: post(id: $id) { post { ...post tags status parent { id } } }
+				postAll(filter: {tags: $tags}) { post { id } }
+			}
+			news { params { maxLength } }
+		}
+
+		fragment post on BlogPost { id title count }
+	`, map[string]interface{}{"id": "1"})
+
+	require.JSONEq(t, `{"data": {
+		"blog": {
+			"__typename": "BlogQuery",
+			"first": {"post": {"id": "1", "title": "hello", "count": "3", "tags": ["a", "b"], "status": "PUBLISHED", "parent": null}},
+			"postAll": {"post": [{"id": "1"}, {"id": "2"}]}
+		},
+		"news": {"params": {"maxLength": 140}}
+	}}`, out)
+	require.Equal(t, []invocation{
+		{"alice.mars.blog.Query/Post", `{"id":"1"}`},
+		{"alice.mars.blog.Query/PostAll", `{"filter":{"tags":["a"]}}`},
+		{"alice.mars.news.v1beta1.Query/Params", `{}`},
+	}, i.invocations)
+}
+
+func TestExecuteKeepsOrder(t *testing.T) {
+	i := &invoker{responses: map[string]string{
+		"alice.mars.blog.Query/Post": `{"post": {"id": "1", "title": "hello"}}`,
+	}}
+
+	out := execute(t, testSchema(t), i, `{ blog { post(id: "1") { post { title id } } } }`, nil)
+	require.Equal(t, `{"data":{"blog":{"post":{"post":{"title":"hello","id":"1"}}}}}`, out)
+}
+
+func TestExecuteDirectives(t *testing.T) {
+	i := &invoker{responses: map[string]string{
+		"alice.mars.news.v1beta1.Query/Params": `{"maxLength": 140}`,
+	}}
+
+	out := execute(t, testSchema(t), i, `query ($skip: Boolean!) {
+		news {
+			skipped: params @skip(if: $skip) { maxLength }
+			included: params @include(if: true) { maxLength }
+			... on NewsQuery @include(if: false) { other: params { maxLength } }
+		}
+	}`, map[string]interface{}{"skip": true})
+	require.JSONEq(t, `{"data": {"news": {"included": {"maxLength": 140}}}}`, out)
+}
+
+func TestExecuteErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		response  string
+	}{
+		{
+			name:     "syntax error",
+			query:    "{ blog {",
+			response: `{"errors": [{"message": "syntax error at 1:9: expected name, found <EOF>", "locations": [{"line": 1, "column": 9}]}]}`,
+		},
+		{
+			name:     "mutation",
+			query:    "mutation { blog { params } }",
+			response: `{"errors": [{"message": "only queries are supported, not mutation"}]}`,
+		},
+		{
+			name:     "missing variable",
+			query:    "query ($id: String!) { blog { post(id: $id) { post { id } } } }",
+			response: `{"errors": [{"message": "variable $id is required"}]}`,
+		},
+		{
+			name:  "method error",
+			query: "{ blog { post { post { name } } } }",
+			response: `{
+				"data": {"blog": {"post": null}},
+				"errors": [{"message": "rpc error: code = NotFound desc = not found", "locations": [{"line": 1, "column": 10}], "path": ["blog", "post"]}]
+			}`,
+		},
+		{
+			name:  "unknown argument",
+			query: "{ news { params(id: 1) { maxLength } } }",
+			response: `{
+				"data": {"news": {"params": null}},
+				"errors": [{"message": "unknown argument \"id\" on field \"params\"", "locations": [{"line": 1, "column": 10}], "path": ["news", "params"]}]
+			}`,
+		},
+		{
+			name:  "missing selection",
+			query: "{ news { params } }",
+			response: `{
+				"data": {"news": {"params": null}},
+				"errors": [{"message": "field \"params\" of type \"NewsQueryParamsResponse\" must have a selection of subfields", "locations": [{"line": 1, "column": 10}], "path": ["news", "params"]}]
+			}`,
+		},
+		{
+			name:  "unknown type field",
+			query: "{ news { unknown } }",
+			response: `{
+				"data": {"news": {"unknown": null}},
+				"errors": [{"message": "cannot query field \"unknown\" on type \"NewsQuery\"", "locations": [{"line": 1, "column": 10}], "path": ["news", "unknown"]}]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.JSONEq(t, tt.response, execute(t, testSchema(t), &invoker{}, tt.query, tt.variables))
+		})
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	out := execute(t, testSchema(t), &invoker{}, `{
+		__schema {
+			queryType { name }
+			mutationType { name }
+			directives { name locations args { name type { kind ofType { name } } } }
+		}
+		__type(name: "BlogPost") {
+			kind
+			name
+			fields {
+				name
+				args { name }
+				type { kind name ofType { kind name } }
+			}
+		}
+		missing: __type(name: "Missing") { name }
+	}`, nil)
+
+	require.JSONEq(t, `{"data": {
+		"__schema": {
+			"queryType": {"name": "Query"},
+			"mutationType": null,
+			"directives": [
+				{"name": "include", "locations": ["FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"], "args": [{"name": "if", "type": {"kind": "NON_NULL", "ofType": {"name": "Boolean"}}}]},
+				{"name": "skip", "locations": ["FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"], "args": [{"name": "if", "type": {"kind": "NON_NULL", "ofType": {"name": "Boolean"}}}]}
+			]
+		},
+		"__type": {
+			"kind": "OBJECT",
+			"name": "BlogPost",
+			"fields": [
+				{"name": "id", "args": [], "type": {"kind": "SCALAR", "name": "String", "ofType": null}},
+				{"name": "title", "args": [], "type": {"kind": "SCALAR", "name": "String", "ofType": null}},
+				{"name": "count", "args": [], "type": {"kind": "SCALAR", "name": "String", "ofType": null}},
+				{"name": "tags", "args": [], "type": {"kind": "LIST", "name": null, "ofType": {"kind": "SCALAR", "name": "String"}}},
+				{"name": "status", "args": [], "type": {"kind": "ENUM", "name": "BlogStatus", "ofType": null}},
+				{"name": "parent", "args": [], "type": {"kind": "OBJECT", "name": "BlogPost", "ofType": null}}
+			]
+		},
+		"missing": null
+	}}`, out)
+}
+
+func TestIntrospectionTypes(t *testing.T) {
+	res := testSchema(t).Execute(context.Background(), &invoker{}, Params{
+		Query: "{ __schema { types { name kind } } }",
+	})
+	require.Empty(t, res.Errors)
+
+	out, err := json.Marshal(res.Data)
+	require.NoError(t, err)
+
+	var data struct {
+		Schema struct {
+			Types []struct {
+				Name string
+				Kind string
+			}
+		} `json:"__schema"`
+	}
+	require.NoError(t, json.Unmarshal(out, &data))
+
+	kinds := make(map[string]string)
+	for _, t := range data.Schema.Types {
+		kinds[t.Name] = t.Kind
+	}
+	require.Equal(t, "OBJECT", kinds["Query"])
+	require.Equal(t, "INPUT_OBJECT", kinds["BlogFilterInput"])
+	require.Equal(t, "SCALAR", kinds["JSON"])
+	require.Equal(t, "OBJECT", kinds["__Type"])
+	require.Equal(t, "ENUM", kinds["__TypeKind"])
+}
@@ -0,0 +1,114 @@
+package graphql
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+const (
+	// maxBodySize is the max. size of the body of the POST requests.
+	maxBodySize = 1 << 20
+
+	// maxQuerySize is the max. size of the query string of the GET requests.
+	maxQuerySize = 64 << 10
+)
+
+//go:embed index.tpl
+var index embed.FS
+
+// Client fetches the descriptors of the services of a gRPC server and invokes their methods,
+// grpcui.Client is a client.
+type Client interface {
+	Invoker
+
+	// Files returns the descriptors of the files of the services with the names of the services.
+	Files(ctx context.Context) (*protoregistry.Files, []string, error)
+}
+
+// Handler returns an http handler that serves the GraphQL API at /graphql, its schema at
+// /schema.graphql and a page to run queries at /.
+// the schema is created from the services of the client that include returns true for, all of them
+// when include is nil. it's created for each request since the services of the server may change
+// while it's restarted.
+func Handler(title string, client Client, include func(service string) bool) http.Handler {
+	t := template.Must(template.ParseFS(index, "index.tpl"))
+	router := mux.NewRouter()
+
+	load := func(ctx context.Context) (*Schema, error) {
+		files, names, err := client.Files(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var services []string
+		for _, name := range names {
+			if include == nil || include(name) {
+				services = append(services, name)
+			}
+		}
+		return NewSchema(files, services)
+	}
+
+	router.
+		HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			t.Execute(w, struct{ Title string }{title})
+		}).
+		Methods(http.MethodGet)
+
+	router.
+		HandleFunc("/schema.graphql", func(w http.ResponseWriter, r *http.Request) {
+			schema, err := load(r.Context())
+			if err != nil {
+				xhttp.ResponseJSON(w, http.StatusBadGateway, xhttp.NewErrorResponse(err))
+				return
+			}
+
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			io.WriteString(w, schema.String())
+		}).
+		Methods(http.MethodGet)
+
+	router.
+		HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+			var params Params
+			if r.Method == http.MethodGet {
+				if len(r.URL.RawQuery) > maxQuerySize {
+					xhttp.ResponseJSON(w, http.StatusRequestURITooLong,
+						errorResponse(fmt.Errorf("the query is larger than %d bytes", maxQuerySize)))
+					return
+				}
+				query := r.URL.Query()
+				params.Query = query.Get("query")
+				params.OperationName = query.Get("operationName")
+				if variables := query.Get("variables"); variables != "" {
+					if err := json.Unmarshal([]byte(variables), &params.Variables); err != nil {
+						xhttp.ResponseJSON(w, http.StatusBadRequest, errorResponse(err))
+						return
+					}
+				}
+			} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&params); err != nil {
+				xhttp.ResponseJSON(w, http.StatusBadRequest, errorResponse(err))
+				return
+			}
+
+			schema, err := load(r.Context())
+			if err != nil {
+				xhttp.ResponseJSON(w, http.StatusBadGateway, errorResponse(err))
+				return
+			}
+
+			xhttp.ResponseJSON(w, http.StatusOK, schema.Execute(r.Context(), client, params))
+		}).
+		Methods(http.MethodGet, http.MethodPost)
+
+	return router
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type client struct {
+	*invoker
+	files *protoregistry.Files
+}
+
+func (c client) Files(context.Context) (*protoregistry.Files, []string, error) {
+	return c.files, []string{"alice.mars.blog.Query", "alice.mars.news.v1beta1.Query"}, nil
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	c := client{
+		invoker: &invoker{responses: map[string]string{
+			"alice.mars.news.v1beta1.Query/Params": `{"maxLength": 140}`,
+		}},
+		files: testFiles(t),
+	}
+	s := httptest.NewServer(Handler("mars", c, func(service string) bool {
+		return service == "alice.mars.news.v1beta1.Query"
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func readBody(t *testing.T, res *http.Response) string {
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestHandlerQuery(t *testing.T) {
+	s := newTestServer(t)
+
+	res, err := http.Post(s.URL+"/graphql", "application/json", strings.NewReader(
+		`{"query": "query ($skip: Boolean!) { news { params @skip(if: $skip) { maxLength } } }", "variables": {"skip": false}}`,
+	))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.JSONEq(t, `{"data": {"news": {"params": {"maxLength": 140}}}}`, readBody(t, res))
+
+	res, err = http.Get(s.URL + "/graphql?" + url.Values{"query": {"{ blog { params } }"}}.Encode())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.JSONEq(t, `{
+		"data": {"blog": null},
+		"errors": [{"message": "cannot query field \"blog\" on type \"Query\"", "locations": [{"line": 1, "column": 3}], "path": ["blog"]}]
+	}`, readBody(t, res))
+}
+
+func TestHandlerLimits(t *testing.T) {
+	s := newTestServer(t)
+
+	res, err := http.Post(s.URL+"/graphql", "application/json", strings.NewReader(
+		`{"query": "`+strings.Repeat(" ", maxBodySize)+`{ news { params { maxLength } } }"}`,
+	))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	require.Contains(t, readBody(t, res), "request body too large")
+
+	res, err = http.Get(s.URL + "/graphql?" + url.Values{"query": {strings.Repeat(" ", maxQuerySize) + "{ news { params { maxLength } } }"}}.Encode())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusRequestURITooLong, res.StatusCode)
+	readBody(t, res)
+}
+
+func TestHandlerSchema(t *testing.T) {
+	s := newTestServer(t)
+
+	res, err := http.Get(s.URL + "/schema.graphql")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, `"""The methods of alice.mars.news.v1beta1.Query."""
+type NewsQuery {
+  """Invokes alice.mars.news.v1beta1.Query/Params."""
+  params: NewsQueryParamsResponse
+}
+
+type NewsQueryParamsResponse {
+  maxLength: Int
+}
+
+type Query {
+  """The methods of alice.mars.news.v1beta1.Query."""
+  news: NewsQuery
+}
+`, readBody(t, res))
+}
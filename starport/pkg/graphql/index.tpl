@@ -0,0 +1,80 @@
+<!DOCTYPE html>
+<html lang="en">
+    <head>
+        <meta charset="utf-8" />
+        <title>{{ .Title }} GraphQL</title>
+        <style>
+            body { margin: 0; display: flex; height: 100vh; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 14px; color: #222; }
+            nav { width: 420px; display: flex; flex-direction: column; border-right: 1px solid #ddd; background: #fafafa; }
+            nav h1 { font-size: 16px; margin: 0; padding: 16px; border-bottom: 1px solid #ddd; }
+            nav h1 small { display: block; color: #777; font-weight: normal; margin-top: 4px; }
+            nav pre { flex: 1; margin: 0; padding: 12px 16px; overflow: auto; font-family: Menlo, Consolas, monospace; font-size: 12px; }
+            main { flex: 1; display: flex; flex-direction: column; padding: 16px; overflow: hidden; }
+            main label { margin: 0 0 4px; color: #555; font-size: 12px; }
+            textarea, main pre { margin: 0 0 12px; padding: 8px; font-family: Menlo, Consolas, monospace; font-size: 13px; border: 1px solid #ddd; overflow: auto; }
+            #query, #response { flex: 2; }
+            #variables { flex: 1; }
+            main pre.error { color: #b3261e; }
+            button { align-self: flex-start; margin-bottom: 12px; padding: 6px 16px; }
+        </style>
+    </head>
+    <body>
+        <nav>
+            <h1>{{ .Title }}<small>POST queries to <a href="graphql">/graphql</a>, the schema is at <a href="schema.graphql">/schema.graphql</a></small></h1>
+            <pre id="schema">Loading schema...</pre>
+        </nav>
+        <main>
+            <label for="query">Query</label>
+            <textarea id="query" spellcheck="false">{
+  __schema {
+    queryType {
+      fields {
+        name
+      }
+    }
+  }
+}</textarea>
+            <label for="variables">Variables</label>
+            <textarea id="variables" spellcheck="false">{}</textarea>
+            <button id="run">Run</button>
+            <pre id="response"></pre>
+        </main>
+
+        <script>
+            const $ = (id) => document.getElementById(id);
+
+            async function run() {
+                const response = $("response");
+                response.className = "";
+                response.textContent = "Running...";
+
+                let variables;
+                try {
+                    variables = JSON.parse($("variables").value || "{}");
+                } catch (e) {
+                    response.className = "error";
+                    response.textContent = "invalid variables: " + e.message;
+                    return;
+                }
+
+                const res = await fetch("graphql", {
+                    method: "POST",
+                    headers: { "Content-Type": "application/json" },
+                    body: JSON.stringify({ query: $("query").value, variables }),
+                });
+                const body = await res.json();
+
+                if (body.errors) response.className = "error";
+                response.textContent = JSON.stringify(body, null, 2);
+            }
+
+            async function load() {
+                const res = await fetch("schema.graphql");
+                $("schema").textContent = res.ok ? await res.text() : (await res.json()).error.message;
+            }
+
+            $("run").onclick = run;
+            load();
+        </script>
+    </body>
+</html>
@@ -0,0 +1,171 @@
+package graphql
+
+import (
+	"context"
+)
+
+// addIntrospection adds the types of the introspection of the schema, the __schema and __type
+// fields of the queries are resolved from them.
+func (s *Schema) addIntrospection() {
+	var (
+		schemaType     = &Type{Kind: KindObject, Name: "__Schema"}
+		typeType       = &Type{Kind: KindObject, Name: "__Type"}
+		fieldType      = &Type{Kind: KindObject, Name: "__Field"}
+		inputValueType = &Type{Kind: KindObject, Name: "__InputValue"}
+		enumValueType  = &Type{Kind: KindObject, Name: "__EnumValue"}
+		directiveType  = &Type{Kind: KindObject, Name: "__Directive"}
+		typeKindType   = &Type{
+			Kind: KindEnum,
+			Name: "__TypeKind",
+			EnumValues: []string{
+				string(KindScalar), string(KindObject), "INTERFACE", "UNION", string(KindEnum),
+				string(KindInputObject), string(KindList), string(KindNonNull),
+			},
+		}
+		directiveLocationType = &Type{
+			Kind:       KindEnum,
+			Name:       "__DirectiveLocation",
+			EnumValues: []string{"QUERY", "FIELD", "FRAGMENT_DEFINITION", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		}
+	)
+
+	includeDeprecated := []*InputValue{{Name: "includeDeprecated", Type: booleanType, DefaultValue: "false"}}
+	meta := func(name string, t *Type, resolve func(source interface{}) interface{}) *Field {
+		return &Field{
+			Name: name,
+			Type: t,
+			resolve: func(ctx context.Context, r *request, source interface{}, args map[string]interface{}) (interface{}, error) {
+				return resolve(source), nil
+			},
+		}
+	}
+	none := func(interface{}) interface{} { return nil }
+	notDeprecated := func(interface{}) interface{} { return false }
+
+	schemaType.Fields = []*Field{
+		meta("description", stringType, none),
+		meta("types", nonNullOf(listOf(nonNullOf(typeType))), func(interface{}) interface{} {
+			return s.sortedTypes()
+		}),
+		meta("queryType", nonNullOf(typeType), func(interface{}) interface{} { return s.query }),
+		meta("mutationType", typeType, none),
+		meta("subscriptionType", typeType, none),
+		meta("directives", nonNullOf(listOf(nonNullOf(directiveType))), func(interface{}) interface{} {
+			return s.directives
+		}),
+	}
+
+	typeType.Fields = []*Field{
+		meta("kind", nonNullOf(typeKindType), func(v interface{}) interface{} { return string(v.(*Type).Kind) }),
+		meta("name", stringType, func(v interface{}) interface{} { return nullable(v.(*Type).Name) }),
+		meta("description", stringType, func(v interface{}) interface{} { return nullable(v.(*Type).Description) }),
+		meta("specifiedByURL", stringType, none),
+		meta("fields", listOf(nonNullOf(fieldType)), func(v interface{}) interface{} {
+			if t := v.(*Type); t.Kind == KindObject {
+				return t.Fields
+			}
+			return nil
+		}),
+		meta("interfaces", listOf(nonNullOf(typeType)), func(v interface{}) interface{} {
+			if v.(*Type).Kind == KindObject {
+				return []*Type{}
+			}
+			return nil
+		}),
+		meta("possibleTypes", listOf(nonNullOf(typeType)), none),
+		meta("enumValues", listOf(nonNullOf(enumValueType)), func(v interface{}) interface{} {
+			if t := v.(*Type); t.Kind == KindEnum {
+				return t.EnumValues
+			}
+			return nil
+		}),
+		meta("inputFields", listOf(nonNullOf(inputValueType)), func(v interface{}) interface{} {
+			if t := v.(*Type); t.Kind == KindInputObject {
+				return t.InputFields
+			}
+			return nil
+		}),
+		meta("ofType", typeType, func(v interface{}) interface{} {
+			if t := v.(*Type).OfType; t != nil {
+				return t
+			}
+			return nil
+		}),
+	}
+	typeType.Field("fields").Args = includeDeprecated
+	typeType.Field("enumValues").Args = includeDeprecated
+	typeType.Field("inputFields").Args = includeDeprecated
+
+	fieldType.Fields = []*Field{
+		meta("name", nonNullOf(stringType), func(v interface{}) interface{} { return v.(*Field).Name }),
+		meta("description", stringType, func(v interface{}) interface{} { return nullable(v.(*Field).Description) }),
+		meta("args", nonNullOf(listOf(nonNullOf(inputValueType))), func(v interface{}) interface{} {
+			if args := v.(*Field).Args; args != nil {
+				return args
+			}
+			return []*InputValue{}
+		}),
+		meta("type", nonNullOf(typeType), func(v interface{}) interface{} { return v.(*Field).Type }),
+		meta("isDeprecated", nonNullOf(booleanType), notDeprecated),
+		meta("deprecationReason", stringType, none),
+	}
+
+	inputValueType.Fields = []*Field{
+		meta("name", nonNullOf(stringType), func(v interface{}) interface{} { return v.(*InputValue).Name }),
+		meta("description", stringType, func(v interface{}) interface{} { return nullable(v.(*InputValue).Description) }),
+		meta("type", nonNullOf(typeType), func(v interface{}) interface{} { return v.(*InputValue).Type }),
+		meta("defaultValue", stringType, func(v interface{}) interface{} { return nullable(v.(*InputValue).DefaultValue) }),
+		meta("isDeprecated", nonNullOf(booleanType), notDeprecated),
+		meta("deprecationReason", stringType, none),
+	}
+
+	enumValueType.Fields = []*Field{
+		meta("name", nonNullOf(stringType), func(v interface{}) interface{} { return v }),
+		meta("description", stringType, none),
+		meta("isDeprecated", nonNullOf(booleanType), notDeprecated),
+		meta("deprecationReason", stringType, none),
+	}
+
+	directiveType.Fields = []*Field{
+		meta("name", nonNullOf(stringType), func(v interface{}) interface{} { return v.(*directiveDefinition).Name }),
+		meta("description", stringType, func(v interface{}) interface{} {
+			return nullable(v.(*directiveDefinition).Description)
+		}),
+		meta("isRepeatable", nonNullOf(booleanType), notDeprecated),
+		meta("locations", nonNullOf(listOf(nonNullOf(directiveLocationType))), func(v interface{}) interface{} {
+			return v.(*directiveDefinition).Locations
+		}),
+		meta("args", nonNullOf(listOf(nonNullOf(inputValueType))), func(v interface{}) interface{} {
+			return v.(*directiveDefinition).Args
+		}),
+	}
+
+	for _, t := range []*Type{
+		schemaType, typeType, fieldType, inputValueType, enumValueType, directiveType, typeKindType,
+		directiveLocationType,
+	} {
+		s.types[t.Name] = t
+	}
+
+	s.schemaField = meta("__schema", nonNullOf(schemaType), func(interface{}) interface{} { return s })
+	s.typeField = &Field{
+		Name: "__type",
+		Args: []*InputValue{{Name: "name", Type: nonNullOf(stringType)}},
+		Type: typeType,
+		resolve: func(ctx context.Context, r *request, source interface{}, args map[string]interface{}) (interface{}, error) {
+			name, _ := args["name"].(string)
+			if t := s.Type(name); t != nil {
+				return t, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+// nullable returns nil for an empty string.
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
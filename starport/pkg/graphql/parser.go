@@ -0,0 +1,826 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is a parsed GraphQL document.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation is an operation definition of a document.
+type operation struct {
+	// kind is query, mutation or subscription.
+	kind       string
+	name       string
+	variables  []*variableDefinition
+	directives []*directive
+	selections []selection
+}
+
+type variableDefinition struct {
+	name         string
+	defaultValue interface{}
+	hasDefault   bool
+	nonNull      bool
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+}
+
+// selection is a *field, a *fragmentSpread or an *inlineFragment.
+type selection interface{}
+
+type field struct {
+	alias      string
+	name       string
+	arguments  []*argument
+	directives []*directive
+	selections []selection
+	location   Location
+}
+
+// responseKey is the key of the value of the field in the response.
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+}
+
+type argument struct {
+	name  string
+	value interface{}
+}
+
+// values of the arguments are literals of strings, int64s, float64s, bools and nils, or of the
+// types below.
+type (
+	variable    string
+	enumValue   string
+	listValue   []interface{}
+	objectValue []*argument
+)
+
+// Location is a position in a GraphQL document.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// SyntaxError is an error of the syntax of a GraphQL document.
+type SyntaxError struct {
+	Location Location
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Location.Line, e.Location.Column, e.Message)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind     tokenKind
+	value    string
+	location Location
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "<EOF>"
+	case tokenString:
+		return strconv.Quote(t.value)
+	}
+	return t.value
+}
+
+// lexer reads the tokens of a document.
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func (l *lexer) location() Location {
+	return Location{Line: l.line, Column: l.col}
+}
+
+func (l *lexer) errorf(loc Location, format string, args ...interface{}) error {
+	return &SyntaxError{Location: loc, Message: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+// skipIgnored skips the whitespaces, the commas and the comments.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	loc := l.location()
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, location: loc}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		return token{kind: tokenPunctuator, value: "...", location: loc}, nil
+	case strings.ContainsRune("!$&():=@[]{}|", rune(c)):
+		l.advance(1)
+		return token{kind: tokenPunctuator, value: string(c), location: loc}, nil
+	case isNameStart(c):
+		start := l.pos
+		for l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], location: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.number(loc)
+	case strings.HasPrefix(l.src[l.pos:], `"""`):
+		return l.blockString(loc)
+	case c == '"':
+		return l.string(loc)
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, l.errorf(loc, "unexpected character %q", r)
+}
+
+func (l *lexer) number(loc Location) (token, error) {
+	start := l.pos
+	kind := tokenInt
+
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	// the integer part has no leading zeros.
+	if intStart := l.pos; !l.digits() || (l.src[intStart] == '0' && l.pos-intStart > 1) {
+		return token{}, l.errorf(loc, "invalid number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		if !l.digits() {
+			return token{}, l.errorf(loc, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if !l.digits() {
+			return token{}, l.errorf(loc, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && isNameStart(l.src[l.pos]) {
+		return token{}, l.errorf(loc, "invalid number")
+	}
+	return token{kind: kind, value: l.src[start:l.pos], location: loc}, nil
+}
+
+// digits reads digits and reports if there is at least one.
+func (l *lexer) digits() bool {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.advance(1)
+	}
+	return l.pos > start
+}
+
+func (l *lexer) string(loc Location) (token, error) {
+	l.advance(1)
+
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return token{}, l.errorf(loc, "unterminated string")
+		}
+
+		c := l.src[l.pos]
+		switch c {
+		case '"':
+			l.advance(1)
+			return token{kind: tokenString, value: b.String(), location: loc}, nil
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, l.errorf(loc, "unterminated string")
+			}
+			escaped := l.src[l.pos+1]
+			switch escaped {
+			case '"', '\\', '/':
+				b.WriteByte(escaped)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+6 > len(l.src) {
+					return token{}, l.errorf(l.location(), "invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(l.location(), "invalid unicode escape")
+				}
+				b.WriteRune(rune(code))
+				l.advance(4)
+			default:
+				return token{}, l.errorf(l.location(), "invalid escape \\%c", escaped)
+			}
+			l.advance(2)
+		default:
+			b.WriteByte(c)
+			l.advance(1)
+		}
+	}
+}
+
+func (l *lexer) blockString(loc Location) (token, error) {
+	l.advance(3)
+
+	var b strings.Builder
+	for {
+		switch {
+		case l.pos >= len(l.src):
+			return token{}, l.errorf(loc, "unterminated string")
+		case strings.HasPrefix(l.src[l.pos:], `\"""`):
+			b.WriteString(`"""`)
+			l.advance(4)
+		case strings.HasPrefix(l.src[l.pos:], `"""`):
+			l.advance(3)
+			return token{kind: tokenString, value: blockStringValue(b.String()), location: loc}, nil
+		default:
+			b.WriteByte(l.src[l.pos])
+			l.advance(1)
+		}
+	}
+}
+
+// blockStringValue removes the common indentation and the blank first and last lines of a block
+// string.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// maxDepth is the max. nesting of the selection sets, the lists and the objects of a document, so
+// deeply nested documents cannot exhaust the stack of the parser.
+const maxDepth = 64
+
+// parser parses a document from the tokens of the lexer.
+type parser struct {
+	lexer *lexer
+	token token
+
+	// depth is the nesting of the current selection set, list or object.
+	depth int
+}
+
+// parse parses the GraphQL document of the query.
+func parse(query string) (*document, error) {
+	p := &parser{lexer: &lexer{src: query, line: 1, col: 1}}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek("{") || p.peekName("query", "mutation", "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.peekName("fragment"):
+			f, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[f.name]; ok {
+				return nil, fmt.Errorf("fragment %q is defined more than once", f.name)
+			}
+			doc.fragments[f.name] = f
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, p.lexer.errorf(p.token.location, "no operation")
+	}
+	return doc, nil
+}
+
+// nest enters a nested selection set, list or object, leave must be called once it's parsed.
+func (p *parser) nest() error {
+	if p.depth++; p.depth > maxDepth {
+		return p.lexer.errorf(p.token.location, "nested deeper than %d levels", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) read() (err error) {
+	p.token, err = p.lexer.next()
+	return err
+}
+
+func (p *parser) unexpected() error {
+	return p.lexer.errorf(p.token.location, "unexpected %s", p.token)
+}
+
+// peek checks if the current token is the punctuator.
+func (p *parser) peek(punctuator string) bool {
+	return p.token.kind == tokenPunctuator && p.token.value == punctuator
+}
+
+// peekName checks if the current token is one of the names.
+func (p *parser) peekName(names ...string) bool {
+	if p.token.kind != tokenName {
+		return false
+	}
+	for _, name := range names {
+		if p.token.value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// skip reads the next token when the current one is the punctuator and reports if it is.
+func (p *parser) skip(punctuator string) (bool, error) {
+	if !p.peek(punctuator) {
+		return false, nil
+	}
+	return true, p.read()
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		return p.lexer.errorf(p.token.location, "expected %q, found %s", punctuator, p.token)
+	}
+	return p.read()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.lexer.errorf(p.token.location, "expected name, found %s", p.token)
+	}
+	name := p.token.value
+	return name, p.read()
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{kind: "query"}
+	if p.peek("{") {
+		selections, err := p.parseSelectionSet()
+		op.selections = selections
+		return op, err
+	}
+
+	op.kind = p.token.value
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	if p.token.kind == tokenName {
+		op.name = p.token.value
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(")") {
+			v, err := p.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, v)
+		}
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if op.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	op.selections, err = p.parseSelectionSet()
+	return op, err
+}
+
+func (p *parser) parseVariableDefinition() (*variableDefinition, error) {
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+
+	v := &variableDefinition{name: name}
+	if v.nonNull, err = p.parseType(); err != nil {
+		return nil, err
+	}
+
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		if v.defaultValue, err = p.parseValue(true); err != nil {
+			return nil, err
+		}
+		v.hasDefault = true
+	}
+
+	// the directives of variables have no effect on the execution.
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// parseType parses a type reference and reports if it's non-null.
+func (p *parser) parseType() (nonNull bool, err error) {
+	if ok, err := p.skip("["); err != nil {
+		return false, err
+	} else if ok {
+		if err := p.nest(); err != nil {
+			return false, err
+		}
+		defer p.leave()
+		if _, err := p.parseType(); err != nil {
+			return false, err
+		}
+		if err := p.expect("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return false, err
+	}
+	return p.skip("!")
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, p.lexer.errorf(p.token.location, "invalid fragment name \"on\"")
+	}
+	if !p.peekName("on") {
+		return nil, p.lexer.errorf(p.token.location, "expected \"on\", found %s", p.token)
+	}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+
+	f := &fragment{name: name}
+	if f.typeCondition, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	f.selections, err = p.parseSelectionSet()
+	return f, err
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	if err := p.nest(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	var selections []selection
+	for !p.peek("}") {
+		s, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, s)
+	}
+	if len(selections) == 0 {
+		return nil, p.lexer.errorf(p.token.location, "empty selection set")
+	}
+	return selections, p.read()
+}
+
+func (p *parser) parseSelection() (selection, error) {
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.parseFragmentSelection()
+	}
+
+	f := &field{location: p.token.location}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	f.name = name
+
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.alias = name
+		if f.name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if f.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseFragmentSelection() (selection, error) {
+	if p.token.kind == tokenName && p.token.value != "on" {
+		spread := &fragmentSpread{name: p.token.value}
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		var err error
+		spread.directives, err = p.parseDirectives()
+		return spread, err
+	}
+
+	inline := &inlineFragment{}
+	if p.peekName("on") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		var err error
+		if inline.typeCondition, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if inline.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	inline.selections, err = p.parseSelectionSet()
+	return inline, err
+}
+
+func (p *parser) parseDirectives() ([]*directive, error) {
+	var directives []*directive
+	for p.peek("@") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		d := &directive{name: name}
+		if d.arguments, err = p.parseArguments(false); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// parseArguments parses the arguments in parentheses, constant disallows variables.
+func (p *parser) parseArguments(constant bool) ([]*argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+
+	var arguments []*argument
+	for !p.peek(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, &argument{name: name, value: value})
+	}
+	return arguments, p.read()
+}
+
+func (p *parser) parseValue(constant bool) (interface{}, error) {
+	t := p.token
+
+	switch t.kind {
+	case tokenInt:
+		i, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, p.lexer.errorf(t.location, "invalid int %s", t.value)
+		}
+		return i, p.read()
+	case tokenFloat:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, p.lexer.errorf(t.location, "invalid float %s", t.value)
+		}
+		return f, p.read()
+	case tokenString:
+		return t.value, p.read()
+	case tokenName:
+		var v interface{}
+		switch t.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = enumValue(t.value)
+		}
+		return v, p.read()
+	}
+
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		return variable(name), err
+	case p.peek("["):
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		list := listValue{}
+		for !p.peek("]") {
+			v, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.read()
+	case p.peek("{"):
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		object := objectValue{}
+		for !p.peek("}") {
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			v, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, &argument{name: name, value: v})
+		}
+		return object, p.read()
+	}
+
+	return nil, p.unexpected()
+}
@@ -0,0 +1,111 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValues(t *testing.T) {
+	doc, err := parse(`
+		# the arguments of all the kinds.
+		query Q($v: Int = 1) {
+			f(
+				int: -12, float: 1.5e3, string: "\"a\"é\n", block: """
+					line
+					  indented
+				""",
+				bool: true, null: null, enum: PUBLISHED, list: [1, $v], object: {a: {b: [$v]}}
+			)
+		}
+	`)
+	require.NoError(t, err)
+	require.Len(t, doc.operations, 1)
+
+	op := doc.operations[0]
+	require.Equal(t, "query", op.kind)
+	require.Equal(t, "Q", op.name)
+	require.Equal(t, []*variableDefinition{{name: "v", defaultValue: int64(1), hasDefault: true}}, op.variables)
+
+	f := op.selections[0].(*field)
+	require.Equal(t, Location{Line: 4, Column: 4}, f.location)
+	require.Equal(t, []*argument{
+		{"int", int64(-12)},
+		{"float", 1.5e3},
+		{"string", "\"a\"é\n"},
+		{"block", "line\n  indented"},
+		{"bool", true},
+		{"null", nil},
+		{"enum", enumValue("PUBLISHED")},
+		{"list", listValue{int64(1), variable("v")}},
+		{"object", objectValue{{"a", objectValue{{"b", listValue{variable("v")}}}}}},
+	}, f.arguments)
+}
+
+func TestParseSelections(t *testing.T) {
+	doc, err := parse(`{
+		a: b @skip(if: false) { c }
+		...f
+		... on T { d }
+	}
+	fragment f on T { e }`)
+	require.NoError(t, err)
+
+	op := doc.operations[0]
+	require.Equal(t, "query", op.kind)
+	require.Len(t, op.selections, 3)
+
+	f := op.selections[0].(*field)
+	require.Equal(t, "a", f.responseKey())
+	require.Equal(t, "b", f.name)
+	require.Equal(t, []*directive{{"skip", []*argument{{"if", false}}}}, f.directives)
+	require.Equal(t, "c", f.selections[0].(*field).name)
+
+	require.Equal(t, "f", op.selections[1].(*fragmentSpread).name)
+	require.Equal(t, "T", op.selections[2].(*inlineFragment).typeCondition)
+
+	require.Equal(t, "T", doc.fragments["f"].typeCondition)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{"", "syntax error at 1:1: no operation"},
+		{"query ($a: Int = $b) { a }", "syntax error at 1:18: unexpected $"},
+		{"{ a(b: 01) }", "syntax error at 1:8: invalid number"},
+		{`{ a(b: "c) }`, "syntax error at 1:8: unterminated string"},
+		{"{ a }\n  }", "syntax error at 2:3: unexpected }"},
+		{"fragment f on T { a } fragment f on T { a } { a }", `fragment "f" is defined more than once`},
+		{"{ a ^ }", "syntax error at 1:5: unexpected character '^'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parse(tt.query)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseMaxDepth(t *testing.T) {
+	nested := func(open, close string, n int) string {
+		return strings.Repeat(open, n) + strings.Repeat(close, n)
+	}
+
+	for _, query := range []string{
+		"{ " + strings.Repeat("a { ", maxDepth) + "b" + strings.Repeat(" }", maxDepth) + " }",
+		"{ a(v: " + nested("[", "]", maxDepth+1) + ") }",
+		"{ a(v: " + strings.Repeat("{a: ", maxDepth+1) + "1" + strings.Repeat("}", maxDepth+1) + ") }",
+		"query ($v: " + strings.Repeat("[", maxDepth+1) + "Int" + strings.Repeat("]", maxDepth+1) + ") { a }",
+	} {
+		_, err := parse(query)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "nested deeper than")
+	}
+
+	_, err := parse("{ " + strings.Repeat("a { ", maxDepth-1) + "b" + strings.Repeat(" }", maxDepth-1) + " }")
+	require.NoError(t, err)
+}
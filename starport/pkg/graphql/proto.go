@@ -0,0 +1,279 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// versionSegment matches the version segments of the proto packages, e.g. v1beta1.
+var versionSegment = regexp.MustCompile(`^v\d+((alpha|beta)\d*)?$`)
+
+// wellKnownTypes are the scalars of the well-known protobuf messages that have a special JSON.
+var wellKnownTypes = map[protoreflect.FullName]*Type{
+	"google.protobuf.Timestamp":   stringType,
+	"google.protobuf.Duration":    stringType,
+	"google.protobuf.FieldMask":   stringType,
+	"google.protobuf.Any":         jsonType,
+	"google.protobuf.Struct":      jsonType,
+	"google.protobuf.Value":       jsonType,
+	"google.protobuf.ListValue":   jsonType,
+	"google.protobuf.Empty":       jsonType,
+	"google.protobuf.DoubleValue": floatType,
+	"google.protobuf.FloatValue":  floatType,
+	"google.protobuf.Int64Value":  stringType,
+	"google.protobuf.UInt64Value": stringType,
+	"google.protobuf.Int32Value":  intType,
+	"google.protobuf.UInt32Value": intType,
+	"google.protobuf.BoolValue":   booleanType,
+	"google.protobuf.StringValue": stringType,
+	"google.protobuf.BytesValue":  stringType,
+}
+
+// NewSchema creates a schema from the gRPC services with the names.
+// the query has a field for each service named after the package of the service, e.g. blog for
+// the Query service of the blog module, and the type of the field has a field for each unary method
+// of the service that invokes the method with the arguments as the request.
+// the names of the fields of the messages are the names of the fields in JSON.
+func NewSchema(files *protoregistry.Files, services []string) (*Schema, error) {
+	b := &builder{
+		schema: newSchema(),
+		names:  make(map[string]protoreflect.FullName),
+	}
+
+	services = append([]string(nil), services...)
+	sort.Strings(services)
+
+	for _, name := range services {
+		d, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("service %q not found", name)
+		}
+		sd, ok := d.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a service", name)
+		}
+		b.addService(sd)
+	}
+
+	return b.schema, nil
+}
+
+// builder builds the types of a schema from proto descriptors.
+type builder struct {
+	schema *Schema
+
+	// names are the full names of the descriptors by the names of their types.
+	names map[string]protoreflect.FullName
+}
+
+// typeName returns the name of the type of the descriptor, the name of the descriptor prefixed with
+// the last segment of its package that isn't a version, e.g. BlogPost for blog.Post.
+// the full name is used when the name is taken by another descriptor.
+func (b *builder) typeName(d protoreflect.Descriptor, suffix string) string {
+	var (
+		fullName = d.FullName()
+		pkg      = d.ParentFile().Package()
+		local    = strings.TrimPrefix(string(fullName), string(pkg)+".")
+		name     = strcase.ToCamel(packageName(pkg)) + strings.ReplaceAll(local, ".", "") + suffix
+	)
+	if taken, ok := b.names[name]; ok && taken != fullName {
+		name = strings.ReplaceAll(string(fullName), ".", "_") + suffix
+	}
+	b.names[name] = fullName
+	return name
+}
+
+// packageName returns the last segment of the package that isn't a version.
+func packageName(pkg protoreflect.FullName) string {
+	segments := strings.Split(string(pkg), ".")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if !versionSegment.MatchString(segments[i]) {
+			return segments[i]
+		}
+	}
+	return segments[len(segments)-1]
+}
+
+// addService adds a field to the query for the service.
+func (b *builder) addService(sd protoreflect.ServiceDescriptor) {
+	t := &Type{
+		Kind:        KindObject,
+		Name:        b.typeName(sd, ""),
+		Description: fmt.Sprintf("The methods of %s.", sd.FullName()),
+	}
+
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			continue
+		}
+
+		f := &Field{
+			Name:        strcase.ToLowerCamel(string(md.Name())),
+			Description: fmt.Sprintf("Invokes %s/%s.", sd.FullName(), md.Name()),
+			Type:        b.outputType(md.Output()),
+			resolve:     resolveMethod(string(sd.FullName()), string(md.Name())),
+		}
+		fields := md.Input().Fields()
+		for j := 0; j < fields.Len(); j++ {
+			fd := fields.Get(j)
+			f.Args = append(f.Args, &InputValue{Name: fd.JSONName(), Type: b.fieldType(fd, true)})
+		}
+		t.Fields = append(t.Fields, f)
+	}
+	if len(t.Fields) == 0 {
+		return
+	}
+	b.schema.types[t.Name] = t
+
+	name := strcase.ToLowerCamel(packageName(sd.ParentFile().Package()))
+	if b.schema.query.Field(name) != nil {
+		name = strcase.ToLowerCamel(strings.ReplaceAll(string(sd.FullName()), ".", "_"))
+	}
+	b.schema.query.Fields = append(b.schema.query.Fields, &Field{
+		Name:        name,
+		Description: t.Description,
+		Type:        t,
+		resolve: func(context.Context, *request, interface{}, map[string]interface{}) (interface{}, error) {
+			return struct{}{}, nil
+		},
+	})
+}
+
+// resolveMethod resolves a field by invoking the method with the arguments as the request.
+func resolveMethod(service, method string) resolveFunc {
+	return func(ctx context.Context, r *request, source interface{}, args map[string]interface{}) (interface{}, error) {
+		req, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+		res, err := r.invoker.Invoke(ctx, service, method, req)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if err := json.Unmarshal(res, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// fieldType returns the type of the field, the type of an input is an input object for messages.
+func (b *builder) fieldType(fd protoreflect.FieldDescriptor, input bool) *Type {
+	if fd.IsMap() {
+		return b.jsonType()
+	}
+
+	var t *Type
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		t = booleanType
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		t = intType
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		t = floatType
+	case protoreflect.EnumKind:
+		t = b.enumType(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if input {
+			t = b.inputType(fd.Message())
+		} else {
+			t = b.outputType(fd.Message())
+		}
+	default:
+		// strings, bytes in base64 and 64-bit integers are strings in JSON.
+		t = stringType
+	}
+
+	if fd.IsList() {
+		return listOf(t)
+	}
+	return t
+}
+
+func (b *builder) jsonType() *Type {
+	b.schema.types[jsonType.Name] = jsonType
+	return jsonType
+}
+
+func (b *builder) enumType(ed protoreflect.EnumDescriptor) *Type {
+	name := b.typeName(ed, "")
+	if t, ok := b.schema.types[name]; ok {
+		return t
+	}
+
+	t := &Type{Kind: KindEnum, Name: name}
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		t.EnumValues = append(t.EnumValues, string(values.Get(i).Name()))
+	}
+	b.schema.types[name] = t
+	return t
+}
+
+func (b *builder) outputType(md protoreflect.MessageDescriptor) *Type {
+	if t, ok := wellKnownTypes[md.FullName()]; ok {
+		if t == jsonType {
+			return b.jsonType()
+		}
+		return t
+	}
+	// objects have at least one field.
+	if md.Fields().Len() == 0 {
+		return b.jsonType()
+	}
+
+	name := b.typeName(md, "")
+	if t, ok := b.schema.types[name]; ok {
+		return t
+	}
+
+	// the type is added before its fields so the messages can reference themselves.
+	t := &Type{Kind: KindObject, Name: name}
+	b.schema.types[name] = t
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		t.Fields = append(t.Fields, &Field{Name: fd.JSONName(), Type: b.fieldType(fd, false)})
+	}
+	return t
+}
+
+func (b *builder) inputType(md protoreflect.MessageDescriptor) *Type {
+	if t, ok := wellKnownTypes[md.FullName()]; ok {
+		if t == jsonType {
+			return b.jsonType()
+		}
+		return t
+	}
+	if md.Fields().Len() == 0 {
+		return b.jsonType()
+	}
+
+	name := b.typeName(md, "Input")
+	if t, ok := b.schema.types[name]; ok {
+		return t
+	}
+
+	t := &Type{Kind: KindInputObject, Name: name}
+	b.schema.types[name] = t
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		t.InputFields = append(t.InputFields, &InputValue{Name: fd.JSONName(), Type: b.fieldType(fd, true)})
+	}
+	return t
+}
@@ -0,0 +1,247 @@
+// Package graphql serves a GraphQL API whose queries are resolved by invoking the methods of gRPC
+// services, the schema of the API is created from the descriptors of the services.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kind is the kind of a GraphQL type.
+type Kind string
+
+// Kinds of the types.
+const (
+	KindScalar      Kind = "SCALAR"
+	KindObject      Kind = "OBJECT"
+	KindEnum        Kind = "ENUM"
+	KindInputObject Kind = "INPUT_OBJECT"
+	KindList        Kind = "LIST"
+	KindNonNull     Kind = "NON_NULL"
+)
+
+// Type is a GraphQL type.
+type Type struct {
+	Kind        Kind
+	Name        string
+	Description string
+
+	// Fields of an object.
+	Fields []*Field
+
+	// InputFields of an input object.
+	InputFields []*InputValue
+
+	// EnumValues of an enum.
+	EnumValues []string
+
+	// OfType is the type of the elements of a list or the nullable type of a non-null type.
+	OfType *Type
+}
+
+// Field returns the field of an object with the name, nil when it doesn't exist.
+func (t *Type) Field(name string) *Field {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// String returns the type as it's referenced in a document, e.g. [String!].
+func (t *Type) String() string {
+	switch t.Kind {
+	case KindList:
+		return "[" + t.OfType.String() + "]"
+	case KindNonNull:
+		return t.OfType.String() + "!"
+	}
+	return t.Name
+}
+
+// Field is a field of an object.
+type Field struct {
+	Name        string
+	Description string
+	Args        []*InputValue
+	Type        *Type
+
+	// resolve returns the value of the field from the value of the object, the value of the field
+	// in a JSON object is returned when it's nil.
+	resolve resolveFunc
+}
+
+// resolveFunc resolves the value of a field.
+type resolveFunc func(ctx context.Context, r *request, source interface{}, args map[string]interface{}) (interface{}, error)
+
+// InputValue is an argument of a field or a field of an input object.
+type InputValue struct {
+	Name        string
+	Description string
+	Type        *Type
+
+	// DefaultValue is the default value in a document, empty when there is none.
+	DefaultValue string
+}
+
+// directiveDefinition is a directive supported in the queries.
+type directiveDefinition struct {
+	Name        string
+	Description string
+	Locations   []string
+	Args        []*InputValue
+}
+
+// The built-in scalars, int64 values are strings in the JSON of protobuf messages so they're
+// strings in the schema as well.
+var (
+	stringType  = &Type{Kind: KindScalar, Name: "String"}
+	intType     = &Type{Kind: KindScalar, Name: "Int"}
+	floatType   = &Type{Kind: KindScalar, Name: "Float"}
+	booleanType = &Type{Kind: KindScalar, Name: "Boolean"}
+	jsonType    = &Type{
+		Kind:        KindScalar,
+		Name:        "JSON",
+		Description: "A JSON value, e.g. an Any, a map or an empty message.",
+	}
+)
+
+func listOf(t *Type) *Type    { return &Type{Kind: KindList, OfType: t} }
+func nonNullOf(t *Type) *Type { return &Type{Kind: KindNonNull, OfType: t} }
+
+// namedType returns the named type of a list or a non-null type.
+func namedType(t *Type) *Type {
+	for t.OfType != nil {
+		t = t.OfType
+	}
+	return t
+}
+
+// Schema is a GraphQL schema whose queries are resolved by invoking the methods of gRPC services.
+type Schema struct {
+	query      *Type
+	types      map[string]*Type
+	directives []*directiveDefinition
+
+	// schemaField and typeField are the __schema and __type fields of the queries.
+	schemaField *Field
+	typeField   *Field
+}
+
+func newSchema() *Schema {
+	s := &Schema{
+		query: &Type{Kind: KindObject, Name: "Query"},
+		types: make(map[string]*Type),
+	}
+	for _, t := range []*Type{s.query, stringType, intType, floatType, booleanType} {
+		s.types[t.Name] = t
+	}
+
+	ifArg := []*InputValue{{Name: "if", Type: nonNullOf(booleanType)}}
+	s.directives = []*directiveDefinition{
+		{
+			Name:        "include",
+			Description: "Includes the field or the fragment only when the argument is true.",
+			Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+			Args:        ifArg,
+		},
+		{
+			Name:        "skip",
+			Description: "Skips the field or the fragment when the argument is true.",
+			Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+			Args:        ifArg,
+		},
+	}
+
+	s.addIntrospection()
+	return s
+}
+
+// Type returns the type with the name, nil when it doesn't exist.
+func (s *Schema) Type(name string) *Type {
+	return s.types[name]
+}
+
+// sortedTypes returns the named types sorted by their names.
+func (s *Schema) sortedTypes() []*Type {
+	var types []*Type
+	for _, t := range s.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types
+}
+
+// String returns the schema in the GraphQL schema definition language, the introspection types and
+// the built-in scalars are omitted.
+func (s *Schema) String() string {
+	var b strings.Builder
+	for _, t := range s.sortedTypes() {
+		if strings.HasPrefix(t.Name, "__") || isBuiltinScalar(t) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		writeDescription(&b, "", t.Description)
+
+		switch t.Kind {
+		case KindScalar:
+			fmt.Fprintf(&b, "scalar %s\n", t.Name)
+		case KindEnum:
+			fmt.Fprintf(&b, "enum %s {\n", t.Name)
+			for _, v := range t.EnumValues {
+				fmt.Fprintf(&b, "  %s\n", v)
+			}
+			b.WriteString("}\n")
+		case KindInputObject:
+			fmt.Fprintf(&b, "input %s {\n", t.Name)
+			for _, f := range t.InputFields {
+				writeDescription(&b, "  ", f.Description)
+				fmt.Fprintf(&b, "  %s\n", inputValueString(f))
+			}
+			b.WriteString("}\n")
+		case KindObject:
+			fmt.Fprintf(&b, "type %s {\n", t.Name)
+			for _, f := range t.Fields {
+				writeDescription(&b, "  ", f.Description)
+				fmt.Fprintf(&b, "  %s", f.Name)
+				if len(f.Args) > 0 {
+					var args []string
+					for _, arg := range f.Args {
+						args = append(args, inputValueString(arg))
+					}
+					fmt.Fprintf(&b, "(%s)", strings.Join(args, ", "))
+				}
+				fmt.Fprintf(&b, ": %s\n", f.Type)
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func isBuiltinScalar(t *Type) bool {
+	switch t {
+	case stringType, intType, floatType, booleanType:
+		return true
+	}
+	return false
+}
+
+func inputValueString(v *InputValue) string {
+	s := fmt.Sprintf("%s: %s", v.Name, v.Type)
+	if v.DefaultValue != "" {
+		s += " = " + v.DefaultValue
+	}
+	return s
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description != "" {
+		fmt.Fprintf(b, "%s\"\"\"%s\"\"\"\n", indent, strings.ReplaceAll(description, `"""`, `\"""`))
+	}
+}
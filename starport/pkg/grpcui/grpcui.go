@@ -0,0 +1,251 @@
+// Package grpcui provides a web UI to explore and invoke the services of a gRPC server
+// by using gRPC reflection, so RPCs can be called without writing a client.
+package grpcui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ErrStreamingNotSupported is returned when a streaming method is invoked.
+var ErrStreamingNotSupported = errors.New("only unary methods can be invoked")
+
+// Service is a gRPC service.
+type Service struct {
+	// Name is the full name of the service.
+	Name string `json:"name"`
+
+	// Methods of the service.
+	Methods []Method `json:"methods"`
+}
+
+// Method is a method of a gRPC service.
+type Method struct {
+	// Name of the method.
+	Name string `json:"name"`
+
+	// Input is the full name of the request message.
+	Input string `json:"input"`
+
+	// Output is the full name of the response message.
+	Output string `json:"output"`
+
+	// Streaming is true when the client or the server streams messages.
+	Streaming bool `json:"streaming"`
+
+	// Template is a request in JSON with all of its fields set to their zero values.
+	Template json.RawMessage `json:"template"`
+}
+
+// Client explores and invokes the services of a gRPC server.
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+// New creates a new client that uses conn to reach to the gRPC server.
+func New(conn grpc.ClientConnInterface) Client {
+	return Client{conn}
+}
+
+// Services returns the services of the gRPC server sorted by their names.
+func (c Client) Services(ctx context.Context) ([]Service, error) {
+	files, names, err := c.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []Service
+
+	for _, name := range names {
+		sd, err := findService(files, name)
+		if err != nil {
+			return nil, err
+		}
+
+		service := Service{Name: name}
+		methods := sd.Methods()
+
+		for i := 0; i < methods.Len(); i++ {
+			md := methods.Get(i)
+
+			template, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(dynamicpb.NewMessage(md.Input()))
+			if err != nil {
+				template = json.RawMessage("{}")
+			}
+
+			service.Methods = append(service.Methods, Method{
+				Name:      string(md.Name()),
+				Input:     string(md.Input().FullName()),
+				Output:    string(md.Output().FullName()),
+				Streaming: md.IsStreamingClient() || md.IsStreamingServer(),
+				Template:  template,
+			})
+		}
+
+		services = append(services, service)
+	}
+
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+
+	return services, nil
+}
+
+// Invoke invokes the method of the service with the request in JSON and returns the response in JSON.
+func (c Client) Invoke(ctx context.Context, service, method string, request []byte) ([]byte, error) {
+	files, _, err := c.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sd, err := findService(files, service)
+	if err != nil {
+		return nil, err
+	}
+
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("method %q not found in service %q", method, service)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, ErrStreamingNotSupported
+	}
+
+	types := typeResolver{files}
+
+	in := dynamicpb.NewMessage(md.Input())
+	if len(strings.TrimSpace(string(request))) > 0 {
+		if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal(request, in); err != nil {
+			return nil, errors.Wrap(err, "invalid request")
+		}
+	}
+
+	out := dynamicpb.NewMessage(md.Output())
+	if err := c.conn.Invoke(ctx, fmt.Sprintf("/%s/%s", service, method), in, out, grpc.ForceCodec(codec{})); err != nil {
+		return nil, err
+	}
+
+	return protojson.MarshalOptions{Resolver: types, EmitUnpopulated: true, Indent: "  "}.Marshal(out)
+}
+
+// Files returns the descriptors of the files of the services of the gRPC server with the names of
+// the services.
+func (c Client) Files(ctx context.Context) (*protoregistry.Files, []string, error) {
+	return c.resolve(ctx)
+}
+
+// FilesByName returns the descriptors of the files with the names and their dependencies, like the
+// files of the services the server doesn't list. the files that the server cannot find are skipped.
+func (c Client) FilesByName(ctx context.Context, names ...string) (*protoregistry.Files, error) {
+	r, err := newResolver(ctx, c.conn)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	for _, name := range names {
+		if err := r.loadFile(name); err != nil && !errors.Is(err, errNotFound) {
+			return nil, errors.Wrapf(err, "cannot resolve file %q", name)
+		}
+	}
+
+	return r.registry()
+}
+
+// resolve fetches the descriptors of all services of the server with the names of the services.
+// descriptors are fetched every time, since services of the server may change while it's restarted.
+func (c Client) resolve(ctx context.Context) (files *protoregistry.Files, services []string, err error) {
+	r, err := newResolver(ctx, c.conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.close()
+
+	if services, err = r.listServices(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range services {
+		if err := r.loadSymbol(name); err != nil {
+			return nil, nil, errors.Wrapf(err, "cannot resolve service %q", name)
+		}
+	}
+
+	files, err = r.registry()
+	return files, services, err
+}
+
+func findService(files *protoregistry.Files, name string) (protoreflect.ServiceDescriptor, error) {
+	d, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("service %q not found", name)
+	}
+
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a service", name)
+	}
+
+	return sd, nil
+}
+
+// typeResolver resolves message types from files to encode Any values in JSON.
+type typeResolver struct {
+	files *protoregistry.Files
+}
+
+func (r typeResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	d, err := r.files.FindDescriptorByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, protoregistry.NotFound
+	}
+
+	return dynamicpb.NewMessageType(md), nil
+}
+
+func (r typeResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	name := url
+	if i := strings.LastIndexByte(url, '/'); i >= 0 {
+		name = url[i+1:]
+	}
+
+	return r.FindMessageByName(protoreflect.FullName(name))
+}
+
+func (r typeResolver) FindExtensionByName(protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return nil, protoregistry.NotFound
+}
+
+func (r typeResolver) FindExtensionByNumber(protoreflect.FullName, protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	return nil, protoregistry.NotFound
+}
+
+// codec encodes dynamic messages in protobuf.
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+
+func (codec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,136 @@
+package grpcui
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T) Client {
+	var (
+		listener = bufconn.Listen(1024 * 1024)
+		server   = grpc.NewServer()
+	)
+
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return New(conn)
+}
+
+func TestServices(t *testing.T) {
+	client := newTestClient(t)
+
+	services, err := client.Services(context.Background())
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+
+	service := services[0]
+	require.Equal(t, "grpc.health.v1.Health", service.Name)
+	require.Len(t, service.Methods, 2)
+
+	check := service.Methods[0]
+	require.Equal(t, "Check", check.Name)
+	require.Equal(t, "grpc.health.v1.HealthCheckRequest", check.Input)
+	require.Equal(t, "grpc.health.v1.HealthCheckResponse", check.Output)
+	require.False(t, check.Streaming)
+	require.JSONEq(t, `{"service": ""}`, string(check.Template))
+
+	require.Equal(t, "Watch", service.Methods[1].Name)
+	require.True(t, service.Methods[1].Streaming)
+}
+
+func TestInvoke(t *testing.T) {
+	var (
+		client = newTestClient(t)
+		ctx    = context.Background()
+	)
+
+	response, err := client.Invoke(ctx, "grpc.health.v1.Health", "Check", []byte(`{"service": ""}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status": "SERVING"}`, string(response))
+
+	// an empty request is sent with its zero values.
+	_, err = client.Invoke(ctx, "grpc.health.v1.Health", "Check", nil)
+	require.NoError(t, err)
+
+	// errors returned by the server.
+	_, err = client.Invoke(ctx, "grpc.health.v1.Health", "Check", []byte(`{"service": "unknown"}`))
+	require.Error(t, err)
+
+	_, err = client.Invoke(ctx, "grpc.health.v1.Health", "Check", []byte(`{"unknown": 1}`))
+	require.Error(t, err)
+
+	_, err = client.Invoke(ctx, "grpc.health.v1.Health", "Watch", nil)
+	require.Equal(t, ErrStreamingNotSupported, err)
+
+	_, err = client.Invoke(ctx, "grpc.health.v1.Health", "Missing", nil)
+	require.Error(t, err)
+
+	_, err = client.Invoke(ctx, "grpc.health.v1.Missing", "Check", nil)
+	require.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(Handler("mars", "localhost:9090", newTestClient(t)))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, err = http.Get(server.URL + "/api/services")
+	require.NoError(t, err)
+	var services []Service
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&services))
+	res.Body.Close()
+	require.Len(t, services, 1)
+
+	res, err = http.Post(server.URL+"/api/invoke/grpc.health.v1.Health/Check", "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	var response map[string]string
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "SERVING", response["status"])
+
+	res, err = http.Post(server.URL+"/api/invoke/grpc.health.v1.Health/Watch", "application/json", nil)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusBadGateway, res.StatusCode)
+}
+
+func TestFilesByName(t *testing.T) {
+	client := newTestClient(t)
+
+	// the files that the server cannot find are skipped.
+	files, err := client.FilesByName(context.Background(), "grpc/health/v1/health.proto", "missing.proto")
+	require.NoError(t, err)
+	require.Equal(t, 1, files.NumFiles())
+
+	_, err = files.FindDescriptorByName("grpc.health.v1.Health")
+	require.NoError(t, err)
+}
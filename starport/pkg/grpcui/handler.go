@@ -0,0 +1,69 @@
+package grpcui
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+//go:embed index.tpl
+var index embed.FS
+
+// Handler returns an http handler that serves the UI at / and its API under /api to explore and invoke
+// the services of the gRPC server at target through the client.
+func Handler(title, target string, client Client) http.Handler {
+	t := template.Must(template.ParseFS(index, "index.tpl"))
+	router := mux.NewRouter()
+
+	router.
+		HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			t.Execute(w, struct {
+				Title  string
+				Target string
+			}{
+				title,
+				target,
+			})
+		}).
+		Methods(http.MethodGet)
+
+	router.
+		HandleFunc("/api/services", func(w http.ResponseWriter, r *http.Request) {
+			services, err := client.Services(r.Context())
+			if err != nil {
+				xhttp.ResponseJSON(w, http.StatusBadGateway, xhttp.NewErrorResponse(err))
+				return
+			}
+
+			xhttp.ResponseJSON(w, http.StatusOK, services)
+		}).
+		Methods(http.MethodGet)
+
+	router.
+		HandleFunc("/api/invoke/{service}/{method}", func(w http.ResponseWriter, r *http.Request) {
+			request, err := io.ReadAll(r.Body)
+			if err != nil {
+				xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(err))
+				return
+			}
+
+			vars := mux.Vars(r)
+
+			response, err := client.Invoke(r.Context(), vars["service"], vars["method"], request)
+			if err != nil {
+				xhttp.ResponseJSON(w, http.StatusBadGateway, xhttp.NewErrorResponse(err))
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(response)
+		}).
+		Methods(http.MethodPost)
+
+	return router
+}
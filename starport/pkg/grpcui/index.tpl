@@ -0,0 +1,120 @@
+<!DOCTYPE html>
+<html lang="en">
+    <head>
+        <meta charset="utf-8" />
+        <title>{{ .Title }} gRPC UI</title>
+        <style>
+            body { margin: 0; display: flex; height: 100vh; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 14px; color: #222; }
+            nav { width: 360px; overflow-y: auto; border-right: 1px solid #ddd; background: #fafafa; }
+            nav h1 { font-size: 16px; margin: 0; padding: 16px; border-bottom: 1px solid #ddd; }
+            nav h1 small { display: block; color: #777; font-weight: normal; margin-top: 4px; }
+            nav input { box-sizing: border-box; width: calc(100% - 32px); margin: 12px 16px; padding: 6px 8px; }
+            nav h2 { font-size: 12px; margin: 12px 16px 4px; color: #555; word-break: break-all; }
+            nav a { display: block; padding: 4px 24px; color: #0b57d0; text-decoration: none; cursor: pointer; }
+            nav a.active, nav a:hover { background: #e8f0fe; }
+            nav a.streaming { color: #999; cursor: default; }
+            main { flex: 1; display: flex; flex-direction: column; padding: 16px; overflow: hidden; }
+            main h3 { margin: 0 0 4px; font-size: 16px; word-break: break-all; }
+            main p { margin: 0 0 12px; color: #777; }
+            textarea, pre { flex: 1; margin: 0 0 12px; padding: 8px; font-family: Menlo, Consolas, monospace; font-size: 13px; border: 1px solid #ddd; overflow: auto; }
+            pre.error { color: #b3261e; }
+            button { align-self: flex-start; margin-bottom: 12px; padding: 6px 16px; }
+        </style>
+    </head>
+    <body>
+        <nav>
+            <h1>{{ .Title }}<small>{{ .Target }}</small></h1>
+            <input id="filter" placeholder="Filter methods" />
+            <div id="services">Loading services...</div>
+        </nav>
+        <main>
+            <h3 id="method">Select a method</h3>
+            <p id="types"></p>
+            <textarea id="request" spellcheck="false"></textarea>
+            <button id="invoke" disabled>Invoke</button>
+            <pre id="response"></pre>
+        </main>
+
+        <script>
+            // services are listed from the API that is served by the UI through gRPC reflection.
+            let services = [];
+            let selected = null;
+
+            const $ = (id) => document.getElementById(id);
+
+            function render() {
+                const filter = $("filter").value.toLowerCase();
+                const list = $("services");
+                list.innerHTML = "";
+
+                for (const service of services) {
+                    const methods = service.methods.filter((m) => (service.name + "/" + m.name).toLowerCase().includes(filter));
+                    if (!methods.length) continue;
+
+                    const title = document.createElement("h2");
+                    title.textContent = service.name;
+                    list.appendChild(title);
+
+                    for (const method of methods) {
+                        const link = document.createElement("a");
+                        link.textContent = method.name;
+                        if (method.streaming) {
+                            link.className = "streaming";
+                            link.title = "streaming methods cannot be invoked";
+                        } else {
+                            link.onclick = () => select(service, method, link);
+                        }
+                        list.appendChild(link);
+                    }
+                }
+            }
+
+            function select(service, method, link) {
+                document.querySelectorAll("nav a.active").forEach((a) => a.classList.remove("active"));
+                link.classList.add("active");
+
+                selected = { service: service.name, method: method.name };
+                $("method").textContent = service.name + "/" + method.name;
+                $("types").textContent = method.input + " → " + method.output;
+                $("request").value = JSON.stringify(method.template, null, 2);
+                $("response").textContent = "";
+                $("invoke").disabled = false;
+            }
+
+            async function invoke() {
+                const response = $("response");
+                response.className = "";
+                response.textContent = "Invoking...";
+
+                const res = await fetch("api/invoke/" + selected.service + "/" + selected.method, {
+                    method: "POST",
+                    body: $("request").value,
+                });
+                const body = await res.json();
+
+                if (!res.ok) {
+                    response.className = "error";
+                    response.textContent = body.error.message;
+                    return;
+                }
+                response.textContent = JSON.stringify(body, null, 2);
+            }
+
+            async function load() {
+                const res = await fetch("api/services");
+                const body = await res.json();
+
+                if (!res.ok) {
+                    $("services").textContent = body.error.message;
+                    return;
+                }
+                services = body || [];
+                render();
+            }
+
+            $("filter").oninput = render;
+            $("invoke").onclick = invoke;
+            load();
+        </script>
+    </body>
+</html>
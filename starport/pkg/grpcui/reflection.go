@@ -0,0 +1,138 @@
+package grpcui
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// reflectionServiceName is the name of the reflection service, it is not listed since it's a streaming
+// service that is used by the UI itself.
+const reflectionServiceName = "grpc.reflection.v1alpha.ServerReflection"
+
+// errNotFound is returned when a symbol or file cannot be found by the server.
+var errNotFound = errors.New("not found")
+
+// resolver fetches the file descriptors of a server through gRPC reflection.
+type resolver struct {
+	stream rpb.ServerReflection_ServerReflectionInfoClient
+	files  map[string]*descriptorpb.FileDescriptorProto
+}
+
+func newResolver(ctx context.Context, conn grpc.ClientConnInterface) (*resolver, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "reflection is not available")
+	}
+
+	return &resolver{
+		stream: stream,
+		files:  make(map[string]*descriptorpb.FileDescriptorProto),
+	}, nil
+}
+
+// close closes the reflection stream.
+func (r *resolver) close() error {
+	return r.stream.CloseSend()
+}
+
+// listServices returns names of the services registered to the server.
+func (r *resolver) listServices() ([]string, error) {
+	res, err := r.request(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, service := range res.GetListServicesResponse().GetService() {
+		if service.Name != reflectionServiceName {
+			names = append(names, service.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// loadSymbol loads the file that defines symbol with its dependencies.
+func (r *resolver) loadSymbol(symbol string) error {
+	return r.load(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	})
+}
+
+// loadFile loads the file with its dependencies.
+func (r *resolver) loadFile(name string) error {
+	if _, ok := r.files[name]; ok {
+		return nil
+	}
+
+	return r.load(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+	})
+}
+
+func (r *resolver) load(req *rpb.ServerReflectionRequest) error {
+	res, err := r.request(req)
+	if err != nil {
+		return err
+	}
+
+	var deps []string
+
+	for _, content := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		var file descriptorpb.FileDescriptorProto
+		if err := proto.Unmarshal(content, &file); err != nil {
+			return errors.Wrap(err, "invalid file descriptor")
+		}
+		r.files[file.GetName()] = &file
+		deps = append(deps, file.GetDependency()...)
+	}
+
+	for _, dep := range deps {
+		if err := r.loadFile(dep); err != nil {
+			// unresolvable dependencies are allowed while building the registry.
+			if errors.Is(err, errNotFound) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// registry builds a registry from the loaded files.
+func (r *resolver) registry() (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, file := range r.files {
+		set.File = append(set.File, file)
+	}
+
+	// files of some dependencies, e.g. gogoproto extensions, might not be served by the server.
+	return protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(set)
+}
+
+func (r *resolver) request(req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	if err := r.stream.Send(req); err != nil {
+		return nil, err
+	}
+
+	res, err := r.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	if errRes := res.GetErrorResponse(); errRes != nil {
+		return nil, errors.Wrap(errNotFound, errRes.GetErrorMessage())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,94 @@
+// Package httpproxy provides a reverse proxy that routes the requests to servers by their hostnames
+// and the TLS certificates to serve it publicly.
+package httpproxy
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// ErrUnknownHost is returned for the requests to a hostname without route.
+var ErrUnknownHost = errors.New("unknown host")
+
+// Proxy is a reverse proxy that routes the requests to servers by their hostnames.
+type Proxy struct {
+	routes map[string]*httputil.ReverseProxy
+}
+
+// New creates a proxy that forwards the requests for the hostnames of the routes to the addresses
+// of their servers, e.g. rpc.example.com to 0.0.0.0:26657.
+func New(routes map[string]string) *Proxy {
+	p := &Proxy{routes: make(map[string]*httputil.ReverseProxy)}
+	for hostname, address := range routes {
+		p.routes[strings.ToLower(hostname)] = newReverseProxy(address)
+	}
+	return p
+}
+
+func newReverseProxy(address string) *httputil.ReverseProxy {
+	target := &url.URL{Scheme: "http", Host: xurl.Address(address)}
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	director := proxy.Director
+	proxy.Director = func(r *http.Request) {
+		director(r)
+		r.Header.Set("X-Forwarded-Host", r.Host)
+		r.Header.Set("X-Forwarded-Proto", "https")
+		r.Host = target.Host
+	}
+
+	// the responses are streamed as is, e.g. the events of the Tendermint RPC websocket.
+	proxy.FlushInterval = -1
+	return proxy
+}
+
+// Hostnames returns the sorted hostnames of the routes.
+func (p *Proxy) Hostnames() []string {
+	var hostnames []string
+	for hostname := range p.routes {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+	return hostnames
+}
+
+// ServeHTTP implements http.Handler.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proxy, ok := p.routes[hostname(r.Host)]
+	if !ok {
+		xhttp.ResponseJSON(w, http.StatusNotFound, xhttp.NewErrorResponse(ErrUnknownHost))
+		return
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// RedirectHandler returns a handler that redirects the requests to the proxy served over TLS at the
+// address.
+func RedirectHandler(address string) http.Handler {
+	_, port, _ := net.SplitHostPort(address)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := hostname(r.Host)
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		u := *r.URL
+		u.Scheme = "https"
+		u.Host = host
+		http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+	})
+}
+
+// hostname returns the lowercase hostname of a host without its port.
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
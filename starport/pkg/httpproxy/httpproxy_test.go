@@ -0,0 +1,118 @@
+package httpproxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newBackend(t *testing.T, name string) string {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s %s", name, r.URL.Path, r.Header.Get("X-Forwarded-Host"), r.Header.Get("X-Forwarded-Proto"))
+	}))
+	t.Cleanup(s.Close)
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func get(t *testing.T, client *http.Client, url, host string) (int, string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Host = host
+
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res.StatusCode, string(body)
+}
+
+func TestProxy(t *testing.T) {
+	p := New(map[string]string{
+		"rpc.example.com": newBackend(t, "rpc"),
+		"API.example.com": newBackend(t, "api"),
+	})
+	require.Equal(t, []string{"api.example.com", "rpc.example.com"}, p.Hostnames())
+
+	s := httptest.NewServer(p)
+	defer s.Close()
+
+	status, body := get(t, s.Client(), s.URL+"/status", "rpc.example.com")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "rpc /status rpc.example.com https", body)
+
+	status, body = get(t, s.Client(), s.URL+"/node_info", "api.example.com:443")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "api /node_info api.example.com:443 https", body)
+
+	status, body = get(t, s.Client(), s.URL, "faucet.example.com")
+	require.Equal(t, http.StatusNotFound, status)
+	require.JSONEq(t, `{"error": {"message": "unknown host"}}`, body)
+}
+
+func TestRedirectHandler(t *testing.T) {
+	tests := []struct {
+		address  string
+		location string
+	}{
+		{"0.0.0.0:443", "https://rpc.example.com/status?height=1"},
+		{":8443", "https://rpc.example.com:8443/status?height=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "http://rpc.example.com:80/status?height=1", nil)
+			RedirectHandler(tt.address).ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusMovedPermanently, w.Code)
+			require.Equal(t, tt.location, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestSelfSignedTLSConfig(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		hostnames = []string{"rpc.example.com", "api.example.com"}
+	)
+
+	config, err := SelfSignedTLSConfig(dir, hostnames)
+	require.NoError(t, err)
+
+	// the certificate is reused for the same hostnames and renewed for new ones.
+	reused, err := SelfSignedTLSConfig(dir, hostnames[:1])
+	require.NoError(t, err)
+	require.Equal(t, config.Certificates[0].Certificate, reused.Certificates[0].Certificate)
+
+	renewed, err := SelfSignedTLSConfig(dir, append(hostnames, "faucet.example.com"))
+	require.NoError(t, err)
+	require.NotEqual(t, config.Certificates[0].Certificate, renewed.Certificates[0].Certificate)
+
+	// the clients that trust the certificate can connect to the hostnames.
+	s := httptest.NewUnstartedServer(New(map[string]string{"api.example.com": newBackend(t, "api")}))
+	s.TLS = renewed
+	s.StartTLS()
+	defer s.Close()
+
+	leaf, err := x509.ParseCertificate(renewed.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	require.False(t, leaf.IsCA)
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: pool, ServerName: "api.example.com"},
+	}}
+	status, body := get(t, client, s.URL+"/node_info", "api.example.com")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "api /node_info api.example.com https", body)
+}
@@ -0,0 +1,133 @@
+package httpproxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	certFile = "cert.pem"
+	keyFile  = "key.pem"
+
+	// selfSignedValidity is the validity of the self-signed certificates.
+	selfSignedValidity = 365 * 24 * time.Hour
+
+	// renewBefore is the time before the expiry of a self-signed certificate when it's renewed.
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// SelfSignedTLSConfig returns a TLS config with a self-signed certificate for the hostnames.
+// the certificate is stored in dir and reused while it's valid for the hostnames, so the clients
+// that trust it keep trusting it across restarts.
+func SelfSignedTLSConfig(dir string, hostnames []string) (*tls.Config, error) {
+	var (
+		certPath = filepath.Join(dir, certFile)
+		keyPath  = filepath.Join(dir, keyFile)
+	)
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil || !isValidFor(cert, hostnames) {
+		if cert, err = createSelfSigned(certPath, keyPath, hostnames); err != nil {
+			return nil, err
+		}
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
+
+// isValidFor checks if the certificate is a leaf certificate valid for the hostnames that doesn't
+// expire soon, the CA certificates created by the previous versions are renewed.
+func isValidFor(cert tls.Certificate, hostnames []string) bool {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil || leaf.IsCA || time.Now().Add(renewBefore).After(leaf.NotAfter) {
+		return false
+	}
+	for _, hostname := range hostnames {
+		if leaf.VerifyHostname(hostname) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func createSelfSigned(certPath, keyPath string, hostnames []string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Starport"}},
+		DNSNames:     hostnames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(selfSignedValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+
+		// the certificate is a leaf certificate, so a client that trusts it only trusts the hostnames
+		// and not any certificate signed with its key.
+		IsCA:                  false,
+		BasicConstraintsValid: true,
+	}
+	if len(hostnames) > 0 {
+		template.Subject.CommonName = hostnames[0]
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	var (
+		certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		keyPEM  = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	)
+	if err := os.MkdirAll(filepath.Dir(certPath), 0700); err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// NewACMEManager returns a manager that obtains and renews the certificates of the hostnames from
+// the ACME CA at the directory URL, Let's Encrypt when it's empty. the certificates are cached in dir.
+// the TLS config of the manager solves the TLS-ALPN challenges and its HTTP handler solves the HTTP
+// challenges.
+func NewACMEManager(dir, email, directoryURL string, hostnames []string) *autocert.Manager {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(dir),
+		HostPolicy: autocert.HostWhitelist(hostnames...),
+		Email:      email,
+	}
+	if directoryURL != "" {
+		m.Client = &acme.Client{DirectoryURL: directoryURL}
+	}
+	return m
+}
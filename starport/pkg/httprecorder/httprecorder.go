@@ -0,0 +1,417 @@
+// Package httprecorder records the requests sent to HTTP and gRPC servers through reverse proxies
+// with their responses in a file, to debug the clients of the servers.
+package httprecorder
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// Kind is the kind of the server that a request is sent to.
+type Kind string
+
+const (
+	// KindAPI is the kind of the HTTP servers.
+	KindAPI Kind = "api"
+
+	// KindGRPC is the kind of the gRPC servers, their bodies are recorded in base64.
+	KindGRPC Kind = "grpc"
+)
+
+const (
+	// Redacted replaces the values that are redacted.
+	Redacted = "[REDACTED]"
+
+	// EncodingBase64 is the encoding of the bodies that are not text.
+	EncodingBase64 = "base64"
+)
+
+// defaultRedact are the names of the headers that are always redacted.
+var defaultRedact = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Record is a request sent through a proxy with its response.
+type Record struct {
+	Time     time.Time     `json:"time"`
+	Kind     Kind          `json:"kind"`
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	Query    string        `json:"query,omitempty"`
+	Status   int           `json:"status"`
+	Duration time.Duration `json:"duration"`
+
+	// GRPCStatus and GRPCMessage are the status code and the message of the gRPC responses.
+	GRPCStatus  string `json:"grpc_status,omitempty"`
+	GRPCMessage string `json:"grpc_message,omitempty"`
+
+	// Error is the error of the proxy when the server can't be reached.
+	Error string `json:"error,omitempty"`
+
+	Request  Message `json:"request"`
+	Response Message `json:"response"`
+}
+
+// Message is the request or the response of a record.
+type Message struct {
+	Header    http.Header `json:"header,omitempty"`
+	Body      string      `json:"body,omitempty"`
+	Encoding  string      `json:"encoding,omitempty"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// Recorder records the requests sent through its proxies, the records are appended to the file as
+// JSON lines.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+	rand *rand.Rand
+
+	sampleRate  float64
+	redact      map[string]bool
+	maxBodySize int
+}
+
+// Option configures a recorder.
+type Option func(*Recorder)
+
+// WithSampleRate records the ratio of the requests, between 0 and 1.
+func WithSampleRate(rate float64) Option {
+	return func(r *Recorder) {
+		r.sampleRate = rate
+	}
+}
+
+// WithRedact redacts the values of the headers, the query params and the JSON fields with the
+// names, the names are case-insensitive.
+func WithRedact(names ...string) Option {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.redact[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// WithMaxBodySize truncates the recorded bodies to size bytes, 0 doesn't truncate.
+func WithMaxBodySize(size int) Option {
+	return func(r *Recorder) {
+		r.maxBodySize = size
+	}
+}
+
+// New creates a recorder that records the requests in the file at path.
+func New(path string, options ...Option) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		file:       file,
+		enc:        json.NewEncoder(file),
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		sampleRate: 1,
+		redact:     make(map[string]bool),
+	}
+	WithRedact(defaultRedact...)(r)
+	for _, apply := range options {
+		apply(r)
+	}
+	return r, nil
+}
+
+// Close closes the file of the records.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.file.Close()
+}
+
+// Proxy returns a reverse proxy to the server of the kind at address that records the requests.
+// the gRPC proxy accepts HTTP/2 without TLS like the gRPC servers of the chains.
+func (r *Recorder) Proxy(kind Kind, address string) http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: xurl.Address(address)})
+
+	// the responses are streamed as is, e.g. the server streams of gRPC.
+	proxy.FlushInterval = -1
+
+	proxy.ModifyResponse = func(res *http.Response) error {
+		if e, ok := res.Request.Context().Value(exchangeKey{}).(*exchange); ok {
+			e.res = res
+			res.Body = &teeReadCloser{res.Body, e.resBody}
+		}
+		return nil
+	}
+	proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		if e, ok := req.Context().Value(exchangeKey{}).(*exchange); ok {
+			e.err = err
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.sample() {
+			proxy.ServeHTTP(w, req)
+			return
+		}
+
+		e := &exchange{
+			start:   time.Now(),
+			reqBody: newBodyBuffer(r.maxBodySize),
+			resBody: newBodyBuffer(r.maxBodySize),
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			req.Body = &teeReadCloser{req.Body, e.reqBody}
+		}
+		header := req.Header.Clone()
+
+		proxy.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), exchangeKey{}, e)))
+
+		r.write(r.record(kind, req, header, e))
+	})
+
+	if kind == KindGRPC {
+		proxy.Transport = &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		}
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+
+	return handler
+}
+
+// exchangeKey is the context key of the exchange of a request that is recorded.
+type exchangeKey struct{}
+
+// exchange is a request that is recorded while it's proxied.
+type exchange struct {
+	start   time.Time
+	reqBody *bodyBuffer
+	resBody *bodyBuffer
+	res     *http.Response
+	err     error
+}
+
+func (r *Recorder) sample() bool {
+	if r.sampleRate >= 1 {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rand.Float64() < r.sampleRate
+}
+
+// record creates the record of the request once its response is sent.
+func (r *Recorder) record(kind Kind, req *http.Request, header http.Header, e *exchange) Record {
+	rec := Record{
+		Time:     e.start,
+		Kind:     kind,
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Query:    r.redactQuery(req.URL.RawQuery),
+		Status:   http.StatusBadGateway,
+		Duration: time.Since(e.start),
+		Request:  r.message(kind, header, e.reqBody),
+	}
+	if e.err != nil {
+		rec.Error = e.err.Error()
+	}
+	if e.res != nil {
+		rec.Status = e.res.StatusCode
+		rec.Response = r.message(kind, e.res.Header, e.resBody)
+
+		if kind == KindGRPC {
+			// the status is in the headers of the responses without a body.
+			rec.GRPCStatus, rec.GRPCMessage = e.res.Trailer.Get("Grpc-Status"), e.res.Trailer.Get("Grpc-Message")
+			if rec.GRPCStatus == "" {
+				rec.GRPCStatus, rec.GRPCMessage = e.res.Header.Get("Grpc-Status"), e.res.Header.Get("Grpc-Message")
+			}
+		}
+	}
+	return rec
+}
+
+func (r *Recorder) message(kind Kind, header http.Header, body *bodyBuffer) Message {
+	m := Message{
+		Header:    r.redactHeader(header),
+		Truncated: body.truncated,
+	}
+
+	data := body.Bytes()
+	switch {
+	case len(data) == 0:
+	case kind == KindGRPC || !utf8.Valid(data):
+		m.Body, m.Encoding = base64.StdEncoding.EncodeToString(data), EncodingBase64
+	default:
+		m.Body = string(r.redactJSON(data))
+	}
+	return m
+}
+
+func (r *Recorder) write(rec Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the requests are proxied even when they can't be recorded.
+	r.enc.Encode(rec)
+}
+
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	redacted := header.Clone()
+	for name := range redacted {
+		if r.redact[strings.ToLower(name)] {
+			redacted[name] = []string{Redacted}
+		}
+	}
+	return redacted
+}
+
+func (r *Recorder) redactQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil || len(values) == 0 {
+		return query
+	}
+
+	var redacted bool
+	for name := range values {
+		if r.redact[strings.ToLower(name)] {
+			values[name] = []string{Redacted}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return query
+	}
+	return values.Encode()
+}
+
+// redactJSON redacts the fields of the JSON data, the data that is not JSON or that is truncated
+// is returned as is.
+func (r *Recorder) redactJSON(data []byte) []byte {
+	// the numbers are decoded as is to not lose their precision when they're encoded back.
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return data
+	}
+	if !r.redactValue(v) {
+		return data
+	}
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+// redactValue redacts the fields of v in place and returns whether some are redacted.
+func (r *Recorder) redactValue(v interface{}) (redacted bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			if r.redact[strings.ToLower(name)] {
+				v[name] = Redacted
+				redacted = true
+				continue
+			}
+			if r.redactValue(value) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if r.redactValue(value) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+// bodyBuffer keeps the first bytes of a body up to its max size.
+type bodyBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func newBodyBuffer(max int) *bodyBuffer {
+	return &bodyBuffer{max: max}
+}
+
+func (b *bodyBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if b.max > 0 {
+		if left := b.max - b.Len(); len(p) > left {
+			p = p[:left]
+			b.truncated = true
+		}
+	}
+	b.Buffer.Write(p)
+	return n, nil
+}
+
+// teeReadCloser writes the bytes read from the body to w.
+type teeReadCloser struct {
+	io.ReadCloser
+	w io.Writer
+}
+
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if n > 0 {
+		t.w.Write(p[:n])
+	}
+	return n, err
+}
+
+// String returns a line that summarizes the record.
+func (rec Record) String() string {
+	status := fmt.Sprint(rec.Status)
+	if rec.GRPCStatus != "" {
+		status = fmt.Sprintf("%d grpc-status=%s", rec.Status, rec.GRPCStatus)
+	}
+	path := rec.Path
+	if rec.Query != "" {
+		path += "?" + rec.Query
+	}
+	return fmt.Sprintf(
+		"%s %-4s %s %s %s %s",
+		rec.Time.Local().Format("15:04:05.000"),
+		rec.Kind,
+		rec.Method,
+		path,
+		status,
+		rec.Duration.Round(time.Microsecond),
+	)
+}
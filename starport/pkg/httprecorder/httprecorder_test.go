@@ -0,0 +1,231 @@
+package httprecorder
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	var records []Record
+	require.NoError(t, Tail(context.Background(), path, func(rec Record) error {
+		records = append(records, rec)
+		return nil
+	}))
+	return records
+}
+
+// waitRecords waits for the n records of the requests, they're written once the responses are
+// sent.
+func waitRecords(t *testing.T, path string, n int) []Record {
+	var records []Record
+	require.Eventually(t, func() bool {
+		records = readRecords(t, path)
+		return len(records) == n
+	}, time.Second, 10*time.Millisecond)
+	return records
+}
+
+func newAPI(t *testing.T) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestProxyAPI(t *testing.T) {
+	var (
+		api  = newAPI(t)
+		path = filepath.Join(t.TempDir(), "requests.jsonl")
+	)
+
+	r, err := New(path, WithRedact("password", "token"))
+	require.NoError(t, err)
+	defer r.Close()
+
+	proxy := httptest.NewServer(r.Proxy(KindAPI, api.Listener.Addr().String()))
+	defer proxy.Close()
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		proxy.URL+"/accounts?token=abc&limit=10",
+		strings.NewReader(`{"name":"alice","password":"secret","amount":100000000000000000001}`),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	// the request is proxied as is.
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	require.Equal(t, `{"name":"alice","password":"secret","amount":100000000000000000001}`, string(body))
+
+	records := waitRecords(t, path, 1)
+
+	rec := records[0]
+	require.Equal(t, KindAPI, rec.Kind)
+	require.Equal(t, http.MethodPost, rec.Method)
+	require.Equal(t, "/accounts", rec.Path)
+	require.Equal(t, "limit=10&token=%5BREDACTED%5D", rec.Query)
+	require.Equal(t, http.StatusCreated, rec.Status)
+	require.Equal(t, []string{Redacted}, rec.Request.Header["Authorization"])
+	require.Equal(t, []string{Redacted}, rec.Response.Header["Set-Cookie"])
+	require.JSONEq(t, `{"name":"alice","password":"[REDACTED]","amount":100000000000000000001}`, rec.Request.Body)
+	require.JSONEq(t, `{"name":"alice","password":"[REDACTED]","amount":100000000000000000001}`, rec.Response.Body)
+}
+
+func TestProxyAPIUnreachable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+
+	r, err := New(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := l.Addr().String()
+	l.Close()
+
+	proxy := httptest.NewServer(r.Proxy(KindAPI, address))
+	defer proxy.Close()
+
+	res, err := http.Get(proxy.URL + "/status")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusBadGateway, res.StatusCode)
+
+	records := waitRecords(t, path, 1)
+	require.Equal(t, http.StatusBadGateway, records[0].Status)
+	require.NotEmpty(t, records[0].Error)
+}
+
+func TestProxySampleAndTruncate(t *testing.T) {
+	var (
+		api  = newAPI(t)
+		path = filepath.Join(t.TempDir(), "requests.jsonl")
+	)
+
+	send := func(r *Recorder) {
+		proxy := httptest.NewServer(r.Proxy(KindAPI, api.Listener.Addr().String()))
+		defer proxy.Close()
+
+		res, err := http.Post(proxy.URL, "text/plain", strings.NewReader("0123456789"))
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	r, err := New(path, WithSampleRate(0))
+	require.NoError(t, err)
+	send(r)
+	require.NoError(t, r.Close())
+	require.Empty(t, readRecords(t, path))
+
+	r, err = New(path, WithMaxBodySize(4))
+	require.NoError(t, err)
+	send(r)
+	require.NoError(t, r.Close())
+
+	records := waitRecords(t, path, 1)
+	require.Equal(t, Message{Body: "0123", Truncated: true}, Message{
+		Body:      records[0].Request.Body,
+		Truncated: records[0].Request.Truncated,
+	})
+	require.Equal(t, "0123", records[0].Response.Body)
+	require.True(t, records[0].Response.Truncated)
+}
+
+func TestProxyGRPC(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(l)
+	defer s.Stop()
+
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+	r, err := New(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	proxy := httptest.NewServer(r.Proxy(KindGRPC, l.Addr().String()))
+	defer proxy.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, proxy.Listener.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	res, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.Error(t, err)
+
+	records := waitRecords(t, path, 2)
+	require.Equal(t, "/grpc.health.v1.Health/Check", records[0].Path)
+	require.Equal(t, "0", records[0].GRPCStatus)
+	require.Equal(t, EncodingBase64, records[0].Response.Encoding)
+	require.Equal(t, "5", records[1].GRPCStatus)
+	require.Equal(t, "unknown service", records[1].GRPCMessage)
+}
+
+func TestTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"kind":"api","path":"/a"}
+not a record
+{"kind":"api","path":"/b"}
+{"kind":"grpc","path":"/c"}
+{"kind":"api","path":`), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	records := make(chan Record)
+	done := make(chan error)
+	go func() {
+		done <- Tail(ctx, path, func(rec Record) error {
+			records <- rec
+			return nil
+		}, TailLast(1), TailFollow(), TailKind(KindAPI))
+	}()
+
+	// only the last record of the kind is handled before following the file.
+	require.Equal(t, "/b", (<-records).Path)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	defer file.Close()
+
+	// the partial line is handled once it's written entirely.
+	_, err = file.WriteString(`"/d"}` + "\n")
+	require.NoError(t, err)
+	require.Equal(t, "/d", (<-records).Path)
+
+	cancel()
+	require.Equal(t, context.Canceled, <-done)
+}
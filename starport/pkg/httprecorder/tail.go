@@ -0,0 +1,152 @@
+package httprecorder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// tailPollInterval is the interval between the reads of a followed file once its end is reached.
+const tailPollInterval = 200 * time.Millisecond
+
+type tailOptions struct {
+	last   int
+	follow bool
+	kind   Kind
+}
+
+// TailOption configures the tail of the records.
+type TailOption func(*tailOptions)
+
+// TailLast handles only the last n records of the file, 0 handles all of them.
+func TailLast(n int) TailOption {
+	return func(o *tailOptions) {
+		o.last = n
+	}
+}
+
+// TailFollow waits for the file to be created and handles the new records until ctx is done.
+func TailFollow() TailOption {
+	return func(o *tailOptions) {
+		o.follow = true
+	}
+}
+
+// TailKind handles only the records of the requests sent to the servers of the kind.
+func TailKind(kind Kind) TailOption {
+	return func(o *tailOptions) {
+		o.kind = kind
+	}
+}
+
+// Tail calls handle with the records of the file at path in order.
+func Tail(ctx context.Context, path string, handle func(Record) error, options ...TailOption) error {
+	var o tailOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	file, err := openTail(ctx, path, o.follow)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var (
+		reader = bufio.NewReader(file)
+		line   []byte
+		last   []Record
+	)
+
+	// readRecord reads the next record of the file, io.EOF is returned at the end of the file and
+	// the partial line that is being written is kept for the next read.
+	readRecord := func() (Record, error) {
+		for {
+			chunk, err := reader.ReadBytes('\n')
+			line = append(line, chunk...)
+			if err != nil {
+				return Record{}, err
+			}
+
+			var rec Record
+			err = json.Unmarshal(line, &rec)
+			line = line[:0]
+			if err != nil {
+				// the lines that are not records are skipped.
+				continue
+			}
+			if o.kind != "" && rec.Kind != o.kind {
+				continue
+			}
+			return rec, nil
+		}
+	}
+
+	for {
+		rec, err := readRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		last = append(last, rec)
+		if o.last > 0 && len(last) > o.last {
+			last = last[1:]
+		}
+	}
+	for _, rec := range last {
+		if err := handle(rec); err != nil {
+			return err
+		}
+	}
+
+	if !o.follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+
+	for {
+		rec, err := readRecord()
+		switch {
+		case err == io.EOF:
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		case err != nil:
+			return err
+		default:
+			if err := handle(rec); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// openTail opens the file at path, it waits for the file to be created when follow is set.
+func openTail(ctx context.Context, path string, follow bool) (*os.File, error) {
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+
+	for {
+		file, err := os.Open(path)
+		if err == nil || !follow || !errors.Is(err, os.ErrNotExist) {
+			return file, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
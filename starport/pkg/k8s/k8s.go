@@ -0,0 +1,387 @@
+// Package k8s writes the Kubernetes manifests and the Helm charts that deploy the nodes of a chain
+// and its faucet.
+package k8s
+
+import (
+	"bytes"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Role is the role of a node in the deployment.
+type Role string
+
+const (
+	// RoleValidator is a node that signs the blocks with the key of a validator.
+	RoleValidator Role = "validator"
+
+	// RoleSentry is a node that shields the validators from the other peers and serves the clients.
+	RoleSentry Role = "sentry"
+)
+
+// The ports of the servers of the nodes in their containers.
+const (
+	PortP2P  = 26656
+	PortRPC  = 26657
+	PortAPI  = 1317
+	PortGRPC = 9090
+)
+
+// HomePath is the home of the nodes in their containers.
+const HomePath = "/chain"
+
+// Defaults of the values of the manifests, they can be changed in the values of the charts.
+const (
+	DefaultPullPolicy  = "IfNotPresent"
+	DefaultStorage     = "10Gi"
+	DefaultServiceType = "ClusterIP"
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+// Chain is a chain deployed to Kubernetes.
+type Chain struct {
+	// Name is the name of the chain, the resources are named after it.
+	Name string
+
+	// ChainID is the ID of the chain.
+	ChainID string
+
+	// Binary is the name of the binary of the chain in its image.
+	Binary string
+
+	// Image is the image of the chain.
+	Image string
+
+	// Genesis is the genesis of the chain.
+	Genesis []byte
+
+	// Nodes are the nodes of the chain.
+	Nodes []Node
+
+	// Faucet is the faucet of the chain, the faucet is not deployed when it's nil.
+	Faucet *Faucet
+}
+
+// Node is a node of a chain deployed in a StatefulSet.
+type Node struct {
+	// Name is the name of the node, unique in the chain.
+	Name string
+
+	Role Role
+
+	// Config are the files of the config dir of the node stored in a ConfigMap, like app.toml.
+	Config map[string][]byte
+
+	// Keys are the files of the config dir of the node stored in a Secret, like node_key.json.
+	Keys map[string][]byte
+}
+
+// Faucet is the faucet of a chain served by Starport.
+type Faucet struct {
+	// Image is the image of Starport.
+	Image string
+
+	// Port is the port the faucet is served at.
+	Port int
+
+	// Config is the config file of the chain that configures the faucet.
+	Config []byte
+
+	// Profile is the profile of the config used by the faucet.
+	Profile string
+
+	// Keyring are the files of the faucet account in the test keyring.
+	Keyring map[string][]byte
+}
+
+// Resource returns the name of the resources of the node.
+func (c Chain) Resource(node string) string {
+	return c.Name + "-" + node
+}
+
+// Validators returns the validator nodes.
+func (c Chain) Validators() []Node {
+	return c.nodes(RoleValidator)
+}
+
+// Sentries returns the sentry nodes.
+func (c Chain) Sentries() []Node {
+	return c.nodes(RoleSentry)
+}
+
+func (c Chain) nodes(role Role) []Node {
+	var nodes []Node
+	for _, n := range c.Nodes {
+		if n.Role == role {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// RPCRole returns the role of the nodes that serve the clients, the sentries when there are some
+// and the validators otherwise.
+func (c Chain) RPCRole() Role {
+	if len(c.Sentries()) > 0 {
+		return RoleSentry
+	}
+	return RoleValidator
+}
+
+// WriteManifests writes the manifests of the chain to dir, a file for each node, a file for the
+// genesis, the Service of the nodes and the faucet. the manifests are applied with kubectl apply -f dir.
+func (c Chain) WriteManifests(dir string) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	w := writer{chain: c}
+	files := map[string][]byte{}
+	for _, n := range c.Nodes {
+		b, err := w.render("node.yaml.tpl", n)
+		if err != nil {
+			return err
+		}
+		files[n.Name+".yaml"] = b
+	}
+	for _, name := range []string{"genesis.yaml", "service.yaml", "faucet.yaml"} {
+		if name == "faucet.yaml" && c.Faucet == nil {
+			continue
+		}
+		b, err := w.render(name+".tpl", Node{})
+		if err != nil {
+			return err
+		}
+		files[name] = b
+	}
+	return writeFiles(dir, files)
+}
+
+// WriteChart writes a Helm chart of the chain to dir, the files of the nodes and the genesis are in
+// the files of the chart and the image, the storage and the types of the Services are values.
+func (c Chain) WriteChart(dir string) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	w := writer{chain: c, helm: true}
+	files := map[string][]byte{
+		"files/genesis/genesis.json": c.Genesis,
+	}
+	for _, n := range c.Nodes {
+		b, err := w.render("node.yaml.tpl", n)
+		if err != nil {
+			return err
+		}
+		files[filepath.Join("templates", n.Name+".yaml")] = b
+		for name, content := range n.Config {
+			files[filepath.Join("files", n.Name, "config", name)] = content
+		}
+		for name, content := range n.Keys {
+			files[filepath.Join("files", n.Name, "keys", name)] = content
+		}
+	}
+	if c.Faucet != nil {
+		files["files/faucet/config/config.yml"] = c.Faucet.Config
+		for name, content := range c.Faucet.Keyring {
+			files[filepath.Join("files", "faucet", "keyring", name)] = content
+		}
+	}
+
+	for _, name := range []string{"Chart.yaml", "values.yaml", "genesis.yaml", "service.yaml", "faucet.yaml"} {
+		if name == "faucet.yaml" && c.Faucet == nil {
+			continue
+		}
+		b, err := w.render(name+".tpl", Node{})
+		if err != nil {
+			return err
+		}
+		path := name
+		if name != "Chart.yaml" && name != "values.yaml" {
+			path = filepath.Join("templates", name)
+		}
+		files[path] = b
+	}
+	return writeFiles(dir, files)
+}
+
+func (c Chain) validate() error {
+	if len(c.Validators()) == 0 {
+		return fmt.Errorf("the chain %s has no validators", c.Name)
+	}
+	// the genesis and the faucet have resources named like the ones of the nodes.
+	seen := map[string]bool{"genesis": true, "faucet": true}
+	for _, n := range c.Nodes {
+		if seen[n.Name] {
+			return fmt.Errorf("the name %s is used by several nodes or reserved", n.Name)
+		}
+		seen[n.Name] = true
+	}
+	return nil
+}
+
+func writeFiles(dir string, files map[string][]byte) error {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writer renders the templates of the manifests, as Helm templates for the charts.
+type writer struct {
+	chain Chain
+	helm  bool
+}
+
+// templateData is the data of the templates.
+type templateData struct {
+	Chain
+	Node Node
+	Home string
+	Helm bool
+}
+
+// values are the values of the charts and their values in the manifests.
+func (w writer) values() map[string]string {
+	values := map[string]string{
+		"image":           w.chain.Image,
+		"imagePullPolicy": DefaultPullPolicy,
+		"storage":         DefaultStorage,
+		"serviceType":     DefaultServiceType,
+	}
+	if w.chain.Faucet != nil {
+		values["faucet.image"] = w.chain.Faucet.Image
+		values["faucet.serviceType"] = DefaultServiceType
+	}
+	return values
+}
+
+func (w writer) render(name string, n Node) ([]byte, error) {
+	funcs := template.FuncMap{
+		"value":   w.value,
+		"plain":   func(key string) string { return strconv.Quote(w.values()[key]) },
+		"config":  w.config,
+		"secrets": w.secrets,
+		"labels":  w.labels,
+		"port":    port,
+		"quote":   strconv.Quote,
+		"files": func(name string, content []byte) map[string][]byte {
+			return map[string][]byte{name: content}
+		},
+	}
+	t, err := template.New(name).Delims("[[", "]]").Funcs(funcs).ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, templateData{Chain: w.chain, Node: n, Home: HomePath, Helm: w.helm}); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// value returns the quoted value with the key, a reference to the value in the charts.
+func (w writer) value(key string) string {
+	if w.helm {
+		return fmt.Sprintf("{{ .Values.%s | quote }}", key)
+	}
+	return strconv.Quote(w.values()[key])
+}
+
+// config returns the entries of the data of a ConfigMap with the files, the files in dir are read in
+// the charts.
+func (w writer) config(dir string, files map[string][]byte) string {
+	if w.helm {
+		return fmt.Sprintf("{{- (.Files.Glob %q).AsConfig | nindent 2 }}", "files/"+dir+"/*")
+	}
+
+	var b strings.Builder
+	for _, name := range sortedNames(files) {
+		fmt.Fprintf(&b, "  %s: %s\n", name, blockScalar(string(files[name]), "    "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// secrets returns the entries of the data of a Secret with the files, the files in dir are read in
+// the charts.
+func (w writer) secrets(dir string, files map[string][]byte) string {
+	if w.helm {
+		return fmt.Sprintf("{{- (.Files.Glob %q).AsSecrets | nindent 2 }}", "files/"+dir+"/*")
+	}
+
+	var b strings.Builder
+	for _, name := range sortedNames(files) {
+		fmt.Fprintf(&b, "  %s: %s\n", name, base64.StdEncoding.EncodeToString(files[name]))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// labels returns the labels of the resources of the component with the name, indented with indent spaces.
+func (w writer) labels(indent int, name string, component interface{}) string {
+	prefix := strings.Repeat(" ", indent)
+	return strings.Join([]string{
+		prefix + "app.kubernetes.io/name: " + name,
+		prefix + "app.kubernetes.io/part-of: " + w.chain.Name,
+		prefix + "app.kubernetes.io/component: " + fmt.Sprint(component),
+	}, "\n")
+}
+
+// port returns the port of the server of the nodes with the name.
+func port(name string) int {
+	return map[string]int{
+		"p2p":  PortP2P,
+		"rpc":  PortRPC,
+		"api":  PortAPI,
+		"grpc": PortGRPC,
+	}[name]
+}
+
+func sortedNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// blockScalar returns s as a YAML literal block indented with indent, s is quoted when it can't be
+// represented as a literal block.
+func blockScalar(s, indent string) string {
+	if s == "" || strings.ContainsAny(s, "\r\t") || strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\n") ||
+		strings.HasSuffix(s, "\n\n") {
+		return strconv.Quote(s)
+	}
+
+	// the final line break is stripped when there's none.
+	header := "|"
+	if !strings.HasSuffix(s, "\n") {
+		header = "|-"
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		b.WriteString("\n")
+		if line != "" {
+			b.WriteString(indent + line)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,190 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func testChain() Chain {
+	return Chain{
+		Name:    "mars",
+		ChainID: "mars-1",
+		Binary:  "marsd",
+		Image:   "mars:latest",
+		Genesis: []byte("{\n  \"chain_id\": \"mars-1\"\n}\n"),
+		Nodes: []Node{
+			{
+				Name:   "alice",
+				Role:   RoleValidator,
+				Config: map[string][]byte{"config.toml": []byte("moniker = \"alice\"\n\n[p2p]\n  pex = false\n")},
+				Keys:   map[string][]byte{"node_key.json": []byte(`{"id":"alice"}`)},
+			},
+			{
+				Name:   "bob",
+				Role:   RoleValidator,
+				Config: map[string][]byte{"config.toml": []byte("moniker = \"bob\"\n")},
+				Keys:   map[string][]byte{"node_key.json": []byte(`{"id":"bob"}`)},
+			},
+			{
+				Name:   "sentry-0",
+				Role:   RoleSentry,
+				Config: map[string][]byte{"config.toml": []byte("moniker = \"sentry-0\"\n")},
+				Keys:   map[string][]byte{"node_key.json": []byte(`{"id":"sentry-0"}`)},
+			},
+		},
+		Faucet: &Faucet{
+			Image:   "starport/cli:latest",
+			Port:    4500,
+			Config:  []byte("faucet:\n  name: faucet\n"),
+			Profile: "staging",
+			Keyring: map[string][]byte{"faucet.info": []byte("info")},
+		},
+	}
+}
+
+// readManifests returns the resources of the manifests in the file by their kinds and names.
+func readManifests(t *testing.T, path string) map[string]map[string]interface{} {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	resources := make(map[string]map[string]interface{})
+	for _, doc := range strings.Split(string(data), "\n---\n") {
+		var r map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(strings.TrimSuffix(doc, "\n")+"\n"), &r), doc)
+		metadata := r["metadata"].(map[string]interface{})
+		resources[r["kind"].(string)+"/"+metadata["name"].(string)] = r
+	}
+	return resources
+}
+
+func TestWriteManifests(t *testing.T) {
+	dir := t.TempDir()
+	c := testChain()
+	require.NoError(t, c.WriteManifests(dir))
+
+	alice := readManifests(t, filepath.Join(dir, "alice.yaml"))
+	require.Len(t, alice, 4)
+
+	config := alice["ConfigMap/mars-alice-config"]["data"].(map[string]interface{})
+	require.Equal(t, string(c.Nodes[0].Config["config.toml"]), config["config.toml"])
+
+	keys := alice["Secret/mars-alice-keys"]["data"].(map[string]interface{})
+	key, err := base64.StdEncoding.DecodeString(keys["node_key.json"].(string))
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"alice"}`, string(key))
+
+	statefulSet := alice["StatefulSet/mars-alice"]["spec"].(map[string]interface{})
+	require.Equal(t, "mars-alice", statefulSet["serviceName"])
+	template := statefulSet["template"].(map[string]interface{})
+	labels := template["metadata"].(map[string]interface{})["labels"].(map[string]interface{})
+	require.Equal(t, "validator", labels["app.kubernetes.io/component"])
+	container := template["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "mars:latest", container["image"])
+	require.Equal(t, []interface{}{"marsd", "start", "--home", HomePath}, container["command"])
+
+	genesis := readManifests(t, filepath.Join(dir, "genesis.yaml"))
+	data := genesis["ConfigMap/mars-genesis"]["data"].(map[string]interface{})
+	require.Equal(t, string(c.Genesis), data["genesis.json"])
+
+	// the sentries serve the clients.
+	service := readManifests(t, filepath.Join(dir, "service.yaml"))["Service/mars"]["spec"].(map[string]interface{})
+	require.Equal(t, "sentry", service["selector"].(map[string]interface{})["app.kubernetes.io/component"])
+
+	faucet := readManifests(t, filepath.Join(dir, "faucet.yaml"))
+	require.Len(t, faucet, 4)
+	deployment := faucet["Deployment/mars-faucet"]["spec"].(map[string]interface{})["template"].(map[string]interface{})
+	container = deployment["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "starport/cli:latest", container["image"])
+	require.Contains(t, container["command"], "staging")
+	require.Contains(t, container["command"], "http://mars:26657")
+
+	require.NoFileExists(t, filepath.Join(dir, "Chart.yaml"))
+}
+
+func TestWriteManifestsWithoutFaucet(t *testing.T) {
+	dir := t.TempDir()
+	c := testChain()
+	c.Faucet = nil
+	c.Nodes = c.Nodes[:1]
+	require.NoError(t, c.WriteManifests(dir))
+
+	require.NoFileExists(t, filepath.Join(dir, "faucet.yaml"))
+	service := readManifests(t, filepath.Join(dir, "service.yaml"))["Service/mars"]["spec"].(map[string]interface{})
+	require.Equal(t, "validator", service["selector"].(map[string]interface{})["app.kubernetes.io/component"])
+}
+
+func TestWriteChart(t *testing.T) {
+	dir := t.TempDir()
+	c := testChain()
+	require.NoError(t, c.WriteChart(dir))
+
+	for _, name := range []string{
+		"Chart.yaml",
+		"values.yaml",
+		"templates/alice.yaml",
+		"templates/sentry-0.yaml",
+		"templates/genesis.yaml",
+		"templates/service.yaml",
+		"templates/faucet.yaml",
+		"files/genesis/genesis.json",
+		"files/alice/config/config.toml",
+		"files/alice/keys/node_key.json",
+		"files/faucet/config/config.yml",
+		"files/faucet/keyring/faucet.info",
+	} {
+		require.FileExists(t, filepath.Join(dir, name))
+	}
+
+	var values map[string]interface{}
+	data, err := os.ReadFile(filepath.Join(dir, "values.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(data, &values))
+	require.Equal(t, "mars:latest", values["image"])
+	require.Equal(t, DefaultStorage, values["storage"])
+	require.Equal(t, true, values["faucet"].(map[string]interface{})["enabled"])
+
+	node, err := os.ReadFile(filepath.Join(dir, "templates/alice.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(node), `image: {{ .Values.image | quote }}`)
+	require.Contains(t, string(node), `{{- (.Files.Glob "files/alice/config/*").AsConfig | nindent 2 }}`)
+	require.Contains(t, string(node), `{{- (.Files.Glob "files/alice/keys/*").AsSecrets | nindent 2 }}`)
+
+	faucet, err := os.ReadFile(filepath.Join(dir, "templates/faucet.yaml"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(faucet), "{{- if .Values.faucet.enabled }}\n"))
+	require.True(t, strings.HasSuffix(string(faucet), "{{- end }}\n"))
+}
+
+func TestValidate(t *testing.T) {
+	c := testChain()
+	c.Nodes = c.Nodes[2:]
+	require.Error(t, c.WriteManifests(t.TempDir()))
+
+	c = testChain()
+	c.Nodes[1].Name = "alice"
+	require.Error(t, c.WriteManifests(t.TempDir()))
+
+	c = testChain()
+	c.Nodes[1].Name = "faucet"
+	require.Error(t, c.WriteManifests(t.TempDir()))
+}
+
+func TestBlockScalar(t *testing.T) {
+	for _, s := range []string{
+		"a\nb\n",
+		"a\n\n  b",
+		"a\n\n",
+		" a\n",
+		"",
+	} {
+		var v struct{ S string }
+		require.NoError(t, yaml.Unmarshal([]byte("s: "+blockScalar(s, "  ")+"\n"), &v), s)
+		require.Equal(t, s, v.S)
+	}
+}
@@ -0,0 +1,5 @@
+apiVersion: v2
+name: [[ .Name ]]
+description: The nodes of the [[ .ChainID ]] chain[[ if .Faucet ]] and its faucet[[ end ]].
+type: application
+version: 0.1.0
@@ -0,0 +1,120 @@
+[[- $name := .Resource "faucet" -]]
+[[- if .Helm ]]{{- if .Values.faucet.enabled }}
+[[ end -]]
+apiVersion: v1
+kind: Secret
+metadata:
+  name: [[ $name ]]-config
+  labels:
+[[ labels 4 $name "faucet" ]]
+type: Opaque
+data:
+[[ secrets "faucet/config" (files "config.yml" .Faucet.Config) ]]
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: [[ $name ]]-keyring
+  labels:
+[[ labels 4 $name "faucet" ]]
+type: Opaque
+data:
+[[ secrets "faucet/keyring" .Faucet.Keyring ]]
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: [[ $name ]]
+  labels:
+[[ labels 4 $name "faucet" ]]
+spec:
+  type: [[ value "faucet.serviceType" ]]
+  selector:
+    app.kubernetes.io/name: [[ $name ]]
+  ports:
+    - name: http
+      port: [[ .Faucet.Port ]]
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: [[ $name ]]
+  labels:
+[[ labels 4 $name "faucet" ]]
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: [[ $name ]]
+  template:
+    metadata:
+      labels:
+[[ labels 8 $name "faucet" ]]
+    spec:
+      initContainers:
+        # the faucet sends the coins with the binary of the chain copied from its image.
+        - name: init-home
+          image: [[ value "image" ]]
+          imagePullPolicy: [[ value "imagePullPolicy" ]]
+          command:
+            - sh
+            - -c
+            - |
+              set -e
+              mkdir -p /faucet/bin /faucet/home/keyring-test
+              cp "$(command -v [[ .Binary ]])" /faucet/bin/
+              cp /etc/faucet/keyring/* /faucet/home/keyring-test/
+          volumeMounts:
+            - name: faucet
+              mountPath: /faucet
+            - name: keyring
+              mountPath: /etc/faucet/keyring
+      containers:
+        - name: faucet
+          image: [[ value "faucet.image" ]]
+          imagePullPolicy: [[ value "imagePullPolicy" ]]
+          command:
+            - starport
+            - chain
+            - faucet
+            - serve
+            - --config
+            - /etc/faucet/config/config.yml
+[[- if .Faucet.Profile ]]
+            - --profile
+            - [[ quote .Faucet.Profile ]]
+[[- end ]]
+            - --home
+            - /faucet/home
+            - --binary
+            - /faucet/bin/[[ .Binary ]]
+            - --chain-id
+            - [[ quote .ChainID ]]
+            - --node
+            - http://[[ .Name ]]:[[ port "rpc" ]]
+            - --api
+            - http://[[ .Name ]]:[[ port "api" ]]
+          ports:
+            - name: http
+              containerPort: [[ .Faucet.Port ]]
+          readinessProbe:
+            httpGet:
+              path: /healthz
+              port: http
+          volumeMounts:
+            - name: faucet
+              mountPath: /faucet
+            - name: config
+              mountPath: /etc/faucet/config
+      volumes:
+        - name: faucet
+          emptyDir: {}
+        - name: config
+          secret:
+            secretName: [[ $name ]]-config
+        - name: keyring
+          secret:
+            secretName: [[ $name ]]-keyring
+[[- if .Helm ]]
+{{- end }}
+[[- end ]]
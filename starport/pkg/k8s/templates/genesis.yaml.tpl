@@ -0,0 +1,8 @@
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: [[ .Resource "genesis" ]]
+  labels:
+    app.kubernetes.io/part-of: [[ .Name ]]
+data:
+[[ config "genesis" (files "genesis.json" .Genesis) ]]
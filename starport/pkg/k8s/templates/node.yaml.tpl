@@ -0,0 +1,128 @@
+[[- $name := .Resource .Node.Name -]]
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: [[ $name ]]-config
+  labels:
+[[ labels 4 $name .Node.Role ]]
+data:
+[[ config (print .Node.Name "/config") .Node.Config ]]
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: [[ $name ]]-keys
+  labels:
+[[ labels 4 $name .Node.Role ]]
+type: Opaque
+data:
+[[ secrets (print .Node.Name "/keys") .Node.Keys ]]
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: [[ $name ]]
+  labels:
+[[ labels 4 $name .Node.Role ]]
+spec:
+  clusterIP: None
+  # the peers are resolved before the nodes are ready.
+  publishNotReadyAddresses: true
+  selector:
+    app.kubernetes.io/name: [[ $name ]]
+  ports:
+    - name: p2p
+      port: [[ port "p2p" ]]
+    - name: rpc
+      port: [[ port "rpc" ]]
+    - name: api
+      port: [[ port "api" ]]
+    - name: grpc
+      port: [[ port "grpc" ]]
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: [[ $name ]]
+  labels:
+[[ labels 4 $name .Node.Role ]]
+spec:
+  serviceName: [[ $name ]]
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: [[ $name ]]
+  template:
+    metadata:
+      labels:
+[[ labels 8 $name .Node.Role ]]
+    spec:
+      securityContext:
+        fsGroup: 1000
+      initContainers:
+        # the config, the keys and the genesis are copied to the home on every start, the state of
+        # the validator is only created on the first start.
+        - name: init-home
+          image: [[ value "image" ]]
+          imagePullPolicy: [[ value "imagePullPolicy" ]]
+          command:
+            - sh
+            - -c
+            - |
+              set -e
+              mkdir -p [[ .Home ]]/config [[ .Home ]]/data
+              cp /etc/chain/config/* /etc/chain/keys/* /etc/chain/genesis/* [[ .Home ]]/config/
+              if [ ! -f [[ .Home ]]/data/priv_validator_state.json ]; then
+                echo '{"height":"0","round":0,"step":0}' > [[ .Home ]]/data/priv_validator_state.json
+              fi
+          volumeMounts:
+            - name: home
+              mountPath: [[ .Home ]]
+            - name: config
+              mountPath: /etc/chain/config
+            - name: keys
+              mountPath: /etc/chain/keys
+            - name: genesis
+              mountPath: /etc/chain/genesis
+      containers:
+        - name: node
+          image: [[ value "image" ]]
+          imagePullPolicy: [[ value "imagePullPolicy" ]]
+          command:
+            - [[ quote .Binary ]]
+            - start
+            - --home
+            - [[ .Home ]]
+          ports:
+            - name: p2p
+              containerPort: [[ port "p2p" ]]
+            - name: rpc
+              containerPort: [[ port "rpc" ]]
+            - name: api
+              containerPort: [[ port "api" ]]
+            - name: grpc
+              containerPort: [[ port "grpc" ]]
+          readinessProbe:
+            tcpSocket:
+              port: rpc
+          volumeMounts:
+            - name: home
+              mountPath: [[ .Home ]]
+      volumes:
+        - name: config
+          configMap:
+            name: [[ $name ]]-config
+        - name: keys
+          secret:
+            secretName: [[ $name ]]-keys
+        - name: genesis
+          configMap:
+            name: [[ .Resource "genesis" ]]
+  volumeClaimTemplates:
+    - metadata:
+        name: home
+      spec:
+        accessModes: ["ReadWriteOnce"]
+        resources:
+          requests:
+            storage: [[ value "storage" ]]
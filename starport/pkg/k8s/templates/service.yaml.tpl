@@ -0,0 +1,19 @@
+# the Service of the nodes that serve the clients.
+apiVersion: v1
+kind: Service
+metadata:
+  name: [[ .Name ]]
+  labels:
+    app.kubernetes.io/part-of: [[ .Name ]]
+spec:
+  type: [[ value "serviceType" ]]
+  selector:
+    app.kubernetes.io/part-of: [[ .Name ]]
+    app.kubernetes.io/component: [[ .RPCRole ]]
+  ports:
+    - name: rpc
+      port: [[ port "rpc" ]]
+    - name: api
+      port: [[ port "api" ]]
+    - name: grpc
+      port: [[ port "grpc" ]]
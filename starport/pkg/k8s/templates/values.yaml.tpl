@@ -0,0 +1,18 @@
+# image is the image of the chain, built with the Dockerfile written with the chart.
+image: [[ plain "image" ]]
+imagePullPolicy: [[ plain "imagePullPolicy" ]]
+
+# storage is the size of the volume of the home of each node.
+storage: [[ plain "storage" ]]
+
+# serviceType is the type of the Service of the RPC, the API and the gRPC server of the nodes.
+serviceType: [[ plain "serviceType" ]]
+[[- if .Faucet ]]
+
+faucet:
+  enabled: true
+
+  # image is the image of Starport that serves the faucet.
+  image: [[ plain "faucet.image" ]]
+  serviceType: [[ plain "faucet.serviceType" ]]
+[[- end ]]
@@ -0,0 +1,224 @@
+// Package keplr creates the chain info that Keplr wallet needs to suggest a chain to its users,
+// see https://docs.keplr.app/api/suggest-chain.html.
+package keplr
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+)
+
+// DefaultCoinType is the BIP44 coin type of the Cosmos SDK chains.
+const DefaultCoinType = 118
+
+const (
+	featureStargate      = "stargate"
+	featureIBCTransfer   = "ibc-transfer"
+	featureNoLegacyStdTx = "no-legacy-stdTx"
+)
+
+// ChainInfo is the payload of Keplr's experimentalSuggestChain.
+type ChainInfo struct {
+	ChainID       string       `json:"chainId"`
+	ChainName     string       `json:"chainName"`
+	RPC           string       `json:"rpc"`
+	REST          string       `json:"rest"`
+	BIP44         BIP44        `json:"bip44"`
+	Bech32Config  Bech32Config `json:"bech32Config"`
+	Currencies    []Currency   `json:"currencies"`
+	FeeCurrencies []Currency   `json:"feeCurrencies"`
+	StakeCurrency Currency     `json:"stakeCurrency"`
+	CoinType      int          `json:"coinType"`
+	Features      []string     `json:"features"`
+}
+
+// BIP44 is the BIP44 config of the chain's keys.
+type BIP44 struct {
+	CoinType int `json:"coinType"`
+}
+
+// Bech32Config is the bech32 prefixes of the chain's addresses and public keys.
+type Bech32Config struct {
+	Bech32PrefixAccAddr  string `json:"bech32PrefixAccAddr"`
+	Bech32PrefixAccPub   string `json:"bech32PrefixAccPub"`
+	Bech32PrefixValAddr  string `json:"bech32PrefixValAddr"`
+	Bech32PrefixValPub   string `json:"bech32PrefixValPub"`
+	Bech32PrefixConsAddr string `json:"bech32PrefixConsAddr"`
+	Bech32PrefixConsPub  string `json:"bech32PrefixConsPub"`
+}
+
+// Currency is a denom of the chain, Keplr shows the amounts in coinDenom with coinDecimals.
+type Currency struct {
+	CoinDenom        string `json:"coinDenom"`
+	CoinMinimalDenom string `json:"coinMinimalDenom"`
+	CoinDecimals     int    `json:"coinDecimals"`
+}
+
+// Option configures the chain info.
+type Option func(*ChainInfo)
+
+// WithChainName sets the name of the chain shown by Keplr, default is the chain id.
+func WithChainName(name string) Option {
+	return func(c *ChainInfo) {
+		c.ChainName = name
+	}
+}
+
+// WithCoinType sets the BIP44 coin type of the chain's keys, default is DefaultCoinType.
+func WithCoinType(coinType int) Option {
+	return func(c *ChainInfo) {
+		c.BIP44.CoinType = coinType
+		c.CoinType = coinType
+	}
+}
+
+// genesis is the part of the genesis needed to create the chain info.
+type genesis struct {
+	ChainID  string `json:"chain_id"`
+	AppState struct {
+		Auth struct {
+			Accounts []struct {
+				Address string `json:"address"`
+			} `json:"accounts"`
+		} `json:"auth"`
+		Bank struct {
+			Balances []struct {
+				Coins []struct {
+					Denom string `json:"denom"`
+				} `json:"coins"`
+			} `json:"balances"`
+			Supply []struct {
+				Denom string `json:"denom"`
+			} `json:"supply"`
+			DenomMetadata []struct {
+				Base       string `json:"base"`
+				Display    string `json:"display"`
+				DenomUnits []struct {
+					Denom    string `json:"denom"`
+					Exponent int    `json:"exponent"`
+				} `json:"denom_units"`
+			} `json:"denom_metadata"`
+		} `json:"bank"`
+		Staking struct {
+			Params struct {
+				BondDenom string `json:"bond_denom"`
+			} `json:"params"`
+		} `json:"staking"`
+		Transfer json.RawMessage `json:"transfer"`
+	} `json:"app_state"`
+}
+
+// NewChainInfo creates the chain info from the genesis of the chain. the chain id, the bech32 prefix,
+// the denoms and the features are read from the genesis and rpc and rest are the addresses of
+// the chain's Tendermint RPC and API.
+func NewChainInfo(genesisContent []byte, rpc, rest string, options ...Option) (ChainInfo, error) {
+	var g genesis
+	if err := json.Unmarshal(genesisContent, &g); err != nil {
+		return ChainInfo{}, err
+	}
+
+	if len(g.AppState.Auth.Accounts) == 0 {
+		return ChainInfo{}, errors.New("genesis has no accounts to detect the address prefix")
+	}
+	prefix, err := cosmosutil.GetAddressPrefix(g.AppState.Auth.Accounts[0].Address)
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	stakeDenom := g.AppState.Staking.Params.BondDenom
+	if stakeDenom == "" {
+		return ChainInfo{}, errors.New("genesis has no bond denom")
+	}
+
+	c := ChainInfo{
+		ChainID:   g.ChainID,
+		ChainName: g.ChainID,
+		RPC:       rpc,
+		REST:      rest,
+		Bech32Config: Bech32Config{
+			Bech32PrefixAccAddr:  prefix,
+			Bech32PrefixAccPub:   prefix + "pub",
+			Bech32PrefixValAddr:  prefix + "valoper",
+			Bech32PrefixValPub:   prefix + "valoperpub",
+			Bech32PrefixConsAddr: prefix + "valcons",
+			Bech32PrefixConsPub:  prefix + "valconspub",
+		},
+		Features: []string{featureStargate, featureNoLegacyStdTx},
+	}
+	WithCoinType(DefaultCoinType)(&c)
+
+	if len(g.AppState.Transfer) > 0 {
+		c.Features = append(c.Features, featureIBCTransfer)
+	}
+
+	for _, denom := range g.denoms() {
+		currency := g.currency(denom)
+		c.Currencies = append(c.Currencies, currency)
+		if denom == stakeDenom {
+			c.StakeCurrency = currency
+		}
+	}
+
+	// the stake denom is the default fee denom of the chains.
+	c.FeeCurrencies = append(c.FeeCurrencies, c.StakeCurrency)
+	for _, currency := range c.Currencies {
+		if currency.CoinMinimalDenom != stakeDenom {
+			c.FeeCurrencies = append(c.FeeCurrencies, currency)
+		}
+	}
+
+	for _, apply := range options {
+		apply(&c)
+	}
+
+	return c, nil
+}
+
+// denoms returns the sorted denoms of the supply, the balances and the stake.
+func (g genesis) denoms() []string {
+	seen := map[string]bool{
+		g.AppState.Staking.Params.BondDenom: true,
+	}
+
+	for _, coin := range g.AppState.Bank.Supply {
+		seen[coin.Denom] = true
+	}
+	for _, balance := range g.AppState.Bank.Balances {
+		for _, coin := range balance.Coins {
+			seen[coin.Denom] = true
+		}
+	}
+
+	var denoms []string
+	for denom := range seen {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	return denoms
+}
+
+// currency returns the currency of denom with its display unit in the denom metadata, the currency
+// of the denoms without metadata is the upper case denom without decimals.
+func (g genesis) currency(denom string) Currency {
+	currency := Currency{
+		CoinDenom:        strings.ToUpper(denom),
+		CoinMinimalDenom: denom,
+	}
+
+	for _, metadata := range g.AppState.Bank.DenomMetadata {
+		if metadata.Base != denom {
+			continue
+		}
+		for _, unit := range metadata.DenomUnits {
+			if unit.Denom == metadata.Display {
+				currency.CoinDenom = strings.ToUpper(unit.Denom)
+				currency.CoinDecimals = unit.Exponent
+			}
+		}
+	}
+
+	return currency
+}
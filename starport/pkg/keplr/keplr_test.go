@@ -0,0 +1,73 @@
+package keplr_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/keplr"
+)
+
+func TestNewChainInfo(t *testing.T) {
+	address, err := bech32.ConvertAndEncode("mars", []byte("alice_______________"))
+	require.NoError(t, err)
+
+	genesis := []byte(`{
+		"chain_id": "mars-1",
+		"app_state": {
+			"auth": {"accounts": [{"address": "` + address + `"}]},
+			"bank": {
+				"balances": [{"coins": [{"denom": "stake"}, {"denom": "utoken"}]}],
+				"supply": [{"denom": "stake"}, {"denom": "utoken"}, {"denom": "ibc/27394FB"}],
+				"denom_metadata": [{
+					"base": "utoken",
+					"display": "token",
+					"denom_units": [{"denom": "utoken", "exponent": 0}, {"denom": "token", "exponent": 6}]
+				}]
+			},
+			"staking": {"params": {"bond_denom": "stake"}},
+			"transfer": {"port_id": "transfer"}
+		}
+	}`)
+
+	var (
+		stake    = keplr.Currency{CoinDenom: "STAKE", CoinMinimalDenom: "stake"}
+		token    = keplr.Currency{CoinDenom: "TOKEN", CoinMinimalDenom: "utoken", CoinDecimals: 6}
+		ibcToken = keplr.Currency{CoinDenom: "IBC/27394FB", CoinMinimalDenom: "ibc/27394FB"}
+	)
+
+	info, err := keplr.NewChainInfo(genesis, "http://localhost:26657", "http://localhost:1317")
+	require.NoError(t, err)
+	require.Equal(t, keplr.ChainInfo{
+		ChainID:   "mars-1",
+		ChainName: "mars-1",
+		RPC:       "http://localhost:26657",
+		REST:      "http://localhost:1317",
+		BIP44:     keplr.BIP44{CoinType: keplr.DefaultCoinType},
+		Bech32Config: keplr.Bech32Config{
+			Bech32PrefixAccAddr:  "mars",
+			Bech32PrefixAccPub:   "marspub",
+			Bech32PrefixValAddr:  "marsvaloper",
+			Bech32PrefixValPub:   "marsvaloperpub",
+			Bech32PrefixConsAddr: "marsvalcons",
+			Bech32PrefixConsPub:  "marsvalconspub",
+		},
+		Currencies:    []keplr.Currency{ibcToken, stake, token},
+		FeeCurrencies: []keplr.Currency{stake, ibcToken, token},
+		StakeCurrency: stake,
+		CoinType:      keplr.DefaultCoinType,
+		Features:      []string{"stargate", "no-legacy-stdTx", "ibc-transfer"},
+	}, info)
+
+	info, err = keplr.NewChainInfo(genesis, "", "", keplr.WithChainName("Mars"), keplr.WithCoinType(60))
+	require.NoError(t, err)
+	require.Equal(t, "Mars", info.ChainName)
+	require.Equal(t, 60, info.BIP44.CoinType)
+	require.Equal(t, 60, info.CoinType)
+}
+
+func TestNewChainInfoInvalidGenesis(t *testing.T) {
+	_, err := keplr.NewChainInfo([]byte(`{"app_state": {"staking": {"params": {"bond_denom": "stake"}}}}`), "", "")
+	require.EqualError(t, err, "genesis has no accounts to detect the address prefix")
+}
@@ -2,23 +2,37 @@ package localfs
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
-
-	wt "github.com/radovskyb/watcher"
 )
 
+// debounceDelay is the time without changes after which the changes are notified, the changes
+// made together, e.g. by saving multiple files, are notified once.
+const debounceDelay = 300 * time.Millisecond
+
 type watcher struct {
-	wt           *wt.Watcher
 	workdir      string
 	ignoreHidden bool
 	ignoreExts   []string
 	onChange     func()
 	interval     time.Duration
-	ctx          context.Context
-	done         *sync.WaitGroup
+	polling      bool
+}
+
+// backend notifies the paths of the files changed under the watched paths, it's implemented with
+// the notifications of the system where they are supported and by polling the filesystem.
+type backend interface {
+	// add watches the file or the dir at path with its sub dirs, the paths that don't exist
+	// are ignored.
+	add(path string) error
+
+	// run sends the paths of the changed files to events until ctx is done.
+	run(ctx context.Context, events chan<- string) error
+
+	// close stops watching the paths.
+	close() error
 }
 
 // WatcherOption used to configure watcher.
@@ -38,10 +52,12 @@ func WatcherOnChange(hook func()) WatcherOption {
 	}
 }
 
-// WatcherPollingInterval overwrites default polling interval to check filesystem changes.
+// WatcherPollingInterval overwrites default polling interval to check filesystem changes, the
+// filesystem is polled instead of using the notifications of the system.
 func WatcherPollingInterval(d time.Duration) WatcherOption {
 	return func(w *watcher) {
 		w.interval = d
+		w.polling = true
 	}
 }
 
@@ -61,63 +77,100 @@ func WatcherIgnoreExt(exts ...string) WatcherOption {
 
 // Watch starts watching changes on the paths. options are used to configure the
 // behaviour of watch operation.
+// the notifications of the system are used on Linux, macOS, the BSDs and Windows, and the
+// filesystem is polled on the other systems or when the paths can't be watched with the
+// notifications, e.g. when the limit of the watched dirs of the system is reached.
 func Watch(ctx context.Context, paths []string, options ...WatcherOption) error {
-	wt := wt.New()
-	wt.SetMaxEvents(1)
-
 	w := &watcher{
-		wt:       wt,
 		onChange: func() {},
 		interval: time.Millisecond * 300,
-		done:     &sync.WaitGroup{},
-		ctx:      ctx,
 	}
 	for _, o := range options {
 		o(w)
 	}
 
-	// ignore hidden paths.
-	w.wt.IgnoreHiddenFiles(w.ignoreHidden)
-
-	// add paths to watch
-	w.addPaths(paths...)
-
-	// start watching.
-	w.done.Add(1)
-	go w.listen()
-	if err := w.wt.Start(w.interval); err != nil {
+	b, err := w.backend(w.absPaths(paths))
+	if err != nil {
 		return err
 	}
-	w.done.Wait()
-	return nil
-}
+	defer b.close()
+
+	events := make(chan string)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- b.run(ctx, events)
+	}()
+
+	var (
+		debounce = time.NewTimer(debounceDelay)
+		changed  bool
+	)
+	debounce.Stop()
+	defer debounce.Stop()
 
-func (w *watcher) listen() {
-	defer w.done.Done()
 	for {
 		select {
-		case e := <-w.wt.Event:
-			if !w.isFileIgnored(e.Path) {
+		case path := <-events:
+			// the dirs change with their files, only the changes of the files are notified.
+			if w.isFileIgnored(path) || isDir(path) {
+				continue
+			}
+			changed = true
+			debounce.Reset(debounceDelay)
+		case <-debounce.C:
+			if changed {
+				changed = false
 				w.onChange()
 			}
-		case <-w.wt.Closed:
-			return
-		case <-w.ctx.Done():
-			w.wt.Close()
+		case err := <-errc:
+			return err
 		}
 	}
 }
 
-func (w *watcher) addPaths(paths ...string) {
+// backend returns the backend watching the paths, the filesystem is polled when the notifications
+// of the system can't be used.
+func (w *watcher) backend(paths []string) (backend, error) {
+	if !w.polling {
+		if b, err := newNativeBackend(w.ignoreHidden); err == nil {
+			if err := addPaths(b, paths); err == nil {
+				return b, nil
+			}
+			b.close()
+		}
+	}
+
+	b := newPollingBackend(w.interval, w.ignoreHidden)
+	if err := addPaths(b, paths); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (w *watcher) absPaths(paths []string) []string {
+	abs := make([]string, 0, len(paths))
 	for _, path := range paths {
 		if !filepath.IsAbs(path) {
 			path = filepath.Join(w.workdir, path)
 		}
-		w.wt.AddRecursive(path)
+		abs = append(abs, path)
 	}
+	return abs
+}
+
+func addPaths(b backend, paths []string) error {
+	for _, path := range paths {
+		if err := b.add(path); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (w *watcher) isFileIgnored(path string) bool {
+	if w.ignoreHidden && isHidden(path) {
+		return true
+	}
 	for _, ext := range w.ignoreExts {
 		if strings.HasSuffix(path, ext) {
 			return true
@@ -125,3 +178,24 @@ func (w *watcher) isFileIgnored(path string) bool {
 	}
 	return false
 }
+
+// isHidden returns true if the file at path is a hidden(dot) file.
+func isHidden(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, ".") && name != "." && name != ".."
+}
+
+// isDir returns true if the path is an existing dir.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// exists returns true if the file at path exists.
+func exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
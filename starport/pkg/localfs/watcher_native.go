@@ -0,0 +1,142 @@
+//go:build linux || darwin || windows || freebsd || openbsd || netbsd || dragonfly
+// +build linux darwin windows freebsd openbsd netbsd dragonfly
+
+package localfs
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// nativeBackend watches the paths with the notifications of the system: inotify on Linux,
+// kqueue on macOS and the BSDs, and ReadDirectoryChangesW on Windows. the notifications are not
+// recursive so the sub dirs are watched one by one, including the ones created while watching.
+type nativeBackend struct {
+	w            *fsnotify.Watcher
+	ignoreHidden bool
+
+	mu sync.Mutex
+	// dirs are the dirs watched with their sub dirs.
+	dirs []string
+	// files are the files watched, their parent dirs are watched to keep watching them when
+	// they are replaced, e.g. by the editors saving a file.
+	files map[string]bool
+}
+
+func newNativeBackend(ignoreHidden bool) (backend, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return &nativeBackend{
+		w:            w,
+		ignoreHidden: ignoreHidden,
+		files:        make(map[string]bool),
+	}, nil
+}
+
+func (b *nativeBackend) add(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if info.IsDir() {
+		b.dirs = append(b.dirs, path)
+	} else {
+		b.files[path] = true
+	}
+	b.mu.Unlock()
+
+	if !info.IsDir() {
+		return b.w.Add(filepath.Dir(path))
+	}
+	return b.addDir(path)
+}
+
+// addDir watches the dir and its sub dirs.
+func (b *nativeBackend) addDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// the dirs removed while they are added are skipped.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if b.ignoreHidden && path != dir && isHidden(path) {
+			return filepath.SkipDir
+		}
+		return b.w.Add(path)
+	})
+}
+
+func (b *nativeBackend) run(ctx context.Context, events chan<- string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-b.w.Errors:
+			if !ok {
+				return nil
+			}
+			// an overflow only loses events, the watching continues.
+			if err != fsnotify.ErrEventOverflow {
+				return err
+			}
+		case e, ok := <-b.w.Events:
+			if !ok {
+				return nil
+			}
+			if !b.isWatched(e.Name) || e.Op == fsnotify.Chmod {
+				continue
+			}
+			// the dirs created in the watched dirs are watched too.
+			if e.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(e.Name); err == nil && info.IsDir() {
+					if err := b.addDir(e.Name); err != nil {
+						return err
+					}
+				}
+			}
+			select {
+			case events <- e.Name:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// isWatched returns true if the path is in the watched dirs or is a watched file.
+func (b *nativeBackend) isWatched(path string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.files[path] {
+		return true
+	}
+	for _, dir := range b.dirs {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *nativeBackend) close() error {
+	return b.w.Close()
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !windows && !freebsd && !openbsd && !netbsd && !dragonfly
+// +build !linux,!darwin,!windows,!freebsd,!openbsd,!netbsd,!dragonfly
+
+package localfs
+
+import "errors"
+
+// newNativeBackend returns an error, the filesystem is polled on the systems without
+// notifications supported.
+func newNativeBackend(bool) (backend, error) {
+	return nil, errors.New("the notifications of the filesystem are not supported")
+}
@@ -0,0 +1,56 @@
+package localfs
+
+import (
+	"context"
+	"time"
+
+	wt "github.com/radovskyb/watcher"
+)
+
+// pollingBackend watches the paths by polling the filesystem, it works on all the systems.
+type pollingBackend struct {
+	wt       *wt.Watcher
+	interval time.Duration
+}
+
+func newPollingBackend(interval time.Duration, ignoreHidden bool) *pollingBackend {
+	w := wt.New()
+	w.IgnoreHiddenFiles(ignoreHidden)
+	return &pollingBackend{wt: w, interval: interval}
+}
+
+func (b *pollingBackend) add(path string) error {
+	if ok, err := exists(path); !ok {
+		return err
+	}
+	return b.wt.AddRecursive(path)
+}
+
+func (b *pollingBackend) run(ctx context.Context, events chan<- string) error {
+	go func() {
+		b.wt.Wait()
+
+		done := ctx.Done()
+		for {
+			select {
+			case e := <-b.wt.Event:
+				select {
+				case events <- e.Path:
+				case <-ctx.Done():
+				}
+			case <-b.wt.Closed:
+				return
+			case <-done:
+				// the events are drained until the watcher is closed.
+				done = nil
+				go b.close()
+			}
+		}
+	}()
+	return b.wt.Start(b.interval)
+}
+
+func (b *pollingBackend) close() error {
+	b.wt.Close()
+	return nil
+}
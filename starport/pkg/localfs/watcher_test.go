@@ -0,0 +1,77 @@
+package localfs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []WatcherOption
+	}{
+		{name: "native"},
+		{name: "polling", options: []WatcherOption{WatcherPollingInterval(50 * time.Millisecond)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "x", "mars"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yml"), nil, 0644))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			changes := make(chan struct{}, 10)
+			done := make(chan error, 1)
+			go func() {
+				done <- Watch(ctx, []string{"x", "config.yml", "missing"}, append(tt.options,
+					WatcherWorkdir(dir),
+					WatcherIgnoreHidden(),
+					WatcherIgnoreExt("pb.go"),
+					WatcherOnChange(func() { changes <- struct{}{} }),
+				)...)
+			}()
+			// the watcher is started before the files are changed.
+			time.Sleep(200 * time.Millisecond)
+
+			expectChange := func(change func()) {
+				t.Helper()
+				change()
+				select {
+				case <-changes:
+				case <-time.After(5 * time.Second):
+					t.Fatal("the change is not notified")
+				}
+			}
+			expectChange(func() {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "mars", "keeper.go"), []byte("package mars"), 0644))
+			})
+			expectChange(func() {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yml"), []byte("version: 1"), 0644))
+			})
+
+			// the ignored files are not notified.
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "mars", "types.pb.go"), nil, 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "x", ".hidden"), nil, 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "other.go"), nil, 0644))
+			select {
+			case <-changes:
+				t.Fatal("an ignored change is notified")
+			case <-time.After(time.Second):
+			}
+
+			cancel()
+			select {
+			case err := <-done:
+				require.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("the watcher is not stopped")
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !(darwin && amd64) && !(darwin && arm64) && !(linux && amd64)
+// +build !darwin !amd64
+// +build !darwin !arm64
+// +build !linux !amd64
+
+package data
+
+// binaryCompressed is empty, nodetime is not bundled for the platform.
+var binaryCompressed []byte
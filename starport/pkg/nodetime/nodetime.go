@@ -7,7 +7,9 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
+	"runtime"
 	"sync"
 
 	"github.com/tendermint/starport/starport/pkg/localfs"
@@ -46,9 +48,18 @@ var (
 	binary     []byte
 )
 
-// Binary returns the binary bytes of the executable.
+// ErrNotBundled is returned when nodetime is not bundled for the platform, e.g. Windows and
+// linux/arm64, the programs of nodetime are not available on it.
+var ErrNotBundled = fmt.Errorf("nodetime is not bundled for %s/%s", runtime.GOOS, runtime.GOARCH)
+
+// Binary returns the binary bytes of the executable, it's empty when nodetime is not bundled
+// for the platform.
 func Binary() []byte {
 	onceBinary.Do(func() {
+		if len(data.Binary()) == 0 {
+			return
+		}
+
 		// untar the binary.
 		gzr, err := gzip.NewReader(bytes.NewReader(data.Binary()))
 		if err != nil {
@@ -73,7 +84,11 @@ func Binary() []byte {
 // Command setups the nodetime binary and returns the command needed to execute c.
 func Command(c CommandName) (command []string, cleanup func(), err error) {
 	cs := string(c)
-	path, cleanup, err := localfs.SaveBytesTemp(Binary(), cs, 0755)
+	bin := Binary()
+	if len(bin) == 0 {
+		return nil, nil, fmt.Errorf("%w, %s cannot be run", ErrNotBundled, cs)
+	}
+	path, cleanup, err := localfs.SaveBytesTemp(bin, cs, 0755)
 	if err != nil {
 		return nil, nil, err
 	}
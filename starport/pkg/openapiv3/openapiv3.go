@@ -0,0 +1,600 @@
+// Package openapiv3 converts Swagger 2.0 specs into OpenAPI 3.0 documents.
+package openapiv3
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Version is the OpenAPI version of converted documents.
+const Version = "3.0.3"
+
+const (
+	defaultMediaType = "application/json"
+	formMediaType    = "application/x-www-form-urlencoded"
+)
+
+// httpMethods are the operation keys of a path item.
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// refReplacer rewrites Swagger 2.0 local references to their OpenAPI 3.0 locations.
+var refReplacer = strings.NewReplacer(
+	"#/definitions/", "#/components/schemas/",
+	"#/parameters/", "#/components/parameters/",
+	"#/responses/", "#/components/responses/",
+)
+
+type object = map[string]interface{}
+
+// Convert converts a Swagger 2.0 spec in JSON format to an OpenAPI 3.0 document in JSON format.
+// All the tags used by operations are declared in the top level tags list of the document.
+func Convert(spec []byte) ([]byte, error) {
+	var v2 object
+	if err := json.Unmarshal(spec, &v2); err != nil {
+		return nil, errors.Wrap(err, "invalid swagger spec")
+	}
+
+	if version, _ := v2["swagger"].(string); version != "2.0" {
+		return nil, errors.Errorf("unsupported swagger version %q", version)
+	}
+
+	v3, err := convert(v2)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(v3, "", "  ")
+}
+
+func convert(v2 object) (object, error) {
+	var (
+		v3       = object{"openapi": Version}
+		consumes = stringList(v2["consumes"], defaultMediaType)
+		produces = stringList(v2["produces"], defaultMediaType)
+	)
+
+	for _, key := range []string{"info", "externalDocs", "security"} {
+		if value, ok := v2[key]; ok {
+			v3[key] = value
+		}
+	}
+	copyExtensions(v2, v3)
+
+	if info, ok := v2["info"].(object); !ok || info["title"] == nil || info["version"] == nil {
+		info = copyObject(info)
+		if info["title"] == nil {
+			info["title"] = ""
+		}
+		if info["version"] == nil {
+			info["version"] = "version not set"
+		}
+		v3["info"] = info
+	}
+
+	if servers := convertServers(v2); len(servers) > 0 {
+		v3["servers"] = servers
+	}
+
+	components := object{}
+
+	if definitions, ok := v2["definitions"].(object); ok && len(definitions) > 0 {
+		schemas := object{}
+		for name, schema := range definitions {
+			schemas[name] = convertSchema(schema)
+		}
+		components["schemas"] = schemas
+	}
+
+	if parameters, ok := v2["parameters"].(object); ok {
+		converted := object{}
+		for name, p := range parameters {
+			if param, ok := p.(object); ok && !isBodyParam(param) {
+				converted[name] = convertParameter(param)
+			}
+		}
+		if len(converted) > 0 {
+			components["parameters"] = converted
+		}
+	}
+
+	if responses, ok := v2["responses"].(object); ok && len(responses) > 0 {
+		converted := object{}
+		for name, r := range responses {
+			converted[name] = convertResponse(r, produces)
+		}
+		components["responses"] = converted
+	}
+
+	if definitions, ok := v2["securityDefinitions"].(object); ok && len(definitions) > 0 {
+		schemes := object{}
+		for name, d := range definitions {
+			scheme, err := convertSecurityScheme(d)
+			if err != nil {
+				return nil, errors.Wrapf(err, "security definition %q", name)
+			}
+			schemes[name] = scheme
+		}
+		components["securitySchemes"] = schemes
+	}
+
+	if len(components) > 0 {
+		v3["components"] = components
+	}
+
+	paths := object{}
+	usedTags := make(map[string]bool)
+
+	if v2Paths, ok := v2["paths"].(object); ok {
+		for path, item := range v2Paths {
+			pathItem, ok := item.(object)
+			if !ok {
+				continue
+			}
+			paths[path] = convertPathItem(pathItem, consumes, produces, usedTags)
+		}
+	}
+	v3["paths"] = paths
+
+	if tags := mergeTags(v2["tags"], usedTags); len(tags) > 0 {
+		v3["tags"] = tags
+	}
+
+	return v3, nil
+}
+
+// convertServers creates the servers list from the host, basePath and schemes fields.
+func convertServers(v2 object) []interface{} {
+	host, _ := v2["host"].(string)
+	if host == "" {
+		return nil
+	}
+
+	basePath, _ := v2["basePath"].(string)
+
+	var servers []interface{}
+	for _, scheme := range stringList(v2["schemes"], "https") {
+		servers = append(servers, object{"url": scheme + "://" + host + basePath})
+	}
+	return servers
+}
+
+func convertPathItem(item object, consumes, produces []string, usedTags map[string]bool) object {
+	converted := object{}
+	copyExtensions(item, converted)
+
+	for _, key := range []string{"summary", "description"} {
+		if value, ok := item[key]; ok {
+			converted[key] = value
+		}
+	}
+
+	if ref, ok := item["$ref"].(string); ok {
+		converted["$ref"] = ref
+	}
+
+	if parameters, ok := item["parameters"].([]interface{}); ok {
+		if params := convertParameters(parameters); len(params) > 0 {
+			converted["parameters"] = params
+		}
+	}
+
+	for _, method := range httpMethods {
+		op, ok := item[method].(object)
+		if !ok {
+			continue
+		}
+		converted[method] = convertOperation(op, consumes, produces)
+
+		for _, tag := range stringList(op["tags"]) {
+			usedTags[tag] = true
+		}
+	}
+
+	return converted
+}
+
+func convertOperation(op object, consumes, produces []string) object {
+	converted := object{}
+	copyExtensions(op, converted)
+
+	for _, key := range []string{"tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security"} {
+		if value, ok := op[key]; ok {
+			converted[key] = value
+		}
+	}
+
+	consumes = stringList(op["consumes"], consumes...)
+	produces = stringList(op["produces"], produces...)
+
+	params, _ := op["parameters"].([]interface{})
+
+	if parameters := convertParameters(params); len(parameters) > 0 {
+		converted["parameters"] = parameters
+	}
+
+	if body := convertRequestBody(params, consumes); body != nil {
+		converted["requestBody"] = body
+	}
+
+	responses := object{}
+	if v2Responses, ok := op["responses"].(object); ok {
+		for code, r := range v2Responses {
+			responses[code] = convertResponse(r, produces)
+		}
+	}
+	converted["responses"] = responses
+
+	return converted
+}
+
+// convertParameters converts all parameters except the body and form ones, which become the request body.
+func convertParameters(params []interface{}) []interface{} {
+	var converted []interface{}
+
+	for _, p := range params {
+		param, ok := p.(object)
+		if !ok || isBodyParam(param) {
+			continue
+		}
+		converted = append(converted, convertParameter(param))
+	}
+
+	return converted
+}
+
+func convertParameter(param object) object {
+	if ref, ok := param["$ref"].(string); ok {
+		return object{"$ref": refReplacer.Replace(ref)}
+	}
+
+	converted := object{}
+	copyExtensions(param, converted)
+
+	for _, key := range []string{"name", "in", "description", "required", "allowEmptyValue"} {
+		if value, ok := param[key]; ok {
+			converted[key] = value
+		}
+	}
+
+	if param["in"] == "path" {
+		converted["required"] = true
+	}
+
+	switch param["collectionFormat"] {
+	case "csv":
+		converted["style"] = "form"
+		converted["explode"] = false
+	case "ssv":
+		converted["style"] = "spaceDelimited"
+	case "pipes":
+		converted["style"] = "pipeDelimited"
+	case "multi":
+		converted["style"] = "form"
+		converted["explode"] = true
+	}
+
+	converted["schema"] = parameterSchema(param)
+
+	return converted
+}
+
+// parameterSchema builds a schema from the inline type definition of a non body parameter.
+func parameterSchema(param object) object {
+	schema := object{}
+
+	for key, value := range param {
+		switch key {
+		case "name", "in", "description", "required", "allowEmptyValue", "collectionFormat":
+			continue
+		case "items":
+			schema[key] = parameterSchema(copyObject(toObject(value)))
+		default:
+			if !strings.HasPrefix(key, "x-") {
+				schema[key] = value
+			}
+		}
+	}
+
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+
+	return schema
+}
+
+// convertRequestBody creates the request body of an operation from its body or form parameters.
+func convertRequestBody(params []interface{}, consumes []string) object {
+	var (
+		body       object
+		formSchema object
+		formFields []string
+	)
+
+	for _, p := range params {
+		param, ok := p.(object)
+		if !ok {
+			continue
+		}
+
+		switch param["in"] {
+		case "body":
+			body = object{
+				"content": mediaTypes(consumes, convertSchema(param["schema"])),
+			}
+			if description, ok := param["description"]; ok {
+				body["description"] = description
+			}
+			if required, ok := param["required"]; ok {
+				body["required"] = required
+			}
+
+		case "formData":
+			if formSchema == nil {
+				formSchema = object{"type": "object", "properties": object{}}
+			}
+
+			name, _ := param["name"].(string)
+			property := parameterSchema(param)
+			if description, ok := param["description"]; ok {
+				property["description"] = description
+			}
+			formSchema["properties"].(object)[name] = property
+
+			if required, _ := param["required"].(bool); required {
+				formFields = append(formFields, name)
+			}
+		}
+	}
+
+	if body != nil {
+		return body
+	}
+
+	if formSchema != nil {
+		if len(formFields) > 0 {
+			sort.Strings(formFields)
+			formSchema["required"] = formFields
+		}
+
+		mediaType := formMediaType
+		for _, c := range consumes {
+			if c == "multipart/form-data" {
+				mediaType = c
+			}
+		}
+
+		return object{"content": mediaTypes([]string{mediaType}, formSchema)}
+	}
+
+	return nil
+}
+
+func convertResponse(r interface{}, produces []string) object {
+	response := toObject(r)
+
+	if ref, ok := response["$ref"].(string); ok {
+		return object{"$ref": refReplacer.Replace(ref)}
+	}
+
+	converted := object{}
+	copyExtensions(response, converted)
+
+	description, ok := response["description"]
+	if !ok {
+		description = ""
+	}
+	converted["description"] = description
+
+	if schema, ok := response["schema"]; ok {
+		converted["content"] = mediaTypes(produces, convertSchema(schema))
+	}
+
+	if headers, ok := response["headers"].(object); ok && len(headers) > 0 {
+		convertedHeaders := object{}
+		for name, h := range headers {
+			header := copyObject(toObject(h))
+			description := header["description"]
+			delete(header, "description")
+
+			convertedHeader := object{"schema": parameterSchema(header)}
+			if description != nil {
+				convertedHeader["description"] = description
+			}
+			convertedHeaders[name] = convertedHeader
+		}
+		converted["headers"] = convertedHeaders
+	}
+
+	return converted
+}
+
+func convertSecurityScheme(d interface{}) (object, error) {
+	definition := toObject(d)
+
+	converted := object{}
+	copyExtensions(definition, converted)
+
+	if description, ok := definition["description"]; ok {
+		converted["description"] = description
+	}
+
+	switch definition["type"] {
+	case "basic":
+		converted["type"] = "http"
+		converted["scheme"] = "basic"
+
+	case "apiKey":
+		converted["type"] = "apiKey"
+		converted["name"] = definition["name"]
+		converted["in"] = definition["in"]
+
+	case "oauth2":
+		flow := object{}
+		scopes, ok := definition["scopes"]
+		if !ok {
+			scopes = object{}
+		}
+		flow["scopes"] = scopes
+
+		var name string
+		switch definition["flow"] {
+		case "implicit":
+			name = "implicit"
+			flow["authorizationUrl"] = definition["authorizationUrl"]
+		case "password":
+			name = "password"
+			flow["tokenUrl"] = definition["tokenUrl"]
+		case "application":
+			name = "clientCredentials"
+			flow["tokenUrl"] = definition["tokenUrl"]
+		case "accessCode":
+			name = "authorizationCode"
+			flow["authorizationUrl"] = definition["authorizationUrl"]
+			flow["tokenUrl"] = definition["tokenUrl"]
+		default:
+			return nil, errors.Errorf("unknown oauth2 flow %v", definition["flow"])
+		}
+
+		converted["type"] = "oauth2"
+		converted["flows"] = object{name: flow}
+
+	default:
+		return nil, errors.Errorf("unknown type %v", definition["type"])
+	}
+
+	return converted, nil
+}
+
+// convertSchema rewrites references and the Swagger specific fields of a schema and its sub schemas.
+func convertSchema(s interface{}) interface{} {
+	switch schema := s.(type) {
+	case object:
+		converted := make(object, len(schema))
+		for key, value := range schema {
+			switch key {
+			case "$ref":
+				if ref, ok := value.(string); ok {
+					converted[key] = refReplacer.Replace(ref)
+					continue
+				}
+			case "x-nullable":
+				converted["nullable"] = value
+				continue
+			case "discriminator":
+				if name, ok := value.(string); ok {
+					converted[key] = object{"propertyName": name}
+					continue
+				}
+			case "type":
+				if value == "file" {
+					converted[key] = "string"
+					converted["format"] = "binary"
+					continue
+				}
+			}
+			converted[key] = convertSchema(value)
+		}
+		return converted
+
+	case []interface{}:
+		converted := make([]interface{}, len(schema))
+		for i, value := range schema {
+			converted[i] = convertSchema(value)
+		}
+		return converted
+
+	default:
+		return s
+	}
+}
+
+// mergeTags returns declared tags followed by the undeclared ones that used by operations in alphabetical order.
+func mergeTags(declared interface{}, used map[string]bool) []interface{} {
+	var (
+		tags     []interface{}
+		existing = make(map[string]bool)
+	)
+
+	list, _ := declared.([]interface{})
+	for _, t := range list {
+		tag, ok := t.(object)
+		if !ok {
+			continue
+		}
+		name, _ := tag["name"].(string)
+		if existing[name] {
+			continue
+		}
+		existing[name] = true
+		tags = append(tags, tag)
+	}
+
+	var missing []string
+	for name := range used {
+		if !existing[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	for _, name := range missing {
+		tags = append(tags, object{"name": name})
+	}
+
+	return tags
+}
+
+func mediaTypes(types []string, schema interface{}) object {
+	content := object{}
+	for _, t := range types {
+		content[t] = object{"schema": schema}
+	}
+	return content
+}
+
+func isBodyParam(param object) bool {
+	in := param["in"]
+	return in == "body" || in == "formData"
+}
+
+// stringList converts v to a list of strings, defaults are returned when v is empty.
+func stringList(v interface{}, defaults ...string) []string {
+	list, _ := v.([]interface{})
+
+	var values []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+
+	if len(values) == 0 {
+		return defaults
+	}
+	return values
+}
+
+func copyExtensions(src, dst object) {
+	for key, value := range src {
+		if strings.HasPrefix(key, "x-") {
+			dst[key] = value
+		}
+	}
+}
+
+func copyObject(o object) object {
+	copied := make(object, len(o))
+	for key, value := range o {
+		copied[key] = value
+	}
+	return copied
+}
+
+func toObject(v interface{}) object {
+	o, _ := v.(object)
+	return o
+}
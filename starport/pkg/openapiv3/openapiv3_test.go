@@ -0,0 +1,131 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const swaggerSpec = `{
+  "swagger": "2.0",
+  "info": {"title": "HTTP API Console", "version": "1.0"},
+  "consumes": ["application/json"],
+  "produces": ["application/json"],
+  "paths": {
+    "/cosmos/bank/v1beta1/balances/{address}": {
+      "get": {
+        "operationId": "CosmosBankV1Beta1AllBalances",
+        "tags": ["cosmos.bank.v1beta1"],
+        "parameters": [
+          {"name": "address", "in": "path", "required": true, "type": "string"},
+          {"name": "pagination.key", "in": "query", "required": false, "type": "string", "format": "byte"},
+          {"name": "denoms", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"}
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {"$ref": "#/definitions/cosmos.bank.v1beta1.QueryAllBalancesResponse"}
+          }
+        }
+      }
+    },
+    "/blog/posts": {
+      "post": {
+        "operationId": "BlogCreatePost",
+        "tags": ["blog.blog"],
+        "parameters": [
+          {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/blog.blog.MsgCreatePost"}}
+        ],
+        "responses": {
+          "default": {"description": "An unexpected error response.", "schema": {"type": "object"}}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "cosmos.bank.v1beta1.QueryAllBalancesResponse": {
+      "type": "object",
+      "properties": {
+        "balances": {"type": "array", "items": {"$ref": "#/definitions/cosmos.base.v1beta1.Coin"}}
+      }
+    },
+    "cosmos.base.v1beta1.Coin": {
+      "type": "object",
+      "x-nullable": true,
+      "properties": {"denom": {"type": "string"}, "amount": {"type": "string"}}
+    },
+    "blog.blog.MsgCreatePost": {"type": "object"}
+  }
+}`
+
+func TestConvert(t *testing.T) {
+	out, err := Convert([]byte(swaggerSpec))
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &doc))
+
+	require.Equal(t, Version, doc["openapi"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "blog.blog"},
+		map[string]interface{}{"name": "cosmos.bank.v1beta1"},
+	}, doc["tags"])
+
+	paths := doc["paths"].(map[string]interface{})
+
+	get := paths["/cosmos/bank/v1beta1/balances/{address}"].(map[string]interface{})["get"].(map[string]interface{})
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "address", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}},
+		map[string]interface{}{"name": "pagination.key", "in": "query", "required": false, "schema": map[string]interface{}{"type": "string", "format": "byte"}},
+		map[string]interface{}{
+			"name":    "denoms",
+			"in":      "query",
+			"style":   "form",
+			"explode": true,
+			"schema":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+	}, get["parameters"])
+	require.Equal(t, map[string]interface{}{
+		"description": "A successful response.",
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/cosmos.bank.v1beta1.QueryAllBalancesResponse"},
+			},
+		},
+	}, get["responses"].(map[string]interface{})["200"])
+
+	post := paths["/blog/posts"].(map[string]interface{})["post"].(map[string]interface{})
+	require.Nil(t, post["parameters"])
+	require.Equal(t, map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/blog.blog.MsgCreatePost"},
+			},
+		},
+	}, post["requestBody"])
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	require.Len(t, schemas, 3)
+	require.Equal(t, map[string]interface{}{
+		"type":     "object",
+		"nullable": true,
+		"properties": map[string]interface{}{
+			"denom":  map[string]interface{}{"type": "string"},
+			"amount": map[string]interface{}{"type": "string"},
+		},
+	}, schemas["cosmos.base.v1beta1.Coin"])
+	require.Equal(t,
+		"#/components/schemas/cosmos.base.v1beta1.Coin",
+		schemas["cosmos.bank.v1beta1.QueryAllBalancesResponse"].(map[string]interface{})["properties"].(map[string]interface{})["balances"].(map[string]interface{})["items"].(map[string]interface{})["$ref"],
+	)
+}
+
+func TestConvertUnsupportedVersion(t *testing.T) {
+	_, err := Convert([]byte(`{"openapi": "3.0.0"}`))
+	require.Error(t, err)
+
+	_, err = Convert([]byte(`not json`))
+	require.Error(t, err)
+}
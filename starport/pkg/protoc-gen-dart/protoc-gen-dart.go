@@ -2,6 +2,8 @@ package protocgendart
 
 import (
 	"fmt"
+	"os/exec"
+	"runtime"
 
 	"github.com/tendermint/starport/starport/pkg/localfs"
 	"github.com/tendermint/starport/starport/pkg/protoc-gen-dart/data"
@@ -10,9 +12,22 @@ import (
 // Name of the plugin.
 const Name = "protoc-gen-dart"
 
-// BinaryPath returns the binary path for the plugin.
+// BinaryPath returns the binary path for the plugin, the plugin installed in the PATH is used on
+// the platforms that it's not bundled for.
 func BinaryPath() (path string, cleanup func(), err error) {
-	return localfs.SaveBytesTemp(data.Binary(), Name, 0755)
+	if binary := data.Binary(); len(binary) > 0 {
+		return localfs.SaveBytesTemp(binary, Name, 0755)
+	}
+	if path, err = exec.LookPath(Name); err != nil {
+		return "", nil, fmt.Errorf(
+			"%s is not bundled for %s/%s, install it and add it to your PATH: %w",
+			Name,
+			runtime.GOOS,
+			runtime.GOARCH,
+			err,
+		)
+	}
+	return path, func() {}, nil
 }
 
 // Flag returns the binary name-binary path format to pass to protoc --plugin.
@@ -0,0 +1,10 @@
+//go:build !(darwin && amd64) && !(darwin && arm64) && !(linux && amd64) && !(linux && arm64)
+// +build !darwin !amd64
+// +build !darwin !arm64
+// +build !linux !amd64
+// +build !linux !arm64
+
+package data
+
+// binary is empty, protoc is not bundled for the platform.
+var binary []byte
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/exec"
@@ -45,8 +47,10 @@ type Cmd struct {
 }
 
 // Command sets the protoc binary up and returns the command needed to execute c.
+// the protoc installed in the PATH is used on the platforms that protoc is not bundled for,
+// e.g. Windows.
 func Command() (command Cmd, cleanup func(), err error) {
-	path, cleanupProto, err := localfs.SaveBytesTemp(data.Binary(), "protoc", 0755)
+	path, cleanupProto, err := binaryPath()
 	if err != nil {
 		return Cmd{}, nil, err
 	}
@@ -70,6 +74,23 @@ func Command() (command Cmd, cleanup func(), err error) {
 	return command, cleanup, nil
 }
 
+// binaryPath returns the path of the bundled protoc binary saved to a temporary file, or of the
+// protoc installed in the PATH when it's not bundled for the platform.
+func binaryPath() (path string, cleanup func(), err error) {
+	if binary := data.Binary(); len(binary) > 0 {
+		return localfs.SaveBytesTemp(binary, "protoc", 0755)
+	}
+	if path, err = osexec.LookPath("protoc"); err != nil {
+		return "", nil, fmt.Errorf(
+			"protoc is not bundled for %s/%s, install it and add it to your PATH: %w",
+			runtime.GOOS,
+			runtime.GOARCH,
+			err,
+		)
+	}
+	return path, func() {}, nil
+}
+
 // Generate generates code into outDir from protoPath and its includePaths by using plugins provided with protocOuts.
 func Generate(ctx context.Context, outDir, protoPath string, includePaths, protocOuts []string, options ...Option) error {
 	c := configs{}
@@ -127,6 +148,40 @@ func Generate(ctx context.Context, outDir, protoPath string, includePaths, proto
 	return nil
 }
 
+// DescriptorSet returns the encoded FileDescriptorSet of the proto files with all of their imports.
+// files can be absolute paths in includePaths or relative to one of them.
+func DescriptorSet(ctx context.Context, files, includePaths []string) ([]byte, error) {
+	cmd, cleanup, err := Command()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	out, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(out)
+
+	setPath := filepath.Join(out, "descriptors.bin")
+
+	command := cmd.Command
+	for _, path := range includePaths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		command = append(command, "-I", path)
+	}
+	command = append(command, "--include_imports", "--descriptor_set_out="+setPath)
+	command = append(command, files...)
+
+	if err := exec.Exec(ctx, command, exec.IncludeStdLogsToError()); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(setPath)
+}
+
 // discoverFiles discovers .proto files to do code generation for. .proto files of the app
 // (everything under protoPath) will always be a part of the discovered files.
 //
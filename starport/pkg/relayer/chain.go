@@ -137,6 +137,16 @@ type channelOptions struct {
 	targetPort    string
 	targetVersion string
 	ordering      string
+
+	// incentivized makes the channel an ICS-29 fee enabled channel.
+	incentivized bool
+
+	// sourcePayee and targetPayee are the addresses that receive the fees on the ends of the channel.
+	sourcePayee string
+	targetPayee string
+
+	// channel is an existing channel to reuse instead of creating a new one.
+	channel *Channel
 }
 
 // newChannelOptions returns default channel options
@@ -150,6 +160,22 @@ func newChannelOptions() channelOptions {
 	}
 }
 
+// applyChannelOptions returns the channel options with the user options applied.
+func applyChannelOptions(options []ChannelOption) channelOptions {
+	channelOptions := newChannelOptions()
+
+	for _, apply := range options {
+		apply(&channelOptions)
+	}
+
+	if channelOptions.incentivized {
+		channelOptions.sourceVersion = IncentivizedVersion(channelOptions.sourceVersion)
+		channelOptions.targetVersion = IncentivizedVersion(channelOptions.targetVersion)
+	}
+
+	return channelOptions
+}
+
 // ChannelOption is used to configure relayer IBC connection
 type ChannelOption func(*channelOptions)
 
@@ -189,19 +215,42 @@ func Ordered() ChannelOption {
 }
 
 // Connect connects dst chain to c chain and creates a path in between in offline mode.
+// when an existing channel is reused with ReuseChannel, the configured path of the channel is
+// recovered instead of creating a new one if there is any.
 // it returns the path id on success otherwise, returns with a non-nil error.
 func (c *Chain) Connect(ctx context.Context, dst *Chain, options ...ChannelOption) (id string, err error) {
-	channelOptions := newChannelOptions()
+	channelOptions := applyChannelOptions(options)
 
-	for _, apply := range options {
-		apply(&channelOptions)
-	}
+	c.r.m.Lock()
+	defer c.r.m.Unlock()
 
 	conf, err := relayerconfig.Get()
 	if err != nil {
 		return "", err
 	}
 
+	if channelOptions.channel != nil {
+		// recover the path of the channel if it's already configured.
+		if path, ok := findPath(conf, *channelOptions.channel); ok {
+			path.Src.ConnectionID = channelOptions.channel.Src.ConnectionID
+			path.Src.ChannelID = channelOptions.channel.Src.ChannelID
+			path.Dst.ConnectionID = channelOptions.channel.Dst.ConnectionID
+			path.Dst.ChannelID = channelOptions.channel.Dst.ChannelID
+			path.Incentivized = channelOptions.incentivized
+			path.Src.Payee = channelOptions.sourcePayee
+			path.Dst.Payee = channelOptions.targetPayee
+
+			if err := conf.UpdatePath(path); err != nil {
+				return "", err
+			}
+			if err := relayerconfig.Save(conf); err != nil {
+				return "", err
+			}
+
+			return path.ID, nil
+		}
+	}
+
 	// determine a unique path name from chain ids with incremental numbers. e.g.:
 	// - src-dst
 	// - src-dst-2
@@ -219,8 +268,9 @@ func (c *Chain) Connect(ctx context.Context, dst *Chain, options ...ChannelOptio
 	}
 
 	confPath := relayerconfig.Path{
-		ID:       pathID,
-		Ordering: channelOptions.ordering,
+		ID:           pathID,
+		Ordering:     channelOptions.ordering,
+		Incentivized: channelOptions.incentivized,
 		Src: relayerconfig.PathEnd{
 			ChainID: c.ID,
 			PortID:  channelOptions.sourcePort,
@@ -233,6 +283,14 @@ func (c *Chain) Connect(ctx context.Context, dst *Chain, options ...ChannelOptio
 		},
 	}
 
+	if channelOptions.channel != nil {
+		confPath.Src = channelOptions.channel.Src
+		confPath.Dst = channelOptions.channel.Dst
+	}
+
+	confPath.Src.Payee = channelOptions.sourcePayee
+	confPath.Dst.Payee = channelOptions.targetPayee
+
 	conf.Paths = append(conf.Paths, confPath)
 
 	if err := relayerconfig.Save(conf); err != nil {
@@ -242,6 +300,32 @@ func (c *Chain) Connect(ctx context.Context, dst *Chain, options ...ChannelOptio
 	return pathID, nil
 }
 
+// findPath finds the configured path of the channel. a path between the same ports of the chains that
+// is not linked yet is also considered as the path of the channel, e.g. when linking it has failed.
+func findPath(conf relayerconfig.Config, channel Channel) (relayerconfig.Path, bool) {
+	var unlinked *relayerconfig.Path
+
+	for i, path := range conf.Paths {
+		if path.Src.ChainID != channel.Src.ChainID || path.Dst.ChainID != channel.Dst.ChainID ||
+			path.Src.PortID != channel.Src.PortID || path.Dst.PortID != channel.Dst.PortID {
+			continue
+		}
+
+		if path.Src.ChannelID == channel.Src.ChannelID && path.Dst.ChannelID == channel.Dst.ChannelID {
+			return path, true
+		}
+
+		if path.Src.ChannelID == "" && unlinked == nil {
+			unlinked = &conf.Paths[i]
+		}
+	}
+
+	if unlinked != nil {
+		return *unlinked, true
+	}
+	return relayerconfig.Path{}, false
+}
+
 // ensureChainSetup sets up the new or existing chain.
 func (c *Chain) ensureChainSetup(ctx context.Context) error {
 	client, err := cosmosclient.New(ctx, cosmosclient.WithNodeAddress(c.rpcAddress))
@@ -263,6 +347,9 @@ func (c *Chain) ensureChainSetup(ctx context.Context) error {
 		GasLimit:      c.gasLimit,
 	}
 
+	c.r.m.Lock()
+	defer c.r.m.Unlock()
+
 	conf, err := relayerconfig.Get()
 	if err != nil {
 		return err
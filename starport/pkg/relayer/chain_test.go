@@ -0,0 +1,59 @@
+package relayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	relayerconfig "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+func TestFindPath(t *testing.T) {
+	channel := Channel{
+		Src: relayerconfig.PathEnd{ChainID: "mars", PortID: TransferPort, ChannelID: "channel-1"},
+		Dst: relayerconfig.PathEnd{ChainID: "venus", PortID: TransferPort, ChannelID: "channel-3"},
+	}
+
+	newPath := func(id, srcChannelID, dstChannelID string) relayerconfig.Path {
+		return relayerconfig.Path{
+			ID:  id,
+			Src: relayerconfig.PathEnd{ChainID: "mars", PortID: TransferPort, ChannelID: srcChannelID},
+			Dst: relayerconfig.PathEnd{ChainID: "venus", PortID: TransferPort, ChannelID: dstChannelID},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		paths []relayerconfig.Path
+		id    string
+		found bool
+	}{
+		{
+			name:  "path of the channel",
+			paths: []relayerconfig.Path{newPath("mars-venus", "", ""), newPath("mars-venus-2", "channel-1", "channel-3")},
+			id:    "mars-venus-2",
+			found: true,
+		},
+		{
+			name:  "unlinked path",
+			paths: []relayerconfig.Path{newPath("mars-venus", "channel-0", "channel-0"), newPath("mars-venus-2", "", "")},
+			id:    "mars-venus-2",
+			found: true,
+		},
+		{
+			name:  "paths of other channels",
+			paths: []relayerconfig.Path{newPath("mars-venus", "channel-0", "channel-0")},
+		},
+		{
+			name: "no paths",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, found := findPath(relayerconfig.Config{Paths: tt.paths}, channel)
+			require.Equal(t, tt.found, found)
+			require.Equal(t, tt.id, path.ID)
+		})
+	}
+}
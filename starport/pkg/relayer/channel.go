@@ -0,0 +1,130 @@
+package relayer
+
+import (
+	"context"
+
+	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	relayerconfig "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+// ErrChannelNotFound is returned when there is no open channel between two chains to reuse.
+var ErrChannelNotFound = errors.New("no open channel found between the chains")
+
+// Channel is an open channel between two chains.
+type Channel struct {
+	// Src is the end of the channel on the source chain.
+	Src relayerconfig.PathEnd
+
+	// Dst is the end of the channel on the destination chain.
+	Dst relayerconfig.PathEnd
+}
+
+// ReuseChannel uses an existing channel returned by FindChannel instead of creating a new one.
+func ReuseChannel(channel Channel) ChannelOption {
+	return func(c *channelOptions) {
+		c.channel = &channel
+	}
+}
+
+// FindChannel finds an open channel between c and dst chains matching the channel options, so it can
+// be reused instead of creating a new client, connection and channel. clients of both ends need to
+// be active and track the chains on the other ends.
+// ErrChannelNotFound is returned when there is no such channel.
+func (c *Chain) FindChannel(ctx context.Context, dst *Chain, options ...ChannelOption) (Channel, error) {
+	channelOptions := applyChannelOptions(options)
+
+	srcq, err := newQuerier(ctx, c.rpcAddress)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	dstq, err := newQuerier(ctx, dst.rpcAddress)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	channels, err := srcq.channels(ctx, channelOptions.sourcePort)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	for _, srcChannel := range channels {
+		if srcChannel.State != channeltypes.OPEN ||
+			srcChannel.Ordering.String() != channelOptions.ordering ||
+			srcChannel.Version != channelOptions.sourceVersion ||
+			srcChannel.Counterparty.PortId != channelOptions.targetPort ||
+			len(srcChannel.ConnectionHops) == 0 {
+			continue
+		}
+
+		ok, err := isChannelEndActive(ctx, srcq, srcChannel.PortId, srcChannel.ChannelId, dst.ID)
+		if err != nil {
+			return Channel{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		dstChannel, err := dstq.channelByID(ctx, srcChannel.Counterparty.PortId, srcChannel.Counterparty.ChannelId)
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return Channel{}, err
+		}
+
+		if dstChannel.State != channeltypes.OPEN ||
+			dstChannel.Version != channelOptions.targetVersion ||
+			dstChannel.Counterparty.ChannelId != srcChannel.ChannelId ||
+			len(dstChannel.ConnectionHops) == 0 {
+			continue
+		}
+
+		ok, err = isChannelEndActive(ctx, dstq, srcChannel.Counterparty.PortId, srcChannel.Counterparty.ChannelId, c.ID)
+		if err != nil {
+			return Channel{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		return Channel{
+			Src: relayerconfig.PathEnd{
+				ChainID:      c.ID,
+				ConnectionID: srcChannel.ConnectionHops[0],
+				ChannelID:    srcChannel.ChannelId,
+				PortID:       srcChannel.PortId,
+				Version:      srcChannel.Version,
+			},
+			Dst: relayerconfig.PathEnd{
+				ChainID:      dst.ID,
+				ConnectionID: dstChannel.ConnectionHops[0],
+				ChannelID:    srcChannel.Counterparty.ChannelId,
+				PortID:       srcChannel.Counterparty.PortId,
+				Version:      dstChannel.Version,
+			},
+		}, nil
+	}
+
+	return Channel{}, ErrChannelNotFound
+}
+
+// isChannelEndActive checks if the client of the channel end is active and tracks the counterparty chain.
+func isChannelEndActive(ctx context.Context, q querier, portID, channelID, counterpartyChainID string) (bool, error) {
+	clientID, chainID, err := q.channelClient(ctx, portID, channelID)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if chainID != counterpartyChainID {
+		return false, nil
+	}
+
+	return q.isClientActive(ctx, clientID)
+}
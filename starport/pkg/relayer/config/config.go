@@ -61,10 +61,12 @@ type Chain struct {
 }
 
 type Path struct {
-	ID       string  `json:"id" yaml:"id"`
-	Ordering string  `json:"ordering" yaml:"ordering,omitempty"`
-	Src      PathEnd `json:"src" yaml:"src"`
-	Dst      PathEnd `json:"dst" yaml:"dst"`
+	ID           string  `json:"id" yaml:"id"`
+	Ordering     string  `json:"ordering" yaml:"ordering,omitempty"`
+	Incentivized bool    `json:"incentivized" yaml:"incentivized,omitempty"`
+	Paused       bool    `json:"paused" yaml:"paused,omitempty"`
+	Src          PathEnd `json:"src" yaml:"src"`
+	Dst          PathEnd `json:"dst" yaml:"dst"`
 }
 
 type PathEnd struct {
@@ -75,6 +77,7 @@ type PathEnd struct {
 	Version      string `json:"version" yaml:"version,omitempty"`
 	PacketHeight int64  `json:"packet_height" yaml:"packet_height,omitempty"`
 	AckHeight    int64  `json:"ack_height" yaml:"ack_height,omitempty"`
+	Payee        string `json:"payee" yaml:"payee,omitempty"`
 }
 
 func Get() (Config, error) {
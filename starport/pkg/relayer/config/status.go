@@ -0,0 +1,67 @@
+package relayerconf
+
+import (
+	"os"
+	"time"
+
+	"github.com/tendermint/starport/starport/pkg/confile"
+)
+
+var statusPath = os.ExpandEnv("$HOME/.starport/relayer/status.yml")
+
+// Status is the relaying status of the paths kept by the running relayers.
+type Status struct {
+	Paths []PathStatus `json:"paths" yaml:"paths,omitempty"`
+}
+
+// PathStatus is the relaying status of a path.
+type PathStatus struct {
+	ID string `json:"id" yaml:"id"`
+
+	// LastAttempt is the time of the last attempt to relay the packets of the path.
+	LastAttempt time.Time `json:"last_attempt" yaml:"last_attempt,omitempty"`
+
+	// LastSuccess is the time of the last attempt that the packets are relayed without an error.
+	LastSuccess time.Time `json:"last_success" yaml:"last_success,omitempty"`
+
+	// LastError is the error of the last attempt, it's empty when the last attempt succeeded.
+	LastError string `json:"last_error" yaml:"last_error,omitempty"`
+
+	// Failures is the count of the consecutive failed attempts.
+	Failures int `json:"failures" yaml:"failures,omitempty"`
+
+	// NextAttempt is the time of the next attempt when the relayer backs off after failures.
+	NextAttempt time.Time `json:"next_attempt" yaml:"next_attempt,omitempty"`
+}
+
+// PathStatusByID returns the status of a path by its id, an empty status is returned when the
+// path is not relayed yet.
+func (s Status) PathStatusByID(id string) PathStatus {
+	for _, status := range s.Paths {
+		if status.ID == id {
+			return status
+		}
+	}
+	return PathStatus{ID: id}
+}
+
+// UpdatePath replaces the status of the path or adds it if it doesn't exist.
+func (s *Status) UpdatePath(status PathStatus) {
+	for i, p := range s.Paths {
+		if p.ID == status.ID {
+			s.Paths[i] = status
+			return
+		}
+	}
+	s.Paths = append(s.Paths, status)
+}
+
+func GetStatus() (Status, error) {
+	s := Status{}
+	err := confile.New(confile.DefaultYAMLEncodingCreator, statusPath).Load(&s)
+	return s, err
+}
+
+func SaveStatus(s Status) error {
+	return confile.New(confile.DefaultYAMLEncodingCreator, statusPath).Save(s)
+}
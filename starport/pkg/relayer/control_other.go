@@ -0,0 +1,25 @@
+//go:build !windows
+// +build !windows
+
+package relayer
+
+import (
+	"net"
+	"sync"
+	"syscall"
+)
+
+// umaskMutex serializes the changes of the umask of the process.
+var umaskMutex sync.Mutex
+
+// listenUnix listens the unix socket at path. the socket is created with no access for the group and
+// the others, so no other user can connect to it before its mode is set.
+func listenUnix(path string) (net.Listener, error) {
+	umaskMutex.Lock()
+	defer umaskMutex.Unlock()
+
+	umask := syscall.Umask(0077)
+	defer syscall.Umask(umask)
+
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,8 @@
+package relayer
+
+import "net"
+
+// listenUnix listens the unix socket at path.
+func listenUnix(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
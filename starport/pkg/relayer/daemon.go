@@ -0,0 +1,537 @@
+package relayer
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+const controlUnixScheme = "unix://"
+
+// DefaultControlAddress is the default address of the control API of the daemon.
+var DefaultControlAddress = controlUnixScheme + os.ExpandEnv("$HOME/.starport/relayer/control.sock")
+
+// DefaultControlTokenPath is the default path of the token generated for the control API served on a
+// TCP address.
+var DefaultControlTokenPath = os.ExpandEnv("$HOME/.starport/relayer/control.token")
+
+// ErrDaemonNotRunning is returned when the daemon is controlled before it's run.
+var ErrDaemonNotRunning = errors.New("relayer daemon is not running")
+
+// ErrControlTokenRequired is returned when the control API is served on a TCP address without a token.
+var ErrControlTokenRequired = errors.New("a token is required to serve the control API on a TCP address")
+
+// Daemon is a long-running relayer that relays the linked paths that are not paused. paths can be
+// added, paused and the configuration can be reloaded while it's running, through its methods or
+// its control API.
+type Daemon struct {
+	r Relayer
+
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// ctx is the context of the daemon, it's set once the daemon is run.
+	ctx context.Context
+
+	// running keeps the funcs to stop relaying the paths that are being relayed by their ids.
+	running map[string]context.CancelFunc
+
+	wg sync.WaitGroup
+}
+
+// NewDaemon creates a new relayer daemon.
+func (r Relayer) NewDaemon() *Daemon {
+	return &Daemon{
+		r:       r,
+		running: make(map[string]context.CancelFunc),
+	}
+}
+
+// Run relays the linked paths that are not paused until ctx is canceled.
+func (d *Daemon) Run(ctx context.Context) error {
+	d.mu.Lock()
+	d.ctx = ctx
+	d.mu.Unlock()
+
+	if err := d.Reload(); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	d.wg.Wait()
+
+	return ctx.Err()
+}
+
+// Reload reloads the configuration, starts relaying the paths that are linked or resumed and stops
+// relaying the paths that are removed or paused since the last load.
+func (d *Daemon) Reload() error {
+	d.r.m.Lock()
+	conf, err := relayerconf.Get()
+	d.r.m.Unlock()
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.ctx == nil {
+		return ErrDaemonNotRunning
+	}
+
+	relayed := relayedPaths(conf)
+
+	for id, stop := range d.running {
+		if !containsPath(relayed, id) {
+			stop()
+			delete(d.running, id)
+		}
+	}
+
+	for _, id := range relayed {
+		if _, ok := d.running[id]; ok {
+			continue
+		}
+
+		id := id
+		ctx, stop := context.WithCancel(d.ctx)
+		d.running[id] = stop
+
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.r.relayPath(ctx, id)
+		}()
+	}
+
+	return nil
+}
+
+// IsRunning checks if the path is being relayed by the daemon.
+func (d *Daemon) IsRunning(pathID string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.running[pathID]
+	return ok
+}
+
+// Pause stops relaying the path, it's kept paused in the configuration until it's resumed.
+func (d *Daemon) Pause(pathID string) error {
+	return d.setPaused(pathID, true)
+}
+
+// Resume starts relaying the paused path again.
+func (d *Daemon) Resume(pathID string) error {
+	return d.setPaused(pathID, false)
+}
+
+func (d *Daemon) setPaused(pathID string, paused bool) error {
+	d.r.m.Lock()
+	conf, err := relayerconf.Get()
+	if err == nil {
+		var path relayerconf.Path
+		if path, err = conf.PathByID(pathID); err == nil {
+			path.Paused = paused
+			if err = conf.UpdatePath(path); err == nil {
+				err = relayerconf.Save(conf)
+			}
+		}
+	}
+	d.r.m.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return d.Reload()
+}
+
+// ChainRequest configures an end of a path added to the daemon.
+type ChainRequest struct {
+	// RPCAddress is the RPC address of the chain's node.
+	RPCAddress string `json:"rpc_address"`
+
+	// Account is the name of the relayer account used on the chain.
+	Account string `json:"account"`
+
+	// FaucetAddress is the optional faucet address to get tokens for the relayer account.
+	FaucetAddress string `json:"faucet_address"`
+
+	GasPrice      string `json:"gas_price"`
+	GasLimit      int64  `json:"gas_limit"`
+	AddressPrefix string `json:"address_prefix"`
+
+	// Port and Version of the channel end, transfer channels are created by default.
+	Port    string `json:"port"`
+	Version string `json:"version"`
+
+	// Payee is the address that receives the fees on the chain when the channel is incentivized.
+	Payee string `json:"payee"`
+}
+
+// AddPathRequest adds a new path to the daemon.
+type AddPathRequest struct {
+	Source ChainRequest `json:"source"`
+	Target ChainRequest `json:"target"`
+
+	// Ordered sets the channel as ordered.
+	Ordered bool `json:"ordered"`
+
+	// Incentivized sets the channel as an incentivized channel of the fee middleware.
+	Incentivized bool `json:"incentivized"`
+
+	// Reuse reuses an existing open channel between the chains when there is one.
+	Reuse bool `json:"reuse"`
+}
+
+// AddPath configures the chains of the request, links them with a new path and starts relaying it.
+func (d *Daemon) AddPath(ctx context.Context, req AddPathRequest) (relayerconf.Path, error) {
+	src, err := d.requestChain(ctx, req.Source)
+	if err != nil {
+		return relayerconf.Path{}, err
+	}
+
+	dst, err := d.requestChain(ctx, req.Target)
+	if err != nil {
+		return relayerconf.Path{}, err
+	}
+
+	var options []ChannelOption
+	if req.Source.Port != "" {
+		options = append(options, SourcePort(req.Source.Port))
+	}
+	if req.Source.Version != "" {
+		options = append(options, SourceVersion(req.Source.Version))
+	}
+	if req.Target.Port != "" {
+		options = append(options, TargetPort(req.Target.Port))
+	}
+	if req.Target.Version != "" {
+		options = append(options, TargetVersion(req.Target.Version))
+	}
+	if req.Ordered {
+		options = append(options, Ordered())
+	}
+	if req.Incentivized {
+		options = append(options,
+			Incentivized(),
+			SourcePayee(req.Source.Payee),
+			TargetPayee(req.Target.Payee),
+		)
+	}
+
+	if req.Reuse {
+		channel, err := src.FindChannel(ctx, dst, options...)
+		switch {
+		case err == nil:
+			options = append(options, ReuseChannel(channel))
+		case errors.Is(err, ErrChannelNotFound):
+		default:
+			return relayerconf.Path{}, err
+		}
+	}
+
+	id, err := src.Connect(ctx, dst, options...)
+	if err != nil {
+		return relayerconf.Path{}, err
+	}
+
+	if err := d.r.Link(ctx, id); err != nil {
+		return relayerconf.Path{}, err
+	}
+
+	if err := d.Reload(); err != nil {
+		return relayerconf.Path{}, err
+	}
+
+	return d.r.GetPath(ctx, id)
+}
+
+// requestChain configures the chain of the request on the relayer.
+func (d *Daemon) requestChain(ctx context.Context, req ChainRequest) (*Chain, error) {
+	var options []Option
+	if req.FaucetAddress != "" {
+		options = append(options, WithFaucet(req.FaucetAddress))
+	}
+	if req.GasPrice != "" {
+		options = append(options, WithGasPrice(req.GasPrice))
+	}
+	if req.GasLimit != 0 {
+		options = append(options, WithGasLimit(req.GasLimit))
+	}
+	if req.AddressPrefix != "" {
+		options = append(options, WithAddressPrefix(req.AddressPrefix))
+	}
+
+	chain, _, err := d.r.NewChain(ctx, req.Account, req.RPCAddress, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the relayer account might be funded already, so getting tokens from the faucet is optional.
+	if req.FaucetAddress != "" {
+		chain.TryRetrieve(ctx)
+	}
+
+	return chain, nil
+}
+
+// relayedPaths returns the ids of the paths that are relayed by the daemon.
+func relayedPaths(conf relayerconf.Config) []string {
+	var ids []string
+	for _, path := range conf.Paths {
+		if path.Src.ChannelID != "" && !path.Paused {
+			ids = append(ids, path.ID)
+		}
+	}
+	return ids
+}
+
+func containsPath(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+type serveOptions struct {
+	token string
+}
+
+// ServeOption configures serving the control API.
+type ServeOption func(*serveOptions)
+
+// ControlToken requires the requests of the control API to have the token as a bearer token in their
+// Authorization header. it's required to serve the API on a TCP address.
+func ControlToken(token string) ServeOption {
+	return func(o *serveOptions) {
+		o.token = token
+	}
+}
+
+// IsUnixControlAddress checks if the address of the control API is a unix socket.
+func IsUnixControlAddress(address string) bool {
+	return strings.HasPrefix(address, controlUnixScheme)
+}
+
+// Serve serves the control API of the daemon on address until ctx is canceled. address is either a
+// unix socket like unix:///path/to/control.sock or a loopback TCP address like localhost:4600, the
+// TCP addresses require a token.
+func (d *Daemon) Serve(ctx context.Context, address string, options ...ServeOption) error {
+	var o serveOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+	if !IsUnixControlAddress(address) && o.token == "" {
+		return ErrControlTokenRequired
+	}
+
+	l, err := listenControl(address)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	return xhttp.ServeListener(ctx, &http.Server{Handler: controlHandler(d, o.token)}, l)
+}
+
+// controlHandler authorizes the requests of the control API with the bearer token when it's set and
+// rejects the POST requests that are not JSON, so the API cannot be called by the forms of web pages.
+func controlHandler(next http.Handler, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			auth := r.Header.Get("Authorization")
+			if !strings.HasPrefix(auth, "Bearer ") ||
+				subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) != 1 {
+				xhttp.ResponseJSON(w, http.StatusUnauthorized, xhttp.NewErrorResponse(errors.New("invalid token")))
+				return
+			}
+		}
+		if r.Method == http.MethodPost {
+			if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+				xhttp.ResponseJSON(w, http.StatusUnsupportedMediaType,
+					xhttp.NewErrorResponse(errors.New("the content type must be application/json")))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// listenControl listens the address of the control API, only the user can access the unix sockets
+// and the TCP addresses must be loopback addresses. the directory of a unix socket is created with
+// access only for the user when it doesn't exist.
+func listenControl(address string) (net.Listener, error) {
+	if !IsUnixControlAddress(address) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, fmt.Errorf("the control API can only be served on a loopback address, not %s", address)
+		}
+		return net.Listen("tcp", address)
+	}
+
+	path := strings.TrimPrefix(address, controlUnixScheme)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	// remove the socket left by a previous daemon that is not stopped gracefully.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	l, err := listenUnix(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// PendingCounts are the counts of the packets and the acknowledgements of a path that are not
+// relayed yet.
+type PendingCounts struct {
+	SrcPackets int `json:"src_packets"`
+	SrcAcks    int `json:"src_acks"`
+	DstPackets int `json:"dst_packets"`
+	DstAcks    int `json:"dst_acks"`
+}
+
+// PathResponse is the payload of the paths returned by the control API.
+type PathResponse struct {
+	Path    relayerconf.Path       `json:"path"`
+	Health  Health                 `json:"health"`
+	Running bool                   `json:"running"`
+	Relay   relayerconf.PathStatus `json:"relay"`
+	Pending *PendingCounts         `json:"pending,omitempty"`
+}
+
+// ServeHTTP implements http.Handler to expose the control API of the daemon.
+func (d *Daemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/paths", d.listPathsHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/paths", d.addPathHandler).
+		Methods(http.MethodPost)
+
+	router.HandleFunc("/paths/{id}/pause", d.pausePathHandler(true)).
+		Methods(http.MethodPost)
+
+	router.HandleFunc("/paths/{id}/resume", d.pausePathHandler(false)).
+		Methods(http.MethodPost)
+
+	router.HandleFunc("/reload", d.reloadHandler).
+		Methods(http.MethodPost)
+
+	router.ServeHTTP(w, r)
+}
+
+func (d *Daemon) listPathsHandler(w http.ResponseWriter, r *http.Request) {
+	statuses, err := d.r.Status(r.Context())
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+		return
+	}
+
+	paths := make([]PathResponse, 0, len(statuses))
+	for _, status := range statuses {
+		path := PathResponse{
+			Path:    status.Path,
+			Health:  status.Health,
+			Running: d.IsRunning(status.Path.ID),
+			Relay:   status.Relay,
+		}
+		if status.Pending != nil {
+			path.Pending = &PendingCounts{
+				SrcPackets: len(status.Pending.Src.Packets),
+				SrcAcks:    len(status.Pending.Src.Acks),
+				DstPackets: len(status.Pending.Dst.Packets),
+				DstAcks:    len(status.Pending.Dst.Acks),
+			}
+		}
+		paths = append(paths, path)
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, paths)
+}
+
+func (d *Daemon) addPathHandler(w http.ResponseWriter, r *http.Request) {
+	var req AddPathRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(err))
+		return
+	}
+	if req.Source.RPCAddress == "" || req.Target.RPCAddress == "" {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(errors.New("rpc addresses of the chains are required")))
+		return
+	}
+	if req.Source.Account == "" || req.Target.Account == "" {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(errors.New("accounts of the chains are required")))
+		return
+	}
+
+	path, err := d.AddPath(r.Context(), req)
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusCreated, path)
+}
+
+func (d *Daemon) pausePathHandler(paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		err := d.setPaused(id, paused)
+		switch {
+		case errors.Is(err, relayerconf.ErrPathCannotBeFound):
+			xhttp.ResponseJSON(w, http.StatusNotFound, xhttp.NewErrorResponse(err))
+			return
+		case err != nil:
+			xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+			return
+		}
+
+		path, err := d.r.GetPath(r.Context(), id)
+		if err != nil {
+			xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+			return
+		}
+		xhttp.ResponseJSON(w, http.StatusOK, path)
+	}
+}
+
+func (d *Daemon) reloadHandler(w http.ResponseWriter, r *http.Request) {
+	if err := d.Reload(); err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, struct{}{})
+}
@@ -0,0 +1,92 @@
+package relayer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+func TestRelayedPaths(t *testing.T) {
+	conf := relayerconf.Config{
+		Paths: []relayerconf.Path{
+			{ID: "linked", Src: relayerconf.PathEnd{ChannelID: "channel-0"}},
+			{ID: "unlinked"},
+			{ID: "paused", Paused: true, Src: relayerconf.PathEnd{ChannelID: "channel-1"}},
+		},
+	}
+
+	require.Equal(t, []string{"linked"}, relayedPaths(conf))
+}
+
+func TestListenControl(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "relayer")
+	path := filepath.Join(dir, "control.sock")
+
+	// the directory of the socket is created for the user only.
+	l, err := listenControl(controlUnixScheme + path)
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	// stale sockets are replaced.
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	l, err = listenControl(controlUnixScheme + path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	l, err = listenControl("127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	for _, address := range []string{"0.0.0.0:0", ":0", "192.0.2.1:4600"} {
+		_, err = listenControl(address)
+		require.Error(t, err, address)
+	}
+}
+
+func TestControlHandler(t *testing.T) {
+	h := controlHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), "secret")
+
+	for _, tt := range []struct {
+		name        string
+		method      string
+		auth        string
+		contentType string
+		status      int
+	}{
+		{"authorized", http.MethodPost, "Bearer secret", "application/json", http.StatusNoContent},
+		{"authorized get", http.MethodGet, "Bearer secret", "", http.StatusNoContent},
+		{"no token", http.MethodGet, "", "", http.StatusUnauthorized},
+		{"invalid token", http.MethodPost, "Bearer other", "application/json", http.StatusUnauthorized},
+		{"form", http.MethodPost, "Bearer secret", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"no content type", http.MethodPost, "Bearer secret", "", http.StatusUnsupportedMediaType},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/reload", nil)
+			if tt.auth != "" {
+				r.Header.Set("Authorization", tt.auth)
+			}
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+const (
+	// FeeVersion is the version of the ICS-29 fee middleware wrapping the app versions of the
+	// incentivized channels.
+	FeeVersion = "ics29-1"
+
+	// gRPC methods of the fee middleware queried to register the payees.
+	methodFeeEnabledChannel = "/ibc.applications.fee.v1.Query/FeeEnabledChannel"
+	methodPayee             = "/ibc.applications.fee.v1.Query/Payee"
+	methodCounterpartyPayee = "/ibc.applications.fee.v1.Query/CounterpartyPayee"
+
+	eventDistributeFee      = "distribute_fee"
+	eventAcknowledgePacket  = "acknowledge_packet"
+	eventTimeoutPacket      = "timeout_packet"
+	attributeFeeReceiver    = "receiver"
+	attributeFee            = "fee"
+	earnedFeesSearchPerPage = 100
+)
+
+// IncentivizedVersion returns the version of an incentivized channel that wraps the app version,
+// e.g. ics20-1, with the fee middleware version.
+func IncentivizedVersion(appVersion string) string {
+	version, _ := json.Marshal(feeMetadata{
+		FeeVersion: FeeVersion,
+		AppVersion: appVersion,
+	})
+	return string(version)
+}
+
+// feeMetadata is the version of the incentivized channels.
+type feeMetadata struct {
+	FeeVersion string `json:"fee_version"`
+	AppVersion string `json:"app_version"`
+}
+
+// Incentivized makes the new channel an incentivized channel of the ICS-29 fee middleware, so the
+// relayer is paid for relaying its packets. source and target versions are wrapped with the fee
+// version.
+func Incentivized() ChannelOption {
+	return func(c *channelOptions) {
+		c.incentivized = true
+	}
+}
+
+// SourcePayee sets the address on the source chain that receives the fees paid on the source chain
+// for relaying the packets of an incentivized channel. by default, it's the relayer account.
+func SourcePayee(address string) ChannelOption {
+	return func(c *channelOptions) {
+		c.sourcePayee = address
+	}
+}
+
+// TargetPayee sets the address on the target chain that receives the fees paid on the target chain
+// for relaying the packets of an incentivized channel. by default, it's the relayer account.
+func TargetPayee(address string) ChannelOption {
+	return func(c *channelOptions) {
+		c.targetPayee = address
+	}
+}
+
+// Fees are the fees earned on a path end.
+type Fees struct {
+	// Payee is the address that receives the fees.
+	Payee string
+
+	// Coins are the fees paid to the payee.
+	Coins sdk.Coins
+}
+
+// PathFees are the fees earned on the both ends of a path.
+type PathFees struct {
+	// Src are the fees earned on the source chain.
+	Src Fees
+
+	// Dst are the fees earned on the destination chain.
+	Dst Fees
+}
+
+// EarnedFees returns the fees of the incentivized path that are paid to the payees for relaying its
+// packets. fees are found from the txs indexed by the nodes of the chains.
+func (r Relayer) EarnedFees(ctx context.Context, pathID string) (PathFees, error) {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return PathFees{}, err
+	}
+
+	path, err := conf.PathByID(pathID)
+	if err != nil {
+		return PathFees{}, err
+	}
+	if !path.Incentivized {
+		return PathFees{}, fmt.Errorf("path %q is not incentivized", pathID)
+	}
+	if path.Src.ChannelID == "" {
+		return PathFees{}, fmt.Errorf("path %q is not linked yet", pathID)
+	}
+
+	var fees PathFees
+
+	if fees.Src, err = r.earnedFees(ctx, conf, path.Src); err != nil {
+		return PathFees{}, err
+	}
+	if fees.Dst, err = r.earnedFees(ctx, conf, path.Dst); err != nil {
+		return PathFees{}, err
+	}
+
+	return fees, nil
+}
+
+// earnedFees returns the fees paid to the payee of end for the acknowledgements and the timeouts of
+// the packets sent from end.
+func (r Relayer) earnedFees(ctx context.Context, conf relayerconf.Config, end relayerconf.PathEnd) (Fees, error) {
+	chain, err := conf.ChainByID(end.ChainID)
+	if err != nil {
+		return Fees{}, err
+	}
+
+	payee, err := r.payee(chain, end)
+	if err != nil {
+		return Fees{}, err
+	}
+
+	q, err := newQuerier(ctx, chain.RPCAddress)
+	if err != nil {
+		return Fees{}, err
+	}
+
+	fees := Fees{Payee: payee}
+
+	for _, event := range []string{eventAcknowledgePacket, eventTimeoutPacket} {
+		query := fmt.Sprintf("%s.%s='%s' AND %s.packet_src_channel='%s'",
+			eventDistributeFee, attributeFeeReceiver, payee, event, end.ChannelID)
+
+		perPage := earnedFeesSearchPerPage
+
+		for page := 1; ; page++ {
+			res, err := q.rpc.TxSearch(ctx, query, false, &page, &perPage, "")
+			if err != nil {
+				return Fees{}, err
+			}
+
+			for _, tx := range res.Txs {
+				coins, err := distributedFees(tx.TxResult.Events, payee)
+				if err != nil {
+					return Fees{}, err
+				}
+				fees.Coins = fees.Coins.Add(coins...)
+			}
+
+			if page*perPage >= res.TotalCount {
+				break
+			}
+		}
+	}
+
+	return fees, nil
+}
+
+// distributedFees returns the sum of the fees distributed to receiver by the events of a tx.
+func distributedFees(events []abci.Event, receiver string) (sdk.Coins, error) {
+	var fees sdk.Coins
+
+	for _, event := range events {
+		if event.Type != eventDistributeFee {
+			continue
+		}
+
+		var (
+			eventReceiver string
+			eventFee      string
+		)
+		for _, attr := range event.Attributes {
+			switch string(attr.Key) {
+			case attributeFeeReceiver:
+				eventReceiver = string(attr.Value)
+			case attributeFee:
+				eventFee = string(attr.Value)
+			}
+		}
+		if eventReceiver != receiver || eventFee == "" {
+			continue
+		}
+
+		coins, err := sdk.ParseCoinsNormalized(eventFee)
+		if err != nil {
+			return nil, err
+		}
+		fees = fees.Add(coins...)
+	}
+
+	return fees, nil
+}
+
+// registerPayees registers the payees of the relayer to the fee middleware on the both ends of the
+// incentivized path. payees that are already registered are kept as is.
+func (r Relayer) registerPayees(ctx context.Context, conf relayerconf.Config, path relayerconf.Path) error {
+	if err := r.registerEndPayees(ctx, conf, path.Src, path.Dst); err != nil {
+		return err
+	}
+	return r.registerEndPayees(ctx, conf, path.Dst, path.Src)
+}
+
+// registerEndPayees registers the payee of end that receives the fees of the acknowledgements and the
+// timeouts relayed to end, and the counterparty payee that receives the fees of the packets relayed
+// to end on the counterparty chain.
+func (r Relayer) registerEndPayees(ctx context.Context, conf relayerconf.Config, end, counterparty relayerconf.PathEnd) error {
+	chain, err := conf.ChainByID(end.ChainID)
+	if err != nil {
+		return err
+	}
+
+	counterpartyChain, err := conf.ChainByID(counterparty.ChainID)
+	if err != nil {
+		return err
+	}
+
+	account, err := r.ca.GetByName(chain.Account)
+	if err != nil {
+		return err
+	}
+	relayerAddress := account.Address(chain.AddressPrefix)
+
+	payee, err := r.payee(chain, end)
+	if err != nil {
+		return err
+	}
+
+	counterpartyPayee, err := r.payee(counterpartyChain, counterparty)
+	if err != nil {
+		return err
+	}
+
+	client, err := cosmosclient.New(ctx,
+		cosmosclient.WithNodeAddress(chain.RPCAddress),
+		cosmosclient.WithAddressPrefix(chain.AddressPrefix),
+		cosmosclient.WithAccountRegistry(r.ca),
+		cosmosclient.WithGasPrices(chain.GasPrice),
+		cosmosclient.WithInterfaceRegistrations(registerFeeInterfaces),
+	)
+	if err != nil {
+		return err
+	}
+
+	var enabled feeEnabledChannelResponse
+	err = client.Context.Invoke(ctx, methodFeeEnabledChannel, &feeEnabledChannelRequest{
+		PortID:    end.PortID,
+		ChannelID: end.ChannelID,
+	}, &enabled)
+	if err != nil {
+		return err
+	}
+	if !enabled.FeeEnabled {
+		return fmt.Errorf("channel %q of %q chain is not fee enabled", end.ChannelID, chain.ID)
+	}
+
+	var msgs []sdk.Msg
+
+	var registeredPayee payeeResponse
+	err = client.Context.Invoke(ctx, methodPayee, &payeeRequest{
+		ChannelID: end.ChannelID,
+		Relayer:   relayerAddress,
+	}, &registeredPayee)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	if registeredPayee.PayeeAddress != payee {
+		msgs = append(msgs, &msgRegisterPayee{
+			PortID:    end.PortID,
+			ChannelID: end.ChannelID,
+			Relayer:   relayerAddress,
+			Payee:     payee,
+		})
+	}
+
+	var registeredCounterpartyPayee counterpartyPayeeResponse
+	err = client.Context.Invoke(ctx, methodCounterpartyPayee, &counterpartyPayeeRequest{
+		ChannelID: end.ChannelID,
+		Relayer:   relayerAddress,
+	}, &registeredCounterpartyPayee)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	if registeredCounterpartyPayee.CounterpartyPayee != counterpartyPayee {
+		msgs = append(msgs, &msgRegisterCounterpartyPayee{
+			PortID:            end.PortID,
+			ChannelID:         end.ChannelID,
+			Relayer:           relayerAddress,
+			CounterpartyPayee: counterpartyPayee,
+		})
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	_, err = client.BroadcastTx(chain.Account, msgs...)
+	return err
+}
+
+// payee returns the payee of end, the relayer account receives the fees when end doesn't have one.
+func (r Relayer) payee(chain relayerconf.Chain, end relayerconf.PathEnd) (string, error) {
+	if end.Payee != "" {
+		return end.Payee, nil
+	}
+
+	account, err := r.ca.GetByName(chain.Account)
+	if err != nil {
+		return "", err
+	}
+	return account.Address(chain.AddressPrefix), nil
+}
+
+// registerFeeInterfaces registers the msgs of the fee middleware.
+func registerFeeInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&msgRegisterPayee{},
+		&msgRegisterCounterpartyPayee{},
+	)
+}
+
+// the msgs and the queries of the fee middleware, it's not a part of the IBC version that is
+// used by the relayer yet.
+
+type msgRegisterPayee struct {
+	PortID    string `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	ChannelID string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Relayer   string `protobuf:"bytes,3,opt,name=relayer,proto3" json:"relayer,omitempty"`
+	Payee     string `protobuf:"bytes,4,opt,name=payee,proto3" json:"payee,omitempty"`
+}
+
+func (m *msgRegisterPayee) Reset()                       { *m = msgRegisterPayee{} }
+func (m *msgRegisterPayee) String() string               { return proto.CompactTextString(m) }
+func (*msgRegisterPayee) ProtoMessage()                  {}
+func (*msgRegisterPayee) XXX_MessageName() string        { return "ibc.applications.fee.v1.MsgRegisterPayee" }
+func (m *msgRegisterPayee) ValidateBasic() error         { return validateRelayer(m.Relayer) }
+func (m *msgRegisterPayee) GetSigners() []sdk.AccAddress { return signers(m.Relayer) }
+
+type msgRegisterCounterpartyPayee struct {
+	PortID            string `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	ChannelID         string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Relayer           string `protobuf:"bytes,3,opt,name=relayer,proto3" json:"relayer,omitempty"`
+	CounterpartyPayee string `protobuf:"bytes,4,opt,name=counterparty_payee,json=counterpartyPayee,proto3" json:"counterparty_payee,omitempty"`
+}
+
+func (m *msgRegisterCounterpartyPayee) Reset()         { *m = msgRegisterCounterpartyPayee{} }
+func (m *msgRegisterCounterpartyPayee) String() string { return proto.CompactTextString(m) }
+func (*msgRegisterCounterpartyPayee) ProtoMessage()    {}
+func (*msgRegisterCounterpartyPayee) XXX_MessageName() string {
+	return "ibc.applications.fee.v1.MsgRegisterCounterpartyPayee"
+}
+func (m *msgRegisterCounterpartyPayee) ValidateBasic() error         { return validateRelayer(m.Relayer) }
+func (m *msgRegisterCounterpartyPayee) GetSigners() []sdk.AccAddress { return signers(m.Relayer) }
+
+// validateRelayer validates the relayer address of the msgs regardless of its prefix.
+func validateRelayer(relayer string) error {
+	_, _, err := bech32.DecodeAndConvert(relayer)
+	return err
+}
+
+// signers returns the relayer address of the msgs as the signer.
+func signers(relayer string) []sdk.AccAddress {
+	_, addr, err := bech32.DecodeAndConvert(relayer)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{addr}
+}
+
+type feeEnabledChannelRequest struct {
+	PortID    string `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	ChannelID string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+}
+
+func (r *feeEnabledChannelRequest) Reset()         { *r = feeEnabledChannelRequest{} }
+func (r *feeEnabledChannelRequest) String() string { return proto.CompactTextString(r) }
+func (*feeEnabledChannelRequest) ProtoMessage()    {}
+
+type feeEnabledChannelResponse struct {
+	FeeEnabled bool `protobuf:"varint,1,opt,name=fee_enabled,json=feeEnabled,proto3" json:"fee_enabled,omitempty"`
+}
+
+func (r *feeEnabledChannelResponse) Reset()         { *r = feeEnabledChannelResponse{} }
+func (r *feeEnabledChannelResponse) String() string { return proto.CompactTextString(r) }
+func (*feeEnabledChannelResponse) ProtoMessage()    {}
+
+type payeeRequest struct {
+	ChannelID string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Relayer   string `protobuf:"bytes,2,opt,name=relayer,proto3" json:"relayer,omitempty"`
+}
+
+func (r *payeeRequest) Reset()         { *r = payeeRequest{} }
+func (r *payeeRequest) String() string { return proto.CompactTextString(r) }
+func (*payeeRequest) ProtoMessage()    {}
+
+type payeeResponse struct {
+	PayeeAddress string `protobuf:"bytes,1,opt,name=payee_address,json=payeeAddress,proto3" json:"payee_address,omitempty"`
+}
+
+func (r *payeeResponse) Reset()         { *r = payeeResponse{} }
+func (r *payeeResponse) String() string { return proto.CompactTextString(r) }
+func (*payeeResponse) ProtoMessage()    {}
+
+type counterpartyPayeeRequest struct {
+	ChannelID string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Relayer   string `protobuf:"bytes,2,opt,name=relayer,proto3" json:"relayer,omitempty"`
+}
+
+func (r *counterpartyPayeeRequest) Reset()         { *r = counterpartyPayeeRequest{} }
+func (r *counterpartyPayeeRequest) String() string { return proto.CompactTextString(r) }
+func (*counterpartyPayeeRequest) ProtoMessage()    {}
+
+type counterpartyPayeeResponse struct {
+	CounterpartyPayee string `protobuf:"bytes,1,opt,name=counterparty_payee,json=counterpartyPayee,proto3" json:"counterparty_payee,omitempty"`
+}
+
+func (r *counterpartyPayeeResponse) Reset()         { *r = counterpartyPayeeResponse{} }
+func (r *counterpartyPayeeResponse) String() string { return proto.CompactTextString(r) }
+func (*counterpartyPayeeResponse) ProtoMessage()    {}
@@ -0,0 +1,63 @@
+package relayer
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestIncentivizedVersion(t *testing.T) {
+	require.Equal(t, `{"fee_version":"ics29-1","app_version":"ics20-1"}`, IncentivizedVersion(TransferVersion))
+
+	options := applyChannelOptions([]ChannelOption{Incentivized(), TargetVersion("blog-1")})
+	require.Equal(t, IncentivizedVersion(TransferVersion), options.sourceVersion)
+	require.Equal(t, IncentivizedVersion("blog-1"), options.targetVersion)
+}
+
+func TestDistributedFees(t *testing.T) {
+	event := func(typ, receiver, fee string) abci.Event {
+		return abci.Event{
+			Type: typ,
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(attributeFeeReceiver), Value: []byte(receiver)},
+				{Key: []byte(attributeFee), Value: []byte(fee)},
+			},
+		}
+	}
+
+	fees, err := distributedFees([]abci.Event{
+		event(eventDistributeFee, "cosmos1a", "10stake"),
+		event(eventDistributeFee, "cosmos1b", "20stake"),
+		event(eventDistributeFee, "cosmos1a", "5stake,3token"),
+		event("transfer", "cosmos1a", "100stake"),
+	}, "cosmos1a")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 15), sdk.NewInt64Coin("token", 3)), fees)
+
+	_, err = distributedFees([]abci.Event{event(eventDistributeFee, "cosmos1a", "invalid")}, "cosmos1a")
+	require.Error(t, err)
+}
+
+func TestRegisterFeeInterfaces(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	registerFeeInterfaces(registry)
+
+	for _, msg := range []sdk.Msg{&msgRegisterPayee{}, &msgRegisterCounterpartyPayee{}} {
+		resolved, err := registry.Resolve(sdk.MsgTypeURL(msg))
+		require.NoError(t, err)
+		require.IsType(t, msg, resolved)
+	}
+	require.Equal(t, "/ibc.applications.fee.v1.MsgRegisterPayee", sdk.MsgTypeURL(&msgRegisterPayee{}))
+
+	// msgs are packed into txs and decoded back by the registry.
+	msg := &msgRegisterPayee{PortID: TransferPort, ChannelID: "channel-0", Relayer: "cosmos1a", Payee: "cosmos1b"}
+	packed, err := codectypes.NewAnyWithValue(msg)
+	require.NoError(t, err)
+
+	var unpacked sdk.Msg
+	require.NoError(t, registry.UnpackAny(packed, &unpacked))
+	require.Equal(t, msg, unpacked)
+}
@@ -0,0 +1,162 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+const (
+	eventSendPacket           = "send_packet"
+	eventWriteAcknowledgement = "write_acknowledgement"
+	attributePacketSrcChannel = "packet_src_channel"
+	attributePacketDstChannel = "packet_dst_channel"
+)
+
+// PendingPacket is a packet or an acknowledgement that is not relayed to the other end of a path yet.
+type PendingPacket struct {
+	// Sequence of the packet.
+	Sequence uint64
+
+	// Height is the height of the block that the packet is sent or acknowledged at.
+	// it's zero when the block cannot be found, e.g. the txs are not indexed by the node.
+	Height int64
+
+	// Time is the time of the block that the packet is sent or acknowledged at.
+	// it's zero when the block cannot be found.
+	Time time.Time
+}
+
+// Age returns the duration since the packet is sent or acknowledged, it's zero when the time is unknown.
+func (p PendingPacket) Age() time.Duration {
+	if p.Time.IsZero() {
+		return 0
+	}
+	return time.Since(p.Time)
+}
+
+// PendingPackets are the packets and acknowledgements of a path end that are not relayed to the
+// other end yet.
+type PendingPackets struct {
+	// Packets are the packets sent from the end that are not received by the other end.
+	Packets []PendingPacket
+
+	// Acks are the acknowledgements written on the end that are not received by the other end.
+	Acks []PendingPacket
+}
+
+// PathPendingPackets are the pending packets of the both ends of a path.
+type PathPendingPackets struct {
+	// Src are the pending packets of the source end.
+	Src PendingPackets
+
+	// Dst are the pending packets of the destination end.
+	Dst PendingPackets
+}
+
+// PendingPackets returns the packets and the acknowledgements of the path that are not relayed yet,
+// with their sequences and ages, to diagnose stuck packets.
+func (r Relayer) PendingPackets(ctx context.Context, pathID string) (PathPendingPackets, error) {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return PathPendingPackets{}, err
+	}
+
+	path, err := conf.PathByID(pathID)
+	if err != nil {
+		return PathPendingPackets{}, err
+	}
+	if path.Src.ChannelID == "" {
+		return PathPendingPackets{}, fmt.Errorf("path %q is not linked yet", pathID)
+	}
+
+	srcq, err := r.pathEndQuerier(ctx, conf, path.Src)
+	if err != nil {
+		return PathPendingPackets{}, err
+	}
+
+	dstq, err := r.pathEndQuerier(ctx, conf, path.Dst)
+	if err != nil {
+		return PathPendingPackets{}, err
+	}
+
+	var pending PathPendingPackets
+
+	if pending.Src, err = pendingPackets(ctx, srcq, dstq, path.Src, path.Dst); err != nil {
+		return PathPendingPackets{}, err
+	}
+	if pending.Dst, err = pendingPackets(ctx, dstq, srcq, path.Dst, path.Src); err != nil {
+		return PathPendingPackets{}, err
+	}
+
+	return pending, nil
+}
+
+func (r Relayer) pathEndQuerier(ctx context.Context, conf relayerconf.Config, end relayerconf.PathEnd) (querier, error) {
+	chain, err := conf.ChainByID(end.ChainID)
+	if err != nil {
+		return querier{}, err
+	}
+	return newQuerier(ctx, chain.RPCAddress)
+}
+
+// pendingPackets returns the packets and the acknowledgements of end that are not received by counterparty.
+func pendingPackets(ctx context.Context, q, cq querier, end, counterparty relayerconf.PathEnd) (PendingPackets, error) {
+	commitments, err := q.packetCommitments(ctx, end.PortID, end.ChannelID)
+	if err != nil {
+		return PendingPackets{}, err
+	}
+
+	packets, err := cq.unreceivedPackets(ctx, counterparty.PortID, counterparty.ChannelID, commitments)
+	if err != nil {
+		return PendingPackets{}, err
+	}
+
+	acks, err := q.packetAcknowledgements(ctx, end.PortID, end.ChannelID)
+	if err != nil {
+		return PendingPackets{}, err
+	}
+
+	// acks are pending while their packets still have commitments on the counterparty.
+	acks, err = cq.unreceivedAcks(ctx, counterparty.PortID, counterparty.ChannelID, acks)
+	if err != nil {
+		return PendingPackets{}, err
+	}
+
+	var pending PendingPackets
+
+	if pending.Packets, err = packetBlocks(ctx, q, eventSendPacket, attributePacketSrcChannel, end.ChannelID, packets); err != nil {
+		return PendingPackets{}, err
+	}
+	if pending.Acks, err = packetBlocks(ctx, q, eventWriteAcknowledgement, attributePacketDstChannel, end.ChannelID, acks); err != nil {
+		return PendingPackets{}, err
+	}
+
+	return pending, nil
+}
+
+// packetBlocks finds the blocks that the event of the packets are emitted in. the ages are left unknown
+// when they cannot be found, e.g. the node doesn't index the txs.
+func packetBlocks(ctx context.Context, q querier, event, channelAttr, channelID string, sequences []uint64) (
+	[]PendingPacket, error) {
+	var packets []PendingPacket
+
+	for _, sequence := range sequences {
+		packet := PendingPacket{Sequence: sequence}
+
+		height, blockTime, err := q.packetEventBlock(ctx, event, channelAttr, channelID, sequence)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err == nil {
+			packet.Height = height
+			packet.Time = blockTime
+		}
+
+		packets = append(packets, packet)
+	}
+
+	return packets, nil
+}
@@ -0,0 +1,245 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	clienttypes "github.com/cosmos/ibc-go/v2/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
+	ibctmtypes "github.com/cosmos/ibc-go/v2/modules/light-clients/07-tendermint/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+)
+
+// clientStatusActive is the status of the IBC clients that can be used to relay packets.
+const clientStatusActive = "Active"
+
+// querier queries the IBC state of a chain.
+type querier struct {
+	rpc     *rpchttp.HTTP
+	channel channeltypes.QueryClient
+	client  clienttypes.QueryClient
+}
+
+// newQuerier creates a querier for the chain at rpcAddress.
+func newQuerier(ctx context.Context, rpcAddress string) (querier, error) {
+	client, err := cosmosclient.New(ctx,
+		cosmosclient.WithNodeAddress(rpcAddress),
+		cosmosclient.WithInterfaceRegistrations(registerIBCInterfaces),
+	)
+	if err != nil {
+		return querier{}, err
+	}
+
+	return querier{
+		rpc:     client.RPC,
+		channel: channeltypes.NewQueryClient(client.Context),
+		client:  clienttypes.NewQueryClient(client.Context),
+	}, nil
+}
+
+// registerIBCInterfaces registers the IBC types packed into Any by the query responses.
+func registerIBCInterfaces(registry codectypes.InterfaceRegistry) {
+	clienttypes.RegisterInterfaces(registry)
+	ibctmtypes.RegisterInterfaces(registry)
+}
+
+// channels returns the channels of the port.
+func (q querier) channels(ctx context.Context, portID string) ([]*channeltypes.IdentifiedChannel, error) {
+	var (
+		channels []*channeltypes.IdentifiedChannel
+		key      []byte
+	)
+
+	for {
+		res, err := q.channel.Channels(ctx, &channeltypes.QueryChannelsRequest{
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, channel := range res.Channels {
+			if channel.PortId == portID {
+				channels = append(channels, channel)
+			}
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return channels, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// channelByID returns the channel of the port by its id.
+func (q querier) channelByID(ctx context.Context, portID, channelID string) (channeltypes.Channel, error) {
+	res, err := q.channel.Channel(ctx, &channeltypes.QueryChannelRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return channeltypes.Channel{}, err
+	}
+	return *res.Channel, nil
+}
+
+// channelClient returns the id of the client that the channel relies on and the id of the chain
+// that the client tracks. chain id is empty for the clients that aren't Tendermint clients.
+func (q querier) channelClient(ctx context.Context, portID, channelID string) (clientID, chainID string, err error) {
+	res, err := q.channel.ChannelClientState(ctx, &channeltypes.QueryChannelClientStateRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	clientState, err := clienttypes.UnpackClientState(res.IdentifiedClientState.ClientState)
+	if err != nil {
+		return "", "", err
+	}
+
+	clientID = res.IdentifiedClientState.ClientId
+
+	if tmClientState, ok := clientState.(*ibctmtypes.ClientState); ok {
+		chainID = tmClientState.ChainId
+	}
+
+	return clientID, chainID, nil
+}
+
+// isClientActive checks if the client can be used to relay packets, e.g. it's not frozen or expired.
+func (q querier) isClientActive(ctx context.Context, clientID string) (bool, error) {
+	res, err := q.client.ClientStatus(ctx, &clienttypes.QueryClientStatusRequest{ClientId: clientID})
+	if err != nil {
+		return false, err
+	}
+	return res.Status == clientStatusActive, nil
+}
+
+// packetCommitments returns the sequences of the packets sent from the channel that are not
+// acknowledged or timed out yet.
+func (q querier) packetCommitments(ctx context.Context, portID, channelID string) ([]uint64, error) {
+	var (
+		sequences []uint64
+		key       []byte
+	)
+
+	for {
+		res, err := q.channel.PacketCommitments(ctx, &channeltypes.QueryPacketCommitmentsRequest{
+			PortId:     portID,
+			ChannelId:  channelID,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, commitment := range res.Commitments {
+			sequences = append(sequences, commitment.Sequence)
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return sequences, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// packetAcknowledgements returns the sequences of the packets received by the channel that are
+// acknowledged.
+func (q querier) packetAcknowledgements(ctx context.Context, portID, channelID string) ([]uint64, error) {
+	var (
+		sequences []uint64
+		key       []byte
+	)
+
+	for {
+		res, err := q.channel.PacketAcknowledgements(ctx, &channeltypes.QueryPacketAcknowledgementsRequest{
+			PortId:     portID,
+			ChannelId:  channelID,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ack := range res.Acknowledgements {
+			sequences = append(sequences, ack.Sequence)
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return sequences, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// unreceivedPackets returns the sequences of the packets that are not received by the channel.
+func (q querier) unreceivedPackets(ctx context.Context, portID, channelID string, sequences []uint64) ([]uint64, error) {
+	if len(sequences) == 0 {
+		return nil, nil
+	}
+
+	res, err := q.channel.UnreceivedPackets(ctx, &channeltypes.QueryUnreceivedPacketsRequest{
+		PortId:                    portID,
+		ChannelId:                 channelID,
+		PacketCommitmentSequences: sequences,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Sequences, nil
+}
+
+// unreceivedAcks returns the sequences of the packets sent from the channel that their acknowledgements
+// are not received by the channel.
+func (q querier) unreceivedAcks(ctx context.Context, portID, channelID string, sequences []uint64) ([]uint64, error) {
+	if len(sequences) == 0 {
+		return nil, nil
+	}
+
+	res, err := q.channel.UnreceivedAcks(ctx, &channeltypes.QueryUnreceivedAcksRequest{
+		PortId:             portID,
+		ChannelId:          channelID,
+		PacketAckSequences: sequences,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Sequences, nil
+}
+
+// packetEventBlock returns the height and the time of the block that the event of the packet is
+// emitted in, e.g. send_packet. channelAttr is the attribute of the event that holds the channel id.
+// zero values are returned when the event cannot be found, e.g. the tx is pruned by the node.
+func (q querier) packetEventBlock(ctx context.Context, event, channelAttr, channelID string, sequence uint64) (
+	height int64, blockTime time.Time, err error) {
+	res, err := q.rpc.TxSearch(ctx,
+		fmt.Sprintf("%s.%s='%s' AND %s.packet_sequence='%d'", event, channelAttr, channelID, event, sequence),
+		false,
+		nil,
+		nil,
+		"",
+	)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if len(res.Txs) == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	height = res.Txs[0].Height
+
+	block, err := q.rpc.Block(ctx, &height)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return height, block.Block.Time, nil
+}
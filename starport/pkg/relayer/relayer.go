@@ -3,36 +3,45 @@ package relayer
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/cenkalti/backoff"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
 	"github.com/tendermint/starport/starport/pkg/cosmosclient"
-	"github.com/tendermint/starport/starport/pkg/ctxticker"
 	tsrelayer "github.com/tendermint/starport/starport/pkg/nodetime/programs/ts-relayer"
 	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
 	"github.com/tendermint/starport/starport/pkg/xurl"
 )
 
 const (
-	ibcSetupGas   int64 = 2256000
-	relayDuration       = time.Second * 5
+	ibcSetupGas     int64 = 2256000
+	relayDuration         = time.Second * 5
+	maxRelayBackoff       = time.Minute * 5
+
+	// maxRelayFailureTime is the time after that a path that is failed to be relayed since then is
+	// given up.
+	maxRelayFailureTime = time.Minute * 30
 )
 
 // Relayer is an IBC relayer.
 type Relayer struct {
 	ca cosmosaccount.Registry
+
+	// m protects relayerconf.Config and relayerconf.Status while paths are relayed concurrently.
+	m *sync.Mutex
 }
 
 // New creates a new IBC relayer and uses ca to access accounts.
 func New(ca cosmosaccount.Registry) Relayer {
 	r := Relayer{
 		ca: ca,
+		m:  &sync.Mutex{},
 	}
 
 	return r
@@ -40,6 +49,7 @@ func New(ca cosmosaccount.Registry) Relayer {
 
 // Link links all chains that has a path to each other.
 // paths are optional and acts as a filter to only link some chains.
+// payees of the relayer are registered to the fee middleware for the incentivized paths.
 // calling Link multiple times for the same paths does not have any side effects.
 func (r Relayer) Link(ctx context.Context, pathIDs ...string) error {
 	conf, err := relayerconf.Get()
@@ -53,69 +63,215 @@ func (r Relayer) Link(ctx context.Context, pathIDs ...string) error {
 			return err
 		}
 
-		if path.Src.ChannelID != "" { // already linked.
-			continue
-		}
+		if path.Src.ChannelID == "" { // not linked yet.
+			if path, err = r.call(ctx, conf, path, "link"); err != nil {
+				return err
+			}
 
-		if path, err = r.call(ctx, conf, path, "link"); err != nil {
-			return err
+			if err := r.savePath(path); err != nil {
+				return err
+			}
 		}
 
-		if err := conf.UpdatePath(path); err != nil {
-			return err
-		}
-		if err := relayerconf.Save(conf); err != nil {
-			return err
+		if path.Incentivized {
+			if err := r.registerPayees(ctx, conf, path); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-// Start relays packets for linked paths until ctx is canceled.
+// Start relays packets for linked paths concurrently until ctx is canceled.
+// paths are relayed independently, when relaying a path fails, it's retried with an exponential
+// backoff without interrupting the other paths. a path that keeps failing for 30 minutes is
+// given up, an error is returned when all paths are given up. relaying status of the paths is kept
+// to be reported by Status.
 func (r Relayer) Start(ctx context.Context, pathIDs ...string) error {
 	conf, err := relayerconf.Get()
 	if err != nil {
 		return err
 	}
 
-	wg, ctx := errgroup.WithContext(ctx)
-	var m sync.Mutex // protects relayerconf.Path.
-
-	start := func(id string) error {
-		path, err := conf.PathByID(id)
-		if err != nil {
+	for _, id := range pathIDs {
+		if _, err := conf.PathByID(id); err != nil {
 			return err
 		}
+	}
 
-		if path, err = r.call(ctx, conf, path, "start"); err != nil {
-			return err
-		}
+	var (
+		wg       sync.WaitGroup
+		m        sync.Mutex
+		failures []string
+	)
+
+	for _, id := range pathIDs {
+		id := id
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.relayPath(ctx, id); err != nil {
+				m.Lock()
+				failures = append(failures, fmt.Sprintf("%s: %s", id, err))
+				m.Unlock()
+			}
+		}()
+	}
 
-		m.Lock()
-		defer m.Unlock()
+	wg.Wait()
 
-		conf, err := relayerconf.Get()
+	if ctx.Err() != nil || len(failures) == 0 {
+		return ctx.Err()
+	}
+
+	// the paths are only given up on errors, all of them have failed.
+	sort.Strings(failures)
+	return fmt.Errorf("cannot relay the paths: %s", strings.Join(failures, "; "))
+}
+
+// relayPath relays packets of the path until ctx is canceled, failed attempts are retried with
+// an exponential backoff. the path is given up with the error of the last attempt when it's failed
+// to be relayed for maxRelayFailureTime.
+func (r Relayer) relayPath(ctx context.Context, id string) error {
+	b := newRelayBackoff()
+
+	for {
+		err := r.relayPathOnce(ctx, id)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		wait := relayDuration
 		if err != nil {
-			return err
+			wait = b.NextBackOff()
+		} else {
+			b.Reset()
 		}
 
-		if err := conf.UpdatePath(path); err != nil {
+		if wait == backoff.Stop {
+			_ = r.updatePathStatus(id, err, 0)
 			return err
 		}
 
-		return relayerconf.Save(conf)
+		// the status is only reported, relaying goes on even when it cannot be saved.
+		_ = r.updatePathStatus(id, err, wait)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
 	}
+}
 
-	for _, id := range pathIDs {
-		id := id
+// relayPathOnce relays the packets of the path from the latest relayed heights once.
+func (r Relayer) relayPathOnce(ctx context.Context, id string) error {
+	r.m.Lock()
+	conf, err := relayerconf.Get()
+	r.m.Unlock()
+	if err != nil {
+		return err
+	}
+
+	path, err := conf.PathByID(id)
+	if err != nil {
+		return err
+	}
 
-		wg.Go(func() error {
-			return ctxticker.DoNow(ctx, relayDuration, func() error { return start(id) })
-		})
+	if path, err = r.call(ctx, conf, path, "start"); err != nil {
+		return err
+	}
+
+	return r.savePath(path)
+}
+
+// savePath saves the updated path to the config, other paths might be updated meanwhile so the
+// config is reloaded before saving.
+func (r Relayer) savePath(path relayerconf.Path) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return err
+	}
+
+	if err := conf.UpdatePath(path); err != nil {
+		return err
+	}
+
+	return relayerconf.Save(conf)
+}
+
+// updatePathStatus saves the result of the last attempt to relay the path, wait is the duration
+// until the next attempt.
+func (r Relayer) updatePathStatus(id string, relayErr error, wait time.Duration) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	status, err := relayerconf.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	pathStatus := status.PathStatusByID(id)
+	pathStatus.LastAttempt = now
+	pathStatus.NextAttempt = now.Add(wait)
+
+	if relayErr != nil {
+		pathStatus.LastError = relayErr.Error()
+		pathStatus.Failures++
+	} else {
+		pathStatus.LastSuccess = now
+		pathStatus.LastError = ""
+		pathStatus.Failures = 0
+	}
+
+	status.UpdatePath(pathStatus)
+
+	return relayerconf.SaveStatus(status)
+}
+
+// newRelayBackoff returns the backoff used to retry the paths that are failed to be relayed.
+func newRelayBackoff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = relayDuration
+	b.MaxInterval = maxRelayBackoff
+	b.MaxElapsedTime = maxRelayFailureTime
+	b.Reset()
+	return b
+}
+
+// Clear relays the pending packets and acknowledgements of the linked path once, including the ones
+// sent before the relayed heights kept for the path, so packets stuck by a relayer interrupted in
+// between are cleared.
+func (r Relayer) Clear(ctx context.Context, pathID string) error {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return err
+	}
+
+	path, err := conf.PathByID(pathID)
+	if err != nil {
+		return err
+	}
+	if path.Src.ChannelID == "" {
+		return fmt.Errorf("path %q is not linked yet", pathID)
+	}
+
+	// relay from the beginning of the channel.
+	path.Src.PacketHeight, path.Src.AckHeight = 0, 0
+	path.Dst.PacketHeight, path.Dst.AckHeight = 0, 0
+
+	if path, err = r.call(ctx, conf, path, "start"); err != nil {
+		return err
 	}
 
-	return wg.Wait()
+	return r.savePath(path)
 }
 
 func (r Relayer) call(ctx context.Context, conf relayerconf.Config, path relayerconf.Path, action string) (
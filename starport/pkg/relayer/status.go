@@ -0,0 +1,116 @@
+package relayer
+
+import (
+	"context"
+	"time"
+
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+// inactiveDuration is the duration after that a path is considered as not relayed anymore when
+// there is no attempt to relay it.
+const inactiveDuration = maxRelayBackoff * 2
+
+// Health is the health of a path.
+type Health string
+
+const (
+	// HealthHealthy is the health of the paths that are relayed without errors.
+	HealthHealthy Health = "healthy"
+
+	// HealthFailing is the health of the paths that their last attempts to relay are failed.
+	HealthFailing Health = "failing"
+
+	// HealthInactive is the health of the paths that are not being relayed, e.g. the relayer is
+	// not started.
+	HealthInactive Health = "inactive"
+)
+
+// PathStatus is the status of a path.
+type PathStatus struct {
+	// Path is the path with its last relayed heights.
+	Path relayerconf.Path
+
+	// Relay is the status of the attempts to relay the path.
+	Relay relayerconf.PathStatus
+
+	// Health of the path.
+	Health Health
+
+	// Pending are the packets not relayed yet, it's nil when the path is not linked or the pending
+	// packets cannot be queried.
+	Pending *PathPendingPackets
+
+	// PendingErr is the error of querying the pending packets.
+	PendingErr error
+}
+
+// Status returns the status of the paths with the counts of their pending packets.
+// when no paths are provided, the status of all paths are returned.
+func (r Relayer) Status(ctx context.Context, pathIDs ...string) ([]PathStatus, error) {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := relayerconf.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := conf.Paths
+	if len(pathIDs) > 0 {
+		paths = nil
+		for _, id := range pathIDs {
+			path, err := conf.PathByID(id)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	var statuses []PathStatus
+
+	for _, path := range paths {
+		pathStatus := PathStatus{
+			Path:  path,
+			Relay: status.PathStatusByID(path.ID),
+		}
+		pathStatus.Health = pathHealth(pathStatus.Relay, time.Now())
+
+		if path.Src.ChannelID != "" {
+			pending, err := r.PendingPackets(ctx, path.ID)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if err != nil {
+				pathStatus.PendingErr = err
+			} else {
+				pathStatus.Pending = &pending
+			}
+		}
+
+		statuses = append(statuses, pathStatus)
+	}
+
+	return statuses, nil
+}
+
+// pathHealth returns the health of a path from the status of its relaying attempts.
+func pathHealth(status relayerconf.PathStatus, now time.Time) Health {
+	switch {
+	case status.LastAttempt.IsZero():
+		return HealthInactive
+
+	// the next attempt is overdue, the relayer has been stopped.
+	case now.Sub(status.NextAttempt) > inactiveDuration:
+		return HealthInactive
+
+	case status.Failures > 0:
+		return HealthFailing
+
+	default:
+		return HealthHealthy
+	}
+}
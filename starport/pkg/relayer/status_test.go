@@ -0,0 +1,90 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/stretchr/testify/require"
+
+	relayerconf "github.com/tendermint/starport/starport/pkg/relayer/config"
+)
+
+func TestPathHealth(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		status relayerconf.PathStatus
+		health Health
+	}{
+		{
+			name:   "never relayed",
+			status: relayerconf.PathStatus{},
+			health: HealthInactive,
+		},
+		{
+			name: "relayed",
+			status: relayerconf.PathStatus{
+				LastAttempt: now.Add(-time.Second),
+				LastSuccess: now.Add(-time.Second),
+				NextAttempt: now.Add(relayDuration),
+			},
+			health: HealthHealthy,
+		},
+		{
+			name: "failing",
+			status: relayerconf.PathStatus{
+				LastAttempt: now.Add(-time.Second),
+				LastError:   "error",
+				Failures:    3,
+				NextAttempt: now.Add(maxRelayBackoff),
+			},
+			health: HealthFailing,
+		},
+		{
+			name: "stopped",
+			status: relayerconf.PathStatus{
+				LastAttempt: now.Add(-time.Hour),
+				LastSuccess: now.Add(-time.Hour),
+				NextAttempt: now.Add(-time.Hour).Add(relayDuration),
+			},
+			health: HealthInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.health, pathHealth(tt.status, now))
+		})
+	}
+}
+
+func TestRelayBackoff(t *testing.T) {
+	b := newRelayBackoff()
+
+	var last time.Duration
+	for i := 0; i < 20; i++ {
+		last = b.NextBackOff()
+		require.Greater(t, int64(last), int64(0))
+	}
+	// randomized intervals are capped around the max backoff.
+	require.LessOrEqual(t, int64(last), int64(maxRelayBackoff*3/2))
+
+	b.Reset()
+	require.LessOrEqual(t, int64(b.NextBackOff()), int64(relayDuration*3/2))
+
+	// the path is given up after failing for the max failure time.
+	clock := &testClock{now: time.Now()}
+	b.Clock = clock
+	b.Reset()
+	require.NotEqual(t, backoff.Stop, b.NextBackOff())
+	clock.now = clock.now.Add(maxRelayFailureTime + time.Second)
+	require.Equal(t, backoff.Stop, b.NextBackOff())
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
@@ -1,6 +1,7 @@
 package repoversion
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -120,3 +121,56 @@ func Determine(path string) (v Version, err error) {
 
 	return v, nil
 }
+
+// ErrNoTag is returned when the repository does not have any tags.
+var ErrNoTag = errors.New("no tag found in the repository")
+
+// LatestTag returns the name of the most recent tag of the repository at path,
+// tags are compared by the commit time of the commits that they are pointing to.
+func LatestTag(path string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", err
+	}
+
+	tags, err := repo.Tags()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		latest     string
+		latestTime int64
+	)
+
+	err = tags.ForEach(func(t *plumbing.Reference) error {
+		hash := t.Hash()
+
+		// resolve annotated tags to their commits.
+		if obj, err := repo.TagObject(hash); err == nil {
+			hash = obj.Target
+		}
+
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			// tags that are not pointing to a commit are ignored.
+			return nil
+		}
+
+		if when := commit.Committer.When.Unix(); latest == "" || when > latestTime {
+			latest = t.Name().Short()
+			latestTime = when
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if latest == "" {
+		return "", ErrNoTag
+	}
+
+	return latest, nil
+}
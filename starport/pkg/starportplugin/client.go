@@ -0,0 +1,221 @@
+package starportplugin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// startTimeout is the duration that a plugin has to write its handshake line after it's started.
+const startTimeout = time.Second * 10
+
+// Client is a running plugin.
+type Client struct {
+	path string
+
+	cmd        *exec.Cmd
+	rpc        *rpc.Client
+	stdoutDone chan struct{}
+
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+// Option configures the client of a plugin.
+type Option func(*Client)
+
+// Stdin sets the stdin of the plugin process, it's os.Stdin by default.
+func Stdin(r io.Reader) Option {
+	return func(c *Client) {
+		c.stdin = r
+	}
+}
+
+// Stdout sets the stdout that the outputs of the plugin process are written to, it's os.Stdout
+// by default.
+func Stdout(w io.Writer) Option {
+	return func(c *Client) {
+		c.stdout = w
+	}
+}
+
+// Stderr sets the stderr of the plugin process, it's os.Stderr by default.
+func Stderr(w io.Writer) Option {
+	return func(c *Client) {
+		c.stderr = w
+	}
+}
+
+// Start runs the plugin binary at path and connects to it, the plugin process is killed when ctx
+// is canceled. Close must be called to stop the plugin.
+func Start(ctx context.Context, path string, options ...Option) (*Client, error) {
+	c := &Client{
+		path:       path,
+		stdin:      os.Stdin,
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+		stdoutDone: make(chan struct{}),
+	}
+	for _, apply := range options {
+		apply(c)
+	}
+
+	c.cmd = exec.CommandContext(ctx, path)
+	c.cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", magicCookieKey, magicCookieValue))
+	c.cmd.Stdin = c.stdin
+	c.cmd.Stderr = c.stderr
+
+	stdout, err := c.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	network, address, err := readHandshake(ctx, bufio.NewReader(stdout), c.stdout, c.stdoutDone)
+	if err != nil {
+		_ = c.cmd.Process.Kill()
+		<-c.stdoutDone
+		_ = c.cmd.Wait()
+		return nil, fmt.Errorf("cannot start plugin %s: %w", path, err)
+	}
+
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		_ = c.cmd.Process.Kill()
+		<-c.stdoutDone
+		_ = c.cmd.Wait()
+		return nil, err
+	}
+	c.rpc = jsonrpc.NewClient(conn)
+
+	return c, nil
+}
+
+// readHandshake reads the handshake line of the plugin, the rest of the plugin's stdout is copied
+// to w until the plugin exits.
+func readHandshake(ctx context.Context, r *bufio.Reader, w io.Writer, done chan struct{}) (network, address string, err error) {
+	lines := make(chan string, 1)
+	go func() {
+		defer close(done)
+
+		line, _ := r.ReadString('\n')
+		lines <- line
+		_, _ = io.Copy(w, r)
+	}()
+
+	var line string
+	select {
+	case line = <-lines:
+	case <-time.After(startTimeout):
+		return "", "", errors.New("timeout waiting for the handshake")
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	}
+
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("invalid handshake %q, make sure that the plugin calls starportplugin.Serve", line)
+	}
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid protocol version %q", parts[0])
+	}
+	if version != ProtocolVersion {
+		return "", "", fmt.Errorf("protocol version %d is not supported, expected %d", version, ProtocolVersion)
+	}
+
+	return parts[1], parts[2], nil
+}
+
+// Path returns the path of the plugin binary.
+func (c *Client) Path() string {
+	return c.path
+}
+
+// Manifest returns the manifest of the plugin.
+func (c *Client) Manifest() (Manifest, error) {
+	var m Manifest
+	err := c.call("Manifest", struct{}{}, &m)
+	return m, err
+}
+
+// Execute runs a command of the plugin.
+func (c *Client) Execute(req ExecuteRequest) error {
+	return c.call("Execute", req, &struct{}{})
+}
+
+// Hook calls the hook of the plugin.
+func (c *Client) Hook(req HookRequest) error {
+	return c.call("Hook", req, &struct{}{})
+}
+
+func (c *Client) call(method string, args, reply interface{}) error {
+	err := c.rpc.Call(rpcName+"."+method, args, reply)
+
+	// the errors returned by the plugins are sent as texts.
+	var serverErr rpc.ServerError
+	if errors.As(err, &serverErr) {
+		return errors.New(string(serverErr))
+	}
+	return err
+}
+
+// Close stops the plugin, the plugin exits once the connection is closed.
+func (c *Client) Close() error {
+	err := c.rpc.Close()
+	<-c.stdoutDone
+	if waitErr := c.cmd.Wait(); waitErr != nil && err == nil {
+		err = waitErr
+	}
+	return err
+}
+
+// ReadManifest starts the plugin at path and returns its manifest.
+func ReadManifest(ctx context.Context, path string, options ...Option) (Manifest, error) {
+	c, err := Start(ctx, path, options...)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer c.Close()
+
+	return c.Manifest()
+}
+
+// Discover returns the paths of the plugin binaries in dir, the executable files are considered
+// as plugins. no plugins are returned when dir doesn't exist.
+func Discover(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0 {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
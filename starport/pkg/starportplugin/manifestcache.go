@@ -0,0 +1,98 @@
+package starportplugin
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/xfilepath"
+)
+
+// ManifestCachePath returns the path of the file that the manifests of the plugins are cached in.
+var ManifestCachePath = xfilepath.Join(chainconfig.ConfigDirPath, xfilepath.Path("plugins.json"))
+
+// ManifestCache caches the manifests of the plugins by the paths, the sizes and the modification
+// times of their binaries, a plugin is only started to read its manifest when its binary changes.
+type ManifestCache struct {
+	path    string
+	entries map[string]manifestCacheEntry
+	used    map[string]bool
+	changed bool
+}
+
+type manifestCacheEntry struct {
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"mod_time"`
+	Manifest Manifest  `json:"manifest"`
+}
+
+// OpenManifestCache opens the cache at path, the cache is empty when the file doesn't exist or
+// cannot be decoded.
+func OpenManifestCache(path string) (*ManifestCache, error) {
+	c := &ManifestCache{
+		path:    path,
+		entries: make(map[string]manifestCacheEntry),
+		used:    make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		c.entries = make(map[string]manifestCacheEntry)
+	}
+	return c, nil
+}
+
+// Manifest returns the manifest of the plugin at path from the cache, the manifest is read from
+// the plugin with ReadManifest when the binary has changed since it was cached.
+func (c *ManifestCache) Manifest(ctx context.Context, path string, options ...Option) (Manifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+	c.used[path] = true
+
+	entry, ok := c.entries[path]
+	if ok && entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime()) {
+		return entry.Manifest, nil
+	}
+
+	manifest, err := ReadManifest(ctx, path, options...)
+	if err != nil {
+		return Manifest{}, err
+	}
+	c.entries[path] = manifestCacheEntry{
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Manifest: manifest,
+	}
+	c.changed = true
+	return manifest, nil
+}
+
+// Save writes the cache to its file when it has changed, the entries of the plugins that are
+// not requested since the cache was opened are removed.
+func (c *ManifestCache) Save() error {
+	for path := range c.entries {
+		if !c.used[path] {
+			delete(c.entries, path)
+			c.changed = true
+		}
+	}
+	if !c.changed {
+		return nil
+	}
+
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
@@ -0,0 +1,130 @@
+// Package starportplugin provides the plugin system to extend Starport with commands and hooks
+// implemented by external binaries.
+//
+// A plugin is a Go binary that calls Serve with its implementation of Plugin. Starport runs the
+// plugin binaries found in the plugins directory, adds the commands of the plugins under starport
+// and calls their hooks before and after the commands that they hook into are run. the chain and
+// its config are sent to the plugins over RPC within each request.
+package starportplugin
+
+import (
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/xfilepath"
+)
+
+// ProtocolVersion is the version of the protocol between Starport and the plugins, plugins
+// of other versions are not loaded.
+const ProtocolVersion = 1
+
+// PluginsPath returns the path of the directory that the plugin binaries are installed in.
+var PluginsPath = xfilepath.Join(chainconfig.ConfigDirPath, xfilepath.Path("plugins"))
+
+// Plugin is implemented by the plugins.
+type Plugin interface {
+	// Manifest returns the commands and the hooks of the plugin.
+	Manifest() (Manifest, error)
+
+	// Execute runs a command of the plugin.
+	Execute(ExecuteRequest) error
+
+	// Hook is called before and after the commands that the plugin hooks into are run, an error
+	// returned before a command is run stops the command.
+	Hook(HookRequest) error
+}
+
+// Manifest describes a plugin.
+type Manifest struct {
+	// Name of the plugin.
+	Name string
+
+	// Commands are added under starport.
+	Commands []Command
+
+	// Hooks are the paths of the commands that the plugin hooks into, e.g. "chain serve". a hook
+	// into "scaffold" hooks into all of its sub commands.
+	Hooks []string
+}
+
+// Command is a command of a plugin.
+type Command struct {
+	// Use, Short and Long are the same with the ones of cobra commands.
+	Use   string
+	Short string
+	Long  string
+
+	// Parent is the path of the command that the command is added under, e.g. "chain", the command
+	// is added to starport itself when it's empty.
+	Parent string
+
+	// Flags of the command.
+	Flags []Flag
+}
+
+// Flag is a flag of a plugin command.
+type Flag struct {
+	Name      string
+	Shorthand string
+	Usage     string
+	Default   string
+
+	// Bool makes the flag a boolean flag, flags are strings otherwise.
+	Bool bool
+}
+
+// Chain is the chain that a command is run for.
+type Chain struct {
+	// AppPath is the path of the chain's source code.
+	AppPath string
+
+	// ConfigPath is the path of the chain's config, it's empty when the chain has no config.
+	ConfigPath string
+
+	// Config is the parsed config of the chain, it's nil when the chain has no config.
+	Config *chainconfig.Config
+}
+
+// ExecuteRequest is sent to run a command of a plugin.
+type ExecuteRequest struct {
+	// Command is the path of the command under starport, e.g. "chain hello".
+	Command string
+
+	// Args are the positional arguments of the command.
+	Args []string
+
+	// Flags are the values of the command's flags by their names.
+	Flags map[string]string
+
+	// Chain is the chain in the path of the command.
+	Chain Chain
+}
+
+// HookEvent is the event of a command that a hook is called for.
+type HookEvent string
+
+const (
+	// HookPre is the event before a command is run.
+	HookPre HookEvent = "pre"
+
+	// HookPost is the event after a command is run.
+	HookPost HookEvent = "post"
+)
+
+// HookRequest is sent to call a hook of a plugin.
+type HookRequest struct {
+	Event HookEvent
+
+	// Command is the path of the command under starport, e.g. "chain serve".
+	Command string
+
+	// Args are the positional arguments of the command.
+	Args []string
+
+	// Flags are the values of the command's flags that are set by their names.
+	Flags map[string]string
+
+	// Chain is the chain in the path of the command.
+	Chain Chain
+
+	// Error is the error of the command for the post hooks, it's empty when the command succeeded.
+	Error string
+}
@@ -0,0 +1,134 @@
+package starportplugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testPluginEnv makes the test binary serve testPlugin instead of running the tests.
+const testPluginEnv = "STARPORT_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testPluginEnv) != "" {
+		if err := Serve(testPlugin{}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type testPlugin struct{}
+
+func (testPlugin) Manifest() (Manifest, error) {
+	return Manifest{
+		Name:     "test",
+		Commands: []Command{{Use: "hello [name]", Parent: "chain"}},
+		Hooks:    []string{"chain serve"},
+	}, nil
+}
+
+func (testPlugin) Execute(req ExecuteRequest) error {
+	fmt.Printf("hello %s from %s\n", req.Args[0], req.Chain.AppPath)
+	return nil
+}
+
+func (testPlugin) Hook(req HookRequest) error {
+	if req.Event == HookPre && req.Flags["reset"] == "true" {
+		return errors.New("reset is not allowed")
+	}
+	return nil
+}
+
+func TestPlugin(t *testing.T) {
+	t.Setenv(testPluginEnv, "1")
+
+	ctx := context.Background()
+	stdout := &bytes.Buffer{}
+
+	manifest, err := ReadManifest(ctx, os.Args[0])
+	require.NoError(t, err)
+	require.Equal(t, "test", manifest.Name)
+	require.Equal(t, []string{"chain serve"}, manifest.Hooks)
+
+	client, err := Start(ctx, os.Args[0], Stdout(stdout))
+	require.NoError(t, err)
+
+	require.NoError(t, client.Execute(ExecuteRequest{
+		Command: "chain hello",
+		Args:    []string{"mars"},
+		Chain:   Chain{AppPath: "/mars"},
+	}))
+	require.NoError(t, client.Hook(HookRequest{Event: HookPre, Command: "chain serve"}))
+	require.EqualError(t, client.Hook(HookRequest{
+		Event:   HookPre,
+		Command: "chain serve",
+		Flags:   map[string]string{"reset": "true"},
+	}), "reset is not allowed")
+
+	require.NoError(t, client.Close())
+	require.Equal(t, "hello mars from /mars\n", stdout.String())
+}
+
+func TestServeNotRunByStarport(t *testing.T) {
+	require.Equal(t, ErrNotRunByStarport, Serve(testPlugin{}))
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "readme.md"), nil, 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "c"), 0755))
+
+	paths, err := Discover(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "a"), filepath.Join(dir, "b")}, paths)
+
+	paths, err = Discover(filepath.Join(dir, "none"))
+	require.NoError(t, err)
+	require.Empty(t, paths)
+}
+
+func TestManifestCache(t *testing.T) {
+	t.Setenv(testPluginEnv, "1")
+
+	var (
+		dir       = t.TempDir()
+		path      = filepath.Join(dir, "test")
+		cachePath = filepath.Join(dir, "plugins.json")
+	)
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf("#!/bin/sh\nexec %q\n", os.Args[0])), 0755))
+
+	cache, err := OpenManifestCache(cachePath)
+	require.NoError(t, err)
+	manifest, err := cache.Manifest(context.Background(), path)
+	require.NoError(t, err)
+	require.Equal(t, "test", manifest.Name)
+	require.NoError(t, cache.Save())
+
+	// the cached manifest is returned without starting the plugin.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cache, err = OpenManifestCache(cachePath)
+	require.NoError(t, err)
+	manifest, err = cache.Manifest(canceled, path)
+	require.NoError(t, err)
+	require.Equal(t, "test", manifest.Name)
+
+	// the plugin is started again once its binary changes.
+	modTime := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	_, err = cache.Manifest(canceled, path)
+	require.Error(t, err)
+}
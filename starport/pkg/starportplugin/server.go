@@ -0,0 +1,73 @@
+package starportplugin
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+)
+
+const (
+	// magicCookieKey and magicCookieValue are set in the environment of the plugins by Starport,
+	// so the plugin binaries can tell that they are not run directly by users.
+	magicCookieKey   = "STARPORT_PLUGIN_MAGIC_COOKIE"
+	magicCookieValue = "a8b1f7e06c4d4c2a9f3e5d7b2c1a0f9e"
+
+	// rpcName is the name of the RPC service served by the plugins.
+	rpcName = "Plugin"
+)
+
+// ErrNotRunByStarport is returned by Serve when the plugin binary is run directly.
+var ErrNotRunByStarport = errors.New("this binary is a Starport plugin, install it in the plugins directory of Starport to use it")
+
+// Serve serves the plugin to Starport, it's called by the main function of the plugin binaries.
+// the plugin listens on a local address that is written to stdout and serves a single connection
+// of Starport, stdout and stderr can be used by the plugin to print its outputs after that.
+func Serve(p Plugin) error {
+	if os.Getenv(magicCookieKey) != magicCookieValue {
+		return ErrNotRunByStarport
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(rpcName, &rpcServer{p}); err != nil {
+		return err
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	// the handshake line tells Starport the version of the protocol and the address to connect to.
+	fmt.Printf("%d|%s|%s\n", ProtocolVersion, l.Addr().Network(), l.Addr().String())
+
+	conn, err := l.Accept()
+	if err != nil {
+		return err
+	}
+
+	// the plugin exits when Starport closes the connection.
+	server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	return nil
+}
+
+// rpcServer exposes the plugin with the method signatures of net/rpc.
+type rpcServer struct {
+	p Plugin
+}
+
+func (s *rpcServer) Manifest(_ struct{}, reply *Manifest) (err error) {
+	*reply, err = s.p.Manifest()
+	return err
+}
+
+func (s *rpcServer) Execute(req ExecuteRequest, _ *struct{}) error {
+	return s.p.Execute(req)
+}
+
+func (s *rpcServer) Hook(req HookRequest, _ *struct{}) error {
+	return s.p.Hook(req)
+}
@@ -0,0 +1,210 @@
+package starportsdk
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+// Chain is a chain whose source code is on the local filesystem.
+type Chain struct {
+	chain  *chain.Chain
+	events eventForwarder
+}
+
+type chainOptions struct {
+	home           string
+	id             string
+	keyringBackend string
+	configFile     string
+	profile        string
+	verbose        bool
+	events         chan<- Event
+}
+
+// ChainOption configures a chain.
+type ChainOption func(*chainOptions)
+
+// ChainHome sets the home directory of the chain's node.
+func ChainHome(path string) ChainOption {
+	return func(o *chainOptions) {
+		o.home = path
+	}
+}
+
+// ChainID sets the id of the chain.
+func ChainID(id string) ChainOption {
+	return func(o *chainOptions) {
+		o.id = id
+	}
+}
+
+// ChainKeyringBackend sets the keyring backend of the chain, it's test by default.
+func ChainKeyringBackend(backend string) ChainOption {
+	return func(o *chainOptions) {
+		o.keyringBackend = backend
+	}
+}
+
+// ChainConfigFile sets the path of the chain's config, config.yml of the chain is used by default.
+func ChainConfigFile(path string) ChainOption {
+	return func(o *chainOptions) {
+		o.configFile = path
+	}
+}
+
+// ChainProfile sets the profile of the chain's config that is applied.
+func ChainProfile(name string) ChainOption {
+	return func(o *chainOptions) {
+		o.profile = name
+	}
+}
+
+// ChainVerbose makes the outputs of the chain's binary printed.
+func ChainVerbose() ChainOption {
+	return func(o *chainOptions) {
+		o.verbose = true
+	}
+}
+
+// ChainEvents sets the channel that the events of the chain's operations are sent to. the channel
+// is expected to be read until the chain is closed.
+func ChainEvents(ch chan<- Event) ChainOption {
+	return func(o *chainOptions) {
+		o.events = ch
+	}
+}
+
+// OpenChain opens the chain whose source code is at path.
+func OpenChain(path string, options ...ChainOption) (*Chain, error) {
+	o := chainOptions{
+		keyringBackend: string(chaincmd.KeyringBackendTest),
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := chaincmd.KeyringBackendFromString(o.keyringBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	logLevel := chain.LogSilent
+	if o.verbose {
+		logLevel = chain.LogVerbose
+	}
+
+	f := forwardEvents(o.events)
+
+	chainOptions := []chain.Option{
+		chain.LogLevel(logLevel),
+		chain.KeyringBackend(backend),
+		chain.CollectEvents(f.bus),
+	}
+	if o.home != "" {
+		chainOptions = append(chainOptions, chain.HomePath(o.home))
+	}
+	if o.id != "" {
+		chainOptions = append(chainOptions, chain.ID(o.id))
+	}
+	if o.configFile != "" {
+		chainOptions = append(chainOptions, chain.ConfigFile(o.configFile))
+	}
+	if o.profile != "" {
+		chainOptions = append(chainOptions, chain.ConfigProfile(o.profile))
+	}
+
+	c, err := chain.New(absPath, chainOptions...)
+	if err != nil {
+		f.close()
+		return nil, err
+	}
+
+	return &Chain{chain: c, events: f}, nil
+}
+
+// ID returns the id of the chain.
+func (c *Chain) ID() (string, error) {
+	return c.chain.ID()
+}
+
+// Home returns the home directory of the chain's node.
+func (c *Chain) Home() (string, error) {
+	return c.chain.Home()
+}
+
+// Build builds the chain's binary and installs it, it's placed in output instead when output
+// isn't empty.
+func (c *Chain) Build(ctx context.Context, output string) (binaryName string, err error) {
+	return c.chain.Build(ctx, output)
+}
+
+// Init initializes the chain's home and its accounts and validators defined in the config.
+// the chain must be built before.
+func (c *Chain) Init(ctx context.Context) error {
+	return c.chain.Init(ctx, true)
+}
+
+type serveOptions struct {
+	forceReset bool
+	resetOnce  bool
+	grpcUI     bool
+}
+
+// ServeOption configures serving a chain.
+type ServeOption func(*serveOptions)
+
+// ServeForceReset resets the state of the chain when it's served and on every source change.
+func ServeForceReset() ServeOption {
+	return func(o *serveOptions) {
+		o.forceReset = true
+	}
+}
+
+// ServeResetOnce resets the state of the chain when it's served.
+func ServeResetOnce() ServeOption {
+	return func(o *serveOptions) {
+		o.resetOnce = true
+	}
+}
+
+// ServeGRPCUI starts a web UI for the gRPC services of the chain.
+func ServeGRPCUI() ServeOption {
+	return func(o *serveOptions) {
+		o.grpcUI = true
+	}
+}
+
+// Serve builds, initializes and starts the chain and rebuilds it on every source change until
+// ctx is canceled.
+func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
+	var o serveOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	var serveOptions []chain.ServeOption
+	if o.forceReset {
+		serveOptions = append(serveOptions, chain.ServeForceReset())
+	}
+	if o.resetOnce {
+		serveOptions = append(serveOptions, chain.ServeResetOnce())
+	}
+	if o.grpcUI {
+		serveOptions = append(serveOptions, chain.ServeGRPCUI())
+	}
+
+	return c.chain.Serve(ctx, serveOptions...)
+}
+
+// Close releases the chain, the events of the chain are no longer sent after it returns.
+func (c *Chain) Close() {
+	c.events.close()
+}
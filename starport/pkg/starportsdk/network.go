@@ -0,0 +1,487 @@
+package starportsdk
+
+import (
+	"context"
+	"os"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	"github.com/tendermint/starport/starport/services/chain"
+	"github.com/tendermint/starport/starport/services/network"
+	"github.com/tendermint/starport/starport/services/network/networkchain"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+const (
+	// DefaultNetworkNodeAddress is the address of the node of the public SPN network.
+	DefaultNetworkNodeAddress = "https://rpc.alpha.starport.network:443"
+
+	// DefaultNetworkFaucetAddress is the address of the faucet of the public SPN network.
+	DefaultNetworkFaucetAddress = "https://faucet.alpha.starport.network"
+)
+
+// Network is a connection to SPN, the network that chains are launched collaboratively on.
+type Network struct {
+	cosmos  cosmosclient.Client
+	account string
+	network network.Network
+	events  eventForwarder
+}
+
+type networkOptions struct {
+	nodeAddress    string
+	faucetAddress  string
+	keyringBackend string
+	account        string
+	events         chan<- Event
+}
+
+// NetworkOption configures a network.
+type NetworkOption func(*networkOptions)
+
+// NetworkNodeAddress sets the address of the SPN node, it's DefaultNetworkNodeAddress by default.
+func NetworkNodeAddress(address string) NetworkOption {
+	return func(o *networkOptions) {
+		o.nodeAddress = address
+	}
+}
+
+// NetworkFaucetAddress sets the address of the SPN faucet that funds the account, it's
+// DefaultNetworkFaucetAddress by default.
+func NetworkFaucetAddress(address string) NetworkOption {
+	return func(o *networkOptions) {
+		o.faucetAddress = address
+	}
+}
+
+// NetworkKeyringBackend sets the keyring backend of the accounts, it's os by default.
+func NetworkKeyringBackend(backend string) NetworkOption {
+	return func(o *networkOptions) {
+		o.keyringBackend = backend
+	}
+}
+
+// NetworkAccount sets the name of the account that sends the transactions to SPN, the default
+// account is used by default and it's created when it doesn't exist.
+func NetworkAccount(name string) NetworkOption {
+	return func(o *networkOptions) {
+		o.account = name
+	}
+}
+
+// NetworkEvents sets the channel that the events of the network's operations are sent to. the
+// channel is expected to be read until the network is closed.
+func NetworkEvents(ch chan<- Event) NetworkOption {
+	return func(o *networkOptions) {
+		o.events = ch
+	}
+}
+
+// ConnectNetwork connects to SPN with the account that sends the transactions.
+func ConnectNetwork(ctx context.Context, options ...NetworkOption) (*Network, error) {
+	o := networkOptions{
+		nodeAddress:    DefaultNetworkNodeAddress,
+		faucetAddress:  DefaultNetworkFaucetAddress,
+		keyringBackend: string(cosmosaccount.KeyringOS),
+		account:        cosmosaccount.DefaultAccount,
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	cosmos, err := cosmosclient.New(ctx,
+		cosmosclient.WithHome(cosmosaccount.KeyringHome),
+		cosmosclient.WithNodeAddress(o.nodeAddress),
+		cosmosclient.WithAddressPrefix(networktypes.SPN),
+		cosmosclient.WithUseFaucet(o.faucetAddress, networktypes.SPNDenom, 5),
+		cosmosclient.WithKeyringServiceName(cosmosaccount.KeyringServiceName),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringBackend(o.keyringBackend)),
+		cosmosclient.WithAutoFees(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cosmos.AccountRegistry.EnsureDefaultAccount(); err != nil {
+		return nil, err
+	}
+
+	account, err := cosmos.AccountRegistry.GetByName(o.account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot use account %s", o.account)
+	}
+
+	f := forwardEvents(o.events)
+
+	n, err := network.New(network.NewCosmosClient(cosmos), account, network.CollectEvents(f.bus))
+	if err != nil {
+		f.close()
+		return nil, err
+	}
+
+	return &Network{
+		cosmos:  cosmos,
+		account: o.account,
+		network: n,
+		events:  f,
+	}, nil
+}
+
+type publishOptions struct {
+	branch     string
+	tag        string
+	hash       string
+	ref        string
+	path       string
+	genesisURL string
+	chainID    string
+	campaignID uint64
+	noCheck    bool
+}
+
+// PublishOption configures publishing a chain.
+type PublishOption func(*publishOptions)
+
+// PublishBranch publishes the chain from the branch of its repository.
+func PublishBranch(branch string) PublishOption {
+	return func(o *publishOptions) {
+		o.branch = branch
+	}
+}
+
+// PublishTag publishes the chain from the tag of its repository.
+func PublishTag(tag string) PublishOption {
+	return func(o *publishOptions) {
+		o.tag = tag
+	}
+}
+
+// PublishHash publishes the chain from the commit hash of its repository.
+func PublishHash(hash string) PublishOption {
+	return func(o *publishOptions) {
+		o.hash = hash
+	}
+}
+
+// PublishRef publishes the chain from a ref of its repository, the ref can be a tag, a branch or a
+// commit and it's resolved to the hash of its commit.
+func PublishRef(ref string) PublishOption {
+	return func(o *publishOptions) {
+		o.ref = ref
+	}
+}
+
+// PublishPath sets the path of the chain in its repository, for chains in monorepos.
+func PublishPath(path string) PublishOption {
+	return func(o *publishOptions) {
+		o.path = path
+	}
+}
+
+// PublishGenesisURL sets the URL of a custom genesis of the chain.
+func PublishGenesisURL(url string) PublishOption {
+	return func(o *publishOptions) {
+		o.genesisURL = url
+	}
+}
+
+// PublishChainID sets the id of the chain that is launched.
+func PublishChainID(id string) PublishOption {
+	return func(o *publishOptions) {
+		o.chainID = id
+	}
+}
+
+// PublishCampaign publishes the chain for an existing campaign.
+func PublishCampaign(id uint64) PublishOption {
+	return func(o *publishOptions) {
+		o.campaignID = id
+	}
+}
+
+// PublishNoCheck skips verifying the integrity of the chain.
+func PublishNoCheck() PublishOption {
+	return func(o *publishOptions) {
+		o.noCheck = true
+	}
+}
+
+// Publish publishes the chain whose source code is in the repository at sourceURL to start a new
+// network and returns the ids of its launch and campaign.
+func (n *Network) Publish(ctx context.Context, sourceURL string, options ...PublishOption) (launchID, campaignID uint64, err error) {
+	var o publishOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	var source networkchain.SourceOption
+	switch {
+	case o.ref != "":
+		source = networkchain.SourceRemoteRef(sourceURL, o.ref)
+	case o.tag != "":
+		source = networkchain.SourceRemoteTag(sourceURL, o.tag)
+	case o.branch != "":
+		source = networkchain.SourceRemoteBranch(sourceURL, o.branch)
+	case o.hash != "":
+		source = networkchain.SourceRemoteHash(sourceURL, o.hash)
+	default:
+		source = networkchain.SourceRemote(sourceURL)
+	}
+
+	// the chain is initialized in a temporary home to be verified.
+	home, err := os.MkdirTemp("", "")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.RemoveAll(home)
+
+	chainOptions := []networkchain.Option{
+		networkchain.WithHome(home),
+		networkchain.CollectEvents(n.events.bus),
+	}
+	var networkOptions []network.PublishOption
+
+	if o.path != "" {
+		chainOptions = append(chainOptions, networkchain.WithSourcePath(o.path))
+	}
+	if o.genesisURL != "" {
+		chainOptions = append(chainOptions, networkchain.WithGenesisFromURL(o.genesisURL))
+		networkOptions = append(networkOptions, network.WithCustomGenesis(o.genesisURL))
+	}
+	if o.campaignID != 0 {
+		networkOptions = append(networkOptions, network.WithCampaign(o.campaignID))
+	}
+	if o.chainID != "" {
+		networkOptions = append(networkOptions, network.WithChainID(o.chainID))
+	}
+
+	c, err := networkchain.New(ctx, n.cosmos.AccountRegistry, source, chainOptions...)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if o.noCheck {
+		networkOptions = append(networkOptions, network.WithNoCheck())
+	} else if err := c.Init(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	return n.network.Publish(ctx, c, networkOptions...)
+}
+
+type campaignOptions struct {
+	totalSupply string
+	allocations []string
+}
+
+// CampaignOption configures creating a campaign.
+type CampaignOption func(*campaignOptions)
+
+// CampaignTotalSupply sets the total supply of the campaign, e.g. "1000000foo,500bar".
+func CampaignTotalSupply(totalSupply string) CampaignOption {
+	return func(o *campaignOptions) {
+		o.totalSupply = totalSupply
+	}
+}
+
+// CampaignAllocation allocates the shares of the campaign to the address, e.g. "1000foo".
+func CampaignAllocation(address, shares string) CampaignOption {
+	return func(o *campaignOptions) {
+		o.allocations = append(o.allocations, address+"="+shares)
+	}
+}
+
+// CreateCampaign creates a new campaign coordinated by the account and returns its id.
+func (n *Network) CreateCampaign(ctx context.Context, name string, options ...CampaignOption) (campaignID uint64, err error) {
+	var o campaignOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	var campaignOptions []network.CampaignOption
+	if o.totalSupply != "" {
+		totalSupply, err := sdk.ParseCoinsNormalized(o.totalSupply)
+		if err != nil {
+			return 0, errors.Wrap(err, "error parsing total supply")
+		}
+		campaignOptions = append(campaignOptions, network.WithTotalSupply(totalSupply))
+	}
+	for _, allocationStr := range o.allocations {
+		allocation, err := network.ParseAllocation(allocationStr, nil)
+		if err != nil {
+			return 0, err
+		}
+		campaignOptions = append(campaignOptions, network.WithAllocations(allocation))
+	}
+
+	return n.network.CreateCampaign(ctx, name, campaignOptions...)
+}
+
+type joinOptions struct {
+	gentxPath     string
+	home          string
+	nodeHome      string
+	publicAddress string
+	binary        *networkchain.BinaryDownload
+}
+
+// JoinOption configures joining a network.
+type JoinOption func(*joinOptions)
+
+// JoinGentx joins with the gentx at path instead of generating one.
+func JoinGentx(path string) JoinOption {
+	return func(o *joinOptions) {
+		o.gentxPath = path
+	}
+}
+
+// JoinHome sets the home directory of the validator's node, it's in the Starport's directory of
+// the launch by default.
+func JoinHome(path string) JoinOption {
+	return func(o *joinOptions) {
+		o.home = path
+	}
+}
+
+// JoinNodeHome generates the gentx with the keys and the moniker of the existing node's home at
+// path.
+func JoinNodeHome(path string) JoinOption {
+	return func(o *joinOptions) {
+		o.nodeHome = path
+	}
+}
+
+// JoinPublicAddress sets the address that the peers connect to the validator's node at, it's
+// detected from the node's config and the public IP of the machine by default.
+func JoinPublicAddress(address string) JoinOption {
+	return func(o *joinOptions) {
+		o.publicAddress = address
+	}
+}
+
+// JoinBinary downloads the prebuilt binary of the chain from url instead of building the chain,
+// the binary is verified against its sha256 checksum.
+func JoinBinary(url, checksum string) JoinOption {
+	return func(o *joinOptions) {
+		o.binary = &networkchain.BinaryDownload{URL: url, Checksum: checksum}
+	}
+}
+
+// Join requests to join the launch as a validator with the bonded amount, e.g. "100000000stake".
+// the gentx is generated by initializing the chain for the launch beforehand unless the gentx or
+// an existing node's home is given.
+func (n *Network) Join(ctx context.Context, launchID uint64, amount string, options ...JoinOption) error {
+	var o joinOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	coin, err := sdk.ParseCoinNormalized(amount)
+	if err != nil {
+		return errors.Wrap(err, "error parsing amount")
+	}
+
+	chainLaunch, err := n.network.ChainLaunch(ctx, launchID)
+	if err != nil {
+		return err
+	}
+
+	chainOptions := []networkchain.Option{
+		networkchain.CollectEvents(n.events.bus),
+	}
+	if o.home != "" {
+		chainOptions = append(chainOptions, networkchain.WithHome(o.home))
+	}
+	if o.binary != nil {
+		chainOptions = append(chainOptions, networkchain.WithBinaryDownload(o.binary.URL, o.binary.Checksum))
+	}
+
+	c, err := networkchain.New(ctx, n.cosmos.AccountRegistry, networkchain.SourceLaunch(chainLaunch), chainOptions...)
+	if err != nil {
+		return err
+	}
+
+	var joinOptions []network.JoinOption
+	if o.gentxPath != "" {
+		joinOptions = append(joinOptions, network.WithCustomGentxPath(o.gentxPath))
+	}
+	if o.publicAddress != "" {
+		joinOptions = append(joinOptions, network.WithPublicAddress(o.publicAddress))
+	}
+
+	if o.nodeHome != "" {
+		nodeHome, err := networkchain.ReadNodeHome(o.nodeHome)
+		if err != nil {
+			return err
+		}
+
+		v := chain.Validator{
+			Name:          n.account,
+			StakingAmount: coin.String(),
+			GasPrices:     "0" + coin.Denom,
+		}
+		if _, err := c.InitFromNodeHome(ctx, nodeHome, v, n.account); err != nil {
+			return err
+		}
+	}
+
+	return n.network.Join(ctx, c, launchID, coin, joinOptions...)
+}
+
+type trackOptions struct {
+	interval time.Duration
+	webhooks []string
+}
+
+// TrackOption configures tracking a request.
+type TrackOption func(*trackOptions)
+
+// TrackInterval sets the duration between the checks of the request's status.
+func TrackInterval(interval time.Duration) TrackOption {
+	return func(o *trackOptions) {
+		o.interval = interval
+	}
+}
+
+// TrackWebhook notifies the URL with the request's record as JSON once the request is settled.
+func TrackWebhook(url string) TrackOption {
+	return func(o *trackOptions) {
+		o.webhooks = append(o.webhooks, url)
+	}
+}
+
+// RequestStatus returns the status of the request of the launch, it's pending, approved or
+// rejected.
+func (n *Network) RequestStatus(ctx context.Context, launchID, requestID uint64) (string, error) {
+	record, err := n.network.RequestStatus(ctx, launchID, requestID)
+	return string(record.Status), err
+}
+
+// TrackRequest waits for the request of the launch to be approved or rejected and returns its
+// status.
+func (n *Network) TrackRequest(ctx context.Context, launchID, requestID uint64, options ...TrackOption) (string, error) {
+	var o trackOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	var trackOptions []network.TrackOption
+	if o.interval != 0 {
+		trackOptions = append(trackOptions, network.WithTrackInterval(o.interval))
+	}
+	for _, url := range o.webhooks {
+		trackOptions = append(trackOptions, network.WithWebhook(url))
+	}
+
+	record, err := n.network.TrackRequest(ctx, launchID, requestID, trackOptions...)
+	return string(record.Status), err
+}
+
+// Close releases the network, the events of the network are no longer sent after it returns.
+func (n *Network) Close() {
+	n.events.close()
+}
@@ -0,0 +1,349 @@
+package starportsdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tendermint/starport/starport/pkg/chaintemplate"
+	"github.com/tendermint/starport/starport/pkg/cosmosver"
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/pkg/xgenny"
+	"github.com/tendermint/starport/starport/services/scaffolder"
+)
+
+// Changes are the files of a chain's source code that are changed by scaffolding.
+type Changes struct {
+	Created  []string
+	Modified []string
+}
+
+func newChanges(sm xgenny.SourceModification) Changes {
+	c := Changes{
+		Created:  sm.CreatedFiles(),
+		Modified: sm.ModifiedFiles(),
+	}
+	sort.Strings(c.Created)
+	sort.Strings(c.Modified)
+	return c
+}
+
+type scaffoldChainOptions struct {
+	addressPrefix   string
+	noDefaultModule bool
+	template        string
+	templateValues  map[string]string
+}
+
+// ScaffoldChainOption configures scaffolding a chain.
+type ScaffoldChainOption func(*scaffoldChainOptions)
+
+// AddressPrefix sets the prefix of the chain's account addresses, it's cosmos by default.
+func AddressPrefix(prefix string) ScaffoldChainOption {
+	return func(o *scaffoldChainOptions) {
+		o.addressPrefix = prefix
+	}
+}
+
+// NoDefaultModule prevents scaffolding a default module in the chain.
+func NoDefaultModule() ScaffoldChainOption {
+	return func(o *scaffoldChainOptions) {
+		o.noDefaultModule = true
+	}
+}
+
+// ChainTemplate scaffolds the chain from the template in a local directory or a git repository at
+// source, values are the values of the variables declared by the template.
+func ChainTemplate(source string, values map[string]string) ScaffoldChainOption {
+	return func(o *scaffoldChainOptions) {
+		o.template = source
+		o.templateValues = values
+	}
+}
+
+// ScaffoldChain scaffolds a new chain named after the Go module path name, e.g. github.com/org/repo,
+// in the directory at path and returns the path of its source code.
+func ScaffoldChain(path, name string, options ...ScaffoldChainOption) (appPath string, err error) {
+	o := scaffoldChainOptions{
+		addressPrefix: "cosmos",
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	var initOptions []scaffolder.InitOption
+	if o.template != "" {
+		t, err := chaintemplate.Open(context.Background(), o.template)
+		if err != nil {
+			return "", err
+		}
+		defer t.Close()
+
+		initOptions = append(initOptions, scaffolder.FromTemplate(t, o.templateValues))
+	}
+
+	return scaffolder.Init(placeholder.New(), path, name, o.addressPrefix, o.noDefaultModule, initOptions...)
+}
+
+type scaffoldOptions struct {
+	module            string
+	fields            []string
+	response          []string
+	description       string
+	signer            string
+	withoutMessage    bool
+	withoutSimulation bool
+	paginated         bool
+	ibc               bool
+	ordering          string
+	cliFlags          bool
+	cliDefaults       map[string]string
+	autoCLI           bool
+}
+
+// ScaffoldOption configures scaffolding a component of a chain, the options that don't apply to
+// the component are ignored.
+type ScaffoldOption func(*scaffoldOptions)
+
+// InModule sets the module that the component is added into, the chain's default module is used
+// by default.
+func InModule(name string) ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.module = name
+	}
+}
+
+// Fields sets the fields of a type, a message or a query request, e.g. "title", "count:uint".
+func Fields(fields ...string) ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.fields = fields
+	}
+}
+
+// ResponseFields sets the fields of the response of a message or a query.
+func ResponseFields(fields ...string) ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.response = fields
+	}
+}
+
+// Description sets the description of the CLI command of a message or a query.
+func Description(description string) ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.description = description
+	}
+}
+
+// Signer sets the name of the signer field of the messages, it's creator by default.
+func Signer(signer string) ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.signer = signer
+	}
+}
+
+// WithoutMessage prevents scaffolding the messages of a type.
+func WithoutMessage() ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.withoutMessage = true
+	}
+}
+
+// WithoutSimulation prevents scaffolding the simulation of the messages of a type or a message.
+func WithoutSimulation() ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.withoutSimulation = true
+	}
+}
+
+// Paginated makes a query paginated.
+func Paginated() ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.paginated = true
+	}
+}
+
+// IBC makes a module an IBC module whose channels have the ordering, e.g. "none", "ordered"
+// or "unordered".
+func IBC(ordering string) ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.ibc = true
+		o.ordering = ordering
+	}
+}
+
+// CLIFlags makes the CLI command of a message or a query read the fields from flags instead of
+// positional args, defaults are the default values of the flags by the names of the fields.
+func CLIFlags(defaults map[string]string) ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.cliFlags = true
+		o.cliDefaults = defaults
+	}
+}
+
+// AutoCLI makes the CLI commands of a module generated from the methods of its services.
+func AutoCLI() ScaffoldOption {
+	return func(o *scaffoldOptions) {
+		o.autoCLI = true
+	}
+}
+
+func newScaffoldOptions(options []ScaffoldOption) scaffoldOptions {
+	var o scaffoldOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+	return o
+}
+
+// TypeKind is the kind of storage of a type.
+type TypeKind scaffolder.AddTypeKind
+
+// TypeList stores a type in a list.
+func TypeList() TypeKind {
+	return TypeKind(scaffolder.ListType())
+}
+
+// TypeMap stores a type in a key-value store with the index fields.
+func TypeMap(indexes ...string) TypeKind {
+	return TypeKind(scaffolder.MapType(indexes...))
+}
+
+// TypeSingleton stores a single instance of a type.
+func TypeSingleton() TypeKind {
+	return TypeKind(scaffolder.SingletonType())
+}
+
+// TypeNoStorage scaffolds a type without any storage, messages or CLI commands.
+func TypeNoStorage() TypeKind {
+	return TypeKind(scaffolder.DryType())
+}
+
+// ScaffoldModule adds a new module to the chain at appPath, the fields are the params of the
+// module.
+func ScaffoldModule(appPath, name string, options ...ScaffoldOption) (Changes, error) {
+	o := newScaffoldOptions(options)
+
+	sc, err := openScaffolder(appPath)
+	if err != nil {
+		return Changes{}, err
+	}
+
+	var moduleOptions []scaffolder.ModuleCreationOption
+	if len(o.fields) > 0 {
+		moduleOptions = append(moduleOptions, scaffolder.WithParams(o.fields))
+	}
+	if o.ibc {
+		moduleOptions = append(moduleOptions, scaffolder.WithIBC(), scaffolder.WithIBCChannelOrdering(o.ordering))
+	}
+	if o.autoCLI {
+		moduleOptions = append(moduleOptions, scaffolder.WithAutoCLI())
+	}
+
+	sm, err := sc.CreateModule(placeholder.New(), name, moduleOptions...)
+	if err != nil {
+		return Changes{}, err
+	}
+	return newChanges(sm), nil
+}
+
+// ScaffoldType adds a new type with the kind of storage to the chain at appPath.
+func ScaffoldType(ctx context.Context, appPath, name string, kind TypeKind, options ...ScaffoldOption) (Changes, error) {
+	o := newScaffoldOptions(options)
+
+	sc, err := openScaffolder(appPath)
+	if err != nil {
+		return Changes{}, err
+	}
+
+	var typeOptions []scaffolder.AddTypeOption
+	if len(o.fields) > 0 {
+		typeOptions = append(typeOptions, scaffolder.TypeWithFields(o.fields...))
+	}
+	if o.module != "" {
+		typeOptions = append(typeOptions, scaffolder.TypeWithModule(o.module))
+	}
+	if o.withoutMessage {
+		typeOptions = append(typeOptions, scaffolder.TypeWithoutMessage())
+	} else {
+		if o.signer != "" {
+			typeOptions = append(typeOptions, scaffolder.TypeWithSigner(o.signer))
+		}
+		if o.withoutSimulation {
+			typeOptions = append(typeOptions, scaffolder.TypeWithoutSimulation())
+		}
+	}
+
+	sm, err := sc.AddType(ctx, name, placeholder.New(), scaffolder.AddTypeKind(kind), typeOptions...)
+	if err != nil {
+		return Changes{}, err
+	}
+	return newChanges(sm), nil
+}
+
+// ScaffoldMessage adds a new message to the chain at appPath.
+func ScaffoldMessage(ctx context.Context, appPath, name string, options ...ScaffoldOption) (Changes, error) {
+	o := newScaffoldOptions(options)
+
+	sc, err := openScaffolder(appPath)
+	if err != nil {
+		return Changes{}, err
+	}
+
+	var messageOptions []scaffolder.MessageOption
+	if o.description != "" {
+		messageOptions = append(messageOptions, scaffolder.WithDescription(o.description))
+	}
+	if o.signer != "" {
+		messageOptions = append(messageOptions, scaffolder.WithSigner(o.signer))
+	}
+	if o.withoutSimulation {
+		messageOptions = append(messageOptions, scaffolder.WithoutSimulation())
+	}
+	if o.cliFlags {
+		messageOptions = append(messageOptions, scaffolder.WithCLIFlags(o.cliDefaults))
+	}
+
+	sm, err := sc.AddMessage(ctx, placeholder.New(), o.module, name, o.fields, o.response, messageOptions...)
+	if err != nil {
+		return Changes{}, err
+	}
+	return newChanges(sm), nil
+}
+
+// ScaffoldQuery adds a new query to the chain at appPath.
+func ScaffoldQuery(ctx context.Context, appPath, name string, options ...ScaffoldOption) (Changes, error) {
+	o := newScaffoldOptions(options)
+	if o.description == "" {
+		o.description = fmt.Sprintf("Query %s", name)
+	}
+
+	sc, err := openScaffolder(appPath)
+	if err != nil {
+		return Changes{}, err
+	}
+
+	var queryOptions []scaffolder.QueryOption
+	if o.cliFlags {
+		queryOptions = append(queryOptions, scaffolder.QueryWithCLIFlags(o.cliDefaults))
+	}
+
+	sm, err := sc.AddQuery(ctx, placeholder.New(), o.module, name, o.description, o.fields, o.response, o.paginated, queryOptions...)
+	if err != nil {
+		return Changes{}, err
+	}
+	return newChanges(sm), nil
+}
+
+// openScaffolder opens the chain at appPath for scaffolding, the chains scaffolded with the Cosmos
+// SDK versions older than v0.44 must be migrated first.
+func openScaffolder(appPath string) (scaffolder.Scaffolder, error) {
+	sc, err := scaffolder.App(appPath)
+	if err != nil {
+		return sc, err
+	}
+	if sc.Version.LT(cosmosver.StargateFortyFourVersion) {
+		return sc, fmt.Errorf("the chain is scaffolded with an old version of Cosmos SDK %s, migrate it with https://docs.starport.network/migration",
+			sc.Version.String())
+	}
+	return sc, nil
+}
@@ -0,0 +1,60 @@
+// Package starportsdk is the Go API of Starport. it lets Go programs such as CI tools and
+// orchestration services build, initialize and serve chains, scaffold their source code and
+// publish them to networks or join networks as validators without shelling out to the CLI.
+//
+// The long running operations are canceled with their contexts and they report their progress
+// as events to the channels given with ChainEvents and NetworkEvents. Chains and networks must
+// be closed once they are no longer used.
+package starportsdk
+
+import (
+	"sync"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+)
+
+// Event is the progress of an operation.
+type Event struct {
+	// Description of the step of the operation.
+	Description string
+
+	// Done is true when the step is completed, it's ongoing otherwise.
+	Done bool
+}
+
+// eventForwarder forwards the events sent to its bus to a channel, the events are dropped when
+// there is no channel.
+type eventForwarder struct {
+	bus events.Bus
+	wg  *sync.WaitGroup
+}
+
+func forwardEvents(ch chan<- Event) eventForwarder {
+	f := eventForwarder{
+		bus: events.NewBus(),
+		wg:  &sync.WaitGroup{},
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		for e := range f.bus {
+			if ch != nil {
+				ch <- Event{
+					Description: e.Description,
+					Done:        !e.IsOngoing(),
+				}
+			}
+		}
+	}()
+
+	return f
+}
+
+// close stops forwarding events after the events that are already sent are forwarded, the
+// channel is not closed since it's owned by the caller.
+func (f eventForwarder) close() {
+	f.bus.Shutdown()
+	f.wg.Wait()
+}
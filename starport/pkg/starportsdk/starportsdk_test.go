@@ -0,0 +1,27 @@
+package starportsdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+)
+
+func TestForwardEvents(t *testing.T) {
+	ch := make(chan Event, 2)
+
+	f := forwardEvents(ch)
+	f.bus.Send(events.New(events.StatusOngoing, "Building"))
+	f.bus.Send(events.New(events.StatusDone, "Built"))
+	f.close()
+
+	require.Equal(t, Event{Description: "Building"}, <-ch)
+	require.Equal(t, Event{Description: "Built", Done: true}, <-ch)
+}
+
+func TestForwardEventsWithoutChannel(t *testing.T) {
+	f := forwardEvents(nil)
+	f.bus.Send(events.New(events.StatusDone, "Built"))
+	f.close()
+}
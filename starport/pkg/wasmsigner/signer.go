@@ -0,0 +1,332 @@
+// Package wasmsigner creates the bytes to sign and the encoded txs of Cosmos SDK chains from the
+// descriptors of their proto files, so the custom messages of a chain can be signed without its Go types.
+// the package only depends on the standard library and the protobuf runtime, so it can be compiled to
+// WebAssembly and used by browsers, while the Go types of the Cosmos SDK can't.
+package wasmsigner
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Source is the source code of the package, it's copied into the signing helpers generated for chains.
+//go:embed signer.go
+var Source embed.FS
+
+const (
+	typeTxBody   = "cosmos.tx.v1beta1.TxBody"
+	typeAuthInfo = "cosmos.tx.v1beta1.AuthInfo"
+	typeSignDoc  = "cosmos.tx.v1beta1.SignDoc"
+	typeTxRaw    = "cosmos.tx.v1beta1.TxRaw"
+
+	typeURLPubKey = "/cosmos.crypto.secp256k1.PubKey"
+)
+
+// SignMode is the mode to sign txs with.
+type SignMode string
+
+const (
+	// SignModeDirect signs the proto encoded tx.
+	SignModeDirect SignMode = "direct"
+
+	// SignModeAmino signs the legacy Amino JSON of the tx, it's used by the hardware wallets.
+	SignModeAmino SignMode = "amino"
+)
+
+// Coin is an amount of a denom.
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// Fee is the fee paid for a tx.
+type Fee struct {
+	Amount  []Coin `json:"amount"`
+	Gas     uint64 `json:"gas,string"`
+	Payer   string `json:"payer,omitempty"`
+	Granter string `json:"granter,omitempty"`
+}
+
+// Doc describes a tx to sign, it's decoded from JSON.
+type Doc struct {
+	// Mode is the sign mode, default is SignModeDirect.
+	Mode SignMode `json:"mode"`
+
+	ChainID       string `json:"chainId"`
+	AccountNumber uint64 `json:"accountNumber,string"`
+	Sequence      uint64 `json:"sequence,string"`
+
+	// PubKey is the compressed secp256k1 public key of the signer.
+	PubKey []byte `json:"pubKey"`
+
+	// Msgs are the messages of the tx in the JSON format of proto with their type URLs in the
+	// "@type" field, e.g. {"@type": "/cosmos.bank.v1beta1.MsgSend", "fromAddress": ...}.
+	Msgs []json.RawMessage `json:"msgs"`
+
+	Fee  Fee    `json:"fee"`
+	Memo string `json:"memo"`
+
+	// AminoTypes are the Amino names of the messages by their type URLs, they're only needed to
+	// sign with SignModeAmino, e.g. "/cosmos.bank.v1beta1.MsgSend": "cosmos-sdk/MsgSend".
+	AminoTypes map[string]string `json:"aminoTypes"`
+}
+
+// Signer creates the bytes to sign and the encoded txs with the proto types of a chain.
+type Signer struct {
+	types *protoregistry.Types
+}
+
+// New creates a new signer with the proto types in descriptorSet, an encoded FileDescriptorSet.
+// the set must include the files of the messages to sign and the cosmos.tx.v1beta1 files with
+// all of their imports.
+func New(descriptorSet []byte) (*Signer, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(descriptorSet, &set); err != nil {
+		return nil, err
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Signer{types: &protoregistry.Types{}}
+
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		err = s.registerMessages(fd.Messages())
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{typeTxBody, typeAuthInfo, typeSignDoc, typeTxRaw} {
+		if _, err := s.types.FindMessageByName(protoreflect.FullName(name)); err != nil {
+			return nil, fmt.Errorf("%s is not in the descriptors: %w", name, err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Signer) registerMessages(messages protoreflect.MessageDescriptors) error {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if err := s.types.RegisterMessage(dynamicpb.NewMessageType(md)); err != nil {
+			return err
+		}
+		if err := s.registerMessages(md.Messages()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SignBytes returns the bytes to sign for the tx described by doc.
+func (s *Signer) SignBytes(doc Doc) ([]byte, error) {
+	if doc.Mode == SignModeAmino {
+		return s.aminoSignBytes(doc)
+	}
+
+	body, authInfo, err := s.encodeBodyAndAuthInfo(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.encode(typeSignDoc, map[string]interface{}{
+		"bodyBytes":     body,
+		"authInfoBytes": authInfo,
+		"chainId":       doc.ChainID,
+		"accountNumber": strconv.FormatUint(doc.AccountNumber, 10),
+	})
+}
+
+// EncodeTx returns the proto encoded tx described by doc with the signature of its sign bytes,
+// the tx is ready to broadcast.
+func (s *Signer) EncodeTx(doc Doc, signature []byte) ([]byte, error) {
+	body, authInfo, err := s.encodeBodyAndAuthInfo(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.encode(typeTxRaw, map[string]interface{}{
+		"bodyBytes":     body,
+		"authInfoBytes": authInfo,
+		"signatures":    [][]byte{signature},
+	})
+}
+
+func (s *Signer) encodeBodyAndAuthInfo(doc Doc) (body, authInfo []byte, err error) {
+	msgs := doc.Msgs
+	if msgs == nil {
+		msgs = []json.RawMessage{}
+	}
+
+	body, err = s.encode(typeTxBody, map[string]interface{}{
+		"messages": msgs,
+		"memo":     doc.Memo,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mode := "SIGN_MODE_DIRECT"
+	if doc.Mode == SignModeAmino {
+		mode = "SIGN_MODE_LEGACY_AMINO_JSON"
+	}
+
+	signerInfo := map[string]interface{}{
+		"modeInfo": map[string]interface{}{"single": map[string]interface{}{"mode": mode}},
+		"sequence": strconv.FormatUint(doc.Sequence, 10),
+	}
+	if len(doc.PubKey) > 0 {
+		signerInfo["publicKey"] = map[string]interface{}{"@type": typeURLPubKey, "key": doc.PubKey}
+	}
+
+	authInfo, err = s.encode(typeAuthInfo, map[string]interface{}{
+		"signerInfos": []interface{}{signerInfo},
+		"fee": map[string]interface{}{
+			"amount":   coins(doc.Fee.Amount),
+			"gasLimit": strconv.FormatUint(doc.Fee.Gas, 10),
+			"payer":    doc.Fee.Payer,
+			"granter":  doc.Fee.Granter,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return body, authInfo, nil
+}
+
+// encode creates a message of the type with name from its JSON in v and encodes the message with
+// the deterministic proto encoding.
+func (s *Signer) encode(name string, v interface{}) ([]byte, error) {
+	mt, err := s.types.FindMessageByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := mt.New().Interface()
+	if err := (protojson.UnmarshalOptions{Resolver: s.types}).Unmarshal(content, m); err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %w", name, err)
+	}
+
+	return proto.MarshalOptions{Deterministic: true}.Marshal(m)
+}
+
+// aminoSignBytes returns the sorted JSON of the legacy StdSignDoc for the tx described by doc.
+func (s *Signer) aminoSignBytes(doc Doc) ([]byte, error) {
+	msgs := make([]interface{}, 0, len(doc.Msgs))
+
+	for _, msg := range doc.Msgs {
+		typeURL, value, err := s.aminoValue(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := doc.AminoTypes[typeURL]
+		if !ok {
+			return nil, fmt.Errorf("amino type of %s is not set", typeURL)
+		}
+
+		msgs = append(msgs, map[string]interface{}{"type": name, "value": value})
+	}
+
+	fee := map[string]interface{}{
+		"amount": coins(doc.Fee.Amount),
+		"gas":    strconv.FormatUint(doc.Fee.Gas, 10),
+	}
+	if doc.Fee.Payer != "" {
+		fee["payer"] = doc.Fee.Payer
+	}
+	if doc.Fee.Granter != "" {
+		fee["granter"] = doc.Fee.Granter
+	}
+
+	content, err := json.Marshal(map[string]interface{}{
+		"account_number": strconv.FormatUint(doc.AccountNumber, 10),
+		"chain_id":       doc.ChainID,
+		"fee":            fee,
+		"memo":           doc.Memo,
+		"msgs":           msgs,
+		"sequence":       strconv.FormatUint(doc.Sequence, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortJSON(content)
+}
+
+// aminoValue returns the type URL of msg with its value in the JSON format of Amino, the fields
+// are named as in the proto files and the enums are numbers.
+func (s *Signer) aminoValue(msg json.RawMessage) (typeURL string, value interface{}, err error) {
+	any, err := s.types.FindMessageByName("google.protobuf.Any")
+	if err != nil {
+		return "", nil, err
+	}
+
+	m := any.New().Interface()
+	if err := (protojson.UnmarshalOptions{Resolver: s.types}).Unmarshal(msg, m); err != nil {
+		return "", nil, fmt.Errorf("cannot decode msg: %w", err)
+	}
+
+	content, err := protojson.MarshalOptions{
+		UseProtoNames:  true,
+		UseEnumNumbers: true,
+		Resolver:       s.types,
+	}.Marshal(m)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return "", nil, err
+	}
+
+	if err := json.Unmarshal(fields["@type"], &typeURL); err != nil {
+		return "", nil, errors.New("msg has no type URL")
+	}
+	delete(fields, "@type")
+
+	return typeURL, fields, nil
+}
+
+// coins returns the JSON of the coins, no coins are encoded as an empty list.
+func coins(c []Coin) []Coin {
+	if c == nil {
+		return []Coin{}
+	}
+	return c
+}
+
+// sortJSON returns content with the keys of its objects sorted and without spaces.
+func sortJSON(content []byte) ([]byte, error) {
+	d := json.NewDecoder(strings.NewReader(string(content)))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
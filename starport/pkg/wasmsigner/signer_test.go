@@ -0,0 +1,197 @@
+package wasmsigner_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"path"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/tendermint/starport/starport/pkg/wasmsigner"
+)
+
+const (
+	chainID       = "mars"
+	accountNumber = 7
+	sequence      = 3
+)
+
+func TestSigner(t *testing.T) {
+	s, err := wasmsigner.New(descriptorSet(t,
+		"cosmos/tx/v1beta1/tx.proto",
+		"cosmos/bank/v1beta1/tx.proto",
+		"cosmos/crypto/secp256k1/keys.proto",
+	))
+	require.NoError(t, err)
+
+	var (
+		priv = secp256k1.GenPrivKeyFromSecret([]byte("mars"))
+		from = sdk.AccAddress(priv.PubKey().Address())
+		to   = sdk.AccAddress([]byte("venus_______________"))
+		msg  = banktypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("token", 10)))
+		fee  = sdk.NewCoins(sdk.NewInt64Coin("stake", 5))
+	)
+
+	registry := codectypes.NewInterfaceRegistry()
+	banktypes.RegisterInterfaces(registry)
+	txConfig := authtx.NewTxConfig(codec.NewProtoCodec(registry), authtx.DefaultSignModes)
+
+	doc := wasmsigner.Doc{
+		ChainID:       chainID,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+		PubKey:        priv.PubKey().Bytes(),
+		Msgs: []json.RawMessage{json.RawMessage(`{
+			"@type": "/cosmos.bank.v1beta1.MsgSend",
+			"fromAddress": "` + from.String() + `",
+			"toAddress": "` + to.String() + `",
+			"amount": [{"denom": "token", "amount": "10"}]
+		}`)},
+		Fee:        wasmsigner.Fee{Amount: []wasmsigner.Coin{{Denom: "stake", Amount: "5"}}, Gas: 200000},
+		Memo:       "hello",
+		AminoTypes: map[string]string{"/cosmos.bank.v1beta1.MsgSend": "cosmos-sdk/MsgSend"},
+	}
+
+	tests := []struct {
+		name string
+		mode wasmsigner.SignMode
+		sdk  signing.SignMode
+	}{
+		{name: "direct", mode: wasmsigner.SignModeDirect, sdk: signing.SignMode_SIGN_MODE_DIRECT},
+		{name: "amino", mode: wasmsigner.SignModeAmino, sdk: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := doc
+			doc.Mode = tt.mode
+
+			// the tx created with the Go types of the SDK.
+			builder := txConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(msg))
+			builder.SetFeeAmount(fee)
+			builder.SetGasLimit(200000)
+			builder.SetMemo("hello")
+			require.NoError(t, builder.SetSignatures(signing.SignatureV2{
+				PubKey:   priv.PubKey(),
+				Data:     &signing.SingleSignatureData{SignMode: tt.sdk},
+				Sequence: sequence,
+			}))
+
+			expected, err := txConfig.SignModeHandler().GetSignBytes(tt.sdk, authsigning.SignerData{
+				ChainID:       chainID,
+				AccountNumber: accountNumber,
+				Sequence:      sequence,
+			}, builder.GetTx())
+			require.NoError(t, err)
+
+			signBytes, err := s.SignBytes(doc)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), string(signBytes))
+
+			signature, err := priv.Sign(signBytes)
+			require.NoError(t, err)
+
+			require.NoError(t, builder.SetSignatures(signing.SignatureV2{
+				PubKey:   priv.PubKey(),
+				Data:     &signing.SingleSignatureData{SignMode: tt.sdk, Signature: signature},
+				Sequence: sequence,
+			}))
+			expectedTx, err := txConfig.TxEncoder()(builder.GetTx())
+			require.NoError(t, err)
+
+			encodedTx, err := s.EncodeTx(doc, signature)
+			require.NoError(t, err)
+			require.Equal(t, expectedTx, encodedTx)
+		})
+	}
+
+	t.Run("missing amino type", func(t *testing.T) {
+		doc := doc
+		doc.Mode = wasmsigner.SignModeAmino
+		doc.AminoTypes = nil
+
+		_, err := s.SignBytes(doc)
+		require.EqualError(t, err, "amino type of /cosmos.bank.v1beta1.MsgSend is not set")
+	})
+}
+
+func TestNewMissingTxTypes(t *testing.T) {
+	_, err := wasmsigner.New(descriptorSet(t, "cosmos/bank/v1beta1/tx.proto"))
+	require.Error(t, err)
+}
+
+// descriptorSet returns the encoded set of the proto files registered by the Go types of the SDK
+// with all of their imports.
+func descriptorSet(t *testing.T, files ...string) []byte {
+	t.Helper()
+
+	var (
+		set  descriptorpb.FileDescriptorSet
+		seen = make(map[string]bool)
+		add  func(name string)
+	)
+
+	add = func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+
+		fd := fileDescriptor(t, name)
+		for _, dep := range fd.Dependency {
+			add(dep)
+		}
+		set.File = append(set.File, fd)
+	}
+
+	for _, name := range files {
+		add(name)
+	}
+
+	content, err := proto.Marshal(&set)
+	require.NoError(t, err)
+	return content
+}
+
+// fileDescriptor returns the descriptor of a proto file registered by the SDK, or by the protobuf
+// runtime for the well-known types.
+func fileDescriptor(t *testing.T, name string) *descriptorpb.FileDescriptorProto {
+	t.Helper()
+
+	if fd, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+		return protodesc.ToFileDescriptorProto(fd)
+	}
+
+	// some files are registered with their base names.
+	gz := gogoproto.FileDescriptor(name)
+	if gz == nil {
+		gz = gogoproto.FileDescriptor(path.Base(name))
+	}
+	require.NotNil(t, gz, name)
+
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var fd descriptorpb.FileDescriptorProto
+	require.NoError(t, proto.Unmarshal(content, &fd))
+	fd.Name = proto.String(name)
+	return &fd
+}
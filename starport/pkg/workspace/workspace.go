@@ -0,0 +1,184 @@
+// Package workspace reads the workspace files that list the chains of a multi-chain repository,
+// so the chains can be built and served by their names and connected with the relayer.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+)
+
+// File is the name of the workspace file at the root of a workspace.
+const File = "starport.work.yml"
+
+const (
+	defaultAddressPrefix = "cosmos"
+	defaultGasPrice      = "0.00025stake"
+)
+
+// ErrNotFound is returned when no workspace file is found.
+var ErrNotFound = errors.New("workspace file not found, create a " + File + " at the root of the workspace")
+
+// Workspace lists the chains of a workspace.
+type Workspace struct {
+	Chains  []Chain `yaml:"chains"`
+	Relayer Relayer `yaml:"relayer,omitempty"`
+
+	// dir is the directory of the workspace file.
+	dir string
+}
+
+// Chain is a chain of a workspace.
+type Chain struct {
+	Name string `yaml:"name"`
+
+	// Path of the chain's source code relative to the workspace.
+	Path string `yaml:"path"`
+
+	// Config is the config file of the chain relative to its path, the default config of the chain
+	// is used when it's empty.
+	Config string `yaml:"config,omitempty"`
+
+	// AddressPrefix and GasPrice are used by the relayer to send txs to the chain.
+	AddressPrefix string `yaml:"address_prefix,omitempty"`
+	GasPrice      string `yaml:"gas_price,omitempty"`
+}
+
+// Relayer configures the relayer between the chains of a workspace.
+type Relayer struct {
+	Paths []RelayerPath `yaml:"paths"`
+}
+
+// RelayerPath is a relayer path between two chains of a workspace.
+type RelayerPath struct {
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+}
+
+// Locate finds the workspace file in dir or in its parent directories.
+func Locate(dir string) (path string, err error) {
+	if dir, err = filepath.Abs(dir); err != nil {
+		return "", err
+	}
+	for {
+		path = filepath.Join(dir, File)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// Parse parses the workspace file at path.
+func Parse(path string) (Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	var w Workspace
+	if err := yaml.Unmarshal(data, &w); err != nil {
+		return Workspace{}, fmt.Errorf("invalid workspace file %s: %w", path, err)
+	}
+	if w.dir, err = filepath.Abs(filepath.Dir(path)); err != nil {
+		return Workspace{}, err
+	}
+
+	names := make(map[string]bool, len(w.Chains))
+	for i, c := range w.Chains {
+		switch {
+		case c.Name == "":
+			return Workspace{}, fmt.Errorf("chain %d of the workspace has no name", i)
+		case c.Path == "":
+			return Workspace{}, fmt.Errorf("chain %q of the workspace has no path", c.Name)
+		case names[c.Name]:
+			return Workspace{}, fmt.Errorf("chain %q is listed more than once in the workspace", c.Name)
+		}
+		names[c.Name] = true
+
+		if c.AddressPrefix == "" {
+			w.Chains[i].AddressPrefix = defaultAddressPrefix
+		}
+		if c.GasPrice == "" {
+			w.Chains[i].GasPrice = defaultGasPrice
+		}
+	}
+
+	for _, p := range w.Relayer.Paths {
+		for _, name := range []string{p.Source, p.Target} {
+			if !names[name] {
+				return Workspace{}, fmt.Errorf("relayer path %s-%s has unknown chain %q", p.Source, p.Target, name)
+			}
+		}
+		if p.Source == p.Target {
+			return Workspace{}, fmt.Errorf("relayer path %s-%s connects the chain to itself", p.Source, p.Target)
+		}
+	}
+
+	return w, nil
+}
+
+// Open locates the workspace file from dir and parses it.
+func Open(dir string) (Workspace, error) {
+	path, err := Locate(dir)
+	if err != nil {
+		return Workspace{}, err
+	}
+	return Parse(path)
+}
+
+// Save saves the workspace file in dir and returns it as parsed from the file.
+func Save(dir string, w Workspace) (Workspace, error) {
+	data, err := yaml.Marshal(w)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	path := filepath.Join(dir, File)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return Workspace{}, err
+	}
+	return Parse(path)
+}
+
+// Dir returns the directory of the workspace.
+func (w Workspace) Dir() string {
+	return w.dir
+}
+
+// Chain returns the chain of the workspace by its name.
+func (w Workspace) Chain(name string) (Chain, error) {
+	for _, c := range w.Chains {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Chain{}, fmt.Errorf("chain %q is not in the workspace", name)
+}
+
+// ChainPath returns the absolute path of the chain's source code.
+func (w Workspace) ChainPath(c Chain) string {
+	if filepath.IsAbs(c.Path) {
+		return c.Path
+	}
+	return filepath.Join(w.dir, c.Path)
+}
+
+// ChainConfigPath returns the absolute path of the chain's config file, it's empty when the chain
+// uses its default config.
+func (w Workspace) ChainConfigPath(c Chain) string {
+	if c.Config == "" || filepath.IsAbs(c.Config) {
+		return c.Config
+	}
+	return filepath.Join(w.ChainPath(c), c.Config)
+}
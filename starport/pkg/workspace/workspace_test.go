@@ -0,0 +1,145 @@
+package workspace_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/workspace"
+)
+
+func writeWorkspace(t *testing.T, dir, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, workspace.File), []byte(content), 0644))
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	writeWorkspace(t, dir, `
+chains:
+  - name: mars
+    path: mars
+  - name: venus
+    path: chains/venus
+    config: venus.yml
+    address_prefix: venus
+relayer:
+  paths:
+    - source: mars
+      target: venus
+`)
+	nested := filepath.Join(dir, "mars", "x")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	w, err := workspace.Open(nested)
+	require.NoError(t, err)
+	require.Equal(t, dir, w.Dir())
+	require.Len(t, w.Chains, 2)
+	require.Equal(t, []workspace.RelayerPath{{Source: "mars", Target: "venus"}}, w.Relayer.Paths)
+
+	mars, err := w.Chain("mars")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "mars"), w.ChainPath(mars))
+	require.Empty(t, w.ChainConfigPath(mars))
+	require.Equal(t, "cosmos", mars.AddressPrefix)
+	require.Equal(t, "0.00025stake", mars.GasPrice)
+
+	venus, err := w.Chain("venus")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "chains", "venus", "venus.yml"), w.ChainConfigPath(venus))
+	require.Equal(t, "venus", venus.AddressPrefix)
+
+	_, err = w.Chain("earth")
+	require.Error(t, err)
+}
+
+func TestOpenNotFound(t *testing.T) {
+	_, err := workspace.Open(t.TempDir())
+	require.ErrorIs(t, err, workspace.ErrNotFound)
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "chain without name",
+			content: `
+chains:
+  - path: mars
+`,
+		},
+		{
+			name: "chain without path",
+			content: `
+chains:
+  - name: mars
+`,
+		},
+		{
+			name: "duplicated chain",
+			content: `
+chains:
+  - name: mars
+    path: mars
+  - name: mars
+    path: venus
+`,
+		},
+		{
+			name: "relayer path with unknown chain",
+			content: `
+chains:
+  - name: mars
+    path: mars
+relayer:
+  paths:
+    - source: mars
+      target: venus
+`,
+		},
+		{
+			name: "relayer path to the same chain",
+			content: `
+chains:
+  - name: mars
+    path: mars
+relayer:
+  paths:
+    - source: mars
+      target: mars
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeWorkspace(t, dir, tt.content)
+
+			_, err := workspace.Parse(filepath.Join(dir, workspace.File))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := workspace.Save(dir, workspace.Workspace{
+		Chains: []workspace.Chain{
+			{Name: "mars", Path: "mars"},
+			{Name: "venus", Path: "venus"},
+		},
+		Relayer: workspace.Relayer{
+			Paths: []workspace.RelayerPath{{Source: "mars", Target: "venus"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, dir, w.Dir())
+
+	parsed, err := workspace.Open(dir)
+	require.NoError(t, err)
+	require.Equal(t, w, parsed)
+}
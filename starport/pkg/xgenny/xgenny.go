@@ -58,3 +58,25 @@ func (w Walker) walkDir(wl packd.WalkFunc, path string) error {
 
 	return nil
 }
+
+// walkerFunc implements packd.Walker with a function.
+type walkerFunc func(wl packd.WalkFunc) error
+
+// Walk implements packd.Walker.
+func (f walkerFunc) Walk(wl packd.WalkFunc) error {
+	return f(wl)
+}
+
+// Without returns a walker that skips the files of w with a path that contains one of parts.
+func Without(w packd.Walker, parts ...string) packd.Walker {
+	return walkerFunc(func(wl packd.WalkFunc) error {
+		return w.Walk(func(path string, f packd.File) error {
+			for _, part := range parts {
+				if strings.Contains(path, part) {
+					return nil
+				}
+			}
+			return wl(path, f)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package xhttp
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"time"
 )
@@ -12,19 +13,46 @@ const ShutdownTimeout = time.Minute
 
 // Serve starts s server and shutdowns it once the ctx is cancelled.
 func Serve(ctx context.Context, s *http.Server) error {
-	go func() {
-		<-ctx.Done()
+	go shutdownOnDone(ctx, s)
 
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
-		defer cancel()
+	err := s.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
 
-		s.Shutdown(shutdownCtx)
-	}()
+// ServeTLS is same as Serve except it serves over TLS with the certificates of the TLS config of s
+// server.
+func ServeTLS(ctx context.Context, s *http.Server) error {
+	go shutdownOnDone(ctx, s)
 
-	err := s.ListenAndServe()
+	err := s.ListenAndServeTLS("", "")
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// ServeListener is same as Serve except it accepts the connections of s server from l.
+func ServeListener(ctx context.Context, s *http.Server, l net.Listener) error {
+	go shutdownOnDone(ctx, s)
+
+	err := s.Serve(l)
 	if errors.Is(err, http.ErrServerClosed) {
 		return nil
 	}
 
 	return err
 }
+
+func shutdownOnDone(ctx context.Context, s *http.Server) {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+
+	s.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,57 @@
+// Package xnet provides helpers for the network of the machine.
+package xnet
+
+import (
+	"errors"
+	"net"
+
+	"github.com/rdegges/go-ipify"
+	"github.com/tendermint/tendermint/p2p/upnp"
+)
+
+var (
+	// externalIP returns the external IP of the UPnP gateway of the local network.
+	externalIP = func() (net.IP, error) {
+		nat, err := upnp.Discover()
+		if err != nil {
+			return nil, err
+		}
+		return nat.GetExternalAddress()
+	}
+
+	// queryIP asks the public IP of the machine to a public IP query service.
+	queryIP = ipify.GetIp
+)
+
+// PublicIP returns the public IP of the machine. the UPnP gateway of the local network is asked
+// first and a public IP query service is used when there is no gateway.
+func PublicIP() (string, error) {
+	if ip, err := externalIP(); err == nil && isPublic(ip) {
+		return ip.String(), nil
+	}
+
+	ip, err := queryIP()
+	if err != nil {
+		return "", err
+	}
+	if net.ParseIP(ip) == nil {
+		return "", errors.New("cannot detect the public IP")
+	}
+	return ip, nil
+}
+
+// privateNetworks are the address blocks of the private and carrier-grade NAT networks.
+var privateNetworks = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"}
+
+func isPublic(ip net.IP) bool {
+	if ip == nil || !ip.IsGlobalUnicast() {
+		return false
+	}
+	for _, network := range privateNetworks {
+		_, n, _ := net.ParseCIDR(network)
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package xnet
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		externalIP net.IP
+		queryIP    string
+		want       string
+		err        bool
+	}{
+		{
+			name:       "gateway",
+			externalIP: net.ParseIP("203.0.113.1"),
+			queryIP:    "203.0.113.2",
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "private gateway",
+			externalIP: net.ParseIP("192.168.1.1"),
+			queryIP:    "203.0.113.2",
+			want:       "203.0.113.2",
+		},
+		{
+			name:    "no gateway",
+			queryIP: "203.0.113.2",
+			want:    "203.0.113.2",
+		},
+		{
+			name: "no ip",
+			err:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(e func() (net.IP, error), q func() (string, error)) {
+				externalIP, queryIP = e, q
+			}(externalIP, queryIP)
+
+			externalIP = func() (net.IP, error) {
+				if tt.externalIP == nil {
+					return nil, errors.New("no gateway")
+				}
+				return tt.externalIP, nil
+			}
+			queryIP = func() (string, error) {
+				if tt.queryIP == "" {
+					return "", errors.New("no connection")
+				}
+				return tt.queryIP, nil
+			}
+
+			got, err := PublicIP()
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
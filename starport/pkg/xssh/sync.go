@@ -0,0 +1,267 @@
+package xssh
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// manifestFile is the file in the synced directories of the targets that lists the synced files,
+// the files are only deleted from the target when they're listed in it.
+const manifestFile = ".starport-sync"
+
+// fileState is the state of a file used to detect its changes.
+type fileState struct {
+	size    int64
+	modTime int64
+	mode    fs.FileMode
+}
+
+// Syncer syncs the files of a local directory to a directory of the target.
+type Syncer struct {
+	client        *Client
+	local, remote string
+	match         func(path string, isDir bool) bool
+
+	// synced are the states of the files in the remote directory by their paths, nil until they're
+	// read from the manifest of the remote directory.
+	synced map[string]fileState
+}
+
+// NewSyncer creates a syncer of the files of the local directory to the remote directory that match,
+// all the files when match is nil. the paths given to match are relative to the local directory with
+// slashes.
+func (c *Client) NewSyncer(local, remote string, match func(path string, isDir bool) bool) *Syncer {
+	return &Syncer{
+		client: c,
+		local:  local,
+		remote: remote,
+		match:  match,
+	}
+}
+
+// SyncResult are the files changed by a sync.
+type SyncResult struct {
+	Uploaded []string
+	Removed  []string
+}
+
+// Sync uploads the files that changed since the previous sync and deletes the files that were
+// removed, the previous sync is read from the target so the unchanged files are not uploaded again
+// by a new syncer.
+func (s *Syncer) Sync(ctx context.Context) (SyncResult, error) {
+	local, err := s.walk()
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	if s.synced == nil {
+		if s.synced, err = s.readManifest(ctx); err != nil {
+			return SyncResult{}, err
+		}
+	}
+
+	var res SyncResult
+	for p, state := range local {
+		if synced, ok := s.synced[p]; !ok || synced != state {
+			res.Uploaded = append(res.Uploaded, p)
+		}
+	}
+	for p := range s.synced {
+		if _, ok := local[p]; !ok {
+			res.Removed = append(res.Removed, p)
+		}
+	}
+	if len(res.Uploaded) == 0 && len(res.Removed) == 0 {
+		return res, nil
+	}
+	sort.Strings(res.Uploaded)
+	sort.Strings(res.Removed)
+
+	command := fmt.Sprintf("mkdir -p %[1]s && cd %[1]s", Quote(s.remote))
+	if len(res.Removed) > 0 {
+		var removed []string
+		for _, p := range res.Removed {
+			removed = append(removed, Quote(p))
+		}
+		command += " && rm -f -- " + strings.Join(removed, " ")
+	}
+	command += " && tar -xzf -"
+
+	var manifest strings.Builder
+	for _, p := range sortedPaths(local) {
+		state := local[p]
+		fmt.Fprintf(&manifest, "%s\t%d\t%d\t%o\n", p, state.size, state.modTime, uint32(state.mode))
+	}
+
+	if err := s.client.upload(ctx, command, func(tw *tar.Writer) error {
+		for _, p := range res.Uploaded {
+			if err := addFile(tw, s.local, p); err != nil {
+				return err
+			}
+		}
+		return addBytes(tw, manifestFile, []byte(manifest.String()))
+	}); err != nil {
+		return SyncResult{}, err
+	}
+
+	s.synced = local
+	return res, nil
+}
+
+// walk returns the states of the local files.
+func (s *Syncer) walk() (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	err := filepath.WalkDir(s.local, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.local, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if s.match != nil && !s.match(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[rel] = fileState{
+			size:    info.Size(),
+			modTime: info.ModTime().UnixNano(),
+			mode:    info.Mode().Perm(),
+		}
+		return nil
+	})
+	return files, err
+}
+
+// readManifest reads the states of the files synced in the remote directory.
+func (s *Syncer) readManifest(ctx context.Context) (map[string]fileState, error) {
+	out, err := s.client.Output(ctx, fmt.Sprintf("cat %s 2>/dev/null || true", Quote(path.Join(s.remote, manifestFile))))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]fileState)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		modTime, _ := strconv.ParseInt(fields[2], 10, 64)
+		mode, _ := strconv.ParseUint(fields[3], 8, 32)
+		files[fields[0]] = fileState{size: size, modTime: modTime, mode: fs.FileMode(mode)}
+	}
+	return files, scanner.Err()
+}
+
+func sortedPaths(files map[string]fileState) []string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Upload uploads the files of the local directory to the remote directory, the existing files of
+// the remote directory are overwritten.
+func (c *Client) Upload(ctx context.Context, local, remote string) error {
+	command := fmt.Sprintf("mkdir -p %[1]s && cd %[1]s && tar -xzf -", Quote(remote))
+	return c.upload(ctx, command, func(tw *tar.Writer) error {
+		return filepath.WalkDir(local, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			rel, err := filepath.Rel(local, p)
+			if err != nil {
+				return err
+			}
+			return addFile(tw, local, filepath.ToSlash(rel))
+		})
+	})
+}
+
+// upload runs the command with a gzipped tarball of the files written by write as its stdin.
+func (c *Client) upload(ctx context.Context, command string, write func(*tar.Writer) error) error {
+	r, w := io.Pipe()
+	go func() {
+		var (
+			gw = gzip.NewWriter(w)
+			tw = tar.NewWriter(gw)
+		)
+		err := write(tw)
+		if err == nil {
+			err = tw.Close()
+		}
+		if err == nil {
+			err = gw.Close()
+		}
+		w.CloseWithError(err)
+	}()
+	defer r.Close()
+
+	_, err := c.Output(ctx, command, RunStdin(r))
+	return err
+}
+
+func addFile(tw *tar.Writer, root, name string) error {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func addBytes(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
@@ -0,0 +1,377 @@
+// Package xssh provides an SSH client to run commands on a target machine, forward its ports and
+// sync files to it.
+package xssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	defaultPort = "22"
+
+	// interruptTimeout is the time that the commands have to exit after they're interrupted when
+	// their context is canceled, they're killed afterwards.
+	interruptTimeout = 30 * time.Second
+)
+
+// ErrInvalidTarget is returned for the targets that are not ssh:// URLs.
+var ErrInvalidTarget = errors.New("target must be an ssh://[user@]host[:port][/path] URL")
+
+// Target is a machine reachable over SSH.
+type Target struct {
+	User string
+
+	// Address is the host of the machine with its port.
+	Address string
+
+	// Path is the path of a directory on the machine, relative to the home of the user when it's not
+	// absolute, empty when the target has no path.
+	Path string
+}
+
+// ParseTarget parses a target formatted as ssh://[user@]host[:port][/path], the user is the current
+// user when it's omitted. a path that starts with /~/ is relative to the home of the user.
+func ParseTarget(s string) (Target, error) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "ssh" || u.Hostname() == "" || u.RawQuery != "" || u.Fragment != "" {
+		return Target{}, ErrInvalidTarget
+	}
+
+	t := Target{
+		User:    u.User.Username(),
+		Address: u.Host,
+		Path:    u.Path,
+	}
+	if u.Port() == "" {
+		t.Address = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	if t.User == "" {
+		t.User = currentUser()
+	}
+	if strings.HasPrefix(t.Path, "/~/") {
+		t.Path = strings.TrimPrefix(t.Path, "/~/")
+	}
+	t.Path = strings.TrimSuffix(t.Path, "/")
+	return t, nil
+}
+
+func currentUser() string {
+	for _, name := range []string{"USER", "USERNAME"} {
+		if user := os.Getenv(name); user != "" {
+			return user
+		}
+	}
+	return "root"
+}
+
+// String returns the target as an ssh:// URL.
+func (t Target) String() string {
+	u := url.URL{
+		Scheme: "ssh",
+		User:   url.User(t.User),
+		Host:   t.Address,
+	}
+	if t.Path != "" {
+		u.Path = "/" + strings.TrimPrefix(t.Path, "/")
+		if !strings.HasPrefix(t.Path, "/") {
+			u.Path = "/~" + u.Path
+		}
+	}
+	return u.String()
+}
+
+// Client is an SSH client connected to a target.
+type Client struct {
+	target Target
+	client *ssh.Client
+}
+
+type dialOptions struct {
+	auth            []ssh.AuthMethod
+	hostKeyCallback ssh.HostKeyCallback
+}
+
+// DialOption configures Dial.
+type DialOption func(*dialOptions)
+
+// WithAuth authenticates with the methods instead of the SSH agent and the default keys of the user.
+func WithAuth(methods ...ssh.AuthMethod) DialOption {
+	return func(o *dialOptions) {
+		o.auth = methods
+	}
+}
+
+// WithHostKeyCallback verifies the host key of the target with the callback instead of the known
+// hosts of the user.
+func WithHostKeyCallback(callback ssh.HostKeyCallback) DialOption {
+	return func(o *dialOptions) {
+		o.hostKeyCallback = callback
+	}
+}
+
+// Dial connects to the target, by default the user is authenticated with the SSH agent and the keys
+// in ~/.ssh without passphrase, and the host key is verified with ~/.ssh/known_hosts.
+func Dial(ctx context.Context, target Target, options ...DialOption) (*Client, error) {
+	var o dialOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	if o.auth == nil {
+		o.auth = defaultAuth()
+	}
+	if o.hostKeyCallback == nil {
+		callback, err := knownHostsCallback()
+		if err != nil {
+			return nil, err
+		}
+		o.hostKeyCallback = callback
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", target.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// the handshake is abandoned once the ctx is canceled.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, target.Address, &ssh.ClientConfig{
+		User:            target.User,
+		Auth:            o.auth,
+		HostKeyCallback: o.hostKeyCallback,
+	})
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	return &Client{
+		target: target,
+		client: ssh.NewClient(sshConn, chans, reqs),
+	}, nil
+}
+
+func defaultAuth() []ssh.AuthMethod {
+	var methods []ssh.AuthMethod
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return methods
+	}
+	var signers []ssh.Signer
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		key, err := os.ReadFile(filepath.Join(home, ".ssh", name))
+		if err != nil {
+			continue
+		}
+		// the keys with a passphrase are used through the agent.
+		if signer, err := ssh.ParsePrivateKey(key); err == nil {
+			signers = append(signers, signer)
+		}
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+	return methods
+}
+
+func knownHostsCallback() (ssh.HostKeyCallback, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(home, ".ssh", "known_hosts")
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the known hosts to verify the target, connect to it once with ssh to add it to %s: %w", path, err)
+	}
+	return callback, nil
+}
+
+// Target returns the target of the client.
+func (c *Client) Target() Target {
+	return c.target
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+type runOptions struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	terminal       bool
+}
+
+// RunOption configures Run.
+type RunOption func(*runOptions)
+
+// RunStdin sets the stdin of the command.
+func RunStdin(r io.Reader) RunOption {
+	return func(o *runOptions) {
+		o.stdin = r
+	}
+}
+
+// RunStdout sets the stdout of the command.
+func RunStdout(w io.Writer) RunOption {
+	return func(o *runOptions) {
+		o.stdout = w
+	}
+}
+
+// RunStderr sets the stderr of the command.
+func RunStderr(w io.Writer) RunOption {
+	return func(o *runOptions) {
+		o.stderr = w
+	}
+}
+
+// RunTerminal runs the command in a terminal, so it's hung up when the connection is lost and its
+// output keeps its colors. the stderr of the command is written to its stdout.
+func RunTerminal() RunOption {
+	return func(o *runOptions) {
+		o.terminal = true
+	}
+}
+
+// Run runs the command with the shell of the target. the command is interrupted once the ctx is
+// canceled and killed when it doesn't exit in time.
+func (c *Client) Run(ctx context.Context, command string, options ...RunOption) error {
+	var o runOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	s, err := c.client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	s.Stdin = o.stdin
+	s.Stdout = o.stdout
+	s.Stderr = o.stderr
+	if o.terminal {
+		if err := s.RequestPty("xterm-256color", 40, 200, ssh.TerminalModes{ssh.ECHO: 0}); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Start(command); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Wait() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.Signal(ssh.SIGINT)
+	select {
+	case <-done:
+	case <-time.After(interruptTimeout):
+	}
+	return ctx.Err()
+}
+
+// Output runs the command and returns its stdout, the error contains the stderr of the command when
+// it fails.
+func (c *Client) Output(ctx context.Context, command string, options ...RunOption) (string, error) {
+	var stdout, stderr bytes.Buffer
+	options = append(options, RunStdout(&stdout), RunStderr(&stderr))
+	if err := c.Run(ctx, command, options...); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// Forward listens at the local address and forwards the connections to the address on the target
+// until the ctx is canceled.
+func (c *Client) Forward(ctx context.Context, localAddress, targetAddress string) error {
+	l, err := net.Listen("tcp", localAddress)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go c.forward(conn, targetAddress)
+	}
+}
+
+func (c *Client) forward(conn net.Conn, address string) {
+	defer conn.Close()
+
+	target, err := c.client.Dial("tcp", address)
+	if err != nil {
+		return
+	}
+	defer target.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(target, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, target)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// Quote quotes s to be used as a single word in the commands of a shell.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,376 @@
+package xssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestClient returns a client of an SSH server that runs the commands with the local shell.
+func newTestClient(t *testing.T) *Client {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostKey, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "alice" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("invalid password")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConn(conn, config)
+		}
+	}()
+
+	target, err := ParseTarget("ssh://alice@" + l.Addr().String())
+	require.NoError(t, err)
+
+	client, err := Dial(
+		context.Background(),
+		target,
+		WithAuth(ssh.Password("secret")),
+		WithHostKeyCallback(ssh.FixedHostKey(hostKey.PublicKey())),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func serveTestConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for ch := range chans {
+		switch ch.ChannelType() {
+		case "session":
+			go serveTestSession(ch)
+		case "direct-tcpip":
+			go serveTestForward(ch)
+		default:
+			ch.Reject(ssh.UnknownChannelType, "unknown channel type")
+		}
+	}
+}
+
+func serveTestSession(newCh ssh.NewChannel) {
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		return
+	}
+	defer ch.Close()
+
+	var cmd *exec.Cmd
+	for req := range reqs {
+		switch req.Type {
+		case "pty-req":
+			req.Reply(true, nil)
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				req.Reply(false, nil)
+				continue
+			}
+			cmd = exec.Command("sh", "-c", payload.Command)
+			cmd.Stdin, cmd.Stdout, cmd.Stderr = ch, ch, ch.Stderr()
+			if err := cmd.Start(); err != nil {
+				req.Reply(false, nil)
+				continue
+			}
+			req.Reply(true, nil)
+
+			go func() {
+				status := make([]byte, 4)
+				if err := cmd.Wait(); err != nil {
+					var exitErr *exec.ExitError
+					code := 1
+					if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+						code = exitErr.ExitCode()
+					}
+					binary.BigEndian.PutUint32(status, uint32(code))
+				}
+				ch.SendRequest("exit-status", false, status)
+				ch.Close()
+			}()
+		case "signal":
+			if cmd != nil && cmd.Process != nil {
+				cmd.Process.Signal(os.Interrupt)
+			}
+		default:
+			req.Reply(false, nil)
+		}
+	}
+}
+
+func serveTestForward(newCh ssh.NewChannel) {
+	var payload struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newCh.ExtraData(), &payload); err != nil {
+		newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	conn, err := net.Dial("tcp", net.JoinHostPort(payload.Host, fmt.Sprint(payload.Port)))
+	if err != nil {
+		newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	defer conn.Close()
+
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		return
+	}
+	defer ch.Close()
+	go ssh.DiscardRequests(reqs)
+
+	go io.Copy(conn, ch)
+	io.Copy(ch, conn)
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		want   Target
+		err    error
+	}{
+		{target: "ssh://alice@example.com", want: Target{User: "alice", Address: "example.com:22"}},
+		{target: "ssh://alice@example.com:2222/srv/mars/", want: Target{User: "alice", Address: "example.com:2222", Path: "/srv/mars"}},
+		{target: "ssh://alice@example.com/~/mars", want: Target{User: "alice", Address: "example.com:22", Path: "mars"}},
+		{target: "ssh://alice@[::1]:2222", want: Target{User: "alice", Address: "[::1]:2222"}},
+		{target: "alice@example.com", err: ErrInvalidTarget},
+		{target: "http://example.com", err: ErrInvalidTarget},
+		{target: "ssh:///mars", err: ErrInvalidTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			target, err := ParseTarget(tt.target)
+			if tt.err != nil {
+				require.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, target)
+
+			parsed, err := ParseTarget(target.String())
+			require.NoError(t, err)
+			require.Equal(t, target, parsed)
+		})
+	}
+
+	defer os.Setenv("USER", os.Getenv("USER"))
+	os.Setenv("USER", "bob")
+	target, err := ParseTarget("ssh://example.com")
+	require.NoError(t, err)
+	require.Equal(t, "bob", target.User)
+}
+
+func TestDialErrors(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := Dial(context.Background(), Target{User: "alice", Address: client.Target().Address},
+		WithAuth(ssh.Password("wrong")),
+		WithHostKeyCallback(ssh.InsecureIgnoreHostKey()),
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to authenticate")
+
+	_, err = Dial(context.Background(), Target{User: "alice", Address: client.Target().Address},
+		WithAuth(ssh.Password("secret")),
+		WithHostKeyCallback(func(string, net.Addr, ssh.PublicKey) error { return errors.New("unknown host") }),
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown host")
+}
+
+func TestRun(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	out, err := client.Output(ctx, "echo "+Quote("it's a 'test'"))
+	require.NoError(t, err)
+	require.Equal(t, "it's a 'test'\n", out)
+
+	_, err = client.Output(ctx, "echo failed >&2; exit 3")
+	var exitErr *ssh.ExitError
+	require.True(t, errors.As(err, &exitErr))
+	require.Equal(t, 3, exitErr.ExitStatus())
+	require.Contains(t, err.Error(), ": failed")
+
+	out, err = client.Output(ctx, "tr a-z A-Z", RunStdin(strings.NewReader("mars")))
+	require.NoError(t, err)
+	require.Equal(t, "MARS", out)
+}
+
+func TestRunInterrupt(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.Run(ctx, "trap 'echo interrupted; exit 0' INT; while true; do sleep 0.05; done")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, int64(time.Since(start)), int64(interruptTimeout))
+}
+
+func TestForward(t *testing.T) {
+	client := newTestClient(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "forwarded")
+	}))
+	defer s.Close()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	local := l.Addr().String()
+	l.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- client.Forward(ctx, local, strings.TrimPrefix(s.URL, "http://")) }()
+
+	require.Eventually(t, func() bool {
+		res, err := http.Get("http://" + local)
+		if err != nil {
+			return false
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		return string(body) == "forwarded"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func readFiles(t *testing.T, dir string) map[string]string {
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		files[filepath.ToSlash(rel)] = string(content)
+		return err
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func TestSync(t *testing.T) {
+	var (
+		client = newTestClient(t)
+		ctx    = context.Background()
+		local  = t.TempDir()
+		remote = filepath.Join(t.TempDir(), "mars")
+		match  = func(path string, isDir bool) bool { return path != "node_modules" }
+	)
+
+	writeFiles(t, local, map[string]string{
+		"go.mod":                 "module mars",
+		"x/blog/keeper/post.go":  "package keeper",
+		"node_modules/vue/x.js":  "vue",
+		"x/blog/types/types.go":  "package types",
+		"x/blog/types/errors.go": "package types",
+	})
+
+	res, err := client.NewSyncer(local, remote, match).Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"go.mod", "x/blog/keeper/post.go", "x/blog/types/errors.go", "x/blog/types/types.go"}, res.Uploaded)
+	require.Empty(t, res.Removed)
+
+	// the files of the target that were not synced are kept.
+	writeFiles(t, remote, map[string]string{"build/marsd": "binary"})
+
+	// a new syncer reads the synced files from the target.
+	s := client.NewSyncer(local, remote, match)
+	res, err = s.Sync(ctx)
+	require.NoError(t, err)
+	require.Empty(t, res.Uploaded)
+	require.Empty(t, res.Removed)
+
+	writeFiles(t, local, map[string]string{"x/blog/keeper/post.go": "package keeper // changed"})
+	require.NoError(t, os.Remove(filepath.Join(local, "x/blog/types/errors.go")))
+
+	res, err = s.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SyncResult{Uploaded: []string{"x/blog/keeper/post.go"}, Removed: []string{"x/blog/types/errors.go"}}, res)
+
+	files := readFiles(t, remote)
+	delete(files, manifestFile)
+	require.Equal(t, map[string]string{
+		"go.mod":                "module mars",
+		"x/blog/keeper/post.go": "package keeper // changed",
+		"x/blog/types/types.go": "package types",
+		"build/marsd":           "binary",
+	}, files)
+}
+
+func TestUpload(t *testing.T) {
+	var (
+		client = newTestClient(t)
+		local  = t.TempDir()
+		remote = filepath.Join(t.TempDir(), "home")
+	)
+
+	writeFiles(t, local, map[string]string{
+		"config/genesis.json": "{}",
+		"data/priv.json":      "{}",
+	})
+	require.NoError(t, client.Upload(context.Background(), local, remote))
+	require.Equal(t, map[string]string{
+		"config/genesis.json": "{}",
+		"data/priv.json":      "{}",
+	}, readFiles(t, remote))
+}
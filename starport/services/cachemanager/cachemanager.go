@@ -0,0 +1,290 @@
+// Package cachemanager accounts for the caches that Starport keeps in its config dir and removes
+// their old entries, on demand or automatically with the GC policy of the user.
+package cachemanager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+// the caches of Starport.
+const (
+	CacheCodegen     = "codegen"
+	CacheLocalChains = "local-chains"
+	CacheSources     = "sources"
+	CacheGo          = "go"
+	CacheBinaries    = "binaries"
+)
+
+// ErrCacheNotFound is returned for the names that are not caches of Starport.
+var ErrCacheNotFound = errors.New("cache not found")
+
+// Cache is a dir where Starport keeps files that can be recreated, each file or dir in it is an entry.
+type Cache struct {
+	// Name is the name of the cache.
+	Name string
+
+	// Description tells what's kept in the cache and what happens once it's removed.
+	Description string
+
+	// Path is the path of the dir of the cache.
+	Path string
+}
+
+// Entry is a file or a dir of a cache.
+type Entry struct {
+	// Cache is the name of the cache of the entry.
+	Cache string
+
+	// Path is the path of the entry.
+	Path string
+
+	// Size is the size in bytes of the files of the entry.
+	Size int64
+
+	// ModTime is the last modification time of the files of the entry.
+	ModTime time.Time
+}
+
+// Usage is the disk usage of a cache.
+type Usage struct {
+	Cache
+
+	// Entries are the entries of the cache from the oldest to the newest.
+	Entries []Entry
+
+	// Size is the size in bytes of the entries of the cache.
+	Size int64
+}
+
+// Manager manages the caches of Starport.
+type Manager struct {
+	configDir string
+	caches    []Cache
+}
+
+// New returns a manager of the caches, the GC policy and its state are kept in configDir.
+func New(configDir string, caches ...Cache) Manager {
+	return Manager{configDir, caches}
+}
+
+// Default returns the manager of the caches of Starport in its config dir.
+func Default() (Manager, error) {
+	dir, err := chainconfig.ConfigDirPath()
+	if err != nil {
+		return Manager{}, err
+	}
+	return New(dir, DefaultCaches(dir)...), nil
+}
+
+// DefaultCaches returns the caches of Starport in the config dir.
+func DefaultCaches(configDir string) []Cache {
+	return []Cache{
+		{
+			Name:        CacheCodegen,
+			Description: "Records of the generated code, the code of the apps is generated again once removed",
+			Path:        filepath.Join(configDir, CacheCodegen),
+		},
+		{
+			Name:        CacheLocalChains,
+			Description: "Genesis exported by the served chains, the state of the chains is reset once removed",
+			Path:        filepath.Join(configDir, CacheLocalChains),
+		},
+		{
+			Name:        CacheSources,
+			Description: "Sources of the chains fetched from Starport Network to build their binaries",
+			Path:        SourcesPath(configDir),
+		},
+		{
+			Name:        CacheGo,
+			Description: "Go build and module caches shared by the chains of an SDK version, the dependencies are downloaded and compiled again once removed",
+			Path:        filepath.Join(configDir, CacheGo),
+		},
+		{
+			Name:        CacheBinaries,
+			Description: "Binaries built from the sources of the chains, reused until their source changes, the chains are built again once removed",
+			Path:        BinariesPath(configDir),
+		},
+	}
+}
+
+// SourcesPath returns the path of the cache of the sources fetched for the chains of the network.
+func SourcesPath(configDir string) string {
+	return filepath.Join(configDir, CacheSources)
+}
+
+// GoPath returns the path of the Go build and module caches shared by the chains of the SDK
+// version, e.g. v0.44.5.
+func GoPath(configDir, sdkVersion string) string {
+	if sdkVersion == "" {
+		sdkVersion = "unknown"
+	}
+	return filepath.Join(configDir, CacheGo, strings.ReplaceAll(sdkVersion, "/", "_"))
+}
+
+// BinariesPath returns the path of the cache of the binaries built for the chains.
+func BinariesPath(configDir string) string {
+	return filepath.Join(configDir, CacheBinaries)
+}
+
+// Caches returns the caches.
+func (m Manager) Caches() []Cache {
+	return m.caches
+}
+
+// Cache returns the cache by name.
+func (m Manager) Cache(name string) (Cache, error) {
+	for _, c := range m.caches {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Cache{}, fmt.Errorf("%w: %s", ErrCacheNotFound, name)
+}
+
+// Usage returns the disk usage of the caches by name, all the caches are returned when no names
+// are given.
+func (m Manager) Usage(names ...string) ([]Usage, error) {
+	caches, err := m.selectCaches(names)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]Usage, 0, len(caches))
+	for _, c := range caches {
+		u, err := usage(c)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, u)
+	}
+	return usages, nil
+}
+
+// Clean removes the entries of the caches by name that are not modified for the duration, all
+// the entries are removed when the duration is 0. all the caches are cleaned when no names
+// are given.
+func (m Manager) Clean(olderThan time.Duration, names ...string) ([]Entry, error) {
+	usages, err := m.Usage(names...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, u := range usages {
+		for _, e := range u.Entries {
+			if olderThan == 0 || time.Since(e.ModTime) > olderThan {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return entries, remove(entries)
+}
+
+func (m Manager) selectCaches(names []string) ([]Cache, error) {
+	if len(names) == 0 {
+		return m.caches, nil
+	}
+	caches := make([]Cache, 0, len(names))
+	for _, name := range names {
+		c, err := m.Cache(name)
+		if err != nil {
+			return nil, err
+		}
+		caches = append(caches, c)
+	}
+	return caches, nil
+}
+
+func usage(c Cache) (Usage, error) {
+	u := Usage{Cache: c}
+
+	dirEntries, err := os.ReadDir(c.Path)
+	if os.IsNotExist(err) {
+		return u, nil
+	}
+	if err != nil {
+		return u, err
+	}
+
+	for _, d := range dirEntries {
+		e := Entry{
+			Cache: c.Name,
+			Path:  filepath.Join(c.Path, d.Name()),
+		}
+		err := filepath.WalkDir(e.Path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if info.ModTime().After(e.ModTime) {
+				e.ModTime = info.ModTime()
+			}
+			e.Size += info.Size()
+			return nil
+		})
+		if err != nil {
+			return u, err
+		}
+
+		// the entries without files are as old as their dir.
+		if e.ModTime.IsZero() {
+			info, err := d.Info()
+			if err != nil {
+				return u, err
+			}
+			e.ModTime = info.ModTime()
+		}
+		u.Entries = append(u.Entries, e)
+		u.Size += e.Size
+	}
+
+	sortByAge(u.Entries)
+	return u, nil
+}
+
+// sortByAge sorts the entries from the oldest to the newest.
+func sortByAge(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ModTime.Before(entries[j].ModTime)
+	})
+}
+
+func remove(entries []Entry) error {
+	for _, e := range entries {
+		if err := os.RemoveAll(e.Path); err == nil {
+			continue
+		}
+		// the dirs of the Go module caches are read-only, they are made writable to be removed.
+		if err := makeWritable(e.Path); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(e.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func makeWritable(path string) error {
+	return filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return os.Chmod(path, 0755)
+	})
+}
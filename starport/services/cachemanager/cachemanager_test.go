@@ -0,0 +1,165 @@
+package cachemanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeEntry(t *testing.T, path string, size int, age time.Duration) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func newTestManager(t *testing.T) (Manager, string) {
+	dir := t.TempDir()
+	return New(dir, DefaultCaches(dir)...), dir
+}
+
+func TestUsage(t *testing.T) {
+	m, dir := newTestManager(t)
+	writeEntry(t, filepath.Join(dir, CacheCodegen, "new", "sha256.json"), 10, time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheCodegen, "old", "sha256.json"), 20, 48*time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheLocalChains, "chain", "exported_genesis.json"), 30, 0)
+
+	usages, err := m.Usage()
+	require.NoError(t, err)
+	require.Len(t, usages, 5)
+
+	require.Equal(t, CacheCodegen, usages[0].Name)
+	require.EqualValues(t, 30, usages[0].Size)
+	require.Len(t, usages[0].Entries, 2)
+	require.Equal(t, filepath.Join(dir, CacheCodegen, "old"), usages[0].Entries[0].Path)
+	require.EqualValues(t, 20, usages[0].Entries[0].Size)
+
+	require.EqualValues(t, 30, usages[1].Size)
+	require.Empty(t, usages[2].Entries)
+
+	_, err = m.Usage("unknown")
+	require.ErrorIs(t, err, ErrCacheNotFound)
+}
+
+func TestClean(t *testing.T) {
+	m, dir := newTestManager(t)
+	writeEntry(t, filepath.Join(dir, CacheCodegen, "new", "sha256.json"), 10, time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheCodegen, "old", "sha256.json"), 20, 48*time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheLocalChains, "chain", "exported_genesis.json"), 30, 48*time.Hour)
+
+	removed, err := m.Clean(24*time.Hour, CacheCodegen)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.NoDirExists(t, filepath.Join(dir, CacheCodegen, "old"))
+	require.DirExists(t, filepath.Join(dir, CacheCodegen, "new"))
+	require.DirExists(t, filepath.Join(dir, CacheLocalChains, "chain"))
+
+	removed, err = m.Clean(0)
+	require.NoError(t, err)
+	require.Len(t, removed, 2)
+	require.NoDirExists(t, filepath.Join(dir, CacheCodegen, "new"))
+	require.NoDirExists(t, filepath.Join(dir, CacheLocalChains, "chain"))
+}
+
+func TestCleanReadOnly(t *testing.T) {
+	m, dir := newTestManager(t)
+	mod := filepath.Join(GoPath(dir, "v0.44.5"), "mod", "github.com", "foo@v1.0.0")
+	writeEntry(t, filepath.Join(mod, "go.mod"), 10, time.Hour)
+	require.NoError(t, os.Chmod(mod, 0555))
+
+	removed, err := m.Clean(0, CacheGo)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.NoDirExists(t, GoPath(dir, "v0.44.5"))
+}
+
+func TestGC(t *testing.T) {
+	m, dir := newTestManager(t)
+	writeEntry(t, filepath.Join(dir, CacheCodegen, "a"), 100, time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheSources, "b"), 100, 2*time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheSources, "c"), 100, 3*time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheSources, "d"), 100, 72*time.Hour)
+	writeEntry(t, filepath.Join(dir, CacheLocalChains, "e"), 100, 72*time.Hour)
+
+	removed, err := m.GC(Policy{
+		MaxAge:  "2d",
+		MaxSize: "150B",
+		Caches:  []string{CacheCodegen, CacheSources},
+	})
+	require.NoError(t, err)
+	require.Len(t, removed, 3)
+	require.FileExists(t, filepath.Join(dir, CacheCodegen, "a"))
+	require.NoFileExists(t, filepath.Join(dir, CacheSources, "b"))
+	require.NoFileExists(t, filepath.Join(dir, CacheSources, "c"))
+	require.NoFileExists(t, filepath.Join(dir, CacheSources, "d"))
+	require.FileExists(t, filepath.Join(dir, CacheLocalChains, "e"))
+}
+
+func TestAutoGC(t *testing.T) {
+	m, dir := newTestManager(t)
+	writeEntry(t, filepath.Join(dir, CacheCodegen, "old"), 10, 40*24*time.Hour)
+
+	removed, err := m.AutoGC()
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+
+	// the interval of the default policy is not elapsed since the last run.
+	writeEntry(t, filepath.Join(dir, CacheCodegen, "old"), 10, 40*24*time.Hour)
+	removed, err = m.AutoGC()
+	require.NoError(t, err)
+	require.Empty(t, removed)
+	require.FileExists(t, filepath.Join(dir, CacheCodegen, "old"))
+}
+
+func TestPolicy(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	p, err := m.Policy()
+	require.NoError(t, err)
+	require.Equal(t, DefaultPolicy(), p)
+
+	p = Policy{MaxAge: "7d", MaxSize: "1GB", Caches: []string{CacheSources}}
+	require.NoError(t, m.SetPolicy(p))
+	got, err := m.Policy()
+	require.NoError(t, err)
+	require.Equal(t, p, got)
+
+	require.Error(t, m.SetPolicy(Policy{MaxAge: "a week"}))
+	require.ErrorIs(t, m.SetPolicy(Policy{Caches: []string{"unknown"}}), ErrCacheNotFound)
+}
+
+func TestParseSize(t *testing.T) {
+	for s, want := range map[string]int64{
+		"":      0,
+		"512":   512,
+		"10B":   10,
+		"2KB":   2 << 10,
+		"1.5mb": 3 << 19,
+		"2GB":   2 << 30,
+	} {
+		got, err := ParseSize(s)
+		require.NoError(t, err, s)
+		require.Equal(t, want, got, s)
+	}
+
+	_, err := ParseSize("lots")
+	require.Error(t, err)
+	require.Equal(t, "1.5MB", FormatSize(3<<19))
+	require.Equal(t, "12B", FormatSize(12))
+}
+
+func TestParseAge(t *testing.T) {
+	d, err := ParseAge("30d")
+	require.NoError(t, err)
+	require.Equal(t, 30*24*time.Hour, d)
+
+	d, err = ParseAge("12h")
+	require.NoError(t, err)
+	require.Equal(t, 12*time.Hour, d)
+
+	_, err = ParseAge("-1h")
+	require.Error(t, err)
+}
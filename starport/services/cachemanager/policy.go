@@ -0,0 +1,171 @@
+package cachemanager
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	policyFile = "cache.yml"
+
+	// lastGCFile is touched each time the automatic GC runs.
+	lastGCFile = "cache.gc"
+)
+
+// Policy is the GC policy of the caches, it's configured globally in the config dir of Starport.
+type Policy struct {
+	// MaxAge is the age after which the entries are removed, e.g. 720h or 30d. the entries are
+	// kept regardless of their age when it's empty.
+	MaxAge string `yaml:"max_age,omitempty" json:"max_age,omitempty"`
+
+	// MaxSize is the total size of the caches above which the oldest entries are removed, e.g.
+	// 500MB or 2GB. the size is not limited when it's empty.
+	MaxSize string `yaml:"max_size,omitempty" json:"max_size,omitempty"`
+
+	// Interval is the time between two runs of the automatic GC, e.g. 24h. the automatic GC is
+	// disabled when it's empty.
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty"`
+
+	// Caches are the names of the caches collected by the GC.
+	Caches []string `yaml:"caches,omitempty" json:"caches,omitempty"`
+}
+
+// DefaultPolicy is the policy used until the user configures one, it collects the entries of
+// the caches that are recreated transparently once they are not used for 30 days.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAge:   "30d",
+		Interval: "24h",
+		Caches:   []string{CacheCodegen, CacheSources, CacheGo, CacheBinaries},
+	}
+}
+
+// Validate checks that the durations and the size of the policy can be parsed.
+func (p Policy) Validate() error {
+	if _, err := ParseAge(p.MaxAge); err != nil {
+		return err
+	}
+	if _, err := ParseSize(p.MaxSize); err != nil {
+		return err
+	}
+	_, err := ParseAge(p.Interval)
+	return err
+}
+
+// Policy returns the GC policy of the user, the default policy is returned when it's not configured.
+func (m Manager) Policy() (Policy, error) {
+	data, err := os.ReadFile(filepath.Join(m.configDir, policyFile))
+	if os.IsNotExist(err) {
+		return DefaultPolicy(), nil
+	}
+	if err != nil {
+		return Policy{}, err
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return Policy{}, err
+	}
+	return p, p.Validate()
+}
+
+// SetPolicy saves the GC policy of the user.
+func (m Manager) SetPolicy(p Policy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if _, err := m.selectCaches(p.Caches); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.configDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.configDir, policyFile), data, 0644)
+}
+
+// GC removes the entries of the caches of the policy that are older than its max age, and then
+// the oldest entries until the total size of the caches is below its max size.
+func (m Manager) GC(p Policy) ([]Entry, error) {
+	maxAge, err := ParseAge(p.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	maxSize, err := ParseSize(p.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Caches) == 0 || (maxAge == 0 && maxSize == 0) {
+		return nil, nil
+	}
+
+	usages, err := m.Usage(p.Caches...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		kept    []Entry
+		removed []Entry
+		size    int64
+	)
+	for _, u := range usages {
+		for _, e := range u.Entries {
+			if maxAge != 0 && time.Since(e.ModTime) > maxAge {
+				removed = append(removed, e)
+				continue
+			}
+			kept = append(kept, e)
+			size += e.Size
+		}
+	}
+
+	if maxSize != 0 {
+		sortByAge(kept)
+		for len(kept) > 0 && size > maxSize {
+			removed = append(removed, kept[0])
+			size -= kept[0].Size
+			kept = kept[1:]
+		}
+	}
+
+	return removed, remove(removed)
+}
+
+// AutoGC runs the GC with the policy of the user when its interval is elapsed since the last run.
+func (m Manager) AutoGC() ([]Entry, error) {
+	p, err := m.Policy()
+	if err != nil {
+		return nil, err
+	}
+	interval, err := ParseAge(p.Interval)
+	if err != nil || interval == 0 {
+		return nil, err
+	}
+
+	lastGCPath := filepath.Join(m.configDir, lastGCFile)
+	if info, err := os.Stat(lastGCPath); err == nil && time.Since(info.ModTime()) < interval {
+		return nil, nil
+	}
+
+	// the run is recorded first so a failing GC is not retried by every command.
+	if err := os.MkdirAll(m.configDir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(lastGCPath, nil, 0644); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := os.Chtimes(lastGCPath, now, now); err != nil {
+		return nil, err
+	}
+
+	return m.GC(p)
+}
@@ -0,0 +1,75 @@
+package cachemanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses a size in bytes with an optional B, KB, MB, GB or TB unit, e.g. 500MB. the
+// units are powers of 1024 and an empty size is 0.
+func ParseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+
+	multiplier := int64(1)
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s, multiplier = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.bytes
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q, expected e.g. 500MB or 2GB", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// FormatSize formats a size in bytes with the largest unit it has at least one of.
+func FormatSize(size int64) string {
+	for _, u := range sizeUnits {
+		if size >= u.bytes && u.bytes > 1 {
+			return fmt.Sprintf("%.1f%s", float64(size)/float64(u.bytes), u.suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+// ParseAge parses a duration like time.ParseDuration, days are accepted with the d unit, e.g.
+// 30d. an empty duration is 0.
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q, expected e.g. 30d or 12h", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected e.g. 30d or 12h", s)
+	}
+	return d, nil
+}
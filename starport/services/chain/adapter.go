@@ -0,0 +1,328 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/tendermint/starport/starport/pkg/goanalysis"
+)
+
+// AdapterFile is the file of the adapter of the chains that are not scaffolded by Starport, it's
+// at the root of their source code.
+const AdapterFile = "starport.yml"
+
+// defaultNodeHomeVar is the var of the SDK apps that holds the default home of their node.
+const defaultNodeHomeVar = "DefaultNodeHome"
+
+// Adapter describes the layout of a chain that is not scaffolded by Starport, so it can be built,
+// served and published like the scaffolded chains. the empty fields are detected from the layout
+// of the scaffolded chains.
+type Adapter struct {
+	// Name is the name of the app, it's the name of the Go module by default.
+	Name string `yaml:"name,omitempty"`
+
+	// Main is the path of the main package of the app's binary relative to the root of the chain.
+	Main string `yaml:"main,omitempty"`
+
+	// Binary is the name of the app's binary, e.g. gaiad.
+	Binary string `yaml:"binary,omitempty"`
+
+	// Home is the default home of the node of the app, e.g. $HOME/.gaia.
+	Home string `yaml:"home,omitempty"`
+
+	// Watch are the paths relative to the root of the chain that the app is built from, they're
+	// watched to rebuild the app during serve.
+	Watch []string `yaml:"watch,omitempty"`
+}
+
+// ReadAdapter reads the adapter of the chain at path, found is false when the chain has no adapter.
+func ReadAdapter(path string) (adapter Adapter, found bool, err error) {
+	data, err := os.ReadFile(filepath.Join(path, AdapterFile))
+	if os.IsNotExist(err) {
+		return Adapter{}, false, nil
+	}
+	if err != nil {
+		return Adapter{}, false, err
+	}
+	if err := yaml.Unmarshal(data, &adapter); err != nil {
+		return Adapter{}, false, fmt.Errorf("invalid %s: %w", AdapterFile, err)
+	}
+	return adapter, true, nil
+}
+
+// WriteAdapter writes the adapter of the chain at path.
+func WriteAdapter(path string, adapter Adapter) error {
+	data, err := yaml.Marshal(adapter)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(path, AdapterFile), data, 0644)
+}
+
+// Detection is the layout of a chain detected from its source code.
+type Detection struct {
+	Adapter
+
+	// MainPackages are all of the main packages of the chain.
+	MainPackages []string
+
+	// AppConstructor is the function that creates the app of the chain, e.g. app.NewGaiaApp.
+	AppConstructor string
+}
+
+type detectOptions struct {
+	main string
+}
+
+// DetectOption configures the detection of an adapter.
+type DetectOption func(*detectOptions)
+
+// DetectWithMain sets the main package of the app's binary relative to the root of the chain
+// instead of picking it from the main packages of the chain.
+func DetectWithMain(main string) DetectOption {
+	return func(o *detectOptions) {
+		o.main = filepath.ToSlash(filepath.Clean(main))
+	}
+}
+
+// DetectAdapter detects the adapter of the chain at path: its main package, the name of its binary,
+// the default home of its node and the paths it's built from. a chain without an app constructor
+// is not a Cosmos SDK chain.
+func DetectAdapter(path string, options ...DetectOption) (Detection, error) {
+	var o detectOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	app, err := NewAppAt(path)
+	if err != nil {
+		return Detection{}, err
+	}
+	root := app.Path
+
+	d := Detection{}
+	if d.AppConstructor, err = findAppConstructor(root); err != nil {
+		return Detection{}, err
+	}
+	if d.AppConstructor == "" {
+		return Detection{}, errors.New("no app constructor found, the app of a chain is created by a New...App function or by the New function of its app package")
+	}
+
+	mains, err := goanalysis.DiscoverMain(root)
+	if err != nil {
+		return Detection{}, err
+	}
+	for _, main := range mains {
+		rel, err := filepath.Rel(root, main)
+		if err != nil {
+			return Detection{}, err
+		}
+		d.MainPackages = append(d.MainPackages, filepath.ToSlash(rel))
+	}
+	sort.Strings(d.MainPackages)
+
+	if d.Main = o.main; d.Main == "" {
+		if d.Main, err = pickMainPackage(d.MainPackages); err != nil {
+			return Detection{}, err
+		}
+	} else if !containsString(d.MainPackages, d.Main) {
+		return Detection{}, fmt.Errorf("%s is not a main package, the main packages are: %s", d.Main, strings.Join(d.MainPackages, ", "))
+	}
+	d.Binary = filepath.Base(d.Main)
+	d.Name = strings.TrimSuffix(d.Binary, "d")
+	if d.Name == "" {
+		d.Name = d.Binary
+	}
+
+	if d.Home, err = findDefaultNodeHome(root); err != nil {
+		return Detection{}, err
+	}
+
+	d.Watch, err = sourceDirs(root)
+	return d, err
+}
+
+// pickMainPackage picks the main package of the app's binary, that's the only main package or the
+// only one in cmd named after a daemon, e.g. cmd/gaiad.
+func pickMainPackage(mains []string) (string, error) {
+	switch len(mains) {
+	case 0:
+		return "", errors.New("main package cannot be found")
+	case 1:
+		return mains[0], nil
+	}
+
+	var daemons []string
+	for _, main := range mains {
+		if strings.HasPrefix(main, "cmd/") && strings.HasSuffix(main, "d") {
+			daemons = append(daemons, main)
+		}
+	}
+	if len(daemons) == 1 {
+		return daemons[0], nil
+	}
+	return "", fmt.Errorf("%w: %s", goanalysis.ErrMultipleMainPackagesFound, strings.Join(mains, ", "))
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// walkGoFiles calls fn with the parsed Go files of the chain at root, the tests, the hidden dirs and
+// the vendored dependencies and the test data are skipped.
+func walkGoFiles(root string, mode parser.Mode, fn func(path string, f *ast.File) error) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, mode)
+		if err != nil {
+			return err
+		}
+		return fn(path, f)
+	})
+}
+
+// findAppConstructor finds the function of the chain at root that creates its app, that's a
+// New...App function or the New function of the app package like in the scaffolded chains. the
+// constructors of the app package are preferred.
+func findAppConstructor(root string) (string, error) {
+	var constructors []string
+	err := walkGoFiles(root, 0, func(_ string, f *ast.File) error {
+		if f.Name.Name == "main" {
+			return nil
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Type.Results == nil {
+				continue
+			}
+			name := fn.Name.Name
+			if strings.HasPrefix(name, "New") && strings.HasSuffix(name, "App") || name == "New" && f.Name.Name == "app" {
+				constructors = append(constructors, f.Name.Name+"."+name)
+			}
+		}
+		return nil
+	})
+	if err != nil || len(constructors) == 0 {
+		return "", err
+	}
+	sort.Strings(constructors)
+	for _, c := range constructors {
+		if strings.HasPrefix(c, "app.") {
+			return c, nil
+		}
+	}
+	return constructors[0], nil
+}
+
+// findDefaultNodeHome finds the default home of the node of the chain at root from the
+// DefaultNodeHome var of its app, e.g. $HOME/.gaia for filepath.Join(userHomeDir, ".gaia"). it's
+// empty when the home is not a literal.
+func findDefaultNodeHome(root string) (home string, err error) {
+	err = walkGoFiles(root, 0, func(_ string, f *ast.File) error {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if home != "" {
+				return false
+			}
+			var value ast.Expr
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok && id.Name == defaultNodeHomeVar && i < len(n.Rhs) {
+						value = n.Rhs[i]
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range n.Names {
+					if name.Name == defaultNodeHomeVar && i < len(n.Values) {
+						value = n.Values[i]
+					}
+				}
+			}
+			if dir := homeDirLiteral(value); dir != "" {
+				home = filepath.Join("$HOME", dir)
+			}
+			return true
+		})
+		return nil
+	})
+	return home, err
+}
+
+// homeDirLiteral returns the literal dir of a home joined to the home dir of the user, e.g. .gaia
+// for filepath.Join(userHomeDir, ".gaia").
+func homeDirLiteral(value ast.Expr) string {
+	call, ok := value.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Join" {
+		return ""
+	}
+	lit, ok := call.Args[1].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	dir, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// sourceDirs returns the top-level dirs of the chain at root that have Go packages, and its proto dirs.
+func sourceDirs(root string) ([]string, error) {
+	dirs := make(map[string]bool)
+	err := walkGoFiles(root, parser.PackageClauseOnly, func(path string, _ *ast.File) error {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if parts := strings.Split(filepath.ToSlash(rel), "/"); len(parts) > 1 {
+			dirs[parts[0]] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{"proto", "third_party"} {
+		if info, err := os.Stat(filepath.Join(root, dir)); err == nil && info.IsDir() {
+			dirs[dir] = true
+		}
+	}
+
+	watch := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		watch = append(watch, dir)
+	}
+	sort.Strings(watch)
+	return watch, nil
+}
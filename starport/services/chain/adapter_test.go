@@ -0,0 +1,104 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/goanalysis"
+)
+
+// writeFiles writes the files of a chain in dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestDetectAdapter(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module github.com/cosmos/gaia/v6\n\ngo 1.16\n",
+		"app/app.go": `package app
+
+import (
+	"os"
+	"path/filepath"
+)
+
+var DefaultNodeHome string
+
+func init() {
+	userHomeDir, _ := os.UserHomeDir()
+	DefaultNodeHome = filepath.Join(userHomeDir, ".gaia")
+}
+
+type GaiaApp struct{}
+
+func NewGaiaApp() *GaiaApp { return &GaiaApp{} }
+`,
+		"cmd/gaiad/main.go":          "package main\n\nfunc main() {}\n",
+		"cmd/gaiad/cmd/root.go":      "package cmd\n",
+		"contrib/tool/main.go":       "package main\n\nfunc main() {}\n",
+		"x/globalfee/module.go":      "package globalfee\n",
+		"x/globalfee/module_test.go": "package globalfee\n",
+		"proto/gaia/fee.proto":       "syntax = \"proto3\";\n",
+		"docs/README.md":             "# Gaia\n",
+	})
+
+	d, err := DetectAdapter(dir)
+	require.NoError(t, err)
+	require.Equal(t, Detection{
+		Adapter: Adapter{
+			Name:   "gaia",
+			Main:   "cmd/gaiad",
+			Binary: "gaiad",
+			Home:   "$HOME/.gaia",
+			Watch:  []string{"app", "cmd", "contrib", "proto", "x"},
+		},
+		MainPackages:   []string{"cmd/gaiad", "contrib/tool"},
+		AppConstructor: "app.NewGaiaApp",
+	}, d)
+
+	d, err = DetectAdapter(dir, DetectWithMain("contrib/tool"))
+	require.NoError(t, err)
+	require.Equal(t, "tool", d.Binary)
+
+	_, err = DetectAdapter(dir, DetectWithMain("x/globalfee"))
+	require.EqualError(t, err, "x/globalfee is not a main package, the main packages are: cmd/gaiad, contrib/tool")
+
+	writeFiles(t, dir, map[string]string{"cmd/gaiacli/main.go": "package main\n\nfunc main() {}\n"})
+	writeFiles(t, dir, map[string]string{"cmd/keysd/main.go": "package main\n\nfunc main() {}\n"})
+	_, err = DetectAdapter(dir)
+	require.ErrorIs(t, err, goanalysis.ErrMultipleMainPackagesFound)
+}
+
+func TestDetectAdapterNoApp(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":  "module github.com/foo/bar\n\ngo 1.16\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	_, err := DetectAdapter(dir)
+	require.EqualError(t, err, "no app constructor found, the app of a chain is created by a New...App function or by the New function of its app package")
+}
+
+func TestAdapterFile(t *testing.T) {
+	dir := t.TempDir()
+
+	_, found, err := ReadAdapter(dir)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	adapter := Adapter{Name: "gaia", Main: "cmd/gaiad", Binary: "gaiad", Home: "$HOME/.gaia", Watch: []string{"app", "x"}}
+	require.NoError(t, WriteAdapter(dir, adapter))
+
+	got, found, err := ReadAdapter(dir)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, adapter, got)
+}
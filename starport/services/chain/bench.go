@@ -0,0 +1,310 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/cosmosbench"
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// BenchMsgBankSend is the msg of the load tests that send coins from the signers to themselves.
+const BenchMsgBankSend = "bank-send"
+
+const (
+	defaultBenchSigners  = 10
+	defaultBenchTPS      = 100
+	defaultBenchDuration = time.Minute
+)
+
+type benchOptions struct {
+	signers  int
+	from     string
+	fields   []byte
+	tps      int
+	duration time.Duration
+	gas      uint64
+	fees     string
+}
+
+// BenchOption configures a load test of the chain.
+type BenchOption func(*benchOptions)
+
+// BenchSigners sets the number of concurrent signers, 10 by default.
+func BenchSigners(n int) BenchOption {
+	return func(o *benchOptions) {
+		o.signers = n
+	}
+}
+
+// BenchFrom sets the account that funds new signers, the faucet account or the first account of the
+// config by default. the test accounts of the chain are the signers when the account is not set.
+func BenchFrom(account string) BenchOption {
+	return func(o *benchOptions) {
+		o.from = account
+	}
+}
+
+// BenchMsgFields sets the fields of the msgs of the modules of the chain in JSON.
+func BenchMsgFields(fields []byte) BenchOption {
+	return func(o *benchOptions) {
+		o.fields = fields
+	}
+}
+
+// BenchTPS sets the rate the txs are broadcasted at, 100 txs per second by default.
+func BenchTPS(tps int) BenchOption {
+	return func(o *benchOptions) {
+		o.tps = tps
+	}
+}
+
+// BenchDuration sets the time the txs are broadcasted for, one minute by default.
+func BenchDuration(duration time.Duration) BenchOption {
+	return func(o *benchOptions) {
+		o.duration = duration
+	}
+}
+
+// BenchGas sets the gas limit of the txs, the gas of a tx is simulated by default.
+func BenchGas(gas uint64) BenchOption {
+	return func(o *benchOptions) {
+		o.gas = gas
+	}
+}
+
+// BenchFees sets the fees of each tx.
+func BenchFees(fees string) BenchOption {
+	return func(o *benchOptions) {
+		o.fees = fees
+	}
+}
+
+// Bench drives load against the served chain with txs of msg and reports their latency, their
+// throughput and their gas. msg is BenchMsgBankSend or the name of a msg of the modules of the chain,
+// resolved with the gRPC reflection of the node. the txs are signed by the test accounts of the chain
+// when it has some, otherwise by new accounts kept in memory, funded by a single tx from an account of
+// the keyring of the chain with a coin and the fees of the txs.
+func (c *Chain) Bench(ctx context.Context, msg string, options ...BenchOption) (cosmosbench.Report, error) {
+	o := benchOptions{
+		signers:  defaultBenchSigners,
+		tps:      defaultBenchTPS,
+		duration: defaultBenchDuration,
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+	if o.signers <= 0 {
+		return cosmosbench.Report{}, errors.New("the number of signers must be positive")
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return cosmosbench.Report{}, err
+	}
+
+	var fees sdktypes.Coins
+	if o.fees != "" {
+		if fees, err = sdktypes.ParseCoinsNormalized(o.fees); err != nil {
+			return cosmosbench.Report{}, errors.Wrap(err, "invalid fees")
+		}
+	}
+
+	registry, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	if err != nil {
+		return cosmosbench.Report{}, err
+	}
+
+	var signers benchSigners
+	if o.from == "" && conf.TestAccounts.Count > 0 {
+		signers, err = c.benchTestAccounts(conf, registry, o.signers)
+	} else {
+		signers, err = c.benchFundedSigners(ctx, conf, registry, o, fees)
+	}
+	if err != nil {
+		return cosmosbench.Report{}, err
+	}
+
+	// the msgs are resolved before the signers are funded.
+	var msgFunc cosmosbench.MsgFunc
+	if msg == BenchMsgBankSend {
+		msgFunc = cosmosbench.BankSend(sdktypes.NewCoins(signers.coin))
+	} else {
+		conn, err := grpc.DialContext(ctx, localAddress(conf.Host.GRPC), grpc.WithInsecure())
+		if err != nil {
+			return cosmosbench.Report{}, err
+		}
+		defer conn.Close()
+		if msgFunc, err = cosmosbench.ReflectMsg(ctx, conn, msg, o.fields); err != nil {
+			return cosmosbench.Report{}, err
+		}
+	}
+
+	if signers.fund != nil {
+		fmt.Fprintf(c.stdLog().out, "💰 Funding %d signers from %s...\n", len(signers.signers), signers.funder)
+		if err := signers.fund(); err != nil {
+			return cosmosbench.Report{}, errors.Wrap(err, "cannot fund the signers")
+		}
+	}
+
+	benchClient, err := cosmosclient.New(ctx,
+		cosmosclient.WithNodeAddress(xurl.HTTP(conf.Host.RPC)),
+		cosmosclient.WithAccountRegistry(registry),
+		cosmosclient.WithAddressPrefix(signers.prefix),
+	)
+	if err != nil {
+		return cosmosbench.Report{}, errors.Wrap(err, "cannot connect to the chain, make sure that it's served")
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🏋️  Broadcasting %d txs per second for %s...\n", o.tps, o.duration)
+	return cosmosbench.Run(ctx, benchClient, signers.signers, msgFunc,
+		cosmosbench.TPS(o.tps),
+		cosmosbench.Duration(o.duration),
+		cosmosbench.Gas(o.gas),
+		cosmosbench.Fees(o.fees),
+	)
+}
+
+// benchSigners are the signers of a load test, the coin is sent by the bank sends of the signers.
+// fund funds the signers from the account of funder when they're not funded yet.
+type benchSigners struct {
+	signers []cosmosbench.Signer
+	prefix  string
+	coin    sdktypes.Coin
+	funder  string
+	fund    func() error
+}
+
+// benchTestAccounts returns the test accounts of the chain as the signers, up to n of them, their keys
+// are imported from their export to the registry. they're funded at genesis with the coins of the
+// test accounts of the config.
+func (c *Chain) benchTestAccounts(conf chainconfig.Config, registry cosmosaccount.Registry, n int) (benchSigners, error) {
+	testAccounts, err := c.TestAccounts()
+	if err != nil {
+		return benchSigners{}, err
+	}
+	coin, err := sdktypes.ParseCoinNormalized(conf.TestAccounts.Coins[0])
+	if err != nil {
+		return benchSigners{}, err
+	}
+
+	signers := benchSigners{coin: sdktypes.NewInt64Coin(coin.Denom, 1)}
+	for i, account := range testAccounts.Accounts {
+		if i == n {
+			break
+		}
+		if _, err := registry.Import(account.Name, account.PrivateKey, ""); err != nil {
+			return benchSigners{}, err
+		}
+		if signers.prefix, _, err = bech32.DecodeAndConvert(account.Address); err != nil {
+			return benchSigners{}, err
+		}
+		signers.signers = append(signers.signers, cosmosbench.Signer{Name: account.Name, Address: account.Address})
+	}
+	return signers, nil
+}
+
+// benchFundedSigners returns new accounts of the registry as the signers, they're funded from the
+// account of the from option. each signer is funded with the coin of the bank sends and the fees of
+// its share of the txs.
+func (c *Chain) benchFundedSigners(
+	ctx context.Context,
+	conf chainconfig.Config,
+	registry cosmosaccount.Registry,
+	o benchOptions,
+	fees sdktypes.Coins,
+) (benchSigners, error) {
+	if o.from == "" {
+		switch {
+		case conf.Faucet.Name != nil:
+			o.from = *conf.Faucet.Name
+		case len(conf.Accounts) > 0:
+			o.from = conf.Accounts[0].Name
+		default:
+			return benchSigners{}, errors.New("no account to fund the signers, set one with the config")
+		}
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return benchSigners{}, err
+	}
+	funder, err := commands.ShowAccount(ctx, o.from)
+	if err != nil {
+		return benchSigners{}, errors.Wrapf(err, "cannot find the account %s", o.from)
+	}
+	prefix, _, err := bech32.DecodeAndConvert(funder.Address)
+	if err != nil {
+		return benchSigners{}, err
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return benchSigners{}, err
+	}
+	backend, err := c.KeyringBackend()
+	if err != nil {
+		return benchSigners{}, err
+	}
+	funderClient, err := cosmosclient.New(ctx,
+		cosmosclient.WithNodeAddress(xurl.HTTP(conf.Host.RPC)),
+		cosmosclient.WithHome(home),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringBackend(backend)),
+		cosmosclient.WithAddressPrefix(prefix),
+		cosmosclient.WithInterfaceRegistrations(banktypes.RegisterInterfaces),
+	)
+	if err != nil {
+		return benchSigners{}, errors.Wrap(err, "cannot connect to the chain, make sure that it's served")
+	}
+
+	balances, err := funderClient.Balances(ctx, funder.Address)
+	if err != nil {
+		return benchSigners{}, err
+	}
+	if balances.Empty() {
+		return benchSigners{}, fmt.Errorf("the account %s has no coins to fund the signers", o.from)
+	}
+
+	var (
+		signers = benchSigners{prefix: prefix, coin: sdktypes.NewInt64Coin(balances[0].Denom, 1), funder: o.from}
+		txs     = int64(math.Ceil(float64(o.tps)*o.duration.Seconds()/float64(o.signers))) + 1
+		fund    = sdktypes.NewCoins(signers.coin).Add(multiplyCoins(fees, txs)...)
+		send    = &banktypes.MsgMultiSend{
+			Inputs: []banktypes.Input{{Address: funder.Address, Coins: multiplyCoins(fund, int64(o.signers))}},
+		}
+	)
+	for i := 0; i < o.signers; i++ {
+		name := fmt.Sprintf("bench-%d", i)
+		account, _, err := registry.Create(name)
+		if err != nil {
+			return benchSigners{}, err
+		}
+		signer := cosmosbench.Signer{Name: name, Address: account.Address(prefix)}
+		signers.signers = append(signers.signers, signer)
+		send.Outputs = append(send.Outputs, banktypes.Output{Address: signer.Address, Coins: fund})
+	}
+	signers.fund = func() error {
+		_, err := funderClient.BroadcastTx(o.from, send)
+		return err
+	}
+	return signers, nil
+}
+
+func multiplyCoins(coins sdktypes.Coins, n int64) sdktypes.Coins {
+	var multiplied sdktypes.Coins
+	for _, coin := range coins {
+		multiplied = multiplied.Add(sdktypes.NewCoin(coin.Denom, coin.Amount.MulRaw(n)))
+	}
+	return multiplied
+}
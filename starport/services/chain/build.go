@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/pkg/errors"
 
+	"github.com/tendermint/starport/starport/chainconfig"
 	"github.com/tendermint/starport/starport/pkg/checksum"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/exec"
@@ -35,6 +36,15 @@ func (c *Chain) Build(ctx context.Context, output string) (binaryName string, er
 		return "", err
 	}
 
+	conf, err := c.Config()
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.generateKeplrChainInfo(conf); err != nil {
+		return "", err
+	}
+
 	return c.Binary()
 }
 
@@ -51,7 +61,7 @@ func (c *Chain) build(ctx context.Context, output string) (err error) {
 		return err
 	}
 
-	buildFlags, err := c.preBuild(ctx)
+	buildFlags, env, err := c.preBuild(ctx)
 	if err != nil {
 		return err
 	}
@@ -60,13 +70,62 @@ func (c *Chain) build(ctx context.Context, output string) (err error) {
 	if err != nil {
 		return err
 	}
+	binary = gocmd.BinaryName(binary, runtime.GOOS)
 
 	path, err := c.discoverMain(c.app.Path)
 	if err != nil {
 		return err
 	}
 
-	return gocmd.BuildPath(ctx, output, binary, path, buildFlags)
+	// the binary built from the same source with the same flags is reused from the binaries cache.
+	key, err := c.binaryKey(ctx, path, buildFlags, env)
+	if err != nil {
+		return err
+	}
+	if ok, err := reuseBinary(key, output, binary); err == nil && ok {
+		fmt.Fprintln(c.stdLog().out, "♻️  Reusing the binary built from the same source...")
+		return nil
+	}
+
+	if err := gocmd.BuildPath(ctx, output, binary, path, buildFlags, exec.StepOption(step.Env(env...))); err != nil {
+		return err
+	}
+
+	// the build never fails because of the cache, the binary is built again next time.
+	_ = cacheBinary(key, output, binary)
+	return nil
+}
+
+// buildTarget builds the binary of the chain for the platform in output, the code is not generated.
+func (c *Chain) buildTarget(ctx context.Context, output, goos, goarch string) (err error) {
+	defer func() {
+		var exitErr *exec.ExitError
+
+		if errors.As(err, &exitErr) || errors.Is(err, goanalysis.ErrMultipleMainPackagesFound) {
+			err = &CannotBuildAppError{err}
+		}
+	}()
+
+	buildFlags, env, err := c.preBuild(ctx)
+	if err != nil {
+		return err
+	}
+
+	binary, err := c.Binary()
+	if err != nil {
+		return err
+	}
+
+	path, err := c.discoverMain(c.app.Path)
+	if err != nil {
+		return err
+	}
+
+	env = append([]string{
+		cmdrunner.Env(gocmd.EnvGOOS, goos),
+		cmdrunner.Env(gocmd.EnvGOARCH, goarch),
+	}, env...)
+	return gocmd.BuildPath(ctx, output, gocmd.BinaryName(binary, goos), path, buildFlags, exec.StepOption(step.Env(env...)))
 }
 
 // BuildRelease builds binaries for a release. targets is a list
@@ -85,7 +144,7 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		return "", err
 	}
 
-	buildFlags, err := c.preBuild(ctx)
+	buildFlags, env, err := c.preBuild(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -127,13 +186,13 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		defer os.RemoveAll(out)
 
 		buildOptions := []exec.Option{
-			exec.StepOption(step.Env(
+			exec.StepOption(step.Env(append([]string{
 				cmdrunner.Env(gocmd.EnvGOOS, goos),
 				cmdrunner.Env(gocmd.EnvGOARCH, goarch),
-			)),
+			}, env...)...)),
 		}
 
-		if err := gocmd.BuildPath(ctx, out, binary, mainPath, buildFlags, buildOptions...); err != nil {
+		if err := gocmd.BuildPath(ctx, out, gocmd.BinaryName(binary, goos), mainPath, buildFlags, buildOptions...); err != nil {
 			return "", err
 		}
 
@@ -163,42 +222,59 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 	return releasePath, checksum.Sum(releasePath, checksumPath)
 }
 
-func (c *Chain) preBuild(ctx context.Context) (buildFlags []string, err error) {
+func (c *Chain) preBuild(ctx context.Context) (buildFlags, env []string, err error) {
 	config, err := c.Config()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	chainID, err := c.ID()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	env, err = c.buildEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	envOption := exec.StepOption(step.Env(env...))
+
+	binary, err := c.Binary()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	ldFlags := config.Build.LDFlags
-	ldFlags = append(ldFlags,
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Name=%s", strings.Title(c.app.Name)),
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.AppName=%sd", c.app.Name),
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Version=%s", c.sourceVersion.tag),
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Commit=%s", c.sourceVersion.hash),
-		fmt.Sprintf("-X %s/cmd/%s/cmd.ChainID=%s", c.app.ImportPath, c.app.D(), chainID),
-	)
 	buildFlags = []string{
 		gocmd.FlagMod, gocmd.FlagModValueReadOnly,
-		gocmd.FlagLdflags, gocmd.Ldflags(ldFlags...),
+		gocmd.FlagLdflags, gocmd.Ldflags(c.ldFlags(config, binary, chainID)...),
+	}
+	if len(config.Build.Tags) > 0 {
+		buildFlags = append(buildFlags, gocmd.FlagTags, gocmd.Tags(config.Build.Tags...))
 	}
 
 	fmt.Fprintln(c.stdLog().out, "📦 Installing dependencies...")
 
-	if err := gocmd.ModTidy(ctx, c.app.Path); err != nil {
-		return nil, err
+	if err := gocmd.ModTidy(ctx, c.app.Path, envOption); err != nil {
+		return nil, nil, err
 	}
-	if err := gocmd.ModVerify(ctx, c.app.Path); err != nil {
-		return nil, err
+	if err := gocmd.ModVerify(ctx, c.app.Path, envOption); err != nil {
+		return nil, nil, err
 	}
 
 	fmt.Fprintln(c.stdLog().out, "🛠️  Building the blockchain...")
 
-	return buildFlags, nil
+	return buildFlags, env, nil
+}
+
+// ldFlags returns the ldflags of the config with the flags that set the version and the ID of the chain.
+func (c *Chain) ldFlags(config chainconfig.Config, binary, chainID string) []string {
+	return append(append([]string{}, config.Build.LDFlags...),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Name=%s", strings.Title(c.app.Name)),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.AppName=%s", binary),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Version=%s", c.sourceVersion.tag),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Commit=%s", c.sourceVersion.hash),
+		fmt.Sprintf("-X %s/cmd/%s/cmd.ChainID=%s", c.app.ImportPath, c.app.D(), chainID),
+	)
 }
 
 func (c *Chain) discoverMain(path string) (pkgPath string, err error) {
@@ -210,6 +286,9 @@ func (c *Chain) discoverMain(path string) (pkgPath string, err error) {
 	if conf.Build.Main != "" {
 		return filepath.Join(c.app.Path, conf.Build.Main), nil
 	}
+	if c.adapter.Main != "" {
+		return filepath.Join(c.app.Path, c.adapter.Main), nil
+	}
 
 	path, err = goanalysis.DiscoverOneMain(path)
 	if err == goanalysis.ErrMultipleMainPackagesFound {
@@ -0,0 +1,146 @@
+package chain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/cmdrunner"
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+	"github.com/tendermint/starport/starport/services/cachemanager"
+)
+
+// buildEnv returns the env of the go commands building the chain, the Go build and module caches
+// are shared by the chains of the same SDK version so their dependencies are only downloaded and
+// compiled once. the caches set by the user with GOCACHE and GOMODCACHE are used when set.
+func (c *Chain) buildEnv() ([]string, error) {
+	configDir, err := chainconfig.ConfigDirPath()
+	if err != nil {
+		return nil, err
+	}
+	goPath := cachemanager.GoPath(configDir, c.Version.Version)
+
+	var env []string
+	if os.Getenv(gocmd.EnvGOCACHE) == "" {
+		env = append(env, cmdrunner.Env(gocmd.EnvGOCACHE, filepath.Join(goPath, "build")))
+	}
+	if os.Getenv(gocmd.EnvGOMODCACHE) == "" {
+		// the module cache is writable so the GC of Starport can remove it.
+		env = append(env,
+			cmdrunner.Env(gocmd.EnvGOMODCACHE, filepath.Join(goPath, "mod")),
+			cmdrunner.Env(gocmd.EnvGOFLAGS, strings.TrimSpace(os.Getenv(gocmd.EnvGOFLAGS)+" -modcacherw")),
+		)
+	}
+	return env, nil
+}
+
+// binaryKey returns the key of the binary built from the sources of the chain with the build
+// flags and the env, the binaries built with the same key are the same.
+func (c *Chain) binaryKey(ctx context.Context, mainPath string, buildFlags, env []string) (string, error) {
+	checksum, err := dirchange.Checksum(c.app.Path, append([]string{"go.mod", "go.sum"}, c.sourcePaths()...))
+	if err != nil {
+		return "", err
+	}
+	goVersion, err := gocmd.Version(ctx)
+	if err != nil {
+		return "", err
+	}
+	mainPath, err = filepath.Rel(c.app.Path, mainPath)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%x\n%s\n%s\n", checksum, goVersion, mainPath)
+	for _, flag := range buildFlags {
+		fmt.Fprintln(h, flag)
+	}
+	for _, e := range env {
+		fmt.Fprintln(h, e)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cachedBinaryPath returns the path of the binary built with the key in the binaries cache.
+func cachedBinaryPath(key, binary string) (string, error) {
+	configDir, err := chainconfig.ConfigDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cachemanager.BinariesPath(configDir), key, binary), nil
+}
+
+// reuseBinary copies the binary built with the key from the binaries cache to the output, ok is
+// false when it's not in the cache.
+func reuseBinary(key, output, binary string) (ok bool, err error) {
+	cached, err := cachedBinaryPath(key, binary)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(cached); os.IsNotExist(err) {
+		return false, nil
+	}
+	out, err := gocmd.BinaryPath(output, binary)
+	if err != nil {
+		return false, err
+	}
+	if err := copyBinary(cached, out); err != nil {
+		return false, err
+	}
+
+	// the binary is used, its entry is kept by the GC.
+	now := time.Now()
+	return true, os.Chtimes(cached, now, now)
+}
+
+// cacheBinary copies the binary built in the output to the binaries cache with the key.
+func cacheBinary(key, output, binary string) error {
+	cached, err := cachedBinaryPath(key, binary)
+	if err != nil {
+		return err
+	}
+	out, err := gocmd.BinaryPath(output, binary)
+	if err != nil {
+		return err
+	}
+	return copyBinary(out, cached)
+}
+
+// copyBinary copies the binary at src to dst, dst is replaced at once so a binary in use or
+// copied concurrently is never partially written.
+func copyBinary(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
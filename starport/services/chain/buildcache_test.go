@@ -0,0 +1,35 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyBinary(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "marsd")
+	require.NoError(t, os.WriteFile(src, []byte("binary"), 0644))
+
+	dst := filepath.Join(dir, "cache", "key", "marsd")
+	require.NoError(t, copyBinary(src, dst))
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "binary", string(data))
+
+	info, err := os.Stat(dst)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	// the binary is replaced and no temporary file is left.
+	require.NoError(t, os.WriteFile(src, []byte("new binary"), 0644))
+	require.NoError(t, copyBinary(src, dst))
+	data, err = os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "new binary", string(data))
+	entries, err := os.ReadDir(filepath.Dir(dst))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
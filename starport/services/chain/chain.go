@@ -15,6 +15,7 @@ import (
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
 	"github.com/tendermint/starport/starport/pkg/confile"
 	"github.com/tendermint/starport/starport/pkg/cosmosver"
+	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/pkg/repoversion"
 	"github.com/tendermint/starport/starport/pkg/xurl"
 )
@@ -50,6 +51,9 @@ type Chain struct {
 	// app holds info about blockchain app.
 	app App
 
+	// adapter describes the layout of the app when it's not scaffolded by Starport.
+	adapter Adapter
+
 	options chainOptions
 
 	Version cosmosver.Version
@@ -65,6 +69,8 @@ type Chain struct {
 	protoBuiltAtLeastOnce bool
 
 	stdout, stderr io.Writer
+
+	ev events.Bus
 }
 
 // chainOptions holds user given options that overwrites chain's defaults.
@@ -84,6 +90,12 @@ type chainOptions struct {
 
 	// path of a custom config file
 	ConfigFile string
+
+	// configProfile is the profile of the config file to use.
+	configProfile string
+
+	// configCommands enables the ${cmd:command} references of the config file.
+	configCommands bool
 }
 
 // Option configures Chain.
@@ -124,6 +136,21 @@ func ConfigFile(configFile string) Option {
 	}
 }
 
+// ConfigProfile selects a profile defined in the config file.
+func ConfigProfile(name string) Option {
+	return func(c *Chain) {
+		c.options.configProfile = name
+	}
+}
+
+// AllowConfigCommands enables the ${cmd:command} references of the config file, they run shell
+// commands when the config is parsed.
+func AllowConfigCommands() Option {
+	return func(c *Chain) {
+		c.options.configCommands = true
+	}
+}
+
 // EnableThirdPartyModuleCodegen enables code generation for third party modules,
 // including the SDK.
 func EnableThirdPartyModuleCodegen() Option {
@@ -132,6 +159,14 @@ func EnableThirdPartyModuleCodegen() Option {
 	}
 }
 
+// CollectEvents collects events from the chain, e.g. issues found in proto files.
+// Events are printed to the stdout when no event bus is set.
+func CollectEvents(ev events.Bus) Option {
+	return func(c *Chain) {
+		c.ev = ev
+	}
+}
+
 // New initializes a new Chain with options that its source lives at path.
 func New(path string, options ...Option) (*Chain, error) {
 	app, err := NewAppAt(path)
@@ -139,8 +174,17 @@ func New(path string, options ...Option) (*Chain, error) {
 		return nil, err
 	}
 
+	adapter, _, err := ReadAdapter(app.Path)
+	if err != nil {
+		return nil, err
+	}
+	if adapter.Name != "" {
+		app.Name = adapter.Name
+	}
+
 	c := &Chain{
 		app:            app,
+		adapter:        adapter,
 		logLevel:       LogSilent,
 		serveRefresher: make(chan struct{}, 1),
 		stdout:         io.Discard,
@@ -177,6 +221,15 @@ func New(path string, options ...Option) (*Chain, error) {
 	return c, nil
 }
 
+// sourcePaths returns the paths of the app that its binary is built from, they're declared by the
+// adapter of the chains that are not scaffolded by Starport.
+func (c *Chain) sourcePaths() []string {
+	if len(c.adapter.Watch) > 0 {
+		return append([]string(nil), c.adapter.Watch...)
+	}
+	return append([]string(nil), appBackendSourceWatchPaths...)
+}
+
 func (c *Chain) appVersion() (v version, err error) {
 
 	ver, err := repoversion.Determine(c.app.Path)
@@ -223,7 +276,11 @@ func (c *Chain) Config() (chainconfig.Config, error) {
 	if configPath == "" {
 		return chainconfig.DefaultConf, nil
 	}
-	return chainconfig.ParseFile(configPath)
+	options := []chainconfig.ParseOption{chainconfig.WithProfile(c.options.configProfile)}
+	if c.options.configCommands {
+		options = append(options, chainconfig.WithCommandSecrets())
+	}
+	return chainconfig.ParseFile(configPath, options...)
 }
 
 // ID returns the chain's id.
@@ -261,6 +318,9 @@ func (c *Chain) Binary() (string, error) {
 	if conf.Build.Binary != "" {
 		return conf.Build.Binary, nil
 	}
+	if c.adapter.Binary != "" {
+		return c.adapter.Binary, nil
+	}
 
 	return c.app.D(), nil
 }
@@ -300,6 +360,9 @@ func (c *Chain) DefaultHome() (string, error) {
 	if config.Init.Home != "" {
 		return config.Init.Home, nil
 	}
+	if c.adapter.Home != "" {
+		return os.ExpandEnv(c.adapter.Home), nil
+	}
 
 	return c.plugin.Home(), nil
 }
@@ -406,27 +469,33 @@ func (c *Chain) KeyringBackend() (chaincmd.KeyringBackend, error) {
 
 // Commands returns the runner execute commands on the chain's binary
 func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
-	id, err := c.ID()
+	home, err := c.Home()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	home, err := c.Home()
+	config, err := c.Config()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	binary, err := c.Binary()
+	return c.commands(ctx, home, config.Host.RPC)
+}
+
+// commands returns the runner execute commands on the chain's binary for the node at home that
+// serves its RPC at rpcAddress.
+func (c *Chain) commands(ctx context.Context, home, rpcAddress string) (chaincmdrunner.Runner, error) {
+	id, err := c.ID()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	backend, err := c.KeyringBackend()
+	binary, err := c.Binary()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	config, err := c.Config()
+	backend, err := c.KeyringBackend()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
@@ -435,7 +504,7 @@ func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
 		chaincmd.WithChainID(id),
 		chaincmd.WithHome(home),
 		chaincmd.WithVersion(c.Version),
-		chaincmd.WithNodeAddress(xurl.TCP(config.Host.RPC)),
+		chaincmd.WithNodeAddress(xurl.TCP(rpcAddress)),
 		chaincmd.WithKeyringBackend(backend),
 	}
 
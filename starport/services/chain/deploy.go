@@ -0,0 +1,427 @@
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/otiai10/copy"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/p2p"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	"github.com/tendermint/starport/starport/pkg/confile"
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+	"github.com/tendermint/starport/starport/pkg/k8s"
+)
+
+const dockerfile = "Dockerfile"
+
+var nonDNSLabel = regexp.MustCompile(`[^a-z0-9-]+`)
+
+type deployOptions struct {
+	sentries    int
+	image       string
+	faucet      bool
+	faucetImage string
+	helm        bool
+}
+
+// DeployOption configures the deployment of the chain.
+type DeployOption func(*deployOptions)
+
+// DeploySentries deploys n sentries in front of the validators, the validators only connect to the
+// sentries and the sentries serve the clients.
+func DeploySentries(n int) DeployOption {
+	return func(o *deployOptions) {
+		o.sentries = n
+	}
+}
+
+// DeployImage sets the image of the chain, <name>:latest by default.
+func DeployImage(image string) DeployOption {
+	return func(o *deployOptions) {
+		o.image = image
+	}
+}
+
+// DeployFaucet deploys the faucet of the config served by Starport from the image.
+func DeployFaucet(image string) DeployOption {
+	return func(o *deployOptions) {
+		o.faucet = true
+		o.faucetImage = image
+	}
+}
+
+// DeployHelm writes a Helm chart of the chain next to the manifests.
+func DeployHelm() DeployOption {
+	return func(o *deployOptions) {
+		o.helm = true
+	}
+}
+
+// DeployK8s initializes the chain and writes to dir the Kubernetes manifests that deploy the validators
+// of the config with their genesis, a Dockerfile that builds the image of the chain and optionally a
+// Helm chart of the chain in a dir named after the chain. the chain is initialized in its home, the
+// deployed nodes are reachable by each other in the cluster and serve their servers on all interfaces.
+func (c *Chain) DeployK8s(ctx context.Context, dir string, options ...DeployOption) (k8s.Chain, error) {
+	var o deployOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+	if o.sentries < 0 {
+		return k8s.Chain{}, errors.New("the number of sentries can't be negative")
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return k8s.Chain{}, err
+	}
+	if o.faucet && (conf.Faucet.Name == nil || c.ConfigPath() == "") {
+		return k8s.Chain{}, ErrFaucetIsNotEnabled
+	}
+
+	if _, err := c.Build(ctx, ""); err != nil {
+		return k8s.Chain{}, err
+	}
+
+	fmt.Fprintln(c.stdLog().out, "💿 Initializing the app...")
+	if err := c.Init(ctx, true); err != nil {
+		return k8s.Chain{}, err
+	}
+
+	chainID, err := c.ID()
+	if err != nil {
+		return k8s.Chain{}, err
+	}
+	binary, err := c.Binary()
+	if err != nil {
+		return k8s.Chain{}, err
+	}
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return k8s.Chain{}, err
+	}
+	genesis, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return k8s.Chain{}, err
+	}
+
+	deployment := k8s.Chain{
+		Name:    dnsLabel(c.Name()),
+		ChainID: chainID,
+		Binary:  binary,
+		Image:   o.image,
+		Genesis: genesis,
+	}
+	if deployment.Image == "" {
+		deployment.Image = deployment.Name + ":latest"
+	}
+
+	if deployment.Nodes, err = c.deployNodes(conf, deployment, o.sentries); err != nil {
+		return k8s.Chain{}, err
+	}
+
+	if o.faucet {
+		if deployment.Faucet, err = c.deployFaucet(ctx, conf, o.faucetImage); err != nil {
+			return k8s.Chain{}, err
+		}
+	}
+
+	if err := deployment.WriteManifests(dir); err != nil {
+		return k8s.Chain{}, err
+	}
+	if o.helm {
+		if err := deployment.WriteChart(filepath.Join(dir, deployment.Name)); err != nil {
+			return k8s.Chain{}, err
+		}
+	}
+	if err := c.writeDockerfile(conf, deployment, dir); err != nil {
+		return k8s.Chain{}, err
+	}
+	return deployment, nil
+}
+
+// deployNodes returns the nodes of the validators initialized in their homes and the sentries in front
+// of them, the configs of the nodes are changed to run in the cluster.
+func (c *Chain) deployNodes(conf chainconfig.Config, deployment k8s.Chain, sentries int) ([]k8s.Node, error) {
+	nodes, err := c.nodes(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	type deployNode struct {
+		k8s.Node
+		dir, peer string
+	}
+	var deployNodes []deployNode
+
+	// the keys of the node are generated when keysDir is empty.
+	add := func(name string, role k8s.Role, configDir, keysDir string, keys ...string) error {
+		n := deployNode{
+			Node: k8s.Node{Name: name, Role: role, Keys: make(map[string][]byte)},
+			dir:  filepath.Join(tmp, name),
+		}
+		for _, file := range []string{"app.toml", "client.toml", "config.toml"} {
+			if err := copy.Copy(filepath.Join(configDir, file), filepath.Join(n.dir, file)); err != nil {
+				return err
+			}
+		}
+
+		if keysDir == "" {
+			keysDir = filepath.Join(tmp, name+"-keys")
+			if err := os.MkdirAll(keysDir, 0755); err != nil {
+				return err
+			}
+		}
+		nodeKey, err := p2p.LoadOrGenNodeKey(filepath.Join(keysDir, "node_key.json"))
+		if err != nil {
+			return err
+		}
+		n.peer = fmt.Sprintf("%s@%s:%d", nodeKey.ID(), deployment.Resource(name), k8s.PortP2P)
+
+		for _, key := range append([]string{"node_key.json"}, keys...) {
+			if n.Keys[key], err = os.ReadFile(filepath.Join(keysDir, key)); err != nil {
+				return err
+			}
+		}
+		deployNodes = append(deployNodes, n)
+		return nil
+	}
+
+	for _, n := range nodes {
+		name := dnsLabel(n.validator.Name)
+		if name == "" {
+			name = string(k8s.RoleValidator)
+		}
+		configDir := filepath.Join(n.home, "config")
+		if err := add(name, k8s.RoleValidator, configDir, configDir, "priv_validator_key.json"); err != nil {
+			return nil, err
+		}
+	}
+
+	// the sentries have the configs of the chain's node.
+	for i := 0; i < sentries; i++ {
+		name := fmt.Sprintf("%s-%d", k8s.RoleSentry, i)
+		if err := add(name, k8s.RoleSentry, filepath.Join(nodes[0].home, "config"), ""); err != nil {
+			return nil, err
+		}
+	}
+
+	peers := func(role k8s.Role, except string) string {
+		var peers []string
+		for _, n := range deployNodes {
+			if n.Role == role && n.Name != except {
+				peers = append(peers, n.peer)
+			}
+		}
+		return strings.Join(peers, ",")
+	}
+	var validatorIDs []string
+	for _, n := range deployNodes {
+		if n.Role == k8s.RoleValidator {
+			validatorIDs = append(validatorIDs, strings.Split(n.peer, "@")[0])
+		}
+	}
+
+	deployed := make([]k8s.Node, 0, len(deployNodes))
+	for _, n := range deployNodes {
+		p2pConfig := map[string]interface{}{
+			"laddr": fmt.Sprintf("tcp://0.0.0.0:%d", k8s.PortP2P),
+			// the nodes are resolved by the names of their Services.
+			"addr_book_strict":   false,
+			"allow_duplicate_ip": true,
+		}
+		switch {
+		case sentries == 0:
+			p2pConfig["persistent_peers"] = peers(k8s.RoleValidator, n.Name)
+		case n.Role == k8s.RoleValidator:
+			// the validators are only known by the sentries.
+			p2pConfig["persistent_peers"] = peers(k8s.RoleSentry, "")
+			p2pConfig["pex"] = false
+		default:
+			others := peers(k8s.RoleSentry, n.Name)
+			if others != "" {
+				others = "," + others
+			}
+			p2pConfig["persistent_peers"] = peers(k8s.RoleValidator, "") + others
+			p2pConfig["private_peer_ids"] = strings.Join(validatorIDs, ",")
+			p2pConfig["unconditional_peer_ids"] = strings.Join(validatorIDs, ",")
+		}
+
+		configs := []struct {
+			file    string
+			changes map[string]interface{}
+		}{
+			{"config.toml", map[string]interface{}{
+				"moniker": n.Name,
+				"rpc":     map[string]interface{}{"laddr": fmt.Sprintf("tcp://0.0.0.0:%d", k8s.PortRPC)},
+				"p2p":     p2pConfig,
+			}},
+			{"app.toml", map[string]interface{}{
+				"api":      map[string]interface{}{"enable": true, "address": fmt.Sprintf("tcp://0.0.0.0:%d", k8s.PortAPI)},
+				"grpc":     map[string]interface{}{"address": fmt.Sprintf("0.0.0.0:%d", k8s.PortGRPC)},
+				"grpc-web": map[string]interface{}{"address": fmt.Sprintf("0.0.0.0:%d", k8s.PortGRPC+1)},
+			}},
+			{"client.toml", map[string]interface{}{
+				"node": fmt.Sprintf("tcp://localhost:%d", k8s.PortRPC),
+			}},
+		}
+
+		n.Config = make(map[string][]byte)
+		for _, config := range configs {
+			path := filepath.Join(n.dir, config.file)
+			if err := updateConfigFile(confile.DefaultTOMLEncodingCreator, path, config.changes); err != nil {
+				return nil, err
+			}
+			if n.Config[config.file], err = os.ReadFile(path); err != nil {
+				return nil, err
+			}
+		}
+		deployed = append(deployed, n.Node)
+	}
+	return deployed, nil
+}
+
+// deployFaucet returns the faucet of the config served from the image with the faucet account of the
+// keyring of the chain.
+func (c *Chain) deployFaucet(ctx context.Context, conf chainconfig.Config, image string) (*k8s.Faucet, error) {
+	_, port, err := net.SplitHostPort(chainconfig.FaucetHost(conf))
+	if err != nil {
+		return nil, err
+	}
+	faucet := &k8s.Faucet{
+		Image:   image,
+		Profile: c.options.configProfile,
+		Keyring: make(map[string][]byte),
+	}
+	if faucet.Port, err = strconv.Atoi(port); err != nil {
+		return nil, errors.Wrapf(err, "invalid port of the faucet %s", port)
+	}
+	if faucet.Config, err = os.ReadFile(c.ConfigPath()); err != nil {
+		return nil, err
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return nil, err
+	}
+	account, err := commands.ShowAccount(ctx, *conf.Faucet.Name)
+	if err != nil {
+		return nil, err
+	}
+	_, address, err := bech32.DecodeAndConvert(account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// the keyring of the test backend stores the key of an account with its name and its address.
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+	keyringDir := filepath.Join(home, "keyring-"+string(chaincmd.KeyringBackendTest))
+	for _, file := range []string{account.Name + ".info", hex.EncodeToString(address) + ".address"} {
+		if faucet.Keyring[file], err = os.ReadFile(filepath.Join(keyringDir, file)); err != nil {
+			return nil, err
+		}
+	}
+	return faucet, nil
+}
+
+// writeDockerfile writes to dir the Dockerfile of the image of the chain, the image is built from
+// the source of the chain.
+func (c *Chain) writeDockerfile(conf chainconfig.Config, deployment k8s.Chain, dir string) error {
+	goVersion := "1.16"
+	if module, err := gomodule.ParseAt(c.app.Path); err != nil {
+		return err
+	} else if module.Go != nil {
+		goVersion = module.Go.Version
+	}
+
+	main, err := c.discoverMain(c.app.Path)
+	if err != nil {
+		return err
+	}
+	main, err = filepath.Rel(c.app.Path, main)
+	if err != nil {
+		return err
+	}
+
+	build := []string{
+		"go", "build",
+		gocmd.FlagMod, gocmd.FlagModValueReadOnly,
+		gocmd.FlagLdflags, gocmd.Ldflags(c.ldFlags(conf, deployment.Binary, deployment.ChainID)...),
+	}
+	if len(conf.Build.Tags) > 0 {
+		build = append(build, gocmd.FlagTags, gocmd.Tags(conf.Build.Tags...))
+	}
+	build = append(build, "-o", "/usr/local/bin/"+deployment.Binary, "./"+filepath.ToSlash(main))
+	run, err := json.Marshal(build)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, dockerfile)
+	if abs, err := filepath.Abs(path); err == nil {
+		if rel, err := filepath.Rel(c.app.Path, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+
+	content := fmt.Sprintf(`# the image of the chain, built from the source of the chain with:
+#
+#   docker build -f %[1]s -t %[2]s .
+#
+FROM golang:%[3]s-buster as builder
+
+WORKDIR /src
+
+# cache dependencies.
+COPY go.mod go.sum ./
+RUN go mod download
+
+COPY . .
+RUN %[4]s
+
+FROM debian:buster-slim
+
+# the user of the nodes owns their homes.
+RUN useradd -ms /bin/bash -u 1000 chain
+USER chain
+
+COPY --from=builder /usr/local/bin/%[5]s /usr/local/bin/%[5]s
+
+ENTRYPOINT ["%[5]s"]
+`, path, deployment.Image, goVersion, run, deployment.Binary)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, dockerfile), []byte(content), 0644)
+}
+
+// dnsLabel returns s as a DNS label, the names of the resources of Kubernetes are DNS labels.
+func dnsLabel(s string) string {
+	s = strings.Trim(nonDNSLabel.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(s) > 63 {
+		s = strings.TrimRight(s[:63], "-")
+	}
+	return s
+}
@@ -3,14 +3,17 @@ package chain
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 
+	"github.com/tendermint/starport/starport/chainconfig"
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
 	"github.com/tendermint/starport/starport/pkg/cosmosfaucet"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
 	"github.com/tendermint/starport/starport/pkg/xurl"
 )
 
@@ -28,7 +31,8 @@ var (
 
 // Faucet returns the faucet for the chain or an error if the faucet
 // configuration is wrong or not configured (not enabled) at all.
-func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
+// options are applied after the options read from the configuration.
+func (c *Chain) Faucet(ctx context.Context, options ...cosmosfaucet.Option) (cosmosfaucet.Faucet, error) {
 	id, err := c.ID()
 	if err != nil {
 		return cosmosfaucet.Faucet{}, err
@@ -68,11 +72,69 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		cosmosfaucet.OpenAPI(xurl.HTTP(apiAddress)),
 	}
 
+	configOptions, err := faucetConfigOptions(conf)
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+	faucetOptions = append(faucetOptions, configOptions...)
+
+	if c.ev != nil {
+		faucetOptions = append(faucetOptions, cosmosfaucet.CollectEvents(c.ev))
+	}
+
+	faucetOptions = append(faucetOptions, options...)
+
+	// init the faucet with options and return.
+	return cosmosfaucet.New(ctx, commands, faucetOptions...)
+}
+
+// ServeFaucet serves the faucet of a chain deployed without its source at the faucet host of the config
+// until the ctx is canceled. the coins are sent with the commands from the faucet account in their
+// keyring and the OpenAPI console of the faucet uses the API at apiAddress.
+func ServeFaucet(ctx context.Context, commands chaincmdrunner.Runner, conf chainconfig.Config, chainID, apiAddress string) error {
+	if conf.Faucet.Name == nil {
+		return ErrFaucetIsNotEnabled
+	}
+
+	if _, err := commands.ShowAccount(ctx, *conf.Faucet.Name); err != nil {
+		if err == chaincmdrunner.ErrAccountDoesNotExist {
+			return ErrFaucetAccountDoesNotExist
+		}
+		return err
+	}
+
+	faucetOptions := []cosmosfaucet.Option{
+		cosmosfaucet.Account(*conf.Faucet.Name, "", ""),
+		cosmosfaucet.ChainID(chainID),
+		cosmosfaucet.OpenAPI(apiAddress),
+	}
+
+	configOptions, err := faucetConfigOptions(conf)
+	if err != nil {
+		return err
+	}
+
+	faucet, err := cosmosfaucet.New(ctx, commands, append(faucetOptions, configOptions...)...)
+	if err != nil {
+		return err
+	}
+	defer faucet.Close()
+
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    chainconfig.FaucetHost(conf),
+		Handler: faucet,
+	})
+}
+
+// faucetConfigOptions returns the options of the faucet read from the config.
+func faucetConfigOptions(conf chainconfig.Config) ([]cosmosfaucet.Option, error) {
+	var faucetOptions []cosmosfaucet.Option
+
 	// parse coins to pass to the faucet as coins.
 	for _, coin := range conf.Faucet.Coins {
 		parsedCoin, err := sdk.ParseCoinNormalized(coin)
 		if err != nil {
-			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, coin)
+			return nil, fmt.Errorf("%s: %s", err, coin)
 		}
 
 		var amountMax uint64
@@ -81,7 +143,7 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		for _, coinMax := range conf.Faucet.CoinsMax {
 			parsedMax, err := sdk.ParseCoinNormalized(coinMax)
 			if err != nil {
-				return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, coin)
+				return nil, fmt.Errorf("%s: %s", err, coin)
 			}
 			if parsedMax.Denom == parsedCoin.Denom {
 				amountMax = parsedMax.Amount.Uint64()
@@ -92,15 +154,98 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		faucetOptions = append(faucetOptions, cosmosfaucet.Coin(parsedCoin.Amount.Uint64(), amountMax, parsedCoin.Denom))
 	}
 
+	if conf.Faucet.FeeCoin != "" {
+		feeCoin, err := sdk.ParseCoinNormalized(conf.Faucet.FeeCoin)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, conf.Faucet.FeeCoin)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.FeeCoin(feeCoin.Amount.Uint64(), feeCoin.Denom))
+	}
+
 	if conf.Faucet.RateLimitWindow != "" {
 		rateLimitWindow, err := time.ParseDuration(conf.Faucet.RateLimitWindow)
 		if err != nil {
-			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, conf.Faucet.RateLimitWindow)
+			return nil, fmt.Errorf("%s: %s", err, conf.Faucet.RateLimitWindow)
 		}
 
 		faucetOptions = append(faucetOptions, cosmosfaucet.RefreshWindow(rateLimitWindow))
 	}
 
-	// init the faucet with options and return.
-	return cosmosfaucet.New(ctx, commands, faucetOptions...)
+	for denom, window := range conf.Faucet.RateLimitWindows {
+		rateLimitWindow, err := time.ParseDuration(window)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, window)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.CoinRefreshWindow(rateLimitWindow, denom))
+	}
+
+	for _, coinMax := range conf.Faucet.IPCoinsMax {
+		parsedMax, err := sdk.ParseCoinNormalized(coinMax)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, coinMax)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.IPCoinMax(parsedMax.Amount.Uint64(), parsedMax.Denom))
+	}
+
+	if conf.Faucet.IPRateLimitWindow != "" {
+		ipRateLimitWindow, err := time.ParseDuration(conf.Faucet.IPRateLimitWindow)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, conf.Faucet.IPRateLimitWindow)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.IPRefreshWindow(ipRateLimitWindow))
+	}
+
+	for denom, window := range conf.Faucet.IPRateLimitWindows {
+		ipRateLimitWindow, err := time.ParseDuration(window)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, window)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.IPCoinRefreshWindow(ipRateLimitWindow, denom))
+	}
+
+	if conf.Faucet.TrustForwardedFor {
+		faucetOptions = append(faucetOptions, cosmosfaucet.TrustForwardedFor())
+	}
+
+	if conf.Faucet.AdminToken != "" {
+		faucetOptions = append(faucetOptions, cosmosfaucet.AdminToken(conf.Faucet.AdminToken))
+	}
+
+	if conf.Faucet.Captcha.Provider != "" {
+		faucetOptions = append(faucetOptions, cosmosfaucet.Captcha(
+			cosmosfaucet.CaptchaProvider(conf.Faucet.Captcha.Provider),
+			conf.Faucet.Captcha.Secret,
+		))
+	}
+
+	if conf.Faucet.Captcha.SiteKey != "" {
+		faucetOptions = append(faucetOptions, cosmosfaucet.CaptchaSiteKey(conf.Faucet.Captcha.SiteKey))
+	}
+
+	faucetOptions = append(faucetOptions, cosmosfaucet.UIBranding(cosmosfaucet.Branding{
+		Title:       conf.Faucet.UI.Title,
+		Description: conf.Faucet.UI.Description,
+		LogoURL:     conf.Faucet.UI.Logo,
+		Color:       conf.Faucet.UI.Color,
+	}))
+
+	if conf.Faucet.SignedChallenge {
+		faucetOptions = append(faucetOptions, cosmosfaucet.SignedChallenge(cosmosfaucet.DefaultChallengeTTL))
+	}
+
+	if conf.Faucet.BatchInterval != "" {
+		batchInterval, err := time.ParseDuration(conf.Faucet.BatchInterval)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, conf.Faucet.BatchInterval)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.BatchTransfers(batchInterval))
+	}
+
+	return faucetOptions, nil
 }
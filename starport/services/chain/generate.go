@@ -5,23 +5,36 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/tendermint/starport/starport/chainconfig"
 	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
 	"github.com/tendermint/starport/starport/pkg/cosmosgen"
 	"github.com/tendermint/starport/starport/pkg/giturl"
 )
 
 const (
-	defaultVuexPath    = "vue/src/store"
-	defaultDartPath    = "flutter/lib"
-	defaultOpenAPIPath = "docs/static/openapi.yml"
+	defaultVuexPath       = "vue/src/store"
+	defaultVuePath        = "vue/src/composables"
+	defaultReactPath      = "react/src/hooks"
+	defaultDartPath       = "flutter/lib"
+	defaultRustPath       = "client/rust"
+	defaultGoClientPath   = "client/go"
+	defaultWasmSignerPath = "vue/src/signer"
+	defaultOpenAPIPath    = "docs/static/openapi.yml"
+	defaultOpenAPIV3Path  = "docs/static/openapi.json"
 )
 
 type generateOptions struct {
-	isGoEnabled      bool
-	isVuexEnabled    bool
-	isDartEnabled    bool
-	isOpenAPIEnabled bool
+	isGoEnabled         bool
+	isVuexEnabled       bool
+	isVueEnabled        bool
+	isReactEnabled      bool
+	isDartEnabled       bool
+	isRustEnabled       bool
+	isGoClientEnabled   bool
+	isWasmSignerEnabled bool
+	isOpenAPIEnabled    bool
 }
 
 // GenerateTarget is a target to generate code for from proto files.
@@ -41,6 +54,20 @@ func GenerateVuex() GenerateTarget {
 	}
 }
 
+// GenerateVue enables generating Vue 3 composables.
+func GenerateVue() GenerateTarget {
+	return func(o *generateOptions) {
+		o.isVueEnabled = true
+	}
+}
+
+// GenerateReact enables generating React Query hooks.
+func GenerateReact() GenerateTarget {
+	return func(o *generateOptions) {
+		o.isReactEnabled = true
+	}
+}
+
 // GenerateDart enables generating Dart client.
 func GenerateDart() GenerateTarget {
 	return func(o *generateOptions) {
@@ -48,7 +75,28 @@ func GenerateDart() GenerateTarget {
 	}
 }
 
-// GenerateOpenAPI enables generating OpenAPI spec for your chain.
+// GenerateRust enables generating Rust client crates.
+func GenerateRust() GenerateTarget {
+	return func(o *generateOptions) {
+		o.isRustEnabled = true
+	}
+}
+
+// GenerateGoClient enables generating a standalone Go client module for all modules used by the chain.
+func GenerateGoClient() GenerateTarget {
+	return func(o *generateOptions) {
+		o.isGoClientEnabled = true
+	}
+}
+
+// GenerateWasmSigner enables generating a WebAssembly helper to sign the chain's txs in browsers.
+func GenerateWasmSigner() GenerateTarget {
+	return func(o *generateOptions) {
+		o.isWasmSignerEnabled = true
+	}
+}
+
+// GenerateOpenAPI enables generating OpenAPI specs for your chain, both in Swagger 2.0 and OpenAPI 3.0 formats.
 func GenerateOpenAPI() GenerateTarget {
 	return func(o *generateOptions) {
 		o.isOpenAPIEnabled = true
@@ -67,14 +115,38 @@ func (c *Chain) generateAll(ctx context.Context) error {
 		additionalTargets = append(additionalTargets, GenerateVuex())
 	}
 
+	if conf.Client.Vue.Path != "" {
+		additionalTargets = append(additionalTargets, GenerateVue())
+	}
+
+	if conf.Client.React.Path != "" {
+		additionalTargets = append(additionalTargets, GenerateReact())
+	}
+
 	if conf.Client.Dart.Path != "" {
 		additionalTargets = append(additionalTargets, GenerateDart())
 	}
 
-	if conf.Client.OpenAPI.Path != "" {
+	if conf.Client.Rust.Path != "" {
+		additionalTargets = append(additionalTargets, GenerateRust())
+	}
+
+	if conf.Client.Go.Path != "" {
+		additionalTargets = append(additionalTargets, GenerateGoClient())
+	}
+
+	if conf.Client.WasmSigner.Path != "" {
+		additionalTargets = append(additionalTargets, GenerateWasmSigner())
+	}
+
+	if conf.Client.OpenAPI.Enabled() {
 		additionalTargets = append(additionalTargets, GenerateOpenAPI())
 	}
 
+	if err := c.checkProto(ctx, conf); err != nil {
+		return err
+	}
+
 	return c.Generate(ctx, GenerateGo(), additionalTargets...)
 }
 
@@ -101,12 +173,22 @@ func (c *Chain) Generate(
 
 	fmt.Fprintln(c.stdLog().out, "🛠️  Building proto...")
 
+	cacheDir, err := codegenCacheDir()
+	if err != nil {
+		return err
+	}
+
 	options := []cosmosgen.Option{
 		cosmosgen.IncludeDirs(conf.Build.Proto.ThirdPartyPaths),
+		cosmosgen.WithCacheDir(cacheDir),
 	}
 
 	if targetOptions.isGoEnabled {
 		options = append(options, cosmosgen.WithGoGeneration(c.app.ImportPath))
+
+		if conf.Build.Proto.Buf.Enabled {
+			options = append(options, cosmosgen.WithBuf(bufTemplate(conf)))
+		}
 	}
 
 	enableThirdPartyModuleCodegen := !c.protoBuiltAtLeastOnce && c.options.isThirdPartyModuleCodegenEnabled
@@ -135,6 +217,32 @@ func (c *Chain) Generate(
 		)
 	}
 
+	if targetOptions.isVueEnabled {
+		rootPath, err := jsRootPath(c.app.Path, conf.Client.Vue.Path, defaultVuePath)
+		if err != nil {
+			return err
+		}
+
+		options = append(options, cosmosgen.WithVueGeneration(enableThirdPartyModuleCodegen, jsModulePath(rootPath)))
+	}
+
+	if targetOptions.isReactEnabled {
+		rootPath, err := jsRootPath(c.app.Path, conf.Client.React.Path, defaultReactPath)
+		if err != nil {
+			return err
+		}
+
+		options = append(options, cosmosgen.WithReactGeneration(enableThirdPartyModuleCodegen, jsModulePath(rootPath)))
+	}
+
+	isJSEnabled := targetOptions.isVuexEnabled || targetOptions.isVueEnabled || targetOptions.isReactEnabled
+
+	if isJSEnabled && conf.Client.BundleReport {
+		options = append(options, cosmosgen.WithBundleSizeReport(func(report cosmosgen.BundleReport) {
+			c.sendEvent("📦 Bundle sizes of the generated TypeScript clients:\n%s", report)
+		}))
+	}
+
 	if targetOptions.isDartEnabled {
 		dartPath := conf.Client.Dart.Path
 
@@ -158,14 +266,54 @@ func (c *Chain) Generate(
 		)
 	}
 
-	if targetOptions.isOpenAPIEnabled {
-		openAPIPath := conf.Client.OpenAPI.Path
+	if targetOptions.isRustEnabled {
+		rustPath := conf.Client.Rust.Path
+
+		if rustPath == "" {
+			rustPath = defaultRustPath
+		}
+
+		rootPath := filepath.Join(c.app.Path, rustPath)
+		if err := os.MkdirAll(rootPath, 0766); err != nil {
+			return err
+		}
+
+		options = append(options,
+			cosmosgen.WithRustGeneration(
+				enableThirdPartyModuleCodegen,
+				func(m module.Module) string {
+					return filepath.Join(rootPath, strings.ReplaceAll(m.Pkg.Name, ".", "-"))
+				},
+				rootPath,
+			),
+		)
+	}
+
+	if targetOptions.isGoClientEnabled {
+		goClientPath := conf.Client.Go.Path
+
+		if goClientPath == "" {
+			goClientPath = defaultGoClientPath
+		}
+
+		options = append(options, cosmosgen.WithGoClientGeneration(c.app.ImportPath, goClientPath))
+	}
+
+	if targetOptions.isWasmSignerEnabled {
+		wasmSignerPath := conf.Client.WasmSigner.Path
 
-		if openAPIPath == "" {
-			openAPIPath = defaultOpenAPIPath
+		if wasmSignerPath == "" {
+			wasmSignerPath = defaultWasmSignerPath
 		}
 
-		options = append(options, cosmosgen.WithOpenAPIGeneration(openAPIPath))
+		options = append(options, cosmosgen.WithWasmSignerGeneration(wasmSignerPath))
+	}
+
+	if targetOptions.isOpenAPIEnabled {
+		options = append(options,
+			cosmosgen.WithOpenAPIGeneration(openAPIPath(conf)),
+			cosmosgen.WithOpenAPIV3Generation(openAPIV3Path(conf)),
+		)
 	}
 
 	if err := cosmosgen.Generate(ctx, c.app.Path, conf.Build.Proto.Path, options...); err != nil {
@@ -176,3 +324,50 @@ func (c *Chain) Generate(
 
 	return nil
 }
+
+// codegenCacheDir returns the path of the dir where codegen cache records are saved.
+func codegenCacheDir() (string, error) {
+	configDir, err := chainconfig.ConfigDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "codegen"), nil
+}
+
+// jsRootPath returns the root path of generated JS code and ensures that it exists.
+func jsRootPath(appPath, path, defaultPath string) (string, error) {
+	if path == "" {
+		path = defaultPath
+	}
+
+	rootPath := filepath.Join(appPath, path, "generated")
+	if err := os.MkdirAll(rootPath, 0766); err != nil {
+		return "", err
+	}
+
+	return rootPath, nil
+}
+
+// jsModulePath returns a func that returns the path of the JS lib generated for a module in rootPath.
+func jsModulePath(rootPath string) func(m module.Module) string {
+	return func(m module.Module) string {
+		parsedGitURL, _ := giturl.Parse(m.Pkg.GoImportName)
+		return filepath.Join(rootPath, parsedGitURL.UserAndRepo(), m.Pkg.Name, "module")
+	}
+}
+
+// openAPIPath returns the path of the Swagger 2.0 spec relative to the app's source code.
+func openAPIPath(conf chainconfig.Config) string {
+	if conf.Client.OpenAPI.Path != "" {
+		return conf.Client.OpenAPI.Path
+	}
+	return defaultOpenAPIPath
+}
+
+// openAPIV3Path returns the path of the OpenAPI 3.0 spec relative to the app's source code.
+func openAPIV3Path(conf chainconfig.Config) string {
+	if conf.Client.OpenAPI.V3Path != "" {
+		return conf.Client.OpenAPI.V3Path
+	}
+	return defaultOpenAPIV3Path
+}
@@ -0,0 +1,109 @@
+package chain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/otiai10/copy"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+)
+
+// generatedGoSuffixes are the suffixes of the Go files generated from the proto files.
+var generatedGoSuffixes = []string{".pb.go", ".pb.gw.go"}
+
+// CheckGenerated regenerates the code of the chain from its proto files in a temporary copy of its
+// source code and returns the generated files that don't match the ones of the source code.
+// the code is generated for the same targets as when the chain is built, the Go code and the
+// clients and OpenAPI specs that have a path in the config.
+func (c *Chain) CheckGenerated(ctx context.Context) ([]dirchange.Change, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "starport-generate-check")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := copy.Copy(c.app.Path, tmp, copy.Options{
+		Skip: func(src string) (bool, error) {
+			return src != c.app.Path && !isSourcePath(filepath.Base(src)), nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	check, err := New(tmp, func(check *Chain) {
+		check.options = c.options
+		check.logLevel = c.logLevel
+		check.ev = c.ev
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := check.generateAll(ctx); err != nil {
+		return nil, err
+	}
+
+	paths := generatedPaths(conf)
+	return dirchange.Diff(c.app.Path, tmp, func(path string, isDir bool) bool {
+		if !isSourcePath(filepath.Base(path)) {
+			return false
+		}
+		if isDir {
+			return true
+		}
+		for _, generated := range paths {
+			if path == generated || strings.HasPrefix(path, generated+string(filepath.Separator)) {
+				return true
+			}
+		}
+		for _, suffix := range generatedGoSuffixes {
+			if strings.HasSuffix(path, suffix) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// isSourcePath checks if a file or a directory with the name is a part of the source code of the
+// chain, the hidden files and the installed node modules aren't.
+func isSourcePath(name string) bool {
+	return !strings.HasPrefix(name, ".") && name != "node_modules"
+}
+
+// generatedPaths returns the paths of the clients and the OpenAPI specs generated from the proto
+// files, relative to the source code of the chain.
+func generatedPaths(conf chainconfig.Config) []string {
+	var paths []string
+	for _, path := range []string{
+		conf.Client.Vuex.Path,
+		conf.Client.Vue.Path,
+		conf.Client.React.Path,
+		conf.Client.Dart.Path,
+	} {
+		if path != "" {
+			paths = append(paths, filepath.Join(path, "generated"))
+		}
+	}
+	for _, path := range []string{
+		conf.Client.Rust.Path,
+		conf.Client.Go.Path,
+		conf.Client.WasmSigner.Path,
+	} {
+		if path != "" {
+			paths = append(paths, filepath.Clean(path))
+		}
+	}
+	if conf.Client.OpenAPI.Enabled() {
+		paths = append(paths, filepath.Clean(openAPIPath(conf)), filepath.Clean(openAPIV3Path(conf)))
+	}
+	return paths
+}
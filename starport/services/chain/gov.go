@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cenkalti/backoff"
+
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+)
+
+// govPollInterval is the interval of the queries made while waiting for the end of a voting period.
+const govPollInterval = time.Second
+
+var errProposalPending = errors.New("proposal is pending")
+
+// GovVoteWithValidators votes with the option on a proposal from the accounts of the validators
+// defined in the config, and returns the names of the accounts that voted.
+func (c *Chain) GovVoteWithValidators(ctx context.Context, proposalID uint64, option string) ([]string, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var voters []string
+	for _, validator := range conf.Validators {
+		if err := commands.GovVote(ctx, validator.Name, proposalID, option); err != nil {
+			return voters, err
+		}
+		voters = append(voters, validator.Name)
+	}
+	return voters, nil
+}
+
+// GovWaitProposal waits until the deposit and voting periods of a proposal are over and returns the
+// proposal with its final tally. it waits for the time of the blocks to pass the voting end time,
+// so the voting period of devnets should be short, it's set in the genesis of the config.
+func (c *Chain) GovWaitProposal(ctx context.Context, proposalID uint64) (chaincmdrunner.GovProposal, error) {
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return chaincmdrunner.GovProposal{}, err
+	}
+
+	var proposal chaincmdrunner.GovProposal
+
+	check := func() error {
+		if proposal, err = commands.GovProposal(ctx, proposalID); err != nil {
+			return backoff.Permanent(err)
+		}
+		switch proposal.Status {
+		case chaincmdrunner.GovProposalStatusDepositPeriod, chaincmdrunner.GovProposalStatusVotingPeriod:
+			return errProposalPending
+		}
+		return nil
+	}
+
+	err = backoff.Retry(check, backoff.WithContext(backoff.NewConstantBackOff(govPollInterval), ctx))
+	return proposal, err
+}
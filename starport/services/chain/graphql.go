@@ -0,0 +1,42 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+
+	"google.golang.org/grpc"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/graphql"
+	"github.com/tendermint/starport/starport/pkg/grpcui"
+	"github.com/tendermint/starport/starport/pkg/protoanalysis"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// runGraphQLServer serves a GraphQL API for the Query services of the modules of the chain, the
+// queries are resolved by invoking the services through the gRPC server of the node.
+func (c *Chain) runGraphQLServer(ctx context.Context, config chainconfig.Config) error {
+	pkgs, err := protoanalysis.Parse(ctx, nil, filepath.Join(c.app.Path, config.Build.Proto.Path))
+	if err != nil {
+		return err
+	}
+	services := make(map[string]bool)
+	for _, pkg := range pkgs {
+		services[pkg.Name+".Query"] = true
+	}
+
+	// the connection is established lazily, so the API keeps working while the node restarts.
+	conn, err := grpc.DialContext(ctx, config.Host.GRPC, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return xhttp.Serve(ctx, &http.Server{
+		Addr: config.Host.GraphQL,
+		Handler: graphql.Handler(c.app.Name, grpcui.New(conn), func(service string) bool {
+			return services[service]
+		}),
+	})
+}
@@ -0,0 +1,27 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/grpcui"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// runGRPCUIServer serves a web UI to explore and invoke the gRPC services of the node.
+func (c *Chain) runGRPCUIServer(ctx context.Context, config chainconfig.Config) error {
+	// the connection is established lazily, so the UI keeps working while the node restarts.
+	conn, err := grpc.DialContext(ctx, config.Host.GRPC, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    config.Host.GRPCUI,
+		Handler: grpcui.Handler(c.app.Name, config.Host.GRPC, grpcui.New(conn)),
+	})
+}
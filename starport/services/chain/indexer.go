@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/cosmosindexer"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// runIndexer indexes the blocks of the chain in the PostgreSQL database with the URL until ctx is
+// canceled, the serve goes on when the database fails so it can be fixed while serving.
+func (c *Chain) runIndexer(ctx context.Context, config chainconfig.Config, databaseURL string) {
+	if err := c.index(ctx, config, databaseURL); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(c.stdLog().err, "❌ Cannot index the chain: %s\n", err)
+	}
+}
+
+func (c *Chain) index(ctx context.Context, config chainconfig.Config, databaseURL string) error {
+	client, err := rpchttp.New(xurl.HTTP(config.Host.RPC), "/websocket")
+	if err != nil {
+		return err
+	}
+
+	sink, err := cosmosindexer.NewPostgres(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer sink.Close()
+
+	fmt.Fprintf(c.stdLog().out, "📇 Indexing the blocks in %s\n", redactURL(databaseURL))
+
+	return cosmosindexer.New(client, sink).Run(ctx)
+}
+
+// redactURL hides the password of the URL.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "the database"
+	}
+	return u.Redacted()
+}
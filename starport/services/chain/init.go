@@ -7,8 +7,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/imdario/mergo"
-
 	"github.com/tendermint/starport/starport/chainconfig"
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
 	"github.com/tendermint/starport/starport/pkg/confile"
@@ -47,29 +45,13 @@ func (c *Chain) InitChain(ctx context.Context) error {
 		return err
 	}
 
-	// cleanup persistent data from previous `serve`.
-	home, err := c.Home()
-	if err != nil {
-		return err
-	}
-	if err := os.RemoveAll(home); err != nil {
-		return err
-	}
-
-	commands, err := c.Commands(ctx)
+	nodes, err := c.nodes(conf)
 	if err != nil {
 		return err
 	}
 
-	// init node.
-	if err := commands.Init(ctx, moniker); err != nil {
-		return err
-	}
-
-	// overwrite configuration changes from Starport's config.yml to
-	// over app's sdk configs.
-
-	if err := c.plugin.Configure(home, conf); err != nil {
+	// init the chain's node, the persistent data from previous `serve` is cleaned up.
+	if err := c.initNode(ctx, conf, nodes[0], moniker); err != nil {
 		return err
 	}
 
@@ -83,48 +65,20 @@ func (c *Chain) InitChain(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	appTOMLPath, err := c.AppTOMLPath()
-	if err != nil {
-		return err
-	}
-	clientTOMLPath, err := c.ClientTOMLPath()
-	if err != nil {
-		return err
-	}
-	configTOMLPath, err := c.ConfigTOMLPath()
-	if err != nil {
-		return err
-	}
 
-	appconfigs := []struct {
-		ec      confile.EncodingCreator
-		path    string
-		changes map[string]interface{}
-	}{
-		{confile.DefaultJSONEncodingCreator, genesisPath, conf.Genesis},
-		{confile.DefaultTOMLEncodingCreator, appTOMLPath, conf.Init.App},
-		{confile.DefaultTOMLEncodingCreator, clientTOMLPath, conf.Init.Client},
-		{confile.DefaultTOMLEncodingCreator, configTOMLPath, conf.Init.Config},
-	}
-
-	for _, ac := range appconfigs {
-		cf := confile.New(ac.ec, ac.path)
-		var conf map[string]interface{}
-		if err := cf.Load(&conf); err != nil {
-			return err
-		}
-		if err := mergo.Merge(&conf, ac.changes, mergo.WithOverride); err != nil {
-			return err
-		}
-		if err := cf.Save(conf); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	// the metadata of the declared denoms and the params of the tokenomics are overwritten by the
+	// genesis of the config.
+	return updateConfigFile(
+		confile.DefaultJSONEncodingCreator,
+		genesisPath,
+		conf.DenomsGenesis(),
+		conf.TokenomicsGenesis(),
+		conf.Genesis,
+	)
 }
 
-// InitAccounts initializes the chain accounts and creates validator gentxs
+// InitAccounts initializes the chain accounts and the test accounts and creates validator gentxs,
+// the nodes of the validators other than the first one are initialized to create their gentxs.
 func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error {
 	commands, err := c.Commands(ctx)
 	if err != nil {
@@ -168,11 +122,21 @@ func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error
 		}
 	}
 
-	_, err = c.IssueGentx(ctx, Validator{
-		Name:          conf.Validator.Name,
-		StakingAmount: conf.Validator.Staked,
-	})
-	return err
+	if err := c.initTestAccounts(ctx, conf, commands); err != nil {
+		return err
+	}
+
+	// the gentxs of the other validators are generated in their nodes first, so they are
+	// collected together with the gentx of the first validator.
+	if err := c.initNodes(ctx, conf); err != nil {
+		return err
+	}
+
+	if _, err = c.IssueGentx(ctx, ValidatorFromConfig(conf.Validators[0])); err != nil {
+		return err
+	}
+
+	return c.connectNodes(ctx, conf)
 }
 
 // IssueGentx generates a gentx from the validator information in chain config and import it in the chain genesis
@@ -227,6 +191,23 @@ type Validator struct {
 	SecurityContact         string
 }
 
+// ValidatorFromConfig returns the validator of the gentx of a validator defined in the config.
+func ValidatorFromConfig(v chainconfig.Validator) Validator {
+	return Validator{
+		Name:                    v.Name,
+		Moniker:                 v.Gentx.Moniker,
+		StakingAmount:           v.Bonded,
+		CommissionRate:          v.Gentx.CommissionRate,
+		CommissionMaxRate:       v.Gentx.CommissionMaxRate,
+		CommissionMaxChangeRate: v.Gentx.CommissionMaxChangeRate,
+		MinSelfDelegation:       v.Gentx.MinSelfDelegation,
+		Details:                 v.Gentx.Details,
+		Identity:                v.Gentx.Identity,
+		Website:                 v.Gentx.Website,
+		SecurityContact:         v.Gentx.SecurityContact,
+	}
+}
+
 // Account represents an account in the chain.
 type Account struct {
 	Name     string
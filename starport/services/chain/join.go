@@ -0,0 +1,122 @@
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/confile"
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// nodeIDLength is the length of the hex encoded IDs of the Tendermint nodes.
+const nodeIDLength = 40
+
+// InitFromGenesis initializes the node of the chain to join a running network instead of creating
+// a local genesis. the genesis of the network is fetched from genesisSource that is a URL or the
+// path of a genesis file and the node connects to the network through the persistent peers given
+// in the id@host:port format. the chain ID of the chain is set to the chain ID of the genesis.
+func (c *Chain) InitFromGenesis(ctx context.Context, genesisSource string, peers []string) error {
+	for _, peer := range peers {
+		if err := validatePeer(peer); err != nil {
+			return err
+		}
+	}
+
+	genesis, err := fetchGenesis(ctx, genesisSource)
+	if err != nil {
+		return err
+	}
+
+	var g struct {
+		ChainID string `json:"chain_id"`
+	}
+	if err := json.Unmarshal(genesis, &g); err != nil {
+		return errors.Wrap(err, "invalid genesis")
+	}
+	if g.ChainID == "" {
+		return errors.New("invalid genesis: chain_id is empty")
+	}
+	c.options.chainID = g.ChainID
+
+	conf, err := c.Config()
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	nodes, err := c.nodes(conf)
+	if err != nil {
+		return err
+	}
+
+	// init the chain's node, the persistent data from previous `serve` is cleaned up.
+	if err := c.initNode(ctx, conf, nodes[0], moniker); err != nil {
+		return err
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(genesisPath, genesis, 0644); err != nil {
+		return err
+	}
+
+	if err := updateConfigFile(
+		confile.DefaultTOMLEncodingCreator,
+		filepath.Join(nodes[0].home, "config/config.toml"),
+		map[string]interface{}{
+			"p2p": map[string]interface{}{
+				"persistent_peers": strings.Join(peers, ","),
+			},
+		},
+	); err != nil {
+		return err
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return err
+	}
+	return commands.ValidateGenesis(ctx)
+}
+
+// fetchGenesis returns the genesis from the URL or the path of the file.
+func fetchGenesis(ctx context.Context, source string) ([]byte, error) {
+	if xurl.IsHTTP(source) {
+		genesis, _, err := cosmosutil.GenesisAndHashFromURL(ctx, source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot fetch the genesis from %s", source)
+		}
+		return genesis, nil
+	}
+
+	genesis, err := os.ReadFile(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the genesis")
+	}
+	return genesis, nil
+}
+
+// validatePeer checks that the peer is in the id@host:port format.
+func validatePeer(peer string) error {
+	parts := strings.Split(peer, "@")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid peer %q, expected id@host:port", peer)
+	}
+	if _, err := hex.DecodeString(parts[0]); err != nil || len(parts[0]) != nodeIDLength {
+		return fmt.Errorf("invalid peer %q, the node ID must be %d hex characters", peer, nodeIDLength)
+	}
+	if _, _, err := net.SplitHostPort(parts[1]); err != nil {
+		return fmt.Errorf("invalid peer %q: %s", peer, err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePeer(t *testing.T) {
+	require.NoError(t, validatePeer("3b7a2ff6f9a4fbcfd3b9b2ad0bbd3fe1e4f7c2a1@1.2.3.4:26656"))
+	require.Error(t, validatePeer("1.2.3.4:26656"))
+	require.Error(t, validatePeer("3b7a@1.2.3.4:26656"))
+	require.Error(t, validatePeer("zz7a2ff6f9a4fbcfd3b9b2ad0bbd3fe1e4f7c2a1@1.2.3.4:26656"))
+	require.Error(t, validatePeer("3b7a2ff6f9a4fbcfd3b9b2ad0bbd3fe1e4f7c2a1@1.2.3.4"))
+}
+
+func TestFetchGenesis(t *testing.T) {
+	genesis := []byte(`{"chain_id":"mars-1"}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(genesis)
+	}))
+	defer server.Close()
+
+	fetched, err := fetchGenesis(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, genesis, fetched)
+
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, os.WriteFile(path, genesis, 0644))
+
+	fetched, err = fetchGenesis(context.Background(), path)
+	require.NoError(t, err)
+	require.Equal(t, genesis, fetched)
+}
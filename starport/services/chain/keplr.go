@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/keplr"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// generateKeplrChainInfo saves the Keplr chain info of the chain to client.keplr.path. the chain info
+// is created from the genesis, so it's only saved once the chain is initialized.
+func (c *Chain) generateKeplrChainInfo(config chainconfig.Config) error {
+	if config.Client.Keplr.Path == "" {
+		return nil
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	genesis, err := os.ReadFile(genesisPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var options []keplr.Option
+	if config.Client.Keplr.ChainName != "" {
+		options = append(options, keplr.WithChainName(config.Client.Keplr.ChainName))
+	}
+	if config.Client.Keplr.CoinType != 0 {
+		options = append(options, keplr.WithCoinType(config.Client.Keplr.CoinType))
+	}
+
+	info, err := keplr.NewChainInfo(genesis, browserURL(config.Host.RPC), browserURL(config.Host.API), options...)
+	if err != nil {
+		return errors.Wrap(err, "cannot create the Keplr chain info")
+	}
+
+	content, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(c.app.Path, config.Client.Keplr.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// browserURL returns the HTTP URL of a host of the chain that can be used from the browsers, the
+// hosts listening on all the interfaces are reached at localhost.
+func browserURL(host string) string {
+	return xurl.HTTP(strings.Replace(host, "0.0.0.0", "localhost", 1))
+}
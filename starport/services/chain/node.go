@@ -0,0 +1,296 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imdario/mergo"
+	"github.com/otiai10/copy"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/pkg/confile"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// node is the node of a validator defined in the config.
+type node struct {
+	validator chainconfig.Validator
+
+	// home is the home directory of the node.
+	home string
+
+	// host is the addresses of the node's servers.
+	host chainconfig.Host
+}
+
+// nodes returns the nodes of the validators defined in the config, the first one is the node at
+// the chain's home. the other nodes are at their homes or next to the chain's home by default.
+// when no validators are defined, e.g. the chain has no config, only the chain's node is returned.
+func (c *Chain) nodes(conf chainconfig.Config) ([]node, error) {
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conf.Validators) == 0 {
+		return []node{{home: home, host: conf.Host}}, nil
+	}
+
+	nodes := make([]node, 0, len(conf.Validators))
+	for i, validator := range conf.Validators {
+		n := node{
+			validator: validator,
+			home:      home,
+			host:      conf.ValidatorHost(i),
+		}
+		if i > 0 {
+			n.home = home + "-" + validator.Name
+			if validator.Home != "" {
+				n.home = filepath.Join(os.ExpandEnv(validator.Home))
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// nodeCommands returns the runner to execute commands on the node.
+func (c *Chain) nodeCommands(ctx context.Context, n node) (chaincmdrunner.Runner, error) {
+	return c.commands(ctx, n.home, n.host.RPC)
+}
+
+// nodeConfig returns the config used for the node, it has the addresses of the node's servers.
+func nodeConfig(conf chainconfig.Config, n node) chainconfig.Config {
+	conf.Host = n.host
+	return conf
+}
+
+// initNode initializes the node in its home and applies the app, client and config overwrites
+// of the config and the node's validator on top of each other.
+func (c *Chain) initNode(ctx context.Context, conf chainconfig.Config, n node, moniker string) error {
+	if err := os.RemoveAll(n.home); err != nil {
+		return err
+	}
+
+	commands, err := c.nodeCommands(ctx, n)
+	if err != nil {
+		return err
+	}
+
+	if err := commands.Init(ctx, moniker); err != nil {
+		return err
+	}
+
+	// overwrite configuration changes from Starport's config.yml to
+	// over app's sdk configs.
+	if err := c.plugin.Configure(n.home, nodeConfig(conf, n)); err != nil {
+		return err
+	}
+
+	configs := []struct {
+		path    string
+		changes []map[string]interface{}
+	}{
+		{"config/app.toml", []map[string]interface{}{conf.Init.Snapshots.AppConfig(), conf.Init.App, n.validator.App}},
+		{"config/client.toml", []map[string]interface{}{conf.Init.Client, n.validator.Client}},
+		{"config/config.toml", []map[string]interface{}{conf.Init.Config, n.validator.Config}},
+	}
+
+	for _, config := range configs {
+		if err := updateConfigFile(
+			confile.DefaultTOMLEncodingCreator,
+			filepath.Join(n.home, config.path),
+			config.changes...,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateListenAddresses writes the addresses of the RPC and the API servers of the node's host to
+// its config, the overwrites of the config and the node's validator are applied on top of them
+// unless the servers are bound to the loopback interface.
+func (c *Chain) updateListenAddresses(conf chainconfig.Config, n node, loopback bool) error {
+	configs := []struct {
+		path    string
+		changes []map[string]interface{}
+	}{
+		{"config/app.toml", []map[string]interface{}{
+			{"api": map[string]interface{}{"address": xurl.TCP(n.host.API)}},
+		}},
+		{"config/config.toml", []map[string]interface{}{
+			{"rpc": map[string]interface{}{"laddr": xurl.TCP(n.host.RPC)}},
+		}},
+	}
+	if !loopback {
+		configs[0].changes = append(configs[0].changes, conf.Init.App, n.validator.App)
+		configs[1].changes = append(configs[1].changes, conf.Init.Config, n.validator.Config)
+	}
+
+	for _, config := range configs {
+		if err := updateConfigFile(
+			confile.DefaultTOMLEncodingCreator,
+			filepath.Join(n.home, config.path),
+			config.changes...,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initNodes initializes the nodes of the validators other than the first one, their gentxs are
+// generated with the keys and the genesis of the chain's node and added to its gentxs.
+func (c *Chain) initNodes(ctx context.Context, conf chainconfig.Config) error {
+	nodes, err := c.nodes(conf)
+	if err != nil {
+		return err
+	}
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	primary := nodes[0]
+
+	backend, err := c.KeyringBackend()
+	if err != nil {
+		return err
+	}
+	keyringDir := "keyring-" + string(backend)
+
+	for _, n := range nodes[1:] {
+		if err := c.initNode(ctx, conf, n, n.validator.Name); err != nil {
+			return err
+		}
+
+		// the node uses the accounts of the chain's node to generate its gentx.
+		for _, path := range []string{keyringDir, "config/genesis.json"} {
+			src := filepath.Join(primary.home, path)
+			if _, err := os.Stat(src); os.IsNotExist(err) {
+				continue
+			}
+			if err := copy.Copy(src, filepath.Join(n.home, path)); err != nil {
+				return err
+			}
+		}
+
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		v := ValidatorFromConfig(n.validator)
+		if v.Moniker == "" {
+			v.Moniker = n.validator.Name
+		}
+		gentxPath, err := c.plugin.Gentx(ctx, commands, v)
+		if err != nil {
+			return err
+		}
+
+		if err := copy.Copy(gentxPath, filepath.Join(
+			primary.home,
+			"config/gentx",
+			fmt.Sprintf("gentx-%s.json", n.validator.Name),
+		)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// connectNodes shares the genesis of the chain's node with the other nodes and makes the nodes
+// persistent peers of each other.
+func (c *Chain) connectNodes(ctx context.Context, conf chainconfig.Config) error {
+	nodes, err := c.nodes(conf)
+	if err != nil {
+		return err
+	}
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	peers := make([]string, len(nodes))
+	for i, n := range nodes {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+		id, err := commands.ShowNodeID(ctx)
+		if err != nil {
+			return err
+		}
+		peers[i] = fmt.Sprintf("%s@%s", id, localAddress(n.host.P2P))
+	}
+
+	for i, n := range nodes {
+		if i > 0 {
+			if err := copy.Copy(
+				filepath.Join(nodes[0].home, "config/genesis.json"),
+				filepath.Join(n.home, "config/genesis.json"),
+			); err != nil {
+				return err
+			}
+		}
+
+		var others []string
+		for j, peer := range peers {
+			if j != i {
+				others = append(others, peer)
+			}
+		}
+
+		if err := updateConfigFile(
+			confile.DefaultTOMLEncodingCreator,
+			filepath.Join(n.home, "config/config.toml"),
+			map[string]interface{}{
+				"p2p": map[string]interface{}{
+					"persistent_peers": strings.Join(others, ","),
+					// the nodes run on the same machine.
+					"allow_duplicate_ip": true,
+					"addr_book_strict":   false,
+				},
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// localAddress returns the address to connect to a server listening at address on this machine.
+func localAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if host == "" || host == "0.0.0.0" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// updateConfigFile merges the changes into the config file at path in order, so the latter ones
+// take precedence over the former ones.
+func updateConfigFile(ec confile.EncodingCreator, path string, changes ...map[string]interface{}) error {
+	cf := confile.New(ec, path)
+	var conf map[string]interface{}
+	if err := cf.Load(&conf); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if err := mergo.Merge(&conf, change, mergo.WithOverride); err != nil {
+			return err
+		}
+	}
+	return cf.Save(conf)
+}
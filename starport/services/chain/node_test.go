@@ -0,0 +1,40 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/confile"
+)
+
+func TestUpdateConfigFileSnapshots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`minimum-gas-prices = ""
+
+[state-sync]
+snapshot-interval = 0
+snapshot-keep-recent = 2
+`), 0644))
+
+	snapshots := chainconfig.Snapshots{Interval: 500, KeepRecent: 5}
+	require.NoError(t, updateConfigFile(
+		confile.DefaultTOMLEncodingCreator,
+		path,
+		snapshots.AppConfig(),
+		map[string]interface{}{"minimum-gas-prices": "0stake"},
+	))
+
+	appToml, err := toml.LoadFile(path)
+	require.NoError(t, err)
+	require.EqualValues(t, 500, appToml.Get("state-sync.snapshot-interval"))
+	require.EqualValues(t, 5, appToml.Get("state-sync.snapshot-keep-recent"))
+	require.Equal(t, "0stake", appToml.Get("minimum-gas-prices"))
+
+	// the snapshots are left as they are when they're disabled.
+	require.Nil(t, chainconfig.Snapshots{KeepRecent: 5}.AppConfig())
+}
@@ -0,0 +1,80 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/openapiconsole"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+const openAPIV3SpecRoute = "/openapi.json"
+
+// isOpenAPIServerEnabled checks if the OpenAPI spec of the chain is generated, so it can be served.
+func isOpenAPIServerEnabled(config chainconfig.Config) bool {
+	return config.Client.OpenAPI.Enabled() && config.Host.OpenAPI != ""
+}
+
+// runOpenAPIServer serves the OpenAPI 3.0 spec of the chain and a console to explore it.
+func (c *Chain) runOpenAPIServer(ctx context.Context, config chainconfig.Config) error {
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    config.Host.OpenAPI,
+		Handler: c.openAPIHandler(config),
+	})
+}
+
+// openAPIHandler returns a handler that serves the spec at /openapi.json and the console at /.
+func (c *Chain) openAPIHandler(config chainconfig.Config) http.Handler {
+	var (
+		specPath   = filepath.Join(c.app.Path, openAPIV3Path(config))
+		apiAddress = xurl.HTTP(config.Host.API)
+		router     = mux.NewRouter()
+	)
+
+	router.
+		Handle("/", openapiconsole.Handler(c.app.Name, openAPIV3SpecRoute[1:])).
+		Methods(http.MethodGet)
+
+	router.
+		HandleFunc(openAPIV3SpecRoute, func(w http.ResponseWriter, r *http.Request) {
+			// the spec is read on every request since it's regenerated when proto files change.
+			spec, err := openAPIV3Spec(specPath, apiAddress)
+			if err != nil {
+				xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(spec)
+		}).
+		Methods(http.MethodGet)
+
+	return router
+}
+
+// openAPIV3Spec reads the OpenAPI 3.0 spec at path and points its server to the chain's API address.
+func openAPIV3Spec(path, apiAddress string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the OpenAPI spec")
+	}
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return nil, errors.Wrap(err, "invalid OpenAPI spec")
+	}
+
+	spec["servers"] = []map[string]string{
+		{"url": apiAddress},
+	}
+
+	return json.Marshal(spec)
+}
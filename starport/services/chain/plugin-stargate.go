@@ -67,6 +67,8 @@ func (p *stargatePlugin) appTOML(homePath string, conf chainconfig.Config) error
 	config.Set("api.enabled-unsafe-cors", true)
 	config.Set("rpc.cors_allowed_origins", []string{"*"})
 	config.Set("api.address", xurl.TCP(conf.Host.API))
+	// the gRPC server registers gRPC reflection, that is used by clients like grpcurl and the gRPC UI.
+	config.Set("grpc.enable", true)
 	config.Set("grpc.address", conf.Host.GRPC)
 	config.Set("grpc-web.address", conf.Host.GRPCWeb)
 	file, err := os.OpenFile(path, os.O_RDWR|os.O_TRUNC, 0644)
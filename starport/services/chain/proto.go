@@ -0,0 +1,156 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/cosmosbuf"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/repoversion"
+)
+
+// ErrNoTagToCompare is returned when there is no git tag to check the proto files against.
+var ErrNoTagToCompare = errors.New("chain's repository does not have any tags to check proto files against")
+
+// CheckProtoBreaking checks the proto files of the chain for breaking changes against the versions of
+// them at the git tag. When tag is empty, the latest tag of the chain's repository is used.
+// The tag that is checked against is returned, found breaking changes are returned as cosmosbuf.Annotations.
+func (c *Chain) CheckProtoBreaking(ctx context.Context, tag string) (checkedTag string, err error) {
+	conf, err := c.Config()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := cosmosbuf.New()
+	if err != nil {
+		return "", err
+	}
+
+	if tag == "" {
+		tag, err = repoversion.LatestTag(c.app.Path)
+		if errors.Is(err, repoversion.ErrNoTag) {
+			return "", ErrNoTagToCompare
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return tag, c.checkProtoBreaking(ctx, b, conf, tag)
+}
+
+func (c *Chain) checkProtoBreaking(ctx context.Context, b cosmosbuf.Buf, conf chainconfig.Config, tag string) error {
+	var (
+		protoDir = filepath.Join(c.app.Path, conf.Build.Proto.Path)
+		against  = cosmosbuf.GitInput(filepath.Join(c.app.Path, ".git"), tag, filepath.ToSlash(conf.Build.Proto.Path))
+	)
+
+	return b.Breaking(ctx, protoDir, against)
+}
+
+// checkProto lints the proto files and checks them for breaking changes against the latest tag
+// when these checks are enabled by the config. Found issues are reported as events with their
+// file and line references, the build fails if any issue is found by a check with error severity.
+func (c *Chain) checkProto(ctx context.Context, conf chainconfig.Config) error {
+	var (
+		lint     = conf.Build.Proto.Check.Lint
+		breaking = conf.Build.Proto.Check.Breaking
+	)
+
+	if !isProtoCheckEnabled(lint) && !isProtoCheckEnabled(breaking) {
+		return nil
+	}
+
+	b, err := cosmosbuf.New()
+	if err != nil {
+		if lint == chainconfig.ProtoCheckError || breaking == chainconfig.ProtoCheckError {
+			return &CannotBuildAppError{err}
+		}
+
+		c.sendEvent("⚠️  Skipping proto checks: %s", err)
+		return nil
+	}
+
+	var failed []string
+
+	if isProtoCheckEnabled(lint) {
+		protoDir := filepath.Join(c.app.Path, conf.Build.Proto.Path)
+
+		if c.reportProtoIssues("lint", lint, b.Lint(ctx, protoDir)) {
+			failed = append(failed, "lint")
+		}
+	}
+
+	if isProtoCheckEnabled(breaking) {
+		tag, err := repoversion.LatestTag(c.app.Path)
+		switch {
+		case errors.Is(err, repoversion.ErrNoTag):
+			// there is no release to compare with yet.
+		case err != nil:
+			return err
+		default:
+			if c.reportProtoIssues("breaking", breaking, c.checkProtoBreaking(ctx, b, conf, tag)) {
+				failed = append(failed, "breaking")
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return &CannotBuildAppError{fmt.Errorf("proto %s check failed", strings.Join(failed, " and "))}
+	}
+
+	return nil
+}
+
+// reportProtoIssues sends issues found by a proto check as events and reports whether the build
+// should fail because of them.
+func (c *Chain) reportProtoIssues(check, severity string, err error) (failed bool) {
+	if err == nil {
+		return false
+	}
+
+	icon := "⚠️ "
+	if severity == chainconfig.ProtoCheckError {
+		icon = "❌"
+	}
+
+	var annotations cosmosbuf.Annotations
+	if errors.As(err, &annotations) {
+		for _, annotation := range annotations {
+			c.sendEvent("%s proto %s: %s", icon, check, annotation)
+		}
+	} else {
+		c.sendEvent("%s proto %s: %s", icon, check, err)
+	}
+
+	return severity == chainconfig.ProtoCheckError
+}
+
+// sendEvent sends a formatted event to the event bus of the chain or prints it when there is no bus.
+func (c *Chain) sendEvent(format string, a ...interface{}) {
+	description := fmt.Sprintf(format, a...)
+
+	if c.ev == nil {
+		fmt.Fprintln(c.stdLog().out, description)
+		return
+	}
+
+	c.ev.Send(events.New(events.StatusDone, description))
+}
+
+func isProtoCheckEnabled(severity string) bool {
+	return severity == chainconfig.ProtoCheckWarn || severity == chainconfig.ProtoCheckError
+}
+
+// bufTemplate returns the path of the buf generation template relative to the proto dir.
+func bufTemplate(conf chainconfig.Config) string {
+	if conf.Build.Proto.Buf.Template != "" {
+		return conf.Build.Proto.Buf.Template
+	}
+	return cosmosbuf.GenTemplateFileName
+}
@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/cosmosbuf"
+	"github.com/tendermint/starport/starport/pkg/events"
+)
+
+func TestReportProtoIssues(t *testing.T) {
+	collect := func(severity string, err error) (descriptions []string, failed bool) {
+		ev := events.NewBus()
+		c := &Chain{ev: ev}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for event := range ev {
+				descriptions = append(descriptions, event.Description)
+			}
+		}()
+
+		failed = c.reportProtoIssues("lint", severity, err)
+		ev.Shutdown()
+		<-done
+
+		return descriptions, failed
+	}
+
+	annotations := cosmosbuf.Annotations{
+		{Path: "mars/post.proto", StartLine: 8, StartColumn: 3, Message: `Field "1" should have a comment.`},
+		{Path: "mars/tx.proto", StartLine: 2, StartColumn: 1, Message: `Package name "mars" should be suffixed with a version.`},
+	}
+
+	descriptions, failed := collect(chainconfig.ProtoCheckWarn, annotations)
+	require.False(t, failed)
+	require.Equal(t, []string{
+		`⚠️  proto lint: mars/post.proto:8:3:Field "1" should have a comment.`,
+		`⚠️  proto lint: mars/tx.proto:2:1:Package name "mars" should be suffixed with a version.`,
+	}, descriptions)
+
+	descriptions, failed = collect(chainconfig.ProtoCheckError, annotations)
+	require.True(t, failed)
+	require.Len(t, descriptions, 2)
+
+	descriptions, failed = collect(chainconfig.ProtoCheckError, errors.New("buf.yaml not found"))
+	require.True(t, failed)
+	require.Equal(t, []string{"❌ proto lint: buf.yaml not found"}, descriptions)
+
+	descriptions, failed = collect(chainconfig.ProtoCheckError, nil)
+	require.False(t, failed)
+	require.Empty(t, descriptions)
+}
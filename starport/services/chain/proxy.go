@@ -0,0 +1,137 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/httpproxy"
+	"github.com/tendermint/starport/starport/pkg/xfilepath"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// proxyCertsPath is the place where the certificates of the proxies are stored, they're not in the
+// home of the chain so they outlive the resets of its state.
+var proxyCertsPath = xfilepath.Join(
+	chainconfig.ConfigDirPath,
+	xfilepath.Path("proxy"),
+)
+
+// ErrProxyDomainRequired is returned when the chain is served with --public without proxy.domain.
+var ErrProxyDomainRequired = errors.New("proxy.domain is required in config.yml to serve the chain publicly")
+
+// proxyRoutes returns the addresses of the servers of the chain by their public hostnames.
+func proxyRoutes(config chainconfig.Config, isFaucetEnabled, isOpenAPIEnabled bool, options serveOptions) map[string]string {
+	p := config.Proxy
+	routes := map[string]string{
+		p.Hostname("rpc"): config.Host.RPC,
+		p.Hostname("api"): config.Host.API,
+	}
+	if isFaucetEnabled {
+		routes[p.Hostname("faucet")] = chainconfig.FaucetHost(config)
+	}
+	if isOpenAPIEnabled {
+		routes[p.Hostname("openapi")] = config.Host.OpenAPI
+	}
+	if options.grpcUI {
+		routes[p.Hostname("grpc-ui")] = config.Host.GRPCUI
+	}
+	if options.graphQL {
+		routes[p.Hostname("graphql")] = config.Host.GraphQL
+	}
+	return routes
+}
+
+// loopbackConfig returns the config with the servers behind the proxy listening on the loopback
+// interface instead of all the interfaces.
+func loopbackConfig(config chainconfig.Config) chainconfig.Config {
+	config.Host = loopbackHost(config.Host)
+	config.Host.OpenAPI = localAddress(config.Host.OpenAPI)
+	config.Host.GRPCUI = localAddress(config.Host.GRPCUI)
+	config.Host.GraphQL = localAddress(config.Host.GraphQL)
+	config.Faucet.Host = localAddress(chainconfig.FaucetHost(config))
+	config.Faucet.Port = 0
+	return config
+}
+
+// loopbackHost returns the host of a node with its RPC and API servers listening on the loopback
+// interface instead of all the interfaces.
+func loopbackHost(host chainconfig.Host) chainconfig.Host {
+	host.RPC = localAddress(host.RPC)
+	host.API = localAddress(host.API)
+	return host
+}
+
+// runProxyServers serves the servers of the chain over TLS at host.proxy routed by their hostnames,
+// and redirects the requests at host.proxy-http to it.
+func (c *Chain) runProxyServers(ctx context.Context, config chainconfig.Config, routes map[string]string) error {
+	if config.Proxy.Domain == "" {
+		return ErrProxyDomainRequired
+	}
+
+	certsPath, err := proxyCertsPath()
+	if err != nil {
+		return err
+	}
+
+	var (
+		proxy     = httpproxy.New(routes)
+		hostnames = proxy.Hostnames()
+		dir       = filepath.Join(certsPath, config.Proxy.Domain)
+		redirect  = httpproxy.RedirectHandler(config.Host.Proxy)
+		server    = &http.Server{
+			Addr:    config.Host.Proxy,
+			Handler: proxy,
+
+			// the handshake errors are logged for each client that doesn't trust the certificate
+			// and for the probes of the readiness.
+			ErrorLog: log.New(io.Discard, "", 0),
+		}
+		tlsName string
+	)
+
+	switch config.Proxy.TLS {
+	case chainconfig.ProxyTLSACME:
+		m := httpproxy.NewACMEManager(
+			filepath.Join(dir, chainconfig.ProxyTLSACME),
+			config.Proxy.Email,
+			config.Proxy.ACMEDirectory,
+			hostnames,
+		)
+		server.TLSConfig = m.TLSConfig()
+		redirect = m.HTTPHandler(redirect)
+		tlsName = "ACME certificates"
+	default:
+		if server.TLSConfig, err = httpproxy.SelfSignedTLSConfig(
+			filepath.Join(dir, chainconfig.ProxyTLSSelfSigned),
+			hostnames,
+		); err != nil {
+			return err
+		}
+		tlsName = fmt.Sprintf("a self-signed certificate in %s", filepath.Join(dir, chainconfig.ProxyTLSSelfSigned))
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🔒 Serving the chain publicly with %s:\n", tlsName)
+	for _, hostname := range hostnames {
+		fmt.Fprintf(c.stdLog().out, "   https://%s → %s\n", hostname, routes[hostname])
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return xhttp.ServeTLS(ctx, server)
+	})
+	g.Go(func() error {
+		return xhttp.Serve(ctx, &http.Server{
+			Addr:    config.Host.ProxyHTTP,
+			Handler: redirect,
+		})
+	})
+	return g.Wait()
+}
@@ -0,0 +1,262 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+const (
+	// readinessTimeout is the time that the servers of the chain have to get ready after start.
+	readinessTimeout = time.Minute
+
+	// readinessPollInterval is the interval between the probes of a server that is not ready.
+	readinessPollInterval = 500 * time.Millisecond
+)
+
+// endpoint is a server of the chain that is probed to report its readiness.
+type endpoint struct {
+	// name of the server as printed.
+	name string
+
+	// url of the server as printed.
+	url string
+
+	// probe checks that the server is ready.
+	probe func(ctx context.Context) error
+
+	// hint returns an actionable message printed when the server doesn't get ready.
+	hint func() string
+}
+
+// endpoints returns the servers of the chain started for the config and the nodes.
+func endpoints(
+	config chainconfig.Config,
+	nodes []node,
+	isFaucetEnabled,
+	isOpenAPIEnabled,
+	isGRPCUIEnabled,
+	isGraphQLEnabled,
+	isPublic,
+	isRecorderEnabled bool,
+) []endpoint {
+	var (
+		primary = nodes[0]
+		appTOML = filepath.Join(primary.home, "config/app.toml")
+	)
+
+	list := []endpoint{
+		{
+			name:  "Tendermint node",
+			url:   xurl.HTTP(config.Host.RPC),
+			probe: probeHTTP(xurl.HTTP(config.Host.RPC) + "/status"),
+			hint:  hostHint("host.rpc"),
+		},
+		{
+			name:  "Blockchain API",
+			url:   xurl.HTTP(config.Host.API),
+			probe: probeHTTP(xurl.HTTP(config.Host.API) + "/cosmos/base/tendermint/v1beta1/node_info"),
+			hint:  appTOMLHint(appTOML, "api.enable", "host.api"),
+		},
+		{
+			name:  "gRPC server",
+			url:   config.Host.GRPC,
+			probe: probeTCP(config.Host.GRPC),
+			hint:  appTOMLHint(appTOML, "grpc.enable", "host.grpc"),
+		},
+	}
+
+	for _, n := range nodes[1:] {
+		list = append(list, endpoint{
+			name:  fmt.Sprintf("Tendermint node of %s", n.validator.Name),
+			url:   xurl.HTTP(n.host.RPC),
+			probe: probeHTTP(xurl.HTTP(n.host.RPC) + "/status"),
+			hint:  hostHint(fmt.Sprintf("the host.rpc of the validator %s", n.validator.Name)),
+		})
+	}
+
+	if isFaucetEnabled {
+		faucetURL := xurl.HTTP(chainconfig.FaucetHost(config))
+		list = append(list, endpoint{
+			name:  "Token faucet",
+			url:   faucetURL,
+			probe: probeHTTP(faucetURL + "/healthz"),
+			hint:  hostHint("faucet.host"),
+		})
+	}
+
+	if isOpenAPIEnabled {
+		list = append(list, endpoint{
+			name:  "OpenAPI console",
+			url:   xurl.HTTP(config.Host.OpenAPI),
+			probe: probeHTTP(xurl.HTTP(config.Host.OpenAPI)),
+			hint:  hostHint("host.openapi"),
+		})
+	}
+
+	if isGRPCUIEnabled {
+		list = append(list, endpoint{
+			name:  "gRPC UI",
+			url:   xurl.HTTP(config.Host.GRPCUI),
+			probe: probeHTTP(xurl.HTTP(config.Host.GRPCUI)),
+			hint:  hostHint("host.grpc-ui"),
+		})
+	}
+
+	if isGraphQLEnabled {
+		list = append(list, endpoint{
+			name:  "GraphQL API",
+			url:   xurl.HTTP(config.Host.GraphQL),
+			probe: probeHTTP(xurl.HTTP(config.Host.GraphQL)),
+			hint:  hostHint("host.graphql"),
+		})
+	}
+
+	if isPublic {
+		list = append(list, endpoint{
+			name:  "TLS proxy",
+			url:   config.Host.Proxy,
+			probe: probeTCP(config.Host.Proxy),
+			hint:  hostHint("host.proxy"),
+		})
+	}
+
+	if isRecorderEnabled {
+		list = append(list,
+			endpoint{
+				name:  "API recorder",
+				url:   xurl.HTTP(config.Host.APIRecorder),
+				probe: probeTCP(config.Host.APIRecorder),
+				hint:  hostHint("host.api-recorder"),
+			},
+			endpoint{
+				name:  "gRPC recorder",
+				url:   config.Host.GRPCRecorder,
+				probe: probeTCP(config.Host.GRPCRecorder),
+				hint:  hostHint("host.grpc-recorder"),
+			},
+		)
+	}
+
+	return list
+}
+
+// reportReadiness probes the servers until they are ready and prints their addresses, the servers
+// that don't get ready before the timeout are reported with the reason and a hint to fix them.
+func (c *Chain) reportReadiness(ctx context.Context, list []endpoint) {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, e := range list {
+		e := e
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := waitReady(ctx, e.probe)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err == nil:
+				fmt.Fprintf(c.stdLog().out, "🌍 %s: %s\n", e.name, e.url)
+			case ctx.Err() == context.Canceled:
+				// the chain is stopped or restarted.
+			default:
+				fmt.Fprintf(c.stdLog().out, "⚠️  %s is not ready at %s: %s\n", e.name, e.url, err)
+				fmt.Fprintf(c.stdLog().out, "   %s\n", e.hint())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// waitReady probes until the probe succeeds or ctx is done, the error of the last probe that is
+// not interrupted by ctx is returned when ctx is done.
+func waitReady(ctx context.Context, probe func(ctx context.Context) error) error {
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		err := probe(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() == nil || lastErr == nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastErr
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeHTTP returns a probe that checks that a GET request to url succeeds.
+func probeHTTP(url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("unexpected status %s", res.Status)
+		}
+		return nil
+	}
+}
+
+// probeTCP returns a probe that checks that the server at address accepts connections.
+func probeTCP(address string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", xurl.Address(address))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// hostHint returns a hint for a server that doesn't get ready with its host in config.yml.
+func hostHint(key string) func() string {
+	return func() string {
+		return fmt.Sprintf("check the logs of the chain and that the address of %s in config.yml is not used by another process", key)
+	}
+}
+
+// appTOMLHint returns a hint that tells to enable the server in the app.toml at path when it's
+// disabled there, or a hint for its host in config.yml otherwise.
+func appTOMLHint(path, enableKey, hostKey string) func() string {
+	return func() string {
+		if appTOML, err := toml.LoadFile(path); err == nil {
+			if enabled, ok := appTOML.Get(enableKey).(bool); ok && !enabled {
+				return fmt.Sprintf("the server is disabled, set %s to true in %s or in init.app of config.yml", enableKey, path)
+			}
+		}
+		return hostHint(hostKey)()
+	}
+}
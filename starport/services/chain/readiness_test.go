@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitReady(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the server gets ready after the first request.
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	require.NoError(t, waitReady(context.Background(), probeHTTP(server.URL)))
+	require.EqualValues(t, 2, requests)
+	require.NoError(t, waitReady(context.Background(), probeTCP(server.Listener.Addr().String())))
+}
+
+func TestWaitReadyTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), readinessPollInterval*2)
+	defer cancel()
+
+	err := waitReady(ctx, probeHTTP(server.URL))
+	require.EqualError(t, err, "unexpected status 404 Not Found")
+}
+
+func TestAppTOMLHint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[api]\nenable = false\n\n[grpc]\nenable = true\n"), 0644))
+
+	require.Contains(t, appTOMLHint(path, "api.enable", "host.api")(), "set api.enable to true")
+	require.Contains(t, appTOMLHint(path, "grpc.enable", "host.grpc")(), "host.grpc in config.yml")
+}
@@ -0,0 +1,72 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/httprecorder"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// requestsFile is the default file of the recorded requests in the home of the chain.
+const requestsFile = "requests.jsonl"
+
+// RequestsPath returns the path of the file of the requests recorded while serving with the
+// recorder, a relative recorder.file of the config is relative to the source of the chain.
+func (c *Chain) RequestsPath() (string, error) {
+	config, err := c.Config()
+	if err != nil {
+		return "", err
+	}
+	if config.Recorder.File != "" {
+		if filepath.IsAbs(config.Recorder.File) {
+			return config.Recorder.File, nil
+		}
+		return filepath.Join(c.app.Path, config.Recorder.File), nil
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, requestsFile), nil
+}
+
+// runRecorderServers serves the proxies of the API and the gRPC server of the node that record
+// the requests.
+func (c *Chain) runRecorderServers(ctx context.Context, config chainconfig.Config) error {
+	path, err := c.RequestsPath()
+	if err != nil {
+		return err
+	}
+
+	r, err := httprecorder.New(
+		path,
+		httprecorder.WithSampleRate(config.Recorder.SampleRate),
+		httprecorder.WithRedact(config.Recorder.Redact...),
+		httprecorder.WithMaxBodySize(config.Recorder.MaxBodySize),
+	)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return xhttp.Serve(ctx, &http.Server{
+			Addr:    config.Host.APIRecorder,
+			Handler: r.Proxy(httprecorder.KindAPI, config.Host.API),
+		})
+	})
+	g.Go(func() error {
+		return xhttp.Serve(ctx, &http.Server{
+			Addr:    config.Host.GRPCRecorder,
+			Handler: r.Proxy(httprecorder.KindGRPC, config.Host.GRPC),
+		})
+	})
+	return g.Wait()
+}
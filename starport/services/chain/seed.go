@@ -0,0 +1,111 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// seedPollInterval is the interval of the queries made while waiting for the first block of the
+// chain to send the seed txs.
+const seedPollInterval = 500 * time.Millisecond
+
+// Seed sends the txs to the chain in order, each tx is added to a block before the next one is sent
+// so the txs can depend on the previous ones, e.g. a contract instantiated from a stored code.
+func (c *Chain) Seed(ctx context.Context, txs []chainconfig.SeedTx) error {
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return err
+	}
+
+	addresses := make(map[string]string)
+	funcs := template.FuncMap{
+		"address": func(name string) (string, error) {
+			if address, ok := addresses[name]; ok {
+				return address, nil
+			}
+			account, err := commands.ShowAccount(ctx, name)
+			if err != nil {
+				return "", err
+			}
+			addresses[name] = account.Address
+			return account.Address, nil
+		},
+	}
+
+	for i, tx := range txs {
+		args := []string{tx.Module, tx.Msg}
+		for _, arg := range tx.Args {
+			arg, err := renderSeedArg(arg, funcs)
+			if err != nil {
+				return fmt.Errorf("seed[%d]: %w", i, err)
+			}
+			args = append(args, arg)
+		}
+
+		if _, err := commands.Tx(ctx, tx.From, args...); err != nil {
+			return fmt.Errorf("seed[%d] %s %s: %w", i, tx.Module, tx.Msg, err)
+		}
+	}
+
+	return nil
+}
+
+func renderSeedArg(arg string, funcs template.FuncMap) (string, error) {
+	t, err := template.New("arg").Funcs(funcs).Parse(arg)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, nil); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// seed sends the seed txs of the config once the chain has produced its first block and reports
+// whether they are sent, the serve goes on when a tx fails so it can be fixed while serving.
+func (c *Chain) seed(ctx context.Context, config chainconfig.Config) {
+	if err := waitForFirstBlock(ctx, config.Host.RPC); err != nil {
+		return
+	}
+
+	if err := c.Seed(ctx, config.Seed); err != nil {
+		if ctx.Err() == nil {
+			fmt.Fprintf(c.stdLog().err, "❌ Cannot seed the chain: %s\n", err)
+		}
+		return
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🌱 Seeded the chain with %d txs.\n", len(config.Seed))
+}
+
+// waitForFirstBlock waits until the chain with the RPC address produces its first block.
+func waitForFirstBlock(ctx context.Context, rpcAddress string) error {
+	client, err := rpchttp.New(xurl.HTTP(rpcAddress), "/websocket")
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(seedPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if status, err := client.Status(ctx); err == nil && status.SyncInfo.LatestBlockHeight > 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -22,7 +22,7 @@ import (
 	"github.com/tendermint/starport/starport/pkg/xexec"
 	"github.com/tendermint/starport/starport/pkg/xfilepath"
 	"github.com/tendermint/starport/starport/pkg/xhttp"
-	"github.com/tendermint/starport/starport/pkg/xurl"
+	"github.com/tendermint/starport/starport/pkg/xssh"
 )
 
 const (
@@ -53,6 +53,15 @@ var (
 type serveOptions struct {
 	forceReset bool
 	resetOnce  bool
+	grpcUI     bool
+	graphQL    bool
+	public     bool
+	record     bool
+	indexURL   string
+
+	// target is the machine that serves the chain instead of the local machine when it's set.
+	target      *xssh.Target
+	targetBuild string
 }
 
 func newServeOption() serveOptions {
@@ -79,6 +88,54 @@ func ServeResetOnce() ServeOption {
 	}
 }
 
+// ServeGRPCUI starts a web UI to explore and invoke the gRPC services of the node through gRPC reflection
+func ServeGRPCUI() ServeOption {
+	return func(c *serveOptions) {
+		c.grpcUI = true
+	}
+}
+
+// ServeGraphQL starts a GraphQL API whose queries are resolved by the gRPC query services of the
+// modules of the chain
+func ServeGraphQL() ServeOption {
+	return func(c *serveOptions) {
+		c.graphQL = true
+	}
+}
+
+// ServePublic exposes the servers of the chain behind a reverse proxy served over TLS that routes
+// the requests by their hostnames under proxy.domain
+func ServePublic() ServeOption {
+	return func(c *serveOptions) {
+		c.public = true
+	}
+}
+
+// ServeRecordRequests starts the proxies of the API and the gRPC server of the node that record the
+// requests with their responses
+func ServeRecordRequests() ServeOption {
+	return func(c *serveOptions) {
+		c.record = true
+	}
+}
+
+// ServeIndex indexes the blocks of the chain with their txs and events in the PostgreSQL database
+// with the URL while the chain is served
+func ServeIndex(url string) ServeOption {
+	return func(c *serveOptions) {
+		c.indexURL = url
+	}
+}
+
+// ServeTarget serves the chain on the target over SSH instead of the local machine, the binary of the
+// chain is built on the target with TargetBuildRemote and cross-compiled locally with TargetBuildLocal.
+func ServeTarget(target xssh.Target, build string) ServeOption {
+	return func(c *serveOptions) {
+		c.target = &target
+		c.targetBuild = build
+	}
+}
+
 // Serve serves an app.
 func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
 	serveOptions := newServeOption()
@@ -88,6 +145,10 @@ func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
 		apply(&serveOptions)
 	}
 
+	if serveOptions.target != nil {
+		return c.serveTarget(ctx, serveOptions)
+	}
+
 	// initial checks and setup.
 	if err := c.setup(); err != nil {
 		return err
@@ -134,7 +195,7 @@ func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
 				shouldReset := serveOptions.forceReset || serveOptions.resetOnce
 
 				// serve the app.
-				err = c.serve(serveCtx, shouldReset)
+				err = c.serve(serveCtx, serveOptions, shouldReset)
 				serveOptions.resetOnce = false
 
 				switch {
@@ -224,7 +285,12 @@ func (c *Chain) refreshServe() {
 }
 
 func (c *Chain) watchAppBackend(ctx context.Context) error {
-	watchPaths := appBackendSourceWatchPaths
+	return c.watchSource(ctx, c.refreshServe)
+}
+
+// watchSource calls onChange when the source or the config of the chain change.
+func (c *Chain) watchSource(ctx context.Context, onChange func()) error {
+	watchPaths := c.sourcePaths()
 	if c.ConfigPath() != "" {
 		watchPaths = append(watchPaths, c.ConfigPath())
 	}
@@ -233,7 +299,7 @@ func (c *Chain) watchAppBackend(ctx context.Context) error {
 		ctx,
 		watchPaths,
 		localfs.WatcherWorkdir(c.app.Path),
-		localfs.WatcherOnChange(c.refreshServe),
+		localfs.WatcherOnChange(onChange),
 		localfs.WatcherIgnoreHidden(),
 		localfs.WatcherIgnoreExt(ignoredExts...),
 	)
@@ -242,17 +308,12 @@ func (c *Chain) watchAppBackend(ctx context.Context) error {
 // serve performs the operations to serve the blockchain: build, init and start
 // if the chain is already initialized and the file didn't changed, the app is directly started
 // if the files changed, the state is imported
-func (c *Chain) serve(ctx context.Context, forceReset bool) error {
+func (c *Chain) serve(ctx context.Context, options serveOptions, forceReset bool) error {
 	conf, err := c.Config()
 	if err != nil {
 		return &CannotBuildAppError{err}
 	}
 
-	commands, err := c.Commands(ctx)
-	if err != nil {
-		return err
-	}
-
 	saveDir, err := c.chainSavePath()
 	if err != nil {
 		return err
@@ -285,7 +346,7 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 
 	// check if source has been modified since last serve
 	// if the state must not be reset but the source has changed, we rebuild the chain and import the exported state
-	sourceModified, err := dirchange.HasDirChecksumChanged(c.app.Path, appBackendSourceWatchPaths, saveDir, sourceChecksum)
+	sourceModified, err := dirchange.HasDirChecksumChanged(c.app.Path, c.sourcePaths(), saveDir, sourceChecksum)
 	if err != nil {
 		return err
 	}
@@ -331,7 +392,9 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 		}
 	}
 
-	// init phase
+	// init phase, the seed txs are only sent to the initialized chains.
+	var seed bool
+
 	// nolint:gocritic
 	if !isInit || (appModified && !exportGenesisExists) {
 		fmt.Fprintln(c.stdLog().out, "💿 Initializing the app...")
@@ -339,16 +402,17 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 		if err := c.Init(ctx, true); err != nil {
 			return err
 		}
+		seed = true
 	} else if appModified {
 		// if the chain is already initialized but the source has been modified
 		// we reset the chain database and import the genesis state
 		fmt.Fprintln(c.stdLog().out, "💿 Existent genesis detected, restoring the database...")
 
-		if err := commands.UnsafeReset(ctx); err != nil {
+		if err := c.resetNodes(ctx, conf); err != nil {
 			return err
 		}
 
-		if err := c.importChainState(); err != nil {
+		if err := c.importChainState(conf); err != nil {
 			return err
 		}
 	} else {
@@ -361,7 +425,7 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 			return err
 		}
 	}
-	if err := dirchange.SaveDirChecksum(c.app.Path, appBackendSourceWatchPaths, saveDir, sourceChecksum); err != nil {
+	if err := dirchange.SaveDirChecksum(c.app.Path, c.sourcePaths(), saveDir, sourceChecksum); err != nil {
 		return err
 	}
 	binaryPath, err = exec.LookPath(binaryName)
@@ -372,23 +436,56 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 		return err
 	}
 
+	// the chain info is updated since the genesis can be changed by the init.
+	if err := c.generateKeplrChainInfo(conf); err != nil {
+		return err
+	}
+
 	// start the blockchain
-	return c.start(ctx, conf)
+	return c.start(ctx, conf, options, seed)
 }
 
-func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
-	commands, err := c.Commands(ctx)
+func (c *Chain) start(ctx context.Context, config chainconfig.Config, options serveOptions, seed bool) error {
+	// the servers behind the proxy are only reached through it when the chain is served publicly.
+	if options.public {
+		config = loopbackConfig(config)
+	}
+
+	nodes, err := c.nodes(config)
+	if err != nil {
+		return err
+	}
+
+	home, err := c.Home()
 	if err != nil {
 		return err
 	}
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	// start the blockchain.
-	g.Go(func() error { return c.plugin.Start(ctx, commands, config) })
+	// start the nodes of the validators.
+	for i, n := range nodes {
+		n := n
+
+		if options.public {
+			n.host = loopbackHost(n.host)
+			nodes[i] = n
+		}
+		if err := c.updateListenAddresses(config, n, options.public); err != nil {
+			return err
+		}
 
-	// start the faucet if enabled.
-	faucet, err := c.Faucet(ctx)
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		g.Go(func() error { return c.plugin.Start(ctx, commands, nodeConfig(config, n)) })
+	}
+
+	// start the faucet if enabled, the claim history of the faucet is persisted
+	// in the home of the chain to keep the limits across restarts.
+	faucet, err := c.Faucet(ctx, cosmosfaucet.ClaimsDir(home))
 	isFaucetEnabled := err != ErrFaucetIsNotEnabled
 
 	if isFaucetEnabled {
@@ -400,32 +497,71 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 		}
 
 		g.Go(func() (err error) {
-			if err := c.runFaucetServer(ctx, faucet); err != nil {
+			if err := c.runFaucetServer(ctx, config, faucet); err != nil {
 				return &CannotBuildAppError{err}
 			}
 			return nil
 		})
 	}
 
+	// serve the OpenAPI spec of the chain if it's generated.
+	isOpenAPIEnabled := isOpenAPIServerEnabled(config)
+
+	if isOpenAPIEnabled {
+		g.Go(func() error { return c.runOpenAPIServer(ctx, config) })
+	}
+
+	// serve the gRPC UI if enabled.
+	if options.grpcUI {
+		g.Go(func() error { return c.runGRPCUIServer(ctx, config) })
+	}
+
+	// serve the GraphQL API if enabled.
+	if options.graphQL {
+		g.Go(func() error { return c.runGraphQLServer(ctx, config) })
+	}
+
+	// expose the servers publicly if enabled.
+	if options.public {
+		routes := proxyRoutes(config, isFaucetEnabled, isOpenAPIEnabled, options)
+		g.Go(func() error { return c.runProxyServers(ctx, config, routes) })
+	}
+
+	// serve the proxies that record the requests if enabled.
+	if options.record {
+		g.Go(func() error { return c.runRecorderServers(ctx, config) })
+	}
+
+	// index the blocks into the database if enabled.
+	if options.indexURL != "" {
+		g.Go(func() error {
+			c.runIndexer(ctx, config, options.indexURL)
+			return nil
+		})
+	}
+
 	// set the app as being served
 	c.served = true
 
-	// print the server addresses.
-	fmt.Fprintf(c.stdLog().out, "🌍 Tendermint node: %s\n", xurl.HTTP(config.Host.RPC))
-	fmt.Fprintf(c.stdLog().out, "🌍 Blockchain API: %s\n", xurl.HTTP(config.Host.API))
+	// print the server addresses once they are ready.
+	g.Go(func() error {
+		c.reportReadiness(ctx, endpoints(config, nodes, isFaucetEnabled, isOpenAPIEnabled, options.grpcUI, options.graphQL, options.public, options.record))
+		return nil
+	})
 
-	if isFaucetEnabled {
-		fmt.Fprintf(c.stdLog().out, "🌍 Token faucet: %s\n", xurl.HTTP(chainconfig.FaucetHost(config)))
+	// send the seed txs to the chain once it produces blocks.
+	if seed && len(config.Seed) > 0 {
+		g.Go(func() error {
+			c.seed(ctx, config)
+			return nil
+		})
 	}
 
 	return g.Wait()
 }
 
-func (c *Chain) runFaucetServer(ctx context.Context, faucet cosmosfaucet.Faucet) error {
-	config, err := c.Config()
-	if err != nil {
-		return err
-	}
+func (c *Chain) runFaucetServer(ctx context.Context, config chainconfig.Config, faucet cosmosfaucet.Faucet) error {
+	defer faucet.Close()
 
 	return xhttp.Serve(ctx, &http.Server{
 		Addr:    chainconfig.FaucetHost(config),
@@ -443,18 +579,43 @@ func (c *Chain) saveChainState(ctx context.Context, commands chaincmdrunner.Runn
 	return commands.Export(ctx, genesisPath)
 }
 
-// importChainState imports the saved genesis in chain config to use it as the genesis
-func (c *Chain) importChainState() error {
+// importChainState imports the saved genesis in chain config to use it as the genesis of the nodes
+func (c *Chain) importChainState(conf chainconfig.Config) error {
 	exportGenesisPath, err := c.exportedGenesisPath()
 	if err != nil {
 		return err
 	}
-	genesisPath, err := c.GenesisPath()
+
+	nodes, err := c.nodes(conf)
 	if err != nil {
 		return err
 	}
 
-	return copy.Copy(exportGenesisPath, genesisPath)
+	for _, n := range nodes {
+		if err := copy.Copy(exportGenesisPath, filepath.Join(n.home, "config/genesis.json")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetNodes resets the databases of the nodes.
+func (c *Chain) resetNodes(ctx context.Context, conf chainconfig.Config) error {
+	nodes, err := c.nodes(conf)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+		if err := commands.UnsafeReset(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // chainSavePath returns the path where the chain state is saved
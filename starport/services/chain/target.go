@@ -0,0 +1,471 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+	"github.com/tendermint/starport/starport/pkg/lineprefixer"
+	"github.com/tendermint/starport/starport/pkg/xssh"
+)
+
+// Builds of the chains served on a target.
+const (
+	// TargetBuildRemote syncs the source of the chain to the target and serves it there with Starport.
+	TargetBuildRemote = "remote"
+
+	// TargetBuildLocal cross-compiles the binary of the chain locally and ships it to the target with
+	// the home of the chain initialized locally.
+	TargetBuildLocal = "local"
+)
+
+const (
+	// targetDir is the directory of the chains served on the targets, relative to the home of the
+	// user on the target.
+	targetDir = ".starport/targets"
+
+	// targetEnv adds the usual directories of Go and Starport to the PATH of the commands run on the
+	// target, the shells of the SSH commands don't read the profile of the user.
+	targetEnv = `PATH="$PATH:$HOME/go/bin:/usr/local/go/bin:/usr/local/bin"`
+
+	// targetConfigChecksum is the file containing the checksum to detect the config modifications of
+	// the chains served on a target.
+	targetConfigChecksum = "target_config_checksum.txt"
+)
+
+// ErrTargetValidators is returned when a chain with several validators is served on a target with
+// TargetBuildLocal.
+var ErrTargetValidators = errors.New("the chains built locally for a target can only have one validator")
+
+// serveTarget serves the chain on the target of the options instead of the local machine, the servers
+// of the target are forwarded to their local addresses so the chain is used as if it was served locally.
+func (c *Chain) serveTarget(ctx context.Context, options serveOptions) error {
+	conf, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	target := *options.target
+	fmt.Fprintf(c.stdLog().out, "🔌 Connecting to %s...\n", target)
+
+	client, err := xssh.Dial(ctx, target)
+	if err != nil {
+		return errors.Wrapf(err, "cannot connect to %s", target)
+	}
+	defer client.Close()
+
+	dir := target.Path
+	if dir == "" {
+		dir = path.Join(targetDir, c.app.Name)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	// the servers that can't be forwarded, e.g. because their address is used locally, are only
+	// reachable on the target.
+	for _, f := range targetForwards(conf, options) {
+		f := f
+		g.Go(func() error {
+			if err := client.Forward(ctx, f.address, targetAddress(f.address)); err != nil {
+				fmt.Fprintf(c.stdLog().err, "❌ Cannot forward the %s of the target to %s: %s\n", f.name, f.address, err)
+			}
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		if options.targetBuild == TargetBuildLocal {
+			return c.serveTargetBinary(ctx, client, conf, dir, options)
+		}
+		return c.serveTargetSource(ctx, client, dir, options)
+	})
+
+	return g.Wait()
+}
+
+// forward is a server of the target forwarded to the same address locally.
+type forward struct {
+	name    string
+	address string
+}
+
+func targetForwards(conf chainconfig.Config, options serveOptions) []forward {
+	forwards := []forward{
+		{"Tendermint node", conf.Host.RPC},
+		{"blockchain API", conf.Host.API},
+		{"gRPC server", conf.Host.GRPC},
+	}
+	if options.targetBuild == TargetBuildLocal {
+		return forwards
+	}
+
+	// the other servers are started by Starport on the target.
+	if conf.Faucet.Name != nil {
+		forwards = append(forwards, forward{"token faucet", chainconfig.FaucetHost(conf)})
+	}
+	if isOpenAPIServerEnabled(conf) {
+		forwards = append(forwards, forward{"OpenAPI console", conf.Host.OpenAPI})
+	}
+	if options.grpcUI {
+		forwards = append(forwards, forward{"gRPC UI", conf.Host.GRPCUI})
+	}
+	if options.graphQL {
+		forwards = append(forwards, forward{"GraphQL API", conf.Host.GraphQL})
+	}
+	return forwards
+}
+
+// targetAddress returns the address of a server on the target from its local address.
+func targetAddress(address string) string {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+// sourceMatch matches the files of the source synced to the targets.
+func sourceMatch(p string, isDir bool) bool {
+	name := path.Base(p)
+	return !strings.HasPrefix(name, ".") && name != "node_modules" && p != releaseDir
+}
+
+// serveTargetSource syncs the source of the chain to the dir of the target while Starport serves it
+// there, the changes of the source are synced so Starport rebuilds the chain on the target.
+func (c *Chain) serveTargetSource(ctx context.Context, client *xssh.Client, dir string, options serveOptions) error {
+	version, err := client.Output(ctx, targetEnv+" starport version")
+	if err != nil {
+		return errors.Wrap(err, "cannot find starport on the target, install it or build the chain locally with --target-build local")
+	}
+	version = strings.SplitN(strings.TrimSpace(version), "\n", 2)[0]
+	fmt.Fprintf(c.stdLog().out, "🔌 Connected to %s\n", version)
+
+	args, err := c.targetServeArgs(options)
+	if err != nil {
+		return err
+	}
+
+	syncer := client.NewSyncer(c.app.Path, dir, sourceMatch)
+	if err := c.syncTarget(ctx, syncer); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	changes := make(chan struct{}, 1)
+	g.Go(func() error {
+		return c.watchSource(ctx, func() { notify(changes) })
+	})
+	g.Go(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-changes:
+				// the sync is retried on the next change.
+				if err := c.syncTarget(ctx, syncer); err != nil && ctx.Err() == nil {
+					fmt.Fprintf(c.stdLog().err, "❌ Cannot sync the source to the target: %s\n", err)
+				}
+			}
+		}
+	})
+	g.Go(func() error {
+		command := fmt.Sprintf("cd %s && %s starport chain serve %s", xssh.Quote(dir), targetEnv, strings.Join(args, " "))
+		err := client.Run(ctx, command, xssh.RunTerminal(), xssh.RunStdout(c.stdLog().out), xssh.RunStderr(c.stdLog().err))
+		if err != nil && ctx.Err() == nil {
+			return errors.Wrap(err, "starport stopped on the target")
+		}
+		return ctx.Err()
+	})
+
+	return g.Wait()
+}
+
+// targetServeArgs returns the args of the serve command on the target for the options.
+func (c *Chain) targetServeArgs(options serveOptions) ([]string, error) {
+	args := []string{"-p", "."}
+
+	if c.options.ConfigFile != "" {
+		path, err := filepath.Abs(c.options.ConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(c.app.Path, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, errors.New("the config file must be in the source of the chain to serve it on a target")
+		}
+		args = append(args, "-c", xssh.Quote(filepath.ToSlash(rel)))
+	}
+	if c.options.configProfile != "" {
+		args = append(args, "--profile", xssh.Quote(c.options.configProfile))
+	}
+	if c.options.configCommands {
+		args = append(args, "--allow-config-commands")
+	}
+	if c.logLevel == LogVerbose {
+		args = append(args, "-v")
+	}
+
+	for _, flag := range []struct {
+		enabled bool
+		name    string
+	}{
+		{options.forceReset, "--force-reset"},
+		{options.resetOnce, "--reset-once"},
+		{options.grpcUI, "--grpc-ui"},
+		{options.graphQL, "--graphql"},
+		{options.public, "--public"},
+		{options.record, "--record-requests"},
+		{c.options.isThirdPartyModuleCodegenEnabled, "--proto-all-modules"},
+	} {
+		if flag.enabled {
+			args = append(args, flag.name)
+		}
+	}
+	if options.indexURL != "" {
+		args = append(args, "--index", xssh.Quote(options.indexURL))
+	}
+	return args, nil
+}
+
+// syncTarget syncs the changes of the source to the target.
+func (c *Chain) syncTarget(ctx context.Context, syncer *xssh.Syncer) error {
+	res, err := syncer.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	if len(res.Uploaded) > 0 || len(res.Removed) > 0 {
+		fmt.Fprintf(c.stdLog().out, "📤 Synced the source to the target: %d file(s) uploaded, %d removed\n", len(res.Uploaded), len(res.Removed))
+	}
+	return nil
+}
+
+// notify notifies the channel of a change without blocking, the changes are merged until they're handled.
+func notify(changes chan struct{}) {
+	select {
+	case changes <- struct{}{}:
+	default:
+	}
+}
+
+// serveTargetBinary builds the binary of the chain locally for the platform of the target, ships it
+// to the dir of the target and starts the node there. the binary is built and shipped again when the
+// source changes, the home of the chain is initialized locally and shipped when the chain is served
+// for the first time, when the state is reset and when the config changes.
+func (c *Chain) serveTargetBinary(
+	ctx context.Context,
+	client *xssh.Client,
+	conf chainconfig.Config,
+	dir string,
+	options serveOptions,
+) error {
+	if err := c.setup(); err != nil {
+		return err
+	}
+	if len(conf.Validators) > 1 {
+		return ErrTargetValidators
+	}
+
+	uname, err := client.Output(ctx, "uname -sm")
+	if err != nil {
+		return err
+	}
+	goos, goarch, err := parseUname(uname)
+	if err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	changes := make(chan struct{}, 1)
+	g.Go(func() error {
+		return c.watchSource(ctx, func() { notify(changes) })
+	})
+	g.Go(func() error {
+		reset := options.forceReset || options.resetOnce
+		for {
+			var (
+				runCtx, cancel = context.WithCancel(ctx)
+				done           = make(chan error, 1)
+			)
+			err := c.deployTarget(ctx, client, dir, goos, goarch, reset)
+			reset = options.forceReset
+
+			var buildErr *CannotBuildAppError
+			switch {
+			case err == nil:
+				go func() { done <- c.runTarget(runCtx, client, conf, dir) }()
+			case errors.As(err, &buildErr) && ctx.Err() == nil:
+				fmt.Fprintf(c.stdLog().err, "%s\n", errorColor(err.Error()))
+				fmt.Fprintf(c.stdLog().out, "%s\n", infoColor("Waiting for a fix before retrying..."))
+				close(done)
+			default:
+				cancel()
+				return err
+			}
+
+			stopped := false
+			select {
+			case <-ctx.Done():
+			case <-changes:
+			case err, ok := <-done:
+				stopped = true
+				if ok && err != nil && ctx.Err() == nil {
+					fmt.Fprintf(c.stdLog().err, "%s\n", errorColor("the node stopped on the target: "+err.Error()))
+					fmt.Fprintf(c.stdLog().out, "%s\n", infoColor("Waiting for a fix before retrying..."))
+				}
+				select {
+				case <-ctx.Done():
+				case <-changes:
+				}
+			}
+
+			// the node is stopped before it's restarted with the new binary.
+			cancel()
+			if !stopped {
+				<-done
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+	})
+
+	return g.Wait()
+}
+
+// deployTarget builds the binary for the target and ships it, the home of the chain is initialized
+// and shipped as well when it's reset, not initialized on the target or when the config changed.
+func (c *Chain) deployTarget(ctx context.Context, client *xssh.Client, dir, goos, goarch string, reset bool) error {
+	var (
+		binDir  = path.Join(dir, "bin")
+		homeDir = path.Join(dir, "home")
+	)
+
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return err
+	}
+
+	initialize := reset
+	if !initialize {
+		_, err := client.Output(ctx, fmt.Sprintf("test -f %s", xssh.Quote(path.Join(homeDir, "config", "genesis.json"))))
+		initialize = err != nil
+	}
+	if !initialize && c.ConfigPath() != "" {
+		if initialize, err = dirchange.HasDirChecksumChanged(c.app.Path, []string{c.ConfigPath()}, saveDir, targetConfigChecksum); err != nil {
+			return err
+		}
+	}
+
+	if initialize {
+		// the binary of the local platform initializes the home.
+		if err := c.build(ctx, ""); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(c.stdLog().out, "💿 Initializing the app...")
+		if err := c.Init(ctx, true); err != nil {
+			return err
+		}
+
+		home, err := c.Home()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdLog().out, "📤 Shipping the home of the chain to the target...")
+		if _, err := client.Output(ctx, fmt.Sprintf("rm -rf %s", xssh.Quote(homeDir))); err != nil {
+			return err
+		}
+		if err := client.Upload(ctx, home, homeDir); err != nil {
+			return err
+		}
+		if c.ConfigPath() != "" {
+			if err := dirchange.SaveDirChecksum(c.app.Path, []string{c.ConfigPath()}, saveDir, targetConfigChecksum); err != nil {
+				return err
+			}
+		}
+	} else if err := c.generateAll(ctx); err != nil {
+		return err
+	}
+
+	out, err := os.MkdirTemp("", "")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(out)
+
+	fmt.Fprintf(c.stdLog().out, "🛠️  Building the binary for %s/%s...\n", goos, goarch)
+	if err := c.buildTarget(ctx, out, goos, goarch); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdLog().out, "📤 Shipping the binary to the target...")
+	return client.Upload(ctx, out, binDir)
+}
+
+// runTarget runs the node on the target until the ctx is canceled, the logs of the node are streamed
+// with the prefix of the daemon.
+func (c *Chain) runTarget(ctx context.Context, client *xssh.Client, conf chainconfig.Config, dir string) error {
+	binary, err := c.Binary()
+	if err != nil {
+		return err
+	}
+
+	command := fmt.Sprintf("%s start --home %s --pruning nothing --grpc.address %s",
+		xssh.Quote(path.Join(dir, "bin", binary)),
+		xssh.Quote(path.Join(dir, "home")),
+		xssh.Quote(conf.Host.GRPC),
+	)
+
+	prefix := c.genPrefix(logAppd)
+	logs := lineprefixer.NewWriter(os.Stdout, func() string { return prefix })
+
+	fmt.Fprintf(c.stdLog().out, "🌍 Serving the chain on %s\n", client.Target())
+	err = client.Run(ctx, command, xssh.RunTerminal(), xssh.RunStdout(logs), xssh.RunStderr(logs))
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// parseUname returns the GOOS and the GOARCH of the platform described by the output of uname -sm.
+func parseUname(s string) (goos, goarch string, err error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("cannot detect the platform of the target from %q", strings.TrimSpace(s))
+	}
+
+	goos = strings.ToLower(fields[0])
+	switch fields[1] {
+	case "x86_64", "amd64":
+		goarch = "amd64"
+	case "aarch64", "arm64":
+		goarch = "arm64"
+	case "i386", "i686":
+		goarch = "386"
+	default:
+		if strings.HasPrefix(fields[1], "armv") {
+			goarch = "arm"
+		}
+	}
+
+	switch goos {
+	case "linux", "darwin", "freebsd":
+	default:
+		goarch = ""
+	}
+	if goarch == "" {
+		return "", "", fmt.Errorf("the platform %s of the target is not supported", strings.TrimSpace(s))
+	}
+	return goos, goarch, nil
+}
@@ -0,0 +1,64 @@
+package chain
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUname(t *testing.T) {
+	for _, tt := range []struct {
+		uname, goos, goarch string
+	}{
+		{"Linux x86_64\n", "linux", "amd64"},
+		{"Linux aarch64", "linux", "arm64"},
+		{"Linux armv7l", "linux", "arm"},
+		{"Linux i686", "linux", "386"},
+		{"Darwin arm64", "darwin", "arm64"},
+		{"FreeBSD amd64", "freebsd", "amd64"},
+	} {
+		goos, goarch, err := parseUname(tt.uname)
+		require.NoError(t, err, tt.uname)
+		require.Equal(t, tt.goos, goos, tt.uname)
+		require.Equal(t, tt.goarch, goarch, tt.uname)
+	}
+
+	for _, uname := range []string{"", "Linux", "Linux riscv64", "MINGW64_NT-10.0 x86_64"} {
+		_, _, err := parseUname(uname)
+		require.Error(t, err, uname)
+	}
+}
+
+func TestTargetAddress(t *testing.T) {
+	require.Equal(t, "127.0.0.1:26657", targetAddress("0.0.0.0:26657"))
+	require.Equal(t, "127.0.0.1:1317", targetAddress(":1317"))
+}
+
+func TestSourceMatch(t *testing.T) {
+	require.True(t, sourceMatch("app/app.go", false))
+	require.True(t, sourceMatch("x/blog/release", true))
+	require.False(t, sourceMatch(".git", true))
+	require.False(t, sourceMatch("vue/node_modules", true))
+	require.False(t, sourceMatch("release", true))
+}
+
+func TestTargetServeArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	c := &Chain{app: App{Path: dir}}
+	c.options.ConfigFile = filepath.Join(dir, "configs", "dev.yml")
+	c.options.configProfile = "dev"
+	c.logLevel = LogVerbose
+
+	args, err := c.targetServeArgs(serveOptions{resetOnce: true, graphQL: true, indexURL: "postgres://localhost/mars"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-p", ".", "-c", "'configs/dev.yml'", "--profile", "'dev'", "-v", "--reset-once", "--graphql",
+		"--index", "'postgres://localhost/mars'",
+	}, args)
+
+	c.options.ConfigFile = filepath.Join(filepath.Dir(dir), "config.yml")
+	_, err = c.targetServeArgs(serveOptions{})
+	require.Error(t, err)
+}
@@ -0,0 +1,164 @@
+package chain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+)
+
+// TestAccounts are the test accounts of the chain exported to the path of the test accounts of the
+// config, the frontends of the chain sign their txs with the keys of the accounts.
+type TestAccounts struct {
+	ChainID  string        `json:"chain_id"`
+	Mnemonic string        `json:"mnemonic"`
+	Accounts []TestAccount `json:"accounts"`
+}
+
+// TestAccount is an account derived from the mnemonic of the test accounts.
+type TestAccount struct {
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	HDPath  string   `json:"hd_path"`
+	Coins   []string `json:"coins"`
+
+	// PublicKey is the compressed secp256k1 public key in base64.
+	PublicKey string `json:"public_key"`
+
+	// PrivateKey is the secp256k1 private key in hex.
+	PrivateKey string `json:"private_key"`
+}
+
+// initTestAccounts derives the test accounts of the config into the keyring of the chain, adds them
+// to the genesis with their coins and exports them.
+func (c *Chain) initTestAccounts(ctx context.Context, conf chainconfig.Config, commands chaincmdrunner.Runner) error {
+	t := conf.TestAccounts
+	if t.Count == 0 {
+		return nil
+	}
+
+	chainID, err := c.ID()
+	if err != nil {
+		return err
+	}
+	home, err := c.Home()
+	if err != nil {
+		return err
+	}
+	backend, err := c.KeyringBackend()
+	if err != nil {
+		return err
+	}
+	registry, err := cosmosaccount.New(
+		cosmosaccount.WithHome(home),
+		cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringBackend(backend)),
+	)
+	if err != nil {
+		return err
+	}
+
+	var (
+		prefix   string
+		exported = TestAccounts{
+			ChainID:  chainID,
+			Mnemonic: t.MnemonicOrDefault(),
+		}
+	)
+	for i := 0; i < t.Count; i++ {
+		var (
+			name   = t.Name(i)
+			hdPath = hd.CreateHDPath(t.CoinTypeOrDefault(), 0, uint32(i)).String()
+		)
+		account, err := registry.Import(name, exported.Mnemonic, "", cosmosaccount.WithHDPath(hdPath))
+		if err != nil {
+			return errors.Wrapf(err, "cannot derive the test account %s", name)
+		}
+
+		// the prefix of the addresses is the prefix of the chain's binary.
+		if prefix == "" {
+			shown, err := commands.ShowAccount(ctx, name)
+			if err != nil {
+				return err
+			}
+			if prefix, _, err = bech32.DecodeAndConvert(shown.Address); err != nil {
+				return err
+			}
+		}
+
+		privateKey, err := registry.ExportHex(name, "")
+		if err != nil {
+			return err
+		}
+		testAccount := TestAccount{
+			Name:       name,
+			Address:    account.Address(prefix),
+			HDPath:     hdPath,
+			Coins:      t.Coins,
+			PublicKey:  base64.StdEncoding.EncodeToString(account.Info.GetPubKey().Bytes()),
+			PrivateKey: privateKey,
+		}
+		if err := commands.AddGenesisAccount(ctx, testAccount.Address, strings.Join(t.Coins, ",")); err != nil {
+			return err
+		}
+		exported.Accounts = append(exported.Accounts, testAccount)
+	}
+
+	content, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(c.app.Path, t.PathOrDefault())
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// the export holds the private keys, only the user can read it. an existing export keeps its
+	// mode when it's written, so it's set again.
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🧪 Created %d test accounts, exported to: %s\n", t.Count, t.PathOrDefault())
+	if exported.Mnemonic != chainconfig.DefaultTestAccountsMnemonic {
+		fmt.Fprintf(c.stdLog().out, "⚠️  The custom mnemonic of the test accounts is exported with their private keys to %s, keep it out of source control\n", t.PathOrDefault())
+	}
+	return nil
+}
+
+// TestAccounts returns the test accounts exported by the initialization of the chain.
+func (c *Chain) TestAccounts() (TestAccounts, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return TestAccounts{}, err
+	}
+	if conf.TestAccounts.Count == 0 {
+		return TestAccounts{}, errors.New("the chain has no test accounts, set them with test_accounts in the config")
+	}
+
+	content, err := os.ReadFile(filepath.Join(c.app.Path, conf.TestAccounts.PathOrDefault()))
+	if os.IsNotExist(err) {
+		return TestAccounts{}, errors.New("the test accounts are not exported yet, initialize the chain first")
+	}
+	if err != nil {
+		return TestAccounts{}, err
+	}
+
+	var accounts TestAccounts
+	if err := json.Unmarshal(content, &accounts); err != nil {
+		return TestAccounts{}, errors.Wrap(err, "invalid test accounts")
+	}
+	return accounts, nil
+}
@@ -0,0 +1,54 @@
+package gentxcollector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// HTTPClient is a client of the collector served over HTTP.
+type HTTPClient struct {
+	addr string
+}
+
+// NewClient returns a new client of the collector at addr.
+func NewClient(addr string) HTTPClient {
+	return HTTPClient{strings.TrimSuffix(addr, "/")}
+}
+
+// Submit submits the gentx of a validator to the collector.
+func (c HTTPClient) Submit(ctx context.Context, s Submission) (Validator, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return Validator{}, err
+	}
+
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.addr+"/gentxs", bytes.NewReader(data))
+	if err != nil {
+		return Validator{}, err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+
+	hres, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return Validator{}, err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		var res xhttp.ErrorResponseBody
+		if err := json.NewDecoder(hres.Body).Decode(&res); err != nil || res.Error.Message == "" {
+			return Validator{}, errors.New(http.StatusText(hres.StatusCode))
+		}
+		return Validator{}, errors.New(res.Error.Message)
+	}
+
+	var v Validator
+	err = json.NewDecoder(hres.Body).Decode(&v)
+	return v, err
+}
@@ -0,0 +1,357 @@
+// Package gentxcollector coordinates the launch of chains without SPN. the coordinator collects the
+// gentxs of the validators, validates them with the verifications of the network service and
+// assembles the final genesis from them.
+package gentxcollector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+	"github.com/tendermint/starport/starport/services/network/networkchain"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+const (
+	// BaseGenesisFile is the file in the dir of the collector that the genesis that the validators
+	// generate their gentxs for is saved to.
+	BaseGenesisFile = "genesis.json"
+
+	// FinalGenesisFile is the file in the dir of the collector that the final genesis is saved to.
+	FinalGenesisFile = "genesis.final.json"
+
+	// launchID is the launch ID of the requests built from the submissions, the chains launched
+	// without SPN have no launch ID.
+	launchID = 0
+
+	submissionsDir = "gentxs"
+	homeDir        = "home"
+)
+
+// ErrNotFinalized is returned when the final genesis is not assembled yet.
+var ErrNotFinalized = errors.New("the genesis is not finalized yet")
+
+// Submission is the gentx of a validator submitted to the coordinator.
+type Submission struct {
+	Gentx json.RawMessage `json:"gentx"`
+
+	// Peer is the address of the validator's node in the id@host:port format.
+	Peer string `json:"peer"`
+}
+
+// Validator is a validator that has submitted its gentx.
+type Validator struct {
+	// Address is the address of the validator in the gentx.
+	Address        string   `json:"address"`
+	SelfDelegation sdk.Coin `json:"self_delegation"`
+	Peer           string   `json:"peer"`
+}
+
+// Collector collects the gentxs submitted by the validators in a dir.
+type Collector struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// New creates a collector that keeps the submissions and the genesis files in dir.
+func New(dir string) (*Collector, error) {
+	if err := os.MkdirAll(filepath.Join(dir, submissionsDir), 0755); err != nil {
+		return nil, err
+	}
+	return &Collector{dir: dir}, nil
+}
+
+// Home returns the home of the chain used by the coordinator to assemble the genesis.
+func (c *Collector) Home() string {
+	return filepath.Join(c.dir, homeDir)
+}
+
+// BaseGenesisPath returns the path of the genesis that the validators generate their gentxs for.
+func (c *Collector) BaseGenesisPath() string {
+	return filepath.Join(c.dir, BaseGenesisFile)
+}
+
+// FinalGenesisPath returns the path of the final genesis.
+func (c *Collector) FinalGenesisPath() string {
+	return filepath.Join(c.dir, FinalGenesisFile)
+}
+
+// Init initializes the chain in the home of the collector and saves its genesis as the base
+// genesis. the previous submissions are kept.
+func (c *Collector) Init(ctx context.Context, chain *networkchain.Chain) error {
+	chain.SetHome(c.Home())
+	if err := chain.Init(ctx); err != nil {
+		return err
+	}
+
+	genesisPath, err := chain.GenesisPath()
+	if err != nil {
+		return err
+	}
+	return copyFile(genesisPath, c.BaseGenesisPath())
+}
+
+// Submit validates the submission and saves it, the previous submission of the validator is
+// replaced. the gentx and the peer are validated like the requests of the validators on SPN and
+// the gentx must be signed by its delegator for the chain of the base genesis.
+func (c *Collector) Submit(s Submission) (Validator, error) {
+	req, err := request(s)
+	if err != nil {
+		return Validator{}, err
+	}
+	if err := networktypes.VerifyRequest(req); err != nil {
+		return Validator{}, err
+	}
+
+	chainID, err := c.chainID()
+	if err != nil {
+		return Validator{}, err
+	}
+	if err := cosmosutil.VerifyGentxSignature(s.Gentx, chainID); err != nil {
+		return Validator{}, errors.Wrap(err, "invalid gentx")
+	}
+
+	info, _, err := cosmosutil.ParseGentx(s.Gentx)
+	if err != nil {
+		return Validator{}, err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return Validator{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.WriteFile(c.submissionPath(info.DelegatorAddress), data, 0644); err != nil {
+		return Validator{}, err
+	}
+
+	return Validator{
+		Address:        info.DelegatorAddress,
+		SelfDelegation: info.SelfDelegation,
+		Peer:           s.Peer,
+	}, nil
+}
+
+// Validators returns the validators that have submitted their gentxs, sorted by address.
+func (c *Collector) Validators() ([]Validator, error) {
+	submissions, err := c.submissions()
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]Validator, 0, len(submissions))
+	for _, s := range submissions {
+		info, _, err := cosmosutil.ParseGentx(s.Gentx)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, Validator{
+			Address:        info.DelegatorAddress,
+			SelfDelegation: info.SelfDelegation,
+			Peer:           s.Peer,
+		})
+	}
+	return validators, nil
+}
+
+// GenesisInformation returns the genesis information of the submissions. an account is added to
+// the genesis for each validator with the validator coins or with its self-delegation when the
+// validator coins are empty.
+func (c *Collector) GenesisInformation(validatorCoins sdk.Coins) (networktypes.GenesisInformation, error) {
+	submissions, err := c.submissions()
+	if err != nil {
+		return networktypes.GenesisInformation{}, err
+	}
+
+	var gi networktypes.GenesisInformation
+	for i, s := range submissions {
+		validatorReq, err := request(s)
+		if err != nil {
+			return gi, err
+		}
+		validatorReq.RequestID = uint64(i)
+
+		validator := validatorReq.Content.GetGenesisValidator()
+		coins := validatorCoins
+		if coins.Empty() {
+			coins = sdk.NewCoins(validator.SelfDelegation)
+		}
+		if !coins.IsAllGTE(sdk.NewCoins(validator.SelfDelegation)) {
+			return gi, fmt.Errorf(
+				"the validator coins %s don't cover the self-delegation %s of %s",
+				coins,
+				validator.SelfDelegation,
+				validator.Address,
+			)
+		}
+
+		accountReq := launchtypes.Request{
+			RequestID: uint64(i),
+			Content:   launchtypes.NewGenesisAccount(launchID, validator.Address, coins),
+		}
+
+		for _, req := range []launchtypes.Request{accountReq, validatorReq} {
+			if err := networktypes.VerifyRequest(req); err != nil {
+				return gi, err
+			}
+			if gi, err = gi.ApplyRequest(req); err != nil {
+				return gi, err
+			}
+		}
+	}
+	return gi, nil
+}
+
+// Finalize assembles the final genesis from the base genesis and the submissions in the home of the
+// collector and saves it, the chain must be initialized by Init.
+func (c *Collector) Finalize(
+	ctx context.Context,
+	chain *networkchain.Chain,
+	validatorCoins sdk.Coins,
+	options ...networkchain.PrepareOption,
+) error {
+	gi, err := c.GenesisInformation(validatorCoins)
+	if err != nil {
+		return err
+	}
+	if len(gi.GenesisValidators) == 0 {
+		return errors.New("no gentx is submitted")
+	}
+
+	chain.SetHome(c.Home())
+	if err := chain.Prepare(ctx, gi, options...); err != nil {
+		return err
+	}
+
+	genesisPath, err := chain.GenesisPath()
+	if err != nil {
+		return err
+	}
+	return copyFile(genesisPath, c.FinalGenesisPath())
+}
+
+// Peers returns the addresses of the nodes of the validators in the id@host:port format.
+func (c *Collector) Peers() ([]string, error) {
+	validators, err := c.Validators()
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]string, len(validators))
+	for i, v := range validators {
+		peers[i] = v.Peer
+	}
+	return peers, nil
+}
+
+// submissions returns the saved submissions sorted by the addresses of their validators.
+func (c *Collector) submissions() ([]Submission, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(c.dir, submissionsDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	submissions := make([]Submission, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var s Submission
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, errors.Wrapf(err, "invalid submission %s", path)
+		}
+		submissions = append(submissions, s)
+	}
+	return submissions, nil
+}
+
+// chainID returns the chain ID of the base genesis.
+func (c *Collector) chainID() (string, error) {
+	data, err := os.ReadFile(c.BaseGenesisPath())
+	if os.IsNotExist(err) {
+		return "", errors.New("the collector is not initialized, the base genesis is missing")
+	}
+	if err != nil {
+		return "", err
+	}
+	var genesis struct {
+		ChainID string `json:"chain_id"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return "", errors.Wrap(err, "invalid base genesis")
+	}
+	if genesis.ChainID == "" {
+		return "", errors.New("the base genesis has no chain ID")
+	}
+	return genesis.ChainID, nil
+}
+
+func (c *Collector) submissionPath(address string) string {
+	return filepath.Join(c.dir, submissionsDir, address+".json")
+}
+
+// request returns the request of SPN that adds the validator of the submission.
+func request(s Submission) (launchtypes.Request, error) {
+	info, _, err := cosmosutil.ParseGentx(s.Gentx)
+	if err != nil {
+		return launchtypes.Request{}, errors.Wrap(err, "invalid gentx")
+	}
+
+	// the addresses are verified with the SPN prefix like the requests on SPN.
+	address, err := cosmosutil.ChangeAddressPrefix(info.DelegatorAddress, networktypes.SPN)
+	if err != nil {
+		return launchtypes.Request{}, err
+	}
+
+	parts := strings.Split(s.Peer, "@")
+	if len(parts) != 2 || parts[0] == "" {
+		return launchtypes.Request{}, fmt.Errorf("invalid peer %q, expected id@host:port", s.Peer)
+	}
+
+	return launchtypes.Request{
+		Content: launchtypes.NewGenesisValidator(
+			launchID,
+			address,
+			s.Gentx,
+			info.PubKey,
+			info.SelfDelegation,
+			launchtypes.NewPeerConn(parts[0], parts[1]),
+		),
+	}, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,149 @@
+package gentxcollector_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/services/gentxcollector"
+)
+
+const peer = "8c2e28a1ba1a7d33d6bb4d3a95dfd67b2b7a6e51@192.168.0.1:26656"
+
+var gentx = json.RawMessage(`{"body":{"messages":[{"@type":"/cosmos.staking.v1beta1.MsgCreateValidator","description":{"moniker":"x","identity":"","website":"","security_contact":"","details":""},"commission":{"rate":"0.100000000000000000","max_rate":"0.200000000000000000","max_change_rate":"0.010000000000000000"},"min_self_delegation":"1","delegator_address":"cosmos12xeajwf397d05zwpyspp7hzkexy25tnd85m0hj","validator_address":"cosmosvaloper12xeajwf397d05zwpyspp7hzkexy25tndzq06mp","pubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"bZskSTVbeZax2s+6Tkf5x6DaQzksE798EqttFKuho9Y="},"value":{"denom":"stake","amount":"50000000"}}],"memo":"148aeab74643ab3e38d1eade2c81437e94f579bc@192.0.2.2:26656","timeout_height":"0","extension_options":[],"non_critical_extension_options":[]},"auth_info":{"signer_infos":[{"public_key":{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"AymqQlHwd3r8pabiKvGfYwtXKnqTSrzkuSzxSK3EISjJ"},"mode_info":{"single":{"mode":"SIGN_MODE_DIRECT"}},"sequence":"0"}],"fee":{"amount":[],"gas_limit":"200000","payer":"","granter":""}},"signatures":["rDogJ/23jRS8Uqyr0iZzXyCLo5lU8X8zhY/GUAIyPwRHVIZhDreg5OgNIzQDhEUMms2a4+pJGQ6ES49oy0pIVQ=="]}`)
+
+// newCollector returns a collector with the base genesis of the chain the gentx is signed for.
+func newCollector(t *testing.T) *gentxcollector.Collector {
+	c, err := gentxcollector.New(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(c.BaseGenesisPath(), []byte(`{"chain_id":"gxchain"}`), 0644))
+	return c
+}
+
+func TestSubmit(t *testing.T) {
+	c := newCollector(t)
+
+	v, err := c.Submit(gentxcollector.Submission{Gentx: gentx, Peer: peer})
+	require.NoError(t, err)
+	require.Equal(t, "cosmos12xeajwf397d05zwpyspp7hzkexy25tnd85m0hj", v.Address)
+	require.Equal(t, sdk.NewCoin("stake", sdk.NewInt(50000000)), v.SelfDelegation)
+
+	// the resubmission of the validator replaces its previous submission.
+	resubmitted := "8c2e28a1ba1a7d33d6bb4d3a95dfd67b2b7a6e51@192.168.0.2:26656"
+	_, err = c.Submit(gentxcollector.Submission{Gentx: gentx, Peer: resubmitted})
+	require.NoError(t, err)
+
+	validators, err := c.Validators()
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+
+	peers, err := c.Peers()
+	require.NoError(t, err)
+	require.Equal(t, []string{resubmitted}, peers)
+}
+
+func TestSubmitInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    gentxcollector.Submission
+	}{
+		{
+			name: "invalid gentx",
+			s:    gentxcollector.Submission{Gentx: json.RawMessage(`{}`), Peer: peer},
+		},
+		{
+			name: "tampered gentx",
+			s: gentxcollector.Submission{
+				Gentx: bytes.Replace(gentx, []byte(`"amount":"50000000"`), []byte(`"amount":"60000000"`), 1),
+				Peer:  peer,
+			},
+		},
+		{
+			name: "peer without node ID",
+			s:    gentxcollector.Submission{Gentx: gentx, Peer: "192.168.0.1:26656"},
+		},
+		{
+			name: "peer without port",
+			s:    gentxcollector.Submission{Gentx: gentx, Peer: "8c2e28a1ba1a7d33d6bb4d3a95dfd67b2b7a6e51@192.168.0.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCollector(t)
+
+			_, err := c.Submit(tt.s)
+			require.Error(t, err)
+
+			validators, err := c.Validators()
+			require.NoError(t, err)
+			require.Empty(t, validators)
+		})
+	}
+}
+
+func TestGenesisInformation(t *testing.T) {
+	c := newCollector(t)
+
+	_, err := c.Submit(gentxcollector.Submission{Gentx: gentx, Peer: peer})
+	require.NoError(t, err)
+
+	gi, err := c.GenesisInformation(nil)
+	require.NoError(t, err)
+	require.Len(t, gi.GenesisAccounts, 1)
+	require.Equal(t, "50000000stake", gi.GenesisAccounts[0].Coins)
+	require.Len(t, gi.GenesisValidators, 1)
+	require.Equal(t, "192.168.0.1:26656", gi.GenesisValidators[0].Peer.GetTcpAddress())
+
+	gi, err = c.GenesisInformation(sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000000)), sdk.NewCoin("token", sdk.NewInt(10))))
+	require.NoError(t, err)
+	require.Equal(t, "100000000stake,10token", gi.GenesisAccounts[0].Coins)
+
+	_, err = c.GenesisInformation(sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(1))))
+	require.Error(t, err)
+}
+
+func TestHTTP(t *testing.T) {
+	c := newCollector(t)
+
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	client := gentxcollector.NewClient(server.URL)
+
+	_, err := client.Submit(context.Background(), gentxcollector.Submission{Gentx: gentx, Peer: "invalid"})
+	require.EqualError(t, err, `invalid peer "invalid", expected id@host:port`)
+
+	v, err := client.Submit(context.Background(), gentxcollector.Submission{Gentx: gentx, Peer: peer})
+	require.NoError(t, err)
+	require.Equal(t, peer, v.Peer)
+
+	res, err := http.Post(server.URL+"/gentxs", "application/json", bytes.NewReader(make([]byte, 1<<20)))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Get(server.URL + "/genesis")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, err = http.Get(server.URL + "/genesis/final")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res, err = http.Get(server.URL + "/peers")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var peers []string
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&peers))
+	require.Equal(t, []string{peer}, peers)
+}
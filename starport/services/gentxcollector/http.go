@@ -0,0 +1,90 @@
+package gentxcollector
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/cors"
+
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+// maxSubmissionSize is the max. size of the body of a submission, a gentx is a few KB.
+const maxSubmissionSize = 64 << 10
+
+// ServeHTTP implements http.Handler to collect the gentxs of the validators and to share the
+// genesis files and the peers of the launch with them.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router := mux.NewRouter()
+
+	router.Handle("/gentxs", cors.Default().Handler(http.HandlerFunc(c.submitHandler))).
+		Methods(http.MethodPost)
+
+	router.HandleFunc("/gentxs", c.validatorsHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/genesis", c.genesisHandler(c.BaseGenesisPath())).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/genesis/final", c.genesisHandler(c.FinalGenesisPath())).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/peers", c.peersHandler).
+		Methods(http.MethodGet)
+
+	router.ServeHTTP(w, r)
+}
+
+func (c *Collector) submitHandler(w http.ResponseWriter, r *http.Request) {
+	var s Submission
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubmissionSize)
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(err))
+		return
+	}
+
+	validator, err := c.Submit(s)
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(err))
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, validator)
+}
+
+func (c *Collector) validatorsHandler(w http.ResponseWriter, r *http.Request) {
+	validators, err := c.Validators()
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, validators)
+}
+
+func (c *Collector) peersHandler(w http.ResponseWriter, r *http.Request) {
+	peers, err := c.Peers()
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, peers)
+}
+
+// genesisHandler serves the genesis file at path.
+func (c *Collector) genesisHandler(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		genesis, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err) && path == c.FinalGenesisPath():
+			xhttp.ResponseJSON(w, http.StatusNotFound, xhttp.NewErrorResponse(ErrNotFinalized))
+			return
+		case err != nil:
+			xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(genesis)
+	}
+}
@@ -0,0 +1,68 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// AccountAddress returns the SPN address of the account.
+func (n Network) AccountAddress() string {
+	return n.account.Address(networktypes.SPN)
+}
+
+// Balance returns the balances of the address on SPN, the balances of the account are returned
+// when the address is empty.
+func (n Network) Balance(ctx context.Context, address string) (sdk.Coins, error) {
+	if address == "" {
+		address = n.AccountAddress()
+	}
+	if _, err := sdk.GetFromBech32(address, networktypes.SPN); err != nil {
+		return nil, errors.Wrapf(err, "invalid SPN address %q", address)
+	}
+
+	n.ev.Send(events.New(events.StatusOngoing, "Fetching the balances"))
+
+	return n.cosmos.Balances(ctx, address)
+}
+
+// Fund requests coins from the SPN faucet to the account, the faucet sends its default coins
+// when coins is empty.
+func (n Network) Fund(ctx context.Context, coins sdk.Coins) error {
+	n.ev.Send(events.New(events.StatusOngoing, "Requesting coins from the faucet"))
+
+	if err := n.cosmos.FundFromFaucet(ctx, n.account.Name, coins); err != nil {
+		return err
+	}
+
+	n.ev.Send(events.New(events.StatusDone, "Coins received from the faucet"))
+	return nil
+}
+
+// Transfer sends the amount from the account to the address on SPN.
+func (n Network) Transfer(ctx context.Context, toAddress string, amount sdk.Coins) error {
+	from, err := sdk.GetFromBech32(n.AccountAddress(), networktypes.SPN)
+	if err != nil {
+		return err
+	}
+	to, err := sdk.GetFromBech32(toAddress, networktypes.SPN)
+	if err != nil {
+		return errors.Wrapf(err, "invalid SPN address %q", toAddress)
+	}
+
+	n.ev.Send(events.New(events.StatusOngoing, fmt.Sprintf("Sending %s to %s", amount, toAddress)))
+
+	msgSend := banktypes.NewMsgSend(from, to, amount)
+	if _, err := n.cosmos.BroadcastTxAndWait(ctx, n.account.Name, msgSend); err != nil {
+		return err
+	}
+
+	n.ev.Send(events.New(events.StatusDone, fmt.Sprintf("%s sent to %s", amount, toAddress)))
+	return nil
+}
@@ -0,0 +1,21 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceInvalidAddress(t *testing.T) {
+	n := Network{}
+
+	for _, address := range []string{
+		"spn1abc",
+		"cosmos1x6w8nh6e7luqgjvkh6qygzqkpz3c6wdrzhn57s",
+	} {
+		_, err := n.Balance(context.Background(), address)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid SPN address")
+	}
+}
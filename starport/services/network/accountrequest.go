@@ -0,0 +1,166 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// VestingSchedule is the schedule of a delayed vesting account, the vesting coins are locked until
+// the end time and unlocked all at once.
+type VestingSchedule struct {
+	// Vesting are the coins of the total balance that are locked until the end time.
+	Vesting sdk.Coins
+
+	// EndTime is when the vesting coins are unlocked.
+	EndTime time.Time
+}
+
+// Validate checks that the vesting coins are part of the total balance and that they are unlocked
+// after the launch of the chain. the launch time is zero when the launch is not triggered yet, the
+// end time must be in the future then.
+func (s VestingSchedule) Validate(totalBalance sdk.Coins, launchTime time.Time) error {
+	if s.Vesting.Empty() || !s.Vesting.IsValid() {
+		return fmt.Errorf("invalid vesting coins %q", s.Vesting)
+	}
+	if !s.Vesting.IsAllLTE(totalBalance) {
+		return fmt.Errorf("the vesting coins %s are not a subset of the total balance %s", s.Vesting, totalBalance)
+	}
+	if s.EndTime.IsZero() {
+		return errors.New("the end time of the vesting is required")
+	}
+
+	if launchTime.IsZero() {
+		if !s.EndTime.After(time.Now()) {
+			return fmt.Errorf("the end time of the vesting %s is in the past", s.EndTime.Format(time.RFC3339))
+		}
+		return nil
+	}
+	if !s.EndTime.After(launchTime) {
+		return fmt.Errorf(
+			"the end time of the vesting %s must be after the launch time %s",
+			s.EndTime.Format(time.RFC3339),
+			launchTime.Format(time.RFC3339),
+		)
+	}
+	return nil
+}
+
+// accountRequestOptions holds info about how to request a genesis account.
+type accountRequestOptions struct {
+	vesting *VestingSchedule
+}
+
+// AccountRequestOption configures the request of a genesis account.
+type AccountRequestOption func(*accountRequestOptions)
+
+// WithVesting requests a delayed vesting account with the schedule instead of a genesis account.
+func WithVesting(schedule VestingSchedule) AccountRequestOption {
+	return func(o *accountRequestOptions) {
+		o.vesting = &schedule
+	}
+}
+
+// RequestAccount sends a request to add a genesis account with the coins to a launch, the request is
+// a vesting account request when the account has a vesting schedule. the vesting schedule is
+// validated against the launch time before the request is sent.
+func (n Network) RequestAccount(
+	ctx context.Context,
+	launchID uint64,
+	address string,
+	coins sdk.Coins,
+	options ...AccountRequestOption,
+) (requestID uint64, err error) {
+	o := accountRequestOptions{}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	if coins.Empty() || !coins.IsValid() {
+		return 0, fmt.Errorf("invalid coins %q", coins)
+	}
+
+	hasAccount, err := n.hasAccount(ctx, launchID, address)
+	if err != nil {
+		return 0, err
+	}
+	if hasAccount {
+		return 0, fmt.Errorf("account %s already exist", address)
+	}
+
+	var (
+		msg         sdk.Msg
+		requestType = networktypes.RequestGenesisAccount
+	)
+	if o.vesting != nil {
+		chainLaunch, err := n.ChainLaunch(ctx, launchID)
+		if err != nil {
+			return 0, err
+		}
+		var launchTime time.Time
+		if chainLaunch.LaunchTime != 0 {
+			launchTime = time.Unix(chainLaunch.LaunchTime, 0)
+		}
+		if err := o.vesting.Validate(coins, launchTime); err != nil {
+			return 0, err
+		}
+
+		msg = launchtypes.NewMsgRequestAddVestingAccount(
+			n.account.Address(networktypes.SPN),
+			launchID,
+			address,
+			*launchtypes.NewDelayedVesting(coins, o.vesting.Vesting, o.vesting.EndTime.Unix()),
+		)
+		requestType = networktypes.RequestVestingAccount
+	} else {
+		msg = launchtypes.NewMsgRequestAddAccount(
+			n.account.Address(networktypes.SPN),
+			launchID,
+			address,
+			coins,
+		)
+	}
+
+	n.ev.Send(events.New(events.StatusOngoing, "Broadcasting account transactions"))
+	res, err := n.cosmos.
+		WithBroadcastOptions(cosmosclient.WithMemo(fmt.Sprintf("starport request account %d", launchID))).
+		BroadcastTxAndWait(ctx, n.account.Name, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	var autoApproved bool
+	if o.vesting != nil {
+		var requestRes launchtypes.MsgRequestAddVestingAccountResponse
+		if err := res.Decode(&requestRes); err != nil {
+			return 0, err
+		}
+		requestID, autoApproved = requestRes.RequestID, requestRes.AutoApproved
+	} else {
+		var requestRes launchtypes.MsgRequestAddAccountResponse
+		if err := res.Decode(&requestRes); err != nil {
+			return 0, err
+		}
+		requestID, autoApproved = requestRes.RequestID, requestRes.AutoApproved
+	}
+
+	if autoApproved {
+		n.ev.Send(events.New(events.StatusDone, "Account added to the network by the coordinator!"))
+		return requestID, nil
+	}
+	if err := n.recordRequest(launchID, requestID, requestType, address); err != nil {
+		return requestID, err
+	}
+	n.ev.Send(events.New(events.StatusDone,
+		fmt.Sprintf("Request %d to add account to the network has been submitted!", requestID),
+	))
+	return requestID, nil
+}
@@ -0,0 +1,82 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingScheduleValidate(t *testing.T) {
+	var (
+		totalBalance = sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))
+		launchTime   = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name       string
+		schedule   VestingSchedule
+		launchTime time.Time
+		err        string
+	}{
+		{
+			name: "after the launch time",
+			schedule: VestingSchedule{
+				Vesting: sdk.NewCoins(sdk.NewInt64Coin("stake", 500)),
+				EndTime: launchTime.Add(time.Hour),
+			},
+			launchTime: launchTime,
+		},
+		{
+			name: "in the future without launch time",
+			schedule: VestingSchedule{
+				Vesting: sdk.NewCoins(sdk.NewInt64Coin("stake", 500)),
+				EndTime: time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "before the launch time",
+			schedule: VestingSchedule{
+				Vesting: sdk.NewCoins(sdk.NewInt64Coin("stake", 500)),
+				EndTime: launchTime.Add(-time.Hour),
+			},
+			launchTime: launchTime,
+			err:        "the end time of the vesting 2029-12-31T23:00:00Z must be after the launch time 2030-01-01T00:00:00Z",
+		},
+		{
+			name: "in the past without launch time",
+			schedule: VestingSchedule{
+				Vesting: sdk.NewCoins(sdk.NewInt64Coin("stake", 500)),
+				EndTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			err: "the end time of the vesting 2020-01-01T00:00:00Z is in the past",
+		},
+		{
+			name: "more than the total balance",
+			schedule: VestingSchedule{
+				Vesting: sdk.NewCoins(sdk.NewInt64Coin("stake", 1500)),
+				EndTime: launchTime.Add(time.Hour),
+			},
+			launchTime: launchTime,
+			err:        "the vesting coins 1500stake are not a subset of the total balance 1000stake",
+		},
+		{
+			name: "no end time",
+			schedule: VestingSchedule{
+				Vesting: sdk.NewCoins(sdk.NewInt64Coin("stake", 500)),
+			},
+			err: "the end time of the vesting is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate(totalBalance, tt.launchTime)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
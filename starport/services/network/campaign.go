@@ -0,0 +1,222 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	campaigntypes "github.com/tendermint/spn/x/campaign/types"
+	profiletypes "github.com/tendermint/spn/x/profile/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	"github.com/tendermint/starport/starport/pkg/cosmoserror"
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// Allocation is a special allocation of the shares of a campaign to an address.
+type Allocation struct {
+	Address string
+	Shares  campaigntypes.Shares
+}
+
+// ParseAllocation parses an allocation in the address=shares format, e.g. spn1...=1000foo,500bar.
+// the shares can be given in the units of the denoms, e.g. spn1...=12.5foo for 12500000ufoo.
+func ParseAllocation(allocation string, denoms cosmosutil.Denoms) (Allocation, error) {
+	parts := strings.SplitN(allocation, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Allocation{}, fmt.Errorf("invalid allocation %q, expected address=shares", allocation)
+	}
+
+	coins, err := denoms.ParseCoins(parts[1])
+	if err != nil {
+		return Allocation{}, errors.Wrapf(err, "error parsing the shares of allocation %q", allocation)
+	}
+
+	return Allocation{
+		Address: parts[0],
+		Shares:  campaigntypes.NewSharesFromCoins(coins),
+	}, nil
+}
+
+// ParseCampaignID parses the id of a campaign.
+func ParseCampaignID(id string) (uint64, error) {
+	campaignID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing campaignID")
+	}
+	return campaignID, nil
+}
+
+// ValidateAllocations checks that the allocations can be added to the shares already allocated
+// without exceeding the total shares of the campaign, the denoms that are not in the total shares
+// are limited to the default total share number of SPN.
+func ValidateAllocations(allocatedShares, totalShares campaigntypes.Shares, allocations ...Allocation) error {
+	shares := allocatedShares
+	for _, allocation := range allocations {
+		if err := campaigntypes.CheckShares(allocation.Shares); err != nil {
+			return errors.Wrapf(err, "invalid shares for %s", allocation.Address)
+		}
+		shares = campaigntypes.IncreaseShares(shares, allocation.Shares)
+	}
+
+	if campaigntypes.IsTotalSharesReached(shares, totalShares) {
+		return fmt.Errorf(
+			"the allocations exceed the total shares of the campaign: allocated %s with the allocations, total %s",
+			sdk.Coins(shares),
+			sdk.Coins(totalShares),
+		)
+	}
+	return nil
+}
+
+// campaignOptions holds info about how to create a campaign.
+type campaignOptions struct {
+	totalSupply sdk.Coins
+	allocations []Allocation
+}
+
+// CampaignOption configures campaign creation.
+type CampaignOption func(*campaignOptions)
+
+// WithTotalSupply sets the total supply of the campaign.
+func WithTotalSupply(totalSupply sdk.Coins) CampaignOption {
+	return func(o *campaignOptions) {
+		o.totalSupply = totalSupply
+	}
+}
+
+// WithAllocations adds special allocations of the campaign's shares.
+func WithAllocations(allocations ...Allocation) CampaignOption {
+	return func(o *campaignOptions) {
+		o.allocations = append(o.allocations, allocations...)
+	}
+}
+
+// CreateCampaign creates a new campaign coordinated by the account and returns its id. the
+// coordinator of the account is created too when it doesn't exist.
+func (n Network) CreateCampaign(ctx context.Context, name string, options ...CampaignOption) (campaignID uint64, err error) {
+	o := campaignOptions{}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	n.ev.Send(events.New(events.StatusOngoing, "Creating the campaign"))
+
+	cosmos := n.cosmos.WithBroadcastOptions(cosmosclient.WithMemo(fmt.Sprintf("starport campaign create %s", name)))
+
+	if err := n.ensureCoordinator(ctx, cosmos); err != nil {
+		return 0, err
+	}
+
+	if campaignID, err = n.createCampaign(ctx, cosmos, name, o.totalSupply); err != nil {
+		return 0, err
+	}
+
+	// the allocations need the id of the campaign, so they are bundled in the next transaction.
+	if len(o.allocations) > 0 {
+		if err := n.addAllocations(ctx, cosmos, campaignID, o.allocations); err != nil {
+			return campaignID, errors.Wrapf(err, "campaign %d is created but its allocations cannot be added", campaignID)
+		}
+	}
+
+	n.ev.Send(events.New(events.StatusDone, fmt.Sprintf("Campaign %d created", campaignID)))
+
+	return campaignID, nil
+}
+
+// ensureCoordinator creates the coordinator of the account when it doesn't exist.
+func (n Network) ensureCoordinator(ctx context.Context, cosmos CosmosClient) error {
+	coordinatorAddress := n.account.Address(networktypes.SPN)
+
+	_, err := profiletypes.
+		NewQueryClient(n.cosmos.QueryConn()).
+		CoordinatorByAddress(ctx, &profiletypes.QueryGetCoordinatorByAddressRequest{
+			Address: coordinatorAddress,
+		})
+	if cosmoserror.Unwrap(err) == cosmoserror.ErrInvalidRequest {
+		msgCreateCoordinator := profiletypes.NewMsgCreateCoordinator(
+			coordinatorAddress,
+			"",
+			"",
+			"",
+		)
+		_, err = cosmos.BroadcastTxAndWait(ctx, n.account.Name, msgCreateCoordinator)
+	}
+	return err
+}
+
+// createCampaign creates a campaign with the total supply and returns its id.
+func (n Network) createCampaign(ctx context.Context, cosmos CosmosClient, name string, totalSupply sdk.Coins) (uint64, error) {
+	msgCreateCampaign := campaigntypes.NewMsgCreateCampaign(
+		n.account.Address(networktypes.SPN),
+		name,
+		totalSupply,
+	)
+	res, err := cosmos.BroadcastTxAndWait(ctx, n.account.Name, msgCreateCampaign)
+	if err != nil {
+		return 0, err
+	}
+
+	var createCampaignRes campaigntypes.MsgCreateCampaignResponse
+	if err := res.Decode(&createCampaignRes); err != nil {
+		return 0, err
+	}
+	return createCampaignRes.CampaignID, nil
+}
+
+// UpdateSpecialAllocations adds special allocations of the shares of a campaign coordinated by
+// the account. the allocations are validated against the total shares of the campaign before
+// they are sent, SPN only lets the coordinators increase the shares of an address.
+func (n Network) UpdateSpecialAllocations(ctx context.Context, campaignID uint64, allocations ...Allocation) error {
+	if len(allocations) == 0 {
+		return errors.New("no allocations to update")
+	}
+
+	n.ev.Send(events.New(events.StatusOngoing, "Fetching the campaign"))
+
+	res, err := campaigntypes.NewQueryClient(n.cosmos.QueryConn()).Campaign(ctx, &campaigntypes.QueryGetCampaignRequest{
+		CampaignID: campaignID,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error querying campaign %d", campaignID)
+	}
+	if res.Campaign.MainnetInitialized {
+		return fmt.Errorf("the mainnet of campaign %d is initialized, its allocations can't be updated", campaignID)
+	}
+	if err := ValidateAllocations(res.Campaign.AllocatedShares, res.Campaign.TotalShares, allocations...); err != nil {
+		return err
+	}
+
+	cosmos := n.cosmos.WithBroadcastOptions(cosmosclient.WithMemo(fmt.Sprintf("starport campaign update-allocations %d", campaignID)))
+	if err := n.addAllocations(ctx, cosmos, campaignID, allocations); err != nil {
+		return err
+	}
+
+	n.ev.Send(events.New(events.StatusDone, fmt.Sprintf("Allocations of campaign %d updated", campaignID)))
+
+	return nil
+}
+
+// addAllocations adds the shares of the allocations to the campaign in a single transaction.
+func (n Network) addAllocations(ctx context.Context, cosmos CosmosClient, campaignID uint64, allocations []Allocation) error {
+	n.ev.Send(events.New(events.StatusOngoing, "Adding the allocations"))
+
+	coordinatorAddress := n.account.Address(networktypes.SPN)
+
+	msgs := make([]sdk.Msg, 0, len(allocations))
+	for _, allocation := range allocations {
+		msgs = append(msgs, campaigntypes.NewMsgAddShares(
+			campaignID,
+			coordinatorAddress,
+			allocation.Address,
+			allocation.Shares,
+		))
+	}
+	_, err := cosmos.BroadcastTxAndWait(ctx, n.account.Name, msgs...)
+	return err
+}
@@ -0,0 +1,124 @@
+package network
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	campaigntypes "github.com/tendermint/spn/x/campaign/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+)
+
+var testDenoms = cosmosutil.Denoms{
+	{Base: "umars", Units: []cosmosutil.DenomUnit{{Denom: "mars", Exponent: 6}}},
+}
+
+func TestParseAllocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		allocation string
+		want       Allocation
+		err        string
+	}{
+		{
+			name:       "valid allocation",
+			allocation: "spn1abc=1000foo,500bar",
+			want: Allocation{
+				Address: "spn1abc",
+				Shares: campaigntypes.NewSharesFromCoins(sdk.NewCoins(
+					sdk.NewInt64Coin("bar", 500),
+					sdk.NewInt64Coin("foo", 1000),
+				)),
+			},
+		},
+		{
+			name:       "shares in a denom unit",
+			allocation: "spn1abc=12.5mars",
+			want: Allocation{
+				Address: "spn1abc",
+				Shares:  campaigntypes.NewSharesFromCoins(sdk.NewCoins(sdk.NewInt64Coin("umars", 12500000))),
+			},
+		},
+		{
+			name:       "no shares",
+			allocation: "spn1abc",
+			err:        `invalid allocation "spn1abc", expected address=shares`,
+		},
+		{
+			name:       "no address",
+			allocation: "=1000foo",
+			err:        `invalid allocation "=1000foo", expected address=shares`,
+		},
+		{
+			name:       "invalid shares",
+			allocation: "spn1abc=foo",
+			err:        `error parsing the shares of allocation "spn1abc=foo": invalid decimal coin expression: foo`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAllocation(tt.allocation, testDenoms)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateAllocations(t *testing.T) {
+	shares := func(s string) campaigntypes.Shares {
+		shares, err := campaigntypes.NewShares(s)
+		require.NoError(t, err)
+		return shares
+	}
+
+	tests := []struct {
+		name        string
+		allocated   campaigntypes.Shares
+		total       campaigntypes.Shares
+		allocations []Allocation
+		err         string
+	}{
+		{
+			name:      "within the total shares",
+			allocated: shares("400foo"),
+			total:     shares("1000foo"),
+			allocations: []Allocation{
+				{Address: "spn1abc", Shares: shares("300foo")},
+				{Address: "spn1def", Shares: shares("300foo")},
+			},
+		},
+		{
+			name:      "exceeding the total shares",
+			allocated: shares("400foo"),
+			total:     shares("1000foo"),
+			allocations: []Allocation{
+				{Address: "spn1abc", Shares: shares("300foo")},
+				{Address: "spn1def", Shares: shares("301foo")},
+			},
+			err: "the allocations exceed the total shares of the campaign: allocated 1001s/foo with the allocations, total 1000s/foo",
+		},
+		{
+			name:  "exceeding the default total shares",
+			total: shares("1000foo"),
+			allocations: []Allocation{
+				{Address: "spn1abc", Shares: shares("100001bar")},
+			},
+			err: "the allocations exceed the total shares of the campaign: allocated 100001s/bar with the allocations, total 1000s/foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAllocations(tt.allocated, tt.total, tt.allocations...)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package network
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+)
+
+// CosmosClient is the client of SPN the network service queries SPN and sends its transactions
+// with. it's implemented for a cosmosclient.Client by NewCosmosClient, and by the fake of the
+// networktest package to test without a SPN node.
+type CosmosClient interface {
+	// QueryConn returns the connection the gRPC queries of the SPN modules are sent to.
+	QueryConn() gogogrpc.ClientConn
+
+	// Status returns the status of the SPN node.
+	Status(ctx context.Context) (*ctypes.ResultStatus, error)
+
+	// Balances returns all the balances of the address.
+	Balances(ctx context.Context, address string) (sdk.Coins, error)
+
+	// FundFromFaucet requests the coins from the faucet of SPN to the account.
+	FundFromFaucet(ctx context.Context, accountName string, coins sdk.Coins) error
+
+	// BroadcastTxAndWait broadcasts a transaction signed by the account with the messages and
+	// waits for its inclusion in a block.
+	BroadcastTxAndWait(ctx context.Context, accountName string, msgs ...sdk.Msg) (cosmosclient.Response, error)
+
+	// WithBroadcastOptions returns a copy of the client sending its transactions with the options.
+	WithBroadcastOptions(options ...cosmosclient.BroadcastOption) CosmosClient
+}
+
+// cosmosClient is the CosmosClient of a cosmosclient.Client.
+type cosmosClient struct {
+	cosmosclient.Client
+}
+
+// NewCosmosClient returns the CosmosClient of a cosmosclient.Client connected to SPN.
+func NewCosmosClient(c cosmosclient.Client) CosmosClient {
+	return cosmosClient{c}
+}
+
+func (c cosmosClient) QueryConn() gogogrpc.ClientConn {
+	return c.Context
+}
+
+func (c cosmosClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	return c.RPC.Status(ctx)
+}
+
+func (c cosmosClient) WithBroadcastOptions(options ...cosmosclient.BroadcastOption) CosmosClient {
+	return cosmosClient{c.Client.WithBroadcastOptions(options...)}
+}
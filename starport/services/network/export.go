@@ -0,0 +1,63 @@
+package network
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// PeersFormat is a format of the list of the peers of a chain for the provisioning of its nodes.
+type PeersFormat string
+
+const (
+	// PeersFormatComma is the comma separated list of the peers.
+	PeersFormatComma PeersFormat = "comma"
+
+	// PeersFormatConfigTOML is the persistent_peers line of the config.toml of the nodes.
+	PeersFormatConfigTOML PeersFormat = "config-toml"
+)
+
+// ParsePeersFormat parses a format of the list of the peers.
+func ParsePeersFormat(format string) (PeersFormat, error) {
+	switch f := PeersFormat(format); f {
+	case PeersFormatComma, PeersFormatConfigTOML:
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid peers format %q, must be one of: %s, %s", format, PeersFormatComma, PeersFormatConfigTOML)
+}
+
+// FormatPeers formats the peer addresses of a chain in the format, the peers are comma separated
+// by default.
+func FormatPeers(peers []string, format PeersFormat) string {
+	list := strings.Join(peers, ",")
+	if format == PeersFormatConfigTOML {
+		return fmt.Sprintf("persistent_peers = %q", list)
+	}
+	return list
+}
+
+// validatorsCSVHeader is the header of the CSV of the genesis validators.
+var validatorsCSVHeader = []string{"address", "self_delegation", "peer_id", "peer_address"}
+
+// WriteValidatorsCSV writes the genesis validators of a chain in the CSV format, one row per
+// validator after the header.
+func WriteValidatorsCSV(w io.Writer, validators []networktypes.GenesisValidator) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(validatorsCSVHeader); err != nil {
+		return err
+	}
+	for _, v := range validators {
+		peer, err := PeerAddress(v.Peer)
+		if err != nil {
+			return err
+		}
+		if err := cw.Write([]string{v.Address, v.SelfDelegation.String(), v.Peer.Id, peer}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
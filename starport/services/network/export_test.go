@@ -0,0 +1,51 @@
+package network
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+func TestFormatPeers(t *testing.T) {
+	peers := []string{"a@1.2.3.4:26656", "b@5.6.7.8:26656"}
+
+	require.Equal(t, "a@1.2.3.4:26656,b@5.6.7.8:26656", FormatPeers(peers, PeersFormatComma))
+	require.Equal(t, `persistent_peers = "a@1.2.3.4:26656,b@5.6.7.8:26656"`, FormatPeers(peers, PeersFormatConfigTOML))
+	require.Equal(t, `persistent_peers = ""`, FormatPeers(nil, PeersFormatConfigTOML))
+
+	format, err := ParsePeersFormat("config-toml")
+	require.NoError(t, err)
+	require.Equal(t, PeersFormatConfigTOML, format)
+
+	_, err = ParsePeersFormat("json")
+	require.EqualError(t, err, `invalid peers format "json", must be one of: comma, config-toml`)
+}
+
+func TestWriteValidatorsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteValidatorsCSV(&buf, []networktypes.GenesisValidator{
+		{
+			Address:        "spn1a",
+			Peer:           launchtypes.NewPeerConn("a", "1.2.3.4:26656"),
+			SelfDelegation: sdk.NewInt64Coin("stake", 100),
+		},
+		{
+			Address:        "spn1b",
+			Peer:           launchtypes.NewPeerTunnel("b", "tunnel", "https://b.example.com"),
+			SelfDelegation: sdk.NewInt64Coin("stake", 200),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `address,self_delegation,peer_id,peer_address
+spn1a,100stake,a,a@1.2.3.4:26656
+spn1b,200stake,b,b@https://b.example.com
+`, buf.String())
+
+	err = WriteValidatorsCSV(&buf, []networktypes.GenesisValidator{{Address: "spn1c"}})
+	require.EqualError(t, err, "invalid peer connection type: <nil>")
+}
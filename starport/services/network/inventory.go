@@ -0,0 +1,176 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/goccy/go-yaml"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// InventoryFormat is a format of the inventory of the validators of a launched chain.
+type InventoryFormat string
+
+const (
+	// InventoryFormatAnsible is the YAML inventory of Ansible.
+	InventoryFormatAnsible InventoryFormat = "ansible"
+
+	// InventoryFormatTerraform is the tfvars file of Terraform.
+	InventoryFormatTerraform InventoryFormat = "terraform"
+)
+
+// ParseInventoryFormat parses a format of the inventory.
+func ParseInventoryFormat(format string) (InventoryFormat, error) {
+	switch f := InventoryFormat(format); f {
+	case InventoryFormatAnsible, InventoryFormatTerraform:
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid inventory format %q, must be one of: %s, %s", format, InventoryFormatAnsible, InventoryFormatTerraform)
+}
+
+// InventoryValidator is an approved validator of a launched chain in its inventory.
+type InventoryValidator struct {
+	Address string
+	Moniker string
+	NodeID  string
+
+	// Host and Port are the address of the node of the validator that its peers connect to, the
+	// port is empty for the nodes behind HTTP tunnels.
+	Host string
+	Port string
+}
+
+// Inventory is the inventory of the approved validators of a launched chain, for the infra teams to
+// template the monitoring and the firewall configs of the chain.
+type Inventory struct {
+	LaunchID   uint64
+	ChainID    string
+	Validators []InventoryValidator
+}
+
+// NewInventory returns the inventory of the chain from its approved genesis validators.
+func NewInventory(chainLaunch networktypes.ChainLaunch, validators []networktypes.GenesisValidator) (Inventory, error) {
+	inventory := Inventory{
+		LaunchID: chainLaunch.ID,
+		ChainID:  chainLaunch.ChainID,
+	}
+	for _, v := range validators {
+		iv := InventoryValidator{
+			Address: v.Address,
+			NodeID:  v.Peer.Id,
+		}
+		if len(v.Gentx) > 0 {
+			info, _, err := cosmosutil.ParseGentx(v.Gentx)
+			if err != nil {
+				return Inventory{}, fmt.Errorf("invalid gentx of the validator %s: %w", v.Address, err)
+			}
+			iv.Moniker = info.Moniker
+		}
+
+		switch conn := v.Peer.Connection.(type) {
+		case *launchtypes.Peer_TcpAddress:
+			host, port, err := net.SplitHostPort(conn.TcpAddress)
+			if err != nil {
+				return Inventory{}, fmt.Errorf("invalid peer address of the validator %s: %w", v.Address, err)
+			}
+			iv.Host, iv.Port = host, port
+		case *launchtypes.Peer_HttpTunnel:
+			iv.Host = conn.HttpTunnel.Address
+		default:
+			return Inventory{}, fmt.Errorf("invalid peer connection type: %T", v.Peer.Connection)
+		}
+		inventory.Validators = append(inventory.Validators, iv)
+	}
+	return inventory, nil
+}
+
+// Inventory returns the inventory of the approved validators of a launched chain from SPN, it fails
+// when the launch is not triggered yet since the validators can still change.
+func (n Network) Inventory(ctx context.Context, launchID uint64) (Inventory, error) {
+	chainLaunch, err := n.ChainLaunch(ctx, launchID)
+	if err != nil {
+		return Inventory{}, err
+	}
+	if chainLaunch.LaunchTime == 0 {
+		return Inventory{}, fmt.Errorf("the chain %d is not launched yet, its validators can still change", launchID)
+	}
+
+	validators, err := n.GenesisValidators(ctx, launchID)
+	if err != nil {
+		return Inventory{}, err
+	}
+	return NewInventory(chainLaunch, validators)
+}
+
+// Write writes the inventory in the format.
+func (i Inventory) Write(w io.Writer, format InventoryFormat) error {
+	switch format {
+	case InventoryFormatAnsible:
+		return i.writeAnsible(w)
+	case InventoryFormatTerraform:
+		return i.writeTerraform(w)
+	default:
+		_, err := ParseInventoryFormat(string(format))
+		return err
+	}
+}
+
+// writeAnsible writes the inventory in the YAML format of Ansible, the validators are the hosts of the
+// validators group named after their addresses and the chain is described by the vars of the group.
+func (i Inventory) writeAnsible(w io.Writer) error {
+	hosts := yaml.MapSlice{}
+	for _, v := range i.Validators {
+		vars := yaml.MapSlice{
+			{Key: "ansible_host", Value: v.Host},
+			{Key: "moniker", Value: v.Moniker},
+			{Key: "node_id", Value: v.NodeID},
+		}
+		if v.Port != "" {
+			vars = append(vars, yaml.MapItem{Key: "p2p_port", Value: v.Port})
+		}
+		hosts = append(hosts, yaml.MapItem{Key: v.Address, Value: vars})
+	}
+
+	inventory := yaml.MapSlice{
+		{Key: "validators", Value: yaml.MapSlice{
+			{Key: "hosts", Value: hosts},
+			{Key: "vars", Value: yaml.MapSlice{
+				{Key: "chain_id", Value: i.ChainID},
+				{Key: "launch_id", Value: i.LaunchID},
+			}},
+		}},
+	}
+	data, err := yaml.Marshal(inventory)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// writeTerraform writes the inventory as the variables of a tfvars file of Terraform.
+func (i Inventory) writeTerraform(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "chain_id  = %q\nlaunch_id = %d\n\nvalidators = [\n", i.ChainID, i.LaunchID); err != nil {
+		return err
+	}
+	for _, v := range i.Validators {
+		if _, err := fmt.Fprintf(
+			w,
+			"  {\n    address = %q\n    moniker = %q\n    node_id = %q\n    host    = %q\n    port    = %q\n  },\n",
+			v.Address,
+			v.Moniker,
+			v.NodeID,
+			v.Host,
+			v.Port,
+		); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "]\n")
+	return err
+}
@@ -0,0 +1,93 @@
+package network
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+func testInventory(t *testing.T) Inventory {
+	inventory, err := NewInventory(
+		networktypes.ChainLaunch{ID: 3, ChainID: "mars-1"},
+		[]networktypes.GenesisValidator{
+			{
+				Address: "spn1a",
+				Gentx:   []byte(`{"body":{"messages":[{"description":{"moniker":"alice"},"value":{"denom":"stake","amount":"100"}}]}}`),
+				Peer:    launchtypes.NewPeerConn("a", "1.2.3.4:26656"),
+			},
+			{
+				Address: "spn1b",
+				Peer:    launchtypes.NewPeerTunnel("b", "tunnel", "https://b.example.com"),
+			},
+		},
+	)
+	require.NoError(t, err)
+	return inventory
+}
+
+func TestNewInventory(t *testing.T) {
+	require.Equal(t, Inventory{
+		LaunchID: 3,
+		ChainID:  "mars-1",
+		Validators: []InventoryValidator{
+			{Address: "spn1a", Moniker: "alice", NodeID: "a", Host: "1.2.3.4", Port: "26656"},
+			{Address: "spn1b", NodeID: "b", Host: "https://b.example.com"},
+		},
+	}, testInventory(t))
+
+	_, err := NewInventory(networktypes.ChainLaunch{}, []networktypes.GenesisValidator{
+		{Address: "spn1c", Peer: launchtypes.NewPeerConn("c", "1.2.3.4")},
+	})
+	require.Error(t, err)
+}
+
+func TestInventoryWrite(t *testing.T) {
+	inventory := testInventory(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, inventory.Write(&buf, InventoryFormatAnsible))
+	require.Equal(t, `validators:
+  hosts:
+    spn1a:
+      ansible_host: 1.2.3.4
+      moniker: alice
+      node_id: a
+      p2p_port: "26656"
+    spn1b:
+      ansible_host: https://b.example.com
+      moniker: ""
+      node_id: b
+  vars:
+    chain_id: mars-1
+    launch_id: 3
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, inventory.Write(&buf, InventoryFormatTerraform))
+	require.Equal(t, `chain_id  = "mars-1"
+launch_id = 3
+
+validators = [
+  {
+    address = "spn1a"
+    moniker = "alice"
+    node_id = "a"
+    host    = "1.2.3.4"
+    port    = "26656"
+  },
+  {
+    address = "spn1b"
+    moniker = ""
+    node_id = "b"
+    host    = "https://b.example.com"
+    port    = ""
+  },
+]
+`, buf.String())
+
+	require.EqualError(t, inventory.Write(&buf, "json"), `invalid inventory format "json", must be one of: ansible, terraform`)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
 	launchtypes "github.com/tendermint/spn/x/launch/types"
 
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
 	"github.com/tendermint/starport/starport/pkg/cosmoserror"
 	"github.com/tendermint/starport/starport/pkg/cosmosutil"
 	"github.com/tendermint/starport/starport/pkg/events"
@@ -15,20 +17,66 @@ import (
 	"github.com/tendermint/starport/starport/services/network/networktypes"
 )
 
+// joinOptions holds info about how to join a network.
+type joinOptions struct {
+	gentxPath     string
+	publicAddress string
+	vesting       *VestingSchedule
+}
+
+// JoinOption configures joining a network.
+type JoinOption func(*joinOptions)
+
+// WithCustomGentxPath uses a custom gentx instead of the default gentx of the chain's home.
+func WithCustomGentxPath(path string) JoinOption {
+	return func(o *joinOptions) {
+		o.gentxPath = path
+	}
+}
+
+// WithPublicAddress sets the public address of the validator's node that its peers connect to,
+// it's detected from the config of the chain's home and the public IP of the machine otherwise.
+func WithPublicAddress(address string) JoinOption {
+	return func(o *joinOptions) {
+		o.publicAddress = address
+	}
+}
+
+// WithAccountVesting requests a delayed vesting account with the schedule for the validator
+// instead of a genesis account.
+func WithAccountVesting(schedule VestingSchedule) JoinOption {
+	return func(o *joinOptions) {
+		o.vesting = &schedule
+	}
+}
+
 // Join to the network.
 func (n Network) Join(
 	ctx context.Context,
 	c Chain,
 	launchID uint64,
 	amount sdk.Coin,
-	publicAddress string,
-	gentxPath string,
+	options ...JoinOption,
 ) error {
+	o := joinOptions{}
+	for _, apply := range options {
+		apply(&o)
+	}
+
 	nodeID, err := c.NodeID(ctx)
 	if err != nil {
 		return err
 	}
 
+	publicAddress := o.publicAddress
+	if publicAddress == "" {
+		n.ev.Send(events.New(events.StatusOngoing, "Detecting the public address of the node"))
+		if publicAddress, err = detectPublicAddress(c); err != nil {
+			return errors.Wrap(err, "cannot detect the public address of the node, set it manually")
+		}
+		n.ev.Send(events.New(events.StatusDone, "Public address of the node: "+publicAddress))
+	}
+
 	var peer launchtypes.Peer
 	if xurl.IsHTTP(publicAddress) {
 		peer = launchtypes.NewPeerTunnel(nodeID, networkchain.HTTPTunnelChisel, publicAddress)
@@ -37,6 +85,7 @@ func (n Network) Join(
 
 	}
 
+	gentxPath := o.gentxPath
 	isCustomGentx := gentxPath != ""
 
 	// if the custom gentx is not provided, get the chain default from the chain home folder.
@@ -65,6 +114,10 @@ func (n Network) Join(
 		return err
 	}
 
+	var accountOptions []AccountRequestOption
+	if o.vesting != nil {
+		accountOptions = append(accountOptions, WithVesting(*o.vesting))
+	}
 	if err := n.sendAccountRequest(
 		ctx,
 		genesisPath,
@@ -72,6 +125,7 @@ func (n Network) Join(
 		launchID,
 		accountAddress,
 		amount,
+		accountOptions...,
 	); err != nil {
 		return err
 	}
@@ -87,14 +141,14 @@ func (n Network) sendAccountRequest(
 	launchID uint64,
 	accountAddress string,
 	amount sdk.Coin,
+	options ...AccountRequestOption,
 ) (err error) {
 	address := n.account.Address(networktypes.SPN)
 	n.ev.Send(events.New(events.StatusOngoing, "Verifying account already exists "+address))
 
 	// if is custom gentx path, avoid to check account into genesis from the home folder
-	var accExist bool
 	if !isCustomGentx {
-		accExist, err = cosmosutil.CheckGenesisContainsAddress(genesisPath, address)
+		accExist, err := cosmosutil.CheckGenesisContainsAddress(genesisPath, address)
 		if err != nil {
 			return err
 		}
@@ -102,42 +156,9 @@ func (n Network) sendAccountRequest(
 			return fmt.Errorf("account %s already exist", address)
 		}
 	}
-	// check if account exists as a genesis account in SPN chain launch information
-	hasAccount, err := n.hasAccount(ctx, launchID, address)
-	if err != nil {
-		return err
-	}
-	if hasAccount {
-		return fmt.Errorf("account %s already exist", address)
-	}
 
-	msg := launchtypes.NewMsgRequestAddAccount(
-		n.account.Address(networktypes.SPN),
-		launchID,
-		accountAddress,
-		sdk.NewCoins(amount),
-	)
-
-	n.ev.Send(events.New(events.StatusOngoing, "Broadcasting account transactions"))
-	res, err := n.cosmos.BroadcastTx(n.account.Name, msg)
-	if err != nil {
-		return err
-	}
-
-	var requestRes launchtypes.MsgRequestAddAccountResponse
-	if err := res.Decode(&requestRes); err != nil {
-		return err
-	}
-
-	if requestRes.AutoApproved {
-		n.ev.Send(events.New(events.StatusDone, "Account added to the network by the coordinator!"))
-	} else {
-		n.ev.Send(events.New(events.StatusDone,
-			fmt.Sprintf("Request %d to add account to the network has been submitted!",
-				requestRes.RequestID),
-		))
-	}
-	return nil
+	_, err = n.RequestAccount(ctx, launchID, accountAddress, sdk.NewCoins(amount), options...)
+	return err
 }
 
 // sendValidatorRequest creates the RequestAddValidator message into the SPN
@@ -170,7 +191,9 @@ func (n Network) sendValidatorRequest(
 
 	n.ev.Send(events.New(events.StatusOngoing, "Broadcasting validator transaction"))
 
-	res, err := n.cosmos.BroadcastTx(n.account.Name, msg)
+	res, err := n.cosmos.
+		WithBroadcastOptions(cosmosclient.WithMemo(fmt.Sprintf("starport join %d", launchID))).
+		BroadcastTxAndWait(ctx, n.account.Name, msg)
 	if err != nil {
 		return err
 	}
@@ -183,6 +206,9 @@ func (n Network) sendValidatorRequest(
 	if requestRes.AutoApproved {
 		n.ev.Send(events.New(events.StatusDone, "Validator added to the network by the coordinator!"))
 	} else {
+		if err := n.recordRequest(launchID, requestRes.RequestID, networktypes.RequestGenesisValidator, valAddress); err != nil {
+			return err
+		}
 		n.ev.Send(events.New(events.StatusDone,
 			fmt.Sprintf("Request %d to join the network as a validator has been submitted!",
 				requestRes.RequestID),
@@ -193,7 +219,7 @@ func (n Network) sendValidatorRequest(
 
 // hasValidator verify if the validator already exist into the SPN store
 func (n Network) hasValidator(ctx context.Context, launchID uint64, address string) (bool, error) {
-	_, err := launchtypes.NewQueryClient(n.cosmos.Context).GenesisValidator(ctx, &launchtypes.QueryGetGenesisValidatorRequest{
+	_, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).GenesisValidator(ctx, &launchtypes.QueryGetGenesisValidatorRequest{
 		LaunchID: launchID,
 		Address:  address,
 	})
@@ -207,7 +233,7 @@ func (n Network) hasValidator(ctx context.Context, launchID uint64, address stri
 
 // hasAccount verify if the account already exist into the SPN store
 func (n Network) hasAccount(ctx context.Context, launchID uint64, address string) (bool, error) {
-	_, err := launchtypes.NewQueryClient(n.cosmos.Context).VestingAccount(ctx, &launchtypes.QueryGetVestingAccountRequest{
+	_, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).VestingAccount(ctx, &launchtypes.QueryGetVestingAccountRequest{
 		LaunchID: launchID,
 		Address:  address,
 	})
@@ -217,7 +243,7 @@ func (n Network) hasAccount(ctx context.Context, launchID uint64, address string
 		return false, err
 	}
 
-	_, err = launchtypes.NewQueryClient(n.cosmos.Context).GenesisAccount(ctx, &launchtypes.QueryGetGenesisAccountRequest{
+	_, err = launchtypes.NewQueryClient(n.cosmos.QueryConn()).GenesisAccount(ctx, &launchtypes.QueryGetGenesisAccountRequest{
 		LaunchID: launchID,
 		Address:  address,
 	})
@@ -7,6 +7,7 @@ import (
 
 	launchtypes "github.com/tendermint/spn/x/launch/types"
 
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
 	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/pkg/xtime"
 	"github.com/tendermint/starport/starport/services/network/networktypes"
@@ -14,7 +15,7 @@ import (
 
 // LaunchParams fetches the chain launch module params from SPN
 func (n Network) LaunchParams(ctx context.Context) (launchtypes.Params, error) {
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).Params(ctx, &launchtypes.QueryParamsRequest{})
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).Params(ctx, &launchtypes.QueryParamsRequest{})
 	if err != nil {
 		return launchtypes.Params{}, err
 	}
@@ -50,7 +51,9 @@ func (n Network) TriggerLaunch(ctx context.Context, launchID uint64, remainingTi
 
 	msg := launchtypes.NewMsgTriggerLaunch(address, launchID, uint64(remainingTime.Seconds()))
 	n.ev.Send(events.New(events.StatusOngoing, "Setting launch time"))
-	res, err := n.cosmos.BroadcastTx(n.account.Name, msg)
+	res, err := n.cosmos.
+		WithBroadcastOptions(cosmosclient.WithMemo(fmt.Sprintf("starport launch %d", launchID))).
+		BroadcastTxAndWait(ctx, n.account.Name, msg)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,144 @@
+package network
+
+import (
+	"bytes"
+	_ "embed" // embed is required for the default template of the README.
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+)
+
+const (
+	// LaunchInfoFile is the machine readable file of the launch files.
+	LaunchInfoFile = "launch.json"
+
+	// LaunchReadmeFile is the file of the launch files with the instructions for the validators.
+	LaunchReadmeFile = "README.md"
+)
+
+//go:embed templates/launch-readme.md.tpl
+var launchReadmeTemplate string
+
+// Hardware are the hardware requirements of the nodes of the validators.
+type Hardware struct {
+	CPU    int    `json:"cpu"`
+	Memory string `json:"memory"`
+	Disk   string `json:"disk"`
+}
+
+// DefaultHardware are the hardware requirements of the launch files by default.
+var DefaultHardware = Hardware{CPU: 4, Memory: "16GB", Disk: "500GB"}
+
+// Timeline is the planned timeline of a launch, the times that are not planned are nil.
+type Timeline struct {
+	// PublishedAt is the time the chain is published at.
+	PublishedAt time.Time `json:"published_at"`
+
+	// GentxDeadline is the time the requests of the validators to join are accepted until.
+	GentxDeadline *time.Time `json:"gentx_deadline,omitempty"`
+
+	// LaunchTime is the time the chain is planned to be launched at.
+	LaunchTime *time.Time `json:"launch_time,omitempty"`
+}
+
+// JoinStep is a step for the validators to join a launch.
+type JoinStep struct {
+	Description string `json:"description"`
+	Command     string `json:"command"`
+}
+
+// LaunchInfo is the information of a published chain that its validators need to join it.
+type LaunchInfo struct {
+	LaunchID   uint64     `json:"launch_id"`
+	CampaignID uint64     `json:"campaign_id"`
+	ChainID    string     `json:"chain_id"`
+	SourceURL  string     `json:"source_url"`
+	SourceHash string     `json:"source_hash"`
+	GenesisURL string     `json:"genesis_url,omitempty"`
+	Join       []JoinStep `json:"join"`
+	Hardware   Hardware   `json:"hardware"`
+	Timeline   Timeline   `json:"timeline"`
+}
+
+// JoinSteps returns the steps for the validators to join the launch.
+func JoinSteps(launchID uint64) []JoinStep {
+	return []JoinStep{
+		{
+			Description: "Initialize the node of the validator and generate its gentx",
+			Command:     fmt.Sprintf("starport network chain init %d", launchID),
+		},
+		{
+			Description: "Request to join the network with the self-delegation of the validator",
+			Command:     fmt.Sprintf("starport network chain join %d <amount>", launchID),
+		},
+		{
+			Description: "Once the chain is launched, prepare the node with the final genesis and start it",
+			Command:     fmt.Sprintf("starport network chain prepare %d", launchID),
+		},
+	}
+}
+
+// ParseLaunchReadmeTemplate parses a text/template of Go for the README of the launch files. the
+// template is executed with the LaunchInfo and its inc function increments an int.
+func ParseLaunchReadmeTemplate(text string) (*template.Template, error) {
+	t, err := template.
+		New(LaunchReadmeFile).
+		Funcs(template.FuncMap{"inc": func(i int) int { return i + 1 }}).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template of the README: %w", err)
+	}
+	return t, nil
+}
+
+type launchFilesOptions struct {
+	readmeTemplate *template.Template
+}
+
+// LaunchFilesOption configures the launch files.
+type LaunchFilesOption func(*launchFilesOptions)
+
+// WithLaunchReadmeTemplate renders the README of the launch files with a template parsed by
+// ParseLaunchReadmeTemplate.
+func WithLaunchReadmeTemplate(t *template.Template) LaunchFilesOption {
+	return func(o *launchFilesOptions) {
+		o.readmeTemplate = t
+	}
+}
+
+// WriteLaunchFiles writes the launch files of a published chain to dir: the README with the
+// instructions for the validators and the machine readable launch.json.
+func WriteLaunchFiles(dir string, info LaunchInfo, options ...LaunchFilesOption) error {
+	var o launchFilesOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	t := o.readmeTemplate
+	if t == nil {
+		var err error
+		if t, err = ParseLaunchReadmeTemplate(launchReadmeTemplate); err != nil {
+			return err
+		}
+	}
+	var readme bytes.Buffer
+	if err := t.Execute(&readme, info); err != nil {
+		return fmt.Errorf("can't render the README: %w", err)
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, LaunchInfoFile), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, LaunchReadmeFile), readme.Bytes(), 0644)
+}
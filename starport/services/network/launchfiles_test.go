@@ -0,0 +1,67 @@
+package network
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteLaunchFiles(t *testing.T) {
+	var (
+		dir        = filepath.Join(t.TempDir(), "launch")
+		launchTime = time.Date(2022, 3, 1, 15, 0, 0, 0, time.UTC)
+		info       = LaunchInfo{
+			LaunchID:   42,
+			CampaignID: 3,
+			ChainID:    "mars-1",
+			SourceURL:  "https://github.com/foo/mars",
+			SourceHash: "b8e5c7e",
+			Join:       JoinSteps(42),
+			Hardware:   DefaultHardware,
+			Timeline: Timeline{
+				PublishedAt: time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+				LaunchTime:  &launchTime,
+			},
+		}
+	)
+
+	require.NoError(t, WriteLaunchFiles(dir, info))
+
+	data, err := os.ReadFile(filepath.Join(dir, LaunchInfoFile))
+	require.NoError(t, err)
+	var got LaunchInfo
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, info, got)
+	require.NotContains(t, string(data), "gentx_deadline")
+
+	readme, err := os.ReadFile(filepath.Join(dir, LaunchReadmeFile))
+	require.NoError(t, err)
+	require.Contains(t, string(readme), "| Launch ID | 42 |")
+	require.Contains(t, string(readme), "- 4 CPU cores")
+	require.Contains(t, string(readme), "- Planned launch: 2022-03-01 15:00 UTC")
+	require.NotContains(t, string(readme), "Requests to join accepted until")
+	require.Contains(t, string(readme), "3. Once the chain is launched")
+	require.Contains(t, string(readme), "   starport network chain join 42 <amount>\n")
+
+	// the README is rendered with a custom template.
+	tpl, err := ParseLaunchReadmeTemplate("{{ .ChainID }}: {{ range .Join }}{{ .Command }};{{ end }}")
+	require.NoError(t, err)
+	require.NoError(t, WriteLaunchFiles(dir, info, WithLaunchReadmeTemplate(tpl)))
+	readme, err = os.ReadFile(filepath.Join(dir, LaunchReadmeFile))
+	require.NoError(t, err)
+	require.Equal(t,
+		"mars-1: starport network chain init 42;starport network chain join 42 <amount>;starport network chain prepare 42;",
+		string(readme),
+	)
+
+	_, err = ParseLaunchReadmeTemplate("{{ .ChainID ")
+	require.Error(t, err)
+
+	tpl, err = ParseLaunchReadmeTemplate("{{ .Unknown }}")
+	require.NoError(t, err)
+	require.Error(t, WriteLaunchFiles(dir, info, WithLaunchReadmeTemplate(tpl)))
+}
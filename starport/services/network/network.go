@@ -7,15 +7,21 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
-	"github.com/tendermint/starport/starport/pkg/cosmosclient"
 	"github.com/tendermint/starport/starport/pkg/events"
 )
 
 // Network is network builder.
 type Network struct {
 	ev      events.Bus
-	cosmos  cosmosclient.Client
+	cosmos  CosmosClient
 	account cosmosaccount.Account
+
+	// requestHistoryPath is the path of the file that the records of the requests are kept in.
+	requestHistoryPath string
+
+	// publishCheckpointPath is the path of the file that the checkpoints of the unfinished
+	// publishes are kept in.
+	publishCheckpointPath string
 }
 
 type Chain interface {
@@ -40,8 +46,24 @@ func CollectEvents(ev events.Bus) Option {
 	}
 }
 
+// WithRequestHistoryPath sets the path of the file that the records of the sent and tracked
+// requests are kept in, it's DefaultRequestHistoryPath by default.
+func WithRequestHistoryPath(path string) Option {
+	return func(b *Network) {
+		b.requestHistoryPath = path
+	}
+}
+
+// WithPublishCheckpointPath sets the path of the file that the checkpoints of the unfinished
+// publishes are kept in, it's DefaultPublishCheckpointPath by default.
+func WithPublishCheckpointPath(path string) Option {
+	return func(b *Network) {
+		b.publishCheckpointPath = path
+	}
+}
+
 // New creates a Builder.
-func New(cosmos cosmosclient.Client, account cosmosaccount.Account, options ...Option) (Network, error) {
+func New(cosmos CosmosClient, account cosmosaccount.Account, options ...Option) (Network, error) {
 	n := Network{
 		cosmos:  cosmos,
 		account: account,
@@ -49,6 +71,20 @@ func New(cosmos cosmosclient.Client, account cosmosaccount.Account, options ...O
 	for _, opt := range options {
 		opt(&n)
 	}
+	if n.requestHistoryPath == "" {
+		path, err := DefaultRequestHistoryPath()
+		if err != nil {
+			return Network{}, err
+		}
+		n.requestHistoryPath = path
+	}
+	if n.publishCheckpointPath == "" {
+		path, err := DefaultPublishCheckpointPath()
+		if err != nil {
+			return Network{}, err
+		}
+		n.publishCheckpointPath = path
+	}
 	return n, nil
 }
 
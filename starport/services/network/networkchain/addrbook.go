@@ -0,0 +1,45 @@
+package networkchain
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+)
+
+// AddrBookFile is the file in the config dir of the chain that the address book is kept in.
+const AddrBookFile = "addrbook.json"
+
+// AddrBookPath returns the path of the address book of the chain.
+func (c Chain) AddrBookPath() (string, error) {
+	home, err := c.chain.Home()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, cosmosutil.ChainConfigDir, AddrBookFile), nil
+}
+
+// writeAddrBook writes a new address book with the peer addresses in the id@host:port format to
+// path, the existing address book is replaced.
+func writeAddrBook(path string, addresses []string) error {
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+
+	book := pex.NewAddrBook(path, false)
+	for _, address := range addresses {
+		netAddress, err := p2p.NewNetAddressString(address)
+		if err != nil {
+			return err
+		}
+		if err := book.AddAddress(netAddress, netAddress); err != nil {
+			return err
+		}
+	}
+	book.Save()
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package networkchain
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAddrBook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), AddrBookFile)
+
+	// an existing address book is replaced.
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0644))
+
+	addresses := []string{
+		"e6a59e37b2761f26a21c9168f78a7f2b07c120c7@1.2.3.4:26656",
+		"b0ba2e446b5b2c6f1a4e2c4fad02d800cf0ac8c3@127.0.0.1:22001",
+	}
+	require.NoError(t, writeAddrBook(path, addresses))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var book struct {
+		Addrs []struct {
+			Addr struct {
+				ID   string `json:"id"`
+				IP   string `json:"ip"`
+				Port int    `json:"port"`
+			} `json:"addr"`
+		} `json:"addrs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &book))
+	require.Len(t, book.Addrs, 2)
+
+	ids := []string{book.Addrs[0].Addr.ID, book.Addrs[1].Addr.ID}
+	require.ElementsMatch(t, []string{
+		"e6a59e37b2761f26a21c9168f78a7f2b07c120c7",
+		"b0ba2e446b5b2c6f1a4e2c4fad02d800cf0ac8c3",
+	}, ids)
+}
+
+func TestWriteAddrBookInvalidAddress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), AddrBookFile)
+	require.Error(t, writeAddrBook(path, []string{"invalid"}))
+}
@@ -0,0 +1,309 @@
+package networkchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// AuditStatus is the result of a check of an audit.
+type AuditStatus string
+
+const (
+	// AuditPass is the status of the checks that pass.
+	AuditPass AuditStatus = "pass"
+
+	// AuditWarn is the status of the checks that pass but are risky for a mainnet.
+	AuditWarn AuditStatus = "warn"
+
+	// AuditFail is the status of the checks that fail, the chain shouldn't be launched.
+	AuditFail AuditStatus = "fail"
+)
+
+// the thresholds of the audits.
+const (
+	defaultAuditMinValidators = 4
+	minUnbondingTime          = 14 * 24 * time.Hour
+	minVotingPeriod           = 24 * time.Hour
+)
+
+// AuditCheck is a check of an audit.
+type AuditCheck struct {
+	Name    string      `json:"name"`
+	Status  AuditStatus `json:"status"`
+	Message string      `json:"message"`
+}
+
+// AuditReport is the result of the audit of the genesis of a chain.
+type AuditReport struct {
+	Checks []AuditCheck `json:"checks"`
+}
+
+// Count returns the number of checks with the status.
+func (r AuditReport) Count(status AuditStatus) int {
+	var n int
+	for _, check := range r.Checks {
+		if check.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+type auditOptions struct {
+	minValidators int
+}
+
+// AuditOption configures an audit.
+type AuditOption func(*auditOptions)
+
+// AuditMinValidators sets the number of validators below which the validator count is a warning.
+func AuditMinValidators(n int) AuditOption {
+	return func(o *auditOptions) {
+		o.minValidators = n
+	}
+}
+
+// auditGenesis is the part of a genesis the audits check.
+type auditGenesis struct {
+	AppState struct {
+		Auth struct {
+			Accounts []json.RawMessage `json:"accounts"`
+		} `json:"auth"`
+		Bank struct {
+			Balances []struct {
+				Address string    `json:"address"`
+				Coins   []rawCoin `json:"coins"`
+			} `json:"balances"`
+			Supply []rawCoin `json:"supply"`
+		} `json:"bank"`
+		Staking struct {
+			Params struct {
+				UnbondingTime string `json:"unbonding_time"`
+				MaxValidators uint32 `json:"max_validators"`
+				BondDenom     string `json:"bond_denom"`
+			} `json:"params"`
+		} `json:"staking"`
+		Gov *struct {
+			VotingParams struct {
+				VotingPeriod string `json:"voting_period"`
+			} `json:"voting_params"`
+		} `json:"gov"`
+		Genutil struct {
+			Gentxs []struct {
+				Body struct {
+					Messages []struct {
+						Description struct {
+							Moniker string `json:"moniker"`
+						} `json:"description"`
+						ValidatorAddress string `json:"validator_address"`
+						PubKey           struct {
+							Key string `json:"key"`
+						} `json:"pubkey"`
+						Value rawCoin `json:"value"`
+					} `json:"messages"`
+				} `json:"body"`
+			} `json:"gen_txs"`
+		} `json:"genutil"`
+	} `json:"app_state"`
+}
+
+type rawCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type auditValidator struct {
+	name  string
+	key   string
+	stake sdk.Int
+}
+
+// Audit runs the pre-launch checks of a mainnet on the genesis of a chain: the validator count and
+// the distribution of their stake, the consensus keys of the validators, the balances of the
+// accounts against the supply and the sanity of the staking and governance params.
+func Audit(genesis []byte, options ...AuditOption) (AuditReport, error) {
+	o := auditOptions{minValidators: defaultAuditMinValidators}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	var g auditGenesis
+	if err := json.Unmarshal(genesis, &g); err != nil {
+		return AuditReport{}, errors.Wrap(err, "the genesis can't be parsed")
+	}
+
+	var validators []auditValidator
+	for _, gentx := range g.AppState.Genutil.Gentxs {
+		for _, msg := range gentx.Body.Messages {
+			stake, ok := sdk.NewIntFromString(msg.Value.Amount)
+			if !ok {
+				return AuditReport{}, fmt.Errorf("invalid self-delegation %q of the validator %s", msg.Value.Amount, msg.ValidatorAddress)
+			}
+			name := msg.Description.Moniker
+			if name == "" {
+				name = msg.ValidatorAddress
+			}
+			validators = append(validators, auditValidator{name: name, key: msg.PubKey.Key, stake: stake})
+		}
+	}
+
+	var report AuditReport
+	add := func(name string, status AuditStatus, format string, args ...interface{}) {
+		report.Checks = append(report.Checks, AuditCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// validators.
+	switch n := len(validators); {
+	case n == 0:
+		add("validator count", AuditFail, "the genesis has no validators")
+	case n < o.minValidators:
+		add("validator count", AuditWarn, "%d validator(s), less than the %d expected for a mainnet", n, o.minValidators)
+	default:
+		add("validator count", AuditPass, "%d validators", n)
+	}
+
+	if len(validators) > 0 {
+		status, message := auditStakeDistribution(validators)
+		add("stake distribution", status, "%s", message)
+	}
+
+	keys := make(map[string][]string)
+	for _, v := range validators {
+		keys[v.key] = append(keys[v.key], v.name)
+	}
+	var duplicates []string
+	for _, names := range keys {
+		if len(names) > 1 {
+			duplicates = append(duplicates, strings.Join(names, ", "))
+		}
+	}
+	sort.Strings(duplicates)
+	if len(duplicates) > 0 {
+		add("consensus keys", AuditFail, "validators share consensus keys, only one of them can sign: %s", strings.Join(duplicates, "; "))
+	} else {
+		add("consensus keys", AuditPass, "the consensus keys of the validators are unique")
+	}
+
+	// accounts and supply.
+	balances := sdk.NewCoins()
+	for _, b := range g.AppState.Bank.Balances {
+		coins, err := parseRawCoins(b.Coins)
+		if err != nil {
+			return AuditReport{}, errors.Wrapf(err, "invalid balance of %s", b.Address)
+		}
+		balances = balances.Add(coins...)
+	}
+	supply, err := parseRawCoins(g.AppState.Bank.Supply)
+	if err != nil {
+		return AuditReport{}, errors.Wrap(err, "invalid supply")
+	}
+	switch {
+	case supply.Empty():
+		add("supply", AuditPass, "the supply is computed from the balances: %s", balances)
+	case !supply.IsEqual(balances):
+		add("supply", AuditFail, "the balances sum up to %s but the supply is %s", balances, supply)
+	default:
+		add("supply", AuditPass, "the balances sum up to the supply: %s", supply)
+	}
+
+	accounts := make(map[string]bool)
+	for _, acc := range g.AppState.Auth.Accounts {
+		accounts[findAddress(acc)] = true
+	}
+	var missing []string
+	for _, b := range g.AppState.Bank.Balances {
+		if !accounts[b.Address] {
+			missing = append(missing, b.Address)
+		}
+	}
+	if len(missing) > 0 {
+		add("accounts", AuditWarn, "%d balance(s) have no account: %s", len(missing), strings.Join(missing, ", "))
+	} else {
+		add("accounts", AuditPass, "%d accounts", len(accounts))
+	}
+
+	// params.
+	params := g.AppState.Staking.Params
+	if denom := params.BondDenom; balances.AmountOf(denom).IsZero() {
+		add("bond denom", AuditFail, "no account has the bond denom %q", denom)
+	} else {
+		add("bond denom", AuditPass, "%s", denom)
+	}
+
+	unbonding, err := time.ParseDuration(params.UnbondingTime)
+	switch {
+	case err != nil || unbonding <= 0:
+		add("unbonding period", AuditFail, "invalid unbonding period %q", params.UnbondingTime)
+	case unbonding < minUnbondingTime:
+		add("unbonding period", AuditWarn, "%s is short for a mainnet, the stake is only secured against long range attacks for the unbonding period", unbonding)
+	default:
+		add("unbonding period", AuditPass, "%s", unbonding)
+	}
+
+	switch {
+	case params.MaxValidators == 0:
+		add("max validators", AuditFail, "the max validators is 0, no validator can be bonded")
+	case int(params.MaxValidators) < len(validators):
+		add("max validators", AuditWarn, "the max validators is %d, %d genesis validators aren't bonded at launch", params.MaxValidators, len(validators)-int(params.MaxValidators))
+	default:
+		add("max validators", AuditPass, "%d", params.MaxValidators)
+	}
+
+	if gov := g.AppState.Gov; gov != nil {
+		voting, err := time.ParseDuration(gov.VotingParams.VotingPeriod)
+		switch {
+		case err != nil || voting <= 0:
+			add("voting period", AuditFail, "invalid voting period %q", gov.VotingParams.VotingPeriod)
+		case voting < minVotingPeriod:
+			add("voting period", AuditWarn, "%s is short for a mainnet, the validators may miss the proposals", voting)
+		default:
+			add("voting period", AuditPass, "%s", voting)
+		}
+	}
+
+	return report, nil
+}
+
+// auditStakeDistribution checks the share of the total stake of the largest validator. more than
+// a third of the stake halts the chain and more than two thirds control the consensus.
+func auditStakeDistribution(validators []auditValidator) (AuditStatus, string) {
+	total := sdk.ZeroInt()
+	largest := validators[0]
+	for _, v := range validators {
+		total = total.Add(v.stake)
+		if v.stake.GT(largest.stake) {
+			largest = v
+		}
+	}
+	if total.IsZero() {
+		return AuditFail, "the validators have no stake"
+	}
+
+	share := largest.stake.ToDec().QuoInt(total)
+	message := fmt.Sprintf("the largest validator %s has %.2f%% of the stake", largest.name, share.MustFloat64()*100)
+	switch {
+	case share.GT(sdk.NewDec(2).QuoInt64(3)):
+		return AuditFail, message + ", it controls the consensus alone"
+	case share.GT(sdk.OneDec().QuoInt64(3)):
+		return AuditWarn, message + ", it can halt the chain alone"
+	}
+	return AuditPass, message
+}
+
+func parseRawCoins(raw []rawCoin) (sdk.Coins, error) {
+	coins := sdk.NewCoins()
+	for _, c := range raw {
+		amount, ok := sdk.NewIntFromString(c.Amount)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q of %s", c.Amount, c.Denom)
+		}
+		coins = coins.Add(sdk.NewCoin(c.Denom, amount))
+	}
+	return coins, nil
+}
@@ -0,0 +1,113 @@
+package networkchain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// auditGentx returns a gentx of a validator with its consensus key and self-delegation.
+func auditGentx(moniker, key, stake string) string {
+	return fmt.Sprintf(`{"body": {"messages": [{
+  "@type": "/cosmos.staking.v1beta1.MsgCreateValidator",
+  "description": {"moniker": %q},
+  "validator_address": "cosmosvaloper1%s",
+  "pubkey": {"@type": "/cosmos.crypto.ed25519.PubKey", "key": %q},
+  "value": {"denom": "stake", "amount": %q}
+}]}}`, moniker, moniker, key, stake)
+}
+
+// auditGenesisJSON returns a genesis with the gentxs, the supply and the staking params.
+func auditGenesisJSON(supply, unbonding string, maxValidators int, gentxs ...string) []byte {
+	return []byte(fmt.Sprintf(`{
+  "app_state": {
+    "auth": {"accounts": [
+      {"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": "cosmos1a"},
+      {"@type": "/cosmos.vesting.v1beta1.DelayedVestingAccount", "base_vesting_account": {"base_account": {"address": "cosmos1b"}}}
+    ]},
+    "bank": {
+      "balances": [
+        {"address": "cosmos1a", "coins": [{"denom": "stake", "amount": "600"}]},
+        {"address": "cosmos1b", "coins": [{"denom": "stake", "amount": "400"}, {"denom": "token", "amount": "10"}]}
+      ],
+      "supply": %s
+    },
+    "staking": {"params": {"unbonding_time": %q, "max_validators": %d, "bond_denom": "stake"}},
+    "gov": {"voting_params": {"voting_period": "172800s"}},
+    "genutil": {"gen_txs": [%s]}
+  }
+}`, supply, unbonding, maxValidators, strings.Join(gentxs, ",")))
+}
+
+func auditStatuses(report AuditReport) map[string]AuditStatus {
+	statuses := make(map[string]AuditStatus)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestAudit(t *testing.T) {
+	report, err := Audit(auditGenesisJSON(
+		`[{"denom": "stake", "amount": "1000"}, {"denom": "token", "amount": "10"}]`,
+		"1814400s",
+		100,
+		auditGentx("alice", "a", "100"),
+		auditGentx("bob", "b", "100"),
+		auditGentx("carol", "c", "100"),
+		auditGentx("dave", "d", "90"),
+	))
+	require.NoError(t, err)
+	require.Equal(t, 0, report.Count(AuditWarn)+report.Count(AuditFail), report.Checks)
+	require.Equal(t, map[string]AuditStatus{
+		"validator count":    AuditPass,
+		"stake distribution": AuditPass,
+		"consensus keys":     AuditPass,
+		"supply":             AuditPass,
+		"accounts":           AuditPass,
+		"bond denom":         AuditPass,
+		"unbonding period":   AuditPass,
+		"max validators":     AuditPass,
+		"voting period":      AuditPass,
+	}, auditStatuses(report))
+	require.Equal(t, "the largest validator alice has 25.64% of the stake", report.Checks[1].Message)
+
+	report, err = Audit(auditGenesisJSON(
+		`[{"denom": "stake", "amount": "2000"}]`,
+		"86400s",
+		1,
+		auditGentx("alice", "a", "300"),
+		auditGentx("bob", "a", "100"),
+	))
+	require.NoError(t, err)
+	statuses := auditStatuses(report)
+	require.Equal(t, AuditWarn, statuses["validator count"])
+	require.Equal(t, AuditFail, statuses["stake distribution"])
+	require.Equal(t, AuditFail, statuses["consensus keys"])
+	require.Equal(t, AuditFail, statuses["supply"])
+	require.Equal(t, AuditWarn, statuses["unbonding period"])
+	require.Equal(t, AuditWarn, statuses["max validators"])
+	require.Equal(t, 3, report.Count(AuditFail))
+
+	for _, check := range report.Checks {
+		if check.Name == "consensus keys" {
+			require.Equal(t, "validators share consensus keys, only one of them can sign: alice, bob", check.Message)
+		}
+		if check.Name == "supply" {
+			require.Equal(t, "the balances sum up to 1000stake,10token but the supply is 2000stake", check.Message)
+		}
+	}
+
+	// the supply is computed from the balances when it's not set.
+	report, err = Audit(auditGenesisJSON("[]", "1814400s", 100), AuditMinValidators(1))
+	require.NoError(t, err)
+	statuses = auditStatuses(report)
+	require.Equal(t, AuditFail, statuses["validator count"])
+	require.Equal(t, AuditPass, statuses["supply"])
+	require.NotContains(t, statuses, "stake distribution")
+
+	_, err = Audit([]byte("{"))
+	require.Error(t, err)
+}
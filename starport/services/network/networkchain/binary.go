@@ -0,0 +1,192 @@
+package networkchain
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+)
+
+// binaryChecksumPrefix is the optional prefix of the checksums of the binaries.
+const binaryChecksumPrefix = "sha256:"
+
+// BinaryDownload is a prebuilt binary of the blockchain that is downloaded instead of building the
+// blockchain from its source.
+type BinaryDownload struct {
+	// URL is the URL of the binary, the binary is served as is or in a tar.gz archive.
+	URL string
+
+	// Checksum is the sha256 checksum of the file served at the URL.
+	Checksum string
+}
+
+// BinaryChecksumMismatchError is returned when a binary downloaded from a URL doesn't match its checksum.
+type BinaryChecksumMismatchError struct {
+	URL              string
+	ExpectedChecksum string
+	Checksum         string
+}
+
+func (e *BinaryChecksumMismatchError) Error() string {
+	return fmt.Sprintf(`the binary downloaded from %s doesn't match its checksum:
+  expected sha256: %s
+  actual sha256:   %s`,
+		e.URL,
+		e.ExpectedChecksum,
+		e.Checksum,
+	)
+}
+
+// ParseBinaryChecksum validates a sha256 checksum of a binary in hex, optionally prefixed by sha256:,
+// and returns it in lowercase without prefix.
+func ParseBinaryChecksum(checksum string) (string, error) {
+	sum := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(checksum), binaryChecksumPrefix))
+	if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid checksum %q, expected a sha256 checksum in hex", checksum)
+	}
+	return sum, nil
+}
+
+// WithBinaryDownload downloads the prebuilt binary of the blockchain from url instead of building
+// it, the binary is verified against its sha256 checksum before it's installed.
+func WithBinaryDownload(url, checksum string) Option {
+	return func(c *Chain) {
+		c.binaryDownload = &BinaryDownload{URL: url, Checksum: checksum}
+	}
+}
+
+// BinaryPath returns the path that the binary of the blockchain is installed at.
+func (c Chain) BinaryPath() (string, error) {
+	binary, err := c.chain.Binary()
+	if err != nil {
+		return "", err
+	}
+	return gocmd.BinaryPath("", gocmd.BinaryName(binary, runtime.GOOS))
+}
+
+// build builds the binary of the blockchain or downloads it when the blockchain has a prebuilt binary.
+func (c *Chain) build(ctx context.Context) error {
+	if c.binaryDownload == nil {
+		c.ev.Send(events.New(events.StatusOngoing, "Building the blockchain"))
+		if _, err := c.chain.Build(ctx, ""); err != nil {
+			return err
+		}
+		c.ev.Send(events.New(events.StatusDone, "Blockchain built"))
+		return nil
+	}
+
+	path, err := c.BinaryPath()
+	if err != nil {
+		return err
+	}
+
+	c.ev.Send(events.New(events.StatusOngoing, "Downloading the binary"))
+	if err := downloadBinary(ctx, *c.binaryDownload, filepath.Base(path), path); err != nil {
+		return err
+	}
+	c.ev.Send(events.New(events.StatusDone, fmt.Sprintf("Binary verified and installed at %s", path)))
+	return nil
+}
+
+// downloadBinary downloads the binary named binary, verifies it and installs it at path. the binary
+// is extracted when it's served in a tar.gz archive.
+func downloadBinary(ctx context.Context, d BinaryDownload, binary, path string) error {
+	expected, err := ParseBinaryChecksum(d.Checksum)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot download the binary from %s: %s", d.URL, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "cannot download the binary from %s", d.URL)
+	}
+
+	sum := sha256.Sum256(data)
+	if checksum := hex.EncodeToString(sum[:]); checksum != expected {
+		return &BinaryChecksumMismatchError{URL: d.URL, ExpectedChecksum: expected, Checksum: checksum}
+	}
+
+	if bytes.HasPrefix(data, gzipMagic) {
+		if data, err = extractBinary(data, binary); err != nil {
+			return errors.Wrapf(err, "cannot extract the binary from %s", d.URL)
+		}
+	}
+	return installBinary(path, data)
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// extractBinary extracts the binary named binary from a tar.gz archive.
+func extractBinary(archive []byte, binary string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("the archive doesn't contain the %s binary", binary)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag == tar.TypeReg && filepath.Base(h.Name) == binary {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// installBinary writes the binary at path, the binary is written next to path and renamed over it
+// so a binary in use is never left half written.
+func installBinary(path string, binary []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,119 @@
+package networkchain
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func tarGz(t *testing.T, files map[string][]byte) []byte {
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0755,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return b.Bytes()
+}
+
+func TestParseBinaryChecksum(t *testing.T) {
+	sum := sha256Hex([]byte("marsd"))
+
+	checksum, err := ParseBinaryChecksum("sha256:" + sum)
+	require.NoError(t, err)
+	require.Equal(t, sum, checksum)
+
+	checksum, err = ParseBinaryChecksum(" " + string(bytes.ToUpper([]byte(sum))))
+	require.NoError(t, err)
+	require.Equal(t, sum, checksum)
+
+	_, err = ParseBinaryChecksum("abc")
+	require.EqualError(t, err, `invalid checksum "abc", expected a sha256 checksum in hex`)
+}
+
+func TestDownloadBinary(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho marsd\n")
+	archive := tarGz(t, map[string][]byte{
+		"README.md":       []byte("marsd"),
+		"release/marsd":   binary,
+		"release/marscli": []byte("marscli"),
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/marsd", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/marsd.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(archive) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, tt := range []struct {
+		name     string
+		download BinaryDownload
+		err      string
+	}{
+		{
+			name:     "binary",
+			download: BinaryDownload{URL: server.URL + "/marsd", Checksum: sha256Hex(binary)},
+		},
+		{
+			name:     "archive",
+			download: BinaryDownload{URL: server.URL + "/marsd.tar.gz", Checksum: "sha256:" + sha256Hex(archive)},
+		},
+		{
+			name:     "checksum mismatch",
+			download: BinaryDownload{URL: server.URL + "/marsd", Checksum: sha256Hex(archive)},
+			err:      "doesn't match its checksum",
+		},
+		{
+			name:     "not found",
+			download: BinaryDownload{URL: server.URL + "/gaiad", Checksum: sha256Hex(binary)},
+			err:      "404 Not Found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "bin", "marsd")
+
+			err := downloadBinary(context.Background(), tt.download, "marsd", path)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				require.NoFileExists(t, path)
+				return
+			}
+			require.NoError(t, err)
+
+			installed, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, binary, installed)
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+		})
+	}
+
+	_, err := extractBinary(archive, "gaiad")
+	require.EqualError(t, err, "the archive doesn't contain the gaiad binary")
+}
@@ -0,0 +1,244 @@
+package networkchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// msgCreateValidatorType is the type of the gentx messages that create the genesis validators.
+const msgCreateValidatorType = "/cosmos.staking.v1beta1.MsgCreateValidator"
+
+// GenesisDiff is the difference between the base genesis of a chain and its finalized genesis.
+type GenesisDiff struct {
+	AccountsAdded   []string      `yaml:"accounts_added" json:"accounts_added"`
+	ValidatorsAdded []string      `yaml:"validators_added" json:"validators_added"`
+	ParamsChanged   []ParamChange `yaml:"params_changed" json:"params_changed"`
+}
+
+// ParamChange is a param of the genesis that has a different value in the finalized genesis.
+type ParamChange struct {
+	// Path of the param, e.g. staking.params.bond_denom or consensus_params.block.max_gas.
+	Path   string `yaml:"path" json:"path"`
+	Before string `yaml:"before" json:"before"`
+	After  string `yaml:"after" json:"after"`
+}
+
+// IsEmpty checks if the genesis has no change.
+func (d GenesisDiff) IsEmpty() bool {
+	return len(d.AccountsAdded) == 0 && len(d.ValidatorsAdded) == 0 && len(d.ParamsChanged) == 0
+}
+
+// WriteTo writes the human-readable diff to w.
+func (d GenesisDiff) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	if d.IsEmpty() {
+		b.WriteString("The genesis has no change\n")
+	}
+	if len(d.AccountsAdded) > 0 {
+		b.WriteString("Accounts added:\n")
+		for _, address := range d.AccountsAdded {
+			fmt.Fprintf(&b, "  + %s\n", address)
+		}
+	}
+	if len(d.ValidatorsAdded) > 0 {
+		b.WriteString("Validators added:\n")
+		for _, validator := range d.ValidatorsAdded {
+			fmt.Fprintf(&b, "  + %s\n", validator)
+		}
+	}
+	if len(d.ParamsChanged) > 0 {
+		b.WriteString("Params changed:\n")
+		for _, change := range d.ParamsChanged {
+			fmt.Fprintf(&b, "  ~ %s: %s -> %s\n", change.Path, change.Before, change.After)
+		}
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// genesisSummary holds the parts of a genesis that are compared.
+type genesisSummary struct {
+	accounts   []string
+	validators []string
+	params     map[string]string
+}
+
+// DiffGenesis returns the difference between the base genesis and the finalized genesis. the
+// lists of the diff are sorted, so the same genesis files give the same diff.
+func DiffGenesis(base, finalized []byte) (GenesisDiff, error) {
+	baseSummary, err := summarizeGenesis(base)
+	if err != nil {
+		return GenesisDiff{}, fmt.Errorf("cannot read the base genesis: %w", err)
+	}
+	finalizedSummary, err := summarizeGenesis(finalized)
+	if err != nil {
+		return GenesisDiff{}, fmt.Errorf("cannot read the finalized genesis: %w", err)
+	}
+
+	diff := GenesisDiff{
+		AccountsAdded:   added(baseSummary.accounts, finalizedSummary.accounts),
+		ValidatorsAdded: added(baseSummary.validators, finalizedSummary.validators),
+	}
+
+	paths := make([]string, 0, len(finalizedSummary.params))
+	for path := range finalizedSummary.params {
+		paths = append(paths, path)
+	}
+	for path := range baseSummary.params {
+		if _, ok := finalizedSummary.params[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		before, after := baseSummary.params[path], finalizedSummary.params[path]
+		if before != after {
+			diff.ParamsChanged = append(diff.ParamsChanged, ParamChange{
+				Path:   path,
+				Before: before,
+				After:  after,
+			})
+		}
+	}
+
+	return diff, nil
+}
+
+// added returns the sorted elements of finalized that are not in base.
+func added(base, finalized []string) (list []string) {
+	baseSet := make(map[string]bool, len(base))
+	for _, e := range base {
+		baseSet[e] = true
+	}
+	for _, e := range finalized {
+		if !baseSet[e] {
+			list = append(list, e)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// summarizeGenesis reads the accounts, the validators and the params of the genesis.
+func summarizeGenesis(genesis []byte) (genesisSummary, error) {
+	var g struct {
+		ConsensusParams json.RawMessage            `json:"consensus_params"`
+		AppState        map[string]json.RawMessage `json:"app_state"`
+	}
+	if err := json.Unmarshal(genesis, &g); err != nil {
+		return genesisSummary{}, err
+	}
+
+	s := genesisSummary{
+		params: make(map[string]string),
+	}
+
+	var auth struct {
+		Accounts []json.RawMessage `json:"accounts"`
+	}
+	if err := unmarshalModule(g.AppState, "auth", &auth); err != nil {
+		return s, err
+	}
+	for _, account := range auth.Accounts {
+		if address := findAddress(account); address != "" {
+			s.accounts = append(s.accounts, address)
+		}
+	}
+
+	var genutil struct {
+		GenTxs []struct {
+			Body struct {
+				Messages []struct {
+					Type             string `json:"@type"`
+					ValidatorAddress string `json:"validator_address"`
+					Description      struct {
+						Moniker string `json:"moniker"`
+					} `json:"description"`
+				} `json:"messages"`
+			} `json:"body"`
+		} `json:"gen_txs"`
+	}
+	if err := unmarshalModule(g.AppState, "genutil", &genutil); err != nil {
+		return s, err
+	}
+	for _, gentx := range genutil.GenTxs {
+		for _, msg := range gentx.Body.Messages {
+			if msg.Type != msgCreateValidatorType {
+				continue
+			}
+			validator := msg.ValidatorAddress
+			if msg.Description.Moniker != "" {
+				validator = fmt.Sprintf("%s (%s)", msg.Description.Moniker, msg.ValidatorAddress)
+			}
+			s.validators = append(s.validators, validator)
+		}
+	}
+
+	if len(g.ConsensusParams) > 0 {
+		flattenParams("consensus_params", g.ConsensusParams, s.params)
+	}
+	for module, state := range g.AppState {
+		var moduleState struct {
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(state, &moduleState); err != nil || len(moduleState.Params) == 0 {
+			continue
+		}
+		flattenParams(module+".params", moduleState.Params, s.params)
+	}
+
+	return s, nil
+}
+
+// unmarshalModule unmarshals the state of the module in the app state, the module is skipped when
+// it's not in the genesis.
+func unmarshalModule(appState map[string]json.RawMessage, module string, v interface{}) error {
+	state, ok := appState[module]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(state, v); err != nil {
+		return fmt.Errorf("invalid %s state: %w", module, err)
+	}
+	return nil
+}
+
+// findAddress returns the address of the account, vesting accounts keep the address in their
+// nested base accounts.
+func findAddress(account json.RawMessage) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(account, &fields); err != nil {
+		return ""
+	}
+	if rawAddress, ok := fields["address"]; ok {
+		var address string
+		if err := json.Unmarshal(rawAddress, &address); err == nil {
+			return address
+		}
+	}
+	for _, key := range []string{"base_vesting_account", "base_account"} {
+		if nested, ok := fields[key]; ok {
+			return findAddress(nested)
+		}
+	}
+	return ""
+}
+
+// flattenParams adds the leaf values of the params to flat by their paths.
+func flattenParams(path string, params json.RawMessage, flat map[string]string) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(params, &fields); err != nil {
+		// not an object, the params are a value.
+		flat[path] = string(params)
+		return
+	}
+	for key, value := range fields {
+		flattenParams(path+"."+key, value, flat)
+	}
+}
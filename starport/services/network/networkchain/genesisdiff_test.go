@@ -0,0 +1,67 @@
+package networkchain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const baseGenesis = `{
+  "consensus_params": {"block": {"max_gas": "-1"}},
+  "app_state": {
+    "auth": {"accounts": [{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": "cosmos1a"}]},
+    "genutil": {"gen_txs": []},
+    "staking": {"params": {"bond_denom": "stake", "max_validators": 100}}
+  }
+}`
+
+const finalizedGenesis = `{
+  "consensus_params": {"block": {"max_gas": "-1"}},
+  "app_state": {
+    "auth": {"accounts": [
+      {"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": "cosmos1a"},
+      {"@type": "/cosmos.vesting.v1beta1.DelayedVestingAccount", "base_vesting_account": {"base_account": {"address": "cosmos1c"}}},
+      {"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": "cosmos1b"}
+    ]},
+    "genutil": {"gen_txs": [{"body": {"messages": [
+      {"@type": "/cosmos.staking.v1beta1.MsgCreateValidator", "validator_address": "cosmosvaloper1a", "description": {"moniker": "alice"}}
+    ]}}]},
+    "staking": {"params": {"bond_denom": "stake", "max_validators": 50}}
+  }
+}`
+
+func TestDiffGenesis(t *testing.T) {
+	diff, err := DiffGenesis([]byte(baseGenesis), []byte(finalizedGenesis))
+	require.NoError(t, err)
+	require.Equal(t, GenesisDiff{
+		AccountsAdded:   []string{"cosmos1b", "cosmos1c"},
+		ValidatorsAdded: []string{"alice (cosmosvaloper1a)"},
+		ParamsChanged: []ParamChange{
+			{Path: "staking.params.max_validators", Before: "100", After: "50"},
+		},
+	}, diff)
+
+	var b strings.Builder
+	_, err = diff.WriteTo(&b)
+	require.NoError(t, err)
+	require.Equal(t, `Accounts added:
+  + cosmos1b
+  + cosmos1c
+Validators added:
+  + alice (cosmosvaloper1a)
+Params changed:
+  ~ staking.params.max_validators: 100 -> 50
+`, b.String())
+}
+
+func TestDiffGenesisNoChange(t *testing.T) {
+	diff, err := DiffGenesis([]byte(baseGenesis), []byte(baseGenesis))
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty())
+}
+
+func TestDiffGenesisInvalid(t *testing.T) {
+	_, err := DiffGenesis([]byte(baseGenesis), []byte("{"))
+	require.Error(t, err)
+}
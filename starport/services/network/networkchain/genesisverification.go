@@ -0,0 +1,71 @@
+package networkchain
+
+import (
+	"fmt"
+)
+
+// GenesisVerificationStatus is the result of the verification of a genesis fetched from a URL.
+type GenesisVerificationStatus string
+
+const (
+	// GenesisVerified is the status of a genesis matching the hash published for the chain.
+	GenesisVerified GenesisVerificationStatus = "verified"
+
+	// GenesisNotPublished is the status of a genesis whose hash is not published for the chain,
+	// the genesis can't be verified.
+	GenesisNotPublished GenesisVerificationStatus = "not-published"
+
+	// GenesisMismatchIgnored is the status of a genesis not matching the hash published for the
+	// chain that is used anyway because the verification is skipped.
+	GenesisMismatchIgnored GenesisVerificationStatus = "mismatch-ignored"
+)
+
+// GenesisVerification describes the verification of a genesis fetched from a URL against the hash
+// published for the chain.
+type GenesisVerification struct {
+	URL          string                    `yaml:"url"`
+	ExpectedHash string                    `yaml:"expected_hash"`
+	Hash         string                    `yaml:"hash"`
+	Status       GenesisVerificationStatus `yaml:"status"`
+}
+
+// GenesisHashMismatchError is returned when a genesis fetched from a URL doesn't match the hash
+// published for the chain.
+type GenesisHashMismatchError struct {
+	URL          string
+	ExpectedHash string
+	Hash         string
+}
+
+func (e *GenesisHashMismatchError) Error() string {
+	return fmt.Sprintf(`the genesis fetched from %s doesn't match the genesis published for the chain:
+  expected sha256: %s
+  actual sha256:   %s
+the genesis served at the URL has changed since the chain was published, ask the coordinator for the published genesis`,
+		e.URL,
+		e.ExpectedHash,
+		e.Hash,
+	)
+}
+
+// verifyGenesisHash verifies the hash of the genesis fetched from url against the expected hash
+// published for the chain. a mismatch is only an error when the verification is not skipped.
+func verifyGenesisHash(url, expectedHash, hash string, skip bool) (GenesisVerification, error) {
+	v := GenesisVerification{
+		URL:          url,
+		ExpectedHash: expectedHash,
+		Hash:         hash,
+	}
+
+	switch {
+	case expectedHash == "":
+		v.Status = GenesisNotPublished
+	case expectedHash == hash:
+		v.Status = GenesisVerified
+	case skip:
+		v.Status = GenesisMismatchIgnored
+	default:
+		return v, &GenesisHashMismatchError{URL: url, ExpectedHash: expectedHash, Hash: hash}
+	}
+	return v, nil
+}
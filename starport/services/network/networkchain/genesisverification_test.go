@@ -0,0 +1,31 @@
+package networkchain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyGenesisHash(t *testing.T) {
+	const url = "https://example.com/genesis.json"
+
+	v, err := verifyGenesisHash(url, "abc", "abc", false)
+	require.NoError(t, err)
+	require.Equal(t, GenesisVerified, v.Status)
+
+	v, err = verifyGenesisHash(url, "", "abc", false)
+	require.NoError(t, err)
+	require.Equal(t, GenesisNotPublished, v.Status)
+
+	v, err = verifyGenesisHash(url, "abc", "def", true)
+	require.NoError(t, err)
+	require.Equal(t, GenesisMismatchIgnored, v.Status)
+
+	_, err = verifyGenesisHash(url, "abc", "def", false)
+	var mismatchErr *GenesisHashMismatchError
+	require.True(t, errors.As(err, &mismatchErr))
+	require.Equal(t, "abc", mismatchErr.ExpectedHash)
+	require.Equal(t, "def", mismatchErr.Hash)
+	require.Contains(t, err.Error(), "expected sha256: abc")
+}
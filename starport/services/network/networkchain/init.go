@@ -7,6 +7,7 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/cosmosutil"
 	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/cachemanager"
 )
 
 // Init initializes blockchain by building the binaries and running the init command and
@@ -23,12 +24,10 @@ func (c *Chain) Init(ctx context.Context) error {
 	}
 
 	// build the chain and initialize it with a new validator key
-	c.ev.Send(events.New(events.StatusOngoing, "Building the blockchain"))
-	if _, err := c.chain.Build(ctx, ""); err != nil {
+	if err := c.build(ctx); err != nil {
 		return err
 	}
 
-	c.ev.Send(events.New(events.StatusDone, "Blockchain built"))
 	c.ev.Send(events.New(events.StatusOngoing, "Initializing the blockchain"))
 
 	if err := c.chain.Init(ctx, false); err != nil {
@@ -62,17 +61,33 @@ func (c *Chain) initGenesis(ctx context.Context) error {
 	// if the blockchain has a genesis URL, the initial genesis is fetched from the URL
 	// otherwise, the default genesis is used, which requires no action since the default genesis is generated from the init command
 	if c.genesisURL != "" {
-		genesis, hash, err := cosmosutil.GenesisAndHashFromURL(ctx, c.genesisURL)
+		c.ev.Send(events.New(events.StatusOngoing, "Fetching the genesis"))
+		genesis, hash, err := cosmosutil.GenesisAndHashFromURL(
+			ctx,
+			c.genesisURL,
+			cosmosutil.WithGenesisFetchProgress(c.genesisFetchProgress),
+		)
 		if err != nil {
 			return err
 		}
+		c.ev.Send(events.New(events.StatusDone, "Genesis fetched"))
 
 		// if the blockchain has been initialized with no genesis hash, we assign the fetched hash to it
 		// otherwise we check the genesis integrity with the existing hash
+		verification, err := verifyGenesisHash(c.genesisURL, c.genesisHash, hash, c.skipGenesisVerification)
+		if err != nil {
+			return err
+		}
+		if verification.Status == GenesisMismatchIgnored {
+			c.ev.Send(events.New(events.StatusDone, fmt.Sprintf(
+				"Genesis hash %s doesn't match the published hash %s, the verification is skipped",
+				hash,
+				c.genesisHash,
+			)))
+		}
+		c.genesisVerification = &verification
 		if c.genesisHash == "" {
 			c.genesisHash = hash
-		} else if hash != c.genesisHash {
-			return fmt.Errorf("genesis from URL %s is invalid. Expected hash %s, actual hash %s", c.genesisURL, c.genesisHash, hash)
 		}
 
 		// replace the default genesis with the fetched genesis
@@ -111,3 +126,12 @@ func (c *Chain) checkGenesis(ctx context.Context) error {
 	// example: gentxs formats are not checked
 	// to perform a full validity check of the genesis we must try to start the chain with sample accounts
 }
+
+// genesisFetchProgress sends the progress of the genesis download.
+func (c *Chain) genesisFetchProgress(read, total int64) {
+	progress := cachemanager.FormatSize(read)
+	if total > 0 {
+		progress = fmt.Sprintf("%s/%s", progress, cachemanager.FormatSize(total))
+	}
+	c.ev.Send(events.New(events.StatusOngoing, fmt.Sprintf("Fetching the genesis (%s)", progress)))
+}
@@ -3,16 +3,20 @@ package networkchain
 import (
 	"context"
 	"os"
+	"path/filepath"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
 
+	"github.com/tendermint/starport/starport/chainconfig"
 	sperrors "github.com/tendermint/starport/starport/errors"
 	"github.com/tendermint/starport/starport/pkg/chaincmd"
 	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
 	"github.com/tendermint/starport/starport/pkg/cosmosver"
 	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/pkg/gitpod"
+	"github.com/tendermint/starport/starport/services/cachemanager"
 	"github.com/tendermint/starport/starport/services/chain"
 	"github.com/tendermint/starport/starport/services/network/networktypes"
 )
@@ -25,13 +29,19 @@ type Chain struct {
 	home string
 
 	url         string
+	sourcePath  string
 	hash        string
 	genesisURL  string
 	genesisHash string
 	launchTime  int64
 
+	skipGenesisVerification bool
+	genesisVerification     *GenesisVerification
+
 	keyringBackend chaincmd.KeyringBackend
 
+	binaryDownload *BinaryDownload
+
 	isInitialized bool
 
 	ref plumbing.ReferenceName
@@ -78,11 +88,28 @@ func SourceRemoteHash(url, hash string) SourceOption {
 	}
 }
 
+// SourceRemoteRef uses a remote ref as source for the blockchain, the ref is a commit hash, a tag
+// or a branch and it's resolved to the hash of its commit.
+func SourceRemoteRef(url, ref string) SourceOption {
+	return func(c *Chain) {
+		c.url = url
+		c.hash = ref
+	}
+}
+
+// SourceLocal uses the local directory at path as source for the blockchain, the source isn't
+// fetched. it's used by coordinators launching their blockchains without SPN.
+func SourceLocal(path string) SourceOption {
+	return func(c *Chain) {
+		c.path = path
+	}
+}
+
 // SourceLaunch returns a source option for initializing a chain from a launch
 func SourceLaunch(launch networktypes.ChainLaunch) SourceOption {
 	return func(c *Chain) {
 		c.id = launch.ChainID
-		c.url = launch.SourceURL
+		c.url, c.sourcePath = SplitSourceURL(launch.SourceURL)
 		c.hash = launch.SourceHash
 		c.genesisURL = launch.GenesisURL
 		c.genesisHash = launch.GenesisHash
@@ -98,6 +125,14 @@ func WithHome(path string) Option {
 	}
 }
 
+// WithSourcePath sets the path of the blockchain in its repository, it's used when the blockchain
+// isn't at the root of a monorepo.
+func WithSourcePath(path string) Option {
+	return func(c *Chain) {
+		c.sourcePath = path
+	}
+}
+
 // WithKeyringBackend provides the keyring backend to use to initialize the blockchain
 func WithKeyringBackend(keyringBackend chaincmd.KeyringBackend) Option {
 	return func(c *Chain) {
@@ -112,6 +147,21 @@ func WithGenesisFromURL(genesisURL string) Option {
 	}
 }
 
+// WithoutGenesisVerification uses the genesis fetched from the genesis URL even when it doesn't
+// match the genesis hash published for the chain.
+func WithoutGenesisVerification() Option {
+	return func(c *Chain) {
+		c.skipGenesisVerification = true
+	}
+}
+
+// WithLaunchTime sets the genesis time of the blockchain as a unix timestamp.
+func WithLaunchTime(launchTime int64) Option {
+	return func(c *Chain) {
+		c.launchTime = launchTime
+	}
+}
+
 // CollectEvents collects events from the chain.
 func CollectEvents(ev events.Bus) Option {
 	return func(c *Chain) {
@@ -129,14 +179,27 @@ func New(ctx context.Context, ar cosmosaccount.Registry, source SourceOption, op
 		apply(c)
 	}
 
-	c.ev.Send(events.New(events.StatusOngoing, "Fetching the source code"))
-
 	var err error
-	if c.path, c.hash, err = fetchSource(ctx, c.url, c.ref, c.hash); err != nil {
+	if c.sourcePath, err = cleanSourcePath(c.sourcePath); err != nil {
 		return nil, err
 	}
 
-	c.ev.Send(events.New(events.StatusDone, "Source code fetched"))
+	// the source is fetched unless it's local.
+	if c.path == "" {
+		c.ev.Send(events.New(events.StatusOngoing, "Fetching the source code"))
+
+		if c.path, c.hash, err = fetchSource(ctx, c.url, c.ref, c.hash); err != nil {
+			return nil, err
+		}
+		if c.sourcePath != "" {
+			c.path = filepath.Join(c.path, filepath.FromSlash(c.sourcePath))
+			if _, err := os.Stat(c.path); err != nil {
+				return nil, errors.Wrapf(err, "cannot find the blockchain at %s in the repository", c.sourcePath)
+			}
+		}
+
+		c.ev.Send(events.New(events.StatusDone, "Source code fetched"))
+	}
 	c.ev.Send(events.New(events.StatusOngoing, "Setting up the blockchain"))
 
 	chainOption := []chain.Option{
@@ -204,8 +267,10 @@ func (c Chain) ConfigTOMLPath() (string, error) {
 	return c.chain.ConfigTOMLPath()
 }
 
+// SourceURL returns the URL of the blockchain's source, the path of the blockchain in its
+// repository is joined to the URL of the repository when it's set.
 func (c Chain) SourceURL() string {
-	return c.url
+	return JoinSourceURL(c.url, c.sourcePath)
 }
 
 func (c Chain) SourceHash() string {
@@ -239,7 +304,8 @@ func (c Chain) NodeID(ctx context.Context) (string, error) {
 	return nodeID, nil
 }
 
-// fetchSource fetches the chain source from url and returns a temporary path where source is saved
+// fetchSource fetches the chain source from url and returns the path where source is saved, the
+// sources are saved in the sources cache of Starport to be collected by its GC.
 func fetchSource(
 	ctx context.Context,
 	url string,
@@ -248,12 +314,17 @@ func fetchSource(
 ) (path, hash string, err error) {
 	var repo *git.Repository
 
-	if path, err = os.MkdirTemp("", ""); err != nil {
+	configDir, err := chainconfig.ConfigDirPath()
+	if err != nil {
 		return "", "", err
 	}
+	sourcesDir := cachemanager.SourcesPath(configDir)
 
-	// ensure the path for chain source exists
-	if err := os.MkdirAll(path, 0755); err != nil {
+	// ensure the path for chain sources exists
+	if err := os.MkdirAll(sourcesDir, 0755); err != nil {
+		return "", "", err
+	}
+	if path, err = os.MkdirTemp(sourcesDir, ""); err != nil {
 		return "", "", err
 	}
 
@@ -272,24 +343,23 @@ func fetchSource(
 	}
 
 	if customHash != "" {
-		hash = customHash
-
 		// checkout to a certain hash when specified. this is used by validators to make sure to use
-		// the locked version of the blockchain.
+		// the locked version of the blockchain. the hash can also be a tag or a branch resolved to
+		// the hash of its commit.
 		wt, err := repo.Worktree()
 		if err != nil {
 			return "", "", err
 		}
-		h, err := repo.ResolveRevision(plumbing.Revision(customHash))
+		githash, err := resolveRevision(repo, customHash)
 		if err != nil {
 			return "", "", err
 		}
-		githash := *h
 		if err := wt.Checkout(&git.CheckoutOptions{
 			Hash: githash,
 		}); err != nil {
 			return "", "", err
 		}
+		hash = githash.String()
 	} else {
 		// when no specific hash is provided. HEAD is fetched
 		ref, err := repo.Head()
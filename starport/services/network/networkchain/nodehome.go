@@ -0,0 +1,105 @@
+package networkchain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/otiai10/copy"
+	"github.com/pelletier/go-toml"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+// nodeKeyFiles are the files of a node's home that identify the node and its validator.
+var nodeKeyFiles = []string{
+	"config/node_key.json",
+	"config/priv_validator_key.json",
+}
+
+// NodeHome is the config of an existing node's home.
+type NodeHome struct {
+	// Path of the home.
+	Path string
+
+	// Moniker of the node.
+	Moniker string
+
+	// ExternalAddress is the address that the node advertises to its peers, it's empty when the
+	// node doesn't set it.
+	ExternalAddress string
+
+	// ListenAddress is the address that the node listens to its peers at.
+	ListenAddress string
+}
+
+// ReadNodeHome reads the existing node's home at path, the home must have the keys of the node
+// and its validator.
+func ReadNodeHome(path string) (NodeHome, error) {
+	for _, file := range nodeKeyFiles {
+		if _, err := os.Stat(filepath.Join(path, file)); err != nil {
+			return NodeHome{}, fmt.Errorf("cannot read the node home %s: %w", path, err)
+		}
+	}
+
+	configToml, err := toml.LoadFile(filepath.Join(path, "config/config.toml"))
+	if err != nil {
+		return NodeHome{}, err
+	}
+
+	home := NodeHome{Path: path}
+	home.Moniker, _ = configToml.Get("moniker").(string)
+	home.ExternalAddress, _ = configToml.Get("p2p.external_address").(string)
+	home.ListenAddress, _ = configToml.Get("p2p.laddr").(string)
+
+	return home, nil
+}
+
+// InitFromNodeHome initializes the chain with the keys of the existing node's home and issues the
+// gentx of the validator in config/gentx/gentx.json. the validator gets the moniker of the node
+// when it has none.
+func (c *Chain) InitFromNodeHome(ctx context.Context, home NodeHome, v chain.Validator, accountName string) (string, error) {
+	if err := c.Init(ctx); err != nil {
+		return "", err
+	}
+
+	c.ev.Send(events.New(events.StatusOngoing, "Importing the node keys"))
+
+	chainHome, err := c.chain.Home()
+	if err != nil {
+		return "", err
+	}
+	for _, file := range nodeKeyFiles {
+		if err := copy.Copy(filepath.Join(home.Path, file), filepath.Join(chainHome, file)); err != nil {
+			return "", err
+		}
+	}
+
+	if v.Moniker == "" {
+		v.Moniker = home.Moniker
+	}
+
+	configPath, err := c.chain.ConfigTOMLPath()
+	if err != nil {
+		return "", err
+	}
+	configToml, err := toml.LoadFile(configPath)
+	if err != nil {
+		return "", err
+	}
+	if v.Moniker != "" {
+		configToml.Set("moniker", v.Moniker)
+	}
+	if home.ExternalAddress != "" {
+		configToml.Set("p2p.external_address", home.ExternalAddress)
+	}
+	if err := os.WriteFile(configPath, []byte(configToml.String()), 0644); err != nil {
+		return "", err
+	}
+
+	c.ev.Send(events.New(events.StatusDone, "Node keys imported"))
+
+	return c.InitAccount(ctx, v, accountName)
+}
@@ -18,8 +18,57 @@ import (
 	"github.com/tendermint/starport/starport/services/network/networktypes"
 )
 
-// Prepare prepares the chain to be launched from genesis information
-func (c Chain) Prepare(ctx context.Context, gi networktypes.GenesisInformation) error {
+// prepareOptions holds info about how to prepare the chain for launch.
+type prepareOptions struct {
+	seeds     []string
+	addrBook  bool
+	stateSync *StateSync
+	snapshots *Snapshots
+}
+
+// PrepareOption configures the preparation of the chain for launch.
+type PrepareOption func(*prepareOptions)
+
+// WithSeeds designates the genesis validators with the addresses as seed nodes, their peers are
+// added to the seeds of the node instead of its persistent peers.
+func WithSeeds(validatorAddresses ...string) PrepareOption {
+	return func(o *prepareOptions) {
+		o.seeds = append(o.seeds, validatorAddresses...)
+	}
+}
+
+// WithAddrBook generates the address book of the node from the peers of the genesis validators.
+func WithAddrBook() PrepareOption {
+	return func(o *prepareOptions) {
+		o.addrBook = true
+	}
+}
+
+// WithStateSync enables state sync for the node to join the launched chain from a snapshot of its
+// state instead of replaying its blocks.
+func WithStateSync(s StateSync) PrepareOption {
+	return func(o *prepareOptions) {
+		o.stateSync = &s
+	}
+}
+
+// WithSnapshots enables the snapshots of the state for the node to provide them to the nodes
+// joining the chain with state sync.
+func WithSnapshots(s Snapshots) PrepareOption {
+	return func(o *prepareOptions) {
+		o.snapshots = &s
+	}
+}
+
+// Prepare prepares the chain to be launched from genesis information. the difference between the
+// base genesis and the finalized genesis is written to the report at PrepareReportPath along with
+// the SPN block height of the genesis information.
+func (c Chain) Prepare(ctx context.Context, gi networktypes.GenesisInformation, options ...PrepareOption) error {
+	var o prepareOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
 	// chain initialization
 	chainHome, err := c.chain.Home()
 	if err != nil {
@@ -38,11 +87,9 @@ func (c Chain) Prepare(ctx context.Context, gi networktypes.GenesisInformation)
 		return err
 	default:
 		// if config and validator key already exists, build the chain and initialize the genesis
-		c.ev.Send(events.New(events.StatusOngoing, "Building the blockchain"))
-		if _, err := c.chain.Build(ctx, ""); err != nil {
+		if err := c.build(ctx); err != nil {
 			return err
 		}
-		c.ev.Send(events.New(events.StatusDone, "Blockchain build complete"))
 
 		c.ev.Send(events.New(events.StatusOngoing, "Initializing the genesis"))
 		if err := c.initGenesis(ctx); err != nil {
@@ -51,7 +98,16 @@ func (c Chain) Prepare(ctx context.Context, gi networktypes.GenesisInformation)
 		c.ev.Send(events.New(events.StatusDone, "Genesis initialized"))
 	}
 
-	if err := c.buildGenesis(ctx, gi); err != nil {
+	genesisPath, err := c.chain.GenesisPath()
+	if err != nil {
+		return err
+	}
+	baseGenesis, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return errors.Wrap(err, "base genesis of the blockchain can't be read")
+	}
+
+	if err := c.buildGenesis(ctx, gi, o); err != nil {
 		return err
 	}
 
@@ -65,12 +121,73 @@ func (c Chain) Prepare(ctx context.Context, gi networktypes.GenesisInformation)
 		return err
 	}
 
+	if err := c.writePrepareReport(gi, baseGenesis, genesisPath); err != nil {
+		return err
+	}
+
+	if o.stateSync != nil {
+		configPath, err := c.chain.ConfigTOMLPath()
+		if err != nil {
+			return err
+		}
+		if _, err := ApplyStateSync(ctx, configPath, *o.stateSync); err != nil {
+			return errors.Wrap(err, "error enabling state sync")
+		}
+	}
+	if o.snapshots != nil {
+		appPath, err := c.chain.AppTOMLPath()
+		if err != nil {
+			return err
+		}
+		if err := applySnapshots(appPath, *o.snapshots); err != nil {
+			return errors.Wrap(err, "error enabling snapshots")
+		}
+	}
+
 	// reset the saved state in case the chain has been started before
 	return cmd.UnsafeReset(ctx)
 }
 
+// writePrepareReport writes the report of the genesis built from the genesis information.
+func (c Chain) writePrepareReport(gi networktypes.GenesisInformation, baseGenesis []byte, genesisPath string) error {
+	genesis, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return errors.Wrap(err, "genesis of the blockchain can't be read")
+	}
+
+	diff, err := DiffGenesis(baseGenesis, genesis)
+	if err != nil {
+		return err
+	}
+
+	reportPath, err := c.PrepareReportPath()
+	if err != nil {
+		return err
+	}
+
+	chainID, err := c.ID()
+	if err != nil {
+		return err
+	}
+
+	binary, err := c.BinaryPath()
+	if err != nil {
+		return err
+	}
+
+	return savePrepareReport(PrepareReport{
+		ChainID:             chainID,
+		Binary:              binary,
+		SPNHeight:           gi.Height,
+		BaseGenesisHash:     genesisHash(baseGenesis),
+		GenesisHash:         genesisHash(genesis),
+		GenesisVerification: c.genesisVerification,
+		Diff:                diff,
+	}, reportPath)
+}
+
 // buildGenesis builds the genesis for the chain from the launch approved requests
-func (c Chain) buildGenesis(ctx context.Context, gi networktypes.GenesisInformation) error {
+func (c Chain) buildGenesis(ctx context.Context, gi networktypes.GenesisInformation, o prepareOptions) error {
 	c.ev.Send(events.New(events.StatusOngoing, "Building the genesis"))
 
 	addressPrefix, err := c.detectPrefix(ctx)
@@ -85,7 +202,7 @@ func (c Chain) buildGenesis(ctx context.Context, gi networktypes.GenesisInformat
 	if err := c.applyVestingAccounts(ctx, gi.VestingAccounts, addressPrefix); err != nil {
 		return errors.Wrap(err, "error applying vesting accounts to genesis")
 	}
-	if err := c.applyGenesisValidators(ctx, gi.GenesisValidators); err != nil {
+	if err := c.applyGenesisValidators(ctx, gi.GenesisValidators, o); err != nil {
 		return errors.Wrap(err, "error applying genesis validators to genesis")
 	}
 
@@ -167,7 +284,11 @@ func (c Chain) applyVestingAccounts(
 }
 
 // applyGenesisValidators gathers the validator gentxs into the genesis and adds peers in config
-func (c Chain) applyGenesisValidators(ctx context.Context, genesisVals []networktypes.GenesisValidator) error {
+func (c Chain) applyGenesisValidators(
+	ctx context.Context,
+	genesisVals []networktypes.GenesisValidator,
+	o prepareOptions,
+) error {
 	// no validator
 	if len(genesisVals) == 0 {
 		return nil
@@ -202,13 +323,28 @@ func (c Chain) applyGenesisValidators(ctx context.Context, genesisVals []network
 		return err
 	}
 
-	return c.updateConfigFromGenesisValidators(genesisVals)
+	return c.updateConfigFromGenesisValidators(genesisVals, o)
 }
 
-// updateConfigFromGenesisValidators adds the peer addresses into the config.toml of the chain
-func (c Chain) updateConfigFromGenesisValidators(genesisVals []networktypes.GenesisValidator) error {
+// updateConfigFromGenesisValidators adds the peer addresses into the config.toml of the chain, the
+// peers of the validators designated as seeds are added as seeds and the others as persistent peers.
+func (c Chain) updateConfigFromGenesisValidators(genesisVals []networktypes.GenesisValidator, o prepareOptions) error {
+	validators := make(map[string]bool, len(genesisVals))
+	for _, val := range genesisVals {
+		validators[val.Address] = true
+	}
+	seeds := make(map[string]bool, len(o.seeds))
+	for _, address := range o.seeds {
+		if !validators[address] {
+			return errors.Errorf("seed %s is not a genesis validator", address)
+		}
+		seeds[address] = true
+	}
+
 	var (
 		p2pAddresses    []string
+		seedAddresses   []string
+		peerAddresses   []string
 		tunnelAddresses []TunneledPeer
 	)
 	for i, val := range genesisVals {
@@ -230,6 +366,12 @@ func (c Chain) updateConfigFromGenesisValidators(genesisVals []networktypes.Gene
 		default:
 			return fmt.Errorf("invalid peer type")
 		}
+
+		if address := p2pAddresses[len(p2pAddresses)-1]; seeds[val.Address] {
+			seedAddresses = append(seedAddresses, address)
+		} else {
+			peerAddresses = append(peerAddresses, address)
+		}
 	}
 
 	if len(p2pAddresses) > 0 {
@@ -242,10 +384,8 @@ func (c Chain) updateConfigFromGenesisValidators(genesisVals []networktypes.Gene
 		if err != nil {
 			return err
 		}
-		configToml.Set("p2p.persistent_peers", strings.Join(p2pAddresses, ","))
-		if err != nil {
-			return err
-		}
+		configToml.Set("p2p.persistent_peers", strings.Join(peerAddresses, ","))
+		configToml.Set("p2p.seeds", strings.Join(seedAddresses, ","))
 
 		// if there are tunneled peers they will be connected with tunnel clients via localhost,
 		// so we need to allow to have few nodes with the same ip
@@ -263,6 +403,16 @@ func (c Chain) updateConfigFromGenesisValidators(genesisVals []networktypes.Gene
 		if _, err = configToml.WriteTo(configTomlFile); err != nil {
 			return err
 		}
+
+		if o.addrBook {
+			addrBookPath, err := c.AddrBookPath()
+			if err != nil {
+				return err
+			}
+			if err := writeAddrBook(addrBookPath, p2pAddresses); err != nil {
+				return errors.Wrap(err, "error generating the address book")
+			}
+		}
 	}
 
 	if len(tunnelAddresses) > 0 {
@@ -0,0 +1,63 @@
+package networkchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+
+	"github.com/tendermint/starport/starport/pkg/confile"
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+)
+
+// PrepareReportFile is the file in the config dir of the chain that the report of the last
+// genesis preparation is written to.
+const PrepareReportFile = "prepare-report.yml"
+
+// PrepareReport describes how the finalized genesis of the chain is prepared, so the genesis
+// can be reproduced and verified by the other validators.
+type PrepareReport struct {
+	ChainID string `yaml:"chain_id"`
+
+	// Binary is the path of the binary of the chain that the genesis is prepared with.
+	Binary string `yaml:"binary,omitempty"`
+
+	// SPNHeight is the block height of SPN that the genesis information is queried at.
+	SPNHeight int64 `yaml:"spn_height"`
+
+	// BaseGenesisHash and GenesisHash are the sha256 hashes of the base genesis and the
+	// finalized genesis.
+	BaseGenesisHash string `yaml:"base_genesis_hash"`
+	GenesisHash     string `yaml:"genesis_hash"`
+
+	// GenesisVerification is the verification of the base genesis against the hash published for
+	// the chain, it's nil when the base genesis is not fetched from a URL.
+	GenesisVerification *GenesisVerification `yaml:"genesis_verification,omitempty"`
+
+	Diff GenesisDiff `yaml:"diff"`
+}
+
+// PrepareReportPath returns the path of the report of the last genesis preparation.
+func (c Chain) PrepareReportPath() (string, error) {
+	home, err := c.chain.Home()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, cosmosutil.ChainConfigDir, PrepareReportFile), nil
+}
+
+// LoadPrepareReport loads the report of a genesis preparation from path.
+func LoadPrepareReport(path string) (report PrepareReport, err error) {
+	err = confile.New(confile.DefaultYAMLEncodingCreator, path).Load(&report)
+	return
+}
+
+// savePrepareReport saves the report of a genesis preparation to path.
+func savePrepareReport(report PrepareReport, path string) error {
+	return confile.New(confile.DefaultYAMLEncodingCreator, path).Save(report)
+}
+
+// genesisHash returns the sha256 hash of the genesis.
+func genesisHash(genesis []byte) string {
+	h := sha256.Sum256(genesis)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,267 @@
+package networkchain
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
+)
+
+// ServiceFormat is the format of the service file that supervises the node of a chain.
+type ServiceFormat string
+
+const (
+	// ServiceFormatSystemd is a systemd unit for Linux.
+	ServiceFormatSystemd ServiceFormat = "systemd"
+
+	// ServiceFormatLaunchd is a launchd property list for macOS.
+	ServiceFormatLaunchd ServiceFormat = "launchd"
+)
+
+// ParseServiceFormat parses the format of a service file.
+func ParseServiceFormat(format string) (ServiceFormat, error) {
+	switch f := ServiceFormat(format); f {
+	case ServiceFormatSystemd, ServiceFormatLaunchd:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown service format %q, expected %s or %s", format, ServiceFormatSystemd, ServiceFormatLaunchd)
+}
+
+// DefaultServiceFormat returns the format of the service manager of the OS, launchd on macOS and
+// systemd otherwise.
+func DefaultServiceFormat() ServiceFormat {
+	if runtime.GOOS == "darwin" {
+		return ServiceFormatLaunchd
+	}
+	return ServiceFormatSystemd
+}
+
+// the restart policy of the nodes.
+const (
+	serviceRestartSec  = 3
+	serviceLimitNOFILE = 65535
+)
+
+// NodeService is the service that supervises the node of a launched chain, the node is restarted
+// when it fails.
+type NodeService struct {
+	// ChainID is the ID of the chain, the service is named after it.
+	ChainID string
+
+	// Binary is the absolute path of the binary of the chain.
+	Binary string
+
+	// Home is the home of the node.
+	Home string
+
+	// User is the user that runs the node, the user of the service manager when empty.
+	User string
+
+	// Cosmovisor is the absolute path of cosmovisor, the node is run by cosmovisor to be upgraded
+	// automatically when it's set.
+	Cosmovisor string
+}
+
+// NewNodeService returns the service of the node of the chain prepared in home, the binary and the
+// chain ID are read from the report of the preparation.
+func NewNodeService(home string) (NodeService, error) {
+	reportPath := filepath.Join(home, cosmosutil.ChainConfigDir, PrepareReportFile)
+	if _, err := os.Stat(reportPath); os.IsNotExist(err) {
+		return NodeService{}, fmt.Errorf("the chain is not prepared in %s, run \"starport network chain prepare\" first", home)
+	}
+	report, err := LoadPrepareReport(reportPath)
+	if err != nil {
+		return NodeService{}, err
+	}
+	if report.Binary == "" {
+		return NodeService{}, fmt.Errorf("the report of the preparation in %s has no binary, prepare the chain again", home)
+	}
+	return NodeService{
+		ChainID: report.ChainID,
+		Binary:  report.Binary,
+		Home:    home,
+	}, nil
+}
+
+// Name returns the name of the service, e.g. mars-1 for systemd and network.starport.mars-1 for launchd.
+func (s NodeService) Name(format ServiceFormat) string {
+	if format == ServiceFormatLaunchd {
+		return "network.starport." + s.ChainID
+	}
+	return s.ChainID
+}
+
+// DefaultPath returns the path that the service file is installed at, systemd units are system
+// services and launchd property lists are agents of the user.
+func (s NodeService) DefaultPath(format ServiceFormat) (string, error) {
+	if format == ServiceFormatLaunchd {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "Library", "LaunchAgents", s.Name(format)+".plist"), nil
+	}
+	return filepath.Join("/etc/systemd/system", s.Name(format)+".service"), nil
+}
+
+// LogPath returns the path of the logs of the node for the services that don't collect them, like launchd.
+func (s NodeService) LogPath() string {
+	return filepath.Join(s.Home, "node.log")
+}
+
+// Command returns the command that starts the node.
+func (s NodeService) Command() []string {
+	if s.Cosmovisor != "" {
+		return []string{s.Cosmovisor, "run", "start", "--home", s.Home}
+	}
+	return []string{s.Binary, "start", "--home", s.Home}
+}
+
+// Env returns the environment of the node, it configures cosmovisor.
+func (s NodeService) Env() []string {
+	if s.Cosmovisor == "" {
+		return nil
+	}
+	return []string{
+		"DAEMON_NAME=" + filepath.Base(s.Binary),
+		"DAEMON_HOME=" + s.Home,
+		"DAEMON_RESTART_AFTER_UPGRADE=true",
+		"DAEMON_ALLOW_DOWNLOAD_BINARIES=false",
+	}
+}
+
+// CosmovisorBinaryPath returns the path of the genesis binary of the chain in the cosmovisor dir of
+// the home, cosmovisor runs it until the first upgrade.
+func (s NodeService) CosmovisorBinaryPath() string {
+	return filepath.Join(s.Home, "cosmovisor", "genesis", "bin", filepath.Base(s.Binary))
+}
+
+// SetupCosmovisor copies the binary of the chain to the cosmovisor dir of the home.
+func (s NodeService) SetupCosmovisor() error {
+	binary, err := os.ReadFile(s.Binary)
+	if err != nil {
+		return err
+	}
+	return installBinary(s.CosmovisorBinaryPath(), binary)
+}
+
+// Write writes the service file in the format.
+func (s NodeService) Write(w io.Writer, format ServiceFormat) error {
+	tpl := systemdTemplate
+	if format == ServiceFormatLaunchd {
+		tpl = launchdTemplate
+	}
+	return tpl.Execute(w, struct {
+		NodeService
+		Label      string
+		Command    []string
+		Env        []string
+		LogPath    string
+		RestartSec int
+		LimitFiles int
+	}{
+		NodeService: s,
+		Label:       s.Name(format),
+		Command:     s.Command(),
+		Env:         s.Env(),
+		LogPath:     s.LogPath(),
+		RestartSec:  serviceRestartSec,
+		LimitFiles:  serviceLimitNOFILE,
+	})
+}
+
+var systemdTemplate = template.Must(template.New("systemd").Funcs(template.FuncMap{
+	"command": systemdCommand,
+}).Parse(`[Unit]
+Description={{.ChainID}} node
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+{{- if .User}}
+User={{.User}}
+{{- end}}
+ExecStart={{command .Command}}
+{{- range .Env}}
+Environment="{{.}}"
+{{- end}}
+Restart=on-failure
+RestartSec={{.RestartSec}}
+LimitNOFILE={{.LimitFiles}}
+
+[Install]
+WantedBy=multi-user.target
+`))
+
+// systemdCommand returns the command line of the args, the args with spaces or quotes are quoted.
+func systemdCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\"'\\") {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+var launchdTemplate = template.Must(template.New("launchd").Funcs(template.FuncMap{
+	"splitEnv": func(kv string) []string { return strings.SplitN(kv, "=", 2) },
+	"xml": func(s string) (string, error) {
+		var b strings.Builder
+		err := xml.EscapeText(&b, []byte(s))
+		return b.String(), err
+	},
+}).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+  <key>Label</key>
+  <string>{{xml .Label}}</string>
+{{- if .User}}
+  <key>UserName</key>
+  <string>{{xml .User}}</string>
+{{- end}}
+  <key>ProgramArguments</key>
+  <array>
+{{- range .Command}}
+    <string>{{xml .}}</string>
+{{- end}}
+  </array>
+{{- if .Env}}
+  <key>EnvironmentVariables</key>
+  <dict>
+{{- range .Env}}{{$kv := splitEnv .}}
+    <key>{{xml (index $kv 0)}}</key>
+    <string>{{xml (index $kv 1)}}</string>
+{{- end}}
+  </dict>
+{{- end}}
+  <key>RunAtLoad</key>
+  <true/>
+  <key>KeepAlive</key>
+  <dict>
+    <key>SuccessfulExit</key>
+    <false/>
+  </dict>
+  <key>ThrottleInterval</key>
+  <integer>{{.RestartSec}}</integer>
+  <key>SoftResourceLimits</key>
+  <dict>
+    <key>NumberOfFiles</key>
+    <integer>{{.LimitFiles}}</integer>
+  </dict>
+  <key>StandardOutPath</key>
+  <string>{{xml .LogPath}}</string>
+  <key>StandardErrorPath</key>
+  <string>{{xml .LogPath}}</string>
+</dict>
+</plist>
+`))
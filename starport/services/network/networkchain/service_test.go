@@ -0,0 +1,123 @@
+package networkchain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServiceFormat(t *testing.T) {
+	format, err := ParseServiceFormat("launchd")
+	require.NoError(t, err)
+	require.Equal(t, ServiceFormatLaunchd, format)
+
+	_, err = ParseServiceFormat("upstart")
+	require.EqualError(t, err, `unknown service format "upstart", expected systemd or launchd`)
+}
+
+func TestNodeServiceWriteSystemd(t *testing.T) {
+	s := NodeService{
+		ChainID: "mars-1",
+		Binary:  "/home/alice/go/bin/marsd",
+		Home:    "/home/alice/spn/1",
+		User:    "alice",
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, s.Write(&b, ServiceFormatSystemd))
+	require.Equal(t, `[Unit]
+Description=mars-1 node
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+User=alice
+ExecStart=/home/alice/go/bin/marsd start --home /home/alice/spn/1
+Restart=on-failure
+RestartSec=3
+LimitNOFILE=65535
+
+[Install]
+WantedBy=multi-user.target
+`, b.String())
+
+	s.User = ""
+	s.Home = "/home/alice/my chains/1"
+	s.Cosmovisor = "/home/alice/go/bin/cosmovisor"
+	b.Reset()
+	require.NoError(t, s.Write(&b, ServiceFormatSystemd))
+	require.Contains(t, b.String(), `
+[Service]
+ExecStart=/home/alice/go/bin/cosmovisor run start --home "/home/alice/my chains/1"
+Environment="DAEMON_NAME=marsd"
+Environment="DAEMON_HOME=/home/alice/my chains/1"
+Environment="DAEMON_RESTART_AFTER_UPGRADE=true"
+Environment="DAEMON_ALLOW_DOWNLOAD_BINARIES=false"
+Restart=on-failure
+`)
+
+	path, err := s.DefaultPath(ServiceFormatSystemd)
+	require.NoError(t, err)
+	require.Equal(t, "/etc/systemd/system/mars-1.service", path)
+}
+
+func TestNodeServiceWriteLaunchd(t *testing.T) {
+	s := NodeService{
+		ChainID:    "mars-1",
+		Binary:     "/Users/alice/go/bin/marsd",
+		Home:       "/Users/alice/spn/1&2",
+		Cosmovisor: "/Users/alice/go/bin/cosmovisor",
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, s.Write(&b, ServiceFormatLaunchd))
+	plist := b.String()
+	require.Contains(t, plist, `
+  <key>Label</key>
+  <string>network.starport.mars-1</string>
+  <key>ProgramArguments</key>
+  <array>
+    <string>/Users/alice/go/bin/cosmovisor</string>
+    <string>run</string>
+    <string>start</string>
+    <string>--home</string>
+    <string>/Users/alice/spn/1&amp;2</string>
+  </array>
+  <key>EnvironmentVariables</key>
+  <dict>
+    <key>DAEMON_NAME</key>
+    <string>marsd</string>
+`)
+	require.Contains(t, plist, "<string>/Users/alice/spn/1&amp;2/node.log</string>")
+	require.NotContains(t, plist, "UserName")
+}
+
+func TestNodeServiceSetupCosmovisor(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "marsd")
+	require.NoError(t, os.WriteFile(binary, []byte("marsd"), 0755))
+
+	s := NodeService{Binary: binary, Home: filepath.Join(dir, "home")}
+	require.NoError(t, s.SetupCosmovisor())
+
+	installed, err := os.ReadFile(filepath.Join(dir, "home", "cosmovisor", "genesis", "bin", "marsd"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("marsd"), installed)
+}
+
+func TestNewNodeService(t *testing.T) {
+	home := t.TempDir()
+
+	_, err := NewNodeService(home)
+	require.EqualError(t, err, `the chain is not prepared in `+home+`, run "starport network chain prepare" first`)
+
+	reportPath := filepath.Join(home, "config", PrepareReportFile)
+	require.NoError(t, savePrepareReport(PrepareReport{ChainID: "mars-1", Binary: "/go/bin/marsd"}, reportPath))
+
+	s, err := NewNodeService(home)
+	require.NoError(t, err)
+	require.Equal(t, NodeService{ChainID: "mars-1", Binary: "/go/bin/marsd", Home: home}, s)
+}
@@ -0,0 +1,65 @@
+package networkchain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// sourcePathSeparator separates the URL of the repository from the path of the chain in the
+// repository in a source URL, e.g. https://github.com/foo/bar//chains/foo.
+const sourcePathSeparator = "//"
+
+// JoinSourceURL returns the source URL of the chain at sourcePath in the repository at repoURL.
+func JoinSourceURL(repoURL, sourcePath string) string {
+	if sourcePath == "" {
+		return repoURL
+	}
+	return repoURL + sourcePathSeparator + sourcePath
+}
+
+// SplitSourceURL splits the source URL of a chain into the URL of its repository and the path of
+// the chain in the repository, the path is empty when the chain is at the root of the repository.
+func SplitSourceURL(sourceURL string) (repoURL, sourcePath string) {
+	// skip the separator of the scheme.
+	start := 0
+	if i := strings.Index(sourceURL, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	i := strings.Index(sourceURL[start:], sourcePathSeparator)
+	if i < 0 {
+		return sourceURL, ""
+	}
+	i += start
+	return sourceURL[:i], sourceURL[i+len(sourcePathSeparator):]
+}
+
+// cleanSourcePath cleans the path of a chain in its repository, the path must be inside the
+// repository.
+func cleanSourcePath(sourcePath string) (string, error) {
+	if sourcePath == "" {
+		return "", nil
+	}
+	cleaned := path.Clean(strings.Trim(sourcePath, "/"))
+	if cleaned == "." {
+		return "", nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("source path %q is outside of the repository", sourcePath)
+	}
+	return cleaned, nil
+}
+
+// resolveRevision resolves the revision to a commit hash, the revision can be a commit hash, a
+// tag or a remote branch.
+func resolveRevision(repo *git.Repository, revision string) (plumbing.Hash, error) {
+	for _, rev := range []string{revision, "origin/" + revision} {
+		if h, err := repo.ResolveRevision(plumbing.Revision(rev)); err == nil {
+			return *h, nil
+		}
+	}
+	return plumbing.ZeroHash, fmt.Errorf("cannot resolve %q to a commit, it must be a commit hash, a tag or a branch", revision)
+}
@@ -0,0 +1,82 @@
+package networkchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceURL(t *testing.T) {
+	tests := []struct {
+		sourceURL  string
+		repoURL    string
+		sourcePath string
+	}{
+		{
+			sourceURL: "https://github.com/foo/bar",
+			repoURL:   "https://github.com/foo/bar",
+		},
+		{
+			sourceURL:  "https://github.com/foo/bar//chains/foo",
+			repoURL:    "https://github.com/foo/bar",
+			sourcePath: "chains/foo",
+		},
+		{
+			sourceURL:  "git@github.com:foo/bar.git//chains/foo",
+			repoURL:    "git@github.com:foo/bar.git",
+			sourcePath: "chains/foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sourceURL, func(t *testing.T) {
+			repoURL, sourcePath := SplitSourceURL(tt.sourceURL)
+			require.Equal(t, tt.repoURL, repoURL)
+			require.Equal(t, tt.sourcePath, sourcePath)
+			require.Equal(t, tt.sourceURL, JoinSourceURL(repoURL, sourcePath))
+		})
+	}
+}
+
+func TestCleanSourcePath(t *testing.T) {
+	for path, want := range map[string]string{
+		"":             "",
+		"/":            "",
+		"chains/foo/":  "chains/foo",
+		"/chains/foo":  "chains/foo",
+		"chains/./foo": "chains/foo",
+	} {
+		got, err := cleanSourcePath(path)
+		require.NoError(t, err)
+		require.Equal(t, want, got, path)
+	}
+
+	for _, path := range []string{"..", "../foo", "chains/../../foo"} {
+		_, err := cleanSourcePath(path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestResolveRevision(t *testing.T) {
+	repo, err := git.PlainInit(t.TempDir(), false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	commit, err := wt.Commit("init", &git.CommitOptions{
+		Author: &object.Signature{Name: "foo", Email: "foo@bar.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v0.3.0", commit, nil)
+	require.NoError(t, err)
+
+	for _, revision := range []string{"v0.3.0", commit.String()} {
+		h, err := resolveRevision(repo, revision)
+		require.NoError(t, err)
+		require.Equal(t, commit, h)
+	}
+
+	_, err = resolveRevision(repo, "v1.0.0")
+	require.Error(t, err)
+}
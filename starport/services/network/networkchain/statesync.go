@@ -0,0 +1,126 @@
+package networkchain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+const (
+	// stateSyncTrustOffset is the number of blocks that the trusted block is behind the latest
+	// block of the state sync RPC server, so the snapshots taken after it can be verified.
+	stateSyncTrustOffset = 1000
+
+	// defaultStateSyncTrustPeriod is the period that the trusted block is trusted for, it must be
+	// shorter than the unbonding period of the chain.
+	defaultStateSyncTrustPeriod = "168h0m0s"
+)
+
+// StateSync is the config to state sync a node joining a launched chain.
+type StateSync struct {
+	// RPCServers are the RPC servers that the light client verifies the snapshots with.
+	RPCServers []string
+
+	// TrustPeriod is the period that the trusted block is trusted for.
+	TrustPeriod string
+}
+
+// Snapshots is the config of a node providing the snapshots of the state to the nodes syncing it.
+type Snapshots struct {
+	// Interval is the number of blocks between the snapshots.
+	Interval uint64
+
+	// KeepRecent is the number of recent snapshots to keep and serve.
+	KeepRecent uint32
+}
+
+// TrustedBlock is the block that the light client of state sync trusts.
+type TrustedBlock struct {
+	Height int64
+	Hash   string
+}
+
+// fetchTrustedBlock fetches the block to trust from the RPC server.
+var fetchTrustedBlock = func(ctx context.Context, rpcServer string) (TrustedBlock, error) {
+	client, err := rpchttp.New(rpcServer, "/websocket")
+	if err != nil {
+		return TrustedBlock{}, err
+	}
+
+	status, err := client.Status(ctx)
+	if err != nil {
+		return TrustedBlock{}, err
+	}
+
+	height := status.SyncInfo.LatestBlockHeight - stateSyncTrustOffset
+	if height < 1 {
+		height = 1
+	}
+
+	block, err := client.Block(ctx, &height)
+	if err != nil {
+		return TrustedBlock{}, err
+	}
+
+	return TrustedBlock{
+		Height: height,
+		Hash:   block.BlockID.Hash.String(),
+	}, nil
+}
+
+// ApplyStateSync enables state sync in the config.toml at configPath with a block trusted by the
+// first RPC server, the node syncs the state from the snapshots of its peers at its first start.
+func ApplyStateSync(ctx context.Context, configPath string, s StateSync) (TrustedBlock, error) {
+	if len(s.RPCServers) == 0 {
+		return TrustedBlock{}, errors.New("state sync needs at least one RPC server")
+	}
+
+	block, err := fetchTrustedBlock(ctx, s.RPCServers[0])
+	if err != nil {
+		return TrustedBlock{}, errors.Wrapf(err, "cannot fetch the trusted block from %s", s.RPCServers[0])
+	}
+
+	// tendermint requires two RPC servers, a single server is used for both.
+	rpcServers := s.RPCServers
+	if len(rpcServers) == 1 {
+		rpcServers = append(rpcServers, rpcServers[0])
+	}
+
+	trustPeriod := s.TrustPeriod
+	if trustPeriod == "" {
+		trustPeriod = defaultStateSyncTrustPeriod
+	}
+
+	configToml, err := toml.LoadFile(configPath)
+	if err != nil {
+		return TrustedBlock{}, err
+	}
+	configToml.Set("statesync.enable", true)
+	configToml.Set("statesync.rpc_servers", strings.Join(rpcServers, ","))
+	configToml.Set("statesync.trust_height", block.Height)
+	configToml.Set("statesync.trust_hash", block.Hash)
+	configToml.Set("statesync.trust_period", trustPeriod)
+
+	return block, os.WriteFile(configPath, []byte(configToml.String()), 0644)
+}
+
+// applySnapshots enables the snapshots of the state in the app.toml at appPath.
+func applySnapshots(appPath string, s Snapshots) error {
+	if s.Interval == 0 {
+		return fmt.Errorf("snapshot interval must be greater than 0")
+	}
+
+	appToml, err := toml.LoadFile(appPath)
+	if err != nil {
+		return err
+	}
+	appToml.Set("state-sync.snapshot-interval", int64(s.Interval))
+	appToml.Set("state-sync.snapshot-keep-recent", int64(s.KeepRecent))
+
+	return os.WriteFile(appPath, []byte(appToml.String()), 0644)
+}
@@ -0,0 +1,59 @@
+package networkchain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyStateSync(t *testing.T) {
+	fetch := fetchTrustedBlock
+	t.Cleanup(func() { fetchTrustedBlock = fetch })
+
+	var fetchedFrom string
+	fetchTrustedBlock = func(_ context.Context, rpcServer string) (TrustedBlock, error) {
+		fetchedFrom = rpcServer
+		return TrustedBlock{Height: 1000, Hash: "ABCD"}, nil
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte("[statesync]\nenable = false\n"), 0644))
+
+	block, err := ApplyStateSync(context.Background(), configPath, StateSync{
+		RPCServers: []string{"http://rpc1:26657"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TrustedBlock{Height: 1000, Hash: "ABCD"}, block)
+	require.Equal(t, "http://rpc1:26657", fetchedFrom)
+
+	config, err := toml.LoadFile(configPath)
+	require.NoError(t, err)
+	require.Equal(t, true, config.Get("statesync.enable"))
+	require.Equal(t, "http://rpc1:26657,http://rpc1:26657", config.Get("statesync.rpc_servers"))
+	require.Equal(t, int64(1000), config.Get("statesync.trust_height"))
+	require.Equal(t, "ABCD", config.Get("statesync.trust_hash"))
+	require.Equal(t, defaultStateSyncTrustPeriod, config.Get("statesync.trust_period"))
+}
+
+func TestApplyStateSyncNoRPCServer(t *testing.T) {
+	_, err := ApplyStateSync(context.Background(), "config.toml", StateSync{})
+	require.Error(t, err)
+}
+
+func TestApplySnapshots(t *testing.T) {
+	appPath := filepath.Join(t.TempDir(), "app.toml")
+	require.NoError(t, os.WriteFile(appPath, []byte("[state-sync]\nsnapshot-interval = 0\n"), 0644))
+
+	require.NoError(t, applySnapshots(appPath, Snapshots{Interval: 500, KeepRecent: 3}))
+
+	app, err := toml.LoadFile(appPath)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), app.Get("state-sync.snapshot-interval"))
+	require.Equal(t, int64(3), app.Get("state-sync.snapshot-keep-recent"))
+
+	require.Error(t, applySnapshots(appPath, Snapshots{}))
+}
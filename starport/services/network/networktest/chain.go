@@ -0,0 +1,58 @@
+package networktest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tendermint/starport/starport/services/network"
+)
+
+// Chain is a fake network.Chain, its paths are empty unless they are set.
+type Chain struct {
+	ChainID      string
+	ChainName    string
+	Source       string
+	Hash         string
+	Home         string
+	GenesisFile  string
+	GentxsDir    string
+	DefaultGentx string
+	AppTOML      string
+	ConfigTOML   string
+	NodeIDValue  string
+}
+
+var _ network.Chain = Chain{}
+
+var errNoPath = errors.New("the path is not set")
+
+func (c Chain) ID() (string, error) {
+	if c.ChainID == "" {
+		return "", errors.New("the chain ID is not set")
+	}
+	return c.ChainID, nil
+}
+
+func (c Chain) Name() string       { return c.ChainName }
+func (c Chain) SourceURL() string  { return c.Source }
+func (c Chain) SourceHash() string { return c.Hash }
+
+func (c Chain) GenesisPath() (string, error)      { return path(c.GenesisFile) }
+func (c Chain) GentxsPath() (string, error)       { return path(c.GentxsDir) }
+func (c Chain) DefaultGentxPath() (string, error) { return path(c.DefaultGentx) }
+func (c Chain) AppTOMLPath() (string, error)      { return path(c.AppTOML) }
+func (c Chain) ConfigTOMLPath() (string, error)   { return path(c.ConfigTOML) }
+
+func (c Chain) NodeID(context.Context) (string, error) {
+	if c.NodeIDValue == "" {
+		return "", errors.New("the node ID is not set")
+	}
+	return c.NodeIDValue, nil
+}
+
+func path(p string) (string, error) {
+	if p == "" {
+		return "", errNoPath
+	}
+	return p, nil
+}
@@ -0,0 +1,198 @@
+// Package networktest provides test doubles of SPN for the network service, so the service and the
+// tools built on it can be tested without a SPN node.
+//
+// FakeCosmos is a network.CosmosClient keeping an in-memory state of SPN: the coordinators,
+// campaigns and chains created with the transactions it receives are returned by its queries. the
+// responses of the other queries and transactions are scripted with OnQuery and OnBroadcast.
+package networktest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	"github.com/gogo/protobuf/proto"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	"github.com/tendermint/starport/starport/services/network"
+)
+
+// QueryHandler returns the response of a gRPC query of SPN, req is the request of the query, e.g.
+// a *launchtypes.QueryGetChainRequest.
+type QueryHandler func(ctx context.Context, req interface{}) (proto.Message, error)
+
+// BroadcastHandler returns the response of a message of a transaction sent to SPN, e.g. a
+// *launchtypes.MsgCreateChainResponse for a *launchtypes.MsgCreateChain.
+type BroadcastHandler func(ctx context.Context, msg sdk.Msg) (proto.Message, error)
+
+// FakeCosmos is a fake client of SPN, it's safe to use concurrently.
+type FakeCosmos struct {
+	mu sync.Mutex
+
+	cdc   codec.Codec
+	state *SPN
+
+	queries    map[string]QueryHandler
+	broadcasts map[string]BroadcastHandler
+
+	broadcasted []sdk.Msg
+	balances    map[string]sdk.Coins
+	funded      map[string]sdk.Coins
+	height      int64
+	txs         int
+}
+
+var _ network.CosmosClient = (*FakeCosmos)(nil)
+
+// NewFakeCosmos returns a fake client of SPN with an empty state.
+func NewFakeCosmos() *FakeCosmos {
+	f := &FakeCosmos{
+		cdc:        codec.NewProtoCodec(codectypes.NewInterfaceRegistry()),
+		state:      &SPN{},
+		queries:    make(map[string]QueryHandler),
+		broadcasts: make(map[string]BroadcastHandler),
+		balances:   make(map[string]sdk.Coins),
+		funded:     make(map[string]sdk.Coins),
+		height:     1,
+	}
+	f.registerStateHandlers()
+	return f
+}
+
+// State returns the in-memory state of SPN, it can be changed to set up the test.
+func (f *FakeCosmos) State() *SPN {
+	return f.state
+}
+
+// OnQuery scripts the response of the gRPC query with the full method name, e.g.
+// "/tendermint.spn.launch.Query/Chain". it replaces the response built from the state.
+func (f *FakeCosmos) OnQuery(method string, handler QueryHandler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries[method] = handler
+}
+
+// OnBroadcast scripts the response of the messages with the type URL, e.g.
+// "/tendermint.spn.launch.MsgCreateChain". a transaction fails with the first error returned for
+// its messages. it replaces the response built from the state.
+func (f *FakeCosmos) OnBroadcast(msgTypeURL string, handler BroadcastHandler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts[msgTypeURL] = handler
+}
+
+// Broadcasted returns the messages of the transactions that succeeded, in their order.
+func (f *FakeCosmos) Broadcasted() []sdk.Msg {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]sdk.Msg(nil), f.broadcasted...)
+}
+
+// SetBalances sets the balances of the address.
+func (f *FakeCosmos) SetBalances(address string, coins sdk.Coins) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.balances[address] = coins
+}
+
+// Funded returns the coins requested from the faucet for the account.
+func (f *FakeCosmos) Funded(accountName string) sdk.Coins {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.funded[accountName]
+}
+
+// SetHeight sets the latest block height of the node, each successful transaction increases it.
+func (f *FakeCosmos) SetHeight(height int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.height = height
+}
+
+// QueryConn returns the connection the queries are answered from.
+func (f *FakeCosmos) QueryConn() gogogrpc.ClientConn {
+	return queryConn{f}
+}
+
+// Status returns the status of the node with its latest block height.
+func (f *FakeCosmos) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status := &ctypes.ResultStatus{}
+	status.SyncInfo.LatestBlockHeight = f.height
+	return status, nil
+}
+
+// Balances returns the balances of the address set with SetBalances.
+func (f *FakeCosmos) Balances(ctx context.Context, address string) (sdk.Coins, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.balances[address], nil
+}
+
+// FundFromFaucet records the coins requested for the account.
+func (f *FakeCosmos) FundFromFaucet(ctx context.Context, accountName string, coins sdk.Coins) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.funded[accountName] = f.funded[accountName].Add(coins...)
+	return nil
+}
+
+// BroadcastTxAndWait answers the messages with their handlers, the messages are only recorded
+// when all of them succeed, like the messages of a transaction.
+func (f *FakeCosmos) BroadcastTxAndWait(ctx context.Context, accountName string, msgs ...sdk.Msg) (cosmosclient.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// the state changed by the handlers is restored when the transaction fails.
+	state := f.state.clone()
+	data, err := f.deliver(ctx, msgs)
+	if err != nil {
+		*f.state = state
+		return cosmosclient.Response{}, err
+	}
+
+	f.broadcasted = append(f.broadcasted, msgs...)
+	f.height++
+	f.txs++
+
+	return cosmosclient.NewResponse(f.cdc, &sdk.TxResponse{
+		Height: f.height,
+		TxHash: fmt.Sprintf("%064X", f.txs),
+		Data:   hex.EncodeToString(data),
+	}), nil
+}
+
+// deliver answers the messages with their handlers and returns the encoded data of the transaction.
+func (f *FakeCosmos) deliver(ctx context.Context, msgs []sdk.Msg) ([]byte, error) {
+	var txMsgData sdk.TxMsgData
+	for _, msg := range msgs {
+		typeURL := sdk.MsgTypeURL(msg)
+		handler, ok := f.broadcasts[typeURL]
+		if !ok {
+			return nil, fmt.Errorf("no handler for the message %s", typeURL)
+		}
+		res, err := handler(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		data, err := proto.Marshal(res)
+		if err != nil {
+			return nil, err
+		}
+		txMsgData.Data = append(txMsgData.Data, &sdk.MsgData{MsgType: typeURL, Data: data})
+	}
+	return f.cdc.Marshal(&txMsgData)
+}
+
+// WithBroadcastOptions returns the client, the options have no effect on the fake transactions.
+func (f *FakeCosmos) WithBroadcastOptions(...cosmosclient.BroadcastOption) network.CosmosClient {
+	return f
+}
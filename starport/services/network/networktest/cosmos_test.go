@@ -0,0 +1,123 @@
+package networktest_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/services/network"
+	"github.com/tendermint/starport/starport/services/network/networktest"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+func newNetwork(t *testing.T, cosmos network.CosmosClient) (network.Network, cosmosaccount.Account) {
+	registry, err := cosmosaccount.New(cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringMemory))
+	require.NoError(t, err)
+	account, _, err := registry.Create("alice")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	n, err := network.New(
+		cosmos,
+		account,
+		network.WithRequestHistoryPath(filepath.Join(dir, "requests.yml")),
+		network.WithPublishCheckpointPath(filepath.Join(dir, "publish-checkpoints.yml")),
+	)
+	require.NoError(t, err)
+	return n, account
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	cosmos := networktest.NewFakeCosmos()
+	n, account := newNetwork(t, cosmos)
+	chain := networktest.Chain{
+		ChainID:   "mars-1",
+		ChainName: "mars",
+		Source:    "https://github.com/tendermint/mars",
+		Hash:      "a1b2c3",
+	}
+
+	launchID, campaignID, err := n.Publish(ctx, chain)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, launchID)
+	require.EqualValues(t, 1, campaignID)
+
+	coordinator, ok := cosmos.State().Coordinator(account.Address(networktypes.SPN))
+	require.True(t, ok)
+	published, ok := cosmos.State().Chain(launchID)
+	require.True(t, ok)
+	require.Equal(t, coordinator.CoordinatorID, published.CoordinatorID)
+	require.Equal(t, "mars-1", published.GenesisChainID)
+	require.Equal(t, campaignID, published.CampaignID)
+	require.Len(t, cosmos.Broadcasted(), 3)
+
+	// the chain is published again only when it's forced.
+	_, _, err = n.Publish(ctx, chain)
+	var duplicateErr *network.DuplicateChainError
+	require.True(t, errors.As(err, &duplicateErr))
+	require.Len(t, duplicateErr.Duplicates, 1)
+	require.Len(t, cosmos.Broadcasted(), 3)
+
+	launchID, _, err = n.Publish(ctx, chain, network.WithForce())
+	require.NoError(t, err)
+	require.EqualValues(t, 2, launchID)
+}
+
+func TestPublishResume(t *testing.T) {
+	ctx := context.Background()
+	cosmos := networktest.NewFakeCosmos()
+	n, _ := newNetwork(t, cosmos)
+	chain := networktest.Chain{ChainID: "mars-1", ChainName: "mars"}
+
+	createChain := sdk.MsgTypeURL(&launchtypes.MsgCreateChain{})
+	cosmos.OnBroadcast(createChain, func(context.Context, sdk.Msg) (proto.Message, error) {
+		return nil, errors.New("connection lost")
+	})
+	_, _, err := n.Publish(ctx, chain)
+	require.EqualError(t, err, "connection lost")
+	require.Len(t, cosmos.State().Campaigns, 1)
+	require.Empty(t, cosmos.State().Chains)
+
+	// the campaign created before the failure is reused.
+	cosmos.OnBroadcast(createChain, func(_ context.Context, msg sdk.Msg) (proto.Message, error) {
+		require.EqualValues(t, 1, msg.(*launchtypes.MsgCreateChain).CampaignID)
+		return &launchtypes.MsgCreateChainResponse{LaunchID: 42}, nil
+	})
+	launchID, campaignID, err := n.Publish(ctx, chain)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, launchID)
+	require.EqualValues(t, 1, campaignID)
+	require.Len(t, cosmos.State().Campaigns, 1)
+}
+
+func TestFakeCosmosQuery(t *testing.T) {
+	ctx := context.Background()
+	cosmos := networktest.NewFakeCosmos()
+	client := launchtypes.NewQueryClient(cosmos.QueryConn())
+
+	_, err := client.Chain(ctx, &launchtypes.QueryGetChainRequest{LaunchID: 1})
+	require.Error(t, err)
+
+	cosmos.State().Chains = append(cosmos.State().Chains, launchtypes.Chain{LaunchID: 1, GenesisChainID: "mars-1"})
+	res, err := client.Chain(ctx, &launchtypes.QueryGetChainRequest{LaunchID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "mars-1", res.Chain.GenesisChainID)
+
+	cosmos.OnQuery("/tendermint.spn.launch.Query/Chain", func(context.Context, interface{}) (proto.Message, error) {
+		return &launchtypes.QueryGetChainResponse{Chain: launchtypes.Chain{LaunchID: 1, GenesisChainID: "venus-1"}}, nil
+	})
+	res, err = client.Chain(ctx, &launchtypes.QueryGetChainRequest{LaunchID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "venus-1", res.Chain.GenesisChainID)
+
+	_, err = client.GenesisAccountAll(ctx, &launchtypes.QueryAllGenesisAccountRequest{LaunchID: 1})
+	require.Error(t, err)
+}
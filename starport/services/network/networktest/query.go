@@ -0,0 +1,48 @@
+package networktest
+
+import (
+	"context"
+	"errors"
+
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queryConn answers the gRPC queries sent to a FakeCosmos with its query handlers.
+type queryConn struct {
+	f *FakeCosmos
+}
+
+var _ gogogrpc.ClientConn = queryConn{}
+
+func (c queryConn) Invoke(ctx context.Context, method string, args, reply interface{}, _ ...grpc.CallOption) error {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	handler, ok := c.f.queries[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "no handler for the query %s", method)
+	}
+	res, err := handler(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	// the response is copied to reply like it's decoded from the node.
+	data, err := proto.Marshal(res)
+	if err != nil {
+		return err
+	}
+	message, ok := reply.(proto.Message)
+	if !ok {
+		return errors.New("the reply of the query is not a proto message")
+	}
+	return proto.Unmarshal(data, message)
+}
+
+func (queryConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streams are not supported by the fake")
+}
@@ -0,0 +1,157 @@
+package networktest
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	campaigntypes "github.com/tendermint/spn/x/campaign/types"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+	profiletypes "github.com/tendermint/spn/x/profile/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SPN is the in-memory state of SPN kept by a FakeCosmos, the IDs of the created objects are
+// their indexes plus one.
+type SPN struct {
+	Coordinators []profiletypes.Coordinator
+	Campaigns    []campaigntypes.Campaign
+	Chains       []launchtypes.Chain
+}
+
+func (s SPN) clone() SPN {
+	return SPN{
+		Coordinators: append([]profiletypes.Coordinator(nil), s.Coordinators...),
+		Campaigns:    append([]campaigntypes.Campaign(nil), s.Campaigns...),
+		Chains:       append([]launchtypes.Chain(nil), s.Chains...),
+	}
+}
+
+// Coordinator returns the coordinator of the address.
+func (s SPN) Coordinator(address string) (profiletypes.Coordinator, bool) {
+	for _, c := range s.Coordinators {
+		if c.Address == address {
+			return c, true
+		}
+	}
+	return profiletypes.Coordinator{}, false
+}
+
+// Campaign returns the campaign with the ID.
+func (s SPN) Campaign(id uint64) (campaigntypes.Campaign, bool) {
+	for _, c := range s.Campaigns {
+		if c.CampaignID == id {
+			return c, true
+		}
+	}
+	return campaigntypes.Campaign{}, false
+}
+
+// Chain returns the chain with the launch ID.
+func (s SPN) Chain(launchID uint64) (launchtypes.Chain, bool) {
+	for _, c := range s.Chains {
+		if c.LaunchID == launchID {
+			return c, true
+		}
+	}
+	return launchtypes.Chain{}, false
+}
+
+// errNotFound is the error of SPN for the queries of objects that don't exist.
+var errNotFound = status.Error(codes.InvalidArgument, "not found")
+
+// registerStateHandlers registers the queries and the messages answered from the state.
+func (f *FakeCosmos) registerStateHandlers() {
+	s := f.state
+
+	f.queries["/tendermint.spn.profile.Query/CoordinatorByAddress"] = func(_ context.Context, req interface{}) (proto.Message, error) {
+		c, ok := s.Coordinator(req.(*profiletypes.QueryGetCoordinatorByAddressRequest).Address)
+		if !ok {
+			return nil, errNotFound
+		}
+		return &profiletypes.QueryGetCoordinatorByAddressResponse{
+			CoordinatorByAddress: profiletypes.CoordinatorByAddress{
+				Address:       c.Address,
+				CoordinatorID: c.CoordinatorID,
+			},
+		}, nil
+	}
+	f.queries["/tendermint.spn.campaign.Query/Campaign"] = func(_ context.Context, req interface{}) (proto.Message, error) {
+		c, ok := s.Campaign(req.(*campaigntypes.QueryGetCampaignRequest).CampaignID)
+		if !ok {
+			return nil, errNotFound
+		}
+		return &campaigntypes.QueryGetCampaignResponse{Campaign: c}, nil
+	}
+	f.queries["/tendermint.spn.campaign.Query/CampaignAll"] = func(context.Context, interface{}) (proto.Message, error) {
+		return &campaigntypes.QueryAllCampaignResponse{Campaign: s.Campaigns}, nil
+	}
+	f.queries["/tendermint.spn.launch.Query/Chain"] = func(_ context.Context, req interface{}) (proto.Message, error) {
+		c, ok := s.Chain(req.(*launchtypes.QueryGetChainRequest).LaunchID)
+		if !ok {
+			return nil, errNotFound
+		}
+		return &launchtypes.QueryGetChainResponse{Chain: c}, nil
+	}
+	f.queries["/tendermint.spn.launch.Query/ChainAll"] = func(context.Context, interface{}) (proto.Message, error) {
+		return &launchtypes.QueryAllChainResponse{Chain: s.Chains}, nil
+	}
+
+	f.broadcasts[sdk.MsgTypeURL(&profiletypes.MsgCreateCoordinator{})] = func(_ context.Context, msg sdk.Msg) (proto.Message, error) {
+		m := msg.(*profiletypes.MsgCreateCoordinator)
+		if _, ok := s.Coordinator(m.Address); ok {
+			return nil, status.Error(codes.InvalidArgument, "coordinator address already exist")
+		}
+		id := uint64(len(s.Coordinators) + 1)
+		s.Coordinators = append(s.Coordinators, profiletypes.Coordinator{
+			CoordinatorID: id,
+			Address:       m.Address,
+			Description:   m.Description,
+		})
+		return &profiletypes.MsgCreateCoordinatorResponse{CoordinatorID: id}, nil
+	}
+	f.broadcasts[sdk.MsgTypeURL(&campaigntypes.MsgCreateCampaign{})] = func(_ context.Context, msg sdk.Msg) (proto.Message, error) {
+		m := msg.(*campaigntypes.MsgCreateCampaign)
+		coordinator, ok := s.Coordinator(m.Coordinator)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "coordinator address not found")
+		}
+		id := uint64(len(s.Campaigns) + 1)
+		s.Campaigns = append(s.Campaigns, campaigntypes.Campaign{
+			CampaignID:    id,
+			CampaignName:  m.CampaignName,
+			CoordinatorID: coordinator.CoordinatorID,
+			TotalSupply:   m.TotalSupply,
+		})
+		return &campaigntypes.MsgCreateCampaignResponse{CampaignID: id}, nil
+	}
+	f.broadcasts[sdk.MsgTypeURL(&launchtypes.MsgCreateChain{})] = func(_ context.Context, msg sdk.Msg) (proto.Message, error) {
+		m := msg.(*launchtypes.MsgCreateChain)
+		coordinator, ok := s.Coordinator(m.Coordinator)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "coordinator address not found")
+		}
+		if m.HasCampaign {
+			if _, ok := s.Campaign(m.CampaignID); !ok {
+				return nil, status.Error(codes.InvalidArgument, "campaign not found")
+			}
+		}
+		initialGenesis := launchtypes.NewDefaultInitialGenesis()
+		if m.GenesisURL != "" {
+			initialGenesis = launchtypes.NewGenesisURL(m.GenesisURL, m.GenesisHash)
+		}
+		id := uint64(len(s.Chains) + 1)
+		s.Chains = append(s.Chains, launchtypes.Chain{
+			LaunchID:       id,
+			CoordinatorID:  coordinator.CoordinatorID,
+			GenesisChainID: m.GenesisChainID,
+			SourceURL:      m.SourceURL,
+			SourceHash:     m.SourceHash,
+			InitialGenesis: initialGenesis,
+			HasCampaign:    m.HasCampaign,
+			CampaignID:     m.CampaignID,
+		})
+		return &launchtypes.MsgCreateChainResponse{LaunchID: id}, nil
+	}
+}
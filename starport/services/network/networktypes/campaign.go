@@ -0,0 +1,17 @@
+package networktypes
+
+import campaigntypes "github.com/tendermint/spn/x/campaign/types"
+
+// Campaign represents a campaign on SPN
+type Campaign struct {
+	ID   uint64 `json:"ID"`
+	Name string `json:"Name"`
+}
+
+// ToCampaign converts a campaign data from SPN and returns a Campaign object
+func ToCampaign(campaign campaigntypes.Campaign) Campaign {
+	return Campaign{
+		ID:   campaign.CampaignID,
+		Name: campaign.CampaignName,
+	}
+}
@@ -0,0 +1,126 @@
+package networktypes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+)
+
+// DuplicateField is a field of the validators that must be unique in a launch.
+type DuplicateField string
+
+// the fields of the validators that must be unique.
+const (
+	DuplicateConsPubKey DuplicateField = "consensus key"
+	DuplicateNodeID     DuplicateField = "node ID"
+	DuplicateAddress    DuplicateField = "operator address"
+)
+
+// ValidatorDuplicate is a value of a field shared by validators of a launch, the genesis of the
+// launch can't be built with duplicates.
+type ValidatorDuplicate struct {
+	Field DuplicateField `json:"field"`
+	Value string         `json:"value"`
+
+	// Validators are the addresses of the approved validators sharing the value.
+	Validators []string `json:"validators,omitempty"`
+
+	// Requests are the IDs of the pending requests sharing the value, sorted.
+	Requests []uint64 `json:"requests,omitempty"`
+}
+
+func (d ValidatorDuplicate) String() string {
+	var shared []string
+	for _, address := range d.Validators {
+		shared = append(shared, "validator "+address)
+	}
+	for _, id := range d.Requests {
+		shared = append(shared, fmt.Sprintf("request #%d", id))
+	}
+	return fmt.Sprintf("%s %s is shared by %s", d.Field, d.Value, strings.Join(shared, ", "))
+}
+
+// HasRequest checks if the request shares the duplicated value.
+func (d ValidatorDuplicate) HasRequest(requestID uint64) bool {
+	for _, id := range d.Requests {
+		if id == requestID {
+			return true
+		}
+	}
+	return false
+}
+
+// FindValidatorDuplicates returns the consensus keys, the node IDs and the operator addresses
+// shared by the approved validators and the pending requests to add validators of a launch.
+func FindValidatorDuplicates(validators []GenesisValidator, requests []launchtypes.Request) []ValidatorDuplicate {
+	type field struct {
+		name  DuplicateField
+		value string
+	}
+	var (
+		fields []field
+		shared = make(map[field]*ValidatorDuplicate)
+	)
+	get := func(name DuplicateField, value string) *ValidatorDuplicate {
+		f := field{name, value}
+		d, ok := shared[f]
+		if !ok {
+			d = &ValidatorDuplicate{Field: name, Value: value}
+			shared[f] = d
+			fields = append(fields, f)
+		}
+		return d
+	}
+
+	for _, v := range validators {
+		for name, value := range validatorFields(v.Address, v.ConsPubKey, v.Peer) {
+			d := get(name, value)
+			d.Validators = append(d.Validators, v.Address)
+		}
+	}
+	for _, request := range requests {
+		req, ok := request.Content.Content.(*launchtypes.RequestContent_GenesisValidator)
+		if !ok {
+			continue
+		}
+		v := req.GenesisValidator
+		for name, value := range validatorFields(v.Address, v.ConsPubKey, v.Peer) {
+			d := get(name, value)
+			d.Requests = append(d.Requests, request.RequestID)
+		}
+	}
+
+	var duplicates []ValidatorDuplicate
+	for _, f := range fields {
+		d := shared[f]
+		if len(d.Validators)+len(d.Requests) < 2 {
+			continue
+		}
+		sort.Slice(d.Requests, func(i, j int) bool { return d.Requests[i] < d.Requests[j] })
+		duplicates = append(duplicates, *d)
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if duplicates[i].Field != duplicates[j].Field {
+			return duplicates[i].Field < duplicates[j].Field
+		}
+		return duplicates[i].Value < duplicates[j].Value
+	})
+	return duplicates
+}
+
+// validatorFields returns the fields of a validator that must be unique, the empty ones are skipped.
+func validatorFields(address string, consPubKey []byte, peer launchtypes.Peer) map[DuplicateField]string {
+	fields := make(map[DuplicateField]string)
+	for name, value := range map[DuplicateField]string{
+		DuplicateAddress:    address,
+		DuplicateConsPubKey: string(consPubKey),
+		DuplicateNodeID:     peer.Id,
+	} {
+		if value != "" {
+			fields[name] = value
+		}
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+package networktypes_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+func TestFindValidatorDuplicates(t *testing.T) {
+	validatorRequest := func(id uint64, address, key, nodeID string) launchtypes.Request {
+		return launchtypes.Request{
+			RequestID: id,
+			Content: launchtypes.NewGenesisValidator(
+				1,
+				address,
+				nil,
+				[]byte(key),
+				sdk.NewInt64Coin("stake", 100),
+				launchtypes.NewPeerConn(nodeID, "0.0.0.0:26656"),
+			),
+		}
+	}
+
+	var (
+		validators = []networktypes.GenesisValidator{
+			{Address: "spn1alice", ConsPubKey: []byte("keyA"), Peer: launchtypes.NewPeerConn("nodeA", "0.0.0.0:26656")},
+			{Address: "spn1bob", ConsPubKey: []byte("keyB"), Peer: launchtypes.NewPeerConn("nodeB", "0.0.0.0:26656")},
+		}
+		requests = []launchtypes.Request{
+			validatorRequest(5, "spn1carol", "keyA", "nodeC"),
+			validatorRequest(3, "spn1dave", "keyD", "nodeD"),
+			validatorRequest(4, "spn1erin", "keyE", "nodeD"),
+			{RequestID: 6, Content: launchtypes.NewGenesisAccount(1, "spn1alice", sdk.NewCoins())},
+		}
+	)
+
+	duplicates := networktypes.FindValidatorDuplicates(validators, requests)
+	require.Equal(t, []networktypes.ValidatorDuplicate{
+		{Field: networktypes.DuplicateConsPubKey, Value: "keyA", Validators: []string{"spn1alice"}, Requests: []uint64{5}},
+		{Field: networktypes.DuplicateNodeID, Value: "nodeD", Requests: []uint64{3, 4}},
+	}, duplicates)
+	require.Equal(t, "consensus key keyA is shared by validator spn1alice, request #5", duplicates[0].String())
+	require.True(t, duplicates[1].HasRequest(4))
+	require.False(t, duplicates[1].HasRequest(5))
+
+	require.Empty(t, networktypes.FindValidatorDuplicates(validators, nil))
+}
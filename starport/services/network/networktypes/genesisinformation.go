@@ -17,6 +17,9 @@ type GenesisInformation struct {
 	GenesisAccounts   []GenesisAccount
 	VestingAccounts   []VestingAccount
 	GenesisValidators []GenesisValidator
+
+	// Height is the SPN block height that the information is queried at, it's 0 when unknown.
+	Height int64
 }
 
 // GenesisAccount represents an account with initial coin allocation for the chain for the chain genesis
@@ -38,6 +41,7 @@ type VestingAccount struct {
 type GenesisValidator struct {
 	Address        string
 	Gentx          []byte
+	ConsPubKey     []byte
 	Peer           launchtypes.Peer
 	SelfDelegation sdk.Coin
 }
@@ -70,6 +74,7 @@ func ToGenesisValidator(val launchtypes.GenesisValidator) GenesisValidator {
 	return GenesisValidator{
 		Address:        val.Address,
 		Gentx:          val.GenTx,
+		ConsPubKey:     val.ConsPubKey,
 		Peer:           val.Peer,
 		SelfDelegation: val.SelfDelegation,
 	}
@@ -94,12 +94,14 @@ func TestToGenesisValidator(t *testing.T) {
 		{
 			name: "genesis validator",
 			fetched: launchtypes.GenesisValidator{
-				GenTx: []byte("abc"),
-				Peer:  launchtypes.NewPeerConn("abc", "abc@0.0.0.0"),
+				GenTx:      []byte("abc"),
+				ConsPubKey: []byte("def"),
+				Peer:       launchtypes.NewPeerConn("abc", "abc@0.0.0.0"),
 			},
 			expected: networktypes.GenesisValidator{
-				Gentx: []byte("abc"),
-				Peer:  launchtypes.NewPeerConn("abc", "abc@0.0.0.0"),
+				Gentx:      []byte("abc"),
+				ConsPubKey: []byte("def"),
+				Peer:       launchtypes.NewPeerConn("abc", "abc@0.0.0.0"),
 			},
 		},
 	}
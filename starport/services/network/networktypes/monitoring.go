@@ -0,0 +1,37 @@
+package networktypes
+
+// MonitoringStage is the stage of the relay of the monitoring data of a launch to SPN, the data
+// relayed from the launched chain is used to distribute the rewards of the testnet.
+type MonitoringStage string
+
+const (
+	// MonitoringNotConfigured is the stage of the launches without a verified IBC client on SPN.
+	MonitoringNotConfigured MonitoringStage = "not-configured"
+
+	// MonitoringClientVerified is the stage of the launches with a verified IBC client on SPN but
+	// without a monitoring channel yet.
+	MonitoringClientVerified MonitoringStage = "client-verified"
+
+	// MonitoringRelaying is the stage of the launches with a monitoring channel established with SPN.
+	MonitoringRelaying MonitoringStage = "relaying"
+)
+
+// MonitoringStatus is the status of the relay of the monitoring data of a launch to SPN.
+type MonitoringStatus struct {
+	LaunchID          uint64   `json:"LaunchID"`
+	ProviderClientID  string   `json:"ProviderClientID"`
+	VerifiedClientIDs []string `json:"VerifiedClientIDs"`
+	ChannelIDs        []string `json:"ChannelIDs"`
+}
+
+// Stage returns the stage of the relay of the monitoring data.
+func (s MonitoringStatus) Stage() MonitoringStage {
+	switch {
+	case len(s.ChannelIDs) > 0:
+		return MonitoringRelaying
+	case len(s.VerifiedClientIDs) > 0:
+		return MonitoringClientVerified
+	default:
+		return MonitoringNotConfigured
+	}
+}
@@ -0,0 +1,20 @@
+package networktypes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+func TestMonitoringStatusStage(t *testing.T) {
+	require.Equal(t, networktypes.MonitoringNotConfigured, networktypes.MonitoringStatus{}.Stage())
+	require.Equal(t, networktypes.MonitoringClientVerified, networktypes.MonitoringStatus{
+		VerifiedClientIDs: []string{"07-tendermint-0"},
+	}.Stage())
+	require.Equal(t, networktypes.MonitoringRelaying, networktypes.MonitoringStatus{
+		VerifiedClientIDs: []string{"07-tendermint-0"},
+		ChannelIDs:        []string{"channel-0"},
+	}.Stage())
+}
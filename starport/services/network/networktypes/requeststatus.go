@@ -0,0 +1,94 @@
+package networktypes
+
+import (
+	"time"
+
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+)
+
+// RequestStatus is the status of a request.
+type RequestStatus string
+
+const (
+	// RequestPending is the status of a request that is waiting to be settled by the coordinator.
+	RequestPending RequestStatus = "pending"
+
+	// RequestApproved is the status of a request that is approved by the coordinator.
+	RequestApproved RequestStatus = "approved"
+
+	// RequestRejected is the status of a request that is rejected by the coordinator.
+	RequestRejected RequestStatus = "rejected"
+)
+
+// RequestType is the type of the content of a request.
+type RequestType string
+
+const (
+	RequestGenesisAccount   RequestType = "genesis-account"
+	RequestVestingAccount   RequestType = "vesting-account"
+	RequestGenesisValidator RequestType = "genesis-validator"
+	RequestAccountRemoval   RequestType = "account-removal"
+	RequestValidatorRemoval RequestType = "validator-removal"
+)
+
+// RequestRecord keeps the status of a request and its history, SPN removes the requests once
+// they are settled so the records are the only way to know what happened to them.
+type RequestRecord struct {
+	LaunchID  uint64 `yaml:"launch_id" json:"launchID"`
+	RequestID uint64 `yaml:"request_id" json:"requestID"`
+
+	// Type and Address describe the content of the request.
+	Type    RequestType `yaml:"type" json:"type"`
+	Address string      `yaml:"address" json:"address"`
+
+	Status  RequestStatus         `yaml:"status" json:"status"`
+	History []RequestStatusChange `yaml:"history" json:"history"`
+}
+
+// RequestStatusChange is a change of the status of a request.
+type RequestStatusChange struct {
+	Status RequestStatus `yaml:"status" json:"status"`
+	Time   time.Time     `yaml:"time" json:"time"`
+}
+
+// NewRequestRecord returns the pending record of a request.
+func NewRequestRecord(request launchtypes.Request) RequestRecord {
+	r := RequestRecord{
+		LaunchID:  request.LaunchID,
+		RequestID: request.RequestID,
+	}
+
+	switch content := request.Content.Content.(type) {
+	case *launchtypes.RequestContent_GenesisAccount:
+		r.Type, r.Address = RequestGenesisAccount, content.GenesisAccount.Address
+	case *launchtypes.RequestContent_VestingAccount:
+		r.Type, r.Address = RequestVestingAccount, content.VestingAccount.Address
+	case *launchtypes.RequestContent_GenesisValidator:
+		r.Type, r.Address = RequestGenesisValidator, content.GenesisValidator.Address
+	case *launchtypes.RequestContent_AccountRemoval:
+		r.Type, r.Address = RequestAccountRemoval, content.AccountRemoval.Address
+	case *launchtypes.RequestContent_ValidatorRemoval:
+		r.Type, r.Address = RequestValidatorRemoval, content.ValidatorRemoval.ValAddress
+	}
+
+	r.SetStatus(RequestPending, time.Unix(request.CreatedAt, 0).UTC())
+	return r
+}
+
+// SetStatus changes the status of the request at t, the history is left as it is when the status
+// doesn't change.
+func (r *RequestRecord) SetStatus(status RequestStatus, t time.Time) {
+	if r.Status == status {
+		return
+	}
+	r.Status = status
+	r.History = append(r.History, RequestStatusChange{
+		Status: status,
+		Time:   t,
+	})
+}
+
+// IsSettled checks if the request is approved or rejected.
+func (r RequestRecord) IsSettled() bool {
+	return r.Status == RequestApproved || r.Status == RequestRejected
+}
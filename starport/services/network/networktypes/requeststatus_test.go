@@ -0,0 +1,50 @@
+package networktypes_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+func TestNewRequestRecord(t *testing.T) {
+	request := launchtypes.Request{
+		LaunchID:  1,
+		RequestID: 2,
+		CreatedAt: 1000,
+		Content:   launchtypes.NewGenesisAccount(1, "spn1abc", sdk.NewCoins(sdk.NewInt64Coin("stake", 100))),
+	}
+
+	require.Equal(t, networktypes.RequestRecord{
+		LaunchID:  1,
+		RequestID: 2,
+		Type:      networktypes.RequestGenesisAccount,
+		Address:   "spn1abc",
+		Status:    networktypes.RequestPending,
+		History: []networktypes.RequestStatusChange{
+			{Status: networktypes.RequestPending, Time: time.Unix(1000, 0).UTC()},
+		},
+	}, networktypes.NewRequestRecord(request))
+}
+
+func TestRequestRecordSetStatus(t *testing.T) {
+	var (
+		r   networktypes.RequestRecord
+		now = time.Now()
+	)
+
+	r.SetStatus(networktypes.RequestPending, now)
+	r.SetStatus(networktypes.RequestPending, now.Add(time.Minute))
+	require.False(t, r.IsSettled())
+
+	r.SetStatus(networktypes.RequestApproved, now.Add(time.Hour))
+	require.True(t, r.IsSettled())
+	require.Equal(t, []networktypes.RequestStatusChange{
+		{Status: networktypes.RequestPending, Time: now},
+		{Status: networktypes.RequestApproved, Time: now.Add(time.Hour)},
+	}, r.History)
+}
@@ -2,10 +2,21 @@ package network
 
 import (
 	"fmt"
+	"net"
+	"strings"
 
+	"github.com/pelletier/go-toml"
 	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/pkg/xnet"
 )
 
+// defaultP2PPort is the port that the nodes listen to their peers at by default.
+const defaultP2PPort = "26656"
+
+// publicIP returns the public IP of the machine.
+var publicIP = xnet.PublicIP
+
 func PeerAddress(peer launchtypes.Peer) (string, error) {
 	var peerAddr string
 	switch conn := peer.Connection.(type) {
@@ -18,3 +29,34 @@ func PeerAddress(peer launchtypes.Peer) (string, error) {
 	}
 	return peerAddr, nil
 }
+
+// detectPublicAddress detects the public address of the chain's node. the external address set in
+// the node's config is used if any, otherwise the address is made of the public IP of the machine
+// and the port that the node listens to its peers at.
+func detectPublicAddress(c Chain) (string, error) {
+	configPath, err := c.ConfigTOMLPath()
+	if err != nil {
+		return "", err
+	}
+	configToml, err := toml.LoadFile(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	if external, _ := configToml.Get("p2p.external_address").(string); external != "" {
+		return external, nil
+	}
+
+	port := defaultP2PPort
+	if laddr, _ := configToml.Get("p2p.laddr").(string); laddr != "" {
+		if _, p, err := net.SplitHostPort(strings.TrimPrefix(laddr, "tcp://")); err == nil {
+			port = p
+		}
+	}
+
+	ip, err := publicIP()
+	if err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(ip, port), nil
+}
@@ -2,6 +2,8 @@ package network
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -44,3 +46,55 @@ func TestPeerAddress(t *testing.T) {
 		})
 	}
 }
+
+type configChain struct {
+	Chain
+	configPath string
+}
+
+func (c configChain) ConfigTOMLPath() (string, error) {
+	return c.configPath, nil
+}
+
+func TestDetectPublicAddress(t *testing.T) {
+	defer func(ip func() (string, error)) { publicIP = ip }(publicIP)
+	publicIP = func() (string, error) { return "203.0.113.1", nil }
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name: "external address",
+			config: `[p2p]
+external_address = "198.51.100.1:26656"
+laddr = "tcp://0.0.0.0:26656"
+`,
+			want: "198.51.100.1:26656",
+		},
+		{
+			name: "listen port",
+			config: `[p2p]
+external_address = ""
+laddr = "tcp://0.0.0.0:36656"
+`,
+			want: "203.0.113.1:36656",
+		},
+		{
+			name:   "default port",
+			config: "moniker = \"mars\"\n",
+			want:   "203.0.113.1:26656",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.config), 0644))
+
+			got, err := detectPublicAddress(configChain{configPath: configPath})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
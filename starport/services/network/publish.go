@@ -2,12 +2,13 @@ package network
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	campaigntypes "github.com/tendermint/spn/x/campaign/types"
 	launchtypes "github.com/tendermint/spn/x/launch/types"
-	profiletypes "github.com/tendermint/spn/x/profile/types"
 
-	"github.com/tendermint/starport/starport/pkg/cosmoserror"
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
 	"github.com/tendermint/starport/starport/pkg/cosmosutil"
 	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/services/network/networktypes"
@@ -19,6 +20,7 @@ type publishOptions struct {
 	chainID    string
 	campaignID uint64
 	noCheck    bool
+	force      bool
 }
 
 // PublishOption configures chain creation.
@@ -45,6 +47,14 @@ func WithNoCheck() PublishOption {
 	}
 }
 
+// WithForce publishes the chain even if the coordinator already published chains with the same
+// chain ID or the same source.
+func WithForce() PublishOption {
+	return func(o *publishOptions) {
+		o.force = true
+	}
+}
+
 // WithCustomGenesis enables using a custom genesis during publish.
 func WithCustomGenesis(url string) PublishOption {
 	return func(o *publishOptions) {
@@ -52,7 +62,9 @@ func WithCustomGenesis(url string) PublishOption {
 	}
 }
 
-// Publish submits Genesis to SPN to announce a new network.
+// Publish submits Genesis to SPN to announce a new network. it fails with a DuplicateChainError
+// before sending any transaction when the coordinator already published the chain, unless
+// WithForce is used.
 func (n Network) Publish(ctx context.Context, c Chain, options ...PublishOption) (launchID, campaignID uint64, err error) {
 	o := publishOptions{}
 	for _, apply := range options {
@@ -76,55 +88,78 @@ func (n Network) Publish(ctx context.Context, c Chain, options ...PublishOption)
 		}
 	}
 
-	coordinatorAddress := n.account.Address(networktypes.SPN)
-	campaignID = o.campaignID
+	if !o.force {
+		duplicates, err := n.duplicateChains(ctx, chainID, c.SourceURL(), c.SourceHash())
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(duplicates) > 0 {
+			return 0, 0, &DuplicateChainError{ChainID: chainID, Duplicates: duplicates}
+		}
+	}
+
+	// the publish is resumed from its checkpoint when a previous publish of the chain failed, so
+	// the transactions that succeeded are not sent again.
+	status, err := n.cosmos.Status(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	key := publishKey{
+		SPNChainID:  status.NodeInfo.Network,
+		Coordinator: n.account.Address(networktypes.SPN),
+		ChainID:     chainID,
+		SourceURL:   c.SourceURL(),
+		SourceHash:  c.SourceHash(),
+		GenesisURL:  o.genesisURL,
+	}
+	checkpoint, resumed, err := loadPublishCheckpoint(n.publishCheckpointPath, key)
+	if err != nil {
+		return 0, 0, err
+	}
+	if resumed {
+		n.ev.Send(events.New(events.StatusDone, fmt.Sprintf(
+			"Resuming the publish of %s interrupted at %s",
+			chainID,
+			checkpoint.UpdatedAt.Local().Format(time.RFC3339),
+		)))
+	}
 
 	n.ev.Send(events.New(events.StatusOngoing, "Publishing the network"))
 
-	_, err = profiletypes.
-		NewQueryClient(n.cosmos.Context).
-		CoordinatorByAddress(ctx, &profiletypes.QueryGetCoordinatorByAddressRequest{
-			Address: coordinatorAddress,
-		})
-	if cosmoserror.Unwrap(err) == cosmoserror.ErrInvalidRequest {
-		msgCreateCoordinator := profiletypes.NewMsgCreateCoordinator(
-			coordinatorAddress,
-			"",
-			"",
-			"",
-		)
-		if _, err := n.cosmos.BroadcastTx(n.account.Name, msgCreateCoordinator); err != nil {
+	cosmos := n.cosmos.WithBroadcastOptions(cosmosclient.WithMemo(fmt.Sprintf("starport publish %s", chainID)))
+
+	if !checkpoint.CoordinatorCreated {
+		if err := n.ensureCoordinator(ctx, cosmos); err != nil {
+			return 0, 0, err
+		}
+		checkpoint.CoordinatorCreated = true
+		if err := savePublishCheckpoint(n.publishCheckpointPath, checkpoint); err != nil {
 			return 0, 0, err
 		}
-	} else if err != nil {
-		return 0, 0, err
+	}
+
+	campaignID = o.campaignID
+	if campaignID == 0 {
+		campaignID = checkpoint.CampaignID
 	}
 
 	if campaignID != 0 {
 		_, err = campaigntypes.
-			NewQueryClient(n.cosmos.Context).
+			NewQueryClient(n.cosmos.QueryConn()).
 			Campaign(ctx, &campaigntypes.QueryGetCampaignRequest{
-				CampaignID: o.campaignID,
+				CampaignID: campaignID,
 			})
 		if err != nil {
 			return 0, 0, err
 		}
-	} else {
-		msgCreateCampaign := campaigntypes.NewMsgCreateCampaign(
-			coordinatorAddress,
-			c.Name(),
-			nil,
-		)
-		res, err := n.cosmos.BroadcastTx(n.account.Name, msgCreateCampaign)
-		if err != nil {
-			return 0, 0, err
-		}
-
-		var createCampaignRes campaigntypes.MsgCreateCampaignResponse
-		if err := res.Decode(&createCampaignRes); err != nil {
+	} else if campaignID, err = n.createCampaign(ctx, cosmos, c.Name(), nil); err != nil {
+		return 0, 0, err
+	}
+	if checkpoint.CampaignID != campaignID {
+		checkpoint.CampaignID = campaignID
+		if err := savePublishCheckpoint(n.publishCheckpointPath, checkpoint); err != nil {
 			return 0, 0, err
 		}
-		campaignID = createCampaignRes.CampaignID
 	}
 
 	msgCreateChain := launchtypes.NewMsgCreateChain(
@@ -137,7 +172,7 @@ func (n Network) Publish(ctx context.Context, c Chain, options ...PublishOption)
 		true,
 		campaignID,
 	)
-	res, err := n.cosmos.BroadcastTx(n.account.Name, msgCreateChain)
+	res, err := cosmos.BroadcastTxAndWait(ctx, n.account.Name, msgCreateChain)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -147,5 +182,10 @@ func (n Network) Publish(ctx context.Context, c Chain, options ...PublishOption)
 		return 0, 0, err
 	}
 
+	// the chain is created, a new publish of the chain creates a new launch.
+	if err := removePublishCheckpoint(n.publishCheckpointPath, key); err != nil {
+		return 0, 0, err
+	}
+
 	return createChainRes.LaunchID, campaignID, nil
 }
@@ -0,0 +1,115 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tendermint/starport/starport/pkg/confile"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// publishCheckpointFile is the file that the checkpoints of the unfinished publishes are kept in.
+const publishCheckpointFile = "publish-checkpoints.yml"
+
+// DefaultPublishCheckpointPath returns the default path of the file that the checkpoints of the
+// unfinished publishes are kept in.
+func DefaultPublishCheckpointPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, networktypes.SPN, publishCheckpointFile), nil
+}
+
+// publishKey identifies a publish, a publish of the same chain by the same coordinator on the same
+// SPN chain is resumed from its checkpoint.
+type publishKey struct {
+	// SPNChainID is the chain ID of SPN, the campaigns of a local SPN and of a testnet cannot be
+	// mixed up since the coordinator has the same address on both.
+	SPNChainID  string `yaml:"spn_chain_id"`
+	Coordinator string `yaml:"coordinator"`
+	ChainID     string `yaml:"chain_id"`
+	SourceURL   string `yaml:"source_url"`
+	SourceHash  string `yaml:"source_hash"`
+	GenesisURL  string `yaml:"genesis_url"`
+}
+
+// publishCheckpoint is the progress of a publish, its transactions that succeeded are not sent
+// again when the publish is resumed.
+type publishCheckpoint struct {
+	Key publishKey `yaml:"key"`
+
+	// CoordinatorCreated is true once the coordinator exists.
+	CoordinatorCreated bool `yaml:"coordinator_created"`
+
+	// CampaignID is the campaign of the chain, it's 0 until the campaign is created.
+	CampaignID uint64 `yaml:"campaign_id"`
+
+	UpdatedAt time.Time `yaml:"updated_at"`
+}
+
+type publishCheckpoints struct {
+	Checkpoints []publishCheckpoint `yaml:"checkpoints"`
+}
+
+// loadPublishCheckpoint loads the checkpoint of a publish from path, ok is false when the publish
+// has no checkpoint.
+func loadPublishCheckpoint(path string, key publishKey) (checkpoint publishCheckpoint, ok bool, err error) {
+	checkpoints, err := loadPublishCheckpoints(path)
+	if err != nil {
+		return checkpoint, false, err
+	}
+	for _, c := range checkpoints.Checkpoints {
+		if c.Key == key {
+			return c, true, nil
+		}
+	}
+	return publishCheckpoint{Key: key}, false, nil
+}
+
+// savePublishCheckpoint adds or updates the checkpoint of a publish in path.
+func savePublishCheckpoint(path string, checkpoint publishCheckpoint) error {
+	checkpoints, err := loadPublishCheckpoints(path)
+	if err != nil {
+		return err
+	}
+	checkpoint.UpdatedAt = time.Now().UTC()
+
+	found := false
+	for i, c := range checkpoints.Checkpoints {
+		if c.Key == checkpoint.Key {
+			checkpoints.Checkpoints[i] = checkpoint
+			found = true
+			break
+		}
+	}
+	if !found {
+		checkpoints.Checkpoints = append(checkpoints.Checkpoints, checkpoint)
+	}
+
+	return confile.New(confile.DefaultYAMLEncodingCreator, path).Save(checkpoints)
+}
+
+// removePublishCheckpoint removes the checkpoint of a finished publish from path.
+func removePublishCheckpoint(path string, key publishKey) error {
+	checkpoints, err := loadPublishCheckpoints(path)
+	if err != nil {
+		return err
+	}
+
+	kept := checkpoints.Checkpoints[:0]
+	for _, c := range checkpoints.Checkpoints {
+		if c.Key != key {
+			kept = append(kept, c)
+		}
+	}
+	checkpoints.Checkpoints = kept
+
+	return confile.New(confile.DefaultYAMLEncodingCreator, path).Save(checkpoints)
+}
+
+func loadPublishCheckpoints(path string) (checkpoints publishCheckpoints, err error) {
+	err = confile.New(confile.DefaultYAMLEncodingCreator, path).Load(&checkpoints)
+	return checkpoints, err
+}
@@ -0,0 +1,51 @@
+package network
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishCheckpoint(t *testing.T) {
+	var (
+		path  = filepath.Join(t.TempDir(), publishCheckpointFile)
+		key   = publishKey{SPNChainID: "spn-1", Coordinator: "spn1foo", ChainID: "earth-1", SourceURL: "https://github.com/foo/earth"}
+		other = publishKey{SPNChainID: "spn-1", Coordinator: "spn1foo", ChainID: "mars-1", SourceURL: "https://github.com/foo/mars"}
+	)
+
+	checkpoint, ok, err := loadPublishCheckpoint(path, key)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, key, checkpoint.Key)
+
+	checkpoint.CoordinatorCreated = true
+	require.NoError(t, savePublishCheckpoint(path, checkpoint))
+	checkpoint.CampaignID = 3
+	require.NoError(t, savePublishCheckpoint(path, checkpoint))
+	require.NoError(t, savePublishCheckpoint(path, publishCheckpoint{Key: other, CampaignID: 4}))
+
+	got, ok, err := loadPublishCheckpoint(path, key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.True(t, got.CoordinatorCreated)
+	require.EqualValues(t, 3, got.CampaignID)
+	require.False(t, got.UpdatedAt.IsZero())
+
+	// the publishes of the chain on another SPN chain have their own checkpoints.
+	otherSPN := key
+	otherSPN.SPNChainID = "spn-2"
+	_, ok, err = loadPublishCheckpoint(path, otherSPN)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, removePublishCheckpoint(path, key))
+	_, ok, err = loadPublishCheckpoint(path, key)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	got, ok, err = loadPublishCheckpoint(path, other)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.EqualValues(t, 4, got.CampaignID)
+}
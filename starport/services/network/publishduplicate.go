@@ -0,0 +1,95 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+	profiletypes "github.com/tendermint/spn/x/profile/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/starport/starport/pkg/cosmoserror"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// DuplicateChainError is returned by Publish when the coordinator already published chains with the
+// same chain ID or the same source, the chain is published anyway with WithForce.
+type DuplicateChainError struct {
+	ChainID    string
+	Duplicates []networktypes.ChainLaunch
+}
+
+func (e *DuplicateChainError) Error() string {
+	launches := make([]string, 0, len(e.Duplicates))
+	for _, d := range e.Duplicates {
+		launches = append(launches, fmt.Sprintf("launch %d (%s, %s@%s)", d.ID, d.ChainID, d.SourceURL, d.SourceHash))
+	}
+	return fmt.Sprintf(
+		"the chain %s looks already published by the coordinator: %s",
+		e.ChainID,
+		strings.Join(launches, ", "),
+	)
+}
+
+// duplicateChains returns the chains published by the coordinator with the chain ID or with the
+// same source URL and hash.
+func (n Network) duplicateChains(ctx context.Context, chainID, sourceURL, sourceHash string) ([]networktypes.ChainLaunch, error) {
+	n.ev.Send(events.New(events.StatusOngoing, "Checking the chains published by the coordinator"))
+
+	res, err := profiletypes.
+		NewQueryClient(n.cosmos.QueryConn()).
+		CoordinatorByAddress(ctx, &profiletypes.QueryGetCoordinatorByAddressRequest{
+			Address: n.account.Address(networktypes.SPN),
+		})
+	if cosmoserror.Unwrap(err) == cosmoserror.ErrInvalidRequest || status.Code(err) == codes.NotFound {
+		// a new coordinator has no chains.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	coordinatorID := res.CoordinatorByAddress.CoordinatorID
+
+	var (
+		duplicates []networktypes.ChainLaunch
+		page       = &query.PageRequest{}
+	)
+	for {
+		chains, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).ChainAll(ctx, &launchtypes.QueryAllChainRequest{
+			Pagination: page,
+		})
+		if err != nil {
+			return nil, err
+		}
+		duplicates = append(duplicates, findDuplicateChains(chains.Chain, coordinatorID, chainID, sourceURL, sourceHash)...)
+
+		if chains.Pagination == nil || len(chains.Pagination.NextKey) == 0 {
+			return duplicates, nil
+		}
+		page = &query.PageRequest{Key: chains.Pagination.NextKey}
+	}
+}
+
+// findDuplicateChains returns the chains of the coordinator with the chain ID or with the same
+// source URL and hash.
+func findDuplicateChains(
+	chains []launchtypes.Chain,
+	coordinatorID uint64,
+	chainID,
+	sourceURL,
+	sourceHash string,
+) (duplicates []networktypes.ChainLaunch) {
+	for _, c := range chains {
+		if c.CoordinatorID != coordinatorID {
+			continue
+		}
+		if c.GenesisChainID == chainID || (c.SourceURL == sourceURL && c.SourceHash == sourceHash) {
+			duplicates = append(duplicates, networktypes.ToChainLaunch(c))
+		}
+	}
+	return duplicates
+}
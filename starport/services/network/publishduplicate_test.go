@@ -0,0 +1,28 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+)
+
+func TestFindDuplicateChains(t *testing.T) {
+	chains := []launchtypes.Chain{
+		{LaunchID: 1, CoordinatorID: 1, GenesisChainID: "earth-1", SourceURL: "https://github.com/foo/earth", SourceHash: "a"},
+		{LaunchID: 2, CoordinatorID: 1, GenesisChainID: "earth-2", SourceURL: "https://github.com/foo/earth", SourceHash: "b"},
+		{LaunchID: 3, CoordinatorID: 1, GenesisChainID: "mars-1", SourceURL: "https://github.com/foo/mars", SourceHash: "c"},
+		{LaunchID: 4, CoordinatorID: 2, GenesisChainID: "earth-1", SourceURL: "https://github.com/foo/earth", SourceHash: "a"},
+	}
+
+	duplicates := findDuplicateChains(chains, 1, "earth-1", "https://github.com/foo/earth", "b")
+	require.Len(t, duplicates, 2)
+	require.EqualValues(t, 1, duplicates[0].ID)
+	require.EqualValues(t, 2, duplicates[1].ID)
+
+	require.Empty(t, findDuplicateChains(chains, 1, "venus-1", "https://github.com/foo/venus", "d"))
+	require.Empty(t, findDuplicateChains(chains, 3, "earth-1", "https://github.com/foo/earth", "a"))
+
+	err := &DuplicateChainError{ChainID: "earth-1", Duplicates: duplicates[:1]}
+	require.Contains(t, err.Error(), "launch 1 (earth-1, https://github.com/foo/earth@a)")
+}
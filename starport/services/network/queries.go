@@ -2,8 +2,13 @@ package network
 
 import (
 	"context"
+	"strconv"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/pkg/errors"
+	campaigntypes "github.com/tendermint/spn/x/campaign/types"
 	launchtypes "github.com/tendermint/spn/x/launch/types"
 
 	"github.com/tendermint/starport/starport/pkg/events"
@@ -14,7 +19,7 @@ import (
 func (n Network) ChainLaunch(ctx context.Context, id uint64) (networktypes.ChainLaunch, error) {
 	n.ev.Send(events.New(events.StatusOngoing, "Fetching chain information"))
 
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).Chain(ctx, &launchtypes.QueryGetChainRequest{
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).Chain(ctx, &launchtypes.QueryGetChainRequest{
 		LaunchID: id,
 	})
 	if err != nil {
@@ -29,7 +34,7 @@ func (n Network) ChainLaunches(ctx context.Context) ([]networktypes.ChainLaunch,
 	var chainLaunches []networktypes.ChainLaunch
 
 	n.ev.Send(events.New(events.StatusOngoing, "Fetching chains information"))
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).ChainAll(ctx, &launchtypes.QueryAllChainRequest{})
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).ChainAll(ctx, &launchtypes.QueryAllChainRequest{})
 	if err != nil {
 		return chainLaunches, err
 	}
@@ -42,8 +47,34 @@ func (n Network) ChainLaunches(ctx context.Context) ([]networktypes.ChainLaunch,
 	return chainLaunches, nil
 }
 
-// GenesisInformation returns all the information to construct the genesis from a chain ID
+// Campaigns fetches the campaigns from Starport Network
+func (n Network) Campaigns(ctx context.Context) ([]networktypes.Campaign, error) {
+	var campaigns []networktypes.Campaign
+
+	n.ev.Send(events.New(events.StatusOngoing, "Fetching campaigns information"))
+	res, err := campaigntypes.NewQueryClient(n.cosmos.QueryConn()).CampaignAll(ctx, &campaigntypes.QueryAllCampaignRequest{})
+	if err != nil {
+		return campaigns, err
+	}
+
+	for _, campaign := range res.Campaign {
+		campaigns = append(campaigns, networktypes.ToCampaign(campaign))
+	}
+
+	return campaigns, nil
+}
+
+// GenesisInformation returns all the information to construct the genesis from a chain ID. the
+// information is queried at the latest SPN block height, so the genesis built from it is a
+// consistent snapshot that can be reproduced from the height kept in the information.
 func (n Network) GenesisInformation(ctx context.Context, launchID uint64) (gi networktypes.GenesisInformation, err error) {
+	status, err := n.cosmos.Status(ctx)
+	if err != nil {
+		return gi, errors.Wrap(err, "error querying SPN status")
+	}
+	height := status.SyncInfo.LatestBlockHeight
+	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+
 	genAccs, err := n.GenesisAccounts(ctx, launchID)
 	if err != nil {
 		return gi, errors.Wrap(err, "error querying genesis accounts")
@@ -59,13 +90,15 @@ func (n Network) GenesisInformation(ctx context.Context, launchID uint64) (gi ne
 		return gi, errors.Wrap(err, "error querying genesis validators")
 	}
 
-	return networktypes.NewGenesisInformation(genAccs, vestingAccs, genVals), nil
+	gi = networktypes.NewGenesisInformation(genAccs, vestingAccs, genVals)
+	gi.Height = height
+	return gi, nil
 }
 
 // GenesisAccounts returns the list of approved genesis accounts for a launch from SPN
 func (n Network) GenesisAccounts(ctx context.Context, launchID uint64) (genAccs []networktypes.GenesisAccount, err error) {
 	n.ev.Send(events.New(events.StatusOngoing, "Fetching genesis accounts"))
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).GenesisAccountAll(ctx, &launchtypes.QueryAllGenesisAccountRequest{
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).GenesisAccountAll(ctx, &launchtypes.QueryAllGenesisAccountRequest{
 		LaunchID: launchID,
 	})
 	if err != nil {
@@ -82,7 +115,7 @@ func (n Network) GenesisAccounts(ctx context.Context, launchID uint64) (genAccs
 // VestingAccounts returns the list of approved genesis vesting accounts for a launch from SPN
 func (n Network) VestingAccounts(ctx context.Context, launchID uint64) (vestingAccs []networktypes.VestingAccount, err error) {
 	n.ev.Send(events.New(events.StatusOngoing, "Fetching genesis vesting accounts"))
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).VestingAccountAll(ctx, &launchtypes.QueryAllVestingAccountRequest{
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).VestingAccountAll(ctx, &launchtypes.QueryAllVestingAccountRequest{
 		LaunchID: launchID,
 	})
 	if err != nil {
@@ -104,7 +137,7 @@ func (n Network) VestingAccounts(ctx context.Context, launchID uint64) (vestingA
 // GenesisValidators returns the list of approved genesis validators for a launch from SPN
 func (n Network) GenesisValidators(ctx context.Context, launchID uint64) (genVals []networktypes.GenesisValidator, err error) {
 	n.ev.Send(events.New(events.StatusOngoing, "Fetching genesis validators"))
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).GenesisValidatorAll(ctx, &launchtypes.QueryAllGenesisValidatorRequest{
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).GenesisValidatorAll(ctx, &launchtypes.QueryAllGenesisValidatorRequest{
 		LaunchID: launchID,
 	})
 	if err != nil {
@@ -117,3 +150,21 @@ func (n Network) GenesisValidators(ctx context.Context, launchID uint64) (genVal
 
 	return genVals, nil
 }
+
+// Peers returns the addresses of the peers of the approved genesis validators for a launch from SPN.
+func (n Network) Peers(ctx context.Context, launchID uint64) ([]string, error) {
+	validators, err := n.GenesisValidators(ctx, launchID)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]string, 0, len(validators))
+	for _, v := range validators {
+		peer, err := PeerAddress(v.Peer)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
@@ -2,10 +2,12 @@ package network
 
 import (
 	"context"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	launchtypes "github.com/tendermint/spn/x/launch/types"
 
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
 	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/services/network/networktypes"
 )
@@ -34,7 +36,7 @@ func RejectRequest(requestID uint64) Reviewal {
 
 // Requests fetches all the chain requests from SPN by launch id
 func (n Network) Requests(ctx context.Context, launchID uint64) ([]launchtypes.Request, error) {
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).RequestAll(ctx, &launchtypes.QueryAllRequestRequest{
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).RequestAll(ctx, &launchtypes.QueryAllRequestRequest{
 		LaunchID: launchID,
 	})
 	if err != nil {
@@ -45,7 +47,7 @@ func (n Network) Requests(ctx context.Context, launchID uint64) ([]launchtypes.R
 
 // Request fetches the chain request from SPN by launch and request id
 func (n Network) Request(ctx context.Context, launchID, requestID uint64) (launchtypes.Request, error) {
-	res, err := launchtypes.NewQueryClient(n.cosmos.Context).Request(ctx, &launchtypes.QueryGetRequestRequest{
+	res, err := launchtypes.NewQueryClient(n.cosmos.QueryConn()).Request(ctx, &launchtypes.QueryGetRequestRequest{
 		LaunchID:  launchID,
 		RequestID: requestID,
 	})
@@ -68,8 +70,22 @@ func (n Network) RequestFromIDs(ctx context.Context, launchID uint64, requestIDs
 	return reqs, nil
 }
 
+// ValidatorDuplicates returns the consensus keys, the node IDs and the operator addresses shared by
+// the approved validators and the pending requests to add validators of the launch.
+func (n Network) ValidatorDuplicates(ctx context.Context, launchID uint64) ([]networktypes.ValidatorDuplicate, error) {
+	validators, err := n.GenesisValidators(ctx, launchID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := n.Requests(ctx, launchID)
+	if err != nil {
+		return nil, err
+	}
+	return networktypes.FindValidatorDuplicates(validators, requests), nil
+}
+
 // SubmitRequest submits reviewals for proposals in batch for chain.
-func (n Network) SubmitRequest(launchID uint64, reviewal ...Reviewal) error {
+func (n Network) SubmitRequest(ctx context.Context, launchID uint64, reviewal ...Reviewal) error {
 	n.ev.Send(events.New(events.StatusOngoing, "Submitting requests..."))
 
 	messages := make([]sdk.Msg, len(reviewal))
@@ -82,7 +98,9 @@ func (n Network) SubmitRequest(launchID uint64, reviewal ...Reviewal) error {
 		)
 	}
 
-	res, err := n.cosmos.BroadcastTx(n.account.Name, messages...)
+	res, err := n.cosmos.
+		WithBroadcastOptions(cosmosclient.WithMemo(fmt.Sprintf("starport request %d", launchID))).
+		BroadcastTxAndWait(ctx, n.account.Name, messages...)
 	if err != nil {
 		return err
 	}
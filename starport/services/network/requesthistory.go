@@ -0,0 +1,68 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/starport/starport/pkg/confile"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// requestHistoryFile is the file that the records of the requests are kept in.
+const requestHistoryFile = "requests.yml"
+
+// DefaultRequestHistoryPath returns the default path of the file that the records of the requests
+// sent and tracked on this machine are kept in.
+func DefaultRequestHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, networktypes.SPN, requestHistoryFile), nil
+}
+
+type requestHistory struct {
+	Requests []networktypes.RequestRecord `yaml:"requests"`
+}
+
+// loadRequestRecord loads the record of a request from the history at path, ok is false when
+// the request has no record.
+func loadRequestRecord(path string, launchID, requestID uint64) (record networktypes.RequestRecord, ok bool, err error) {
+	history, err := loadRequestHistory(path)
+	if err != nil {
+		return record, false, err
+	}
+	for _, r := range history.Requests {
+		if r.LaunchID == launchID && r.RequestID == requestID {
+			return r, true, nil
+		}
+	}
+	return record, false, nil
+}
+
+// saveRequestRecord adds or updates the record of a request in the history at path.
+func saveRequestRecord(path string, record networktypes.RequestRecord) error {
+	history, err := loadRequestHistory(path)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, r := range history.Requests {
+		if r.LaunchID == record.LaunchID && r.RequestID == record.RequestID {
+			history.Requests[i] = record
+			found = true
+			break
+		}
+	}
+	if !found {
+		history.Requests = append(history.Requests, record)
+	}
+
+	return confile.New(confile.DefaultYAMLEncodingCreator, path).Save(history)
+}
+
+func loadRequestHistory(path string) (history requestHistory, err error) {
+	err = confile.New(confile.DefaultYAMLEncodingCreator, path).Load(&history)
+	return history, err
+}
@@ -0,0 +1,213 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	launchtypes "github.com/tendermint/spn/x/launch/types"
+
+	"github.com/tendermint/starport/starport/pkg/cosmoserror"
+	"github.com/tendermint/starport/starport/pkg/ctxticker"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// defaultTrackInterval is the default duration between the checks of a tracked request.
+const defaultTrackInterval = time.Second * 10
+
+// errRequestSettled stops tracking a request.
+var errRequestSettled = errors.New("request settled")
+
+// RequestStatus returns the record of a request with its current status. the submitted and tracked
+// requests are recorded on this machine, so their statuses are known after they are settled and
+// removed from SPN. a settled request is approved when its content is applied to the launch.
+func (n Network) RequestStatus(ctx context.Context, launchID, requestID uint64) (networktypes.RequestRecord, error) {
+	record, ok, err := loadRequestRecord(n.requestHistoryPath, launchID, requestID)
+	if err != nil {
+		return record, err
+	}
+
+	request, err := n.Request(ctx, launchID, requestID)
+	switch {
+	case err == nil:
+		if !ok {
+			record = networktypes.NewRequestRecord(request)
+		}
+	case cosmoserror.Unwrap(err) != cosmoserror.ErrInvalidRequest:
+		return record, err
+	case !ok:
+		return record, fmt.Errorf("request %d of launch %d is not pending and it's not recorded on this machine, its status is unknown",
+			requestID, launchID)
+	case !record.IsSettled():
+		applied, err := n.isRequestApplied(ctx, record)
+		if err != nil {
+			return record, err
+		}
+		status := networktypes.RequestRejected
+		if applied {
+			status = networktypes.RequestApproved
+		}
+		record.SetStatus(status, time.Now().UTC())
+	}
+
+	return record, saveRequestRecord(n.requestHistoryPath, record)
+}
+
+// isRequestApplied checks if the content of the request is applied to the launch.
+func (n Network) isRequestApplied(ctx context.Context, record networktypes.RequestRecord) (bool, error) {
+	var (
+		query    = launchtypes.NewQueryClient(n.cosmos.QueryConn())
+		launchID = record.LaunchID
+		address  = record.Address
+	)
+
+	genesisAccountExists := func() (bool, error) {
+		return queryExists(query.GenesisAccount(ctx, &launchtypes.QueryGetGenesisAccountRequest{
+			LaunchID: launchID,
+			Address:  address,
+		}))
+	}
+	vestingAccountExists := func() (bool, error) {
+		return queryExists(query.VestingAccount(ctx, &launchtypes.QueryGetVestingAccountRequest{
+			LaunchID: launchID,
+			Address:  address,
+		}))
+	}
+	genesisValidatorExists := func() (bool, error) {
+		return queryExists(query.GenesisValidator(ctx, &launchtypes.QueryGetGenesisValidatorRequest{
+			LaunchID: launchID,
+			Address:  address,
+		}))
+	}
+
+	switch record.Type {
+	case networktypes.RequestGenesisAccount:
+		return genesisAccountExists()
+	case networktypes.RequestVestingAccount:
+		return vestingAccountExists()
+	case networktypes.RequestGenesisValidator:
+		return genesisValidatorExists()
+	case networktypes.RequestAccountRemoval:
+		exists, err := genesisAccountExists()
+		if err != nil || exists {
+			return false, err
+		}
+		exists, err = vestingAccountExists()
+		return !exists, err
+	case networktypes.RequestValidatorRemoval:
+		exists, err := genesisValidatorExists()
+		return !exists, err
+	default:
+		return false, fmt.Errorf("unknown type %q of request %d", record.Type, record.RequestID)
+	}
+}
+
+// queryExists checks if the object queried from SPN exists.
+func queryExists(_ interface{}, err error) (bool, error) {
+	if cosmoserror.Unwrap(err) == cosmoserror.ErrInvalidRequest {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// recordRequest records a submitted request.
+func (n Network) recordRequest(launchID, requestID uint64, requestType networktypes.RequestType, address string) error {
+	record := networktypes.RequestRecord{
+		LaunchID:  launchID,
+		RequestID: requestID,
+		Type:      requestType,
+		Address:   address,
+	}
+	record.SetStatus(networktypes.RequestPending, time.Now().UTC())
+	return saveRequestRecord(n.requestHistoryPath, record)
+}
+
+// trackOptions holds info about how to track a request.
+type trackOptions struct {
+	interval time.Duration
+	webhooks []string
+}
+
+// TrackOption configures tracking a request.
+type TrackOption func(*trackOptions)
+
+// WithTrackInterval sets the duration between the checks of the request's status.
+func WithTrackInterval(interval time.Duration) TrackOption {
+	return func(o *trackOptions) {
+		o.interval = interval
+	}
+}
+
+// WithWebhook notifies the URL once the request is settled. the record of the request is posted
+// to the URL as JSON.
+func WithWebhook(url string) TrackOption {
+	return func(o *trackOptions) {
+		o.webhooks = append(o.webhooks, url)
+	}
+}
+
+// TrackRequest watches the request until it's approved or rejected and returns its record.
+func (n Network) TrackRequest(ctx context.Context, launchID, requestID uint64, options ...TrackOption) (networktypes.RequestRecord, error) {
+	o := trackOptions{
+		interval: defaultTrackInterval,
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	n.ev.Send(events.New(events.StatusOngoing, fmt.Sprintf("Waiting for request %d to be settled", requestID)))
+
+	var record networktypes.RequestRecord
+	err := ctxticker.DoNow(ctx, o.interval, func() (err error) {
+		if record, err = n.RequestStatus(ctx, launchID, requestID); err != nil {
+			return err
+		}
+		if record.IsSettled() {
+			return errRequestSettled
+		}
+		return nil
+	})
+	if !errors.Is(err, errRequestSettled) {
+		return record, err
+	}
+
+	n.ev.Send(events.New(events.StatusDone, fmt.Sprintf("Request %d %s", requestID, record.Status)))
+
+	for _, url := range o.webhooks {
+		if err := notifyWebhook(ctx, url, record); err != nil {
+			return record, err
+		}
+	}
+
+	return record, nil
+}
+
+// notifyWebhook posts the record of the request to the URL.
+func notifyWebhook(ctx context.Context, url string, record networktypes.RequestRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with %s", url, res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+func TestRequestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spn", requestHistoryFile)
+
+	_, ok, err := loadRequestRecord(path, 1, 2)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	record := networktypes.RequestRecord{
+		LaunchID:  1,
+		RequestID: 2,
+		Type:      networktypes.RequestGenesisValidator,
+		Address:   "spn1abc",
+	}
+	record.SetStatus(networktypes.RequestPending, time.Unix(1000, 0).UTC())
+	require.NoError(t, saveRequestRecord(path, record))
+	require.NoError(t, saveRequestRecord(path, networktypes.RequestRecord{LaunchID: 1, RequestID: 3}))
+
+	record.SetStatus(networktypes.RequestApproved, time.Unix(2000, 0).UTC())
+	require.NoError(t, saveRequestRecord(path, record))
+
+	got, ok, err := loadRequestRecord(path, 1, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, record, got)
+
+	history, err := loadRequestHistory(path)
+	require.NoError(t, err)
+	require.Len(t, history.Requests, 2)
+}
+
+func TestNotifyWebhook(t *testing.T) {
+	record := networktypes.RequestRecord{
+		LaunchID:  1,
+		RequestID: 2,
+		Status:    networktypes.RequestRejected,
+	}
+
+	var got networktypes.RequestRecord
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	require.NoError(t, notifyWebhook(context.Background(), server.URL, record))
+	require.Equal(t, record, got)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	require.Error(t, notifyWebhook(context.Background(), failing.URL, record))
+}
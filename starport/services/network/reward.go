@@ -0,0 +1,56 @@
+package network
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	monitoringctypes "github.com/tendermint/spn/x/monitoringc/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/services/network/networktypes"
+)
+
+// MonitoringStatus fetches the status of the relay of the monitoring data of a launch to SPN, the
+// rewards of the testnet are distributed from the monitoring data.
+func (n Network) MonitoringStatus(ctx context.Context, launchID uint64) (networktypes.MonitoringStatus, error) {
+	n.ev.Send(events.New(events.StatusOngoing, "Fetching monitoring information"))
+
+	monitoringStatus := networktypes.MonitoringStatus{LaunchID: launchID}
+	client := monitoringctypes.NewQueryClient(n.cosmos.QueryConn())
+
+	providerRes, err := client.ProviderClientID(ctx, &monitoringctypes.QueryGetProviderClientIDRequest{
+		LaunchID: launchID,
+	})
+	switch {
+	case status.Code(err) == codes.NotFound:
+	case err != nil:
+		return monitoringStatus, errors.Wrap(err, "error querying the provider client")
+	default:
+		monitoringStatus.ProviderClientID = providerRes.ProviderClientID.ClientID
+	}
+
+	verifiedRes, err := client.VerifiedClientIds(ctx, &monitoringctypes.QueryVerifiedClientIdsRequest{
+		LaunchID: launchID,
+	})
+	switch {
+	case status.Code(err) == codes.NotFound:
+	case err != nil:
+		return monitoringStatus, errors.Wrap(err, "error querying the verified clients")
+	default:
+		monitoringStatus.VerifiedClientIDs = verifiedRes.ClientIds
+	}
+
+	channelsRes, err := client.LaunchIDFromChannelIDAll(ctx, &monitoringctypes.QueryAllLaunchIDFromChannelIDRequest{})
+	if err != nil {
+		return monitoringStatus, errors.Wrap(err, "error querying the monitoring channels")
+	}
+	for _, channel := range channelsRes.LaunchIDFromChannelID {
+		if channel.LaunchID == launchID {
+			monitoringStatus.ChannelIDs = append(monitoringStatus.ChannelIDs, channel.ChannelID)
+		}
+	}
+
+	return monitoringStatus, nil
+}
@@ -0,0 +1,45 @@
+# {{ .ChainID }}
+
+The chain `{{ .ChainID }}` is published on Starport Network and looks for validators to launch it.
+
+| | |
+| --- | --- |
+| Launch ID | {{ .LaunchID }} |
+| Campaign ID | {{ .CampaignID }} |
+| Chain ID | {{ .ChainID }} |
+| Source | {{ .SourceURL }} |
+| Commit | {{ .SourceHash }} |
+{{- with .GenesisURL }}
+| Genesis | {{ . }} |
+{{- end }}
+
+## Hardware requirements
+
+The node of each validator needs at least:
+
+- {{ .Hardware.CPU }} CPU cores
+- {{ .Hardware.Memory }} of memory
+- {{ .Hardware.Disk }} of disk
+
+## Timeline
+
+- Published: {{ .Timeline.PublishedAt.Format "2006-01-02 15:04 MST" }}
+{{- with .Timeline.GentxDeadline }}
+- Requests to join accepted until: {{ .Format "2006-01-02 15:04 MST" }}
+{{- end }}
+{{- with .Timeline.LaunchTime }}
+- Planned launch: {{ .Format "2006-01-02 15:04 MST" }}
+{{- else }}
+- Planned launch: announced by the coordinator
+{{- end }}
+
+## Join as a validator
+
+Install [Starport](https://docs.starport.network/guide/install.html) and run:
+{{ range $i, $step := .Join }}
+{{ inc $i }}. {{ $step.Description }}:
+
+   ```bash
+   {{ $step.Command }}
+   ```
+{{ end -}}
@@ -0,0 +1,325 @@
+// Package oplog persists the events of the long operations, e.g. publishing a chain, to a log per
+// run of the operation so the past runs can be reviewed with "starport logs". each entry of a run
+// is timestamped and has the ID of the run to correlate the entries.
+package oplog
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/events"
+)
+
+const (
+	logsDir = "logs"
+	logExt  = ".jsonl"
+
+	// timeLayout is the layout of the time in the file names of the runs, the runs are sorted by
+	// their start time when their file names are sorted.
+	timeLayout = "20060102T150405.000000000Z"
+
+	// MaxRuns is the number of runs kept for an operation, the oldest runs are removed when a
+	// new run starts.
+	MaxRuns = 20
+)
+
+// ErrRunNotFound is returned when a run of an operation doesn't exist.
+var ErrRunNotFound = errors.New("run not found")
+
+// EntryKind is the kind of an entry of the log of a run.
+type EntryKind string
+
+const (
+	EntryStart EntryKind = "start"
+	EntryEvent EntryKind = "event"
+	EntryEnd   EntryKind = "end"
+)
+
+// Entry is an entry of the log of a run.
+type Entry struct {
+	Time  time.Time `json:"time"`
+	RunID string    `json:"run_id"`
+	Kind  EntryKind `json:"kind"`
+
+	// Args are the arguments of the operation, they are only set for the start entry.
+	Args []string `json:"args,omitempty"`
+
+	// Ongoing and Description describe the event of an event entry.
+	Ongoing     bool   `json:"ongoing,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Error is the error of the operation, it's only set for the end entry of a failed run.
+	Error string `json:"error,omitempty"`
+}
+
+// Run is a run of an operation.
+type Run struct {
+	ID        string    `json:"id"`
+	Operation string    `json:"operation"`
+	Path      string    `json:"path"`
+	Start     time.Time `json:"start"`
+
+	// End is zero when the run is not finished, e.g. it's still running or it was killed.
+	End time.Time `json:"end"`
+
+	Error   string  `json:"error,omitempty"`
+	Entries []Entry `json:"entries,omitempty"`
+}
+
+// Finished returns true if the run ended, successfully or not.
+func (r Run) Finished() bool {
+	return !r.End.IsZero()
+}
+
+// Manager manages the logs of the operations kept in a dir.
+type Manager struct {
+	dir string
+}
+
+// New returns a manager keeping the logs in the logs dir of configDir.
+func New(configDir string) Manager {
+	return Manager{filepath.Join(configDir, logsDir)}
+}
+
+// Default returns a manager keeping the logs in the config dir of Starport.
+func Default() (Manager, error) {
+	dir, err := chainconfig.ConfigDirPath()
+	if err != nil {
+		return Manager{}, err
+	}
+	return New(dir), nil
+}
+
+// Operations returns the operations that have runs, sorted by name.
+func (m Manager) Operations() ([]string, error) {
+	entries, err := os.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []string
+	for _, e := range entries {
+		if e.IsDir() {
+			operations = append(operations, e.Name())
+		}
+	}
+	return operations, nil
+}
+
+// Runs returns the runs of the operation, from the latest to the oldest.
+func (m Manager) Runs(operation string) ([]Run, error) {
+	paths, err := m.runPaths(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]Run, 0, len(paths))
+	for i := len(paths) - 1; i >= 0; i-- {
+		r, err := readRun(operation, paths[i])
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, r)
+	}
+	return runs, nil
+}
+
+// Run returns the run of the operation with the ID, or the latest run when the ID is empty.
+func (m Manager) Run(operation, id string) (Run, error) {
+	paths, err := m.runPaths(operation)
+	if err != nil {
+		return Run{}, err
+	}
+	for i := len(paths) - 1; i >= 0; i-- {
+		if id == "" || strings.HasSuffix(strings.TrimSuffix(paths[i], logExt), "-"+id) {
+			return readRun(operation, paths[i])
+		}
+	}
+	return Run{}, fmt.Errorf("%w: %s %s", ErrRunNotFound, operation, id)
+}
+
+// runPaths returns the paths of the logs of the runs of the operation, from the oldest to the latest.
+func (m Manager) runPaths(operation string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(m.dir, operation, "*"+logExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Start starts a new run of the operation with the arguments, the oldest runs are removed to only
+// keep MaxRuns runs of the operation.
+func (m Manager) Start(operation string, args []string) (*Log, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(m.dir, operation)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	paths, err := m.runPaths(operation)
+	if err != nil {
+		return nil, err
+	}
+	for len(paths) >= MaxRuns {
+		if err := os.Remove(paths[0]); err != nil {
+			return nil, err
+		}
+		paths = paths[1:]
+	}
+
+	start := time.Now().UTC()
+	name := fmt.Sprintf("%s-%s%s", start.Format(timeLayout), id, logExt)
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{f: f, id: id}
+	if err := l.write(Entry{Time: start, Kind: EntryStart, Args: args}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Log is the log of a run of an operation.
+type Log struct {
+	mu   sync.Mutex
+	f    *os.File
+	id   string
+	done []chan struct{}
+}
+
+// ID returns the ID of the run.
+func (l *Log) ID() string {
+	return l.id
+}
+
+// Path returns the path of the log of the run.
+func (l *Log) Path() string {
+	return l.f.Name()
+}
+
+// Write writes the event to the log.
+func (l *Log) Write(e events.Event) error {
+	return l.write(Entry{
+		Time:        time.Now().UTC(),
+		Kind:        EntryEvent,
+		Ongoing:     e.IsOngoing(),
+		Description: e.Description,
+	})
+}
+
+// Record returns a bus that writes the events sent to it to the log before forwarding them to bus.
+// bus is shut down once the returned bus is shut down and all its events are forwarded.
+func (l *Log) Record(bus events.Bus) events.Bus {
+	recorded := events.NewBus()
+	done := make(chan struct{})
+
+	l.mu.Lock()
+	l.done = append(l.done, done)
+	l.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		defer bus.Shutdown()
+
+		for e := range recorded {
+			// the log never blocks the operation, the events that can't be written are skipped.
+			_ = l.Write(e)
+			bus.Send(e)
+		}
+	}()
+	return recorded
+}
+
+// Close ends the run with the error of the operation, it waits for the events of the recorded
+// buses that are shut down to be written.
+func (l *Log) Close(err error) error {
+	l.mu.Lock()
+	done := l.done
+	l.mu.Unlock()
+	for _, d := range done {
+		select {
+		case <-d:
+		case <-time.After(time.Second):
+		}
+	}
+
+	end := Entry{Time: time.Now().UTC(), Kind: EntryEnd}
+	if err != nil {
+		end.Error = err.Error()
+	}
+	if werr := l.write(end); werr != nil {
+		l.f.Close()
+		return werr
+	}
+	return l.f.Close()
+}
+
+func (l *Log) write(e Entry) error {
+	e.RunID = l.id
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.f.Write(append(data, '\n'))
+	return err
+}
+
+func readRun(operation, path string) (Run, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Run{}, err
+	}
+	defer f.Close()
+
+	r := Run{Operation: operation, Path: path}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var e Entry
+		// the lines that can't be decoded, e.g. a line cut by a crash, are skipped.
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		switch e.Kind {
+		case EntryStart:
+			r.ID, r.Start = e.RunID, e.Time
+		case EntryEnd:
+			r.End, r.Error = e.Time, e.Error
+		}
+		r.Entries = append(r.Entries, e)
+	}
+	return r, scanner.Err()
+}
+
+func newID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
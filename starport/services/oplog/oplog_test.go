@@ -0,0 +1,80 @@
+package oplog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/starport/starport/pkg/events"
+)
+
+func TestLog(t *testing.T) {
+	m := New(t.TempDir())
+
+	l, err := m.Start("network-chain-publish", []string{"github.com/foo/bar"})
+	require.NoError(t, err)
+
+	var (
+		bus      = events.NewBus()
+		received = make(chan []events.Event)
+	)
+	go func() {
+		var evs []events.Event
+		for e := range bus {
+			evs = append(evs, e)
+		}
+		received <- evs
+	}()
+
+	recorded := l.Record(bus)
+	recorded.Send(events.New(events.StatusOngoing, "Fetching the source code"))
+	recorded.Send(events.New(events.StatusDone, "Source code fetched"))
+	recorded.Shutdown()
+	require.Len(t, <-received, 2)
+	require.NoError(t, l.Close(errors.New("publish failed")))
+
+	operations, err := m.Operations()
+	require.NoError(t, err)
+	require.Equal(t, []string{"network-chain-publish"}, operations)
+
+	r, err := m.Run("network-chain-publish", "")
+	require.NoError(t, err)
+	require.Equal(t, l.ID(), r.ID)
+	require.True(t, r.Finished())
+	require.Equal(t, "publish failed", r.Error)
+	require.Len(t, r.Entries, 4)
+	require.Equal(t, []string{"github.com/foo/bar"}, r.Entries[0].Args)
+	require.Equal(t, EntryEvent, r.Entries[1].Kind)
+	require.True(t, r.Entries[1].Ongoing)
+	require.Equal(t, "Source code fetched", r.Entries[2].Description)
+	for _, e := range r.Entries {
+		require.Equal(t, l.ID(), e.RunID)
+	}
+
+	_, err = m.Run("network-chain-publish", "unknown")
+	require.ErrorIs(t, err, ErrRunNotFound)
+}
+
+func TestRuns(t *testing.T) {
+	m := New(t.TempDir())
+
+	var ids []string
+	for i := 0; i < MaxRuns+2; i++ {
+		l, err := m.Start("chain-serve", nil)
+		require.NoError(t, err)
+		require.NoError(t, l.Close(nil))
+		ids = append(ids, l.ID())
+	}
+
+	runs, err := m.Runs("chain-serve")
+	require.NoError(t, err)
+	require.Len(t, runs, MaxRuns)
+	require.Equal(t, ids[len(ids)-1], runs[0].ID)
+	require.Equal(t, ids[2], runs[len(runs)-1].ID)
+
+	r, err := m.Run("chain-serve", ids[5])
+	require.NoError(t, err)
+	require.Equal(t, ids[5], r.ID)
+	require.Empty(t, r.Error)
+}
@@ -0,0 +1,23 @@
+package scaffolder
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/starport/starport/templates/module"
+)
+
+// isAutoCLIModule checks if the CLI commands of the module are generated from its services, the
+// commands of the components of these modules are declared in the AutoCLI options of the module.
+func isAutoCLIModule(appPath string, moduleName string) (bool, error) {
+	absPath, err := filepath.Abs(filepath.Join(appPath, moduleDir, moduleName, module.PathAutoCLI))
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(absPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return true, err
+}
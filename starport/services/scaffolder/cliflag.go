@@ -0,0 +1,86 @@
+package scaffolder
+
+import (
+	"fmt"
+
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+	"github.com/tendermint/starport/starport/templates/field"
+)
+
+// sdkFlags are the flags added by the Cosmos SDK to the tx and query commands, the fields of a
+// command that reads its fields from flags can't be named as them.
+var sdkFlags = map[string]struct{}{
+	"account-number":  {},
+	"algo":            {},
+	"broadcast-mode":  {},
+	"chain-id":        {},
+	"count-total":     {},
+	"dry-run":         {},
+	"fee-account":     {},
+	"fees":            {},
+	"from":            {},
+	"gas":             {},
+	"gas-adjustment":  {},
+	"gas-prices":      {},
+	"generate-only":   {},
+	"height":          {},
+	"help":            {},
+	"home":            {},
+	"keyring-backend": {},
+	"keyring-dir":     {},
+	"ledger":          {},
+	"limit":           {},
+	"log_format":      {},
+	"log_level":       {},
+	"node":            {},
+	"note":            {},
+	"offline":         {},
+	"offset":          {},
+	"output":          {},
+	"page":            {},
+	"page-key":        {},
+	"reverse":         {},
+	"sequence":        {},
+	"sign-mode":       {},
+	"timeout-height":  {},
+	"trace":           {},
+	"yes":             {},
+}
+
+// cliFlagFields sets the default values of the CLI flags of the fields and checks that the flags
+// don't conflict with the flags of the Cosmos SDK.
+// defaults are the default values by the names of the fields.
+func cliFlagFields(fields field.Fields, defaults map[string]string) (field.Fields, error) {
+	for _, f := range fields {
+		if _, ok := sdkFlags[f.Name.Kebab]; ok {
+			return nil, fmt.Errorf("%s can't be used as a field name, the command already has a --%s flag", f.Name.Original, f.Name.Kebab)
+		}
+	}
+
+	for name, value := range defaults {
+		defaultName, err := multiformatname.NewName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		i := fieldIndex(fields, defaultName)
+		if i < 0 {
+			return nil, fmt.Errorf("%s isn't a field of the command", name)
+		}
+		fields[i].Default = value
+		if _, err := fields[i].CLIFlagDefault(); err != nil {
+			return nil, fmt.Errorf("invalid default value of %s: %s", name, err.Error())
+		}
+	}
+	return fields, nil
+}
+
+// fieldIndex returns the index of the field with the name in fields, -1 when it's not found.
+func fieldIndex(fields field.Fields, name multiformatname.Name) int {
+	for i, f := range fields {
+		if f.Name.LowerCamel == name.LowerCamel {
+			return i
+		}
+	}
+	return -1
+}
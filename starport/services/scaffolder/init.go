@@ -11,6 +11,7 @@ import (
 	"github.com/tendermint/flutter/v2"
 	"github.com/tendermint/vue"
 
+	"github.com/tendermint/starport/starport/pkg/chaintemplate"
 	"github.com/tendermint/starport/starport/pkg/giturl"
 	"github.com/tendermint/starport/starport/pkg/gomodulepath"
 	"github.com/tendermint/starport/starport/pkg/localfs"
@@ -28,8 +29,38 @@ var (
 	}
 )
 
+// initOptions holds info about how to initialize an app.
+type initOptions struct {
+	template       *chaintemplate.Template
+	templateValues map[string]string
+}
+
+// InitOption configures the initialization of an app.
+type InitOption func(*initOptions)
+
+// FromTemplate initializes the app from the chain template instead of the built-in template,
+// values are the values of the variables declared by the template.
+func FromTemplate(t *chaintemplate.Template, values map[string]string) InitOption {
+	return func(o *initOptions) {
+		o.template = t
+		o.templateValues = values
+	}
+}
+
 // Init initializes a new app with name and given options.
-func Init(tracer *placeholder.Tracer, root, name, addressPrefix string, noDefaultModule bool) (path string, err error) {
+func Init(
+	tracer *placeholder.Tracer,
+	root,
+	name,
+	addressPrefix string,
+	noDefaultModule bool,
+	options ...InitOption,
+) (path string, err error) {
+	var o initOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
 	if root, err = filepath.Abs(root); err != nil {
 		return "", err
 	}
@@ -42,7 +73,12 @@ func Init(tracer *placeholder.Tracer, root, name, addressPrefix string, noDefaul
 	path = filepath.Join(root, pathInfo.Root)
 
 	// create the project
-	if err := generate(tracer, pathInfo, addressPrefix, path, noDefaultModule); err != nil {
+	if o.template != nil {
+		err = generateFromTemplate(o.template, o.templateValues, pathInfo, addressPrefix, path)
+	} else {
+		err = generate(tracer, pathInfo, addressPrefix, path, noDefaultModule)
+	}
+	if err != nil {
 		return "", err
 	}
 
@@ -122,6 +158,35 @@ func generate(
 	return Vue(filepath.Join(absRoot, "vue"))
 }
 
+// generateFromTemplate generates the app from the chain template. the options of the built-in
+// template are available to the chain template as variables.
+func generateFromTemplate(
+	t *chaintemplate.Template,
+	values map[string]string,
+	pathInfo gomodulepath.Path,
+	addressPrefix,
+	absRoot string,
+) error {
+	gu, err := giturl.Parse(pathInfo.RawPath)
+	if err != nil {
+		return err
+	}
+
+	values, err = t.Values(values, map[string]string{
+		"ModulePath":       pathInfo.RawPath,
+		"AppName":          pathInfo.Package,
+		"OwnerName":        owner(pathInfo.RawPath),
+		"OwnerAndRepoName": gu.UserAndRepo(),
+		"BinaryNamePrefix": pathInfo.Root,
+		"AddressPrefix":    addressPrefix,
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.Render(absRoot, values)
+}
+
 // Vue scaffolds a Vue.js app for a chain.
 func Vue(path string) error {
 	return localfs.Save(vue.Boilerplate(), path)
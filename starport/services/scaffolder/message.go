@@ -3,6 +3,9 @@ package scaffolder
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/gobuffalo/genny"
 
@@ -15,11 +18,17 @@ import (
 	modulecreate "github.com/tendermint/starport/starport/templates/module/create"
 )
 
+// sequenceIDField is the response field that is assigned from the sequence of a message
+const sequenceIDField = "id"
+
 // messageOptions represents configuration for the message scaffolding
 type messageOptions struct {
 	description       string
 	signer            string
 	withoutSimulation bool
+	sequence          string
+	cliFlags          bool
+	cliDefaults       map[string]string
 }
 
 // newMessageOptions returns a messageOptions with default options
@@ -54,6 +63,23 @@ func WithoutSimulation() MessageOption {
 	}
 }
 
+// WithSequence assigns the id of the message response from an auto-incrementing sequence stored in
+// the keeper, the sequence is shared by the messages that use the same name
+func WithSequence(name string) MessageOption {
+	return func(m *messageOptions) {
+		m.sequence = name
+	}
+}
+
+// WithCLIFlags makes the CLI command of the message read the fields from flags instead of
+// positional args, defaults are the default values of the flags by the names of the fields
+func WithCLIFlags(defaults map[string]string) MessageOption {
+	return func(m *messageOptions) {
+		m.cliFlags = true
+		m.cliDefaults = defaults
+	}
+}
+
 // AddMessage adds a new message to scaffolded app
 func (s Scaffolder) AddMessage(
 	ctx context.Context,
@@ -97,6 +123,32 @@ func (s Scaffolder) AddMessage(
 	if err != nil {
 		return sm, err
 	}
+	if scaffoldingOpts.cliFlags {
+		parsedMsgFields, err = cliFlagFields(parsedMsgFields, scaffoldingOpts.cliDefaults)
+		if err != nil {
+			return sm, err
+		}
+	}
+
+	// Assign the id of the response from the sequence
+	var (
+		sequence       multiformatname.Name
+		sequenceExists bool
+	)
+	if scaffoldingOpts.sequence != "" {
+		sequence, err = multiformatname.NewName(scaffoldingOpts.sequence)
+		if err != nil {
+			return sm, err
+		}
+		resFields, err = sequenceResponseFields(resFields)
+		if err != nil {
+			return sm, err
+		}
+		sequenceExists, err = checkSequenceExists(s.path, moduleName, sequence)
+		if err != nil {
+			return sm, err
+		}
+	}
 
 	// Check and parse provided response fields
 	if err := checkCustomTypes(ctx, s.path, moduleName, resFields); err != nil {
@@ -112,20 +164,29 @@ func (s Scaffolder) AddMessage(
 		return sm, err
 	}
 
+	autoCLI, err := isAutoCLIModule(s.path, moduleName)
+	if err != nil {
+		return sm, err
+	}
+
 	var (
 		g    *genny.Generator
 		opts = &message.Options{
-			AppName:      s.modpath.Package,
-			AppPath:      s.path,
-			ModulePath:   s.modpath.RawPath,
-			ModuleName:   moduleName,
-			OwnerName:    owner(s.modpath.RawPath),
-			MsgName:      name,
-			Fields:       parsedMsgFields,
-			ResFields:    parsedResFields,
-			MsgDesc:      scaffoldingOpts.description,
-			MsgSigner:    mfSigner,
-			NoSimulation: scaffoldingOpts.withoutSimulation,
+			AppName:        s.modpath.Package,
+			AppPath:        s.path,
+			ModulePath:     s.modpath.RawPath,
+			ModuleName:     moduleName,
+			OwnerName:      owner(s.modpath.RawPath),
+			MsgName:        name,
+			Fields:         parsedMsgFields,
+			ResFields:      parsedResFields,
+			MsgDesc:        scaffoldingOpts.description,
+			MsgSigner:      mfSigner,
+			NoSimulation:   scaffoldingOpts.withoutSimulation,
+			Sequence:       sequence,
+			SequenceExists: sequenceExists,
+			CLIFlags:       scaffoldingOpts.cliFlags,
+			AutoCLI:        autoCLI,
 		}
 	)
 
@@ -183,3 +244,32 @@ func checkForbiddenMessageField(name string) error {
 
 	return checkGoReservedWord(name)
 }
+
+// sequenceResponseFields returns the response fields with the id assigned from the sequence, the id
+// is added as the first field when it's not already a response field
+func sequenceResponseFields(resFields []string) ([]string, error) {
+	for _, f := range resFields {
+		name, typ := f, string(datatype.String)
+		if i := strings.Index(f, ":"); i != -1 {
+			name, typ = f[:i], f[i+1:]
+		}
+		if strings.ToLower(name) != sequenceIDField {
+			continue
+		}
+		if typ != string(datatype.Uint) {
+			return nil, fmt.Errorf("the %s response field is assigned from the sequence and must be a %s, not a %s", name, datatype.Uint, typ)
+		}
+		return resFields, nil
+	}
+	return append([]string{fmt.Sprintf("%s:%s", sequenceIDField, datatype.Uint)}, resFields...), nil
+}
+
+// checkSequenceExists checks if the sequence is already generated in the module by another message
+func checkSequenceExists(appPath, moduleName string, sequence multiformatname.Name) (bool, error) {
+	path := filepath.Join(appPath, "x", moduleName, "keeper", sequence.Snake+"_sequence.go")
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/tendermint/starport/starport/pkg/cmdrunner"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
 	appanalysis "github.com/tendermint/starport/starport/pkg/cosmosanalysis/app"
+	keeperanalysis "github.com/tendermint/starport/starport/pkg/cosmosanalysis/keeper"
 	"github.com/tendermint/starport/starport/pkg/cosmosver"
 	"github.com/tendermint/starport/starport/pkg/gocmd"
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
@@ -102,6 +103,18 @@ type moduleCreationOptions struct {
 
 	// dependencies list of module dependencies
 	dependencies []modulecreate.Dependency
+
+	// transientStore true if the module has a transient store
+	transientStore bool
+
+	// memoryStore true if the memory store of the module is mounted
+	memoryStore bool
+
+	// capability true if the module owns object capabilities
+	capability bool
+
+	// autoCLI true if the CLI commands of the module are generated from its services
+	autoCLI bool
 }
 
 // ModuleCreationOption configures Chain.
@@ -142,6 +155,34 @@ func WithDependencies(dependencies []modulecreate.Dependency) ModuleCreationOpti
 	}
 }
 
+// WithTransientStore scaffolds a module with a transient store, the store is reset at the end of each block
+func WithTransientStore() ModuleCreationOption {
+	return func(m *moduleCreationOptions) {
+		m.transientStore = true
+	}
+}
+
+// WithMemoryStore scaffolds a module with its memory store mounted, the store is kept in memory and not persisted
+func WithMemoryStore() ModuleCreationOption {
+	return func(m *moduleCreationOptions) {
+		m.memoryStore = true
+	}
+}
+
+// WithCapability scaffolds a module with a scoped capability keeper to own object capabilities
+func WithCapability() ModuleCreationOption {
+	return func(m *moduleCreationOptions) {
+		m.capability = true
+	}
+}
+
+// WithAutoCLI scaffolds a module with CLI commands generated from the methods of its services
+func WithAutoCLI() ModuleCreationOption {
+	return func(m *moduleCreationOptions) {
+		m.autoCLI = true
+	}
+}
+
 // CreateModule creates a new empty module in the scaffolded app
 func (s Scaffolder) CreateModule(
 	tracer *placeholder.Tracer,
@@ -174,6 +215,16 @@ func (s Scaffolder) CreateModule(
 		apply(&creationOpts)
 	}
 
+	// IBC modules own the capabilities of their ports and channels with the scoped keeper of IBC
+	if creationOpts.capability && creationOpts.ibc {
+		return sm, errors.New("an IBC module already has a scoped capability keeper")
+	}
+
+	// The packets of IBC modules are sent by commands that aren't methods of the msg service
+	if creationOpts.autoCLI && creationOpts.ibc {
+		return sm, errors.New("the CLI commands of an IBC module can't be generated from its services")
+	}
+
 	// Parse params with the associated type
 	params, err := field.ParseFields(creationOpts.params, checkForbiddenTypeIndex)
 	if err != nil {
@@ -184,17 +235,24 @@ func (s Scaffolder) CreateModule(
 	if err := checkDependencies(creationOpts.dependencies, s.path); err != nil {
 		return sm, err
 	}
+	if err := discoverDependencyKeepers(creationOpts.dependencies, s.path, s.modpath.RawPath); err != nil {
+		return sm, err
+	}
 
 	opts := &modulecreate.CreateOptions{
-		ModuleName:   moduleName,
-		ModulePath:   s.modpath.RawPath,
-		Params:       params,
-		AppName:      s.modpath.Package,
-		AppPath:      s.path,
-		OwnerName:    owner(s.modpath.RawPath),
-		IsIBC:        creationOpts.ibc,
-		IBCOrdering:  creationOpts.ibcChannelOrdering,
-		Dependencies: creationOpts.dependencies,
+		ModuleName:     moduleName,
+		ModulePath:     s.modpath.RawPath,
+		Params:         params,
+		AppName:        s.modpath.Package,
+		AppPath:        s.path,
+		OwnerName:      owner(s.modpath.RawPath),
+		IsIBC:          creationOpts.ibc,
+		IBCOrdering:    creationOpts.ibcChannelOrdering,
+		Dependencies:   creationOpts.dependencies,
+		TransientStore: creationOpts.transientStore,
+		MemoryStore:    creationOpts.memoryStore,
+		Capability:     creationOpts.capability,
+		AutoCLI:        creationOpts.autoCLI,
 	}
 
 	// Generator from Cosmos SDK version
@@ -225,7 +283,12 @@ func (s Scaffolder) CreateModule(
 		return sm, runErr
 	}
 
-	return sm, finish(opts.AppPath, s.modpath.RawPath)
+	if err := finish(opts.AppPath, s.modpath.RawPath); err != nil {
+		return sm, err
+	}
+
+	// The module is created but can't be registered in app.go, e.g. when a placeholder is missing
+	return sm, runErr
 }
 
 // ImportModule imports specified module with name to the scaffolded app.
@@ -393,3 +456,107 @@ func checkDependencies(dependencies []modulecreate.Dependency, appPath string) e
 
 	return nil
 }
+
+// discoverDependencyKeepers sets the methods of the expected keepers of the dependencies that are
+// modules of the app from the methods of their keepers.
+func discoverDependencyKeepers(dependencies []modulecreate.Dependency, appPath, modulePath string) error {
+	names := newImportNames()
+	for i, dep := range dependencies {
+		path := filepath.Join(appPath, moduleDir, dep.Name, "keeper")
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		methods, err := keeperanalysis.Discover(path, "Keeper")
+		if err != nil {
+			return err
+		}
+
+		// the packages of the app are prefixed by the name of the dependency since the types
+		// packages of the modules share the same name.
+		qualifier := func(path string) string {
+			var prefix string
+			if strings.HasPrefix(path, modulePath+"/") {
+				prefix = dep.Name
+			}
+			return names.name(path, prefix)
+		}
+
+		imported := make(map[string]struct{})
+		for _, m := range methods {
+			signature, err := m.Signature(qualifier)
+			if err != nil {
+				return err
+			}
+			dependencies[i].Methods = append(dependencies[i].Methods, signature)
+
+			for _, path := range m.Imports() {
+				name := qualifier(path)
+				if _, ok := imported[path]; ok || expectedKeepersImports[path] == name {
+					continue
+				}
+				imported[path] = struct{}{}
+
+				// the package doesn't need a name when it's named with the last element of its path.
+				if name == path[strings.LastIndex(path, "/")+1:] {
+					name = ""
+				}
+				dependencies[i].Imports = append(dependencies[i].Imports, modulecreate.Import{
+					Name: name,
+					Path: path,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// expectedKeepersImports are the packages already imported by the expected keepers of a module.
+var expectedKeepersImports = map[string]string{
+	"github.com/cosmos/cosmos-sdk/types":        "sdk",
+	"github.com/cosmos/cosmos-sdk/x/auth/types": "types",
+}
+
+// importNames names the packages imported by the expected keepers of a module, each package
+// has a single name that isn't shared with another package.
+type importNames struct {
+	names map[string]string // names by import path
+	paths map[string]string // import paths by name
+}
+
+func newImportNames() importNames {
+	n := importNames{
+		names: make(map[string]string),
+		paths: make(map[string]string),
+	}
+	for path, name := range expectedKeepersImports {
+		n.names[path] = name
+		n.paths[name] = path
+	}
+	return n
+}
+
+// name returns the name of the package with the import path, the name of a new package is the
+// last element of its path with the prefix.
+func (n importNames) name(path, prefix string) string {
+	if name, ok := n.names[path]; ok {
+		return name
+	}
+
+	base := prefix + strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, path[strings.LastIndex(path, "/")+1:])
+
+	name := base
+	for i := 2; n.paths[name] != ""; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	n.names[path] = name
+	n.paths[name] = path
+	return name
+}
@@ -13,6 +13,24 @@ import (
 	"github.com/tendermint/starport/starport/templates/query"
 )
 
+// queryOptions represents configuration for the query scaffolding
+type queryOptions struct {
+	cliFlags    bool
+	cliDefaults map[string]string
+}
+
+// QueryOption configures the query scaffolding
+type QueryOption func(*queryOptions)
+
+// QueryWithCLIFlags makes the CLI command of the query read the request fields from flags instead
+// of positional args, defaults are the default values of the flags by the names of the fields
+func QueryWithCLIFlags(defaults map[string]string) QueryOption {
+	return func(q *queryOptions) {
+		q.cliFlags = true
+		q.cliDefaults = defaults
+	}
+}
+
 // AddQuery adds a new query to scaffolded app
 func (s Scaffolder) AddQuery(
 	ctx context.Context,
@@ -23,7 +41,14 @@ func (s Scaffolder) AddQuery(
 	reqFields,
 	resFields []string,
 	paginated bool,
+	options ...QueryOption,
 ) (sm xgenny.SourceModification, err error) {
+	// Create the options
+	var scaffoldingOpts queryOptions
+	for _, apply := range options {
+		apply(&scaffoldingOpts)
+	}
+
 	// If no module is provided, we add the type to the app's module
 	if moduleName == "" {
 		moduleName = s.modpath.Package
@@ -51,6 +76,12 @@ func (s Scaffolder) AddQuery(
 	if err != nil {
 		return sm, err
 	}
+	if scaffoldingOpts.cliFlags {
+		parsedReqFields, err = cliFlagFields(parsedReqFields, scaffoldingOpts.cliDefaults)
+		if err != nil {
+			return sm, err
+		}
+	}
 
 	// Check and parse provided response fields
 	if err := checkCustomTypes(ctx, s.path, moduleName, resFields); err != nil {
@@ -61,6 +92,11 @@ func (s Scaffolder) AddQuery(
 		return sm, err
 	}
 
+	autoCLI, err := isAutoCLIModule(s.path, moduleName)
+	if err != nil {
+		return sm, err
+	}
+
 	var (
 		g    *genny.Generator
 		opts = &query.Options{
@@ -74,6 +110,8 @@ func (s Scaffolder) AddQuery(
 			ResFields:   parsedResFields,
 			Description: description,
 			Paginated:   paginated,
+			CLIFlags:    scaffoldingOpts.cliFlags,
+			AutoCLI:     autoCLI,
 		}
 	)
 
@@ -2,6 +2,7 @@ package scaffolder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -39,6 +40,7 @@ type addTypeOptions struct {
 	withoutMessage    bool
 	withoutSimulation bool
 	signer            string
+	stream            bool
 }
 
 // newAddTypeOptions returns a addTypeOptions with default options
@@ -112,6 +114,14 @@ func TypeWithSigner(signer string) AddTypeOption {
 	}
 }
 
+// TypeWithStream emits the create, update and delete events of the type from its messages, so the
+// changes of the type can be streamed over websocket. only lists are streamed.
+func TypeWithStream() AddTypeOption {
+	return func(o *addTypeOptions) {
+		o.stream = true
+	}
+}
+
 // AddType adds a new type to a scaffolded app.
 // if non of the list, map or singleton given, a dry type without anything extra (like a storage layer, models, CLI etc.)
 // will be scaffolded.
@@ -144,6 +154,10 @@ func (s Scaffolder) AddType(
 		return sm, err
 	}
 
+	if o.stream && o.withoutMessage {
+		return sm, errors.New("the events of a streamed type are emitted by its messages, it can't be scaffolded without messages")
+	}
+
 	signer := ""
 	if !o.withoutMessage {
 		signer = o.signer
@@ -168,6 +182,11 @@ func (s Scaffolder) AddType(
 		return sm, err
 	}
 
+	autoCLI, err := isAutoCLIModule(s.path, moduleName)
+	if err != nil {
+		return sm, err
+	}
+
 	var (
 		g    *genny.Generator
 		opts = &typed.Options{
@@ -182,6 +201,8 @@ func (s Scaffolder) AddType(
 			NoSimulation: o.withoutSimulation,
 			MsgSigner:    mfSigner,
 			IsIBC:        isIBC,
+			Stream:       o.stream,
+			AutoCLI:      autoCLI,
 		}
 		gens []*genny.Generator
 	)
@@ -2,6 +2,7 @@ package datatype
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 )
@@ -27,6 +28,23 @@ var (
             		}`,
 				prefix, name.UpperCamel, argIndex)
 		},
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("Bool", name, defaultValue, name.Original)
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return cliFlagValue("GetBool", name, prefix)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			if value == "" {
+				return "false", nil
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return "", fmt.Errorf("%s is not a bool", value)
+			}
+			return strconv.FormatBool(b), nil
+		},
+		CLIFlagOptional: true,
 		ToBytes: func(name string) string {
 			return fmt.Sprintf(`%[1]vBytes := []byte{0}
 					if %[1]v {
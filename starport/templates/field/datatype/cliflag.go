@@ -0,0 +1,39 @@
+package datatype
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+)
+
+// cliFlag returns the definition of the CLI flag of a field with the flag function of its type,
+// e.g. Int32.
+func cliFlag(flagFunc string, name multiformatname.Name, defaultValue, usage string) string {
+	return fmt.Sprintf(`cmd.Flags().%s("%s", %s, "%s")`, flagFunc, name.Kebab, defaultValue, usage)
+}
+
+// cliFlagValue returns the code that gets the value of the CLI flag of a field with the getter
+// of its type, e.g. GetInt32.
+func cliFlagValue(getter string, name multiformatname.Name, prefix string) string {
+	return fmt.Sprintf(`%s%s, err := cmd.Flags().%s("%s")
+					if err != nil {
+						return err
+					}`, prefix, name.UpperCamel, getter, name.Kebab)
+}
+
+// sliceDefault returns the Go literal of the slice of the comma separated default value of a
+// flag, the elements are converted to Go literals with literal.
+func sliceDefault(elemType, value string, literal func(string) (string, error)) (string, error) {
+	var elems []string
+	if value != "" {
+		for _, v := range strings.Split(value, ",") {
+			elem, err := literal(v)
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, elem)
+		}
+	}
+	return fmt.Sprintf("[]%s{%s}", elemType, strings.Join(elems, ", ")), nil
+}
@@ -2,6 +2,9 @@ package datatype
 
 import (
 	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 )
@@ -22,9 +25,31 @@ var (
 						return err
 					}`, prefix, name.UpperCamel, argIndex)
 		},
-		GoCLIImports: []GoImport{{Name: "github.com/cosmos/cosmos-sdk/types", Alias: "sdk"}},
-		ProtoImports: []string{"gogoproto/gogo.proto", "cosmos/base/v1beta1/coin.proto"},
-		NonIndex:     true,
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("String", name, defaultValue, name.Original+" as a coin, e.g. 10token")
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return fmt.Sprintf(`%[1]vCast%[2]v, err := cmd.Flags().GetString("%[3]v")
+					if err != nil {
+						return err
+					}
+					%[1]v%[2]v, err := sdk.ParseCoinNormalized(%[1]vCast%[2]v)
+					if err != nil {
+						return err
+					}`, prefix, name.UpperCamel, name.Kebab)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			if value != "" {
+				if _, err := sdk.ParseCoinNormalized(value); err != nil {
+					return "", err
+				}
+			}
+			return strconv.Quote(value), nil
+		},
+		GoCLIFlagImports: []GoImport{{Name: "github.com/cosmos/cosmos-sdk/types", Alias: "sdk"}},
+		GoCLIImports:     []GoImport{{Name: "github.com/cosmos/cosmos-sdk/types", Alias: "sdk"}},
+		ProtoImports:     []string{"gogoproto/gogo.proto", "cosmos/base/v1beta1/coin.proto"},
+		NonIndex:         true,
 	}
 
 	// DataCoinSlice coin array data type definition
@@ -42,8 +67,29 @@ var (
 						return err
 					}`, prefix, name.UpperCamel, argIndex)
 		},
-		GoCLIImports: []GoImport{{Name: "github.com/cosmos/cosmos-sdk/types", Alias: "sdk"}},
-		ProtoImports: []string{"gogoproto/gogo.proto", "cosmos/base/v1beta1/coin.proto"},
-		NonIndex:     true,
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("String", name, defaultValue, name.Original+" as coins, e.g. 10token,20stake")
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return fmt.Sprintf(`%[1]vCast%[2]v, err := cmd.Flags().GetString("%[3]v")
+					if err != nil {
+						return err
+					}
+					%[1]v%[2]v, err := sdk.ParseCoinsNormalized(%[1]vCast%[2]v)
+					if err != nil {
+						return err
+					}`, prefix, name.UpperCamel, name.Kebab)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			if _, err := sdk.ParseCoinsNormalized(value); err != nil {
+				return "", err
+			}
+			return strconv.Quote(value), nil
+		},
+		CLIFlagOptional:  true,
+		GoCLIFlagImports: []GoImport{{Name: "github.com/cosmos/cosmos-sdk/types", Alias: "sdk"}},
+		GoCLIImports:     []GoImport{{Name: "github.com/cosmos/cosmos-sdk/types", Alias: "sdk"}},
+		ProtoImports:     []string{"gogoproto/gogo.proto", "cosmos/base/v1beta1/coin.proto"},
+		NonIndex:         true,
 	}
 )
@@ -1,6 +1,7 @@
 package datatype
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
@@ -24,7 +25,31 @@ var (
                 		return err
             		}`, prefix, name.UpperCamel, datatype, argIndex)
 		},
-		GoCLIImports: []GoImport{{Name: "encoding/json"}},
-		NonIndex:     true,
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("String", name, defaultValue, "path of a JSON file with the "+name.Original)
+		},
+		CLIFlagValue: func(name multiformatname.Name, datatype, prefix string) string {
+			return fmt.Sprintf(`%[1]v%[2]v := new(types.%[3]v)
+					%[1]vFile%[2]v, err := cmd.Flags().GetString("%[4]v")
+					if err != nil {
+						return err
+					}
+					%[1]vBytes%[2]v, err := os.ReadFile(%[1]vFile%[2]v)
+					if err != nil {
+						return err
+					}
+					if err := json.Unmarshal(%[1]vBytes%[2]v, %[1]v%[2]v); err != nil {
+						return err
+					}`, prefix, name.UpperCamel, datatype, name.Kebab)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			if value != "" {
+				return "", errors.New("custom types are read from JSON files, they have no default value")
+			}
+			return `""`, nil
+		},
+		GoCLIFlagImports: []GoImport{{Name: "encoding/json"}, {Name: "os"}},
+		GoCLIImports:     []GoImport{{Name: "encoding/json"}},
+		NonIndex:         true,
 	}
 )
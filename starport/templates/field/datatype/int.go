@@ -2,6 +2,7 @@ package datatype
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 )
@@ -27,6 +28,18 @@ var (
             		}`,
 				prefix, name.UpperCamel, argIndex)
 		},
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("Int32", name, defaultValue, name.Original)
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return cliFlagValue("GetInt32", name, prefix)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			if value == "" {
+				return "0", nil
+			}
+			return intLiteral(value)
+		},
 		ToBytes: func(name string) string {
 			return fmt.Sprintf(`%[1]vBytes := make([]byte, 4)
   					binary.BigEndian.PutUint32(%[1]vBytes, uint32(%[1]v))`, name)
@@ -58,7 +71,26 @@ var (
 						%[1]v%[2]v[i] = value
 					}`, prefix, name.UpperCamel, argIndex)
 		},
-		GoCLIImports: []GoImport{{Name: "github.com/spf13/cast"}, {Name: "strings"}},
-		NonIndex:     true,
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("Int32Slice", name, defaultValue, name.Original+", separated by commas")
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return cliFlagValue("GetInt32Slice", name, prefix)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			return sliceDefault("int32", value, intLiteral)
+		},
+		CLIFlagOptional: true,
+		GoCLIImports:    []GoImport{{Name: "github.com/spf13/cast"}, {Name: "strings"}},
+		NonIndex:        true,
 	}
 )
+
+// intLiteral returns the Go literal of an int32 value.
+func intLiteral(value string) (string, error) {
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("%s is not an int", value)
+	}
+	return strconv.FormatInt(i, 10), nil
+}
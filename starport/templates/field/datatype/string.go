@@ -2,6 +2,7 @@ package datatype
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 )
@@ -23,6 +24,15 @@ var (
 		CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
 			return fmt.Sprintf("%s%s := args[%d]", prefix, name.UpperCamel, argIndex)
 		},
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("String", name, defaultValue, name.Original)
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return cliFlagValue("GetString", name, prefix)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			return strconv.Quote(value), nil
+		},
 		ToBytes: func(name string) string {
 			return fmt.Sprintf("%[1]vBytes := []byte(%[1]v)", name)
 		},
@@ -45,7 +55,19 @@ var (
 			return fmt.Sprintf(`%[1]v%[2]v := strings.Split(args[%[3]v], listSeparator)`,
 				prefix, name.UpperCamel, argIndex)
 		},
-		GoCLIImports: []GoImport{{Name: "strings"}},
-		NonIndex:     true,
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("StringSlice", name, defaultValue, name.Original+", separated by commas")
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return cliFlagValue("GetStringSlice", name, prefix)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			return sliceDefault("string", value, func(v string) (string, error) {
+				return strconv.Quote(v), nil
+			})
+		},
+		CLIFlagOptional: true,
+		GoCLIImports:    []GoImport{{Name: "strings"}},
+		NonIndex:        true,
 	}
 )
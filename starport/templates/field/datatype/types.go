@@ -79,6 +79,11 @@ type DataType struct {
 	ToBytes           func(name string) string
 	ToString          func(name string) string
 	CLIArgs           func(name multiformatname.Name, datatype, prefix string, argIndex int) string
+	CLIFlag           func(name multiformatname.Name, defaultValue string) string
+	CLIFlagValue      func(name multiformatname.Name, datatype, prefix string) string
+	CLIFlagDefault    func(value string) (string, error)
+	CLIFlagOptional   bool
+	GoCLIFlagImports  []GoImport
 	NonIndex          bool
 }
 
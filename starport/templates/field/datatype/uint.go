@@ -2,6 +2,7 @@ package datatype
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 )
@@ -27,6 +28,18 @@ var (
             		}`,
 				prefix, name.UpperCamel, argIndex)
 		},
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("Uint64", name, defaultValue, name.Original)
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return cliFlagValue("GetUint64", name, prefix)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			if value == "" {
+				return "0", nil
+			}
+			return uintLiteral(value)
+		},
 		ToBytes: func(name string) string {
 			return fmt.Sprintf(`%[1]vBytes := make([]byte, 8)
   					binary.BigEndian.PutUint64(%[1]vBytes, %[1]v)`, name)
@@ -59,7 +72,33 @@ var (
 					}`,
 				prefix, name.UpperCamel, argIndex)
 		},
-		GoCLIImports: []GoImport{{Name: "github.com/spf13/cast"}, {Name: "strings"}},
-		NonIndex:     true,
+		CLIFlag: func(name multiformatname.Name, defaultValue string) string {
+			return cliFlag("UintSlice", name, defaultValue, name.Original+", separated by commas")
+		},
+		CLIFlagValue: func(name multiformatname.Name, _, prefix string) string {
+			return fmt.Sprintf(`%[1]vCast%[2]v, err := cmd.Flags().GetUintSlice("%[3]v")
+					if err != nil {
+						return err
+					}
+					%[1]v%[2]v := make([]uint64, len(%[1]vCast%[2]v))
+					for i, value := range %[1]vCast%[2]v {
+						%[1]v%[2]v[i] = uint64(value)
+					}`, prefix, name.UpperCamel, name.Kebab)
+		},
+		CLIFlagDefault: func(value string) (string, error) {
+			return sliceDefault("uint", value, uintLiteral)
+		},
+		CLIFlagOptional: true,
+		GoCLIImports:    []GoImport{{Name: "github.com/spf13/cast"}, {Name: "strings"}},
+		NonIndex:        true,
 	}
 )
+
+// uintLiteral returns the Go literal of an uint64 value.
+func uintLiteral(value string) (string, error) {
+	i, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%s is not an uint", value)
+	}
+	return strconv.FormatUint(i, 10), nil
+}
@@ -14,6 +14,9 @@ type Field struct {
 	Name         multiformatname.Name
 	DatatypeName datatype.Name
 	Datatype     string
+
+	// Default is the default value of the CLI flag of the field
+	Default string
 }
 
 // DataType returns the field Datatype
@@ -102,6 +105,42 @@ func (f Field) CLIArgs(prefix string, argIndex int) string {
 	return dt.CLIArgs(f.Name, f.Datatype, prefix, argIndex)
 }
 
+// CLIFlag returns the definition of the CLI flag of the field, the flag is required when it has
+// no default value and no zero value
+func (f Field) CLIFlag() string {
+	dt, ok := datatype.SupportedTypes[f.DatatypeName]
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	defaultValue, err := f.CLIFlagDefault()
+	if err != nil {
+		panic(err)
+	}
+	flag := dt.CLIFlag(f.Name, defaultValue)
+	if f.Default == "" && !dt.CLIFlagOptional {
+		flag += fmt.Sprintf("\n_ = cmd.MarkFlagRequired(\"%s\")", f.Name.Kebab)
+	}
+	return flag
+}
+
+// CLIFlagValue returns the code getting the value of the CLI flag of the field
+func (f Field) CLIFlagValue(prefix string) string {
+	dt, ok := datatype.SupportedTypes[f.DatatypeName]
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	return dt.CLIFlagValue(f.Name, f.Datatype, prefix)
+}
+
+// CLIFlagDefault returns the Go literal of the default value of the CLI flag of the field
+func (f Field) CLIFlagDefault() (string, error) {
+	dt, ok := datatype.SupportedTypes[f.DatatypeName]
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	return dt.CLIFlagDefault(f.Default)
+}
+
 // ToBytes returns the Datatype byte array cast
 func (f Field) ToBytes(name string) string {
 	dt, ok := datatype.SupportedTypes[f.DatatypeName]
@@ -143,3 +182,12 @@ func (f Field) ProtoImports() []string {
 	}
 	return dt.ProtoImports
 }
+
+// GoCLIFlagImports returns the Datatype imports for CLI package when the CLI uses flags
+func (f Field) GoCLIFlagImports() []datatype.GoImport {
+	dt, ok := datatype.SupportedTypes[f.DatatypeName]
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	return dt.GoCLIFlagImports
+}
@@ -0,0 +1,31 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIFlag(t *testing.T) {
+	fields, err := ParseFields([]string{"title", "count:uint", "ids:uints", "signed:bool", "amount:coin"}, noCheck)
+	require.NoError(t, err)
+	fields[1].Default = "10"
+	fields[2].Default = "1,2"
+
+	require.Equal(t, "cmd.Flags().String(\"title\", \"\", \"title\")\n_ = cmd.MarkFlagRequired(\"title\")", fields[0].CLIFlag())
+	require.Equal(t, "cmd.Flags().Uint64(\"count\", 10, \"count\")", fields[1].CLIFlag())
+	require.Equal(t, "cmd.Flags().UintSlice(\"ids\", []uint{1, 2}, \"ids, separated by commas\")", fields[2].CLIFlag())
+	require.Equal(t, "cmd.Flags().Bool(\"signed\", false, \"signed\")", fields[3].CLIFlag())
+	require.Equal(t, "cmd.Flags().String(\"amount\", \"\", \"amount as a coin, e.g. 10token\")\n_ = cmd.MarkFlagRequired(\"amount\")", fields[4].CLIFlag())
+}
+
+func TestCLIFlagInvalidDefault(t *testing.T) {
+	fields, err := ParseFields([]string{"count:int", "ids:uints", "signed:bool", "amount:coin", "note:Note"}, noCheck)
+	require.NoError(t, err)
+
+	for i, value := range []string{"1.5", "1,-2", "maybe", "token", "{}"} {
+		fields[i].Default = value
+		_, err := fields[i].CLIFlagDefault()
+		require.Error(t, err, fields[i].Name.Original)
+	}
+}
@@ -26,6 +26,22 @@ func (f Fields) GoCLIImports() []datatype.GoImport {
 	return allImports
 }
 
+// GoCLIFlagImports return all go CLI imports when the CLI uses flags
+func (f Fields) GoCLIFlagImports() []datatype.GoImport {
+	allImports := make([]datatype.GoImport, 0)
+	exist := make(map[string]struct{})
+	for _, fields := range f {
+		for _, goImport := range fields.GoCLIFlagImports() {
+			if _, ok := exist[goImport.Name]; ok {
+				continue
+			}
+			exist[goImport.Name] = struct{}{}
+			allImports = append(allImports, goImport)
+		}
+	}
+	return allImports
+}
+
 // ProtoImports return all proto imports
 func (f Fields) ProtoImports() []string {
 	allImports := make([]string, 0)
@@ -1,6 +1,7 @@
 package plushhelpers
 
 import (
+	"html/template"
 	"strings"
 
 	"github.com/gobuffalo/plush"
@@ -15,6 +16,12 @@ func ExtendPlushContext(ctx *plush.Context) {
 	ctx.Set("mergeProtoImports", mergeProtoImports)
 	ctx.Set("mergeCustomImports", mergeCustomImports)
 	ctx.Set("title", strings.Title)
+	ctx.Set("raw", raw)
+}
+
+// raw writes the code unescaped, the code of the CLI flags has quoted strings.
+func raw(code string) template.HTML {
+	return template.HTML(code)
 }
 
 func mergeCustomImports(fields ...field.Fields) []string {
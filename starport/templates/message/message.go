@@ -8,6 +8,7 @@ import (
 	"github.com/gobuffalo/plush"
 	"github.com/gobuffalo/plushgen"
 
+	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/templates/field/plushhelpers"
 	"github.com/tendermint/starport/starport/templates/testutil"
 )
@@ -18,9 +19,16 @@ var (
 
 	//go:embed stargate/simapp/* stargate/simapp/**/*
 	fsStargateSimapp embed.FS
+
+	//go:embed stargate/sequence/* stargate/sequence/**/*
+	fsStargateSequence embed.FS
 )
 
 func Box(box packd.Walker, opts *Options, g *genny.Generator) error {
+	if opts.AutoCLI {
+		// The commands of modules with AutoCLI are declared in their AutoCLI options
+		box = xgenny.Without(box, "/client/cli/")
+	}
 	if err := g.Box(box); err != nil {
 		return err
 	}
@@ -34,11 +42,16 @@ func Box(box packd.Walker, opts *Options, g *genny.Generator) error {
 	ctx.Set("ModulePath", opts.ModulePath)
 	ctx.Set("Fields", opts.Fields)
 	ctx.Set("ResFields", opts.ResFields)
+	ctx.Set("Sequence", opts.Sequence)
+	ctx.Set("HasSequence", opts.HasSequence())
+	ctx.Set("CLIFlags", opts.CLIFlags)
+	ctx.Set("CLIImports", opts.CLIImports())
 
 	plushhelpers.ExtendPlushContext(ctx)
 	g.Transformer(plushgen.Transformer(ctx))
 	g.Transformer(genny.Replace("{{moduleName}}", opts.ModuleName))
 	g.Transformer(genny.Replace("{{msgName}}", opts.MsgName.Snake))
+	g.Transformer(genny.Replace("{{sequenceName}}", opts.Sequence.Snake))
 
 	// Create the 'testutil' package with the test helpers
 	return testutil.Register(g, opts.AppPath)
@@ -3,6 +3,7 @@ package message
 import (
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 	"github.com/tendermint/starport/starport/templates/field"
+	"github.com/tendermint/starport/starport/templates/field/datatype"
 )
 
 // Options ...
@@ -18,9 +19,37 @@ type Options struct {
 	Fields       field.Fields
 	ResFields    field.Fields
 	NoSimulation bool
+
+	// Sequence is the auto-incrementing sequence of the keeper that the message assigns the id of
+	// its response from, the message has no sequence when it's empty.
+	Sequence multiformatname.Name
+
+	// SequenceExists is true when the sequence is already generated in the module.
+	SequenceExists bool
+
+	// CLIFlags is true when the CLI command of the message reads the fields from flags instead of
+	// positional args.
+	CLIFlags bool
+
+	// AutoCLI is true when the CLI command of the message is declared in the AutoCLI options of
+	// the module instead of a cobra command.
+	AutoCLI bool
+}
+
+// HasSequence checks if the message assigns ids from a sequence.
+func (opts *Options) HasSequence() bool {
+	return opts.Sequence.UpperCamel != ""
 }
 
 // Validate that options are usuable
 func (opts *Options) Validate() error {
 	return nil
 }
+
+// CLIImports returns the imports of the CLI command of the message.
+func (opts *Options) CLIImports() []datatype.GoImport {
+	if opts.CLIFlags {
+		return opts.Fields.GoCLIFlagImports()
+	}
+	return opts.Fields.GoCLIImports()
+}
@@ -0,0 +1,131 @@
+package message
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/gobuffalo/genny"
+
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/templates/module"
+	"github.com/tendermint/starport/starport/templates/typed"
+)
+
+// sequenceModify adds the store key of the sequence of the message and keeps the sequence in the
+// genesis, like the count of the lists.
+func sequenceModify(replacer placeholder.Replacer, opts *Options, g *genny.Generator) {
+	g.RunFn(typesKeySequenceModify(opts))
+	g.RunFn(genesisProtoSequenceModify(replacer, opts))
+	g.RunFn(genesisModuleSequenceModify(replacer, opts))
+	g.RunFn(genesisTestsSequenceModify(replacer, opts))
+}
+
+func typesKeySequenceModify(opts *Options) genny.RunFn {
+	return func(r *genny.Runner) error {
+		path := filepath.Join(opts.AppPath, "x", opts.ModuleName, "types/keys.go")
+		f, err := r.Disk.Find(path)
+		if err != nil {
+			return err
+		}
+		content := f.String() + fmt.Sprintf(`
+const (
+	%[1]vSequenceKey= "%[1]v-sequence-"
+)
+`, opts.Sequence.UpperCamel)
+		newFile := genny.NewFileS(path, content)
+		return r.File(newFile)
+	}
+}
+
+func genesisProtoSequenceModify(replacer placeholder.Replacer, opts *Options) genny.RunFn {
+	return func(r *genny.Runner) error {
+		path := filepath.Join(opts.AppPath, "proto", opts.ModuleName, "genesis.proto")
+		f, err := r.Disk.Find(path)
+		if err != nil {
+			return err
+		}
+
+		// Parse proto file to determine the field numbers
+		highestNumber, err := typed.GenesisStateHighestFieldNumber(path)
+		if err != nil {
+			return err
+		}
+
+		templateProtoState := `uint64 %[2]vSequence = %[3]v;
+  %[1]v`
+		replacementProtoState := fmt.Sprintf(
+			templateProtoState,
+			typed.PlaceholderGenesisProtoState,
+			opts.Sequence.LowerCamel,
+			highestNumber+1,
+		)
+		content := replacer.Replace(f.String(), typed.PlaceholderGenesisProtoState, replacementProtoState)
+
+		newFile := genny.NewFileS(path, content)
+		return r.File(newFile)
+	}
+}
+
+func genesisModuleSequenceModify(replacer placeholder.Replacer, opts *Options) genny.RunFn {
+	return func(r *genny.Runner) error {
+		path := filepath.Join(opts.AppPath, "x", opts.ModuleName, "genesis.go")
+		f, err := r.Disk.Find(path)
+		if err != nil {
+			return err
+		}
+
+		templateModuleInit := `// Set %[2]v sequence
+k.Set%[3]vSequence(ctx, genState.%[3]vSequence)
+%[1]v`
+		replacementModuleInit := fmt.Sprintf(
+			templateModuleInit,
+			typed.PlaceholderGenesisModuleInit,
+			opts.Sequence.LowerCamel,
+			opts.Sequence.UpperCamel,
+		)
+		content := replacer.Replace(f.String(), typed.PlaceholderGenesisModuleInit, replacementModuleInit)
+
+		templateModuleExport := `genesis.%[2]vSequence = k.Get%[2]vSequence(ctx)
+%[1]v`
+		replacementModuleExport := fmt.Sprintf(
+			templateModuleExport,
+			typed.PlaceholderGenesisModuleExport,
+			opts.Sequence.UpperCamel,
+		)
+		content = replacer.Replace(content, typed.PlaceholderGenesisModuleExport, replacementModuleExport)
+
+		newFile := genny.NewFileS(path, content)
+		return r.File(newFile)
+	}
+}
+
+func genesisTestsSequenceModify(replacer placeholder.Replacer, opts *Options) genny.RunFn {
+	return func(r *genny.Runner) error {
+		path := filepath.Join(opts.AppPath, "x", opts.ModuleName, "genesis_test.go")
+		f, err := r.Disk.Find(path)
+		if err != nil {
+			return err
+		}
+
+		templateState := `%[2]vSequence: 2,
+	%[1]v`
+		replacementState := fmt.Sprintf(
+			templateState,
+			module.PlaceholderGenesisTestState,
+			opts.Sequence.UpperCamel,
+		)
+		content := replacer.Replace(f.String(), module.PlaceholderGenesisTestState, replacementState)
+
+		templateAssert := `require.Equal(t, genesisState.%[2]vSequence, got.%[2]vSequence)
+%[1]v`
+		replacementAssert := fmt.Sprintf(
+			templateAssert,
+			module.PlaceholderGenesisTestAssert,
+			opts.Sequence.UpperCamel,
+		)
+		content = replacer.Replace(content, module.PlaceholderGenesisTestAssert, replacementAssert)
+
+		newFile := genny.NewFileS(path, content)
+		return r.File(newFile)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
+	"github.com/tendermint/starport/starport/templates/module"
 	"github.com/tendermint/starport/starport/templates/typed"
 )
 
@@ -20,7 +21,11 @@ func NewStargate(replacer placeholder.Replacer, opts *Options) (*genny.Generator
 	g.RunFn(protoTxRPCModify(replacer, opts))
 	g.RunFn(protoTxMessageModify(replacer, opts))
 	g.RunFn(typesCodecModify(replacer, opts))
-	g.RunFn(clientCliTxModify(replacer, opts))
+	if opts.AutoCLI {
+		g.RunFn(autoCLITxModify(replacer, opts))
+	} else {
+		g.RunFn(clientCliTxModify(replacer, opts))
+	}
 
 	template := xgenny.NewEmbedWalker(
 		fsStargateMessage,
@@ -28,6 +33,18 @@ func NewStargate(replacer placeholder.Replacer, opts *Options) (*genny.Generator
 		opts.AppPath,
 	)
 
+	if opts.HasSequence() && !opts.SequenceExists {
+		sequenceModify(replacer, opts, g)
+		sequenceTemplate := xgenny.NewEmbedWalker(
+			fsStargateSequence,
+			"stargate/sequence",
+			opts.AppPath,
+		)
+		if err := Box(sequenceTemplate, opts, g); err != nil {
+			return nil, err
+		}
+	}
+
 	if !opts.NoSimulation {
 		g.RunFn(moduleSimulationModify(replacer, opts))
 		simappTemplate := xgenny.NewEmbedWalker(
@@ -194,6 +211,22 @@ func clientCliTxModify(replacer placeholder.Replacer, opts *Options) genny.RunFn
 	}
 }
 
+func autoCLITxModify(replacer placeholder.Replacer, opts *Options) genny.RunFn {
+	rpc := module.AutoCLIRPC{
+		Method: opts.MsgName.UpperCamel,
+		Use:    opts.MsgName.Kebab,
+		Short:  opts.MsgDesc,
+		Signer: opts.MsgSigner.LowerCamel,
+	}
+	if opts.CLIFlags {
+		rpc.FlagDefaults = module.AutoCLIFlagDefaults(opts.Fields)
+	} else {
+		rpc.Use += opts.Fields.String()
+		rpc.PositionalArgs = module.AutoCLIArgs(opts.Fields)
+	}
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLITx, rpc)
+}
+
 func moduleSimulationModify(replacer placeholder.Replacer, opts *Options) genny.RunFn {
 	return func(r *genny.Runner) error {
 		path := filepath.Join(opts.AppPath, "x", opts.ModuleName, "module_simulation.go")
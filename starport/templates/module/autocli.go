@@ -0,0 +1,107 @@
+package module
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gobuffalo/genny"
+
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/templates/field"
+)
+
+// PathAutoCLI is the path of the AutoCLI options in a module
+const PathAutoCLI = "client/cli/autocli.go"
+
+// AutoCLIRPC is the declaration of the command of a method of a service in the AutoCLI options of a module
+type AutoCLIRPC struct {
+	Method         string
+	Use            string
+	Short          string
+	PositionalArgs []string
+	FlagDefaults   map[string]string
+	Signer         string
+}
+
+// String returns the declaration of the command as an element of the RPCs of the options
+func (rpc AutoCLIRPC) String() string {
+	fields := []string{
+		fmt.Sprintf("Method: %q", rpc.Method),
+		fmt.Sprintf("Use: %q", rpc.Use),
+		fmt.Sprintf("Short: %q", rpc.Short),
+	}
+	if len(rpc.PositionalArgs) > 0 {
+		args := make([]string, len(rpc.PositionalArgs))
+		for i, arg := range rpc.PositionalArgs {
+			args[i] = fmt.Sprintf("%q", arg)
+		}
+		fields = append(fields, fmt.Sprintf("PositionalArgs: []string{%s}", strings.Join(args, ", ")))
+	}
+	if len(rpc.FlagDefaults) > 0 {
+		names := make([]string, 0, len(rpc.FlagDefaults))
+		for name := range rpc.FlagDefaults {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		defaults := make([]string, len(names))
+		for i, name := range names {
+			defaults[i] = fmt.Sprintf("%q: %q", name, rpc.FlagDefaults[name])
+		}
+		fields = append(fields, fmt.Sprintf("FlagDefaults: map[string]string{%s}", strings.Join(defaults, ", ")))
+	}
+	if rpc.Signer != "" {
+		fields = append(fields, fmt.Sprintf("Signer: %q", rpc.Signer))
+	}
+	return fmt.Sprintf("{%s},", strings.Join(fields, ", "))
+}
+
+// AutoCLIArgs returns the names of the fields read from the positional args of a command in order
+func AutoCLIArgs(fields ...field.Fields) []string {
+	var args []string
+	for _, fs := range fields {
+		for _, f := range fs {
+			args = append(args, f.Name.LowerCamel)
+		}
+	}
+	return args
+}
+
+// AutoCLIFlagDefaults returns the default values of the flags of the fields by their names
+func AutoCLIFlagDefaults(fields field.Fields) map[string]string {
+	defaults := make(map[string]string)
+	for _, f := range fields {
+		if f.Default != "" {
+			defaults[f.Name.LowerCamel] = f.Default
+		}
+	}
+	return defaults
+}
+
+// AutoCLIModify returns the modification declaring the commands of the methods in the AutoCLI options of a module
+// placeholderAutoCLI is the placeholder of the query or the tx options
+func AutoCLIModify(
+	replacer placeholder.Replacer,
+	appPath,
+	moduleName,
+	placeholderAutoCLI string,
+	rpcs ...AutoCLIRPC,
+) genny.RunFn {
+	return func(r *genny.Runner) error {
+		path := filepath.Join(appPath, "x", moduleName, PathAutoCLI)
+		f, err := r.Disk.Find(path)
+		if err != nil {
+			return err
+		}
+
+		var replacement string
+		for _, rpc := range rpcs {
+			replacement += rpc.String() + "\n"
+		}
+		content := replacer.Replace(f.String(), placeholderAutoCLI, replacement+placeholderAutoCLI)
+		newFile := genny.NewFileS(path, content)
+		return r.File(newFile)
+	}
+}
@@ -39,6 +39,7 @@ func NewIBC(replacer placeholder.Replacer, opts *CreateOptions) (*genny.Generato
 	ctx.Set("ownerName", opts.OwnerName)
 	ctx.Set("ibcOrdering", opts.IBCOrdering)
 	ctx.Set("dependencies", opts.Dependencies)
+	ctx.Set("transientStore", opts.TransientStore)
 
 	// Used for proto package name
 	ctx.Set("formatOwnerName", xstrings.FormatUsername)
@@ -187,20 +188,8 @@ func appIBCModify(replacer placeholder.Replacer, opts *CreateOptions) genny.RunF
 		)
 		content := replacer.Replace(f.String(), module.PlaceholderIBCAppRouter, replacementRouter)
 
-		// Scoped keeper declaration for the module
-		templateScopedKeeperDeclaration := `Scoped%[1]vKeeper capabilitykeeper.ScopedKeeper`
-		replacementScopedKeeperDeclaration := fmt.Sprintf(templateScopedKeeperDeclaration, strings.Title(opts.ModuleName))
-		content = replacer.Replace(content, module.PlaceholderIBCAppScopedKeeperDeclaration, replacementScopedKeeperDeclaration)
-
-		// Scoped keeper definition
-		templateScopedKeeperDefinition := `scoped%[1]vKeeper := app.CapabilityKeeper.ScopeToModule(%[2]vmoduletypes.ModuleName)
-app.Scoped%[1]vKeeper = scoped%[1]vKeeper`
-		replacementScopedKeeperDefinition := fmt.Sprintf(
-			templateScopedKeeperDefinition,
-			strings.Title(opts.ModuleName),
-			opts.ModuleName,
-		)
-		content = replacer.Replace(content, module.PlaceholderIBCAppScopedKeeperDefinition, replacementScopedKeeperDefinition)
+		// Scoped keeper of the module
+		content = scopedKeeperModify(replacer, content, opts)
 
 		// New argument passed to the module keeper
 		templateKeeperArgument := `app.IBCKeeper.ChannelKeeper,
@@ -216,3 +205,46 @@ scoped%[1]vKeeper,`
 		return r.File(newFile)
 	}
 }
+
+// appCapabilityModify gives a scoped capability keeper to the keeper of a module that owns capabilities
+func appCapabilityModify(replacer placeholder.Replacer, opts *CreateOptions) genny.RunFn {
+	return func(r *genny.Runner) error {
+		path := filepath.Join(opts.AppPath, module.PathAppGo)
+		f, err := r.Disk.Find(path)
+		if err != nil {
+			return err
+		}
+
+		// Scoped keeper of the module
+		content := scopedKeeperModify(replacer, f.String(), opts)
+
+		// New argument passed to the module keeper
+		templateKeeperArgument := `scoped%[1]vKeeper,`
+		replacementKeeperArgument := fmt.Sprintf(
+			templateKeeperArgument,
+			strings.Title(opts.ModuleName),
+		)
+		content = replacer.Replace(content, module.PlaceholderIBCAppKeeperArgument, replacementKeeperArgument)
+
+		newFile := genny.NewFileS(path, content)
+		return r.File(newFile)
+	}
+}
+
+// scopedKeeperModify declares and defines the scoped capability keeper of the module in app.go
+func scopedKeeperModify(replacer placeholder.Replacer, content string, opts *CreateOptions) string {
+	// Scoped keeper declaration for the module
+	templateScopedKeeperDeclaration := `Scoped%[1]vKeeper capabilitykeeper.ScopedKeeper`
+	replacementScopedKeeperDeclaration := fmt.Sprintf(templateScopedKeeperDeclaration, strings.Title(opts.ModuleName))
+	content = replacer.Replace(content, module.PlaceholderIBCAppScopedKeeperDeclaration, replacementScopedKeeperDeclaration)
+
+	// Scoped keeper definition
+	templateScopedKeeperDefinition := `scoped%[1]vKeeper := app.CapabilityKeeper.ScopeToModule(%[2]vmoduletypes.ModuleName)
+app.Scoped%[1]vKeeper = scoped%[1]vKeeper`
+	replacementScopedKeeperDefinition := fmt.Sprintf(
+		templateScopedKeeperDefinition,
+		strings.Title(opts.ModuleName),
+		opts.ModuleName,
+	)
+	return replacer.Replace(content, module.PlaceholderIBCAppScopedKeeperDefinition, replacementScopedKeeperDefinition)
+}
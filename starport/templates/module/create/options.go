@@ -24,6 +24,18 @@ type CreateOptions struct {
 
 	// Dependencies of the module
 	Dependencies []Dependency
+
+	// True if the module has a transient store, the transient store is reset at the end of each block
+	TransientStore bool
+
+	// True if the memory store of the module is mounted, the memory store is kept in memory and not persisted
+	MemoryStore bool
+
+	// True if the module owns object capabilities with a scoped capability keeper
+	Capability bool
+
+	// True if the CLI commands of the module are generated from the methods of its services
+	AutoCLI bool
 }
 
 // MsgServerOptions defines options to add MsgServer
@@ -44,6 +56,18 @@ func (opts *CreateOptions) Validate() error {
 type Dependency struct {
 	Name       string
 	KeeperName string // KeeperName represents the name of the keeper for the module in app.go
+
+	// Methods are the methods declared by the expected keeper of the dependency
+	Methods []string
+
+	// Imports are the packages that Methods refer to
+	Imports []Import
+}
+
+// Import is a package imported by the expected keepers of a module
+type Import struct {
+	Name string
+	Path string
 }
 
 // NewDependency returns a new dependency object
@@ -53,7 +77,7 @@ func NewDependency(name, keeperName string) Dependency {
 		keeperName = fmt.Sprintf("%sKeeper", strings.Title(name))
 	}
 	return Dependency{
-		name,
-		keeperName,
+		Name:       name,
+		KeeperName: keeperName,
 	}
 }
@@ -44,17 +44,43 @@ func NewStargate(opts *CreateOptions) (*genny.Generator, error) {
 	if err := g.Box(genesisTestTemplate); err != nil {
 		return g, err
 	}
-	if err := g.Box(stargateTemplate); err != nil {
+	if opts.AutoCLI {
+		// The commands of the CLI are generated from the services instead of the cobra commands
+		autoCLITemplate := xgenny.NewEmbedWalker(
+			fsAutoCLI,
+			"autocli/",
+			opts.AppPath,
+		)
+		if err := g.Box(xgenny.Without(stargateTemplate, "/client/cli/")); err != nil {
+			return g, err
+		}
+		if err := g.Box(autoCLITemplate); err != nil {
+			return g, err
+		}
+	} else if err := g.Box(stargateTemplate); err != nil {
 		return g, err
 	}
+	if opts.Capability {
+		capabilityTemplate := xgenny.NewEmbedWalker(
+			fsCapability,
+			"capability/",
+			opts.AppPath,
+		)
+		if err := g.Box(capabilityTemplate); err != nil {
+			return g, err
+		}
+	}
 	ctx := plush.NewContext()
 	ctx.Set("moduleName", opts.ModuleName)
 	ctx.Set("modulePath", opts.ModulePath)
 	ctx.Set("appName", opts.AppName)
 	ctx.Set("ownerName", opts.OwnerName)
 	ctx.Set("dependencies", opts.Dependencies)
+	ctx.Set("dependencyImports", dependencyImports(opts.Dependencies))
 	ctx.Set("params", opts.Params)
 	ctx.Set("isIBC", opts.IsIBC)
+	ctx.Set("transientStore", opts.TransientStore)
+	ctx.Set("capability", opts.Capability)
 
 	// Used for proto package name
 	ctx.Set("formatOwnerName", xstrings.FormatUsername)
@@ -79,6 +105,9 @@ func NewStargateAppModify(replacer placeholder.Replacer, opts *CreateOptions) *g
 	if opts.IsIBC {
 		g.RunFn(appIBCModify(replacer, opts))
 	}
+	if opts.Capability {
+		g.RunFn(appCapabilityModify(replacer, opts))
+	}
 	return g
 }
 
@@ -107,9 +136,9 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 
 		// Keeper declaration
 		var scopedKeeperDeclaration string
-		if opts.IsIBC {
-			// Scoped keeper declaration for IBC module
-			// We set this placeholder so it is modified by the IBC module scaffolder
+		if opts.IsIBC || opts.Capability {
+			// Scoped keeper declaration for IBC module and module with capabilities
+			// We set this placeholder so it is modified by the IBC or capability scaffolder
 			scopedKeeperDeclaration = module.PlaceholderIBCAppScopedKeeperDeclaration
 		}
 		template = `%[3]v
@@ -130,6 +159,25 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 		replacement = fmt.Sprintf(template, module.PlaceholderSgAppStoreKey, opts.ModuleName)
 		content = replacer.Replace(content, module.PlaceholderSgAppStoreKey, replacement)
 
+		// Memory store key, the memory store is only mounted when the module uses it
+		storeKeyArguments := fmt.Sprintf("keys[%vmoduletypes.MemStoreKey],", opts.ModuleName)
+		if opts.MemoryStore {
+			template = `%[2]vmoduletypes.MemStoreKey,
+%[1]v`
+			replacement = fmt.Sprintf(template, module.PlaceholderSgAppMemStoreKey, opts.ModuleName)
+			content = replacer.Replace(content, module.PlaceholderSgAppMemStoreKey, replacement)
+			storeKeyArguments = fmt.Sprintf("memKeys[%vmoduletypes.MemStoreKey],", opts.ModuleName)
+		}
+
+		// Transient store key
+		if opts.TransientStore {
+			template = `%[2]vmoduletypes.TStoreKey,
+%[1]v`
+			replacement = fmt.Sprintf(template, module.PlaceholderSgAppTransientStoreKey, opts.ModuleName)
+			content = replacer.Replace(content, module.PlaceholderSgAppTransientStoreKey, replacement)
+			storeKeyArguments += fmt.Sprintf("\ntkeys[%vmoduletypes.TStoreKey],", opts.ModuleName)
+		}
+
 		// Module dependencies
 		var depArgs string
 		for _, dep := range opts.Dependencies {
@@ -152,9 +200,9 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 		// Keeper definition
 		var scopedKeeperDefinition string
 		var ibcKeeperArgument string
-		if opts.IsIBC {
-			// Scoped keeper definition for IBC module
-			// We set this placeholder so it is modified by the IBC module scaffolder
+		if opts.IsIBC || opts.Capability {
+			// Scoped keeper definition for IBC module and module with capabilities
+			// We set this placeholder so it is modified by the IBC or capability scaffolder
 			scopedKeeperDefinition = module.PlaceholderIBCAppScopedKeeperDefinition
 			ibcKeeperArgument = module.PlaceholderIBCAppKeeperArgument
 		}
@@ -162,7 +210,7 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 		app.%[5]vKeeper = *%[2]vmodulekeeper.NewKeeper(
 			appCodec,
 			keys[%[2]vmoduletypes.StoreKey],
-			keys[%[2]vmoduletypes.MemStoreKey],
+			%[7]v
 			app.GetSubspace(%[2]vmoduletypes.ModuleName),
 			%[4]v
 			%[6]v)
@@ -177,6 +225,7 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 			ibcKeeperArgument,
 			strings.Title(opts.ModuleName),
 			depArgs,
+			storeKeyArguments,
 		)
 		content = replacer.Replace(content, module.PlaceholderSgAppKeeperDefinition, replacement)
 
@@ -202,3 +251,19 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 		return r.File(newFile)
 	}
 }
+
+// dependencyImports returns the packages imported by the expected keepers of the dependencies
+// once each.
+func dependencyImports(dependencies []Dependency) (imports []Import) {
+	paths := make(map[string]struct{})
+	for _, dep := range dependencies {
+		for _, imp := range dep.Imports {
+			if _, ok := paths[imp.Path]; ok {
+				continue
+			}
+			paths[imp.Path] = struct{}{}
+			imports = append(imports, imp)
+		}
+	}
+	return imports
+}
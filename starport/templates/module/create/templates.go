@@ -11,6 +11,12 @@ var (
 	//go:embed ibc/* ibc/**/*
 	fsIBC embed.FS
 
+	//go:embed capability/* capability/**/*
+	fsCapability embed.FS
+
+	//go:embed autocli/* autocli/**/*
+	fsAutoCLI embed.FS
+
 	//go:embed msgserver/* msgserver/**/*
 	fsMsgServer embed.FS
 
@@ -10,6 +10,8 @@ const (
 	PlaceholderSgAppModuleBasic         = "// this line is used by starport scaffolding # stargate/app/moduleBasic"
 	PlaceholderSgAppKeeperDeclaration   = "// this line is used by starport scaffolding # stargate/app/keeperDeclaration"
 	PlaceholderSgAppStoreKey            = "// this line is used by starport scaffolding # stargate/app/storeKey"
+	PlaceholderSgAppTransientStoreKey   = "// this line is used by starport scaffolding # stargate/app/transientStoreKey"
+	PlaceholderSgAppMemStoreKey         = "// this line is used by starport scaffolding # stargate/app/memStoreKey"
 	PlaceholderSgAppKeeperDefinition    = "// this line is used by starport scaffolding # stargate/app/keeperDefinition"
 	PlaceholderSgAppAppModule           = "// this line is used by starport scaffolding # stargate/app/appModule"
 	PlaceholderSgAppInitGenesis         = "// this line is used by starport scaffolding # stargate/app/initGenesis"
@@ -37,4 +39,8 @@ const (
 	PlaceholderTypesGenesisValidField = "// this line is used by starport scaffolding # types/genesis/validField"
 	PlaceholderGenesisTestState       = "// this line is used by starport scaffolding # genesis/test/state"
 	PlaceholderGenesisTestAssert      = "// this line is used by starport scaffolding # genesis/test/assert"
+
+	// Placeholders in the AutoCLI options of a module
+	PlaceholderAutoCLIQuery = "// this line is used by starport scaffolding # autocli/query"
+	PlaceholderAutoCLITx    = "// this line is used by starport scaffolding # autocli/tx"
 )
@@ -3,6 +3,7 @@ package query
 import (
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 	"github.com/tendermint/starport/starport/templates/field"
+	"github.com/tendermint/starport/starport/templates/field/datatype"
 )
 
 // Options ...
@@ -17,4 +18,20 @@ type Options struct {
 	ResFields   field.Fields
 	ReqFields   field.Fields
 	Paginated   bool
+
+	// CLIFlags is true when the CLI command of the query reads the request fields from flags
+	// instead of positional args.
+	CLIFlags bool
+
+	// AutoCLI is true when the CLI command of the query is declared in the AutoCLI options of the
+	// module instead of a cobra command.
+	AutoCLI bool
+}
+
+// CLIImports returns the imports of the CLI command of the query.
+func (opts *Options) CLIImports() []datatype.GoImport {
+	if opts.CLIFlags {
+		return opts.ReqFields.GoCLIFlagImports()
+	}
+	return opts.ReqFields.GoCLIImports()
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/gobuffalo/plush"
 	"github.com/gobuffalo/plushgen"
 
+	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/templates/field/plushhelpers"
 )
 
@@ -17,6 +18,10 @@ var (
 )
 
 func Box(box packd.Walker, opts *Options, g *genny.Generator) error {
+	if opts.AutoCLI {
+		// The commands of modules with AutoCLI are declared in their AutoCLI options
+		box = xgenny.Without(box, "/client/cli/")
+	}
 	if err := g.Box(box); err != nil {
 		return err
 	}
@@ -30,6 +35,8 @@ func Box(box packd.Walker, opts *Options, g *genny.Generator) error {
 	ctx.Set("ReqFields", opts.ReqFields)
 	ctx.Set("ResFields", opts.ResFields)
 	ctx.Set("Paginated", opts.Paginated)
+	ctx.Set("CLIFlags", opts.CLIFlags)
+	ctx.Set("CLIImports", opts.CLIImports())
 
 	plushhelpers.ExtendPlushContext(ctx)
 	g.Transformer(plushgen.Transformer(ctx))
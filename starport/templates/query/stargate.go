@@ -9,6 +9,7 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
+	"github.com/tendermint/starport/starport/templates/module"
 )
 
 // NewStargate returns the generator to scaffold a empty query in a Stargate module
@@ -23,7 +24,11 @@ func NewStargate(replacer placeholder.Replacer, opts *Options) (*genny.Generator
 	)
 
 	g.RunFn(protoQueryModify(replacer, opts))
-	g.RunFn(cliQueryModify(replacer, opts))
+	if opts.AutoCLI {
+		g.RunFn(autoCLIQueryModify(replacer, opts))
+	} else {
+		g.RunFn(cliQueryModify(replacer, opts))
+	}
 
 	return g, Box(template, opts, g)
 }
@@ -141,3 +146,18 @@ func cliQueryModify(replacer placeholder.Replacer, opts *Options) genny.RunFn {
 		return r.File(newFile)
 	}
 }
+
+func autoCLIQueryModify(replacer placeholder.Replacer, opts *Options) genny.RunFn {
+	rpc := module.AutoCLIRPC{
+		Method: opts.QueryName.UpperCamel,
+		Use:    opts.QueryName.Kebab,
+		Short:  opts.Description,
+	}
+	if opts.CLIFlags {
+		rpc.FlagDefaults = module.AutoCLIFlagDefaults(opts.ReqFields)
+	} else {
+		rpc.Use += opts.ReqFields.String()
+		rpc.PositionalArgs = module.AutoCLIArgs(opts.ReqFields)
+	}
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLIQuery, rpc)
+}
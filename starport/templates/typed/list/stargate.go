@@ -11,6 +11,7 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
+	"github.com/tendermint/starport/starport/templates/module"
 	"github.com/tendermint/starport/starport/templates/typed"
 )
 
@@ -23,6 +24,9 @@ var (
 
 	//go:embed stargate/simapp/* stargate/simapp/**/*
 	fsStargateSimapp embed.FS
+
+	//go:embed stargate/stream/* stargate/stream/**/*
+	fsStargateStream embed.FS
 )
 
 // NewStargate returns the generator to scaffold a new type in a Stargate module
@@ -50,7 +54,11 @@ func NewStargate(replacer placeholder.Replacer, opts *typed.Options) (*genny.Gen
 	g.RunFn(protoQueryModify(replacer, opts))
 	g.RunFn(moduleGRPCGatewayModify(replacer, opts))
 	g.RunFn(typesKeyModify(opts))
-	g.RunFn(clientCliQueryModify(replacer, opts))
+	if opts.AutoCLI {
+		g.RunFn(autoCLIQueryModify(replacer, opts))
+	} else {
+		g.RunFn(clientCliQueryModify(replacer, opts))
+	}
 
 	// Genesis modifications
 	genesisModify(replacer, opts, g)
@@ -60,7 +68,11 @@ func NewStargate(replacer placeholder.Replacer, opts *typed.Options) (*genny.Gen
 		g.RunFn(handlerModify(replacer, opts))
 		g.RunFn(protoTxModify(replacer, opts))
 		g.RunFn(typesCodecModify(replacer, opts))
-		g.RunFn(clientCliTxModify(replacer, opts))
+		if opts.AutoCLI {
+			g.RunFn(autoCLITxModify(replacer, opts))
+		} else {
+			g.RunFn(clientCliTxModify(replacer, opts))
+		}
 
 		if !opts.NoSimulation {
 			g.RunFn(moduleSimulationModify(replacer, opts))
@@ -73,6 +85,18 @@ func NewStargate(replacer placeholder.Replacer, opts *typed.Options) (*genny.Gen
 		if err := typed.Box(messagesTemplate, opts, g); err != nil {
 			return nil, err
 		}
+
+		// Events emitted by the messages to stream the type
+		if opts.Stream {
+			streamTemplate := xgenny.NewEmbedWalker(
+				fsStargateStream,
+				"stargate/stream/",
+				opts.AppPath,
+			)
+			if err := typed.Box(streamTemplate, opts, g); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	g.RunFn(frontendSrcStoreAppModify(replacer, opts))
@@ -408,3 +432,46 @@ func frontendSrcStoreAppModify(replacer placeholder.Replacer, opts *typed.Option
 		return r.File(newFile)
 	}
 }
+
+func autoCLITxModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
+	args := module.AutoCLIArgs(opts.Fields)
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLITx,
+		module.AutoCLIRPC{
+			Method:         "Create" + opts.TypeName.UpperCamel,
+			Use:            "create-" + opts.TypeName.Kebab + opts.Fields.String(),
+			Short:          "Create a new " + opts.TypeName.Original,
+			PositionalArgs: args,
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+		module.AutoCLIRPC{
+			Method:         "Update" + opts.TypeName.UpperCamel,
+			Use:            "update-" + opts.TypeName.Kebab + " [id]" + opts.Fields.String(),
+			Short:          "Update a " + opts.TypeName.Original,
+			PositionalArgs: append([]string{"id"}, args...),
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+		module.AutoCLIRPC{
+			Method:         "Delete" + opts.TypeName.UpperCamel,
+			Use:            "delete-" + opts.TypeName.Kebab + " [id]",
+			Short:          "Delete a " + opts.TypeName.Original + " by id",
+			PositionalArgs: []string{"id"},
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+	)
+}
+
+func autoCLIQueryModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLIQuery,
+		module.AutoCLIRPC{
+			Method: opts.TypeName.UpperCamel + "All",
+			Use:    "list-" + opts.TypeName.Kebab,
+			Short:  "list all " + opts.TypeName.Original,
+		},
+		module.AutoCLIRPC{
+			Method:         opts.TypeName.UpperCamel,
+			Use:            "show-" + opts.TypeName.Kebab + " [id]",
+			Short:          "shows a " + opts.TypeName.Original,
+			PositionalArgs: []string{"id"},
+		},
+	)
+}
@@ -75,7 +75,11 @@ func NewStargate(replacer placeholder.Replacer, opts *typed.Options) (*genny.Gen
 
 	g.RunFn(protoRPCModify(replacer, opts))
 	g.RunFn(moduleGRPCGatewayModify(replacer, opts))
-	g.RunFn(clientCliQueryModify(replacer, opts))
+	if opts.AutoCLI {
+		g.RunFn(autoCLIQueryModify(replacer, opts))
+	} else {
+		g.RunFn(clientCliQueryModify(replacer, opts))
+	}
 	g.RunFn(genesisProtoModify(replacer, opts))
 	g.RunFn(genesisTypesModify(replacer, opts))
 	g.RunFn(genesisModuleModify(replacer, opts))
@@ -86,7 +90,11 @@ func NewStargate(replacer placeholder.Replacer, opts *typed.Options) (*genny.Gen
 	if !opts.NoMessage {
 		g.RunFn(protoTxModify(replacer, opts))
 		g.RunFn(handlerModify(replacer, opts))
-		g.RunFn(clientCliTxModify(replacer, opts))
+		if opts.AutoCLI {
+			g.RunFn(autoCLITxModify(replacer, opts))
+		} else {
+			g.RunFn(clientCliTxModify(replacer, opts))
+		}
 		g.RunFn(typesCodecModify(replacer, opts))
 
 		if !opts.NoSimulation {
@@ -675,3 +683,45 @@ cdc.RegisterConcrete(&MsgDelete%[2]v{}, "%[3]v/Delete%[2]v", nil)
 		return r.File(newFile)
 	}
 }
+
+func autoCLITxModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLITx,
+		module.AutoCLIRPC{
+			Method:         "Create" + opts.TypeName.UpperCamel,
+			Use:            "create-" + opts.TypeName.Kebab + opts.Indexes.String() + opts.Fields.String(),
+			Short:          "Create a new " + opts.TypeName.Original,
+			PositionalArgs: module.AutoCLIArgs(opts.Indexes, opts.Fields),
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+		module.AutoCLIRPC{
+			Method:         "Update" + opts.TypeName.UpperCamel,
+			Use:            "update-" + opts.TypeName.Kebab + opts.Indexes.String() + opts.Fields.String(),
+			Short:          "Update a " + opts.TypeName.Original,
+			PositionalArgs: module.AutoCLIArgs(opts.Indexes, opts.Fields),
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+		module.AutoCLIRPC{
+			Method:         "Delete" + opts.TypeName.UpperCamel,
+			Use:            "delete-" + opts.TypeName.Kebab + opts.Indexes.String(),
+			Short:          "Delete a " + opts.TypeName.Original,
+			PositionalArgs: module.AutoCLIArgs(opts.Indexes),
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+	)
+}
+
+func autoCLIQueryModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLIQuery,
+		module.AutoCLIRPC{
+			Method: opts.TypeName.UpperCamel + "All",
+			Use:    "list-" + opts.TypeName.Kebab,
+			Short:  "list all " + opts.TypeName.Original,
+		},
+		module.AutoCLIRPC{
+			Method:         opts.TypeName.UpperCamel,
+			Use:            "show-" + opts.TypeName.Kebab + opts.Indexes.String(),
+			Short:          "shows a " + opts.TypeName.Original,
+			PositionalArgs: module.AutoCLIArgs(opts.Indexes),
+		},
+	)
+}
@@ -19,6 +19,8 @@ type Options struct {
 	NoMessage    bool
 	NoSimulation bool
 	IsIBC        bool
+	Stream       bool
+	AutoCLI      bool
 }
 
 // Validate that options are usable
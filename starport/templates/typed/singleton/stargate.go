@@ -51,7 +51,11 @@ func NewStargate(replacer placeholder.Replacer, opts *typed.Options) (*genny.Gen
 	g.RunFn(typesKeyModify(opts))
 	g.RunFn(protoRPCModify(replacer, opts))
 	g.RunFn(moduleGRPCGatewayModify(replacer, opts))
-	g.RunFn(clientCliQueryModify(replacer, opts))
+	if opts.AutoCLI {
+		g.RunFn(autoCLIQueryModify(replacer, opts))
+	} else {
+		g.RunFn(clientCliQueryModify(replacer, opts))
+	}
 	g.RunFn(genesisProtoModify(replacer, opts))
 	g.RunFn(genesisTypesModify(replacer, opts))
 	g.RunFn(genesisModuleModify(replacer, opts))
@@ -62,7 +66,11 @@ func NewStargate(replacer placeholder.Replacer, opts *typed.Options) (*genny.Gen
 	if !opts.NoMessage {
 		g.RunFn(protoTxModify(replacer, opts))
 		g.RunFn(handlerModify(replacer, opts))
-		g.RunFn(clientCliTxModify(replacer, opts))
+		if opts.AutoCLI {
+			g.RunFn(autoCLITxModify(replacer, opts))
+		} else {
+			g.RunFn(clientCliTxModify(replacer, opts))
+		}
 		g.RunFn(typesCodecModify(replacer, opts))
 
 		if !opts.NoSimulation {
@@ -533,3 +541,38 @@ cdc.RegisterConcrete(&MsgDelete%[2]v{}, "%[3]v/Delete%[2]v", nil)
 		return r.File(newFile)
 	}
 }
+
+func autoCLITxModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLITx,
+		module.AutoCLIRPC{
+			Method:         "Create" + opts.TypeName.UpperCamel,
+			Use:            "create-" + opts.TypeName.Kebab + opts.Fields.String(),
+			Short:          "Create " + opts.TypeName.Original,
+			PositionalArgs: module.AutoCLIArgs(opts.Fields),
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+		module.AutoCLIRPC{
+			Method:         "Update" + opts.TypeName.UpperCamel,
+			Use:            "update-" + opts.TypeName.Kebab + opts.Fields.String(),
+			Short:          "Update " + opts.TypeName.Original,
+			PositionalArgs: module.AutoCLIArgs(opts.Fields),
+			Signer:         opts.MsgSigner.LowerCamel,
+		},
+		module.AutoCLIRPC{
+			Method: "Delete" + opts.TypeName.UpperCamel,
+			Use:    "delete-" + opts.TypeName.Kebab,
+			Short:  "Delete " + opts.TypeName.Original,
+			Signer: opts.MsgSigner.LowerCamel,
+		},
+	)
+}
+
+func autoCLIQueryModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
+	return module.AutoCLIModify(replacer, opts.AppPath, opts.ModuleName, module.PlaceholderAutoCLIQuery,
+		module.AutoCLIRPC{
+			Method: opts.TypeName.UpperCamel,
+			Use:    "show-" + opts.TypeName.Kebab,
+			Short:  "shows " + opts.TypeName.Original,
+		},
+	)
+}
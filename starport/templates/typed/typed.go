@@ -6,12 +6,17 @@ import (
 	"github.com/gobuffalo/plush"
 	"github.com/gobuffalo/plushgen"
 
+	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/pkg/xstrings"
 	"github.com/tendermint/starport/starport/templates/field/plushhelpers"
 	"github.com/tendermint/starport/starport/templates/testutil"
 )
 
 func Box(box packd.Walker, opts *Options, g *genny.Generator) error {
+	if opts.AutoCLI {
+		// The commands of modules with AutoCLI are declared in their AutoCLI options
+		box = xgenny.Without(box, "/client/cli/")
+	}
 	if err := g.Box(box); err != nil {
 		return err
 	}
@@ -25,6 +30,7 @@ func Box(box packd.Walker, opts *Options, g *genny.Generator) error {
 	ctx.Set("Fields", opts.Fields)
 	ctx.Set("Indexes", opts.Indexes)
 	ctx.Set("NoMessage", opts.NoMessage)
+	ctx.Set("Stream", opts.Stream)
 	ctx.Set("strconv", func() bool {
 		strconv := false
 		for _, field := range opts.Fields {